@@ -142,11 +142,11 @@ func main() {
 					if err != nil {
 						logger.Panic(err)
 					}
-				// case `[method="DELETE.USER"]`:
-				// 	err := kafkaUserConsumerService.Delete(e.Value)
-				// 	if err != nil {
-				// 		logger.Panic(err)
-				// 	}
+				case `[method="DELETE.USER"]`:
+					err := kafkaUserConsumerService.Delete(e.Value)
+					if err != nil {
+						logger.Panic(err)
+					}
 				}
 
 			case kafka.Error:
@@ -19,6 +19,7 @@ type AuthRepository interface {
 	AddTokenTx(ctx context.Context, token domain.ResetPasswordToken) error
 	UpdateTokenTx(ctx context.Context, token domain.ResetPasswordToken) error
 	UpdateUser(c context.Context, id string, user domain.User) error
+	Delete(c context.Context, id string) error
 	// -1
 	// FindUserWithNameNotDeleteByQueryTx(ctx context.Context, query, value string) (domain.UserWithName, error)
 	// FindProjectByIdTx(ctx context.Context, id int) (domain.Project, error)
@@ -189,6 +190,21 @@ func (r *authRepository) UpdateUser(c context.Context, id string, user domain.Us
 	return err
 }
 
+func (r *authRepository) Delete(c context.Context, id string) error {
+	var err error
+
+	// create transaction to delete user
+	err = r.db.WithTransaction(c, func(tx pgx.Tx) error {
+		// delete user by id, if error will rollback
+		if err = r.AuthQuery.Delete(c, tx, id); err != nil {
+			return err
+		}
+		return nil
+	})
+
+	return err
+}
+
 
 // // Project
 // func (r *AuthRepositoryImpl) FindProjectByIdTx(ctx context.Context, id int) (domain.Project, error) {
@@ -3,6 +3,7 @@ package query
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/iqbaludinm/hr-microservice/auth-service/model/domain"
 	"github.com/jackc/pgx/v5"
@@ -14,6 +15,7 @@ type AuthQuery interface {
 	Login(c context.Context, tx pgx.Tx, id string) (domain.User, error)
 	UpdateUser(c context.Context, tx pgx.Tx, id string, user domain.User) error
 	UpdatePassword(c context.Context, tx pgx.Tx, user domain.User) error
+	Delete(c context.Context, tx pgx.Tx, id string) error
 	FindUserNotDeleteByQuery(c context.Context, db *pgxpool.Pool, query, value string) (domain.User, error) // forgot-pass
 	CheckTokenWithQuery(ctx context.Context, db pgx.Tx, query, value string) (domain.ResetPasswordToken, error)
 	AddToken(ctx context.Context, db pgx.Tx, tokens domain.ResetPasswordToken) error
@@ -100,6 +102,22 @@ func (repository *AuthQueryImpl) UpdateUser(c context.Context, tx pgx.Tx, id str
 	return err
 }
 
+func (repository *AuthQueryImpl) Delete(c context.Context, tx pgx.Tx, id string) error {
+	// build UPDATE query
+	query := `UPDATE users SET deleted_at=$1 WHERE id=$2`
+
+	tag, err := tx.Exec(c, query, time.Now(), id)
+	if err != nil {
+		return err
+	}
+	// the user which is not found isn't deactivated, it must not be ignored
+	if tag.RowsAffected() == 0 {
+		return fmt.Errorf("user %s not found", id)
+	}
+
+	return nil
+}
+
 // func (repository *AuthQueryImpl) FindUserWithNameNotDeleteByQuery(ctx context.Context, db pgx.Tx, query, value string) (domain.UserWithName, error) {
 // 	queryStr := fmt.Sprintf(`SELECT u.*, coalesce(r.name,''), coalesce(d.name,''), coalesce(p.name,''), coalesce(pst.name,'') FROM %s u
 // 	LEFT JOIN roles r ON u.role_id = r.id
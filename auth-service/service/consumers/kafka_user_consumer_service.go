@@ -15,7 +15,7 @@ type KafkaAuthConsumerService interface {
 	Insert(message []byte) error
 	Update(message []byte) error
 	UpdatePass(message []byte) error
-	// Delete(message []byte) error
+	Delete(message []byte) error
 }
 
 type kafkaAuthConsumerService struct {
@@ -103,17 +103,19 @@ func (s *kafkaAuthConsumerService) UpdatePass(message []byte) error {
 	return nil
 }
 
-// func (s *kafkaAuthConsumerService) Delete(message []byte) error {
-// 	userMsg := new(kafkamodel.KafkaUserMessage)
+func (s *kafkaAuthConsumerService) Delete(message []byte) error {
+	userMsg := new(kafkamodel.KafkaUserMessage)
 
-// 	if err := json.Unmarshal(message, userMsg); err != nil {
-// 		s.logger.Errorw("error kafka delete user consumer:", "error", err.Error())
-// 	}
+	// the user can't be deactivated without the id of the message
+	if err := json.Unmarshal(message, userMsg); err != nil {
+		s.logger.Errorw("error kafka delete user consumer:", "error", err.Error())
+		return nil
+	}
 
-// 	// delete user
-// 	if err := s.authRepository.Delete(context.TODO(), userMsg.ID); err != nil {
-// 		s.logger.Errorw("error kafka delete user consumer:", "error", err.Error())
-// 	}
+	// delete user
+	if err := s.authRepository.Delete(context.TODO(), userMsg.ID); err != nil {
+		s.logger.Errorw("error kafka delete user consumer:", "error", err.Error())
+	}
 
-// 	return nil
-// }
+	return nil
+}
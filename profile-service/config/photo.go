@@ -0,0 +1,54 @@
+package config
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/iqbaludinm/hr-microservice/profile-service/utils"
+)
+
+// The defaults of the profile photo settings, they're used when the settings are not filled.
+const (
+	DefaultProfilePhotoMaxSizeMB = 2
+	DefaultProfilePhotoSizes     = "512,256,128,64"
+)
+
+var (
+	// ProfilePhotoMaxSizeMB is the maximum size of an uploaded profile photo in megabytes, 2 MB by default.
+	ProfilePhotoMaxSizeMB = parseMaxSize(utils.GetEnv("PROFILE_PHOTO_MAX_SIZE_MB"))
+	// ProfilePhotoSizes is the comma separated sizes in pixels of the square thumbnails generated from the profile
+	// photo, '512,256,128,64' by default. The largest size is the avatar of the user.
+	ProfilePhotoSizes = parseSizes(withDefault(utils.GetEnv("PROFILE_PHOTO_SIZES"), DefaultProfilePhotoSizes))
+	// ProfilePhotoBaseURL is the public URL of this service, the avatar URL of the users starts with it.
+	ProfilePhotoBaseURL = strings.TrimSuffix(utils.GetEnv("PROFILE_PHOTO_BASE_URL"), "/")
+)
+
+// parse the comma separated sizes, the invalid sizes are ignored
+func parseSizes(value string) []int {
+	var sizes []int
+	for _, size := range strings.Split(value, ",") {
+		sizeInt, err := strconv.Atoi(strings.TrimSpace(size))
+		if err != nil || sizeInt <= 0 {
+			continue
+		}
+		sizes = append(sizes, sizeInt)
+	}
+	return sizes
+}
+
+// parse the maximum size in megabytes, the default is used when the size is not filled or invalid
+func parseMaxSize(value string) int {
+	size, err := strconv.Atoi(strings.TrimSpace(value))
+	if err != nil || size <= 0 {
+		return DefaultProfilePhotoMaxSizeMB
+	}
+	return size
+}
+
+// return the default when the value of the setting is not filled
+func withDefault(value, defaultValue string) string {
+	if strings.TrimSpace(value) == "" {
+		return defaultValue
+	}
+	return value
+}
@@ -0,0 +1,12 @@
+package config
+
+import "github.com/iqbaludinm/hr-microservice/profile-service/utils"
+
+var (
+	// StorageDriver is the driver of the object storage where the profile photos are saved, only 'local' is
+	// supported for now. It's 'local' by default.
+	StorageDriver = withDefault(utils.GetEnv("STORAGE_DRIVER"), "local")
+	// StorageLocalDir is the root directory of the objects of the 'local' storage driver, './storage/files' by
+	// default.
+	StorageLocalDir = withDefault(utils.GetEnv("STORAGE_LOCAL_DIR"), "./storage/files")
+)
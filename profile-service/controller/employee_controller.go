@@ -0,0 +1,85 @@
+package controller
+
+import (
+	"github.com/go-playground/validator"
+	"github.com/gofiber/fiber/v2"
+
+	"github.com/iqbaludinm/hr-microservice/profile-service/config"
+	"github.com/iqbaludinm/hr-microservice/profile-service/exception"
+	"github.com/iqbaludinm/hr-microservice/profile-service/middleware"
+	"github.com/iqbaludinm/hr-microservice/profile-service/service/producers"
+
+	"github.com/iqbaludinm/hr-microservice/profile-service/model/web"
+	"github.com/iqbaludinm/hr-microservice/profile-service/service"
+)
+
+type EmployeeController interface {
+	Route(app *fiber.App)
+	FindMyEmployee(ctx *fiber.Ctx) error
+	UpdateMyEmployee(ctx *fiber.Ctx) error
+}
+
+type employeeController struct {
+	validate             *validator.Validate
+	kafkaProducerService producers.KafkaProducerService
+	employeeService      service.EmployeeService
+}
+
+func NewEmployeeController(validate *validator.Validate, kafkaProducerService producers.KafkaProducerService, employeeService service.EmployeeService) EmployeeController {
+	return &employeeController{
+		kafkaProducerService: kafkaProducerService,
+		validate:             validate,
+		employeeService:      employeeService,
+	}
+}
+
+func (controller *employeeController) Route(app *fiber.App) {
+	api := app.Group(config.EndpointPrefixProfile, middleware.IsAuthenticated)
+	api.Get("/me/employee", controller.FindMyEmployee)
+	api.Put("/me/employee", controller.UpdateMyEmployee)
+}
+
+func (controller *employeeController) FindMyEmployee(ctx *fiber.Ctx) error {
+	// the issuer is set by the 'IsAuthenticated' middleware
+	userID, _ := ctx.Locals("issuer").(string)
+
+	employeeResponse, err := controller.employeeService.FindMyEmployee(ctx, userID)
+	if err != nil {
+		return exception.ErrorHandler(ctx, err)
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(web.WebResponse{
+		Code:    fiber.StatusOK,
+		Status:  true,
+		Message: "success",
+		Data:    employeeResponse,
+	})
+}
+
+func (controller *employeeController) UpdateMyEmployee(ctx *fiber.Ctx) error {
+	var request web.UpdateMyEmployeeRequest
+	userID, _ := ctx.Locals("issuer").(string)
+
+	err := ctx.BodyParser(&request)
+	if err != nil {
+		return exception.ErrValidateBadRequest(err.Error(), request)
+	}
+
+	// validate the values of the request body
+	err = controller.validate.Struct(&request)
+	if err != nil {
+		return exception.ErrValidateBadRequest(err.Error(), request)
+	}
+
+	employeeResponse, err := controller.employeeService.UpdateMyEmployee(ctx, userID, request)
+	if err != nil {
+		return exception.ErrorHandler(ctx, err)
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(web.WebResponse{
+		Code:    fiber.StatusOK,
+		Status:  true,
+		Message: "success",
+		Data:    employeeResponse,
+	})
+}
@@ -1,13 +1,17 @@
 package controller
 
 import (
+	"fmt"
+	"io"
 	"log"
+	"strconv"
 
 	"github.com/go-playground/validator"
 	"github.com/gofiber/fiber/v2"
 
 	"github.com/iqbaludinm/hr-microservice/profile-service/config"
 	"github.com/iqbaludinm/hr-microservice/profile-service/exception"
+	"github.com/iqbaludinm/hr-microservice/profile-service/helper"
 	"github.com/iqbaludinm/hr-microservice/profile-service/middleware"
 	"github.com/iqbaludinm/hr-microservice/profile-service/service/producers"
 
@@ -20,6 +24,8 @@ type ProfileController interface {
 	UpdateMyProfile(ctx *fiber.Ctx) error
 	ForgetPassword(ctx *fiber.Ctx) error
 	ResetPassword(ctx *fiber.Ctx) error
+	UpdateMyPhoto(ctx *fiber.Ctx) error
+	FindPhoto(ctx *fiber.Ctx) error
 }
 
 type profileController struct {
@@ -45,6 +51,8 @@ func (controller *profileController) Route(app *fiber.App) {
 		})
 	})
 	api := app.Group(config.EndpointPrefixProfile, middleware.IsAuthenticated)
+	api.Put("/me/photo", controller.UpdateMyPhoto)
+	api.Get("/:user_id/photo", controller.FindPhoto)
 	api.Put("/:profile_id", controller.UpdateMyProfile)
 	api.Post("/forget-password", controller.ForgetPassword)
 	api.Post("/reset-password", controller.ResetPassword)
@@ -136,4 +144,64 @@ func (controller *profileController) ResetPassword(ctx *fiber.Ctx) error {
 		Status:  true,
 		Message: "Reset successfully.",
 	})
-}
\ No newline at end of file
+}
+
+func (controller *profileController) UpdateMyPhoto(ctx *fiber.Ctx) error {
+	// read the photo from the 'photo' field of the multipart form, the size is checked before the photo is read
+	fileHeader, err := ctx.FormFile("photo")
+	if err != nil {
+		return exception.ErrBadRequest("Photo is required.")
+	}
+	if fileHeader.Size > int64(config.ProfilePhotoMaxSizeMB)*1024*1024 {
+		return exception.ErrBadRequest(fmt.Sprintf("Photo can't be larger than %d MB.", config.ProfilePhotoMaxSizeMB))
+	}
+
+	file, err := fileHeader.Open()
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	photo, err := io.ReadAll(file)
+	if err != nil {
+		return err
+	}
+
+	// the photo of the logged in user
+	userID, _ := helper.ParseJwt(ctx.Cookies("token"))
+
+	userResponse, err := controller.profileService.UpdateMyPhoto(ctx, userID, photo)
+	if err != nil {
+		return err
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(web.WebResponse{
+		Code:    fiber.StatusOK,
+		Status:  true,
+		Message: "success",
+		Data:    userResponse,
+	})
+}
+
+func (controller *profileController) FindPhoto(ctx *fiber.Ctx) error {
+	// parse path and query params, the largest thumbnail is returned when the size is not filled
+	userID := ctx.Params("user_id")
+	var size int
+	if ctx.Query("size") != "" {
+		var err error
+		if size, err = strconv.Atoi(ctx.Query("size")); err != nil {
+			return exception.ErrBadRequest("Size must be a number.")
+		}
+	}
+
+	photo, err := controller.profileService.FindPhoto(ctx, userID, size)
+	if err != nil {
+		return err
+	}
+
+	ctx.Set(fiber.HeaderContentType, "image/jpeg")
+	// the avatar URL changes when the photo is replaced, so the photo can be cached
+	ctx.Set(fiber.HeaderCacheControl, "private, max-age=86400")
+
+	return ctx.Status(fiber.StatusOK).Send(photo)
+}
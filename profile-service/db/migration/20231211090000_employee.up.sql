@@ -0,0 +1,25 @@
+-- ======= EMPLOYEES =======
+
+-- replica of the employee master data from the user-service, filled by the 'EMPLOYEE' kafka messages.
+-- Only 'address' and 'emergency_contacts' can be edited from this service.
+CREATE TABLE employees (
+    "id" uuid NOT NULL,
+    "user_id" uuid NOT NULL UNIQUE,
+    "employee_number" varchar NOT NULL,
+    "date_of_birth" date NOT NULL,
+    "gender" varchar NOT NULL,
+    "address" varchar NOT NULL DEFAULT '',
+    "hire_date" date NOT NULL,
+    "employment_type" varchar NOT NULL,
+    "status" varchar NOT NULL,
+    "job_title" varchar NOT NULL,
+    "department" varchar NOT NULL,
+    "manager_id" uuid,
+    "emergency_contacts" jsonb NOT NULL DEFAULT '[]',
+    "created_at" timestamp NOT NULL,
+    "updated_at" timestamp NOT NULL,
+    "deleted_at" timestamp,
+    PRIMARY KEY ("id")
+);
+
+-- ======= END OF EMPLOYEES =======
@@ -0,0 +1,6 @@
+-- ======= USER AVATAR =======
+
+-- the URL of the largest thumbnail of the profile photo, the thumbnails are kept in the storage
+ALTER TABLE users ADD COLUMN "avatar_url" varchar;
+
+-- ======= END OF USER AVATAR =======
@@ -0,0 +1,183 @@
+package helper
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"image"
+	"image/color"
+	"image/jpeg"
+	_ "image/png"
+
+	"golang.org/x/image/draw"
+	_ "golang.org/x/image/webp"
+)
+
+// ErrUnsupportedImage is returned when the image is not a JPEG, PNG or WebP image, or it can't be decoded.
+var ErrUnsupportedImage = errors.New("unsupported image")
+
+// ErrImageTooLarge is returned when the dimension of the image is larger than MaxImagePixels.
+var ErrImageTooLarge = errors.New("image too large")
+
+// MaxImagePixels is the maximum width times height of the decoded image, so a small file can't expand to a huge
+// image in memory.
+const MaxImagePixels = 40_000_000
+
+// the quality of the generated JPEG thumbnails
+const thumbnailQuality = 85
+
+// NewThumbnails returns the square JPEG thumbnails of the image by size. The image is center-cropped, scaled and
+// turned upright following its EXIF orientation. The thumbnails are encoded from the pixels only, so the EXIF and
+// the other metadata of the uploaded image (e.g. the GPS location) are stripped.
+func NewThumbnails(data []byte, sizes []int) (map[int][]byte, error) {
+	config, format, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		return nil, ErrUnsupportedImage
+	}
+	if format != "jpeg" && format != "png" && format != "webp" {
+		return nil, ErrUnsupportedImage
+	}
+	if config.Width*config.Height > MaxImagePixels {
+		return nil, ErrImageTooLarge
+	}
+
+	img, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, ErrUnsupportedImage
+	}
+
+	orientation := 1
+	if format == "jpeg" {
+		orientation = jpegOrientation(data)
+	}
+
+	// the center of the image stays in the center when it is rotated or flipped, so the orientation is applied to
+	// the small thumbnails instead of the whole image
+	square := cropSquare(img.Bounds())
+	thumbnails := map[int][]byte{}
+	for _, size := range sizes {
+		thumbnail := image.NewRGBA(image.Rect(0, 0, size, size))
+		// the transparent area of PNG and WebP images is filled with white, JPEG has no transparency
+		draw.Draw(thumbnail, thumbnail.Bounds(), image.NewUniform(color.White), image.Point{}, draw.Src)
+		draw.CatmullRom.Scale(thumbnail, thumbnail.Bounds(), img, square, draw.Over, nil)
+
+		var buf bytes.Buffer
+		if err := jpeg.Encode(&buf, orient(thumbnail, orientation), &jpeg.Options{Quality: thumbnailQuality}); err != nil {
+			return nil, err
+		}
+		thumbnails[size] = buf.Bytes()
+	}
+
+	return thumbnails, nil
+}
+
+// the largest square in the center of the bounds
+func cropSquare(bounds image.Rectangle) image.Rectangle {
+	side := bounds.Dx()
+	if bounds.Dy() < side {
+		side = bounds.Dy()
+	}
+	x := bounds.Min.X + (bounds.Dx()-side)/2
+	y := bounds.Min.Y + (bounds.Dy()-side)/2
+	return image.Rect(x, y, x+side, y+side)
+}
+
+// orient turns the square image upright following the EXIF orientation (1-8), the image is returned as is for the
+// normal or unknown orientation.
+func orient(img *image.RGBA, orientation int) *image.RGBA {
+	if orientation < 2 || orientation > 8 {
+		return img
+	}
+
+	// the thumbnails are square, so the width and height are kept when the image is rotated
+	n := img.Bounds().Dx()
+	dst := image.NewRGBA(img.Bounds())
+	for y := 0; y < n; y++ {
+		for x := 0; x < n; x++ {
+			var sx, sy int
+			switch orientation {
+			case 2: // flipped horizontally
+				sx, sy = n-1-x, y
+			case 3: // rotated 180
+				sx, sy = n-1-x, n-1-y
+			case 4: // flipped vertically
+				sx, sy = x, n-1-y
+			case 5: // transposed
+				sx, sy = y, x
+			case 6: // rotated 90 counterclockwise, turned clockwise
+				sx, sy = y, n-1-x
+			case 7: // transversed
+				sx, sy = n-1-y, n-1-x
+			case 8: // rotated 90 clockwise, turned counterclockwise
+				sx, sy = n-1-y, x
+			}
+			dst.SetRGBA(x, y, img.RGBAAt(sx, sy))
+		}
+	}
+	return dst
+}
+
+// jpegOrientation reads the orientation tag from the EXIF of the JPEG image, 1 (normal) is returned when the image
+// has no EXIF or the tag can't be read.
+func jpegOrientation(data []byte) int {
+	if len(data) < 4 || data[0] != 0xFF || data[1] != 0xD8 {
+		return 1
+	}
+
+	// walk the segments until the APP1 segment with the EXIF or the start of the image data
+	for i := 2; i+4 <= len(data); {
+		if data[i] != 0xFF {
+			return 1
+		}
+		marker := data[i+1]
+		length := int(binary.BigEndian.Uint16(data[i+2 : i+4]))
+		if marker == 0xDA || length < 2 || i+2+length > len(data) {
+			return 1
+		}
+		segment := data[i+4 : i+2+length]
+		if marker == 0xE1 && len(segment) > 6 && string(segment[:6]) == "Exif\x00\x00" {
+			return exifOrientation(segment[6:])
+		}
+		i += 2 + length
+	}
+
+	return 1
+}
+
+// read the orientation tag (0x0112) from the first IFD of the TIFF structure of the EXIF
+func exifOrientation(tiff []byte) int {
+	if len(tiff) < 8 {
+		return 1
+	}
+
+	var order binary.ByteOrder
+	switch string(tiff[:2]) {
+	case "II":
+		order = binary.LittleEndian
+	case "MM":
+		order = binary.BigEndian
+	default:
+		return 1
+	}
+
+	offset := int(order.Uint32(tiff[4:8]))
+	if offset < 8 || offset+2 > len(tiff) {
+		return 1
+	}
+	count := int(order.Uint16(tiff[offset : offset+2]))
+	for i := 0; i < count; i++ {
+		entry := offset + 2 + i*12
+		if entry+12 > len(tiff) {
+			return 1
+		}
+		if order.Uint16(tiff[entry:entry+2]) == 0x0112 {
+			orientation := int(order.Uint16(tiff[entry+8 : entry+10]))
+			if orientation < 1 || orientation > 8 {
+				return 1
+			}
+			return orientation
+		}
+	}
+
+	return 1
+}
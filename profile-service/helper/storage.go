@@ -0,0 +1,91 @@
+package helper
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// ErrObjectNotFound is returned by the storage when there is no object with the key.
+var ErrObjectNotFound = errors.New("object not found")
+
+// Storage keeps the uploaded files (e.g. the profile photos) by key, the key is a slash separated path such as
+// 'avatars/<user_id>/128.jpg'. The driver is chosen with the 'STORAGE_DRIVER' env.
+type Storage interface {
+	Put(c context.Context, key string, data []byte) error
+	Get(c context.Context, key string) ([]byte, error)
+	Delete(c context.Context, key string) error
+}
+
+// NewStorage returns the storage of the driver, only the 'local' driver is available for now.
+func NewStorage(driver, localDir string) (Storage, error) {
+	switch driver {
+	case "", "local":
+		return NewLocalStorage(localDir), nil
+	default:
+		return nil, fmt.Errorf("unknown storage driver %q", driver)
+	}
+}
+
+// localStorage keeps the objects as files under the directory.
+type localStorage struct {
+	dir string
+}
+
+func NewLocalStorage(dir string) Storage {
+	return &localStorage{dir: dir}
+}
+
+func (s *localStorage) Put(c context.Context, key string, data []byte) error {
+	path, err := s.path(key)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+
+	// write to a temporary file first, so the object is never read half written
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0o600); err != nil {
+		return err
+	}
+	return os.Rename(tmp, path)
+}
+
+func (s *localStorage) Get(c context.Context, key string) ([]byte, error) {
+	path, err := s.path(key)
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, ErrObjectNotFound
+	}
+	return data, err
+}
+
+func (s *localStorage) Delete(c context.Context, key string) error {
+	path, err := s.path(key)
+	if err != nil {
+		return err
+	}
+
+	if err := os.Remove(path); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+	return nil
+}
+
+// the file path of the key, the key can't escape the directory
+func (s *localStorage) path(key string) (string, error) {
+	cleaned := filepath.Clean("/" + key)
+	if key == "" || strings.HasSuffix(key, "/") || cleaned == "/" {
+		return "", fmt.Errorf("invalid storage key %q", key)
+	}
+	return filepath.Join(s.dir, filepath.FromSlash(cleaned)), nil
+}
@@ -20,6 +20,8 @@ import (
 	"github.com/iqbaludinm/hr-microservice/profile-service/config"
 	"github.com/iqbaludinm/hr-microservice/profile-service/controller"
 	"github.com/iqbaludinm/hr-microservice/profile-service/exception"
+	"github.com/iqbaludinm/hr-microservice/profile-service/helper"
+	"github.com/iqbaludinm/hr-microservice/profile-service/middleware"
 	"github.com/iqbaludinm/hr-microservice/profile-service/repository"
 	"github.com/iqbaludinm/hr-microservice/profile-service/repository/query"
 	"github.com/iqbaludinm/hr-microservice/profile-service/service"
@@ -42,6 +44,8 @@ func controllers() {
 		DisableStartupMessage: true,
 		JSONEncoder:           json.Marshal,
 		JSONDecoder:           json.Unmarshal,
+		// the uploaded profile photos can be larger than the default limit of 4 MB
+		BodyLimit: (config.ProfilePhotoMaxSizeMB + 1) * 1024 * 1024,
 	})
 	
 	app.Use(recover.New())
@@ -66,12 +70,25 @@ func controllers() {
 
 	profileQuery := query.NewProfile()
 	profileRepository := repository.NewProfile(store, profileQuery)
-	profileService := service.NewProfileService(profileRepository, kafkaProducerService, logger.Sugar())
+	storage, err := helper.NewStorage(config.StorageDriver, config.StorageLocalDir)
+	if err != nil {
+		sugar.Fatal(err)
+	}
+	profileService := service.NewProfileService(profileRepository, storage, kafkaProducerService, logger.Sugar())
 	profileController := controller.NewProfileController(validate, kafkaProducerService, profileService)
 
+	// reject the token of the deactivated users on the authenticated routes
+	middleware.RevokeDeletedUserSession(profileRepository)
+
+	employeeQuery := query.NewEmployee()
+	employeeRepository := repository.NewEmployee(store, employeeQuery)
+	employeeService := service.NewEmployeeService(employeeRepository, kafkaProducerService, logger.Sugar())
+	employeeController := controller.NewEmployeeController(validate, kafkaProducerService, employeeService)
+
 	profileController.Route(app)
+	employeeController.Route(app)
 
-	err := app.Listen(serverConfig.Host)
+	err = app.Listen(serverConfig.Host)
 	if err != nil {
 		sugar.Fatal(err)
 	}
@@ -100,6 +117,9 @@ func main() {
 	profileQuery := query.NewProfile()
 	profileRepository := repository.NewProfile(store, profileQuery)
 	kafkaUserConsumerService := consumers.NewKafkaUserConsumerService(profileRepository, logger)
+	employeeQuery := query.NewEmployee()
+	employeeRepository := repository.NewEmployee(store, employeeQuery)
+	kafkaEmployeeConsumerService := consumers.NewKafkaEmployeeConsumerService(employeeRepository, logger)
 
 	run := true
 
@@ -141,11 +161,23 @@ func main() {
 					if err != nil {
 						logger.Panic(err)
 					}
-				// case `[method="DELETE.USER"]`:
-				// 	err := kafkaUserConsumerService.Delete(e.Value)
-				// 	if err != nil {
-				// 		logger.Panic(err)
-				// 	}
+				case `[method="DELETE.USER"]`:
+					err := kafkaUserConsumerService.Delete(e.Value)
+					if err != nil {
+						logger.Panic(err)
+					}
+
+				// EMPLOYEE
+				case `[method="POST.EMPLOYEE"]`, `[method="PUT.EMPLOYEE"]`:
+					err := kafkaEmployeeConsumerService.Upsert(e.Value)
+					if err != nil {
+						logger.Panic(err)
+					}
+				case `[method="DELETE.EMPLOYEE"]`:
+					err := kafkaEmployeeConsumerService.Delete(e.Value)
+					if err != nil {
+						logger.Panic(err)
+					}
 				}
 
 			case kafka.Error:
@@ -6,8 +6,12 @@ import (
 	"github.com/gofiber/fiber/v2"
 	"github.com/iqbaludinm/hr-microservice/profile-service/helper"
 	"github.com/iqbaludinm/hr-microservice/profile-service/model/web"
+	"github.com/iqbaludinm/hr-microservice/profile-service/repository"
 )
 
+// the repository used by 'IsAuthenticated' for rejecting the token of the deleted user, it's set by 'RevokeDeletedUserSession'
+var profileRepository repository.ProfileRepository
+
 func IsAuthenticated(c *fiber.Ctx) error {
 	cookie := c.Cookies("token") // ambil token di cookies, dengan key "token"
 
@@ -27,6 +31,32 @@ func IsAuthenticated(c *fiber.Ctx) error {
 		})
 	}
 
+	if profileRepository != nil {
+		// the session is rejected when the user can't be checked, a failed lookup must not re-admit a deleted user
+		deleted, err := profileRepository.IsDeleted(c.Context(), issuer)
+		if err != nil {
+			return c.Status(fiber.StatusInternalServerError).JSON(web.WebResponse{
+				Code:    fiber.StatusInternalServerError,
+				Status:  false,
+				Message: "failed to check the session",
+			})
+		}
+		if deleted {
+			c.ClearCookie("token", "refresh_token")
+			return c.Status(fiber.StatusUnauthorized).JSON(web.WebResponse{
+				Code:    fiber.StatusUnauthorized,
+				Status:  false,
+				Message: "session revoked",
+			})
+		}
+	}
+
 	c.Locals("issuer", issuer)
 	return c.Next()
 }
+
+// RevokeDeletedUserSession makes 'IsAuthenticated' reject the token of the user which has been deleted,
+// e.g. deactivated by the offboarding. Only the authenticated routes are checked.
+func RevokeDeletedUserSession(r repository.ProfileRepository) {
+	profileRepository = r
+}
@@ -0,0 +1,78 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/iqbaludinm/hr-microservice/profile-service/model/web"
+)
+
+// employee struct, a replica of the employee master data from the user-service
+type Employee struct {
+	ID                string             `json:"id"`
+	UserID            string             `json:"user_id"`
+	EmployeeNumber    string             `json:"employee_number"`
+	DateOfBirth       time.Time          `json:"date_of_birth"`
+	Gender            string             `json:"gender"`
+	Address           string             `json:"address"`
+	HireDate          time.Time          `json:"hire_date"`
+	EmploymentType    string             `json:"employment_type"`
+	Status            string             `json:"status"`
+	JobTitle          string             `json:"job_title"`
+	Department        string             `json:"department"`
+	ManagerID         *string            `json:"manager_id"`
+	EmergencyContacts []EmergencyContact `json:"emergency_contacts"`
+	CreatedAt         time.Time          `json:"created_at"`
+	UpdatedAt         time.Time          `json:"updated_at"`
+	DeletedAt         *time.Time         `json:"deleted_at"`
+}
+
+type EmergencyContact struct {
+	Name         string `json:"name"`
+	Relationship string `json:"relationship"`
+	Phone        string `json:"phone"`
+	Address      string `json:"address"`
+}
+
+func (e *Employee) ToEmployeeResponse() web.EmployeeResponse {
+	emergencyContacts := make([]web.EmergencyContactResponse, 0, len(e.EmergencyContacts))
+	for _, contact := range e.EmergencyContacts {
+		emergencyContacts = append(emergencyContacts, web.EmergencyContactResponse{
+			Name:         contact.Name,
+			Relationship: contact.Relationship,
+			Phone:        contact.Phone,
+			Address:      contact.Address,
+		})
+	}
+
+	return web.EmployeeResponse{
+		ID:                e.ID,
+		UserID:            e.UserID,
+		EmployeeNumber:    e.EmployeeNumber,
+		DateOfBirth:       e.DateOfBirth.Format("2006-01-02"),
+		Gender:            e.Gender,
+		Address:           e.Address,
+		HireDate:          e.HireDate.Format("2006-01-02"),
+		EmploymentType:    e.EmploymentType,
+		Status:            e.Status,
+		JobTitle:          e.JobTitle,
+		Department:        e.Department,
+		ManagerID:         e.ManagerID,
+		EmergencyContacts: emergencyContacts,
+		UpdatedAt:         e.UpdatedAt,
+	}
+}
+
+// Convert the emergency contacts from the request body to the domain
+func ToDomainEmergencyContacts(requests []web.EmergencyContactRequest) []EmergencyContact {
+	contacts := make([]EmergencyContact, 0, len(requests))
+	for _, request := range requests {
+		contacts = append(contacts, EmergencyContact{
+			Name:         request.Name,
+			Relationship: request.Relationship,
+			Phone:        request.Phone,
+			Address:      request.Address,
+		})
+	}
+
+	return contacts
+}
@@ -20,6 +20,7 @@ type User struct {
 	CreatedAt time.Time  `json:"created_at"`
 	UpdatedAt time.Time  `json:"updated_at"`
 	DeletedAt *time.Time `json:"deleted_at"`
+	AvatarURL *string    `json:"avatar_url"`
 }
 
 func (user *User) SetPassword(password string) {
@@ -33,10 +34,11 @@ func (user *User) ComparePassword(correctPassword string, password string) error
 
 func (s *User) ToUserResponse() web.UserResponse {
 	return web.UserResponse{
-		ID:    s.ID,
-		Name:  s.Name,
-		Email: s.Email,
-		Phone: s.Phone,
+		ID:        s.ID,
+		Name:      s.Name,
+		Email:     s.Email,
+		Phone:     s.Phone,
+		AvatarURL: s.AvatarURL,
 	}
 }
 
@@ -0,0 +1,76 @@
+package kafkamodel
+
+import (
+	"time"
+
+	"github.com/iqbaludinm/hr-microservice/profile-service/model/domain"
+)
+
+// This struct is used for mapping the incoming 'employee' message from the user-service
+// (POST.EMPLOYEE, PUT.EMPLOYEE, DELETE.EMPLOYEE), and for producing the self-edited personal
+// data back to the user-service (PUT.EMPLOYEE_PERSONAL).
+type KafkaEmployeeMessage struct {
+	ID                string                    `json:"id"`
+	UserID            string                    `json:"user_id"`
+	EmployeeNumber    string                    `json:"employee_number"`
+	DateOfBirth       string                    `json:"date_of_birth"`
+	Gender            string                    `json:"gender"`
+	Address           string                    `json:"address"`
+	HireDate          string                    `json:"hire_date"`
+	EmploymentType    string                    `json:"employment_type"`
+	Status            string                    `json:"status"`
+	JobTitle          string                    `json:"job_title"`
+	Department        string                    `json:"department"`
+	ManagerID         *string                   `json:"manager_id"`
+	EmergencyContacts []domain.EmergencyContact `json:"emergency_contacts"`
+	CreatedAt         time.Time                 `json:"created_at"`
+	UpdatedAt         time.Time                 `json:"updated_at"`
+	DeletedAt         *time.Time                `json:"deleted_at"`
+}
+
+// Convert "Employee" object to "KafkaEmployeeMessage" object
+func NewKafkaEmployeeMessage(employee domain.Employee) KafkaEmployeeMessage {
+	return KafkaEmployeeMessage{
+		ID:                employee.ID,
+		UserID:            employee.UserID,
+		EmployeeNumber:    employee.EmployeeNumber,
+		DateOfBirth:       employee.DateOfBirth.Format("2006-01-02"),
+		Gender:            employee.Gender,
+		Address:           employee.Address,
+		HireDate:          employee.HireDate.Format("2006-01-02"),
+		EmploymentType:    employee.EmploymentType,
+		Status:            employee.Status,
+		JobTitle:          employee.JobTitle,
+		Department:        employee.Department,
+		ManagerID:         employee.ManagerID,
+		EmergencyContacts: employee.EmergencyContacts,
+		CreatedAt:         employee.CreatedAt,
+		UpdatedAt:         employee.UpdatedAt,
+		DeletedAt:         employee.DeletedAt,
+	}
+}
+
+// Convert "KafkaEmployeeMessage" object to "Employee" object
+func (m *KafkaEmployeeMessage) ToEmployee() domain.Employee {
+	dateOfBirth, _ := time.Parse("2006-01-02", m.DateOfBirth)
+	hireDate, _ := time.Parse("2006-01-02", m.HireDate)
+
+	return domain.Employee{
+		ID:                m.ID,
+		UserID:            m.UserID,
+		EmployeeNumber:    m.EmployeeNumber,
+		DateOfBirth:       dateOfBirth,
+		Gender:            m.Gender,
+		Address:           m.Address,
+		HireDate:          hireDate,
+		EmploymentType:    m.EmploymentType,
+		Status:            m.Status,
+		JobTitle:          m.JobTitle,
+		Department:        m.Department,
+		ManagerID:         m.ManagerID,
+		EmergencyContacts: m.EmergencyContacts,
+		CreatedAt:         m.CreatedAt,
+		UpdatedAt:         m.UpdatedAt,
+		DeletedAt:         m.DeletedAt,
+	}
+}
@@ -19,6 +19,7 @@ type KafkaUserMessage struct {
 	CreatedAt time.Time  `json:"created_at"`
 	UpdatedAt time.Time  `json:"updated_at"`
 	DeletedAt *time.Time `json:"deleted_at"`
+	AvatarURL *string    `json:"avatar_url"`
 }
 
 // Convert "User" object to "KafkaUserMessage" object
@@ -32,5 +33,6 @@ func NewKafkaUserMessage(user domain.User) KafkaUserMessage {
 		CreatedAt: user.CreatedAt,
 		UpdatedAt: user.UpdatedAt,
 		DeletedAt: user.DeletedAt,
+		AvatarURL: user.AvatarURL,
 	}
 }
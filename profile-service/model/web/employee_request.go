@@ -0,0 +1,15 @@
+package web
+
+type EmergencyContactRequest struct {
+	Name         string `json:"name" validate:"required"`
+	Relationship string `json:"relationship" validate:"required"`
+	Phone        string `json:"phone" validate:"required,numeric,min=8,max=15"`
+	Address      string `json:"address"`
+}
+
+// The subset of the employee data that can be edited by the employee itself.
+// The other fields are managed by HR through the user-service.
+type UpdateMyEmployeeRequest struct {
+	Address           string                    `json:"address"`
+	EmergencyContacts []EmergencyContactRequest `json:"emergency_contacts" validate:"omitempty,dive"`
+}
@@ -0,0 +1,27 @@
+package web
+
+import "time"
+
+type EmergencyContactResponse struct {
+	Name         string `json:"name"`
+	Relationship string `json:"relationship"`
+	Phone        string `json:"phone"`
+	Address      string `json:"address"`
+}
+
+type EmployeeResponse struct {
+	ID                string                     `json:"id"`
+	UserID            string                     `json:"user_id"`
+	EmployeeNumber    string                     `json:"employee_number"`
+	DateOfBirth       string                     `json:"date_of_birth"`
+	Gender            string                     `json:"gender"`
+	Address           string                     `json:"address"`
+	HireDate          string                     `json:"hire_date"`
+	EmploymentType    string                     `json:"employment_type"`
+	Status            string                     `json:"status"`
+	JobTitle          string                     `json:"job_title"`
+	Department        string                     `json:"department"`
+	ManagerID         *string                    `json:"manager_id"`
+	EmergencyContacts []EmergencyContactResponse `json:"emergency_contacts"`
+	UpdatedAt         time.Time                  `json:"updated_at"`
+}
@@ -5,5 +5,6 @@ type UserResponse struct {
 	Name  string `json:"name"`
 	Email string `json:"email"`
 	Phone string `json:"phone"`
-	
+	// the URL of the largest thumbnail of the profile photo, it is null when the user has no photo
+	AvatarURL *string `json:"avatar_url"`
 }
@@ -0,0 +1,90 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/iqbaludinm/hr-microservice/profile-service/model/domain"
+	"github.com/iqbaludinm/hr-microservice/profile-service/repository/query"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type EmployeeRepository interface {
+	UpsertEmployeeTx(ctx context.Context, employee domain.Employee) error
+	UpdatePersonalTx(ctx context.Context, id string, employee domain.Employee) error
+	DeleteTx(ctx context.Context, id string, employee domain.Employee) error
+	FindByUserIdTx(ctx context.Context, userID string) (domain.Employee, error)
+}
+
+type employeeRepository struct {
+	db            Store
+	EmployeeQuery query.EmployeeQuery
+}
+
+func NewEmployee(db Store, q query.EmployeeQuery) EmployeeRepository {
+	return &employeeRepository{
+		db:            db,
+		EmployeeQuery: q,
+	}
+}
+
+func (r *employeeRepository) UpsertEmployeeTx(ctx context.Context, employee domain.Employee) error {
+	var err error
+
+	err = r.db.WithTransaction(ctx, func(tx pgx.Tx) error {
+		err = r.EmployeeQuery.UpsertEmployee(ctx, tx, employee)
+		if err != nil {
+			return err
+		}
+
+		return nil
+	})
+
+	return err
+}
+
+func (r *employeeRepository) UpdatePersonalTx(ctx context.Context, id string, employee domain.Employee) error {
+	var err error
+
+	err = r.db.WithTransaction(ctx, func(tx pgx.Tx) error {
+		err = r.EmployeeQuery.UpdatePersonal(ctx, tx, id, employee)
+		if err != nil {
+			return err
+		}
+
+		return nil
+	})
+
+	return err
+}
+
+func (r *employeeRepository) DeleteTx(ctx context.Context, id string, employee domain.Employee) error {
+	var err error
+
+	err = r.db.WithTransaction(ctx, func(tx pgx.Tx) error {
+		err = r.EmployeeQuery.Delete(ctx, tx, id, employee)
+		if err != nil {
+			return err
+		}
+
+		return nil
+	})
+
+	return err
+}
+
+func (r *employeeRepository) FindByUserIdTx(ctx context.Context, userID string) (domain.Employee, error) {
+	var data domain.Employee
+	var err error
+
+	err = r.db.WithoutTransaction(ctx, func(db *pgxpool.Pool) error {
+		data, err = r.EmployeeQuery.FindByUserId(ctx, db, userID)
+		if err != nil {
+			return err
+		}
+
+		return nil
+	})
+
+	return data, err
+}
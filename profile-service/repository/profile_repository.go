@@ -18,6 +18,9 @@ type ProfileRepository interface {
 	AddTokenTx(ctx context.Context, token domain.ResetPasswordToken) error
 	UpdateTokenTx(ctx context.Context, token domain.ResetPasswordToken) error
 	UpdatePasswordTx(ctx context.Context, user domain.User) error
+	Delete(c context.Context, id string) error
+	IsDeleted(c context.Context, id string) (bool, error)
+	UpdateAvatar(c context.Context, user domain.User) error
 
 	// -1
 	// FindUserWithNameNotDeleteByQueryTx(ctx context.Context, query, value string) (domain.UserWithName, error)
@@ -155,4 +158,49 @@ func (r *profileRepository) UpdatePasswordTx(ctx context.Context, user domain.Us
 	})
 
 	return err
-}
\ No newline at end of file
+}
+
+func (r *profileRepository) Delete(c context.Context, id string) error {
+	var err error
+
+	// create transaction to delete user
+	err = r.db.WithTransaction(c, func(tx pgx.Tx) error {
+		// delete user by id, if error will rollback
+		if err = r.ProfileQuery.Delete(c, tx, id); err != nil {
+			return err
+		}
+		return nil
+	})
+
+	return err
+}
+
+func (r *profileRepository) IsDeleted(c context.Context, id string) (bool, error) {
+	var deleted bool
+	var err error
+
+	// check the user without transaction.
+	err = r.db.WithoutTransaction(c, func(db *pgxpool.Pool) error {
+		if deleted, err = r.ProfileQuery.IsDeleted(c, db, id); err != nil {
+			return err
+		}
+		return nil
+	})
+
+	return deleted, err
+}
+
+func (r *profileRepository) UpdateAvatar(c context.Context, user domain.User) error {
+	var err error
+
+	// create transaction to update the avatar of the user
+	err = r.db.WithTransaction(c, func(tx pgx.Tx) error {
+		// update avatar by id, if error will rollback
+		if err = r.ProfileQuery.UpdateAvatar(c, tx, user); err != nil {
+			return err
+		}
+		return nil
+	})
+
+	return err
+}
@@ -0,0 +1,133 @@
+package query
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/iqbaludinm/hr-microservice/profile-service/model/domain"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type EmployeeQuery interface {
+	UpsertEmployee(c context.Context, tx pgx.Tx, employee domain.Employee) error
+	UpdatePersonal(c context.Context, tx pgx.Tx, id string, employee domain.Employee) error
+	Delete(c context.Context, tx pgx.Tx, id string, employee domain.Employee) error
+	FindByUserId(c context.Context, db *pgxpool.Pool, userID string) (domain.Employee, error)
+}
+
+type EmployeeQueryImpl struct {
+}
+
+func NewEmployee() EmployeeQuery {
+	return &EmployeeQueryImpl{}
+}
+
+// KAFKA INTEGRATION: inserting or replacing the employee replica from the user-service
+func (repository *EmployeeQueryImpl) UpsertEmployee(c context.Context, tx pgx.Tx, employee domain.Employee) error {
+	emergencyContacts, err := json.Marshal(employee.EmergencyContacts)
+	if err != nil {
+		return err
+	}
+
+	query := `INSERT INTO employees (
+		"id",
+		"user_id",
+		"employee_number",
+		"date_of_birth",
+		"gender",
+		"address",
+		"hire_date",
+		"employment_type",
+		"status",
+		"job_title",
+		"department",
+		"manager_id",
+		"emergency_contacts",
+		"created_at",
+		"updated_at"
+		) VALUES ($1,$2,$3,$4,$5,$6,$7,$8,$9,$10,$11,$12,$13::jsonb,$14,$15)
+		ON CONFLICT ("id") DO UPDATE SET
+			employee_number = EXCLUDED.employee_number,
+			date_of_birth = EXCLUDED.date_of_birth,
+			gender = EXCLUDED.gender,
+			address = EXCLUDED.address,
+			hire_date = EXCLUDED.hire_date,
+			employment_type = EXCLUDED.employment_type,
+			status = EXCLUDED.status,
+			job_title = EXCLUDED.job_title,
+			department = EXCLUDED.department,
+			manager_id = EXCLUDED.manager_id,
+			emergency_contacts = EXCLUDED.emergency_contacts,
+			updated_at = EXCLUDED.updated_at`
+
+	_, err = tx.Exec(c, query,
+		employee.ID,
+		employee.UserID,
+		employee.EmployeeNumber,
+		employee.DateOfBirth,
+		employee.Gender,
+		employee.Address,
+		employee.HireDate,
+		employee.EmploymentType,
+		employee.Status,
+		employee.JobTitle,
+		employee.Department,
+		employee.ManagerID,
+		string(emergencyContacts),
+		employee.CreatedAt,
+		employee.UpdatedAt,
+	)
+
+	return err
+}
+
+func (repository *EmployeeQueryImpl) UpdatePersonal(c context.Context, tx pgx.Tx, id string, employee domain.Employee) error {
+	emergencyContacts, err := json.Marshal(employee.EmergencyContacts)
+	if err != nil {
+		return err
+	}
+
+	query := "UPDATE employees SET address = $1, emergency_contacts = $2::jsonb, updated_at = $3 WHERE id = $4"
+
+	_, err = tx.Exec(c, query, employee.Address, string(emergencyContacts), employee.UpdatedAt, id)
+
+	return err
+}
+
+func (repository *EmployeeQueryImpl) Delete(c context.Context, tx pgx.Tx, id string, employee domain.Employee) error {
+	query := "UPDATE employees SET deleted_at = $1 WHERE id = $2"
+
+	_, err := tx.Exec(c, query, employee.DeletedAt, id)
+
+	return err
+}
+
+func (repository *EmployeeQueryImpl) FindByUserId(c context.Context, db *pgxpool.Pool, userID string) (domain.Employee, error) {
+	query := `SELECT
+			id,
+			user_id,
+			employee_number,
+			date_of_birth,
+			gender,
+			address,
+			hire_date,
+			employment_type,
+			status,
+			job_title,
+			department,
+			manager_id,
+			emergency_contacts,
+			created_at,
+			updated_at,
+			deleted_at
+		FROM employees
+		WHERE user_id = $1 AND deleted_at is NULL`
+
+	row := db.QueryRow(c, query, userID)
+
+	var data domain.Employee
+	err := row.Scan(&data.ID, &data.UserID, &data.EmployeeNumber, &data.DateOfBirth, &data.Gender, &data.Address, &data.HireDate, &data.EmploymentType, &data.Status, &data.JobTitle, &data.Department, &data.ManagerID, &data.EmergencyContacts, &data.CreatedAt, &data.UpdatedAt, &data.DeletedAt)
+
+	return data, err
+}
@@ -3,6 +3,7 @@ package query
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/iqbaludinm/hr-microservice/profile-service/model/domain"
 	"github.com/jackc/pgx/v5"
@@ -19,6 +20,9 @@ type ProfileQuery interface {
 	AddToken(ctx context.Context, db pgx.Tx, tokens domain.ResetPasswordToken) error
 	UpdateToken(ctx context.Context, db pgx.Tx, tokens domain.ResetPasswordToken) error
 	UpdatePassword(c context.Context, tx pgx.Tx, user domain.User) error
+	Delete(c context.Context, tx pgx.Tx, id string) error
+	IsDeleted(c context.Context, db *pgxpool.Pool, id string) (bool, error)
+	UpdateAvatar(c context.Context, tx pgx.Tx, user domain.User) error
 }
 
 type ProfileQueryImpl struct {
@@ -75,11 +79,11 @@ func (repository *ProfileQueryImpl) Login(c context.Context, tx pgx.Tx, email st
 
 func (repository *ProfileQueryImpl) FindUserNotDeleteByQuery(c context.Context, db *pgxpool.Pool, query, value string) (domain.User, error) {
 	var data domain.User
-	queryStr := fmt.Sprintf("SELECT * FROM %s WHERE %s = $1 AND deleted_at is NULL", "users", query)
+	queryStr := fmt.Sprintf("SELECT id, name, email, password, phone, created_at, updated_at, deleted_at, avatar_url FROM %s WHERE %s = $1 AND deleted_at is NULL", "users", query)
 
 	row := db.QueryRow(c, queryStr, value)
 
-	err := row.Scan(&data.ID, &data.Name, &data.Email, &data.Password, &data.Phone, &data.CreatedAt, &data.UpdatedAt, &data.DeletedAt)
+	err := row.Scan(&data.ID, &data.Name, &data.Email, &data.Password, &data.Phone, &data.CreatedAt, &data.UpdatedAt, &data.DeletedAt, &data.AvatarURL)
 
 	if err != nil {
 		return domain.User{}, err
@@ -191,3 +195,34 @@ func (repository *ProfileQueryImpl) UpdatePassword(c context.Context, tx pgx.Tx,
 
 	return nil
 }
+
+func (repository *ProfileQueryImpl) Delete(c context.Context, tx pgx.Tx, id string) error {
+	// build UPDATE query
+	query := `UPDATE users SET deleted_at=$1 WHERE id=$2`
+
+	_, err := tx.Exec(c, query, time.Now(), id)
+
+	return err
+}
+
+// check whether the user is deleted, the user which is not found is not deleted
+func (repository *ProfileQueryImpl) IsDeleted(c context.Context, db *pgxpool.Pool, id string) (bool, error) {
+	query := `SELECT deleted_at IS NOT NULL FROM users WHERE id=$1`
+
+	var deleted bool
+	err := db.QueryRow(c, query, id).Scan(&deleted)
+	if err == pgx.ErrNoRows {
+		return false, nil
+	}
+
+	return deleted, err
+}
+
+func (repository *ProfileQueryImpl) UpdateAvatar(c context.Context, tx pgx.Tx, user domain.User) error {
+	// build UPDATE query
+	query := `UPDATE users SET avatar_url=$1, updated_at=$2 WHERE id=$3`
+
+	_, err := tx.Exec(c, query, user.AvatarURL, user.UpdatedAt, user.ID)
+
+	return err
+}
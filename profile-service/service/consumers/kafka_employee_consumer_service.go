@@ -0,0 +1,60 @@
+package consumers
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/iqbaludinm/hr-microservice/profile-service/model/kafkamodel"
+	"github.com/iqbaludinm/hr-microservice/profile-service/repository"
+	"go.uber.org/zap"
+)
+
+type KafkaEmployeeConsumerService interface {
+	Upsert(message []byte) error
+	Delete(message []byte) error
+}
+
+type kafkaEmployeeConsumerService struct {
+	employeeRepository repository.EmployeeRepository
+	logger             *zap.SugaredLogger
+}
+
+func NewKafkaEmployeeConsumerService(employeeRepository repository.EmployeeRepository, logger *zap.SugaredLogger) KafkaEmployeeConsumerService {
+	return &kafkaEmployeeConsumerService{
+		employeeRepository: employeeRepository,
+		logger:             logger,
+	}
+}
+
+// Upsert is used for both POST.EMPLOYEE and PUT.EMPLOYEE, the message always contains the whole employee.
+func (s *kafkaEmployeeConsumerService) Upsert(message []byte) error {
+	employeeMsg := new(kafkamodel.KafkaEmployeeMessage)
+
+	if err := json.Unmarshal(message, employeeMsg); err != nil {
+		s.logger.Errorw("error kafka upsert employee consumer:", "error", err.Error())
+		return nil
+	}
+
+	// create or update employee
+	if err := s.employeeRepository.UpsertEmployeeTx(context.TODO(), employeeMsg.ToEmployee()); err != nil {
+		s.logger.Errorw("error kafka upsert employee consumer:", "error", err.Error())
+	}
+
+	return nil
+}
+
+func (s *kafkaEmployeeConsumerService) Delete(message []byte) error {
+	employeeMsg := new(kafkamodel.KafkaEmployeeMessage)
+
+	if err := json.Unmarshal(message, employeeMsg); err != nil {
+		s.logger.Errorw("error kafka delete employee consumer:", "error", err.Error())
+		return nil
+	}
+
+	// delete employee
+	if err := s.employeeRepository.DeleteTx(context.TODO(), employeeMsg.ID, employeeMsg.ToEmployee()); err != nil {
+		s.logger.Errorw("error kafka delete employee consumer:", "error", err.Error())
+	}
+
+	return nil
+}
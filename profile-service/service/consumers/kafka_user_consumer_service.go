@@ -15,7 +15,7 @@ type KafkaUserConsumerService interface {
 	Insert(message []byte) error
 	Update(message []byte) error
 	UpdatePass(message []byte) error
-	// Delete(message []byte) error
+	Delete(message []byte) error
 }
 
 type kafkaUserConsumerService struct {
@@ -100,17 +100,17 @@ func (s *kafkaUserConsumerService) UpdatePass(message []byte) error {
 	return nil
 }
 
-// func (s *kafkaUserConsumerService) Delete(message []byte) error {
-// 	userMsg := new(kafkamodel.KafkaUserMessage)
+func (s *kafkaUserConsumerService) Delete(message []byte) error {
+	userMsg := new(kafkamodel.KafkaUserMessage)
 
-// 	if err := json.Unmarshal(message, userMsg); err != nil {
-// 		s.logger.Errorw("error kafka delete user consumer:", "error", err.Error())
-// 	}
+	if err := json.Unmarshal(message, userMsg); err != nil {
+		s.logger.Errorw("error kafka delete user consumer:", "error", err.Error())
+	}
 
-// 	// delete user
-// 	if err := s.userRepository.Delete(context.TODO(), userMsg.ID); err != nil {
-// 		s.logger.Errorw("error kafka delete user consumer:", "error", err.Error())
-// 	}
+	// delete user
+	if err := s.profileRepository.Delete(context.TODO(), userMsg.ID); err != nil {
+		s.logger.Errorw("error kafka delete user consumer:", "error", err.Error())
+	}
 
-// 	return nil
-// }
+	return nil
+}
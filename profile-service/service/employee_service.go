@@ -0,0 +1,70 @@
+package service
+
+import (
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/iqbaludinm/hr-microservice/profile-service/config"
+	"github.com/iqbaludinm/hr-microservice/profile-service/exception"
+	"github.com/iqbaludinm/hr-microservice/profile-service/model/domain"
+	"github.com/iqbaludinm/hr-microservice/profile-service/model/kafkamodel"
+	"github.com/iqbaludinm/hr-microservice/profile-service/model/web"
+	"github.com/iqbaludinm/hr-microservice/profile-service/repository"
+	"github.com/iqbaludinm/hr-microservice/profile-service/service/producers"
+	"go.uber.org/zap"
+)
+
+type EmployeeService interface {
+	FindMyEmployee(ctx *fiber.Ctx, userID string) (web.EmployeeResponse, error)
+	UpdateMyEmployee(ctx *fiber.Ctx, userID string, request web.UpdateMyEmployeeRequest) (web.EmployeeResponse, error)
+}
+
+type employeeService struct {
+	employeeRepository   repository.EmployeeRepository
+	kafkaProducerService producers.KafkaProducerService
+	logger               *zap.SugaredLogger
+}
+
+func NewEmployeeService(employeeRepository repository.EmployeeRepository, kafkaProducerService producers.KafkaProducerService, logger *zap.SugaredLogger) EmployeeService {
+	return &employeeService{
+		employeeRepository:   employeeRepository,
+		kafkaProducerService: kafkaProducerService,
+		logger:               logger,
+	}
+}
+
+func (service *employeeService) FindMyEmployee(ctx *fiber.Ctx, userID string) (web.EmployeeResponse, error) {
+	employee, err := service.employeeRepository.FindByUserIdTx(ctx.Context(), userID)
+	if err != nil {
+		return web.EmployeeResponse{}, exception.ErrNotFound("Employee data not found.")
+	}
+
+	return employee.ToEmployeeResponse(), nil
+}
+
+func (service *employeeService) UpdateMyEmployee(ctx *fiber.Ctx, userID string, request web.UpdateMyEmployeeRequest) (web.EmployeeResponse, error) {
+	employee, err := service.employeeRepository.FindByUserIdTx(ctx.Context(), userID)
+	if err != nil {
+		return web.EmployeeResponse{}, exception.ErrNotFound("Employee data not found.")
+	}
+
+	// only the self-editable fields are updated
+	if request.Address != "" {
+		employee.Address = request.Address
+	}
+	if request.EmergencyContacts != nil {
+		employee.EmergencyContacts = domain.ToDomainEmergencyContacts(request.EmergencyContacts)
+	}
+	employee.UpdatedAt = time.Now()
+
+	err = service.employeeRepository.UpdatePersonalTx(ctx.Context(), employee.ID, employee)
+	if err != nil {
+		return web.EmployeeResponse{}, err
+	}
+
+	// produce to kafka, the user-service is the owner of the employee master data
+	kafkaEmployeeMessage := kafkamodel.NewKafkaEmployeeMessage(employee)
+	go service.kafkaProducerService.Produce(kafkaEmployeeMessage, "PUT.EMPLOYEE_PERSONAL", config.KafkaTopic)
+
+	return employee.ToEmployeeResponse(), nil
+}
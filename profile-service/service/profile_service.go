@@ -1,6 +1,8 @@
 package service
 
 import (
+	"errors"
+	"fmt"
 	"log"
 	"strings"
 	"time"
@@ -29,18 +31,23 @@ type ProfileService interface {
 	UpdateMyProfile(ctx *fiber.Ctx, id string, request web.UpdateProfileRequest) (domain.User, error)
 	ForgetPasswordEmail(ctx *fiber.Ctx, email string) (domain.ResetPasswordToken, error)
 	ResetPassword(ctx *fiber.Ctx, email, token string, request web.ResetPassword) error
+	UpdateMyPhoto(ctx *fiber.Ctx, userID string, photo []byte) (web.UserResponse, error)
+	FindPhoto(ctx *fiber.Ctx, userID string, size int) ([]byte, error)
 }
 
 type profileService struct {
 	profileRepository    repository.ProfileRepository
+	storage              helper.Storage
 	kafkaProducerService producers.KafkaProducerService
 	logger               *zap.SugaredLogger
 }
 
-func NewProfileService(profileRepository repository.ProfileRepository, kafkaProducerService producers.KafkaProducerService, logger *zap.SugaredLogger) ProfileService {
+func NewProfileService(profileRepository repository.ProfileRepository, storage helper.Storage, kafkaProducerService producers.KafkaProducerService, logger *zap.SugaredLogger) ProfileService {
 	return &profileService{
 		profileRepository:    profileRepository,
+		storage:              storage,
 		kafkaProducerService: kafkaProducerService,
+		logger:               logger,
 	}
 }
 
@@ -211,3 +218,95 @@ func (service *profileService) ResetPassword(ctx *fiber.Ctx, email, token string
 
 	return nil
 }
+
+// Photo
+
+// update the profile photo of the user, the thumbnails of every size are generated from the photo and stored. The
+// avatar URL changes on every upload so the old photo is not served from the cache of the clients.
+func (service *profileService) UpdateMyPhoto(ctx *fiber.Ctx, userID string, photo []byte) (web.UserResponse, error) {
+	user, err := service.profileRepository.FindUserNotDeleteByQueryTx(ctx.Context(), "id", userID)
+	if err != nil {
+		return web.UserResponse{}, exception.ErrNotFound("User not found.")
+	}
+
+	if len(photo) > config.ProfilePhotoMaxSizeMB*1024*1024 {
+		return web.UserResponse{}, exception.ErrBadRequest(fmt.Sprintf("Photo can't be larger than %d MB.", config.ProfilePhotoMaxSizeMB))
+	}
+
+	thumbnails, err := helper.NewThumbnails(photo, config.ProfilePhotoSizes)
+	if err != nil {
+		if errors.Is(err, helper.ErrUnsupportedImage) {
+			return web.UserResponse{}, exception.ErrBadRequest("Photo must be a JPEG, PNG or WebP image.")
+		}
+		if errors.Is(err, helper.ErrImageTooLarge) {
+			return web.UserResponse{}, exception.ErrBadRequest("Photo dimension is too large.")
+		}
+		return web.UserResponse{}, err
+	}
+
+	for size, thumbnail := range thumbnails {
+		if err := service.storage.Put(ctx.Context(), avatarKey(user.ID, size), thumbnail); err != nil {
+			service.logger.Infow(err.Error(), "Store Profile Photo Error")
+			return web.UserResponse{}, err
+		}
+	}
+
+	avatarURL := fmt.Sprintf("%s%s/%s/photo?v=%d", config.ProfilePhotoBaseURL, config.EndpointPrefixProfile, user.ID, time.Now().Unix())
+	user.AvatarURL = &avatarURL
+	user.UpdatedAt = time.Now()
+
+	if err := service.profileRepository.UpdateAvatar(ctx.Context(), user); err != nil {
+		service.logger.Infow(err.Error(), "Update Profile Photo Error")
+		return web.UserResponse{}, err
+	}
+
+	// produce to kafka, the other services keep the avatar URL of the user
+	kafkaUserMessage := kafkamodel.NewKafkaUserMessage(user)
+	go service.kafkaProducerService.Produce(kafkaUserMessage, "PUT.USER_AVATAR", config.KafkaTopic)
+
+	return user.ToUserResponse(), nil
+}
+
+// find the thumbnail of the profile photo of the user, the largest thumbnail is returned when the size is 0
+func (service *profileService) FindPhoto(ctx *fiber.Ctx, userID string, size int) ([]byte, error) {
+	if len(config.ProfilePhotoSizes) == 0 {
+		return nil, exception.ErrNotFound("Photo not found.")
+	}
+
+	largest := config.ProfilePhotoSizes[0]
+	valid := false
+	for _, photoSize := range config.ProfilePhotoSizes {
+		if photoSize > largest {
+			largest = photoSize
+		}
+		if photoSize == size {
+			valid = true
+		}
+	}
+	if size == 0 {
+		size, valid = largest, true
+	}
+	if !valid {
+		return nil, exception.ErrBadRequest(fmt.Sprintf("Size must be one of %v.", config.ProfilePhotoSizes))
+	}
+
+	user, err := service.profileRepository.FindUserNotDeleteByQueryTx(ctx.Context(), "id", userID)
+	if err != nil || user.AvatarURL == nil {
+		return nil, exception.ErrNotFound("Photo not found.")
+	}
+
+	photo, err := service.storage.Get(ctx.Context(), avatarKey(user.ID, size))
+	if err != nil {
+		if errors.Is(err, helper.ErrObjectNotFound) {
+			return nil, exception.ErrNotFound("Photo not found.")
+		}
+		return nil, err
+	}
+
+	return photo, nil
+}
+
+// the storage key of the thumbnail of the profile photo, the thumbnails are replaced on every upload
+func avatarKey(userID string, size int) string {
+	return fmt.Sprintf("avatars/%s/%d.jpg", userID, size)
+}
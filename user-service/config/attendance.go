@@ -0,0 +1,16 @@
+package config
+
+import (
+	"strconv"
+
+	"github.com/iqbaludinm/hr-microservice/user-service/utils"
+)
+
+var (
+	// AttendanceRequireLocation rejects the check-in and check-out without GPS coordinates when it is true.
+	AttendanceRequireLocation, _ = strconv.ParseBool(utils.GetEnv("ATTENDANCE_REQUIRE_LOCATION"))
+	// AttendancePhotoDir is the directory where the selfies of the check-in and check-out are saved.
+	AttendancePhotoDir = utils.GetEnv("ATTENDANCE_PHOTO_DIR")
+	// AttendancePhotoMaxSizeKB is the maximum size of the selfie in kilobytes.
+	AttendancePhotoMaxSizeKB, _ = strconv.Atoi(utils.GetEnv("ATTENDANCE_PHOTO_MAX_SIZE_KB"))
+)
@@ -0,0 +1,27 @@
+package config
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/iqbaludinm/hr-microservice/user-service/utils"
+)
+
+var (
+	// ContractExpiryAlertDays is the comma separated days before the end date of a PKWT the expiry alerts are sent,
+	// e.g. '30,14,7'.
+	ContractExpiryAlertDays = parseDays(utils.GetEnv("CONTRACT_EXPIRY_ALERT_DAYS"))
+)
+
+// parse the comma separated days, the invalid days are skipped
+func parseDays(value string) []int {
+	var days []int
+	for _, v := range strings.Split(value, ",") {
+		day, err := strconv.Atoi(strings.TrimSpace(v))
+		if err != nil || day <= 0 {
+			continue
+		}
+		days = append(days, day)
+	}
+	return days
+}
@@ -0,0 +1,18 @@
+package config
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/iqbaludinm/hr-microservice/user-service/utils"
+)
+
+var (
+	// DocumentMaxSizeMB is the maximum size of an uploaded employee document in megabytes.
+	DocumentMaxSizeMB, _ = strconv.Atoi(utils.GetEnv("DOCUMENT_MAX_SIZE_MB"))
+	// DocumentAllowedMimeTypes is the comma separated MIME types of the employee documents that can be uploaded,
+	// the type is detected from the content of the file.
+	DocumentAllowedMimeTypes = strings.Split(utils.GetEnv("DOCUMENT_ALLOWED_MIME_TYPES"), ",")
+	// DocumentExpiryReminderDays is how many days before the expiry date the owner of a document is reminded.
+	DocumentExpiryReminderDays, _ = strconv.Atoi(utils.GetEnv("DOCUMENT_EXPIRY_REMINDER_DAYS"))
+)
@@ -2,4 +2,41 @@ package config
 
 import "github.com/iqbaludinm/hr-microservice/user-service/utils"
 
-var EndpointPrefixUser = utils.GetEnv("ENDPOINT_PREFIX_USER")
+var (
+	EndpointPrefixUser               = utils.GetEnv("ENDPOINT_PREFIX_USER")
+	EndpointPrefixEmployee           = utils.GetEnv("ENDPOINT_PREFIX_EMPLOYEE")
+	EndpointPrefixDepartment         = utils.GetEnv("ENDPOINT_PREFIX_DEPARTMENT")
+	EndpointPrefixPosition           = utils.GetEnv("ENDPOINT_PREFIX_POSITION")
+	EndpointPrefixLeave              = utils.GetEnv("ENDPOINT_PREFIX_LEAVE")
+	EndpointPrefixLeaveType          = utils.GetEnv("ENDPOINT_PREFIX_LEAVE_TYPE")
+	EndpointPrefixHoliday            = utils.GetEnv("ENDPOINT_PREFIX_HOLIDAY")
+	EndpointPrefixShift              = utils.GetEnv("ENDPOINT_PREFIX_SHIFT")
+	EndpointPrefixAttendance         = utils.GetEnv("ENDPOINT_PREFIX_ATTENDANCE")
+	EndpointPrefixOfficeLocation     = utils.GetEnv("ENDPOINT_PREFIX_OFFICE_LOCATION")
+	EndpointPrefixShiftPattern       = utils.GetEnv("ENDPOINT_PREFIX_SHIFT_PATTERN")
+	EndpointPrefixRoster             = utils.GetEnv("ENDPOINT_PREFIX_ROSTER")
+	EndpointPrefixWorkCalendar       = utils.GetEnv("ENDPOINT_PREFIX_WORK_CALENDAR")
+	EndpointPrefixPayroll            = utils.GetEnv("ENDPOINT_PREFIX_PAYROLL")
+	EndpointPrefixPayslip            = utils.GetEnv("ENDPOINT_PREFIX_PAYSLIP")
+	EndpointPrefixHandover           = utils.GetEnv("ENDPOINT_PREFIX_HANDOVER")
+	EndpointPrefixAsset              = utils.GetEnv("ENDPOINT_PREFIX_ASSET")
+	EndpointPrefixOnboardingTemplate = utils.GetEnv("ENDPOINT_PREFIX_ONBOARDING_TEMPLATE")
+	EndpointPrefixOnboarding         = utils.GetEnv("ENDPOINT_PREFIX_ONBOARDING")
+	EndpointPrefixOffboarding        = utils.GetEnv("ENDPOINT_PREFIX_OFFBOARDING")
+	EndpointPrefixEmployeeDocument   = utils.GetEnv("ENDPOINT_PREFIX_EMPLOYEE_DOCUMENT")
+	EndpointPrefixEmploymentContract = utils.GetEnv("ENDPOINT_PREFIX_EMPLOYMENT_CONTRACT")
+	EndpointPrefixReviewCycle        = utils.GetEnv("ENDPOINT_PREFIX_REVIEW_CYCLE")
+	EndpointPrefixPerformanceReview  = utils.GetEnv("ENDPOINT_PREFIX_PERFORMANCE_REVIEW")
+	EndpointPrefixJobRequisition     = utils.GetEnv("ENDPOINT_PREFIX_JOB_REQUISITION")
+	EndpointPrefixCareer             = utils.GetEnv("ENDPOINT_PREFIX_CAREER")
+	EndpointPrefixRecruitmentStage   = utils.GetEnv("ENDPOINT_PREFIX_RECRUITMENT_STAGE")
+	EndpointPrefixJobApplication     = utils.GetEnv("ENDPOINT_PREFIX_JOB_APPLICATION")
+	EndpointPrefixTraining           = utils.GetEnv("ENDPOINT_PREFIX_TRAINING")
+	EndpointPrefixTrainingEnrollment = utils.GetEnv("ENDPOINT_PREFIX_TRAINING_ENROLLMENT")
+	EndpointPrefixExpenseCategory    = utils.GetEnv("ENDPOINT_PREFIX_EXPENSE_CATEGORY")
+	EndpointPrefixExpenseClaim       = utils.GetEnv("ENDPOINT_PREFIX_EXPENSE_CLAIM")
+	EndpointPrefixOvertime           = utils.GetEnv("ENDPOINT_PREFIX_OVERTIME")
+	EndpointPrefixApprovalWorkflow   = utils.GetEnv("ENDPOINT_PREFIX_APPROVAL_WORKFLOW")
+	EndpointPrefixApproval           = utils.GetEnv("ENDPOINT_PREFIX_APPROVAL")
+	EndpointPrefixApprovalDelegation = utils.GetEnv("ENDPOINT_PREFIX_APPROVAL_DELEGATION")
+)
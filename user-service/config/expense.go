@@ -0,0 +1,19 @@
+package config
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/iqbaludinm/hr-microservice/user-service/utils"
+)
+
+var (
+	// ExpenseFinanceDepartmentCode is the code of the department whose employees approve the expense claims after
+	// the manager of the employee.
+	ExpenseFinanceDepartmentCode = utils.GetEnv("EXPENSE_FINANCE_DEPARTMENT_CODE")
+	// ExpenseReceiptMaxSizeMB is the maximum size of the receipt of an expense claim item in megabytes.
+	ExpenseReceiptMaxSizeMB, _ = strconv.Atoi(utils.GetEnv("EXPENSE_RECEIPT_MAX_SIZE_MB"))
+	// ExpenseReceiptAllowedMimeTypes is the comma separated MIME types of the receipts that can be uploaded, the type
+	// is detected from the content of the file.
+	ExpenseReceiptAllowedMimeTypes = strings.Split(utils.GetEnv("EXPENSE_RECEIPT_ALLOWED_MIME_TYPES"), ",")
+)
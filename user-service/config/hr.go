@@ -0,0 +1,23 @@
+package config
+
+import (
+	"strings"
+
+	"github.com/iqbaludinm/hr-microservice/user-service/utils"
+)
+
+var (
+	// HRDepartmentCodes is the comma separated codes of the departments whose employees manage the data of the other
+	// employees, e.g. 'HR,PAY' for the HR and the payroll department. Nobody is HR when it is not filled.
+	HRDepartmentCodes = strings.Split(utils.GetEnv("HR_DEPARTMENT_CODES"), ",")
+)
+
+// IsHRDepartment checks whether the department with the code is one of the HR departments.
+func IsHRDepartment(code string) bool {
+	for _, hrCode := range HRDepartmentCodes {
+		if hrCode = strings.TrimSpace(hrCode); hrCode != "" && hrCode == code {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,12 @@
+package config
+
+import (
+	"strconv"
+
+	"github.com/iqbaludinm/hr-microservice/user-service/utils"
+)
+
+var (
+	// OnboardingReminderDays is how many days before the due date the assignee of an onboarding task is reminded.
+	OnboardingReminderDays, _ = strconv.Atoi(utils.GetEnv("ONBOARDING_REMINDER_DAYS"))
+)
@@ -0,0 +1,15 @@
+package config
+
+import (
+	"strconv"
+
+	"github.com/iqbaludinm/hr-microservice/user-service/utils"
+)
+
+var (
+	// OvertimeMaxHoursPerDay is the maximum overtime hours that can be requested on a working day (PP 35/2021).
+	OvertimeMaxHoursPerDay, _ = strconv.ParseFloat(utils.GetEnv("OVERTIME_MAX_HOURS_PER_DAY"), 64)
+	// OvertimeMaxHoursPerWeek is the maximum overtime hours of the pending and approved requests in a week
+	// (Monday to Sunday).
+	OvertimeMaxHoursPerWeek, _ = strconv.ParseFloat(utils.GetEnv("OVERTIME_MAX_HOURS_PER_WEEK"), 64)
+)
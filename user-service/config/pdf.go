@@ -0,0 +1,19 @@
+package config
+
+import (
+	"strconv"
+
+	"github.com/iqbaludinm/hr-microservice/user-service/utils"
+)
+
+var (
+	// PDFRendererDriver is the driver that converts the html templates to pdf, 'gotenberg' or 'stub'. Gotenberg is
+	// used by default, the stub must be set explicitly.
+	PDFRendererDriver = utils.GetEnv("PDF_RENDERER_DRIVER")
+	// GotenbergEndpoint is the url of the Chromium html route of the Gotenberg service.
+	GotenbergEndpoint = utils.GetEnv("GOTENBERG_ENDPOINT")
+	// GotenbergTimeoutSeconds is the timeout of every request to Gotenberg.
+	GotenbergTimeoutSeconds, _ = strconv.Atoi(utils.GetEnv("GOTENBERG_TIMEOUT_SECONDS"))
+	// GotenbergRetries is the number of retries when Gotenberg is unreachable or returns a server error.
+	GotenbergRetries, _ = strconv.Atoi(utils.GetEnv("GOTENBERG_RETRIES"))
+)
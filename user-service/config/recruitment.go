@@ -0,0 +1,16 @@
+package config
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/iqbaludinm/hr-microservice/user-service/utils"
+)
+
+var (
+	// RecruitmentCVMaxSizeMB is the maximum size of the CV uploaded by a candidate in megabytes.
+	RecruitmentCVMaxSizeMB, _ = strconv.Atoi(utils.GetEnv("RECRUITMENT_CV_MAX_SIZE_MB"))
+	// RecruitmentCVAllowedMimeTypes is the comma separated MIME types of the CV that can be uploaded, the type is
+	// detected from the content of the file.
+	RecruitmentCVAllowedMimeTypes = strings.Split(utils.GetEnv("RECRUITMENT_CV_ALLOWED_MIME_TYPES"), ",")
+)
@@ -0,0 +1,15 @@
+package config
+
+import (
+	"strconv"
+
+	"github.com/iqbaludinm/hr-microservice/user-service/utils"
+)
+
+var (
+	// RosterCalendarURL is the public URL of the roster calendar feed, the calendar token is appended to it.
+	RosterCalendarURL = utils.GetEnv("ROSTER_CALENDAR_URL")
+	// RosterCalendarPastDays and RosterCalendarFutureDays are the range of the shifts in the calendar feed.
+	RosterCalendarPastDays, _   = strconv.Atoi(utils.GetEnv("ROSTER_CALENDAR_PAST_DAYS"))
+	RosterCalendarFutureDays, _ = strconv.Atoi(utils.GetEnv("ROSTER_CALENDAR_FUTURE_DAYS"))
+)
@@ -0,0 +1,12 @@
+package config
+
+import (
+	"strconv"
+
+	"github.com/iqbaludinm/hr-microservice/user-service/utils"
+)
+
+var (
+	// SchedulerIntervalMinutes is the interval of the background jobs (e.g. applying the effective-dated changes).
+	SchedulerIntervalMinutes, _ = strconv.Atoi(utils.GetEnv("SCHEDULER_INTERVAL_MINUTES"))
+)
@@ -0,0 +1,11 @@
+package config
+
+import "github.com/iqbaludinm/hr-microservice/user-service/utils"
+
+var (
+	// StorageDriver is the driver of the object storage where the generated documents are saved, only 'local' is
+	// supported for now.
+	StorageDriver = utils.GetEnv("STORAGE_DRIVER")
+	// StorageLocalDir is the root directory of the objects of the 'local' storage driver.
+	StorageLocalDir = utils.GetEnv("STORAGE_LOCAL_DIR")
+)
@@ -0,0 +1,269 @@
+package controller
+
+import (
+	"strconv"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/gofiber/fiber/v2"
+	"github.com/iqbaludinm/hr-microservice/user-service/config"
+	"github.com/iqbaludinm/hr-microservice/user-service/exception"
+	"github.com/iqbaludinm/hr-microservice/user-service/helper"
+	"github.com/iqbaludinm/hr-microservice/user-service/middleware"
+	"github.com/iqbaludinm/hr-microservice/user-service/model/web"
+	"github.com/iqbaludinm/hr-microservice/user-service/service"
+)
+
+type ApprovalController interface {
+	// This function is used to define the route of the endpoints in this controller to the main application
+	Route(app *fiber.App)
+	Create(ctx *fiber.Ctx) error
+	Approve(ctx *fiber.Ctx) error
+	Reject(ctx *fiber.Ctx) error
+	Cancel(ctx *fiber.Ctx) error
+	FindAll(ctx *fiber.Ctx) error
+	FindMy(ctx *fiber.Ctx) error
+	FindPendingApprovals(ctx *fiber.Ctx) error
+	FindByID(ctx *fiber.Ctx) error
+}
+
+type approvalController struct {
+	validate        *validator.Validate
+	approvalService service.ApprovalService
+}
+
+func NewApprovalController(validate *validator.Validate, approvalService service.ApprovalService) ApprovalController {
+	return &approvalController{
+		validate:        validate,
+		approvalService: approvalService,
+	}
+}
+
+func (controller *approvalController) Route(app *fiber.App) {
+	api := app.Group(config.EndpointPrefixApproval, middleware.IsAuthenticated)
+
+	api.Post("/", controller.Create)
+	api.Get("/", controller.FindAll)
+	api.Get("/me", controller.FindMy)
+	api.Get("/pending", controller.FindPendingApprovals)
+	api.Get("/:approval_id", controller.FindByID)
+	api.Put("/:approval_id/approve", controller.Approve)
+	api.Put("/:approval_id/reject", controller.Reject)
+	api.Put("/:approval_id/cancel", controller.Cancel)
+}
+
+func (controller *approvalController) Create(ctx *fiber.Ctx) error {
+	// parse request body
+	var request web.CreateApprovalRequest
+	err := ctx.BodyParser(&request)
+	if err != nil {
+		return exception.ErrValidateBadRequest(err.Error(), request)
+	}
+	// validate the values of the request body
+	err = controller.validate.Struct(request)
+	if err != nil {
+		return exception.ErrValidateBadRequest(err.Error(), request)
+	}
+
+	// the approval is requested by the logged in user
+	userID, _ := helper.ParseJwt(ctx.Cookies("token"))
+
+	// create approval request
+	approvalResponse, err := controller.approvalService.Create(ctx.Context(), userID, request)
+	if err != nil {
+		return err
+	}
+
+	return ctx.Status(fiber.StatusCreated).JSON(web.WebResponse{
+		Code:    fiber.StatusCreated,
+		Status:  true,
+		Message: "success",
+		Data:    approvalResponse,
+	})
+}
+
+func (controller *approvalController) Approve(ctx *fiber.Ctx) error {
+	// parse request body
+	var request web.DecideApprovalRequest
+	if err := controller.parseDecision(ctx, &request); err != nil {
+		return err
+	}
+
+	// parse path params
+	approvalID := ctx.Params("approval_id")
+	userID, _ := helper.ParseJwt(ctx.Cookies("token"))
+
+	// approve the pending step of the approval
+	approvalResponse, err := controller.approvalService.Approve(ctx.Context(), userID, approvalID, request)
+	if err != nil {
+		return err
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(web.WebResponse{
+		Code:    fiber.StatusOK,
+		Status:  true,
+		Message: "success",
+		Data:    approvalResponse,
+	})
+}
+
+func (controller *approvalController) Reject(ctx *fiber.Ctx) error {
+	// parse request body
+	var request web.DecideApprovalRequest
+	if err := controller.parseDecision(ctx, &request); err != nil {
+		return err
+	}
+
+	// parse path params
+	approvalID := ctx.Params("approval_id")
+	userID, _ := helper.ParseJwt(ctx.Cookies("token"))
+
+	// reject the pending step of the approval
+	approvalResponse, err := controller.approvalService.Reject(ctx.Context(), userID, approvalID, request)
+	if err != nil {
+		return err
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(web.WebResponse{
+		Code:    fiber.StatusOK,
+		Status:  true,
+		Message: "success",
+		Data:    approvalResponse,
+	})
+}
+
+func (controller *approvalController) Cancel(ctx *fiber.Ctx) error {
+	// parse path params
+	approvalID := ctx.Params("approval_id")
+	userID, _ := helper.ParseJwt(ctx.Cookies("token"))
+
+	// cancel approval request
+	approvalResponse, err := controller.approvalService.Cancel(ctx.Context(), userID, approvalID)
+	if err != nil {
+		return err
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(web.WebResponse{
+		Code:    fiber.StatusOK,
+		Status:  true,
+		Message: "success",
+		Data:    approvalResponse,
+	})
+}
+
+func (controller *approvalController) FindAll(ctx *fiber.Ctx) error {
+	// parse query params
+	filter, err := controller.parseFilter(ctx)
+	if err != nil {
+		return err
+	}
+
+	approvalResponses, totalData, err := controller.approvalService.FindAll(ctx.Context(), filter)
+	if err != nil {
+		return err
+	}
+
+	return controller.approvalListResponse(ctx, filter, approvalResponses, totalData)
+}
+
+func (controller *approvalController) FindMy(ctx *fiber.Ctx) error {
+	// parse query params
+	filter, err := controller.parseFilter(ctx)
+	if err != nil {
+		return err
+	}
+	userID, _ := helper.ParseJwt(ctx.Cookies("token"))
+
+	approvalResponses, totalData, err := controller.approvalService.FindMy(ctx.Context(), userID, filter)
+	if err != nil {
+		return err
+	}
+
+	return controller.approvalListResponse(ctx, filter, approvalResponses, totalData)
+}
+
+func (controller *approvalController) FindPendingApprovals(ctx *fiber.Ctx) error {
+	// parse query params
+	filter, err := controller.parseFilter(ctx)
+	if err != nil {
+		return err
+	}
+	userID, _ := helper.ParseJwt(ctx.Cookies("token"))
+
+	approvalResponses, totalData, err := controller.approvalService.FindPending(ctx.Context(), userID, filter)
+	if err != nil {
+		return err
+	}
+
+	return controller.approvalListResponse(ctx, filter, approvalResponses, totalData)
+}
+
+func (controller *approvalController) FindByID(ctx *fiber.Ctx) error {
+	// parse path params
+	approvalID := ctx.Params("approval_id")
+
+	approval, err := controller.approvalService.FindById(ctx.Context(), approvalID)
+	if err != nil {
+		return err
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(web.WebResponse{
+		Code:    fiber.StatusOK,
+		Status:  true,
+		Message: "success",
+		Data:    approval,
+	})
+}
+
+// parse and validate the request body of approving or rejecting an approval
+func (controller *approvalController) parseDecision(ctx *fiber.Ctx, request *web.DecideApprovalRequest) error {
+	// the note is optional, so an empty body is allowed
+	if len(ctx.Body()) > 0 {
+		if err := ctx.BodyParser(request); err != nil {
+			return exception.ErrValidateBadRequest(err.Error(), request)
+		}
+	}
+	if err := controller.validate.Struct(request); err != nil {
+		return exception.ErrValidateBadRequest(err.Error(), request)
+	}
+
+	return nil
+}
+
+// parse and validate the query params of the approval list
+func (controller *approvalController) parseFilter(ctx *fiber.Ctx) (web.ApprovalQueryFilter, error) {
+	var filter web.ApprovalQueryFilter
+	if err := ctx.QueryParser(&filter); err != nil {
+		return filter, exception.ErrValidateBadRequest(err.Error(), filter)
+	}
+	if err := controller.validate.Struct(filter); err != nil {
+		return filter, exception.ErrValidateBadRequest(err.Error(), filter)
+	}
+
+	return filter, nil
+}
+
+// write the approval list, with the pagination when the page or limit is filled
+func (controller *approvalController) approvalListResponse(ctx *fiber.Ctx, filter web.ApprovalQueryFilter, approvalResponses []web.ApprovalResponse, totalData int) error {
+	if filter.Page != "" || filter.Limit != "" {
+		pageInt, _ := strconv.Atoi(filter.Page)
+		if filter.Page == "" {
+			pageInt = 1
+		}
+		return ctx.Status(fiber.StatusOK).JSON(web.WebResponsePagination{
+			Code:      fiber.StatusOK,
+			Status:    true,
+			Page:      pageInt,
+			Count:     len(approvalResponses),
+			TotalData: totalData,
+			Message:   "success",
+			Data:      approvalResponses,
+		})
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(web.WebResponse{
+		Code:    fiber.StatusOK,
+		Status:  true,
+		Message: "success",
+		Data:    approvalResponses,
+	})
+}
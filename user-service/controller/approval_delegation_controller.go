@@ -0,0 +1,182 @@
+package controller
+
+import (
+	"strconv"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/gofiber/fiber/v2"
+	"github.com/iqbaludinm/hr-microservice/user-service/config"
+	"github.com/iqbaludinm/hr-microservice/user-service/exception"
+	"github.com/iqbaludinm/hr-microservice/user-service/helper"
+	"github.com/iqbaludinm/hr-microservice/user-service/middleware"
+	"github.com/iqbaludinm/hr-microservice/user-service/model/web"
+	"github.com/iqbaludinm/hr-microservice/user-service/service"
+)
+
+type ApprovalDelegationController interface {
+	// This function is used to define the route of the endpoints in this controller to the main application
+	Route(app *fiber.App)
+	Create(ctx *fiber.Ctx) error
+	Cancel(ctx *fiber.Ctx) error
+	FindAll(ctx *fiber.Ctx) error
+	FindMy(ctx *fiber.Ctx) error
+	FindByID(ctx *fiber.Ctx) error
+}
+
+type approvalDelegationController struct {
+	validate        *validator.Validate
+	approvalService service.ApprovalService
+}
+
+func NewApprovalDelegationController(validate *validator.Validate, approvalService service.ApprovalService) ApprovalDelegationController {
+	return &approvalDelegationController{
+		validate:        validate,
+		approvalService: approvalService,
+	}
+}
+
+func (controller *approvalDelegationController) Route(app *fiber.App) {
+	api := app.Group(config.EndpointPrefixApprovalDelegation, middleware.IsAuthenticated)
+
+	api.Post("/", controller.Create)
+	api.Get("/", controller.FindAll)
+	api.Get("/me", controller.FindMy)
+	api.Get("/:delegation_id", controller.FindByID)
+	api.Put("/:delegation_id/cancel", controller.Cancel)
+}
+
+func (controller *approvalDelegationController) Create(ctx *fiber.Ctx) error {
+	// parse request body
+	var request web.CreateApprovalDelegationRequest
+	err := ctx.BodyParser(&request)
+	if err != nil {
+		return exception.ErrValidateBadRequest(err.Error(), request)
+	}
+	// validate the values of the request body
+	err = controller.validate.Struct(request)
+	if err != nil {
+		return exception.ErrValidateBadRequest(err.Error(), request)
+	}
+
+	// the approvals of the logged in user are delegated
+	userID, _ := helper.ParseJwt(ctx.Cookies("token"))
+
+	// create approval delegation
+	delegationResponse, err := controller.approvalService.CreateDelegation(ctx.Context(), userID, request)
+	if err != nil {
+		return err
+	}
+
+	return ctx.Status(fiber.StatusCreated).JSON(web.WebResponse{
+		Code:    fiber.StatusCreated,
+		Status:  true,
+		Message: "success",
+		Data:    delegationResponse,
+	})
+}
+
+func (controller *approvalDelegationController) Cancel(ctx *fiber.Ctx) error {
+	// parse path params
+	delegationID := ctx.Params("delegation_id")
+	userID, _ := helper.ParseJwt(ctx.Cookies("token"))
+
+	// cancel approval delegation
+	delegationResponse, err := controller.approvalService.CancelDelegation(ctx.Context(), userID, delegationID)
+	if err != nil {
+		return err
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(web.WebResponse{
+		Code:    fiber.StatusOK,
+		Status:  true,
+		Message: "success",
+		Data:    delegationResponse,
+	})
+}
+
+func (controller *approvalDelegationController) FindAll(ctx *fiber.Ctx) error {
+	// parse query params
+	filter, err := controller.parseFilter(ctx)
+	if err != nil {
+		return err
+	}
+
+	delegationResponses, totalData, err := controller.approvalService.FindAllDelegation(ctx.Context(), filter)
+	if err != nil {
+		return err
+	}
+
+	return controller.delegationListResponse(ctx, filter, delegationResponses, totalData)
+}
+
+func (controller *approvalDelegationController) FindMy(ctx *fiber.Ctx) error {
+	// parse query params
+	filter, err := controller.parseFilter(ctx)
+	if err != nil {
+		return err
+	}
+	userID, _ := helper.ParseJwt(ctx.Cookies("token"))
+
+	delegationResponses, totalData, err := controller.approvalService.FindMyDelegations(ctx.Context(), userID, filter)
+	if err != nil {
+		return err
+	}
+
+	return controller.delegationListResponse(ctx, filter, delegationResponses, totalData)
+}
+
+func (controller *approvalDelegationController) FindByID(ctx *fiber.Ctx) error {
+	// parse path params
+	delegationID := ctx.Params("delegation_id")
+
+	delegation, err := controller.approvalService.FindDelegationById(ctx.Context(), delegationID)
+	if err != nil {
+		return err
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(web.WebResponse{
+		Code:    fiber.StatusOK,
+		Status:  true,
+		Message: "success",
+		Data:    delegation,
+	})
+}
+
+// parse and validate the query params of the approval delegation list
+func (controller *approvalDelegationController) parseFilter(ctx *fiber.Ctx) (web.ApprovalDelegationQueryFilter, error) {
+	var filter web.ApprovalDelegationQueryFilter
+	if err := ctx.QueryParser(&filter); err != nil {
+		return filter, exception.ErrValidateBadRequest(err.Error(), filter)
+	}
+	if err := controller.validate.Struct(filter); err != nil {
+		return filter, exception.ErrValidateBadRequest(err.Error(), filter)
+	}
+
+	return filter, nil
+}
+
+// write the approval delegation list, with the pagination when the page or limit is filled
+func (controller *approvalDelegationController) delegationListResponse(ctx *fiber.Ctx, filter web.ApprovalDelegationQueryFilter, delegationResponses []web.ApprovalDelegationResponse, totalData int) error {
+	if filter.Page != "" || filter.Limit != "" {
+		pageInt, _ := strconv.Atoi(filter.Page)
+		if filter.Page == "" {
+			pageInt = 1
+		}
+		return ctx.Status(fiber.StatusOK).JSON(web.WebResponsePagination{
+			Code:      fiber.StatusOK,
+			Status:    true,
+			Page:      pageInt,
+			Count:     len(delegationResponses),
+			TotalData: totalData,
+			Message:   "success",
+			Data:      delegationResponses,
+		})
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(web.WebResponse{
+		Code:    fiber.StatusOK,
+		Status:  true,
+		Message: "success",
+		Data:    delegationResponses,
+	})
+}
@@ -0,0 +1,169 @@
+package controller
+
+import (
+	"strconv"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/gofiber/fiber/v2"
+	"github.com/iqbaludinm/hr-microservice/user-service/config"
+	"github.com/iqbaludinm/hr-microservice/user-service/exception"
+	"github.com/iqbaludinm/hr-microservice/user-service/middleware"
+	"github.com/iqbaludinm/hr-microservice/user-service/model/web"
+	"github.com/iqbaludinm/hr-microservice/user-service/service"
+)
+
+type ApprovalWorkflowController interface {
+	// This function is used to define the route of the endpoints in this controller to the main application
+	Route(app *fiber.App)
+	CreateWorkflow(ctx *fiber.Ctx) error
+	UpdateWorkflow(ctx *fiber.Ctx) error
+	DeleteWorkflow(ctx *fiber.Ctx) error
+	FindAllWorkflow(ctx *fiber.Ctx) error
+	FindByID(ctx *fiber.Ctx) error
+}
+
+type approvalWorkflowController struct {
+	validate        *validator.Validate
+	approvalService service.ApprovalService
+}
+
+func NewApprovalWorkflowController(validate *validator.Validate, approvalService service.ApprovalService) ApprovalWorkflowController {
+	return &approvalWorkflowController{
+		validate:        validate,
+		approvalService: approvalService,
+	}
+}
+
+func (controller *approvalWorkflowController) Route(app *fiber.App) {
+	api := app.Group(config.EndpointPrefixApprovalWorkflow, middleware.IsAuthenticated)
+
+	api.Get("/", controller.FindAllWorkflow)
+	api.Post("/", controller.CreateWorkflow)
+	api.Get("/:workflow_id", controller.FindByID)
+	api.Put("/:workflow_id", controller.UpdateWorkflow)
+	api.Delete("/:workflow_id", controller.DeleteWorkflow)
+}
+
+func (controller *approvalWorkflowController) CreateWorkflow(ctx *fiber.Ctx) error {
+	// parse request body
+	var request web.ApprovalWorkflowRequest
+	if err := ctx.BodyParser(&request); err != nil {
+		return exception.ErrValidateBadRequest(err.Error(), request)
+	}
+	// validate the values of request body
+	if err := controller.validate.Struct(request); err != nil {
+		return exception.ErrValidateBadRequest(err.Error(), request)
+	}
+
+	workflowResponse, err := controller.approvalService.CreateWorkflow(ctx.Context(), request)
+	if err != nil {
+		return err
+	}
+
+	return ctx.Status(fiber.StatusCreated).JSON(web.WebResponse{
+		Code:    fiber.StatusCreated,
+		Status:  true,
+		Message: "success",
+		Data:    workflowResponse,
+	})
+}
+
+func (controller *approvalWorkflowController) UpdateWorkflow(ctx *fiber.Ctx) error {
+	// parse request body
+	var request web.ApprovalWorkflowRequest
+	if err := ctx.BodyParser(&request); err != nil {
+		return exception.ErrValidateBadRequest(err.Error(), request)
+	}
+	// validate the values of request body
+	if err := controller.validate.Struct(request); err != nil {
+		return exception.ErrValidateBadRequest(err.Error(), request)
+	}
+
+	// parse path params
+	workflowID := ctx.Params("workflow_id")
+
+	workflowResponse, err := controller.approvalService.UpdateWorkflow(ctx.Context(), workflowID, request)
+	if err != nil {
+		return err
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(web.WebResponse{
+		Code:    fiber.StatusOK,
+		Status:  true,
+		Message: "success",
+		Data:    workflowResponse,
+	})
+}
+
+func (controller *approvalWorkflowController) DeleteWorkflow(ctx *fiber.Ctx) error {
+	// parse path params
+	workflowID := ctx.Params("workflow_id")
+
+	err := controller.approvalService.DeleteWorkflow(ctx.Context(), workflowID)
+	if err != nil {
+		return err
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(web.WebResponse{
+		Code:    fiber.StatusOK,
+		Status:  true,
+		Message: "success",
+	})
+}
+
+func (controller *approvalWorkflowController) FindAllWorkflow(ctx *fiber.Ctx) error {
+	// parse query params
+	var filter web.ApprovalWorkflowQueryFilter
+	if err := ctx.QueryParser(&filter); err != nil {
+		return exception.ErrValidateBadRequest(err.Error(), filter)
+	}
+	// validate the values of the query params
+	if err := controller.validate.Struct(filter); err != nil {
+		return exception.ErrValidateBadRequest(err.Error(), filter)
+	}
+
+	workflowResponses, totalData, err := controller.approvalService.FindAllWorkflow(ctx.Context(), filter)
+	if err != nil {
+		return err
+	}
+
+	if filter.Page != "" || filter.Limit != "" {
+		pageInt, _ := strconv.Atoi(filter.Page)
+		if filter.Page == "" {
+			pageInt = 1
+		}
+		return ctx.Status(fiber.StatusOK).JSON(web.WebResponsePagination{
+			Code:      fiber.StatusOK,
+			Status:    true,
+			Page:      pageInt,
+			Count:     len(workflowResponses),
+			TotalData: totalData,
+			Message:   "success",
+			Data:      workflowResponses,
+		})
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(web.WebResponse{
+		Code:    fiber.StatusOK,
+		Status:  true,
+		Message: "success",
+		Data:    workflowResponses,
+	})
+}
+
+func (controller *approvalWorkflowController) FindByID(ctx *fiber.Ctx) error {
+	// parse path params
+	workflowID := ctx.Params("workflow_id")
+
+	workflowResponse, err := controller.approvalService.FindWorkflowById(ctx.Context(), workflowID)
+	if err != nil {
+		return err
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(web.WebResponse{
+		Code:    fiber.StatusOK,
+		Status:  true,
+		Message: "success",
+		Data:    workflowResponse,
+	})
+}
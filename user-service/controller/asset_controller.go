@@ -0,0 +1,306 @@
+package controller
+
+import (
+	"strconv"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/gofiber/fiber/v2"
+	"github.com/iqbaludinm/hr-microservice/user-service/config"
+	"github.com/iqbaludinm/hr-microservice/user-service/exception"
+	"github.com/iqbaludinm/hr-microservice/user-service/helper"
+	"github.com/iqbaludinm/hr-microservice/user-service/middleware"
+	"github.com/iqbaludinm/hr-microservice/user-service/model/web"
+	"github.com/iqbaludinm/hr-microservice/user-service/service"
+)
+
+type AssetController interface {
+	// This function is used to define the route of the endpoints in this controller to the main application
+	Route(app *fiber.App)
+	CreateAsset(ctx *fiber.Ctx) error
+	UpdateAsset(ctx *fiber.Ctx) error
+	DeleteAsset(ctx *fiber.Ctx) error
+	AssignAsset(ctx *fiber.Ctx) error
+	ReturnAsset(ctx *fiber.Ctx) error
+	CreateHandover(ctx *fiber.Ctx) error
+	FindAllAsset(ctx *fiber.Ctx) error
+	FindMyAsset(ctx *fiber.Ctx) error
+	FindByID(ctx *fiber.Ctx) error
+	FindAssignments(ctx *fiber.Ctx) error
+}
+
+type assetController struct {
+	validate     *validator.Validate
+	assetService service.AssetService
+}
+
+func NewAssetController(validate *validator.Validate, assetService service.AssetService) AssetController {
+	return &assetController{
+		validate:     validate,
+		assetService: assetService,
+	}
+}
+
+func (controller *assetController) Route(app *fiber.App) {
+	api := app.Group(config.EndpointPrefixAsset, middleware.IsAuthenticated)
+
+	api.Post("/", controller.CreateAsset)
+	api.Get("/", controller.FindAllAsset)
+	api.Get("/me", controller.FindMyAsset)
+	api.Get("/:asset_id", controller.FindByID)
+	api.Put("/:asset_id", controller.UpdateAsset)
+	api.Delete("/:asset_id", controller.DeleteAsset)
+	api.Post("/:asset_id/assign", controller.AssignAsset)
+	api.Post("/:asset_id/return", controller.ReturnAsset)
+	api.Get("/:asset_id/assignments", controller.FindAssignments)
+	api.Post("/:asset_id/assignments/:assignment_id/handover", controller.CreateHandover)
+}
+
+func (controller *assetController) CreateAsset(ctx *fiber.Ctx) error {
+	// parse request body
+	var request web.AssetRequest
+	if err := ctx.BodyParser(&request); err != nil {
+		return exception.ErrValidateBadRequest(err.Error(), request)
+	}
+	// validate the values of request body
+	if err := controller.validate.Struct(request); err != nil {
+		return exception.ErrValidateBadRequest(err.Error(), request)
+	}
+
+	// create asset
+	assetResponse, err := controller.assetService.CreateAsset(ctx.Context(), request)
+	if err != nil {
+		return err
+	}
+
+	return ctx.Status(fiber.StatusCreated).JSON(web.WebResponse{
+		Code:    fiber.StatusCreated,
+		Status:  true,
+		Message: "success",
+		Data:    assetResponse,
+	})
+}
+
+func (controller *assetController) UpdateAsset(ctx *fiber.Ctx) error {
+	// parse request body
+	var request web.AssetRequest
+	if err := ctx.BodyParser(&request); err != nil {
+		return exception.ErrValidateBadRequest(err.Error(), request)
+	}
+	// validate the values of request body
+	if err := controller.validate.Struct(request); err != nil {
+		return exception.ErrValidateBadRequest(err.Error(), request)
+	}
+
+	// parse path params
+	assetID := ctx.Params("asset_id")
+
+	// update asset
+	assetResponse, err := controller.assetService.UpdateAsset(ctx.Context(), assetID, request)
+	if err != nil {
+		return err
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(web.WebResponse{
+		Code:    fiber.StatusOK,
+		Status:  true,
+		Message: "success",
+		Data:    assetResponse,
+	})
+}
+
+func (controller *assetController) DeleteAsset(ctx *fiber.Ctx) error {
+	// parse path params
+	assetID := ctx.Params("asset_id")
+
+	// delete asset
+	err := controller.assetService.Delete(ctx.Context(), assetID)
+	if err != nil {
+		return err
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(web.WebResponse{
+		Code:    fiber.StatusOK,
+		Status:  true,
+		Message: "success",
+	})
+}
+
+func (controller *assetController) AssignAsset(ctx *fiber.Ctx) error {
+	// parse request body
+	var request web.AssignAssetRequest
+	if err := ctx.BodyParser(&request); err != nil {
+		return exception.ErrValidateBadRequest(err.Error(), request)
+	}
+	// validate the values of request body
+	if err := controller.validate.Struct(request); err != nil {
+		return exception.ErrValidateBadRequest(err.Error(), request)
+	}
+
+	// parse path params
+	assetID := ctx.Params("asset_id")
+	// the asset is assigned by the logged in user
+	userID, _ := helper.ParseJwt(ctx.Cookies("token"))
+
+	// assign asset to the employee
+	assignmentResponse, err := controller.assetService.Assign(ctx.Context(), userID, assetID, request)
+	if err != nil {
+		return err
+	}
+
+	return ctx.Status(fiber.StatusCreated).JSON(web.WebResponse{
+		Code:    fiber.StatusCreated,
+		Status:  true,
+		Message: "success",
+		Data:    assignmentResponse,
+	})
+}
+
+func (controller *assetController) ReturnAsset(ctx *fiber.Ctx) error {
+	// parse request body
+	var request web.ReturnAssetRequest
+	if err := ctx.BodyParser(&request); err != nil {
+		return exception.ErrValidateBadRequest(err.Error(), request)
+	}
+	// validate the values of request body
+	if err := controller.validate.Struct(request); err != nil {
+		return exception.ErrValidateBadRequest(err.Error(), request)
+	}
+
+	// parse path params
+	assetID := ctx.Params("asset_id")
+	// the asset is received by the logged in user
+	userID, _ := helper.ParseJwt(ctx.Cookies("token"))
+
+	// return asset from the employee
+	assignmentResponse, err := controller.assetService.Return(ctx.Context(), userID, assetID, request)
+	if err != nil {
+		return err
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(web.WebResponse{
+		Code:    fiber.StatusOK,
+		Status:  true,
+		Message: "success",
+		Data:    assignmentResponse,
+	})
+}
+
+func (controller *assetController) CreateHandover(ctx *fiber.Ctx) error {
+	// parse request body
+	var request web.AssetHandoverRequest
+	if err := ctx.BodyParser(&request); err != nil {
+		return exception.ErrValidateBadRequest(err.Error(), request)
+	}
+	// validate the values of request body
+	if err := controller.validate.Struct(request); err != nil {
+		return exception.ErrValidateBadRequest(err.Error(), request)
+	}
+
+	// parse path params
+	assetID := ctx.Params("asset_id")
+	assignmentID := ctx.Params("assignment_id")
+	userID, _ := helper.ParseJwt(ctx.Cookies("token"))
+
+	// create the handover document of the assignment
+	handoverResponse, err := controller.assetService.CreateHandover(ctx.Context(), userID, assetID, assignmentID, request)
+	if err != nil {
+		return err
+	}
+
+	return ctx.Status(fiber.StatusCreated).JSON(web.WebResponse{
+		Code:    fiber.StatusCreated,
+		Status:  true,
+		Message: "success",
+		Data:    handoverResponse,
+	})
+}
+
+func (controller *assetController) FindAllAsset(ctx *fiber.Ctx) error {
+	// parse query params
+	var filter web.AssetQueryFilter
+	if err := ctx.QueryParser(&filter); err != nil {
+		return exception.ErrValidateBadRequest(err.Error(), filter)
+	}
+	// validate the values of the query params
+	if err := controller.validate.Struct(filter); err != nil {
+		return exception.ErrValidateBadRequest(err.Error(), filter)
+	}
+
+	assetResponses, totalData, err := controller.assetService.FindAllAsset(ctx.Context(), filter)
+	if err != nil {
+		return err
+	}
+
+	if filter.Page != "" || filter.Limit != "" {
+		pageInt, _ := strconv.Atoi(filter.Page)
+		if filter.Page == "" {
+			pageInt = 1
+		}
+		return ctx.Status(fiber.StatusOK).JSON(web.WebResponsePagination{
+			Code:      fiber.StatusOK,
+			Status:    true,
+			Page:      pageInt,
+			Count:     len(assetResponses),
+			TotalData: totalData,
+			Message:   "success",
+			Data:      assetResponses,
+		})
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(web.WebResponse{
+		Code:    fiber.StatusOK,
+		Status:  true,
+		Message: "success",
+		Data:    assetResponses,
+	})
+}
+
+func (controller *assetController) FindMyAsset(ctx *fiber.Ctx) error {
+	userID, _ := helper.ParseJwt(ctx.Cookies("token"))
+
+	assetResponses, err := controller.assetService.FindMyAsset(ctx.Context(), userID)
+	if err != nil {
+		return err
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(web.WebResponse{
+		Code:    fiber.StatusOK,
+		Status:  true,
+		Message: "success",
+		Data:    assetResponses,
+	})
+}
+
+func (controller *assetController) FindByID(ctx *fiber.Ctx) error {
+	// parse path params
+	assetID := ctx.Params("asset_id")
+
+	asset, err := controller.assetService.FindById(ctx.Context(), assetID)
+	if err != nil {
+		return err
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(web.WebResponse{
+		Code:    fiber.StatusOK,
+		Status:  true,
+		Message: "success",
+		Data:    asset,
+	})
+}
+
+func (controller *assetController) FindAssignments(ctx *fiber.Ctx) error {
+	// parse path params
+	assetID := ctx.Params("asset_id")
+
+	assignmentResponses, err := controller.assetService.FindAssignments(ctx.Context(), assetID)
+	if err != nil {
+		return err
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(web.WebResponse{
+		Code:    fiber.StatusOK,
+		Status:  true,
+		Message: "success",
+		Data:    assignmentResponses,
+	})
+}
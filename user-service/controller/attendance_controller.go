@@ -0,0 +1,252 @@
+package controller
+
+import (
+	"strconv"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/gofiber/fiber/v2"
+	"github.com/iqbaludinm/hr-microservice/user-service/config"
+	"github.com/iqbaludinm/hr-microservice/user-service/exception"
+	"github.com/iqbaludinm/hr-microservice/user-service/helper"
+	"github.com/iqbaludinm/hr-microservice/user-service/middleware"
+	"github.com/iqbaludinm/hr-microservice/user-service/model/web"
+	"github.com/iqbaludinm/hr-microservice/user-service/service"
+)
+
+type AttendanceController interface {
+	// This function is used to define the route of the endpoints in this controller to the main application
+	Route(app *fiber.App)
+	CheckIn(ctx *fiber.Ctx) error
+	CheckOut(ctx *fiber.Ctx) error
+	FindAllAttendance(ctx *fiber.Ctx) error
+	FindMyAttendance(ctx *fiber.Ctx) error
+	FindByID(ctx *fiber.Ctx) error
+	DailySummary(ctx *fiber.Ctx) error
+	MonthlySummary(ctx *fiber.Ctx) error
+}
+
+type attendanceController struct {
+	validate          *validator.Validate
+	attendanceService service.AttendanceService
+}
+
+func NewAttendanceController(validate *validator.Validate, attendanceService service.AttendanceService) AttendanceController {
+	return &attendanceController{
+		validate:          validate,
+		attendanceService: attendanceService,
+	}
+}
+
+func (controller *attendanceController) Route(app *fiber.App) {
+	api := app.Group(config.EndpointPrefixAttendance, middleware.IsAuthenticated)
+
+	api.Post("/check-in", controller.CheckIn)
+	api.Post("/check-out", controller.CheckOut)
+	api.Get("/", controller.FindAllAttendance)
+	api.Get("/me", controller.FindMyAttendance)
+	api.Get("/summary/daily", controller.DailySummary)
+	api.Get("/summary/monthly", controller.MonthlySummary)
+	api.Get("/:attendance_id", controller.FindByID)
+}
+
+func (controller *attendanceController) CheckIn(ctx *fiber.Ctx) error {
+	// parse request body
+	var request web.CheckAttendanceRequest
+	if err := controller.parseCheck(ctx, &request); err != nil {
+		return err
+	}
+	// the selfie is optional
+	selfie, _ := ctx.FormFile("selfie")
+	userID, _ := helper.ParseJwt(ctx.Cookies("token"))
+
+	// check in
+	attendanceResponse, err := controller.attendanceService.CheckIn(ctx.Context(), userID, request, selfie)
+	if err != nil {
+		return err
+	}
+
+	return ctx.Status(fiber.StatusCreated).JSON(web.WebResponse{
+		Code:    fiber.StatusCreated,
+		Status:  true,
+		Message: "success",
+		Data:    attendanceResponse,
+	})
+}
+
+func (controller *attendanceController) CheckOut(ctx *fiber.Ctx) error {
+	// parse request body
+	var request web.CheckAttendanceRequest
+	if err := controller.parseCheck(ctx, &request); err != nil {
+		return err
+	}
+	// the selfie is optional
+	selfie, _ := ctx.FormFile("selfie")
+	userID, _ := helper.ParseJwt(ctx.Cookies("token"))
+
+	// check out
+	attendanceResponse, err := controller.attendanceService.CheckOut(ctx.Context(), userID, request, selfie)
+	if err != nil {
+		return err
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(web.WebResponse{
+		Code:    fiber.StatusOK,
+		Status:  true,
+		Message: "success",
+		Data:    attendanceResponse,
+	})
+}
+
+func (controller *attendanceController) FindAllAttendance(ctx *fiber.Ctx) error {
+	// parse query params
+	filter, err := controller.parseFilter(ctx)
+	if err != nil {
+		return err
+	}
+
+	attendanceResponses, totalData, err := controller.attendanceService.FindAllAttendance(ctx.Context(), filter)
+	if err != nil {
+		return err
+	}
+
+	return controller.attendanceListResponse(ctx, filter, attendanceResponses, totalData)
+}
+
+func (controller *attendanceController) FindMyAttendance(ctx *fiber.Ctx) error {
+	// parse query params
+	filter, err := controller.parseFilter(ctx)
+	if err != nil {
+		return err
+	}
+	userID, _ := helper.ParseJwt(ctx.Cookies("token"))
+
+	attendanceResponses, totalData, err := controller.attendanceService.FindMyAttendance(ctx.Context(), userID, filter)
+	if err != nil {
+		return err
+	}
+
+	return controller.attendanceListResponse(ctx, filter, attendanceResponses, totalData)
+}
+
+func (controller *attendanceController) FindByID(ctx *fiber.Ctx) error {
+	// parse path params
+	attendanceID := ctx.Params("attendance_id")
+
+	attendance, err := controller.attendanceService.FindById(ctx.Context(), attendanceID)
+	if err != nil {
+		return err
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(web.WebResponse{
+		Code:    fiber.StatusOK,
+		Status:  true,
+		Message: "success",
+		Data:    attendance,
+	})
+}
+
+func (controller *attendanceController) DailySummary(ctx *fiber.Ctx) error {
+	// parse query params
+	filter, err := controller.parseSummaryFilter(ctx)
+	if err != nil {
+		return err
+	}
+
+	summary, err := controller.attendanceService.DailySummary(ctx.Context(), filter)
+	if err != nil {
+		return err
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(web.WebResponse{
+		Code:    fiber.StatusOK,
+		Status:  true,
+		Message: "success",
+		Data:    summary,
+	})
+}
+
+func (controller *attendanceController) MonthlySummary(ctx *fiber.Ctx) error {
+	// parse query params
+	filter, err := controller.parseSummaryFilter(ctx)
+	if err != nil {
+		return err
+	}
+
+	summary, err := controller.attendanceService.MonthlySummary(ctx.Context(), filter)
+	if err != nil {
+		return err
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(web.WebResponse{
+		Code:    fiber.StatusOK,
+		Status:  true,
+		Message: "success",
+		Data:    summary,
+	})
+}
+
+// parse and validate the request body of the check-in and check-out, the body is optional
+func (controller *attendanceController) parseCheck(ctx *fiber.Ctx, request *web.CheckAttendanceRequest) error {
+	if len(ctx.Body()) > 0 {
+		if err := ctx.BodyParser(request); err != nil {
+			return exception.ErrValidateBadRequest(err.Error(), request)
+		}
+	}
+	if err := controller.validate.Struct(request); err != nil {
+		return exception.ErrValidateBadRequest(err.Error(), request)
+	}
+
+	return nil
+}
+
+// parse and validate the query params of the attendance list
+func (controller *attendanceController) parseFilter(ctx *fiber.Ctx) (web.AttendanceQueryFilter, error) {
+	var filter web.AttendanceQueryFilter
+	if err := ctx.QueryParser(&filter); err != nil {
+		return filter, exception.ErrValidateBadRequest(err.Error(), filter)
+	}
+	if err := controller.validate.Struct(filter); err != nil {
+		return filter, exception.ErrValidateBadRequest(err.Error(), filter)
+	}
+
+	return filter, nil
+}
+
+// parse and validate the query params of the attendance summary
+func (controller *attendanceController) parseSummaryFilter(ctx *fiber.Ctx) (web.AttendanceSummaryQueryFilter, error) {
+	var filter web.AttendanceSummaryQueryFilter
+	if err := ctx.QueryParser(&filter); err != nil {
+		return filter, exception.ErrValidateBadRequest(err.Error(), filter)
+	}
+	if err := controller.validate.Struct(filter); err != nil {
+		return filter, exception.ErrValidateBadRequest(err.Error(), filter)
+	}
+
+	return filter, nil
+}
+
+// write the attendance list, with the pagination when the page or limit is filled
+func (controller *attendanceController) attendanceListResponse(ctx *fiber.Ctx, filter web.AttendanceQueryFilter, attendanceResponses []web.AttendanceResponse, totalData int) error {
+	if filter.Page != "" || filter.Limit != "" {
+		pageInt, _ := strconv.Atoi(filter.Page)
+		if filter.Page == "" {
+			pageInt = 1
+		}
+		return ctx.Status(fiber.StatusOK).JSON(web.WebResponsePagination{
+			Code:      fiber.StatusOK,
+			Status:    true,
+			Page:      pageInt,
+			Count:     len(attendanceResponses),
+			TotalData: totalData,
+			Message:   "success",
+			Data:      attendanceResponses,
+		})
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(web.WebResponse{
+		Code:    fiber.StatusOK,
+		Status:  true,
+		Message: "success",
+		Data:    attendanceResponses,
+	})
+}
@@ -0,0 +1,155 @@
+package controller
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/gofiber/fiber/v2"
+	"github.com/iqbaludinm/hr-microservice/user-service/config"
+	"github.com/iqbaludinm/hr-microservice/user-service/exception"
+	"github.com/iqbaludinm/hr-microservice/user-service/model/web"
+	"github.com/iqbaludinm/hr-microservice/user-service/service"
+)
+
+type CareerController interface {
+	// This function is used to define the route of the endpoints in this controller to the main application
+	Route(app *fiber.App)
+	FindAllPosting(ctx *fiber.Ctx) error
+	FindPostingByID(ctx *fiber.Ctx) error
+	Apply(ctx *fiber.Ctx) error
+}
+
+type careerController struct {
+	validate              *validator.Validate
+	jobRequisitionService service.JobRequisitionService
+	jobApplicationService service.JobApplicationService
+}
+
+func NewCareerController(validate *validator.Validate, jobRequisitionService service.JobRequisitionService, jobApplicationService service.JobApplicationService) CareerController {
+	return &careerController{
+		validate:              validate,
+		jobRequisitionService: jobRequisitionService,
+		jobApplicationService: jobApplicationService,
+	}
+}
+
+func (controller *careerController) Route(app *fiber.App) {
+	// the career page is visited by the candidates who don't have an account yet,
+	// so the postings and the application form are not behind the authenticated group
+	app.Get(config.EndpointPrefixCareer, controller.FindAllPosting)
+	app.Get(config.EndpointPrefixCareer+"/:posting_id", controller.FindPostingByID)
+	app.Post(config.EndpointPrefixCareer+"/:posting_id/apply", controller.Apply)
+}
+
+func (controller *careerController) FindAllPosting(ctx *fiber.Ctx) error {
+	// parse query params
+	var filter web.JobPostingQueryFilter
+	if err := ctx.QueryParser(&filter); err != nil {
+		return exception.ErrValidateBadRequest(err.Error(), filter)
+	}
+	// validate the values of the query params
+	if err := controller.validate.Struct(filter); err != nil {
+		return exception.ErrValidateBadRequest(err.Error(), filter)
+	}
+
+	postingResponses, totalData, err := controller.jobRequisitionService.FindAllOpenPosting(ctx.Context(), filter)
+	if err != nil {
+		return err
+	}
+
+	if filter.Page != "" || filter.Limit != "" {
+		pageInt, _ := strconv.Atoi(filter.Page)
+		if filter.Page == "" {
+			pageInt = 1
+		}
+		return ctx.Status(fiber.StatusOK).JSON(web.WebResponsePagination{
+			Code:      fiber.StatusOK,
+			Status:    true,
+			Page:      pageInt,
+			Count:     len(postingResponses),
+			TotalData: totalData,
+			Message:   "success",
+			Data:      postingResponses,
+		})
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(web.WebResponse{
+		Code:    fiber.StatusOK,
+		Status:  true,
+		Message: "success",
+		Data:    postingResponses,
+	})
+}
+
+func (controller *careerController) FindPostingByID(ctx *fiber.Ctx) error {
+	// parse path params
+	postingID := ctx.Params("posting_id")
+
+	postingResponse, err := controller.jobRequisitionService.FindOpenPostingById(ctx.Context(), postingID)
+	if err != nil {
+		return err
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(web.WebResponse{
+		Code:    fiber.StatusOK,
+		Status:  true,
+		Message: "success",
+		Data:    postingResponse,
+	})
+}
+
+func (controller *careerController) Apply(ctx *fiber.Ctx) error {
+	// parse the fields of the multipart form
+	var request web.JobApplicationRequest
+	if err := ctx.BodyParser(&request); err != nil {
+		return exception.ErrValidateBadRequest(err.Error(), request)
+	}
+	// validate the values of request body
+	if err := controller.validate.Struct(request); err != nil {
+		return exception.ErrValidateBadRequest(err.Error(), request)
+	}
+
+	file, err := cvFile(ctx)
+	if err != nil {
+		return err
+	}
+
+	// parse path params
+	postingID := ctx.Params("posting_id")
+
+	if err := controller.jobApplicationService.Apply(ctx.Context(), postingID, request, file); err != nil {
+		return err
+	}
+
+	return ctx.Status(fiber.StatusCreated).JSON(web.WebResponse{
+		Code:    fiber.StatusCreated,
+		Status:  true,
+		Message: "success",
+	})
+}
+
+// read the uploaded CV from the 'cv' field of the multipart form, the size is checked before the file is read
+func cvFile(ctx *fiber.Ctx) (web.DocumentFile, error) {
+	fileHeader, err := ctx.FormFile("cv")
+	if err != nil {
+		return web.DocumentFile{}, exception.ErrBadRequest("The CV is required.")
+	}
+	if fileHeader.Size > int64(config.RecruitmentCVMaxSizeMB)*1024*1024 {
+		return web.DocumentFile{}, exception.ErrBadRequest(fmt.Sprintf("The CV can't be larger than %d MB.", config.RecruitmentCVMaxSizeMB))
+	}
+
+	file, err := fileHeader.Open()
+	if err != nil {
+		return web.DocumentFile{}, err
+	}
+	defer file.Close()
+
+	data, err := io.ReadAll(file)
+	if err != nil {
+		return web.DocumentFile{}, err
+	}
+
+	return web.DocumentFile{Name: fileHeader.Filename, Data: data}, nil
+}
@@ -0,0 +1,245 @@
+package controller
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/gofiber/fiber/v2"
+	"github.com/iqbaludinm/hr-microservice/user-service/config"
+	"github.com/iqbaludinm/hr-microservice/user-service/exception"
+	"github.com/iqbaludinm/hr-microservice/user-service/helper"
+	"github.com/iqbaludinm/hr-microservice/user-service/middleware"
+	"github.com/iqbaludinm/hr-microservice/user-service/model/web"
+	"github.com/iqbaludinm/hr-microservice/user-service/service"
+)
+
+type DepartmentController interface {
+	// This function is used to define the route of the endpoints in this controller to the main application
+	Route(app *fiber.App)
+	CreateDepartment(ctx *fiber.Ctx) error
+	UpdateDepartment(ctx *fiber.Ctx) error
+	DeleteDepartment(ctx *fiber.Ctx) error
+	FindAllDepartment(ctx *fiber.Ctx) error
+	FindByID(ctx *fiber.Ctx) error
+	FindTree(ctx *fiber.Ctx) error
+	FindMembers(ctx *fiber.Ctx) error
+	OrgChart(ctx *fiber.Ctx) error
+	OrgChartPDF(ctx *fiber.Ctx) error
+}
+
+type departmentController struct {
+	validate          *validator.Validate
+	departmentService service.DepartmentService
+}
+
+func NewDepartmentController(validate *validator.Validate, departmentService service.DepartmentService) DepartmentController {
+	return &departmentController{
+		validate:          validate,
+		departmentService: departmentService,
+	}
+}
+
+func (controller *departmentController) Route(app *fiber.App) {
+	api := app.Group(config.EndpointPrefixDepartment, middleware.IsAuthenticated)
+
+	api.Post("/", controller.CreateDepartment)
+	api.Get("/", controller.FindAllDepartment)
+	api.Get("/tree", controller.FindTree)
+	api.Get("/org-chart", controller.OrgChart)
+	api.Get("/org-chart/pdf", controller.OrgChartPDF)
+	api.Get("/:department_id", controller.FindByID)
+	api.Get("/:department_id/members", controller.FindMembers)
+	api.Put("/:department_id", controller.UpdateDepartment)
+	api.Delete("/:department_id", controller.DeleteDepartment)
+}
+
+func (controller *departmentController) CreateDepartment(ctx *fiber.Ctx) error {
+	// parse request body
+	var request web.CreateDepartmentRequest
+	err := ctx.BodyParser(&request)
+	if err != nil {
+		return exception.ErrValidateBadRequest(err.Error(), request)
+	}
+	// validate the values of the request body
+	err = controller.validate.Struct(request)
+	if err != nil {
+		return exception.ErrValidateBadRequest(err.Error(), request)
+	}
+
+	// create department
+	departmentResponse, err := controller.departmentService.CreateDepartment(ctx.Context(), request)
+	if err != nil {
+		return err
+	}
+
+	return ctx.Status(fiber.StatusCreated).JSON(web.WebResponse{
+		Code:    fiber.StatusCreated,
+		Status:  true,
+		Message: "success",
+		Data:    departmentResponse,
+	})
+}
+
+func (controller *departmentController) UpdateDepartment(ctx *fiber.Ctx) error {
+	// parse request body
+	var request web.UpdateDepartmentRequest
+	err := ctx.BodyParser(&request)
+	if err != nil {
+		return exception.ErrValidateBadRequest(err.Error(), request)
+	}
+	// validate the values of the request body
+	err = controller.validate.Struct(request)
+	if err != nil {
+		return exception.ErrValidateBadRequest(err.Error(), request)
+	}
+
+	// parse path params
+	departmentID := ctx.Params("department_id")
+
+	// update department
+	departmentResponse, err := controller.departmentService.UpdateDepartment(ctx.Context(), departmentID, request)
+	if err != nil {
+		return err
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(web.WebResponse{
+		Code:    fiber.StatusOK,
+		Status:  true,
+		Message: "success",
+		Data:    departmentResponse,
+	})
+}
+
+func (controller *departmentController) DeleteDepartment(ctx *fiber.Ctx) error {
+	// parse path params
+	departmentID := ctx.Params("department_id")
+
+	// delete department
+	err := controller.departmentService.Delete(ctx.Context(), departmentID)
+	if err != nil {
+		return err
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(web.WebResponse{
+		Code:    fiber.StatusOK,
+		Status:  true,
+		Message: "success",
+	})
+}
+
+func (controller *departmentController) FindByID(ctx *fiber.Ctx) error {
+	// parse path params
+	departmentID := ctx.Params("department_id")
+
+	department, err := controller.departmentService.FindById(ctx.Context(), departmentID)
+	if err != nil {
+		return err
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(web.WebResponse{
+		Code:    fiber.StatusOK,
+		Status:  true,
+		Message: "success",
+		Data:    department,
+	})
+}
+
+func (controller *departmentController) FindAllDepartment(ctx *fiber.Ctx) error {
+	// parse query params
+	var filter web.DepartmentQueryFilter
+	if err := ctx.QueryParser(&filter); err != nil {
+		return exception.ErrValidateBadRequest(err.Error(), filter)
+	}
+
+	departmentResponses, totalData, err := controller.departmentService.FindAllDepartment(ctx.Context(), filter)
+	if err != nil {
+		return err
+	}
+
+	if filter.Page != "" || filter.Limit != "" {
+		pageInt, _ := strconv.Atoi(filter.Page)
+		if filter.Page == "" {
+			pageInt = 1
+		}
+		return ctx.Status(fiber.StatusOK).JSON(web.WebResponsePagination{
+			Code:      fiber.StatusOK,
+			Status:    true,
+			Page:      pageInt,
+			Count:     len(departmentResponses),
+			TotalData: totalData,
+			Message:   "success",
+			Data:      departmentResponses,
+		})
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(web.WebResponse{
+		Code:    fiber.StatusOK,
+		Status:  true,
+		Message: "success",
+		Data:    departmentResponses,
+	})
+}
+
+func (controller *departmentController) FindTree(ctx *fiber.Ctx) error {
+	tree, err := controller.departmentService.FindTree(ctx.Context())
+	if err != nil {
+		return err
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(web.WebResponse{
+		Code:    fiber.StatusOK,
+		Status:  true,
+		Message: "success",
+		Data:    tree,
+	})
+}
+
+func (controller *departmentController) FindMembers(ctx *fiber.Ctx) error {
+	// parse query params
+	var filter web.DepartmentMemberQueryFilter
+	if err := ctx.QueryParser(&filter); err != nil {
+		return exception.ErrValidateBadRequest(err.Error(), filter)
+	}
+
+	// parse path params
+	departmentID := ctx.Params("department_id")
+
+	members, err := controller.departmentService.FindMembers(ctx.Context(), departmentID, filter)
+	if err != nil {
+		return err
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(web.WebResponse{
+		Code:    fiber.StatusOK,
+		Status:  true,
+		Message: "success",
+		Data:    members,
+	})
+}
+
+func (controller *departmentController) OrgChart(ctx *fiber.Ctx) error {
+	orgChart, err := controller.departmentService.OrgChart(ctx.Context())
+	if err != nil {
+		return err
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(web.WebResponse{
+		Code:    fiber.StatusOK,
+		Status:  true,
+		Message: "success",
+		Data:    orgChart,
+	})
+}
+
+func (controller *departmentController) OrgChartPDF(ctx *fiber.Ctx) error {
+	pdf, err := controller.departmentService.OrgChartPDF(ctx.Context())
+	if err != nil {
+		return err
+	}
+
+	ctx.Set(fiber.HeaderContentType, "application/pdf")
+	ctx.Set(fiber.HeaderContentDisposition, fmt.Sprintf("inline; filename=org-chart-%s.pdf", helper.Today().Format(helper.DateLayout)))
+
+	return ctx.Status(fiber.StatusOK).Send(pdf.Bytes())
+}
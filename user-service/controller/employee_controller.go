@@ -0,0 +1,194 @@
+package controller
+
+import (
+	"strconv"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/gofiber/fiber/v2"
+	"github.com/iqbaludinm/hr-microservice/user-service/config"
+	"github.com/iqbaludinm/hr-microservice/user-service/exception"
+	"github.com/iqbaludinm/hr-microservice/user-service/middleware"
+	"github.com/iqbaludinm/hr-microservice/user-service/model/web"
+	"github.com/iqbaludinm/hr-microservice/user-service/service"
+	"github.com/iqbaludinm/hr-microservice/user-service/service/producers"
+)
+
+type EmployeeController interface {
+	// This function is used to define the route of the endpoints in this controller to the main application
+	Route(app *fiber.App)
+	CreateEmployee(ctx *fiber.Ctx) error
+	UpdateEmployee(ctx *fiber.Ctx) error
+	DeleteEmployee(ctx *fiber.Ctx) error
+	FindAllEmployee(ctx *fiber.Ctx) error
+	FindByID(ctx *fiber.Ctx) error
+	ReportingChain(ctx *fiber.Ctx) error
+}
+
+type employeeController struct {
+	validate             *validator.Validate
+	kafkaProducerService producers.KafkaProducerService
+	employeeService      service.EmployeeService
+}
+
+func NewEmployeeController(validate *validator.Validate, kafkaProducerService producers.KafkaProducerService, employeeService service.EmployeeService) EmployeeController {
+	return &employeeController{
+		kafkaProducerService: kafkaProducerService,
+		validate:             validate,
+		employeeService:      employeeService,
+	}
+}
+
+func (controller *employeeController) Route(app *fiber.App) {
+	api := app.Group(config.EndpointPrefixEmployee, middleware.IsAuthenticated)
+
+	api.Post("/", controller.CreateEmployee)
+	api.Get("/", controller.FindAllEmployee)
+	api.Get("/:employee_id", controller.FindByID)
+	api.Put("/:employee_id", controller.UpdateEmployee)
+	api.Delete("/:employee_id", controller.DeleteEmployee)
+	api.Get("/:employee_id/reporting-chain", controller.ReportingChain)
+}
+
+func (controller *employeeController) CreateEmployee(ctx *fiber.Ctx) error {
+	// parse request body
+	var request web.CreateEmployeeRequest
+	err := ctx.BodyParser(&request)
+	if err != nil {
+		return exception.ErrValidateBadRequest(err.Error(), request)
+	}
+	// validate the values of the request body
+	err = controller.validate.Struct(request)
+	if err != nil {
+		return exception.ErrValidateBadRequest(err.Error(), request)
+	}
+
+	// create employee
+	employeeResponse, err := controller.employeeService.CreateEmployee(ctx.Context(), request)
+	if err != nil {
+		return err
+	}
+
+	return ctx.Status(fiber.StatusCreated).JSON(web.WebResponse{
+		Code:    fiber.StatusCreated,
+		Status:  true,
+		Message: "success",
+		Data:    employeeResponse,
+	})
+}
+
+func (controller *employeeController) UpdateEmployee(ctx *fiber.Ctx) error {
+	// parse request body
+	var request web.UpdateEmployeeRequest
+	err := ctx.BodyParser(&request)
+	if err != nil {
+		return exception.ErrValidateBadRequest(err.Error(), request)
+	}
+	// validate the values of the request body
+	err = controller.validate.Struct(request)
+	if err != nil {
+		return exception.ErrValidateBadRequest(err.Error(), request)
+	}
+
+	// parse path params
+	employeeID := ctx.Params("employee_id")
+
+	// update employee
+	employeeResponse, err := controller.employeeService.UpdateEmployee(ctx.Context(), employeeID, request)
+	if err != nil {
+		return err
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(web.WebResponse{
+		Code:    fiber.StatusOK,
+		Status:  true,
+		Message: "success",
+		Data:    employeeResponse,
+	})
+}
+
+func (controller *employeeController) DeleteEmployee(ctx *fiber.Ctx) error {
+	// parse path params
+	employeeID := ctx.Params("employee_id")
+
+	// delete employee
+	err := controller.employeeService.Delete(ctx.Context(), employeeID)
+	if err != nil {
+		return err
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(web.WebResponse{
+		Code:    fiber.StatusOK,
+		Status:  true,
+		Message: "success",
+	})
+}
+
+func (controller *employeeController) FindByID(ctx *fiber.Ctx) error {
+	// parse path params
+	employeeID := ctx.Params("employee_id")
+
+	employee, err := controller.employeeService.FindById(ctx.Context(), employeeID)
+	if err != nil {
+		return err
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(web.WebResponse{
+		Code:    fiber.StatusOK,
+		Status:  true,
+		Message: "success",
+		Data:    employee,
+	})
+}
+
+func (controller *employeeController) FindAllEmployee(ctx *fiber.Ctx) error {
+	// parse query params
+	var filter web.EmployeeQueryFilter
+	if err := ctx.QueryParser(&filter); err != nil {
+		return exception.ErrValidateBadRequest(err.Error(), filter)
+	}
+
+	employeeResponses, totalData, err := controller.employeeService.FindAllEmployee(ctx.Context(), filter)
+	if err != nil {
+		return err
+	}
+
+	if filter.Page != "" || filter.Limit != "" {
+		pageInt, _ := strconv.Atoi(filter.Page)
+		if filter.Page == "" {
+			pageInt = 1
+		}
+		return ctx.Status(fiber.StatusOK).JSON(web.WebResponsePagination{
+			Code:      fiber.StatusOK,
+			Status:    true,
+			Page:      pageInt,
+			Count:     len(employeeResponses),
+			TotalData: totalData,
+			Message:   "success",
+			Data:      employeeResponses,
+		})
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(web.WebResponse{
+		Code:    fiber.StatusOK,
+		Status:  true,
+		Message: "success",
+		Data:    employeeResponses,
+	})
+}
+
+func (controller *employeeController) ReportingChain(ctx *fiber.Ctx) error {
+	// parse path params
+	employeeID := ctx.Params("employee_id")
+
+	chain, err := controller.employeeService.ReportingChain(ctx.Context(), employeeID)
+	if err != nil {
+		return err
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(web.WebResponse{
+		Code:    fiber.StatusOK,
+		Status:  true,
+		Message: "success",
+		Data:    chain,
+	})
+}
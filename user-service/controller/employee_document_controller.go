@@ -0,0 +1,373 @@
+package controller
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/gofiber/fiber/v2"
+	"github.com/iqbaludinm/hr-microservice/user-service/config"
+	"github.com/iqbaludinm/hr-microservice/user-service/exception"
+	"github.com/iqbaludinm/hr-microservice/user-service/helper"
+	"github.com/iqbaludinm/hr-microservice/user-service/middleware"
+	"github.com/iqbaludinm/hr-microservice/user-service/model/web"
+	"github.com/iqbaludinm/hr-microservice/user-service/service"
+)
+
+type EmployeeDocumentController interface {
+	// This function is used to define the route of the endpoints in this controller to the main application
+	Route(app *fiber.App)
+	UploadDocument(ctx *fiber.Ctx) error
+	UploadMyDocument(ctx *fiber.Ctx) error
+	AddVersion(ctx *fiber.Ctx) error
+	UpdateDocument(ctx *fiber.Ctx) error
+	DeleteDocument(ctx *fiber.Ctx) error
+	FindAllDocument(ctx *fiber.Ctx) error
+	FindMyDocuments(ctx *fiber.Ctx) error
+	FindByID(ctx *fiber.Ctx) error
+	FindSharedByID(ctx *fiber.Ctx) error
+	DownloadDocument(ctx *fiber.Ctx) error
+	DownloadSharedDocument(ctx *fiber.Ctx) error
+}
+
+type employeeDocumentController struct {
+	validate                *validator.Validate
+	employeeDocumentService service.EmployeeDocumentService
+}
+
+func NewEmployeeDocumentController(validate *validator.Validate, employeeDocumentService service.EmployeeDocumentService) EmployeeDocumentController {
+	return &employeeDocumentController{
+		validate:                validate,
+		employeeDocumentService: employeeDocumentService,
+	}
+}
+
+func (controller *employeeDocumentController) Route(app *fiber.App) {
+	api := app.Group(config.EndpointPrefixEmployeeDocument, middleware.IsAuthenticated)
+
+	// the documents of every employee are managed by HR, the owner and the managers use the '/me' routes
+	api.Post("/", middleware.IsHR, controller.UploadDocument)
+	api.Get("/", middleware.IsHR, controller.FindAllDocument)
+	api.Post("/me", controller.UploadMyDocument)
+	api.Get("/me", controller.FindMyDocuments)
+	api.Get("/me/:document_id", controller.FindSharedByID)
+	api.Get("/me/:document_id/download", controller.DownloadSharedDocument)
+	api.Get("/:document_id", middleware.IsHR, controller.FindByID)
+	api.Put("/:document_id", middleware.IsHR, controller.UpdateDocument)
+	api.Delete("/:document_id", middleware.IsHR, controller.DeleteDocument)
+	api.Post("/:document_id/versions", middleware.IsHR, controller.AddVersion)
+	api.Get("/:document_id/download", middleware.IsHR, controller.DownloadDocument)
+}
+
+func (controller *employeeDocumentController) UploadDocument(ctx *fiber.Ctx) error {
+	// parse the multipart form
+	var request web.EmployeeDocumentRequest
+	if err := ctx.BodyParser(&request); err != nil {
+		return exception.ErrValidateBadRequest(err.Error(), request)
+	}
+	// validate the values of the form
+	if err := controller.validate.Struct(request); err != nil {
+		return exception.ErrValidateBadRequest(err.Error(), request)
+	}
+	file, err := documentFile(ctx)
+	if err != nil {
+		return err
+	}
+
+	// the document is uploaded by the logged in user
+	userID, _ := helper.ParseJwt(ctx.Cookies("token"))
+
+	documentResponse, err := controller.employeeDocumentService.Upload(ctx.Context(), userID, request, file)
+	if err != nil {
+		return err
+	}
+
+	return ctx.Status(fiber.StatusCreated).JSON(web.WebResponse{
+		Code:    fiber.StatusCreated,
+		Status:  true,
+		Message: "success",
+		Data:    documentResponse,
+	})
+}
+
+func (controller *employeeDocumentController) UploadMyDocument(ctx *fiber.Ctx) error {
+	// parse the multipart form
+	var request web.MyEmployeeDocumentRequest
+	if err := ctx.BodyParser(&request); err != nil {
+		return exception.ErrValidateBadRequest(err.Error(), request)
+	}
+	// validate the values of the form
+	if err := controller.validate.Struct(request); err != nil {
+		return exception.ErrValidateBadRequest(err.Error(), request)
+	}
+	file, err := documentFile(ctx)
+	if err != nil {
+		return err
+	}
+
+	userID, _ := helper.ParseJwt(ctx.Cookies("token"))
+
+	documentResponse, err := controller.employeeDocumentService.UploadMine(ctx.Context(), userID, request, file)
+	if err != nil {
+		return err
+	}
+
+	return ctx.Status(fiber.StatusCreated).JSON(web.WebResponse{
+		Code:    fiber.StatusCreated,
+		Status:  true,
+		Message: "success",
+		Data:    documentResponse,
+	})
+}
+
+func (controller *employeeDocumentController) AddVersion(ctx *fiber.Ctx) error {
+	// parse the multipart form
+	var request web.EmployeeDocumentVersionRequest
+	if err := ctx.BodyParser(&request); err != nil {
+		return exception.ErrValidateBadRequest(err.Error(), request)
+	}
+	// validate the values of the form
+	if err := controller.validate.Struct(request); err != nil {
+		return exception.ErrValidateBadRequest(err.Error(), request)
+	}
+	file, err := documentFile(ctx)
+	if err != nil {
+		return err
+	}
+
+	// parse path params
+	documentID := ctx.Params("document_id")
+	// the version is uploaded by the logged in user
+	userID, _ := helper.ParseJwt(ctx.Cookies("token"))
+
+	documentResponse, err := controller.employeeDocumentService.AddVersion(ctx.Context(), userID, documentID, request, file)
+	if err != nil {
+		return err
+	}
+
+	return ctx.Status(fiber.StatusCreated).JSON(web.WebResponse{
+		Code:    fiber.StatusCreated,
+		Status:  true,
+		Message: "success",
+		Data:    documentResponse,
+	})
+}
+
+func (controller *employeeDocumentController) UpdateDocument(ctx *fiber.Ctx) error {
+	// parse request body
+	var request web.UpdateEmployeeDocumentRequest
+	if err := ctx.BodyParser(&request); err != nil {
+		return exception.ErrValidateBadRequest(err.Error(), request)
+	}
+	// validate the values of request body
+	if err := controller.validate.Struct(request); err != nil {
+		return exception.ErrValidateBadRequest(err.Error(), request)
+	}
+
+	// parse path params
+	documentID := ctx.Params("document_id")
+
+	documentResponse, err := controller.employeeDocumentService.Update(ctx.Context(), documentID, request)
+	if err != nil {
+		return err
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(web.WebResponse{
+		Code:    fiber.StatusOK,
+		Status:  true,
+		Message: "success",
+		Data:    documentResponse,
+	})
+}
+
+func (controller *employeeDocumentController) DeleteDocument(ctx *fiber.Ctx) error {
+	// parse path params
+	documentID := ctx.Params("document_id")
+
+	// delete document
+	err := controller.employeeDocumentService.Delete(ctx.Context(), documentID)
+	if err != nil {
+		return err
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(web.WebResponse{
+		Code:    fiber.StatusOK,
+		Status:  true,
+		Message: "success",
+	})
+}
+
+func (controller *employeeDocumentController) FindAllDocument(ctx *fiber.Ctx) error {
+	// parse query params
+	var filter web.EmployeeDocumentQueryFilter
+	if err := ctx.QueryParser(&filter); err != nil {
+		return exception.ErrValidateBadRequest(err.Error(), filter)
+	}
+	// validate the values of the query params
+	if err := controller.validate.Struct(filter); err != nil {
+		return exception.ErrValidateBadRequest(err.Error(), filter)
+	}
+
+	documentResponses, totalData, err := controller.employeeDocumentService.FindAll(ctx.Context(), filter)
+	if err != nil {
+		return err
+	}
+
+	return documentsResponse(ctx, filter, documentResponses, totalData)
+}
+
+func (controller *employeeDocumentController) FindMyDocuments(ctx *fiber.Ctx) error {
+	// parse query params
+	var filter web.EmployeeDocumentQueryFilter
+	if err := ctx.QueryParser(&filter); err != nil {
+		return exception.ErrValidateBadRequest(err.Error(), filter)
+	}
+	// validate the values of the query params
+	if err := controller.validate.Struct(filter); err != nil {
+		return exception.ErrValidateBadRequest(err.Error(), filter)
+	}
+
+	userID, _ := helper.ParseJwt(ctx.Cookies("token"))
+
+	documentResponses, totalData, err := controller.employeeDocumentService.FindMine(ctx.Context(), userID, filter)
+	if err != nil {
+		return err
+	}
+
+	return documentsResponse(ctx, filter, documentResponses, totalData)
+}
+
+func (controller *employeeDocumentController) FindByID(ctx *fiber.Ctx) error {
+	// parse path params
+	documentID := ctx.Params("document_id")
+
+	documentResponse, err := controller.employeeDocumentService.FindById(ctx.Context(), documentID)
+	if err != nil {
+		return err
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(web.WebResponse{
+		Code:    fiber.StatusOK,
+		Status:  true,
+		Message: "success",
+		Data:    documentResponse,
+	})
+}
+
+func (controller *employeeDocumentController) FindSharedByID(ctx *fiber.Ctx) error {
+	// parse path params
+	documentID := ctx.Params("document_id")
+	userID, _ := helper.ParseJwt(ctx.Cookies("token"))
+
+	documentResponse, err := controller.employeeDocumentService.FindShared(ctx.Context(), userID, documentID)
+	if err != nil {
+		return err
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(web.WebResponse{
+		Code:    fiber.StatusOK,
+		Status:  true,
+		Message: "success",
+		Data:    documentResponse,
+	})
+}
+
+func (controller *employeeDocumentController) DownloadDocument(ctx *fiber.Ctx) error {
+	// parse path and query params, the latest version is downloaded when the version is not filled
+	documentID := ctx.Params("document_id")
+	version, err := documentVersion(ctx)
+	if err != nil {
+		return err
+	}
+
+	data, fileName, mimeType, err := controller.employeeDocumentService.Download(ctx.Context(), documentID, version)
+	if err != nil {
+		return err
+	}
+
+	ctx.Set(fiber.HeaderContentType, mimeType)
+	ctx.Set(fiber.HeaderContentDisposition, fmt.Sprintf("attachment; filename=%s", fileName))
+
+	return ctx.Status(fiber.StatusOK).Send(data)
+}
+
+func (controller *employeeDocumentController) DownloadSharedDocument(ctx *fiber.Ctx) error {
+	// parse path and query params, the latest version is downloaded when the version is not filled
+	documentID := ctx.Params("document_id")
+	version, err := documentVersion(ctx)
+	if err != nil {
+		return err
+	}
+	userID, _ := helper.ParseJwt(ctx.Cookies("token"))
+
+	data, fileName, mimeType, err := controller.employeeDocumentService.DownloadShared(ctx.Context(), userID, documentID, version)
+	if err != nil {
+		return err
+	}
+
+	ctx.Set(fiber.HeaderContentType, mimeType)
+	ctx.Set(fiber.HeaderContentDisposition, fmt.Sprintf("attachment; filename=%s", fileName))
+
+	return ctx.Status(fiber.StatusOK).Send(data)
+}
+
+func documentsResponse(ctx *fiber.Ctx, filter web.EmployeeDocumentQueryFilter, documentResponses []web.EmployeeDocumentResponse, totalData int) error {
+	if filter.Page != "" || filter.Limit != "" {
+		pageInt, _ := strconv.Atoi(filter.Page)
+		if filter.Page == "" {
+			pageInt = 1
+		}
+		return ctx.Status(fiber.StatusOK).JSON(web.WebResponsePagination{
+			Code:      fiber.StatusOK,
+			Status:    true,
+			Page:      pageInt,
+			Count:     len(documentResponses),
+			TotalData: totalData,
+			Message:   "success",
+			Data:      documentResponses,
+		})
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(web.WebResponse{
+		Code:    fiber.StatusOK,
+		Status:  true,
+		Message: "success",
+		Data:    documentResponses,
+	})
+}
+
+// read the uploaded file from the 'file' field of the multipart form, the size is checked before the file is read
+func documentFile(ctx *fiber.Ctx) (web.DocumentFile, error) {
+	fileHeader, err := ctx.FormFile("file")
+	if err != nil {
+		return web.DocumentFile{}, exception.ErrBadRequest("The file is required.")
+	}
+	if fileHeader.Size > int64(config.DocumentMaxSizeMB)*1024*1024 {
+		return web.DocumentFile{}, exception.ErrBadRequest(fmt.Sprintf("The file can't be larger than %d MB.", config.DocumentMaxSizeMB))
+	}
+
+	file, err := fileHeader.Open()
+	if err != nil {
+		return web.DocumentFile{}, err
+	}
+	defer file.Close()
+
+	data, err := io.ReadAll(file)
+	if err != nil {
+		return web.DocumentFile{}, err
+	}
+
+	return web.DocumentFile{Name: fileHeader.Filename, Data: data}, nil
+}
+
+func documentVersion(ctx *fiber.Ctx) (int, error) {
+	if ctx.Query("version") == "" {
+		return 0, nil
+	}
+	version, err := strconv.Atoi(ctx.Query("version"))
+	if err != nil || version < 1 {
+		return 0, exception.ErrBadRequest("Version must be a positive number.")
+	}
+	return version, nil
+}
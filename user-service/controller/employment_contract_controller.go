@@ -0,0 +1,284 @@
+package controller
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/gofiber/fiber/v2"
+	"github.com/iqbaludinm/hr-microservice/user-service/config"
+	"github.com/iqbaludinm/hr-microservice/user-service/exception"
+	"github.com/iqbaludinm/hr-microservice/user-service/helper"
+	"github.com/iqbaludinm/hr-microservice/user-service/middleware"
+	"github.com/iqbaludinm/hr-microservice/user-service/model/web"
+	"github.com/iqbaludinm/hr-microservice/user-service/service"
+)
+
+type EmploymentContractController interface {
+	// This function is used to define the route of the endpoints in this controller to the main application
+	Route(app *fiber.App)
+	CreateContract(ctx *fiber.Ctx) error
+	UpdateContract(ctx *fiber.Ctx) error
+	RenewContract(ctx *fiber.Ctx) error
+	ConvertContract(ctx *fiber.Ctx) error
+	SignContract(ctx *fiber.Ctx) error
+	CancelContract(ctx *fiber.Ctx) error
+	DownloadDocument(ctx *fiber.Ctx) error
+	FindAllContract(ctx *fiber.Ctx) error
+	FindByID(ctx *fiber.Ctx) error
+}
+
+type employmentContractController struct {
+	validate                  *validator.Validate
+	employmentContractService service.EmploymentContractService
+}
+
+func NewEmploymentContractController(validate *validator.Validate, employmentContractService service.EmploymentContractService) EmploymentContractController {
+	return &employmentContractController{
+		validate:                  validate,
+		employmentContractService: employmentContractService,
+	}
+}
+
+func (controller *employmentContractController) Route(app *fiber.App) {
+	api := app.Group(config.EndpointPrefixEmploymentContract, middleware.IsAuthenticated)
+
+	api.Post("/", controller.CreateContract)
+	api.Get("/", controller.FindAllContract)
+	api.Get("/:contract_id", controller.FindByID)
+	api.Put("/:contract_id", controller.UpdateContract)
+	api.Post("/:contract_id/renew", controller.RenewContract)
+	api.Post("/:contract_id/convert", controller.ConvertContract)
+	api.Post("/:contract_id/sign", controller.SignContract)
+	api.Post("/:contract_id/cancel", controller.CancelContract)
+	api.Get("/:contract_id/document", controller.DownloadDocument)
+}
+
+func (controller *employmentContractController) CreateContract(ctx *fiber.Ctx) error {
+	// parse request body
+	var request web.EmploymentContractRequest
+	if err := ctx.BodyParser(&request); err != nil {
+		return exception.ErrValidateBadRequest(err.Error(), request)
+	}
+	// validate the values of request body
+	if err := controller.validate.Struct(request); err != nil {
+		return exception.ErrValidateBadRequest(err.Error(), request)
+	}
+
+	// the contract is drafted by the logged in user
+	userID, _ := helper.ParseJwt(ctx.Cookies("token"))
+
+	contractResponse, err := controller.employmentContractService.Create(ctx.Context(), userID, request)
+	if err != nil {
+		return err
+	}
+
+	return ctx.Status(fiber.StatusCreated).JSON(web.WebResponse{
+		Code:    fiber.StatusCreated,
+		Status:  true,
+		Message: "success",
+		Data:    contractResponse,
+	})
+}
+
+func (controller *employmentContractController) UpdateContract(ctx *fiber.Ctx) error {
+	// parse request body
+	var request web.UpdateEmploymentContractRequest
+	if err := ctx.BodyParser(&request); err != nil {
+		return exception.ErrValidateBadRequest(err.Error(), request)
+	}
+	// validate the values of request body
+	if err := controller.validate.Struct(request); err != nil {
+		return exception.ErrValidateBadRequest(err.Error(), request)
+	}
+
+	// parse path params
+	contractID := ctx.Params("contract_id")
+
+	contractResponse, err := controller.employmentContractService.Update(ctx.Context(), contractID, request)
+	if err != nil {
+		return err
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(web.WebResponse{
+		Code:    fiber.StatusOK,
+		Status:  true,
+		Message: "success",
+		Data:    contractResponse,
+	})
+}
+
+func (controller *employmentContractController) RenewContract(ctx *fiber.Ctx) error {
+	// parse request body
+	var request web.RenewEmploymentContractRequest
+	if err := ctx.BodyParser(&request); err != nil {
+		return exception.ErrValidateBadRequest(err.Error(), request)
+	}
+	// validate the values of request body
+	if err := controller.validate.Struct(request); err != nil {
+		return exception.ErrValidateBadRequest(err.Error(), request)
+	}
+
+	// parse path params
+	contractID := ctx.Params("contract_id")
+	// the renewal is drafted by the logged in user
+	userID, _ := helper.ParseJwt(ctx.Cookies("token"))
+
+	contractResponse, err := controller.employmentContractService.Renew(ctx.Context(), userID, contractID, request)
+	if err != nil {
+		return err
+	}
+
+	return ctx.Status(fiber.StatusCreated).JSON(web.WebResponse{
+		Code:    fiber.StatusCreated,
+		Status:  true,
+		Message: "success",
+		Data:    contractResponse,
+	})
+}
+
+func (controller *employmentContractController) ConvertContract(ctx *fiber.Ctx) error {
+	// parse request body
+	var request web.ConvertEmploymentContractRequest
+	if err := ctx.BodyParser(&request); err != nil {
+		return exception.ErrValidateBadRequest(err.Error(), request)
+	}
+	// validate the values of request body
+	if err := controller.validate.Struct(request); err != nil {
+		return exception.ErrValidateBadRequest(err.Error(), request)
+	}
+
+	// parse path params
+	contractID := ctx.Params("contract_id")
+	// the conversion is drafted by the logged in user
+	userID, _ := helper.ParseJwt(ctx.Cookies("token"))
+
+	contractResponse, err := controller.employmentContractService.Convert(ctx.Context(), userID, contractID, request)
+	if err != nil {
+		return err
+	}
+
+	return ctx.Status(fiber.StatusCreated).JSON(web.WebResponse{
+		Code:    fiber.StatusCreated,
+		Status:  true,
+		Message: "success",
+		Data:    contractResponse,
+	})
+}
+
+func (controller *employmentContractController) SignContract(ctx *fiber.Ctx) error {
+	// parse request body
+	var request web.SignEmploymentContractRequest
+	if err := ctx.BodyParser(&request); err != nil {
+		return exception.ErrValidateBadRequest(err.Error(), request)
+	}
+	// validate the values of request body
+	if err := controller.validate.Struct(request); err != nil {
+		return exception.ErrValidateBadRequest(err.Error(), request)
+	}
+
+	// parse path params
+	contractID := ctx.Params("contract_id")
+
+	contractResponse, err := controller.employmentContractService.Sign(ctx.Context(), contractID, request)
+	if err != nil {
+		return err
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(web.WebResponse{
+		Code:    fiber.StatusOK,
+		Status:  true,
+		Message: "success",
+		Data:    contractResponse,
+	})
+}
+
+func (controller *employmentContractController) CancelContract(ctx *fiber.Ctx) error {
+	// parse path params
+	contractID := ctx.Params("contract_id")
+
+	// cancel the draft contract
+	contractResponse, err := controller.employmentContractService.Cancel(ctx.Context(), contractID)
+	if err != nil {
+		return err
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(web.WebResponse{
+		Code:    fiber.StatusOK,
+		Status:  true,
+		Message: "success",
+		Data:    contractResponse,
+	})
+}
+
+func (controller *employmentContractController) DownloadDocument(ctx *fiber.Ctx) error {
+	// parse path params
+	contractID := ctx.Params("contract_id")
+
+	pdf, fileName, err := controller.employmentContractService.Document(ctx.Context(), contractID)
+	if err != nil {
+		return err
+	}
+
+	ctx.Set(fiber.HeaderContentType, "application/pdf")
+	ctx.Set(fiber.HeaderContentDisposition, fmt.Sprintf("attachment; filename=%s", fileName))
+
+	return ctx.Status(fiber.StatusOK).Send(pdf)
+}
+
+func (controller *employmentContractController) FindAllContract(ctx *fiber.Ctx) error {
+	// parse query params
+	var filter web.EmploymentContractQueryFilter
+	if err := ctx.QueryParser(&filter); err != nil {
+		return exception.ErrValidateBadRequest(err.Error(), filter)
+	}
+	// validate the values of the query params
+	if err := controller.validate.Struct(filter); err != nil {
+		return exception.ErrValidateBadRequest(err.Error(), filter)
+	}
+
+	contractResponses, totalData, err := controller.employmentContractService.FindAll(ctx.Context(), filter)
+	if err != nil {
+		return err
+	}
+
+	if filter.Page != "" || filter.Limit != "" {
+		pageInt, _ := strconv.Atoi(filter.Page)
+		if filter.Page == "" {
+			pageInt = 1
+		}
+		return ctx.Status(fiber.StatusOK).JSON(web.WebResponsePagination{
+			Code:      fiber.StatusOK,
+			Status:    true,
+			Page:      pageInt,
+			Count:     len(contractResponses),
+			TotalData: totalData,
+			Message:   "success",
+			Data:      contractResponses,
+		})
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(web.WebResponse{
+		Code:    fiber.StatusOK,
+		Status:  true,
+		Message: "success",
+		Data:    contractResponses,
+	})
+}
+
+func (controller *employmentContractController) FindByID(ctx *fiber.Ctx) error {
+	// parse path params
+	contractID := ctx.Params("contract_id")
+
+	contractResponse, err := controller.employmentContractService.FindById(ctx.Context(), contractID)
+	if err != nil {
+		return err
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(web.WebResponse{
+		Code:    fiber.StatusOK,
+		Status:  true,
+		Message: "success",
+		Data:    contractResponse,
+	})
+}
@@ -0,0 +1,132 @@
+package controller
+
+import (
+	"github.com/go-playground/validator/v10"
+	"github.com/gofiber/fiber/v2"
+	"github.com/iqbaludinm/hr-microservice/user-service/config"
+	"github.com/iqbaludinm/hr-microservice/user-service/exception"
+	"github.com/iqbaludinm/hr-microservice/user-service/middleware"
+	"github.com/iqbaludinm/hr-microservice/user-service/model/web"
+	"github.com/iqbaludinm/hr-microservice/user-service/service"
+)
+
+type EmploymentHistoryController interface {
+	// This function is used to define the route of the endpoints in this controller to the main application
+	Route(app *fiber.App)
+	CreateChange(ctx *fiber.Ctx) error
+	CancelChange(ctx *fiber.Ctx) error
+	FindAllHistory(ctx *fiber.Ctx) error
+	FindAsOf(ctx *fiber.Ctx) error
+}
+
+type employmentHistoryController struct {
+	validate                 *validator.Validate
+	employmentHistoryService service.EmploymentHistoryService
+}
+
+func NewEmploymentHistoryController(validate *validator.Validate, employmentHistoryService service.EmploymentHistoryService) EmploymentHistoryController {
+	return &employmentHistoryController{
+		validate:                 validate,
+		employmentHistoryService: employmentHistoryService,
+	}
+}
+
+func (controller *employmentHistoryController) Route(app *fiber.App) {
+	api := app.Group(config.EndpointPrefixEmployee+"/:employee_id", middleware.IsAuthenticated)
+
+	api.Post("/histories", controller.CreateChange)
+	api.Get("/histories", controller.FindAllHistory)
+	api.Delete("/histories/:history_id", controller.CancelChange)
+	api.Get("/as-of", controller.FindAsOf)
+}
+
+func (controller *employmentHistoryController) CreateChange(ctx *fiber.Ctx) error {
+	// parse request body
+	var request web.CreateEmploymentChangeRequest
+	err := ctx.BodyParser(&request)
+	if err != nil {
+		return exception.ErrValidateBadRequest(err.Error(), request)
+	}
+	// validate the values of the request body
+	err = controller.validate.Struct(request)
+	if err != nil {
+		return exception.ErrValidateBadRequest(err.Error(), request)
+	}
+
+	// parse path params
+	employeeID := ctx.Params("employee_id")
+
+	// record the employment change, applied immediately or on the effective date
+	historyResponse, err := controller.employmentHistoryService.CreateChange(ctx.Context(), employeeID, request)
+	if err != nil {
+		return err
+	}
+
+	return ctx.Status(fiber.StatusCreated).JSON(web.WebResponse{
+		Code:    fiber.StatusCreated,
+		Status:  true,
+		Message: "success",
+		Data:    historyResponse,
+	})
+}
+
+func (controller *employmentHistoryController) CancelChange(ctx *fiber.Ctx) error {
+	// parse path params
+	employeeID := ctx.Params("employee_id")
+	historyID := ctx.Params("history_id")
+
+	// cancel the pending employment change
+	err := controller.employmentHistoryService.CancelChange(ctx.Context(), employeeID, historyID)
+	if err != nil {
+		return err
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(web.WebResponse{
+		Code:    fiber.StatusOK,
+		Status:  true,
+		Message: "success",
+	})
+}
+
+func (controller *employmentHistoryController) FindAllHistory(ctx *fiber.Ctx) error {
+	// parse path params
+	employeeID := ctx.Params("employee_id")
+
+	histories, err := controller.employmentHistoryService.FindAllHistory(ctx.Context(), employeeID)
+	if err != nil {
+		return err
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(web.WebResponse{
+		Code:    fiber.StatusOK,
+		Status:  true,
+		Message: "success",
+		Data:    histories,
+	})
+}
+
+func (controller *employmentHistoryController) FindAsOf(ctx *fiber.Ctx) error {
+	// parse query params
+	var filter web.EmployeeAsOfQueryFilter
+	if err := ctx.QueryParser(&filter); err != nil {
+		return exception.ErrValidateBadRequest(err.Error(), filter)
+	}
+	if err := controller.validate.Struct(filter); err != nil {
+		return exception.ErrValidateBadRequest(err.Error(), filter)
+	}
+
+	// parse path params
+	employeeID := ctx.Params("employee_id")
+
+	employee, err := controller.employmentHistoryService.FindAsOf(ctx.Context(), employeeID, filter)
+	if err != nil {
+		return err
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(web.WebResponse{
+		Code:    fiber.StatusOK,
+		Status:  true,
+		Message: "success",
+		Data:    employee,
+	})
+}
@@ -0,0 +1,169 @@
+package controller
+
+import (
+	"strconv"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/gofiber/fiber/v2"
+	"github.com/iqbaludinm/hr-microservice/user-service/config"
+	"github.com/iqbaludinm/hr-microservice/user-service/exception"
+	"github.com/iqbaludinm/hr-microservice/user-service/middleware"
+	"github.com/iqbaludinm/hr-microservice/user-service/model/web"
+	"github.com/iqbaludinm/hr-microservice/user-service/service"
+)
+
+type ExpenseCategoryController interface {
+	// This function is used to define the route of the endpoints in this controller to the main application
+	Route(app *fiber.App)
+	CreateCategory(ctx *fiber.Ctx) error
+	UpdateCategory(ctx *fiber.Ctx) error
+	DeleteCategory(ctx *fiber.Ctx) error
+	FindAllCategory(ctx *fiber.Ctx) error
+	FindByID(ctx *fiber.Ctx) error
+}
+
+type expenseCategoryController struct {
+	validate            *validator.Validate
+	expenseClaimService service.ExpenseClaimService
+}
+
+func NewExpenseCategoryController(validate *validator.Validate, expenseClaimService service.ExpenseClaimService) ExpenseCategoryController {
+	return &expenseCategoryController{
+		validate:            validate,
+		expenseClaimService: expenseClaimService,
+	}
+}
+
+func (controller *expenseCategoryController) Route(app *fiber.App) {
+	api := app.Group(config.EndpointPrefixExpenseCategory, middleware.IsAuthenticated)
+
+	api.Get("/", controller.FindAllCategory)
+	api.Post("/", controller.CreateCategory)
+	api.Get("/:category_id", controller.FindByID)
+	api.Put("/:category_id", controller.UpdateCategory)
+	api.Delete("/:category_id", controller.DeleteCategory)
+}
+
+func (controller *expenseCategoryController) CreateCategory(ctx *fiber.Ctx) error {
+	// parse request body
+	var request web.ExpenseCategoryRequest
+	if err := ctx.BodyParser(&request); err != nil {
+		return exception.ErrValidateBadRequest(err.Error(), request)
+	}
+	// validate the values of request body
+	if err := controller.validate.Struct(request); err != nil {
+		return exception.ErrValidateBadRequest(err.Error(), request)
+	}
+
+	categoryResponse, err := controller.expenseClaimService.CreateCategory(ctx.Context(), request)
+	if err != nil {
+		return err
+	}
+
+	return ctx.Status(fiber.StatusCreated).JSON(web.WebResponse{
+		Code:    fiber.StatusCreated,
+		Status:  true,
+		Message: "success",
+		Data:    categoryResponse,
+	})
+}
+
+func (controller *expenseCategoryController) UpdateCategory(ctx *fiber.Ctx) error {
+	// parse request body
+	var request web.ExpenseCategoryRequest
+	if err := ctx.BodyParser(&request); err != nil {
+		return exception.ErrValidateBadRequest(err.Error(), request)
+	}
+	// validate the values of request body
+	if err := controller.validate.Struct(request); err != nil {
+		return exception.ErrValidateBadRequest(err.Error(), request)
+	}
+
+	// parse path params
+	categoryID := ctx.Params("category_id")
+
+	categoryResponse, err := controller.expenseClaimService.UpdateCategory(ctx.Context(), categoryID, request)
+	if err != nil {
+		return err
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(web.WebResponse{
+		Code:    fiber.StatusOK,
+		Status:  true,
+		Message: "success",
+		Data:    categoryResponse,
+	})
+}
+
+func (controller *expenseCategoryController) DeleteCategory(ctx *fiber.Ctx) error {
+	// parse path params
+	categoryID := ctx.Params("category_id")
+
+	err := controller.expenseClaimService.DeleteCategory(ctx.Context(), categoryID)
+	if err != nil {
+		return err
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(web.WebResponse{
+		Code:    fiber.StatusOK,
+		Status:  true,
+		Message: "success",
+	})
+}
+
+func (controller *expenseCategoryController) FindAllCategory(ctx *fiber.Ctx) error {
+	// parse query params
+	var filter web.ExpenseCategoryQueryFilter
+	if err := ctx.QueryParser(&filter); err != nil {
+		return exception.ErrValidateBadRequest(err.Error(), filter)
+	}
+	// validate the values of the query params
+	if err := controller.validate.Struct(filter); err != nil {
+		return exception.ErrValidateBadRequest(err.Error(), filter)
+	}
+
+	categoryResponses, totalData, err := controller.expenseClaimService.FindAllCategory(ctx.Context(), filter)
+	if err != nil {
+		return err
+	}
+
+	if filter.Page != "" || filter.Limit != "" {
+		pageInt, _ := strconv.Atoi(filter.Page)
+		if filter.Page == "" {
+			pageInt = 1
+		}
+		return ctx.Status(fiber.StatusOK).JSON(web.WebResponsePagination{
+			Code:      fiber.StatusOK,
+			Status:    true,
+			Page:      pageInt,
+			Count:     len(categoryResponses),
+			TotalData: totalData,
+			Message:   "success",
+			Data:      categoryResponses,
+		})
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(web.WebResponse{
+		Code:    fiber.StatusOK,
+		Status:  true,
+		Message: "success",
+		Data:    categoryResponses,
+	})
+}
+
+func (controller *expenseCategoryController) FindByID(ctx *fiber.Ctx) error {
+	// parse path params
+	categoryID := ctx.Params("category_id")
+
+	categoryResponse, err := controller.expenseClaimService.FindCategoryById(ctx.Context(), categoryID)
+	if err != nil {
+		return err
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(web.WebResponse{
+		Code:    fiber.StatusOK,
+		Status:  true,
+		Message: "success",
+		Data:    categoryResponse,
+	})
+}
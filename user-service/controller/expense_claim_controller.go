@@ -0,0 +1,448 @@
+package controller
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/gofiber/fiber/v2"
+	"github.com/iqbaludinm/hr-microservice/user-service/config"
+	"github.com/iqbaludinm/hr-microservice/user-service/exception"
+	"github.com/iqbaludinm/hr-microservice/user-service/helper"
+	"github.com/iqbaludinm/hr-microservice/user-service/middleware"
+	"github.com/iqbaludinm/hr-microservice/user-service/model/web"
+	"github.com/iqbaludinm/hr-microservice/user-service/service"
+)
+
+type ExpenseClaimController interface {
+	// This function is used to define the route of the endpoints in this controller to the main application
+	Route(app *fiber.App)
+	CreateClaim(ctx *fiber.Ctx) error
+	UpdateClaim(ctx *fiber.Ctx) error
+	AddItem(ctx *fiber.Ctx) error
+	DeleteItem(ctx *fiber.Ctx) error
+	UploadReceipt(ctx *fiber.Ctx) error
+	DownloadReceipt(ctx *fiber.Ctx) error
+	SubmitClaim(ctx *fiber.Ctx) error
+	ApproveClaim(ctx *fiber.Ctx) error
+	RejectClaim(ctx *fiber.Ctx) error
+	CancelClaim(ctx *fiber.Ctx) error
+	FindAllClaim(ctx *fiber.Ctx) error
+	FindMyClaims(ctx *fiber.Ctx) error
+	FindPendingApprovals(ctx *fiber.Ctx) error
+	FindByID(ctx *fiber.Ctx) error
+}
+
+type expenseClaimController struct {
+	validate            *validator.Validate
+	expenseClaimService service.ExpenseClaimService
+}
+
+func NewExpenseClaimController(validate *validator.Validate, expenseClaimService service.ExpenseClaimService) ExpenseClaimController {
+	return &expenseClaimController{
+		validate:            validate,
+		expenseClaimService: expenseClaimService,
+	}
+}
+
+func (controller *expenseClaimController) Route(app *fiber.App) {
+	api := app.Group(config.EndpointPrefixExpenseClaim, middleware.IsAuthenticated)
+
+	api.Get("/", controller.FindAllClaim)
+	api.Post("/", controller.CreateClaim)
+	api.Get("/me", controller.FindMyClaims)
+	api.Get("/approvals", controller.FindPendingApprovals)
+	api.Get("/:claim_id", controller.FindByID)
+	api.Put("/:claim_id", controller.UpdateClaim)
+	api.Post("/:claim_id/items", controller.AddItem)
+	api.Delete("/:claim_id/items/:seq", controller.DeleteItem)
+	api.Put("/:claim_id/items/:seq/receipt", controller.UploadReceipt)
+	api.Get("/:claim_id/items/:seq/receipt", controller.DownloadReceipt)
+	api.Put("/:claim_id/submit", controller.SubmitClaim)
+	api.Put("/:claim_id/approve", controller.ApproveClaim)
+	api.Put("/:claim_id/reject", controller.RejectClaim)
+	api.Put("/:claim_id/cancel", controller.CancelClaim)
+}
+
+func (controller *expenseClaimController) CreateClaim(ctx *fiber.Ctx) error {
+	// parse request body
+	var request web.ExpenseClaimRequest
+	if err := ctx.BodyParser(&request); err != nil {
+		return exception.ErrValidateBadRequest(err.Error(), request)
+	}
+	// validate the values of request body
+	if err := controller.validate.Struct(request); err != nil {
+		return exception.ErrValidateBadRequest(err.Error(), request)
+	}
+
+	// the claim is created for the logged in user
+	userID, _ := helper.ParseJwt(ctx.Cookies("token"))
+
+	claimResponse, err := controller.expenseClaimService.CreateClaim(ctx.Context(), userID, request)
+	if err != nil {
+		return err
+	}
+
+	return ctx.Status(fiber.StatusCreated).JSON(web.WebResponse{
+		Code:    fiber.StatusCreated,
+		Status:  true,
+		Message: "success",
+		Data:    claimResponse,
+	})
+}
+
+func (controller *expenseClaimController) UpdateClaim(ctx *fiber.Ctx) error {
+	// parse request body
+	var request web.UpdateExpenseClaimRequest
+	if err := ctx.BodyParser(&request); err != nil {
+		return exception.ErrValidateBadRequest(err.Error(), request)
+	}
+	// validate the values of request body
+	if err := controller.validate.Struct(request); err != nil {
+		return exception.ErrValidateBadRequest(err.Error(), request)
+	}
+
+	// parse path params
+	claimID := ctx.Params("claim_id")
+	userID, _ := helper.ParseJwt(ctx.Cookies("token"))
+
+	claimResponse, err := controller.expenseClaimService.UpdateClaim(ctx.Context(), userID, claimID, request)
+	if err != nil {
+		return err
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(web.WebResponse{
+		Code:    fiber.StatusOK,
+		Status:  true,
+		Message: "success",
+		Data:    claimResponse,
+	})
+}
+
+func (controller *expenseClaimController) AddItem(ctx *fiber.Ctx) error {
+	// parse request body
+	var request web.ExpenseClaimItemRequest
+	if err := ctx.BodyParser(&request); err != nil {
+		return exception.ErrValidateBadRequest(err.Error(), request)
+	}
+	// validate the values of request body
+	if err := controller.validate.Struct(request); err != nil {
+		return exception.ErrValidateBadRequest(err.Error(), request)
+	}
+
+	// parse path params
+	claimID := ctx.Params("claim_id")
+	userID, _ := helper.ParseJwt(ctx.Cookies("token"))
+
+	claimResponse, err := controller.expenseClaimService.AddItem(ctx.Context(), userID, claimID, request)
+	if err != nil {
+		return err
+	}
+
+	return ctx.Status(fiber.StatusCreated).JSON(web.WebResponse{
+		Code:    fiber.StatusCreated,
+		Status:  true,
+		Message: "success",
+		Data:    claimResponse,
+	})
+}
+
+func (controller *expenseClaimController) DeleteItem(ctx *fiber.Ctx) error {
+	// parse path params
+	claimID := ctx.Params("claim_id")
+	seq, err := itemSeq(ctx)
+	if err != nil {
+		return err
+	}
+	userID, _ := helper.ParseJwt(ctx.Cookies("token"))
+
+	claimResponse, err := controller.expenseClaimService.DeleteItem(ctx.Context(), userID, claimID, seq)
+	if err != nil {
+		return err
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(web.WebResponse{
+		Code:    fiber.StatusOK,
+		Status:  true,
+		Message: "success",
+		Data:    claimResponse,
+	})
+}
+
+func (controller *expenseClaimController) UploadReceipt(ctx *fiber.Ctx) error {
+	// the receipt is uploaded in the 'file' field of the multipart form
+	file, err := receiptFile(ctx)
+	if err != nil {
+		return err
+	}
+
+	// parse path params
+	claimID := ctx.Params("claim_id")
+	seq, err := itemSeq(ctx)
+	if err != nil {
+		return err
+	}
+	userID, _ := helper.ParseJwt(ctx.Cookies("token"))
+
+	claimResponse, err := controller.expenseClaimService.UploadReceipt(ctx.Context(), userID, claimID, seq, file)
+	if err != nil {
+		return err
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(web.WebResponse{
+		Code:    fiber.StatusOK,
+		Status:  true,
+		Message: "success",
+		Data:    claimResponse,
+	})
+}
+
+func (controller *expenseClaimController) DownloadReceipt(ctx *fiber.Ctx) error {
+	// parse path params
+	claimID := ctx.Params("claim_id")
+	seq, err := itemSeq(ctx)
+	if err != nil {
+		return err
+	}
+
+	data, fileName, mimeType, err := controller.expenseClaimService.Receipt(ctx.Context(), claimID, seq)
+	if err != nil {
+		return err
+	}
+
+	ctx.Set(fiber.HeaderContentType, mimeType)
+	ctx.Set(fiber.HeaderContentDisposition, fmt.Sprintf("attachment; filename=%s", fileName))
+
+	return ctx.Status(fiber.StatusOK).Send(data)
+}
+
+func (controller *expenseClaimController) SubmitClaim(ctx *fiber.Ctx) error {
+	// parse path params
+	claimID := ctx.Params("claim_id")
+	userID, _ := helper.ParseJwt(ctx.Cookies("token"))
+
+	claimResponse, err := controller.expenseClaimService.SubmitClaim(ctx.Context(), userID, claimID)
+	if err != nil {
+		return err
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(web.WebResponse{
+		Code:    fiber.StatusOK,
+		Status:  true,
+		Message: "success",
+		Data:    claimResponse,
+	})
+}
+
+func (controller *expenseClaimController) ApproveClaim(ctx *fiber.Ctx) error {
+	// parse request body
+	var request web.DecideExpenseClaimRequest
+	if err := ctx.BodyParser(&request); err != nil {
+		return exception.ErrValidateBadRequest(err.Error(), request)
+	}
+	// validate the values of request body
+	if err := controller.validate.Struct(request); err != nil {
+		return exception.ErrValidateBadRequest(err.Error(), request)
+	}
+
+	// parse path params
+	claimID := ctx.Params("claim_id")
+	// the claim is approved by the logged in user as the manager or as finance
+	userID, _ := helper.ParseJwt(ctx.Cookies("token"))
+
+	claimResponse, err := controller.expenseClaimService.ApproveClaim(ctx.Context(), userID, claimID, request)
+	if err != nil {
+		return err
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(web.WebResponse{
+		Code:    fiber.StatusOK,
+		Status:  true,
+		Message: "success",
+		Data:    claimResponse,
+	})
+}
+
+func (controller *expenseClaimController) RejectClaim(ctx *fiber.Ctx) error {
+	// parse request body
+	var request web.DecideExpenseClaimRequest
+	if err := ctx.BodyParser(&request); err != nil {
+		return exception.ErrValidateBadRequest(err.Error(), request)
+	}
+	// validate the values of request body
+	if err := controller.validate.Struct(request); err != nil {
+		return exception.ErrValidateBadRequest(err.Error(), request)
+	}
+
+	// parse path params
+	claimID := ctx.Params("claim_id")
+	// the claim is rejected by the logged in user as the manager or as finance
+	userID, _ := helper.ParseJwt(ctx.Cookies("token"))
+
+	claimResponse, err := controller.expenseClaimService.RejectClaim(ctx.Context(), userID, claimID, request)
+	if err != nil {
+		return err
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(web.WebResponse{
+		Code:    fiber.StatusOK,
+		Status:  true,
+		Message: "success",
+		Data:    claimResponse,
+	})
+}
+
+func (controller *expenseClaimController) CancelClaim(ctx *fiber.Ctx) error {
+	// parse path params
+	claimID := ctx.Params("claim_id")
+	userID, _ := helper.ParseJwt(ctx.Cookies("token"))
+
+	claimResponse, err := controller.expenseClaimService.CancelClaim(ctx.Context(), userID, claimID)
+	if err != nil {
+		return err
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(web.WebResponse{
+		Code:    fiber.StatusOK,
+		Status:  true,
+		Message: "success",
+		Data:    claimResponse,
+	})
+}
+
+func (controller *expenseClaimController) FindAllClaim(ctx *fiber.Ctx) error {
+	// parse query params
+	var filter web.ExpenseClaimQueryFilter
+	if err := ctx.QueryParser(&filter); err != nil {
+		return exception.ErrValidateBadRequest(err.Error(), filter)
+	}
+	// validate the values of the query params
+	if err := controller.validate.Struct(filter); err != nil {
+		return exception.ErrValidateBadRequest(err.Error(), filter)
+	}
+
+	claimResponses, totalData, err := controller.expenseClaimService.FindAllClaim(ctx.Context(), filter)
+	if err != nil {
+		return err
+	}
+
+	return expenseClaimsResponse(ctx, filter, claimResponses, totalData)
+}
+
+func (controller *expenseClaimController) FindMyClaims(ctx *fiber.Ctx) error {
+	// parse query params
+	var filter web.ExpenseClaimQueryFilter
+	if err := ctx.QueryParser(&filter); err != nil {
+		return exception.ErrValidateBadRequest(err.Error(), filter)
+	}
+	// validate the values of the query params
+	if err := controller.validate.Struct(filter); err != nil {
+		return exception.ErrValidateBadRequest(err.Error(), filter)
+	}
+
+	// the claims of the logged in user
+	userID, _ := helper.ParseJwt(ctx.Cookies("token"))
+
+	claimResponses, totalData, err := controller.expenseClaimService.FindMyClaims(ctx.Context(), userID, filter)
+	if err != nil {
+		return err
+	}
+
+	return expenseClaimsResponse(ctx, filter, claimResponses, totalData)
+}
+
+func (controller *expenseClaimController) FindPendingApprovals(ctx *fiber.Ctx) error {
+	// parse query params
+	var filter web.ExpenseClaimQueryFilter
+	if err := ctx.QueryParser(&filter); err != nil {
+		return exception.ErrValidateBadRequest(err.Error(), filter)
+	}
+	// validate the values of the query params
+	if err := controller.validate.Struct(filter); err != nil {
+		return exception.ErrValidateBadRequest(err.Error(), filter)
+	}
+
+	// the claims waiting for the approval of the logged in user
+	userID, _ := helper.ParseJwt(ctx.Cookies("token"))
+
+	claimResponses, totalData, err := controller.expenseClaimService.FindPendingApprovals(ctx.Context(), userID, filter)
+	if err != nil {
+		return err
+	}
+
+	return expenseClaimsResponse(ctx, filter, claimResponses, totalData)
+}
+
+func (controller *expenseClaimController) FindByID(ctx *fiber.Ctx) error {
+	// parse path params
+	claimID := ctx.Params("claim_id")
+
+	claimResponse, err := controller.expenseClaimService.FindClaimById(ctx.Context(), claimID)
+	if err != nil {
+		return err
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(web.WebResponse{
+		Code:    fiber.StatusOK,
+		Status:  true,
+		Message: "success",
+		Data:    claimResponse,
+	})
+}
+
+func expenseClaimsResponse(ctx *fiber.Ctx, filter web.ExpenseClaimQueryFilter, claimResponses []web.ExpenseClaimResponse, totalData int) error {
+	if filter.Page != "" || filter.Limit != "" {
+		pageInt, _ := strconv.Atoi(filter.Page)
+		if filter.Page == "" {
+			pageInt = 1
+		}
+		return ctx.Status(fiber.StatusOK).JSON(web.WebResponsePagination{
+			Code:      fiber.StatusOK,
+			Status:    true,
+			Page:      pageInt,
+			Count:     len(claimResponses),
+			TotalData: totalData,
+			Message:   "success",
+			Data:      claimResponses,
+		})
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(web.WebResponse{
+		Code:    fiber.StatusOK,
+		Status:  true,
+		Message: "success",
+		Data:    claimResponses,
+	})
+}
+
+func itemSeq(ctx *fiber.Ctx) (int, error) {
+	seq, err := strconv.Atoi(ctx.Params("seq"))
+	if err != nil || seq < 1 {
+		return 0, exception.ErrBadRequest("Seq must be a positive number.")
+	}
+	return seq, nil
+}
+
+func receiptFile(ctx *fiber.Ctx) (web.DocumentFile, error) {
+	fileHeader, err := ctx.FormFile("file")
+	if err != nil {
+		return web.DocumentFile{}, exception.ErrBadRequest("The receipt is required.")
+	}
+	if fileHeader.Size > int64(config.ExpenseReceiptMaxSizeMB)*1024*1024 {
+		return web.DocumentFile{}, exception.ErrBadRequest(fmt.Sprintf("The receipt can't be larger than %d MB.", config.ExpenseReceiptMaxSizeMB))
+	}
+
+	file, err := fileHeader.Open()
+	if err != nil {
+		return web.DocumentFile{}, err
+	}
+	defer file.Close()
+
+	data, err := io.ReadAll(file)
+	if err != nil {
+		return web.DocumentFile{}, err
+	}
+
+	return web.DocumentFile{Name: fileHeader.Filename, Data: data}, nil
+}
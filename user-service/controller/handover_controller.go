@@ -0,0 +1,319 @@
+package controller
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/gofiber/fiber/v2"
+	"github.com/iqbaludinm/hr-microservice/user-service/config"
+	"github.com/iqbaludinm/hr-microservice/user-service/exception"
+	"github.com/iqbaludinm/hr-microservice/user-service/helper"
+	"github.com/iqbaludinm/hr-microservice/user-service/middleware"
+	"github.com/iqbaludinm/hr-microservice/user-service/model/web"
+	"github.com/iqbaludinm/hr-microservice/user-service/service"
+)
+
+type HandoverController interface {
+	// This function is used to define the route of the endpoints in this controller to the main application
+	Route(app *fiber.App)
+	CreateHandover(ctx *fiber.Ctx) error
+	UpdateHandover(ctx *fiber.Ctx) error
+	SubmitHandover(ctx *fiber.Ctx) error
+	SignHandover(ctx *fiber.Ctx) error
+	RejectHandover(ctx *fiber.Ctx) error
+	CancelHandover(ctx *fiber.Ctx) error
+	DownloadDocument(ctx *fiber.Ctx) error
+	FindAllHandover(ctx *fiber.Ctx) error
+	FindPendingSignatures(ctx *fiber.Ctx) error
+	FindHandoverByID(ctx *fiber.Ctx) error
+}
+
+type handoverController struct {
+	validate        *validator.Validate
+	handoverService service.HandoverService
+}
+
+func NewHandoverController(validate *validator.Validate, handoverService service.HandoverService) HandoverController {
+	return &handoverController{
+		validate:        validate,
+		handoverService: handoverService,
+	}
+}
+
+// The handover documents (BAST), the list endpoint is the document register.
+func (controller *handoverController) Route(app *fiber.App) {
+	api := app.Group(config.EndpointPrefixHandover, middleware.IsAuthenticated)
+
+	api.Post("/", controller.CreateHandover)
+	api.Get("/", controller.FindAllHandover)
+	api.Get("/pending-signature", controller.FindPendingSignatures)
+	api.Get("/:handover_id", controller.FindHandoverByID)
+	api.Put("/:handover_id", controller.UpdateHandover)
+	api.Post("/:handover_id/submit", controller.SubmitHandover)
+	api.Post("/:handover_id/sign", controller.SignHandover)
+	api.Post("/:handover_id/reject", controller.RejectHandover)
+	api.Post("/:handover_id/cancel", controller.CancelHandover)
+	api.Get("/:handover_id/document", controller.DownloadDocument)
+}
+
+func (controller *handoverController) CreateHandover(ctx *fiber.Ctx) error {
+	// parse request body
+	var request web.HandoverRequest
+	if err := ctx.BodyParser(&request); err != nil {
+		return exception.ErrValidateBadRequest(err.Error(), request)
+	}
+	// validate the values of request body
+	if err := controller.validate.Struct(request); err != nil {
+		return exception.ErrValidateBadRequest(err.Error(), request)
+	}
+	// the handover is created by the logged in user
+	userID, _ := helper.ParseJwt(ctx.Cookies("token"))
+
+	// create handover
+	handoverResponse, err := controller.handoverService.CreateHandover(ctx.Context(), userID, request)
+	if err != nil {
+		return err
+	}
+
+	return ctx.Status(fiber.StatusCreated).JSON(web.WebResponse{
+		Code:    fiber.StatusCreated,
+		Status:  true,
+		Message: "success",
+		Data:    handoverResponse,
+	})
+}
+
+func (controller *handoverController) UpdateHandover(ctx *fiber.Ctx) error {
+	// parse request body
+	var request web.HandoverRequest
+	if err := ctx.BodyParser(&request); err != nil {
+		return exception.ErrValidateBadRequest(err.Error(), request)
+	}
+	// validate the values of request body
+	if err := controller.validate.Struct(request); err != nil {
+		return exception.ErrValidateBadRequest(err.Error(), request)
+	}
+
+	// parse path params
+	handoverID := ctx.Params("handover_id")
+	userID, _ := helper.ParseJwt(ctx.Cookies("token"))
+
+	// update the draft handover
+	handoverResponse, err := controller.handoverService.UpdateHandover(ctx.Context(), userID, handoverID, request)
+	if err != nil {
+		return err
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(web.WebResponse{
+		Code:    fiber.StatusOK,
+		Status:  true,
+		Message: "success",
+		Data:    handoverResponse,
+	})
+}
+
+func (controller *handoverController) SubmitHandover(ctx *fiber.Ctx) error {
+	// parse path params
+	handoverID := ctx.Params("handover_id")
+	userID, _ := helper.ParseJwt(ctx.Cookies("token"))
+
+	// submit the handover to the signers
+	handoverResponse, err := controller.handoverService.Submit(ctx.Context(), userID, handoverID)
+	if err != nil {
+		return err
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(web.WebResponse{
+		Code:    fiber.StatusOK,
+		Status:  true,
+		Message: "success",
+		Data:    handoverResponse,
+	})
+}
+
+func (controller *handoverController) SignHandover(ctx *fiber.Ctx) error {
+	// parse request body
+	var request web.SignHandoverRequest
+	if err := controller.parseSignature(ctx, &request); err != nil {
+		return err
+	}
+
+	// parse path params
+	handoverID := ctx.Params("handover_id")
+	userID, _ := helper.ParseJwt(ctx.Cookies("token"))
+
+	// sign handover by the next signer
+	handoverResponse, err := controller.handoverService.Sign(ctx.Context(), userID, handoverID, request)
+	if err != nil {
+		return err
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(web.WebResponse{
+		Code:    fiber.StatusOK,
+		Status:  true,
+		Message: "success",
+		Data:    handoverResponse,
+	})
+}
+
+func (controller *handoverController) RejectHandover(ctx *fiber.Ctx) error {
+	// parse request body
+	var request web.SignHandoverRequest
+	if err := controller.parseSignature(ctx, &request); err != nil {
+		return err
+	}
+
+	// parse path params
+	handoverID := ctx.Params("handover_id")
+	userID, _ := helper.ParseJwt(ctx.Cookies("token"))
+
+	// reject handover by the next signer
+	handoverResponse, err := controller.handoverService.Reject(ctx.Context(), userID, handoverID, request)
+	if err != nil {
+		return err
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(web.WebResponse{
+		Code:    fiber.StatusOK,
+		Status:  true,
+		Message: "success",
+		Data:    handoverResponse,
+	})
+}
+
+func (controller *handoverController) CancelHandover(ctx *fiber.Ctx) error {
+	// parse path params
+	handoverID := ctx.Params("handover_id")
+	userID, _ := helper.ParseJwt(ctx.Cookies("token"))
+
+	// cancel handover
+	handoverResponse, err := controller.handoverService.Cancel(ctx.Context(), userID, handoverID)
+	if err != nil {
+		return err
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(web.WebResponse{
+		Code:    fiber.StatusOK,
+		Status:  true,
+		Message: "success",
+		Data:    handoverResponse,
+	})
+}
+
+func (controller *handoverController) DownloadDocument(ctx *fiber.Ctx) error {
+	// parse path params
+	handoverID := ctx.Params("handover_id")
+
+	pdf, fileName, err := controller.handoverService.Document(ctx.Context(), handoverID)
+	if err != nil {
+		return err
+	}
+
+	ctx.Set(fiber.HeaderContentType, "application/pdf")
+	ctx.Set(fiber.HeaderContentDisposition, fmt.Sprintf("attachment; filename=%s", fileName))
+
+	return ctx.Status(fiber.StatusOK).Send(pdf)
+}
+
+func (controller *handoverController) FindAllHandover(ctx *fiber.Ctx) error {
+	// parse query params
+	filter, err := controller.parseFilter(ctx)
+	if err != nil {
+		return err
+	}
+
+	handoverResponses, totalData, err := controller.handoverService.FindAllHandover(ctx.Context(), filter)
+	if err != nil {
+		return err
+	}
+
+	return controller.listResponse(ctx, filter, handoverResponses, totalData)
+}
+
+func (controller *handoverController) FindPendingSignatures(ctx *fiber.Ctx) error {
+	// parse query params
+	filter, err := controller.parseFilter(ctx)
+	if err != nil {
+		return err
+	}
+	userID, _ := helper.ParseJwt(ctx.Cookies("token"))
+
+	handoverResponses, totalData, err := controller.handoverService.FindPendingSignatures(ctx.Context(), userID, filter)
+	if err != nil {
+		return err
+	}
+
+	return controller.listResponse(ctx, filter, handoverResponses, totalData)
+}
+
+func (controller *handoverController) FindHandoverByID(ctx *fiber.Ctx) error {
+	// parse path params
+	handoverID := ctx.Params("handover_id")
+
+	handover, err := controller.handoverService.FindById(ctx.Context(), handoverID)
+	if err != nil {
+		return err
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(web.WebResponse{
+		Code:    fiber.StatusOK,
+		Status:  true,
+		Message: "success",
+		Data:    handover,
+	})
+}
+
+// parse and validate the request body of signing or rejecting a handover
+func (controller *handoverController) parseSignature(ctx *fiber.Ctx, request *web.SignHandoverRequest) error {
+	// the note is optional, so an empty body is allowed
+	if len(ctx.Body()) > 0 {
+		if err := ctx.BodyParser(request); err != nil {
+			return exception.ErrValidateBadRequest(err.Error(), request)
+		}
+	}
+	if err := controller.validate.Struct(request); err != nil {
+		return exception.ErrValidateBadRequest(err.Error(), request)
+	}
+
+	return nil
+}
+
+// parse and validate the query params of the handover list
+func (controller *handoverController) parseFilter(ctx *fiber.Ctx) (web.HandoverQueryFilter, error) {
+	var filter web.HandoverQueryFilter
+	if err := ctx.QueryParser(&filter); err != nil {
+		return filter, exception.ErrValidateBadRequest(err.Error(), filter)
+	}
+	if err := controller.validate.Struct(filter); err != nil {
+		return filter, exception.ErrValidateBadRequest(err.Error(), filter)
+	}
+
+	return filter, nil
+}
+
+// write the handover list, with the pagination when the page or limit is filled
+func (controller *handoverController) listResponse(ctx *fiber.Ctx, filter web.HandoverQueryFilter, handoverResponses []web.HandoverResponse, totalData int) error {
+	if filter.Page != "" || filter.Limit != "" {
+		pageInt, _ := strconv.Atoi(filter.Page)
+		if filter.Page == "" {
+			pageInt = 1
+		}
+		return ctx.Status(fiber.StatusOK).JSON(web.WebResponsePagination{
+			Code:      fiber.StatusOK,
+			Status:    true,
+			Page:      pageInt,
+			Count:     len(handoverResponses),
+			TotalData: totalData,
+			Message:   "success",
+			Data:      handoverResponses,
+		})
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(web.WebResponse{
+		Code:    fiber.StatusOK,
+		Status:  true,
+		Message: "success",
+		Data:    handoverResponses,
+	})
+}
@@ -0,0 +1,136 @@
+package controller
+
+import (
+	"github.com/go-playground/validator/v10"
+	"github.com/gofiber/fiber/v2"
+	"github.com/iqbaludinm/hr-microservice/user-service/config"
+	"github.com/iqbaludinm/hr-microservice/user-service/exception"
+	"github.com/iqbaludinm/hr-microservice/user-service/middleware"
+	"github.com/iqbaludinm/hr-microservice/user-service/model/web"
+	"github.com/iqbaludinm/hr-microservice/user-service/service"
+)
+
+type HolidayController interface {
+	// This function is used to define the route of the endpoints in this controller to the main application
+	Route(app *fiber.App)
+	CreateHoliday(ctx *fiber.Ctx) error
+	ImportHolidays(ctx *fiber.Ctx) error
+	DeleteHoliday(ctx *fiber.Ctx) error
+	FindAllHoliday(ctx *fiber.Ctx) error
+}
+
+type holidayController struct {
+	validate       *validator.Validate
+	holidayService service.HolidayService
+}
+
+func NewHolidayController(validate *validator.Validate, holidayService service.HolidayService) HolidayController {
+	return &holidayController{
+		validate:       validate,
+		holidayService: holidayService,
+	}
+}
+
+func (controller *holidayController) Route(app *fiber.App) {
+	api := app.Group(config.EndpointPrefixHoliday, middleware.IsAuthenticated)
+
+	api.Post("/", controller.CreateHoliday)
+	api.Post("/import", controller.ImportHolidays)
+	api.Get("/", controller.FindAllHoliday)
+	api.Delete("/:holiday_id", controller.DeleteHoliday)
+}
+
+func (controller *holidayController) CreateHoliday(ctx *fiber.Ctx) error {
+	// parse request body
+	var request web.CreateHolidayRequest
+	err := ctx.BodyParser(&request)
+	if err != nil {
+		return exception.ErrValidateBadRequest(err.Error(), request)
+	}
+	// validate the values of the request body
+	err = controller.validate.Struct(request)
+	if err != nil {
+		return exception.ErrValidateBadRequest(err.Error(), request)
+	}
+
+	// create holiday
+	holidayResponse, err := controller.holidayService.CreateHoliday(ctx.Context(), request)
+	if err != nil {
+		return err
+	}
+
+	return ctx.Status(fiber.StatusCreated).JSON(web.WebResponse{
+		Code:    fiber.StatusCreated,
+		Status:  true,
+		Message: "success",
+		Data:    holidayResponse,
+	})
+}
+
+func (controller *holidayController) ImportHolidays(ctx *fiber.Ctx) error {
+	// parse request form
+	var request web.ImportHolidayRequest
+	err := ctx.BodyParser(&request)
+	if err != nil {
+		return exception.ErrValidateBadRequest(err.Error(), request)
+	}
+	// validate the values of the request form
+	err = controller.validate.Struct(request)
+	if err != nil {
+		return exception.ErrValidateBadRequest(err.Error(), request)
+	}
+	file, _ := ctx.FormFile("file")
+
+	// import holidays
+	importResponse, err := controller.holidayService.ImportHolidays(ctx.Context(), request, file)
+	if err != nil {
+		return err
+	}
+
+	return ctx.Status(fiber.StatusCreated).JSON(web.WebResponse{
+		Code:    fiber.StatusCreated,
+		Status:  true,
+		Message: "success",
+		Data:    importResponse,
+	})
+}
+
+func (controller *holidayController) DeleteHoliday(ctx *fiber.Ctx) error {
+	// parse path params
+	holidayID := ctx.Params("holiday_id")
+
+	// delete holiday
+	err := controller.holidayService.Delete(ctx.Context(), holidayID)
+	if err != nil {
+		return err
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(web.WebResponse{
+		Code:    fiber.StatusOK,
+		Status:  true,
+		Message: "success",
+	})
+}
+
+func (controller *holidayController) FindAllHoliday(ctx *fiber.Ctx) error {
+	// parse query params
+	var filter web.HolidayQueryFilter
+	if err := ctx.QueryParser(&filter); err != nil {
+		return exception.ErrValidateBadRequest(err.Error(), filter)
+	}
+	if err := controller.validate.Struct(filter); err != nil {
+		return exception.ErrValidateBadRequest(err.Error(), filter)
+	}
+
+	holidayResponses, err := controller.holidayService.FindAllHoliday(ctx.Context(), filter)
+	if err != nil {
+		return err
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(web.WebResponse{
+		Code:    fiber.StatusOK,
+		Status:  true,
+		Message: "success",
+		Data:    holidayResponses,
+	})
+}
@@ -0,0 +1,419 @@
+package controller
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/gofiber/fiber/v2"
+	"github.com/iqbaludinm/hr-microservice/user-service/config"
+	"github.com/iqbaludinm/hr-microservice/user-service/exception"
+	"github.com/iqbaludinm/hr-microservice/user-service/helper"
+	"github.com/iqbaludinm/hr-microservice/user-service/middleware"
+	"github.com/iqbaludinm/hr-microservice/user-service/model/web"
+	"github.com/iqbaludinm/hr-microservice/user-service/service"
+)
+
+type JobApplicationController interface {
+	// This function is used to define the route of the endpoints in this controller to the main application
+	Route(app *fiber.App)
+	MoveStage(ctx *fiber.Ctx) error
+	Close(ctx *fiber.Ctx) error
+	ScheduleInterview(ctx *fiber.Ctx) error
+	SubmitScorecard(ctx *fiber.Ctx) error
+	CancelInterview(ctx *fiber.Ctx) error
+	CreateOffer(ctx *fiber.Ctx) error
+	SendOffer(ctx *fiber.Ctx) error
+	RespondOffer(ctx *fiber.Ctx) error
+	CancelOffer(ctx *fiber.Ctx) error
+	Hire(ctx *fiber.Ctx) error
+	FindAll(ctx *fiber.Ctx) error
+	FindByID(ctx *fiber.Ctx) error
+	DownloadCV(ctx *fiber.Ctx) error
+	FindMyInterviews(ctx *fiber.Ctx) error
+	DownloadOffer(ctx *fiber.Ctx) error
+}
+
+type jobApplicationController struct {
+	validate              *validator.Validate
+	jobApplicationService service.JobApplicationService
+}
+
+func NewJobApplicationController(validate *validator.Validate, jobApplicationService service.JobApplicationService) JobApplicationController {
+	return &jobApplicationController{
+		validate:              validate,
+		jobApplicationService: jobApplicationService,
+	}
+}
+
+func (controller *jobApplicationController) Route(app *fiber.App) {
+	api := app.Group(config.EndpointPrefixJobApplication, middleware.IsAuthenticated)
+
+	api.Get("/", controller.FindAll)
+	api.Get("/interviews/me", controller.FindMyInterviews)
+	api.Put("/interviews/:interview_id/scorecard", controller.SubmitScorecard)
+	api.Put("/interviews/:interview_id/cancel", controller.CancelInterview)
+	api.Get("/offers/:offer_id/document", controller.DownloadOffer)
+	api.Put("/offers/:offer_id/send", controller.SendOffer)
+	api.Put("/offers/:offer_id/response", controller.RespondOffer)
+	api.Put("/offers/:offer_id/cancel", controller.CancelOffer)
+	api.Get("/:application_id", controller.FindByID)
+	api.Get("/:application_id/cv", controller.DownloadCV)
+	api.Put("/:application_id/stage", controller.MoveStage)
+	api.Put("/:application_id/close", controller.Close)
+	api.Post("/:application_id/interviews", controller.ScheduleInterview)
+	api.Post("/:application_id/offers", controller.CreateOffer)
+	api.Put("/:application_id/hire", controller.Hire)
+}
+
+func (controller *jobApplicationController) MoveStage(ctx *fiber.Ctx) error {
+	// parse request body
+	var request web.MoveJobApplicationRequest
+	if err := ctx.BodyParser(&request); err != nil {
+		return exception.ErrValidateBadRequest(err.Error(), request)
+	}
+	// validate the values of request body
+	if err := controller.validate.Struct(request); err != nil {
+		return exception.ErrValidateBadRequest(err.Error(), request)
+	}
+
+	// parse path params
+	applicationID := ctx.Params("application_id")
+	// the application is moved by the logged in user
+	userID, _ := helper.ParseJwt(ctx.Cookies("token"))
+
+	applicationResponse, err := controller.jobApplicationService.MoveStage(ctx.Context(), userID, applicationID, request)
+	if err != nil {
+		return err
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(web.WebResponse{
+		Code:    fiber.StatusOK,
+		Status:  true,
+		Message: "success",
+		Data:    applicationResponse,
+	})
+}
+
+func (controller *jobApplicationController) Close(ctx *fiber.Ctx) error {
+	// parse request body
+	var request web.CloseJobApplicationRequest
+	if err := ctx.BodyParser(&request); err != nil {
+		return exception.ErrValidateBadRequest(err.Error(), request)
+	}
+	// validate the values of request body
+	if err := controller.validate.Struct(request); err != nil {
+		return exception.ErrValidateBadRequest(err.Error(), request)
+	}
+
+	// parse path params
+	applicationID := ctx.Params("application_id")
+
+	applicationResponse, err := controller.jobApplicationService.Close(ctx.Context(), applicationID, request)
+	if err != nil {
+		return err
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(web.WebResponse{
+		Code:    fiber.StatusOK,
+		Status:  true,
+		Message: "success",
+		Data:    applicationResponse,
+	})
+}
+
+func (controller *jobApplicationController) ScheduleInterview(ctx *fiber.Ctx) error {
+	// parse request body
+	var request web.InterviewRequest
+	if err := ctx.BodyParser(&request); err != nil {
+		return exception.ErrValidateBadRequest(err.Error(), request)
+	}
+	// validate the values of request body
+	if err := controller.validate.Struct(request); err != nil {
+		return exception.ErrValidateBadRequest(err.Error(), request)
+	}
+
+	// parse path params
+	applicationID := ctx.Params("application_id")
+	// the interview is scheduled by the logged in user
+	userID, _ := helper.ParseJwt(ctx.Cookies("token"))
+
+	interviewResponse, err := controller.jobApplicationService.ScheduleInterview(ctx.Context(), userID, applicationID, request)
+	if err != nil {
+		return err
+	}
+
+	return ctx.Status(fiber.StatusCreated).JSON(web.WebResponse{
+		Code:    fiber.StatusCreated,
+		Status:  true,
+		Message: "success",
+		Data:    interviewResponse,
+	})
+}
+
+func (controller *jobApplicationController) SubmitScorecard(ctx *fiber.Ctx) error {
+	// parse request body
+	var request web.InterviewScorecardRequest
+	if err := ctx.BodyParser(&request); err != nil {
+		return exception.ErrValidateBadRequest(err.Error(), request)
+	}
+	// validate the values of request body
+	if err := controller.validate.Struct(request); err != nil {
+		return exception.ErrValidateBadRequest(err.Error(), request)
+	}
+
+	// parse path params
+	interviewID := ctx.Params("interview_id")
+	// only the interviewer can submit the scorecard of the interview
+	userID, _ := helper.ParseJwt(ctx.Cookies("token"))
+
+	interviewResponse, err := controller.jobApplicationService.SubmitScorecard(ctx.Context(), userID, interviewID, request)
+	if err != nil {
+		return err
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(web.WebResponse{
+		Code:    fiber.StatusOK,
+		Status:  true,
+		Message: "success",
+		Data:    interviewResponse,
+	})
+}
+
+func (controller *jobApplicationController) CancelInterview(ctx *fiber.Ctx) error {
+	// parse path params
+	interviewID := ctx.Params("interview_id")
+
+	interviewResponse, err := controller.jobApplicationService.CancelInterview(ctx.Context(), interviewID)
+	if err != nil {
+		return err
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(web.WebResponse{
+		Code:    fiber.StatusOK,
+		Status:  true,
+		Message: "success",
+		Data:    interviewResponse,
+	})
+}
+
+func (controller *jobApplicationController) CreateOffer(ctx *fiber.Ctx) error {
+	// parse request body
+	var request web.JobOfferRequest
+	if err := ctx.BodyParser(&request); err != nil {
+		return exception.ErrValidateBadRequest(err.Error(), request)
+	}
+	// validate the values of request body
+	if err := controller.validate.Struct(request); err != nil {
+		return exception.ErrValidateBadRequest(err.Error(), request)
+	}
+
+	// parse path params
+	applicationID := ctx.Params("application_id")
+	// the offer is created by the logged in user
+	userID, _ := helper.ParseJwt(ctx.Cookies("token"))
+
+	offerResponse, err := controller.jobApplicationService.CreateOffer(ctx.Context(), userID, applicationID, request)
+	if err != nil {
+		return err
+	}
+
+	return ctx.Status(fiber.StatusCreated).JSON(web.WebResponse{
+		Code:    fiber.StatusCreated,
+		Status:  true,
+		Message: "success",
+		Data:    offerResponse,
+	})
+}
+
+func (controller *jobApplicationController) SendOffer(ctx *fiber.Ctx) error {
+	// parse path params
+	offerID := ctx.Params("offer_id")
+
+	offerResponse, err := controller.jobApplicationService.SendOffer(ctx.Context(), offerID)
+	if err != nil {
+		return err
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(web.WebResponse{
+		Code:    fiber.StatusOK,
+		Status:  true,
+		Message: "success",
+		Data:    offerResponse,
+	})
+}
+
+func (controller *jobApplicationController) RespondOffer(ctx *fiber.Ctx) error {
+	// parse request body
+	var request web.JobOfferResponseRequest
+	if err := ctx.BodyParser(&request); err != nil {
+		return exception.ErrValidateBadRequest(err.Error(), request)
+	}
+	// validate the values of request body
+	if err := controller.validate.Struct(request); err != nil {
+		return exception.ErrValidateBadRequest(err.Error(), request)
+	}
+
+	// parse path params
+	offerID := ctx.Params("offer_id")
+
+	offerResponse, err := controller.jobApplicationService.RespondOffer(ctx.Context(), offerID, request)
+	if err != nil {
+		return err
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(web.WebResponse{
+		Code:    fiber.StatusOK,
+		Status:  true,
+		Message: "success",
+		Data:    offerResponse,
+	})
+}
+
+func (controller *jobApplicationController) CancelOffer(ctx *fiber.Ctx) error {
+	// parse path params
+	offerID := ctx.Params("offer_id")
+
+	offerResponse, err := controller.jobApplicationService.CancelOffer(ctx.Context(), offerID)
+	if err != nil {
+		return err
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(web.WebResponse{
+		Code:    fiber.StatusOK,
+		Status:  true,
+		Message: "success",
+		Data:    offerResponse,
+	})
+}
+
+func (controller *jobApplicationController) Hire(ctx *fiber.Ctx) error {
+	// parse request body
+	var request web.HireRequest
+	if err := ctx.BodyParser(&request); err != nil {
+		return exception.ErrValidateBadRequest(err.Error(), request)
+	}
+	// validate the values of request body
+	if err := controller.validate.Struct(request); err != nil {
+		return exception.ErrValidateBadRequest(err.Error(), request)
+	}
+
+	// parse path params
+	applicationID := ctx.Params("application_id")
+
+	applicationResponse, err := controller.jobApplicationService.Hire(ctx.Context(), applicationID, request)
+	if err != nil {
+		return err
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(web.WebResponse{
+		Code:    fiber.StatusOK,
+		Status:  true,
+		Message: "success",
+		Data:    applicationResponse,
+	})
+}
+
+func (controller *jobApplicationController) FindAll(ctx *fiber.Ctx) error {
+	// parse query params
+	var filter web.JobApplicationQueryFilter
+	if err := ctx.QueryParser(&filter); err != nil {
+		return exception.ErrValidateBadRequest(err.Error(), filter)
+	}
+	// validate the values of the query params
+	if err := controller.validate.Struct(filter); err != nil {
+		return exception.ErrValidateBadRequest(err.Error(), filter)
+	}
+
+	applicationResponses, totalData, err := controller.jobApplicationService.FindAll(ctx.Context(), filter)
+	if err != nil {
+		return err
+	}
+
+	if filter.Page != "" || filter.Limit != "" {
+		pageInt, _ := strconv.Atoi(filter.Page)
+		if filter.Page == "" {
+			pageInt = 1
+		}
+		return ctx.Status(fiber.StatusOK).JSON(web.WebResponsePagination{
+			Code:      fiber.StatusOK,
+			Status:    true,
+			Page:      pageInt,
+			Count:     len(applicationResponses),
+			TotalData: totalData,
+			Message:   "success",
+			Data:      applicationResponses,
+		})
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(web.WebResponse{
+		Code:    fiber.StatusOK,
+		Status:  true,
+		Message: "success",
+		Data:    applicationResponses,
+	})
+}
+
+func (controller *jobApplicationController) FindByID(ctx *fiber.Ctx) error {
+	// parse path params
+	applicationID := ctx.Params("application_id")
+
+	applicationResponse, err := controller.jobApplicationService.FindById(ctx.Context(), applicationID)
+	if err != nil {
+		return err
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(web.WebResponse{
+		Code:    fiber.StatusOK,
+		Status:  true,
+		Message: "success",
+		Data:    applicationResponse,
+	})
+}
+
+func (controller *jobApplicationController) DownloadCV(ctx *fiber.Ctx) error {
+	// parse path params
+	applicationID := ctx.Params("application_id")
+
+	data, fileName, mimeType, err := controller.jobApplicationService.CV(ctx.Context(), applicationID)
+	if err != nil {
+		return err
+	}
+
+	ctx.Set(fiber.HeaderContentType, mimeType)
+	ctx.Set(fiber.HeaderContentDisposition, fmt.Sprintf("attachment; filename=%s", fileName))
+
+	return ctx.Status(fiber.StatusOK).Send(data)
+}
+
+func (controller *jobApplicationController) FindMyInterviews(ctx *fiber.Ctx) error {
+	// the upcoming interviews of the logged in user as the interviewer
+	userID, _ := helper.ParseJwt(ctx.Cookies("token"))
+
+	interviewResponses, err := controller.jobApplicationService.FindMyInterviews(ctx.Context(), userID)
+	if err != nil {
+		return err
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(web.WebResponse{
+		Code:    fiber.StatusOK,
+		Status:  true,
+		Message: "success",
+		Data:    interviewResponses,
+	})
+}
+
+func (controller *jobApplicationController) DownloadOffer(ctx *fiber.Ctx) error {
+	// parse path params
+	offerID := ctx.Params("offer_id")
+
+	data, fileName, err := controller.jobApplicationService.OfferDocument(ctx.Context(), offerID)
+	if err != nil {
+		return err
+	}
+
+	ctx.Set(fiber.HeaderContentType, "application/pdf")
+	ctx.Set(fiber.HeaderContentDisposition, fmt.Sprintf("attachment; filename=%s", fileName))
+
+	return ctx.Status(fiber.StatusOK).Send(data)
+}
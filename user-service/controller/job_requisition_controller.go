@@ -0,0 +1,334 @@
+package controller
+
+import (
+	"strconv"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/gofiber/fiber/v2"
+	"github.com/iqbaludinm/hr-microservice/user-service/config"
+	"github.com/iqbaludinm/hr-microservice/user-service/exception"
+	"github.com/iqbaludinm/hr-microservice/user-service/helper"
+	"github.com/iqbaludinm/hr-microservice/user-service/middleware"
+	"github.com/iqbaludinm/hr-microservice/user-service/model/web"
+	"github.com/iqbaludinm/hr-microservice/user-service/service"
+)
+
+type JobRequisitionController interface {
+	// This function is used to define the route of the endpoints in this controller to the main application
+	Route(app *fiber.App)
+	CreateRequisition(ctx *fiber.Ctx) error
+	UpdateRequisition(ctx *fiber.Ctx) error
+	SubmitRequisition(ctx *fiber.Ctx) error
+	ApproveRequisition(ctx *fiber.Ctx) error
+	RejectRequisition(ctx *fiber.Ctx) error
+	CancelRequisition(ctx *fiber.Ctx) error
+	PublishPosting(ctx *fiber.Ctx) error
+	ClosePosting(ctx *fiber.Ctx) error
+	FindAllRequisition(ctx *fiber.Ctx) error
+	FindPendingApprovals(ctx *fiber.Ctx) error
+	FindByID(ctx *fiber.Ctx) error
+}
+
+type jobRequisitionController struct {
+	validate              *validator.Validate
+	jobRequisitionService service.JobRequisitionService
+}
+
+func NewJobRequisitionController(validate *validator.Validate, jobRequisitionService service.JobRequisitionService) JobRequisitionController {
+	return &jobRequisitionController{
+		validate:              validate,
+		jobRequisitionService: jobRequisitionService,
+	}
+}
+
+func (controller *jobRequisitionController) Route(app *fiber.App) {
+	api := app.Group(config.EndpointPrefixJobRequisition, middleware.IsAuthenticated)
+
+	api.Get("/", controller.FindAllRequisition)
+	api.Post("/", controller.CreateRequisition)
+	api.Get("/approvals", controller.FindPendingApprovals)
+	api.Get("/:requisition_id", controller.FindByID)
+	api.Put("/:requisition_id", controller.UpdateRequisition)
+	api.Put("/:requisition_id/submit", controller.SubmitRequisition)
+	api.Put("/:requisition_id/approve", controller.ApproveRequisition)
+	api.Put("/:requisition_id/reject", controller.RejectRequisition)
+	api.Put("/:requisition_id/cancel", controller.CancelRequisition)
+	api.Post("/:requisition_id/posting", controller.PublishPosting)
+	api.Put("/:requisition_id/posting/close", controller.ClosePosting)
+}
+
+func (controller *jobRequisitionController) CreateRequisition(ctx *fiber.Ctx) error {
+	// parse request body
+	var request web.JobRequisitionRequest
+	if err := ctx.BodyParser(&request); err != nil {
+		return exception.ErrValidateBadRequest(err.Error(), request)
+	}
+	// validate the values of request body
+	if err := controller.validate.Struct(request); err != nil {
+		return exception.ErrValidateBadRequest(err.Error(), request)
+	}
+
+	// the job requisition is requested by the logged in user
+	userID, _ := helper.ParseJwt(ctx.Cookies("token"))
+
+	requisitionResponse, err := controller.jobRequisitionService.CreateRequisition(ctx.Context(), userID, request)
+	if err != nil {
+		return err
+	}
+
+	return ctx.Status(fiber.StatusCreated).JSON(web.WebResponse{
+		Code:    fiber.StatusCreated,
+		Status:  true,
+		Message: "success",
+		Data:    requisitionResponse,
+	})
+}
+
+func (controller *jobRequisitionController) UpdateRequisition(ctx *fiber.Ctx) error {
+	// parse request body
+	var request web.JobRequisitionRequest
+	if err := ctx.BodyParser(&request); err != nil {
+		return exception.ErrValidateBadRequest(err.Error(), request)
+	}
+	// validate the values of request body
+	if err := controller.validate.Struct(request); err != nil {
+		return exception.ErrValidateBadRequest(err.Error(), request)
+	}
+
+	// parse path params
+	requisitionID := ctx.Params("requisition_id")
+	userID, _ := helper.ParseJwt(ctx.Cookies("token"))
+
+	requisitionResponse, err := controller.jobRequisitionService.UpdateRequisition(ctx.Context(), userID, requisitionID, request)
+	if err != nil {
+		return err
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(web.WebResponse{
+		Code:    fiber.StatusOK,
+		Status:  true,
+		Message: "success",
+		Data:    requisitionResponse,
+	})
+}
+
+func (controller *jobRequisitionController) SubmitRequisition(ctx *fiber.Ctx) error {
+	// parse path params
+	requisitionID := ctx.Params("requisition_id")
+	userID, _ := helper.ParseJwt(ctx.Cookies("token"))
+
+	requisitionResponse, err := controller.jobRequisitionService.SubmitRequisition(ctx.Context(), userID, requisitionID)
+	if err != nil {
+		return err
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(web.WebResponse{
+		Code:    fiber.StatusOK,
+		Status:  true,
+		Message: "success",
+		Data:    requisitionResponse,
+	})
+}
+
+func (controller *jobRequisitionController) ApproveRequisition(ctx *fiber.Ctx) error {
+	// parse request body
+	var request web.DecideJobRequisitionRequest
+	if err := ctx.BodyParser(&request); err != nil {
+		return exception.ErrValidateBadRequest(err.Error(), request)
+	}
+	// validate the values of request body
+	if err := controller.validate.Struct(request); err != nil {
+		return exception.ErrValidateBadRequest(err.Error(), request)
+	}
+
+	// parse path params
+	requisitionID := ctx.Params("requisition_id")
+	// the job requisition is approved by the logged in user
+	userID, _ := helper.ParseJwt(ctx.Cookies("token"))
+
+	requisitionResponse, err := controller.jobRequisitionService.ApproveRequisition(ctx.Context(), userID, requisitionID, request)
+	if err != nil {
+		return err
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(web.WebResponse{
+		Code:    fiber.StatusOK,
+		Status:  true,
+		Message: "success",
+		Data:    requisitionResponse,
+	})
+}
+
+func (controller *jobRequisitionController) RejectRequisition(ctx *fiber.Ctx) error {
+	// parse request body
+	var request web.DecideJobRequisitionRequest
+	if err := ctx.BodyParser(&request); err != nil {
+		return exception.ErrValidateBadRequest(err.Error(), request)
+	}
+	// validate the values of request body
+	if err := controller.validate.Struct(request); err != nil {
+		return exception.ErrValidateBadRequest(err.Error(), request)
+	}
+
+	// parse path params
+	requisitionID := ctx.Params("requisition_id")
+	// the job requisition is rejected by the logged in user
+	userID, _ := helper.ParseJwt(ctx.Cookies("token"))
+
+	requisitionResponse, err := controller.jobRequisitionService.RejectRequisition(ctx.Context(), userID, requisitionID, request)
+	if err != nil {
+		return err
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(web.WebResponse{
+		Code:    fiber.StatusOK,
+		Status:  true,
+		Message: "success",
+		Data:    requisitionResponse,
+	})
+}
+
+func (controller *jobRequisitionController) CancelRequisition(ctx *fiber.Ctx) error {
+	// parse path params
+	requisitionID := ctx.Params("requisition_id")
+	userID, _ := helper.ParseJwt(ctx.Cookies("token"))
+
+	requisitionResponse, err := controller.jobRequisitionService.CancelRequisition(ctx.Context(), userID, requisitionID)
+	if err != nil {
+		return err
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(web.WebResponse{
+		Code:    fiber.StatusOK,
+		Status:  true,
+		Message: "success",
+		Data:    requisitionResponse,
+	})
+}
+
+func (controller *jobRequisitionController) PublishPosting(ctx *fiber.Ctx) error {
+	// parse request body
+	var request web.JobPostingRequest
+	if err := ctx.BodyParser(&request); err != nil {
+		return exception.ErrValidateBadRequest(err.Error(), request)
+	}
+	// validate the values of request body
+	if err := controller.validate.Struct(request); err != nil {
+		return exception.ErrValidateBadRequest(err.Error(), request)
+	}
+
+	// parse path params
+	requisitionID := ctx.Params("requisition_id")
+
+	requisitionResponse, err := controller.jobRequisitionService.PublishPosting(ctx.Context(), requisitionID, request)
+	if err != nil {
+		return err
+	}
+
+	return ctx.Status(fiber.StatusCreated).JSON(web.WebResponse{
+		Code:    fiber.StatusCreated,
+		Status:  true,
+		Message: "success",
+		Data:    requisitionResponse,
+	})
+}
+
+func (controller *jobRequisitionController) ClosePosting(ctx *fiber.Ctx) error {
+	// parse path params
+	requisitionID := ctx.Params("requisition_id")
+
+	requisitionResponse, err := controller.jobRequisitionService.ClosePosting(ctx.Context(), requisitionID)
+	if err != nil {
+		return err
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(web.WebResponse{
+		Code:    fiber.StatusOK,
+		Status:  true,
+		Message: "success",
+		Data:    requisitionResponse,
+	})
+}
+
+func (controller *jobRequisitionController) FindAllRequisition(ctx *fiber.Ctx) error {
+	// parse query params
+	var filter web.JobRequisitionQueryFilter
+	if err := ctx.QueryParser(&filter); err != nil {
+		return exception.ErrValidateBadRequest(err.Error(), filter)
+	}
+	// validate the values of the query params
+	if err := controller.validate.Struct(filter); err != nil {
+		return exception.ErrValidateBadRequest(err.Error(), filter)
+	}
+
+	requisitionResponses, totalData, err := controller.jobRequisitionService.FindAllRequisition(ctx.Context(), filter)
+	if err != nil {
+		return err
+	}
+
+	return jobRequisitionsResponse(ctx, filter, requisitionResponses, totalData)
+}
+
+func (controller *jobRequisitionController) FindPendingApprovals(ctx *fiber.Ctx) error {
+	// parse query params
+	var filter web.JobRequisitionQueryFilter
+	if err := ctx.QueryParser(&filter); err != nil {
+		return exception.ErrValidateBadRequest(err.Error(), filter)
+	}
+	// validate the values of the query params
+	if err := controller.validate.Struct(filter); err != nil {
+		return exception.ErrValidateBadRequest(err.Error(), filter)
+	}
+
+	// the job requisitions waiting for the approval of the logged in user
+	userID, _ := helper.ParseJwt(ctx.Cookies("token"))
+
+	requisitionResponses, totalData, err := controller.jobRequisitionService.FindPendingApprovals(ctx.Context(), userID, filter)
+	if err != nil {
+		return err
+	}
+
+	return jobRequisitionsResponse(ctx, filter, requisitionResponses, totalData)
+}
+
+func (controller *jobRequisitionController) FindByID(ctx *fiber.Ctx) error {
+	// parse path params
+	requisitionID := ctx.Params("requisition_id")
+
+	requisitionResponse, err := controller.jobRequisitionService.FindRequisitionById(ctx.Context(), requisitionID)
+	if err != nil {
+		return err
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(web.WebResponse{
+		Code:    fiber.StatusOK,
+		Status:  true,
+		Message: "success",
+		Data:    requisitionResponse,
+	})
+}
+
+func jobRequisitionsResponse(ctx *fiber.Ctx, filter web.JobRequisitionQueryFilter, requisitionResponses []web.JobRequisitionResponse, totalData int) error {
+	if filter.Page != "" || filter.Limit != "" {
+		pageInt, _ := strconv.Atoi(filter.Page)
+		if filter.Page == "" {
+			pageInt = 1
+		}
+		return ctx.Status(fiber.StatusOK).JSON(web.WebResponsePagination{
+			Code:      fiber.StatusOK,
+			Status:    true,
+			Page:      pageInt,
+			Count:     len(requisitionResponses),
+			TotalData: totalData,
+			Message:   "success",
+			Data:      requisitionResponses,
+		})
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(web.WebResponse{
+		Code:    fiber.StatusOK,
+		Status:  true,
+		Message: "success",
+		Data:    requisitionResponses,
+	})
+}
@@ -0,0 +1,317 @@
+package controller
+
+import (
+	"strconv"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/gofiber/fiber/v2"
+	"github.com/iqbaludinm/hr-microservice/user-service/config"
+	"github.com/iqbaludinm/hr-microservice/user-service/exception"
+	"github.com/iqbaludinm/hr-microservice/user-service/helper"
+	"github.com/iqbaludinm/hr-microservice/user-service/middleware"
+	"github.com/iqbaludinm/hr-microservice/user-service/model/web"
+	"github.com/iqbaludinm/hr-microservice/user-service/service"
+)
+
+type LeaveController interface {
+	// This function is used to define the route of the endpoints in this controller to the main application
+	Route(app *fiber.App)
+	CreateLeave(ctx *fiber.Ctx) error
+	Approve(ctx *fiber.Ctx) error
+	Reject(ctx *fiber.Ctx) error
+	Cancel(ctx *fiber.Ctx) error
+	FindAllLeave(ctx *fiber.Ctx) error
+	FindMyLeave(ctx *fiber.Ctx) error
+	FindPendingApprovals(ctx *fiber.Ctx) error
+	FindByID(ctx *fiber.Ctx) error
+	FindMyBalances(ctx *fiber.Ctx) error
+	FindBalances(ctx *fiber.Ctx) error
+}
+
+type leaveController struct {
+	validate     *validator.Validate
+	leaveService service.LeaveService
+}
+
+func NewLeaveController(validate *validator.Validate, leaveService service.LeaveService) LeaveController {
+	return &leaveController{
+		validate:     validate,
+		leaveService: leaveService,
+	}
+}
+
+func (controller *leaveController) Route(app *fiber.App) {
+	api := app.Group(config.EndpointPrefixLeave, middleware.IsAuthenticated)
+
+	api.Post("/", controller.CreateLeave)
+	api.Get("/", controller.FindAllLeave)
+	api.Get("/me", controller.FindMyLeave)
+	api.Get("/approvals", controller.FindPendingApprovals)
+	api.Get("/balances/me", controller.FindMyBalances)
+	api.Get("/balances/:employee_id", controller.FindBalances)
+	api.Get("/:leave_id", controller.FindByID)
+	api.Put("/:leave_id/approve", controller.Approve)
+	api.Put("/:leave_id/reject", controller.Reject)
+	api.Put("/:leave_id/cancel", controller.Cancel)
+}
+
+func (controller *leaveController) CreateLeave(ctx *fiber.Ctx) error {
+	// parse request body
+	var request web.CreateLeaveRequest
+	err := ctx.BodyParser(&request)
+	if err != nil {
+		return exception.ErrValidateBadRequest(err.Error(), request)
+	}
+	// validate the values of the request body
+	err = controller.validate.Struct(request)
+	if err != nil {
+		return exception.ErrValidateBadRequest(err.Error(), request)
+	}
+
+	// the leave is requested by the logged in user
+	userID, _ := helper.ParseJwt(ctx.Cookies("token"))
+
+	// create leave request
+	leaveResponse, err := controller.leaveService.CreateLeave(ctx.Context(), userID, request)
+	if err != nil {
+		return err
+	}
+
+	return ctx.Status(fiber.StatusCreated).JSON(web.WebResponse{
+		Code:    fiber.StatusCreated,
+		Status:  true,
+		Message: "success",
+		Data:    leaveResponse,
+	})
+}
+
+func (controller *leaveController) Approve(ctx *fiber.Ctx) error {
+	// parse request body
+	var request web.DecideLeaveRequest
+	if err := controller.parseDecision(ctx, &request); err != nil {
+		return err
+	}
+
+	// parse path params
+	leaveID := ctx.Params("leave_id")
+	userID, _ := helper.ParseJwt(ctx.Cookies("token"))
+
+	// approve leave request
+	leaveResponse, err := controller.leaveService.Approve(ctx.Context(), userID, leaveID, request)
+	if err != nil {
+		return err
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(web.WebResponse{
+		Code:    fiber.StatusOK,
+		Status:  true,
+		Message: "success",
+		Data:    leaveResponse,
+	})
+}
+
+func (controller *leaveController) Reject(ctx *fiber.Ctx) error {
+	// parse request body
+	var request web.DecideLeaveRequest
+	if err := controller.parseDecision(ctx, &request); err != nil {
+		return err
+	}
+
+	// parse path params
+	leaveID := ctx.Params("leave_id")
+	userID, _ := helper.ParseJwt(ctx.Cookies("token"))
+
+	// reject leave request
+	leaveResponse, err := controller.leaveService.Reject(ctx.Context(), userID, leaveID, request)
+	if err != nil {
+		return err
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(web.WebResponse{
+		Code:    fiber.StatusOK,
+		Status:  true,
+		Message: "success",
+		Data:    leaveResponse,
+	})
+}
+
+func (controller *leaveController) Cancel(ctx *fiber.Ctx) error {
+	// parse path params
+	leaveID := ctx.Params("leave_id")
+	userID, _ := helper.ParseJwt(ctx.Cookies("token"))
+
+	// cancel leave request
+	leaveResponse, err := controller.leaveService.Cancel(ctx.Context(), userID, leaveID)
+	if err != nil {
+		return err
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(web.WebResponse{
+		Code:    fiber.StatusOK,
+		Status:  true,
+		Message: "success",
+		Data:    leaveResponse,
+	})
+}
+
+func (controller *leaveController) FindAllLeave(ctx *fiber.Ctx) error {
+	// parse query params
+	filter, err := controller.parseFilter(ctx)
+	if err != nil {
+		return err
+	}
+
+	leaveResponses, totalData, err := controller.leaveService.FindAllLeave(ctx.Context(), filter)
+	if err != nil {
+		return err
+	}
+
+	return controller.leaveListResponse(ctx, filter, leaveResponses, totalData)
+}
+
+func (controller *leaveController) FindMyLeave(ctx *fiber.Ctx) error {
+	// parse query params
+	filter, err := controller.parseFilter(ctx)
+	if err != nil {
+		return err
+	}
+	userID, _ := helper.ParseJwt(ctx.Cookies("token"))
+
+	leaveResponses, totalData, err := controller.leaveService.FindMyLeave(ctx.Context(), userID, filter)
+	if err != nil {
+		return err
+	}
+
+	return controller.leaveListResponse(ctx, filter, leaveResponses, totalData)
+}
+
+func (controller *leaveController) FindPendingApprovals(ctx *fiber.Ctx) error {
+	// parse query params
+	filter, err := controller.parseFilter(ctx)
+	if err != nil {
+		return err
+	}
+	userID, _ := helper.ParseJwt(ctx.Cookies("token"))
+
+	leaveResponses, totalData, err := controller.leaveService.FindPendingApprovals(ctx.Context(), userID, filter)
+	if err != nil {
+		return err
+	}
+
+	return controller.leaveListResponse(ctx, filter, leaveResponses, totalData)
+}
+
+func (controller *leaveController) FindByID(ctx *fiber.Ctx) error {
+	// parse path params
+	leaveID := ctx.Params("leave_id")
+
+	leave, err := controller.leaveService.FindById(ctx.Context(), leaveID)
+	if err != nil {
+		return err
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(web.WebResponse{
+		Code:    fiber.StatusOK,
+		Status:  true,
+		Message: "success",
+		Data:    leave,
+	})
+}
+
+func (controller *leaveController) FindMyBalances(ctx *fiber.Ctx) error {
+	// parse query params
+	var filter web.LeaveBalanceQueryFilter
+	if err := ctx.QueryParser(&filter); err != nil {
+		return exception.ErrValidateBadRequest(err.Error(), filter)
+	}
+	userID, _ := helper.ParseJwt(ctx.Cookies("token"))
+
+	balanceResponses, err := controller.leaveService.FindMyBalances(ctx.Context(), userID, filter)
+	if err != nil {
+		return err
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(web.WebResponse{
+		Code:    fiber.StatusOK,
+		Status:  true,
+		Message: "success",
+		Data:    balanceResponses,
+	})
+}
+
+func (controller *leaveController) FindBalances(ctx *fiber.Ctx) error {
+	// parse query params
+	var filter web.LeaveBalanceQueryFilter
+	if err := ctx.QueryParser(&filter); err != nil {
+		return exception.ErrValidateBadRequest(err.Error(), filter)
+	}
+
+	// parse path params
+	employeeID := ctx.Params("employee_id")
+
+	balanceResponses, err := controller.leaveService.FindBalances(ctx.Context(), employeeID, filter)
+	if err != nil {
+		return err
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(web.WebResponse{
+		Code:    fiber.StatusOK,
+		Status:  true,
+		Message: "success",
+		Data:    balanceResponses,
+	})
+}
+
+// parse and validate the request body of approving or rejecting a leave request
+func (controller *leaveController) parseDecision(ctx *fiber.Ctx, request *web.DecideLeaveRequest) error {
+	// the note is optional, so an empty body is allowed
+	if len(ctx.Body()) > 0 {
+		if err := ctx.BodyParser(request); err != nil {
+			return exception.ErrValidateBadRequest(err.Error(), request)
+		}
+	}
+	if err := controller.validate.Struct(request); err != nil {
+		return exception.ErrValidateBadRequest(err.Error(), request)
+	}
+
+	return nil
+}
+
+// parse and validate the query params of the leave request list
+func (controller *leaveController) parseFilter(ctx *fiber.Ctx) (web.LeaveQueryFilter, error) {
+	var filter web.LeaveQueryFilter
+	if err := ctx.QueryParser(&filter); err != nil {
+		return filter, exception.ErrValidateBadRequest(err.Error(), filter)
+	}
+	if err := controller.validate.Struct(filter); err != nil {
+		return filter, exception.ErrValidateBadRequest(err.Error(), filter)
+	}
+
+	return filter, nil
+}
+
+// write the leave request list, with the pagination when the page or limit is filled
+func (controller *leaveController) leaveListResponse(ctx *fiber.Ctx, filter web.LeaveQueryFilter, leaveResponses []web.LeaveResponse, totalData int) error {
+	if filter.Page != "" || filter.Limit != "" {
+		pageInt, _ := strconv.Atoi(filter.Page)
+		if filter.Page == "" {
+			pageInt = 1
+		}
+		return ctx.Status(fiber.StatusOK).JSON(web.WebResponsePagination{
+			Code:      fiber.StatusOK,
+			Status:    true,
+			Page:      pageInt,
+			Count:     len(leaveResponses),
+			TotalData: totalData,
+			Message:   "success",
+			Data:      leaveResponses,
+		})
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(web.WebResponse{
+		Code:    fiber.StatusOK,
+		Status:  true,
+		Message: "success",
+		Data:    leaveResponses,
+	})
+}
@@ -0,0 +1,148 @@
+package controller
+
+import (
+	"github.com/go-playground/validator/v10"
+	"github.com/gofiber/fiber/v2"
+	"github.com/iqbaludinm/hr-microservice/user-service/config"
+	"github.com/iqbaludinm/hr-microservice/user-service/exception"
+	"github.com/iqbaludinm/hr-microservice/user-service/middleware"
+	"github.com/iqbaludinm/hr-microservice/user-service/model/web"
+	"github.com/iqbaludinm/hr-microservice/user-service/service"
+)
+
+type LeaveTypeController interface {
+	// This function is used to define the route of the endpoints in this controller to the main application
+	Route(app *fiber.App)
+	CreateLeaveType(ctx *fiber.Ctx) error
+	UpdateLeaveType(ctx *fiber.Ctx) error
+	DeleteLeaveType(ctx *fiber.Ctx) error
+	FindAllLeaveType(ctx *fiber.Ctx) error
+	FindByID(ctx *fiber.Ctx) error
+}
+
+type leaveTypeController struct {
+	validate         *validator.Validate
+	leaveTypeService service.LeaveTypeService
+}
+
+func NewLeaveTypeController(validate *validator.Validate, leaveTypeService service.LeaveTypeService) LeaveTypeController {
+	return &leaveTypeController{
+		validate:         validate,
+		leaveTypeService: leaveTypeService,
+	}
+}
+
+func (controller *leaveTypeController) Route(app *fiber.App) {
+	api := app.Group(config.EndpointPrefixLeaveType, middleware.IsAuthenticated)
+
+	api.Post("/", controller.CreateLeaveType)
+	api.Get("/", controller.FindAllLeaveType)
+	api.Get("/:leave_type_id", controller.FindByID)
+	api.Put("/:leave_type_id", controller.UpdateLeaveType)
+	api.Delete("/:leave_type_id", controller.DeleteLeaveType)
+}
+
+func (controller *leaveTypeController) CreateLeaveType(ctx *fiber.Ctx) error {
+	// parse request body
+	var request web.CreateLeaveTypeRequest
+	err := ctx.BodyParser(&request)
+	if err != nil {
+		return exception.ErrValidateBadRequest(err.Error(), request)
+	}
+	// validate the values of the request body
+	err = controller.validate.Struct(request)
+	if err != nil {
+		return exception.ErrValidateBadRequest(err.Error(), request)
+	}
+
+	// create leave type
+	leaveTypeResponse, err := controller.leaveTypeService.CreateLeaveType(ctx.Context(), request)
+	if err != nil {
+		return err
+	}
+
+	return ctx.Status(fiber.StatusCreated).JSON(web.WebResponse{
+		Code:    fiber.StatusCreated,
+		Status:  true,
+		Message: "success",
+		Data:    leaveTypeResponse,
+	})
+}
+
+func (controller *leaveTypeController) UpdateLeaveType(ctx *fiber.Ctx) error {
+	// parse request body
+	var request web.UpdateLeaveTypeRequest
+	err := ctx.BodyParser(&request)
+	if err != nil {
+		return exception.ErrValidateBadRequest(err.Error(), request)
+	}
+	// validate the values of the request body
+	err = controller.validate.Struct(request)
+	if err != nil {
+		return exception.ErrValidateBadRequest(err.Error(), request)
+	}
+
+	// parse path params
+	leaveTypeID := ctx.Params("leave_type_id")
+
+	// update leave type
+	leaveTypeResponse, err := controller.leaveTypeService.UpdateLeaveType(ctx.Context(), leaveTypeID, request)
+	if err != nil {
+		return err
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(web.WebResponse{
+		Code:    fiber.StatusOK,
+		Status:  true,
+		Message: "success",
+		Data:    leaveTypeResponse,
+	})
+}
+
+func (controller *leaveTypeController) DeleteLeaveType(ctx *fiber.Ctx) error {
+	// parse path params
+	leaveTypeID := ctx.Params("leave_type_id")
+
+	// delete leave type
+	err := controller.leaveTypeService.Delete(ctx.Context(), leaveTypeID)
+	if err != nil {
+		return err
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(web.WebResponse{
+		Code:    fiber.StatusOK,
+		Status:  true,
+		Message: "success",
+	})
+}
+
+func (controller *leaveTypeController) FindByID(ctx *fiber.Ctx) error {
+	// parse path params
+	leaveTypeID := ctx.Params("leave_type_id")
+
+	leaveType, err := controller.leaveTypeService.FindById(ctx.Context(), leaveTypeID)
+	if err != nil {
+		return err
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(web.WebResponse{
+		Code:    fiber.StatusOK,
+		Status:  true,
+		Message: "success",
+		Data:    leaveType,
+	})
+}
+
+func (controller *leaveTypeController) FindAllLeaveType(ctx *fiber.Ctx) error {
+	leaveTypeResponses, err := controller.leaveTypeService.FindAllLeaveType(ctx.Context())
+	if err != nil {
+		return err
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(web.WebResponse{
+		Code:    fiber.StatusOK,
+		Status:  true,
+		Message: "success",
+		Data:    leaveTypeResponses,
+	})
+}
@@ -0,0 +1,247 @@
+package controller
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/gofiber/fiber/v2"
+	"github.com/iqbaludinm/hr-microservice/user-service/config"
+	"github.com/iqbaludinm/hr-microservice/user-service/exception"
+	"github.com/iqbaludinm/hr-microservice/user-service/helper"
+	"github.com/iqbaludinm/hr-microservice/user-service/middleware"
+	"github.com/iqbaludinm/hr-microservice/user-service/model/web"
+	"github.com/iqbaludinm/hr-microservice/user-service/service"
+)
+
+type OffboardingController interface {
+	// This function is used to define the route of the endpoints in this controller to the main application
+	Route(app *fiber.App)
+	CreateOffboarding(ctx *fiber.Ctx) error
+	UpdateOffboarding(ctx *fiber.Ctx) error
+	CancelOffboarding(ctx *fiber.Ctx) error
+	UpdateTask(ctx *fiber.Ctx) error
+	DownloadClearance(ctx *fiber.Ctx) error
+	FindAllOffboarding(ctx *fiber.Ctx) error
+	FindMyTasks(ctx *fiber.Ctx) error
+	FindByID(ctx *fiber.Ctx) error
+}
+
+type offboardingController struct {
+	validate           *validator.Validate
+	offboardingService service.OffboardingService
+}
+
+func NewOffboardingController(validate *validator.Validate, offboardingService service.OffboardingService) OffboardingController {
+	return &offboardingController{
+		validate:           validate,
+		offboardingService: offboardingService,
+	}
+}
+
+func (controller *offboardingController) Route(app *fiber.App) {
+	api := app.Group(config.EndpointPrefixOffboarding, middleware.IsAuthenticated)
+
+	api.Post("/", controller.CreateOffboarding)
+	api.Get("/", controller.FindAllOffboarding)
+	api.Get("/tasks/me", controller.FindMyTasks)
+	api.Get("/:offboarding_id", controller.FindByID)
+	api.Put("/:offboarding_id", controller.UpdateOffboarding)
+	api.Post("/:offboarding_id/cancel", controller.CancelOffboarding)
+	api.Put("/:offboarding_id/tasks/:seq", controller.UpdateTask)
+	api.Get("/:offboarding_id/clearance", controller.DownloadClearance)
+}
+
+func (controller *offboardingController) CreateOffboarding(ctx *fiber.Ctx) error {
+	// parse request body
+	var request web.OffboardingRequest
+	if err := ctx.BodyParser(&request); err != nil {
+		return exception.ErrValidateBadRequest(err.Error(), request)
+	}
+	// validate the values of request body
+	if err := controller.validate.Struct(request); err != nil {
+		return exception.ErrValidateBadRequest(err.Error(), request)
+	}
+
+	// the offboarding is created by the logged in user
+	userID, _ := helper.ParseJwt(ctx.Cookies("token"))
+
+	offboardingResponse, err := controller.offboardingService.Create(ctx.Context(), userID, request)
+	if err != nil {
+		return err
+	}
+
+	return ctx.Status(fiber.StatusCreated).JSON(web.WebResponse{
+		Code:    fiber.StatusCreated,
+		Status:  true,
+		Message: "success",
+		Data:    offboardingResponse,
+	})
+}
+
+func (controller *offboardingController) UpdateOffboarding(ctx *fiber.Ctx) error {
+	// parse request body
+	var request web.UpdateOffboardingRequest
+	if err := ctx.BodyParser(&request); err != nil {
+		return exception.ErrValidateBadRequest(err.Error(), request)
+	}
+	// validate the values of request body
+	if err := controller.validate.Struct(request); err != nil {
+		return exception.ErrValidateBadRequest(err.Error(), request)
+	}
+
+	// parse path params
+	offboardingID := ctx.Params("offboarding_id")
+
+	offboardingResponse, err := controller.offboardingService.Update(ctx.Context(), offboardingID, request)
+	if err != nil {
+		return err
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(web.WebResponse{
+		Code:    fiber.StatusOK,
+		Status:  true,
+		Message: "success",
+		Data:    offboardingResponse,
+	})
+}
+
+func (controller *offboardingController) CancelOffboarding(ctx *fiber.Ctx) error {
+	// parse path params
+	offboardingID := ctx.Params("offboarding_id")
+
+	// cancel the offboarding
+	offboardingResponse, err := controller.offboardingService.Cancel(ctx.Context(), offboardingID)
+	if err != nil {
+		return err
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(web.WebResponse{
+		Code:    fiber.StatusOK,
+		Status:  true,
+		Message: "success",
+		Data:    offboardingResponse,
+	})
+}
+
+func (controller *offboardingController) UpdateTask(ctx *fiber.Ctx) error {
+	// parse request body
+	var request web.UpdateOffboardingTaskRequest
+	if err := ctx.BodyParser(&request); err != nil {
+		return exception.ErrValidateBadRequest(err.Error(), request)
+	}
+	// validate the values of request body
+	if err := controller.validate.Struct(request); err != nil {
+		return exception.ErrValidateBadRequest(err.Error(), request)
+	}
+
+	// parse path params
+	offboardingID := ctx.Params("offboarding_id")
+	seq, err := strconv.Atoi(ctx.Params("seq"))
+	if err != nil {
+		return exception.ErrBadRequest("Task seq must be a number.")
+	}
+	// the task is completed by the logged in user
+	userID, _ := helper.ParseJwt(ctx.Cookies("token"))
+
+	// update the progress of the exit task
+	offboardingResponse, err := controller.offboardingService.UpdateTask(ctx.Context(), userID, offboardingID, seq, request)
+	if err != nil {
+		return err
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(web.WebResponse{
+		Code:    fiber.StatusOK,
+		Status:  true,
+		Message: "success",
+		Data:    offboardingResponse,
+	})
+}
+
+func (controller *offboardingController) DownloadClearance(ctx *fiber.Ctx) error {
+	// parse path params
+	offboardingID := ctx.Params("offboarding_id")
+
+	pdf, fileName, err := controller.offboardingService.Clearance(ctx.Context(), offboardingID)
+	if err != nil {
+		return err
+	}
+
+	ctx.Set(fiber.HeaderContentType, "application/pdf")
+	ctx.Set(fiber.HeaderContentDisposition, fmt.Sprintf("attachment; filename=%s", fileName))
+
+	return ctx.Status(fiber.StatusOK).Send(pdf)
+}
+
+func (controller *offboardingController) FindAllOffboarding(ctx *fiber.Ctx) error {
+	// parse query params
+	var filter web.OffboardingQueryFilter
+	if err := ctx.QueryParser(&filter); err != nil {
+		return exception.ErrValidateBadRequest(err.Error(), filter)
+	}
+	// validate the values of the query params
+	if err := controller.validate.Struct(filter); err != nil {
+		return exception.ErrValidateBadRequest(err.Error(), filter)
+	}
+
+	offboardingResponses, totalData, err := controller.offboardingService.FindAll(ctx.Context(), filter)
+	if err != nil {
+		return err
+	}
+
+	if filter.Page != "" || filter.Limit != "" {
+		pageInt, _ := strconv.Atoi(filter.Page)
+		if filter.Page == "" {
+			pageInt = 1
+		}
+		return ctx.Status(fiber.StatusOK).JSON(web.WebResponsePagination{
+			Code:      fiber.StatusOK,
+			Status:    true,
+			Page:      pageInt,
+			Count:     len(offboardingResponses),
+			TotalData: totalData,
+			Message:   "success",
+			Data:      offboardingResponses,
+		})
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(web.WebResponse{
+		Code:    fiber.StatusOK,
+		Status:  true,
+		Message: "success",
+		Data:    offboardingResponses,
+	})
+}
+
+func (controller *offboardingController) FindMyTasks(ctx *fiber.Ctx) error {
+	userID, _ := helper.ParseJwt(ctx.Cookies("token"))
+
+	taskResponses, err := controller.offboardingService.FindMyTasks(ctx.Context(), userID)
+	if err != nil {
+		return err
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(web.WebResponse{
+		Code:    fiber.StatusOK,
+		Status:  true,
+		Message: "success",
+		Data:    taskResponses,
+	})
+}
+
+func (controller *offboardingController) FindByID(ctx *fiber.Ctx) error {
+	// parse path params
+	offboardingID := ctx.Params("offboarding_id")
+
+	offboardingResponse, err := controller.offboardingService.FindById(ctx.Context(), offboardingID)
+	if err != nil {
+		return err
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(web.WebResponse{
+		Code:    fiber.StatusOK,
+		Status:  true,
+		Message: "success",
+		Data:    offboardingResponse,
+	})
+}
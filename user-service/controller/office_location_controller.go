@@ -0,0 +1,179 @@
+package controller
+
+import (
+	"github.com/go-playground/validator/v10"
+	"github.com/gofiber/fiber/v2"
+	"github.com/iqbaludinm/hr-microservice/user-service/config"
+	"github.com/iqbaludinm/hr-microservice/user-service/exception"
+	"github.com/iqbaludinm/hr-microservice/user-service/middleware"
+	"github.com/iqbaludinm/hr-microservice/user-service/model/web"
+	"github.com/iqbaludinm/hr-microservice/user-service/service"
+)
+
+type OfficeLocationController interface {
+	// This function is used to define the route of the endpoints in this controller to the main application
+	Route(app *fiber.App)
+	CreateOfficeLocation(ctx *fiber.Ctx) error
+	UpdateOfficeLocation(ctx *fiber.Ctx) error
+	DeleteOfficeLocation(ctx *fiber.Ctx) error
+	FindAllOfficeLocation(ctx *fiber.Ctx) error
+	FindByID(ctx *fiber.Ctx) error
+	AssignEmployees(ctx *fiber.Ctx) error
+}
+
+type officeLocationController struct {
+	validate              *validator.Validate
+	officeLocationService service.OfficeLocationService
+}
+
+func NewOfficeLocationController(validate *validator.Validate, officeLocationService service.OfficeLocationService) OfficeLocationController {
+	return &officeLocationController{
+		validate:              validate,
+		officeLocationService: officeLocationService,
+	}
+}
+
+func (controller *officeLocationController) Route(app *fiber.App) {
+	api := app.Group(config.EndpointPrefixOfficeLocation, middleware.IsAuthenticated)
+
+	api.Post("/", controller.CreateOfficeLocation)
+	api.Get("/", controller.FindAllOfficeLocation)
+	api.Get("/:office_location_id", controller.FindByID)
+	api.Put("/:office_location_id", controller.UpdateOfficeLocation)
+	api.Delete("/:office_location_id", controller.DeleteOfficeLocation)
+	api.Put("/:office_location_id/employees", controller.AssignEmployees)
+}
+
+func (controller *officeLocationController) CreateOfficeLocation(ctx *fiber.Ctx) error {
+	// parse request body
+	var request web.CreateOfficeLocationRequest
+	err := ctx.BodyParser(&request)
+	if err != nil {
+		return exception.ErrValidateBadRequest(err.Error(), request)
+	}
+	// validate the values of the request body
+	err = controller.validate.Struct(request)
+	if err != nil {
+		return exception.ErrValidateBadRequest(err.Error(), request)
+	}
+
+	// create office location
+	officeLocationResponse, err := controller.officeLocationService.CreateOfficeLocation(ctx.Context(), request)
+	if err != nil {
+		return err
+	}
+
+	return ctx.Status(fiber.StatusCreated).JSON(web.WebResponse{
+		Code:    fiber.StatusCreated,
+		Status:  true,
+		Message: "success",
+		Data:    officeLocationResponse,
+	})
+}
+
+func (controller *officeLocationController) UpdateOfficeLocation(ctx *fiber.Ctx) error {
+	// parse request body
+	var request web.UpdateOfficeLocationRequest
+	err := ctx.BodyParser(&request)
+	if err != nil {
+		return exception.ErrValidateBadRequest(err.Error(), request)
+	}
+	// validate the values of the request body
+	err = controller.validate.Struct(request)
+	if err != nil {
+		return exception.ErrValidateBadRequest(err.Error(), request)
+	}
+
+	// parse path params
+	officeLocationID := ctx.Params("office_location_id")
+
+	// update office location
+	officeLocationResponse, err := controller.officeLocationService.UpdateOfficeLocation(ctx.Context(), officeLocationID, request)
+	if err != nil {
+		return err
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(web.WebResponse{
+		Code:    fiber.StatusOK,
+		Status:  true,
+		Message: "success",
+		Data:    officeLocationResponse,
+	})
+}
+
+func (controller *officeLocationController) DeleteOfficeLocation(ctx *fiber.Ctx) error {
+	// parse path params
+	officeLocationID := ctx.Params("office_location_id")
+
+	// delete office location
+	err := controller.officeLocationService.Delete(ctx.Context(), officeLocationID)
+	if err != nil {
+		return err
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(web.WebResponse{
+		Code:    fiber.StatusOK,
+		Status:  true,
+		Message: "success",
+	})
+}
+
+func (controller *officeLocationController) FindByID(ctx *fiber.Ctx) error {
+	// parse path params
+	officeLocationID := ctx.Params("office_location_id")
+
+	officeLocation, err := controller.officeLocationService.FindById(ctx.Context(), officeLocationID)
+	if err != nil {
+		return err
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(web.WebResponse{
+		Code:    fiber.StatusOK,
+		Status:  true,
+		Message: "success",
+		Data:    officeLocation,
+	})
+}
+
+func (controller *officeLocationController) FindAllOfficeLocation(ctx *fiber.Ctx) error {
+	officeLocationResponses, err := controller.officeLocationService.FindAllOfficeLocation(ctx.Context())
+	if err != nil {
+		return err
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(web.WebResponse{
+		Code:    fiber.StatusOK,
+		Status:  true,
+		Message: "success",
+		Data:    officeLocationResponses,
+	})
+}
+
+func (controller *officeLocationController) AssignEmployees(ctx *fiber.Ctx) error {
+	// parse request body
+	var request web.AssignOfficeLocationRequest
+	err := ctx.BodyParser(&request)
+	if err != nil {
+		return exception.ErrValidateBadRequest(err.Error(), request)
+	}
+	// validate the values of the request body
+	err = controller.validate.Struct(request)
+	if err != nil {
+		return exception.ErrValidateBadRequest(err.Error(), request)
+	}
+
+	// parse path params
+	officeLocationID := ctx.Params("office_location_id")
+
+	// assign office location to the employees
+	err = controller.officeLocationService.AssignEmployees(ctx.Context(), officeLocationID, request)
+	if err != nil {
+		return err
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(web.WebResponse{
+		Code:    fiber.StatusOK,
+		Status:  true,
+		Message: "success",
+	})
+}
@@ -0,0 +1,216 @@
+package controller
+
+import (
+	"strconv"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/gofiber/fiber/v2"
+	"github.com/iqbaludinm/hr-microservice/user-service/config"
+	"github.com/iqbaludinm/hr-microservice/user-service/exception"
+	"github.com/iqbaludinm/hr-microservice/user-service/helper"
+	"github.com/iqbaludinm/hr-microservice/user-service/middleware"
+	"github.com/iqbaludinm/hr-microservice/user-service/model/web"
+	"github.com/iqbaludinm/hr-microservice/user-service/service"
+)
+
+type OnboardingController interface {
+	// This function is used to define the route of the endpoints in this controller to the main application
+	Route(app *fiber.App)
+	StartOnboarding(ctx *fiber.Ctx) error
+	CancelOnboarding(ctx *fiber.Ctx) error
+	UpdateTask(ctx *fiber.Ctx) error
+	FindAllOnboarding(ctx *fiber.Ctx) error
+	FindMyOnboarding(ctx *fiber.Ctx) error
+	FindMyTasks(ctx *fiber.Ctx) error
+	FindByID(ctx *fiber.Ctx) error
+}
+
+type onboardingController struct {
+	validate          *validator.Validate
+	onboardingService service.OnboardingService
+}
+
+func NewOnboardingController(validate *validator.Validate, onboardingService service.OnboardingService) OnboardingController {
+	return &onboardingController{
+		validate:          validate,
+		onboardingService: onboardingService,
+	}
+}
+
+func (controller *onboardingController) Route(app *fiber.App) {
+	api := app.Group(config.EndpointPrefixOnboarding, middleware.IsAuthenticated)
+
+	api.Post("/", controller.StartOnboarding)
+	api.Get("/", controller.FindAllOnboarding)
+	api.Get("/me", controller.FindMyOnboarding)
+	api.Get("/tasks/me", controller.FindMyTasks)
+	api.Get("/:onboarding_id", controller.FindByID)
+	api.Post("/:onboarding_id/cancel", controller.CancelOnboarding)
+	api.Put("/:onboarding_id/tasks/:seq", controller.UpdateTask)
+}
+
+func (controller *onboardingController) StartOnboarding(ctx *fiber.Ctx) error {
+	// parse request body
+	var request web.StartOnboardingRequest
+	if err := ctx.BodyParser(&request); err != nil {
+		return exception.ErrValidateBadRequest(err.Error(), request)
+	}
+	// validate the values of request body
+	if err := controller.validate.Struct(request); err != nil {
+		return exception.ErrValidateBadRequest(err.Error(), request)
+	}
+
+	// start the onboarding of the employee
+	onboardingResponse, err := controller.onboardingService.Start(ctx.Context(), request)
+	if err != nil {
+		return err
+	}
+
+	return ctx.Status(fiber.StatusCreated).JSON(web.WebResponse{
+		Code:    fiber.StatusCreated,
+		Status:  true,
+		Message: "success",
+		Data:    onboardingResponse,
+	})
+}
+
+func (controller *onboardingController) CancelOnboarding(ctx *fiber.Ctx) error {
+	// parse path params
+	onboardingID := ctx.Params("onboarding_id")
+
+	// cancel the onboarding
+	onboardingResponse, err := controller.onboardingService.Cancel(ctx.Context(), onboardingID)
+	if err != nil {
+		return err
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(web.WebResponse{
+		Code:    fiber.StatusOK,
+		Status:  true,
+		Message: "success",
+		Data:    onboardingResponse,
+	})
+}
+
+func (controller *onboardingController) UpdateTask(ctx *fiber.Ctx) error {
+	// parse request body
+	var request web.UpdateOnboardingTaskRequest
+	if err := ctx.BodyParser(&request); err != nil {
+		return exception.ErrValidateBadRequest(err.Error(), request)
+	}
+	// validate the values of request body
+	if err := controller.validate.Struct(request); err != nil {
+		return exception.ErrValidateBadRequest(err.Error(), request)
+	}
+
+	// parse path params
+	onboardingID := ctx.Params("onboarding_id")
+	seq, err := strconv.Atoi(ctx.Params("seq"))
+	if err != nil {
+		return exception.ErrBadRequest("Task seq must be a number.")
+	}
+	// the task is completed by the logged in user
+	userID, _ := helper.ParseJwt(ctx.Cookies("token"))
+
+	// update the progress of the task
+	onboardingResponse, err := controller.onboardingService.UpdateTask(ctx.Context(), userID, onboardingID, seq, request)
+	if err != nil {
+		return err
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(web.WebResponse{
+		Code:    fiber.StatusOK,
+		Status:  true,
+		Message: "success",
+		Data:    onboardingResponse,
+	})
+}
+
+func (controller *onboardingController) FindAllOnboarding(ctx *fiber.Ctx) error {
+	// parse query params
+	var filter web.OnboardingQueryFilter
+	if err := ctx.QueryParser(&filter); err != nil {
+		return exception.ErrValidateBadRequest(err.Error(), filter)
+	}
+	// validate the values of the query params
+	if err := controller.validate.Struct(filter); err != nil {
+		return exception.ErrValidateBadRequest(err.Error(), filter)
+	}
+
+	onboardingResponses, totalData, err := controller.onboardingService.FindAllOnboarding(ctx.Context(), filter)
+	if err != nil {
+		return err
+	}
+
+	if filter.Page != "" || filter.Limit != "" {
+		pageInt, _ := strconv.Atoi(filter.Page)
+		if filter.Page == "" {
+			pageInt = 1
+		}
+		return ctx.Status(fiber.StatusOK).JSON(web.WebResponsePagination{
+			Code:      fiber.StatusOK,
+			Status:    true,
+			Page:      pageInt,
+			Count:     len(onboardingResponses),
+			TotalData: totalData,
+			Message:   "success",
+			Data:      onboardingResponses,
+		})
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(web.WebResponse{
+		Code:    fiber.StatusOK,
+		Status:  true,
+		Message: "success",
+		Data:    onboardingResponses,
+	})
+}
+
+func (controller *onboardingController) FindMyOnboarding(ctx *fiber.Ctx) error {
+	userID, _ := helper.ParseJwt(ctx.Cookies("token"))
+
+	onboardingResponse, err := controller.onboardingService.FindMyOnboarding(ctx.Context(), userID)
+	if err != nil {
+		return err
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(web.WebResponse{
+		Code:    fiber.StatusOK,
+		Status:  true,
+		Message: "success",
+		Data:    onboardingResponse,
+	})
+}
+
+func (controller *onboardingController) FindMyTasks(ctx *fiber.Ctx) error {
+	userID, _ := helper.ParseJwt(ctx.Cookies("token"))
+
+	taskResponses, err := controller.onboardingService.FindMyTasks(ctx.Context(), userID)
+	if err != nil {
+		return err
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(web.WebResponse{
+		Code:    fiber.StatusOK,
+		Status:  true,
+		Message: "success",
+		Data:    taskResponses,
+	})
+}
+
+func (controller *onboardingController) FindByID(ctx *fiber.Ctx) error {
+	// parse path params
+	onboardingID := ctx.Params("onboarding_id")
+
+	onboardingResponse, err := controller.onboardingService.FindById(ctx.Context(), onboardingID)
+	if err != nil {
+		return err
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(web.WebResponse{
+		Code:    fiber.StatusOK,
+		Status:  true,
+		Message: "success",
+		Data:    onboardingResponse,
+	})
+}
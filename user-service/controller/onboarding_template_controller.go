@@ -0,0 +1,144 @@
+package controller
+
+import (
+	"github.com/go-playground/validator/v10"
+	"github.com/gofiber/fiber/v2"
+	"github.com/iqbaludinm/hr-microservice/user-service/config"
+	"github.com/iqbaludinm/hr-microservice/user-service/exception"
+	"github.com/iqbaludinm/hr-microservice/user-service/middleware"
+	"github.com/iqbaludinm/hr-microservice/user-service/model/web"
+	"github.com/iqbaludinm/hr-microservice/user-service/service"
+)
+
+type OnboardingTemplateController interface {
+	// This function is used to define the route of the endpoints in this controller to the main application
+	Route(app *fiber.App)
+	CreateTemplate(ctx *fiber.Ctx) error
+	UpdateTemplate(ctx *fiber.Ctx) error
+	DeleteTemplate(ctx *fiber.Ctx) error
+	FindAllTemplate(ctx *fiber.Ctx) error
+	FindByID(ctx *fiber.Ctx) error
+}
+
+type onboardingTemplateController struct {
+	validate          *validator.Validate
+	onboardingService service.OnboardingService
+}
+
+func NewOnboardingTemplateController(validate *validator.Validate, onboardingService service.OnboardingService) OnboardingTemplateController {
+	return &onboardingTemplateController{
+		validate:          validate,
+		onboardingService: onboardingService,
+	}
+}
+
+func (controller *onboardingTemplateController) Route(app *fiber.App) {
+	api := app.Group(config.EndpointPrefixOnboardingTemplate, middleware.IsAuthenticated)
+
+	api.Post("/", controller.CreateTemplate)
+	api.Get("/", controller.FindAllTemplate)
+	api.Get("/:template_id", controller.FindByID)
+	api.Put("/:template_id", controller.UpdateTemplate)
+	api.Delete("/:template_id", controller.DeleteTemplate)
+}
+
+func (controller *onboardingTemplateController) CreateTemplate(ctx *fiber.Ctx) error {
+	// parse request body
+	var request web.OnboardingTemplateRequest
+	if err := ctx.BodyParser(&request); err != nil {
+		return exception.ErrValidateBadRequest(err.Error(), request)
+	}
+	// validate the values of request body
+	if err := controller.validate.Struct(request); err != nil {
+		return exception.ErrValidateBadRequest(err.Error(), request)
+	}
+
+	// create onboarding template
+	templateResponse, err := controller.onboardingService.CreateTemplate(ctx.Context(), request)
+	if err != nil {
+		return err
+	}
+
+	return ctx.Status(fiber.StatusCreated).JSON(web.WebResponse{
+		Code:    fiber.StatusCreated,
+		Status:  true,
+		Message: "success",
+		Data:    templateResponse,
+	})
+}
+
+func (controller *onboardingTemplateController) UpdateTemplate(ctx *fiber.Ctx) error {
+	// parse request body
+	var request web.OnboardingTemplateRequest
+	if err := ctx.BodyParser(&request); err != nil {
+		return exception.ErrValidateBadRequest(err.Error(), request)
+	}
+	// validate the values of request body
+	if err := controller.validate.Struct(request); err != nil {
+		return exception.ErrValidateBadRequest(err.Error(), request)
+	}
+
+	// parse path params
+	templateID := ctx.Params("template_id")
+
+	// update onboarding template
+	templateResponse, err := controller.onboardingService.UpdateTemplate(ctx.Context(), templateID, request)
+	if err != nil {
+		return err
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(web.WebResponse{
+		Code:    fiber.StatusOK,
+		Status:  true,
+		Message: "success",
+		Data:    templateResponse,
+	})
+}
+
+func (controller *onboardingTemplateController) DeleteTemplate(ctx *fiber.Ctx) error {
+	// parse path params
+	templateID := ctx.Params("template_id")
+
+	// delete onboarding template
+	err := controller.onboardingService.DeleteTemplate(ctx.Context(), templateID)
+	if err != nil {
+		return err
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(web.WebResponse{
+		Code:    fiber.StatusOK,
+		Status:  true,
+		Message: "success",
+	})
+}
+
+func (controller *onboardingTemplateController) FindAllTemplate(ctx *fiber.Ctx) error {
+	templateResponses, err := controller.onboardingService.FindAllTemplate(ctx.Context())
+	if err != nil {
+		return err
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(web.WebResponse{
+		Code:    fiber.StatusOK,
+		Status:  true,
+		Message: "success",
+		Data:    templateResponses,
+	})
+}
+
+func (controller *onboardingTemplateController) FindByID(ctx *fiber.Ctx) error {
+	// parse path params
+	templateID := ctx.Params("template_id")
+
+	templateResponse, err := controller.onboardingService.FindTemplateById(ctx.Context(), templateID)
+	if err != nil {
+		return err
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(web.WebResponse{
+		Code:    fiber.StatusOK,
+		Status:  true,
+		Message: "success",
+		Data:    templateResponse,
+	})
+}
@@ -0,0 +1,295 @@
+package controller
+
+import (
+	"strconv"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/gofiber/fiber/v2"
+	"github.com/iqbaludinm/hr-microservice/user-service/config"
+	"github.com/iqbaludinm/hr-microservice/user-service/exception"
+	"github.com/iqbaludinm/hr-microservice/user-service/helper"
+	"github.com/iqbaludinm/hr-microservice/user-service/middleware"
+	"github.com/iqbaludinm/hr-microservice/user-service/model/web"
+	"github.com/iqbaludinm/hr-microservice/user-service/service"
+)
+
+type OvertimeController interface {
+	// This function is used to define the route of the endpoints in this controller to the main application
+	Route(app *fiber.App)
+	CreateOvertime(ctx *fiber.Ctx) error
+	Approve(ctx *fiber.Ctx) error
+	Reject(ctx *fiber.Ctx) error
+	Cancel(ctx *fiber.Ctx) error
+	FindAllOvertime(ctx *fiber.Ctx) error
+	FindMyOvertime(ctx *fiber.Ctx) error
+	FindPendingApprovals(ctx *fiber.Ctx) error
+	FindByID(ctx *fiber.Ctx) error
+	Dashboard(ctx *fiber.Ctx) error
+}
+
+type overtimeController struct {
+	validate        *validator.Validate
+	overtimeService service.OvertimeService
+}
+
+func NewOvertimeController(validate *validator.Validate, overtimeService service.OvertimeService) OvertimeController {
+	return &overtimeController{
+		validate:        validate,
+		overtimeService: overtimeService,
+	}
+}
+
+func (controller *overtimeController) Route(app *fiber.App) {
+	api := app.Group(config.EndpointPrefixOvertime, middleware.IsAuthenticated)
+
+	api.Post("/", controller.CreateOvertime)
+	api.Get("/", controller.FindAllOvertime)
+	api.Get("/me", controller.FindMyOvertime)
+	api.Get("/approvals", controller.FindPendingApprovals)
+	api.Get("/dashboard", controller.Dashboard)
+	api.Get("/:overtime_id", controller.FindByID)
+	api.Put("/:overtime_id/approve", controller.Approve)
+	api.Put("/:overtime_id/reject", controller.Reject)
+	api.Put("/:overtime_id/cancel", controller.Cancel)
+}
+
+func (controller *overtimeController) CreateOvertime(ctx *fiber.Ctx) error {
+	// parse request body
+	var request web.CreateOvertimeRequest
+	err := ctx.BodyParser(&request)
+	if err != nil {
+		return exception.ErrValidateBadRequest(err.Error(), request)
+	}
+	// validate the values of the request body
+	err = controller.validate.Struct(request)
+	if err != nil {
+		return exception.ErrValidateBadRequest(err.Error(), request)
+	}
+
+	// the overtime is requested by the logged in user
+	userID, _ := helper.ParseJwt(ctx.Cookies("token"))
+
+	// create overtime request
+	overtimeResponse, err := controller.overtimeService.CreateOvertime(ctx.Context(), userID, request)
+	if err != nil {
+		return err
+	}
+
+	return ctx.Status(fiber.StatusCreated).JSON(web.WebResponse{
+		Code:    fiber.StatusCreated,
+		Status:  true,
+		Message: "success",
+		Data:    overtimeResponse,
+	})
+}
+
+func (controller *overtimeController) Approve(ctx *fiber.Ctx) error {
+	// parse request body
+	var request web.DecideOvertimeRequest
+	if err := controller.parseDecision(ctx, &request); err != nil {
+		return err
+	}
+
+	// parse path params
+	overtimeID := ctx.Params("overtime_id")
+	userID, _ := helper.ParseJwt(ctx.Cookies("token"))
+
+	// approve overtime request
+	overtimeResponse, err := controller.overtimeService.Approve(ctx.Context(), userID, overtimeID, request)
+	if err != nil {
+		return err
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(web.WebResponse{
+		Code:    fiber.StatusOK,
+		Status:  true,
+		Message: "success",
+		Data:    overtimeResponse,
+	})
+}
+
+func (controller *overtimeController) Reject(ctx *fiber.Ctx) error {
+	// parse request body
+	var request web.DecideOvertimeRequest
+	if err := controller.parseDecision(ctx, &request); err != nil {
+		return err
+	}
+
+	// parse path params
+	overtimeID := ctx.Params("overtime_id")
+	userID, _ := helper.ParseJwt(ctx.Cookies("token"))
+
+	// reject overtime request
+	overtimeResponse, err := controller.overtimeService.Reject(ctx.Context(), userID, overtimeID, request)
+	if err != nil {
+		return err
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(web.WebResponse{
+		Code:    fiber.StatusOK,
+		Status:  true,
+		Message: "success",
+		Data:    overtimeResponse,
+	})
+}
+
+func (controller *overtimeController) Cancel(ctx *fiber.Ctx) error {
+	// parse path params
+	overtimeID := ctx.Params("overtime_id")
+	userID, _ := helper.ParseJwt(ctx.Cookies("token"))
+
+	// cancel overtime request
+	overtimeResponse, err := controller.overtimeService.Cancel(ctx.Context(), userID, overtimeID)
+	if err != nil {
+		return err
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(web.WebResponse{
+		Code:    fiber.StatusOK,
+		Status:  true,
+		Message: "success",
+		Data:    overtimeResponse,
+	})
+}
+
+func (controller *overtimeController) FindAllOvertime(ctx *fiber.Ctx) error {
+	// parse query params
+	filter, err := controller.parseFilter(ctx)
+	if err != nil {
+		return err
+	}
+
+	overtimeResponses, totalData, err := controller.overtimeService.FindAllOvertime(ctx.Context(), filter)
+	if err != nil {
+		return err
+	}
+
+	return controller.overtimeListResponse(ctx, filter, overtimeResponses, totalData)
+}
+
+func (controller *overtimeController) FindMyOvertime(ctx *fiber.Ctx) error {
+	// parse query params
+	filter, err := controller.parseFilter(ctx)
+	if err != nil {
+		return err
+	}
+	userID, _ := helper.ParseJwt(ctx.Cookies("token"))
+
+	overtimeResponses, totalData, err := controller.overtimeService.FindMyOvertime(ctx.Context(), userID, filter)
+	if err != nil {
+		return err
+	}
+
+	return controller.overtimeListResponse(ctx, filter, overtimeResponses, totalData)
+}
+
+func (controller *overtimeController) FindPendingApprovals(ctx *fiber.Ctx) error {
+	// parse query params
+	filter, err := controller.parseFilter(ctx)
+	if err != nil {
+		return err
+	}
+	userID, _ := helper.ParseJwt(ctx.Cookies("token"))
+
+	overtimeResponses, totalData, err := controller.overtimeService.FindPendingApprovals(ctx.Context(), userID, filter)
+	if err != nil {
+		return err
+	}
+
+	return controller.overtimeListResponse(ctx, filter, overtimeResponses, totalData)
+}
+
+func (controller *overtimeController) FindByID(ctx *fiber.Ctx) error {
+	// parse path params
+	overtimeID := ctx.Params("overtime_id")
+
+	overtime, err := controller.overtimeService.FindById(ctx.Context(), overtimeID)
+	if err != nil {
+		return err
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(web.WebResponse{
+		Code:    fiber.StatusOK,
+		Status:  true,
+		Message: "success",
+		Data:    overtime,
+	})
+}
+
+func (controller *overtimeController) Dashboard(ctx *fiber.Ctx) error {
+	// parse query params
+	var filter web.OvertimeDashboardQueryFilter
+	if err := ctx.QueryParser(&filter); err != nil {
+		return exception.ErrValidateBadRequest(err.Error(), filter)
+	}
+	if err := controller.validate.Struct(filter); err != nil {
+		return exception.ErrValidateBadRequest(err.Error(), filter)
+	}
+	userID, _ := helper.ParseJwt(ctx.Cookies("token"))
+
+	dashboard, err := controller.overtimeService.Dashboard(ctx.Context(), userID, filter)
+	if err != nil {
+		return err
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(web.WebResponse{
+		Code:    fiber.StatusOK,
+		Status:  true,
+		Message: "success",
+		Data:    dashboard,
+	})
+}
+
+// parse and validate the request body of approving or rejecting a overtime request
+func (controller *overtimeController) parseDecision(ctx *fiber.Ctx, request *web.DecideOvertimeRequest) error {
+	// the note is optional, so an empty body is allowed
+	if len(ctx.Body()) > 0 {
+		if err := ctx.BodyParser(request); err != nil {
+			return exception.ErrValidateBadRequest(err.Error(), request)
+		}
+	}
+	if err := controller.validate.Struct(request); err != nil {
+		return exception.ErrValidateBadRequest(err.Error(), request)
+	}
+
+	return nil
+}
+
+// parse and validate the query params of the overtime request list
+func (controller *overtimeController) parseFilter(ctx *fiber.Ctx) (web.OvertimeQueryFilter, error) {
+	var filter web.OvertimeQueryFilter
+	if err := ctx.QueryParser(&filter); err != nil {
+		return filter, exception.ErrValidateBadRequest(err.Error(), filter)
+	}
+	if err := controller.validate.Struct(filter); err != nil {
+		return filter, exception.ErrValidateBadRequest(err.Error(), filter)
+	}
+
+	return filter, nil
+}
+
+// write the overtime request list, with the pagination when the page or limit is filled
+func (controller *overtimeController) overtimeListResponse(ctx *fiber.Ctx, filter web.OvertimeQueryFilter, overtimeResponses []web.OvertimeResponse, totalData int) error {
+	if filter.Page != "" || filter.Limit != "" {
+		pageInt, _ := strconv.Atoi(filter.Page)
+		if filter.Page == "" {
+			pageInt = 1
+		}
+		return ctx.Status(fiber.StatusOK).JSON(web.WebResponsePagination{
+			Code:      fiber.StatusOK,
+			Status:    true,
+			Page:      pageInt,
+			Count:     len(overtimeResponses),
+			TotalData: totalData,
+			Message:   "success",
+			Data:      overtimeResponses,
+		})
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(web.WebResponse{
+		Code:    fiber.StatusOK,
+		Status:  true,
+		Message: "success",
+		Data:    overtimeResponses,
+	})
+}
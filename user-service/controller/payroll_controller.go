@@ -0,0 +1,412 @@
+package controller
+
+import (
+	"strconv"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/gofiber/fiber/v2"
+	"github.com/iqbaludinm/hr-microservice/user-service/config"
+	"github.com/iqbaludinm/hr-microservice/user-service/exception"
+	"github.com/iqbaludinm/hr-microservice/user-service/helper"
+	"github.com/iqbaludinm/hr-microservice/user-service/middleware"
+	"github.com/iqbaludinm/hr-microservice/user-service/model/web"
+	"github.com/iqbaludinm/hr-microservice/user-service/service"
+)
+
+type PayrollController interface {
+	// This function is used to define the route of the endpoints in this controller to the main application
+	Route(app *fiber.App)
+	CreateSalary(ctx *fiber.Ctx) error
+	FindAllSalary(ctx *fiber.Ctx) error
+	FindRates(ctx *fiber.Ctx) error
+	CreateBpjsRate(ctx *fiber.Ctx) error
+	CreateRun(ctx *fiber.Ctx) error
+	FindAllRun(ctx *fiber.Ctx) error
+	FindRunByID(ctx *fiber.Ctx) error
+	RecalculateRun(ctx *fiber.Ctx) error
+	ReviewRun(ctx *fiber.Ctx) error
+	ReopenRun(ctx *fiber.Ctx) error
+	LockRun(ctx *fiber.Ctx) error
+	DeleteRun(ctx *fiber.Ctx) error
+	FindAllPayslip(ctx *fiber.Ctx) error
+	FindPayslipByID(ctx *fiber.Ctx) error
+	GeneratePayslipDocuments(ctx *fiber.Ctx) error
+}
+
+type payrollController struct {
+	validate       *validator.Validate
+	payrollService service.PayrollService
+	payslipService service.PayslipService
+}
+
+func NewPayrollController(validate *validator.Validate, payrollService service.PayrollService, payslipService service.PayslipService) PayrollController {
+	return &payrollController{
+		validate:       validate,
+		payrollService: payrollService,
+		payslipService: payslipService,
+	}
+}
+
+func (controller *payrollController) Route(app *fiber.App) {
+	// the payroll is managed by HR
+	api := app.Group(config.EndpointPrefixPayroll, middleware.IsAuthenticated, middleware.IsHR)
+
+	api.Post("/salaries", controller.CreateSalary)
+	api.Get("/salaries", controller.FindAllSalary)
+	api.Get("/rates", controller.FindRates)
+	api.Post("/rates/bpjs", controller.CreateBpjsRate)
+	api.Post("/runs", controller.CreateRun)
+	api.Get("/runs", controller.FindAllRun)
+	api.Get("/runs/:payroll_run_id", controller.FindRunByID)
+	api.Delete("/runs/:payroll_run_id", controller.DeleteRun)
+	api.Post("/runs/:payroll_run_id/recalculate", controller.RecalculateRun)
+	api.Post("/runs/:payroll_run_id/review", controller.ReviewRun)
+	api.Post("/runs/:payroll_run_id/reopen", controller.ReopenRun)
+	api.Post("/runs/:payroll_run_id/lock", controller.LockRun)
+	// the payslips of every employee, the employees find their own payslips in the payslip endpoints
+	api.Get("/runs/:payroll_run_id/payslips", controller.FindAllPayslip)
+	api.Get("/runs/:payroll_run_id/payslips/:payslip_id", controller.FindPayslipByID)
+	api.Post("/runs/:payroll_run_id/payslips/documents", controller.GeneratePayslipDocuments)
+}
+
+func (controller *payrollController) CreateSalary(ctx *fiber.Ctx) error {
+	// parse request body
+	var request web.CreateEmployeeSalaryRequest
+	err := ctx.BodyParser(&request)
+	if err != nil {
+		return exception.ErrValidateBadRequest(err.Error(), request)
+	}
+	// validate the values of the request body
+	err = controller.validate.Struct(request)
+	if err != nil {
+		return exception.ErrValidateBadRequest(err.Error(), request)
+	}
+
+	// create employee salary
+	salaryResponse, err := controller.payrollService.CreateSalary(ctx.Context(), request)
+	if err != nil {
+		return err
+	}
+
+	return ctx.Status(fiber.StatusCreated).JSON(web.WebResponse{
+		Code:    fiber.StatusCreated,
+		Status:  true,
+		Message: "success",
+		Data:    salaryResponse,
+	})
+}
+
+func (controller *payrollController) FindAllSalary(ctx *fiber.Ctx) error {
+	// parse query params
+	var filter web.EmployeeSalaryQueryFilter
+	if err := ctx.QueryParser(&filter); err != nil {
+		return exception.ErrValidateBadRequest(err.Error(), filter)
+	}
+	// validate the values of the query params
+	if err := controller.validate.Struct(filter); err != nil {
+		return exception.ErrValidateBadRequest(err.Error(), filter)
+	}
+
+	salaryResponses, err := controller.payrollService.FindAllSalary(ctx.Context(), filter)
+	if err != nil {
+		return err
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(web.WebResponse{
+		Code:    fiber.StatusOK,
+		Status:  true,
+		Message: "success",
+		Data:    salaryResponses,
+	})
+}
+
+func (controller *payrollController) FindRates(ctx *fiber.Ctx) error {
+	// parse query params
+	var filter web.PayrollRateQueryFilter
+	if err := ctx.QueryParser(&filter); err != nil {
+		return exception.ErrValidateBadRequest(err.Error(), filter)
+	}
+	// validate the values of the query params
+	if err := controller.validate.Struct(filter); err != nil {
+		return exception.ErrValidateBadRequest(err.Error(), filter)
+	}
+
+	ratesResponse, err := controller.payrollService.FindRates(ctx.Context(), filter)
+	if err != nil {
+		return err
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(web.WebResponse{
+		Code:    fiber.StatusOK,
+		Status:  true,
+		Message: "success",
+		Data:    ratesResponse,
+	})
+}
+
+func (controller *payrollController) CreateBpjsRate(ctx *fiber.Ctx) error {
+	// parse request body
+	var request web.CreateBpjsRateRequest
+	err := ctx.BodyParser(&request)
+	if err != nil {
+		return exception.ErrValidateBadRequest(err.Error(), request)
+	}
+	// validate the values of the request body
+	err = controller.validate.Struct(request)
+	if err != nil {
+		return exception.ErrValidateBadRequest(err.Error(), request)
+	}
+
+	// create a new version of the BPJS rate
+	rateResponse, err := controller.payrollService.CreateBpjsRate(ctx.Context(), request)
+	if err != nil {
+		return err
+	}
+
+	return ctx.Status(fiber.StatusCreated).JSON(web.WebResponse{
+		Code:    fiber.StatusCreated,
+		Status:  true,
+		Message: "success",
+		Data:    rateResponse,
+	})
+}
+
+func (controller *payrollController) CreateRun(ctx *fiber.Ctx) error {
+	// parse request body
+	var request web.CreatePayrollRunRequest
+	err := ctx.BodyParser(&request)
+	if err != nil {
+		return exception.ErrValidateBadRequest(err.Error(), request)
+	}
+	// validate the values of the request body
+	err = controller.validate.Struct(request)
+	if err != nil {
+		return exception.ErrValidateBadRequest(err.Error(), request)
+	}
+
+	// the payroll run is created by the logged in user
+	userID, _ := helper.ParseJwt(ctx.Cookies("token"))
+
+	// create and calculate the draft payroll run
+	runResponse, err := controller.payrollService.CreateRun(ctx.Context(), userID, request)
+	if err != nil {
+		return err
+	}
+
+	return ctx.Status(fiber.StatusCreated).JSON(web.WebResponse{
+		Code:    fiber.StatusCreated,
+		Status:  true,
+		Message: "success",
+		Data:    runResponse,
+	})
+}
+
+func (controller *payrollController) FindAllRun(ctx *fiber.Ctx) error {
+	// parse query params
+	var filter web.PayrollRunQueryFilter
+	if err := ctx.QueryParser(&filter); err != nil {
+		return exception.ErrValidateBadRequest(err.Error(), filter)
+	}
+	// validate the values of the query params
+	if err := controller.validate.Struct(filter); err != nil {
+		return exception.ErrValidateBadRequest(err.Error(), filter)
+	}
+
+	runResponses, totalData, err := controller.payrollService.FindAllRun(ctx.Context(), filter)
+	if err != nil {
+		return err
+	}
+
+	// with pagination
+	if filter.Page != "" || filter.Limit != "" {
+		pageInt, _ := strconv.Atoi(filter.Page)
+		if filter.Page == "" {
+			pageInt = 1
+		}
+		return ctx.Status(fiber.StatusOK).JSON(web.WebResponsePagination{
+			Code:      fiber.StatusOK,
+			Status:    true,
+			Page:      pageInt,
+			Count:     len(runResponses),
+			TotalData: totalData,
+			Message:   "success",
+			Data:      runResponses,
+		})
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(web.WebResponse{
+		Code:    fiber.StatusOK,
+		Status:  true,
+		Message: "success",
+		Data:    runResponses,
+	})
+}
+
+func (controller *payrollController) FindRunByID(ctx *fiber.Ctx) error {
+	// parse path params
+	runID := ctx.Params("payroll_run_id")
+
+	runResponse, err := controller.payrollService.FindRunById(ctx.Context(), runID)
+	if err != nil {
+		return err
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(web.WebResponse{
+		Code:    fiber.StatusOK,
+		Status:  true,
+		Message: "success",
+		Data:    runResponse,
+	})
+}
+
+func (controller *payrollController) RecalculateRun(ctx *fiber.Ctx) error {
+	// parse path params
+	runID := ctx.Params("payroll_run_id")
+
+	// recalculate the draft payroll run
+	runResponse, err := controller.payrollService.RecalculateRun(ctx.Context(), runID)
+	if err != nil {
+		return err
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(web.WebResponse{
+		Code:    fiber.StatusOK,
+		Status:  true,
+		Message: "success",
+		Data:    runResponse,
+	})
+}
+
+func (controller *payrollController) ReviewRun(ctx *fiber.Ctx) error {
+	// parse path params
+	runID := ctx.Params("payroll_run_id")
+	userID, _ := helper.ParseJwt(ctx.Cookies("token"))
+
+	// mark the draft payroll run as reviewed
+	runResponse, err := controller.payrollService.ReviewRun(ctx.Context(), userID, runID)
+	if err != nil {
+		return err
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(web.WebResponse{
+		Code:    fiber.StatusOK,
+		Status:  true,
+		Message: "success",
+		Data:    runResponse,
+	})
+}
+
+func (controller *payrollController) ReopenRun(ctx *fiber.Ctx) error {
+	// parse path params
+	runID := ctx.Params("payroll_run_id")
+
+	// return the reviewed payroll run to draft
+	runResponse, err := controller.payrollService.ReopenRun(ctx.Context(), runID)
+	if err != nil {
+		return err
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(web.WebResponse{
+		Code:    fiber.StatusOK,
+		Status:  true,
+		Message: "success",
+		Data:    runResponse,
+	})
+}
+
+func (controller *payrollController) LockRun(ctx *fiber.Ctx) error {
+	// parse path params
+	runID := ctx.Params("payroll_run_id")
+	userID, _ := helper.ParseJwt(ctx.Cookies("token"))
+
+	// lock the reviewed payroll run
+	runResponse, err := controller.payrollService.LockRun(ctx.Context(), userID, runID)
+	if err != nil {
+		return err
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(web.WebResponse{
+		Code:    fiber.StatusOK,
+		Status:  true,
+		Message: "success",
+		Data:    runResponse,
+	})
+}
+
+func (controller *payrollController) DeleteRun(ctx *fiber.Ctx) error {
+	// parse path params
+	runID := ctx.Params("payroll_run_id")
+
+	// delete the draft payroll run
+	err := controller.payrollService.DeleteRun(ctx.Context(), runID)
+	if err != nil {
+		return err
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(web.WebResponse{
+		Code:    fiber.StatusOK,
+		Status:  true,
+		Message: "success",
+	})
+}
+
+func (controller *payrollController) FindAllPayslip(ctx *fiber.Ctx) error {
+	// parse path params
+	runID := ctx.Params("payroll_run_id")
+
+	payslipResponses, err := controller.payrollService.FindAllPayslip(ctx.Context(), runID)
+	if err != nil {
+		return err
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(web.WebResponse{
+		Code:    fiber.StatusOK,
+		Status:  true,
+		Message: "success",
+		Data:    payslipResponses,
+	})
+}
+
+func (controller *payrollController) FindPayslipByID(ctx *fiber.Ctx) error {
+	// parse path params
+	runID := ctx.Params("payroll_run_id")
+	payslipID := ctx.Params("payslip_id")
+
+	payslipResponse, err := controller.payrollService.FindPayslipById(ctx.Context(), runID, payslipID)
+	if err != nil {
+		return err
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(web.WebResponse{
+		Code:    fiber.StatusOK,
+		Status:  true,
+		Message: "success",
+		Data:    payslipResponse,
+	})
+}
+
+func (controller *payrollController) GeneratePayslipDocuments(ctx *fiber.Ctx) error {
+	// parse path params
+	runID := ctx.Params("payroll_run_id")
+
+	// parse request body, the body is optional
+	var request web.GeneratePayslipDocumentRequest
+	if len(ctx.Body()) > 0 {
+		if err := ctx.BodyParser(&request); err != nil {
+			return exception.ErrValidateBadRequest(err.Error(), request)
+		}
+	}
+
+	// generate the payslip pdfs of the payroll run
+	documentResponse, err := controller.payslipService.GenerateDocuments(ctx.Context(), runID, request)
+	if err != nil {
+		return err
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(web.WebResponse{
+		Code:    fiber.StatusOK,
+		Status:  true,
+		Message: "success",
+		Data:    documentResponse,
+	})
+}
@@ -0,0 +1,103 @@
+package controller
+
+import (
+	"fmt"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/gofiber/fiber/v2"
+	"github.com/iqbaludinm/hr-microservice/user-service/config"
+	"github.com/iqbaludinm/hr-microservice/user-service/exception"
+	"github.com/iqbaludinm/hr-microservice/user-service/helper"
+	"github.com/iqbaludinm/hr-microservice/user-service/middleware"
+	"github.com/iqbaludinm/hr-microservice/user-service/model/web"
+	"github.com/iqbaludinm/hr-microservice/user-service/service"
+)
+
+type PayslipController interface {
+	// This function is used to define the route of the endpoints in this controller to the main application
+	Route(app *fiber.App)
+	FindMyPayslip(ctx *fiber.Ctx) error
+	DownloadMyPayslip(ctx *fiber.Ctx) error
+	EmailMyPayslip(ctx *fiber.Ctx) error
+}
+
+type payslipController struct {
+	validate       *validator.Validate
+	payslipService service.PayslipService
+}
+
+func NewPayslipController(validate *validator.Validate, payslipService service.PayslipService) PayslipController {
+	return &payslipController{
+		validate:       validate,
+		payslipService: payslipService,
+	}
+}
+
+// The payslips of the logged in user, only their own payslips are found. The payslips of the other employees are
+// managed by HR in the payroll endpoints.
+func (controller *payslipController) Route(app *fiber.App) {
+	api := app.Group(config.EndpointPrefixPayslip, middleware.IsAuthenticated)
+
+	api.Get("/", controller.FindMyPayslip)
+	api.Get("/:payslip_id/download", controller.DownloadMyPayslip)
+	api.Post("/:payslip_id/email", controller.EmailMyPayslip)
+}
+
+func (controller *payslipController) FindMyPayslip(ctx *fiber.Ctx) error {
+	// parse query params
+	var filter web.PayslipQueryFilter
+	if err := ctx.QueryParser(&filter); err != nil {
+		return exception.ErrValidateBadRequest(err.Error(), filter)
+	}
+	// validate the values of the query params
+	if err := controller.validate.Struct(filter); err != nil {
+		return exception.ErrValidateBadRequest(err.Error(), filter)
+	}
+	userID, _ := helper.ParseJwt(ctx.Cookies("token"))
+
+	payslipResponses, err := controller.payslipService.FindMyPayslip(ctx.Context(), userID, filter)
+	if err != nil {
+		return err
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(web.WebResponse{
+		Code:    fiber.StatusOK,
+		Status:  true,
+		Message: "success",
+		Data:    payslipResponses,
+	})
+}
+
+func (controller *payslipController) DownloadMyPayslip(ctx *fiber.Ctx) error {
+	// parse path params
+	payslipID := ctx.Params("payslip_id")
+	userID, _ := helper.ParseJwt(ctx.Cookies("token"))
+
+	pdf, fileName, err := controller.payslipService.DownloadMyPayslip(ctx.Context(), userID, payslipID)
+	if err != nil {
+		return err
+	}
+
+	ctx.Set(fiber.HeaderContentType, "application/pdf")
+	ctx.Set(fiber.HeaderContentDisposition, fmt.Sprintf("attachment; filename=%s", fileName))
+
+	return ctx.Status(fiber.StatusOK).Send(pdf)
+}
+
+func (controller *payslipController) EmailMyPayslip(ctx *fiber.Ctx) error {
+	// parse path params
+	payslipID := ctx.Params("payslip_id")
+	userID, _ := helper.ParseJwt(ctx.Cookies("token"))
+
+	payslipResponse, err := controller.payslipService.EmailMyPayslip(ctx.Context(), userID, payslipID)
+	if err != nil {
+		return err
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(web.WebResponse{
+		Code:    fiber.StatusOK,
+		Status:  true,
+		Message: "success",
+		Data:    payslipResponse,
+	})
+}
@@ -0,0 +1,340 @@
+package controller
+
+import (
+	"fmt"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/gofiber/fiber/v2"
+	"github.com/iqbaludinm/hr-microservice/user-service/config"
+	"github.com/iqbaludinm/hr-microservice/user-service/exception"
+	"github.com/iqbaludinm/hr-microservice/user-service/helper"
+	"github.com/iqbaludinm/hr-microservice/user-service/middleware"
+	"github.com/iqbaludinm/hr-microservice/user-service/model/web"
+	"github.com/iqbaludinm/hr-microservice/user-service/service"
+)
+
+type PerformanceReviewController interface {
+	// This function is used to define the route of the endpoints in this controller to the main application
+	Route(app *fiber.App)
+	UpdateGoals(ctx *fiber.Ctx) error
+	SelfAssessment(ctx *fiber.Ctx) error
+	ManagerAssessment(ctx *fiber.Ctx) error
+	UpdateReviewer(ctx *fiber.Ctx) error
+	RequestPeers(ctx *fiber.Ctx) error
+	SubmitPeerFeedback(ctx *fiber.Ctx) error
+	Calibrate(ctx *fiber.Ctx) error
+	DownloadSummary(ctx *fiber.Ctx) error
+	FindMyReviews(ctx *fiber.Ctx) error
+	FindTeamReviews(ctx *fiber.Ctx) error
+	FindMyPeerRequests(ctx *fiber.Ctx) error
+	FindByID(ctx *fiber.Ctx) error
+}
+
+type performanceReviewController struct {
+	validate                 *validator.Validate
+	performanceReviewService service.PerformanceReviewService
+}
+
+func NewPerformanceReviewController(validate *validator.Validate, performanceReviewService service.PerformanceReviewService) PerformanceReviewController {
+	return &performanceReviewController{
+		validate:                 validate,
+		performanceReviewService: performanceReviewService,
+	}
+}
+
+func (controller *performanceReviewController) Route(app *fiber.App) {
+	api := app.Group(config.EndpointPrefixPerformanceReview, middleware.IsAuthenticated)
+
+	api.Get("/me", controller.FindMyReviews)
+	api.Get("/team", controller.FindTeamReviews)
+	api.Get("/peer-requests/me", controller.FindMyPeerRequests)
+	api.Get("/:review_id", controller.FindByID)
+	api.Put("/:review_id/goals", controller.UpdateGoals)
+	api.Put("/:review_id/self-assessment", controller.SelfAssessment)
+	api.Put("/:review_id/manager-assessment", controller.ManagerAssessment)
+	api.Put("/:review_id/reviewer", controller.UpdateReviewer)
+	// the scores are calibrated by HR as the calibration committee
+	api.Put("/:review_id/calibration", middleware.IsHR, controller.Calibrate)
+	api.Post("/:review_id/peers", controller.RequestPeers)
+	api.Put("/:review_id/peers/me", controller.SubmitPeerFeedback)
+	api.Get("/:review_id/summary", controller.DownloadSummary)
+}
+
+func (controller *performanceReviewController) UpdateGoals(ctx *fiber.Ctx) error {
+	// parse request body
+	var request web.ReviewGoalsRequest
+	if err := ctx.BodyParser(&request); err != nil {
+		return exception.ErrValidateBadRequest(err.Error(), request)
+	}
+	// validate the values of request body
+	if err := controller.validate.Struct(request); err != nil {
+		return exception.ErrValidateBadRequest(err.Error(), request)
+	}
+
+	// parse path params
+	reviewID := ctx.Params("review_id")
+	// the goals are set by the logged in user
+	userID, _ := helper.ParseJwt(ctx.Cookies("token"))
+
+	reviewResponse, err := controller.performanceReviewService.UpdateGoals(ctx.Context(), userID, reviewID, request)
+	if err != nil {
+		return err
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(web.WebResponse{
+		Code:    fiber.StatusOK,
+		Status:  true,
+		Message: "success",
+		Data:    reviewResponse,
+	})
+}
+
+func (controller *performanceReviewController) SelfAssessment(ctx *fiber.Ctx) error {
+	// parse request body
+	var request web.ReviewAssessmentRequest
+	if err := ctx.BodyParser(&request); err != nil {
+		return exception.ErrValidateBadRequest(err.Error(), request)
+	}
+	// validate the values of request body
+	if err := controller.validate.Struct(request); err != nil {
+		return exception.ErrValidateBadRequest(err.Error(), request)
+	}
+
+	// parse path params
+	reviewID := ctx.Params("review_id")
+	// the self assessment is filled by the logged in user
+	userID, _ := helper.ParseJwt(ctx.Cookies("token"))
+
+	reviewResponse, err := controller.performanceReviewService.SelfAssessment(ctx.Context(), userID, reviewID, request)
+	if err != nil {
+		return err
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(web.WebResponse{
+		Code:    fiber.StatusOK,
+		Status:  true,
+		Message: "success",
+		Data:    reviewResponse,
+	})
+}
+
+func (controller *performanceReviewController) ManagerAssessment(ctx *fiber.Ctx) error {
+	// parse request body
+	var request web.ReviewAssessmentRequest
+	if err := ctx.BodyParser(&request); err != nil {
+		return exception.ErrValidateBadRequest(err.Error(), request)
+	}
+	// validate the values of request body
+	if err := controller.validate.Struct(request); err != nil {
+		return exception.ErrValidateBadRequest(err.Error(), request)
+	}
+
+	// parse path params
+	reviewID := ctx.Params("review_id")
+	// the manager assessment is filled by the logged in user
+	userID, _ := helper.ParseJwt(ctx.Cookies("token"))
+
+	reviewResponse, err := controller.performanceReviewService.ManagerAssessment(ctx.Context(), userID, reviewID, request)
+	if err != nil {
+		return err
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(web.WebResponse{
+		Code:    fiber.StatusOK,
+		Status:  true,
+		Message: "success",
+		Data:    reviewResponse,
+	})
+}
+
+func (controller *performanceReviewController) UpdateReviewer(ctx *fiber.Ctx) error {
+	// parse request body
+	var request web.ReviewReviewerRequest
+	if err := ctx.BodyParser(&request); err != nil {
+		return exception.ErrValidateBadRequest(err.Error(), request)
+	}
+	// validate the values of request body
+	if err := controller.validate.Struct(request); err != nil {
+		return exception.ErrValidateBadRequest(err.Error(), request)
+	}
+
+	// parse path params
+	reviewID := ctx.Params("review_id")
+
+	reviewResponse, err := controller.performanceReviewService.UpdateReviewer(ctx.Context(), reviewID, request)
+	if err != nil {
+		return err
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(web.WebResponse{
+		Code:    fiber.StatusOK,
+		Status:  true,
+		Message: "success",
+		Data:    reviewResponse,
+	})
+}
+
+func (controller *performanceReviewController) RequestPeers(ctx *fiber.Ctx) error {
+	// parse request body
+	var request web.ReviewPeersRequest
+	if err := ctx.BodyParser(&request); err != nil {
+		return exception.ErrValidateBadRequest(err.Error(), request)
+	}
+	// validate the values of request body
+	if err := controller.validate.Struct(request); err != nil {
+		return exception.ErrValidateBadRequest(err.Error(), request)
+	}
+
+	// parse path params
+	reviewID := ctx.Params("review_id")
+	// the peer feedback is requested by the logged in user
+	userID, _ := helper.ParseJwt(ctx.Cookies("token"))
+
+	reviewResponse, err := controller.performanceReviewService.RequestPeers(ctx.Context(), userID, reviewID, request)
+	if err != nil {
+		return err
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(web.WebResponse{
+		Code:    fiber.StatusOK,
+		Status:  true,
+		Message: "success",
+		Data:    reviewResponse,
+	})
+}
+
+func (controller *performanceReviewController) SubmitPeerFeedback(ctx *fiber.Ctx) error {
+	// parse request body
+	var request web.ReviewPeerFeedbackRequest
+	if err := ctx.BodyParser(&request); err != nil {
+		return exception.ErrValidateBadRequest(err.Error(), request)
+	}
+	// validate the values of request body
+	if err := controller.validate.Struct(request); err != nil {
+		return exception.ErrValidateBadRequest(err.Error(), request)
+	}
+
+	// parse path params
+	reviewID := ctx.Params("review_id")
+	// the peer feedback is submitted by the logged in user
+	userID, _ := helper.ParseJwt(ctx.Cookies("token"))
+
+	if err := controller.performanceReviewService.SubmitPeerFeedback(ctx.Context(), userID, reviewID, request); err != nil {
+		return err
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(web.WebResponse{
+		Code:    fiber.StatusOK,
+		Status:  true,
+		Message: "success",
+	})
+}
+
+func (controller *performanceReviewController) Calibrate(ctx *fiber.Ctx) error {
+	// parse request body
+	var request web.ReviewCalibrationRequest
+	if err := ctx.BodyParser(&request); err != nil {
+		return exception.ErrValidateBadRequest(err.Error(), request)
+	}
+	// validate the values of request body
+	if err := controller.validate.Struct(request); err != nil {
+		return exception.ErrValidateBadRequest(err.Error(), request)
+	}
+
+	// parse path params
+	reviewID := ctx.Params("review_id")
+	// the score is calibrated by the logged in user
+	userID, _ := helper.ParseJwt(ctx.Cookies("token"))
+
+	reviewResponse, err := controller.performanceReviewService.Calibrate(ctx.Context(), userID, reviewID, request)
+	if err != nil {
+		return err
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(web.WebResponse{
+		Code:    fiber.StatusOK,
+		Status:  true,
+		Message: "success",
+		Data:    reviewResponse,
+	})
+}
+
+func (controller *performanceReviewController) DownloadSummary(ctx *fiber.Ctx) error {
+	// parse path params
+	reviewID := ctx.Params("review_id")
+
+	pdf, fileName, err := controller.performanceReviewService.Summary(ctx.Context(), reviewID)
+	if err != nil {
+		return err
+	}
+
+	ctx.Set(fiber.HeaderContentType, "application/pdf")
+	ctx.Set(fiber.HeaderContentDisposition, fmt.Sprintf("attachment; filename=%s", fileName))
+
+	return ctx.Status(fiber.StatusOK).Send(pdf)
+}
+
+func (controller *performanceReviewController) FindMyReviews(ctx *fiber.Ctx) error {
+	userID, _ := helper.ParseJwt(ctx.Cookies("token"))
+
+	reviewResponses, err := controller.performanceReviewService.FindMyReviews(ctx.Context(), userID)
+	if err != nil {
+		return err
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(web.WebResponse{
+		Code:    fiber.StatusOK,
+		Status:  true,
+		Message: "success",
+		Data:    reviewResponses,
+	})
+}
+
+func (controller *performanceReviewController) FindTeamReviews(ctx *fiber.Ctx) error {
+	userID, _ := helper.ParseJwt(ctx.Cookies("token"))
+
+	reviewResponses, err := controller.performanceReviewService.FindTeamReviews(ctx.Context(), userID)
+	if err != nil {
+		return err
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(web.WebResponse{
+		Code:    fiber.StatusOK,
+		Status:  true,
+		Message: "success",
+		Data:    reviewResponses,
+	})
+}
+
+func (controller *performanceReviewController) FindMyPeerRequests(ctx *fiber.Ctx) error {
+	userID, _ := helper.ParseJwt(ctx.Cookies("token"))
+
+	peerResponses, err := controller.performanceReviewService.FindMyPeerRequests(ctx.Context(), userID)
+	if err != nil {
+		return err
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(web.WebResponse{
+		Code:    fiber.StatusOK,
+		Status:  true,
+		Message: "success",
+		Data:    peerResponses,
+	})
+}
+
+func (controller *performanceReviewController) FindByID(ctx *fiber.Ctx) error {
+	// parse path params
+	reviewID := ctx.Params("review_id")
+
+	reviewResponse, err := controller.performanceReviewService.FindReviewById(ctx.Context(), reviewID)
+	if err != nil {
+		return err
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(web.WebResponse{
+		Code:    fiber.StatusOK,
+		Status:  true,
+		Message: "success",
+		Data:    reviewResponse,
+	})
+}
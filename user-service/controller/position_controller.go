@@ -0,0 +1,154 @@
+package controller
+
+import (
+	"github.com/go-playground/validator/v10"
+	"github.com/gofiber/fiber/v2"
+	"github.com/iqbaludinm/hr-microservice/user-service/config"
+	"github.com/iqbaludinm/hr-microservice/user-service/exception"
+	"github.com/iqbaludinm/hr-microservice/user-service/middleware"
+	"github.com/iqbaludinm/hr-microservice/user-service/model/web"
+	"github.com/iqbaludinm/hr-microservice/user-service/service"
+)
+
+type PositionController interface {
+	// This function is used to define the route of the endpoints in this controller to the main application
+	Route(app *fiber.App)
+	CreatePosition(ctx *fiber.Ctx) error
+	UpdatePosition(ctx *fiber.Ctx) error
+	DeletePosition(ctx *fiber.Ctx) error
+	FindAllPosition(ctx *fiber.Ctx) error
+	FindByID(ctx *fiber.Ctx) error
+}
+
+type positionController struct {
+	validate        *validator.Validate
+	positionService service.PositionService
+}
+
+func NewPositionController(validate *validator.Validate, positionService service.PositionService) PositionController {
+	return &positionController{
+		validate:        validate,
+		positionService: positionService,
+	}
+}
+
+func (controller *positionController) Route(app *fiber.App) {
+	api := app.Group(config.EndpointPrefixPosition, middleware.IsAuthenticated)
+
+	api.Post("/", controller.CreatePosition)
+	api.Get("/", controller.FindAllPosition)
+	api.Get("/:position_id", controller.FindByID)
+	api.Put("/:position_id", controller.UpdatePosition)
+	api.Delete("/:position_id", controller.DeletePosition)
+}
+
+func (controller *positionController) CreatePosition(ctx *fiber.Ctx) error {
+	// parse request body
+	var request web.CreatePositionRequest
+	err := ctx.BodyParser(&request)
+	if err != nil {
+		return exception.ErrValidateBadRequest(err.Error(), request)
+	}
+	// validate the values of the request body
+	err = controller.validate.Struct(request)
+	if err != nil {
+		return exception.ErrValidateBadRequest(err.Error(), request)
+	}
+
+	// create position
+	positionResponse, err := controller.positionService.CreatePosition(ctx.Context(), request)
+	if err != nil {
+		return err
+	}
+
+	return ctx.Status(fiber.StatusCreated).JSON(web.WebResponse{
+		Code:    fiber.StatusCreated,
+		Status:  true,
+		Message: "success",
+		Data:    positionResponse,
+	})
+}
+
+func (controller *positionController) UpdatePosition(ctx *fiber.Ctx) error {
+	// parse request body
+	var request web.UpdatePositionRequest
+	err := ctx.BodyParser(&request)
+	if err != nil {
+		return exception.ErrValidateBadRequest(err.Error(), request)
+	}
+	// validate the values of the request body
+	err = controller.validate.Struct(request)
+	if err != nil {
+		return exception.ErrValidateBadRequest(err.Error(), request)
+	}
+
+	// parse path params
+	positionID := ctx.Params("position_id")
+
+	// update position
+	positionResponse, err := controller.positionService.UpdatePosition(ctx.Context(), positionID, request)
+	if err != nil {
+		return err
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(web.WebResponse{
+		Code:    fiber.StatusOK,
+		Status:  true,
+		Message: "success",
+		Data:    positionResponse,
+	})
+}
+
+func (controller *positionController) DeletePosition(ctx *fiber.Ctx) error {
+	// parse path params
+	positionID := ctx.Params("position_id")
+
+	// delete position
+	err := controller.positionService.Delete(ctx.Context(), positionID)
+	if err != nil {
+		return err
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(web.WebResponse{
+		Code:    fiber.StatusOK,
+		Status:  true,
+		Message: "success",
+	})
+}
+
+func (controller *positionController) FindByID(ctx *fiber.Ctx) error {
+	// parse path params
+	positionID := ctx.Params("position_id")
+
+	position, err := controller.positionService.FindById(ctx.Context(), positionID)
+	if err != nil {
+		return err
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(web.WebResponse{
+		Code:    fiber.StatusOK,
+		Status:  true,
+		Message: "success",
+		Data:    position,
+	})
+}
+
+func (controller *positionController) FindAllPosition(ctx *fiber.Ctx) error {
+	// parse query params
+	var filter web.PositionQueryFilter
+	if err := ctx.QueryParser(&filter); err != nil {
+		return exception.ErrValidateBadRequest(err.Error(), filter)
+	}
+
+	positionResponses, err := controller.positionService.FindAllPosition(ctx.Context(), filter)
+	if err != nil {
+		return err
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(web.WebResponse{
+		Code:    fiber.StatusOK,
+		Status:  true,
+		Message: "success",
+		Data:    positionResponses,
+	})
+}
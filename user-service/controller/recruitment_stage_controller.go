@@ -0,0 +1,123 @@
+package controller
+
+import (
+	"github.com/go-playground/validator/v10"
+	"github.com/gofiber/fiber/v2"
+	"github.com/iqbaludinm/hr-microservice/user-service/config"
+	"github.com/iqbaludinm/hr-microservice/user-service/exception"
+	"github.com/iqbaludinm/hr-microservice/user-service/middleware"
+	"github.com/iqbaludinm/hr-microservice/user-service/model/web"
+	"github.com/iqbaludinm/hr-microservice/user-service/service"
+)
+
+type RecruitmentStageController interface {
+	// This function is used to define the route of the endpoints in this controller to the main application
+	Route(app *fiber.App)
+	CreateStage(ctx *fiber.Ctx) error
+	UpdateStage(ctx *fiber.Ctx) error
+	DeleteStage(ctx *fiber.Ctx) error
+	FindAllStage(ctx *fiber.Ctx) error
+}
+
+type recruitmentStageController struct {
+	validate              *validator.Validate
+	jobApplicationService service.JobApplicationService
+}
+
+func NewRecruitmentStageController(validate *validator.Validate, jobApplicationService service.JobApplicationService) RecruitmentStageController {
+	return &recruitmentStageController{
+		validate:              validate,
+		jobApplicationService: jobApplicationService,
+	}
+}
+
+func (controller *recruitmentStageController) Route(app *fiber.App) {
+	api := app.Group(config.EndpointPrefixRecruitmentStage, middleware.IsAuthenticated)
+
+	api.Get("/", controller.FindAllStage)
+	api.Post("/", controller.CreateStage)
+	api.Put("/:stage_id", controller.UpdateStage)
+	api.Delete("/:stage_id", controller.DeleteStage)
+}
+
+func (controller *recruitmentStageController) CreateStage(ctx *fiber.Ctx) error {
+	// parse request body
+	var request web.RecruitmentStageRequest
+	if err := ctx.BodyParser(&request); err != nil {
+		return exception.ErrValidateBadRequest(err.Error(), request)
+	}
+	// validate the values of request body
+	if err := controller.validate.Struct(request); err != nil {
+		return exception.ErrValidateBadRequest(err.Error(), request)
+	}
+
+	stageResponse, err := controller.jobApplicationService.CreateStage(ctx.Context(), request)
+	if err != nil {
+		return err
+	}
+
+	return ctx.Status(fiber.StatusCreated).JSON(web.WebResponse{
+		Code:    fiber.StatusCreated,
+		Status:  true,
+		Message: "success",
+		Data:    stageResponse,
+	})
+}
+
+func (controller *recruitmentStageController) UpdateStage(ctx *fiber.Ctx) error {
+	// parse request body
+	var request web.RecruitmentStageRequest
+	if err := ctx.BodyParser(&request); err != nil {
+		return exception.ErrValidateBadRequest(err.Error(), request)
+	}
+	// validate the values of request body
+	if err := controller.validate.Struct(request); err != nil {
+		return exception.ErrValidateBadRequest(err.Error(), request)
+	}
+
+	// parse path params
+	stageID := ctx.Params("stage_id")
+
+	stageResponse, err := controller.jobApplicationService.UpdateStage(ctx.Context(), stageID, request)
+	if err != nil {
+		return err
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(web.WebResponse{
+		Code:    fiber.StatusOK,
+		Status:  true,
+		Message: "success",
+		Data:    stageResponse,
+	})
+}
+
+func (controller *recruitmentStageController) DeleteStage(ctx *fiber.Ctx) error {
+	// parse path params
+	stageID := ctx.Params("stage_id")
+
+	// delete stage, the stage which has been used by an application can't be deleted
+	err := controller.jobApplicationService.DeleteStage(ctx.Context(), stageID)
+	if err != nil {
+		return err
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(web.WebResponse{
+		Code:    fiber.StatusOK,
+		Status:  true,
+		Message: "success",
+	})
+}
+
+func (controller *recruitmentStageController) FindAllStage(ctx *fiber.Ctx) error {
+	stageResponses, err := controller.jobApplicationService.FindAllStage(ctx.Context())
+	if err != nil {
+		return err
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(web.WebResponse{
+		Code:    fiber.StatusOK,
+		Status:  true,
+		Message: "success",
+		Data:    stageResponses,
+	})
+}
@@ -0,0 +1,239 @@
+package controller
+
+import (
+	"strconv"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/gofiber/fiber/v2"
+	"github.com/iqbaludinm/hr-microservice/user-service/config"
+	"github.com/iqbaludinm/hr-microservice/user-service/exception"
+	"github.com/iqbaludinm/hr-microservice/user-service/helper"
+	"github.com/iqbaludinm/hr-microservice/user-service/middleware"
+	"github.com/iqbaludinm/hr-microservice/user-service/model/web"
+	"github.com/iqbaludinm/hr-microservice/user-service/service"
+)
+
+type ReviewCycleController interface {
+	// This function is used to define the route of the endpoints in this controller to the main application
+	Route(app *fiber.App)
+	CreateCycle(ctx *fiber.Ctx) error
+	UpdateCycle(ctx *fiber.Ctx) error
+	AdvanceCycle(ctx *fiber.Ctx) error
+	FindAllCycle(ctx *fiber.Ctx) error
+	FindByID(ctx *fiber.Ctx) error
+	FindReviews(ctx *fiber.Ctx) error
+	RatingDistribution(ctx *fiber.Ctx) error
+}
+
+type reviewCycleController struct {
+	validate                 *validator.Validate
+	performanceReviewService service.PerformanceReviewService
+}
+
+func NewReviewCycleController(validate *validator.Validate, performanceReviewService service.PerformanceReviewService) ReviewCycleController {
+	return &reviewCycleController{
+		validate:                 validate,
+		performanceReviewService: performanceReviewService,
+	}
+}
+
+func (controller *reviewCycleController) Route(app *fiber.App) {
+	api := app.Group(config.EndpointPrefixReviewCycle, middleware.IsAuthenticated)
+
+	api.Post("/", controller.CreateCycle)
+	api.Get("/", controller.FindAllCycle)
+	api.Get("/:cycle_id", controller.FindByID)
+	api.Put("/:cycle_id", controller.UpdateCycle)
+	api.Post("/:cycle_id/advance", controller.AdvanceCycle)
+	api.Get("/:cycle_id/reviews", controller.FindReviews)
+	api.Get("/:cycle_id/distribution", controller.RatingDistribution)
+}
+
+func (controller *reviewCycleController) CreateCycle(ctx *fiber.Ctx) error {
+	// parse request body
+	var request web.ReviewCycleRequest
+	if err := ctx.BodyParser(&request); err != nil {
+		return exception.ErrValidateBadRequest(err.Error(), request)
+	}
+	// validate the values of request body
+	if err := controller.validate.Struct(request); err != nil {
+		return exception.ErrValidateBadRequest(err.Error(), request)
+	}
+
+	// the review cycle is created by the logged in user
+	userID, _ := helper.ParseJwt(ctx.Cookies("token"))
+
+	cycleResponse, err := controller.performanceReviewService.CreateCycle(ctx.Context(), userID, request)
+	if err != nil {
+		return err
+	}
+
+	return ctx.Status(fiber.StatusCreated).JSON(web.WebResponse{
+		Code:    fiber.StatusCreated,
+		Status:  true,
+		Message: "success",
+		Data:    cycleResponse,
+	})
+}
+
+func (controller *reviewCycleController) UpdateCycle(ctx *fiber.Ctx) error {
+	// parse request body
+	var request web.ReviewCycleRequest
+	if err := ctx.BodyParser(&request); err != nil {
+		return exception.ErrValidateBadRequest(err.Error(), request)
+	}
+	// validate the values of request body
+	if err := controller.validate.Struct(request); err != nil {
+		return exception.ErrValidateBadRequest(err.Error(), request)
+	}
+
+	// parse path params
+	cycleID := ctx.Params("cycle_id")
+
+	cycleResponse, err := controller.performanceReviewService.UpdateCycle(ctx.Context(), cycleID, request)
+	if err != nil {
+		return err
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(web.WebResponse{
+		Code:    fiber.StatusOK,
+		Status:  true,
+		Message: "success",
+		Data:    cycleResponse,
+	})
+}
+
+func (controller *reviewCycleController) AdvanceCycle(ctx *fiber.Ctx) error {
+	// parse path params
+	cycleID := ctx.Params("cycle_id")
+
+	// move the review cycle to the next phase
+	cycleResponse, err := controller.performanceReviewService.AdvanceCycle(ctx.Context(), cycleID)
+	if err != nil {
+		return err
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(web.WebResponse{
+		Code:    fiber.StatusOK,
+		Status:  true,
+		Message: "success",
+		Data:    cycleResponse,
+	})
+}
+
+func (controller *reviewCycleController) FindAllCycle(ctx *fiber.Ctx) error {
+	// parse query params
+	var filter web.ReviewCycleQueryFilter
+	if err := ctx.QueryParser(&filter); err != nil {
+		return exception.ErrValidateBadRequest(err.Error(), filter)
+	}
+	// validate the values of the query params
+	if err := controller.validate.Struct(filter); err != nil {
+		return exception.ErrValidateBadRequest(err.Error(), filter)
+	}
+
+	cycleResponses, totalData, err := controller.performanceReviewService.FindAllCycle(ctx.Context(), filter)
+	if err != nil {
+		return err
+	}
+
+	if filter.Page != "" || filter.Limit != "" {
+		pageInt, _ := strconv.Atoi(filter.Page)
+		if filter.Page == "" {
+			pageInt = 1
+		}
+		return ctx.Status(fiber.StatusOK).JSON(web.WebResponsePagination{
+			Code:      fiber.StatusOK,
+			Status:    true,
+			Page:      pageInt,
+			Count:     len(cycleResponses),
+			TotalData: totalData,
+			Message:   "success",
+			Data:      cycleResponses,
+		})
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(web.WebResponse{
+		Code:    fiber.StatusOK,
+		Status:  true,
+		Message: "success",
+		Data:    cycleResponses,
+	})
+}
+
+func (controller *reviewCycleController) FindByID(ctx *fiber.Ctx) error {
+	// parse path params
+	cycleID := ctx.Params("cycle_id")
+
+	cycleResponse, err := controller.performanceReviewService.FindCycleById(ctx.Context(), cycleID)
+	if err != nil {
+		return err
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(web.WebResponse{
+		Code:    fiber.StatusOK,
+		Status:  true,
+		Message: "success",
+		Data:    cycleResponse,
+	})
+}
+
+func (controller *reviewCycleController) FindReviews(ctx *fiber.Ctx) error {
+	// parse query params
+	var filter web.PerformanceReviewQueryFilter
+	if err := ctx.QueryParser(&filter); err != nil {
+		return exception.ErrValidateBadRequest(err.Error(), filter)
+	}
+	// validate the values of the query params
+	if err := controller.validate.Struct(filter); err != nil {
+		return exception.ErrValidateBadRequest(err.Error(), filter)
+	}
+
+	// parse path params
+	cycleID := ctx.Params("cycle_id")
+
+	reviewResponses, totalData, err := controller.performanceReviewService.FindCycleReviews(ctx.Context(), cycleID, filter)
+	if err != nil {
+		return err
+	}
+
+	if filter.Page != "" || filter.Limit != "" {
+		pageInt, _ := strconv.Atoi(filter.Page)
+		if filter.Page == "" {
+			pageInt = 1
+		}
+		return ctx.Status(fiber.StatusOK).JSON(web.WebResponsePagination{
+			Code:      fiber.StatusOK,
+			Status:    true,
+			Page:      pageInt,
+			Count:     len(reviewResponses),
+			TotalData: totalData,
+			Message:   "success",
+			Data:      reviewResponses,
+		})
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(web.WebResponse{
+		Code:    fiber.StatusOK,
+		Status:  true,
+		Message: "success",
+		Data:    reviewResponses,
+	})
+}
+
+func (controller *reviewCycleController) RatingDistribution(ctx *fiber.Ctx) error {
+	// parse path params
+	cycleID := ctx.Params("cycle_id")
+
+	distributionResponses, err := controller.performanceReviewService.RatingDistribution(ctx.Context(), cycleID)
+	if err != nil {
+		return err
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(web.WebResponse{
+		Code:    fiber.StatusOK,
+		Status:  true,
+		Message: "success",
+		Data:    distributionResponses,
+	})
+}
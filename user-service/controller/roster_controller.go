@@ -0,0 +1,441 @@
+package controller
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/gofiber/fiber/v2"
+	"github.com/iqbaludinm/hr-microservice/user-service/config"
+	"github.com/iqbaludinm/hr-microservice/user-service/exception"
+	"github.com/iqbaludinm/hr-microservice/user-service/helper"
+	"github.com/iqbaludinm/hr-microservice/user-service/middleware"
+	"github.com/iqbaludinm/hr-microservice/user-service/model/web"
+	"github.com/iqbaludinm/hr-microservice/user-service/service"
+)
+
+type RosterController interface {
+	// This function is used to define the route of the endpoints in this controller to the main application
+	Route(app *fiber.App)
+	AssignRoster(ctx *fiber.Ctx) error
+	ClearRoster(ctx *fiber.Ctx) error
+	FindAllRoster(ctx *fiber.Ctx) error
+	FindMyRoster(ctx *fiber.Ctx) error
+	FindMyCalendar(ctx *fiber.Ctx) error
+	ResetMyCalendar(ctx *fiber.Ctx) error
+	ExportCalendar(ctx *fiber.Ctx) error
+	CreateShiftSwap(ctx *fiber.Ctx) error
+	ApproveShiftSwap(ctx *fiber.Ctx) error
+	RejectShiftSwap(ctx *fiber.Ctx) error
+	CancelShiftSwap(ctx *fiber.Ctx) error
+	FindAllShiftSwap(ctx *fiber.Ctx) error
+	FindMyShiftSwap(ctx *fiber.Ctx) error
+	FindPendingShiftSwaps(ctx *fiber.Ctx) error
+	FindShiftSwapByID(ctx *fiber.Ctx) error
+}
+
+type rosterController struct {
+	validate         *validator.Validate
+	rosterService    service.RosterService
+	shiftSwapService service.ShiftSwapService
+}
+
+func NewRosterController(validate *validator.Validate, rosterService service.RosterService, shiftSwapService service.ShiftSwapService) RosterController {
+	return &rosterController{
+		validate:         validate,
+		rosterService:    rosterService,
+		shiftSwapService: shiftSwapService,
+	}
+}
+
+func (controller *rosterController) Route(app *fiber.App) {
+	// the calendar apps subscribe the feed without the login cookie, so it is registered before the
+	// authenticated group and is authorized by the token in the path
+	app.Get(config.EndpointPrefixRoster+"/calendar/:token", controller.ExportCalendar)
+
+	api := app.Group(config.EndpointPrefixRoster, middleware.IsAuthenticated)
+
+	api.Get("/", controller.FindAllRoster)
+	api.Get("/me", controller.FindMyRoster)
+	api.Post("/assign", controller.AssignRoster)
+	api.Post("/clear", controller.ClearRoster)
+	api.Get("/me/calendar", controller.FindMyCalendar)
+	api.Put("/me/calendar", controller.ResetMyCalendar)
+	api.Post("/swaps", controller.CreateShiftSwap)
+	api.Get("/swaps", controller.FindAllShiftSwap)
+	api.Get("/swaps/me", controller.FindMyShiftSwap)
+	api.Get("/swaps/approvals", controller.FindPendingShiftSwaps)
+	api.Get("/swaps/:shift_swap_id", controller.FindShiftSwapByID)
+	api.Put("/swaps/:shift_swap_id/approve", controller.ApproveShiftSwap)
+	api.Put("/swaps/:shift_swap_id/reject", controller.RejectShiftSwap)
+	api.Put("/swaps/:shift_swap_id/cancel", controller.CancelShiftSwap)
+}
+
+func (controller *rosterController) AssignRoster(ctx *fiber.Ctx) error {
+	// parse request body
+	var request web.AssignRosterRequest
+	err := ctx.BodyParser(&request)
+	if err != nil {
+		return exception.ErrValidateBadRequest(err.Error(), request)
+	}
+	// validate the values of the request body
+	err = controller.validate.Struct(request)
+	if err != nil {
+		return exception.ErrValidateBadRequest(err.Error(), request)
+	}
+
+	// assign roster to the employees
+	err = controller.rosterService.AssignRoster(ctx.Context(), request)
+	if err != nil {
+		return err
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(web.WebResponse{
+		Code:    fiber.StatusOK,
+		Status:  true,
+		Message: "success",
+	})
+}
+
+func (controller *rosterController) ClearRoster(ctx *fiber.Ctx) error {
+	// parse request body
+	var request web.ClearRosterRequest
+	err := ctx.BodyParser(&request)
+	if err != nil {
+		return exception.ErrValidateBadRequest(err.Error(), request)
+	}
+	// validate the values of the request body
+	err = controller.validate.Struct(request)
+	if err != nil {
+		return exception.ErrValidateBadRequest(err.Error(), request)
+	}
+
+	// clear roster of the employees
+	err = controller.rosterService.ClearRoster(ctx.Context(), request)
+	if err != nil {
+		return err
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(web.WebResponse{
+		Code:    fiber.StatusOK,
+		Status:  true,
+		Message: "success",
+	})
+}
+
+func (controller *rosterController) FindAllRoster(ctx *fiber.Ctx) error {
+	// parse query params
+	filter, err := controller.parseFilter(ctx)
+	if err != nil {
+		return err
+	}
+
+	rosterResponses, err := controller.rosterService.FindAllRoster(ctx.Context(), filter)
+	if err != nil {
+		return err
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(web.WebResponse{
+		Code:    fiber.StatusOK,
+		Status:  true,
+		Message: "success",
+		Data:    rosterResponses,
+	})
+}
+
+func (controller *rosterController) FindMyRoster(ctx *fiber.Ctx) error {
+	// parse query params
+	filter, err := controller.parseFilter(ctx)
+	if err != nil {
+		return err
+	}
+	userID, _ := helper.ParseJwt(ctx.Cookies("token"))
+
+	rosterResponses, err := controller.rosterService.FindMyRoster(ctx.Context(), userID, filter)
+	if err != nil {
+		return err
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(web.WebResponse{
+		Code:    fiber.StatusOK,
+		Status:  true,
+		Message: "success",
+		Data:    rosterResponses,
+	})
+}
+
+func (controller *rosterController) FindMyCalendar(ctx *fiber.Ctx) error {
+	userID, _ := helper.ParseJwt(ctx.Cookies("token"))
+
+	calendarResponse, err := controller.rosterService.FindMyCalendar(ctx.Context(), userID)
+	if err != nil {
+		return err
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(web.WebResponse{
+		Code:    fiber.StatusOK,
+		Status:  true,
+		Message: "success",
+		Data:    calendarResponse,
+	})
+}
+
+func (controller *rosterController) ResetMyCalendar(ctx *fiber.Ctx) error {
+	userID, _ := helper.ParseJwt(ctx.Cookies("token"))
+
+	// replace the calendar token, the current subscription URL stops working
+	calendarResponse, err := controller.rosterService.ResetMyCalendar(ctx.Context(), userID)
+	if err != nil {
+		return err
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(web.WebResponse{
+		Code:    fiber.StatusOK,
+		Status:  true,
+		Message: "success",
+		Data:    calendarResponse,
+	})
+}
+
+func (controller *rosterController) ExportCalendar(ctx *fiber.Ctx) error {
+	// parse path params, the calendar apps usually request the feed with the '.ics' extension
+	token := strings.TrimSuffix(ctx.Params("token"), ".ics")
+
+	calendar, err := controller.rosterService.ExportCalendar(ctx.Context(), token)
+	if err != nil {
+		return err
+	}
+
+	ctx.Set(fiber.HeaderContentType, "text/calendar; charset=utf-8")
+	return ctx.Status(fiber.StatusOK).Send(calendar)
+}
+
+func (controller *rosterController) CreateShiftSwap(ctx *fiber.Ctx) error {
+	// parse request body
+	var request web.CreateShiftSwapRequest
+	err := ctx.BodyParser(&request)
+	if err != nil {
+		return exception.ErrValidateBadRequest(err.Error(), request)
+	}
+	// validate the values of the request body
+	err = controller.validate.Struct(request)
+	if err != nil {
+		return exception.ErrValidateBadRequest(err.Error(), request)
+	}
+
+	// the shift swap is requested by the logged in user
+	userID, _ := helper.ParseJwt(ctx.Cookies("token"))
+
+	// create shift swap request
+	swapResponse, err := controller.shiftSwapService.CreateShiftSwap(ctx.Context(), userID, request)
+	if err != nil {
+		return err
+	}
+
+	return ctx.Status(fiber.StatusCreated).JSON(web.WebResponse{
+		Code:    fiber.StatusCreated,
+		Status:  true,
+		Message: "success",
+		Data:    swapResponse,
+	})
+}
+
+func (controller *rosterController) ApproveShiftSwap(ctx *fiber.Ctx) error {
+	// parse request body
+	var request web.DecideShiftSwapRequest
+	if err := controller.parseDecision(ctx, &request); err != nil {
+		return err
+	}
+
+	// parse path params
+	swapID := ctx.Params("shift_swap_id")
+	userID, _ := helper.ParseJwt(ctx.Cookies("token"))
+
+	// approve shift swap request
+	swapResponse, err := controller.shiftSwapService.Approve(ctx.Context(), userID, swapID, request)
+	if err != nil {
+		return err
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(web.WebResponse{
+		Code:    fiber.StatusOK,
+		Status:  true,
+		Message: "success",
+		Data:    swapResponse,
+	})
+}
+
+func (controller *rosterController) RejectShiftSwap(ctx *fiber.Ctx) error {
+	// parse request body
+	var request web.DecideShiftSwapRequest
+	if err := controller.parseDecision(ctx, &request); err != nil {
+		return err
+	}
+
+	// parse path params
+	swapID := ctx.Params("shift_swap_id")
+	userID, _ := helper.ParseJwt(ctx.Cookies("token"))
+
+	// reject shift swap request
+	swapResponse, err := controller.shiftSwapService.Reject(ctx.Context(), userID, swapID, request)
+	if err != nil {
+		return err
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(web.WebResponse{
+		Code:    fiber.StatusOK,
+		Status:  true,
+		Message: "success",
+		Data:    swapResponse,
+	})
+}
+
+func (controller *rosterController) CancelShiftSwap(ctx *fiber.Ctx) error {
+	// parse path params
+	swapID := ctx.Params("shift_swap_id")
+	userID, _ := helper.ParseJwt(ctx.Cookies("token"))
+
+	// cancel shift swap request
+	swapResponse, err := controller.shiftSwapService.Cancel(ctx.Context(), userID, swapID)
+	if err != nil {
+		return err
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(web.WebResponse{
+		Code:    fiber.StatusOK,
+		Status:  true,
+		Message: "success",
+		Data:    swapResponse,
+	})
+}
+
+func (controller *rosterController) FindAllShiftSwap(ctx *fiber.Ctx) error {
+	// parse query params
+	filter, err := controller.parseShiftSwapFilter(ctx)
+	if err != nil {
+		return err
+	}
+
+	swapResponses, totalData, err := controller.shiftSwapService.FindAllShiftSwap(ctx.Context(), filter)
+	if err != nil {
+		return err
+	}
+
+	return controller.shiftSwapListResponse(ctx, filter, swapResponses, totalData)
+}
+
+func (controller *rosterController) FindMyShiftSwap(ctx *fiber.Ctx) error {
+	// parse query params
+	filter, err := controller.parseShiftSwapFilter(ctx)
+	if err != nil {
+		return err
+	}
+	userID, _ := helper.ParseJwt(ctx.Cookies("token"))
+
+	swapResponses, totalData, err := controller.shiftSwapService.FindMyShiftSwap(ctx.Context(), userID, filter)
+	if err != nil {
+		return err
+	}
+
+	return controller.shiftSwapListResponse(ctx, filter, swapResponses, totalData)
+}
+
+func (controller *rosterController) FindPendingShiftSwaps(ctx *fiber.Ctx) error {
+	// parse query params
+	filter, err := controller.parseShiftSwapFilter(ctx)
+	if err != nil {
+		return err
+	}
+	userID, _ := helper.ParseJwt(ctx.Cookies("token"))
+
+	swapResponses, totalData, err := controller.shiftSwapService.FindPendingApprovals(ctx.Context(), userID, filter)
+	if err != nil {
+		return err
+	}
+
+	return controller.shiftSwapListResponse(ctx, filter, swapResponses, totalData)
+}
+
+func (controller *rosterController) FindShiftSwapByID(ctx *fiber.Ctx) error {
+	// parse path params
+	swapID := ctx.Params("shift_swap_id")
+
+	swap, err := controller.shiftSwapService.FindById(ctx.Context(), swapID)
+	if err != nil {
+		return err
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(web.WebResponse{
+		Code:    fiber.StatusOK,
+		Status:  true,
+		Message: "success",
+		Data:    swap,
+	})
+}
+
+// parse and validate the query params of the roster
+func (controller *rosterController) parseFilter(ctx *fiber.Ctx) (web.RosterQueryFilter, error) {
+	var filter web.RosterQueryFilter
+	if err := ctx.QueryParser(&filter); err != nil {
+		return filter, exception.ErrValidateBadRequest(err.Error(), filter)
+	}
+	if err := controller.validate.Struct(filter); err != nil {
+		return filter, exception.ErrValidateBadRequest(err.Error(), filter)
+	}
+
+	return filter, nil
+}
+
+// parse and validate the request body of approving or rejecting a shift swap request
+func (controller *rosterController) parseDecision(ctx *fiber.Ctx, request *web.DecideShiftSwapRequest) error {
+	// the note is optional, so an empty body is allowed
+	if len(ctx.Body()) > 0 {
+		if err := ctx.BodyParser(request); err != nil {
+			return exception.ErrValidateBadRequest(err.Error(), request)
+		}
+	}
+	if err := controller.validate.Struct(request); err != nil {
+		return exception.ErrValidateBadRequest(err.Error(), request)
+	}
+
+	return nil
+}
+
+// parse and validate the query params of the shift swap request list
+func (controller *rosterController) parseShiftSwapFilter(ctx *fiber.Ctx) (web.ShiftSwapQueryFilter, error) {
+	var filter web.ShiftSwapQueryFilter
+	if err := ctx.QueryParser(&filter); err != nil {
+		return filter, exception.ErrValidateBadRequest(err.Error(), filter)
+	}
+	if err := controller.validate.Struct(filter); err != nil {
+		return filter, exception.ErrValidateBadRequest(err.Error(), filter)
+	}
+
+	return filter, nil
+}
+
+// write the shift swap request list, with the pagination when the page or limit is filled
+func (controller *rosterController) shiftSwapListResponse(ctx *fiber.Ctx, filter web.ShiftSwapQueryFilter, swapResponses []web.ShiftSwapResponse, totalData int) error {
+	if filter.Page != "" || filter.Limit != "" {
+		pageInt, _ := strconv.Atoi(filter.Page)
+		if filter.Page == "" {
+			pageInt = 1
+		}
+		return ctx.Status(fiber.StatusOK).JSON(web.WebResponsePagination{
+			Code:      fiber.StatusOK,
+			Status:    true,
+			Page:      pageInt,
+			Count:     len(swapResponses),
+			TotalData: totalData,
+			Message:   "success",
+			Data:      swapResponses,
+		})
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(web.WebResponse{
+		Code:    fiber.StatusOK,
+		Status:  true,
+		Message: "success",
+		Data:    swapResponses,
+	})
+}
@@ -0,0 +1,179 @@
+package controller
+
+import (
+	"github.com/go-playground/validator/v10"
+	"github.com/gofiber/fiber/v2"
+	"github.com/iqbaludinm/hr-microservice/user-service/config"
+	"github.com/iqbaludinm/hr-microservice/user-service/exception"
+	"github.com/iqbaludinm/hr-microservice/user-service/middleware"
+	"github.com/iqbaludinm/hr-microservice/user-service/model/web"
+	"github.com/iqbaludinm/hr-microservice/user-service/service"
+)
+
+type ShiftController interface {
+	// This function is used to define the route of the endpoints in this controller to the main application
+	Route(app *fiber.App)
+	CreateShift(ctx *fiber.Ctx) error
+	UpdateShift(ctx *fiber.Ctx) error
+	DeleteShift(ctx *fiber.Ctx) error
+	FindAllShift(ctx *fiber.Ctx) error
+	FindByID(ctx *fiber.Ctx) error
+	AssignEmployees(ctx *fiber.Ctx) error
+}
+
+type shiftController struct {
+	validate     *validator.Validate
+	shiftService service.ShiftService
+}
+
+func NewShiftController(validate *validator.Validate, shiftService service.ShiftService) ShiftController {
+	return &shiftController{
+		validate:     validate,
+		shiftService: shiftService,
+	}
+}
+
+func (controller *shiftController) Route(app *fiber.App) {
+	api := app.Group(config.EndpointPrefixShift, middleware.IsAuthenticated)
+
+	api.Post("/", controller.CreateShift)
+	api.Get("/", controller.FindAllShift)
+	api.Get("/:shift_id", controller.FindByID)
+	api.Put("/:shift_id", controller.UpdateShift)
+	api.Delete("/:shift_id", controller.DeleteShift)
+	api.Put("/:shift_id/employees", controller.AssignEmployees)
+}
+
+func (controller *shiftController) CreateShift(ctx *fiber.Ctx) error {
+	// parse request body
+	var request web.CreateShiftRequest
+	err := ctx.BodyParser(&request)
+	if err != nil {
+		return exception.ErrValidateBadRequest(err.Error(), request)
+	}
+	// validate the values of the request body
+	err = controller.validate.Struct(request)
+	if err != nil {
+		return exception.ErrValidateBadRequest(err.Error(), request)
+	}
+
+	// create shift
+	shiftResponse, err := controller.shiftService.CreateShift(ctx.Context(), request)
+	if err != nil {
+		return err
+	}
+
+	return ctx.Status(fiber.StatusCreated).JSON(web.WebResponse{
+		Code:    fiber.StatusCreated,
+		Status:  true,
+		Message: "success",
+		Data:    shiftResponse,
+	})
+}
+
+func (controller *shiftController) UpdateShift(ctx *fiber.Ctx) error {
+	// parse request body
+	var request web.UpdateShiftRequest
+	err := ctx.BodyParser(&request)
+	if err != nil {
+		return exception.ErrValidateBadRequest(err.Error(), request)
+	}
+	// validate the values of the request body
+	err = controller.validate.Struct(request)
+	if err != nil {
+		return exception.ErrValidateBadRequest(err.Error(), request)
+	}
+
+	// parse path params
+	shiftID := ctx.Params("shift_id")
+
+	// update shift
+	shiftResponse, err := controller.shiftService.UpdateShift(ctx.Context(), shiftID, request)
+	if err != nil {
+		return err
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(web.WebResponse{
+		Code:    fiber.StatusOK,
+		Status:  true,
+		Message: "success",
+		Data:    shiftResponse,
+	})
+}
+
+func (controller *shiftController) DeleteShift(ctx *fiber.Ctx) error {
+	// parse path params
+	shiftID := ctx.Params("shift_id")
+
+	// delete shift
+	err := controller.shiftService.Delete(ctx.Context(), shiftID)
+	if err != nil {
+		return err
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(web.WebResponse{
+		Code:    fiber.StatusOK,
+		Status:  true,
+		Message: "success",
+	})
+}
+
+func (controller *shiftController) FindByID(ctx *fiber.Ctx) error {
+	// parse path params
+	shiftID := ctx.Params("shift_id")
+
+	shift, err := controller.shiftService.FindById(ctx.Context(), shiftID)
+	if err != nil {
+		return err
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(web.WebResponse{
+		Code:    fiber.StatusOK,
+		Status:  true,
+		Message: "success",
+		Data:    shift,
+	})
+}
+
+func (controller *shiftController) FindAllShift(ctx *fiber.Ctx) error {
+	shiftResponses, err := controller.shiftService.FindAllShift(ctx.Context())
+	if err != nil {
+		return err
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(web.WebResponse{
+		Code:    fiber.StatusOK,
+		Status:  true,
+		Message: "success",
+		Data:    shiftResponses,
+	})
+}
+
+func (controller *shiftController) AssignEmployees(ctx *fiber.Ctx) error {
+	// parse request body
+	var request web.AssignShiftRequest
+	err := ctx.BodyParser(&request)
+	if err != nil {
+		return exception.ErrValidateBadRequest(err.Error(), request)
+	}
+	// validate the values of the request body
+	err = controller.validate.Struct(request)
+	if err != nil {
+		return exception.ErrValidateBadRequest(err.Error(), request)
+	}
+
+	// parse path params
+	shiftID := ctx.Params("shift_id")
+
+	// assign shift to the employees
+	err = controller.shiftService.AssignEmployees(ctx.Context(), shiftID, request)
+	if err != nil {
+		return err
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(web.WebResponse{
+		Code:    fiber.StatusOK,
+		Status:  true,
+		Message: "success",
+	})
+}
@@ -0,0 +1,148 @@
+package controller
+
+import (
+	"github.com/go-playground/validator/v10"
+	"github.com/gofiber/fiber/v2"
+	"github.com/iqbaludinm/hr-microservice/user-service/config"
+	"github.com/iqbaludinm/hr-microservice/user-service/exception"
+	"github.com/iqbaludinm/hr-microservice/user-service/middleware"
+	"github.com/iqbaludinm/hr-microservice/user-service/model/web"
+	"github.com/iqbaludinm/hr-microservice/user-service/service"
+)
+
+type ShiftPatternController interface {
+	// This function is used to define the route of the endpoints in this controller to the main application
+	Route(app *fiber.App)
+	CreatePattern(ctx *fiber.Ctx) error
+	UpdatePattern(ctx *fiber.Ctx) error
+	DeletePattern(ctx *fiber.Ctx) error
+	FindAllPattern(ctx *fiber.Ctx) error
+	FindByID(ctx *fiber.Ctx) error
+}
+
+type shiftPatternController struct {
+	validate            *validator.Validate
+	shiftPatternService service.ShiftPatternService
+}
+
+func NewShiftPatternController(validate *validator.Validate, shiftPatternService service.ShiftPatternService) ShiftPatternController {
+	return &shiftPatternController{
+		validate:            validate,
+		shiftPatternService: shiftPatternService,
+	}
+}
+
+func (controller *shiftPatternController) Route(app *fiber.App) {
+	api := app.Group(config.EndpointPrefixShiftPattern, middleware.IsAuthenticated)
+
+	api.Post("/", controller.CreatePattern)
+	api.Get("/", controller.FindAllPattern)
+	api.Get("/:shift_pattern_id", controller.FindByID)
+	api.Put("/:shift_pattern_id", controller.UpdatePattern)
+	api.Delete("/:shift_pattern_id", controller.DeletePattern)
+}
+
+func (controller *shiftPatternController) CreatePattern(ctx *fiber.Ctx) error {
+	// parse request body
+	var request web.CreateShiftPatternRequest
+	err := ctx.BodyParser(&request)
+	if err != nil {
+		return exception.ErrValidateBadRequest(err.Error(), request)
+	}
+	// validate the values of the request body
+	err = controller.validate.Struct(request)
+	if err != nil {
+		return exception.ErrValidateBadRequest(err.Error(), request)
+	}
+
+	// create shift pattern
+	shiftPatternResponse, err := controller.shiftPatternService.CreatePattern(ctx.Context(), request)
+	if err != nil {
+		return err
+	}
+
+	return ctx.Status(fiber.StatusCreated).JSON(web.WebResponse{
+		Code:    fiber.StatusCreated,
+		Status:  true,
+		Message: "success",
+		Data:    shiftPatternResponse,
+	})
+}
+
+func (controller *shiftPatternController) UpdatePattern(ctx *fiber.Ctx) error {
+	// parse request body
+	var request web.UpdateShiftPatternRequest
+	err := ctx.BodyParser(&request)
+	if err != nil {
+		return exception.ErrValidateBadRequest(err.Error(), request)
+	}
+	// validate the values of the request body
+	err = controller.validate.Struct(request)
+	if err != nil {
+		return exception.ErrValidateBadRequest(err.Error(), request)
+	}
+
+	// parse path params
+	shiftPatternID := ctx.Params("shift_pattern_id")
+
+	// update shift pattern
+	shiftPatternResponse, err := controller.shiftPatternService.UpdatePattern(ctx.Context(), shiftPatternID, request)
+	if err != nil {
+		return err
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(web.WebResponse{
+		Code:    fiber.StatusOK,
+		Status:  true,
+		Message: "success",
+		Data:    shiftPatternResponse,
+	})
+}
+
+func (controller *shiftPatternController) DeletePattern(ctx *fiber.Ctx) error {
+	// parse path params
+	shiftPatternID := ctx.Params("shift_pattern_id")
+
+	// delete shift pattern
+	err := controller.shiftPatternService.Delete(ctx.Context(), shiftPatternID)
+	if err != nil {
+		return err
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(web.WebResponse{
+		Code:    fiber.StatusOK,
+		Status:  true,
+		Message: "success",
+	})
+}
+
+func (controller *shiftPatternController) FindByID(ctx *fiber.Ctx) error {
+	// parse path params
+	shiftPatternID := ctx.Params("shift_pattern_id")
+
+	shiftPattern, err := controller.shiftPatternService.FindById(ctx.Context(), shiftPatternID)
+	if err != nil {
+		return err
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(web.WebResponse{
+		Code:    fiber.StatusOK,
+		Status:  true,
+		Message: "success",
+		Data:    shiftPattern,
+	})
+}
+
+func (controller *shiftPatternController) FindAllPattern(ctx *fiber.Ctx) error {
+	shiftPatternResponses, err := controller.shiftPatternService.FindAllPattern(ctx.Context())
+	if err != nil {
+		return err
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(web.WebResponse{
+		Code:    fiber.StatusOK,
+		Status:  true,
+		Message: "success",
+		Data:    shiftPatternResponses,
+	})
+}
@@ -0,0 +1,213 @@
+package controller
+
+import (
+	"strconv"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/gofiber/fiber/v2"
+	"github.com/iqbaludinm/hr-microservice/user-service/config"
+	"github.com/iqbaludinm/hr-microservice/user-service/exception"
+	"github.com/iqbaludinm/hr-microservice/user-service/middleware"
+	"github.com/iqbaludinm/hr-microservice/user-service/model/web"
+	"github.com/iqbaludinm/hr-microservice/user-service/service"
+)
+
+type TrainingController interface {
+	// This function is used to define the route of the endpoints in this controller to the main application
+	Route(app *fiber.App)
+	CreateTraining(ctx *fiber.Ctx) error
+	UpdateTraining(ctx *fiber.Ctx) error
+	DeleteTraining(ctx *fiber.Ctx) error
+	FindAllTraining(ctx *fiber.Ctx) error
+	FindByID(ctx *fiber.Ctx) error
+	Compliance(ctx *fiber.Ctx) error
+}
+
+type trainingController struct {
+	validate        *validator.Validate
+	trainingService service.TrainingService
+}
+
+func NewTrainingController(validate *validator.Validate, trainingService service.TrainingService) TrainingController {
+	return &trainingController{
+		validate:        validate,
+		trainingService: trainingService,
+	}
+}
+
+func (controller *trainingController) Route(app *fiber.App) {
+	api := app.Group(config.EndpointPrefixTraining, middleware.IsAuthenticated)
+
+	api.Get("/", controller.FindAllTraining)
+	api.Post("/", controller.CreateTraining)
+	api.Get("/compliance", controller.Compliance)
+	api.Get("/:training_id", controller.FindByID)
+	api.Put("/:training_id", controller.UpdateTraining)
+	api.Delete("/:training_id", controller.DeleteTraining)
+}
+
+func (controller *trainingController) CreateTraining(ctx *fiber.Ctx) error {
+	// parse request body
+	var request web.TrainingRequest
+	if err := ctx.BodyParser(&request); err != nil {
+		return exception.ErrValidateBadRequest(err.Error(), request)
+	}
+	// validate the values of request body
+	if err := controller.validate.Struct(request); err != nil {
+		return exception.ErrValidateBadRequest(err.Error(), request)
+	}
+
+	trainingResponse, err := controller.trainingService.CreateTraining(ctx.Context(), request)
+	if err != nil {
+		return err
+	}
+
+	return ctx.Status(fiber.StatusCreated).JSON(web.WebResponse{
+		Code:    fiber.StatusCreated,
+		Status:  true,
+		Message: "success",
+		Data:    trainingResponse,
+	})
+}
+
+func (controller *trainingController) UpdateTraining(ctx *fiber.Ctx) error {
+	// parse request body
+	var request web.TrainingRequest
+	if err := ctx.BodyParser(&request); err != nil {
+		return exception.ErrValidateBadRequest(err.Error(), request)
+	}
+	// validate the values of request body
+	if err := controller.validate.Struct(request); err != nil {
+		return exception.ErrValidateBadRequest(err.Error(), request)
+	}
+
+	// parse path params
+	trainingID := ctx.Params("training_id")
+
+	trainingResponse, err := controller.trainingService.UpdateTraining(ctx.Context(), trainingID, request)
+	if err != nil {
+		return err
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(web.WebResponse{
+		Code:    fiber.StatusOK,
+		Status:  true,
+		Message: "success",
+		Data:    trainingResponse,
+	})
+}
+
+func (controller *trainingController) DeleteTraining(ctx *fiber.Ctx) error {
+	// parse path params
+	trainingID := ctx.Params("training_id")
+
+	// delete training, the training which still has open enrollments can't be deleted
+	err := controller.trainingService.DeleteTraining(ctx.Context(), trainingID)
+	if err != nil {
+		return err
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(web.WebResponse{
+		Code:    fiber.StatusOK,
+		Status:  true,
+		Message: "success",
+	})
+}
+
+func (controller *trainingController) FindAllTraining(ctx *fiber.Ctx) error {
+	// parse query params
+	var filter web.TrainingQueryFilter
+	if err := ctx.QueryParser(&filter); err != nil {
+		return exception.ErrValidateBadRequest(err.Error(), filter)
+	}
+	// validate the values of the query params
+	if err := controller.validate.Struct(filter); err != nil {
+		return exception.ErrValidateBadRequest(err.Error(), filter)
+	}
+
+	trainingResponses, totalData, err := controller.trainingService.FindAllTraining(ctx.Context(), filter)
+	if err != nil {
+		return err
+	}
+
+	if filter.Page != "" || filter.Limit != "" {
+		pageInt, _ := strconv.Atoi(filter.Page)
+		if filter.Page == "" {
+			pageInt = 1
+		}
+		return ctx.Status(fiber.StatusOK).JSON(web.WebResponsePagination{
+			Code:      fiber.StatusOK,
+			Status:    true,
+			Page:      pageInt,
+			Count:     len(trainingResponses),
+			TotalData: totalData,
+			Message:   "success",
+			Data:      trainingResponses,
+		})
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(web.WebResponse{
+		Code:    fiber.StatusOK,
+		Status:  true,
+		Message: "success",
+		Data:    trainingResponses,
+	})
+}
+
+func (controller *trainingController) FindByID(ctx *fiber.Ctx) error {
+	// parse path params
+	trainingID := ctx.Params("training_id")
+
+	trainingResponse, err := controller.trainingService.FindTrainingById(ctx.Context(), trainingID)
+	if err != nil {
+		return err
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(web.WebResponse{
+		Code:    fiber.StatusOK,
+		Status:  true,
+		Message: "success",
+		Data:    trainingResponse,
+	})
+}
+
+// the compliance report, the employees who have not completed their mandatory trainings by the due date
+func (controller *trainingController) Compliance(ctx *fiber.Ctx) error {
+	// parse query params
+	var filter web.TrainingComplianceQueryFilter
+	if err := ctx.QueryParser(&filter); err != nil {
+		return exception.ErrValidateBadRequest(err.Error(), filter)
+	}
+	// validate the values of the query params
+	if err := controller.validate.Struct(filter); err != nil {
+		return exception.ErrValidateBadRequest(err.Error(), filter)
+	}
+
+	complianceResponses, totalData, err := controller.trainingService.Compliance(ctx.Context(), filter)
+	if err != nil {
+		return err
+	}
+
+	if filter.Page != "" || filter.Limit != "" {
+		pageInt, _ := strconv.Atoi(filter.Page)
+		if filter.Page == "" {
+			pageInt = 1
+		}
+		return ctx.Status(fiber.StatusOK).JSON(web.WebResponsePagination{
+			Code:      fiber.StatusOK,
+			Status:    true,
+			Page:      pageInt,
+			Count:     len(complianceResponses),
+			TotalData: totalData,
+			Message:   "success",
+			Data:      complianceResponses,
+		})
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(web.WebResponse{
+		Code:    fiber.StatusOK,
+		Status:  true,
+		Message: "success",
+		Data:    complianceResponses,
+	})
+}
@@ -0,0 +1,224 @@
+package controller
+
+import (
+	"strconv"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/gofiber/fiber/v2"
+	"github.com/iqbaludinm/hr-microservice/user-service/config"
+	"github.com/iqbaludinm/hr-microservice/user-service/exception"
+	"github.com/iqbaludinm/hr-microservice/user-service/helper"
+	"github.com/iqbaludinm/hr-microservice/user-service/middleware"
+	"github.com/iqbaludinm/hr-microservice/user-service/model/web"
+	"github.com/iqbaludinm/hr-microservice/user-service/service"
+)
+
+type TrainingEnrollmentController interface {
+	// This function is used to define the route of the endpoints in this controller to the main application
+	Route(app *fiber.App)
+	Enroll(ctx *fiber.Ctx) error
+	Complete(ctx *fiber.Ctx) error
+	Cancel(ctx *fiber.Ctx) error
+	FindAllEnrollment(ctx *fiber.Ctx) error
+	FindMyEnrollments(ctx *fiber.Ctx) error
+	FindByID(ctx *fiber.Ctx) error
+}
+
+type trainingEnrollmentController struct {
+	validate        *validator.Validate
+	trainingService service.TrainingService
+}
+
+func NewTrainingEnrollmentController(validate *validator.Validate, trainingService service.TrainingService) TrainingEnrollmentController {
+	return &trainingEnrollmentController{
+		validate:        validate,
+		trainingService: trainingService,
+	}
+}
+
+func (controller *trainingEnrollmentController) Route(app *fiber.App) {
+	api := app.Group(config.EndpointPrefixTrainingEnrollment, middleware.IsAuthenticated)
+
+	api.Get("/", controller.FindAllEnrollment)
+	api.Post("/", controller.Enroll)
+	api.Get("/me", controller.FindMyEnrollments)
+	api.Get("/:enrollment_id", controller.FindByID)
+	api.Put("/:enrollment_id/complete", controller.Complete)
+	api.Put("/:enrollment_id/cancel", controller.Cancel)
+}
+
+func (controller *trainingEnrollmentController) Enroll(ctx *fiber.Ctx) error {
+	// parse request body
+	var request web.TrainingEnrollmentRequest
+	if err := ctx.BodyParser(&request); err != nil {
+		return exception.ErrValidateBadRequest(err.Error(), request)
+	}
+	// validate the values of request body
+	if err := controller.validate.Struct(request); err != nil {
+		return exception.ErrValidateBadRequest(err.Error(), request)
+	}
+
+	// the employees are enrolled by the logged in user
+	userID, _ := helper.ParseJwt(ctx.Cookies("token"))
+
+	enrollmentResponses, err := controller.trainingService.Enroll(ctx.Context(), userID, request)
+	if err != nil {
+		return err
+	}
+
+	return ctx.Status(fiber.StatusCreated).JSON(web.WebResponse{
+		Code:    fiber.StatusCreated,
+		Status:  true,
+		Message: "success",
+		Data:    enrollmentResponses,
+	})
+}
+
+func (controller *trainingEnrollmentController) Complete(ctx *fiber.Ctx) error {
+	// parse the multipart form
+	var request web.CompleteTrainingEnrollmentRequest
+	if err := ctx.BodyParser(&request); err != nil {
+		return exception.ErrValidateBadRequest(err.Error(), request)
+	}
+	// validate the values of the form
+	if err := controller.validate.Struct(request); err != nil {
+		return exception.ErrValidateBadRequest(err.Error(), request)
+	}
+
+	// the certificate is optional, it is required by the service when the training requires it
+	var certificate *web.DocumentFile
+	if _, err := ctx.FormFile("file"); err == nil {
+		file, err := documentFile(ctx)
+		if err != nil {
+			return err
+		}
+		certificate = &file
+	}
+
+	// parse path params
+	enrollmentID := ctx.Params("enrollment_id")
+	// the enrollment is completed by the logged in user
+	userID, _ := helper.ParseJwt(ctx.Cookies("token"))
+
+	enrollmentResponse, err := controller.trainingService.Complete(ctx.Context(), userID, enrollmentID, request, certificate)
+	if err != nil {
+		return err
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(web.WebResponse{
+		Code:    fiber.StatusOK,
+		Status:  true,
+		Message: "success",
+		Data:    enrollmentResponse,
+	})
+}
+
+func (controller *trainingEnrollmentController) Cancel(ctx *fiber.Ctx) error {
+	// parse request body
+	var request web.CancelTrainingEnrollmentRequest
+	if err := ctx.BodyParser(&request); err != nil {
+		return exception.ErrValidateBadRequest(err.Error(), request)
+	}
+	// validate the values of request body
+	if err := controller.validate.Struct(request); err != nil {
+		return exception.ErrValidateBadRequest(err.Error(), request)
+	}
+
+	// parse path params
+	enrollmentID := ctx.Params("enrollment_id")
+
+	enrollmentResponse, err := controller.trainingService.Cancel(ctx.Context(), enrollmentID, request)
+	if err != nil {
+		return err
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(web.WebResponse{
+		Code:    fiber.StatusOK,
+		Status:  true,
+		Message: "success",
+		Data:    enrollmentResponse,
+	})
+}
+
+func (controller *trainingEnrollmentController) FindAllEnrollment(ctx *fiber.Ctx) error {
+	// parse query params
+	var filter web.TrainingEnrollmentQueryFilter
+	if err := ctx.QueryParser(&filter); err != nil {
+		return exception.ErrValidateBadRequest(err.Error(), filter)
+	}
+	// validate the values of the query params
+	if err := controller.validate.Struct(filter); err != nil {
+		return exception.ErrValidateBadRequest(err.Error(), filter)
+	}
+
+	enrollmentResponses, totalData, err := controller.trainingService.FindAllEnrollment(ctx.Context(), filter)
+	if err != nil {
+		return err
+	}
+
+	return trainingEnrollmentsResponse(ctx, filter, enrollmentResponses, totalData)
+}
+
+func (controller *trainingEnrollmentController) FindMyEnrollments(ctx *fiber.Ctx) error {
+	// parse query params
+	var filter web.TrainingEnrollmentQueryFilter
+	if err := ctx.QueryParser(&filter); err != nil {
+		return exception.ErrValidateBadRequest(err.Error(), filter)
+	}
+	// validate the values of the query params
+	if err := controller.validate.Struct(filter); err != nil {
+		return exception.ErrValidateBadRequest(err.Error(), filter)
+	}
+
+	// the enrollments of the logged in user
+	userID, _ := helper.ParseJwt(ctx.Cookies("token"))
+
+	enrollmentResponses, totalData, err := controller.trainingService.FindMyEnrollments(ctx.Context(), userID, filter)
+	if err != nil {
+		return err
+	}
+
+	return trainingEnrollmentsResponse(ctx, filter, enrollmentResponses, totalData)
+}
+
+func (controller *trainingEnrollmentController) FindByID(ctx *fiber.Ctx) error {
+	// parse path params
+	enrollmentID := ctx.Params("enrollment_id")
+
+	enrollmentResponse, err := controller.trainingService.FindEnrollmentById(ctx.Context(), enrollmentID)
+	if err != nil {
+		return err
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(web.WebResponse{
+		Code:    fiber.StatusOK,
+		Status:  true,
+		Message: "success",
+		Data:    enrollmentResponse,
+	})
+}
+
+func trainingEnrollmentsResponse(ctx *fiber.Ctx, filter web.TrainingEnrollmentQueryFilter, enrollmentResponses []web.TrainingEnrollmentResponse, totalData int) error {
+	if filter.Page != "" || filter.Limit != "" {
+		pageInt, _ := strconv.Atoi(filter.Page)
+		if filter.Page == "" {
+			pageInt = 1
+		}
+		return ctx.Status(fiber.StatusOK).JSON(web.WebResponsePagination{
+			Code:      fiber.StatusOK,
+			Status:    true,
+			Page:      pageInt,
+			Count:     len(enrollmentResponses),
+			TotalData: totalData,
+			Message:   "success",
+			Data:      enrollmentResponses,
+		})
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(web.WebResponse{
+		Code:    fiber.StatusOK,
+		Status:  true,
+		Message: "success",
+		Data:    enrollmentResponses,
+	})
+}
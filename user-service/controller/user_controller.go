@@ -1,6 +1,7 @@
 package controller
 
 import (
+	"fmt"
 	"log"
 	"strconv"
 
@@ -18,6 +19,8 @@ type UserController interface {
 	// This function is used to define the route of the endpoints in this controller to the main application
 	Route(app *fiber.App)
 	FindAllUser(ctx *fiber.Ctx) error
+	ImportUsers(ctx *fiber.Ctx) error
+	ExportUsers(ctx *fiber.Ctx) error
 	ForgetPassword(ctx *fiber.Ctx) error
 	ResetPassword(ctx *fiber.Ctx) error
 
@@ -51,6 +54,8 @@ func (controller *userController) Route(app *fiber.App) {
 	api.Get("/",
 		controller.FindAllUser,
 	)
+	api.Post("/import", controller.ImportUsers)
+	api.Get("/export", controller.ExportUsers)
 	
 	api.Post("/forget-password", controller.ForgetPassword)
 	api.Post("/reset-password", controller.ResetPassword)
@@ -481,3 +486,69 @@ func (controller *userController) FindAllUser(ctx *fiber.Ctx) error {
 		Data:    userResponses,
 	})
 }
+
+func (controller *userController) ImportUsers(ctx *fiber.Ctx) error {
+	// parse request form
+	var request web.ImportUserRequest
+	err := ctx.BodyParser(&request)
+	if err != nil {
+		return exception.ErrValidateBadRequest(err.Error(), request)
+	}
+	file, _ := ctx.FormFile("file")
+
+	// import users
+	importResponse, err := controller.userService.ImportUsers(ctx.Context(), request, file)
+	if err != nil {
+		return err
+	}
+
+	// nothing is created when a row is invalid, the errors of the rows are returned
+	if len(importResponse.Errors) > 0 {
+		return ctx.Status(fiber.StatusUnprocessableEntity).JSON(web.WebResponse{
+			Code:    fiber.StatusUnprocessableEntity,
+			Status:  false,
+			Message: fmt.Sprintf("%d of %d rows are invalid.", len(importResponse.Errors), importResponse.Total),
+			Data:    importResponse,
+		})
+	}
+
+	code := fiber.StatusCreated
+	if importResponse.DryRun {
+		code = fiber.StatusOK
+	}
+	return ctx.Status(code).JSON(web.WebResponse{
+		Code:    code,
+		Status:  true,
+		Message: "success",
+		Data:    importResponse,
+	})
+}
+
+func (controller *userController) ExportUsers(ctx *fiber.Ctx) error {
+	// parse query params, the users are filtered as the user list
+	var filter web.UserQueryFilter
+	if err := ctx.QueryParser(&filter); err != nil {
+		return exception.ErrValidateBadRequest(err.Error(), filter)
+	}
+	var request web.ExportUserRequest
+	if err := ctx.QueryParser(&request); err != nil {
+		return exception.ErrValidateBadRequest(err.Error(), request)
+	}
+	if err := controller.validate.Struct(request); err != nil {
+		return exception.ErrValidateBadRequest(err.Error(), request)
+	}
+
+	file, fileName, err := controller.userService.ExportUsers(ctx.Context(), filter, request.Format)
+	if err != nil {
+		return err
+	}
+
+	contentType := "text/csv; charset=utf-8"
+	if request.Format == "xlsx" {
+		contentType = "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"
+	}
+	ctx.Set(fiber.HeaderContentType, contentType)
+	ctx.Set(fiber.HeaderContentDisposition, fmt.Sprintf("attachment; filename=%s", fileName))
+
+	return ctx.Status(fiber.StatusOK).Send(file)
+}
@@ -0,0 +1,175 @@
+package controller
+
+import (
+	"github.com/go-playground/validator/v10"
+	"github.com/gofiber/fiber/v2"
+	"github.com/iqbaludinm/hr-microservice/user-service/config"
+	"github.com/iqbaludinm/hr-microservice/user-service/exception"
+	"github.com/iqbaludinm/hr-microservice/user-service/middleware"
+	"github.com/iqbaludinm/hr-microservice/user-service/model/web"
+	"github.com/iqbaludinm/hr-microservice/user-service/service"
+)
+
+type WorkCalendarController interface {
+	// This function is used to define the route of the endpoints in this controller to the main application
+	Route(app *fiber.App)
+	CreateWorkCalendar(ctx *fiber.Ctx) error
+	UpdateWorkCalendar(ctx *fiber.Ctx) error
+	DeleteWorkCalendar(ctx *fiber.Ctx) error
+	FindAllWorkCalendar(ctx *fiber.Ctx) error
+	FindByID(ctx *fiber.Ctx) error
+	CountWorkingDays(ctx *fiber.Ctx) error
+}
+
+type workCalendarController struct {
+	validate            *validator.Validate
+	workCalendarService service.WorkCalendarService
+}
+
+func NewWorkCalendarController(validate *validator.Validate, workCalendarService service.WorkCalendarService) WorkCalendarController {
+	return &workCalendarController{
+		validate:            validate,
+		workCalendarService: workCalendarService,
+	}
+}
+
+func (controller *workCalendarController) Route(app *fiber.App) {
+	api := app.Group(config.EndpointPrefixWorkCalendar, middleware.IsAuthenticated)
+
+	api.Post("/", controller.CreateWorkCalendar)
+	api.Get("/", controller.FindAllWorkCalendar)
+	api.Get("/working-days", controller.CountWorkingDays)
+	api.Get("/:work_calendar_id", controller.FindByID)
+	api.Put("/:work_calendar_id", controller.UpdateWorkCalendar)
+	api.Delete("/:work_calendar_id", controller.DeleteWorkCalendar)
+}
+
+func (controller *workCalendarController) CreateWorkCalendar(ctx *fiber.Ctx) error {
+	// parse request body
+	var request web.CreateWorkCalendarRequest
+	err := ctx.BodyParser(&request)
+	if err != nil {
+		return exception.ErrValidateBadRequest(err.Error(), request)
+	}
+	// validate the values of the request body
+	err = controller.validate.Struct(request)
+	if err != nil {
+		return exception.ErrValidateBadRequest(err.Error(), request)
+	}
+
+	// create work calendar
+	workCalendarResponse, err := controller.workCalendarService.CreateWorkCalendar(ctx.Context(), request)
+	if err != nil {
+		return err
+	}
+
+	return ctx.Status(fiber.StatusCreated).JSON(web.WebResponse{
+		Code:    fiber.StatusCreated,
+		Status:  true,
+		Message: "success",
+		Data:    workCalendarResponse,
+	})
+}
+
+func (controller *workCalendarController) UpdateWorkCalendar(ctx *fiber.Ctx) error {
+	// parse request body
+	var request web.UpdateWorkCalendarRequest
+	err := ctx.BodyParser(&request)
+	if err != nil {
+		return exception.ErrValidateBadRequest(err.Error(), request)
+	}
+	// validate the values of the request body
+	err = controller.validate.Struct(request)
+	if err != nil {
+		return exception.ErrValidateBadRequest(err.Error(), request)
+	}
+
+	// parse path params
+	workCalendarID := ctx.Params("work_calendar_id")
+
+	// update work calendar
+	workCalendarResponse, err := controller.workCalendarService.UpdateWorkCalendar(ctx.Context(), workCalendarID, request)
+	if err != nil {
+		return err
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(web.WebResponse{
+		Code:    fiber.StatusOK,
+		Status:  true,
+		Message: "success",
+		Data:    workCalendarResponse,
+	})
+}
+
+func (controller *workCalendarController) DeleteWorkCalendar(ctx *fiber.Ctx) error {
+	// parse path params
+	workCalendarID := ctx.Params("work_calendar_id")
+
+	// delete work calendar
+	err := controller.workCalendarService.Delete(ctx.Context(), workCalendarID)
+	if err != nil {
+		return err
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(web.WebResponse{
+		Code:    fiber.StatusOK,
+		Status:  true,
+		Message: "success",
+	})
+}
+
+func (controller *workCalendarController) FindByID(ctx *fiber.Ctx) error {
+	// parse path params
+	workCalendarID := ctx.Params("work_calendar_id")
+
+	workCalendar, err := controller.workCalendarService.FindById(ctx.Context(), workCalendarID)
+	if err != nil {
+		return err
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(web.WebResponse{
+		Code:    fiber.StatusOK,
+		Status:  true,
+		Message: "success",
+		Data:    workCalendar,
+	})
+}
+
+func (controller *workCalendarController) FindAllWorkCalendar(ctx *fiber.Ctx) error {
+	workCalendarResponses, err := controller.workCalendarService.FindAllWorkCalendar(ctx.Context())
+	if err != nil {
+		return err
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(web.WebResponse{
+		Code:    fiber.StatusOK,
+		Status:  true,
+		Message: "success",
+		Data:    workCalendarResponses,
+	})
+}
+
+func (controller *workCalendarController) CountWorkingDays(ctx *fiber.Ctx) error {
+	// parse query params
+	var filter web.WorkingDaysQueryFilter
+	if err := ctx.QueryParser(&filter); err != nil {
+		return exception.ErrValidateBadRequest(err.Error(), filter)
+	}
+	// validate the values of the query params
+	if err := controller.validate.Struct(filter); err != nil {
+		return exception.ErrValidateBadRequest(err.Error(), filter)
+	}
+
+	// count the working days between the dates
+	workingDaysResponse, err := controller.workCalendarService.CountWorkingDays(ctx.Context(), filter)
+	if err != nil {
+		return err
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(web.WebResponse{
+		Code:    fiber.StatusOK,
+		Status:  true,
+		Message: "success",
+		Data:    workingDaysResponse,
+	})
+}
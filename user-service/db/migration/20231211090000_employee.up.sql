@@ -0,0 +1,27 @@
+-- ======= EMPLOYEES =======
+
+-- initialize tables
+CREATE TABLE employees (
+    "id" uuid NOT NULL,
+    "user_id" uuid NOT NULL UNIQUE REFERENCES users ("id"),
+    "employee_number" varchar NOT NULL UNIQUE,
+    "date_of_birth" date NOT NULL,
+    "gender" varchar NOT NULL,
+    "nik" varchar(16) NOT NULL UNIQUE,
+    "address" varchar NOT NULL DEFAULT '',
+    "hire_date" date NOT NULL,
+    "employment_type" varchar NOT NULL,
+    "status" varchar NOT NULL,
+    "job_title" varchar NOT NULL,
+    "department" varchar NOT NULL,
+    "manager_id" uuid REFERENCES employees ("id"),
+    "emergency_contacts" jsonb NOT NULL DEFAULT '[]',
+    "created_at" timestamp NOT NULL,
+    "updated_at" timestamp NOT NULL,
+    "deleted_at" timestamp,
+    PRIMARY KEY ("id")
+);
+
+CREATE INDEX employees_manager_id_idx ON employees ("manager_id");
+
+-- ======= END OF EMPLOYEES =======
@@ -0,0 +1,90 @@
+-- ======= DEPARTMENTS =======
+
+-- initialize tables
+CREATE TABLE departments (
+    "id" uuid NOT NULL,
+    "code" varchar NOT NULL UNIQUE,
+    "name" varchar NOT NULL,
+    "parent_id" uuid REFERENCES departments ("id"),
+    "created_at" timestamp NOT NULL,
+    "updated_at" timestamp NOT NULL,
+    "deleted_at" timestamp,
+    PRIMARY KEY ("id")
+);
+
+CREATE INDEX departments_parent_id_idx ON departments ("parent_id");
+
+-- move the free-text department of the employees to the departments table.
+-- The names that give the same code (e.g. 'R&D' and 'R D') are numbered to keep the code unique.
+INSERT INTO departments ("id", "code", "name", "created_at", "updated_at")
+    SELECT uuid_generate_v4(), CASE WHEN n = 1 THEN code ELSE code || '_' || n END, department, NOW(), NOW()
+    FROM (
+        SELECT department, code, row_number() OVER (PARTITION BY code ORDER BY department) AS n
+        FROM (
+            SELECT department, upper(regexp_replace(department, '\W+', '_', 'g')) AS code
+            FROM (SELECT DISTINCT department FROM employees) AS e
+        ) AS c
+    ) AS d;
+
+-- ======= END OF DEPARTMENTS =======
+
+
+-- ======= POSITIONS =======
+
+-- initialize tables
+CREATE TABLE positions (
+    "id" uuid NOT NULL,
+    "department_id" uuid NOT NULL REFERENCES departments ("id"),
+    "title" varchar NOT NULL,
+    "level" int NOT NULL DEFAULT 0,
+    "created_at" timestamp NOT NULL,
+    "updated_at" timestamp NOT NULL,
+    "deleted_at" timestamp,
+    PRIMARY KEY ("id"),
+    UNIQUE ("department_id", "title")
+);
+
+-- ======= END OF POSITIONS =======
+
+
+-- ======= EMPLOYEES =======
+
+ALTER TABLE employees
+    ADD COLUMN "department_id" uuid REFERENCES departments ("id"),
+    ADD COLUMN "position_id" uuid REFERENCES positions ("id");
+
+UPDATE employees AS e SET department_id = d.id FROM departments AS d WHERE d.name = e.department;
+
+ALTER TABLE employees ALTER COLUMN "department_id" SET NOT NULL;
+ALTER TABLE employees DROP COLUMN "department";
+
+CREATE INDEX employees_department_id_idx ON employees ("department_id");
+
+-- ======= END OF EMPLOYEES =======
+
+
+-- ======= EMPLOYMENT_HISTORIES =======
+
+-- effective-dated assignment of the employee to a department and position.
+-- A row with 'applied_at' is null is a future-dated change that is waiting for the scheduler.
+CREATE TABLE employment_histories (
+    "id" uuid NOT NULL,
+    "employee_id" uuid NOT NULL REFERENCES employees ("id"),
+    "department_id" uuid NOT NULL REFERENCES departments ("id"),
+    "position_id" uuid REFERENCES positions ("id"),
+    "effective_from" date NOT NULL,
+    "effective_to" date,
+    "reason" varchar NOT NULL DEFAULT '',
+    "applied_at" timestamp,
+    "created_at" timestamp NOT NULL,
+    PRIMARY KEY ("id")
+);
+
+CREATE INDEX employment_histories_employee_id_idx ON employment_histories ("employee_id", "effective_from");
+
+-- the current assignment of the existing employees starts from the hire date
+INSERT INTO employment_histories ("id", "employee_id", "department_id", "position_id", "effective_from", "reason", "applied_at", "created_at")
+    SELECT uuid_generate_v4(), e.id, e.department_id, e.position_id, e.hire_date, 'hire', NOW(), NOW()
+    FROM employees AS e;
+
+-- ======= END OF EMPLOYMENT_HISTORIES =======
@@ -0,0 +1,25 @@
+-- ======= EMPLOYEES =======
+
+ALTER TABLE employees ADD COLUMN "salary_grade" varchar NOT NULL DEFAULT '';
+
+-- ======= END OF EMPLOYEES =======
+
+
+-- ======= EMPLOYMENT_HISTORIES =======
+
+-- every row is a full snapshot of the employment (department, position, job title, salary grade and manager)
+-- that is effective from 'effective_from' until 'effective_to'. The rows are append-only.
+ALTER TABLE employment_histories
+    ADD COLUMN "change_type" varchar NOT NULL DEFAULT 'transfer',
+    ADD COLUMN "job_title" varchar NOT NULL DEFAULT '',
+    ADD COLUMN "salary_grade" varchar NOT NULL DEFAULT '',
+    ADD COLUMN "manager_id" uuid REFERENCES employees ("id");
+
+UPDATE employment_histories SET change_type = 'hire' WHERE reason = 'hire';
+
+-- fill the snapshot of the existing rows with the current data of the employees
+UPDATE employment_histories AS h SET job_title = e.job_title, manager_id = e.manager_id
+    FROM employees AS e
+    WHERE e.id = h.employee_id;
+
+-- ======= END OF EMPLOYMENT_HISTORIES =======
@@ -0,0 +1,93 @@
+-- ======= PUBLIC_HOLIDAYS =======
+
+-- the public holidays are excluded from the day count of the leave
+CREATE TABLE public_holidays (
+    "id" uuid NOT NULL,
+    "date" date NOT NULL UNIQUE,
+    "name" varchar NOT NULL,
+    "created_at" timestamp NOT NULL,
+    "updated_at" timestamp NOT NULL,
+    PRIMARY KEY ("id")
+);
+
+-- ======= END OF PUBLIC_HOLIDAYS =======
+
+
+-- ======= LEAVE_TYPES =======
+
+-- initialize tables
+CREATE TABLE leave_types (
+    "id" uuid NOT NULL,
+    "code" varchar NOT NULL UNIQUE,
+    "name" varchar NOT NULL,
+    -- 'annual': the whole quota is given at the start of the year,
+    -- 'monthly': 1/12 of the quota is given every month,
+    -- 'none': the leave type has no quota (e.g. unpaid leave).
+    "accrual_method" varchar NOT NULL DEFAULT 'annual',
+    "annual_quota" numeric(5,1) NOT NULL DEFAULT 0,
+    "max_carry_over" numeric(5,1) NOT NULL DEFAULT 0,
+    "requires_balance" boolean NOT NULL DEFAULT true,
+    "allow_half_day" boolean NOT NULL DEFAULT true,
+    "is_paid" boolean NOT NULL DEFAULT true,
+    "created_at" timestamp NOT NULL,
+    "updated_at" timestamp NOT NULL,
+    "deleted_at" timestamp,
+    PRIMARY KEY ("id")
+);
+
+-- insert default leave types
+INSERT INTO leave_types ("id", "code", "name", "accrual_method", "annual_quota", "max_carry_over", "requires_balance", "allow_half_day", "is_paid", "created_at", "updated_at") VALUES
+    (uuid_generate_v4(), 'ANNUAL', 'Cuti Tahunan', 'monthly', 12, 6, true, true, true, NOW(), NOW()),
+    (uuid_generate_v4(), 'SICK', 'Cuti Sakit', 'none', 0, 0, false, true, true, NOW(), NOW()),
+    (uuid_generate_v4(), 'UNPAID', 'Cuti di Luar Tanggungan', 'none', 0, 0, false, false, false, NOW(), NOW());
+
+-- ======= END OF LEAVE_TYPES =======
+
+
+-- ======= LEAVE_BALANCES =======
+
+-- the entitlement is calculated from the leave type, only the carried over, used and pending days are stored
+CREATE TABLE leave_balances (
+    "id" uuid NOT NULL,
+    "employee_id" uuid NOT NULL REFERENCES employees ("id"),
+    "leave_type_id" uuid NOT NULL REFERENCES leave_types ("id"),
+    "year" int NOT NULL,
+    "carried_over" numeric(5,1) NOT NULL DEFAULT 0,
+    "used" numeric(5,1) NOT NULL DEFAULT 0,
+    "pending" numeric(5,1) NOT NULL DEFAULT 0,
+    "created_at" timestamp NOT NULL,
+    "updated_at" timestamp NOT NULL,
+    PRIMARY KEY ("id"),
+    UNIQUE ("employee_id", "leave_type_id", "year")
+);
+
+-- ======= END OF LEAVE_BALANCES =======
+
+
+-- ======= LEAVE_REQUESTS =======
+
+-- initialize tables
+CREATE TABLE leave_requests (
+    "id" uuid NOT NULL,
+    "employee_id" uuid NOT NULL REFERENCES employees ("id"),
+    "leave_type_id" uuid NOT NULL REFERENCES leave_types ("id"),
+    "start_date" date NOT NULL,
+    "end_date" date NOT NULL,
+    -- '' for full days, 'morning' or 'afternoon' for a single half day
+    "half_day" varchar NOT NULL DEFAULT '',
+    "days" numeric(5,1) NOT NULL,
+    "reason" varchar NOT NULL DEFAULT '',
+    "status" varchar NOT NULL DEFAULT 'pending',
+    "approver_id" uuid REFERENCES employees ("id"),
+    "decision_note" varchar NOT NULL DEFAULT '',
+    "decided_at" timestamp,
+    "cancelled_at" timestamp,
+    "created_at" timestamp NOT NULL,
+    "updated_at" timestamp NOT NULL,
+    PRIMARY KEY ("id")
+);
+
+CREATE INDEX leave_requests_employee_id_idx ON leave_requests ("employee_id", "start_date");
+CREATE INDEX leave_requests_approver_id_idx ON leave_requests ("approver_id", "status");
+
+-- ======= END OF LEAVE_REQUESTS =======
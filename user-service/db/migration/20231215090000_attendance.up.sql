@@ -0,0 +1,91 @@
+-- ======= OFFICE_LOCATIONS =======
+
+-- the geofences of the offices, the check-in and check-out coordinates are validated against them
+CREATE TABLE office_locations (
+    "id" uuid NOT NULL,
+    "name" varchar NOT NULL,
+    "latitude" double precision NOT NULL,
+    "longitude" double precision NOT NULL,
+    "radius_meters" int NOT NULL DEFAULT 100,
+    "created_at" timestamp NOT NULL,
+    "updated_at" timestamp NOT NULL,
+    "deleted_at" timestamp,
+    PRIMARY KEY ("id")
+);
+
+-- ======= END OF OFFICE_LOCATIONS =======
+
+
+-- ======= SHIFTS =======
+
+-- initialize tables
+CREATE TABLE shifts (
+    "id" uuid NOT NULL,
+    "code" varchar NOT NULL UNIQUE,
+    "name" varchar NOT NULL,
+    -- the shift crosses midnight when the end time is before the start time
+    "start_time" time NOT NULL,
+    "end_time" time NOT NULL,
+    "late_tolerance_minutes" int NOT NULL DEFAULT 0,
+    "early_leave_tolerance_minutes" int NOT NULL DEFAULT 0,
+    -- the work after the end of the shift is counted as overtime when it is at least this long
+    "min_overtime_minutes" int NOT NULL DEFAULT 30,
+    -- the default shift is used for the employees without an assigned shift
+    "is_default" boolean NOT NULL DEFAULT false,
+    "created_at" timestamp NOT NULL,
+    "updated_at" timestamp NOT NULL,
+    "deleted_at" timestamp,
+    PRIMARY KEY ("id")
+);
+
+-- only one shift can be the default
+CREATE UNIQUE INDEX shifts_is_default_idx ON shifts ("is_default") WHERE is_default AND deleted_at is null;
+
+INSERT INTO shifts ("id", "code", "name", "start_time", "end_time", "late_tolerance_minutes", "is_default", "created_at", "updated_at")
+    VALUES (uuid_generate_v4(), 'REGULAR', 'Regular', '08:00', '17:00', 15, true, NOW(), NOW());
+
+-- ======= END OF SHIFTS =======
+
+
+-- ======= EMPLOYEES =======
+
+ALTER TABLE employees ADD COLUMN "shift_id" uuid REFERENCES shifts ("id");
+
+-- ======= END OF EMPLOYEES =======
+
+
+-- ======= ATTENDANCES =======
+
+-- initialize tables
+CREATE TABLE attendances (
+    "id" uuid NOT NULL,
+    "employee_id" uuid NOT NULL REFERENCES employees ("id"),
+    -- the work date of the shift, a shift that crosses midnight belongs to the date it starts
+    "date" date NOT NULL,
+    "shift_id" uuid REFERENCES shifts ("id"),
+    -- the schedule of the shift on the date
+    "scheduled_start" timestamp,
+    "scheduled_end" timestamp,
+    "check_in_at" timestamp NOT NULL,
+    "check_in_latitude" double precision,
+    "check_in_longitude" double precision,
+    "check_in_location_id" uuid REFERENCES office_locations ("id"),
+    "check_in_photo" varchar NOT NULL DEFAULT '',
+    "check_out_at" timestamp,
+    "check_out_latitude" double precision,
+    "check_out_longitude" double precision,
+    "check_out_location_id" uuid REFERENCES office_locations ("id"),
+    "check_out_photo" varchar NOT NULL DEFAULT '',
+    "late_minutes" int NOT NULL DEFAULT 0,
+    "early_leave_minutes" int NOT NULL DEFAULT 0,
+    "overtime_minutes" int NOT NULL DEFAULT 0,
+    "work_minutes" int NOT NULL DEFAULT 0,
+    "created_at" timestamp NOT NULL,
+    "updated_at" timestamp NOT NULL,
+    PRIMARY KEY ("id"),
+    UNIQUE ("employee_id", "date")
+);
+
+CREATE INDEX attendances_date_idx ON attendances ("date");
+
+-- ======= END OF ATTENDANCES =======
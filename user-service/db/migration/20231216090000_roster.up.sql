@@ -0,0 +1,89 @@
+-- ======= SHIFTS =======
+
+-- the unpaid break during the shift, it is excluded from the work time
+ALTER TABLE shifts ADD COLUMN "break_minutes" int NOT NULL DEFAULT 0;
+
+-- ======= END OF SHIFTS =======
+
+
+-- ======= SHIFT_PATTERNS =======
+
+-- initialize tables
+CREATE TABLE shift_patterns (
+    "id" uuid NOT NULL,
+    "code" varchar NOT NULL UNIQUE,
+    "name" varchar NOT NULL,
+    "created_at" timestamp NOT NULL,
+    "updated_at" timestamp NOT NULL,
+    "deleted_at" timestamp,
+    PRIMARY KEY ("id")
+);
+
+-- the days of the rotating pattern, the pattern repeats after its last day
+CREATE TABLE shift_pattern_days (
+    "pattern_id" uuid NOT NULL REFERENCES shift_patterns ("id") ON DELETE CASCADE,
+    "day_index" int NOT NULL,
+    -- the day off has no shift
+    "shift_id" uuid REFERENCES shifts ("id"),
+    PRIMARY KEY ("pattern_id", "day_index")
+);
+
+-- ======= END OF SHIFT_PATTERNS =======
+
+
+-- ======= ROSTERS =======
+
+-- the planned shift of the employee on the date, it overrides the assigned or default shift
+CREATE TABLE rosters (
+    "id" uuid NOT NULL,
+    "employee_id" uuid NOT NULL REFERENCES employees ("id"),
+    "date" date NOT NULL,
+    -- the day off has no shift
+    "shift_id" uuid REFERENCES shifts ("id"),
+    -- the pattern the roster was generated from
+    "pattern_id" uuid REFERENCES shift_patterns ("id"),
+    "created_at" timestamp NOT NULL,
+    "updated_at" timestamp NOT NULL,
+    PRIMARY KEY ("id"),
+    UNIQUE ("employee_id", "date")
+);
+
+CREATE INDEX rosters_date_idx ON rosters ("date");
+
+-- ======= END OF ROSTERS =======
+
+
+-- ======= SHIFT_SWAPS =======
+
+-- initialize tables
+CREATE TABLE shift_swaps (
+    "id" uuid NOT NULL,
+    -- the requester and the colleague exchange their shifts on the date
+    "requester_id" uuid NOT NULL REFERENCES employees ("id"),
+    "colleague_id" uuid NOT NULL REFERENCES employees ("id"),
+    "date" date NOT NULL,
+    -- the shifts of the requester and the colleague when the swap was requested, the day off has no shift
+    "requester_shift_id" uuid REFERENCES shifts ("id"),
+    "colleague_shift_id" uuid REFERENCES shifts ("id"),
+    "reason" varchar NOT NULL DEFAULT '',
+    "status" varchar NOT NULL DEFAULT 'pending',
+    "approver_id" uuid REFERENCES employees ("id"),
+    "decision_note" varchar NOT NULL DEFAULT '',
+    "decided_at" timestamp,
+    "cancelled_at" timestamp,
+    "created_at" timestamp NOT NULL,
+    "updated_at" timestamp NOT NULL,
+    PRIMARY KEY ("id")
+);
+
+CREATE INDEX shift_swaps_approver_id_idx ON shift_swaps ("approver_id", "status");
+
+-- ======= END OF SHIFT_SWAPS =======
+
+
+-- ======= EMPLOYEES =======
+
+-- the token of the roster calendar feed, the feed is subscribed without logging in
+ALTER TABLE employees ADD COLUMN "calendar_token" uuid UNIQUE;
+
+-- ======= END OF EMPLOYEES =======
@@ -0,0 +1,56 @@
+-- ======= WORK_CALENDARS =======
+
+-- initialize tables
+CREATE TABLE work_calendars (
+    "id" uuid NOT NULL,
+    "code" varchar NOT NULL UNIQUE,
+    "name" varchar NOT NULL,
+    -- the days of the week off (0 is Sunday, 6 is Saturday)
+    "weekend_days" int[] NOT NULL DEFAULT '{0,6}',
+    -- the default calendar is used for the employees without an office location or with a location without a calendar
+    "is_default" boolean NOT NULL DEFAULT false,
+    "created_at" timestamp NOT NULL,
+    "updated_at" timestamp NOT NULL,
+    "deleted_at" timestamp,
+    PRIMARY KEY ("id")
+);
+
+-- only one calendar can be the default
+CREATE UNIQUE INDEX work_calendars_is_default_idx ON work_calendars ("is_default") WHERE is_default AND deleted_at is null;
+
+INSERT INTO work_calendars ("id", "code", "name", "weekend_days", "is_default", "created_at", "updated_at")
+    VALUES (uuid_generate_v4(), 'NATIONAL', 'National', '{0,6}', true, NOW(), NOW());
+
+-- ======= END OF WORK_CALENDARS =======
+
+
+-- ======= PUBLIC_HOLIDAYS =======
+
+-- the holiday without a calendar is a national day and applies to every calendar. The day of a calendar overrides
+-- the national day on the same date, e.g. a regional holiday or a working day on a national holiday.
+ALTER TABLE public_holidays ADD COLUMN "work_calendar_id" uuid REFERENCES work_calendars ("id");
+-- public_holiday, collective_leave (cuti bersama) or working_day
+ALTER TABLE public_holidays ADD COLUMN "type" varchar NOT NULL DEFAULT 'public_holiday';
+
+-- the date is unique per calendar, the national days share the nil uuid
+ALTER TABLE public_holidays DROP CONSTRAINT public_holidays_date_key;
+CREATE UNIQUE INDEX public_holidays_calendar_date_key
+    ON public_holidays ((COALESCE("work_calendar_id", '00000000-0000-0000-0000-000000000000'::uuid)), "date");
+
+-- ======= END OF PUBLIC_HOLIDAYS =======
+
+
+-- ======= OFFICE_LOCATIONS =======
+
+-- the calendar of the location, the default calendar is used when it is empty
+ALTER TABLE office_locations ADD COLUMN "work_calendar_id" uuid REFERENCES work_calendars ("id");
+
+-- ======= END OF OFFICE_LOCATIONS =======
+
+
+-- ======= EMPLOYEES =======
+
+-- the base office of the employee, its calendar decides the working days of the employee
+ALTER TABLE employees ADD COLUMN "office_location_id" uuid REFERENCES office_locations ("id");
+
+-- ======= END OF EMPLOYEES =======
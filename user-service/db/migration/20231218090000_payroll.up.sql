@@ -0,0 +1,323 @@
+-- ======= EMPLOYEE_SALARIES =======
+
+-- the effective-dated salary of the employee, the payroll uses the salary effective on the last day of the period
+CREATE TABLE employee_salaries (
+    "id" uuid NOT NULL,
+    "employee_id" uuid NOT NULL REFERENCES employees ("id"),
+    -- the monthly amounts are in rupiah
+    "base_salary" bigint NOT NULL,
+    -- the allowances, stored as an array of {code, name, amount, fixed}. The fixed allowances are part of the
+    -- wage the BPJS contributions, the overtime and the unpaid leave are based on.
+    "allowances" jsonb NOT NULL DEFAULT '[]',
+    -- the PTKP status of PPh 21, e.g. TK/0 or K/1
+    "tax_status" varchar NOT NULL DEFAULT 'TK/0',
+    "effective_from" date NOT NULL,
+    "note" varchar NOT NULL DEFAULT '',
+    "created_at" timestamp NOT NULL,
+    "updated_at" timestamp NOT NULL,
+    PRIMARY KEY ("id"),
+    UNIQUE ("employee_id", "effective_from")
+);
+
+-- ======= END OF EMPLOYEE_SALARIES =======
+
+
+-- ======= BPJS_RATES =======
+
+-- the rates of the BPJS programs (kesehatan, jht, jp, jkk, jkm) in percent, a new version starts on its effective date
+CREATE TABLE bpjs_rates (
+    "id" uuid NOT NULL,
+    "program" varchar NOT NULL,
+    "employee_rate" numeric(6,4) NOT NULL DEFAULT 0,
+    "employer_rate" numeric(6,4) NOT NULL DEFAULT 0,
+    -- the maximum monthly wage of the contribution, the wage is not capped when it is empty
+    "wage_cap" bigint,
+    -- the employer contribution is a taxable benefit of the employee (PPh 21)
+    "employer_taxable" boolean NOT NULL DEFAULT false,
+    -- the employee contribution is deducted from the annual taxable income (PPh 21)
+    "employee_deductible" boolean NOT NULL DEFAULT false,
+    "effective_from" date NOT NULL,
+    "created_at" timestamp NOT NULL,
+    PRIMARY KEY ("id"),
+    UNIQUE ("program", "effective_from")
+);
+
+INSERT INTO bpjs_rates ("id", "program", "employee_rate", "employer_rate", "wage_cap", "employer_taxable", "employee_deductible", "effective_from", "created_at") VALUES
+    (uuid_generate_v4(), 'kesehatan', 1, 4, 12000000, true, false, '2024-01-01', NOW()),
+    (uuid_generate_v4(), 'jht', 2, 3.7, NULL, false, true, '2024-01-01', NOW()),
+    (uuid_generate_v4(), 'jp', 1, 2, 9559600, false, true, '2024-01-01', NOW()),
+    (uuid_generate_v4(), 'jp', 1, 2, 10042300, false, true, '2024-03-01', NOW()),
+    (uuid_generate_v4(), 'jp', 1, 2, 10547400, false, true, '2025-03-01', NOW()),
+    (uuid_generate_v4(), 'jkk', 0, 0.24, NULL, true, false, '2024-01-01', NOW()),
+    (uuid_generate_v4(), 'jkm', 0, 0.3, NULL, true, false, '2024-01-01', NOW());
+
+-- ======= END OF BPJS_RATES =======
+
+
+-- ======= PTKP_RATES =======
+
+-- the annual non-taxable income (PTKP) and the TER category of the tax status
+CREATE TABLE ptkp_rates (
+    "tax_status" varchar NOT NULL,
+    "amount" bigint NOT NULL,
+    "ter_category" varchar NOT NULL,
+    "effective_from" date NOT NULL,
+    PRIMARY KEY ("tax_status", "effective_from")
+);
+
+INSERT INTO ptkp_rates ("tax_status", "amount", "ter_category", "effective_from") VALUES
+    ('TK/0', 54000000, 'A', '2024-01-01'),
+    ('TK/1', 58500000, 'A', '2024-01-01'),
+    ('TK/2', 63000000, 'B', '2024-01-01'),
+    ('TK/3', 67500000, 'B', '2024-01-01'),
+    ('K/0', 58500000, 'A', '2024-01-01'),
+    ('K/1', 63000000, 'B', '2024-01-01'),
+    ('K/2', 67500000, 'B', '2024-01-01'),
+    ('K/3', 72000000, 'C', '2024-01-01');
+
+-- ======= END OF PTKP_RATES =======
+
+
+-- ======= PPH21_TER_RATES =======
+
+-- the monthly effective rates (TER) of PPh 21 in percent, the income bounds are inclusive. The rates of a category
+-- are replaced together by a new version.
+CREATE TABLE pph21_ter_rates (
+    "category" varchar NOT NULL,
+    "min_income" bigint NOT NULL,
+    "max_income" bigint,
+    "rate" numeric(6,4) NOT NULL,
+    "effective_from" date NOT NULL,
+    PRIMARY KEY ("category", "min_income", "effective_from")
+);
+
+INSERT INTO pph21_ter_rates ("category", "min_income", "max_income", "rate", "effective_from") VALUES
+    ('A', 0, 5400000, 0, '2024-01-01'),
+    ('A', 5400001, 5650000, 0.25, '2024-01-01'),
+    ('A', 5650001, 5950000, 0.5, '2024-01-01'),
+    ('A', 5950001, 6300000, 0.75, '2024-01-01'),
+    ('A', 6300001, 6750000, 1, '2024-01-01'),
+    ('A', 6750001, 7500000, 1.25, '2024-01-01'),
+    ('A', 7500001, 8550000, 1.5, '2024-01-01'),
+    ('A', 8550001, 9650000, 1.75, '2024-01-01'),
+    ('A', 9650001, 10050000, 2, '2024-01-01'),
+    ('A', 10050001, 10350000, 2.25, '2024-01-01'),
+    ('A', 10350001, 10700000, 2.5, '2024-01-01'),
+    ('A', 10700001, 11050000, 3, '2024-01-01'),
+    ('A', 11050001, 11600000, 3.5, '2024-01-01'),
+    ('A', 11600001, 12500000, 4, '2024-01-01'),
+    ('A', 12500001, 13750000, 5, '2024-01-01'),
+    ('A', 13750001, 15100000, 6, '2024-01-01'),
+    ('A', 15100001, 16950000, 7, '2024-01-01'),
+    ('A', 16950001, 19750000, 8, '2024-01-01'),
+    ('A', 19750001, 24150000, 9, '2024-01-01'),
+    ('A', 24150001, 26450000, 10, '2024-01-01'),
+    ('A', 26450001, 28000000, 11, '2024-01-01'),
+    ('A', 28000001, 30050000, 12, '2024-01-01'),
+    ('A', 30050001, 32400000, 13, '2024-01-01'),
+    ('A', 32400001, 35400000, 14, '2024-01-01'),
+    ('A', 35400001, 39100000, 15, '2024-01-01'),
+    ('A', 39100001, 43850000, 16, '2024-01-01'),
+    ('A', 43850001, 47800000, 17, '2024-01-01'),
+    ('A', 47800001, 51400000, 18, '2024-01-01'),
+    ('A', 51400001, 56300000, 19, '2024-01-01'),
+    ('A', 56300001, 62200000, 20, '2024-01-01'),
+    ('A', 62200001, 68600000, 21, '2024-01-01'),
+    ('A', 68600001, 77500000, 22, '2024-01-01'),
+    ('A', 77500001, 89000000, 23, '2024-01-01'),
+    ('A', 89000001, 103000000, 24, '2024-01-01'),
+    ('A', 103000001, 125000000, 25, '2024-01-01'),
+    ('A', 125000001, 157000000, 26, '2024-01-01'),
+    ('A', 157000001, 206000000, 27, '2024-01-01'),
+    ('A', 206000001, 337000000, 28, '2024-01-01'),
+    ('A', 337000001, 454000000, 29, '2024-01-01'),
+    ('A', 454000001, 550000000, 30, '2024-01-01'),
+    ('A', 550000001, 695000000, 31, '2024-01-01'),
+    ('A', 695000001, 910000000, 32, '2024-01-01'),
+    ('A', 910000001, 1400000000, 33, '2024-01-01'),
+    ('A', 1400000001, NULL, 34, '2024-01-01'),
+    ('B', 0, 6200000, 0, '2024-01-01'),
+    ('B', 6200001, 6500000, 0.25, '2024-01-01'),
+    ('B', 6500001, 6850000, 0.5, '2024-01-01'),
+    ('B', 6850001, 7300000, 0.75, '2024-01-01'),
+    ('B', 7300001, 9200000, 1, '2024-01-01'),
+    ('B', 9200001, 10750000, 1.5, '2024-01-01'),
+    ('B', 10750001, 11250000, 2, '2024-01-01'),
+    ('B', 11250001, 11600000, 2.5, '2024-01-01'),
+    ('B', 11600001, 12600000, 3, '2024-01-01'),
+    ('B', 12600001, 13600000, 4, '2024-01-01'),
+    ('B', 13600001, 14950000, 5, '2024-01-01'),
+    ('B', 14950001, 16400000, 6, '2024-01-01'),
+    ('B', 16400001, 18450000, 7, '2024-01-01'),
+    ('B', 18450001, 21850000, 8, '2024-01-01'),
+    ('B', 21850001, 26000000, 9, '2024-01-01'),
+    ('B', 26000001, 27700000, 10, '2024-01-01'),
+    ('B', 27700001, 29350000, 11, '2024-01-01'),
+    ('B', 29350001, 31450000, 12, '2024-01-01'),
+    ('B', 31450001, 33950000, 13, '2024-01-01'),
+    ('B', 33950001, 37100000, 14, '2024-01-01'),
+    ('B', 37100001, 41100000, 15, '2024-01-01'),
+    ('B', 41100001, 45800000, 16, '2024-01-01'),
+    ('B', 45800001, 49500000, 17, '2024-01-01'),
+    ('B', 49500001, 53800000, 18, '2024-01-01'),
+    ('B', 53800001, 58500000, 19, '2024-01-01'),
+    ('B', 58500001, 64000000, 20, '2024-01-01'),
+    ('B', 64000001, 71000000, 21, '2024-01-01'),
+    ('B', 71000001, 80000000, 22, '2024-01-01'),
+    ('B', 80000001, 93000000, 23, '2024-01-01'),
+    ('B', 93000001, 109000000, 24, '2024-01-01'),
+    ('B', 109000001, 129000000, 25, '2024-01-01'),
+    ('B', 129000001, 163000000, 26, '2024-01-01'),
+    ('B', 163000001, 211000000, 27, '2024-01-01'),
+    ('B', 211000001, 374000000, 28, '2024-01-01'),
+    ('B', 374000001, 459000000, 29, '2024-01-01'),
+    ('B', 459000001, 555000000, 30, '2024-01-01'),
+    ('B', 555000001, 704000000, 31, '2024-01-01'),
+    ('B', 704000001, 957000000, 32, '2024-01-01'),
+    ('B', 957000001, 1405000000, 33, '2024-01-01'),
+    ('B', 1405000001, NULL, 34, '2024-01-01'),
+    ('C', 0, 6600000, 0, '2024-01-01'),
+    ('C', 6600001, 6950000, 0.25, '2024-01-01'),
+    ('C', 6950001, 7350000, 0.5, '2024-01-01'),
+    ('C', 7350001, 7800000, 0.75, '2024-01-01'),
+    ('C', 7800001, 8850000, 1, '2024-01-01'),
+    ('C', 8850001, 9800000, 1.25, '2024-01-01'),
+    ('C', 9800001, 10950000, 1.5, '2024-01-01'),
+    ('C', 10950001, 11200000, 1.75, '2024-01-01'),
+    ('C', 11200001, 12050000, 2, '2024-01-01'),
+    ('C', 12050001, 12950000, 3, '2024-01-01'),
+    ('C', 12950001, 14150000, 4, '2024-01-01'),
+    ('C', 14150001, 15550000, 5, '2024-01-01'),
+    ('C', 15550001, 17050000, 6, '2024-01-01'),
+    ('C', 17050001, 19500000, 7, '2024-01-01'),
+    ('C', 19500001, 22700000, 8, '2024-01-01'),
+    ('C', 22700001, 26600000, 9, '2024-01-01'),
+    ('C', 26600001, 28100000, 10, '2024-01-01'),
+    ('C', 28100001, 30100000, 11, '2024-01-01'),
+    ('C', 30100001, 32600000, 12, '2024-01-01'),
+    ('C', 32600001, 35400000, 13, '2024-01-01'),
+    ('C', 35400001, 38900000, 14, '2024-01-01'),
+    ('C', 38900001, 43000000, 15, '2024-01-01'),
+    ('C', 43000001, 47400000, 16, '2024-01-01'),
+    ('C', 47400001, 51200000, 17, '2024-01-01'),
+    ('C', 51200001, 55800000, 18, '2024-01-01'),
+    ('C', 55800001, 60400000, 19, '2024-01-01'),
+    ('C', 60400001, 66700000, 20, '2024-01-01'),
+    ('C', 66700001, 74500000, 21, '2024-01-01'),
+    ('C', 74500001, 83200000, 22, '2024-01-01'),
+    ('C', 83200001, 95600000, 23, '2024-01-01'),
+    ('C', 95600001, 110000000, 24, '2024-01-01'),
+    ('C', 110000001, 134000000, 25, '2024-01-01'),
+    ('C', 134000001, 169000000, 26, '2024-01-01'),
+    ('C', 169000001, 221000000, 27, '2024-01-01'),
+    ('C', 221000001, 390000000, 28, '2024-01-01'),
+    ('C', 390000001, 463000000, 29, '2024-01-01'),
+    ('C', 463000001, 561000000, 30, '2024-01-01'),
+    ('C', 561000001, 709000000, 31, '2024-01-01'),
+    ('C', 709000001, 965000000, 32, '2024-01-01'),
+    ('C', 965000001, 1419000000, 33, '2024-01-01'),
+    ('C', 1419000001, NULL, 34, '2024-01-01');
+
+-- ======= END OF PPH21_TER_RATES =======
+
+
+-- ======= PPH21_RATES =======
+
+-- the progressive annual rates (Pasal 17) of PPh 21 in percent, used for the last period of the year. Each layer
+-- ends at 'up_to', the last layer has no end.
+CREATE TABLE pph21_rates (
+    "up_to" bigint,
+    "rate" numeric(6,4) NOT NULL,
+    "effective_from" date NOT NULL
+);
+
+CREATE UNIQUE INDEX pph21_rates_up_to_key ON pph21_rates ((COALESCE("up_to", 0)), "effective_from");
+
+INSERT INTO pph21_rates ("up_to", "rate", "effective_from") VALUES
+    (60000000, 5, '2024-01-01'),
+    (250000000, 15, '2024-01-01'),
+    (500000000, 25, '2024-01-01'),
+    (5000000000, 30, '2024-01-01'),
+    (NULL, 35, '2024-01-01');
+
+-- ======= END OF PPH21_RATES =======
+
+
+-- ======= PAYROLL_RUNS =======
+
+-- the monthly payroll run, it is calculated as a draft, reviewed and then locked
+CREATE TABLE payroll_runs (
+    "id" uuid NOT NULL,
+    "year" int NOT NULL,
+    "month" int NOT NULL,
+    "period_start" date NOT NULL,
+    "period_end" date NOT NULL,
+    -- draft, reviewed or locked
+    "status" varchar NOT NULL DEFAULT 'draft',
+    "employee_count" int NOT NULL DEFAULT 0,
+    "total_gross" bigint NOT NULL DEFAULT 0,
+    "total_deductions" bigint NOT NULL DEFAULT 0,
+    "total_net" bigint NOT NULL DEFAULT 0,
+    "total_employer_contributions" bigint NOT NULL DEFAULT 0,
+    "calculated_at" timestamp NOT NULL,
+    "created_by" uuid REFERENCES users ("id"),
+    "reviewed_by" uuid REFERENCES users ("id"),
+    "reviewed_at" timestamp,
+    "locked_by" uuid REFERENCES users ("id"),
+    "locked_at" timestamp,
+    "created_at" timestamp NOT NULL,
+    "updated_at" timestamp NOT NULL,
+    PRIMARY KEY ("id"),
+    UNIQUE ("year", "month")
+);
+
+-- ======= END OF PAYROLL_RUNS =======
+
+
+-- ======= PAYSLIPS =======
+
+-- the payroll of an employee in the run, the inputs are kept so the calculation can be reproduced
+CREATE TABLE payslips (
+    "id" uuid NOT NULL,
+    "payroll_run_id" uuid NOT NULL REFERENCES payroll_runs ("id") ON DELETE CASCADE,
+    "employee_id" uuid NOT NULL REFERENCES employees ("id"),
+    -- the inputs of the calculation
+    "base_salary" bigint NOT NULL,
+    "allowances" jsonb NOT NULL DEFAULT '[]',
+    "tax_status" varchar NOT NULL,
+    "ter_category" varchar NOT NULL,
+    "working_days" int NOT NULL,
+    -- the paid overtime hours, weighted by the overtime multipliers of the days
+    "overtime_hours" numeric(8,2) NOT NULL DEFAULT 0,
+    "unpaid_leave_days" numeric(5,1) NOT NULL DEFAULT 0,
+    -- the results of the calculation
+    "gross_pay" bigint NOT NULL,
+    "total_deductions" bigint NOT NULL,
+    "net_pay" bigint NOT NULL,
+    "employer_contributions" bigint NOT NULL,
+    -- the gross income of PPh 21, including the taxable employer contributions
+    "taxable_income" bigint NOT NULL,
+    -- the employee contributions deducted from the annual taxable income
+    "deductible_contributions" bigint NOT NULL,
+    "income_tax" bigint NOT NULL,
+    "created_at" timestamp NOT NULL,
+    PRIMARY KEY ("id"),
+    UNIQUE ("payroll_run_id", "employee_id")
+);
+
+CREATE INDEX payslips_employee_id_idx ON payslips ("employee_id");
+
+-- the line items of the payslip: earning, deduction or employer_contribution
+CREATE TABLE payslip_items (
+    "payslip_id" uuid NOT NULL REFERENCES payslips ("id") ON DELETE CASCADE,
+    "seq" int NOT NULL,
+    "code" varchar NOT NULL,
+    "name" varchar NOT NULL,
+    "category" varchar NOT NULL,
+    -- e.g. the overtime hours or the unpaid leave days
+    "quantity" numeric(8,2) NOT NULL DEFAULT 0,
+    "amount" bigint NOT NULL,
+    PRIMARY KEY ("payslip_id", "seq")
+);
+
+-- ======= END OF PAYSLIPS =======
@@ -0,0 +1,9 @@
+-- ======= PAYSLIPS =======
+
+-- the payslip PDF kept in the storage, the PDF is protected with the password derived from the date of birth
+ALTER TABLE payslips
+    ADD COLUMN "document_key" varchar,
+    ADD COLUMN "document_generated_at" timestamp,
+    ADD COLUMN "emailed_at" timestamp;
+
+-- ======= END OF PAYSLIPS =======
@@ -0,0 +1,62 @@
+-- ======= HANDOVERS =======
+
+-- the running number of the handover documents (BAST), it is never reset so the number is unique
+CREATE SEQUENCE handover_number_seq;
+
+CREATE TABLE handovers (
+    "id" uuid NOT NULL,
+    -- the number of the document in the register, e.g. 'BAST/2023/12/00001'
+    "document_number" varchar NOT NULL UNIQUE,
+    -- 'employee' is the handover of the work of an employee, 'asset' is the handover of goods
+    "type" varchar NOT NULL,
+    "title" varchar NOT NULL,
+    "description" text NOT NULL DEFAULT '',
+    "location" varchar NOT NULL DEFAULT '',
+    "handover_date" date NOT NULL,
+    -- the employee who hands over and the employee who receives
+    "from_employee_id" uuid NOT NULL REFERENCES employees ("id"),
+    "to_employee_id" uuid NOT NULL REFERENCES employees ("id"),
+    "status" varchar NOT NULL DEFAULT 'draft',
+    -- the signed document in the storage, it is generated when every signer has signed
+    "document_key" varchar,
+    "emailed_at" timestamp,
+    "created_by" uuid REFERENCES users ("id"),
+    "submitted_at" timestamp,
+    "completed_at" timestamp,
+    "cancelled_at" timestamp,
+    "created_at" timestamp NOT NULL,
+    "updated_at" timestamp NOT NULL,
+    PRIMARY KEY ("id")
+);
+
+CREATE INDEX handovers_status_idx ON handovers ("status", "handover_date");
+
+CREATE TABLE handover_items (
+    "handover_id" uuid NOT NULL REFERENCES handovers ("id") ON DELETE CASCADE,
+    "seq" int NOT NULL,
+    "name" varchar NOT NULL,
+    "description" varchar NOT NULL DEFAULT '',
+    "quantity" numeric(12,2) NOT NULL DEFAULT 1,
+    "unit" varchar NOT NULL DEFAULT '',
+    "serial_number" varchar NOT NULL DEFAULT '',
+    "condition" varchar NOT NULL DEFAULT '',
+    "note" varchar NOT NULL DEFAULT '',
+    PRIMARY KEY ("handover_id", "seq")
+);
+
+-- the signers sign the handover one by one in the order of the sequence
+CREATE TABLE handover_signers (
+    "handover_id" uuid NOT NULL REFERENCES handovers ("id") ON DELETE CASCADE,
+    "seq" int NOT NULL,
+    "employee_id" uuid NOT NULL REFERENCES employees ("id"),
+    -- the role printed above the signature, e.g. 'Dibuat oleh' or 'Diterima oleh'
+    "role" varchar NOT NULL,
+    "status" varchar NOT NULL DEFAULT 'pending',
+    "note" varchar NOT NULL DEFAULT '',
+    "signed_at" timestamp,
+    PRIMARY KEY ("handover_id", "seq")
+);
+
+CREATE INDEX handover_signers_employee_id_idx ON handover_signers ("employee_id", "status");
+
+-- ======= END OF HANDOVERS =======
@@ -0,0 +1,56 @@
+-- ======= ASSETS =======
+
+CREATE TABLE assets (
+    "id" uuid NOT NULL,
+    -- the inventory code of the asset, e.g. 'IT-LPT-0001'
+    "asset_code" varchar NOT NULL UNIQUE,
+    "name" varchar NOT NULL,
+    "category" varchar NOT NULL,
+    "brand" varchar NOT NULL DEFAULT '',
+    "serial_number" varchar NOT NULL DEFAULT '',
+    "purchase_date" date,
+    "purchase_price" numeric(15,2) NOT NULL DEFAULT 0,
+    -- 'new', 'good', 'fair', 'damaged' or 'lost'
+    "condition" varchar NOT NULL DEFAULT 'good',
+    "location" varchar NOT NULL DEFAULT '',
+    -- 'available', 'assigned', 'maintenance' or 'retired'
+    "status" varchar NOT NULL DEFAULT 'available',
+    -- the employee who holds the asset, it is only filled when the asset is assigned
+    "employee_id" uuid REFERENCES employees ("id"),
+    "note" text NOT NULL DEFAULT '',
+    "created_at" timestamp NOT NULL,
+    "updated_at" timestamp NOT NULL,
+    "deleted_at" timestamp,
+    PRIMARY KEY ("id")
+);
+
+CREATE INDEX assets_employee_id_idx ON assets ("employee_id") WHERE deleted_at IS NULL;
+CREATE INDEX assets_category_idx ON assets ("category", "status") WHERE deleted_at IS NULL;
+
+-- the assignment history of the assets, the assignment is open until the asset is returned
+CREATE TABLE asset_assignments (
+    "id" uuid NOT NULL,
+    "asset_id" uuid NOT NULL REFERENCES assets ("id"),
+    "employee_id" uuid NOT NULL REFERENCES employees ("id"),
+    "assigned_at" date NOT NULL,
+    "assigned_by" uuid REFERENCES users ("id"),
+    "assign_condition" varchar NOT NULL,
+    "assign_note" varchar NOT NULL DEFAULT '',
+    -- the handover document (BAST) of the assignment
+    "assign_handover_id" uuid REFERENCES handovers ("id"),
+    "returned_at" date,
+    "returned_by" uuid REFERENCES users ("id"),
+    "return_condition" varchar,
+    "return_note" varchar NOT NULL DEFAULT '',
+    -- the handover document (BAST) of the return
+    "return_handover_id" uuid REFERENCES handovers ("id"),
+    "created_at" timestamp NOT NULL,
+    "updated_at" timestamp NOT NULL,
+    PRIMARY KEY ("id")
+);
+
+-- an asset can only be held by one employee at a time
+CREATE UNIQUE INDEX asset_assignments_open_idx ON asset_assignments ("asset_id") WHERE returned_at IS NULL;
+CREATE INDEX asset_assignments_employee_id_idx ON asset_assignments ("employee_id", "assigned_at");
+
+-- ======= END OF ASSETS =======
@@ -0,0 +1,75 @@
+-- ======= ONBOARDING =======
+
+-- the checklist template of the new employees, the template of the position is used before the template of the
+-- department and the default template (without position and department)
+CREATE TABLE onboarding_templates (
+    "id" uuid NOT NULL,
+    "name" varchar NOT NULL,
+    "description" text NOT NULL DEFAULT '',
+    "department_id" uuid REFERENCES departments ("id"),
+    "position_id" uuid REFERENCES positions ("id"),
+    "created_at" timestamp NOT NULL,
+    "updated_at" timestamp NOT NULL,
+    "deleted_at" timestamp,
+    PRIMARY KEY ("id")
+);
+
+CREATE INDEX onboarding_templates_position_id_idx ON onboarding_templates ("position_id", "department_id") WHERE deleted_at IS NULL;
+
+CREATE TABLE onboarding_template_tasks (
+    "template_id" uuid NOT NULL REFERENCES onboarding_templates ("id") ON DELETE CASCADE,
+    "seq" int NOT NULL,
+    "title" varchar NOT NULL,
+    "description" varchar NOT NULL DEFAULT '',
+    -- 'document', 'account', 'asset', 'training' or 'other'
+    "category" varchar NOT NULL,
+    -- 'hr', 'it', 'manager' or 'employee'
+    "assignee_role" varchar NOT NULL,
+    -- the employee who does the 'hr' or 'it' task, e.g. the HR officer
+    "assignee_id" uuid REFERENCES employees ("id"),
+    -- the due date of the task relative to the hire date, it is negative when the task is due before the hire date
+    "due_days" int NOT NULL DEFAULT 0,
+    PRIMARY KEY ("template_id", "seq")
+);
+
+-- the onboarding of an employee, the tasks are copied from the template when the onboarding is started
+CREATE TABLE onboardings (
+    "id" uuid NOT NULL,
+    "employee_id" uuid NOT NULL UNIQUE REFERENCES employees ("id"),
+    "template_id" uuid REFERENCES onboarding_templates ("id"),
+    -- 'in_progress', 'completed' or 'cancelled'
+    "status" varchar NOT NULL DEFAULT 'in_progress',
+    "started_at" timestamp NOT NULL,
+    "completed_at" timestamp,
+    "cancelled_at" timestamp,
+    "created_at" timestamp NOT NULL,
+    "updated_at" timestamp NOT NULL,
+    PRIMARY KEY ("id")
+);
+
+CREATE INDEX onboardings_status_idx ON onboardings ("status");
+
+CREATE TABLE onboarding_tasks (
+    "onboarding_id" uuid NOT NULL REFERENCES onboardings ("id") ON DELETE CASCADE,
+    "seq" int NOT NULL,
+    "title" varchar NOT NULL,
+    "description" varchar NOT NULL DEFAULT '',
+    "category" varchar NOT NULL,
+    "assignee_role" varchar NOT NULL,
+    -- the employee who does the task, it is resolved from the role when the onboarding is started
+    "assignee_id" uuid REFERENCES employees ("id"),
+    "due_date" date NOT NULL,
+    -- 'pending', 'done' or 'skipped'
+    "status" varchar NOT NULL DEFAULT 'pending',
+    "note" varchar NOT NULL DEFAULT '',
+    "completed_by" uuid REFERENCES users ("id"),
+    "completed_at" timestamp,
+    -- the last time the assignee is reminded, the assignee is reminded at most once a day
+    "reminded_at" timestamp,
+    PRIMARY KEY ("onboarding_id", "seq")
+);
+
+CREATE INDEX onboarding_tasks_assignee_id_idx ON onboarding_tasks ("assignee_id", "status");
+CREATE INDEX onboarding_tasks_due_date_idx ON onboarding_tasks ("due_date") WHERE status = 'pending';
+
+-- ======= END OF ONBOARDING =======
@@ -0,0 +1,54 @@
+-- ======= OFFBOARDING =======
+
+-- the resignation or termination of an employee, the account of the employee is deactivated after the last working
+-- day
+CREATE TABLE offboardings (
+    "id" uuid NOT NULL,
+    "employee_id" uuid NOT NULL REFERENCES employees ("id"),
+    -- 'resignation' or 'termination'
+    "type" varchar NOT NULL,
+    "notice_date" date NOT NULL,
+    "last_working_day" date NOT NULL,
+    "reason" text NOT NULL DEFAULT '',
+    "rehire_eligible" boolean NOT NULL DEFAULT true,
+    -- 'in_progress', 'completed' or 'cancelled'
+    "status" varchar NOT NULL DEFAULT 'in_progress',
+    "completed_at" timestamp,
+    "cancelled_at" timestamp,
+    -- the account of the employee is deactivated and the sessions are revoked
+    "deactivated_at" timestamp,
+    -- the storage key of the clearance document
+    "clearance_document_key" varchar,
+    "created_by" uuid REFERENCES users ("id"),
+    "created_at" timestamp NOT NULL,
+    "updated_at" timestamp NOT NULL,
+    PRIMARY KEY ("id")
+);
+
+-- an employee has at most one offboarding which is not cancelled
+CREATE UNIQUE INDEX offboardings_employee_id_idx ON offboardings ("employee_id") WHERE status <> 'cancelled';
+CREATE INDEX offboardings_last_working_day_idx ON offboardings ("last_working_day") WHERE deactivated_at IS NULL;
+
+CREATE TABLE offboarding_tasks (
+    "offboarding_id" uuid NOT NULL REFERENCES offboardings ("id") ON DELETE CASCADE,
+    "seq" int NOT NULL,
+    "title" varchar NOT NULL,
+    "description" varchar NOT NULL DEFAULT '',
+    -- 'asset_return', 'knowledge_transfer', 'final_pay', 'exit_interview' or 'other'
+    "category" varchar NOT NULL,
+    -- 'hr', 'it', 'manager' or 'employee'
+    "assignee_role" varchar NOT NULL,
+    "assignee_id" uuid REFERENCES employees ("id"),
+    "due_date" date NOT NULL,
+    -- 'pending', 'done' or 'skipped'
+    "status" varchar NOT NULL DEFAULT 'pending',
+    -- e.g. the notes of the exit interview
+    "note" text NOT NULL DEFAULT '',
+    "completed_by" uuid REFERENCES users ("id"),
+    "completed_at" timestamp,
+    PRIMARY KEY ("offboarding_id", "seq")
+);
+
+CREATE INDEX offboarding_tasks_assignee_id_idx ON offboarding_tasks ("assignee_id", "status");
+
+-- ======= END OF OFFBOARDING =======
@@ -0,0 +1,45 @@
+-- ======= EMPLOYEE DOCUMENT =======
+
+-- the documents of the employee (e.g. KTP, NPWP, contracts and certificates), every upload is kept as a version
+CREATE TABLE employee_documents (
+    "id" uuid NOT NULL,
+    "employee_id" uuid NOT NULL REFERENCES employees ("id"),
+    -- 'ktp', 'npwp', 'contract', 'certificate' or 'other'
+    "type" varchar NOT NULL,
+    "title" varchar NOT NULL,
+    -- the number printed on the document, e.g. the NIK of the KTP or the number of the certificate
+    "number" varchar NOT NULL DEFAULT '',
+    "expiry_date" date,
+    -- who can view the document besides HR: 'employee' (the employee), 'manager' (the employee and the managers
+    -- of the employee) or 'hr' (HR only)
+    "access" varchar NOT NULL DEFAULT 'employee',
+    "latest_version" int NOT NULL DEFAULT 1,
+    -- the expiry date the owner has been reminded of, the owner is reminded again when the expiry date changes
+    "reminded_expiry_date" date,
+    "created_by" uuid REFERENCES users ("id"),
+    "created_at" timestamp NOT NULL,
+    "updated_at" timestamp NOT NULL,
+    "deleted_at" timestamp,
+    PRIMARY KEY ("id")
+);
+
+CREATE INDEX employee_documents_employee_id_idx ON employee_documents ("employee_id") WHERE deleted_at IS NULL;
+CREATE INDEX employee_documents_expiry_date_idx ON employee_documents ("expiry_date") WHERE deleted_at IS NULL;
+
+CREATE TABLE employee_document_versions (
+    "document_id" uuid NOT NULL REFERENCES employee_documents ("id") ON DELETE CASCADE,
+    "version" int NOT NULL,
+    -- the key of the file in the storage
+    "storage_key" varchar NOT NULL,
+    "file_name" varchar NOT NULL,
+    "mime_type" varchar NOT NULL,
+    "size" bigint NOT NULL,
+    -- the sha256 checksum of the file in hex
+    "checksum" varchar NOT NULL,
+    "note" varchar NOT NULL DEFAULT '',
+    "uploaded_by" uuid REFERENCES users ("id"),
+    "uploaded_at" timestamp NOT NULL,
+    PRIMARY KEY ("document_id", "version")
+);
+
+-- ======= END OF EMPLOYEE DOCUMENT =======
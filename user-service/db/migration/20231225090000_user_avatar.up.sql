@@ -0,0 +1,6 @@
+-- ======= USER AVATAR =======
+
+-- the URL of the profile photo uploaded to the profile-service, filled by the 'PUT.USER_AVATAR' kafka message
+ALTER TABLE users ADD COLUMN "avatar_url" varchar;
+
+-- ======= END OF USER AVATAR =======
@@ -0,0 +1,45 @@
+-- ======= EMPLOYMENT CONTRACTS =======
+
+-- the running number of the employment contracts, it is never reset so the number is unique
+CREATE SEQUENCE employment_contract_number_seq;
+
+-- the employment contract of an employee, PKWT (fixed-term) or PKWTT (permanent). The contract is drafted, then
+-- active once it is signed until it is renewed, converted to PKWTT or its end date has passed.
+CREATE TABLE employment_contracts (
+    "id" uuid NOT NULL,
+    -- the number of the contract in the register, e.g. 'PKWT/2023/12/00001'
+    "contract_number" varchar NOT NULL UNIQUE,
+    "employee_id" uuid NOT NULL REFERENCES employees ("id"),
+    -- 'pkwt' or 'pkwtt'
+    "type" varchar NOT NULL,
+    "job_title" varchar NOT NULL,
+    "start_date" date NOT NULL,
+    -- the end date of the PKWT, the PKWTT has no end date
+    "end_date" date,
+    -- the end of the probation of the PKWTT, the PKWT can't have a probation
+    "probation_end_date" date,
+    -- the salary of the employee the contract refers to
+    "salary_id" uuid REFERENCES employee_salaries ("id"),
+    -- the contract which is renewed or converted by this contract
+    "previous_contract_id" uuid REFERENCES employment_contracts ("id"),
+    -- 'draft', 'active', 'renewed', 'converted', 'ended' or 'cancelled'
+    "status" varchar NOT NULL DEFAULT 'draft',
+    -- the signed contract uploaded to the employee documents
+    "signed_document_id" uuid REFERENCES employee_documents ("id"),
+    "signed_at" timestamp,
+    -- the smallest number of days before the end date the expiry alert has been sent for, e.g. 14 after the 30 and
+    -- 14 days alerts
+    "alerted_days" int,
+    "note" varchar NOT NULL DEFAULT '',
+    "created_by" uuid REFERENCES users ("id"),
+    "created_at" timestamp NOT NULL,
+    "updated_at" timestamp NOT NULL,
+    PRIMARY KEY ("id")
+);
+
+-- an employee has at most one active contract, and a contract is renewed or converted at most once
+CREATE UNIQUE INDEX employment_contracts_employee_id_idx ON employment_contracts ("employee_id") WHERE status = 'active';
+CREATE UNIQUE INDEX employment_contracts_previous_contract_id_idx ON employment_contracts ("previous_contract_id") WHERE status <> 'cancelled';
+CREATE INDEX employment_contracts_end_date_idx ON employment_contracts ("end_date") WHERE status = 'active';
+
+-- ======= END OF EMPLOYMENT CONTRACTS =======
@@ -0,0 +1,89 @@
+-- ======= PERFORMANCE REVIEWS =======
+
+-- the review cycle of a period, e.g. the 2023 annual review. The cycle moves through the phases: 'draft',
+-- 'goal_setting', 'self_assessment', 'manager_assessment', 'calibration' and 'closed'.
+CREATE TABLE review_cycles (
+    "id" uuid NOT NULL,
+    "name" varchar NOT NULL,
+    "description" text NOT NULL DEFAULT '',
+    "period_start" date NOT NULL,
+    "period_end" date NOT NULL,
+    "status" varchar NOT NULL DEFAULT 'draft',
+    -- whether the peer feedback can be requested, and whether the peers are hidden from the reviewee and the manager
+    "peer_feedback" boolean NOT NULL DEFAULT false,
+    "peer_anonymous" boolean NOT NULL DEFAULT true,
+    "created_by" uuid REFERENCES users ("id"),
+    "closed_at" timestamp,
+    "created_at" timestamp NOT NULL,
+    "updated_at" timestamp NOT NULL,
+    PRIMARY KEY ("id")
+);
+
+CREATE INDEX review_cycles_status_idx ON review_cycles ("status");
+
+-- the review of a participant of the cycle, the reviewer is the manager of the employee when the cycle is created
+CREATE TABLE performance_reviews (
+    "id" uuid NOT NULL,
+    "cycle_id" uuid NOT NULL REFERENCES review_cycles ("id") ON DELETE CASCADE,
+    "employee_id" uuid NOT NULL REFERENCES employees ("id"),
+    "reviewer_id" uuid REFERENCES employees ("id"),
+    -- the weighted scores of the goals from 1 to 5
+    "self_score" numeric(4,2),
+    "self_comment" text NOT NULL DEFAULT '',
+    "self_submitted_at" timestamp,
+    "manager_score" numeric(4,2),
+    "manager_comment" text NOT NULL DEFAULT '',
+    "manager_submitted_at" timestamp,
+    -- the score adjusted by HR in the calibration, the manager score is used when it is not calibrated
+    "calibrated_score" numeric(4,2),
+    "calibration_note" text NOT NULL DEFAULT '',
+    "calibrated_by" uuid REFERENCES users ("id"),
+    -- the final score and rating are set when the cycle is closed and can't be changed afterwards
+    "final_score" numeric(4,2),
+    -- 'outstanding', 'exceeds', 'meets', 'needs_improvement' or 'unsatisfactory'
+    "final_rating" varchar,
+    "locked_at" timestamp,
+    "created_at" timestamp NOT NULL,
+    "updated_at" timestamp NOT NULL,
+    PRIMARY KEY ("id"),
+    UNIQUE ("cycle_id", "employee_id")
+);
+
+CREATE INDEX performance_reviews_employee_id_idx ON performance_reviews ("employee_id");
+CREATE INDEX performance_reviews_reviewer_id_idx ON performance_reviews ("reviewer_id");
+
+-- the goals or KPIs of the review, the weights of the goals of a review add up to 100
+CREATE TABLE performance_review_goals (
+    "review_id" uuid NOT NULL REFERENCES performance_reviews ("id") ON DELETE CASCADE,
+    "seq" int NOT NULL,
+    "title" varchar NOT NULL,
+    "description" varchar NOT NULL DEFAULT '',
+    -- the measure of the KPI, e.g. '95% on-time delivery'
+    "target" varchar NOT NULL DEFAULT '',
+    "weight" int NOT NULL,
+    -- the scores from 1 to 5
+    "self_score" int,
+    "self_comment" varchar NOT NULL DEFAULT '',
+    "manager_score" int,
+    "manager_comment" varchar NOT NULL DEFAULT '',
+    PRIMARY KEY ("review_id", "seq")
+);
+
+-- the feedback of a peer of the reviewed employee
+CREATE TABLE performance_review_peers (
+    "review_id" uuid NOT NULL REFERENCES performance_reviews ("id") ON DELETE CASCADE,
+    "reviewer_id" uuid NOT NULL REFERENCES employees ("id"),
+    -- 'requested' or 'submitted'
+    "status" varchar NOT NULL DEFAULT 'requested',
+    -- the score from 1 to 5
+    "score" int,
+    "comment" text NOT NULL DEFAULT '',
+    "requested_by" uuid REFERENCES users ("id"),
+    "requested_at" timestamp NOT NULL,
+    "submitted_at" timestamp,
+    PRIMARY KEY ("review_id", "reviewer_id")
+);
+
+CREATE INDEX performance_review_peers_reviewer_id_idx ON performance_review_peers ("reviewer_id", "status");
+
+-- ======= END OF PERFORMANCE REVIEWS =======
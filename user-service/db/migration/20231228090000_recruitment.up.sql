@@ -0,0 +1,192 @@
+-- ======= JOB REQUISITIONS =======
+
+-- the request to hire for a department, it is approved by the manager of the requester before it can be posted
+CREATE TABLE job_requisitions (
+    "id" uuid NOT NULL,
+    "title" varchar NOT NULL,
+    "department_id" uuid NOT NULL REFERENCES departments ("id"),
+    "position_id" uuid REFERENCES positions ("id"),
+    -- the number of candidates to be hired, the requisition is filled once they are hired
+    "headcount" int NOT NULL DEFAULT 1,
+    -- the number of the hired candidates, it is increased with the hire so the hires can't exceed the headcount
+    "hired" int NOT NULL DEFAULT 0,
+    -- the employment type of the hired employees, e.g. 'permanent' or 'contract'
+    "employment_type" varchar NOT NULL,
+    "description" varchar NOT NULL DEFAULT '',
+    "reason" varchar NOT NULL DEFAULT '',
+    -- the budgeted monthly salary range, it is never shown on the posting
+    "salary_min" bigint,
+    "salary_max" bigint,
+    -- 'draft', 'pending_approval', 'approved', 'rejected', 'filled' or 'cancelled'
+    "status" varchar NOT NULL DEFAULT 'draft',
+    "requested_by" uuid NOT NULL REFERENCES users ("id"),
+    -- the employee who approves the requisition, the manager of the requester at the time it is submitted
+    "approver_id" uuid REFERENCES employees ("id"),
+    "decision_note" varchar NOT NULL DEFAULT '',
+    "decided_at" timestamp,
+    "created_at" timestamp NOT NULL,
+    "updated_at" timestamp NOT NULL,
+    PRIMARY KEY ("id")
+);
+
+CREATE INDEX job_requisitions_status_idx ON job_requisitions ("status");
+CREATE INDEX job_requisitions_approver_id_idx ON job_requisitions ("approver_id") WHERE status = 'pending_approval';
+
+-- the public posting of the approved requisition, a requisition has at most one posting
+CREATE TABLE job_postings (
+    "id" uuid NOT NULL,
+    "requisition_id" uuid NOT NULL UNIQUE REFERENCES job_requisitions ("id"),
+    "title" varchar NOT NULL,
+    "description" varchar NOT NULL DEFAULT '',
+    "requirements" varchar NOT NULL DEFAULT '',
+    "location" varchar NOT NULL DEFAULT '',
+    -- 'open' or 'closed', only the open posting accepts applications
+    "status" varchar NOT NULL DEFAULT 'open',
+    "published_at" timestamp NOT NULL,
+    -- the date the posting is closed automatically, the posting stays open when it is empty
+    "closes_on" date,
+    "closed_at" timestamp,
+    "created_at" timestamp NOT NULL,
+    "updated_at" timestamp NOT NULL,
+    PRIMARY KEY ("id")
+);
+
+CREATE INDEX job_postings_status_idx ON job_postings ("status");
+
+-- ======= END OF JOB REQUISITIONS =======
+
+
+-- ======= JOB APPLICATIONS =======
+
+-- the configurable stages of the recruitment pipeline, the applications move through them in the order of the seq
+CREATE TABLE recruitment_stages (
+    "id" uuid NOT NULL,
+    "name" varchar NOT NULL UNIQUE,
+    "seq" int NOT NULL UNIQUE,
+    "created_at" timestamp NOT NULL,
+    "updated_at" timestamp NOT NULL,
+    PRIMARY KEY ("id")
+);
+
+INSERT INTO recruitment_stages ("id", "name", "seq", "created_at", "updated_at") VALUES
+    (uuid_generate_v4(), 'Screening', 1, NOW(), NOW()),
+    (uuid_generate_v4(), 'Interview', 2, NOW(), NOW()),
+    (uuid_generate_v4(), 'Offer', 3, NOW(), NOW());
+
+-- the candidate who applied through the postings, the candidate is identified by the email so the applications
+-- of the same person are kept together
+CREATE TABLE candidates (
+    "id" uuid NOT NULL,
+    "name" varchar NOT NULL,
+    "email" varchar NOT NULL UNIQUE,
+    "phone" varchar NOT NULL DEFAULT '',
+    "created_at" timestamp NOT NULL,
+    "updated_at" timestamp NOT NULL,
+    PRIMARY KEY ("id")
+);
+
+-- the application of a candidate to a posting
+CREATE TABLE job_applications (
+    "id" uuid NOT NULL,
+    "posting_id" uuid NOT NULL REFERENCES job_postings ("id"),
+    "candidate_id" uuid NOT NULL REFERENCES candidates ("id"),
+    -- the current stage of the application in the pipeline
+    "stage_id" uuid NOT NULL REFERENCES recruitment_stages ("id"),
+    -- 'active', 'hired', 'rejected' or 'withdrawn'
+    "status" varchar NOT NULL DEFAULT 'active',
+    "cover_letter" varchar NOT NULL DEFAULT '',
+    -- the uploaded CV, the MIME type is detected from the content of the file
+    "cv_file_name" varchar NOT NULL,
+    "cv_mime_type" varchar NOT NULL,
+    "cv_size" bigint NOT NULL,
+    "cv_storage_key" varchar NOT NULL,
+    "rejection_reason" varchar NOT NULL DEFAULT '',
+    -- the user the candidate is converted to when hired
+    "user_id" uuid REFERENCES users ("id"),
+    "hired_at" timestamp,
+    "created_at" timestamp NOT NULL,
+    "updated_at" timestamp NOT NULL,
+    PRIMARY KEY ("id"),
+    UNIQUE ("posting_id", "candidate_id")
+);
+
+CREATE INDEX job_applications_stage_id_idx ON job_applications ("stage_id") WHERE status = 'active';
+
+-- the moves of the application between the stages, the first row is the stage the application starts from
+CREATE TABLE job_application_stage_moves (
+    "id" uuid NOT NULL,
+    "application_id" uuid NOT NULL REFERENCES job_applications ("id") ON DELETE CASCADE,
+    "from_stage_id" uuid REFERENCES recruitment_stages ("id"),
+    "to_stage_id" uuid NOT NULL REFERENCES recruitment_stages ("id"),
+    "note" varchar NOT NULL DEFAULT '',
+    -- the user who moved the application, it is empty when the candidate applied
+    "moved_by" uuid REFERENCES users ("id"),
+    "moved_at" timestamp NOT NULL,
+    PRIMARY KEY ("id")
+);
+
+CREATE INDEX job_application_stage_moves_application_id_idx ON job_application_stage_moves ("application_id");
+
+-- the interview of the application in a stage, the interviewer fills the scorecard after the interview
+CREATE TABLE interviews (
+    "id" uuid NOT NULL,
+    "application_id" uuid NOT NULL REFERENCES job_applications ("id") ON DELETE CASCADE,
+    "stage_id" uuid NOT NULL REFERENCES recruitment_stages ("id"),
+    "interviewer_id" uuid NOT NULL REFERENCES employees ("id"),
+    "scheduled_at" timestamp NOT NULL,
+    "duration_minutes" int NOT NULL,
+    -- the room or the meeting link of the interview
+    "location" varchar NOT NULL DEFAULT '',
+    -- 'scheduled', 'completed' or 'cancelled'
+    "status" varchar NOT NULL DEFAULT 'scheduled',
+    -- 'strong_yes', 'yes', 'no' or 'strong_no'
+    "recommendation" varchar,
+    -- the average of the scores of the scorecard items
+    "score" numeric(4,2),
+    "feedback" varchar NOT NULL DEFAULT '',
+    "feedback_submitted_at" timestamp,
+    "created_by" uuid REFERENCES users ("id"),
+    "created_at" timestamp NOT NULL,
+    "updated_at" timestamp NOT NULL,
+    PRIMARY KEY ("id")
+);
+
+CREATE INDEX interviews_application_id_idx ON interviews ("application_id");
+CREATE INDEX interviews_interviewer_id_idx ON interviews ("interviewer_id") WHERE status = 'scheduled';
+
+-- the criteria of the scorecard of the interview, e.g. 'communication' or 'technical skills'
+CREATE TABLE interview_scorecard_items (
+    "interview_id" uuid NOT NULL REFERENCES interviews ("id") ON DELETE CASCADE,
+    "seq" int NOT NULL,
+    "criterion" varchar NOT NULL,
+    -- 1 to 5
+    "score" int NOT NULL,
+    "comment" varchar NOT NULL DEFAULT '',
+    PRIMARY KEY ("interview_id", "seq")
+);
+
+-- the offer to the candidate, the offer letter is rendered from the offer and emailed to the candidate
+CREATE TABLE job_offers (
+    "id" uuid NOT NULL,
+    "application_id" uuid NOT NULL REFERENCES job_applications ("id") ON DELETE CASCADE,
+    "job_title" varchar NOT NULL,
+    "base_salary" bigint NOT NULL,
+    "start_date" date NOT NULL,
+    -- the last date the candidate can accept the offer
+    "expires_on" date NOT NULL,
+    -- 'draft', 'sent', 'accepted', 'declined' or 'cancelled'
+    "status" varchar NOT NULL DEFAULT 'draft',
+    "note" varchar NOT NULL DEFAULT '',
+    "response_note" varchar NOT NULL DEFAULT '',
+    "created_by" uuid REFERENCES users ("id"),
+    "sent_at" timestamp,
+    "responded_at" timestamp,
+    "created_at" timestamp NOT NULL,
+    "updated_at" timestamp NOT NULL,
+    PRIMARY KEY ("id")
+);
+
+-- an application has at most one offer in progress or accepted
+CREATE UNIQUE INDEX job_offers_application_id_idx ON job_offers ("application_id") WHERE status IN ('draft', 'sent', 'accepted');
+
+-- ======= END OF JOB APPLICATIONS =======
@@ -0,0 +1,61 @@
+-- ======= TRAINING =======
+
+-- the catalog of the trainings and courses
+CREATE TABLE trainings (
+    "id" uuid NOT NULL,
+    "code" varchar NOT NULL,
+    "name" varchar NOT NULL,
+    "description" text NOT NULL DEFAULT '',
+    "provider" varchar NOT NULL DEFAULT '',
+    "duration_hours" int NOT NULL DEFAULT 0,
+    -- the certificate must be uploaded when the enrollment is completed
+    "certificate_required" boolean NOT NULL DEFAULT false,
+    -- how long the certificate is valid from the completion date, 0 when the certificate doesn't expire
+    "certificate_validity_months" int NOT NULL DEFAULT 0,
+    "created_at" timestamp NOT NULL,
+    "updated_at" timestamp NOT NULL,
+    "deleted_at" timestamp,
+    PRIMARY KEY ("id")
+);
+
+CREATE UNIQUE INDEX trainings_code_key ON trainings ("code") WHERE deleted_at IS NULL;
+
+-- the trainings which are mandatory for the employees of the position, the employees are enrolled by the scheduler
+-- and must complete the training within the due days of the enrollment
+CREATE TABLE training_requirements (
+    "training_id" uuid NOT NULL REFERENCES trainings ("id") ON DELETE CASCADE,
+    "position_id" uuid NOT NULL REFERENCES positions ("id"),
+    "due_days" int NOT NULL,
+    "created_at" timestamp NOT NULL,
+    PRIMARY KEY ("training_id", "position_id")
+);
+
+CREATE INDEX training_requirements_position_id_idx ON training_requirements ("position_id");
+
+CREATE TABLE training_enrollments (
+    "id" uuid NOT NULL,
+    "training_id" uuid NOT NULL REFERENCES trainings ("id"),
+    "employee_id" uuid NOT NULL REFERENCES employees ("id"),
+    -- the enrollment is required by the position of the employee
+    "mandatory" boolean NOT NULL DEFAULT false,
+    -- 'enrolled', 'completed' or 'cancelled'
+    "status" varchar NOT NULL DEFAULT 'enrolled',
+    "due_date" date,
+    "completed_on" date,
+    "note" varchar NOT NULL DEFAULT '',
+    -- the certificate is kept in the employee documents, so its expiry is reminded with the other documents
+    "certificate_document_id" uuid REFERENCES employee_documents ("id"),
+    -- null when the employee is enrolled by the scheduler
+    "enrolled_by" uuid REFERENCES users ("id"),
+    "completed_by" uuid REFERENCES users ("id"),
+    "created_at" timestamp NOT NULL,
+    "updated_at" timestamp NOT NULL,
+    PRIMARY KEY ("id")
+);
+
+-- the employee is enrolled at most once in the same training until the enrollment is completed or cancelled
+CREATE UNIQUE INDEX training_enrollments_training_id_employee_id_idx ON training_enrollments ("training_id", "employee_id") WHERE status = 'enrolled';
+CREATE INDEX training_enrollments_employee_id_idx ON training_enrollments ("employee_id");
+CREATE INDEX training_enrollments_due_date_idx ON training_enrollments ("due_date") WHERE status = 'enrolled';
+
+-- ======= END OF TRAINING =======
@@ -0,0 +1,99 @@
+-- ======= EXPENSE CLAIM =======
+
+-- the categories of the expenses which can be claimed, e.g. travel, meal or medical
+CREATE TABLE expense_categories (
+    "id" uuid NOT NULL,
+    "code" varchar NOT NULL,
+    "name" varchar NOT NULL,
+    "description" text NOT NULL DEFAULT '',
+    -- the receipt of every line item of the category must be uploaded before the claim is submitted
+    "receipt_required" boolean NOT NULL DEFAULT true,
+    "created_at" timestamp NOT NULL,
+    "updated_at" timestamp NOT NULL,
+    "deleted_at" timestamp,
+    PRIMARY KEY ("id")
+);
+
+CREATE UNIQUE INDEX expense_categories_code_key ON expense_categories ("code") WHERE deleted_at IS NULL;
+
+-- the maximum amount of the category in a claim by the salary grade of the employee, the category has no limit for
+-- the grade without a row
+CREATE TABLE expense_category_limits (
+    "category_id" uuid NOT NULL REFERENCES expense_categories ("id") ON DELETE CASCADE,
+    "salary_grade" varchar NOT NULL,
+    -- in rupiah
+    "max_amount" bigint NOT NULL,
+    "created_at" timestamp NOT NULL,
+    PRIMARY KEY ("category_id", "salary_grade")
+);
+
+CREATE TABLE expense_claims (
+    "id" uuid NOT NULL,
+    "employee_id" uuid NOT NULL REFERENCES employees ("id"),
+    "title" varchar NOT NULL,
+    "description" text NOT NULL DEFAULT '',
+    -- 'draft', 'submitted', 'manager_approved', 'approved', 'rejected', 'cancelled' or 'paid'
+    "status" varchar NOT NULL DEFAULT 'draft',
+    -- the sum of the line items in rupiah
+    "total_amount" bigint NOT NULL DEFAULT 0,
+    -- the manager of the employee when the claim is submitted, the claim is approved by the manager then by finance
+    "manager_id" uuid REFERENCES employees ("id"),
+    "submitted_at" timestamp,
+    "manager_approved_at" timestamp,
+    "finance_approved_by" uuid REFERENCES users ("id"),
+    "finance_approved_at" timestamp,
+    -- the payroll run the approved claim is reimbursed with, it is released when the draft run is deleted
+    "payroll_run_id" uuid REFERENCES payroll_runs ("id") ON DELETE SET NULL,
+    "paid_at" timestamp,
+    "created_at" timestamp NOT NULL,
+    "updated_at" timestamp NOT NULL,
+    PRIMARY KEY ("id")
+);
+
+CREATE INDEX expense_claims_employee_id_idx ON expense_claims ("employee_id");
+CREATE INDEX expense_claims_manager_id_idx ON expense_claims ("manager_id") WHERE status = 'submitted';
+CREATE INDEX expense_claims_payroll_run_id_idx ON expense_claims ("payroll_run_id");
+
+-- the line item of the claim, the amount is converted to rupiah with the exchange rate of the receipt
+CREATE TABLE expense_claim_items (
+    "claim_id" uuid NOT NULL REFERENCES expense_claims ("id") ON DELETE CASCADE,
+    "seq" int NOT NULL,
+    "category_id" uuid NOT NULL REFERENCES expense_categories ("id"),
+    "expense_date" date NOT NULL,
+    "description" varchar NOT NULL DEFAULT '',
+    -- ISO 4217 code of the currency of the receipt
+    "currency" varchar(3) NOT NULL DEFAULT 'IDR',
+    "amount" numeric(15,2) NOT NULL,
+    -- the rupiah of a unit of the currency, 1 for 'IDR'
+    "exchange_rate" numeric(15,4) NOT NULL DEFAULT 1,
+    "amount_idr" bigint NOT NULL,
+    -- the receipt kept in the storage, the MIME type is detected from the content of the file
+    "receipt_storage_key" varchar,
+    "receipt_file_name" varchar,
+    "receipt_mime_type" varchar,
+    PRIMARY KEY ("claim_id", "seq")
+);
+
+-- the status changes of the claim, the first row is the claim being created
+CREATE TABLE expense_claim_histories (
+    "id" uuid NOT NULL,
+    "claim_id" uuid NOT NULL REFERENCES expense_claims ("id") ON DELETE CASCADE,
+    "from_status" varchar,
+    "to_status" varchar NOT NULL,
+    "note" varchar NOT NULL DEFAULT '',
+    "changed_by" uuid REFERENCES users ("id"),
+    "changed_at" timestamp NOT NULL,
+    PRIMARY KEY ("id")
+);
+
+CREATE INDEX expense_claim_histories_claim_id_idx ON expense_claim_histories ("claim_id");
+
+-- ======= END OF EXPENSE CLAIM =======
+
+-- ======= PAYSLIPS =======
+
+-- the sum of the approved expense claims reimbursed with the payslip
+ALTER TABLE payslips
+    ADD COLUMN "reimbursement" bigint NOT NULL DEFAULT 0;
+
+-- ======= END OF PAYSLIPS =======
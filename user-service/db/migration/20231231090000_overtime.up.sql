@@ -0,0 +1,29 @@
+-- ======= OVERTIME_REQUESTS =======
+
+-- the overtime is requested before it is worked and approved by the manager, the paid hours are the approved hours
+-- limited by the overtime of the attendance on the date
+CREATE TABLE overtime_requests (
+    "id" uuid NOT NULL,
+    "employee_id" uuid NOT NULL REFERENCES employees ("id"),
+    "date" date NOT NULL,
+    "hours" numeric(4,2) NOT NULL,
+    "reason" varchar NOT NULL DEFAULT '',
+    -- true when the date is a working day of the employee's calendar, on a rest day the whole work time of the
+    -- attendance is overtime
+    "working_day" boolean NOT NULL,
+    "status" varchar NOT NULL DEFAULT 'pending',
+    "approver_id" uuid REFERENCES employees ("id"),
+    "decision_note" varchar NOT NULL DEFAULT '',
+    "decided_at" timestamp,
+    "cancelled_at" timestamp,
+    "created_at" timestamp NOT NULL,
+    "updated_at" timestamp NOT NULL,
+    PRIMARY KEY ("id")
+);
+
+-- an employee has one pending or approved overtime request per date
+CREATE UNIQUE INDEX overtime_requests_employee_id_date_key ON overtime_requests ("employee_id", "date") WHERE status IN ('pending', 'approved');
+CREATE INDEX overtime_requests_date_idx ON overtime_requests ("date");
+CREATE INDEX overtime_requests_approver_id_idx ON overtime_requests ("approver_id", "status");
+
+-- ======= END OF OVERTIME_REQUESTS =======
@@ -0,0 +1,109 @@
+-- ======= APPROVAL_WORKFLOWS =======
+
+-- the definition of the approval steps of an entity type, e.g. the leave or the expense claim
+CREATE TABLE approval_workflows (
+    "id" uuid NOT NULL,
+    "code" varchar NOT NULL,
+    "name" varchar NOT NULL,
+    -- 'leave', 'overtime', 'expense_claim', 'handover' or 'employment_contract'
+    "entity_type" varchar NOT NULL,
+    "description" varchar NOT NULL DEFAULT '',
+    "is_active" boolean NOT NULL DEFAULT true,
+    "created_at" timestamp NOT NULL,
+    "updated_at" timestamp NOT NULL,
+    "deleted_at" timestamp,
+    PRIMARY KEY ("id")
+);
+
+CREATE UNIQUE INDEX approval_workflows_code_key ON approval_workflows ("code") WHERE deleted_at is null;
+-- an entity type has one active workflow
+CREATE UNIQUE INDEX approval_workflows_entity_type_key ON approval_workflows ("entity_type") WHERE is_active AND deleted_at is null;
+
+-- the steps with the same order run in parallel, the next order starts when every step of the order is approved
+CREATE TABLE approval_workflow_steps (
+    "workflow_id" uuid NOT NULL REFERENCES approval_workflows ("id") ON DELETE CASCADE,
+    "seq" int NOT NULL,
+    "step_order" int NOT NULL,
+    "name" varchar NOT NULL,
+    -- 'manager': the manager of the requester, 'role': any employee of the position, 'user': the employee
+    "approver_type" varchar NOT NULL,
+    "approver_position_id" uuid REFERENCES positions ("id"),
+    "approver_employee_id" uuid REFERENCES employees ("id"),
+    -- the step is only required when the amount of the request is at least the minimum amount
+    "min_amount" bigint,
+    -- the step is escalated when it is not decided within the timeout
+    "timeout_hours" int,
+    PRIMARY KEY ("workflow_id", "seq")
+);
+
+-- ======= END OF APPROVAL_WORKFLOWS =======
+
+
+-- ======= APPROVAL_REQUESTS =======
+
+-- the approval of an entity, e.g. a leave request, through the steps of the active workflow of its type
+CREATE TABLE approval_requests (
+    "id" uuid NOT NULL,
+    "workflow_id" uuid NOT NULL REFERENCES approval_workflows ("id"),
+    "entity_type" varchar NOT NULL,
+    "entity_id" varchar NOT NULL,
+    "title" varchar NOT NULL,
+    "amount" bigint,
+    "requester_id" uuid NOT NULL REFERENCES employees ("id"),
+    "status" varchar NOT NULL DEFAULT 'pending',
+    "created_at" timestamp NOT NULL,
+    "updated_at" timestamp NOT NULL,
+    "completed_at" timestamp,
+    PRIMARY KEY ("id")
+);
+
+-- an entity has one pending approval
+CREATE UNIQUE INDEX approval_requests_entity_key ON approval_requests ("entity_type", "entity_id") WHERE status = 'pending';
+CREATE INDEX approval_requests_requester_id_idx ON approval_requests ("requester_id");
+
+-- the steps of the workflow are copied to the request when it is created, so a change of the workflow doesn't
+-- affect the pending requests. The approver is resolved when the request is created, except for a role.
+CREATE TABLE approval_tasks (
+    "id" uuid NOT NULL,
+    "request_id" uuid NOT NULL REFERENCES approval_requests ("id") ON DELETE CASCADE,
+    "seq" int NOT NULL,
+    "step_order" int NOT NULL,
+    "name" varchar NOT NULL,
+    "approver_type" varchar NOT NULL,
+    "approver_position_id" uuid REFERENCES positions ("id"),
+    "approver_id" uuid REFERENCES employees ("id"),
+    -- the approver the task was assigned to before it was delegated or escalated
+    "assigned_from_id" uuid REFERENCES employees ("id"),
+    "timeout_hours" int,
+    -- 'waiting' until the earlier steps are approved, then 'pending', 'approved', 'rejected' or 'skipped'
+    "status" varchar NOT NULL DEFAULT 'waiting',
+    "due_at" timestamp,
+    "escalated_at" timestamp,
+    "note" varchar NOT NULL DEFAULT '',
+    "decided_by" uuid REFERENCES employees ("id"),
+    "decided_at" timestamp,
+    "created_at" timestamp NOT NULL,
+    "updated_at" timestamp NOT NULL,
+    PRIMARY KEY ("id"),
+    UNIQUE ("request_id", "seq")
+);
+
+CREATE INDEX approval_tasks_approver_id_idx ON approval_tasks ("approver_id", "status");
+CREATE INDEX approval_tasks_due_at_idx ON approval_tasks ("due_at") WHERE status = 'pending' AND escalated_at is null;
+
+-- the full history of the actions on the request, the actor is empty for the actions of the system
+CREATE TABLE approval_actions (
+    "id" uuid NOT NULL,
+    "request_id" uuid NOT NULL REFERENCES approval_requests ("id") ON DELETE CASCADE,
+    "task_id" uuid REFERENCES approval_tasks ("id") ON DELETE CASCADE,
+    -- 'submitted', 'assigned', 'delegated', 'escalated', 'approved', 'rejected', 'skipped', 'cancelled' or 'completed'
+    "action" varchar NOT NULL,
+    "actor_id" uuid REFERENCES employees ("id"),
+    "note" varchar NOT NULL DEFAULT '',
+    "created_at" timestamp NOT NULL,
+    PRIMARY KEY ("id")
+);
+
+CREATE INDEX approval_actions_request_id_idx ON approval_actions ("request_id", "created_at");
+
+-- ======= END OF APPROVAL_REQUESTS =======
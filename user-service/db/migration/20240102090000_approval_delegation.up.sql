@@ -0,0 +1,31 @@
+-- ======= APPROVAL_DELEGATIONS =======
+
+-- the window in which the approvals of the delegator are decided by the delegate, e.g. while the delegator is on leave
+CREATE TABLE approval_delegations (
+    "id" uuid NOT NULL,
+    "delegator_id" uuid NOT NULL REFERENCES employees ("id"),
+    "delegate_id" uuid NOT NULL REFERENCES employees ("id"),
+    "start_date" date NOT NULL,
+    "end_date" date NOT NULL,
+    -- the entity types of the approvals which are delegated, empty for every type
+    "entity_types" varchar[] NOT NULL DEFAULT '{}',
+    "reason" varchar NOT NULL DEFAULT '',
+    "cancelled_at" timestamp,
+    "created_at" timestamp NOT NULL,
+    "updated_at" timestamp NOT NULL,
+    PRIMARY KEY ("id"),
+    CHECK ("delegator_id" <> "delegate_id"),
+    CHECK ("end_date" >= "start_date")
+);
+
+CREATE INDEX approval_delegations_delegator_id_idx ON approval_delegations ("delegator_id", "end_date") WHERE cancelled_at is null;
+CREATE INDEX approval_delegations_delegate_id_idx ON approval_delegations ("delegate_id", "end_date") WHERE cancelled_at is null;
+
+-- the approver the task is decided on behalf of, when it is delegated
+ALTER TABLE approval_tasks
+    ADD COLUMN "on_behalf_of_id" uuid REFERENCES employees ("id");
+
+ALTER TABLE approval_actions
+    ADD COLUMN "on_behalf_of_id" uuid REFERENCES employees ("id");
+
+-- ======= END OF APPROVAL_DELEGATIONS =======
@@ -20,6 +20,10 @@ func ErrNotFound(message string) *fiber.Error {
 	return fiber.NewError(fiber.StatusNotFound, message)
 }
 
+func ErrConflict(message string) *fiber.Error {
+	return fiber.NewError(fiber.StatusConflict, message)
+}
+
 func ErrUnprocessableEntity(message string) *fiber.Error {
 	return fiber.NewError(fiber.StatusUnprocessableEntity, message)
 }
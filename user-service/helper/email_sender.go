@@ -219,28 +219,8 @@ func EmailSender(file bytes.Buffer) error {
 	
 	</html>`
 
-	server := mail.NewSMTPClient()
-
-	server.Host = host
-	server.Port = port
-	server.Username = user
-	server.Password = password
-	server.Encryption = mail.EncryptionSTARTTLS
-
-	server.KeepAlive = true
-
-	// Timeout for connect to SMTP Server
-	server.ConnectTimeout = time.Duration(connectTimeout) * time.Second
-
-	// Timeout for send the data and wait respond
-	server.SendTimeout = time.Duration(sendTimeout) * time.Second
-
-	// Set TLSConfig to provide custom TLS configuration. For example,
-	// to skip TLS verification (useful for testing):
-	server.TLSConfig = &tls.Config{InsecureSkipVerify: true}
-
 	// SMTP client
-	smtpClient, err := server.Connect()
+	smtpClient, err := connectSMTP(user, password)
 	if err != nil {
 		logger.Errorw("Failed to connect to the smtp client", "error", err)
 	}
@@ -271,3 +251,64 @@ func EmailSender(file bytes.Buffer) error {
 		return nil
 	}
 }
+
+// SendEmail sends the html email with the attachment (if any) to the receivers.
+func SendEmail(to []string, subject, body, attachmentName string, attachment []byte) error {
+	if len(to) == 0 {
+		return errors.New("email receiver has not been set")
+	}
+
+	smtpClient, err := connectSMTP(emails, password)
+	if err != nil {
+		logger.Errorw("Failed to connect to the smtp client", "error", err)
+		return err
+	}
+
+	message := mail.NewMSG()
+	message.SetFrom(sender).
+		AddTo(to...).
+		SetSubject(subject)
+
+	message.SetBody(mail.TextHTML, body)
+
+	if attachment != nil {
+		message.Attach(&mail.File{Data: attachment, Name: attachmentName})
+	}
+
+	if message.Error != nil {
+		logger.Errorw("Failed to create email", "error", message.Error)
+		return message.Error
+	}
+
+	if err := message.Send(smtpClient); err != nil {
+		logger.Errorw("Failed to send email", "error", err)
+		return err
+	}
+
+	return nil
+}
+
+// connect to the SMTP server with the credential
+func connectSMTP(user, password string) (*mail.SMTPClient, error) {
+	server := mail.NewSMTPClient()
+
+	server.Host = host
+	server.Port = port
+	server.Username = user
+	server.Password = password
+	server.Encryption = mail.EncryptionSTARTTLS
+
+	server.KeepAlive = true
+
+	// Timeout for connect to SMTP Server
+	server.ConnectTimeout = time.Duration(connectTimeout) * time.Second
+
+	// Timeout for send the data and wait respond
+	server.SendTimeout = time.Duration(sendTimeout) * time.Second
+
+	// Set TLSConfig to provide custom TLS configuration. For example,
+	// to skip TLS verification (useful for testing):
+	server.TLSConfig = &tls.Config{InsecureSkipVerify: true}
+
+	return server.Connect()
+}
@@ -0,0 +1,25 @@
+package helper
+
+import (
+	"strconv"
+	"strings"
+)
+
+// FormatRupiah formats the amount with the Indonesian thousand separator, e.g. 'Rp 1.250.000' or 'Rp -15.000'.
+func FormatRupiah(amount int64) string {
+	sign := ""
+	if amount < 0 {
+		sign = "-"
+		amount = -amount
+	}
+
+	digits := strconv.FormatInt(amount, 10)
+	var groups []string
+	for len(digits) > 3 {
+		groups = append([]string{digits[len(digits)-3:]}, groups...)
+		digits = digits[:len(digits)-3]
+	}
+	groups = append([]string{digits}, groups...)
+
+	return "Rp " + sign + strings.Join(groups, ".")
+}
@@ -0,0 +1,216 @@
+package helper
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// the layout of the date-time in the calendar, it is always written in UTC
+const iCalendarTimeLayout = "20060102T150405Z"
+
+// the layouts of the all-day date and the floating (local) date-time in the calendar
+const (
+	iCalendarDateLayout      = "20060102"
+	iCalendarLocalTimeLayout = "20060102T150405"
+)
+
+// ICalendarEvent is an event of the iCalendar (RFC 5545) feed. The end of the event is exclusive,
+// the all-day event starts and ends at midnight UTC of the dates.
+type ICalendarEvent struct {
+	UID         string
+	Summary     string
+	Description string
+	Start       time.Time
+	End         time.Time
+	AllDay      bool
+}
+
+// RenderICalendar renders the events as an iCalendar (.ics) document with the calendar name.
+func RenderICalendar(name string, events []ICalendarEvent) []byte {
+	var b strings.Builder
+	writeLine := func(line string) {
+		b.WriteString(foldICalendarLine(line))
+		b.WriteString("\r\n")
+	}
+
+	stamp := time.Now().UTC().Format(iCalendarTimeLayout)
+
+	writeLine("BEGIN:VCALENDAR")
+	writeLine("VERSION:2.0")
+	writeLine("PRODID:-//hr-microservice//roster//EN")
+	writeLine("CALSCALE:GREGORIAN")
+	writeLine("METHOD:PUBLISH")
+	writeLine("X-WR-CALNAME:" + escapeICalendarText(name))
+	for _, event := range events {
+		writeLine("BEGIN:VEVENT")
+		writeLine("UID:" + event.UID)
+		writeLine("DTSTAMP:" + stamp)
+		if event.AllDay {
+			writeLine("DTSTART;VALUE=DATE:" + event.Start.Format(iCalendarDateLayout))
+			writeLine("DTEND;VALUE=DATE:" + event.End.Format(iCalendarDateLayout))
+		} else {
+			writeLine("DTSTART:" + event.Start.UTC().Format(iCalendarTimeLayout))
+			writeLine("DTEND:" + event.End.UTC().Format(iCalendarTimeLayout))
+		}
+		writeLine("SUMMARY:" + escapeICalendarText(event.Summary))
+		if event.Description != "" {
+			writeLine("DESCRIPTION:" + escapeICalendarText(event.Description))
+		}
+		writeLine("END:VEVENT")
+	}
+	writeLine("END:VCALENDAR")
+
+	return []byte(b.String())
+}
+
+// ParseICalendar parses the events of the iCalendar (.ics) document. The floating date-time (without
+// a time zone) is parsed in WIB, the event without an end lasts a day (all-day) or has no duration.
+func ParseICalendar(data []byte) ([]ICalendarEvent, error) {
+	events := []ICalendarEvent{}
+	var event *ICalendarEvent
+	for _, line := range unfoldICalendarLines(data) {
+		name, params, value, ok := splitICalendarLine(line)
+		if !ok {
+			continue
+		}
+
+		switch {
+		case name == "BEGIN" && value == "VEVENT":
+			event = &ICalendarEvent{}
+		case name == "END" && value == "VEVENT":
+			if event == nil {
+				return nil, fmt.Errorf("event %d: END:VEVENT without BEGIN:VEVENT", len(events)+1)
+			}
+			if event.Start.IsZero() {
+				return nil, fmt.Errorf("event %d: the event has no DTSTART", len(events)+1)
+			}
+			if event.End.IsZero() {
+				event.End = event.Start
+				if event.AllDay {
+					event.End = event.Start.AddDate(0, 0, 1)
+				}
+			}
+			events = append(events, *event)
+			event = nil
+		case event == nil:
+			// the properties of the calendar and the other components are ignored
+		case name == "UID":
+			event.UID = value
+		case name == "SUMMARY":
+			event.Summary = unescapeICalendarText(value)
+		case name == "DESCRIPTION":
+			event.Description = unescapeICalendarText(value)
+		case name == "DTSTART", name == "DTEND":
+			at, allDay, err := parseICalendarTime(params, value)
+			if err != nil {
+				return nil, fmt.Errorf("event %d: %s", len(events)+1, err.Error())
+			}
+			if name == "DTSTART" {
+				event.Start, event.AllDay = at, allDay
+			} else {
+				event.End = at
+			}
+		}
+	}
+
+	return events, nil
+}
+
+// unfold the continuation lines (starting with a space or a tab) into their previous line
+func unfoldICalendarLines(data []byte) []string {
+	lines := []string{}
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		line := strings.TrimRight(scanner.Text(), "\r")
+		if (strings.HasPrefix(line, " ") || strings.HasPrefix(line, "\t")) && len(lines) > 0 {
+			lines[len(lines)-1] += line[1:]
+			continue
+		}
+		lines = append(lines, line)
+	}
+	return lines
+}
+
+// split the content line into its upper-cased name, its parameters and its value
+func splitICalendarLine(line string) (name string, params map[string]string, value string, ok bool) {
+	colon := strings.Index(line, ":")
+	if colon < 0 {
+		return "", nil, "", false
+	}
+
+	parts := strings.Split(line[:colon], ";")
+	params = map[string]string{}
+	for _, param := range parts[1:] {
+		if key, val, found := strings.Cut(param, "="); found {
+			params[strings.ToUpper(key)] = strings.Trim(val, `"`)
+		}
+	}
+
+	return strings.ToUpper(parts[0]), params, line[colon+1:], true
+}
+
+// parse the date or date-time value, the TZID parameter is used when it's a known time zone
+func parseICalendarTime(params map[string]string, value string) (time.Time, bool, error) {
+	if params["VALUE"] == "DATE" || len(value) == len(iCalendarDateLayout) {
+		date, err := time.Parse(iCalendarDateLayout, value)
+		if err != nil {
+			return time.Time{}, false, fmt.Errorf("invalid date %q", value)
+		}
+		return date, true, nil
+	}
+
+	if strings.HasSuffix(value, "Z") {
+		at, err := time.Parse(iCalendarTimeLayout, value)
+		if err != nil {
+			return time.Time{}, false, fmt.Errorf("invalid date-time %q", value)
+		}
+		return at, false, nil
+	}
+
+	location := WIB
+	if tzid, ok := params["TZID"]; ok {
+		if loaded, err := time.LoadLocation(tzid); err == nil {
+			location = loaded
+		}
+	}
+	at, err := time.ParseInLocation(iCalendarLocalTimeLayout, value, location)
+	if err != nil {
+		return time.Time{}, false, fmt.Errorf("invalid date-time %q", value)
+	}
+	return at, false, nil
+}
+
+// unescape the special characters of the text value
+func unescapeICalendarText(text string) string {
+	return strings.NewReplacer(`\\`, `\`, `\;`, ";", `\,`, ",", `\n`, "\n", `\N`, "\n").Replace(text)
+}
+
+// escape the special characters of the text value
+func escapeICalendarText(text string) string {
+	return strings.NewReplacer(`\`, `\\`, ";", `\;`, ",", `\,`, "\r\n", `\n`, "\n", `\n`).Replace(text)
+}
+
+// fold the line longer than 75 octets, the continuation lines start with a space
+func foldICalendarLine(line string) string {
+	if len(line) <= 75 {
+		return line
+	}
+
+	var b strings.Builder
+	width := 0
+	for _, r := range line {
+		size := len(string(r))
+		if width+size > 75 {
+			b.WriteString("\r\n ")
+			width = 1
+		}
+		b.WriteRune(r)
+		width += size
+	}
+
+	return b.String()
+}
@@ -0,0 +1,250 @@
+package helper
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// GotenbergConfig is the configuration of the Gotenberg driver, the timeout is applied to every attempt.
+type GotenbergConfig struct {
+	Endpoint string
+	Timeout  time.Duration
+	Retries  int
+	Backoff  time.Duration
+}
+
+// NewPDFRenderer returns the renderer of the driver. The 'gotenberg' driver is used by default and requires the
+// Gotenberg endpoint, the 'stub' driver must be set explicitly to run the apps locally without Gotenberg.
+func NewPDFRenderer(driver string, config GotenbergConfig) (PDFRenderer, error) {
+	switch driver {
+	case "", "gotenberg":
+		if config.Endpoint == "" {
+			return nil, errors.New("gotenberg endpoint has not been set, set PDF_RENDERER_DRIVER=stub to render without Gotenberg")
+		}
+		return NewGotenbergRenderer(config), nil
+	case "stub":
+		logger.Warnw("The pdfs are rendered with the stub driver, they are not the real documents")
+		return NewStubPDFRenderer(), nil
+	default:
+		return nil, fmt.Errorf("unknown pdf renderer driver %q", driver)
+	}
+}
+
+// gotenbergRenderer converts the html to pdf with the Chromium route of the Gotenberg service.
+type gotenbergRenderer struct {
+	config GotenbergConfig
+	client *http.Client
+}
+
+func NewGotenbergRenderer(config GotenbergConfig) PDFRenderer {
+	if config.Timeout <= 0 {
+		config.Timeout = 30 * time.Second
+	}
+	if config.Retries < 0 {
+		config.Retries = 0
+	}
+	if config.Backoff <= 0 {
+		config.Backoff = time.Second
+	}
+
+	return &gotenbergRenderer{
+		config: config,
+		client: &http.Client{Timeout: config.Timeout},
+	}
+}
+
+// gotenbergError is the error response of the Gotenberg service, only the server errors are retried.
+type gotenbergError struct {
+	status string
+	code   int
+	body   string
+}
+
+func (e *gotenbergError) Error() string {
+	return fmt.Sprintf("gotenberg service return status: %s: %s", e.status, e.body)
+}
+
+func (r *gotenbergRenderer) Render(c context.Context, document PDFDocument, options PDFOptions) ([]byte, error) {
+	formData, contentType, err := gotenbergForm(document, options)
+	if err != nil {
+		return nil, err
+	}
+
+	var lastErr error
+	for attempt := 0; attempt <= r.config.Retries; attempt++ {
+		// wait before retrying, the wait is longer on every attempt
+		if attempt > 0 {
+			select {
+			case <-c.Done():
+				return nil, c.Err()
+			case <-time.After(time.Duration(attempt) * r.config.Backoff):
+			}
+		}
+
+		pdf, err := r.send(c, formData, contentType)
+		if err == nil {
+			return pdf, nil
+		}
+		lastErr = err
+
+		// the request is not retried when it is canceled or rejected by Gotenberg
+		var gotenbergErr *gotenbergError
+		if c.Err() != nil || (errors.As(err, &gotenbergErr) && gotenbergErr.code < http.StatusInternalServerError) {
+			break
+		}
+		logger.Warnw("Failed to render pdf", "attempt", attempt+1, "error", err)
+	}
+
+	return nil, lastErr
+}
+
+// send the form to Gotenberg and return the rendered pdf
+func (r *gotenbergRenderer) send(c context.Context, formData []byte, contentType string) ([]byte, error) {
+	req, err := http.NewRequestWithContext(c, http.MethodPost, r.config.Endpoint, bytes.NewReader(formData))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", contentType)
+
+	resp, err := r.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		// only the beginning of the body is kept, it is the error message of Gotenberg
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return nil, &gotenbergError{status: resp.Status, code: resp.StatusCode, body: string(body)}
+	}
+
+	return io.ReadAll(resp.Body)
+}
+
+// build the multipart form of the Chromium route, the sizes are in inches
+func gotenbergForm(document PDFDocument, options PDFOptions) ([]byte, string, error) {
+	var formData bytes.Buffer
+	w := multipart.NewWriter(&formData)
+
+	files := map[string][]byte{"index.html": document.HTML}
+	if document.Header != nil {
+		files["header.html"] = document.Header
+	}
+	if document.Footer != nil {
+		files["footer.html"] = document.Footer
+	}
+	for name, content := range files {
+		fw, err := w.CreateFormFile("files", name)
+		if err != nil {
+			return nil, "", err
+		}
+		if _, err := fw.Write(content); err != nil {
+			return nil, "", err
+		}
+	}
+
+	size := paperSize(options.PaperSize)
+	fields := map[string]string{
+		"paperWidth":  formatNumber(size[0]),
+		"paperHeight": formatNumber(size[1]),
+	}
+	if options.Landscape {
+		fields["landscape"] = "true"
+	}
+	if options.Margin != nil {
+		fields["marginTop"] = formatNumber(options.Margin.Top)
+		fields["marginRight"] = formatNumber(options.Margin.Right)
+		fields["marginBottom"] = formatNumber(options.Margin.Bottom)
+		fields["marginLeft"] = formatNumber(options.Margin.Left)
+	}
+	if options.Password != "" {
+		fields["userPassword"] = options.Password
+	}
+	for name, value := range fields {
+		if err := w.WriteField(name, value); err != nil {
+			return nil, "", err
+		}
+	}
+
+	if err := w.Close(); err != nil {
+		return nil, "", err
+	}
+
+	return formData.Bytes(), w.FormDataContentType(), nil
+}
+
+func formatNumber(value float64) string {
+	return strconv.FormatFloat(value, 'f', -1, 64)
+}
+
+// StubPDFRenderer returns a blank one page pdf instead of converting the html, it keeps the last rendered
+// document so the tests can check the rendered html.
+type StubPDFRenderer struct {
+	mu       sync.Mutex
+	document PDFDocument
+	options  PDFOptions
+}
+
+func NewStubPDFRenderer() *StubPDFRenderer {
+	return &StubPDFRenderer{}
+}
+
+func (r *StubPDFRenderer) Render(c context.Context, document PDFDocument, options PDFOptions) ([]byte, error) {
+	if err := c.Err(); err != nil {
+		return nil, err
+	}
+
+	r.mu.Lock()
+	r.document = document
+	r.options = options
+	r.mu.Unlock()
+
+	size := paperSize(options.PaperSize)
+	if options.Landscape {
+		size[0], size[1] = size[1], size[0]
+	}
+
+	return blankPDF(size[0]*72, size[1]*72), nil
+}
+
+// Last returns the last rendered document and its options.
+func (r *StubPDFRenderer) Last() (PDFDocument, PDFOptions) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return r.document, r.options
+}
+
+// build a valid pdf with one blank page, the size is in points
+func blankPDF(width, height float64) []byte {
+	objects := []string{
+		"<< /Type /Catalog /Pages 2 0 R >>",
+		"<< /Type /Pages /Kids [3 0 R] /Count 1 >>",
+		fmt.Sprintf("<< /Type /Page /Parent 2 0 R /MediaBox [0 0 %s %s] >>", formatNumber(width), formatNumber(height)),
+	}
+
+	var pdf bytes.Buffer
+	pdf.WriteString("%PDF-1.4\n")
+	offsets := make([]int, len(objects))
+	for i, object := range objects {
+		offsets[i] = pdf.Len()
+		fmt.Fprintf(&pdf, "%d 0 obj\n%s\nendobj\n", i+1, object)
+	}
+
+	xref := pdf.Len()
+	fmt.Fprintf(&pdf, "xref\n0 %d\n0000000000 65535 f \n", len(objects)+1)
+	for _, offset := range offsets {
+		fmt.Fprintf(&pdf, "%010d 00000 n \n", offset)
+	}
+	fmt.Fprintf(&pdf, "trailer\n<< /Size %d /Root 1 0 R >>\nstartxref\n%d\n%%%%EOF\n", len(objects)+1, xref)
+
+	return pdf.Bytes()
+}
@@ -7,13 +7,10 @@ import (
 	"html/template"
 )
 
+// RenderHTML renders the template in the 'templates' folder with the functions of the registry.
 func RenderHTML(templateFS embed.FS, templateName string, data interface{}) (bytes.Buffer, error) {
-	// Define the functions
-	funcMap := template.FuncMap{
-		"add": func(x int) int { return x + 1 },
-	}
 	// render html from template
-	parsedTemplate, err := template.New(templateName).Funcs(funcMap).ParseFS(templateFS, fmt.Sprintf("templates/%s", templateName))
+	parsedTemplate, err := template.New(templateName).Funcs(TemplateFuncs()).ParseFS(templateFS, fmt.Sprintf("templates/%s", templateName))
 	if err != nil {
 		return bytes.Buffer{}, err
 	}
@@ -2,120 +2,100 @@ package helper
 
 import (
 	"bytes"
+	"context"
 	"embed"
 	"fmt"
-	"html/template"
-	"io"
-	"mime/multipart"
-	"net/http"
-	"strconv"
-	"strings"
-	"time"
 )
 
+// PDFMargin is the margin of the pdf page in inches.
+type PDFMargin struct {
+	Top    float64
+	Right  float64
+	Bottom float64
+	Left   float64
+}
+
+// PDFOptions are the page options of the rendered pdf, the renderer uses its own default when an option is empty.
+type PDFOptions struct {
+	Landscape bool
+	// PaperSize is the name of the paper, e.g. 'A4' or 'Letter'. The default value is 'A4'.
+	PaperSize string
+	Margin    *PDFMargin
+	// HeaderTemplate and FooterTemplate are the templates printed on every page, they are rendered with the same
+	// data as the main template.
+	HeaderTemplate string
+	FooterTemplate string
+	// Password protects the pdf, the pdf can only be opened with the password when it is not empty.
+	Password string
+}
+
+// PDFDocument is the rendered html of the pdf, the header and the footer are optional.
+type PDFDocument struct {
+	HTML   []byte
+	Header []byte
+	Footer []byte
+}
+
+// PDFRenderer converts the html to pdf. The driver is chosen with the 'PDF_RENDERER_DRIVER' env.
+type PDFRenderer interface {
+	Render(c context.Context, document PDFDocument, options PDFOptions) ([]byte, error)
+}
+
 // This function is used for rendering a pdf from a template.
 // The template is located in 'templates' folder, that is embedded in the executable of this apps.
 // The flow of this function is:
 //
-// 1. Render the html from the template.
-//
-// 2. Create a buffer to add the html to the request form-data.
-//
-// 3. Create the http request to gotenberg service.
+// 1. Render the html of the template, the header and the footer with 'RenderHTML'.
 //
-// 4. Send the request to gotenberg service.
+// 2. Convert the html to pdf with the renderer.
 //
-// 5. Copy the rendered pdf from response Body to 'pdfResult' variable.
-//
-// 6. Return the 'pdfResult' variable.
-func RenderPDF(templateFS embed.FS, templateName string, landscape bool, data interface{}) (bytes.Buffer, error) {
-	// Define the functions
-	funcMap := template.FuncMap{
-		"add": func(x int) int { return x + 1 },
-		"add_values": func(x int, yString string) int {
-			y, _ := strconv.Atoi(yString)
-			return x + y
-		},
-		"timestamp_to_date": func(x time.Time) string { return x.Format("2006-01-02") },
-		"variance_background_color": func(variance int) template.HTMLAttr {
-			var style string
-			if variance < 0 {
-				style = "style='background-color: #C6EFCD; color: #549E57;'"
-			} else {
-				style = "style='background-color: #FFC7CD; color: #CB5F65;'"
-			}
-			return template.HTMLAttr(style)
-		},
-		"forecasting_get_model_data": func(data string) string {
-			return strings.Split(data, ",")[0]
-		},
-		"forecasting_get_component_description_data": func(data string) string {
-			return strings.Split(data, ",")[1]
-		},
-	}
-	// render html from template
-	parsedTemplate, err := template.New(templateName).Funcs(funcMap).ParseFS(templateFS, fmt.Sprintf("templates/%s", templateName))
-	if err != nil {
-		return bytes.Buffer{}, err
-	}
+// 3. Return the rendered pdf.
+func RenderPDF(c context.Context, renderer PDFRenderer, templateFS embed.FS, templateName string, options PDFOptions, data interface{}) (bytes.Buffer, error) {
+	var document PDFDocument
 
-	var html bytes.Buffer
-	err = parsedTemplate.Execute(&html, data)
+	html, err := RenderHTML(templateFS, templateName, data)
 	if err != nil {
 		return bytes.Buffer{}, err
 	}
+	document.HTML = html.Bytes()
 
-	// Create a buffer to add the html to the request form-data
-	var formData bytes.Buffer
-	w := multipart.NewWriter(&formData)
-
-	fw, err := w.CreateFormFile("index.html", "index.html")
-	if err != nil {
-		return bytes.Buffer{}, err
-	}
-	if _, err = io.Copy(fw, &html); err != nil {
-		return bytes.Buffer{}, err
-	}
-
-	// set landscape or portrait
-	if landscape {
-		fieldWriter, err := w.CreateFormField("landscape")
+	if options.HeaderTemplate != "" {
+		header, err := RenderHTML(templateFS, options.HeaderTemplate, data)
 		if err != nil {
 			return bytes.Buffer{}, err
 		}
-		_, err = fieldWriter.Write([]byte("true"))
+		document.Header = header.Bytes()
+	}
+
+	if options.FooterTemplate != "" {
+		footer, err := RenderHTML(templateFS, options.FooterTemplate, data)
 		if err != nil {
 			return bytes.Buffer{}, err
 		}
+		document.Footer = footer.Bytes()
 	}
 
-	if err = w.Close(); err != nil {
-		return bytes.Buffer{}, err
-	}
-
-	// Create the http request to gotenberg service
-	req, err := http.NewRequest("POST", gotenbergEndpoint, &formData)
+	pdf, err := renderer.Render(c, document, options)
 	if err != nil {
-		return bytes.Buffer{}, err
+		return bytes.Buffer{}, fmt.Errorf("render %s: %w", templateName, err)
 	}
-	req.Header.Set("Content-Type", w.FormDataContentType())
 
-	// Send the request to gotenberg service
-	client := &http.Client{}
-	resp, err := client.Do(req)
-	if err != nil {
-		return bytes.Buffer{}, err
-	}
-	if resp.StatusCode != 200 {
-		return bytes.Buffer{}, fmt.Errorf("gotenberg service return status: %s \n %s", resp.Status, resp.Body)
-	}
+	return *bytes.NewBuffer(pdf), nil
+}
 
-	// copy the rendered pdf from response Body to 'pdfResult' variable
-	var pdfResult bytes.Buffer
-	if _, err := io.Copy(&pdfResult, resp.Body); err != nil {
-		return bytes.Buffer{}, err
-	}
-	defer resp.Body.Close()
+// the paper sizes in inches (width x height) of the portrait page
+var paperSizes = map[string][2]float64{
+	"A3":     {11.7, 16.54},
+	"A4":     {8.27, 11.7},
+	"A5":     {5.83, 8.27},
+	"Letter": {8.5, 11},
+	"Legal":  {8.5, 14},
+}
 
-	return pdfResult, nil
+// the paper size of the options, it is 'A4' when the size is empty or unknown
+func paperSize(name string) [2]float64 {
+	if size, ok := paperSizes[name]; ok {
+		return size
+	}
+	return paperSizes["A4"]
 }
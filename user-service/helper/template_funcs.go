@@ -0,0 +1,60 @@
+package helper
+
+import (
+	"html/template"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// templateFuncs is the registry of the functions that can be called in every template rendered by 'RenderHTML'
+// and 'RenderPDF'.
+var (
+	templateFuncsMu sync.RWMutex
+	templateFuncs   = template.FuncMap{
+		"add": func(x int) int { return x + 1 },
+		"add_values": func(x int, yString string) int {
+			y, _ := strconv.Atoi(yString)
+			return x + y
+		},
+		"timestamp_to_date": func(x time.Time) string { return x.Format(DateLayout) },
+		"variance_background_color": func(variance int) template.HTMLAttr {
+			var style string
+			if variance < 0 {
+				style = "style='background-color: #C6EFCD; color: #549E57;'"
+			} else {
+				style = "style='background-color: #FFC7CD; color: #CB5F65;'"
+			}
+			return template.HTMLAttr(style)
+		},
+		"rupiah": FormatRupiah,
+		"forecasting_get_model_data": func(data string) string {
+			return strings.Split(data, ",")[0]
+		},
+		"forecasting_get_component_description_data": func(data string) string {
+			return strings.Split(data, ",")[1]
+		},
+	}
+)
+
+// RegisterTemplateFunc adds the function to the registry, a function with the same name is replaced.
+// It should be called before the templates are rendered, e.g. in an 'init' function.
+func RegisterTemplateFunc(name string, fn interface{}) {
+	templateFuncsMu.Lock()
+	defer templateFuncsMu.Unlock()
+
+	templateFuncs[name] = fn
+}
+
+// TemplateFuncs returns a copy of the registered functions.
+func TemplateFuncs() template.FuncMap {
+	templateFuncsMu.RLock()
+	defer templateFuncsMu.RUnlock()
+
+	funcMap := make(template.FuncMap, len(templateFuncs))
+	for name, fn := range templateFuncs {
+		funcMap[name] = fn
+	}
+	return funcMap
+}
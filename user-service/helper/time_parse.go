@@ -23,3 +23,41 @@ func ParseTimeToShortIndonesian(t time.Time) string {
 	month := monthNames[t.Month()-1]
 	return fmt.Sprintf("%d-%s-%d", t.Day(), month, t.Year())
 }
+
+// ParsePeriodToIndonesian returns the month and year of a period, e.g. 'Januari 2024'.
+func ParsePeriodToIndonesian(year, month int) string {
+	monthNames := strings.Split("Januari Februari Maret April Mei Juni Juli Agustus September Oktober November Desember", " ")
+
+	return fmt.Sprintf("%s %d", monthNames[month-1], year)
+}
+
+// DateLayout is the layout used for every date-only field (birth date, hire date, etc.)
+// that is sent or received through the API.
+const DateLayout = "2006-01-02"
+
+// ParseDate parses a date-only string with the DateLayout. An empty string returns a zero time.
+func ParseDate(value string) (time.Time, error) {
+	if value == "" {
+		return time.Time{}, nil
+	}
+	return time.Parse(DateLayout, value)
+}
+
+// ParseOptionalDate parses an optional date-only string with the DateLayout. An empty or invalid string returns nil.
+func ParseOptionalDate(value string) *time.Time {
+	date, err := time.Parse(DateLayout, value)
+	if err != nil {
+		return nil
+	}
+	return &date
+}
+
+// WIB is the Western Indonesia Time zone (UTC+7), the working time zone of the company.
+var WIB = time.FixedZone("WIB", 7*60*60)
+
+// Today returns the current date in WIB as a date-only value (midnight UTC),
+// the same representation as the 'date' columns scanned from the database.
+func Today() time.Time {
+	y, m, d := time.Now().In(WIB).Date()
+	return time.Date(y, m, d, 0, 0, 0, 0, time.UTC)
+}
@@ -0,0 +1,35 @@
+package helper
+
+import (
+	"io"
+	"mime/multipart"
+	"os"
+	"path/filepath"
+)
+
+// SaveMultipartFile saves the uploaded file to the directory with the file name and returns the path of the saved file.
+// The directory is created when it doesn't exist.
+func SaveMultipartFile(file *multipart.FileHeader, dir, fileName string) (string, error) {
+	src, err := file.Open()
+	if err != nil {
+		return "", err
+	}
+	defer src.Close()
+
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return "", err
+	}
+
+	path := filepath.Join(dir, fileName)
+	dst, err := os.Create(path)
+	if err != nil {
+		return "", err
+	}
+	defer dst.Close()
+
+	if _, err := io.Copy(dst, src); err != nil {
+		return "", err
+	}
+
+	return path, nil
+}
@@ -21,7 +21,6 @@ var (
 	RoleMechanic       = utils.GetEnv("ROLE_MECHANIC_ID")
 	DefaultLimit, _    = strconv.Atoi(utils.GetEnv("DEFAULT_LIMIT"))
 	SafetyCheckID      = utils.GetEnv("SAFETY_CHECK_ID")
-	gotenbergEndpoint  = utils.GetEnv("GOTENBERG_ENDPOINT")
 
 	// reset-pass
 	UrlReset = utils.GetEnv("URL_RESET_PASSWORD_LOCAL")
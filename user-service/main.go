@@ -1,6 +1,7 @@
 package main
 
 import (
+	"context"
 	"embed"
 	"encoding/json"
 	"fmt"
@@ -22,16 +23,20 @@ import (
 	"github.com/iqbaludinm/hr-microservice/user-service/config"
 	"github.com/iqbaludinm/hr-microservice/user-service/controller"
 	"github.com/iqbaludinm/hr-microservice/user-service/exception"
+	"github.com/iqbaludinm/hr-microservice/user-service/helper"
+	"github.com/iqbaludinm/hr-microservice/user-service/middleware"
+	"github.com/iqbaludinm/hr-microservice/user-service/model/domain"
 	"github.com/iqbaludinm/hr-microservice/user-service/repository"
 	"github.com/iqbaludinm/hr-microservice/user-service/repository/query"
 	"github.com/iqbaludinm/hr-microservice/user-service/service"
 	"github.com/iqbaludinm/hr-microservice/user-service/service/consumers"
 	"github.com/iqbaludinm/hr-microservice/user-service/service/producers"
+	"github.com/iqbaludinm/hr-microservice/user-service/service/schedulers"
 	"github.com/iqbaludinm/hr-microservice/user-service/utils"
 	"go.uber.org/zap"
 )
 
-// go:embed templates
+//go:embed templates
 var templateFS embed.FS
 
 var logger = utils.NewLogger()
@@ -44,6 +49,8 @@ func controllers() {
 		DisableStartupMessage: true,
 		JSONEncoder:           json.Marshal,
 		JSONDecoder:           json.Unmarshal,
+		// the uploaded employee documents are larger than the default limit of 4 MB
+		BodyLimit: (config.DocumentMaxSizeMB + 1) * 1024 * 1024,
 	})
 
 	app.Use(recover.New())
@@ -74,12 +81,179 @@ func controllers() {
 
 	userQuery := query.NewUser()
 	userRepository := repository.NewUser(store, userQuery)
-	userService := service.NewUserService(userRepository, kafkaProducerService, logger.Sugar())
+	userService := service.NewUserService(userRepository, validate, kafkaProducerService, logger.Sugar())
 	userController := controller.NewUserController(validate, kafkaProducerService, userService)
 
+	// reject the token of the deactivated users on the authenticated routes
+	middleware.RevokeDeletedUserSession(userRepository)
+
+	departmentQuery := query.NewDepartment()
+	departmentRepository := repository.NewDepartment(store, departmentQuery)
+	positionQuery := query.NewPosition()
+	positionRepository := repository.NewPosition(store, positionQuery)
+
+	employeeQuery := query.NewEmployee()
+	employmentHistoryQuery := query.NewEmploymentHistory()
+	employeeRepository := repository.NewEmployee(store, employeeQuery, employmentHistoryQuery)
+	employmentHistoryRepository := repository.NewEmploymentHistory(store, employmentHistoryQuery, employeeQuery)
+
+	// only let the employees of the HR departments through the HR routes
+	middleware.RestrictToHR(employeeRepository, departmentRepository)
+
+	handoverQuery := query.NewHandover()
+	assetRepository := repository.NewAsset(store, query.NewAsset(), handoverQuery)
+	onboardingRepository := repository.NewOnboarding(store, query.NewOnboarding())
+	employeeService := service.NewEmployeeService(employeeRepository, userRepository, departmentRepository, positionRepository, assetRepository, onboardingRepository, kafkaProducerService, logger.Sugar())
+	employeeController := controller.NewEmployeeController(validate, kafkaProducerService, employeeService)
+	employmentHistoryService := service.NewEmploymentHistoryService(employmentHistoryRepository, employeeRepository, departmentRepository, positionRepository, kafkaProducerService, logger.Sugar())
+	employmentHistoryController := controller.NewEmploymentHistoryController(validate, employmentHistoryService)
+
+	pdfRenderer, err := helper.NewPDFRenderer(config.PDFRendererDriver, helper.GotenbergConfig{
+		Endpoint: config.GotenbergEndpoint,
+		Timeout:  time.Duration(config.GotenbergTimeoutSeconds) * time.Second,
+		Retries:  config.GotenbergRetries,
+	})
+	if err != nil {
+		sugar.Fatal(err)
+	}
+
+	departmentService := service.NewDepartmentService(departmentRepository, positionRepository, employeeRepository, templateFS, pdfRenderer, logger.Sugar())
+	departmentController := controller.NewDepartmentController(validate, departmentService)
+	positionService := service.NewPositionService(positionRepository, departmentRepository, employeeRepository, logger.Sugar())
+	positionController := controller.NewPositionController(validate, positionService)
+
+	workCalendarRepository := repository.NewWorkCalendar(store, query.NewWorkCalendar())
+	holidayRepository := repository.NewHoliday(store, query.NewHoliday())
+	holidayService := service.NewHolidayService(holidayRepository, workCalendarRepository, logger.Sugar())
+	holidayController := controller.NewHolidayController(validate, holidayService)
+	leaveTypeRepository := repository.NewLeaveType(store, query.NewLeaveType())
+	leaveTypeService := service.NewLeaveTypeService(leaveTypeRepository, logger.Sugar())
+	leaveTypeController := controller.NewLeaveTypeController(validate, leaveTypeService)
+	leaveRepository := repository.NewLeave(store, query.NewLeave(), query.NewLeaveBalance())
+	approvalRepository := repository.NewApproval(store, query.NewApproval())
+	approvalService := service.NewApprovalService(approvalRepository, leaveRepository, employeeRepository, positionRepository, kafkaProducerService, logger.Sugar())
+	leaveService := service.NewLeaveService(leaveRepository, leaveTypeRepository, holidayRepository, workCalendarRepository, employeeRepository, approvalService, kafkaProducerService, logger.Sugar())
+	leaveController := controller.NewLeaveController(validate, leaveService)
+
+	officeLocationRepository := repository.NewOfficeLocation(store, query.NewOfficeLocation())
+	officeLocationService := service.NewOfficeLocationService(officeLocationRepository, workCalendarRepository, employeeRepository, logger.Sugar())
+	officeLocationController := controller.NewOfficeLocationController(validate, officeLocationService)
+	workCalendarService := service.NewWorkCalendarService(workCalendarRepository, holidayRepository, officeLocationRepository, employeeRepository, logger.Sugar())
+	workCalendarController := controller.NewWorkCalendarController(validate, workCalendarService)
+	shiftRepository := repository.NewShift(store, query.NewShift())
+	shiftService := service.NewShiftService(shiftRepository, employeeRepository, logger.Sugar())
+	shiftController := controller.NewShiftController(validate, shiftService)
+	shiftPatternRepository := repository.NewShiftPattern(store, query.NewShiftPattern())
+	shiftPatternService := service.NewShiftPatternService(shiftPatternRepository, shiftRepository, logger.Sugar())
+	shiftPatternController := controller.NewShiftPatternController(validate, shiftPatternService)
+	rosterRepository := repository.NewRoster(store, query.NewRoster())
+	rosterService := service.NewRosterService(rosterRepository, shiftRepository, shiftPatternRepository, holidayRepository, workCalendarRepository, employeeRepository, departmentRepository, logger.Sugar())
+	shiftSwapRepository := repository.NewShiftSwap(store, query.NewShiftSwap(), query.NewRoster())
+	shiftSwapService := service.NewShiftSwapService(shiftSwapRepository, rosterRepository, shiftRepository, holidayRepository, workCalendarRepository, employeeRepository, kafkaProducerService, logger.Sugar())
+	rosterController := controller.NewRosterController(validate, rosterService, shiftSwapService)
+	attendanceRepository := repository.NewAttendance(store, query.NewAttendance())
+	attendanceService := service.NewAttendanceService(attendanceRepository, shiftRepository, rosterRepository, officeLocationRepository, employeeRepository, departmentRepository, kafkaProducerService, logger.Sugar())
+	attendanceController := controller.NewAttendanceController(validate, attendanceService)
+	expenseClaimRepository := repository.NewExpenseClaim(store, query.NewExpenseClaim())
+	overtimeRepository := repository.NewOvertime(store, query.NewOvertime())
+	payrollRepository := repository.NewPayroll(store, query.NewPayroll(), query.NewExpenseClaim())
+	payrollRateRepository := repository.NewPayrollRate(store, query.NewPayrollRate())
+	employeeSalaryRepository := repository.NewEmployeeSalary(store, query.NewEmployeeSalary())
+	payrollService := service.NewPayrollService(payrollRepository, payrollRateRepository, employeeSalaryRepository, overtimeRepository, leaveRepository, leaveTypeRepository, holidayRepository, workCalendarRepository, expenseClaimRepository, employeeRepository, kafkaProducerService, logger.Sugar())
+	storage, err := helper.NewStorage(config.StorageDriver, config.StorageLocalDir)
+	if err != nil {
+		sugar.Fatal(err)
+	}
+	payslipService := service.NewPayslipService(payrollRepository, employeeRepository, storage, templateFS, pdfRenderer, logger.Sugar())
+	payrollController := controller.NewPayrollController(validate, payrollService, payslipService)
+	payslipController := controller.NewPayslipController(validate, payslipService)
+	handoverRepository := repository.NewHandover(store, handoverQuery)
+	handoverService := service.NewHandoverService(handoverRepository, employeeRepository, storage, templateFS, pdfRenderer, approvalService, kafkaProducerService, logger.Sugar())
+	handoverController := controller.NewHandoverController(validate, handoverService)
+	assetService := service.NewAssetService(assetRepository, handoverRepository, employeeRepository, logger.Sugar())
+	assetController := controller.NewAssetController(validate, assetService)
+	onboardingService := service.NewOnboardingService(onboardingRepository, employeeRepository, departmentRepository, positionRepository, kafkaProducerService, logger.Sugar())
+	onboardingTemplateController := controller.NewOnboardingTemplateController(validate, onboardingService)
+	onboardingController := controller.NewOnboardingController(validate, onboardingService)
+	offboardingRepository := repository.NewOffboarding(store, query.NewOffboarding())
+	offboardingService := service.NewOffboardingService(offboardingRepository, employeeRepository, userRepository, assetRepository, storage, templateFS, pdfRenderer, kafkaProducerService, logger.Sugar())
+	offboardingController := controller.NewOffboardingController(validate, offboardingService)
+	employeeDocumentRepository := repository.NewEmployeeDocument(store, query.NewEmployeeDocument())
+	employeeDocumentService := service.NewEmployeeDocumentService(employeeDocumentRepository, employeeRepository, storage, kafkaProducerService, logger.Sugar())
+	employeeDocumentController := controller.NewEmployeeDocumentController(validate, employeeDocumentService)
+	employmentContractRepository := repository.NewEmploymentContract(store, query.NewEmploymentContract())
+	employmentContractService := service.NewEmploymentContractService(employmentContractRepository, employeeRepository, employeeSalaryRepository, employeeDocumentRepository, templateFS, pdfRenderer, approvalService, kafkaProducerService, logger.Sugar())
+	employmentContractController := controller.NewEmploymentContractController(validate, employmentContractService)
+	performanceReviewRepository := repository.NewPerformanceReview(store, query.NewPerformanceReview())
+	performanceReviewService := service.NewPerformanceReviewService(performanceReviewRepository, employeeRepository, templateFS, pdfRenderer, kafkaProducerService, logger.Sugar())
+	reviewCycleController := controller.NewReviewCycleController(validate, performanceReviewService)
+	performanceReviewController := controller.NewPerformanceReviewController(validate, performanceReviewService)
+	jobRequisitionRepository := repository.NewJobRequisition(store, query.NewJobRequisition())
+	jobApplicationRepository := repository.NewJobApplication(store, query.NewJobApplication(), query.NewJobRequisition(), userQuery, employeeQuery, employmentHistoryQuery)
+	jobRequisitionService := service.NewJobRequisitionService(jobRequisitionRepository, employeeRepository, departmentRepository, positionRepository, kafkaProducerService, logger.Sugar())
+	jobApplicationService := service.NewJobApplicationService(jobApplicationRepository, jobRequisitionRepository, employeeRepository, userRepository, onboardingRepository, storage, templateFS, pdfRenderer, kafkaProducerService, logger.Sugar())
+	jobRequisitionController := controller.NewJobRequisitionController(validate, jobRequisitionService)
+	careerController := controller.NewCareerController(validate, jobRequisitionService, jobApplicationService)
+	recruitmentStageController := controller.NewRecruitmentStageController(validate, jobApplicationService)
+	jobApplicationController := controller.NewJobApplicationController(validate, jobApplicationService)
+	trainingRepository := repository.NewTraining(store, query.NewTraining(), query.NewEmployeeDocument())
+	trainingService := service.NewTrainingService(trainingRepository, employeeRepository, positionRepository, storage, kafkaProducerService, logger.Sugar())
+	trainingController := controller.NewTrainingController(validate, trainingService)
+	trainingEnrollmentController := controller.NewTrainingEnrollmentController(validate, trainingService)
+	expenseClaimService := service.NewExpenseClaimService(expenseClaimRepository, employeeRepository, departmentRepository, storage, approvalService, kafkaProducerService, logger.Sugar())
+	expenseCategoryController := controller.NewExpenseCategoryController(validate, expenseClaimService)
+	expenseClaimController := controller.NewExpenseClaimController(validate, expenseClaimService)
+	overtimeService := service.NewOvertimeService(overtimeRepository, holidayRepository, workCalendarRepository, employeeRepository, departmentRepository, approvalService, kafkaProducerService, logger.Sugar())
+	overtimeController := controller.NewOvertimeController(validate, overtimeService)
+	// the approvals of the entities are applied through the services owning them
+	approvalService.RegisterEntity(domain.ApprovalEntityLeave, leaveService)
+	approvalService.RegisterEntity(domain.ApprovalEntityOvertime, overtimeService)
+	approvalService.RegisterEntity(domain.ApprovalEntityExpenseClaim, expenseClaimService)
+	approvalService.RegisterEntity(domain.ApprovalEntityHandover, handoverService)
+	approvalService.RegisterEntity(domain.ApprovalEntityEmploymentContract, employmentContractService)
+	approvalWorkflowController := controller.NewApprovalWorkflowController(validate, approvalService)
+	approvalController := controller.NewApprovalController(validate, approvalService)
+	approvalDelegationController := controller.NewApprovalDelegationController(validate, approvalService)
+
 	userController.Route(app)
+	employeeController.Route(app)
+	employmentHistoryController.Route(app)
+	departmentController.Route(app)
+	positionController.Route(app)
+	holidayController.Route(app)
+	workCalendarController.Route(app)
+	leaveTypeController.Route(app)
+	leaveController.Route(app)
+	officeLocationController.Route(app)
+	shiftController.Route(app)
+	shiftPatternController.Route(app)
+	rosterController.Route(app)
+	attendanceController.Route(app)
+	payrollController.Route(app)
+	payslipController.Route(app)
+	handoverController.Route(app)
+	assetController.Route(app)
+	onboardingTemplateController.Route(app)
+	onboardingController.Route(app)
+	offboardingController.Route(app)
+	employeeDocumentController.Route(app)
+	employmentContractController.Route(app)
+	reviewCycleController.Route(app)
+	performanceReviewController.Route(app)
+	jobRequisitionController.Route(app)
+	careerController.Route(app)
+	recruitmentStageController.Route(app)
+	jobApplicationController.Route(app)
+	trainingController.Route(app)
+	trainingEnrollmentController.Route(app)
+	expenseCategoryController.Route(app)
+	expenseClaimController.Route(app)
+	overtimeController.Route(app)
+	approvalWorkflowController.Route(app)
+	approvalController.Route(app)
+	approvalDelegationController.Route(app)
 
-	err := app.Listen(serverConfig.Host)
+	err = app.Listen(serverConfig.Host)
 	if err != nil {
 		sugar.Fatal(err)
 	}
@@ -87,10 +261,60 @@ func controllers() {
 
 }
 
+// This function is used for running the background jobs, e.g. applying the effective-dated changes.
+func backgroundJobs() {
+	time.Local = time.UTC
+
+	db := config.NewPostgresDatabase()
+	store := repository.NewStore(db)
+
+	kafkaProducer := config.NewKafkaProducer()
+	kafkaProducerService := producers.NewKafkaProducerService(kafkaProducer, logger)
+
+	departmentRepository := repository.NewDepartment(store, query.NewDepartment())
+	positionRepository := repository.NewPosition(store, query.NewPosition())
+	employeeQuery := query.NewEmployee()
+	employmentHistoryQuery := query.NewEmploymentHistory()
+	employeeRepository := repository.NewEmployee(store, employeeQuery, employmentHistoryQuery)
+	employmentHistoryRepository := repository.NewEmploymentHistory(store, employmentHistoryQuery, employeeQuery)
+	employmentHistoryService := service.NewEmploymentHistoryService(employmentHistoryRepository, employeeRepository, departmentRepository, positionRepository, kafkaProducerService, logger)
+	onboardingRepository := repository.NewOnboarding(store, query.NewOnboarding())
+	onboardingService := service.NewOnboardingService(onboardingRepository, employeeRepository, departmentRepository, positionRepository, kafkaProducerService, logger)
+	userRepository := repository.NewUser(store, query.NewUser())
+	assetRepository := repository.NewAsset(store, query.NewAsset(), query.NewHandover())
+	offboardingRepository := repository.NewOffboarding(store, query.NewOffboarding())
+	// the clearance document is not rendered by the background jobs, so the storage and the renderer are not set
+	offboardingService := service.NewOffboardingService(offboardingRepository, employeeRepository, userRepository, assetRepository, nil, templateFS, nil, kafkaProducerService, logger)
+	employeeDocumentRepository := repository.NewEmployeeDocument(store, query.NewEmployeeDocument())
+	// the expiry reminders don't read the files, so the storage is not set
+	employeeDocumentService := service.NewEmployeeDocumentService(employeeDocumentRepository, employeeRepository, nil, kafkaProducerService, logger)
+	leaveRepository := repository.NewLeave(store, query.NewLeave(), query.NewLeaveBalance())
+	approvalService := service.NewApprovalService(repository.NewApproval(store, query.NewApproval()), leaveRepository, employeeRepository, positionRepository, kafkaProducerService, logger)
+	employmentContractRepository := repository.NewEmploymentContract(store, query.NewEmploymentContract())
+	// the contract document is not rendered by the background jobs, so the renderer is not set
+	employmentContractService := service.NewEmploymentContractService(employmentContractRepository, employeeRepository, nil, employeeDocumentRepository, templateFS, nil, approvalService, kafkaProducerService, logger)
+	jobRequisitionService := service.NewJobRequisitionService(repository.NewJobRequisition(store, query.NewJobRequisition()), employeeRepository, departmentRepository, positionRepository, kafkaProducerService, logger)
+	// the certificates are not uploaded by the background jobs, so the storage is not set
+	trainingService := service.NewTrainingService(repository.NewTraining(store, query.NewTraining(), query.NewEmployeeDocument()), employeeRepository, positionRepository, nil, kafkaProducerService, logger)
+
+	scheduler := schedulers.NewScheduler(config.SchedulerIntervalMinutes, logger)
+	scheduler.Register("apply-due-employment-changes", employmentHistoryService.ApplyDueChanges)
+	scheduler.Register("send-onboarding-reminders", onboardingService.SendReminders)
+	scheduler.Register("deactivate-offboarded-employees", offboardingService.DeactivateDue)
+	scheduler.Register("send-document-expiry-reminders", employeeDocumentService.SendExpiryReminders)
+	scheduler.Register("send-contract-expiry-alerts", employmentContractService.SendExpiryAlerts)
+	scheduler.Register("end-expired-contracts", employmentContractService.EndExpired)
+	scheduler.Register("close-expired-job-postings", jobRequisitionService.CloseExpiredPostings)
+	scheduler.Register("enroll-mandatory-trainings", trainingService.EnrollMandatory)
+	scheduler.Register("escalate-overdue-approvals", approvalService.EscalateOverdue)
+	scheduler.Start(context.Background())
+}
+
 func main() {
 	time.Local = time.UTC
 
 	go controllers()
+	go backgroundJobs()
 
 	sigchan := make(chan os.Signal, 1)
 	signal.Notify(sigchan, syscall.SIGINT, syscall.SIGTERM)
@@ -108,6 +332,9 @@ func main() {
 	userQuery := query.NewUser()
 	userRepository := repository.NewUser(store, userQuery)
 	kafkaUserConsumerService := consumers.NewKafkaUserConsumerService(userRepository, logger)
+	employeeQuery := query.NewEmployee()
+	employeeRepository := repository.NewEmployee(store, employeeQuery, query.NewEmploymentHistory())
+	kafkaEmployeeConsumerService := consumers.NewKafkaEmployeeConsumerService(employeeRepository, logger)
 
 	run := true
 
@@ -149,11 +376,23 @@ func main() {
 					if err != nil {
 						logger.Panic(err)
 					}
+				case `[method="PUT.USER_AVATAR"]`:
+					err := kafkaUserConsumerService.UpdateAvatar(e.Value)
+					if err != nil {
+						logger.Panic(err)
+					}
 				case `[method="DELETE.USER"]`:
 					err := kafkaUserConsumerService.Delete(e.Value)
 					if err != nil {
 						logger.Panic(err)
 					}
+
+				// EMPLOYEE
+				case `[method="PUT.EMPLOYEE_PERSONAL"]`:
+					err := kafkaEmployeeConsumerService.UpdatePersonal(e.Value)
+					if err != nil {
+						logger.Panic(err)
+					}
 				}
 
 			case kafka.Error:
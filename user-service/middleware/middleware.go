@@ -1,17 +1,30 @@
 package middleware
 
 import (
+	"context"
 	"strings"
 
 	"github.com/gofiber/fiber/v2"
+	"github.com/iqbaludinm/hr-microservice/user-service/config"
 	"github.com/iqbaludinm/hr-microservice/user-service/helper"
 	"github.com/iqbaludinm/hr-microservice/user-service/model/web"
+	"github.com/iqbaludinm/hr-microservice/user-service/repository"
+)
+
+// the repository used by 'IsAuthenticated' for rejecting the token of the deleted user, it's set by 'RevokeDeletedUserSession'
+var userRepository repository.UserRepository
+
+// the repositories used by 'IsHR' for finding the department of the logged in user, they're set by 'RestrictToHR'
+var (
+	employeeRepository   repository.EmployeeRepository
+	departmentRepository repository.DepartmentRepository
 )
 
 func IsAuthenticated(c *fiber.Ctx) error {
 	cookie := c.Cookies("token") // ambil token di cookies, dengan key "token"
 
-	if _, err := helper.ParseJwt(cookie); err != nil {
+	issuer, err := helper.ParseJwt(cookie)
+	if err != nil {
 		if strings.Contains(err.Error(), "token is expired") {
 			return c.Status(401).JSON(web.WebResponse{
 				Code:    99281,
@@ -26,5 +39,81 @@ func IsAuthenticated(c *fiber.Ctx) error {
 		})
 	}
 
+	if userRepository == nil {
+		return c.Next()
+	}
+
+	// the session is rejected when the user can't be checked, a failed lookup must not re-admit a deleted user
+	deleted, err := userRepository.IsDeleted(c.Context(), issuer)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(web.WebResponse{
+			Code:    fiber.StatusInternalServerError,
+			Status:  false,
+			Message: "failed to check the session",
+		})
+	}
+	if deleted {
+		c.ClearCookie("token", "refresh_token")
+		return c.Status(fiber.StatusUnauthorized).JSON(web.WebResponse{
+			Code:    fiber.StatusUnauthorized,
+			Status:  false,
+			Message: "session revoked",
+		})
+	}
+
 	return c.Next()
 }
+
+// IsHR only lets the employees of the HR departments through, it's used after 'IsAuthenticated'. Nobody is let through
+// when the repositories are not set.
+func IsHR(c *fiber.Ctx) error {
+	issuer, _ := helper.ParseJwt(c.Cookies("token"))
+
+	hr := false
+	var err error
+	if employeeRepository != nil && departmentRepository != nil {
+		hr, err = isHR(c.Context(), issuer)
+	}
+	if err != nil && !strings.Contains(err.Error(), "no rows") {
+		return c.Status(fiber.StatusInternalServerError).JSON(web.WebResponse{
+			Code:    fiber.StatusInternalServerError,
+			Status:  false,
+			Message: "failed to check the department",
+		})
+	}
+	if !hr {
+		return c.Status(fiber.StatusForbidden).JSON(web.WebResponse{
+			Code:    fiber.StatusForbidden,
+			Status:  false,
+			Message: "only HR can access the resource",
+		})
+	}
+
+	return c.Next()
+}
+
+// the user is HR when its employee belongs to one of the HR departments
+func isHR(c context.Context, userID string) (bool, error) {
+	employee, err := employeeRepository.FindByUserId(c, userID)
+	if err != nil {
+		return false, err
+	}
+	department, err := departmentRepository.FindById(c, employee.DepartmentID)
+	if err != nil {
+		return false, err
+	}
+
+	return config.IsHRDepartment(department.Code), nil
+}
+
+// RestrictToHR makes 'IsHR' find the department of the logged in user with the repositories.
+func RestrictToHR(employees repository.EmployeeRepository, departments repository.DepartmentRepository) {
+	employeeRepository = employees
+	departmentRepository = departments
+}
+
+// RevokeDeletedUserSession makes 'IsAuthenticated' reject the token of the user which has been deleted,
+// e.g. deactivated by the offboarding. Only the authenticated routes are checked.
+func RevokeDeletedUserSession(r repository.UserRepository) {
+	userRepository = r
+}
@@ -0,0 +1,276 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/iqbaludinm/hr-microservice/user-service/model/web"
+)
+
+// Status of the approval request.
+const (
+	ApprovalStatusPending   = "pending"
+	ApprovalStatusApproved  = "approved"
+	ApprovalStatusRejected  = "rejected"
+	ApprovalStatusCancelled = "cancelled"
+)
+
+// Status of the approval task. The task is waiting until the tasks of the earlier steps are approved.
+const (
+	ApprovalTaskStatusWaiting  = "waiting"
+	ApprovalTaskStatusPending  = "pending"
+	ApprovalTaskStatusApproved = "approved"
+	ApprovalTaskStatusRejected = "rejected"
+	ApprovalTaskStatusSkipped  = "skipped"
+)
+
+// Type of the actions recorded in the history of the approval request.
+const (
+	ApprovalActionSubmitted = "submitted"
+	ApprovalActionAssigned  = "assigned"
+	ApprovalActionDelegated = "delegated"
+	ApprovalActionEscalated = "escalated"
+	ApprovalActionApproved  = "approved"
+	ApprovalActionRejected  = "rejected"
+	ApprovalActionSkipped   = "skipped"
+	ApprovalActionCancelled = "cancelled"
+	ApprovalActionCompleted = "completed"
+)
+
+// ApprovalSubject is what the approval of an entity is about, it is found from the entity by the service owning it so
+// the requester and the amount can't be chosen by the client
+type ApprovalSubject struct {
+	RequesterID string
+	Title       string
+	Amount      *int64
+}
+
+// approval request main struct, the approval of an entity through the steps of the workflow
+type ApprovalRequest struct {
+	ID          string     `json:"id"`
+	WorkflowID  string     `json:"workflow_id"`
+	EntityType  string     `json:"entity_type"`
+	EntityID    string     `json:"entity_id"`
+	Title       string     `json:"title"`
+	Amount      *int64     `json:"amount"`
+	RequesterID string     `json:"requester_id"`
+	Status      string     `json:"status"`
+	CreatedAt   time.Time  `json:"created_at"`
+	UpdatedAt   time.Time  `json:"updated_at"`
+	CompletedAt *time.Time `json:"completed_at"`
+
+	Tasks   []ApprovalTask   `json:"tasks"`
+	Actions []ApprovalAction `json:"actions"`
+
+	// joined from the 'approval_workflows', 'employees' and 'users' table
+	WorkflowCode    string `json:"workflow_code"`
+	WorkflowName    string `json:"workflow_name"`
+	RequesterName   string `json:"requester_name"`
+	RequesterUserID string `json:"requester_user_id"`
+}
+
+// the step of the workflow copied to the approval request, the approver of a role is any employee of the position
+// until the task is escalated
+type ApprovalTask struct {
+	ID                 string     `json:"id"`
+	RequestID          string     `json:"request_id"`
+	Seq                int        `json:"seq"`
+	StepOrder          int        `json:"step_order"`
+	Name               string     `json:"name"`
+	ApproverType       string     `json:"approver_type"`
+	ApproverPositionID *string    `json:"approver_position_id"`
+	ApproverID         *string    `json:"approver_id"`
+	AssignedFromID     *string    `json:"assigned_from_id"`
+	TimeoutHours       *int       `json:"timeout_hours"`
+	Status             string     `json:"status"`
+	DueAt              *time.Time `json:"due_at"`
+	EscalatedAt        *time.Time `json:"escalated_at"`
+	Note               string     `json:"note"`
+	DecidedBy          *string    `json:"decided_by"`
+	DecidedAt          *time.Time `json:"decided_at"`
+	OnBehalfOfID       *string    `json:"on_behalf_of_id"`
+	CreatedAt          time.Time  `json:"created_at"`
+	UpdatedAt          time.Time  `json:"updated_at"`
+
+	// joined from the 'positions', 'employees' and 'users' table
+	ApproverPositionName string `json:"approver_position_name"`
+	ApproverName         string `json:"approver_name"`
+	ApproverUserID       string `json:"approver_user_id"`
+	AssignedFromName     string `json:"assigned_from_name"`
+	DecidedByName        string `json:"decided_by_name"`
+	OnBehalfOfName       string `json:"on_behalf_of_name"`
+}
+
+// the action on the approval request, the actor is empty for the actions of the system. The delegate acts on behalf
+// of the approver.
+type ApprovalAction struct {
+	ID           string    `json:"id"`
+	RequestID    string    `json:"request_id"`
+	TaskID       *string   `json:"task_id"`
+	Action       string    `json:"action"`
+	ActorID      *string   `json:"actor_id"`
+	OnBehalfOfID *string   `json:"on_behalf_of_id"`
+	Note         string    `json:"note"`
+	CreatedAt    time.Time `json:"created_at"`
+
+	// joined from the 'employees' and 'users' table
+	ActorName      string `json:"actor_name"`
+	OnBehalfOfName string `json:"on_behalf_of_name"`
+}
+
+func (r *ApprovalRequest) ToApprovalResponse() web.ApprovalResponse {
+	var tasks []web.ApprovalTaskResponse
+	for _, task := range r.Tasks {
+		tasks = append(tasks, web.ApprovalTaskResponse{
+			ID:                   task.ID,
+			Seq:                  task.Seq,
+			StepOrder:            task.StepOrder,
+			Name:                 task.Name,
+			ApproverType:         task.ApproverType,
+			ApproverPositionID:   task.ApproverPositionID,
+			ApproverPositionName: task.ApproverPositionName,
+			ApproverID:           task.ApproverID,
+			ApproverName:         task.ApproverName,
+			AssignedFromID:       task.AssignedFromID,
+			AssignedFromName:     task.AssignedFromName,
+			Status:               task.Status,
+			DueAt:                task.DueAt,
+			EscalatedAt:          task.EscalatedAt,
+			Note:                 task.Note,
+			DecidedBy:            task.DecidedBy,
+			DecidedByName:        task.DecidedByName,
+			OnBehalfOfID:         task.OnBehalfOfID,
+			OnBehalfOfName:       task.OnBehalfOfName,
+			DecidedAt:            task.DecidedAt,
+		})
+	}
+
+	var actions []web.ApprovalActionResponse
+	for _, action := range r.Actions {
+		actions = append(actions, web.ApprovalActionResponse{
+			ID:             action.ID,
+			TaskID:         action.TaskID,
+			Action:         action.Action,
+			ActorID:        action.ActorID,
+			ActorName:      action.ActorName,
+			OnBehalfOfID:   action.OnBehalfOfID,
+			OnBehalfOfName: action.OnBehalfOfName,
+			Note:           action.Note,
+			CreatedAt:      action.CreatedAt,
+		})
+	}
+
+	return web.ApprovalResponse{
+		ID:            r.ID,
+		WorkflowID:    r.WorkflowID,
+		WorkflowCode:  r.WorkflowCode,
+		WorkflowName:  r.WorkflowName,
+		EntityType:    r.EntityType,
+		EntityID:      r.EntityID,
+		Title:         r.Title,
+		Amount:        r.Amount,
+		RequesterID:   r.RequesterID,
+		RequesterName: r.RequesterName,
+		Status:        r.Status,
+		Tasks:         tasks,
+		Actions:       actions,
+		CreatedAt:     r.CreatedAt,
+		UpdatedAt:     r.UpdatedAt,
+		CompletedAt:   r.CompletedAt,
+	}
+}
+
+// NextStage returns the index of the waiting tasks with the lowest step order, the tasks run in parallel. It is empty
+// when every task is decided.
+func (r *ApprovalRequest) NextStage() []int {
+	var stage []int
+	for i, task := range r.Tasks {
+		if task.Status != ApprovalTaskStatusWaiting {
+			continue
+		}
+		if len(stage) > 0 && task.StepOrder > r.Tasks[stage[0]].StepOrder {
+			continue
+		}
+		if len(stage) > 0 && task.StepOrder < r.Tasks[stage[0]].StepOrder {
+			stage = nil
+		}
+		stage = append(stage, i)
+	}
+	return stage
+}
+
+// StageApproved reports whether every task of the step order is approved.
+func (r *ApprovalRequest) StageApproved(stepOrder int) bool {
+	for _, task := range r.Tasks {
+		if task.StepOrder == stepOrder && task.Status != ApprovalTaskStatusApproved {
+			return false
+		}
+	}
+	return true
+}
+
+// AssignedTask returns the index of the pending task the employee can decide, by its approver or by the position of
+// the role. It is -1 when the employee has no pending task.
+func (r *ApprovalRequest) AssignedTask(employeeID string, positionID *string) int {
+	for i, task := range r.Tasks {
+		if task.Status == ApprovalTaskStatusPending && task.IsAssignedTo(employeeID, positionID) {
+			return i
+		}
+	}
+	return -1
+}
+
+// NewAction returns the action on the approval request, the task and the actor are optional.
+func (r *ApprovalRequest) NewAction(id string, taskID *string, action string, actorID *string, note string, createdAt time.Time) ApprovalAction {
+	return ApprovalAction{
+		ID:        id,
+		RequestID: r.ID,
+		TaskID:    taskID,
+		Action:    action,
+		ActorID:   actorID,
+		Note:      note,
+		CreatedAt: createdAt,
+	}
+}
+
+// DecisionNote returns the note of the last decision of the approval request, e.g. the reason of the rejection.
+func (r *ApprovalRequest) DecisionNote() string {
+	for i := len(r.Actions) - 1; i >= 0; i-- {
+		action := r.Actions[i]
+		if action.Note != "" && (action.Action == ApprovalActionApproved || action.Action == ApprovalActionRejected) {
+			return action.Note
+		}
+	}
+	return ""
+}
+
+// IsAssignedTo reports whether the employee is the approver of the task, or the task of a role is not assigned yet
+// and the employee holds the position.
+func (t *ApprovalTask) IsAssignedTo(employeeID string, positionID *string) bool {
+	if t.ApproverID != nil {
+		return *t.ApproverID == employeeID
+	}
+	return t.ApproverPositionID != nil && positionID != nil && *t.ApproverPositionID == *positionID
+}
+
+// Overdue reports whether the pending task is not decided within its timeout and is not escalated yet.
+func (t *ApprovalTask) Overdue(now time.Time) bool {
+	return t.Status == ApprovalTaskStatusPending && t.EscalatedAt == nil && t.DueAt != nil && t.DueAt.Before(now)
+}
+
+// ToDomainApprovalTask copies the step of the workflow to the task of the approval request.
+func ToDomainApprovalTask(id, requestID string, step ApprovalWorkflowStep, createdAt time.Time) ApprovalTask {
+	return ApprovalTask{
+		ID:                 id,
+		RequestID:          requestID,
+		Seq:                step.Seq,
+		StepOrder:          step.StepOrder,
+		Name:               step.Name,
+		ApproverType:       step.ApproverType,
+		ApproverPositionID: step.ApproverPositionID,
+		ApproverID:         step.ApproverEmployeeID,
+		TimeoutHours:       step.TimeoutHours,
+		Status:             ApprovalTaskStatusWaiting,
+		CreatedAt:          createdAt,
+		UpdatedAt:          createdAt,
+	}
+}
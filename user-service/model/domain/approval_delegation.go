@@ -0,0 +1,154 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/iqbaludinm/hr-microservice/user-service/helper"
+	"github.com/iqbaludinm/hr-microservice/user-service/model/web"
+)
+
+// approval delegation main struct, the approvals of the delegator are decided by the delegate between the dates
+// (inclusive). The delegation covers every entity type when the entity types are empty.
+type ApprovalDelegation struct {
+	ID          string     `json:"id"`
+	DelegatorID string     `json:"delegator_id"`
+	DelegateID  string     `json:"delegate_id"`
+	StartDate   time.Time  `json:"start_date"`
+	EndDate     time.Time  `json:"end_date"`
+	EntityTypes []string   `json:"entity_types"`
+	Reason      string     `json:"reason"`
+	CancelledAt *time.Time `json:"cancelled_at"`
+	CreatedAt   time.Time  `json:"created_at"`
+	UpdatedAt   time.Time  `json:"updated_at"`
+
+	// joined from the 'employees' and 'users' table
+	DelegatorName   string `json:"delegator_name"`
+	DelegatorUserID string `json:"delegator_user_id"`
+	DelegateName    string `json:"delegate_name"`
+	DelegateUserID  string `json:"delegate_user_id"`
+}
+
+func (d *ApprovalDelegation) ToApprovalDelegationResponse() web.ApprovalDelegationResponse {
+	entityTypes := d.EntityTypes
+	if entityTypes == nil {
+		entityTypes = []string{}
+	}
+
+	return web.ApprovalDelegationResponse{
+		ID:            d.ID,
+		DelegatorID:   d.DelegatorID,
+		DelegatorName: d.DelegatorName,
+		DelegateID:    d.DelegateID,
+		DelegateName:  d.DelegateName,
+		StartDate:     d.StartDate.Format(helper.DateLayout),
+		EndDate:       d.EndDate.Format(helper.DateLayout),
+		EntityTypes:   entityTypes,
+		Reason:        d.Reason,
+		CancelledAt:   d.CancelledAt,
+		CreatedAt:     d.CreatedAt,
+		UpdatedAt:     d.UpdatedAt,
+	}
+}
+
+// Overlaps reports whether both delegations of the same delegator cover a date and an entity type, the approvals of
+// the date would have two delegates.
+func (d *ApprovalDelegation) Overlaps(other ApprovalDelegation) bool {
+	if d.DelegatorID != other.DelegatorID {
+		return false
+	}
+	_, _, datesOverlap := overlapDates(d.StartDate, d.EndDate, other.StartDate, other.EndDate)
+	_, typesOverlap := overlapEntityTypes(d.EntityTypes, other.EntityTypes)
+	return datesOverlap && typesOverlap
+}
+
+// FindCycle returns the delegations through which the delegate delegates back to the delegator on a date and for an
+// entity type the delegation covers. It is empty when the delegation doesn't form a cycle.
+func (d *ApprovalDelegation) FindCycle(delegations []ApprovalDelegation) []ApprovalDelegation {
+	var path []ApprovalDelegation
+	visited := map[string]bool{d.DelegateID: true}
+
+	// follow the delegations of the employee which cover the dates and the entity types of the path so far
+	var visit func(employeeID string, startDate, endDate time.Time, entityTypes []string) bool
+	visit = func(employeeID string, startDate, endDate time.Time, entityTypes []string) bool {
+		for _, next := range delegations {
+			if next.ID == d.ID || next.DelegatorID != employeeID || next.CancelledAt != nil {
+				continue
+			}
+			nextStartDate, nextEndDate, ok := overlapDates(startDate, endDate, next.StartDate, next.EndDate)
+			if !ok {
+				continue
+			}
+			nextEntityTypes, ok := overlapEntityTypes(entityTypes, next.EntityTypes)
+			if !ok {
+				continue
+			}
+
+			path = append(path, next)
+			if next.DelegateID == d.DelegatorID {
+				return true
+			}
+			// an employee is visited once in the path, so the search ends even when the saved delegations form a cycle
+			if !visited[next.DelegateID] {
+				visited[next.DelegateID] = true
+				if visit(next.DelegateID, nextStartDate, nextEndDate, nextEntityTypes) {
+					return true
+				}
+				visited[next.DelegateID] = false
+			}
+			path = path[:len(path)-1]
+		}
+		return false
+	}
+
+	if visit(d.DelegateID, d.StartDate, d.EndDate, d.EntityTypes) {
+		return path
+	}
+	return nil
+}
+
+// ToDomainApprovalDelegation converts the request of the delegator, the dates are validated by the request.
+func ToDomainApprovalDelegation(delegatorID string, request web.CreateApprovalDelegationRequest) ApprovalDelegation {
+	startDate, _ := helper.ParseDate(request.StartDate)
+	endDate, _ := helper.ParseDate(request.EndDate)
+
+	return ApprovalDelegation{
+		DelegatorID: delegatorID,
+		DelegateID:  request.DelegateID,
+		StartDate:   startDate,
+		EndDate:     endDate,
+		EntityTypes: request.EntityTypes,
+		Reason:      request.Reason,
+	}
+}
+
+// the common dates of both ranges (inclusive)
+func overlapDates(startA, endA, startB, endB time.Time) (time.Time, time.Time, bool) {
+	start, end := startA, endA
+	if startB.After(start) {
+		start = startB
+	}
+	if endB.Before(end) {
+		end = endB
+	}
+	return start, end, !end.Before(start)
+}
+
+// the common entity types of both delegations, empty entity types cover every type
+func overlapEntityTypes(a, b []string) ([]string, bool) {
+	if len(a) == 0 {
+		return b, true
+	}
+	if len(b) == 0 {
+		return a, true
+	}
+
+	var common []string
+	for _, entityTypeA := range a {
+		for _, entityTypeB := range b {
+			if entityTypeA == entityTypeB {
+				common = append(common, entityTypeA)
+			}
+		}
+	}
+	return common, len(common) > 0
+}
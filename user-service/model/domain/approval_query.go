@@ -0,0 +1,214 @@
+package domain
+
+import (
+	"fmt"
+
+	"github.com/iqbaludinm/hr-microservice/user-service/model/web"
+)
+
+type ApprovalWorkflowQueryFilter struct {
+	EntityType string
+	Search     string
+
+	// Pagination is used for fetching the data by page. The default value is 1.
+	Pagination Pagination
+}
+
+// BuildApprovalWorkflowQueries builds the WHERE clause of the approval workflow query, the deleted workflows are
+// never returned. The values are returned as 'args' so they are sent as query parameters.
+func (q *ApprovalWorkflowQueryFilter) BuildApprovalWorkflowQueries() (filter string, args []interface{}, pagination string) {
+	filter = "WHERE w.deleted_at IS NULL"
+	add := func(condition string, value interface{}) {
+		args = append(args, value)
+		filter += fmt.Sprintf(" AND "+condition, len(args))
+	}
+
+	// filter workflow by the entity type
+	if q.EntityType != "" {
+		add("w.entity_type = $%d", q.EntityType)
+	}
+
+	// search workflow by the code or the name
+	if q.Search != "" {
+		add("(w.code ILIKE '%%' || $%[1]d || '%%' OR w.name ILIKE '%%' || $%[1]d || '%%')", q.Search)
+	}
+
+	return filter, args, q.Pagination.Build()
+}
+
+// Helper function for converting the ApprovalWorkflowQueryFilter from web to domain
+// This function is used for calling a function in the 'repository' layer
+func ToDomainApprovalWorkflowQueryFilter(q web.ApprovalWorkflowQueryFilter) ApprovalWorkflowQueryFilter {
+	return ApprovalWorkflowQueryFilter{
+		EntityType: q.EntityType,
+		Search:     q.Search,
+		Pagination: NewPagination(q.Page, q.Limit),
+	}
+}
+
+type ApprovalQueryFilter struct {
+	EntityType         string
+	EntityID           string
+	RequesterID        string
+	Status             string
+	ApproverID         string
+	ApproverPositionID string
+
+	// Pagination is used for fetching the data by page. The default value is 1.
+	Pagination Pagination
+}
+
+// BuildApprovalQueries builds the WHERE clause of the approval request query.
+// The values are returned as 'args' so they are sent as query parameters.
+func (q *ApprovalQueryFilter) BuildApprovalQueries() (filter string, args []interface{}, pagination string) {
+	add := func(condition string, value interface{}) {
+		args = append(args, value)
+		filter += fmt.Sprintf(" AND "+condition, len(args))
+	}
+
+	// filter request by the entity type
+	if q.EntityType != "" {
+		add("r.entity_type = $%d", q.EntityType)
+	}
+
+	// filter request by the entity
+	if q.EntityID != "" {
+		add("r.entity_id = $%d", q.EntityID)
+	}
+
+	// filter request by the requester
+	if q.RequesterID != "" {
+		add("r.requester_id = $%d", q.RequesterID)
+	}
+
+	// filter request by status
+	if q.Status != "" {
+		add("r.status = $%d", q.Status)
+	}
+
+	// filter request with a pending task of the approver, of a role of the approver's position which is not
+	// assigned yet, or of the delegators who delegate the request to the approver today
+	if q.ApproverID != "" {
+		args = append(args, q.ApproverID, q.ApproverPositionID)
+		filter += fmt.Sprintf(` AND EXISTS (
+			SELECT 1 FROM approval_tasks AS t
+			WHERE t.request_id = r.id AND t.status = 'pending' AND (
+				t.approver_id = $%[1]d OR
+				(t.approver_id IS NULL AND t.approver_position_id::text = $%[2]d) OR
+				t.approver_id IN (
+					SELECT d.delegator_id FROM approval_delegations AS d
+					WHERE
+						d.delegate_id = $%[1]d AND
+						d.cancelled_at IS NULL AND
+						(now() AT TIME ZONE 'Asia/Jakarta')::date BETWEEN d.start_date AND d.end_date AND
+						(cardinality(d.entity_types) = 0 OR r.entity_type = ANY(d.entity_types)))))`, len(args)-1, len(args))
+	}
+
+	// remove the first ' AND ' from the filter
+	if len(filter) > 0 {
+		filter = "WHERE " + filter[5:]
+	}
+
+	return filter, args, q.Pagination.Build()
+}
+
+// Helper function for converting the ApprovalQueryFilter from web to domain
+// This function is used for calling a function in the 'repository' layer
+func ToDomainApprovalQueryFilter(q web.ApprovalQueryFilter) ApprovalQueryFilter {
+	return ApprovalQueryFilter{
+		EntityType:  q.EntityType,
+		EntityID:    q.EntityID,
+		RequesterID: q.RequesterID,
+		Status:      q.Status,
+		Pagination:  NewPagination(q.Page, q.Limit),
+	}
+}
+
+type ApprovalDelegationQueryFilter struct {
+	DelegatorID string
+	DelegateID  string
+	// EmployeeID filters the delegations of the employee as the delegator or as the delegate
+	EmployeeID string
+	// EntityType filters the delegations which cover the entity type
+	EntityType string
+	// Date filters the delegations which are active on the date, From and To filter the delegations that overlap the
+	// date range. The dates have the DateLayout format.
+	Date string
+	From string
+	To   string
+	// Active filters the delegations which are not cancelled
+	Active bool
+
+	// Pagination is used for fetching the data by page. The default value is 1.
+	Pagination Pagination
+}
+
+// BuildApprovalDelegationQueries builds the WHERE clause of the approval delegation query.
+// The values are returned as 'args' so they are sent as query parameters.
+func (q *ApprovalDelegationQueryFilter) BuildApprovalDelegationQueries() (filter string, args []interface{}, pagination string) {
+	add := func(condition string, value interface{}) {
+		args = append(args, value)
+		filter += fmt.Sprintf(" AND "+condition, len(args))
+	}
+
+	// filter delegation by the delegator
+	if q.DelegatorID != "" {
+		add("d.delegator_id = $%d", q.DelegatorID)
+	}
+
+	// filter delegation by the delegate
+	if q.DelegateID != "" {
+		add("d.delegate_id = $%d", q.DelegateID)
+	}
+
+	// filter delegation by the delegator or the delegate
+	if q.EmployeeID != "" {
+		add("(d.delegator_id = $%[1]d OR d.delegate_id = $%[1]d)", q.EmployeeID)
+	}
+
+	// filter delegation which covers the entity type, the empty entity types cover every type
+	if q.EntityType != "" {
+		add("(cardinality(d.entity_types) = 0 OR $%d = ANY(d.entity_types))", q.EntityType)
+	}
+
+	// filter delegation which is active on the date
+	if q.Date != "" {
+		add("d.start_date <= $%[1]d::date AND d.end_date >= $%[1]d::date", q.Date)
+	}
+
+	// filter delegation that ends on or after the 'from' date
+	if q.From != "" {
+		add("d.end_date >= $%d::date", q.From)
+	}
+
+	// filter delegation that starts on or before the 'to' date
+	if q.To != "" {
+		add("d.start_date <= $%d::date", q.To)
+	}
+
+	// filter delegation which is not cancelled
+	if q.Active {
+		filter += " AND d.cancelled_at IS NULL"
+	}
+
+	// remove the first ' AND ' from the filter
+	if len(filter) > 0 {
+		filter = "WHERE " + filter[5:]
+	}
+
+	return filter, args, q.Pagination.Build()
+}
+
+// Helper function for converting the ApprovalDelegationQueryFilter from web to domain
+// This function is used for calling a function in the 'repository' layer
+func ToDomainApprovalDelegationQueryFilter(q web.ApprovalDelegationQueryFilter) ApprovalDelegationQueryFilter {
+	return ApprovalDelegationQueryFilter{
+		DelegatorID: q.DelegatorID,
+		DelegateID:  q.DelegateID,
+		EntityType:  q.EntityType,
+		Date:        q.Date,
+		// a cancelled delegation is not active on any date
+		Active:     q.Date != "",
+		Pagination: NewPagination(q.Page, q.Limit),
+	}
+}
@@ -0,0 +1,128 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/iqbaludinm/hr-microservice/user-service/model/web"
+)
+
+// Type of the entity approved by the workflow.
+const (
+	ApprovalEntityLeave              = "leave"
+	ApprovalEntityOvertime           = "overtime"
+	ApprovalEntityExpenseClaim       = "expense_claim"
+	ApprovalEntityHandover           = "handover"
+	ApprovalEntityEmploymentContract = "employment_contract"
+)
+
+// Type of the approver of the workflow step.
+const (
+	ApproverManager = "manager"
+	ApproverRole    = "role"
+	ApproverUser    = "user"
+)
+
+// approval workflow main struct, the definition of the approval steps of an entity type
+type ApprovalWorkflow struct {
+	ID          string     `json:"id"`
+	Code        string     `json:"code"`
+	Name        string     `json:"name"`
+	EntityType  string     `json:"entity_type"`
+	Description string     `json:"description"`
+	IsActive    bool       `json:"is_active"`
+	CreatedAt   time.Time  `json:"created_at"`
+	UpdatedAt   time.Time  `json:"updated_at"`
+	DeletedAt   *time.Time `json:"deleted_at"`
+
+	Steps []ApprovalWorkflowStep `json:"steps"`
+}
+
+// the step of the workflow, the steps with the same order run in parallel
+type ApprovalWorkflowStep struct {
+	WorkflowID         string  `json:"workflow_id"`
+	Seq                int     `json:"seq"`
+	StepOrder          int     `json:"step_order"`
+	Name               string  `json:"name"`
+	ApproverType       string  `json:"approver_type"`
+	ApproverPositionID *string `json:"approver_position_id"`
+	ApproverEmployeeID *string `json:"approver_employee_id"`
+	MinAmount          *int64  `json:"min_amount"`
+	TimeoutHours       *int    `json:"timeout_hours"`
+
+	// joined from the 'positions', 'employees' and 'users' table
+	ApproverPositionName string `json:"approver_position_name"`
+	ApproverEmployeeName string `json:"approver_employee_name"`
+}
+
+func (w *ApprovalWorkflow) ToApprovalWorkflowResponse() web.ApprovalWorkflowResponse {
+	response := web.ApprovalWorkflowResponse{
+		ID:          w.ID,
+		Code:        w.Code,
+		Name:        w.Name,
+		EntityType:  w.EntityType,
+		Description: w.Description,
+		IsActive:    w.IsActive,
+		CreatedAt:   w.CreatedAt,
+		UpdatedAt:   w.UpdatedAt,
+	}
+	for _, step := range w.Steps {
+		response.Steps = append(response.Steps, web.ApprovalWorkflowStepResponse{
+			Seq:                  step.Seq,
+			StepOrder:            step.StepOrder,
+			Name:                 step.Name,
+			ApproverType:         step.ApproverType,
+			ApproverPositionID:   step.ApproverPositionID,
+			ApproverPositionName: step.ApproverPositionName,
+			ApproverEmployeeID:   step.ApproverEmployeeID,
+			ApproverEmployeeName: step.ApproverEmployeeName,
+			MinAmount:            step.MinAmount,
+			TimeoutHours:         step.TimeoutHours,
+		})
+	}
+
+	return response
+}
+
+// Applies returns whether the step is required for the amount of the request, a step with a minimum amount is
+// skipped when the request has no amount.
+func (s *ApprovalWorkflowStep) Applies(amount *int64) bool {
+	if s.MinAmount == nil {
+		return true
+	}
+	return amount != nil && *amount >= *s.MinAmount
+}
+
+// Helper function for converting the ApprovalWorkflowRequest from web to domain.
+// Only the ids of the approver of its type are kept.
+func ToDomainApprovalWorkflow(id string, request web.ApprovalWorkflowRequest) ApprovalWorkflow {
+	workflow := ApprovalWorkflow{
+		ID:          id,
+		Code:        request.Code,
+		Name:        request.Name,
+		EntityType:  request.EntityType,
+		Description: request.Description,
+		IsActive:    request.IsActive,
+		CreatedAt:   time.Now(),
+		UpdatedAt:   time.Now(),
+	}
+	for i, step := range request.Steps {
+		workflowStep := ApprovalWorkflowStep{
+			WorkflowID:   id,
+			Seq:          i + 1,
+			StepOrder:    step.StepOrder,
+			Name:         step.Name,
+			ApproverType: step.ApproverType,
+			MinAmount:    step.MinAmount,
+			TimeoutHours: step.TimeoutHours,
+		}
+		switch step.ApproverType {
+		case ApproverRole:
+			workflowStep.ApproverPositionID = step.ApproverPositionID
+		case ApproverUser:
+			workflowStep.ApproverEmployeeID = step.ApproverEmployeeID
+		}
+		workflow.Steps = append(workflow.Steps, workflowStep)
+	}
+
+	return workflow
+}
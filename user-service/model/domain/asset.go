@@ -0,0 +1,233 @@
+package domain
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/iqbaludinm/hr-microservice/user-service/helper"
+	"github.com/iqbaludinm/hr-microservice/user-service/model/web"
+)
+
+// Status of the asset, an asset is assigned when it is held by an employee.
+const (
+	AssetStatusAvailable   = "available"
+	AssetStatusAssigned    = "assigned"
+	AssetStatusMaintenance = "maintenance"
+	AssetStatusRetired     = "retired"
+)
+
+// Condition of the asset, a lost asset can only be set when the asset is returned.
+const (
+	AssetConditionNew     = "new"
+	AssetConditionGood    = "good"
+	AssetConditionFair    = "fair"
+	AssetConditionDamaged = "damaged"
+	AssetConditionLost    = "lost"
+)
+
+// Event of the asset assignment that produces a handover document.
+const (
+	AssetEventAssign = "assign"
+	AssetEventReturn = "return"
+)
+
+// asset main struct, the company asset in the inventory
+type Asset struct {
+	ID            string     `json:"id"`
+	AssetCode     string     `json:"asset_code"`
+	Name          string     `json:"name"`
+	Category      string     `json:"category"`
+	Brand         string     `json:"brand"`
+	SerialNumber  string     `json:"serial_number"`
+	PurchaseDate  *time.Time `json:"purchase_date"`
+	PurchasePrice float64    `json:"purchase_price"`
+	Condition     string     `json:"condition"`
+	Location      string     `json:"location"`
+	Status        string     `json:"status"`
+	EmployeeID    *string    `json:"employee_id"`
+	Note          string     `json:"note"`
+	CreatedAt     time.Time  `json:"created_at"`
+	UpdatedAt     time.Time  `json:"updated_at"`
+	DeletedAt     *time.Time `json:"deleted_at"`
+
+	// joined from the 'employees', 'users' and the open 'asset_assignments' of the asset
+	EmployeeNumber string     `json:"employee_number"`
+	EmployeeName   string     `json:"employee_name"`
+	AssignmentID   *string    `json:"assignment_id"`
+	AssignedAt     *time.Time `json:"assigned_at"`
+}
+
+// the assignment of an asset to an employee, the assignment is open until the asset is returned
+type AssetAssignment struct {
+	ID               string     `json:"id"`
+	AssetID          string     `json:"asset_id"`
+	EmployeeID       string     `json:"employee_id"`
+	AssignedAt       time.Time  `json:"assigned_at"`
+	AssignedBy       *string    `json:"assigned_by"`
+	AssignCondition  string     `json:"assign_condition"`
+	AssignNote       string     `json:"assign_note"`
+	AssignHandoverID *string    `json:"assign_handover_id"`
+	ReturnedAt       *time.Time `json:"returned_at"`
+	ReturnedBy       *string    `json:"returned_by"`
+	ReturnCondition  *string    `json:"return_condition"`
+	ReturnNote       string     `json:"return_note"`
+	ReturnHandoverID *string    `json:"return_handover_id"`
+	CreatedAt        time.Time  `json:"created_at"`
+	UpdatedAt        time.Time  `json:"updated_at"`
+
+	// joined from the 'assets', 'employees' and 'users' table
+	AssetCode      string `json:"asset_code"`
+	AssetName      string `json:"asset_name"`
+	EmployeeNumber string `json:"employee_number"`
+	EmployeeName   string `json:"employee_name"`
+}
+
+// HandoverID returns the handover document of the event of the assignment.
+func (a *AssetAssignment) HandoverID(event string) *string {
+	if event == AssetEventReturn {
+		return a.ReturnHandoverID
+	}
+	return a.AssignHandoverID
+}
+
+// NewAssetHandover returns the draft handover document of the event of the assignment, from the employee who
+// hands over the asset to the employee who receives it. The asset is the only item of the handover, with the
+// condition when the asset is handed over.
+func NewAssetHandover(asset Asset, assignment AssetAssignment, event, fromEmployeeID, toEmployeeID, location string, signers []web.HandoverSignerRequest) Handover {
+	title := fmt.Sprintf("Serah Terima Aset %s", asset.AssetCode)
+	handoverDate, condition, note := assignment.AssignedAt, assignment.AssignCondition, assignment.AssignNote
+	if event == AssetEventReturn && assignment.ReturnedAt != nil {
+		title = fmt.Sprintf("Pengembalian Aset %s", asset.AssetCode)
+		handoverDate, note = *assignment.ReturnedAt, assignment.ReturnNote
+		if assignment.ReturnCondition != nil {
+			condition = *assignment.ReturnCondition
+		}
+	}
+	if location == "" {
+		location = asset.Location
+	}
+
+	handover := Handover{
+		Type:           HandoverTypeAsset,
+		Title:          title,
+		Location:       location,
+		HandoverDate:   handoverDate,
+		FromEmployeeID: fromEmployeeID,
+		ToEmployeeID:   toEmployeeID,
+		Items: []HandoverItem{{
+			Seq:          1,
+			Name:         asset.Name,
+			Description:  strings.TrimSpace(fmt.Sprintf("%s - %s %s", asset.AssetCode, asset.Category, asset.Brand)),
+			Quantity:     1,
+			Unit:         "unit",
+			SerialNumber: asset.SerialNumber,
+			Condition:    condition,
+			Note:         note,
+		}},
+	}
+
+	// the employee who hands over signs first, then the employee who receives
+	if len(signers) == 0 {
+		signers = []web.HandoverSignerRequest{
+			{EmployeeID: fromEmployeeID, Role: "Yang Menyerahkan"},
+			{EmployeeID: toEmployeeID, Role: "Yang Menerima"},
+		}
+	}
+	for i, signer := range signers {
+		handover.Signers = append(handover.Signers, HandoverSigner{
+			Seq:        i + 1,
+			EmployeeID: signer.EmployeeID,
+			Role:       signer.Role,
+			Status:     HandoverSignerPending,
+		})
+	}
+
+	return handover
+}
+
+func (a *Asset) ToAssetResponse() web.AssetResponse {
+	return web.AssetResponse{
+		ID:             a.ID,
+		AssetCode:      a.AssetCode,
+		Name:           a.Name,
+		Category:       a.Category,
+		Brand:          a.Brand,
+		SerialNumber:   a.SerialNumber,
+		PurchaseDate:   formatOptionalDate(a.PurchaseDate),
+		PurchasePrice:  a.PurchasePrice,
+		Condition:      a.Condition,
+		Location:       a.Location,
+		Status:         a.Status,
+		Note:           a.Note,
+		CreatedAt:      a.CreatedAt,
+		UpdatedAt:      a.UpdatedAt,
+		EmployeeID:     a.EmployeeID,
+		EmployeeNumber: a.EmployeeNumber,
+		EmployeeName:   a.EmployeeName,
+		AssignmentID:   a.AssignmentID,
+		AssignedAt:     formatOptionalDate(a.AssignedAt),
+	}
+}
+
+func (a *AssetAssignment) ToAssetAssignmentResponse() web.AssetAssignmentResponse {
+	return web.AssetAssignmentResponse{
+		ID:               a.ID,
+		AssetID:          a.AssetID,
+		AssetCode:        a.AssetCode,
+		AssetName:        a.AssetName,
+		EmployeeID:       a.EmployeeID,
+		EmployeeNumber:   a.EmployeeNumber,
+		EmployeeName:     a.EmployeeName,
+		AssignedAt:       a.AssignedAt.Format(helper.DateLayout),
+		AssignedBy:       a.AssignedBy,
+		AssignCondition:  a.AssignCondition,
+		AssignNote:       a.AssignNote,
+		AssignHandoverID: a.AssignHandoverID,
+		ReturnedAt:       formatOptionalDate(a.ReturnedAt),
+		ReturnedBy:       a.ReturnedBy,
+		ReturnCondition:  a.ReturnCondition,
+		ReturnNote:       a.ReturnNote,
+		ReturnHandoverID: a.ReturnHandoverID,
+		CreatedAt:        a.CreatedAt,
+		UpdatedAt:        a.UpdatedAt,
+	}
+}
+
+// Helper function for converting the AssetRequest from web to domain, the status is available when it is not
+// filled
+func ToDomainAsset(request web.AssetRequest) Asset {
+	var purchaseDate *time.Time
+	if request.PurchaseDate != "" {
+		date, _ := helper.ParseDate(request.PurchaseDate)
+		purchaseDate = &date
+	}
+
+	status := request.Status
+	if status == "" {
+		status = AssetStatusAvailable
+	}
+
+	return Asset{
+		AssetCode:     request.AssetCode,
+		Name:          request.Name,
+		Category:      request.Category,
+		Brand:         request.Brand,
+		SerialNumber:  request.SerialNumber,
+		PurchaseDate:  purchaseDate,
+		PurchasePrice: request.PurchasePrice,
+		Condition:     request.Condition,
+		Location:      request.Location,
+		Status:        status,
+		Note:          request.Note,
+	}
+}
+
+// format the optional date with the DateLayout, it is nil when the date is not set
+func formatOptionalDate(date *time.Time) *string {
+	if date == nil {
+		return nil
+	}
+	value := date.Format(helper.DateLayout)
+	return &value
+}
@@ -0,0 +1,70 @@
+package domain
+
+import (
+	"fmt"
+
+	"github.com/iqbaludinm/hr-microservice/user-service/model/web"
+)
+
+type AssetQueryFilter struct {
+	Category  string
+	Status    string
+	Condition string
+	// EmployeeID filters the assets held by the employee
+	EmployeeID string
+	// Search filters the assets by the asset code, the name or the serial number
+	Search string
+
+	// Pagination is used for fetching the data by page. The default value is 1.
+	Pagination Pagination
+}
+
+// BuildAssetQueries builds the WHERE clause of the asset query, the deleted assets are never returned.
+// The values are returned as 'args' so they are sent as query parameters.
+func (q *AssetQueryFilter) BuildAssetQueries() (filter string, args []interface{}, pagination string) {
+	filter = "WHERE a.deleted_at IS NULL"
+	add := func(condition string, value interface{}) {
+		args = append(args, value)
+		filter += fmt.Sprintf(" AND "+condition, len(args))
+	}
+
+	// filter asset by category
+	if q.Category != "" {
+		add("a.category = $%d", q.Category)
+	}
+
+	// filter asset by status
+	if q.Status != "" {
+		add("a.status = $%d", q.Status)
+	}
+
+	// filter asset by condition
+	if q.Condition != "" {
+		add("a.condition = $%d", q.Condition)
+	}
+
+	// filter asset by the employee who holds the asset
+	if q.EmployeeID != "" {
+		add("a.employee_id = $%d", q.EmployeeID)
+	}
+
+	// filter asset by the asset code, the name or the serial number
+	if q.Search != "" {
+		add("(a.asset_code ILIKE '%%' || $%[1]d || '%%' OR a.name ILIKE '%%' || $%[1]d || '%%' OR a.serial_number ILIKE '%%' || $%[1]d || '%%')", q.Search)
+	}
+
+	return filter, args, q.Pagination.Build()
+}
+
+// Helper function for converting the AssetQueryFilter from web to domain
+// This function is used for calling a function in the 'repository' layer
+func ToDomainAssetQueryFilter(q web.AssetQueryFilter) AssetQueryFilter {
+	return AssetQueryFilter{
+		Category:   q.Category,
+		Status:     q.Status,
+		Condition:  q.Condition,
+		EmployeeID: q.EmployeeID,
+		Search:     q.Search,
+		Pagination: NewPagination(q.Page, q.Limit),
+	}
+}
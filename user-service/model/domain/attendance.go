@@ -0,0 +1,180 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/iqbaludinm/hr-microservice/user-service/helper"
+	"github.com/iqbaludinm/hr-microservice/user-service/model/web"
+)
+
+// attendance main struct, an employee has one attendance per work date
+type Attendance struct {
+	ID                 string     `json:"id"`
+	EmployeeID         string     `json:"employee_id"`
+	Date               time.Time  `json:"date"`
+	ShiftID            *string    `json:"shift_id"`
+	ScheduledStart     *time.Time `json:"scheduled_start"`
+	ScheduledEnd       *time.Time `json:"scheduled_end"`
+	CheckInAt          time.Time  `json:"check_in_at"`
+	CheckInLatitude    *float64   `json:"check_in_latitude"`
+	CheckInLongitude   *float64   `json:"check_in_longitude"`
+	CheckInLocationID  *string    `json:"check_in_location_id"`
+	CheckInPhoto       string     `json:"check_in_photo"`
+	CheckOutAt         *time.Time `json:"check_out_at"`
+	CheckOutLatitude   *float64   `json:"check_out_latitude"`
+	CheckOutLongitude  *float64   `json:"check_out_longitude"`
+	CheckOutLocationID *string    `json:"check_out_location_id"`
+	CheckOutPhoto      string     `json:"check_out_photo"`
+	LateMinutes        int        `json:"late_minutes"`
+	EarlyLeaveMinutes  int        `json:"early_leave_minutes"`
+	OvertimeMinutes    int        `json:"overtime_minutes"`
+	WorkMinutes        int        `json:"work_minutes"`
+	CreatedAt          time.Time  `json:"created_at"`
+	UpdatedAt          time.Time  `json:"updated_at"`
+
+	// joined from the 'employees', 'users', 'departments' and 'shifts' table
+	EmployeeNumber string `json:"employee_number"`
+	EmployeeName   string `json:"employee_name"`
+	DepartmentName string `json:"department_name"`
+	ShiftName      string `json:"shift_name"`
+}
+
+func (a *Attendance) ToAttendanceResponse() web.AttendanceResponse {
+	return web.AttendanceResponse{
+		ID:                 a.ID,
+		EmployeeID:         a.EmployeeID,
+		EmployeeNumber:     a.EmployeeNumber,
+		EmployeeName:       a.EmployeeName,
+		DepartmentName:     a.DepartmentName,
+		Date:               a.Date.Format(helper.DateLayout),
+		ShiftID:            a.ShiftID,
+		ShiftName:          a.ShiftName,
+		ScheduledStart:     a.ScheduledStart,
+		ScheduledEnd:       a.ScheduledEnd,
+		CheckInAt:          a.CheckInAt,
+		CheckInLatitude:    a.CheckInLatitude,
+		CheckInLongitude:   a.CheckInLongitude,
+		CheckInLocationID:  a.CheckInLocationID,
+		CheckInPhoto:       a.CheckInPhoto,
+		CheckOutAt:         a.CheckOutAt,
+		CheckOutLatitude:   a.CheckOutLatitude,
+		CheckOutLongitude:  a.CheckOutLongitude,
+		CheckOutLocationID: a.CheckOutLocationID,
+		CheckOutPhoto:      a.CheckOutPhoto,
+		IsLate:             a.LateMinutes > 0,
+		LateMinutes:        a.LateMinutes,
+		IsEarlyLeave:       a.EarlyLeaveMinutes > 0,
+		EarlyLeaveMinutes:  a.EarlyLeaveMinutes,
+		OvertimeMinutes:    a.OvertimeMinutes,
+		WorkMinutes:        a.WorkMinutes,
+		CreatedAt:          a.CreatedAt,
+		UpdatedAt:          a.UpdatedAt,
+	}
+}
+
+// NewAttendance creates the attendance of the check-in. When the employee has a shift, the attendance is
+// scheduled on the work date of the shift and the late arrival is computed against the shift start.
+func NewAttendance(employeeID string, shift *Shift, checkInAt time.Time) Attendance {
+	attendance := Attendance{
+		EmployeeID: employeeID,
+		CheckInAt:  checkInAt,
+	}
+
+	if shift == nil {
+		local := checkInAt.In(helper.WIB)
+		attendance.Date = time.Date(local.Year(), local.Month(), local.Day(), 0, 0, 0, 0, time.UTC)
+		return attendance
+	}
+
+	attendance.Date = shift.WorkDate(checkInAt)
+	start, end := shift.Schedule(attendance.Date)
+	start, end = start.UTC(), end.UTC()
+	attendance.ShiftID = &shift.ID
+	attendance.ScheduledStart = &start
+	attendance.ScheduledEnd = &end
+
+	if late := minutesBetween(start, checkInAt); late > shift.LateToleranceMinutes {
+		attendance.LateMinutes = late
+	}
+
+	return attendance
+}
+
+// CheckOut records the check-out and computes the work time (without the break), early leave and overtime
+// against the shift.
+func (a *Attendance) CheckOut(shift *Shift, checkOutAt time.Time) {
+	a.CheckOutAt = &checkOutAt
+	a.WorkMinutes = minutesBetween(a.CheckInAt, checkOutAt)
+	a.EarlyLeaveMinutes = 0
+	a.OvertimeMinutes = 0
+
+	if shift == nil || a.ScheduledEnd == nil {
+		return
+	}
+
+	// the break of the shift is not counted as work time
+	a.WorkMinutes -= shift.BreakMinutes
+	if a.WorkMinutes < 0 {
+		a.WorkMinutes = 0
+	}
+
+	if early := minutesBetween(checkOutAt, *a.ScheduledEnd); early > shift.EarlyLeaveToleranceMinutes {
+		a.EarlyLeaveMinutes = early
+	}
+	if overtime := minutesBetween(*a.ScheduledEnd, checkOutAt); overtime > 0 && overtime >= shift.MinOvertimeMinutes {
+		a.OvertimeMinutes = overtime
+	}
+}
+
+// minutesBetween returns the whole minutes from 'from' to 'to', a negative duration returns 0.
+func minutesBetween(from, to time.Time) int {
+	if !to.After(from) {
+		return 0
+	}
+	return int(to.Sub(from) / time.Minute)
+}
+
+// The summary of the attendances, per employee or per department.
+type AttendanceSummary struct {
+	EmployeeID     string
+	EmployeeNumber string
+	EmployeeName   string
+	DepartmentID   string
+	DepartmentName string
+
+	PresentDays       int
+	LateDays          int
+	EarlyLeaveDays    int
+	MissingCheckOut   int
+	LateMinutes       int
+	EarlyLeaveMinutes int
+	OvertimeMinutes   int
+	WorkMinutes       int
+}
+
+func (s *AttendanceSummary) ToAttendanceSummaryItem() web.AttendanceSummaryItem {
+	return web.AttendanceSummaryItem{
+		EmployeeID:        s.EmployeeID,
+		EmployeeNumber:    s.EmployeeNumber,
+		EmployeeName:      s.EmployeeName,
+		DepartmentID:      s.DepartmentID,
+		DepartmentName:    s.DepartmentName,
+		PresentDays:       s.PresentDays,
+		LateDays:          s.LateDays,
+		EarlyLeaveDays:    s.EarlyLeaveDays,
+		MissingCheckOut:   s.MissingCheckOut,
+		LateMinutes:       s.LateMinutes,
+		EarlyLeaveMinutes: s.EarlyLeaveMinutes,
+		OvertimeMinutes:   s.OvertimeMinutes,
+		WorkMinutes:       s.WorkMinutes,
+	}
+}
+
+// CheckOutDeadline returns the last time the attendance can be checked out, 6 hours after the end of the shift
+// (or the end of the work date without a shift). After it, the check-out is considered missing.
+func (a *Attendance) CheckOutDeadline() time.Time {
+	if a.ScheduledEnd != nil {
+		return a.ScheduledEnd.Add(6 * time.Hour)
+	}
+	return time.Date(a.Date.Year(), a.Date.Month(), a.Date.Day(), 0, 0, 0, 0, helper.WIB).AddDate(0, 0, 1)
+}
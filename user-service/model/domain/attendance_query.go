@@ -0,0 +1,67 @@
+package domain
+
+import (
+	"fmt"
+
+	"github.com/iqbaludinm/hr-microservice/user-service/model/web"
+)
+
+type AttendanceQueryFilter struct {
+	EmployeeID string
+	// DepartmentIDs filters the attendances of the employees in the departments, e.g. a department and its sub-departments
+	DepartmentIDs []string
+	// From and To filter the work date (inclusive), with the DateLayout format
+	From string
+	To   string
+
+	// Pagination is used for fetching the data by page. The default value is 1.
+	Pagination Pagination
+}
+
+// BuildAttendanceQueries builds the WHERE clause of the attendance query.
+// The values are returned as 'args' so they are sent as query parameters.
+func (q *AttendanceQueryFilter) BuildAttendanceQueries() (filter string, args []interface{}, pagination string) {
+	add := func(condition string, value interface{}) {
+		args = append(args, value)
+		filter += fmt.Sprintf(" AND "+condition, len(args))
+	}
+
+	// filter attendance by employee
+	if q.EmployeeID != "" {
+		add("a.employee_id = $%d", q.EmployeeID)
+	}
+
+	// filter attendance by the departments of the employees
+	if len(q.DepartmentIDs) > 0 {
+		add("e.department_id = ANY($%d::uuid[])", q.DepartmentIDs)
+	}
+
+	// filter attendance on or after the 'from' date
+	if q.From != "" {
+		add("a.date >= $%d::date", q.From)
+	}
+
+	// filter attendance on or before the 'to' date
+	if q.To != "" {
+		add("a.date <= $%d::date", q.To)
+	}
+
+	// remove the first ' AND ' from the filter
+	if len(filter) > 0 {
+		filter = "WHERE " + filter[5:]
+	}
+
+	return filter, args, q.Pagination.Build()
+}
+
+// Helper function for converting the AttendanceQueryFilter from web to domain
+// This function is used for calling a function in the 'repository' layer.
+// The department is resolved to its sub-departments by the service.
+func ToDomainAttendanceQueryFilter(q web.AttendanceQueryFilter) AttendanceQueryFilter {
+	return AttendanceQueryFilter{
+		EmployeeID: q.EmployeeID,
+		From:       q.From,
+		To:         q.To,
+		Pagination: NewPagination(q.Page, q.Limit),
+	}
+}
@@ -0,0 +1,122 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/iqbaludinm/hr-microservice/user-service/model/web"
+)
+
+// department main struct
+type Department struct {
+	ID        string     `json:"id"`
+	Code      string     `json:"code"`
+	Name      string     `json:"name"`
+	ParentID  *string    `json:"parent_id"`
+	CreatedAt time.Time  `json:"created_at"`
+	UpdatedAt time.Time  `json:"updated_at"`
+	DeletedAt *time.Time `json:"deleted_at"`
+}
+
+func (d *Department) ToDepartmentResponse() web.DepartmentResponse {
+	return web.DepartmentResponse{
+		ID:        d.ID,
+		Code:      d.Code,
+		Name:      d.Name,
+		ParentID:  d.ParentID,
+		CreatedAt: d.CreatedAt,
+		UpdatedAt: d.UpdatedAt,
+	}
+}
+
+// BuildDepartmentTree converts the flat list of departments to a tree, started from the root
+// departments (the departments without parent or with a parent that is not in the list).
+func BuildDepartmentTree(departments []Department) []web.DepartmentTreeResponse {
+	exist := map[string]bool{}
+	children := map[string][]Department{}
+	for _, department := range departments {
+		exist[department.ID] = true
+	}
+
+	var roots []Department
+	for _, department := range departments {
+		if department.ParentID == nil || !exist[*department.ParentID] {
+			roots = append(roots, department)
+			continue
+		}
+		children[*department.ParentID] = append(children[*department.ParentID], department)
+	}
+
+	var build func(department Department) web.DepartmentTreeResponse
+	build = func(department Department) web.DepartmentTreeResponse {
+		node := web.DepartmentTreeResponse{
+			DepartmentResponse: department.ToDepartmentResponse(),
+			Children:           []web.DepartmentTreeResponse{},
+		}
+		for _, child := range children[department.ID] {
+			node.Children = append(node.Children, build(child))
+		}
+		return node
+	}
+
+	tree := []web.DepartmentTreeResponse{}
+	for _, root := range roots {
+		tree = append(tree, build(root))
+	}
+
+	return tree
+}
+
+// BuildOrgChart converts the departments and their members to the org chart tree.
+// The head count of a department includes the members of its sub-departments.
+func BuildOrgChart(departments []Department, employees []Employee) []web.OrgChartDepartment {
+	members := map[string][]web.OrgChartMember{}
+	for _, employee := range employees {
+		members[employee.DepartmentID] = append(members[employee.DepartmentID], web.OrgChartMember{
+			ID:             employee.ID,
+			EmployeeNumber: employee.EmployeeNumber,
+			Name:           employee.Name,
+			JobTitle:       employee.JobTitle,
+			Position:       employee.Position,
+			ManagerID:      employee.ManagerID,
+		})
+	}
+
+	var build func(node web.DepartmentTreeResponse) web.OrgChartDepartment
+	build = func(node web.DepartmentTreeResponse) web.OrgChartDepartment {
+		chart := web.OrgChartDepartment{
+			ID:       node.ID,
+			Code:     node.Code,
+			Name:     node.Name,
+			Members:  members[node.ID],
+			Children: []web.OrgChartDepartment{},
+		}
+		if chart.Members == nil {
+			chart.Members = []web.OrgChartMember{}
+		}
+		chart.HeadCount = len(chart.Members)
+		for _, child := range node.Children {
+			childChart := build(child)
+			chart.HeadCount += childChart.HeadCount
+			chart.Children = append(chart.Children, childChart)
+		}
+		return chart
+	}
+
+	charts := []web.OrgChartDepartment{}
+	for _, root := range BuildDepartmentTree(departments) {
+		charts = append(charts, build(root))
+	}
+
+	return charts
+}
+
+// Helper function for converting the DepartmentQueryFilter from web to domain
+// This function is used for calling a function in the 'repository' layer
+func ToDomainDepartmentQueryFilter(q web.DepartmentQueryFilter) DepartmentQueryFilter {
+	return DepartmentQueryFilter{
+		Name:        q.Name,
+		ParentID:    q.ParentID,
+		ShowDeleted: q.ShowDeleted,
+		Pagination:  NewPagination(q.Page, q.Limit),
+	}
+}
@@ -0,0 +1,45 @@
+package domain
+
+import "fmt"
+
+type DepartmentQueryFilter struct {
+	Name     string
+	ParentID string
+
+	// ShowDeleted is used for showing the soft-deleted department or not.
+	ShowDeleted bool
+
+	// Pagination is used for fetching the data by page. The default value is 1.
+	Pagination Pagination
+}
+
+// BuildDepartmentQueries builds the WHERE clause of the department query.
+// The values are returned as 'args' so they are sent as query parameters.
+func (q *DepartmentQueryFilter) BuildDepartmentQueries() (filter string, args []interface{}, pagination string) {
+	add := func(condition string, value interface{}) {
+		args = append(args, value)
+		filter += fmt.Sprintf(" AND "+condition, len(args))
+	}
+
+	// filter department by name
+	if q.Name != "" {
+		add("d.name ILIKE '%%' || $%d || '%%'", q.Name)
+	}
+
+	// filter department by parent
+	if q.ParentID != "" {
+		add("d.parent_id = $%d", q.ParentID)
+	}
+
+	// filter department by deleted_at is null
+	if !q.ShowDeleted {
+		filter += " AND d.deleted_at is null"
+	}
+
+	// remove the first ' AND ' from the filter
+	if len(filter) > 0 {
+		filter = "WHERE " + filter[5:]
+	}
+
+	return filter, args, q.Pagination.Build()
+}
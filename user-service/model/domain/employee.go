@@ -0,0 +1,146 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/iqbaludinm/hr-microservice/user-service/helper"
+	"github.com/iqbaludinm/hr-microservice/user-service/model/web"
+)
+
+// Gender of the employee.
+const (
+	GenderMale   = "male"
+	GenderFemale = "female"
+)
+
+// Employment type of the employee.
+const (
+	EmploymentTypePermanent  = "permanent"
+	EmploymentTypeContract   = "contract"
+	EmploymentTypeProbation  = "probation"
+	EmploymentTypeInternship = "internship"
+	EmploymentTypeOutsource  = "outsource"
+)
+
+// Employment status of the employee.
+const (
+	EmployeeStatusActive     = "active"
+	EmployeeStatusSuspended  = "suspended"
+	EmployeeStatusResigned   = "resigned"
+	EmployeeStatusTerminated = "terminated"
+)
+
+// employee main struct
+type Employee struct {
+	ID                string             `json:"id"`
+	UserID            string             `json:"user_id"`
+	EmployeeNumber    string             `json:"employee_number"`
+	DateOfBirth       time.Time          `json:"date_of_birth"`
+	Gender            string             `json:"gender"`
+	NIK               string             `json:"nik"`
+	Address           string             `json:"address"`
+	HireDate          time.Time          `json:"hire_date"`
+	EmploymentType    string             `json:"employment_type"`
+	Status            string             `json:"status"`
+	JobTitle          string             `json:"job_title"`
+	SalaryGrade       string             `json:"salary_grade"`
+	DepartmentID      string             `json:"department_id"`
+	PositionID        *string            `json:"position_id"`
+	ManagerID         *string            `json:"manager_id"`
+	ShiftID           *string            `json:"shift_id"`
+	OfficeLocationID  *string            `json:"office_location_id"`
+	EmergencyContacts []EmergencyContact `json:"emergency_contacts"`
+	CreatedAt         time.Time          `json:"created_at"`
+	UpdatedAt         time.Time          `json:"updated_at"`
+	DeletedAt         *time.Time         `json:"deleted_at"`
+
+	// These fields are joined from the 'users', 'departments', 'positions' and 'office_locations' table,
+	// they are never written from the employee.
+	Name           string  `json:"name"`
+	Email          string  `json:"email"`
+	Phone          string  `json:"phone"`
+	Department     string  `json:"department"`
+	Position       string  `json:"position"`
+	WorkCalendarID *string `json:"work_calendar_id"`
+}
+
+// EmergencyContact is stored as a 'jsonb' array in the 'employees' table.
+type EmergencyContact struct {
+	Name         string `json:"name"`
+	Relationship string `json:"relationship"`
+	Phone        string `json:"phone"`
+	Address      string `json:"address"`
+}
+
+func (e *Employee) ToEmployeeResponse() web.EmployeeResponse {
+	emergencyContacts := make([]web.EmergencyContactResponse, 0, len(e.EmergencyContacts))
+	for _, contact := range e.EmergencyContacts {
+		emergencyContacts = append(emergencyContacts, web.EmergencyContactResponse{
+			Name:         contact.Name,
+			Relationship: contact.Relationship,
+			Phone:        contact.Phone,
+			Address:      contact.Address,
+		})
+	}
+
+	return web.EmployeeResponse{
+		ID:                e.ID,
+		UserID:            e.UserID,
+		Name:              e.Name,
+		Email:             e.Email,
+		Phone:             e.Phone,
+		EmployeeNumber:    e.EmployeeNumber,
+		DateOfBirth:       e.DateOfBirth.Format(helper.DateLayout),
+		Gender:            e.Gender,
+		NIK:               e.NIK,
+		Address:           e.Address,
+		HireDate:          e.HireDate.Format(helper.DateLayout),
+		EmploymentType:    e.EmploymentType,
+		Status:            e.Status,
+		JobTitle:          e.JobTitle,
+		SalaryGrade:       e.SalaryGrade,
+		DepartmentID:      e.DepartmentID,
+		Department:        e.Department,
+		PositionID:        e.PositionID,
+		Position:          e.Position,
+		ManagerID:         e.ManagerID,
+		ShiftID:           e.ShiftID,
+		OfficeLocationID:  e.OfficeLocationID,
+		WorkCalendarID:    e.WorkCalendarID,
+		EmergencyContacts: emergencyContacts,
+		CreatedAt:         e.CreatedAt,
+		UpdatedAt:         e.UpdatedAt,
+	}
+}
+
+// Convert the emergency contacts from the request body to the domain
+func ToDomainEmergencyContacts(requests []web.EmergencyContactRequest) []EmergencyContact {
+	contacts := make([]EmergencyContact, 0, len(requests))
+	for _, request := range requests {
+		contacts = append(contacts, EmergencyContact{
+			Name:         request.Name,
+			Relationship: request.Relationship,
+			Phone:        request.Phone,
+			Address:      request.Address,
+		})
+	}
+
+	return contacts
+}
+
+// Helper function for converting the EmployeeQueryFilter from web to domain
+// This function is used for calling a function in the 'repository' layer
+func ToDomainEmployeeQueryFilter(q web.EmployeeQueryFilter) EmployeeQueryFilter {
+	return EmployeeQueryFilter{
+		Name:           q.Name,
+		EmployeeNumber: q.EmployeeNumber,
+		DepartmentID:   q.DepartmentID,
+		PositionID:     q.PositionID,
+		JobTitle:       q.JobTitle,
+		EmploymentType: q.EmploymentType,
+		Status:         q.Status,
+		ManagerID:      q.ManagerID,
+		ShowDeleted:    q.ShowDeleted,
+		Pagination:     NewPagination(q.Page, q.Limit),
+	}
+}
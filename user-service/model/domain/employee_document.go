@@ -0,0 +1,154 @@
+package domain
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/iqbaludinm/hr-microservice/user-service/helper"
+	"github.com/iqbaludinm/hr-microservice/user-service/model/web"
+)
+
+// Type of the employee document.
+const (
+	EmployeeDocumentTypeKTP         = "ktp"
+	EmployeeDocumentTypeNPWP        = "npwp"
+	EmployeeDocumentTypeContract    = "contract"
+	EmployeeDocumentTypeCertificate = "certificate"
+	EmployeeDocumentTypeOther       = "other"
+)
+
+// Access of the employee document, who can view the document besides HR.
+const (
+	EmployeeDocumentAccessEmployee = "employee"
+	EmployeeDocumentAccessManager  = "manager"
+	EmployeeDocumentAccessHR       = "hr"
+)
+
+// employee document main struct, the file of the document is kept in the versions
+type EmployeeDocument struct {
+	ID                 string     `json:"id"`
+	EmployeeID         string     `json:"employee_id"`
+	Type               string     `json:"type"`
+	Title              string     `json:"title"`
+	Number             string     `json:"number"`
+	ExpiryDate         *time.Time `json:"expiry_date"`
+	Access             string     `json:"access"`
+	LatestVersion      int        `json:"latest_version"`
+	RemindedExpiryDate *time.Time `json:"reminded_expiry_date"`
+	CreatedBy          *string    `json:"created_by"`
+	CreatedAt          time.Time  `json:"created_at"`
+	UpdatedAt          time.Time  `json:"updated_at"`
+	DeletedAt          *time.Time `json:"deleted_at"`
+
+	Versions []EmployeeDocumentVersion `json:"versions"`
+
+	// joined from the 'employees', 'users' and the latest 'employee_document_versions'
+	EmployeeNumber string `json:"employee_number"`
+	EmployeeName   string `json:"employee_name"`
+	EmployeeUserID string `json:"employee_user_id"`
+	FileName       string `json:"file_name"`
+	MimeType       string `json:"mime_type"`
+	Size           int64  `json:"size"`
+	Checksum       string `json:"checksum"`
+}
+
+// the uploaded file of the employee document
+type EmployeeDocumentVersion struct {
+	DocumentID string    `json:"document_id"`
+	Version    int       `json:"version"`
+	StorageKey string    `json:"storage_key"`
+	FileName   string    `json:"file_name"`
+	MimeType   string    `json:"mime_type"`
+	Size       int64     `json:"size"`
+	Checksum   string    `json:"checksum"`
+	Note       string    `json:"note"`
+	UploadedBy *string   `json:"uploaded_by"`
+	UploadedAt time.Time `json:"uploaded_at"`
+}
+
+// Expired returns whether the expiry date of the document has passed.
+func (d *EmployeeDocument) Expired() bool {
+	return d.ExpiryDate != nil && d.ExpiryDate.Before(helper.Today())
+}
+
+// NewStorageKey returns the storage key of the version of the document, the extension of the file is kept.
+func (d *EmployeeDocument) NewStorageKey(version int, fileName string) string {
+	return fmt.Sprintf("documents/%s/%s/v%d%s", d.EmployeeID, d.ID, version, strings.ToLower(filepath.Ext(fileName)))
+}
+
+// FindVersion returns the version of the document, the latest version is returned when the version is 0.
+func (d *EmployeeDocument) FindVersion(version int) (EmployeeDocumentVersion, bool) {
+	if version == 0 {
+		version = d.LatestVersion
+	}
+	for _, v := range d.Versions {
+		if v.Version == version {
+			return v, true
+		}
+	}
+	return EmployeeDocumentVersion{}, false
+}
+
+func (d *EmployeeDocument) ToEmployeeDocumentResponse() web.EmployeeDocumentResponse {
+	var expiryDate *string
+	if d.ExpiryDate != nil {
+		date := d.ExpiryDate.Format(helper.DateLayout)
+		expiryDate = &date
+	}
+
+	var versions []web.EmployeeDocumentVersionResponse
+	for _, v := range d.Versions {
+		versions = append(versions, web.EmployeeDocumentVersionResponse{
+			Version:    v.Version,
+			FileName:   v.FileName,
+			MimeType:   v.MimeType,
+			Size:       v.Size,
+			Checksum:   v.Checksum,
+			Note:       v.Note,
+			UploadedBy: v.UploadedBy,
+			UploadedAt: v.UploadedAt,
+		})
+	}
+
+	return web.EmployeeDocumentResponse{
+		ID:             d.ID,
+		EmployeeID:     d.EmployeeID,
+		EmployeeNumber: d.EmployeeNumber,
+		EmployeeName:   d.EmployeeName,
+		Type:           d.Type,
+		Title:          d.Title,
+		Number:         d.Number,
+		ExpiryDate:     expiryDate,
+		Expired:        d.Expired(),
+		Access:         d.Access,
+		LatestVersion:  d.LatestVersion,
+		FileName:       d.FileName,
+		MimeType:       d.MimeType,
+		Size:           d.Size,
+		Checksum:       d.Checksum,
+		CreatedBy:      d.CreatedBy,
+		CreatedAt:      d.CreatedAt,
+		UpdatedAt:      d.UpdatedAt,
+		Versions:       versions,
+	}
+}
+
+// Helper function for converting the EmployeeDocumentRequest from web to domain, the access is 'employee' when it
+// is not filled
+func ToDomainEmployeeDocument(request web.EmployeeDocumentRequest) EmployeeDocument {
+	access := request.Access
+	if access == "" {
+		access = EmployeeDocumentAccessEmployee
+	}
+
+	return EmployeeDocument{
+		EmployeeID: request.EmployeeID,
+		Type:       request.Type,
+		Title:      request.Title,
+		Number:     request.Number,
+		ExpiryDate: helper.ParseOptionalDate(request.ExpiryDate),
+		Access:     access,
+	}
+}
@@ -0,0 +1,71 @@
+package domain
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/iqbaludinm/hr-microservice/user-service/helper"
+	"github.com/iqbaludinm/hr-microservice/user-service/model/web"
+)
+
+type EmployeeDocumentQueryFilter struct {
+	EmployeeID string
+	Type       string
+	// ExpiryBefore filters the documents which expire on or before the date, including the expired documents
+	ExpiryBefore *time.Time
+	// VisibleToEmployee hides the documents which can only be viewed by HR
+	VisibleToEmployee bool
+
+	// Pagination is used for fetching the data by page. The default value is 1.
+	Pagination Pagination
+}
+
+// BuildEmployeeDocumentQueries builds the WHERE clause of the employee document query, the deleted documents are
+// never returned. The values are returned as 'args' so they are sent as query parameters.
+func (q *EmployeeDocumentQueryFilter) BuildEmployeeDocumentQueries() (filter string, args []interface{}, pagination string) {
+	filter = "WHERE d.deleted_at IS NULL"
+	add := func(condition string, value interface{}) {
+		args = append(args, value)
+		filter += fmt.Sprintf(" AND "+condition, len(args))
+	}
+
+	// filter document by employee
+	if q.EmployeeID != "" {
+		add("d.employee_id = $%d", q.EmployeeID)
+	}
+
+	// filter document by type
+	if q.Type != "" {
+		add("d.type = $%d", q.Type)
+	}
+
+	// filter document by the expiry date
+	if q.ExpiryBefore != nil {
+		add("d.expiry_date <= $%d", *q.ExpiryBefore)
+	}
+
+	// hide the documents which can only be viewed by HR
+	if q.VisibleToEmployee {
+		add("d.access <> $%d", EmployeeDocumentAccessHR)
+	}
+
+	return filter, args, q.Pagination.Build()
+}
+
+// Helper function for converting the EmployeeDocumentQueryFilter from web to domain
+// This function is used for calling a function in the 'repository' layer
+func ToDomainEmployeeDocumentQueryFilter(q web.EmployeeDocumentQueryFilter) EmployeeDocumentQueryFilter {
+	var expiryBefore *time.Time
+	if days, err := strconv.Atoi(q.ExpiringWithin); err == nil {
+		date := helper.Today().AddDate(0, 0, days)
+		expiryBefore = &date
+	}
+
+	return EmployeeDocumentQueryFilter{
+		EmployeeID:   q.EmployeeID,
+		Type:         q.Type,
+		ExpiryBefore: expiryBefore,
+		Pagination:   NewPagination(q.Page, q.Limit),
+	}
+}
@@ -0,0 +1,88 @@
+package domain
+
+import "fmt"
+
+type EmployeeQueryFilter struct {
+	Name           string
+	EmployeeNumber string
+	DepartmentID   string
+	DepartmentIDs  []string
+	PositionID     string
+	JobTitle       string
+	EmploymentType string
+	Status         string
+	ManagerID      string
+
+	// ShowDeleted is used for showing the soft-deleted employee or not.
+	ShowDeleted bool
+
+	// Pagination is used for fetching the data by page. The default value is 1.
+	Pagination Pagination
+}
+
+// BuildEmployeeQueries builds the WHERE clause of the employee query.
+// The values are returned as 'args' so they are sent as query parameters instead of being
+// concatenated to the query. The first placeholder starts from 'offset' + 1.
+func (q *EmployeeQueryFilter) BuildEmployeeQueries(offset int) (filter string, args []interface{}, pagination string) {
+	add := func(condition string, value interface{}) {
+		args = append(args, value)
+		filter += fmt.Sprintf(" AND "+condition, offset+len(args))
+	}
+
+	// filter employee by the user name
+	if q.Name != "" {
+		add("u.name ILIKE '%%' || $%d || '%%'", q.Name)
+	}
+
+	// filter employee by employee number
+	if q.EmployeeNumber != "" {
+		add("e.employee_number = $%d", q.EmployeeNumber)
+	}
+
+	// filter employee by department
+	if q.DepartmentID != "" {
+		add("e.department_id = $%d", q.DepartmentID)
+	}
+
+	// filter employee by multiple departments, e.g. a department and its sub-departments
+	if len(q.DepartmentIDs) > 0 {
+		add("e.department_id = ANY($%d::uuid[])", q.DepartmentIDs)
+	}
+
+	// filter employee by position
+	if q.PositionID != "" {
+		add("e.position_id = $%d", q.PositionID)
+	}
+
+	// filter employee by job title
+	if q.JobTitle != "" {
+		add("e.job_title = $%d", q.JobTitle)
+	}
+
+	// filter employee by employment type
+	if q.EmploymentType != "" {
+		add("e.employment_type = $%d", q.EmploymentType)
+	}
+
+	// filter employee by status
+	if q.Status != "" {
+		add("e.status = $%d", q.Status)
+	}
+
+	// filter employee by manager
+	if q.ManagerID != "" {
+		add("e.manager_id = $%d", q.ManagerID)
+	}
+
+	// filter employee by deleted_at is null
+	if !q.ShowDeleted {
+		filter += " AND e.deleted_at is null"
+	}
+
+	// remove the first ' AND ' from the filter
+	if len(filter) > 0 {
+		filter = "WHERE " + filter[5:]
+	}
+
+	return filter, args, q.Pagination.Build()
+}
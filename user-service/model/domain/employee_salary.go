@@ -0,0 +1,108 @@
+package domain
+
+import (
+	"strings"
+	"time"
+
+	"github.com/iqbaludinm/hr-microservice/user-service/helper"
+	"github.com/iqbaludinm/hr-microservice/user-service/model/web"
+)
+
+// The tax statuses (PTKP) of PPh 21, TK is unmarried and K is married with the number of dependents.
+var TaxStatuses = []string{"TK/0", "TK/1", "TK/2", "TK/3", "K/0", "K/1", "K/2", "K/3"}
+
+// employee salary main struct, the salary is effective from its date until the next salary of the employee
+type EmployeeSalary struct {
+	ID            string            `json:"id"`
+	EmployeeID    string            `json:"employee_id"`
+	BaseSalary    int64             `json:"base_salary"`
+	Allowances    []SalaryAllowance `json:"allowances"`
+	TaxStatus     string            `json:"tax_status"`
+	EffectiveFrom time.Time         `json:"effective_from"`
+	Note          string            `json:"note"`
+	CreatedAt     time.Time         `json:"created_at"`
+	UpdatedAt     time.Time         `json:"updated_at"`
+
+	// joined from the 'employees' and 'users' table
+	EmployeeNumber string `json:"employee_number"`
+	EmployeeName   string `json:"employee_name"`
+}
+
+// SalaryAllowance is stored as a 'jsonb' array in the 'employee_salaries' and 'payslips' table. The fixed
+// allowances are paid regardless of the attendance and are part of the wage.
+type SalaryAllowance struct {
+	Code   string `json:"code"`
+	Name   string `json:"name"`
+	Amount int64  `json:"amount"`
+	Fixed  bool   `json:"fixed"`
+}
+
+func (s *EmployeeSalary) ToEmployeeSalaryResponse() web.EmployeeSalaryResponse {
+	return web.EmployeeSalaryResponse{
+		ID:             s.ID,
+		EmployeeID:     s.EmployeeID,
+		EmployeeNumber: s.EmployeeNumber,
+		EmployeeName:   s.EmployeeName,
+		BaseSalary:     s.BaseSalary,
+		Allowances:     toSalaryAllowanceResponses(s.Allowances),
+		TaxStatus:      s.TaxStatus,
+		EffectiveFrom:  s.EffectiveFrom.Format(helper.DateLayout),
+		Note:           s.Note,
+		CreatedAt:      s.CreatedAt,
+		UpdatedAt:      s.UpdatedAt,
+	}
+}
+
+// Wage returns the base salary and the fixed allowances, the BPJS contributions, the overtime and the unpaid
+// leave are based on the wage.
+func Wage(baseSalary int64, allowances []SalaryAllowance) int64 {
+	wage := baseSalary
+	for _, allowance := range allowances {
+		if allowance.Fixed {
+			wage += allowance.Amount
+		}
+	}
+	return wage
+}
+
+func toSalaryAllowanceResponses(allowances []SalaryAllowance) []web.SalaryAllowanceResponse {
+	result := make([]web.SalaryAllowanceResponse, 0, len(allowances))
+	for _, allowance := range allowances {
+		result = append(result, web.SalaryAllowanceResponse{
+			Code:   allowance.Code,
+			Name:   allowance.Name,
+			Amount: allowance.Amount,
+			Fixed:  allowance.Fixed,
+		})
+	}
+	return result
+}
+
+// Helper function for converting the CreateEmployeeSalaryRequest from web to domain
+func ToDomainEmployeeSalary(request web.CreateEmployeeSalaryRequest) EmployeeSalary {
+	effectiveFrom, _ := helper.ParseDate(request.EffectiveFrom)
+
+	allowances := make([]SalaryAllowance, 0, len(request.Allowances))
+	for _, allowance := range request.Allowances {
+		allowances = append(allowances, SalaryAllowance{
+			Code:   strings.ToUpper(allowance.Code),
+			Name:   allowance.Name,
+			Amount: allowance.Amount,
+			Fixed:  allowance.Fixed,
+		})
+	}
+
+	taxStatus := request.TaxStatus
+	if taxStatus == "" {
+		taxStatus = "TK/0"
+	}
+
+	return EmployeeSalary{
+		EmployeeID:    request.EmployeeID,
+		BaseSalary:    request.BaseSalary,
+		Allowances:    allowances,
+		TaxStatus:     taxStatus,
+		EffectiveFrom: effectiveFrom,
+		Note:          request.Note,
+	}
+}
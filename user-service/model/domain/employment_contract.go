@@ -0,0 +1,169 @@
+package domain
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/iqbaludinm/hr-microservice/user-service/helper"
+	"github.com/iqbaludinm/hr-microservice/user-service/model/web"
+)
+
+// Type of the employment contract.
+const (
+	// PKWT (Perjanjian Kerja Waktu Tertentu) is the fixed-term contract
+	ContractTypePKWT = "pkwt"
+	// PKWTT (Perjanjian Kerja Waktu Tidak Tertentu) is the permanent contract
+	ContractTypePKWTT = "pkwtt"
+)
+
+// Status of the employment contract.
+const (
+	ContractStatusDraft     = "draft"
+	ContractStatusActive    = "active"
+	ContractStatusRenewed   = "renewed"
+	ContractStatusConverted = "converted"
+	ContractStatusEnded     = "ended"
+	ContractStatusCancelled = "cancelled"
+)
+
+// The limits of PP 35/2021, a PKWT with its renewals can't be longer than 5 years and the probation of a PKWTT can't
+// be longer than 3 months.
+const (
+	MaxPKWTYears       = 5
+	MaxProbationMonths = 3
+)
+
+// employment contract main struct, the contract renewing or converting another contract refers to it as the previous
+// contract
+type EmploymentContract struct {
+	ID                 string     `json:"id"`
+	ContractNumber     string     `json:"contract_number"`
+	EmployeeID         string     `json:"employee_id"`
+	Type               string     `json:"type"`
+	JobTitle           string     `json:"job_title"`
+	StartDate          time.Time  `json:"start_date"`
+	EndDate            *time.Time `json:"end_date"`
+	ProbationEndDate   *time.Time `json:"probation_end_date"`
+	SalaryID           *string    `json:"salary_id"`
+	PreviousContractID *string    `json:"previous_contract_id"`
+	Status             string     `json:"status"`
+	SignedDocumentID   *string    `json:"signed_document_id"`
+	SignedAt           *time.Time `json:"signed_at"`
+	AlertedDays        *int       `json:"alerted_days"`
+	Note               string     `json:"note"`
+	CreatedBy          *string    `json:"created_by"`
+	CreatedAt          time.Time  `json:"created_at"`
+	UpdatedAt          time.Time  `json:"updated_at"`
+
+	// joined from the 'employees', 'users' and 'employee_salaries' table
+	EmployeeNumber string  `json:"employee_number"`
+	EmployeeName   string  `json:"employee_name"`
+	EmployeeUserID string  `json:"employee_user_id"`
+	ManagerUserID  *string `json:"manager_user_id"`
+	BaseSalary     *int64  `json:"base_salary"`
+}
+
+// ContractNumber returns the number of the contract in the register, e.g. 'PKWT/2023/12/00001'.
+func ContractNumber(contractType string, date time.Time, number int64) string {
+	return fmt.Sprintf("%s/%d/%02d/%05d", strings.ToUpper(contractType), date.Year(), date.Month(), number)
+}
+
+// DaysLeft returns the days until the end date of the contract, the PKWTT has no end date.
+func (c *EmploymentContract) DaysLeft(today time.Time) (int, bool) {
+	if c.EndDate == nil {
+		return 0, false
+	}
+	return int(c.EndDate.Sub(today).Hours() / 24), true
+}
+
+// AlertDays returns the alert the contract is due for, i.e. the smallest of the days before the end date which is
+// not less than the days left. The alert is not due when it, or a smaller one, has been sent.
+func (c *EmploymentContract) AlertDays(today time.Time, alertDays []int) (int, bool) {
+	daysLeft, ok := c.DaysLeft(today)
+	if !ok || daysLeft < 0 {
+		return 0, false
+	}
+
+	due := -1
+	for _, days := range alertDays {
+		if daysLeft <= days && (due < 0 || days < due) {
+			due = days
+		}
+	}
+	if due < 0 || (c.AlertedDays != nil && *c.AlertedDays <= due) {
+		return 0, false
+	}
+	return due, true
+}
+
+// EmploymentType returns the employment type of the employee under the contract.
+func (c *EmploymentContract) EmploymentType() string {
+	if c.Type == ContractTypePKWTT {
+		return EmploymentTypePermanent
+	}
+	return EmploymentTypeContract
+}
+
+// PreviousStatus returns the status of the previous contract once this contract is signed.
+func (c *EmploymentContract) PreviousStatus() string {
+	if c.Type == ContractTypePKWTT {
+		return ContractStatusConverted
+	}
+	return ContractStatusRenewed
+}
+
+func (c *EmploymentContract) ToEmploymentContractResponse() web.EmploymentContractResponse {
+	var daysLeft *int
+	if days, ok := c.DaysLeft(helper.Today()); ok && c.Status == ContractStatusActive {
+		daysLeft = &days
+	}
+
+	return web.EmploymentContractResponse{
+		ID:                 c.ID,
+		ContractNumber:     c.ContractNumber,
+		EmployeeID:         c.EmployeeID,
+		EmployeeNumber:     c.EmployeeNumber,
+		EmployeeName:       c.EmployeeName,
+		Type:               c.Type,
+		JobTitle:           c.JobTitle,
+		StartDate:          c.StartDate.Format(helper.DateLayout),
+		EndDate:            formatOptionalDate(c.EndDate),
+		ProbationEndDate:   formatOptionalDate(c.ProbationEndDate),
+		SalaryID:           c.SalaryID,
+		BaseSalary:         c.BaseSalary,
+		PreviousContractID: c.PreviousContractID,
+		Status:             c.Status,
+		SignedDocumentID:   c.SignedDocumentID,
+		SignedAt:           c.SignedAt,
+		Note:               c.Note,
+		CreatedBy:          c.CreatedBy,
+		CreatedAt:          c.CreatedAt,
+		UpdatedAt:          c.UpdatedAt,
+		DaysLeft:           daysLeft,
+	}
+}
+
+// Helper function for converting the EmploymentContractRequest from web to domain
+func ToDomainEmploymentContract(request web.EmploymentContractRequest) EmploymentContract {
+	startDate, _ := helper.ParseDate(request.StartDate)
+
+	return EmploymentContract{
+		EmployeeID:       request.EmployeeID,
+		Type:             request.Type,
+		JobTitle:         request.JobTitle,
+		StartDate:        startDate,
+		EndDate:          helper.ParseOptionalDate(request.EndDate),
+		ProbationEndDate: helper.ParseOptionalDate(request.ProbationEndDate),
+		SalaryID:         emptyToNil(request.SalaryID),
+		Status:           ContractStatusDraft,
+		Note:             request.Note,
+	}
+}
+
+func emptyToNil(value *string) *string {
+	if value == nil || *value == "" {
+		return nil
+	}
+	return value
+}
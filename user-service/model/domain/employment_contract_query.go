@@ -0,0 +1,70 @@
+package domain
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/iqbaludinm/hr-microservice/user-service/helper"
+	"github.com/iqbaludinm/hr-microservice/user-service/model/web"
+)
+
+type EmploymentContractQueryFilter struct {
+	Status     string
+	Type       string
+	EmployeeID string
+	// ExpiringWithin filters the active contracts which end within the days, it is ignored when it is 0
+	ExpiringWithin int
+
+	// Pagination is used for fetching the data by page. The default value is 1.
+	Pagination Pagination
+}
+
+// BuildEmploymentContractQueries builds the WHERE clause of the employment contract query.
+// The values are returned as 'args' so they are sent as query parameters.
+func (q *EmploymentContractQueryFilter) BuildEmploymentContractQueries() (filter string, args []interface{}, pagination string) {
+	add := func(condition string, value interface{}) {
+		args = append(args, value)
+		filter += fmt.Sprintf(" AND "+condition, len(args))
+	}
+
+	// filter contract by status
+	if q.Status != "" {
+		add("ec.status = $%d", q.Status)
+	}
+
+	// filter contract by type
+	if q.Type != "" {
+		add("ec.type = $%d", q.Type)
+	}
+
+	// filter contract by employee
+	if q.EmployeeID != "" {
+		add("ec.employee_id = $%d", q.EmployeeID)
+	}
+
+	// filter the active contract which ends within the days
+	if q.ExpiringWithin > 0 {
+		add("ec.status = 'active' AND ec.end_date <= $%d::date", helper.Today().AddDate(0, 0, q.ExpiringWithin))
+	}
+
+	// remove the first ' AND ' from the filter
+	if len(filter) > 0 {
+		filter = "WHERE " + filter[5:]
+	}
+
+	return filter, args, q.Pagination.Build()
+}
+
+// Helper function for converting the EmploymentContractQueryFilter from web to domain
+// This function is used for calling a function in the 'repository' layer
+func ToDomainEmploymentContractQueryFilter(q web.EmploymentContractQueryFilter) EmploymentContractQueryFilter {
+	expiringWithin, _ := strconv.Atoi(q.ExpiringWithin)
+
+	return EmploymentContractQueryFilter{
+		Status:         q.Status,
+		Type:           q.Type,
+		EmployeeID:     q.EmployeeID,
+		ExpiringWithin: expiringWithin,
+		Pagination:     NewPagination(q.Page, q.Limit),
+	}
+}
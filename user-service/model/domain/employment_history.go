@@ -0,0 +1,98 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/iqbaludinm/hr-microservice/user-service/helper"
+	"github.com/iqbaludinm/hr-microservice/user-service/model/web"
+)
+
+// Type of the change that creates the employment history.
+const (
+	ChangeTypeHire          = "hire"
+	ChangeTypeTransfer      = "transfer"
+	ChangeTypePromotion     = "promotion"
+	ChangeTypeDemotion      = "demotion"
+	ChangeTypeJobChange     = "job_change"
+	ChangeTypeSalaryChange  = "salary_change"
+	ChangeTypeManagerChange = "manager_change"
+)
+
+// employment history main struct. Every row is a full snapshot of the employment that is effective
+// from 'EffectiveFrom' until 'EffectiveTo'. The rows are never updated except for closing the period
+// ('effective_to') and marking the future-dated row as applied ('applied_at').
+type EmploymentHistory struct {
+	ID            string     `json:"id"`
+	EmployeeID    string     `json:"employee_id"`
+	ChangeType    string     `json:"change_type"`
+	DepartmentID  string     `json:"department_id"`
+	PositionID    *string    `json:"position_id"`
+	JobTitle      string     `json:"job_title"`
+	SalaryGrade   string     `json:"salary_grade"`
+	ManagerID     *string    `json:"manager_id"`
+	EffectiveFrom time.Time  `json:"effective_from"`
+	EffectiveTo   *time.Time `json:"effective_to"`
+	Reason        string     `json:"reason"`
+	AppliedAt     *time.Time `json:"applied_at"`
+	CreatedAt     time.Time  `json:"created_at"`
+
+	// joined from the 'departments', 'positions' and 'users' (of the manager) table
+	DepartmentName string `json:"department_name"`
+	PositionTitle  string `json:"position_title"`
+	ManagerName    string `json:"manager_name"`
+}
+
+// IsPending returns true when the change is future-dated and hasn't been applied to the employee.
+func (h *EmploymentHistory) IsPending() bool {
+	return h.AppliedAt == nil
+}
+
+// NewEmploymentHistory creates the snapshot of the current employment of the employee.
+func NewEmploymentHistory(employee Employee) EmploymentHistory {
+	return EmploymentHistory{
+		EmployeeID:     employee.ID,
+		DepartmentID:   employee.DepartmentID,
+		PositionID:     employee.PositionID,
+		JobTitle:       employee.JobTitle,
+		SalaryGrade:    employee.SalaryGrade,
+		ManagerID:      employee.ManagerID,
+		DepartmentName: employee.Department,
+		PositionTitle:  employee.Position,
+	}
+}
+
+// ApplyTo copies the employment snapshot to the employee.
+func (h *EmploymentHistory) ApplyTo(employee *Employee) {
+	employee.DepartmentID = h.DepartmentID
+	employee.PositionID = h.PositionID
+	employee.JobTitle = h.JobTitle
+	employee.SalaryGrade = h.SalaryGrade
+	employee.ManagerID = h.ManagerID
+}
+
+func (h *EmploymentHistory) ToEmploymentHistoryResponse() web.EmploymentHistoryResponse {
+	var effectiveTo *string
+	if h.EffectiveTo != nil {
+		date := h.EffectiveTo.Format(helper.DateLayout)
+		effectiveTo = &date
+	}
+
+	return web.EmploymentHistoryResponse{
+		ID:             h.ID,
+		EmployeeID:     h.EmployeeID,
+		ChangeType:     h.ChangeType,
+		DepartmentID:   h.DepartmentID,
+		DepartmentName: h.DepartmentName,
+		PositionID:     h.PositionID,
+		PositionTitle:  h.PositionTitle,
+		JobTitle:       h.JobTitle,
+		SalaryGrade:    h.SalaryGrade,
+		ManagerID:      h.ManagerID,
+		ManagerName:    h.ManagerName,
+		EffectiveFrom:  h.EffectiveFrom.Format(helper.DateLayout),
+		EffectiveTo:    effectiveTo,
+		Reason:         h.Reason,
+		Pending:        h.IsPending(),
+		CreatedAt:      h.CreatedAt,
+	}
+}
@@ -0,0 +1,304 @@
+package domain
+
+import (
+	"fmt"
+	"math"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/iqbaludinm/hr-microservice/user-service/helper"
+	"github.com/iqbaludinm/hr-microservice/user-service/model/web"
+)
+
+// Status of the expense claim. The submitted claim is approved by the manager of the employee and then by finance,
+// the approved claim is paid with the payroll run it is reimbursed in.
+const (
+	ExpenseClaimDraft           = "draft"
+	ExpenseClaimSubmitted       = "submitted"
+	ExpenseClaimManagerApproved = "manager_approved"
+	ExpenseClaimApproved        = "approved"
+	ExpenseClaimRejected        = "rejected"
+	ExpenseClaimCancelled       = "cancelled"
+	ExpenseClaimPaid            = "paid"
+)
+
+// The currency of the rupiah, the amount of the other currencies is converted with the exchange rate.
+const CurrencyIDR = "IDR"
+
+// expense category main struct
+type ExpenseCategory struct {
+	ID              string     `json:"id"`
+	Code            string     `json:"code"`
+	Name            string     `json:"name"`
+	Description     string     `json:"description"`
+	ReceiptRequired bool       `json:"receipt_required"`
+	CreatedAt       time.Time  `json:"created_at"`
+	UpdatedAt       time.Time  `json:"updated_at"`
+	DeletedAt       *time.Time `json:"deleted_at"`
+
+	Limits []ExpenseCategoryLimit `json:"limits"`
+}
+
+// the maximum amount of the category in a claim of the employee with the salary grade
+type ExpenseCategoryLimit struct {
+	CategoryID  string    `json:"category_id"`
+	SalaryGrade string    `json:"salary_grade"`
+	MaxAmount   int64     `json:"max_amount"`
+	CreatedAt   time.Time `json:"created_at"`
+
+	// joined from the 'expense_categories' table
+	CategoryName string `json:"category_name"`
+}
+
+// expense claim main struct, the total amount is in rupiah
+type ExpenseClaim struct {
+	ID                string     `json:"id"`
+	EmployeeID        string     `json:"employee_id"`
+	Title             string     `json:"title"`
+	Description       string     `json:"description"`
+	Status            string     `json:"status"`
+	TotalAmount       int64      `json:"total_amount"`
+	ManagerID         *string    `json:"manager_id"`
+	SubmittedAt       *time.Time `json:"submitted_at"`
+	ManagerApprovedAt *time.Time `json:"manager_approved_at"`
+	FinanceApprovedBy *string    `json:"finance_approved_by"`
+	FinanceApprovedAt *time.Time `json:"finance_approved_at"`
+	PayrollRunID      *string    `json:"payroll_run_id"`
+	PaidAt            *time.Time `json:"paid_at"`
+	CreatedAt         time.Time  `json:"created_at"`
+	UpdatedAt         time.Time  `json:"updated_at"`
+
+	Items     []ExpenseClaimItem    `json:"items"`
+	Histories []ExpenseClaimHistory `json:"histories"`
+
+	// joined from the 'employees', 'users' and 'departments' table
+	EmployeeNumber string `json:"employee_number"`
+	EmployeeName   string `json:"employee_name"`
+	EmployeeUserID string `json:"employee_user_id"`
+	Department     string `json:"department"`
+}
+
+// the line item of the expense claim, the amount is in the currency of the receipt
+type ExpenseClaimItem struct {
+	ClaimID           string    `json:"claim_id"`
+	Seq               int       `json:"seq"`
+	CategoryID        string    `json:"category_id"`
+	ExpenseDate       time.Time `json:"expense_date"`
+	Description       string    `json:"description"`
+	Currency          string    `json:"currency"`
+	Amount            float64   `json:"amount"`
+	ExchangeRate      float64   `json:"exchange_rate"`
+	AmountIDR         int64     `json:"amount_idr"`
+	ReceiptStorageKey *string   `json:"receipt_storage_key"`
+	ReceiptFileName   *string   `json:"receipt_file_name"`
+	ReceiptMimeType   *string   `json:"receipt_mime_type"`
+
+	// joined from the 'expense_categories' table
+	CategoryCode    string `json:"category_code"`
+	CategoryName    string `json:"category_name"`
+	ReceiptRequired bool   `json:"receipt_required"`
+}
+
+// the status change of the expense claim, the from status is empty when the claim is created
+type ExpenseClaimHistory struct {
+	ID         string    `json:"id"`
+	ClaimID    string    `json:"claim_id"`
+	FromStatus *string   `json:"from_status"`
+	ToStatus   string    `json:"to_status"`
+	Note       string    `json:"note"`
+	ChangedBy  *string   `json:"changed_by"`
+	ChangedAt  time.Time `json:"changed_at"`
+}
+
+func (e *ExpenseCategory) ToExpenseCategoryResponse() web.ExpenseCategoryResponse {
+	var limits []web.ExpenseCategoryLimitResponse
+	for _, limit := range e.Limits {
+		limits = append(limits, web.ExpenseCategoryLimitResponse{
+			SalaryGrade: limit.SalaryGrade,
+			MaxAmount:   limit.MaxAmount,
+		})
+	}
+
+	return web.ExpenseCategoryResponse{
+		ID:              e.ID,
+		Code:            e.Code,
+		Name:            e.Name,
+		Description:     e.Description,
+		ReceiptRequired: e.ReceiptRequired,
+		CreatedAt:       e.CreatedAt,
+		UpdatedAt:       e.UpdatedAt,
+		Limits:          limits,
+	}
+}
+
+func (e *ExpenseClaim) ToExpenseClaimResponse() web.ExpenseClaimResponse {
+	var items []web.ExpenseClaimItemResponse
+	for _, item := range e.Items {
+		items = append(items, web.ExpenseClaimItemResponse{
+			Seq:          item.Seq,
+			CategoryID:   item.CategoryID,
+			CategoryCode: item.CategoryCode,
+			CategoryName: item.CategoryName,
+			ExpenseDate:  item.ExpenseDate.Format(helper.DateLayout),
+			Description:  item.Description,
+			Currency:     item.Currency,
+			Amount:       item.Amount,
+			ExchangeRate: item.ExchangeRate,
+			AmountIDR:    item.AmountIDR,
+			HasReceipt:   item.ReceiptStorageKey != nil,
+			ReceiptName:  item.ReceiptFileName,
+		})
+	}
+
+	var histories []web.ExpenseClaimHistoryResponse
+	for _, history := range e.Histories {
+		histories = append(histories, web.ExpenseClaimHistoryResponse{
+			FromStatus: history.FromStatus,
+			ToStatus:   history.ToStatus,
+			Note:       history.Note,
+			ChangedBy:  history.ChangedBy,
+			ChangedAt:  history.ChangedAt,
+		})
+	}
+
+	return web.ExpenseClaimResponse{
+		ID:                e.ID,
+		EmployeeID:        e.EmployeeID,
+		EmployeeNumber:    e.EmployeeNumber,
+		EmployeeName:      e.EmployeeName,
+		Department:        e.Department,
+		Title:             e.Title,
+		Description:       e.Description,
+		Status:            e.Status,
+		TotalAmount:       e.TotalAmount,
+		ManagerID:         e.ManagerID,
+		SubmittedAt:       e.SubmittedAt,
+		ManagerApprovedAt: e.ManagerApprovedAt,
+		FinanceApprovedBy: e.FinanceApprovedBy,
+		FinanceApprovedAt: e.FinanceApprovedAt,
+		PayrollRunID:      e.PayrollRunID,
+		PaidAt:            e.PaidAt,
+		CreatedAt:         e.CreatedAt,
+		UpdatedAt:         e.UpdatedAt,
+		Items:             items,
+		Histories:         histories,
+	}
+}
+
+// Editable reports whether the items of the claim can be changed, the rejected claim is edited before it is
+// submitted again.
+func (e *ExpenseClaim) Editable() bool {
+	return e.Status == ExpenseClaimDraft || e.Status == ExpenseClaimRejected
+}
+
+// Total sums the rupiah amount of the items of the claim.
+func (e *ExpenseClaim) Total() {
+	e.TotalAmount = 0
+	for _, item := range e.Items {
+		e.TotalAmount += item.AmountIDR
+	}
+}
+
+// NextSeq returns the seq of the item added to the claim.
+func (e *ExpenseClaim) NextSeq() int {
+	seq := 0
+	for _, item := range e.Items {
+		if item.Seq > seq {
+			seq = item.Seq
+		}
+	}
+	return seq + 1
+}
+
+// FindItem returns the item of the claim by its seq.
+func (e *ExpenseClaim) FindItem(seq int) (ExpenseClaimItem, bool) {
+	for _, item := range e.Items {
+		if item.Seq == seq {
+			return item, true
+		}
+	}
+	return ExpenseClaimItem{}, false
+}
+
+// ExceededLimits returns the limits of the grade which are exceeded by the sum of the items of their category.
+func (e *ExpenseClaim) ExceededLimits(limits []ExpenseCategoryLimit) []ExpenseCategoryLimit {
+	amounts := map[string]int64{}
+	for _, item := range e.Items {
+		amounts[item.CategoryID] += item.AmountIDR
+	}
+
+	var exceeded []ExpenseCategoryLimit
+	for _, limit := range limits {
+		if amounts[limit.CategoryID] > limit.MaxAmount {
+			exceeded = append(exceeded, limit)
+		}
+	}
+	return exceeded
+}
+
+// NewHistory returns the history of the claim moved from the status to the current status of the claim.
+func (e *ExpenseClaim) NewHistory(id string, fromStatus *string, note string, changedBy *string, changedAt time.Time) ExpenseClaimHistory {
+	return ExpenseClaimHistory{
+		ID:         id,
+		ClaimID:    e.ID,
+		FromStatus: fromStatus,
+		ToStatus:   e.Status,
+		Note:       note,
+		ChangedBy:  changedBy,
+		ChangedAt:  changedAt,
+	}
+}
+
+// NewReceiptStorageKey returns the storage key of the receipt of the item, the extension of the file is kept.
+func (i *ExpenseClaimItem) NewReceiptStorageKey(fileName string) string {
+	return fmt.Sprintf("expenses/%s/%d%s", i.ClaimID, i.Seq, strings.ToLower(filepath.Ext(fileName)))
+}
+
+func ToDomainExpenseCategory(request web.ExpenseCategoryRequest) ExpenseCategory {
+	category := ExpenseCategory{
+		Code:            request.Code,
+		Name:            request.Name,
+		Description:     request.Description,
+		ReceiptRequired: request.ReceiptRequired,
+	}
+	for _, limit := range request.Limits {
+		category.Limits = append(category.Limits, ExpenseCategoryLimit{
+			SalaryGrade: limit.SalaryGrade,
+			MaxAmount:   limit.MaxAmount,
+		})
+	}
+	return category
+}
+
+func ToDomainExpenseClaim(request web.ExpenseClaimRequest) ExpenseClaim {
+	claim := ExpenseClaim{
+		Title:       request.Title,
+		Description: request.Description,
+	}
+	for i, item := range request.Items {
+		claimItem := ToDomainExpenseClaimItem(item)
+		claimItem.Seq = i + 1
+		claim.Items = append(claim.Items, claimItem)
+	}
+	return claim
+}
+
+// ToDomainExpenseClaimItem converts the item and its amount to rupiah, the exchange rate of the rupiah is always 1.
+func ToDomainExpenseClaimItem(request web.ExpenseClaimItemRequest) ExpenseClaimItem {
+	expenseDate, _ := helper.ParseDate(request.ExpenseDate)
+	exchangeRate := request.ExchangeRate
+	if request.Currency == CurrencyIDR {
+		exchangeRate = 1
+	}
+
+	return ExpenseClaimItem{
+		CategoryID:   request.CategoryID,
+		ExpenseDate:  expenseDate,
+		Description:  request.Description,
+		Currency:     request.Currency,
+		Amount:       request.Amount,
+		ExchangeRate: exchangeRate,
+		AmountIDR:    int64(math.Round(request.Amount * exchangeRate)),
+	}
+}
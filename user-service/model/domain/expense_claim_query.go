@@ -0,0 +1,132 @@
+package domain
+
+import (
+	"fmt"
+
+	"github.com/iqbaludinm/hr-microservice/user-service/model/web"
+)
+
+type ExpenseCategoryQueryFilter struct {
+	Search string
+
+	// Pagination is used for fetching the data by page. The default value is 1.
+	Pagination Pagination
+}
+
+// BuildExpenseCategoryQueries builds the WHERE clause of the expense category query, the deleted categories are
+// never returned. The values are returned as 'args' so they are sent as query parameters.
+func (q *ExpenseCategoryQueryFilter) BuildExpenseCategoryQueries() (filter string, args []interface{}, pagination string) {
+	filter = "WHERE ec.deleted_at IS NULL"
+	add := func(condition string, value interface{}) {
+		args = append(args, value)
+		filter += fmt.Sprintf(" AND "+condition, len(args))
+	}
+
+	// search category by the code or the name
+	if q.Search != "" {
+		add("(ec.code ILIKE '%%' || $%[1]d || '%%' OR ec.name ILIKE '%%' || $%[1]d || '%%')", q.Search)
+	}
+
+	return filter, args, q.Pagination.Build()
+}
+
+// Helper function for converting the ExpenseCategoryQueryFilter from web to domain
+// This function is used for calling a function in the 'repository' layer
+func ToDomainExpenseCategoryQueryFilter(q web.ExpenseCategoryQueryFilter) ExpenseCategoryQueryFilter {
+	return ExpenseCategoryQueryFilter{
+		Search:     q.Search,
+		Pagination: NewPagination(q.Page, q.Limit),
+	}
+}
+
+type ExpenseClaimQueryFilter struct {
+	EmployeeID   string
+	DepartmentID string
+	PayrollRunID string
+	Status       string
+	From         string
+	To           string
+	Search       string
+	// ApproverID filters the submitted claims waiting for the approval of the manager, the claims approved by the
+	// manager are included too when the approver is finance
+	ApproverID string
+	Finance    bool
+
+	// Pagination is used for fetching the data by page. The default value is 1.
+	Pagination Pagination
+}
+
+// BuildExpenseClaimQueries builds the WHERE clause of the expense claim query.
+// The values are returned as 'args' so they are sent as query parameters.
+func (q *ExpenseClaimQueryFilter) BuildExpenseClaimQueries() (filter string, args []interface{}, pagination string) {
+	add := func(condition string, value interface{}) {
+		args = append(args, value)
+		filter += fmt.Sprintf(" AND "+condition, len(args))
+	}
+
+	// filter claim by employee
+	if q.EmployeeID != "" {
+		add("c.employee_id = $%d", q.EmployeeID)
+	}
+
+	// filter claim by the department of the employee
+	if q.DepartmentID != "" {
+		add("e.department_id = $%d", q.DepartmentID)
+	}
+
+	// filter claim by the payroll run it is reimbursed in
+	if q.PayrollRunID != "" {
+		add("c.payroll_run_id = $%d", q.PayrollRunID)
+	}
+
+	// filter claim by status
+	if q.Status != "" {
+		add("c.status = $%d", q.Status)
+	}
+
+	// filter claim created from the date
+	if q.From != "" {
+		add("c.created_at >= $%d::date", q.From)
+	}
+
+	// filter claim created until the date
+	if q.To != "" {
+		add("c.created_at < $%d::date + 1", q.To)
+	}
+
+	// search claim by the title or the name of the employee
+	if q.Search != "" {
+		add("(c.title ILIKE '%%' || $%[1]d || '%%' OR u.name ILIKE '%%' || $%[1]d || '%%')", q.Search)
+	}
+
+	// filter claim waiting for the approval of the approver
+	if q.ApproverID != "" {
+		if q.Finance {
+			add("((c.status = 'submitted' AND c.manager_id = $%d) OR c.status = 'manager_approved')", q.ApproverID)
+		} else {
+			add("c.status = 'submitted' AND c.manager_id = $%d", q.ApproverID)
+		}
+	}
+
+	// remove the first ' AND ' from the filter
+	if len(filter) > 0 {
+		filter = "WHERE " + filter[5:]
+	}
+
+	return filter, args, q.Pagination.Build()
+}
+
+// Helper function for converting the ExpenseClaimQueryFilter from web to domain
+// This function is used for calling a function in the 'repository' layer
+func ToDomainExpenseClaimQueryFilter(q web.ExpenseClaimQueryFilter) ExpenseClaimQueryFilter {
+	return ExpenseClaimQueryFilter{
+		EmployeeID:   q.EmployeeID,
+		DepartmentID: q.DepartmentID,
+		PayrollRunID: q.PayrollRunID,
+		Status:       q.Status,
+		From:         q.From,
+		To:           q.To,
+		Search:       q.Search,
+		Pagination:   NewPagination(q.Page, q.Limit),
+	}
+}
@@ -0,0 +1,215 @@
+package domain
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/iqbaludinm/hr-microservice/user-service/helper"
+	"github.com/iqbaludinm/hr-microservice/user-service/model/web"
+)
+
+// Type of the handover (BAST).
+const (
+	HandoverTypeEmployee = "employee"
+	HandoverTypeAsset    = "asset"
+)
+
+// Status of the handover, the draft is submitted for the signatures and approved when every signer has signed.
+const (
+	HandoverStatusDraft     = "draft"
+	HandoverStatusPending   = "pending"
+	HandoverStatusApproved  = "approved"
+	HandoverStatusRejected  = "rejected"
+	HandoverStatusCancelled = "cancelled"
+)
+
+// Status of the signature of a handover signer.
+const (
+	HandoverSignerPending  = "pending"
+	HandoverSignerSigned   = "signed"
+	HandoverSignerRejected = "rejected"
+)
+
+// handover main struct, the handover document (Berita Acara Serah Terima) from an employee to another employee
+type Handover struct {
+	ID             string     `json:"id"`
+	DocumentNumber string     `json:"document_number"`
+	Type           string     `json:"type"`
+	Title          string     `json:"title"`
+	Description    string     `json:"description"`
+	Location       string     `json:"location"`
+	HandoverDate   time.Time  `json:"handover_date"`
+	FromEmployeeID string     `json:"from_employee_id"`
+	ToEmployeeID   string     `json:"to_employee_id"`
+	Status         string     `json:"status"`
+	DocumentKey    *string    `json:"document_key"`
+	EmailedAt      *time.Time `json:"emailed_at"`
+	CreatedBy      *string    `json:"created_by"`
+	SubmittedAt    *time.Time `json:"submitted_at"`
+	CompletedAt    *time.Time `json:"completed_at"`
+	CancelledAt    *time.Time `json:"cancelled_at"`
+	CreatedAt      time.Time  `json:"created_at"`
+	UpdatedAt      time.Time  `json:"updated_at"`
+
+	Items   []HandoverItem   `json:"items"`
+	Signers []HandoverSigner `json:"signers"`
+
+	// joined from the 'employees' and 'users' table
+	FromEmployeeNumber string `json:"from_employee_number"`
+	FromEmployeeName   string `json:"from_employee_name"`
+	ToEmployeeNumber   string `json:"to_employee_number"`
+	ToEmployeeName     string `json:"to_employee_name"`
+}
+
+// the line item of the handover, e.g. the handed over goods or the handed over work
+type HandoverItem struct {
+	HandoverID   string  `json:"handover_id"`
+	Seq          int     `json:"seq"`
+	Name         string  `json:"name"`
+	Description  string  `json:"description"`
+	Quantity     float64 `json:"quantity"`
+	Unit         string  `json:"unit"`
+	SerialNumber string  `json:"serial_number"`
+	Condition    string  `json:"condition"`
+	Note         string  `json:"note"`
+}
+
+// the signer of the handover, the signature is the time the signer signed the handover
+type HandoverSigner struct {
+	HandoverID string     `json:"handover_id"`
+	Seq        int        `json:"seq"`
+	EmployeeID string     `json:"employee_id"`
+	Role       string     `json:"role"`
+	Status     string     `json:"status"`
+	Note       string     `json:"note"`
+	SignedAt   *time.Time `json:"signed_at"`
+
+	// joined from the 'employees' and 'users' table
+	EmployeeNumber string `json:"employee_number"`
+	EmployeeName   string `json:"employee_name"`
+	JobTitle       string `json:"job_title"`
+	UserID         string `json:"user_id"`
+}
+
+// HandoverDocumentNumber returns the number of the handover document in the register, e.g. 'BAST/2023/12/00001'.
+func HandoverDocumentNumber(date time.Time, number int64) string {
+	return fmt.Sprintf("BAST/%d/%02d/%05d", date.Year(), date.Month(), number)
+}
+
+// NextSigner returns the signer who has to sign the pending handover, it is nil when the handover is not pending.
+func (h *Handover) NextSigner() *HandoverSigner {
+	if h.Status != HandoverStatusPending {
+		return nil
+	}
+	for i := range h.Signers {
+		if h.Signers[i].Status == HandoverSignerPending {
+			return &h.Signers[i]
+		}
+	}
+	return nil
+}
+
+// NewDocumentKey returns the storage key of the signed handover document.
+func (h *Handover) NewDocumentKey() string {
+	return fmt.Sprintf("handovers/%d/%s.pdf", h.HandoverDate.Year(), h.ID)
+}
+
+func (h *Handover) ToHandoverResponse() web.HandoverResponse {
+	items := make([]web.HandoverItemResponse, 0, len(h.Items))
+	for _, item := range h.Items {
+		items = append(items, web.HandoverItemResponse{
+			Seq:          item.Seq,
+			Name:         item.Name,
+			Description:  item.Description,
+			Quantity:     item.Quantity,
+			Unit:         item.Unit,
+			SerialNumber: item.SerialNumber,
+			Condition:    item.Condition,
+			Note:         item.Note,
+		})
+	}
+
+	signers := make([]web.HandoverSignerResponse, 0, len(h.Signers))
+	for _, signer := range h.Signers {
+		signers = append(signers, web.HandoverSignerResponse{
+			Seq:            signer.Seq,
+			EmployeeID:     signer.EmployeeID,
+			EmployeeNumber: signer.EmployeeNumber,
+			EmployeeName:   signer.EmployeeName,
+			JobTitle:       signer.JobTitle,
+			Role:           signer.Role,
+			Status:         signer.Status,
+			Note:           signer.Note,
+			SignedAt:       signer.SignedAt,
+		})
+	}
+
+	return web.HandoverResponse{
+		ID:                 h.ID,
+		DocumentNumber:     h.DocumentNumber,
+		Type:               h.Type,
+		Title:              h.Title,
+		Description:        h.Description,
+		Location:           h.Location,
+		HandoverDate:       h.HandoverDate.Format(helper.DateLayout),
+		FromEmployeeID:     h.FromEmployeeID,
+		FromEmployeeNumber: h.FromEmployeeNumber,
+		FromEmployeeName:   h.FromEmployeeName,
+		ToEmployeeID:       h.ToEmployeeID,
+		ToEmployeeNumber:   h.ToEmployeeNumber,
+		ToEmployeeName:     h.ToEmployeeName,
+		Status:             h.Status,
+		DocumentGenerated:  h.DocumentKey != nil,
+		EmailedAt:          h.EmailedAt,
+		CreatedBy:          h.CreatedBy,
+		SubmittedAt:        h.SubmittedAt,
+		CompletedAt:        h.CompletedAt,
+		CancelledAt:        h.CancelledAt,
+		CreatedAt:          h.CreatedAt,
+		UpdatedAt:          h.UpdatedAt,
+		Items:              items,
+		Signers:            signers,
+	}
+}
+
+// Helper function for converting the HandoverRequest from web to domain, the items and the signers are numbered
+// in the order of the request
+func ToDomainHandover(request web.HandoverRequest) Handover {
+	handoverDate, _ := helper.ParseDate(request.HandoverDate)
+
+	items := make([]HandoverItem, 0, len(request.Items))
+	for i, item := range request.Items {
+		items = append(items, HandoverItem{
+			Seq:          i + 1,
+			Name:         item.Name,
+			Description:  item.Description,
+			Quantity:     item.Quantity,
+			Unit:         item.Unit,
+			SerialNumber: item.SerialNumber,
+			Condition:    item.Condition,
+			Note:         item.Note,
+		})
+	}
+
+	signers := make([]HandoverSigner, 0, len(request.Signers))
+	for i, signer := range request.Signers {
+		signers = append(signers, HandoverSigner{
+			Seq:        i + 1,
+			EmployeeID: signer.EmployeeID,
+			Role:       signer.Role,
+			Status:     HandoverSignerPending,
+		})
+	}
+
+	return Handover{
+		Type:           request.Type,
+		Title:          request.Title,
+		Description:    request.Description,
+		Location:       request.Location,
+		HandoverDate:   handoverDate,
+		FromEmployeeID: request.FromEmployeeID,
+		ToEmployeeID:   request.ToEmployeeID,
+		Items:          items,
+		Signers:        signers,
+	}
+}
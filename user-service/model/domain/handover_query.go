@@ -0,0 +1,92 @@
+package domain
+
+import (
+	"fmt"
+
+	"github.com/iqbaludinm/hr-microservice/user-service/model/web"
+)
+
+type HandoverQueryFilter struct {
+	Type   string
+	Status string
+	// EmployeeID filters the handovers of the employee, as the employee who hands over or receives
+	EmployeeID string
+	// SignerID filters the pending handovers where the employee is the next signer
+	SignerID string
+	// From and To filter the handover date, with the DateLayout format
+	From string
+	To   string
+	// Search filters the handovers by the document number or the title
+	Search string
+
+	// Pagination is used for fetching the data by page. The default value is 1.
+	Pagination Pagination
+}
+
+// BuildHandoverQueries builds the WHERE clause of the handover query.
+// The values are returned as 'args' so they are sent as query parameters.
+func (q *HandoverQueryFilter) BuildHandoverQueries() (filter string, args []interface{}, pagination string) {
+	add := func(condition string, value interface{}) {
+		args = append(args, value)
+		filter += fmt.Sprintf(" AND "+condition, len(args))
+	}
+
+	// filter handover by type
+	if q.Type != "" {
+		add("h.type = $%d", q.Type)
+	}
+
+	// filter handover by status
+	if q.Status != "" {
+		add("h.status = $%d", q.Status)
+	}
+
+	// filter handover by the employee who hands over or receives
+	if q.EmployeeID != "" {
+		add("(h.from_employee_id = $%[1]d OR h.to_employee_id = $%[1]d)", q.EmployeeID)
+	}
+
+	// filter the pending handover by its next signer, the first signer who has not signed yet
+	if q.SignerID != "" {
+		add(`h.status = 'pending' AND $%d = (
+			SELECT s.employee_id FROM handover_signers AS s
+			WHERE s.handover_id = h.id AND s.status = 'pending'
+			ORDER BY s.seq LIMIT 1)`, q.SignerID)
+	}
+
+	// filter handover on or after the 'from' date
+	if q.From != "" {
+		add("h.handover_date >= $%d::date", q.From)
+	}
+
+	// filter handover on or before the 'to' date
+	if q.To != "" {
+		add("h.handover_date <= $%d::date", q.To)
+	}
+
+	// filter handover by the document number or the title
+	if q.Search != "" {
+		add("(h.document_number ILIKE '%%' || $%[1]d || '%%' OR h.title ILIKE '%%' || $%[1]d || '%%')", q.Search)
+	}
+
+	// remove the first ' AND ' from the filter
+	if len(filter) > 0 {
+		filter = "WHERE " + filter[5:]
+	}
+
+	return filter, args, q.Pagination.Build()
+}
+
+// Helper function for converting the HandoverQueryFilter from web to domain
+// This function is used for calling a function in the 'repository' layer
+func ToDomainHandoverQueryFilter(q web.HandoverQueryFilter) HandoverQueryFilter {
+	return HandoverQueryFilter{
+		Type:       q.Type,
+		Status:     q.Status,
+		EmployeeID: q.EmployeeID,
+		From:       q.From,
+		To:         q.To,
+		Search:     q.Search,
+		Pagination: NewPagination(q.Page, q.Limit),
+	}
+}
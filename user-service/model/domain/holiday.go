@@ -0,0 +1,44 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/iqbaludinm/hr-microservice/user-service/helper"
+	"github.com/iqbaludinm/hr-microservice/user-service/model/web"
+)
+
+// the types of the calendar day
+const (
+	HolidayTypePublicHoliday   = "public_holiday"
+	HolidayTypeCollectiveLeave = "collective_leave"
+	// the working day overrides the weekend or the national holiday of the calendar
+	HolidayTypeWorkingDay = "working_day"
+)
+
+// public holiday main struct, the holiday without a calendar is a national day and applies to every calendar
+type Holiday struct {
+	ID             string    `json:"id"`
+	WorkCalendarID *string   `json:"work_calendar_id"`
+	Date           time.Time `json:"date"`
+	Name           string    `json:"name"`
+	Type           string    `json:"type"`
+	CreatedAt      time.Time `json:"created_at"`
+	UpdatedAt      time.Time `json:"updated_at"`
+}
+
+func (h *Holiday) ToHolidayResponse() web.HolidayResponse {
+	return web.HolidayResponse{
+		ID:             h.ID,
+		WorkCalendarID: h.WorkCalendarID,
+		Date:           h.Date.Format(helper.DateLayout),
+		Name:           h.Name,
+		Type:           h.Type,
+		CreatedAt:      h.CreatedAt,
+		UpdatedAt:      h.UpdatedAt,
+	}
+}
+
+// IsDayOff returns true when the employees don't work on the day.
+func (h *Holiday) IsDayOff() bool {
+	return h.Type != HolidayTypeWorkingDay
+}
@@ -0,0 +1,51 @@
+package domain
+
+import (
+	"fmt"
+	"time"
+)
+
+type HolidayQueryFilter struct {
+	From time.Time
+	To   time.Time
+	// WorkCalendarID limits the holidays to the national days and the days of the calendar,
+	// all holidays are returned when it is empty.
+	WorkCalendarID string
+	Type           string
+}
+
+// BuildHolidayQueries builds the WHERE clause of the holiday query.
+// The values are returned as 'args' so they are sent as query parameters.
+func (q *HolidayQueryFilter) BuildHolidayQueries() (filter string, args []interface{}) {
+	add := func(condition string, value interface{}) {
+		args = append(args, value)
+		filter += fmt.Sprintf(" AND "+condition, len(args))
+	}
+
+	// filter holiday on or after the 'from' date
+	if !q.From.IsZero() {
+		add("date >= $%d", q.From)
+	}
+
+	// filter holiday on or before the 'to' date
+	if !q.To.IsZero() {
+		add("date <= $%d", q.To)
+	}
+
+	// filter holiday by the national days and the days of the calendar
+	if q.WorkCalendarID != "" {
+		add("(work_calendar_id is null OR work_calendar_id = $%d)", q.WorkCalendarID)
+	}
+
+	// filter holiday by type
+	if q.Type != "" {
+		add("type = $%d", q.Type)
+	}
+
+	// remove the first ' AND ' from the filter
+	if len(filter) > 0 {
+		filter = "WHERE " + filter[5:]
+	}
+
+	return filter, args
+}
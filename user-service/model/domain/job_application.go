@@ -0,0 +1,341 @@
+package domain
+
+import (
+	"fmt"
+	"math"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/iqbaludinm/hr-microservice/user-service/helper"
+	"github.com/iqbaludinm/hr-microservice/user-service/model/web"
+)
+
+// Status of the job application
+const (
+	JobApplicationActive    = "active"
+	JobApplicationHired     = "hired"
+	JobApplicationRejected  = "rejected"
+	JobApplicationWithdrawn = "withdrawn"
+)
+
+// Status of the interview
+const (
+	InterviewScheduled = "scheduled"
+	InterviewCompleted = "completed"
+	InterviewCancelled = "cancelled"
+)
+
+// Status of the job offer
+const (
+	JobOfferDraft     = "draft"
+	JobOfferSent      = "sent"
+	JobOfferAccepted  = "accepted"
+	JobOfferDeclined  = "declined"
+	JobOfferCancelled = "cancelled"
+)
+
+// recruitment stage main struct
+type RecruitmentStage struct {
+	ID        string    `json:"id"`
+	Name      string    `json:"name"`
+	Seq       int       `json:"seq"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// candidate main struct
+type Candidate struct {
+	ID        string    `json:"id"`
+	Name      string    `json:"name"`
+	Email     string    `json:"email"`
+	Phone     string    `json:"phone"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// job application main struct
+type JobApplication struct {
+	ID              string     `json:"id"`
+	PostingID       string     `json:"posting_id"`
+	CandidateID     string     `json:"candidate_id"`
+	StageID         string     `json:"stage_id"`
+	Status          string     `json:"status"`
+	CoverLetter     string     `json:"cover_letter"`
+	CVFileName      string     `json:"cv_file_name"`
+	CVMimeType      string     `json:"cv_mime_type"`
+	CVSize          int64      `json:"cv_size"`
+	CVStorageKey    string     `json:"cv_storage_key"`
+	RejectionReason string     `json:"rejection_reason"`
+	UserID          *string    `json:"user_id"`
+	HiredAt         *time.Time `json:"hired_at"`
+	CreatedAt       time.Time  `json:"created_at"`
+	UpdatedAt       time.Time  `json:"updated_at"`
+
+	// These fields are joined from the 'job_postings', 'job_requisitions', 'candidates' and 'recruitment_stages'
+	// table, they are never written from the application.
+	PostingTitle   string `json:"posting_title"`
+	RequisitionID  string `json:"requisition_id"`
+	RequestedBy    string `json:"requested_by"`
+	CandidateName  string `json:"candidate_name"`
+	CandidateEmail string `json:"candidate_email"`
+	CandidatePhone string `json:"candidate_phone"`
+	StageName      string `json:"stage_name"`
+
+	Moves      []JobApplicationMove `json:"moves"`
+	Interviews []Interview          `json:"interviews"`
+	Offers     []JobOffer           `json:"offers"`
+}
+
+// the move of the application between the stages
+type JobApplicationMove struct {
+	ID            string    `json:"id"`
+	ApplicationID string    `json:"application_id"`
+	FromStageID   *string   `json:"from_stage_id"`
+	ToStageID     string    `json:"to_stage_id"`
+	Note          string    `json:"note"`
+	MovedBy       *string   `json:"moved_by"`
+	MovedAt       time.Time `json:"moved_at"`
+
+	// These fields are joined from the 'recruitment_stages' table, they are never written from the move.
+	FromStageName *string `json:"from_stage_name"`
+	ToStageName   string  `json:"to_stage_name"`
+}
+
+// interview main struct
+type Interview struct {
+	ID                  string     `json:"id"`
+	ApplicationID       string     `json:"application_id"`
+	StageID             string     `json:"stage_id"`
+	InterviewerID       string     `json:"interviewer_id"`
+	ScheduledAt         time.Time  `json:"scheduled_at"`
+	DurationMinutes     int        `json:"duration_minutes"`
+	Location            string     `json:"location"`
+	Status              string     `json:"status"`
+	Recommendation      *string    `json:"recommendation"`
+	Score               *float64   `json:"score"`
+	Feedback            string     `json:"feedback"`
+	FeedbackSubmittedAt *time.Time `json:"feedback_submitted_at"`
+	CreatedBy           *string    `json:"created_by"`
+	CreatedAt           time.Time  `json:"created_at"`
+	UpdatedAt           time.Time  `json:"updated_at"`
+
+	// These fields are joined from the 'job_applications', 'candidates', 'job_postings', 'recruitment_stages',
+	// 'employees' and 'users' table, they are never written from the interview.
+	CandidateName     string `json:"candidate_name"`
+	CandidateEmail    string `json:"candidate_email"`
+	PostingTitle      string `json:"posting_title"`
+	StageName         string `json:"stage_name"`
+	InterviewerName   string `json:"interviewer_name"`
+	InterviewerUserID string `json:"interviewer_user_id"`
+
+	Items []InterviewScorecardItem `json:"items"`
+}
+
+// the scored criterion of the scorecard of the interview
+type InterviewScorecardItem struct {
+	InterviewID string `json:"interview_id"`
+	Seq         int    `json:"seq"`
+	Criterion   string `json:"criterion"`
+	Score       int    `json:"score"`
+	Comment     string `json:"comment"`
+}
+
+// job offer main struct
+type JobOffer struct {
+	ID            string     `json:"id"`
+	ApplicationID string     `json:"application_id"`
+	JobTitle      string     `json:"job_title"`
+	BaseSalary    int64      `json:"base_salary"`
+	StartDate     time.Time  `json:"start_date"`
+	ExpiresOn     time.Time  `json:"expires_on"`
+	Status        string     `json:"status"`
+	Note          string     `json:"note"`
+	ResponseNote  string     `json:"response_note"`
+	CreatedBy     *string    `json:"created_by"`
+	SentAt        *time.Time `json:"sent_at"`
+	RespondedAt   *time.Time `json:"responded_at"`
+	CreatedAt     time.Time  `json:"created_at"`
+	UpdatedAt     time.Time  `json:"updated_at"`
+}
+
+// NewCVStorageKey returns the storage key of the CV of the application, the extension of the file is kept.
+func (a *JobApplication) NewCVStorageKey(fileName string) string {
+	return fmt.Sprintf("recruitment/%s/%s/cv%s", a.PostingID, a.ID, strings.ToLower(filepath.Ext(fileName)))
+}
+
+// FindOffer returns the offer of the application which is in progress or accepted.
+func (a *JobApplication) FindOffer() (JobOffer, bool) {
+	for _, offer := range a.Offers {
+		switch offer.Status {
+		case JobOfferDraft, JobOfferSent, JobOfferAccepted:
+			return offer, true
+		}
+	}
+	return JobOffer{}, false
+}
+
+// AverageScore returns the average of the scores of the scorecard items rounded to 2 decimals.
+func (i *Interview) AverageScore() *float64 {
+	if len(i.Items) == 0 {
+		return nil
+	}
+
+	total := 0
+	for _, item := range i.Items {
+		total += item.Score
+	}
+	score := math.Round(float64(total)/float64(len(i.Items))*100) / 100
+	return &score
+}
+
+// IsExpired returns whether the sent offer can no longer be accepted on the date.
+func (o *JobOffer) IsExpired(today time.Time) bool {
+	return today.After(o.ExpiresOn)
+}
+
+func (s *RecruitmentStage) ToRecruitmentStageResponse() web.RecruitmentStageResponse {
+	return web.RecruitmentStageResponse{
+		ID:        s.ID,
+		Name:      s.Name,
+		Seq:       s.Seq,
+		CreatedAt: s.CreatedAt,
+		UpdatedAt: s.UpdatedAt,
+	}
+}
+
+func (a *JobApplication) ToJobApplicationResponse() web.JobApplicationResponse {
+	var moves []web.JobApplicationMoveResponse
+	for _, move := range a.Moves {
+		moves = append(moves, web.JobApplicationMoveResponse{
+			FromStageID:   move.FromStageID,
+			FromStageName: move.FromStageName,
+			ToStageID:     move.ToStageID,
+			ToStageName:   move.ToStageName,
+			Note:          move.Note,
+			MovedBy:       move.MovedBy,
+			MovedAt:       move.MovedAt,
+		})
+	}
+
+	var interviews []web.InterviewResponse
+	for _, interview := range a.Interviews {
+		interviews = append(interviews, interview.ToInterviewResponse())
+	}
+
+	var offers []web.JobOfferResponse
+	for _, offer := range a.Offers {
+		offers = append(offers, offer.ToJobOfferResponse())
+	}
+
+	return web.JobApplicationResponse{
+		ID:              a.ID,
+		PostingID:       a.PostingID,
+		PostingTitle:    a.PostingTitle,
+		RequisitionID:   a.RequisitionID,
+		CandidateID:     a.CandidateID,
+		CandidateName:   a.CandidateName,
+		CandidateEmail:  a.CandidateEmail,
+		CandidatePhone:  a.CandidatePhone,
+		StageID:         a.StageID,
+		StageName:       a.StageName,
+		Status:          a.Status,
+		CoverLetter:     a.CoverLetter,
+		CVFileName:      a.CVFileName,
+		CVMimeType:      a.CVMimeType,
+		CVSize:          a.CVSize,
+		RejectionReason: a.RejectionReason,
+		UserID:          a.UserID,
+		HiredAt:         a.HiredAt,
+		CreatedAt:       a.CreatedAt,
+		UpdatedAt:       a.UpdatedAt,
+		Moves:           moves,
+		Interviews:      interviews,
+		Offers:          offers,
+	}
+}
+
+func (i *Interview) ToInterviewResponse() web.InterviewResponse {
+	var items []web.InterviewScorecardResponse
+	for _, item := range i.Items {
+		items = append(items, web.InterviewScorecardResponse{
+			Seq:       item.Seq,
+			Criterion: item.Criterion,
+			Score:     item.Score,
+			Comment:   item.Comment,
+		})
+	}
+
+	return web.InterviewResponse{
+		ID:                  i.ID,
+		ApplicationID:       i.ApplicationID,
+		CandidateName:       i.CandidateName,
+		PostingTitle:        i.PostingTitle,
+		StageID:             i.StageID,
+		StageName:           i.StageName,
+		InterviewerID:       i.InterviewerID,
+		InterviewerName:     i.InterviewerName,
+		ScheduledAt:         i.ScheduledAt,
+		DurationMinutes:     i.DurationMinutes,
+		Location:            i.Location,
+		Status:              i.Status,
+		Recommendation:      i.Recommendation,
+		Score:               i.Score,
+		Feedback:            i.Feedback,
+		FeedbackSubmittedAt: i.FeedbackSubmittedAt,
+		CreatedBy:           i.CreatedBy,
+		CreatedAt:           i.CreatedAt,
+		UpdatedAt:           i.UpdatedAt,
+		Items:               items,
+	}
+}
+
+func (o *JobOffer) ToJobOfferResponse() web.JobOfferResponse {
+	return web.JobOfferResponse{
+		ID:            o.ID,
+		ApplicationID: o.ApplicationID,
+		JobTitle:      o.JobTitle,
+		BaseSalary:    o.BaseSalary,
+		StartDate:     o.StartDate.Format(helper.DateLayout),
+		ExpiresOn:     o.ExpiresOn.Format(helper.DateLayout),
+		Status:        o.Status,
+		Note:          o.Note,
+		ResponseNote:  o.ResponseNote,
+		CreatedBy:     o.CreatedBy,
+		SentAt:        o.SentAt,
+		RespondedAt:   o.RespondedAt,
+		CreatedAt:     o.CreatedAt,
+		UpdatedAt:     o.UpdatedAt,
+	}
+}
+
+// Helper function for converting the InterviewScorecardRequest items from web to domain, the seq follows the order
+// of the items
+func ToDomainInterviewScorecardItems(interviewID string, request web.InterviewScorecardRequest) []InterviewScorecardItem {
+	var items []InterviewScorecardItem
+	for i, item := range request.Items {
+		items = append(items, InterviewScorecardItem{
+			InterviewID: interviewID,
+			Seq:         i + 1,
+			Criterion:   item.Criterion,
+			Score:       item.Score,
+			Comment:     item.Comment,
+		})
+	}
+	return items
+}
+
+// Helper function for converting the JobOfferRequest from web to domain
+func ToDomainJobOffer(request web.JobOfferRequest) JobOffer {
+	startDate, _ := helper.ParseDate(request.StartDate)
+	expiresOn, _ := helper.ParseDate(request.ExpiresOn)
+
+	return JobOffer{
+		JobTitle:   request.JobTitle,
+		BaseSalary: request.BaseSalary,
+		StartDate:  startDate,
+		ExpiresOn:  expiresOn,
+		Status:     JobOfferDraft,
+		Note:       request.Note,
+	}
+}
@@ -0,0 +1,65 @@
+package domain
+
+import (
+	"fmt"
+
+	"github.com/iqbaludinm/hr-microservice/user-service/model/web"
+)
+
+type JobApplicationQueryFilter struct {
+	PostingID string
+	StageID   string
+	Status    string
+	Search    string
+
+	// Pagination is used for fetching the data by page. The default value is 1.
+	Pagination Pagination
+}
+
+// BuildJobApplicationQueries builds the WHERE clause of the job application query.
+// The values are returned as 'args' so they are sent as query parameters.
+func (q *JobApplicationQueryFilter) BuildJobApplicationQueries() (filter string, args []interface{}, pagination string) {
+	add := func(condition string, value interface{}) {
+		args = append(args, value)
+		filter += fmt.Sprintf(" AND "+condition, len(args))
+	}
+
+	// filter application by posting
+	if q.PostingID != "" {
+		add("ja.posting_id = $%d", q.PostingID)
+	}
+
+	// filter application by stage
+	if q.StageID != "" {
+		add("ja.stage_id = $%d", q.StageID)
+	}
+
+	// filter application by status
+	if q.Status != "" {
+		add("ja.status = $%d", q.Status)
+	}
+
+	// search application by the name or the email of the candidate
+	if q.Search != "" {
+		add("(ca.name ILIKE '%%' || $%[1]d || '%%' OR ca.email ILIKE '%%' || $%[1]d || '%%')", q.Search)
+	}
+
+	// remove the first ' AND ' from the filter
+	if len(filter) > 0 {
+		filter = "WHERE " + filter[5:]
+	}
+
+	return filter, args, q.Pagination.Build()
+}
+
+// Helper function for converting the JobApplicationQueryFilter from web to domain
+// This function is used for calling a function in the 'repository' layer
+func ToDomainJobApplicationQueryFilter(q web.JobApplicationQueryFilter) JobApplicationQueryFilter {
+	return JobApplicationQueryFilter{
+		PostingID:  q.PostingID,
+		StageID:    q.StageID,
+		Status:     q.Status,
+		Search:     q.Search,
+		Pagination: NewPagination(q.Page, q.Limit),
+	}
+}
@@ -0,0 +1,164 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/iqbaludinm/hr-microservice/user-service/helper"
+	"github.com/iqbaludinm/hr-microservice/user-service/model/web"
+)
+
+// Status of the job requisition
+const (
+	JobRequisitionDraft           = "draft"
+	JobRequisitionPendingApproval = "pending_approval"
+	JobRequisitionApproved        = "approved"
+	JobRequisitionRejected        = "rejected"
+	JobRequisitionFilled          = "filled"
+	JobRequisitionCancelled       = "cancelled"
+)
+
+// Status of the job posting
+const (
+	JobPostingOpen   = "open"
+	JobPostingClosed = "closed"
+)
+
+// job requisition main struct
+type JobRequisition struct {
+	ID             string     `json:"id"`
+	Title          string     `json:"title"`
+	DepartmentID   string     `json:"department_id"`
+	PositionID     *string    `json:"position_id"`
+	Headcount      int        `json:"headcount"`
+	EmploymentType string     `json:"employment_type"`
+	Description    string     `json:"description"`
+	Reason         string     `json:"reason"`
+	SalaryMin      *int64     `json:"salary_min"`
+	SalaryMax      *int64     `json:"salary_max"`
+	Status         string     `json:"status"`
+	RequestedBy    string     `json:"requested_by"`
+	ApproverID     *string    `json:"approver_id"`
+	DecisionNote   string     `json:"decision_note"`
+	DecidedAt      *time.Time `json:"decided_at"`
+	CreatedAt      time.Time  `json:"created_at"`
+	UpdatedAt      time.Time  `json:"updated_at"`
+
+	// These fields are joined from the 'departments', 'positions', 'users', 'employees' and 'job_applications'
+	// table, they are never written from the requisition.
+	Department     string  `json:"department"`
+	Position       *string `json:"position"`
+	RequesterName  string  `json:"requester_name"`
+	ApproverName   *string `json:"approver_name"`
+	ApproverUserID *string `json:"approver_user_id"`
+	Hired          int     `json:"hired"`
+
+	// The posting of the requisition, it is nil until the requisition is posted
+	Posting *JobPosting `json:"posting"`
+}
+
+// job posting main struct
+type JobPosting struct {
+	ID            string     `json:"id"`
+	RequisitionID string     `json:"requisition_id"`
+	Title         string     `json:"title"`
+	Description   string     `json:"description"`
+	Requirements  string     `json:"requirements"`
+	Location      string     `json:"location"`
+	Status        string     `json:"status"`
+	PublishedAt   time.Time  `json:"published_at"`
+	ClosesOn      *time.Time `json:"closes_on"`
+	ClosedAt      *time.Time `json:"closed_at"`
+	CreatedAt     time.Time  `json:"created_at"`
+	UpdatedAt     time.Time  `json:"updated_at"`
+
+	// These fields are joined from the 'job_requisitions' and 'departments' table, they are never written from
+	// the posting.
+	DepartmentID   string `json:"department_id"`
+	Department     string `json:"department"`
+	EmploymentType string `json:"employment_type"`
+	RequestedBy    string `json:"requested_by"`
+}
+
+// IsOpen returns whether the posting accepts applications on the date.
+func (p *JobPosting) IsOpen(today time.Time) bool {
+	return p.Status == JobPostingOpen && (p.ClosesOn == nil || !today.After(*p.ClosesOn))
+}
+
+func (r *JobRequisition) ToJobRequisitionResponse() web.JobRequisitionResponse {
+	var posting *web.JobPostingResponse
+	if r.Posting != nil {
+		response := r.Posting.ToJobPostingResponse()
+		posting = &response
+	}
+
+	return web.JobRequisitionResponse{
+		ID:             r.ID,
+		Title:          r.Title,
+		DepartmentID:   r.DepartmentID,
+		Department:     r.Department,
+		PositionID:     r.PositionID,
+		Position:       r.Position,
+		Headcount:      r.Headcount,
+		Hired:          r.Hired,
+		EmploymentType: r.EmploymentType,
+		Description:    r.Description,
+		Reason:         r.Reason,
+		SalaryMin:      r.SalaryMin,
+		SalaryMax:      r.SalaryMax,
+		Status:         r.Status,
+		RequestedBy:    r.RequestedBy,
+		RequesterName:  r.RequesterName,
+		ApproverID:     r.ApproverID,
+		ApproverName:   r.ApproverName,
+		DecisionNote:   r.DecisionNote,
+		DecidedAt:      r.DecidedAt,
+		CreatedAt:      r.CreatedAt,
+		UpdatedAt:      r.UpdatedAt,
+		Posting:        posting,
+	}
+}
+
+func (p *JobPosting) ToJobPostingResponse() web.JobPostingResponse {
+	return web.JobPostingResponse{
+		ID:             p.ID,
+		RequisitionID:  p.RequisitionID,
+		Title:          p.Title,
+		Department:     p.Department,
+		EmploymentType: p.EmploymentType,
+		Description:    p.Description,
+		Requirements:   p.Requirements,
+		Location:       p.Location,
+		Status:         p.Status,
+		PublishedAt:    p.PublishedAt,
+		ClosesOn:       formatOptionalDate(p.ClosesOn),
+		ClosedAt:       p.ClosedAt,
+	}
+}
+
+// Helper function for converting the JobRequisitionRequest from web to domain
+func ToDomainJobRequisition(request web.JobRequisitionRequest) JobRequisition {
+	return JobRequisition{
+		Title:          request.Title,
+		DepartmentID:   request.DepartmentID,
+		PositionID:     emptyToNil(request.PositionID),
+		Headcount:      request.Headcount,
+		EmploymentType: request.EmploymentType,
+		Description:    request.Description,
+		Reason:         request.Reason,
+		SalaryMin:      request.SalaryMin,
+		SalaryMax:      request.SalaryMax,
+		Status:         JobRequisitionDraft,
+	}
+}
+
+// Helper function for converting the JobPostingRequest from web to domain
+func ToDomainJobPosting(request web.JobPostingRequest) JobPosting {
+	return JobPosting{
+		Title:        request.Title,
+		Description:  request.Description,
+		Requirements: request.Requirements,
+		Location:     request.Location,
+		Status:       JobPostingOpen,
+		ClosesOn:     helper.ParseOptionalDate(request.ClosesOn),
+	}
+}
@@ -0,0 +1,108 @@
+package domain
+
+import (
+	"fmt"
+
+	"github.com/iqbaludinm/hr-microservice/user-service/model/web"
+)
+
+type JobRequisitionQueryFilter struct {
+	Status       string
+	DepartmentID string
+	ApproverID   string
+
+	// Pagination is used for fetching the data by page. The default value is 1.
+	Pagination Pagination
+}
+
+// BuildJobRequisitionQueries builds the WHERE clause of the job requisition query.
+// The values are returned as 'args' so they are sent as query parameters.
+func (q *JobRequisitionQueryFilter) BuildJobRequisitionQueries() (filter string, args []interface{}, pagination string) {
+	add := func(condition string, value interface{}) {
+		args = append(args, value)
+		filter += fmt.Sprintf(" AND "+condition, len(args))
+	}
+
+	// filter requisition by status
+	if q.Status != "" {
+		add("jr.status = $%d", q.Status)
+	}
+
+	// filter requisition by department
+	if q.DepartmentID != "" {
+		add("jr.department_id = $%d", q.DepartmentID)
+	}
+
+	// filter requisition by approver
+	if q.ApproverID != "" {
+		add("jr.approver_id = $%d", q.ApproverID)
+	}
+
+	// remove the first ' AND ' from the filter
+	if len(filter) > 0 {
+		filter = "WHERE " + filter[5:]
+	}
+
+	return filter, args, q.Pagination.Build()
+}
+
+type JobPostingQueryFilter struct {
+	// OpenOn filters the open postings which are not closed on the date, it is ignored when it is empty
+	OpenOn       string
+	Search       string
+	DepartmentID string
+
+	// Pagination is used for fetching the data by page. The default value is 1.
+	Pagination Pagination
+}
+
+// BuildJobPostingQueries builds the WHERE clause of the job posting query.
+// The values are returned as 'args' so they are sent as query parameters.
+func (q *JobPostingQueryFilter) BuildJobPostingQueries() (filter string, args []interface{}, pagination string) {
+	add := func(condition string, value interface{}) {
+		args = append(args, value)
+		filter += fmt.Sprintf(" AND "+condition, len(args))
+	}
+
+	// filter the open posting which is not closed on the date
+	if q.OpenOn != "" {
+		add("jp.status = 'open' AND (jp.closes_on IS NULL OR jp.closes_on >= $%d::date)", q.OpenOn)
+	}
+
+	// search posting by title
+	if q.Search != "" {
+		add("jp.title ILIKE '%%' || $%d || '%%'", q.Search)
+	}
+
+	// filter posting by department
+	if q.DepartmentID != "" {
+		add("jr.department_id = $%d", q.DepartmentID)
+	}
+
+	// remove the first ' AND ' from the filter
+	if len(filter) > 0 {
+		filter = "WHERE " + filter[5:]
+	}
+
+	return filter, args, q.Pagination.Build()
+}
+
+// Helper function for converting the JobRequisitionQueryFilter from web to domain
+// This function is used for calling a function in the 'repository' layer
+func ToDomainJobRequisitionQueryFilter(q web.JobRequisitionQueryFilter) JobRequisitionQueryFilter {
+	return JobRequisitionQueryFilter{
+		Status:       q.Status,
+		DepartmentID: q.DepartmentID,
+		Pagination:   NewPagination(q.Page, q.Limit),
+	}
+}
+
+// Helper function for converting the JobPostingQueryFilter from web to domain
+// This function is used for calling a function in the 'repository' layer
+func ToDomainJobPostingQueryFilter(q web.JobPostingQueryFilter) JobPostingQueryFilter {
+	return JobPostingQueryFilter{
+		Search:       q.Search,
+		DepartmentID: q.DepartmentID,
+		Pagination:   NewPagination(q.Page, q.Limit),
+	}
+}
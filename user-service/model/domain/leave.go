@@ -0,0 +1,138 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/iqbaludinm/hr-microservice/user-service/helper"
+	"github.com/iqbaludinm/hr-microservice/user-service/model/web"
+)
+
+// Status of the leave request.
+const (
+	LeaveStatusPending   = "pending"
+	LeaveStatusApproved  = "approved"
+	LeaveStatusRejected  = "rejected"
+	LeaveStatusCancelled = "cancelled"
+)
+
+// Half day of the leave request, an empty value is used for full days.
+const (
+	HalfDayMorning   = "morning"
+	HalfDayAfternoon = "afternoon"
+)
+
+// leave request main struct
+type Leave struct {
+	ID           string     `json:"id"`
+	EmployeeID   string     `json:"employee_id"`
+	LeaveTypeID  string     `json:"leave_type_id"`
+	StartDate    time.Time  `json:"start_date"`
+	EndDate      time.Time  `json:"end_date"`
+	HalfDay      string     `json:"half_day"`
+	Days         float64    `json:"days"`
+	Reason       string     `json:"reason"`
+	Status       string     `json:"status"`
+	ApproverID   *string    `json:"approver_id"`
+	DecisionNote string     `json:"decision_note"`
+	DecidedAt    *time.Time `json:"decided_at"`
+	CancelledAt  *time.Time `json:"cancelled_at"`
+	CreatedAt    time.Time  `json:"created_at"`
+	UpdatedAt    time.Time  `json:"updated_at"`
+
+	// joined from the 'employees', 'users' and 'leave_types' table
+	EmployeeNumber string `json:"employee_number"`
+	EmployeeName   string `json:"employee_name"`
+	EmployeeUserID string `json:"employee_user_id"`
+	LeaveTypeCode  string `json:"leave_type_code"`
+	LeaveTypeName  string `json:"leave_type_name"`
+	ApproverName   string `json:"approver_name"`
+}
+
+func (l *Leave) ToLeaveResponse() web.LeaveResponse {
+	return web.LeaveResponse{
+		ID:             l.ID,
+		EmployeeID:     l.EmployeeID,
+		EmployeeNumber: l.EmployeeNumber,
+		EmployeeName:   l.EmployeeName,
+		LeaveTypeID:    l.LeaveTypeID,
+		LeaveTypeCode:  l.LeaveTypeCode,
+		LeaveTypeName:  l.LeaveTypeName,
+		StartDate:      l.StartDate.Format(helper.DateLayout),
+		EndDate:        l.EndDate.Format(helper.DateLayout),
+		HalfDay:        l.HalfDay,
+		Days:           l.Days,
+		Reason:         l.Reason,
+		Status:         l.Status,
+		ApproverID:     l.ApproverID,
+		ApproverName:   l.ApproverName,
+		DecisionNote:   l.DecisionNote,
+		DecidedAt:      l.DecidedAt,
+		CancelledAt:    l.CancelledAt,
+		CreatedAt:      l.CreatedAt,
+		UpdatedAt:      l.UpdatedAt,
+	}
+}
+
+// CountLeaveDays counts the working days between the start and end date (inclusive),
+// the weekends and holidays of the calendar are excluded. A half day leave is counted as 0.5 day.
+func CountLeaveDays(startDate, endDate time.Time, halfDay string, calendar Calendar) float64 {
+	days := float64(calendar.CountWorkingDays(startDate, endDate))
+
+	if halfDay != "" && days > 0 {
+		return 0.5
+	}
+	return days
+}
+
+// leave balance main struct, the balance is kept per employee, leave type and year.
+type LeaveBalance struct {
+	ID          string    `json:"id"`
+	EmployeeID  string    `json:"employee_id"`
+	LeaveTypeID string    `json:"leave_type_id"`
+	Year        int       `json:"year"`
+	CarriedOver float64   `json:"carried_over"`
+	Used        float64   `json:"used"`
+	Pending     float64   `json:"pending"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+
+	// Entitled is calculated from the accrual method of the leave type, it is not stored
+	Entitled float64 `json:"entitled"`
+
+	// joined from the 'leave_types' table
+	LeaveTypeCode string `json:"leave_type_code"`
+	LeaveTypeName string `json:"leave_type_name"`
+}
+
+// Available returns the days that can still be requested, the pending requests are reserved.
+func (b *LeaveBalance) Available() float64 {
+	return b.Entitled + b.CarriedOver - b.Used - b.Pending
+}
+
+func (b *LeaveBalance) ToLeaveBalanceResponse() web.LeaveBalanceResponse {
+	return web.LeaveBalanceResponse{
+		EmployeeID:    b.EmployeeID,
+		LeaveTypeID:   b.LeaveTypeID,
+		LeaveTypeCode: b.LeaveTypeCode,
+		LeaveTypeName: b.LeaveTypeName,
+		Year:          b.Year,
+		Entitled:      b.Entitled,
+		CarriedOver:   b.CarriedOver,
+		Used:          b.Used,
+		Pending:       b.Pending,
+		Available:     b.Available(),
+	}
+}
+
+// Helper function for converting the LeaveQueryFilter from web to domain
+// This function is used for calling a function in the 'repository' layer
+func ToDomainLeaveQueryFilter(q web.LeaveQueryFilter) LeaveQueryFilter {
+	return LeaveQueryFilter{
+		EmployeeID:  q.EmployeeID,
+		LeaveTypeID: q.LeaveTypeID,
+		Status:      q.Status,
+		From:        q.From,
+		To:          q.To,
+		Pagination:  NewPagination(q.Page, q.Limit),
+	}
+}
@@ -0,0 +1,62 @@
+package domain
+
+import "fmt"
+
+type LeaveQueryFilter struct {
+	EmployeeID  string
+	ApproverID  string
+	LeaveTypeID string
+	Status      string
+	// From and To filter the leave requests that overlap the date range, with the DateLayout format
+	From string
+	To   string
+
+	// Pagination is used for fetching the data by page. The default value is 1.
+	Pagination Pagination
+}
+
+// BuildLeaveQueries builds the WHERE clause of the leave request query.
+// The values are returned as 'args' so they are sent as query parameters.
+func (q *LeaveQueryFilter) BuildLeaveQueries() (filter string, args []interface{}, pagination string) {
+	add := func(condition string, value interface{}) {
+		args = append(args, value)
+		filter += fmt.Sprintf(" AND "+condition, len(args))
+	}
+
+	// filter leave by employee
+	if q.EmployeeID != "" {
+		add("l.employee_id = $%d", q.EmployeeID)
+	}
+
+	// filter leave by approver
+	if q.ApproverID != "" {
+		add("l.approver_id = $%d", q.ApproverID)
+	}
+
+	// filter leave by leave type
+	if q.LeaveTypeID != "" {
+		add("l.leave_type_id = $%d", q.LeaveTypeID)
+	}
+
+	// filter leave by status
+	if q.Status != "" {
+		add("l.status = $%d", q.Status)
+	}
+
+	// filter leave that ends on or after the 'from' date
+	if q.From != "" {
+		add("l.end_date >= $%d::date", q.From)
+	}
+
+	// filter leave that starts on or before the 'to' date
+	if q.To != "" {
+		add("l.start_date <= $%d::date", q.To)
+	}
+
+	// remove the first ' AND ' from the filter
+	if len(filter) > 0 {
+		filter = "WHERE " + filter[5:]
+	}
+
+	return filter, args, q.Pagination.Build()
+}
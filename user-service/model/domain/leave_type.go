@@ -0,0 +1,102 @@
+package domain
+
+import (
+	"math"
+	"time"
+
+	"github.com/iqbaludinm/hr-microservice/user-service/model/web"
+)
+
+// Accrual method of the leave type.
+const (
+	// the whole quota is given at the start of the year (prorated from the hire month in the first year)
+	AccrualMethodAnnual = "annual"
+	// 1/12 of the quota is given every month
+	AccrualMethodMonthly = "monthly"
+	// the leave type has no quota, e.g. unpaid or sick leave
+	AccrualMethodNone = "none"
+)
+
+// leave type main struct
+type LeaveType struct {
+	ID              string     `json:"id"`
+	Code            string     `json:"code"`
+	Name            string     `json:"name"`
+	AccrualMethod   string     `json:"accrual_method"`
+	AnnualQuota     float64    `json:"annual_quota"`
+	MaxCarryOver    float64    `json:"max_carry_over"`
+	RequiresBalance bool       `json:"requires_balance"`
+	AllowHalfDay    bool       `json:"allow_half_day"`
+	IsPaid          bool       `json:"is_paid"`
+	CreatedAt       time.Time  `json:"created_at"`
+	UpdatedAt       time.Time  `json:"updated_at"`
+	DeletedAt       *time.Time `json:"deleted_at"`
+}
+
+func (t *LeaveType) ToLeaveTypeResponse() web.LeaveTypeResponse {
+	return web.LeaveTypeResponse{
+		ID:              t.ID,
+		Code:            t.Code,
+		Name:            t.Name,
+		AccrualMethod:   t.AccrualMethod,
+		AnnualQuota:     t.AnnualQuota,
+		MaxCarryOver:    t.MaxCarryOver,
+		RequiresBalance: t.RequiresBalance,
+		AllowHalfDay:    t.AllowHalfDay,
+		IsPaid:          t.IsPaid,
+		CreatedAt:       t.CreatedAt,
+		UpdatedAt:       t.UpdatedAt,
+	}
+}
+
+// Entitlement returns the days that are given to the employee in the year until the 'asOf' date,
+// rounded down to a half day. An employee hired in the middle of the year gets the prorated quota.
+func (t *LeaveType) Entitlement(hireDate time.Time, year int, asOf time.Time) float64 {
+	if t.AccrualMethod != AccrualMethodAnnual && t.AccrualMethod != AccrualMethodMonthly {
+		return 0
+	}
+	if hireDate.Year() > year || asOf.Year() < year {
+		return 0
+	}
+
+	firstMonth := 1
+	if hireDate.Year() == year {
+		firstMonth = int(hireDate.Month())
+	}
+
+	lastMonth := 12
+	if t.AccrualMethod == AccrualMethodMonthly && asOf.Year() == year {
+		lastMonth = int(asOf.Month())
+	}
+
+	months := lastMonth - firstMonth + 1
+	if months <= 0 {
+		return 0
+	}
+
+	return math.Floor(t.AnnualQuota/12*float64(months)*2) / 2
+}
+
+// Helper function for converting the CreateLeaveTypeRequest from web to domain
+func ToDomainLeaveType(request web.CreateLeaveTypeRequest) LeaveType {
+	leaveType := LeaveType{
+		Code:            request.Code,
+		Name:            request.Name,
+		AccrualMethod:   request.AccrualMethod,
+		AnnualQuota:     request.AnnualQuota,
+		MaxCarryOver:    request.MaxCarryOver,
+		RequiresBalance: true,
+		AllowHalfDay:    true,
+		IsPaid:          true,
+	}
+	if request.RequiresBalance != nil {
+		leaveType.RequiresBalance = *request.RequiresBalance
+	}
+	if request.AllowHalfDay != nil {
+		leaveType.AllowHalfDay = *request.AllowHalfDay
+	}
+	if request.IsPaid != nil {
+		leaveType.IsPaid = *request.IsPaid
+	}
+	return leaveType
+}
@@ -0,0 +1,274 @@
+package domain
+
+import (
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/iqbaludinm/hr-microservice/user-service/helper"
+	"github.com/iqbaludinm/hr-microservice/user-service/model/web"
+)
+
+// Type of the offboarding.
+const (
+	OffboardingTypeResignation = "resignation"
+	OffboardingTypeTermination = "termination"
+)
+
+// Status of the offboarding, the offboarding is completed when every exit task is done or skipped.
+const (
+	OffboardingStatusInProgress = "in_progress"
+	OffboardingStatusCompleted  = "completed"
+	OffboardingStatusCancelled  = "cancelled"
+)
+
+// Status of the exit task.
+const (
+	OffboardingTaskPending = "pending"
+	OffboardingTaskDone    = "done"
+	OffboardingTaskSkipped = "skipped"
+)
+
+// Category of the exit task.
+const (
+	OffboardingCategoryAssetReturn       = "asset_return"
+	OffboardingCategoryKnowledgeTransfer = "knowledge_transfer"
+	OffboardingCategoryFinalPay          = "final_pay"
+	OffboardingCategoryExitInterview     = "exit_interview"
+	OffboardingCategoryOther             = "other"
+)
+
+// Role of the assignee of the exit task.
+const (
+	OffboardingAssigneeHR       = "hr"
+	OffboardingAssigneeIT       = "it"
+	OffboardingAssigneeManager  = "manager"
+	OffboardingAssigneeEmployee = "employee"
+)
+
+// the exit checklist of every offboarding, the due days are relative to the last working day
+var offboardingChecklist = []web.OffboardingTaskRequest{
+	{
+		Title:        "Knowledge transfer",
+		Description:  "Hand over the ongoing work, documents and accesses to the manager or the successor.",
+		Category:     OffboardingCategoryKnowledgeTransfer,
+		AssigneeRole: OffboardingAssigneeManager,
+		DueDays:      -3,
+	},
+	{
+		Title:        "Exit interview",
+		Description:  "Interview the employee about the reason of leaving, the notes are written on the task.",
+		Category:     OffboardingCategoryExitInterview,
+		AssigneeRole: OffboardingAssigneeHR,
+		DueDays:      -1,
+	},
+	{
+		Title:        "Return company assets",
+		Description:  "Receive every asset held by the employee, the task can only be done when no asset is held.",
+		Category:     OffboardingCategoryAssetReturn,
+		AssigneeRole: OffboardingAssigneeIT,
+		DueDays:      0,
+	},
+	{
+		Title:        "Final pay settlement",
+		Description:  "Settle the last salary, the remaining leave and the severance pay of the employee.",
+		Category:     OffboardingCategoryFinalPay,
+		AssigneeRole: OffboardingAssigneeHR,
+		DueDays:      7,
+	},
+}
+
+// offboarding main struct, the resignation or termination of an employee
+type Offboarding struct {
+	ID                   string     `json:"id"`
+	EmployeeID           string     `json:"employee_id"`
+	Type                 string     `json:"type"`
+	NoticeDate           time.Time  `json:"notice_date"`
+	LastWorkingDay       time.Time  `json:"last_working_day"`
+	Reason               string     `json:"reason"`
+	RehireEligible       bool       `json:"rehire_eligible"`
+	Status               string     `json:"status"`
+	CompletedAt          *time.Time `json:"completed_at"`
+	CancelledAt          *time.Time `json:"cancelled_at"`
+	DeactivatedAt        *time.Time `json:"deactivated_at"`
+	ClearanceDocumentKey *string    `json:"clearance_document_key"`
+	CreatedBy            *string    `json:"created_by"`
+	CreatedAt            time.Time  `json:"created_at"`
+	UpdatedAt            time.Time  `json:"updated_at"`
+
+	Tasks []OffboardingTask `json:"tasks"`
+
+	// joined from the 'employees' and 'users' table, and counted from the 'offboarding_tasks' table
+	EmployeeNumber string `json:"employee_number"`
+	EmployeeName   string `json:"employee_name"`
+	EmployeeUserID string `json:"employee_user_id"`
+	TotalTasks     int    `json:"total_tasks"`
+	CompletedTasks int    `json:"completed_tasks"`
+}
+
+// the exit task of the offboarding
+type OffboardingTask struct {
+	OffboardingID string     `json:"offboarding_id"`
+	Seq           int        `json:"seq"`
+	Title         string     `json:"title"`
+	Description   string     `json:"description"`
+	Category      string     `json:"category"`
+	AssigneeRole  string     `json:"assignee_role"`
+	AssigneeID    *string    `json:"assignee_id"`
+	DueDate       time.Time  `json:"due_date"`
+	Status        string     `json:"status"`
+	Note          string     `json:"note"`
+	CompletedBy   *string    `json:"completed_by"`
+	CompletedAt   *time.Time `json:"completed_at"`
+
+	// joined from the 'offboardings', 'employees' and 'users' table
+	AssigneeName   string  `json:"assignee_name"`
+	AssigneeUserID *string `json:"assignee_user_id"`
+	EmployeeID     string  `json:"employee_id"`
+	EmployeeNumber string  `json:"employee_number"`
+	EmployeeName   string  `json:"employee_name"`
+}
+
+// AddExitTasks adds the exit checklist and the additional tasks to the offboarding. The task of the employee is
+// assigned to the employee itself, the task of the manager to the manager of the employee and the task of HR or IT
+// to the assignee of the task, or the HR or IT assignee of the offboarding when it is not filled.
+func (o *Offboarding) AddExitTasks(employee Employee, hrAssigneeID, itAssigneeID *string, tasks []web.OffboardingTaskRequest) {
+	for _, task := range append(offboardingChecklist, tasks...) {
+		assigneeID := task.AssigneeID
+		if assigneeID != nil && *assigneeID == "" {
+			assigneeID = nil
+		}
+		switch task.AssigneeRole {
+		case OffboardingAssigneeEmployee:
+			assigneeID = &employee.ID
+		case OffboardingAssigneeManager:
+			assigneeID = employee.ManagerID
+		case OffboardingAssigneeHR:
+			if assigneeID == nil {
+				assigneeID = hrAssigneeID
+			}
+		case OffboardingAssigneeIT:
+			if assigneeID == nil {
+				assigneeID = itAssigneeID
+			}
+		}
+
+		o.Tasks = append(o.Tasks, OffboardingTask{
+			Seq:          len(o.Tasks) + 1,
+			Title:        task.Title,
+			Description:  task.Description,
+			Category:     task.Category,
+			AssigneeRole: task.AssigneeRole,
+			AssigneeID:   assigneeID,
+			DueDate:      o.LastWorkingDay.AddDate(0, 0, task.DueDays),
+			Status:       OffboardingTaskPending,
+		})
+	}
+}
+
+// Finished returns whether every exit task of the offboarding is done or skipped.
+func (o *Offboarding) Finished() bool {
+	for _, task := range o.Tasks {
+		if task.Status == OffboardingTaskPending {
+			return false
+		}
+	}
+	return true
+}
+
+// EffectiveDate returns the date the account of the employee is deactivated, the day after the last working day.
+func (o *Offboarding) EffectiveDate() time.Time {
+	return o.LastWorkingDay.AddDate(0, 0, 1)
+}
+
+// EmployeeStatus returns the status of the employee after the offboarding is effective.
+func (o *Offboarding) EmployeeStatus() string {
+	if o.Type == OffboardingTypeTermination {
+		return EmployeeStatusTerminated
+	}
+	return EmployeeStatusResigned
+}
+
+// NewClearanceDocumentKey returns the storage key of the clearance document.
+func (o *Offboarding) NewClearanceDocumentKey() string {
+	return fmt.Sprintf("offboardings/%d/%s-clearance.pdf", o.LastWorkingDay.Year(), o.ID)
+}
+
+// the progress is the percentage of the done or skipped tasks
+func (o *Offboarding) ToOffboardingResponse() web.OffboardingResponse {
+	var tasks []web.OffboardingTaskResponse
+	for _, task := range o.Tasks {
+		tasks = append(tasks, task.ToOffboardingTaskResponse())
+	}
+
+	var progress float64
+	if o.TotalTasks > 0 {
+		progress = math.Round(float64(o.CompletedTasks)/float64(o.TotalTasks)*10000) / 100
+	}
+
+	return web.OffboardingResponse{
+		ID:             o.ID,
+		EmployeeID:     o.EmployeeID,
+		EmployeeNumber: o.EmployeeNumber,
+		EmployeeName:   o.EmployeeName,
+		Type:           o.Type,
+		NoticeDate:     o.NoticeDate.Format(helper.DateLayout),
+		LastWorkingDay: o.LastWorkingDay.Format(helper.DateLayout),
+		Reason:         o.Reason,
+		RehireEligible: o.RehireEligible,
+		Status:         o.Status,
+		TotalTasks:     o.TotalTasks,
+		CompletedTasks: o.CompletedTasks,
+		Progress:       progress,
+		CompletedAt:    o.CompletedAt,
+		CancelledAt:    o.CancelledAt,
+		DeactivatedAt:  o.DeactivatedAt,
+		CreatedBy:      o.CreatedBy,
+		CreatedAt:      o.CreatedAt,
+		UpdatedAt:      o.UpdatedAt,
+		Tasks:          tasks,
+	}
+}
+
+func (t *OffboardingTask) ToOffboardingTaskResponse() web.OffboardingTaskResponse {
+	return web.OffboardingTaskResponse{
+		OffboardingID:  t.OffboardingID,
+		Seq:            t.Seq,
+		Title:          t.Title,
+		Description:    t.Description,
+		Category:       t.Category,
+		AssigneeRole:   t.AssigneeRole,
+		AssigneeID:     t.AssigneeID,
+		AssigneeName:   t.AssigneeName,
+		DueDate:        t.DueDate.Format(helper.DateLayout),
+		Status:         t.Status,
+		Note:           t.Note,
+		CompletedBy:    t.CompletedBy,
+		CompletedAt:    t.CompletedAt,
+		EmployeeID:     t.EmployeeID,
+		EmployeeNumber: t.EmployeeNumber,
+		EmployeeName:   t.EmployeeName,
+	}
+}
+
+// Helper function for converting the OffboardingRequest from web to domain, the employee is eligible for rehire
+// when it is not filled
+func ToDomainOffboarding(request web.OffboardingRequest) Offboarding {
+	noticeDate, _ := helper.ParseDate(request.NoticeDate)
+	lastWorkingDay, _ := helper.ParseDate(request.LastWorkingDay)
+
+	rehireEligible := true
+	if request.RehireEligible != nil {
+		rehireEligible = *request.RehireEligible
+	}
+
+	return Offboarding{
+		EmployeeID:     request.EmployeeID,
+		Type:           request.Type,
+		NoticeDate:     noticeDate,
+		LastWorkingDay: lastWorkingDay,
+		Reason:         request.Reason,
+		RehireEligible: rehireEligible,
+		Status:         OffboardingStatusInProgress,
+	}
+}
@@ -0,0 +1,65 @@
+package domain
+
+import (
+	"fmt"
+
+	"github.com/iqbaludinm/hr-microservice/user-service/model/web"
+)
+
+type OffboardingQueryFilter struct {
+	Status       string
+	Type         string
+	EmployeeID   string
+	DepartmentID string
+
+	// Pagination is used for fetching the data by page. The default value is 1.
+	Pagination Pagination
+}
+
+// BuildOffboardingQueries builds the WHERE clause of the offboarding query.
+// The values are returned as 'args' so they are sent as query parameters.
+func (q *OffboardingQueryFilter) BuildOffboardingQueries() (filter string, args []interface{}, pagination string) {
+	add := func(condition string, value interface{}) {
+		args = append(args, value)
+		filter += fmt.Sprintf(" AND "+condition, len(args))
+	}
+
+	// filter offboarding by status
+	if q.Status != "" {
+		add("o.status = $%d", q.Status)
+	}
+
+	// filter offboarding by type
+	if q.Type != "" {
+		add("o.type = $%d", q.Type)
+	}
+
+	// filter offboarding by employee
+	if q.EmployeeID != "" {
+		add("o.employee_id = $%d", q.EmployeeID)
+	}
+
+	// filter offboarding by the department of the employee
+	if q.DepartmentID != "" {
+		add("e.department_id = $%d", q.DepartmentID)
+	}
+
+	// remove the first ' AND ' from the filter
+	if len(filter) > 0 {
+		filter = "WHERE " + filter[5:]
+	}
+
+	return filter, args, q.Pagination.Build()
+}
+
+// Helper function for converting the OffboardingQueryFilter from web to domain
+// This function is used for calling a function in the 'repository' layer
+func ToDomainOffboardingQueryFilter(q web.OffboardingQueryFilter) OffboardingQueryFilter {
+	return OffboardingQueryFilter{
+		Status:       q.Status,
+		Type:         q.Type,
+		EmployeeID:   q.EmployeeID,
+		DepartmentID: q.DepartmentID,
+		Pagination:   NewPagination(q.Page, q.Limit),
+	}
+}
@@ -0,0 +1,53 @@
+package domain
+
+import (
+	"math"
+	"time"
+
+	"github.com/iqbaludinm/hr-microservice/user-service/model/web"
+)
+
+// office location main struct, the location is a circular geofence around the coordinates.
+// The default calendar is used when the location has no calendar.
+type OfficeLocation struct {
+	ID             string     `json:"id"`
+	Name           string     `json:"name"`
+	Latitude       float64    `json:"latitude"`
+	Longitude      float64    `json:"longitude"`
+	RadiusMeters   int        `json:"radius_meters"`
+	WorkCalendarID *string    `json:"work_calendar_id"`
+	CreatedAt      time.Time  `json:"created_at"`
+	UpdatedAt      time.Time  `json:"updated_at"`
+	DeletedAt      *time.Time `json:"deleted_at"`
+}
+
+func (l *OfficeLocation) ToOfficeLocationResponse() web.OfficeLocationResponse {
+	return web.OfficeLocationResponse{
+		ID:             l.ID,
+		Name:           l.Name,
+		Latitude:       l.Latitude,
+		Longitude:      l.Longitude,
+		RadiusMeters:   l.RadiusMeters,
+		WorkCalendarID: l.WorkCalendarID,
+		CreatedAt:      l.CreatedAt,
+		UpdatedAt:      l.UpdatedAt,
+	}
+}
+
+// Contains returns true when the coordinates are inside the geofence of the office.
+func (l *OfficeLocation) Contains(latitude, longitude float64) bool {
+	return DistanceMeters(l.Latitude, l.Longitude, latitude, longitude) <= float64(l.RadiusMeters)
+}
+
+// DistanceMeters returns the great-circle distance between two coordinates with the haversine formula.
+func DistanceMeters(lat1, lon1, lat2, lon2 float64) float64 {
+	const earthRadiusMeters = 6371000
+	toRadians := func(degree float64) float64 { return degree * math.Pi / 180 }
+
+	dLat := toRadians(lat2 - lat1)
+	dLon := toRadians(lon2 - lon1)
+	a := math.Sin(dLat/2)*math.Sin(dLat/2) +
+		math.Cos(toRadians(lat1))*math.Cos(toRadians(lat2))*math.Sin(dLon/2)*math.Sin(dLon/2)
+
+	return 2 * earthRadiusMeters * math.Asin(math.Sqrt(a))
+}
@@ -0,0 +1,273 @@
+package domain
+
+import (
+	"math"
+	"time"
+
+	"github.com/iqbaludinm/hr-microservice/user-service/helper"
+	"github.com/iqbaludinm/hr-microservice/user-service/model/web"
+)
+
+// Status of the onboarding, the onboarding is completed when every task is done or skipped.
+const (
+	OnboardingStatusInProgress = "in_progress"
+	OnboardingStatusCompleted  = "completed"
+	OnboardingStatusCancelled  = "cancelled"
+)
+
+// Status of the onboarding task.
+const (
+	OnboardingTaskPending = "pending"
+	OnboardingTaskDone    = "done"
+	OnboardingTaskSkipped = "skipped"
+)
+
+// Role of the assignee of the onboarding task.
+const (
+	OnboardingAssigneeHR       = "hr"
+	OnboardingAssigneeIT       = "it"
+	OnboardingAssigneeManager  = "manager"
+	OnboardingAssigneeEmployee = "employee"
+)
+
+// onboarding template main struct, the checklist of the new employees of the position or the department
+type OnboardingTemplate struct {
+	ID           string     `json:"id"`
+	Name         string     `json:"name"`
+	Description  string     `json:"description"`
+	DepartmentID *string    `json:"department_id"`
+	PositionID   *string    `json:"position_id"`
+	CreatedAt    time.Time  `json:"created_at"`
+	UpdatedAt    time.Time  `json:"updated_at"`
+	DeletedAt    *time.Time `json:"deleted_at"`
+
+	Tasks []OnboardingTemplateTask `json:"tasks"`
+}
+
+// the task of the onboarding template, the due date is relative to the hire date of the employee
+type OnboardingTemplateTask struct {
+	TemplateID   string  `json:"template_id"`
+	Seq          int     `json:"seq"`
+	Title        string  `json:"title"`
+	Description  string  `json:"description"`
+	Category     string  `json:"category"`
+	AssigneeRole string  `json:"assignee_role"`
+	AssigneeID   *string `json:"assignee_id"`
+	DueDays      int     `json:"due_days"`
+}
+
+// onboarding main struct, the checklist of a new employee
+type Onboarding struct {
+	ID          string     `json:"id"`
+	EmployeeID  string     `json:"employee_id"`
+	TemplateID  *string    `json:"template_id"`
+	Status      string     `json:"status"`
+	StartedAt   time.Time  `json:"started_at"`
+	CompletedAt *time.Time `json:"completed_at"`
+	CancelledAt *time.Time `json:"cancelled_at"`
+	CreatedAt   time.Time  `json:"created_at"`
+	UpdatedAt   time.Time  `json:"updated_at"`
+
+	Tasks []OnboardingTask `json:"tasks"`
+
+	// joined from the 'employees' and 'users' table, and counted from the 'onboarding_tasks' table
+	EmployeeNumber string    `json:"employee_number"`
+	EmployeeName   string    `json:"employee_name"`
+	HireDate       time.Time `json:"hire_date"`
+	TotalTasks     int       `json:"total_tasks"`
+	CompletedTasks int       `json:"completed_tasks"`
+	OverdueTasks   int       `json:"overdue_tasks"`
+}
+
+// the task of the onboarding, the assignee is resolved from the role when the onboarding is started
+type OnboardingTask struct {
+	OnboardingID string     `json:"onboarding_id"`
+	Seq          int        `json:"seq"`
+	Title        string     `json:"title"`
+	Description  string     `json:"description"`
+	Category     string     `json:"category"`
+	AssigneeRole string     `json:"assignee_role"`
+	AssigneeID   *string    `json:"assignee_id"`
+	DueDate      time.Time  `json:"due_date"`
+	Status       string     `json:"status"`
+	Note         string     `json:"note"`
+	CompletedBy  *string    `json:"completed_by"`
+	CompletedAt  *time.Time `json:"completed_at"`
+	RemindedAt   *time.Time `json:"reminded_at"`
+
+	// joined from the 'onboardings', 'employees' and 'users' table
+	AssigneeName   string  `json:"assignee_name"`
+	AssigneeUserID *string `json:"assignee_user_id"`
+	EmployeeID     string  `json:"employee_id"`
+	EmployeeNumber string  `json:"employee_number"`
+	EmployeeName   string  `json:"employee_name"`
+}
+
+// NewOnboarding returns the onboarding of the employee with the tasks of the template. The task of the employee
+// is assigned to the employee itself, the task of the manager to the manager of the employee and the task of HR
+// or IT to the assignee of the template task.
+func NewOnboarding(employee Employee, template OnboardingTemplate) Onboarding {
+	onboarding := Onboarding{
+		EmployeeID: employee.ID,
+		TemplateID: &template.ID,
+		Status:     OnboardingStatusInProgress,
+	}
+
+	for _, task := range template.Tasks {
+		assigneeID := task.AssigneeID
+		switch task.AssigneeRole {
+		case OnboardingAssigneeEmployee:
+			assigneeID = &employee.ID
+		case OnboardingAssigneeManager:
+			assigneeID = employee.ManagerID
+		}
+
+		onboarding.Tasks = append(onboarding.Tasks, OnboardingTask{
+			Seq:          task.Seq,
+			Title:        task.Title,
+			Description:  task.Description,
+			Category:     task.Category,
+			AssigneeRole: task.AssigneeRole,
+			AssigneeID:   assigneeID,
+			DueDate:      employee.HireDate.AddDate(0, 0, task.DueDays),
+			Status:       OnboardingTaskPending,
+		})
+	}
+
+	return onboarding
+}
+
+// Finished returns whether every task of the onboarding is done or skipped.
+func (o *Onboarding) Finished() bool {
+	for _, task := range o.Tasks {
+		if task.Status == OnboardingTaskPending {
+			return false
+		}
+	}
+	return true
+}
+
+// Overdue returns whether the pending task has passed its due date.
+func (t *OnboardingTask) Overdue() bool {
+	return t.Status == OnboardingTaskPending && t.DueDate.Before(helper.Today())
+}
+
+func (t *OnboardingTemplate) ToOnboardingTemplateResponse() web.OnboardingTemplateResponse {
+	tasks := make([]web.OnboardingTemplateTaskResponse, 0, len(t.Tasks))
+	for _, task := range t.Tasks {
+		tasks = append(tasks, web.OnboardingTemplateTaskResponse{
+			Seq:          task.Seq,
+			Title:        task.Title,
+			Description:  task.Description,
+			Category:     task.Category,
+			AssigneeRole: task.AssigneeRole,
+			AssigneeID:   task.AssigneeID,
+			DueDays:      task.DueDays,
+		})
+	}
+
+	return web.OnboardingTemplateResponse{
+		ID:           t.ID,
+		Name:         t.Name,
+		Description:  t.Description,
+		DepartmentID: t.DepartmentID,
+		PositionID:   t.PositionID,
+		CreatedAt:    t.CreatedAt,
+		UpdatedAt:    t.UpdatedAt,
+		Tasks:        tasks,
+	}
+}
+
+// the progress is the percentage of the done or skipped tasks
+func (o *Onboarding) ToOnboardingResponse() web.OnboardingResponse {
+	var tasks []web.OnboardingTaskResponse
+	for _, task := range o.Tasks {
+		tasks = append(tasks, task.ToOnboardingTaskResponse())
+	}
+
+	var progress float64
+	if o.TotalTasks > 0 {
+		progress = math.Round(float64(o.CompletedTasks)/float64(o.TotalTasks)*10000) / 100
+	}
+
+	return web.OnboardingResponse{
+		ID:             o.ID,
+		EmployeeID:     o.EmployeeID,
+		EmployeeNumber: o.EmployeeNumber,
+		EmployeeName:   o.EmployeeName,
+		HireDate:       o.HireDate.Format(helper.DateLayout),
+		TemplateID:     o.TemplateID,
+		Status:         o.Status,
+		TotalTasks:     o.TotalTasks,
+		CompletedTasks: o.CompletedTasks,
+		OverdueTasks:   o.OverdueTasks,
+		Progress:       progress,
+		StartedAt:      o.StartedAt,
+		CompletedAt:    o.CompletedAt,
+		CancelledAt:    o.CancelledAt,
+		CreatedAt:      o.CreatedAt,
+		UpdatedAt:      o.UpdatedAt,
+		Tasks:          tasks,
+	}
+}
+
+func (t *OnboardingTask) ToOnboardingTaskResponse() web.OnboardingTaskResponse {
+	return web.OnboardingTaskResponse{
+		OnboardingID:   t.OnboardingID,
+		Seq:            t.Seq,
+		Title:          t.Title,
+		Description:    t.Description,
+		Category:       t.Category,
+		AssigneeRole:   t.AssigneeRole,
+		AssigneeID:     t.AssigneeID,
+		AssigneeName:   t.AssigneeName,
+		DueDate:        t.DueDate.Format(helper.DateLayout),
+		Overdue:        t.Overdue(),
+		Status:         t.Status,
+		Note:           t.Note,
+		CompletedBy:    t.CompletedBy,
+		CompletedAt:    t.CompletedAt,
+		EmployeeID:     t.EmployeeID,
+		EmployeeNumber: t.EmployeeNumber,
+		EmployeeName:   t.EmployeeName,
+	}
+}
+
+// Helper function for converting the OnboardingTemplateRequest from web to domain, the tasks are numbered in the
+// order of the request
+func ToDomainOnboardingTemplate(request web.OnboardingTemplateRequest) OnboardingTemplate {
+	departmentID := request.DepartmentID
+	if departmentID != nil && *departmentID == "" {
+		departmentID = nil
+	}
+	positionID := request.PositionID
+	if positionID != nil && *positionID == "" {
+		positionID = nil
+	}
+
+	tasks := make([]OnboardingTemplateTask, 0, len(request.Tasks))
+	for i, task := range request.Tasks {
+		assigneeID := task.AssigneeID
+		if assigneeID != nil && *assigneeID == "" {
+			assigneeID = nil
+		}
+
+		tasks = append(tasks, OnboardingTemplateTask{
+			Seq:          i + 1,
+			Title:        task.Title,
+			Description:  task.Description,
+			Category:     task.Category,
+			AssigneeRole: task.AssigneeRole,
+			AssigneeID:   assigneeID,
+			DueDays:      task.DueDays,
+		})
+	}
+
+	return OnboardingTemplate{
+		Name:         request.Name,
+		Description:  request.Description,
+		DepartmentID: departmentID,
+		PositionID:   positionID,
+		Tasks:        tasks,
+	}
+}
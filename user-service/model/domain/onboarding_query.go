@@ -0,0 +1,58 @@
+package domain
+
+import (
+	"fmt"
+
+	"github.com/iqbaludinm/hr-microservice/user-service/model/web"
+)
+
+type OnboardingQueryFilter struct {
+	Status       string
+	EmployeeID   string
+	DepartmentID string
+
+	// Pagination is used for fetching the data by page. The default value is 1.
+	Pagination Pagination
+}
+
+// BuildOnboardingQueries builds the WHERE clause of the onboarding query.
+// The values are returned as 'args' so they are sent as query parameters.
+func (q *OnboardingQueryFilter) BuildOnboardingQueries() (filter string, args []interface{}, pagination string) {
+	add := func(condition string, value interface{}) {
+		args = append(args, value)
+		filter += fmt.Sprintf(" AND "+condition, len(args))
+	}
+
+	// filter onboarding by status
+	if q.Status != "" {
+		add("o.status = $%d", q.Status)
+	}
+
+	// filter onboarding by employee
+	if q.EmployeeID != "" {
+		add("o.employee_id = $%d", q.EmployeeID)
+	}
+
+	// filter onboarding by the department of the employee
+	if q.DepartmentID != "" {
+		add("e.department_id = $%d", q.DepartmentID)
+	}
+
+	// remove the first ' AND ' from the filter
+	if len(filter) > 0 {
+		filter = "WHERE " + filter[5:]
+	}
+
+	return filter, args, q.Pagination.Build()
+}
+
+// Helper function for converting the OnboardingQueryFilter from web to domain
+// This function is used for calling a function in the 'repository' layer
+func ToDomainOnboardingQueryFilter(q web.OnboardingQueryFilter) OnboardingQueryFilter {
+	return OnboardingQueryFilter{
+		Status:       q.Status,
+		EmployeeID:   q.EmployeeID,
+		DepartmentID: q.DepartmentID,
+		Pagination:   NewPagination(q.Page, q.Limit),
+	}
+}
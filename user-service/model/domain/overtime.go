@@ -0,0 +1,194 @@
+package domain
+
+import (
+	"math"
+	"sort"
+	"time"
+
+	"github.com/iqbaludinm/hr-microservice/user-service/helper"
+	"github.com/iqbaludinm/hr-microservice/user-service/model/web"
+)
+
+// Status of the overtime request.
+const (
+	OvertimeStatusPending   = "pending"
+	OvertimeStatusApproved  = "approved"
+	OvertimeStatusRejected  = "rejected"
+	OvertimeStatusCancelled = "cancelled"
+)
+
+// overtime request main struct, the overtime is requested before it is worked and approved by the manager
+type Overtime struct {
+	ID           string     `json:"id"`
+	EmployeeID   string     `json:"employee_id"`
+	Date         time.Time  `json:"date"`
+	Hours        float64    `json:"hours"`
+	Reason       string     `json:"reason"`
+	WorkingDay   bool       `json:"working_day"`
+	Status       string     `json:"status"`
+	ApproverID   *string    `json:"approver_id"`
+	DecisionNote string     `json:"decision_note"`
+	DecidedAt    *time.Time `json:"decided_at"`
+	CancelledAt  *time.Time `json:"cancelled_at"`
+	CreatedAt    time.Time  `json:"created_at"`
+	UpdatedAt    time.Time  `json:"updated_at"`
+
+	// joined from the 'attendances' table, the minutes are empty when there is no checked out attendance on the date
+	AttendanceOvertimeMinutes *int `json:"attendance_overtime_minutes"`
+	AttendanceWorkMinutes     *int `json:"attendance_work_minutes"`
+
+	// joined from the 'employees', 'users' and 'departments' table
+	EmployeeNumber string `json:"employee_number"`
+	EmployeeName   string `json:"employee_name"`
+	EmployeeUserID string `json:"employee_user_id"`
+	DepartmentID   string `json:"department_id"`
+	DepartmentName string `json:"department_name"`
+	ApproverName   string `json:"approver_name"`
+}
+
+func (o *Overtime) ToOvertimeResponse() web.OvertimeResponse {
+	response := web.OvertimeResponse{
+		ID:             o.ID,
+		EmployeeID:     o.EmployeeID,
+		EmployeeNumber: o.EmployeeNumber,
+		EmployeeName:   o.EmployeeName,
+		DepartmentID:   o.DepartmentID,
+		DepartmentName: o.DepartmentName,
+		Date:           o.Date.Format(helper.DateLayout),
+		Hours:          o.Hours,
+		Reason:         o.Reason,
+		WorkingDay:     o.WorkingDay,
+		Status:         o.Status,
+		ApproverID:     o.ApproverID,
+		ApproverName:   o.ApproverName,
+		DecisionNote:   o.DecisionNote,
+		DecidedAt:      o.DecidedAt,
+		CancelledAt:    o.CancelledAt,
+		PaidHours:      toHours(o.PaidMinutes()),
+		CreatedAt:      o.CreatedAt,
+		UpdatedAt:      o.UpdatedAt,
+	}
+	if actual, ok := o.ActualMinutes(); ok {
+		hours := toHours(actual)
+		response.ActualHours = &hours
+	}
+
+	return response
+}
+
+// ActualMinutes returns the overtime worked on the date from the checked out attendance, on a working day it is the
+// overtime after the shift and on a rest day it is the whole work time. It returns false when there is no checked
+// out attendance yet.
+func (o *Overtime) ActualMinutes() (int, bool) {
+	if o.AttendanceWorkMinutes == nil || o.AttendanceOvertimeMinutes == nil {
+		return 0, false
+	}
+	if o.WorkingDay {
+		return *o.AttendanceOvertimeMinutes, true
+	}
+	return *o.AttendanceWorkMinutes, true
+}
+
+// PaidMinutes returns the approved minutes limited by the actual minutes, the overtime that is not approved or not
+// worked is not paid.
+func (o *Overtime) PaidMinutes() int {
+	actual, ok := o.ActualMinutes()
+	if o.Status != OvertimeStatusApproved || !ok {
+		return 0
+	}
+	approved := int(math.Round(o.Hours * 60))
+	if actual < approved {
+		return actual
+	}
+	return approved
+}
+
+// MultipliedHours returns the paid hours with the multipliers of the day (Kepmenakertrans 102/2004).
+func (o *Overtime) MultipliedHours(sixDayWeek bool) float64 {
+	return OvertimeHours(o.PaidMinutes(), o.WorkingDay, sixDayWeek)
+}
+
+// The summary of the overtime requests of an employee.
+type OvertimeSummary struct {
+	EmployeeID     string
+	EmployeeNumber string
+	EmployeeName   string
+	DepartmentName string
+
+	PendingRequests  int
+	ApprovedRequests int
+	RejectedRequests int
+	ApprovedMinutes  int
+	ActualMinutes    int
+	PaidMinutes      int
+	MultipliedHours  float64
+}
+
+// Add counts the overtime request in the summary, the hours are only counted for the approved requests.
+func (s *OvertimeSummary) Add(overtime Overtime, sixDayWeek bool) {
+	switch overtime.Status {
+	case OvertimeStatusPending:
+		s.PendingRequests++
+	case OvertimeStatusRejected:
+		s.RejectedRequests++
+	case OvertimeStatusApproved:
+		s.ApprovedRequests++
+		s.ApprovedMinutes += int(math.Round(overtime.Hours * 60))
+		if actual, ok := overtime.ActualMinutes(); ok {
+			s.ActualMinutes += actual
+		}
+		s.PaidMinutes += overtime.PaidMinutes()
+		s.MultipliedHours += overtime.MultipliedHours(sixDayWeek)
+	}
+}
+
+func (s *OvertimeSummary) ToOvertimeDashboardTotal() web.OvertimeDashboardTotal {
+	return web.OvertimeDashboardTotal{
+		PendingRequests:  s.PendingRequests,
+		ApprovedRequests: s.ApprovedRequests,
+		RejectedRequests: s.RejectedRequests,
+		ApprovedHours:    toHours(s.ApprovedMinutes),
+		ActualHours:      toHours(s.ActualMinutes),
+		PaidHours:        toHours(s.PaidMinutes),
+		MultipliedHours:  math.Round(s.MultipliedHours*100) / 100,
+	}
+}
+
+// SummarizeOvertimes summarizes the overtime requests per employee ordered by the department and the name, with the
+// total of every employee. The 6 working days week of the employees (by id) decides the multipliers of the days off.
+func SummarizeOvertimes(overtimes []Overtime, sixDayWeek map[string]bool) ([]OvertimeSummary, OvertimeSummary) {
+	var total OvertimeSummary
+	byEmployee := map[string]*OvertimeSummary{}
+	for _, overtime := range overtimes {
+		summary, ok := byEmployee[overtime.EmployeeID]
+		if !ok {
+			summary = &OvertimeSummary{
+				EmployeeID:     overtime.EmployeeID,
+				EmployeeNumber: overtime.EmployeeNumber,
+				EmployeeName:   overtime.EmployeeName,
+				DepartmentName: overtime.DepartmentName,
+			}
+			byEmployee[overtime.EmployeeID] = summary
+		}
+		summary.Add(overtime, sixDayWeek[overtime.EmployeeID])
+		total.Add(overtime, sixDayWeek[overtime.EmployeeID])
+	}
+
+	summaries := []OvertimeSummary{}
+	for _, summary := range byEmployee {
+		summaries = append(summaries, *summary)
+	}
+	sort.Slice(summaries, func(i, j int) bool {
+		if summaries[i].DepartmentName != summaries[j].DepartmentName {
+			return summaries[i].DepartmentName < summaries[j].DepartmentName
+		}
+		return summaries[i].EmployeeName < summaries[j].EmployeeName
+	})
+
+	return summaries, total
+}
+
+// toHours converts the minutes to the hours rounded to 2 decimals.
+func toHours(minutes int) float64 {
+	return math.Round(float64(minutes)/60*100) / 100
+}
@@ -0,0 +1,80 @@
+package domain
+
+import (
+	"fmt"
+
+	"github.com/iqbaludinm/hr-microservice/user-service/model/web"
+)
+
+type OvertimeQueryFilter struct {
+	EmployeeID string
+	ApproverID string
+	// DepartmentIDs filters the overtime requests of the employees in the departments, e.g. a department and its
+	// sub-departments
+	DepartmentIDs []string
+	Status        string
+	// From and To filter the overtime date (inclusive), with the DateLayout format
+	From string
+	To   string
+
+	// Pagination is used for fetching the data by page. The default value is 1.
+	Pagination Pagination
+}
+
+// BuildOvertimeQueries builds the WHERE clause of the overtime request query.
+// The values are returned as 'args' so they are sent as query parameters.
+func (q *OvertimeQueryFilter) BuildOvertimeQueries() (filter string, args []interface{}, pagination string) {
+	add := func(condition string, value interface{}) {
+		args = append(args, value)
+		filter += fmt.Sprintf(" AND "+condition, len(args))
+	}
+
+	// filter overtime by employee
+	if q.EmployeeID != "" {
+		add("o.employee_id = $%d", q.EmployeeID)
+	}
+
+	// filter overtime by approver
+	if q.ApproverID != "" {
+		add("o.approver_id = $%d", q.ApproverID)
+	}
+
+	// filter overtime by the departments of the employees
+	if len(q.DepartmentIDs) > 0 {
+		add("e.department_id = ANY($%d::uuid[])", q.DepartmentIDs)
+	}
+
+	// filter overtime by status
+	if q.Status != "" {
+		add("o.status = $%d", q.Status)
+	}
+
+	// filter overtime on or after the 'from' date
+	if q.From != "" {
+		add("o.date >= $%d::date", q.From)
+	}
+
+	// filter overtime on or before the 'to' date
+	if q.To != "" {
+		add("o.date <= $%d::date", q.To)
+	}
+
+	// remove the first ' AND ' from the filter
+	if len(filter) > 0 {
+		filter = "WHERE " + filter[5:]
+	}
+
+	return filter, args, q.Pagination.Build()
+}
+
+// Helper function for converting the OvertimeQueryFilter from web to domain
+// This function is used for calling a function in the 'repository' layer
+func ToDomainOvertimeQueryFilter(q web.OvertimeQueryFilter) OvertimeQueryFilter {
+	return OvertimeQueryFilter{
+		EmployeeID: q.EmployeeID,
+		Status:     q.Status,
+		From:       q.From,
+		To:         q.To,
+		Pagination: NewPagination(q.Page, q.Limit),
+	}
+}
@@ -0,0 +1,349 @@
+package domain
+
+import (
+	"fmt"
+	"math"
+	"strings"
+	"time"
+
+	"github.com/iqbaludinm/hr-microservice/user-service/helper"
+	"github.com/iqbaludinm/hr-microservice/user-service/model/web"
+)
+
+// Status of the payroll run, the run is calculated as a draft, reviewed and then locked.
+const (
+	PayrollStatusDraft    = "draft"
+	PayrollStatusReviewed = "reviewed"
+	PayrollStatusLocked   = "locked"
+)
+
+// Category of the payslip item.
+const (
+	PayslipItemEarning              = "earning"
+	PayslipItemDeduction            = "deduction"
+	PayslipItemEmployerContribution = "employer_contribution"
+)
+
+// The monthly working hours the hourly wage of the overtime is based on (Kepmenakertrans 102/2004).
+const monthlyWorkingHours = 173
+
+// payroll run main struct, there is one run per month
+type PayrollRun struct {
+	ID                         string     `json:"id"`
+	Year                       int        `json:"year"`
+	Month                      int        `json:"month"`
+	PeriodStart                time.Time  `json:"period_start"`
+	PeriodEnd                  time.Time  `json:"period_end"`
+	Status                     string     `json:"status"`
+	EmployeeCount              int        `json:"employee_count"`
+	TotalGross                 int64      `json:"total_gross"`
+	TotalDeductions            int64      `json:"total_deductions"`
+	TotalNet                   int64      `json:"total_net"`
+	TotalEmployerContributions int64      `json:"total_employer_contributions"`
+	CalculatedAt               time.Time  `json:"calculated_at"`
+	CreatedBy                  *string    `json:"created_by"`
+	ReviewedBy                 *string    `json:"reviewed_by"`
+	ReviewedAt                 *time.Time `json:"reviewed_at"`
+	LockedBy                   *string    `json:"locked_by"`
+	LockedAt                   *time.Time `json:"locked_at"`
+	CreatedAt                  time.Time  `json:"created_at"`
+	UpdatedAt                  time.Time  `json:"updated_at"`
+}
+
+// payslip main struct, the payroll of an employee in the run. The inputs of the calculation are kept with the
+// results so the payslip can be reproduced. The reimbursement is the sum of the approved expense claims paid with the
+// payslip, it is not taxable.
+type Payslip struct {
+	ID              string            `json:"id"`
+	PayrollRunID    string            `json:"payroll_run_id"`
+	EmployeeID      string            `json:"employee_id"`
+	BaseSalary      int64             `json:"base_salary"`
+	Allowances      []SalaryAllowance `json:"allowances"`
+	TaxStatus       string            `json:"tax_status"`
+	TerCategory     string            `json:"ter_category"`
+	WorkingDays     int               `json:"working_days"`
+	OvertimeHours   float64           `json:"overtime_hours"`
+	UnpaidLeaveDays float64           `json:"unpaid_leave_days"`
+	Reimbursement   int64             `json:"reimbursement"`
+
+	GrossPay                int64     `json:"gross_pay"`
+	TotalDeductions         int64     `json:"total_deductions"`
+	NetPay                  int64     `json:"net_pay"`
+	EmployerContributions   int64     `json:"employer_contributions"`
+	TaxableIncome           int64     `json:"taxable_income"`
+	DeductibleContributions int64     `json:"deductible_contributions"`
+	IncomeTax               int64     `json:"income_tax"`
+	CreatedAt               time.Time `json:"created_at"`
+
+	// the generated pdf of the payslip in the storage, it is generated once the run is locked
+	DocumentKey         *string    `json:"document_key"`
+	DocumentGeneratedAt *time.Time `json:"document_generated_at"`
+	EmailedAt           *time.Time `json:"emailed_at"`
+
+	Items []PayslipItem `json:"items"`
+
+	// the expense claims which are reimbursed with the payslip, they are assigned to the run when the payslip is saved
+	ExpenseClaimIDs []string `json:"-"`
+
+	// joined from the 'payroll_runs', 'employees', 'users' and 'departments' table
+	Year           int    `json:"year"`
+	Month          int    `json:"month"`
+	RunStatus      string `json:"run_status"`
+	EmployeeNumber string `json:"employee_number"`
+	EmployeeName   string `json:"employee_name"`
+	DepartmentName string `json:"department_name"`
+}
+
+// the line item of the payslip, the quantity is e.g. the overtime hours or the unpaid leave days
+type PayslipItem struct {
+	PayslipID string  `json:"payslip_id"`
+	Seq       int     `json:"seq"`
+	Code      string  `json:"code"`
+	Name      string  `json:"name"`
+	Category  string  `json:"category"`
+	Quantity  float64 `json:"quantity"`
+	Amount    int64   `json:"amount"`
+}
+
+// PayslipYearToDate is the sum of the locked payslips of the employee in the earlier months of the year, it is
+// used for the annual PPh 21 of the last period.
+type PayslipYearToDate struct {
+	EmployeeID              string
+	Months                  int
+	TaxableIncome           int64
+	DeductibleContributions int64
+	IncomeTax               int64
+}
+
+func (p *PayrollRun) ToPayrollRunResponse() web.PayrollRunResponse {
+	return web.PayrollRunResponse{
+		ID:                         p.ID,
+		Year:                       p.Year,
+		Month:                      p.Month,
+		PeriodStart:                p.PeriodStart.Format(helper.DateLayout),
+		PeriodEnd:                  p.PeriodEnd.Format(helper.DateLayout),
+		Status:                     p.Status,
+		EmployeeCount:              p.EmployeeCount,
+		TotalGross:                 p.TotalGross,
+		TotalDeductions:            p.TotalDeductions,
+		TotalNet:                   p.TotalNet,
+		TotalEmployerContributions: p.TotalEmployerContributions,
+		CalculatedAt:               p.CalculatedAt,
+		CreatedBy:                  p.CreatedBy,
+		ReviewedBy:                 p.ReviewedBy,
+		ReviewedAt:                 p.ReviewedAt,
+		LockedBy:                   p.LockedBy,
+		LockedAt:                   p.LockedAt,
+		CreatedAt:                  p.CreatedAt,
+		UpdatedAt:                  p.UpdatedAt,
+	}
+}
+
+// Total sums the payslips of the run.
+func (p *PayrollRun) Total(payslips []Payslip) {
+	p.EmployeeCount = len(payslips)
+	p.TotalGross, p.TotalDeductions, p.TotalNet, p.TotalEmployerContributions = 0, 0, 0, 0
+	for _, payslip := range payslips {
+		p.TotalGross += payslip.GrossPay
+		p.TotalDeductions += payslip.TotalDeductions
+		p.TotalNet += payslip.NetPay
+		p.TotalEmployerContributions += payslip.EmployerContributions
+	}
+}
+
+func (p *Payslip) ToPayslipResponse() web.PayslipResponse {
+	items := make([]web.PayslipItemResponse, 0, len(p.Items))
+	for _, item := range p.Items {
+		items = append(items, web.PayslipItemResponse{
+			Code:     item.Code,
+			Name:     item.Name,
+			Category: item.Category,
+			Quantity: item.Quantity,
+			Amount:   item.Amount,
+		})
+	}
+
+	return web.PayslipResponse{
+		ID:                      p.ID,
+		PayrollRunID:            p.PayrollRunID,
+		Year:                    p.Year,
+		Month:                   p.Month,
+		EmployeeID:              p.EmployeeID,
+		EmployeeNumber:          p.EmployeeNumber,
+		EmployeeName:            p.EmployeeName,
+		DepartmentName:          p.DepartmentName,
+		BaseSalary:              p.BaseSalary,
+		Allowances:              toSalaryAllowanceResponses(p.Allowances),
+		TaxStatus:               p.TaxStatus,
+		TerCategory:             p.TerCategory,
+		WorkingDays:             p.WorkingDays,
+		OvertimeHours:           p.OvertimeHours,
+		UnpaidLeaveDays:         p.UnpaidLeaveDays,
+		Reimbursement:           p.Reimbursement,
+		GrossPay:                p.GrossPay,
+		TotalDeductions:         p.TotalDeductions,
+		NetPay:                  p.NetPay,
+		EmployerContributions:   p.EmployerContributions,
+		TaxableIncome:           p.TaxableIncome,
+		DeductibleContributions: p.DeductibleContributions,
+		IncomeTax:               p.IncomeTax,
+		DocumentGenerated:       p.DocumentKey != nil,
+		DocumentGeneratedAt:     p.DocumentGeneratedAt,
+		EmailedAt:               p.EmailedAt,
+		Items:                   items,
+	}
+}
+
+// NewDocumentKey returns the storage key of the payslip pdf, the payslips are grouped by the period of the run.
+func (p *Payslip) NewDocumentKey() string {
+	return fmt.Sprintf("payslips/%d/%02d/%s.pdf", p.Year, p.Month, p.ID)
+}
+
+// DocumentName returns the file name of the payslip pdf for the download and the email attachment.
+func (p *Payslip) DocumentName() string {
+	return fmt.Sprintf("payslip-%d-%02d-%s.pdf", p.Year, p.Month, p.EmployeeNumber)
+}
+
+// PayslipPassword returns the password of the payslip pdf, it is the date of birth of the employee in the
+// DDMMYYYY format.
+func PayslipPassword(dateOfBirth time.Time) string {
+	return dateOfBirth.Format("02012006")
+}
+
+// OvertimeHours converts the overtime minutes of a day to the paid hours (Kepmenakertrans 102/2004). On a working
+// day the first hour is paid 1.5 times and the next hours 2 times. On a day off of a 5 working days week the first
+// 8 hours are paid 2 times, the 9th hour 3 times and the next hours 4 times, on a day off of a 6 working days week
+// the first 7 hours are paid 2 times, the 8th hour 3 times and the next hours 4 times.
+func OvertimeHours(minutes int, workingDay, sixDayWeek bool) float64 {
+	hours := float64(minutes) / 60
+	if hours <= 0 {
+		return 0
+	}
+
+	if workingDay {
+		return 1.5*math.Min(hours, 1) + 2*math.Max(hours-1, 0)
+	}
+	normal := 8.0
+	if sixDayWeek {
+		normal = 7
+	}
+	return 2*math.Min(hours, normal) + 3*math.Min(math.Max(hours-normal, 0), 1) + 4*math.Max(hours-normal-1, 0)
+}
+
+// Calculate computes the line items and the totals of the payslip from its inputs and the rates. The PPh 21 is
+// withheld with the TER rate, except in the last period of the year where the annual tax with the progressive rates
+// is reconciled with the tax withheld in the earlier months (the year-to-date).
+func (p *Payslip) Calculate(rates PayrollRates, yearToDate PayslipYearToDate, lastPeriod bool) error {
+	ptkp, ok := rates.Ptkp[p.TaxStatus]
+	if !ok {
+		return fmt.Errorf("no PTKP rate of the tax status %s", p.TaxStatus)
+	}
+	p.TerCategory = ptkp.TerCategory
+
+	p.Items = []PayslipItem{}
+	add := func(code, name, category string, quantity float64, amount int64) {
+		p.Items = append(p.Items, PayslipItem{
+			PayslipID: p.ID,
+			Seq:       len(p.Items) + 1,
+			Code:      code,
+			Name:      name,
+			Category:  category,
+			Quantity:  quantity,
+			Amount:    amount,
+		})
+	}
+
+	// earnings
+	wage := Wage(p.BaseSalary, p.Allowances)
+	add("BASE_SALARY", "Base salary", PayslipItemEarning, 0, p.BaseSalary)
+	gross := p.BaseSalary
+	for _, allowance := range p.Allowances {
+		add(allowance.Code, allowance.Name, PayslipItemEarning, 0, allowance.Amount)
+		gross += allowance.Amount
+	}
+	if p.OvertimeHours > 0 {
+		overtime := int64(math.Round(float64(wage) / monthlyWorkingHours * p.OvertimeHours))
+		add("OVERTIME", "Overtime", PayslipItemEarning, p.OvertimeHours, overtime)
+		gross += overtime
+	}
+	if p.Reimbursement > 0 {
+		add("REIMBURSEMENT", "Expense reimbursement", PayslipItemEarning, 0, p.Reimbursement)
+		gross += p.Reimbursement
+	}
+
+	// the unpaid leave is deducted from the wage by the working days of the period
+	var deductions, taxableIncome int64
+	if p.UnpaidLeaveDays > 0 && p.WorkingDays > 0 {
+		unpaidLeave := int64(math.Round(float64(wage) / float64(p.WorkingDays) * p.UnpaidLeaveDays))
+		if unpaidLeave > wage {
+			unpaidLeave = wage
+		}
+		add("UNPAID_LEAVE", "Unpaid leave", PayslipItemDeduction, p.UnpaidLeaveDays, unpaidLeave)
+		deductions += unpaidLeave
+		taxableIncome -= unpaidLeave
+	}
+
+	// the BPJS contributions are based on the wage up to the cap of the program
+	var employerContributions, deductibleContributions int64
+	for _, rate := range rates.Bpjs {
+		base := wage
+		if rate.WageCap != nil && base > *rate.WageCap {
+			base = *rate.WageCap
+		}
+		name := "BPJS " + strings.ToUpper(rate.Program)
+		code := "BPJS_" + strings.ToUpper(rate.Program)
+
+		if employee := int64(math.Round(float64(base) * rate.EmployeeRate / 100)); employee > 0 {
+			add(code, name, PayslipItemDeduction, 0, employee)
+			deductions += employee
+			if rate.EmployeeDeductible {
+				deductibleContributions += employee
+			}
+		}
+		if employer := int64(math.Round(float64(base) * rate.EmployerRate / 100)); employer > 0 {
+			add(code+"_EMPLOYER", name+" (employer)", PayslipItemEmployerContribution, 0, employer)
+			employerContributions += employer
+			if rate.EmployerTaxable {
+				taxableIncome += employer
+			}
+		}
+	}
+	// the reimbursement of the expenses is paid back to the employee, it is not an income
+	taxableIncome += gross - p.Reimbursement
+
+	// PPh 21
+	var incomeTax int64
+	if !lastPeriod {
+		rate, ok := rates.TerRate(p.TerCategory, taxableIncome)
+		if !ok {
+			return fmt.Errorf("no TER rate of the category %s", p.TerCategory)
+		}
+		incomeTax = int64(math.Floor(float64(taxableIncome) * rate / 100))
+	} else {
+		annualIncome := yearToDate.TaxableIncome + taxableIncome
+		annualDeductible := yearToDate.DeductibleContributions + deductibleContributions
+		netIncome := annualIncome - JobCost(annualIncome, yearToDate.Months+1) - annualDeductible
+
+		// the taxable income (PKP) is rounded down to the thousand
+		pkp := (netIncome - ptkp.Amount) / 1000 * 1000
+		if pkp < 0 {
+			pkp = 0
+		}
+		// the tax can be negative when more tax was withheld in the earlier months, it is refunded
+		incomeTax = rates.AnnualTax(pkp) - yearToDate.IncomeTax
+	}
+	if incomeTax != 0 {
+		add("PPH21", "PPh 21", PayslipItemDeduction, 0, incomeTax)
+		deductions += incomeTax
+	}
+
+	p.GrossPay = gross
+	p.TotalDeductions = deductions
+	p.NetPay = gross - deductions
+	p.EmployerContributions = employerContributions
+	p.TaxableIncome = taxableIncome
+	p.DeductibleContributions = deductibleContributions
+	p.IncomeTax = incomeTax
+
+	return nil
+}
@@ -0,0 +1,51 @@
+package domain
+
+import (
+	"fmt"
+
+	"github.com/iqbaludinm/hr-microservice/user-service/model/web"
+)
+
+type PayrollRunQueryFilter struct {
+	Year   int
+	Status string
+
+	// Pagination is used for fetching the data by page. The default value is 1.
+	Pagination Pagination
+}
+
+// BuildPayrollRunQueries builds the WHERE clause of the payroll run query.
+// The values are returned as 'args' so they are sent as query parameters.
+func (q *PayrollRunQueryFilter) BuildPayrollRunQueries() (filter string, args []interface{}, pagination string) {
+	add := func(condition string, value interface{}) {
+		args = append(args, value)
+		filter += fmt.Sprintf(" AND "+condition, len(args))
+	}
+
+	// filter payroll run by year
+	if q.Year != 0 {
+		add("year = $%d", q.Year)
+	}
+
+	// filter payroll run by status
+	if q.Status != "" {
+		add("status = $%d", q.Status)
+	}
+
+	// remove the first ' AND ' from the filter
+	if len(filter) > 0 {
+		filter = "WHERE " + filter[5:]
+	}
+
+	return filter, args, q.Pagination.Build()
+}
+
+// Helper function for converting the PayrollRunQueryFilter from web to domain
+// This function is used for calling a function in the 'repository' layer
+func ToDomainPayrollRunQueryFilter(q web.PayrollRunQueryFilter) PayrollRunQueryFilter {
+	return PayrollRunQueryFilter{
+		Year:       q.Year,
+		Status:     q.Status,
+		Pagination: NewPagination(q.Page, q.Limit),
+	}
+}
@@ -0,0 +1,174 @@
+package domain
+
+import (
+	"math"
+	"time"
+
+	"github.com/iqbaludinm/hr-microservice/user-service/helper"
+	"github.com/iqbaludinm/hr-microservice/user-service/model/web"
+)
+
+// the rate of a BPJS program in percent, a new version of the program starts on its effective date
+type BpjsRate struct {
+	ID                 string    `json:"id"`
+	Program            string    `json:"program"`
+	EmployeeRate       float64   `json:"employee_rate"`
+	EmployerRate       float64   `json:"employer_rate"`
+	WageCap            *int64    `json:"wage_cap"`
+	EmployerTaxable    bool      `json:"employer_taxable"`
+	EmployeeDeductible bool      `json:"employee_deductible"`
+	EffectiveFrom      time.Time `json:"effective_from"`
+	CreatedAt          time.Time `json:"created_at"`
+}
+
+// the annual non-taxable income (PTKP) and the TER category of the tax status
+type PtkpRate struct {
+	TaxStatus     string    `json:"tax_status"`
+	Amount        int64     `json:"amount"`
+	TerCategory   string    `json:"ter_category"`
+	EffectiveFrom time.Time `json:"effective_from"`
+}
+
+// the monthly effective rate (TER) of PPh 21 in percent for the income range (inclusive), the range has no
+// maximum when it is empty
+type TerRate struct {
+	Category      string    `json:"category"`
+	MinIncome     int64     `json:"min_income"`
+	MaxIncome     *int64    `json:"max_income"`
+	Rate          float64   `json:"rate"`
+	EffectiveFrom time.Time `json:"effective_from"`
+}
+
+// the layer of the progressive annual rates (Pasal 17) of PPh 21 in percent, the last layer has no end
+type TaxRate struct {
+	UpTo          *int64    `json:"up_to"`
+	Rate          float64   `json:"rate"`
+	EffectiveFrom time.Time `json:"effective_from"`
+}
+
+// PayrollRates are the statutory rates effective on a date, the payroll is calculated with the rates effective
+// on the last day of the period.
+type PayrollRates struct {
+	Bpjs []BpjsRate
+	Ptkp map[string]PtkpRate
+	Ter  []TerRate
+	Tax  []TaxRate
+}
+
+// The job cost (biaya jabatan) deducted from the annual gross income, 5% of the income and at most 500,000 a month.
+const (
+	jobCostRate       = 5
+	jobCostMonthlyMax = 500000
+)
+
+// TerRate returns the TER rate of the category for the monthly gross income.
+func (r *PayrollRates) TerRate(category string, income int64) (float64, bool) {
+	for _, rate := range r.Ter {
+		if rate.Category == category && income >= rate.MinIncome && (rate.MaxIncome == nil || income <= *rate.MaxIncome) {
+			return rate.Rate, true
+		}
+	}
+	return 0, false
+}
+
+// AnnualTax returns the annual PPh 21 of the taxable income (PKP) with the progressive rates.
+func (r *PayrollRates) AnnualTax(taxableIncome int64) int64 {
+	var tax float64
+	var lower int64
+	for _, rate := range r.Tax {
+		upper := taxableIncome
+		if rate.UpTo != nil && *rate.UpTo < taxableIncome {
+			upper = *rate.UpTo
+		}
+		if upper > lower {
+			tax += float64(upper-lower) * rate.Rate / 100
+		}
+		if rate.UpTo == nil || taxableIncome <= *rate.UpTo {
+			break
+		}
+		lower = *rate.UpTo
+	}
+	return int64(math.Floor(tax))
+}
+
+// JobCost returns the job cost (biaya jabatan) of the annual gross income earned in the months.
+func JobCost(income int64, months int) int64 {
+	cost := income * jobCostRate / 100
+	if limit := int64(jobCostMonthlyMax * months); cost > limit {
+		cost = limit
+	}
+	if cost < 0 {
+		return 0
+	}
+	return cost
+}
+
+func (r *PayrollRates) ToPayrollRatesResponse(date time.Time) web.PayrollRatesResponse {
+	response := web.PayrollRatesResponse{
+		Date: date.Format(helper.DateLayout),
+		Bpjs: []web.BpjsRateResponse{},
+		Ptkp: []web.PtkpRateResponse{},
+		Ter:  []web.TerRateResponse{},
+		Tax:  []web.TaxRateResponse{},
+	}
+	for _, rate := range r.Bpjs {
+		response.Bpjs = append(response.Bpjs, rate.ToBpjsRateResponse())
+	}
+	for _, status := range TaxStatuses {
+		if rate, ok := r.Ptkp[status]; ok {
+			response.Ptkp = append(response.Ptkp, web.PtkpRateResponse{
+				TaxStatus:     rate.TaxStatus,
+				Amount:        rate.Amount,
+				TerCategory:   rate.TerCategory,
+				EffectiveFrom: rate.EffectiveFrom.Format(helper.DateLayout),
+			})
+		}
+	}
+	for _, rate := range r.Ter {
+		response.Ter = append(response.Ter, web.TerRateResponse{
+			Category:      rate.Category,
+			MinIncome:     rate.MinIncome,
+			MaxIncome:     rate.MaxIncome,
+			Rate:          rate.Rate,
+			EffectiveFrom: rate.EffectiveFrom.Format(helper.DateLayout),
+		})
+	}
+	for _, rate := range r.Tax {
+		response.Tax = append(response.Tax, web.TaxRateResponse{
+			UpTo:          rate.UpTo,
+			Rate:          rate.Rate,
+			EffectiveFrom: rate.EffectiveFrom.Format(helper.DateLayout),
+		})
+	}
+
+	return response
+}
+
+func (r *BpjsRate) ToBpjsRateResponse() web.BpjsRateResponse {
+	return web.BpjsRateResponse{
+		ID:                 r.ID,
+		Program:            r.Program,
+		EmployeeRate:       r.EmployeeRate,
+		EmployerRate:       r.EmployerRate,
+		WageCap:            r.WageCap,
+		EmployerTaxable:    r.EmployerTaxable,
+		EmployeeDeductible: r.EmployeeDeductible,
+		EffectiveFrom:      r.EffectiveFrom.Format(helper.DateLayout),
+		CreatedAt:          r.CreatedAt,
+	}
+}
+
+// Helper function for converting the CreateBpjsRateRequest from web to domain
+func ToDomainBpjsRate(request web.CreateBpjsRateRequest) BpjsRate {
+	effectiveFrom, _ := helper.ParseDate(request.EffectiveFrom)
+
+	return BpjsRate{
+		Program:            request.Program,
+		EmployeeRate:       request.EmployeeRate,
+		EmployerRate:       request.EmployerRate,
+		WageCap:            request.WageCap,
+		EmployerTaxable:    request.EmployerTaxable,
+		EmployeeDeductible: request.EmployeeDeductible,
+		EffectiveFrom:      effectiveFrom,
+	}
+}
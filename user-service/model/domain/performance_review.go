@@ -0,0 +1,379 @@
+package domain
+
+import (
+	"math"
+	"time"
+
+	"github.com/iqbaludinm/hr-microservice/user-service/helper"
+	"github.com/iqbaludinm/hr-microservice/user-service/model/web"
+)
+
+// Status of the review cycle, the cycle moves through the phases in this order.
+const (
+	ReviewCycleDraft             = "draft"
+	ReviewCycleGoalSetting       = "goal_setting"
+	ReviewCycleSelfAssessment    = "self_assessment"
+	ReviewCycleManagerAssessment = "manager_assessment"
+	ReviewCycleCalibration       = "calibration"
+	ReviewCycleClosed            = "closed"
+)
+
+// the next phase of the review cycle
+var nextReviewCycleStatus = map[string]string{
+	ReviewCycleDraft:             ReviewCycleGoalSetting,
+	ReviewCycleGoalSetting:       ReviewCycleSelfAssessment,
+	ReviewCycleSelfAssessment:    ReviewCycleManagerAssessment,
+	ReviewCycleManagerAssessment: ReviewCycleCalibration,
+	ReviewCycleCalibration:       ReviewCycleClosed,
+}
+
+// Rating of the performance review, from the highest to the lowest.
+const (
+	ReviewRatingOutstanding      = "outstanding"
+	ReviewRatingExceeds          = "exceeds"
+	ReviewRatingMeets            = "meets"
+	ReviewRatingNeedsImprovement = "needs_improvement"
+	ReviewRatingUnsatisfactory   = "unsatisfactory"
+)
+
+// ReviewRatings are the ratings from the highest to the lowest.
+var ReviewRatings = []string{
+	ReviewRatingOutstanding,
+	ReviewRatingExceeds,
+	ReviewRatingMeets,
+	ReviewRatingNeedsImprovement,
+	ReviewRatingUnsatisfactory,
+}
+
+// Status of the peer feedback.
+const (
+	ReviewPeerRequested = "requested"
+	ReviewPeerSubmitted = "submitted"
+)
+
+// ReviewGoalTotalWeight is the total weight of the goals of a review.
+const ReviewGoalTotalWeight = 100
+
+// review cycle main struct, the review period of the participants
+type ReviewCycle struct {
+	ID            string     `json:"id"`
+	Name          string     `json:"name"`
+	Description   string     `json:"description"`
+	PeriodStart   time.Time  `json:"period_start"`
+	PeriodEnd     time.Time  `json:"period_end"`
+	Status        string     `json:"status"`
+	PeerFeedback  bool       `json:"peer_feedback"`
+	PeerAnonymous bool       `json:"peer_anonymous"`
+	CreatedBy     *string    `json:"created_by"`
+	ClosedAt      *time.Time `json:"closed_at"`
+	CreatedAt     time.Time  `json:"created_at"`
+	UpdatedAt     time.Time  `json:"updated_at"`
+
+	Reviews []PerformanceReview `json:"reviews"`
+
+	// counted from the 'performance_reviews' table
+	TotalReviews     int `json:"total_reviews"`
+	SelfSubmitted    int `json:"self_submitted"`
+	ManagerSubmitted int `json:"manager_submitted"`
+}
+
+// performance review main struct, the review of a participant of the cycle
+type PerformanceReview struct {
+	ID                 string     `json:"id"`
+	CycleID            string     `json:"cycle_id"`
+	EmployeeID         string     `json:"employee_id"`
+	ReviewerID         *string    `json:"reviewer_id"`
+	SelfScore          *float64   `json:"self_score"`
+	SelfComment        string     `json:"self_comment"`
+	SelfSubmittedAt    *time.Time `json:"self_submitted_at"`
+	ManagerScore       *float64   `json:"manager_score"`
+	ManagerComment     string     `json:"manager_comment"`
+	ManagerSubmittedAt *time.Time `json:"manager_submitted_at"`
+	CalibratedScore    *float64   `json:"calibrated_score"`
+	CalibrationNote    string     `json:"calibration_note"`
+	CalibratedBy       *string    `json:"calibrated_by"`
+	FinalScore         *float64   `json:"final_score"`
+	FinalRating        *string    `json:"final_rating"`
+	LockedAt           *time.Time `json:"locked_at"`
+	CreatedAt          time.Time  `json:"created_at"`
+	UpdatedAt          time.Time  `json:"updated_at"`
+
+	Goals []ReviewGoal `json:"goals"`
+	Peers []ReviewPeer `json:"peers"`
+
+	// joined from the 'review_cycles', 'employees' and 'users' table, and averaged from the submitted
+	// 'performance_review_peers'
+	CycleName      string   `json:"cycle_name"`
+	CycleStatus    string   `json:"cycle_status"`
+	PeerAnonymous  bool     `json:"peer_anonymous"`
+	EmployeeNumber string   `json:"employee_number"`
+	EmployeeName   string   `json:"employee_name"`
+	EmployeeUserID string   `json:"employee_user_id"`
+	ReviewerName   *string  `json:"reviewer_name"`
+	ReviewerUserID *string  `json:"reviewer_user_id"`
+	PeerScore      *float64 `json:"peer_score"`
+}
+
+// the goal or KPI of the review, scored from 1 to 5 by the employee and the reviewer
+type ReviewGoal struct {
+	ReviewID       string `json:"review_id"`
+	Seq            int    `json:"seq"`
+	Title          string `json:"title"`
+	Description    string `json:"description"`
+	Target         string `json:"target"`
+	Weight         int    `json:"weight"`
+	SelfScore      *int   `json:"self_score"`
+	SelfComment    string `json:"self_comment"`
+	ManagerScore   *int   `json:"manager_score"`
+	ManagerComment string `json:"manager_comment"`
+}
+
+// the feedback of a peer of the reviewed employee
+type ReviewPeer struct {
+	ReviewID    string     `json:"review_id"`
+	ReviewerID  string     `json:"reviewer_id"`
+	Status      string     `json:"status"`
+	Score       *int       `json:"score"`
+	Comment     string     `json:"comment"`
+	RequestedBy *string    `json:"requested_by"`
+	RequestedAt time.Time  `json:"requested_at"`
+	SubmittedAt *time.Time `json:"submitted_at"`
+
+	// joined from the 'performance_reviews', 'review_cycles', 'employees' and 'users' table
+	ReviewerName   string `json:"reviewer_name"`
+	ReviewerUserID string `json:"reviewer_user_id"`
+	CycleName      string `json:"cycle_name"`
+	CycleStatus    string `json:"cycle_status"`
+	EmployeeID     string `json:"employee_id"`
+	EmployeeNumber string `json:"employee_number"`
+	EmployeeName   string `json:"employee_name"`
+}
+
+// NextStatus returns the next phase of the cycle, it is false when the cycle is closed.
+func (c *ReviewCycle) NextStatus() (string, bool) {
+	status, ok := nextReviewCycleStatus[c.Status]
+	return status, ok
+}
+
+// AcceptsPeerFeedback returns whether the peer feedback can be requested and submitted, it is during the self and
+// the manager assessment.
+func (c *ReviewCycle) AcceptsPeerFeedback() bool {
+	return c.PeerFeedback && (c.Status == ReviewCycleSelfAssessment || c.Status == ReviewCycleManagerAssessment)
+}
+
+// TotalWeight returns the total weight of the goals of the review.
+func (r *PerformanceReview) TotalWeight() int {
+	total := 0
+	for _, goal := range r.Goals {
+		total += goal.Weight
+	}
+	return total
+}
+
+// SelfGoalScore and ManagerGoalScore return the weighted score of the goals, they are nil when a goal is not
+// scored.
+func (r *PerformanceReview) SelfGoalScore() *float64 {
+	return weightedScore(r.Goals, func(goal ReviewGoal) *int { return goal.SelfScore })
+}
+
+func (r *PerformanceReview) ManagerGoalScore() *float64 {
+	return weightedScore(r.Goals, func(goal ReviewGoal) *int { return goal.ManagerScore })
+}
+
+// CurrentScore returns the score the rating is taken from, the calibrated score or the manager score. It is the
+// final score once the review is locked.
+func (r *PerformanceReview) CurrentScore() *float64 {
+	if r.FinalScore != nil {
+		return r.FinalScore
+	}
+	if r.CalibratedScore != nil {
+		return r.CalibratedScore
+	}
+	return r.ManagerScore
+}
+
+// Lock sets the final score and rating of the review, it is called when the cycle is closed.
+func (r *PerformanceReview) Lock(lockedAt time.Time) {
+	score := r.CurrentScore()
+	if score != nil {
+		rating := ReviewRating(*score)
+		r.FinalScore = score
+		r.FinalRating = &rating
+	}
+	r.LockedAt = &lockedAt
+	r.UpdatedAt = lockedAt
+}
+
+// ReviewRating returns the rating of the score from 1 to 5.
+func ReviewRating(score float64) string {
+	switch {
+	case score >= 4.5:
+		return ReviewRatingOutstanding
+	case score >= 3.5:
+		return ReviewRatingExceeds
+	case score >= 2.5:
+		return ReviewRatingMeets
+	case score >= 1.5:
+		return ReviewRatingNeedsImprovement
+	default:
+		return ReviewRatingUnsatisfactory
+	}
+}
+
+func weightedScore(goals []ReviewGoal, score func(ReviewGoal) *int) *float64 {
+	if len(goals) == 0 {
+		return nil
+	}
+
+	total, weights := 0, 0
+	for _, goal := range goals {
+		value := score(goal)
+		if value == nil {
+			return nil
+		}
+		total += *value * goal.Weight
+		weights += goal.Weight
+	}
+
+	weighted := math.Round(float64(total)/float64(weights)*100) / 100
+	return &weighted
+}
+
+func (c *ReviewCycle) ToReviewCycleResponse() web.ReviewCycleResponse {
+	return web.ReviewCycleResponse{
+		ID:               c.ID,
+		Name:             c.Name,
+		Description:      c.Description,
+		PeriodStart:      c.PeriodStart.Format(helper.DateLayout),
+		PeriodEnd:        c.PeriodEnd.Format(helper.DateLayout),
+		Status:           c.Status,
+		PeerFeedback:     c.PeerFeedback,
+		PeerAnonymous:    c.PeerAnonymous,
+		TotalReviews:     c.TotalReviews,
+		SelfSubmitted:    c.SelfSubmitted,
+		ManagerSubmitted: c.ManagerSubmitted,
+		CreatedBy:        c.CreatedBy,
+		ClosedAt:         c.ClosedAt,
+		CreatedAt:        c.CreatedAt,
+		UpdatedAt:        c.UpdatedAt,
+	}
+}
+
+func (r *PerformanceReview) ToPerformanceReviewResponse() web.PerformanceReviewResponse {
+	response := web.PerformanceReviewResponse{
+		ID:                 r.ID,
+		CycleID:            r.CycleID,
+		CycleName:          r.CycleName,
+		CycleStatus:        r.CycleStatus,
+		EmployeeID:         r.EmployeeID,
+		EmployeeNumber:     r.EmployeeNumber,
+		EmployeeName:       r.EmployeeName,
+		ReviewerID:         r.ReviewerID,
+		ReviewerName:       r.ReviewerName,
+		SelfScore:          r.SelfScore,
+		SelfComment:        r.SelfComment,
+		SelfSubmittedAt:    r.SelfSubmittedAt,
+		ManagerScore:       r.ManagerScore,
+		ManagerComment:     r.ManagerComment,
+		ManagerSubmittedAt: r.ManagerSubmittedAt,
+		PeerScore:          r.PeerScore,
+		CalibratedScore:    r.CalibratedScore,
+		CalibrationNote:    r.CalibrationNote,
+		FinalScore:         r.FinalScore,
+		FinalRating:        r.FinalRating,
+		LockedAt:           r.LockedAt,
+		CreatedAt:          r.CreatedAt,
+		UpdatedAt:          r.UpdatedAt,
+	}
+
+	for _, goal := range r.Goals {
+		response.Goals = append(response.Goals, web.ReviewGoalResponse{
+			Seq:            goal.Seq,
+			Title:          goal.Title,
+			Description:    goal.Description,
+			Target:         goal.Target,
+			Weight:         goal.Weight,
+			SelfScore:      goal.SelfScore,
+			SelfComment:    goal.SelfComment,
+			ManagerScore:   goal.ManagerScore,
+			ManagerComment: goal.ManagerComment,
+		})
+	}
+	for _, peer := range r.Peers {
+		response.Peers = append(response.Peers, peer.ToReviewPeerResponse(r.PeerAnonymous))
+	}
+
+	return response
+}
+
+// ToReviewPeerResponse hides the reviewer of the feedback when the peers of the cycle are anonymous.
+func (p *ReviewPeer) ToReviewPeerResponse(anonymous bool) web.ReviewPeerResponse {
+	response := web.ReviewPeerResponse{
+		ReviewID:    p.ReviewID,
+		Status:      p.Status,
+		Score:       p.Score,
+		Comment:     p.Comment,
+		RequestedAt: p.RequestedAt,
+		SubmittedAt: p.SubmittedAt,
+	}
+	if !anonymous {
+		reviewerID, reviewerName := p.ReviewerID, p.ReviewerName
+		response.ReviewerID = &reviewerID
+		response.ReviewerName = &reviewerName
+	}
+
+	return response
+}
+
+func (p *ReviewPeer) ToReviewPeerRequestResponse() web.ReviewPeerRequestResponse {
+	return web.ReviewPeerRequestResponse{
+		ReviewID:       p.ReviewID,
+		CycleName:      p.CycleName,
+		EmployeeID:     p.EmployeeID,
+		EmployeeNumber: p.EmployeeNumber,
+		EmployeeName:   p.EmployeeName,
+		Status:         p.Status,
+		Score:          p.Score,
+		Comment:        p.Comment,
+		RequestedAt:    p.RequestedAt,
+		SubmittedAt:    p.SubmittedAt,
+	}
+}
+
+// Helper function for converting the ReviewCycleRequest from web to domain, the peers are anonymous by default
+func ToDomainReviewCycle(request web.ReviewCycleRequest) ReviewCycle {
+	periodStart, _ := helper.ParseDate(request.PeriodStart)
+	periodEnd, _ := helper.ParseDate(request.PeriodEnd)
+
+	peerAnonymous := true
+	if request.PeerAnonymous != nil {
+		peerAnonymous = *request.PeerAnonymous
+	}
+
+	return ReviewCycle{
+		Name:          request.Name,
+		Description:   request.Description,
+		PeriodStart:   periodStart,
+		PeriodEnd:     periodEnd,
+		Status:        ReviewCycleDraft,
+		PeerFeedback:  request.PeerFeedback,
+		PeerAnonymous: peerAnonymous,
+	}
+}
+
+// Helper function for converting the ReviewGoalsRequest from web to domain, the goals are numbered in the order of
+// the request
+func ToDomainReviewGoals(reviewID string, request web.ReviewGoalsRequest) []ReviewGoal {
+	var goals []ReviewGoal
+	for i, goal := range request.Goals {
+		goals = append(goals, ReviewGoal{
+			ReviewID:    reviewID,
+			Seq:         i + 1,
+			Title:       goal.Title,
+			Description: goal.Description,
+			Target:      goal.Target,
+			Weight:      goal.Weight,
+		})
+	}
+	return goals
+}
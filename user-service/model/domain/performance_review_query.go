@@ -0,0 +1,108 @@
+package domain
+
+import (
+	"fmt"
+
+	"github.com/iqbaludinm/hr-microservice/user-service/model/web"
+)
+
+type ReviewCycleQueryFilter struct {
+	Status string
+
+	// Pagination is used for fetching the data by page. The default value is 1.
+	Pagination Pagination
+}
+
+// BuildReviewCycleQueries builds the WHERE clause of the review cycle query.
+// The values are returned as 'args' so they are sent as query parameters.
+func (q *ReviewCycleQueryFilter) BuildReviewCycleQueries() (filter string, args []interface{}, pagination string) {
+	add := func(condition string, value interface{}) {
+		args = append(args, value)
+		filter += fmt.Sprintf(" AND "+condition, len(args))
+	}
+
+	// filter review cycle by status
+	if q.Status != "" {
+		add("rc.status = $%d", q.Status)
+	}
+
+	// remove the first ' AND ' from the filter
+	if len(filter) > 0 {
+		filter = "WHERE " + filter[5:]
+	}
+
+	return filter, args, q.Pagination.Build()
+}
+
+// Helper function for converting the ReviewCycleQueryFilter from web to domain
+// This function is used for calling a function in the 'repository' layer
+func ToDomainReviewCycleQueryFilter(q web.ReviewCycleQueryFilter) ReviewCycleQueryFilter {
+	return ReviewCycleQueryFilter{
+		Status:     q.Status,
+		Pagination: NewPagination(q.Page, q.Limit),
+	}
+}
+
+type PerformanceReviewQueryFilter struct {
+	CycleID      string
+	EmployeeID   string
+	ReviewerID   string
+	DepartmentID string
+	Rating       string
+
+	// Pagination is used for fetching the data by page. The default value is 1.
+	Pagination Pagination
+}
+
+// BuildPerformanceReviewQueries builds the WHERE clause of the performance review query.
+// The values are returned as 'args' so they are sent as query parameters.
+func (q *PerformanceReviewQueryFilter) BuildPerformanceReviewQueries() (filter string, args []interface{}, pagination string) {
+	add := func(condition string, value interface{}) {
+		args = append(args, value)
+		filter += fmt.Sprintf(" AND "+condition, len(args))
+	}
+
+	// filter review by cycle
+	if q.CycleID != "" {
+		add("r.cycle_id = $%d", q.CycleID)
+	}
+
+	// filter review by the reviewed employee
+	if q.EmployeeID != "" {
+		add("r.employee_id = $%d", q.EmployeeID)
+	}
+
+	// filter review by the reviewer
+	if q.ReviewerID != "" {
+		add("r.reviewer_id = $%d", q.ReviewerID)
+	}
+
+	// filter review by the department of the employee
+	if q.DepartmentID != "" {
+		add("e.department_id = $%d", q.DepartmentID)
+	}
+
+	// filter review by the final rating
+	if q.Rating != "" {
+		add("r.final_rating = $%d", q.Rating)
+	}
+
+	// remove the first ' AND ' from the filter
+	if len(filter) > 0 {
+		filter = "WHERE " + filter[5:]
+	}
+
+	return filter, args, q.Pagination.Build()
+}
+
+// Helper function for converting the PerformanceReviewQueryFilter from web to domain
+// This function is used for calling a function in the 'repository' layer
+func ToDomainPerformanceReviewQueryFilter(q web.PerformanceReviewQueryFilter) PerformanceReviewQueryFilter {
+	return PerformanceReviewQueryFilter{
+		EmployeeID:   q.EmployeeID,
+		ReviewerID:   q.ReviewerID,
+		DepartmentID: q.DepartmentID,
+		Rating:       q.Rating,
+		Pagination:   NewPagination(q.Page, q.Limit),
+	}
+}
@@ -0,0 +1,33 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/iqbaludinm/hr-microservice/user-service/model/web"
+)
+
+// position main struct, a position always belongs to a department
+type Position struct {
+	ID           string     `json:"id"`
+	DepartmentID string     `json:"department_id"`
+	Title        string     `json:"title"`
+	Level        int        `json:"level"`
+	CreatedAt    time.Time  `json:"created_at"`
+	UpdatedAt    time.Time  `json:"updated_at"`
+	DeletedAt    *time.Time `json:"deleted_at"`
+
+	// joined from the 'departments' table
+	DepartmentName string `json:"department_name"`
+}
+
+func (p *Position) ToPositionResponse() web.PositionResponse {
+	return web.PositionResponse{
+		ID:             p.ID,
+		DepartmentID:   p.DepartmentID,
+		DepartmentName: p.DepartmentName,
+		Title:          p.Title,
+		Level:          p.Level,
+		CreatedAt:      p.CreatedAt,
+		UpdatedAt:      p.UpdatedAt,
+	}
+}
@@ -0,0 +1,75 @@
+package domain
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/iqbaludinm/hr-microservice/user-service/helper"
+	"github.com/iqbaludinm/hr-microservice/user-service/model/web"
+)
+
+// Source of the schedule of the employee.
+const (
+	RosterSourceRoster  = "roster"
+	RosterSourceDefault = "default"
+)
+
+// roster main struct, the planned shift of the employee on the date. The day off has no shift.
+type Roster struct {
+	ID         string    `json:"id"`
+	EmployeeID string    `json:"employee_id"`
+	Date       time.Time `json:"date"`
+	ShiftID    *string   `json:"shift_id"`
+	PatternID  *string   `json:"pattern_id"`
+	CreatedAt  time.Time `json:"created_at"`
+	UpdatedAt  time.Time `json:"updated_at"`
+}
+
+// RosterEntry is the schedule of the employee on the date, from the roster or from the assigned or default shift.
+// The day off has no shift.
+type RosterEntry struct {
+	EmployeeID     string
+	EmployeeNumber string
+	EmployeeName   string
+	Date           time.Time
+	Shift          *Shift
+	Source         string
+}
+
+func (r *RosterEntry) ToRosterResponse() web.RosterResponse {
+	response := web.RosterResponse{
+		EmployeeID:     r.EmployeeID,
+		EmployeeNumber: r.EmployeeNumber,
+		EmployeeName:   r.EmployeeName,
+		Date:           r.Date.Format(helper.DateLayout),
+		IsDayOff:       r.Shift == nil,
+		Source:         r.Source,
+	}
+	if r.Shift != nil {
+		start, end := r.Shift.Schedule(r.Date)
+		response.ShiftID = &r.Shift.ID
+		response.ShiftCode = r.Shift.Code
+		response.ShiftName = r.Shift.Name
+		response.StartAt = &start
+		response.EndAt = &end
+		response.BreakMinutes = r.Shift.BreakMinutes
+	}
+
+	return response
+}
+
+// ToICalendarEvent converts the schedule to the event of the calendar feed, the day off has no event.
+func (r *RosterEntry) ToICalendarEvent() (helper.ICalendarEvent, bool) {
+	if r.Shift == nil {
+		return helper.ICalendarEvent{}, false
+	}
+
+	start, end := r.Shift.Schedule(r.Date)
+	return helper.ICalendarEvent{
+		UID:         fmt.Sprintf("%s-%s@hr-microservice", r.EmployeeID, r.Date.Format("20060102")),
+		Summary:     fmt.Sprintf("%s Shift", r.Shift.Name),
+		Description: fmt.Sprintf("%s (%s - %s)", r.Shift.Code, r.Shift.StartTime, r.Shift.EndTime),
+		Start:       start,
+		End:         end,
+	}, true
+}
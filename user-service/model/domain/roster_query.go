@@ -0,0 +1,43 @@
+package domain
+
+import (
+	"fmt"
+	"time"
+)
+
+type RosterQueryFilter struct {
+	EmployeeIDs []string
+	From        time.Time
+	To          time.Time
+}
+
+// BuildRosterQueries builds the WHERE clause of the roster query.
+// The values are returned as 'args' so they are sent as query parameters.
+func (q *RosterQueryFilter) BuildRosterQueries() (filter string, args []interface{}) {
+	add := func(condition string, value interface{}) {
+		args = append(args, value)
+		filter += fmt.Sprintf(" AND "+condition, len(args))
+	}
+
+	// filter roster by employees
+	if len(q.EmployeeIDs) > 0 {
+		add("r.employee_id = ANY($%d::uuid[])", q.EmployeeIDs)
+	}
+
+	// filter roster on or after the 'from' date
+	if !q.From.IsZero() {
+		add("r.date >= $%d", q.From)
+	}
+
+	// filter roster on or before the 'to' date
+	if !q.To.IsZero() {
+		add("r.date <= $%d", q.To)
+	}
+
+	// remove the first ' AND ' from the filter
+	if len(filter) > 0 {
+		filter = "WHERE " + filter[5:]
+	}
+
+	return filter, args
+}
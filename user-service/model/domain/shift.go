@@ -0,0 +1,95 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/iqbaludinm/hr-microservice/user-service/helper"
+	"github.com/iqbaludinm/hr-microservice/user-service/model/web"
+)
+
+// TimeLayout is the layout of the start and end time of the shift.
+const TimeLayout = "15:04"
+
+// shift main struct, the start and end time use the TimeLayout format in WIB
+type Shift struct {
+	ID                         string     `json:"id"`
+	Code                       string     `json:"code"`
+	Name                       string     `json:"name"`
+	StartTime                  string     `json:"start_time"`
+	EndTime                    string     `json:"end_time"`
+	BreakMinutes               int        `json:"break_minutes"`
+	LateToleranceMinutes       int        `json:"late_tolerance_minutes"`
+	EarlyLeaveToleranceMinutes int        `json:"early_leave_tolerance_minutes"`
+	MinOvertimeMinutes         int        `json:"min_overtime_minutes"`
+	IsDefault                  bool       `json:"is_default"`
+	CreatedAt                  time.Time  `json:"created_at"`
+	UpdatedAt                  time.Time  `json:"updated_at"`
+	DeletedAt                  *time.Time `json:"deleted_at"`
+}
+
+func (s *Shift) ToShiftResponse() web.ShiftResponse {
+	return web.ShiftResponse{
+		ID:                         s.ID,
+		Code:                       s.Code,
+		Name:                       s.Name,
+		StartTime:                  s.StartTime,
+		EndTime:                    s.EndTime,
+		BreakMinutes:               s.BreakMinutes,
+		LateToleranceMinutes:       s.LateToleranceMinutes,
+		EarlyLeaveToleranceMinutes: s.EarlyLeaveToleranceMinutes,
+		MinOvertimeMinutes:         s.MinOvertimeMinutes,
+		IsDefault:                  s.IsDefault,
+		CreatedAt:                  s.CreatedAt,
+		UpdatedAt:                  s.UpdatedAt,
+	}
+}
+
+// Schedule returns the start and end of the shift on the work date. The shift that crosses midnight
+// ends on the next day.
+func (s *Shift) Schedule(date time.Time) (start, end time.Time) {
+	start = atTime(date, s.StartTime)
+	end = atTime(date, s.EndTime)
+	if !end.After(start) {
+		end = end.AddDate(0, 0, 1)
+	}
+	return start, end
+}
+
+// Minutes returns the length of the shift, including the break.
+func (s *Shift) Minutes() int {
+	start, end := s.Schedule(time.Date(2000, 1, 1, 0, 0, 0, 0, time.UTC))
+	return int(end.Sub(start) / time.Minute)
+}
+
+// atTime combines the date with the time of day (TimeLayout format) in WIB.
+func atTime(date time.Time, clock string) time.Time {
+	t, _ := time.Parse(TimeLayout, clock)
+	return time.Date(date.Year(), date.Month(), date.Day(), t.Hour(), t.Minute(), 0, 0, helper.WIB)
+}
+
+// Helper function for converting the CreateShiftRequest from web to domain
+func ToDomainShift(request web.CreateShiftRequest) Shift {
+	return Shift{
+		Code:                       request.Code,
+		Name:                       request.Name,
+		StartTime:                  request.StartTime,
+		EndTime:                    request.EndTime,
+		BreakMinutes:               request.BreakMinutes,
+		LateToleranceMinutes:       request.LateToleranceMinutes,
+		EarlyLeaveToleranceMinutes: request.EarlyLeaveToleranceMinutes,
+		MinOvertimeMinutes:         request.MinOvertimeMinutes,
+		IsDefault:                  request.IsDefault,
+	}
+}
+
+// WorkDate returns the work date of the shift that is checked in at the time. Checking in after midnight
+// of a shift that crosses midnight (before its end time) belongs to the previous date.
+func (s *Shift) WorkDate(at time.Time) time.Time {
+	local := at.In(helper.WIB)
+	date := time.Date(local.Year(), local.Month(), local.Day(), 0, 0, 0, 0, time.UTC)
+
+	if s.EndTime < s.StartTime && local.Format(TimeLayout) < s.EndTime {
+		return date.AddDate(0, 0, -1)
+	}
+	return date
+}
@@ -0,0 +1,74 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/iqbaludinm/hr-microservice/user-service/model/web"
+)
+
+// rotating shift pattern main struct, the pattern repeats after its last day
+type ShiftPattern struct {
+	ID        string            `json:"id"`
+	Code      string            `json:"code"`
+	Name      string            `json:"name"`
+	Days      []ShiftPatternDay `json:"days"`
+	CreatedAt time.Time         `json:"created_at"`
+	UpdatedAt time.Time         `json:"updated_at"`
+	DeletedAt *time.Time        `json:"deleted_at"`
+}
+
+// the shift of the day in the rotation, the day off has no shift
+type ShiftPatternDay struct {
+	DayIndex int     `json:"day_index"`
+	ShiftID  *string `json:"shift_id"`
+
+	// joined from the 'shifts' table
+	ShiftCode string `json:"shift_code"`
+	ShiftName string `json:"shift_name"`
+}
+
+func (p *ShiftPattern) ToShiftPatternResponse() web.ShiftPatternResponse {
+	days := []web.ShiftPatternDayResponse{}
+	for _, day := range p.Days {
+		days = append(days, web.ShiftPatternDayResponse{
+			DayIndex:  day.DayIndex,
+			ShiftID:   day.ShiftID,
+			ShiftCode: day.ShiftCode,
+			ShiftName: day.ShiftName,
+			IsDayOff:  day.ShiftID == nil,
+		})
+	}
+
+	return web.ShiftPatternResponse{
+		ID:        p.ID,
+		Code:      p.Code,
+		Name:      p.Name,
+		Days:      days,
+		CreatedAt: p.CreatedAt,
+		UpdatedAt: p.UpdatedAt,
+	}
+}
+
+// ShiftOn returns the shift id on the n-th day of the rotation (starting from 0), nil is a day off.
+func (p *ShiftPattern) ShiftOn(day int) *string {
+	if len(p.Days) == 0 {
+		return nil
+	}
+	return p.Days[day%len(p.Days)].ShiftID
+}
+
+// Helper function for converting the shift ids of the request to the days of the pattern,
+// an empty id is a day off.
+func ToDomainShiftPatternDays(shiftIDs []string) []ShiftPatternDay {
+	days := make([]ShiftPatternDay, 0, len(shiftIDs))
+	for i, shiftID := range shiftIDs {
+		day := ShiftPatternDay{DayIndex: i}
+		if shiftID != "" {
+			id := shiftID
+			day.ShiftID = &id
+		}
+		days = append(days, day)
+	}
+
+	return days
+}
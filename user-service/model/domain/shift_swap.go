@@ -0,0 +1,68 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/iqbaludinm/hr-microservice/user-service/helper"
+	"github.com/iqbaludinm/hr-microservice/user-service/model/web"
+)
+
+// Status of the shift swap request.
+const (
+	ShiftSwapStatusPending   = "pending"
+	ShiftSwapStatusApproved  = "approved"
+	ShiftSwapStatusRejected  = "rejected"
+	ShiftSwapStatusCancelled = "cancelled"
+)
+
+// shift swap request main struct, the requester and the colleague exchange their shifts on the date.
+// The shifts are kept as they were requested, the day off has no shift.
+type ShiftSwap struct {
+	ID               string     `json:"id"`
+	RequesterID      string     `json:"requester_id"`
+	ColleagueID      string     `json:"colleague_id"`
+	Date             time.Time  `json:"date"`
+	RequesterShiftID *string    `json:"requester_shift_id"`
+	ColleagueShiftID *string    `json:"colleague_shift_id"`
+	Reason           string     `json:"reason"`
+	Status           string     `json:"status"`
+	ApproverID       *string    `json:"approver_id"`
+	DecisionNote     string     `json:"decision_note"`
+	DecidedAt        *time.Time `json:"decided_at"`
+	CancelledAt      *time.Time `json:"cancelled_at"`
+	CreatedAt        time.Time  `json:"created_at"`
+	UpdatedAt        time.Time  `json:"updated_at"`
+
+	// joined from the 'employees', 'users' and 'shifts' table
+	RequesterName      string `json:"requester_name"`
+	RequesterUserID    string `json:"requester_user_id"`
+	RequesterShiftName string `json:"requester_shift_name"`
+	ColleagueName      string `json:"colleague_name"`
+	ColleagueUserID    string `json:"colleague_user_id"`
+	ColleagueShiftName string `json:"colleague_shift_name"`
+	ApproverName       string `json:"approver_name"`
+}
+
+func (w *ShiftSwap) ToShiftSwapResponse() web.ShiftSwapResponse {
+	return web.ShiftSwapResponse{
+		ID:                 w.ID,
+		RequesterID:        w.RequesterID,
+		RequesterName:      w.RequesterName,
+		RequesterShiftID:   w.RequesterShiftID,
+		RequesterShiftName: w.RequesterShiftName,
+		ColleagueID:        w.ColleagueID,
+		ColleagueName:      w.ColleagueName,
+		ColleagueShiftID:   w.ColleagueShiftID,
+		ColleagueShiftName: w.ColleagueShiftName,
+		Date:               w.Date.Format(helper.DateLayout),
+		Reason:             w.Reason,
+		Status:             w.Status,
+		ApproverID:         w.ApproverID,
+		ApproverName:       w.ApproverName,
+		DecisionNote:       w.DecisionNote,
+		DecidedAt:          w.DecidedAt,
+		CancelledAt:        w.CancelledAt,
+		CreatedAt:          w.CreatedAt,
+		UpdatedAt:          w.UpdatedAt,
+	}
+}
@@ -0,0 +1,72 @@
+package domain
+
+import (
+	"fmt"
+
+	"github.com/iqbaludinm/hr-microservice/user-service/model/web"
+)
+
+type ShiftSwapQueryFilter struct {
+	// EmployeeID filters the shift swap requests of the employee, as the requester or the colleague
+	EmployeeID string
+	ApproverID string
+	Status     string
+	// From and To filter the date of the shift swap, with the DateLayout format
+	From string
+	To   string
+
+	// Pagination is used for fetching the data by page. The default value is 1.
+	Pagination Pagination
+}
+
+// BuildShiftSwapQueries builds the WHERE clause of the shift swap query.
+// The values are returned as 'args' so they are sent as query parameters.
+func (q *ShiftSwapQueryFilter) BuildShiftSwapQueries() (filter string, args []interface{}, pagination string) {
+	add := func(condition string, value interface{}) {
+		args = append(args, value)
+		filter += fmt.Sprintf(" AND "+condition, len(args))
+	}
+
+	// filter shift swap by the requester or the colleague
+	if q.EmployeeID != "" {
+		add("(w.requester_id = $%[1]d OR w.colleague_id = $%[1]d)", q.EmployeeID)
+	}
+
+	// filter shift swap by approver
+	if q.ApproverID != "" {
+		add("w.approver_id = $%d", q.ApproverID)
+	}
+
+	// filter shift swap by status
+	if q.Status != "" {
+		add("w.status = $%d", q.Status)
+	}
+
+	// filter shift swap on or after the 'from' date
+	if q.From != "" {
+		add("w.date >= $%d::date", q.From)
+	}
+
+	// filter shift swap on or before the 'to' date
+	if q.To != "" {
+		add("w.date <= $%d::date", q.To)
+	}
+
+	// remove the first ' AND ' from the filter
+	if len(filter) > 0 {
+		filter = "WHERE " + filter[5:]
+	}
+
+	return filter, args, q.Pagination.Build()
+}
+
+// Helper function for converting the ShiftSwapQueryFilter from web to domain
+// This function is used for calling a function in the 'repository' layer
+func ToDomainShiftSwapQueryFilter(q web.ShiftSwapQueryFilter) ShiftSwapQueryFilter {
+	return ShiftSwapQueryFilter{
+		Status:     q.Status,
+		From:       q.From,
+		To:         q.To,
+		Pagination: NewPagination(q.Page, q.Limit),
+	}
+}
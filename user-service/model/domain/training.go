@@ -0,0 +1,193 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/iqbaludinm/hr-microservice/user-service/helper"
+	"github.com/iqbaludinm/hr-microservice/user-service/model/web"
+)
+
+// Status of the training enrollment.
+const (
+	TrainingEnrollmentEnrolled  = "enrolled"
+	TrainingEnrollmentCompleted = "completed"
+	TrainingEnrollmentCancelled = "cancelled"
+)
+
+// training main struct, the training or course in the catalog
+type Training struct {
+	ID                        string     `json:"id"`
+	Code                      string     `json:"code"`
+	Name                      string     `json:"name"`
+	Description               string     `json:"description"`
+	Provider                  string     `json:"provider"`
+	DurationHours             int        `json:"duration_hours"`
+	CertificateRequired       bool       `json:"certificate_required"`
+	CertificateValidityMonths int        `json:"certificate_validity_months"`
+	CreatedAt                 time.Time  `json:"created_at"`
+	UpdatedAt                 time.Time  `json:"updated_at"`
+	DeletedAt                 *time.Time `json:"deleted_at"`
+
+	Requirements []TrainingRequirement `json:"requirements"`
+}
+
+// the position which must take the training
+type TrainingRequirement struct {
+	TrainingID string    `json:"training_id"`
+	PositionID string    `json:"position_id"`
+	DueDays    int       `json:"due_days"`
+	CreatedAt  time.Time `json:"created_at"`
+
+	// joined from the 'positions' table
+	Position string `json:"position"`
+}
+
+// training enrollment main struct, the training of an employee
+type TrainingEnrollment struct {
+	ID                    string     `json:"id"`
+	TrainingID            string     `json:"training_id"`
+	EmployeeID            string     `json:"employee_id"`
+	Mandatory             bool       `json:"mandatory"`
+	Status                string     `json:"status"`
+	DueDate               *time.Time `json:"due_date"`
+	CompletedOn           *time.Time `json:"completed_on"`
+	Note                  string     `json:"note"`
+	CertificateDocumentID *string    `json:"certificate_document_id"`
+	EnrolledBy            *string    `json:"enrolled_by"`
+	CompletedBy           *string    `json:"completed_by"`
+	CreatedAt             time.Time  `json:"created_at"`
+	UpdatedAt             time.Time  `json:"updated_at"`
+
+	// joined from the 'trainings', 'employees', 'users', 'departments', 'positions' and 'employee_documents' table
+	TrainingCode          string     `json:"training_code"`
+	TrainingName          string     `json:"training_name"`
+	EmployeeNumber        string     `json:"employee_number"`
+	EmployeeName          string     `json:"employee_name"`
+	EmployeeUserID        string     `json:"employee_user_id"`
+	Department            string     `json:"department"`
+	Position              string     `json:"position"`
+	CertificateExpiryDate *time.Time `json:"certificate_expiry_date"`
+}
+
+// the employee who must be enrolled in the mandatory training of the position, it is found by the scheduler
+type MandatoryTraining struct {
+	TrainingID     string
+	TrainingName   string
+	DueDays        int
+	EmployeeID     string
+	EmployeeUserID string
+	// the expiry date of the certificate of the last completion, the employee is enrolled again before the
+	// certificate expires
+	CertificateExpiryDate *time.Time
+}
+
+// CertificateExpiryDate returns the expiry date of the certificate which is completed on the date, it is nil when
+// the certificate doesn't expire.
+func (t *Training) CertificateExpiryDate(completedOn time.Time) *time.Time {
+	if t.CertificateValidityMonths == 0 {
+		return nil
+	}
+	expiryDate := completedOn.AddDate(0, t.CertificateValidityMonths, 0)
+	return &expiryDate
+}
+
+// DueDate returns the date the mandatory training must be completed by. The renewal is due when the certificate
+// expires, the first enrollment is due after the due days of the requirement.
+func (m *MandatoryTraining) DueDate(today time.Time) time.Time {
+	if m.CertificateExpiryDate != nil && !m.CertificateExpiryDate.Before(today) {
+		return *m.CertificateExpiryDate
+	}
+	return today.AddDate(0, 0, m.DueDays)
+}
+
+// Overdue returns whether the enrollment is not completed by the due date.
+func (e *TrainingEnrollment) Overdue() bool {
+	return e.Status == TrainingEnrollmentEnrolled && e.DueDate != nil && e.DueDate.Before(helper.Today())
+}
+
+func (t *Training) ToTrainingResponse() web.TrainingResponse {
+	var requirements []web.TrainingRequirementResponse
+	for _, requirement := range t.Requirements {
+		requirements = append(requirements, web.TrainingRequirementResponse{
+			PositionID: requirement.PositionID,
+			Position:   requirement.Position,
+			DueDays:    requirement.DueDays,
+		})
+	}
+
+	return web.TrainingResponse{
+		ID:                        t.ID,
+		Code:                      t.Code,
+		Name:                      t.Name,
+		Description:               t.Description,
+		Provider:                  t.Provider,
+		DurationHours:             t.DurationHours,
+		CertificateRequired:       t.CertificateRequired,
+		CertificateValidityMonths: t.CertificateValidityMonths,
+		CreatedAt:                 t.CreatedAt,
+		UpdatedAt:                 t.UpdatedAt,
+		Requirements:              requirements,
+	}
+}
+
+func (e *TrainingEnrollment) ToTrainingEnrollmentResponse() web.TrainingEnrollmentResponse {
+	return web.TrainingEnrollmentResponse{
+		ID:                    e.ID,
+		TrainingID:            e.TrainingID,
+		TrainingCode:          e.TrainingCode,
+		TrainingName:          e.TrainingName,
+		EmployeeID:            e.EmployeeID,
+		EmployeeNumber:        e.EmployeeNumber,
+		EmployeeName:          e.EmployeeName,
+		Department:            e.Department,
+		Position:              e.Position,
+		Mandatory:             e.Mandatory,
+		Status:                e.Status,
+		DueDate:               formatOptionalDate(e.DueDate),
+		Overdue:               e.Overdue(),
+		CompletedOn:           formatOptionalDate(e.CompletedOn),
+		Note:                  e.Note,
+		CertificateDocumentID: e.CertificateDocumentID,
+		CertificateExpiryDate: formatOptionalDate(e.CertificateExpiryDate),
+		EnrolledBy:            e.EnrolledBy,
+		CompletedBy:           e.CompletedBy,
+		CreatedAt:             e.CreatedAt,
+		UpdatedAt:             e.UpdatedAt,
+	}
+}
+
+func (e *TrainingEnrollment) ToTrainingComplianceResponse() web.TrainingComplianceResponse {
+	return web.TrainingComplianceResponse{
+		EnrollmentID:   e.ID,
+		TrainingID:     e.TrainingID,
+		TrainingCode:   e.TrainingCode,
+		TrainingName:   e.TrainingName,
+		EmployeeID:     e.EmployeeID,
+		EmployeeNumber: e.EmployeeNumber,
+		EmployeeName:   e.EmployeeName,
+		Department:     e.Department,
+		Position:       e.Position,
+		DueDate:        e.DueDate.Format(helper.DateLayout),
+		DaysOverdue:    int(helper.Today().Sub(*e.DueDate).Hours() / 24),
+	}
+}
+
+// Helper function for converting the TrainingRequest from web to domain
+func ToDomainTraining(request web.TrainingRequest) Training {
+	training := Training{
+		Code:                      request.Code,
+		Name:                      request.Name,
+		Description:               request.Description,
+		Provider:                  request.Provider,
+		DurationHours:             request.DurationHours,
+		CertificateRequired:       request.CertificateRequired,
+		CertificateValidityMonths: request.CertificateValidityMonths,
+	}
+	for _, requirement := range request.Requirements {
+		training.Requirements = append(training.Requirements, TrainingRequirement{
+			PositionID: requirement.PositionID,
+			DueDays:    requirement.DueDays,
+		})
+	}
+	return training
+}
@@ -0,0 +1,126 @@
+package domain
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/iqbaludinm/hr-microservice/user-service/helper"
+	"github.com/iqbaludinm/hr-microservice/user-service/model/web"
+)
+
+type TrainingQueryFilter struct {
+	Search     string
+	PositionID string
+
+	// Pagination is used for fetching the data by page. The default value is 1.
+	Pagination Pagination
+}
+
+// BuildTrainingQueries builds the WHERE clause of the training query, the deleted trainings are never returned.
+// The values are returned as 'args' so they are sent as query parameters.
+func (q *TrainingQueryFilter) BuildTrainingQueries() (filter string, args []interface{}, pagination string) {
+	filter = "WHERE t.deleted_at IS NULL"
+	add := func(condition string, value interface{}) {
+		args = append(args, value)
+		filter += fmt.Sprintf(" AND "+condition, len(args))
+	}
+
+	// search training by the code or the name
+	if q.Search != "" {
+		add("(t.code ILIKE '%%' || $%[1]d || '%%' OR t.name ILIKE '%%' || $%[1]d || '%%')", q.Search)
+	}
+
+	// filter training which is mandatory for the position
+	if q.PositionID != "" {
+		add("EXISTS (SELECT 1 FROM training_requirements tr WHERE tr.training_id = t.id AND tr.position_id = $%d)", q.PositionID)
+	}
+
+	return filter, args, q.Pagination.Build()
+}
+
+// Helper function for converting the TrainingQueryFilter from web to domain
+// This function is used for calling a function in the 'repository' layer
+func ToDomainTrainingQueryFilter(q web.TrainingQueryFilter) TrainingQueryFilter {
+	return TrainingQueryFilter{
+		Search:     q.Search,
+		PositionID: q.PositionID,
+		Pagination: NewPagination(q.Page, q.Limit),
+	}
+}
+
+type TrainingEnrollmentQueryFilter struct {
+	TrainingID   string
+	EmployeeID   string
+	DepartmentID string
+	Status       string
+	// OverdueOn filters the mandatory enrollments which are not completed before the date
+	OverdueOn *time.Time
+
+	// Pagination is used for fetching the data by page. The default value is 1.
+	Pagination Pagination
+}
+
+// BuildTrainingEnrollmentQueries builds the WHERE clause of the training enrollment query.
+// The values are returned as 'args' so they are sent as query parameters.
+func (q *TrainingEnrollmentQueryFilter) BuildTrainingEnrollmentQueries() (filter string, args []interface{}, pagination string) {
+	add := func(condition string, value interface{}) {
+		args = append(args, value)
+		filter += fmt.Sprintf(" AND "+condition, len(args))
+	}
+
+	// filter enrollment by training
+	if q.TrainingID != "" {
+		add("te.training_id = $%d", q.TrainingID)
+	}
+
+	// filter enrollment by employee
+	if q.EmployeeID != "" {
+		add("te.employee_id = $%d", q.EmployeeID)
+	}
+
+	// filter enrollment by the department of the employee
+	if q.DepartmentID != "" {
+		add("e.department_id = $%d", q.DepartmentID)
+	}
+
+	// filter enrollment by status
+	if q.Status != "" {
+		add("te.status = $%d", q.Status)
+	}
+
+	// filter the mandatory enrollment which is overdue
+	if q.OverdueOn != nil {
+		add("te.mandatory AND te.status = 'enrolled' AND te.due_date < $%d", *q.OverdueOn)
+	}
+
+	// remove the first ' AND ' from the filter
+	if len(filter) > 0 {
+		filter = "WHERE " + filter[5:]
+	}
+
+	return filter, args, q.Pagination.Build()
+}
+
+// Helper function for converting the TrainingEnrollmentQueryFilter from web to domain
+// This function is used for calling a function in the 'repository' layer
+func ToDomainTrainingEnrollmentQueryFilter(q web.TrainingEnrollmentQueryFilter) TrainingEnrollmentQueryFilter {
+	return TrainingEnrollmentQueryFilter{
+		TrainingID:   q.TrainingID,
+		EmployeeID:   q.EmployeeID,
+		DepartmentID: q.DepartmentID,
+		Status:       q.Status,
+		Pagination:   NewPagination(q.Page, q.Limit),
+	}
+}
+
+// Helper function for converting the TrainingComplianceQueryFilter from web to domain, the compliance report is
+// the mandatory enrollments which are overdue today
+func ToDomainTrainingComplianceQueryFilter(q web.TrainingComplianceQueryFilter) TrainingEnrollmentQueryFilter {
+	today := helper.Today()
+	return TrainingEnrollmentQueryFilter{
+		TrainingID:   q.TrainingID,
+		DepartmentID: q.DepartmentID,
+		OverdueOn:    &today,
+		Pagination:   NewPagination(q.Page, q.Limit),
+	}
+}
@@ -1,11 +1,8 @@
 package domain
 
 import (
-	"fmt"
-	"strconv"
 	"time"
 
-	"github.com/iqbaludinm/hr-microservice/user-service/helper"
 	"github.com/iqbaludinm/hr-microservice/user-service/model/web"
 	"golang.org/x/crypto/bcrypt"
 )
@@ -20,6 +17,7 @@ type User struct {
 	CreatedAt time.Time  `json:"created_at"`
 	UpdatedAt time.Time  `json:"updated_at"`
 	DeletedAt *time.Time `json:"deleted_at"`
+	AvatarURL *string    `json:"avatar_url"`
 }
 
 func (user *User) SetPassword(password string) {
@@ -37,6 +35,7 @@ func (s *User) ToUserResponse() web.UserResponse {
 		Name: s.Name,
 		Email: s.Email,
 		Phone: s.Phone,
+		AvatarURL: s.AvatarURL,
 	}
 }
 
@@ -48,34 +47,11 @@ func ToDomainUserQueryFilter(q web.UserQueryFilter) UserQueryFilter {
 		showDeleted = true
 	}
 
-	// pagination validation. If the value is not a number, then we will use the default value.
-	var page, limit string
-	if q.Page != "" {
-		pageInt, _ := strconv.Atoi(q.Page)
-		if q.Limit != "" {
-			limitInt, _ := strconv.Atoi(q.Limit)
-			page = fmt.Sprintf("%d", (pageInt-1)*limitInt)
-			limit = fmt.Sprintf("%d", limitInt)
-		} else {
-			page = fmt.Sprintf("%d", (pageInt-1)*helper.DefaultLimit)
-			limit = fmt.Sprintf("%d", helper.DefaultLimit)
-		}
-	} else {
-		if q.Limit != "" {
-			limitInt, _ := strconv.Atoi(q.Limit)
-			page = "0"
-			limit = fmt.Sprintf("%d", limitInt)
-		}
-	}
-
 	return UserQueryFilter{
 		Name: q.Name,
 		Email: q.Email,
 		Phone: q.Phone,
 		ShowDeleted:   showDeleted,
-		Pagination: Pagination{
-			Page:  page,
-			Limit: limit,
-		},
+		Pagination:    NewPagination(q.Page, q.Limit),
 	}
 }
\ No newline at end of file
@@ -1,6 +1,11 @@
 package domain
 
-import "fmt"
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/iqbaludinm/hr-microservice/user-service/helper"
+)
 
 type UserQueryFilter struct {
 	Name  string
@@ -19,20 +24,63 @@ type Pagination struct {
 	Limit string
 }
 
-func (q *UserQueryFilter) BuildUserQueries() (filter, pagination string) {
+// NewPagination converts the 'page' and 'limit' query params into an OFFSET/LIMIT pair.
+// If the value is not a number, then we will use the default value.
+func NewPagination(pageQuery, limitQuery string) Pagination {
+	var page, limit string
+	if pageQuery != "" {
+		pageInt, _ := strconv.Atoi(pageQuery)
+		if limitQuery != "" {
+			limitInt, _ := strconv.Atoi(limitQuery)
+			page = fmt.Sprintf("%d", (pageInt-1)*limitInt)
+			limit = fmt.Sprintf("%d", limitInt)
+		} else {
+			page = fmt.Sprintf("%d", (pageInt-1)*helper.DefaultLimit)
+			limit = fmt.Sprintf("%d", helper.DefaultLimit)
+		}
+	} else {
+		if limitQuery != "" {
+			limitInt, _ := strconv.Atoi(limitQuery)
+			page = "0"
+			limit = fmt.Sprintf("%d", limitInt)
+		}
+	}
+
+	return Pagination{
+		Page:  page,
+		Limit: limit,
+	}
+}
+
+// Build the OFFSET/LIMIT clause. It returns an empty string when the pagination is not set.
+func (p Pagination) Build() string {
+	if p.Page != "" && p.Limit != "" {
+		return fmt.Sprintf("OFFSET %s LIMIT %s", p.Page, p.Limit)
+	}
+	return ""
+}
+
+// BuildUserQueries builds the WHERE clause of the filter, the values are passed as the query args so they're never
+// part of the SQL.
+func (q *UserQueryFilter) BuildUserQueries() (filter string, args []interface{}, pagination string) {
+	add := func(condition string, value interface{}) {
+		args = append(args, value)
+		filter += fmt.Sprintf(" AND "+condition, len(args))
+	}
+
 	// filter user by name
 	if q.Name != "" {
-		filter += " AND u.name LIKE '%" + q.Name + "%'"
+		add("u.name LIKE '%%' || $%d || '%%'", q.Name)
 	}
 
 	// filter user by email
 	if q.Email != "" {
-		filter += " AND u.email = '" + q.Email + "'"
+		add("u.email = $%d", q.Email)
 	}
 
 	// filter user by phone
 	if q.Phone != "" {
-		filter += " AND u.phone = " + q.Phone
+		add("u.phone = $%d", q.Phone)
 	}
 
 	// filter user by deleted_at is null
@@ -54,5 +102,5 @@ func (q *UserQueryFilter) BuildUserQueries() (filter, pagination string) {
 		filter = "WHERE " + filter
 	}
 
-	return filter, pagination
+	return filter, args, pagination
 }
@@ -0,0 +1,146 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/iqbaludinm/hr-microservice/user-service/helper"
+	"github.com/iqbaludinm/hr-microservice/user-service/model/web"
+)
+
+// work calendar main struct, the weekend days use the time.Weekday numbers (0 is Sunday)
+type WorkCalendar struct {
+	ID          string     `json:"id"`
+	Code        string     `json:"code"`
+	Name        string     `json:"name"`
+	WeekendDays []int      `json:"weekend_days"`
+	IsDefault   bool       `json:"is_default"`
+	CreatedAt   time.Time  `json:"created_at"`
+	UpdatedAt   time.Time  `json:"updated_at"`
+	DeletedAt   *time.Time `json:"deleted_at"`
+}
+
+// the weekend of the calendar when no calendar is configured
+var defaultWeekendDays = []int{int(time.Sunday), int(time.Saturday)}
+
+func (w *WorkCalendar) ToWorkCalendarResponse() web.WorkCalendarResponse {
+	return web.WorkCalendarResponse{
+		ID:          w.ID,
+		Code:        w.Code,
+		Name:        w.Name,
+		WeekendDays: w.WeekendDays,
+		IsDefault:   w.IsDefault,
+		CreatedAt:   w.CreatedAt,
+		UpdatedAt:   w.UpdatedAt,
+	}
+}
+
+// IsWeekend returns true when the day of the week of the date is off.
+func (w *WorkCalendar) IsWeekend(date time.Time) bool {
+	for _, day := range w.WeekendDays {
+		if time.Weekday(day) == date.Weekday() {
+			return true
+		}
+	}
+	return false
+}
+
+// Calendar is the work calendar with its days between two dates, it answers whether a date is a working day.
+type Calendar struct {
+	WorkCalendar WorkCalendar
+	// the days by date (with the DateLayout format), the day of the calendar overrides the national day
+	days map[string]Holiday
+}
+
+// NewCalendar builds the calendar from the national days and the days of the work calendar.
+func NewCalendar(workCalendar WorkCalendar, holidays []Holiday) Calendar {
+	days := map[string]Holiday{}
+	for _, holiday := range holidays {
+		if holiday.WorkCalendarID == nil {
+			days[holiday.Date.Format(helper.DateLayout)] = holiday
+		}
+	}
+	for _, holiday := range holidays {
+		if holiday.WorkCalendarID != nil && *holiday.WorkCalendarID == workCalendar.ID {
+			days[holiday.Date.Format(helper.DateLayout)] = holiday
+		}
+	}
+
+	return Calendar{WorkCalendar: workCalendar, days: days}
+}
+
+// Day returns the holiday or the working day override on the date.
+func (c *Calendar) Day(date time.Time) (Holiday, bool) {
+	day, ok := c.days[date.Format(helper.DateLayout)]
+	return day, ok
+}
+
+// IsWorkingDay returns true when the date is not a weekend nor a holiday, or the date is overridden as a working day.
+func (c *Calendar) IsWorkingDay(date time.Time) bool {
+	if day, ok := c.Day(date); ok {
+		return !day.IsDayOff()
+	}
+	return !c.WorkCalendar.IsWeekend(date)
+}
+
+// SixDayWeek returns true when the calendar has a single weekend day, the overtime of its days off is paid with the
+// multipliers of a 6 working days week.
+func (c *Calendar) SixDayWeek() bool {
+	return len(c.WorkCalendar.WeekendDays) == 1
+}
+
+// CountWorkingDays counts the working days between the dates (inclusive).
+func (c *Calendar) CountWorkingDays(from, to time.Time) int {
+	days := 0
+	for date := from; !date.After(to); date = date.AddDate(0, 0, 1) {
+		if c.IsWorkingDay(date) {
+			days++
+		}
+	}
+	return days
+}
+
+// Holidays returns the holidays and the working day overrides between the dates (inclusive) ordered by date.
+func (c *Calendar) Holidays(from, to time.Time) []Holiday {
+	holidays := []Holiday{}
+	for date := from; !date.After(to); date = date.AddDate(0, 0, 1) {
+		if day, ok := c.Day(date); ok {
+			holidays = append(holidays, day)
+		}
+	}
+	return holidays
+}
+
+// Calendars are the work calendars with their days between two dates, for resolving the calendar of many
+// employees at once.
+type Calendars struct {
+	byId            map[string]Calendar
+	defaultCalendar Calendar
+}
+
+// NewCalendars builds the calendars from the work calendars and the holidays of every calendar.
+func NewCalendars(workCalendars []WorkCalendar, holidays []Holiday) Calendars {
+	calendars := Calendars{
+		byId: map[string]Calendar{},
+		// the weekends are off when there is no default calendar
+		defaultCalendar: NewCalendar(WorkCalendar{WeekendDays: defaultWeekendDays}, holidays),
+	}
+	for _, workCalendar := range workCalendars {
+		calendar := NewCalendar(workCalendar, holidays)
+		calendars.byId[workCalendar.ID] = calendar
+		if workCalendar.IsDefault {
+			calendars.defaultCalendar = calendar
+		}
+	}
+
+	return calendars
+}
+
+// For returns the calendar by id, the default calendar is returned when the id is empty or not found.
+func (c *Calendars) For(workCalendarID *string) Calendar {
+	if workCalendarID != nil {
+		if calendar, ok := c.byId[*workCalendarID]; ok {
+			return calendar
+		}
+	}
+	return c.defaultCalendar
+}
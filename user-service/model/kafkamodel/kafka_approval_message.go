@@ -0,0 +1,41 @@
+package kafkamodel
+
+import (
+	"time"
+
+	"github.com/iqbaludinm/hr-microservice/user-service/model/domain"
+)
+
+// This struct is used for mapping the 'approval request' data that is produced to 'kafka' with the
+// 'POST.APPROVAL_COMPLETED' method, when the approval is approved, rejected or cancelled. The result is already
+// applied to the entity by the service owning it, the message informs the other services.
+type KafkaApprovalMessage struct {
+	ID              string     `json:"id"`
+	WorkflowID      string     `json:"workflow_id"`
+	WorkflowCode    string     `json:"workflow_code"`
+	EntityType      string     `json:"entity_type"`
+	EntityID        string     `json:"entity_id"`
+	Title           string     `json:"title"`
+	Amount          *int64     `json:"amount"`
+	RequesterID     string     `json:"requester_id"`
+	RequesterUserID string     `json:"requester_user_id"`
+	Status          string     `json:"status"`
+	CompletedAt     *time.Time `json:"completed_at"`
+}
+
+// Convert "ApprovalRequest" object to "KafkaApprovalMessage" object
+func NewKafkaApprovalMessage(request domain.ApprovalRequest) KafkaApprovalMessage {
+	return KafkaApprovalMessage{
+		ID:              request.ID,
+		WorkflowID:      request.WorkflowID,
+		WorkflowCode:    request.WorkflowCode,
+		EntityType:      request.EntityType,
+		EntityID:        request.EntityID,
+		Title:           request.Title,
+		Amount:          request.Amount,
+		RequesterID:     request.RequesterID,
+		RequesterUserID: request.RequesterUserID,
+		Status:          request.Status,
+		CompletedAt:     request.CompletedAt,
+	}
+}
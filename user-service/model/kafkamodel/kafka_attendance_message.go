@@ -0,0 +1,41 @@
+package kafkamodel
+
+import (
+	"time"
+
+	"github.com/iqbaludinm/hr-microservice/user-service/helper"
+	"github.com/iqbaludinm/hr-microservice/user-service/model/domain"
+)
+
+// This struct is used for mapping the 'attendance' data that is produced to 'kafka' with the
+// 'POST.ATTENDANCE' (check-in) and 'PUT.ATTENDANCE' (check-out) methods.
+type KafkaAttendanceMessage struct {
+	ID                string     `json:"id"`
+	EmployeeID        string     `json:"employee_id"`
+	Date              string     `json:"date"`
+	ShiftID           *string    `json:"shift_id"`
+	CheckInAt         time.Time  `json:"check_in_at"`
+	CheckInLocationID *string    `json:"check_in_location_id"`
+	CheckOutAt        *time.Time `json:"check_out_at"`
+	LateMinutes       int        `json:"late_minutes"`
+	EarlyLeaveMinutes int        `json:"early_leave_minutes"`
+	OvertimeMinutes   int        `json:"overtime_minutes"`
+	WorkMinutes       int        `json:"work_minutes"`
+}
+
+// Convert "Attendance" object to "KafkaAttendanceMessage" object
+func NewKafkaAttendanceMessage(attendance domain.Attendance) KafkaAttendanceMessage {
+	return KafkaAttendanceMessage{
+		ID:                attendance.ID,
+		EmployeeID:        attendance.EmployeeID,
+		Date:              attendance.Date.Format(helper.DateLayout),
+		ShiftID:           attendance.ShiftID,
+		CheckInAt:         attendance.CheckInAt,
+		CheckInLocationID: attendance.CheckInLocationID,
+		CheckOutAt:        attendance.CheckOutAt,
+		LateMinutes:       attendance.LateMinutes,
+		EarlyLeaveMinutes: attendance.EarlyLeaveMinutes,
+		OvertimeMinutes:   attendance.OvertimeMinutes,
+		WorkMinutes:       attendance.WorkMinutes,
+	}
+}
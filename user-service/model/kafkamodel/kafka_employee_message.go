@@ -0,0 +1,60 @@
+package kafkamodel
+
+import (
+	"time"
+
+	"github.com/iqbaludinm/hr-microservice/user-service/helper"
+	"github.com/iqbaludinm/hr-microservice/user-service/model/domain"
+)
+
+// This struct is used for mapping the 'employee' data that is produced to 'kafka' with the
+// 'EMPLOYEE' methods (POST.EMPLOYEE, PUT.EMPLOYEE, DELETE.EMPLOYEE) and the self-edited
+// personal data that is consumed back from profile-service (PUT.EMPLOYEE_PERSONAL).
+type KafkaEmployeeMessage struct {
+	ID                string                    `json:"id"`
+	UserID            string                    `json:"user_id"`
+	EmployeeNumber    string                    `json:"employee_number"`
+	DateOfBirth       string                    `json:"date_of_birth"`
+	Gender            string                    `json:"gender"`
+	NIK               string                    `json:"nik"`
+	Address           string                    `json:"address"`
+	HireDate          string                    `json:"hire_date"`
+	EmploymentType    string                    `json:"employment_type"`
+	Status            string                    `json:"status"`
+	JobTitle          string                    `json:"job_title"`
+	SalaryGrade       string                    `json:"salary_grade"`
+	DepartmentID      string                    `json:"department_id"`
+	Department        string                    `json:"department"`
+	PositionID        *string                   `json:"position_id"`
+	ManagerID         *string                   `json:"manager_id"`
+	EmergencyContacts []domain.EmergencyContact `json:"emergency_contacts"`
+	CreatedAt         time.Time                 `json:"created_at"`
+	UpdatedAt         time.Time                 `json:"updated_at"`
+	DeletedAt         *time.Time                `json:"deleted_at"`
+}
+
+// Convert "Employee" object to "KafkaEmployeeMessage" object
+func NewKafkaEmployeeMessage(employee domain.Employee) KafkaEmployeeMessage {
+	return KafkaEmployeeMessage{
+		ID:                employee.ID,
+		UserID:            employee.UserID,
+		EmployeeNumber:    employee.EmployeeNumber,
+		DateOfBirth:       employee.DateOfBirth.Format(helper.DateLayout),
+		Gender:            employee.Gender,
+		NIK:               employee.NIK,
+		Address:           employee.Address,
+		HireDate:          employee.HireDate.Format(helper.DateLayout),
+		EmploymentType:    employee.EmploymentType,
+		Status:            employee.Status,
+		JobTitle:          employee.JobTitle,
+		SalaryGrade:       employee.SalaryGrade,
+		DepartmentID:      employee.DepartmentID,
+		Department:        employee.Department,
+		PositionID:        employee.PositionID,
+		ManagerID:         employee.ManagerID,
+		EmergencyContacts: employee.EmergencyContacts,
+		CreatedAt:         employee.CreatedAt,
+		UpdatedAt:         employee.UpdatedAt,
+		DeletedAt:         employee.DeletedAt,
+	}
+}
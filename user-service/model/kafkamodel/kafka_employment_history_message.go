@@ -0,0 +1,47 @@
+package kafkamodel
+
+import (
+	"time"
+
+	"github.com/iqbaludinm/hr-microservice/user-service/helper"
+	"github.com/iqbaludinm/hr-microservice/user-service/model/domain"
+)
+
+// This struct is used for mapping the 'employment history' data that is produced to 'kafka' with the
+// 'POST.EMPLOYMENT_HISTORY' method, when an employment change becomes effective.
+type KafkaEmploymentHistoryMessage struct {
+	ID            string     `json:"id"`
+	EmployeeID    string     `json:"employee_id"`
+	UserID        string     `json:"user_id"`
+	ChangeType    string     `json:"change_type"`
+	DepartmentID  string     `json:"department_id"`
+	Department    string     `json:"department"`
+	PositionID    *string    `json:"position_id"`
+	Position      string     `json:"position"`
+	JobTitle      string     `json:"job_title"`
+	SalaryGrade   string     `json:"salary_grade"`
+	ManagerID     *string    `json:"manager_id"`
+	EffectiveFrom string     `json:"effective_from"`
+	Reason        string     `json:"reason"`
+	AppliedAt     *time.Time `json:"applied_at"`
+}
+
+// Convert "EmploymentHistory" object to "KafkaEmploymentHistoryMessage" object
+func NewKafkaEmploymentHistoryMessage(history domain.EmploymentHistory, employee domain.Employee) KafkaEmploymentHistoryMessage {
+	return KafkaEmploymentHistoryMessage{
+		ID:            history.ID,
+		EmployeeID:    history.EmployeeID,
+		UserID:        employee.UserID,
+		ChangeType:    history.ChangeType,
+		DepartmentID:  history.DepartmentID,
+		Department:    employee.Department,
+		PositionID:    history.PositionID,
+		Position:      employee.Position,
+		JobTitle:      history.JobTitle,
+		SalaryGrade:   history.SalaryGrade,
+		ManagerID:     history.ManagerID,
+		EffectiveFrom: history.EffectiveFrom.Format(helper.DateLayout),
+		Reason:        history.Reason,
+		AppliedAt:     history.AppliedAt,
+	}
+}
@@ -0,0 +1,32 @@
+package kafkamodel
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// This struct is used for mapping the notification that is produced to the notification topic
+// with the 'POST.NOTIFICATION' method. The notification is delivered to the user by 'UserID'.
+type KafkaNotificationMessage struct {
+	ID        string                 `json:"id"`
+	UserID    string                 `json:"user_id"`
+	Type      string                 `json:"type"`
+	Title     string                 `json:"title"`
+	Message   string                 `json:"message"`
+	Data      map[string]interface{} `json:"data"`
+	CreatedAt time.Time              `json:"created_at"`
+}
+
+// Create a new "KafkaNotificationMessage" object for the user
+func NewKafkaNotificationMessage(userID, notificationType, title, message string, data map[string]interface{}) KafkaNotificationMessage {
+	return KafkaNotificationMessage{
+		ID:        uuid.New().String(),
+		UserID:    userID,
+		Type:      notificationType,
+		Title:     title,
+		Message:   message,
+		Data:      data,
+		CreatedAt: time.Now(),
+	}
+}
@@ -0,0 +1,40 @@
+package kafkamodel
+
+import (
+	"time"
+
+	"github.com/iqbaludinm/hr-microservice/user-service/model/domain"
+)
+
+// This struct is used for mapping the 'payroll run' data that is produced to 'kafka' with the
+// 'PUT.PAYROLL_RUN' method, when the payroll run is locked.
+type KafkaPayrollRunMessage struct {
+	ID                         string     `json:"id"`
+	Year                       int        `json:"year"`
+	Month                      int        `json:"month"`
+	Status                     string     `json:"status"`
+	EmployeeCount              int        `json:"employee_count"`
+	TotalGross                 int64      `json:"total_gross"`
+	TotalDeductions            int64      `json:"total_deductions"`
+	TotalNet                   int64      `json:"total_net"`
+	TotalEmployerContributions int64      `json:"total_employer_contributions"`
+	LockedBy                   *string    `json:"locked_by"`
+	LockedAt                   *time.Time `json:"locked_at"`
+}
+
+// Convert "PayrollRun" object to "KafkaPayrollRunMessage" object
+func NewKafkaPayrollRunMessage(run domain.PayrollRun) KafkaPayrollRunMessage {
+	return KafkaPayrollRunMessage{
+		ID:                         run.ID,
+		Year:                       run.Year,
+		Month:                      run.Month,
+		Status:                     run.Status,
+		EmployeeCount:              run.EmployeeCount,
+		TotalGross:                 run.TotalGross,
+		TotalDeductions:            run.TotalDeductions,
+		TotalNet:                   run.TotalNet,
+		TotalEmployerContributions: run.TotalEmployerContributions,
+		LockedBy:                   run.LockedBy,
+		LockedAt:                   run.LockedAt,
+	}
+}
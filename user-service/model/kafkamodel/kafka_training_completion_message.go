@@ -0,0 +1,45 @@
+package kafkamodel
+
+import (
+	"github.com/iqbaludinm/hr-microservice/user-service/helper"
+	"github.com/iqbaludinm/hr-microservice/user-service/model/domain"
+)
+
+// This struct is used for mapping the 'training enrollment' data that is produced to 'kafka' with the
+// 'POST.TRAINING_COMPLETION' method, when the employee completes the training.
+type KafkaTrainingCompletionMessage struct {
+	ID                    string  `json:"id"`
+	TrainingID            string  `json:"training_id"`
+	TrainingCode          string  `json:"training_code"`
+	TrainingName          string  `json:"training_name"`
+	EmployeeID            string  `json:"employee_id"`
+	UserID                string  `json:"user_id"`
+	Mandatory             bool    `json:"mandatory"`
+	CompletedOn           string  `json:"completed_on"`
+	CertificateDocumentID *string `json:"certificate_document_id"`
+	CertificateExpiryDate *string `json:"certificate_expiry_date"`
+	CompletedBy           *string `json:"completed_by"`
+}
+
+// Convert "TrainingEnrollment" object to "KafkaTrainingCompletionMessage" object
+func NewKafkaTrainingCompletionMessage(enrollment domain.TrainingEnrollment) KafkaTrainingCompletionMessage {
+	var certificateExpiryDate *string
+	if enrollment.CertificateExpiryDate != nil {
+		date := enrollment.CertificateExpiryDate.Format(helper.DateLayout)
+		certificateExpiryDate = &date
+	}
+
+	return KafkaTrainingCompletionMessage{
+		ID:                    enrollment.ID,
+		TrainingID:            enrollment.TrainingID,
+		TrainingCode:          enrollment.TrainingCode,
+		TrainingName:          enrollment.TrainingName,
+		EmployeeID:            enrollment.EmployeeID,
+		UserID:                enrollment.EmployeeUserID,
+		Mandatory:             enrollment.Mandatory,
+		CompletedOn:           enrollment.CompletedOn.Format(helper.DateLayout),
+		CertificateDocumentID: enrollment.CertificateDocumentID,
+		CertificateExpiryDate: certificateExpiryDate,
+		CompletedBy:           enrollment.CompletedBy,
+	}
+}
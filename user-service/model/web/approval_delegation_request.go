@@ -0,0 +1,23 @@
+package web
+
+// The approvals of the logged in user are delegated to the delegate between the dates (inclusive), only the approvals
+// of the entity types are delegated when they are filled.
+type CreateApprovalDelegationRequest struct {
+	DelegateID  string   `json:"delegate_id" validate:"required,uuid"`
+	StartDate   string   `json:"start_date" validate:"required,datetime=2006-01-02"`
+	EndDate     string   `json:"end_date" validate:"required,datetime=2006-01-02"`
+	EntityTypes []string `json:"entity_types" validate:"omitempty,max=5,unique,dive,oneof=leave overtime expense_claim handover employment_contract"`
+	Reason      string   `json:"reason" validate:"max=255"`
+}
+
+type ApprovalDelegationQueryFilter struct {
+	DelegatorID string `query:"delegator_id"`
+	DelegateID  string `query:"delegate_id"`
+	EntityType  string `query:"entity_type"`
+	// Date filters the delegations which are active on the date
+	Date string `query:"date" validate:"omitempty,datetime=2006-01-02"`
+
+	// Pagination is used for fetching the data by page. The default value is 1.
+	Page  string `query:"page"`
+	Limit string `query:"limit"`
+}
@@ -0,0 +1,18 @@
+package web
+
+import "time"
+
+type ApprovalDelegationResponse struct {
+	ID            string     `json:"id"`
+	DelegatorID   string     `json:"delegator_id"`
+	DelegatorName string     `json:"delegator_name"`
+	DelegateID    string     `json:"delegate_id"`
+	DelegateName  string     `json:"delegate_name"`
+	StartDate     string     `json:"start_date"`
+	EndDate       string     `json:"end_date"`
+	EntityTypes   []string   `json:"entity_types"`
+	Reason        string     `json:"reason"`
+	CancelledAt   *time.Time `json:"cancelled_at"`
+	CreatedAt     time.Time  `json:"created_at"`
+	UpdatedAt     time.Time  `json:"updated_at"`
+}
@@ -0,0 +1,24 @@
+package web
+
+// The approval of the entity is requested by its requester through the active workflow of the entity type. The title
+// and the amount which decides the conditional steps are taken from the entity.
+type CreateApprovalRequest struct {
+	EntityType string `json:"entity_type" validate:"required,oneof=leave overtime expense_claim handover employment_contract"`
+	EntityID   string `json:"entity_id" validate:"required,uuid"`
+}
+
+// The request body of approving or rejecting the pending step of an approval.
+type DecideApprovalRequest struct {
+	Note string `json:"note" validate:"max=255"`
+}
+
+type ApprovalQueryFilter struct {
+	EntityType  string `query:"entity_type"`
+	EntityID    string `query:"entity_id"`
+	RequesterID string `query:"requester_id"`
+	Status      string `query:"status" validate:"omitempty,oneof=pending approved rejected cancelled"`
+
+	// Pagination is used for fetching the data by page. The default value is 1.
+	Page  string `query:"page"`
+	Limit string `query:"limit"`
+}
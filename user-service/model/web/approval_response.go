@@ -0,0 +1,57 @@
+package web
+
+import "time"
+
+type ApprovalResponse struct {
+	ID            string                   `json:"id"`
+	WorkflowID    string                   `json:"workflow_id"`
+	WorkflowCode  string                   `json:"workflow_code"`
+	WorkflowName  string                   `json:"workflow_name"`
+	EntityType    string                   `json:"entity_type"`
+	EntityID      string                   `json:"entity_id"`
+	Title         string                   `json:"title"`
+	Amount        *int64                   `json:"amount"`
+	RequesterID   string                   `json:"requester_id"`
+	RequesterName string                   `json:"requester_name"`
+	Status        string                   `json:"status"`
+	Tasks         []ApprovalTaskResponse   `json:"tasks,omitempty"`
+	Actions       []ApprovalActionResponse `json:"actions,omitempty"`
+	CreatedAt     time.Time                `json:"created_at"`
+	UpdatedAt     time.Time                `json:"updated_at"`
+	CompletedAt   *time.Time               `json:"completed_at"`
+}
+
+type ApprovalTaskResponse struct {
+	ID                   string     `json:"id"`
+	Seq                  int        `json:"seq"`
+	StepOrder            int        `json:"step_order"`
+	Name                 string     `json:"name"`
+	ApproverType         string     `json:"approver_type"`
+	ApproverPositionID   *string    `json:"approver_position_id"`
+	ApproverPositionName string     `json:"approver_position_name"`
+	ApproverID           *string    `json:"approver_id"`
+	ApproverName         string     `json:"approver_name"`
+	AssignedFromID       *string    `json:"assigned_from_id"`
+	AssignedFromName     string     `json:"assigned_from_name"`
+	Status               string     `json:"status"`
+	DueAt                *time.Time `json:"due_at"`
+	EscalatedAt          *time.Time `json:"escalated_at"`
+	Note                 string     `json:"note"`
+	DecidedBy            *string    `json:"decided_by"`
+	DecidedByName        string     `json:"decided_by_name"`
+	OnBehalfOfID         *string    `json:"on_behalf_of_id"`
+	OnBehalfOfName       string     `json:"on_behalf_of_name"`
+	DecidedAt            *time.Time `json:"decided_at"`
+}
+
+type ApprovalActionResponse struct {
+	ID             string    `json:"id"`
+	TaskID         *string   `json:"task_id"`
+	Action         string    `json:"action"`
+	ActorID        *string   `json:"actor_id"`
+	ActorName      string    `json:"actor_name"`
+	OnBehalfOfID   *string   `json:"on_behalf_of_id"`
+	OnBehalfOfName string    `json:"on_behalf_of_name"`
+	Note           string    `json:"note"`
+	CreatedAt      time.Time `json:"created_at"`
+}
@@ -0,0 +1,33 @@
+package web
+
+// The entity type has one active workflow, the steps with the same order run in parallel.
+type ApprovalWorkflowRequest struct {
+	Code        string                        `json:"code" validate:"required,max=50"`
+	Name        string                        `json:"name" validate:"required,max=255"`
+	EntityType  string                        `json:"entity_type" validate:"required,oneof=leave overtime expense_claim handover employment_contract"`
+	Description string                        `json:"description"`
+	IsActive    bool                          `json:"is_active"`
+	Steps       []ApprovalWorkflowStepRequest `json:"steps" validate:"required,min=1,max=20,dive"`
+}
+
+// The approver of the 'manager' type is the manager of the requester, the 'role' type is any employee of the position
+// and the 'user' type is the employee. The step is only required when the amount of the request is at least the
+// minimum amount, and it is escalated when it is not decided within the timeout.
+type ApprovalWorkflowStepRequest struct {
+	StepOrder          int     `json:"step_order" validate:"required,min=1"`
+	Name               string  `json:"name" validate:"required,max=255"`
+	ApproverType       string  `json:"approver_type" validate:"required,oneof=manager role user"`
+	ApproverPositionID *string `json:"approver_position_id" validate:"required_if=ApproverType role,omitempty,uuid"`
+	ApproverEmployeeID *string `json:"approver_employee_id" validate:"required_if=ApproverType user,omitempty,uuid"`
+	MinAmount          *int64  `json:"min_amount" validate:"omitempty,min=0"`
+	TimeoutHours       *int    `json:"timeout_hours" validate:"omitempty,min=1"`
+}
+
+type ApprovalWorkflowQueryFilter struct {
+	EntityType string `query:"entity_type"`
+	Search     string `query:"search"`
+
+	// Pagination is used for fetching the data by page. The default value is 1.
+	Page  string `query:"page"`
+	Limit string `query:"limit"`
+}
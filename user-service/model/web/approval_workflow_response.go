@@ -0,0 +1,28 @@
+package web
+
+import "time"
+
+type ApprovalWorkflowResponse struct {
+	ID          string                         `json:"id"`
+	Code        string                         `json:"code"`
+	Name        string                         `json:"name"`
+	EntityType  string                         `json:"entity_type"`
+	Description string                         `json:"description"`
+	IsActive    bool                           `json:"is_active"`
+	Steps       []ApprovalWorkflowStepResponse `json:"steps,omitempty"`
+	CreatedAt   time.Time                      `json:"created_at"`
+	UpdatedAt   time.Time                      `json:"updated_at"`
+}
+
+type ApprovalWorkflowStepResponse struct {
+	Seq                  int     `json:"seq"`
+	StepOrder            int     `json:"step_order"`
+	Name                 string  `json:"name"`
+	ApproverType         string  `json:"approver_type"`
+	ApproverPositionID   *string `json:"approver_position_id"`
+	ApproverPositionName string  `json:"approver_position_name"`
+	ApproverEmployeeID   *string `json:"approver_employee_id"`
+	ApproverEmployeeName string  `json:"approver_employee_name"`
+	MinAmount            *int64  `json:"min_amount"`
+	TimeoutHours         *int    `json:"timeout_hours"`
+}
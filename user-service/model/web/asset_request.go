@@ -0,0 +1,52 @@
+package web
+
+// The assigned status is set by assigning the asset, so it can't be set from the request.
+type AssetRequest struct {
+	AssetCode     string  `json:"asset_code" validate:"required,max=50"`
+	Name          string  `json:"name" validate:"required,max=255"`
+	Category      string  `json:"category" validate:"required,max=100"`
+	Brand         string  `json:"brand" validate:"max=100"`
+	SerialNumber  string  `json:"serial_number" validate:"max=100"`
+	PurchaseDate  string  `json:"purchase_date" validate:"omitempty,datetime=2006-01-02"`
+	PurchasePrice float64 `json:"purchase_price" validate:"gte=0"`
+	Condition     string  `json:"condition" validate:"required,oneof=new good fair damaged"`
+	Location      string  `json:"location" validate:"max=255"`
+	Status        string  `json:"status" validate:"omitempty,oneof=available maintenance retired"`
+	Note          string  `json:"note"`
+}
+
+// The condition of the asset is kept when the condition is not filled.
+type AssignAssetRequest struct {
+	EmployeeID string `json:"employee_id" validate:"required,uuid"`
+	AssignedAt string `json:"assigned_at" validate:"required,datetime=2006-01-02"`
+	Condition  string `json:"condition" validate:"omitempty,oneof=new good fair damaged"`
+	Note       string `json:"note" validate:"max=255"`
+}
+
+type ReturnAssetRequest struct {
+	ReturnedAt string `json:"returned_at" validate:"required,datetime=2006-01-02"`
+	Condition  string `json:"condition" validate:"required,oneof=new good fair damaged lost"`
+	Note       string `json:"note" validate:"max=255"`
+}
+
+// The handover document of an assignment ('assign') or a return ('return'). The employee is the one who hands
+// over the asset on assignment or receives it on return, it is the logged in user when it is not filled. The
+// signers are the employee who hands over and the employee who receives when they are not filled.
+type AssetHandoverRequest struct {
+	Event      string                  `json:"event" validate:"required,oneof=assign return"`
+	EmployeeID string                  `json:"employee_id" validate:"omitempty,uuid"`
+	Location   string                  `json:"location" validate:"max=255"`
+	Signers    []HandoverSignerRequest `json:"signers" validate:"omitempty,dive"`
+}
+
+type AssetQueryFilter struct {
+	Category   string `query:"category"`
+	Status     string `query:"status" validate:"omitempty,oneof=available assigned maintenance retired"`
+	Condition  string `query:"condition" validate:"omitempty,oneof=new good fair damaged lost"`
+	EmployeeID string `query:"employee_id" validate:"omitempty,uuid"`
+	Search     string `query:"search"`
+
+	// Pagination is used for fetching the data by page. The default value is 1.
+	Page  string `query:"page"`
+	Limit string `query:"limit"`
+}
@@ -0,0 +1,48 @@
+package web
+
+import "time"
+
+type AssetResponse struct {
+	ID            string    `json:"id"`
+	AssetCode     string    `json:"asset_code"`
+	Name          string    `json:"name"`
+	Category      string    `json:"category"`
+	Brand         string    `json:"brand"`
+	SerialNumber  string    `json:"serial_number"`
+	PurchaseDate  *string   `json:"purchase_date"`
+	PurchasePrice float64   `json:"purchase_price"`
+	Condition     string    `json:"condition"`
+	Location      string    `json:"location"`
+	Status        string    `json:"status"`
+	Note          string    `json:"note"`
+	CreatedAt     time.Time `json:"created_at"`
+	UpdatedAt     time.Time `json:"updated_at"`
+	// the employee who holds the asset and the open assignment, they are only filled when the asset is assigned
+	EmployeeID     *string `json:"employee_id"`
+	EmployeeNumber string  `json:"employee_number"`
+	EmployeeName   string  `json:"employee_name"`
+	AssignmentID   *string `json:"assignment_id"`
+	AssignedAt     *string `json:"assigned_at"`
+}
+
+type AssetAssignmentResponse struct {
+	ID               string    `json:"id"`
+	AssetID          string    `json:"asset_id"`
+	AssetCode        string    `json:"asset_code"`
+	AssetName        string    `json:"asset_name"`
+	EmployeeID       string    `json:"employee_id"`
+	EmployeeNumber   string    `json:"employee_number"`
+	EmployeeName     string    `json:"employee_name"`
+	AssignedAt       string    `json:"assigned_at"`
+	AssignedBy       *string   `json:"assigned_by"`
+	AssignCondition  string    `json:"assign_condition"`
+	AssignNote       string    `json:"assign_note"`
+	AssignHandoverID *string   `json:"assign_handover_id"`
+	ReturnedAt       *string   `json:"returned_at"`
+	ReturnedBy       *string   `json:"returned_by"`
+	ReturnCondition  *string   `json:"return_condition"`
+	ReturnNote       string    `json:"return_note"`
+	ReturnHandoverID *string   `json:"return_handover_id"`
+	CreatedAt        time.Time `json:"created_at"`
+	UpdatedAt        time.Time `json:"updated_at"`
+}
@@ -0,0 +1,29 @@
+package web
+
+// The request body of the check-in and check-out, it can be sent as JSON or multipart form.
+// The selfie is uploaded as the 'selfie' file of the multipart form.
+type CheckAttendanceRequest struct {
+	Latitude  *float64 `json:"latitude" form:"latitude" validate:"omitempty,min=-90,max=90"`
+	Longitude *float64 `json:"longitude" form:"longitude" validate:"omitempty,min=-180,max=180"`
+}
+
+type AttendanceQueryFilter struct {
+	EmployeeID   string `query:"employee_id"`
+	DepartmentID string `query:"department_id"`
+	From         string `query:"from" validate:"omitempty,datetime=2006-01-02"`
+	To           string `query:"to" validate:"omitempty,datetime=2006-01-02"`
+
+	// Pagination is used for fetching the data by page. The default value is 1.
+	Page  string `query:"page"`
+	Limit string `query:"limit"`
+}
+
+// The daily summary uses the 'date' and the monthly summary uses the 'month', the current date or month is used when it's empty.
+type AttendanceSummaryQueryFilter struct {
+	Date         string `query:"date" validate:"omitempty,datetime=2006-01-02"`
+	Month        string `query:"month" validate:"omitempty,datetime=2006-01"`
+	EmployeeID   string `query:"employee_id"`
+	DepartmentID string `query:"department_id"`
+	// GroupBy is used for summarizing the attendances per 'employee' (default) or per 'department'.
+	GroupBy string `query:"group_by" validate:"omitempty,oneof=employee department"`
+}
@@ -0,0 +1,60 @@
+package web
+
+import "time"
+
+type AttendanceResponse struct {
+	ID                 string     `json:"id"`
+	EmployeeID         string     `json:"employee_id"`
+	EmployeeNumber     string     `json:"employee_number"`
+	EmployeeName       string     `json:"employee_name"`
+	DepartmentName     string     `json:"department_name"`
+	Date               string     `json:"date"`
+	ShiftID            *string    `json:"shift_id"`
+	ShiftName          string     `json:"shift_name"`
+	ScheduledStart     *time.Time `json:"scheduled_start"`
+	ScheduledEnd       *time.Time `json:"scheduled_end"`
+	CheckInAt          time.Time  `json:"check_in_at"`
+	CheckInLatitude    *float64   `json:"check_in_latitude"`
+	CheckInLongitude   *float64   `json:"check_in_longitude"`
+	CheckInLocationID  *string    `json:"check_in_location_id"`
+	CheckInPhoto       string     `json:"check_in_photo"`
+	CheckOutAt         *time.Time `json:"check_out_at"`
+	CheckOutLatitude   *float64   `json:"check_out_latitude"`
+	CheckOutLongitude  *float64   `json:"check_out_longitude"`
+	CheckOutLocationID *string    `json:"check_out_location_id"`
+	CheckOutPhoto      string     `json:"check_out_photo"`
+	IsLate             bool       `json:"is_late"`
+	LateMinutes        int        `json:"late_minutes"`
+	IsEarlyLeave       bool       `json:"is_early_leave"`
+	EarlyLeaveMinutes  int        `json:"early_leave_minutes"`
+	OvertimeMinutes    int        `json:"overtime_minutes"`
+	WorkMinutes        int        `json:"work_minutes"`
+	CreatedAt          time.Time  `json:"created_at"`
+	UpdatedAt          time.Time  `json:"updated_at"`
+}
+
+// The summary of the attendances in the period, grouped per employee or per department.
+type AttendanceSummaryResponse struct {
+	From  string                  `json:"from"`
+	To    string                  `json:"to"`
+	Items []AttendanceSummaryItem `json:"items"`
+}
+
+type AttendanceSummaryItem struct {
+	// filled when the summary is grouped per employee
+	EmployeeID     string `json:"employee_id,omitempty"`
+	EmployeeNumber string `json:"employee_number,omitempty"`
+	EmployeeName   string `json:"employee_name,omitempty"`
+
+	DepartmentID   string `json:"department_id"`
+	DepartmentName string `json:"department_name"`
+
+	PresentDays       int `json:"present_days"`
+	LateDays          int `json:"late_days"`
+	EarlyLeaveDays    int `json:"early_leave_days"`
+	MissingCheckOut   int `json:"missing_check_out"`
+	LateMinutes       int `json:"late_minutes"`
+	EarlyLeaveMinutes int `json:"early_leave_minutes"`
+	OvertimeMinutes   int `json:"overtime_minutes"`
+	WorkMinutes       int `json:"work_minutes"`
+}
@@ -0,0 +1,31 @@
+package web
+
+type CreateDepartmentRequest struct {
+	Code     string  `json:"code" validate:"required,max=32"`
+	Name     string  `json:"name" validate:"required"`
+	ParentID *string `json:"parent_id" validate:"omitnil,uuid|len=0"`
+}
+
+// All fields are optional, only the filled fields will be updated.
+type UpdateDepartmentRequest struct {
+	Code     string  `json:"code" validate:"omitempty,max=32"`
+	Name     string  `json:"name"`
+	ParentID *string `json:"parent_id" validate:"omitnil,uuid|len=0"`
+}
+
+type DepartmentQueryFilter struct {
+	Name     string `query:"name"`
+	ParentID string `query:"parent_id"`
+
+	// ShowDeleted is used for showing the soft-deleted department or not.
+	ShowDeleted bool `query:"show_deleted"`
+
+	// Pagination is used for fetching the data by page. The default value is 1.
+	Page  string `query:"page"`
+	Limit string `query:"limit"`
+}
+
+type DepartmentMemberQueryFilter struct {
+	// Recursive is used for including the members of the sub-departments.
+	Recursive bool `query:"recursive"`
+}
@@ -0,0 +1,35 @@
+package web
+
+import "time"
+
+type DepartmentResponse struct {
+	ID        string    `json:"id"`
+	Code      string    `json:"code"`
+	Name      string    `json:"name"`
+	ParentID  *string   `json:"parent_id"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+type DepartmentTreeResponse struct {
+	DepartmentResponse
+	Children []DepartmentTreeResponse `json:"children"`
+}
+
+type OrgChartMember struct {
+	ID             string  `json:"id"`
+	EmployeeNumber string  `json:"employee_number"`
+	Name           string  `json:"name"`
+	JobTitle       string  `json:"job_title"`
+	Position       string  `json:"position"`
+	ManagerID      *string `json:"manager_id"`
+}
+
+type OrgChartDepartment struct {
+	ID        string               `json:"id"`
+	Code      string               `json:"code"`
+	Name      string               `json:"name"`
+	HeadCount int                  `json:"head_count"`
+	Members   []OrgChartMember     `json:"members"`
+	Children  []OrgChartDepartment `json:"children"`
+}
@@ -0,0 +1,52 @@
+package web
+
+// The document is uploaded as a multipart form with the file in the 'file' field. The access decides who can view
+// the document besides HR, it is 'employee' when it is not filled.
+type EmployeeDocumentRequest struct {
+	EmployeeID string `form:"employee_id" validate:"required,uuid"`
+	Type       string `form:"type" validate:"required,oneof=ktp npwp contract certificate other"`
+	Title      string `form:"title" validate:"required,max=255"`
+	Number     string `form:"number" validate:"max=100"`
+	ExpiryDate string `form:"expiry_date" validate:"omitempty,datetime=2006-01-02"`
+	Access     string `form:"access" validate:"omitempty,oneof=employee manager hr"`
+	Note       string `form:"note" validate:"max=255"`
+}
+
+// The document uploaded by the employee, the access is always 'employee'.
+type MyEmployeeDocumentRequest struct {
+	Type       string `form:"type" validate:"required,oneof=ktp npwp contract certificate other"`
+	Title      string `form:"title" validate:"required,max=255"`
+	Number     string `form:"number" validate:"max=100"`
+	ExpiryDate string `form:"expiry_date" validate:"omitempty,datetime=2006-01-02"`
+	Note       string `form:"note" validate:"max=255"`
+}
+
+type UpdateEmployeeDocumentRequest struct {
+	Title      string `json:"title" validate:"required,max=255"`
+	Number     string `json:"number" validate:"max=100"`
+	ExpiryDate string `json:"expiry_date" validate:"omitempty,datetime=2006-01-02"`
+	Access     string `json:"access" validate:"required,oneof=employee manager hr"`
+}
+
+// A new version of the document, e.g. the renewed contract. The expiry date is kept when it is not filled.
+type EmployeeDocumentVersionRequest struct {
+	ExpiryDate string `form:"expiry_date" validate:"omitempty,datetime=2006-01-02"`
+	Note       string `form:"note" validate:"max=255"`
+}
+
+// The uploaded file of the document, it is read from the multipart form by the controller.
+type DocumentFile struct {
+	Name string
+	Data []byte
+}
+
+type EmployeeDocumentQueryFilter struct {
+	EmployeeID string `query:"employee_id" validate:"omitempty,uuid"`
+	Type       string `query:"type" validate:"omitempty,oneof=ktp npwp contract certificate other"`
+	// the documents which expire within the days, including the expired documents
+	ExpiringWithin string `query:"expiring_within" validate:"omitempty,number"`
+
+	// Pagination is used for fetching the data by page. The default value is 1.
+	Page  string `query:"page"`
+	Limit string `query:"limit"`
+}
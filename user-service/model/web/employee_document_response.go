@@ -0,0 +1,37 @@
+package web
+
+import "time"
+
+type EmployeeDocumentResponse struct {
+	ID             string    `json:"id"`
+	EmployeeID     string    `json:"employee_id"`
+	EmployeeNumber string    `json:"employee_number"`
+	EmployeeName   string    `json:"employee_name"`
+	Type           string    `json:"type"`
+	Title          string    `json:"title"`
+	Number         string    `json:"number"`
+	ExpiryDate     *string   `json:"expiry_date"`
+	Expired        bool      `json:"expired"`
+	Access         string    `json:"access"`
+	LatestVersion  int       `json:"latest_version"`
+	FileName       string    `json:"file_name"`
+	MimeType       string    `json:"mime_type"`
+	Size           int64     `json:"size"`
+	Checksum       string    `json:"checksum"`
+	CreatedBy      *string   `json:"created_by"`
+	CreatedAt      time.Time `json:"created_at"`
+	UpdatedAt      time.Time `json:"updated_at"`
+	// the versions are only filled when a single document is fetched
+	Versions []EmployeeDocumentVersionResponse `json:"versions,omitempty"`
+}
+
+type EmployeeDocumentVersionResponse struct {
+	Version    int       `json:"version"`
+	FileName   string    `json:"file_name"`
+	MimeType   string    `json:"mime_type"`
+	Size       int64     `json:"size"`
+	Checksum   string    `json:"checksum"`
+	Note       string    `json:"note"`
+	UploadedBy *string   `json:"uploaded_by"`
+	UploadedAt time.Time `json:"uploaded_at"`
+}
@@ -0,0 +1,65 @@
+package web
+
+type EmergencyContactRequest struct {
+	Name         string `json:"name" validate:"required"`
+	Relationship string `json:"relationship" validate:"required"`
+	Phone        string `json:"phone" validate:"required,numeric,min=8,max=15"`
+	Address      string `json:"address"`
+}
+
+type CreateEmployeeRequest struct {
+	UserID            string                    `json:"user_id" validate:"required,uuid"`
+	EmployeeNumber    string                    `json:"employee_number" validate:"required,max=32"`
+	DateOfBirth       string                    `json:"date_of_birth" validate:"required,datetime=2006-01-02"`
+	Gender            string                    `json:"gender" validate:"required,oneof=male female"`
+	NIK               string                    `json:"nik" validate:"required,numeric,len=16"`
+	Address           string                    `json:"address" validate:"required"`
+	HireDate          string                    `json:"hire_date" validate:"required,datetime=2006-01-02"`
+	EmploymentType    string                    `json:"employment_type" validate:"required,oneof=permanent contract probation internship outsource"`
+	Status            string                    `json:"status" validate:"omitempty,oneof=active suspended resigned terminated"`
+	JobTitle          string                    `json:"job_title" validate:"required_without=PositionID"`
+	SalaryGrade       string                    `json:"salary_grade" validate:"max=32"`
+	DepartmentID      string                    `json:"department_id" validate:"required,uuid"`
+	PositionID        *string                   `json:"position_id" validate:"omitempty,uuid"`
+	ManagerID         *string                   `json:"manager_id" validate:"omitempty,uuid"`
+	EmergencyContacts []EmergencyContactRequest `json:"emergency_contacts" validate:"dive"`
+}
+
+// All fields are optional, only the filled fields will be updated.
+// The department, position, job title, salary grade and manager can only be changed through
+// the effective-dated employment change, so the history of the employment is kept.
+type UpdateEmployeeRequest struct {
+	EmployeeNumber    string                    `json:"employee_number" validate:"omitempty,max=32"`
+	DateOfBirth       string                    `json:"date_of_birth" validate:"omitempty,datetime=2006-01-02"`
+	Gender            string                    `json:"gender" validate:"omitempty,oneof=male female"`
+	NIK               string                    `json:"nik" validate:"omitempty,numeric,len=16"`
+	Address           string                    `json:"address"`
+	HireDate          string                    `json:"hire_date" validate:"omitempty,datetime=2006-01-02"`
+	EmploymentType    string                    `json:"employment_type" validate:"omitempty,oneof=permanent contract probation internship outsource"`
+	Status            string                    `json:"status" validate:"omitempty,oneof=active suspended resigned terminated"`
+	EmergencyContacts []EmergencyContactRequest `json:"emergency_contacts" validate:"omitempty,dive"`
+}
+
+// The subset of the employee data that can be edited by the employee itself through the profile-service.
+type UpdateEmployeePersonalRequest struct {
+	Address           string                    `json:"address"`
+	EmergencyContacts []EmergencyContactRequest `json:"emergency_contacts" validate:"omitempty,dive"`
+}
+
+type EmployeeQueryFilter struct {
+	Name           string `query:"name"`
+	EmployeeNumber string `query:"employee_number"`
+	DepartmentID   string `query:"department_id"`
+	PositionID     string `query:"position_id"`
+	JobTitle       string `query:"job_title"`
+	EmploymentType string `query:"employment_type"`
+	Status         string `query:"status"`
+	ManagerID      string `query:"manager_id"`
+
+	// ShowDeleted is used for showing the soft-deleted employee or not.
+	ShowDeleted bool `query:"show_deleted"`
+
+	// Pagination is used for fetching the data by page. The default value is 1.
+	Page  string `query:"page"`
+	Limit string `query:"limit"`
+}
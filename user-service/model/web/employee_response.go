@@ -0,0 +1,41 @@
+package web
+
+import "time"
+
+type EmergencyContactResponse struct {
+	Name         string `json:"name"`
+	Relationship string `json:"relationship"`
+	Phone        string `json:"phone"`
+	Address      string `json:"address"`
+}
+
+type EmployeeResponse struct {
+	ID                string                     `json:"id"`
+	UserID            string                     `json:"user_id"`
+	Name              string                     `json:"name"`
+	Email             string                     `json:"email"`
+	Phone             string                     `json:"phone"`
+	EmployeeNumber    string                     `json:"employee_number"`
+	DateOfBirth       string                     `json:"date_of_birth"`
+	Gender            string                     `json:"gender"`
+	NIK               string                     `json:"nik"`
+	Address           string                     `json:"address"`
+	HireDate          string                     `json:"hire_date"`
+	EmploymentType    string                     `json:"employment_type"`
+	Status            string                     `json:"status"`
+	JobTitle          string                     `json:"job_title"`
+	SalaryGrade       string                     `json:"salary_grade"`
+	DepartmentID      string                     `json:"department_id"`
+	Department        string                     `json:"department"`
+	PositionID        *string                    `json:"position_id"`
+	Position          string                     `json:"position"`
+	ManagerID         *string                    `json:"manager_id"`
+	ShiftID           *string                    `json:"shift_id"`
+	OfficeLocationID  *string                    `json:"office_location_id"`
+	WorkCalendarID    *string                    `json:"work_calendar_id"`
+	EmergencyContacts []EmergencyContactResponse `json:"emergency_contacts"`
+	CreatedAt         time.Time                  `json:"created_at"`
+	UpdatedAt         time.Time                  `json:"updated_at"`
+	// the assets held by the employee, they are only filled when a single employee is fetched
+	Assets []AssetResponse `json:"assets,omitempty"`
+}
@@ -0,0 +1,23 @@
+package web
+
+// The salary is effective from its date until the next salary of the employee. The fixed allowances are paid
+// regardless of the attendance and are part of the wage the BPJS contributions and the overtime are based on.
+type CreateEmployeeSalaryRequest struct {
+	EmployeeID    string                   `json:"employee_id" validate:"required,uuid"`
+	BaseSalary    int64                    `json:"base_salary" validate:"required,min=1"`
+	Allowances    []SalaryAllowanceRequest `json:"allowances" validate:"dive"`
+	TaxStatus     string                   `json:"tax_status" validate:"omitempty,oneof=TK/0 TK/1 TK/2 TK/3 K/0 K/1 K/2 K/3"`
+	EffectiveFrom string                   `json:"effective_from" validate:"required,datetime=2006-01-02"`
+	Note          string                   `json:"note" validate:"max=255"`
+}
+
+type SalaryAllowanceRequest struct {
+	Code   string `json:"code" validate:"required,max=32"`
+	Name   string `json:"name" validate:"required"`
+	Amount int64  `json:"amount" validate:"required,min=1"`
+	Fixed  bool   `json:"fixed"`
+}
+
+type EmployeeSalaryQueryFilter struct {
+	EmployeeID string `query:"employee_id" validate:"required,uuid"`
+}
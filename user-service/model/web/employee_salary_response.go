@@ -0,0 +1,24 @@
+package web
+
+import "time"
+
+type EmployeeSalaryResponse struct {
+	ID             string                    `json:"id"`
+	EmployeeID     string                    `json:"employee_id"`
+	EmployeeNumber string                    `json:"employee_number"`
+	EmployeeName   string                    `json:"employee_name"`
+	BaseSalary     int64                     `json:"base_salary"`
+	Allowances     []SalaryAllowanceResponse `json:"allowances"`
+	TaxStatus      string                    `json:"tax_status"`
+	EffectiveFrom  string                    `json:"effective_from"`
+	Note           string                    `json:"note"`
+	CreatedAt      time.Time                 `json:"created_at"`
+	UpdatedAt      time.Time                 `json:"updated_at"`
+}
+
+type SalaryAllowanceResponse struct {
+	Code   string `json:"code"`
+	Name   string `json:"name"`
+	Amount int64  `json:"amount"`
+	Fixed  bool   `json:"fixed"`
+}
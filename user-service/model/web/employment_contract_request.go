@@ -0,0 +1,59 @@
+package web
+
+// The contract is created as a draft and is active once it is signed. The PKWT must have an end date and can't have
+// a probation, the PKWTT has no end date. The job title is the job title of the employee when it is not filled.
+type EmploymentContractRequest struct {
+	EmployeeID       string  `json:"employee_id" validate:"required,uuid"`
+	Type             string  `json:"type" validate:"required,oneof=pkwt pkwtt"`
+	JobTitle         string  `json:"job_title" validate:"max=255"`
+	StartDate        string  `json:"start_date" validate:"required,datetime=2006-01-02"`
+	EndDate          string  `json:"end_date" validate:"omitempty,datetime=2006-01-02"`
+	ProbationEndDate string  `json:"probation_end_date" validate:"omitempty,datetime=2006-01-02"`
+	SalaryID         *string `json:"salary_id" validate:"omitempty,uuid"`
+	Note             string  `json:"note" validate:"max=1000"`
+}
+
+// Only the draft contract can be changed.
+type UpdateEmploymentContractRequest struct {
+	JobTitle         string  `json:"job_title" validate:"required,max=255"`
+	StartDate        string  `json:"start_date" validate:"required,datetime=2006-01-02"`
+	EndDate          string  `json:"end_date" validate:"omitempty,datetime=2006-01-02"`
+	ProbationEndDate string  `json:"probation_end_date" validate:"omitempty,datetime=2006-01-02"`
+	SalaryID         *string `json:"salary_id" validate:"omitempty,uuid"`
+	Note             string  `json:"note" validate:"max=1000"`
+}
+
+// The renewal starts the day after the end date of the renewed PKWT. The job title and the salary of the renewed
+// contract are kept when they are not filled.
+type RenewEmploymentContractRequest struct {
+	EndDate  string  `json:"end_date" validate:"required,datetime=2006-01-02"`
+	JobTitle string  `json:"job_title" validate:"max=255"`
+	SalaryID *string `json:"salary_id" validate:"omitempty,uuid"`
+	Note     string  `json:"note" validate:"max=1000"`
+}
+
+// The PKWTT starts the day after the end date of the converted PKWT when the start date is not filled. The job
+// title and the salary of the converted contract are kept when they are not filled.
+type ConvertEmploymentContractRequest struct {
+	StartDate string  `json:"start_date" validate:"omitempty,datetime=2006-01-02"`
+	JobTitle  string  `json:"job_title" validate:"max=255"`
+	SalaryID  *string `json:"salary_id" validate:"omitempty,uuid"`
+	Note      string  `json:"note" validate:"max=1000"`
+}
+
+// The signed contract is uploaded to the employee documents with the 'contract' type first.
+type SignEmploymentContractRequest struct {
+	DocumentID string `json:"document_id" validate:"required,uuid"`
+}
+
+type EmploymentContractQueryFilter struct {
+	Status     string `query:"status" validate:"omitempty,oneof=draft active renewed converted ended cancelled"`
+	Type       string `query:"type" validate:"omitempty,oneof=pkwt pkwtt"`
+	EmployeeID string `query:"employee_id" validate:"omitempty,uuid"`
+	// the active contracts which end within the days
+	ExpiringWithin string `query:"expiring_within" validate:"omitempty,number"`
+
+	// Pagination is used for fetching the data by page. The default value is 1.
+	Page  string `query:"page"`
+	Limit string `query:"limit"`
+}
@@ -0,0 +1,28 @@
+package web
+
+import "time"
+
+type EmploymentContractResponse struct {
+	ID                 string     `json:"id"`
+	ContractNumber     string     `json:"contract_number"`
+	EmployeeID         string     `json:"employee_id"`
+	EmployeeNumber     string     `json:"employee_number"`
+	EmployeeName       string     `json:"employee_name"`
+	Type               string     `json:"type"`
+	JobTitle           string     `json:"job_title"`
+	StartDate          string     `json:"start_date"`
+	EndDate            *string    `json:"end_date"`
+	ProbationEndDate   *string    `json:"probation_end_date"`
+	SalaryID           *string    `json:"salary_id"`
+	BaseSalary         *int64     `json:"base_salary"`
+	PreviousContractID *string    `json:"previous_contract_id"`
+	Status             string     `json:"status"`
+	SignedDocumentID   *string    `json:"signed_document_id"`
+	SignedAt           *time.Time `json:"signed_at"`
+	Note               string     `json:"note"`
+	CreatedBy          *string    `json:"created_by"`
+	CreatedAt          time.Time  `json:"created_at"`
+	UpdatedAt          time.Time  `json:"updated_at"`
+	// the days until the end date of the active PKWT
+	DaysLeft *int `json:"days_left"`
+}
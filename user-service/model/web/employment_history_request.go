@@ -0,0 +1,21 @@
+package web
+
+// A change of the employment (transfer, promotion, job title, salary grade or manager) is effective-dated.
+// Only the filled fields are changed, the other fields are carried over from the current employment.
+// A change with a future effective date is applied by the scheduler on that date.
+type CreateEmploymentChangeRequest struct {
+	ChangeType   string  `json:"change_type" validate:"required,oneof=transfer promotion demotion job_change salary_change manager_change"`
+	DepartmentID string  `json:"department_id" validate:"omitempty,uuid"`
+	PositionID   *string `json:"position_id" validate:"omitnil,uuid|len=0"`
+	JobTitle     string  `json:"job_title"`
+	SalaryGrade  string  `json:"salary_grade" validate:"max=32"`
+	// an empty manager id is used to remove the manager of the employee
+	ManagerID     *string `json:"manager_id" validate:"omitnil,uuid|len=0"`
+	EffectiveDate string  `json:"effective_date" validate:"required,datetime=2006-01-02"`
+	Reason        string  `json:"reason" validate:"max=255"`
+}
+
+type EmployeeAsOfQueryFilter struct {
+	// Date is the date of the employment, the default value is today.
+	Date string `query:"date" validate:"omitempty,datetime=2006-01-02"`
+}
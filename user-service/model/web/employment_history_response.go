@@ -0,0 +1,31 @@
+package web
+
+import "time"
+
+type EmploymentHistoryResponse struct {
+	ID             string    `json:"id"`
+	EmployeeID     string    `json:"employee_id"`
+	ChangeType     string    `json:"change_type"`
+	DepartmentID   string    `json:"department_id"`
+	DepartmentName string    `json:"department_name"`
+	PositionID     *string   `json:"position_id"`
+	PositionTitle  string    `json:"position_title"`
+	JobTitle       string    `json:"job_title"`
+	SalaryGrade    string    `json:"salary_grade"`
+	ManagerID      *string   `json:"manager_id"`
+	ManagerName    string    `json:"manager_name"`
+	EffectiveFrom  string    `json:"effective_from"`
+	EffectiveTo    *string   `json:"effective_to"`
+	Reason         string    `json:"reason"`
+	Pending        bool      `json:"pending"`
+	CreatedAt      time.Time `json:"created_at"`
+}
+
+// The employment of the employee on a specific date.
+type EmployeeAsOfResponse struct {
+	EmployeeID     string                    `json:"employee_id"`
+	EmployeeNumber string                    `json:"employee_number"`
+	Name           string                    `json:"name"`
+	AsOf           string                    `json:"as_of"`
+	Employment     EmploymentHistoryResponse `json:"employment"`
+}
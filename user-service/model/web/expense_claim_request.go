@@ -0,0 +1,66 @@
+package web
+
+// The limits replace the maximum amounts of the category in a claim by the salary grade, the category has no limit
+// for the grade which is not listed.
+type ExpenseCategoryRequest struct {
+	Code            string                        `json:"code" validate:"required,max=50"`
+	Name            string                        `json:"name" validate:"required,max=255"`
+	Description     string                        `json:"description"`
+	ReceiptRequired bool                          `json:"receipt_required"`
+	Limits          []ExpenseCategoryLimitRequest `json:"limits" validate:"dive"`
+}
+
+type ExpenseCategoryLimitRequest struct {
+	SalaryGrade string `json:"salary_grade" validate:"required,max=50"`
+	MaxAmount   int64  `json:"max_amount" validate:"required,gt=0"`
+}
+
+type ExpenseCategoryQueryFilter struct {
+	Search string `query:"search"`
+
+	// Pagination is used for fetching the data by page. The default value is 1.
+	Page  string `query:"page"`
+	Limit string `query:"limit"`
+}
+
+// The claim is created as a draft of the logged in user, the receipts of the items are uploaded afterwards.
+type ExpenseClaimRequest struct {
+	Title       string                    `json:"title" validate:"required,max=255"`
+	Description string                    `json:"description"`
+	Items       []ExpenseClaimItemRequest `json:"items" validate:"required,min=1,max=100,dive"`
+}
+
+type UpdateExpenseClaimRequest struct {
+	Title       string `json:"title" validate:"required,max=255"`
+	Description string `json:"description"`
+}
+
+// The exchange rate is the rupiah of a unit of the currency on the expense date, it is required when the currency
+// is not 'IDR'.
+type ExpenseClaimItemRequest struct {
+	CategoryID   string  `json:"category_id" validate:"required,uuid"`
+	ExpenseDate  string  `json:"expense_date" validate:"required,datetime=2006-01-02"`
+	Description  string  `json:"description" validate:"max=255"`
+	Currency     string  `json:"currency" validate:"required,iso4217"`
+	Amount       float64 `json:"amount" validate:"required,gt=0"`
+	ExchangeRate float64 `json:"exchange_rate" validate:"required_unless=Currency IDR,gte=0"`
+}
+
+// The note is required when the claim is rejected.
+type DecideExpenseClaimRequest struct {
+	Note string `json:"note" validate:"max=255"`
+}
+
+type ExpenseClaimQueryFilter struct {
+	EmployeeID   string `query:"employee_id" validate:"omitempty,uuid"`
+	DepartmentID string `query:"department_id" validate:"omitempty,uuid"`
+	PayrollRunID string `query:"payroll_run_id" validate:"omitempty,uuid"`
+	Status       string `query:"status" validate:"omitempty,oneof=draft submitted manager_approved approved rejected cancelled paid"`
+	From         string `query:"from" validate:"omitempty,datetime=2006-01-02"`
+	To           string `query:"to" validate:"omitempty,datetime=2006-01-02"`
+	Search       string `query:"search"`
+
+	// Pagination is used for fetching the data by page. The default value is 1.
+	Page  string `query:"page"`
+	Limit string `query:"limit"`
+}
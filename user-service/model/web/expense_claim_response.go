@@ -0,0 +1,67 @@
+package web
+
+import "time"
+
+type ExpenseCategoryResponse struct {
+	ID              string    `json:"id"`
+	Code            string    `json:"code"`
+	Name            string    `json:"name"`
+	Description     string    `json:"description"`
+	ReceiptRequired bool      `json:"receipt_required"`
+	CreatedAt       time.Time `json:"created_at"`
+	UpdatedAt       time.Time `json:"updated_at"`
+	// the limits are only filled when a single category is fetched
+	Limits []ExpenseCategoryLimitResponse `json:"limits,omitempty"`
+}
+
+type ExpenseCategoryLimitResponse struct {
+	SalaryGrade string `json:"salary_grade"`
+	MaxAmount   int64  `json:"max_amount"`
+}
+
+type ExpenseClaimResponse struct {
+	ID                string     `json:"id"`
+	EmployeeID        string     `json:"employee_id"`
+	EmployeeNumber    string     `json:"employee_number"`
+	EmployeeName      string     `json:"employee_name"`
+	Department        string     `json:"department"`
+	Title             string     `json:"title"`
+	Description       string     `json:"description"`
+	Status            string     `json:"status"`
+	TotalAmount       int64      `json:"total_amount"`
+	ManagerID         *string    `json:"manager_id"`
+	SubmittedAt       *time.Time `json:"submitted_at"`
+	ManagerApprovedAt *time.Time `json:"manager_approved_at"`
+	FinanceApprovedBy *string    `json:"finance_approved_by"`
+	FinanceApprovedAt *time.Time `json:"finance_approved_at"`
+	PayrollRunID      *string    `json:"payroll_run_id"`
+	PaidAt            *time.Time `json:"paid_at"`
+	CreatedAt         time.Time  `json:"created_at"`
+	UpdatedAt         time.Time  `json:"updated_at"`
+	// the items and the histories are only filled when a single claim is fetched
+	Items     []ExpenseClaimItemResponse    `json:"items,omitempty"`
+	Histories []ExpenseClaimHistoryResponse `json:"histories,omitempty"`
+}
+
+type ExpenseClaimItemResponse struct {
+	Seq          int     `json:"seq"`
+	CategoryID   string  `json:"category_id"`
+	CategoryCode string  `json:"category_code"`
+	CategoryName string  `json:"category_name"`
+	ExpenseDate  string  `json:"expense_date"`
+	Description  string  `json:"description"`
+	Currency     string  `json:"currency"`
+	Amount       float64 `json:"amount"`
+	ExchangeRate float64 `json:"exchange_rate"`
+	AmountIDR    int64   `json:"amount_idr"`
+	HasReceipt   bool    `json:"has_receipt"`
+	ReceiptName  *string `json:"receipt_name"`
+}
+
+type ExpenseClaimHistoryResponse struct {
+	FromStatus *string   `json:"from_status"`
+	ToStatus   string    `json:"to_status"`
+	Note       string    `json:"note"`
+	ChangedBy  *string   `json:"changed_by"`
+	ChangedAt  time.Time `json:"changed_at"`
+}
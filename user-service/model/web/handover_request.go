@@ -0,0 +1,48 @@
+package web
+
+// The handover is created as a draft and submitted to the signers, the signers sign in the order of the request.
+type HandoverRequest struct {
+	Type           string                  `json:"type" validate:"required,oneof=employee asset"`
+	Title          string                  `json:"title" validate:"required,max=255"`
+	Description    string                  `json:"description"`
+	Location       string                  `json:"location" validate:"max=255"`
+	HandoverDate   string                  `json:"handover_date" validate:"required,datetime=2006-01-02"`
+	FromEmployeeID string                  `json:"from_employee_id" validate:"required,uuid"`
+	ToEmployeeID   string                  `json:"to_employee_id" validate:"required,uuid"`
+	Items          []HandoverItemRequest   `json:"items" validate:"required,min=1,dive"`
+	Signers        []HandoverSignerRequest `json:"signers" validate:"required,min=1,dive"`
+}
+
+type HandoverItemRequest struct {
+	Name         string  `json:"name" validate:"required,max=255"`
+	Description  string  `json:"description" validate:"max=255"`
+	Quantity     float64 `json:"quantity" validate:"gt=0"`
+	Unit         string  `json:"unit" validate:"max=50"`
+	SerialNumber string  `json:"serial_number" validate:"max=100"`
+	Condition    string  `json:"condition" validate:"max=100"`
+	Note         string  `json:"note" validate:"max=255"`
+}
+
+// The role is printed above the signature, e.g. 'Dibuat oleh', 'Diperiksa oleh' or 'Diterima oleh'.
+type HandoverSignerRequest struct {
+	EmployeeID string `json:"employee_id" validate:"required,uuid"`
+	Role       string `json:"role" validate:"required,max=100"`
+}
+
+// The request body of signing or rejecting a handover.
+type SignHandoverRequest struct {
+	Note string `json:"note" validate:"max=255"`
+}
+
+type HandoverQueryFilter struct {
+	Type       string `query:"type" validate:"omitempty,oneof=employee asset"`
+	Status     string `query:"status" validate:"omitempty,oneof=draft pending approved rejected cancelled"`
+	EmployeeID string `query:"employee_id" validate:"omitempty,uuid"`
+	From       string `query:"from" validate:"omitempty,datetime=2006-01-02"`
+	To         string `query:"to" validate:"omitempty,datetime=2006-01-02"`
+	Search     string `query:"search"`
+
+	// Pagination is used for fetching the data by page. The default value is 1.
+	Page  string `query:"page"`
+	Limit string `query:"limit"`
+}
@@ -0,0 +1,54 @@
+package web
+
+import "time"
+
+type HandoverResponse struct {
+	ID                 string     `json:"id"`
+	DocumentNumber     string     `json:"document_number"`
+	Type               string     `json:"type"`
+	Title              string     `json:"title"`
+	Description        string     `json:"description"`
+	Location           string     `json:"location"`
+	HandoverDate       string     `json:"handover_date"`
+	FromEmployeeID     string     `json:"from_employee_id"`
+	FromEmployeeNumber string     `json:"from_employee_number"`
+	FromEmployeeName   string     `json:"from_employee_name"`
+	ToEmployeeID       string     `json:"to_employee_id"`
+	ToEmployeeNumber   string     `json:"to_employee_number"`
+	ToEmployeeName     string     `json:"to_employee_name"`
+	Status             string     `json:"status"`
+	DocumentGenerated  bool       `json:"document_generated"`
+	EmailedAt          *time.Time `json:"emailed_at"`
+	CreatedBy          *string    `json:"created_by"`
+	SubmittedAt        *time.Time `json:"submitted_at"`
+	CompletedAt        *time.Time `json:"completed_at"`
+	CancelledAt        *time.Time `json:"cancelled_at"`
+	CreatedAt          time.Time  `json:"created_at"`
+	UpdatedAt          time.Time  `json:"updated_at"`
+	// the items and the signers are only filled when a single handover is fetched
+	Items   []HandoverItemResponse   `json:"items"`
+	Signers []HandoverSignerResponse `json:"signers"`
+}
+
+type HandoverItemResponse struct {
+	Seq          int     `json:"seq"`
+	Name         string  `json:"name"`
+	Description  string  `json:"description"`
+	Quantity     float64 `json:"quantity"`
+	Unit         string  `json:"unit"`
+	SerialNumber string  `json:"serial_number"`
+	Condition    string  `json:"condition"`
+	Note         string  `json:"note"`
+}
+
+type HandoverSignerResponse struct {
+	Seq            int        `json:"seq"`
+	EmployeeID     string     `json:"employee_id"`
+	EmployeeNumber string     `json:"employee_number"`
+	EmployeeName   string     `json:"employee_name"`
+	JobTitle       string     `json:"job_title"`
+	Role           string     `json:"role"`
+	Status         string     `json:"status"`
+	Note           string     `json:"note"`
+	SignedAt       *time.Time `json:"signed_at"`
+}
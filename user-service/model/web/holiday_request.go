@@ -0,0 +1,26 @@
+package web
+
+// The holiday without a work calendar is a national day and applies to every calendar, the holiday of a calendar
+// overrides the national day on the same date. The 'working_day' type makes the date a working day of the calendar.
+type CreateHolidayRequest struct {
+	WorkCalendarID string `json:"work_calendar_id" validate:"omitempty,uuid"`
+	Date           string `json:"date" validate:"required,datetime=2006-01-02"`
+	Name           string `json:"name" validate:"required"`
+	Type           string `json:"type" validate:"omitempty,oneof=public_holiday collective_leave working_day"`
+}
+
+// The request form of importing the holidays, the file is uploaded as the 'file' of the multipart form.
+// The file is an iCalendar (.ics) file or a CSV file with the 'date,name,type' columns. The type of the form
+// is used for the rows without a type, it is detected from the name ("cuti bersama") when it is empty.
+type ImportHolidayRequest struct {
+	WorkCalendarID string `form:"work_calendar_id" validate:"omitempty,uuid"`
+	Type           string `form:"type" validate:"omitempty,oneof=public_holiday collective_leave working_day"`
+}
+
+type HolidayQueryFilter struct {
+	// Year is used for fetching the holidays of the year. The default value is the current year.
+	Year int `query:"year"`
+	// WorkCalendarID is used for fetching the national days together with the days of the calendar.
+	WorkCalendarID string `query:"work_calendar_id" validate:"omitempty,uuid"`
+	Type           string `query:"type" validate:"omitempty,oneof=public_holiday collective_leave working_day"`
+}
@@ -0,0 +1,18 @@
+package web
+
+import "time"
+
+type HolidayResponse struct {
+	ID             string    `json:"id"`
+	WorkCalendarID *string   `json:"work_calendar_id"`
+	Date           string    `json:"date"`
+	Name           string    `json:"name"`
+	Type           string    `json:"type"`
+	CreatedAt      time.Time `json:"created_at"`
+	UpdatedAt      time.Time `json:"updated_at"`
+}
+
+type ImportHolidayResponse struct {
+	Imported int               `json:"imported"`
+	Holidays []HolidayResponse `json:"holidays"`
+}
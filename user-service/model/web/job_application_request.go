@@ -0,0 +1,86 @@
+package web
+
+// The application is sent by the candidate through the public posting as a multipart form with the CV in the
+// 'cv' field. The candidate is identified by the email.
+type JobApplicationRequest struct {
+	Name        string `form:"name" validate:"required,max=255"`
+	Email       string `form:"email" validate:"required,email,max=255"`
+	Phone       string `form:"phone" validate:"required,numeric,min=8,max=15"`
+	CoverLetter string `form:"cover_letter" validate:"max=5000"`
+}
+
+type JobApplicationQueryFilter struct {
+	PostingID string `query:"posting_id" validate:"omitempty,uuid"`
+	StageID   string `query:"stage_id" validate:"omitempty,uuid"`
+	Status    string `query:"status" validate:"omitempty,oneof=active hired rejected withdrawn"`
+	Search    string `query:"search" validate:"max=255"`
+
+	// Pagination is used for fetching the data by page. The default value is 1.
+	Page  string `query:"page"`
+	Limit string `query:"limit"`
+}
+
+type RecruitmentStageRequest struct {
+	Name string `json:"name" validate:"required,max=255"`
+	Seq  int    `json:"seq" validate:"required,gte=1"`
+}
+
+type MoveJobApplicationRequest struct {
+	StageID string `json:"stage_id" validate:"required,uuid"`
+	Note    string `json:"note" validate:"max=1000"`
+}
+
+// The application is rejected by the HR or withdrawn on behalf of the candidate.
+type CloseJobApplicationRequest struct {
+	Status string `json:"status" validate:"required,oneof=rejected withdrawn"`
+	Reason string `json:"reason" validate:"max=1000"`
+}
+
+// The interview is scheduled in the current stage of the application, the schedule is in WIB.
+type InterviewRequest struct {
+	InterviewerID   string `json:"interviewer_id" validate:"required,uuid"`
+	ScheduledAt     string `json:"scheduled_at" validate:"required,datetime=2006-01-02 15:04"`
+	DurationMinutes int    `json:"duration_minutes" validate:"required,gte=15,lte=480"`
+	Location        string `json:"location" validate:"required,max=255"`
+}
+
+type InterviewScorecardRequest struct {
+	Items          []InterviewScorecardItemRequest `json:"items" validate:"required,min=1,max=20,dive"`
+	Recommendation string                          `json:"recommendation" validate:"required,oneof=strong_yes yes no strong_no"`
+	Feedback       string                          `json:"feedback" validate:"required,max=5000"`
+}
+
+type InterviewScorecardItemRequest struct {
+	Criterion string `json:"criterion" validate:"required,max=255"`
+	Score     int    `json:"score" validate:"required,gte=1,lte=5"`
+	Comment   string `json:"comment" validate:"max=1000"`
+}
+
+// The offer is created as a draft and the offer letter is emailed to the candidate when it is sent. The job title of
+// the requisition is used when it is not filled.
+type JobOfferRequest struct {
+	JobTitle   string `json:"job_title" validate:"max=255"`
+	BaseSalary int64  `json:"base_salary" validate:"required,gt=0"`
+	StartDate  string `json:"start_date" validate:"required,datetime=2006-01-02"`
+	ExpiresOn  string `json:"expires_on" validate:"required,datetime=2006-01-02"`
+	Note       string `json:"note" validate:"max=1000"`
+}
+
+// The candidate is hired into the department and the position of the requisition from the start date of the accepted
+// offer, the personal data of the employee is filled by the HR.
+type HireRequest struct {
+	EmployeeNumber    string                    `json:"employee_number" validate:"required,max=32"`
+	DateOfBirth       string                    `json:"date_of_birth" validate:"required,datetime=2006-01-02"`
+	Gender            string                    `json:"gender" validate:"required,oneof=male female"`
+	NIK               string                    `json:"nik" validate:"required,numeric,len=16"`
+	Address           string                    `json:"address" validate:"required"`
+	SalaryGrade       string                    `json:"salary_grade" validate:"max=32"`
+	ManagerID         *string                   `json:"manager_id" validate:"omitempty,uuid"`
+	EmergencyContacts []EmergencyContactRequest `json:"emergency_contacts" validate:"dive"`
+}
+
+// The response of the candidate to the sent offer is recorded by the HR.
+type JobOfferResponseRequest struct {
+	Accepted bool   `json:"accepted"`
+	Note     string `json:"note" validate:"max=1000"`
+}
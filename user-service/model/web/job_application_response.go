@@ -0,0 +1,95 @@
+package web
+
+import "time"
+
+type RecruitmentStageResponse struct {
+	ID        string    `json:"id"`
+	Name      string    `json:"name"`
+	Seq       int       `json:"seq"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+type JobApplicationResponse struct {
+	ID              string     `json:"id"`
+	PostingID       string     `json:"posting_id"`
+	PostingTitle    string     `json:"posting_title"`
+	RequisitionID   string     `json:"requisition_id"`
+	CandidateID     string     `json:"candidate_id"`
+	CandidateName   string     `json:"candidate_name"`
+	CandidateEmail  string     `json:"candidate_email"`
+	CandidatePhone  string     `json:"candidate_phone"`
+	StageID         string     `json:"stage_id"`
+	StageName       string     `json:"stage_name"`
+	Status          string     `json:"status"`
+	CoverLetter     string     `json:"cover_letter"`
+	CVFileName      string     `json:"cv_file_name"`
+	CVMimeType      string     `json:"cv_mime_type"`
+	CVSize          int64      `json:"cv_size"`
+	RejectionReason string     `json:"rejection_reason"`
+	UserID          *string    `json:"user_id"`
+	HiredAt         *time.Time `json:"hired_at"`
+	CreatedAt       time.Time  `json:"created_at"`
+	UpdatedAt       time.Time  `json:"updated_at"`
+
+	Moves      []JobApplicationMoveResponse `json:"moves,omitempty"`
+	Interviews []InterviewResponse          `json:"interviews,omitempty"`
+	Offers     []JobOfferResponse           `json:"offers,omitempty"`
+}
+
+type JobApplicationMoveResponse struct {
+	FromStageID   *string   `json:"from_stage_id"`
+	FromStageName *string   `json:"from_stage_name"`
+	ToStageID     string    `json:"to_stage_id"`
+	ToStageName   string    `json:"to_stage_name"`
+	Note          string    `json:"note"`
+	MovedBy       *string   `json:"moved_by"`
+	MovedAt       time.Time `json:"moved_at"`
+}
+
+type InterviewResponse struct {
+	ID                  string                       `json:"id"`
+	ApplicationID       string                       `json:"application_id"`
+	CandidateName       string                       `json:"candidate_name"`
+	PostingTitle        string                       `json:"posting_title"`
+	StageID             string                       `json:"stage_id"`
+	StageName           string                       `json:"stage_name"`
+	InterviewerID       string                       `json:"interviewer_id"`
+	InterviewerName     string                       `json:"interviewer_name"`
+	ScheduledAt         time.Time                    `json:"scheduled_at"`
+	DurationMinutes     int                          `json:"duration_minutes"`
+	Location            string                       `json:"location"`
+	Status              string                       `json:"status"`
+	Recommendation      *string                      `json:"recommendation"`
+	Score               *float64                     `json:"score"`
+	Feedback            string                       `json:"feedback"`
+	FeedbackSubmittedAt *time.Time                   `json:"feedback_submitted_at"`
+	CreatedBy           *string                      `json:"created_by"`
+	CreatedAt           time.Time                    `json:"created_at"`
+	UpdatedAt           time.Time                    `json:"updated_at"`
+	Items               []InterviewScorecardResponse `json:"items,omitempty"`
+}
+
+type InterviewScorecardResponse struct {
+	Seq       int    `json:"seq"`
+	Criterion string `json:"criterion"`
+	Score     int    `json:"score"`
+	Comment   string `json:"comment"`
+}
+
+type JobOfferResponse struct {
+	ID            string     `json:"id"`
+	ApplicationID string     `json:"application_id"`
+	JobTitle      string     `json:"job_title"`
+	BaseSalary    int64      `json:"base_salary"`
+	StartDate     string     `json:"start_date"`
+	ExpiresOn     string     `json:"expires_on"`
+	Status        string     `json:"status"`
+	Note          string     `json:"note"`
+	ResponseNote  string     `json:"response_note"`
+	CreatedBy     *string    `json:"created_by"`
+	SentAt        *time.Time `json:"sent_at"`
+	RespondedAt   *time.Time `json:"responded_at"`
+	CreatedAt     time.Time  `json:"created_at"`
+	UpdatedAt     time.Time  `json:"updated_at"`
+}
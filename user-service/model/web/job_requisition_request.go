@@ -0,0 +1,47 @@
+package web
+
+// The requisition is created as a draft and is submitted to the manager of the requester for approval. The salary
+// range is the budget of the requisition, it is never shown on the posting.
+type JobRequisitionRequest struct {
+	Title          string  `json:"title" validate:"required,max=255"`
+	DepartmentID   string  `json:"department_id" validate:"required,uuid"`
+	PositionID     *string `json:"position_id" validate:"omitempty,uuid"`
+	Headcount      int     `json:"headcount" validate:"required,gte=1,lte=100"`
+	EmploymentType string  `json:"employment_type" validate:"required,oneof=permanent contract probation internship outsource"`
+	Description    string  `json:"description" validate:"max=5000"`
+	Reason         string  `json:"reason" validate:"max=1000"`
+	SalaryMin      *int64  `json:"salary_min" validate:"omitempty,gte=0"`
+	SalaryMax      *int64  `json:"salary_max" validate:"omitempty,gte=0"`
+}
+
+type DecideJobRequisitionRequest struct {
+	Note string `json:"note" validate:"max=1000"`
+}
+
+type JobRequisitionQueryFilter struct {
+	Status       string `query:"status" validate:"omitempty,oneof=draft pending_approval approved rejected filled cancelled"`
+	DepartmentID string `query:"department_id" validate:"omitempty,uuid"`
+
+	// Pagination is used for fetching the data by page. The default value is 1.
+	Page  string `query:"page"`
+	Limit string `query:"limit"`
+}
+
+// The posting is published from the approved requisition, the title of the requisition is used when the title is
+// not filled. The posting is closed automatically after the closing date.
+type JobPostingRequest struct {
+	Title        string `json:"title" validate:"max=255"`
+	Description  string `json:"description" validate:"required,max=10000"`
+	Requirements string `json:"requirements" validate:"max=10000"`
+	Location     string `json:"location" validate:"max=255"`
+	ClosesOn     string `json:"closes_on" validate:"omitempty,datetime=2006-01-02"`
+}
+
+type JobPostingQueryFilter struct {
+	Search       string `query:"search" validate:"max=255"`
+	DepartmentID string `query:"department_id" validate:"omitempty,uuid"`
+
+	// Pagination is used for fetching the data by page. The default value is 1.
+	Page  string `query:"page"`
+	Limit string `query:"limit"`
+}
@@ -0,0 +1,44 @@
+package web
+
+import "time"
+
+type JobRequisitionResponse struct {
+	ID             string              `json:"id"`
+	Title          string              `json:"title"`
+	DepartmentID   string              `json:"department_id"`
+	Department     string              `json:"department"`
+	PositionID     *string             `json:"position_id"`
+	Position       *string             `json:"position"`
+	Headcount      int                 `json:"headcount"`
+	Hired          int                 `json:"hired"`
+	EmploymentType string              `json:"employment_type"`
+	Description    string              `json:"description"`
+	Reason         string              `json:"reason"`
+	SalaryMin      *int64              `json:"salary_min"`
+	SalaryMax      *int64              `json:"salary_max"`
+	Status         string              `json:"status"`
+	RequestedBy    string              `json:"requested_by"`
+	RequesterName  string              `json:"requester_name"`
+	ApproverID     *string             `json:"approver_id"`
+	ApproverName   *string             `json:"approver_name"`
+	DecisionNote   string              `json:"decision_note"`
+	DecidedAt      *time.Time          `json:"decided_at"`
+	CreatedAt      time.Time           `json:"created_at"`
+	UpdatedAt      time.Time           `json:"updated_at"`
+	Posting        *JobPostingResponse `json:"posting"`
+}
+
+type JobPostingResponse struct {
+	ID             string     `json:"id"`
+	RequisitionID  string     `json:"requisition_id"`
+	Title          string     `json:"title"`
+	Department     string     `json:"department"`
+	EmploymentType string     `json:"employment_type"`
+	Description    string     `json:"description"`
+	Requirements   string     `json:"requirements"`
+	Location       string     `json:"location"`
+	Status         string     `json:"status"`
+	PublishedAt    time.Time  `json:"published_at"`
+	ClosesOn       *string    `json:"closes_on"`
+	ClosedAt       *time.Time `json:"closed_at"`
+}
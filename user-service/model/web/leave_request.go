@@ -0,0 +1,32 @@
+package web
+
+// A half day leave can only be requested for a single day (the start date equals the end date).
+type CreateLeaveRequest struct {
+	LeaveTypeID string `json:"leave_type_id" validate:"required,uuid"`
+	StartDate   string `json:"start_date" validate:"required,datetime=2006-01-02"`
+	EndDate     string `json:"end_date" validate:"required,datetime=2006-01-02"`
+	HalfDay     string `json:"half_day" validate:"omitempty,oneof=morning afternoon"`
+	Reason      string `json:"reason" validate:"max=255"`
+}
+
+// The request body of approving or rejecting a leave request.
+type DecideLeaveRequest struct {
+	Note string `json:"note" validate:"max=255"`
+}
+
+type LeaveQueryFilter struct {
+	EmployeeID  string `query:"employee_id"`
+	LeaveTypeID string `query:"leave_type_id"`
+	Status      string `query:"status"`
+	From        string `query:"from" validate:"omitempty,datetime=2006-01-02"`
+	To          string `query:"to" validate:"omitempty,datetime=2006-01-02"`
+
+	// Pagination is used for fetching the data by page. The default value is 1.
+	Page  string `query:"page"`
+	Limit string `query:"limit"`
+}
+
+type LeaveBalanceQueryFilter struct {
+	// Year is used for fetching the balances of the year. The default value is the current year.
+	Year int `query:"year"`
+}
@@ -0,0 +1,39 @@
+package web
+
+import "time"
+
+type LeaveResponse struct {
+	ID             string     `json:"id"`
+	EmployeeID     string     `json:"employee_id"`
+	EmployeeNumber string     `json:"employee_number"`
+	EmployeeName   string     `json:"employee_name"`
+	LeaveTypeID    string     `json:"leave_type_id"`
+	LeaveTypeCode  string     `json:"leave_type_code"`
+	LeaveTypeName  string     `json:"leave_type_name"`
+	StartDate      string     `json:"start_date"`
+	EndDate        string     `json:"end_date"`
+	HalfDay        string     `json:"half_day"`
+	Days           float64    `json:"days"`
+	Reason         string     `json:"reason"`
+	Status         string     `json:"status"`
+	ApproverID     *string    `json:"approver_id"`
+	ApproverName   string     `json:"approver_name"`
+	DecisionNote   string     `json:"decision_note"`
+	DecidedAt      *time.Time `json:"decided_at"`
+	CancelledAt    *time.Time `json:"cancelled_at"`
+	CreatedAt      time.Time  `json:"created_at"`
+	UpdatedAt      time.Time  `json:"updated_at"`
+}
+
+type LeaveBalanceResponse struct {
+	EmployeeID    string  `json:"employee_id"`
+	LeaveTypeID   string  `json:"leave_type_id"`
+	LeaveTypeCode string  `json:"leave_type_code"`
+	LeaveTypeName string  `json:"leave_type_name"`
+	Year          int     `json:"year"`
+	Entitled      float64 `json:"entitled"`
+	CarriedOver   float64 `json:"carried_over"`
+	Used          float64 `json:"used"`
+	Pending       float64 `json:"pending"`
+	Available     float64 `json:"available"`
+}
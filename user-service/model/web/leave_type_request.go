@@ -0,0 +1,24 @@
+package web
+
+type CreateLeaveTypeRequest struct {
+	Code          string  `json:"code" validate:"required,max=32"`
+	Name          string  `json:"name" validate:"required"`
+	AccrualMethod string  `json:"accrual_method" validate:"required,oneof=annual monthly none"`
+	AnnualQuota   float64 `json:"annual_quota" validate:"min=0,max=365"`
+	MaxCarryOver  float64 `json:"max_carry_over" validate:"min=0,max=365"`
+	// These fields are true when they are not filled.
+	RequiresBalance *bool `json:"requires_balance"`
+	AllowHalfDay    *bool `json:"allow_half_day"`
+	IsPaid          *bool `json:"is_paid"`
+}
+
+// All fields are optional, only the filled fields will be updated.
+type UpdateLeaveTypeRequest struct {
+	Name            string   `json:"name"`
+	AccrualMethod   string   `json:"accrual_method" validate:"omitempty,oneof=annual monthly none"`
+	AnnualQuota     *float64 `json:"annual_quota" validate:"omitempty,min=0,max=365"`
+	MaxCarryOver    *float64 `json:"max_carry_over" validate:"omitempty,min=0,max=365"`
+	RequiresBalance *bool    `json:"requires_balance"`
+	AllowHalfDay    *bool    `json:"allow_half_day"`
+	IsPaid          *bool    `json:"is_paid"`
+}
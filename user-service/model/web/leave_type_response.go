@@ -0,0 +1,17 @@
+package web
+
+import "time"
+
+type LeaveTypeResponse struct {
+	ID              string    `json:"id"`
+	Code            string    `json:"code"`
+	Name            string    `json:"name"`
+	AccrualMethod   string    `json:"accrual_method"`
+	AnnualQuota     float64   `json:"annual_quota"`
+	MaxCarryOver    float64   `json:"max_carry_over"`
+	RequiresBalance bool      `json:"requires_balance"`
+	AllowHalfDay    bool      `json:"allow_half_day"`
+	IsPaid          bool      `json:"is_paid"`
+	CreatedAt       time.Time `json:"created_at"`
+	UpdatedAt       time.Time `json:"updated_at"`
+}
@@ -0,0 +1,48 @@
+package web
+
+// The account of the employee is deactivated after the last working day. The 'hr' tasks of the exit checklist are
+// assigned to the HR assignee, or the logged in user when it is not filled, and the 'it' tasks to the IT assignee.
+type OffboardingRequest struct {
+	EmployeeID     string                   `json:"employee_id" validate:"required,uuid"`
+	Type           string                   `json:"type" validate:"required,oneof=resignation termination"`
+	NoticeDate     string                   `json:"notice_date" validate:"required,datetime=2006-01-02"`
+	LastWorkingDay string                   `json:"last_working_day" validate:"required,datetime=2006-01-02"`
+	Reason         string                   `json:"reason" validate:"required,max=1000"`
+	RehireEligible *bool                    `json:"rehire_eligible"`
+	HRAssigneeID   *string                  `json:"hr_assignee_id" validate:"omitempty,uuid"`
+	ITAssigneeID   *string                  `json:"it_assignee_id" validate:"omitempty,uuid"`
+	Tasks          []OffboardingTaskRequest `json:"tasks" validate:"omitempty,dive"`
+}
+
+// The additional task of the exit checklist, the due days is relative to the last working day.
+type OffboardingTaskRequest struct {
+	Title        string  `json:"title" validate:"required,max=255"`
+	Description  string  `json:"description" validate:"max=255"`
+	Category     string  `json:"category" validate:"required,oneof=asset_return knowledge_transfer final_pay exit_interview other"`
+	AssigneeRole string  `json:"assignee_role" validate:"required,oneof=hr it manager employee"`
+	AssigneeID   *string `json:"assignee_id" validate:"omitempty,uuid"`
+	DueDays      int     `json:"due_days" validate:"gte=-90,lte=90"`
+}
+
+// The last working day and the reason can only be changed before the account is deactivated.
+type UpdateOffboardingRequest struct {
+	LastWorkingDay string `json:"last_working_day" validate:"required,datetime=2006-01-02"`
+	Reason         string `json:"reason" validate:"required,max=1000"`
+	RehireEligible *bool  `json:"rehire_eligible"`
+}
+
+type UpdateOffboardingTaskRequest struct {
+	Status string `json:"status" validate:"required,oneof=pending done skipped"`
+	Note   string `json:"note" validate:"max=5000"`
+}
+
+type OffboardingQueryFilter struct {
+	Status       string `query:"status" validate:"omitempty,oneof=in_progress completed cancelled"`
+	Type         string `query:"type" validate:"omitempty,oneof=resignation termination"`
+	EmployeeID   string `query:"employee_id" validate:"omitempty,uuid"`
+	DepartmentID string `query:"department_id" validate:"omitempty,uuid"`
+
+	// Pagination is used for fetching the data by page. The default value is 1.
+	Page  string `query:"page"`
+	Limit string `query:"limit"`
+}
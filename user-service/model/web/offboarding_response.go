@@ -0,0 +1,46 @@
+package web
+
+import "time"
+
+type OffboardingResponse struct {
+	ID             string     `json:"id"`
+	EmployeeID     string     `json:"employee_id"`
+	EmployeeNumber string     `json:"employee_number"`
+	EmployeeName   string     `json:"employee_name"`
+	Type           string     `json:"type"`
+	NoticeDate     string     `json:"notice_date"`
+	LastWorkingDay string     `json:"last_working_day"`
+	Reason         string     `json:"reason"`
+	RehireEligible bool       `json:"rehire_eligible"`
+	Status         string     `json:"status"`
+	TotalTasks     int        `json:"total_tasks"`
+	CompletedTasks int        `json:"completed_tasks"`
+	Progress       float64    `json:"progress"`
+	CompletedAt    *time.Time `json:"completed_at"`
+	CancelledAt    *time.Time `json:"cancelled_at"`
+	DeactivatedAt  *time.Time `json:"deactivated_at"`
+	CreatedBy      *string    `json:"created_by"`
+	CreatedAt      time.Time  `json:"created_at"`
+	UpdatedAt      time.Time  `json:"updated_at"`
+	// the tasks are only filled when a single offboarding is fetched
+	Tasks []OffboardingTaskResponse `json:"tasks,omitempty"`
+}
+
+type OffboardingTaskResponse struct {
+	OffboardingID  string     `json:"offboarding_id"`
+	Seq            int        `json:"seq"`
+	Title          string     `json:"title"`
+	Description    string     `json:"description"`
+	Category       string     `json:"category"`
+	AssigneeRole   string     `json:"assignee_role"`
+	AssigneeID     *string    `json:"assignee_id"`
+	AssigneeName   string     `json:"assignee_name"`
+	DueDate        string     `json:"due_date"`
+	Status         string     `json:"status"`
+	Note           string     `json:"note"`
+	CompletedBy    *string    `json:"completed_by"`
+	CompletedAt    *time.Time `json:"completed_at"`
+	EmployeeID     string     `json:"employee_id"`
+	EmployeeNumber string     `json:"employee_number"`
+	EmployeeName   string     `json:"employee_name"`
+}
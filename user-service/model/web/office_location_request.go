@@ -0,0 +1,24 @@
+package web
+
+type CreateOfficeLocationRequest struct {
+	Name           string  `json:"name" validate:"required"`
+	Latitude       float64 `json:"latitude" validate:"min=-90,max=90"`
+	Longitude      float64 `json:"longitude" validate:"min=-180,max=180"`
+	RadiusMeters   int     `json:"radius_meters" validate:"required,min=1"`
+	WorkCalendarID string  `json:"work_calendar_id" validate:"omitempty,uuid"`
+}
+
+// All fields are optional, only the filled fields will be updated. An empty work calendar id removes
+// the calendar of the location, the default calendar is used then.
+type UpdateOfficeLocationRequest struct {
+	Name           string   `json:"name"`
+	Latitude       *float64 `json:"latitude" validate:"omitempty,min=-90,max=90"`
+	Longitude      *float64 `json:"longitude" validate:"omitempty,min=-180,max=180"`
+	RadiusMeters   *int     `json:"radius_meters" validate:"omitempty,min=1"`
+	WorkCalendarID *string  `json:"work_calendar_id" validate:"omitempty,uuid"`
+}
+
+// The request body of assigning the office location to the employees.
+type AssignOfficeLocationRequest struct {
+	EmployeeIDs []string `json:"employee_ids" validate:"required,min=1,dive,uuid"`
+}
@@ -0,0 +1,14 @@
+package web
+
+import "time"
+
+type OfficeLocationResponse struct {
+	ID             string    `json:"id"`
+	Name           string    `json:"name"`
+	Latitude       float64   `json:"latitude"`
+	Longitude      float64   `json:"longitude"`
+	RadiusMeters   int       `json:"radius_meters"`
+	WorkCalendarID *string   `json:"work_calendar_id"`
+	CreatedAt      time.Time `json:"created_at"`
+	UpdatedAt      time.Time `json:"updated_at"`
+}
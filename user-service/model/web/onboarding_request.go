@@ -0,0 +1,43 @@
+package web
+
+// The template is used for the new employees of the position, or the department when the position is not filled.
+// The template without position and department is the default template.
+type OnboardingTemplateRequest struct {
+	Name         string                          `json:"name" validate:"required,max=255"`
+	Description  string                          `json:"description"`
+	DepartmentID *string                         `json:"department_id" validate:"omitempty,uuid"`
+	PositionID   *string                         `json:"position_id" validate:"omitempty,uuid"`
+	Tasks        []OnboardingTemplateTaskRequest `json:"tasks" validate:"required,min=1,dive"`
+}
+
+// The due days is relative to the hire date, e.g. -3 is three days before the hire date. The assignee is the
+// employee who does the 'hr' or 'it' task.
+type OnboardingTemplateTaskRequest struct {
+	Title        string  `json:"title" validate:"required,max=255"`
+	Description  string  `json:"description" validate:"max=255"`
+	Category     string  `json:"category" validate:"required,oneof=document account asset training other"`
+	AssigneeRole string  `json:"assignee_role" validate:"required,oneof=hr it manager employee"`
+	AssigneeID   *string `json:"assignee_id" validate:"omitempty,uuid"`
+	DueDays      int     `json:"due_days" validate:"gte=-90,lte=365"`
+}
+
+// The template is selected from the position or the department of the employee when it is not filled.
+type StartOnboardingRequest struct {
+	EmployeeID string  `json:"employee_id" validate:"required,uuid"`
+	TemplateID *string `json:"template_id" validate:"omitempty,uuid"`
+}
+
+type UpdateOnboardingTaskRequest struct {
+	Status string `json:"status" validate:"required,oneof=pending done skipped"`
+	Note   string `json:"note" validate:"max=255"`
+}
+
+type OnboardingQueryFilter struct {
+	Status       string `query:"status" validate:"omitempty,oneof=in_progress completed cancelled"`
+	EmployeeID   string `query:"employee_id" validate:"omitempty,uuid"`
+	DepartmentID string `query:"department_id" validate:"omitempty,uuid"`
+
+	// Pagination is used for fetching the data by page. The default value is 1.
+	Page  string `query:"page"`
+	Limit string `query:"limit"`
+}
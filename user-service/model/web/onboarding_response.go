@@ -0,0 +1,66 @@
+package web
+
+import "time"
+
+type OnboardingTemplateResponse struct {
+	ID           string    `json:"id"`
+	Name         string    `json:"name"`
+	Description  string    `json:"description"`
+	DepartmentID *string   `json:"department_id"`
+	PositionID   *string   `json:"position_id"`
+	CreatedAt    time.Time `json:"created_at"`
+	UpdatedAt    time.Time `json:"updated_at"`
+	// the tasks are only filled when a single template is fetched
+	Tasks []OnboardingTemplateTaskResponse `json:"tasks,omitempty"`
+}
+
+type OnboardingTemplateTaskResponse struct {
+	Seq          int     `json:"seq"`
+	Title        string  `json:"title"`
+	Description  string  `json:"description"`
+	Category     string  `json:"category"`
+	AssigneeRole string  `json:"assignee_role"`
+	AssigneeID   *string `json:"assignee_id"`
+	DueDays      int     `json:"due_days"`
+}
+
+type OnboardingResponse struct {
+	ID             string     `json:"id"`
+	EmployeeID     string     `json:"employee_id"`
+	EmployeeNumber string     `json:"employee_number"`
+	EmployeeName   string     `json:"employee_name"`
+	HireDate       string     `json:"hire_date"`
+	TemplateID     *string    `json:"template_id"`
+	Status         string     `json:"status"`
+	TotalTasks     int        `json:"total_tasks"`
+	CompletedTasks int        `json:"completed_tasks"`
+	OverdueTasks   int        `json:"overdue_tasks"`
+	Progress       float64    `json:"progress"`
+	StartedAt      time.Time  `json:"started_at"`
+	CompletedAt    *time.Time `json:"completed_at"`
+	CancelledAt    *time.Time `json:"cancelled_at"`
+	CreatedAt      time.Time  `json:"created_at"`
+	UpdatedAt      time.Time  `json:"updated_at"`
+	// the tasks are only filled when a single onboarding is fetched
+	Tasks []OnboardingTaskResponse `json:"tasks,omitempty"`
+}
+
+type OnboardingTaskResponse struct {
+	OnboardingID   string     `json:"onboarding_id"`
+	Seq            int        `json:"seq"`
+	Title          string     `json:"title"`
+	Description    string     `json:"description"`
+	Category       string     `json:"category"`
+	AssigneeRole   string     `json:"assignee_role"`
+	AssigneeID     *string    `json:"assignee_id"`
+	AssigneeName   string     `json:"assignee_name"`
+	DueDate        string     `json:"due_date"`
+	Overdue        bool       `json:"overdue"`
+	Status         string     `json:"status"`
+	Note           string     `json:"note"`
+	CompletedBy    *string    `json:"completed_by"`
+	CompletedAt    *time.Time `json:"completed_at"`
+	EmployeeID     string     `json:"employee_id"`
+	EmployeeNumber string     `json:"employee_number"`
+	EmployeeName   string     `json:"employee_name"`
+}
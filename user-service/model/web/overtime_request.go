@@ -0,0 +1,32 @@
+package web
+
+// The overtime is requested before it is worked, the date can't be in the past.
+type CreateOvertimeRequest struct {
+	Date   string  `json:"date" validate:"required,datetime=2006-01-02"`
+	Hours  float64 `json:"hours" validate:"required,gt=0,max=12"`
+	Reason string  `json:"reason" validate:"required,max=255"`
+}
+
+// The request body of approving or rejecting an overtime request.
+type DecideOvertimeRequest struct {
+	Note string `json:"note" validate:"max=255"`
+}
+
+type OvertimeQueryFilter struct {
+	EmployeeID   string `query:"employee_id"`
+	DepartmentID string `query:"department_id"`
+	Status       string `query:"status" validate:"omitempty,oneof=pending approved rejected cancelled"`
+	From         string `query:"from" validate:"omitempty,datetime=2006-01-02"`
+	To           string `query:"to" validate:"omitempty,datetime=2006-01-02"`
+
+	// Pagination is used for fetching the data by page. The default value is 1.
+	Page  string `query:"page"`
+	Limit string `query:"limit"`
+}
+
+// The dashboard shows the overtime of a department and its sub-departments in the month, the department of the
+// logged in user and the current month are used when they're empty.
+type OvertimeDashboardQueryFilter struct {
+	DepartmentID string `query:"department_id"`
+	Month        string `query:"month" validate:"omitempty,datetime=2006-01"`
+}
@@ -0,0 +1,59 @@
+package web
+
+import "time"
+
+type OvertimeResponse struct {
+	ID             string     `json:"id"`
+	EmployeeID     string     `json:"employee_id"`
+	EmployeeNumber string     `json:"employee_number"`
+	EmployeeName   string     `json:"employee_name"`
+	DepartmentID   string     `json:"department_id"`
+	DepartmentName string     `json:"department_name"`
+	Date           string     `json:"date"`
+	Hours          float64    `json:"hours"`
+	Reason         string     `json:"reason"`
+	WorkingDay     bool       `json:"working_day"`
+	Status         string     `json:"status"`
+	ApproverID     *string    `json:"approver_id"`
+	ApproverName   string     `json:"approver_name"`
+	DecisionNote   string     `json:"decision_note"`
+	DecidedAt      *time.Time `json:"decided_at"`
+	CancelledAt    *time.Time `json:"cancelled_at"`
+	// the overtime of the checked out attendance on the date, it is empty before the attendance is checked out
+	ActualHours *float64 `json:"actual_hours"`
+	// the approved hours limited by the actual hours
+	PaidHours float64   `json:"paid_hours"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+type OvertimeDashboardResponse struct {
+	DepartmentID   string `json:"department_id"`
+	DepartmentName string `json:"department_name"`
+	From           string `json:"from"`
+	To             string `json:"to"`
+
+	OvertimeDashboardTotal
+	Items []OvertimeDashboardItem `json:"items"`
+}
+
+type OvertimeDashboardItem struct {
+	EmployeeID     string `json:"employee_id"`
+	EmployeeNumber string `json:"employee_number"`
+	EmployeeName   string `json:"employee_name"`
+	DepartmentName string `json:"department_name"`
+
+	OvertimeDashboardTotal
+}
+
+// The totals of the overtime requests, the multiplied hours are the paid hours with the Kepmenakertrans 102/2004
+// multipliers the overtime pay is based on.
+type OvertimeDashboardTotal struct {
+	PendingRequests  int     `json:"pending_requests"`
+	ApprovedRequests int     `json:"approved_requests"`
+	RejectedRequests int     `json:"rejected_requests"`
+	ApprovedHours    float64 `json:"approved_hours"`
+	ActualHours      float64 `json:"actual_hours"`
+	PaidHours        float64 `json:"paid_hours"`
+	MultipliedHours  float64 `json:"multiplied_hours"`
+}
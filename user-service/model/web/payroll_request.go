@@ -0,0 +1,43 @@
+package web
+
+// The payroll run is calculated for the employees with a salary, the month must come after the locked runs of the year.
+type CreatePayrollRunRequest struct {
+	Year  int `json:"year" validate:"required,min=2000,max=2100"`
+	Month int `json:"month" validate:"required,min=1,max=12"`
+}
+
+type PayrollRunQueryFilter struct {
+	Year   int    `query:"year"`
+	Status string `query:"status" validate:"omitempty,oneof=draft reviewed locked"`
+
+	// Pagination is used for fetching the data by page. The default value is 1.
+	Page  string `query:"page"`
+	Limit string `query:"limit"`
+}
+
+// The rates in percent, a new version of the program starts on the effective date. The wage is not capped when the
+// wage cap is empty.
+type CreateBpjsRateRequest struct {
+	Program            string  `json:"program" validate:"required,oneof=kesehatan jht jp jkk jkm"`
+	EmployeeRate       float64 `json:"employee_rate" validate:"min=0,max=100"`
+	EmployerRate       float64 `json:"employer_rate" validate:"min=0,max=100"`
+	WageCap            *int64  `json:"wage_cap" validate:"omitempty,min=1"`
+	EmployerTaxable    bool    `json:"employer_taxable"`
+	EmployeeDeductible bool    `json:"employee_deductible"`
+	EffectiveFrom      string  `json:"effective_from" validate:"required,datetime=2006-01-02"`
+}
+
+type PayrollRateQueryFilter struct {
+	// Date is used for fetching the rates effective on the date. The default value is today.
+	Date string `query:"date" validate:"omitempty,datetime=2006-01-02"`
+}
+
+// The payslip pdfs of the locked run are generated, they are emailed to the employees when 'send_email' is true.
+type GeneratePayslipDocumentRequest struct {
+	SendEmail bool `json:"send_email"`
+}
+
+type PayslipQueryFilter struct {
+	// Year is used for fetching the payslips of the year. The default value is all years.
+	Year int `query:"year" validate:"omitempty,min=2000,max=2100"`
+}
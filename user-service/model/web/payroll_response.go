@@ -0,0 +1,118 @@
+package web
+
+import "time"
+
+type PayrollRunResponse struct {
+	ID                         string     `json:"id"`
+	Year                       int        `json:"year"`
+	Month                      int        `json:"month"`
+	PeriodStart                string     `json:"period_start"`
+	PeriodEnd                  string     `json:"period_end"`
+	Status                     string     `json:"status"`
+	EmployeeCount              int        `json:"employee_count"`
+	TotalGross                 int64      `json:"total_gross"`
+	TotalDeductions            int64      `json:"total_deductions"`
+	TotalNet                   int64      `json:"total_net"`
+	TotalEmployerContributions int64      `json:"total_employer_contributions"`
+	CalculatedAt               time.Time  `json:"calculated_at"`
+	CreatedBy                  *string    `json:"created_by"`
+	ReviewedBy                 *string    `json:"reviewed_by"`
+	ReviewedAt                 *time.Time `json:"reviewed_at"`
+	LockedBy                   *string    `json:"locked_by"`
+	LockedAt                   *time.Time `json:"locked_at"`
+	CreatedAt                  time.Time  `json:"created_at"`
+	UpdatedAt                  time.Time  `json:"updated_at"`
+}
+
+type PayslipResponse struct {
+	ID                      string                    `json:"id"`
+	PayrollRunID            string                    `json:"payroll_run_id"`
+	Year                    int                       `json:"year"`
+	Month                   int                       `json:"month"`
+	EmployeeID              string                    `json:"employee_id"`
+	EmployeeNumber          string                    `json:"employee_number"`
+	EmployeeName            string                    `json:"employee_name"`
+	DepartmentName          string                    `json:"department_name"`
+	BaseSalary              int64                     `json:"base_salary"`
+	Allowances              []SalaryAllowanceResponse `json:"allowances"`
+	TaxStatus               string                    `json:"tax_status"`
+	TerCategory             string                    `json:"ter_category"`
+	WorkingDays             int                       `json:"working_days"`
+	OvertimeHours           float64                   `json:"overtime_hours"`
+	UnpaidLeaveDays         float64                   `json:"unpaid_leave_days"`
+	Reimbursement           int64                     `json:"reimbursement"`
+	GrossPay                int64                     `json:"gross_pay"`
+	TotalDeductions         int64                     `json:"total_deductions"`
+	NetPay                  int64                     `json:"net_pay"`
+	EmployerContributions   int64                     `json:"employer_contributions"`
+	TaxableIncome           int64                     `json:"taxable_income"`
+	DeductibleContributions int64                     `json:"deductible_contributions"`
+	IncomeTax               int64                     `json:"income_tax"`
+	DocumentGenerated       bool                      `json:"document_generated"`
+	DocumentGeneratedAt     *time.Time                `json:"document_generated_at"`
+	EmailedAt               *time.Time                `json:"emailed_at"`
+	// the line items are only filled when a single payslip is fetched
+	Items []PayslipItemResponse `json:"items"`
+}
+
+type PayslipItemResponse struct {
+	Code     string  `json:"code"`
+	Name     string  `json:"name"`
+	Category string  `json:"category"`
+	Quantity float64 `json:"quantity"`
+	Amount   int64   `json:"amount"`
+}
+
+type PayrollRatesResponse struct {
+	Date string             `json:"date"`
+	Bpjs []BpjsRateResponse `json:"bpjs"`
+	Ptkp []PtkpRateResponse `json:"ptkp"`
+	Ter  []TerRateResponse  `json:"ter"`
+	Tax  []TaxRateResponse  `json:"tax"`
+}
+
+type BpjsRateResponse struct {
+	ID                 string    `json:"id"`
+	Program            string    `json:"program"`
+	EmployeeRate       float64   `json:"employee_rate"`
+	EmployerRate       float64   `json:"employer_rate"`
+	WageCap            *int64    `json:"wage_cap"`
+	EmployerTaxable    bool      `json:"employer_taxable"`
+	EmployeeDeductible bool      `json:"employee_deductible"`
+	EffectiveFrom      string    `json:"effective_from"`
+	CreatedAt          time.Time `json:"created_at"`
+}
+
+type PtkpRateResponse struct {
+	TaxStatus     string `json:"tax_status"`
+	Amount        int64  `json:"amount"`
+	TerCategory   string `json:"ter_category"`
+	EffectiveFrom string `json:"effective_from"`
+}
+
+type TerRateResponse struct {
+	Category      string  `json:"category"`
+	MinIncome     int64   `json:"min_income"`
+	MaxIncome     *int64  `json:"max_income"`
+	Rate          float64 `json:"rate"`
+	EffectiveFrom string  `json:"effective_from"`
+}
+
+type TaxRateResponse struct {
+	UpTo          *int64  `json:"up_to"`
+	Rate          float64 `json:"rate"`
+	EffectiveFrom string  `json:"effective_from"`
+}
+
+// The result of generating the payslip pdfs of a run, the failed payslips can be generated again.
+type GeneratePayslipDocumentResponse struct {
+	Generated int                            `json:"generated"`
+	Emailed   int                            `json:"emailed"`
+	Failed    []PayslipDocumentErrorResponse `json:"failed"`
+}
+
+type PayslipDocumentErrorResponse struct {
+	PayslipID      string `json:"payslip_id"`
+	EmployeeNumber string `json:"employee_number"`
+	Error          string `json:"error"`
+}
@@ -0,0 +1,79 @@
+package web
+
+// The participants are the active employees of the departments, or the listed employees. Every active employee
+// participates when both are not filled. The reviewer of a participant is the manager of the employee.
+type ReviewCycleRequest struct {
+	Name          string   `json:"name" validate:"required,max=255"`
+	Description   string   `json:"description"`
+	PeriodStart   string   `json:"period_start" validate:"required,datetime=2006-01-02"`
+	PeriodEnd     string   `json:"period_end" validate:"required,datetime=2006-01-02"`
+	PeerFeedback  bool     `json:"peer_feedback"`
+	PeerAnonymous *bool    `json:"peer_anonymous"`
+	DepartmentIDs []string `json:"department_ids" validate:"omitempty,dive,uuid"`
+	EmployeeIDs   []string `json:"employee_ids" validate:"omitempty,dive,uuid"`
+}
+
+type ReviewCycleQueryFilter struct {
+	Status string `query:"status" validate:"omitempty,oneof=draft goal_setting self_assessment manager_assessment calibration closed"`
+
+	// Pagination is used for fetching the data by page. The default value is 1.
+	Page  string `query:"page"`
+	Limit string `query:"limit"`
+}
+
+// The goals replace the goals of the review, the weights of the goals must add up to 100.
+type ReviewGoalsRequest struct {
+	Goals []ReviewGoalRequest `json:"goals" validate:"required,min=1,dive"`
+}
+
+type ReviewGoalRequest struct {
+	Title       string `json:"title" validate:"required,max=255"`
+	Description string `json:"description" validate:"max=1000"`
+	Target      string `json:"target" validate:"max=255"`
+	Weight      int    `json:"weight" validate:"required,gte=1,lte=100"`
+}
+
+// The assessment is saved as a draft until it is submitted, every goal must be scored before it is submitted and
+// it can't be changed afterwards.
+type ReviewAssessmentRequest struct {
+	Goals   []ReviewGoalScoreRequest `json:"goals" validate:"omitempty,dive"`
+	Comment string                   `json:"comment" validate:"max=5000"`
+	Submit  bool                     `json:"submit"`
+}
+
+type ReviewGoalScoreRequest struct {
+	Seq     int    `json:"seq" validate:"required,gte=1"`
+	Score   *int   `json:"score" validate:"omitempty,gte=1,lte=5"`
+	Comment string `json:"comment" validate:"max=1000"`
+}
+
+// The reviewer replaces the manager of the employee, e.g. when the employee has no manager.
+type ReviewReviewerRequest struct {
+	ReviewerID string `json:"reviewer_id" validate:"required,uuid"`
+}
+
+type ReviewPeersRequest struct {
+	EmployeeIDs []string `json:"employee_ids" validate:"required,min=1,max=10,dive,uuid"`
+}
+
+type ReviewPeerFeedbackRequest struct {
+	Score   int    `json:"score" validate:"required,gte=1,lte=5"`
+	Comment string `json:"comment" validate:"required,max=5000"`
+}
+
+// The calibrated score replaces the manager score in the final rating.
+type ReviewCalibrationRequest struct {
+	Score float64 `json:"score" validate:"required,gte=1,lte=5"`
+	Note  string  `json:"note" validate:"max=1000"`
+}
+
+type PerformanceReviewQueryFilter struct {
+	EmployeeID   string `query:"employee_id" validate:"omitempty,uuid"`
+	ReviewerID   string `query:"reviewer_id" validate:"omitempty,uuid"`
+	DepartmentID string `query:"department_id" validate:"omitempty,uuid"`
+	Rating       string `query:"rating" validate:"omitempty,oneof=outstanding exceeds meets needs_improvement unsatisfactory"`
+
+	// Pagination is used for fetching the data by page. The default value is 1.
+	Page  string `query:"page"`
+	Limit string `query:"limit"`
+}
@@ -0,0 +1,96 @@
+package web
+
+import "time"
+
+type ReviewCycleResponse struct {
+	ID               string     `json:"id"`
+	Name             string     `json:"name"`
+	Description      string     `json:"description"`
+	PeriodStart      string     `json:"period_start"`
+	PeriodEnd        string     `json:"period_end"`
+	Status           string     `json:"status"`
+	PeerFeedback     bool       `json:"peer_feedback"`
+	PeerAnonymous    bool       `json:"peer_anonymous"`
+	TotalReviews     int        `json:"total_reviews"`
+	SelfSubmitted    int        `json:"self_submitted"`
+	ManagerSubmitted int        `json:"manager_submitted"`
+	CreatedBy        *string    `json:"created_by"`
+	ClosedAt         *time.Time `json:"closed_at"`
+	CreatedAt        time.Time  `json:"created_at"`
+	UpdatedAt        time.Time  `json:"updated_at"`
+}
+
+// The distribution of the ratings of the cycle, the rating is taken from the calibrated score or the manager score
+// before the cycle is closed.
+type ReviewRatingDistributionResponse struct {
+	Rating     string  `json:"rating"`
+	Count      int     `json:"count"`
+	Percentage float64 `json:"percentage"`
+}
+
+type PerformanceReviewResponse struct {
+	ID                 string     `json:"id"`
+	CycleID            string     `json:"cycle_id"`
+	CycleName          string     `json:"cycle_name"`
+	CycleStatus        string     `json:"cycle_status"`
+	EmployeeID         string     `json:"employee_id"`
+	EmployeeNumber     string     `json:"employee_number"`
+	EmployeeName       string     `json:"employee_name"`
+	ReviewerID         *string    `json:"reviewer_id"`
+	ReviewerName       *string    `json:"reviewer_name"`
+	SelfScore          *float64   `json:"self_score"`
+	SelfComment        string     `json:"self_comment"`
+	SelfSubmittedAt    *time.Time `json:"self_submitted_at"`
+	ManagerScore       *float64   `json:"manager_score"`
+	ManagerComment     string     `json:"manager_comment"`
+	ManagerSubmittedAt *time.Time `json:"manager_submitted_at"`
+	PeerScore          *float64   `json:"peer_score"`
+	CalibratedScore    *float64   `json:"calibrated_score"`
+	CalibrationNote    string     `json:"calibration_note"`
+	FinalScore         *float64   `json:"final_score"`
+	FinalRating        *string    `json:"final_rating"`
+	LockedAt           *time.Time `json:"locked_at"`
+	CreatedAt          time.Time  `json:"created_at"`
+	UpdatedAt          time.Time  `json:"updated_at"`
+	// the goals and the peers are only filled when a single review is fetched
+	Goals []ReviewGoalResponse `json:"goals,omitempty"`
+	Peers []ReviewPeerResponse `json:"peers,omitempty"`
+}
+
+type ReviewGoalResponse struct {
+	Seq            int    `json:"seq"`
+	Title          string `json:"title"`
+	Description    string `json:"description"`
+	Target         string `json:"target"`
+	Weight         int    `json:"weight"`
+	SelfScore      *int   `json:"self_score"`
+	SelfComment    string `json:"self_comment"`
+	ManagerScore   *int   `json:"manager_score"`
+	ManagerComment string `json:"manager_comment"`
+}
+
+// The reviewer of the peer feedback is hidden when the peers of the cycle are anonymous.
+type ReviewPeerResponse struct {
+	ReviewID     string     `json:"review_id"`
+	ReviewerID   *string    `json:"reviewer_id"`
+	ReviewerName *string    `json:"reviewer_name"`
+	Status       string     `json:"status"`
+	Score        *int       `json:"score"`
+	Comment      string     `json:"comment"`
+	RequestedAt  time.Time  `json:"requested_at"`
+	SubmittedAt  *time.Time `json:"submitted_at"`
+}
+
+// The peer feedback requested from the logged in user.
+type ReviewPeerRequestResponse struct {
+	ReviewID       string     `json:"review_id"`
+	CycleName      string     `json:"cycle_name"`
+	EmployeeID     string     `json:"employee_id"`
+	EmployeeNumber string     `json:"employee_number"`
+	EmployeeName   string     `json:"employee_name"`
+	Status         string     `json:"status"`
+	Score          *int       `json:"score"`
+	Comment        string     `json:"comment"`
+	RequestedAt    time.Time  `json:"requested_at"`
+	SubmittedAt    *time.Time `json:"submitted_at"`
+}
@@ -0,0 +1,18 @@
+package web
+
+type CreatePositionRequest struct {
+	DepartmentID string `json:"department_id" validate:"required,uuid"`
+	Title        string `json:"title" validate:"required"`
+	Level        int    `json:"level" validate:"min=0"`
+}
+
+// All fields are optional, only the filled fields will be updated.
+type UpdatePositionRequest struct {
+	DepartmentID string `json:"department_id" validate:"omitempty,uuid"`
+	Title        string `json:"title"`
+	Level        *int   `json:"level" validate:"omitempty,min=0"`
+}
+
+type PositionQueryFilter struct {
+	DepartmentID string `query:"department_id"`
+}
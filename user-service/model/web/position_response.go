@@ -0,0 +1,13 @@
+package web
+
+import "time"
+
+type PositionResponse struct {
+	ID             string    `json:"id"`
+	DepartmentID   string    `json:"department_id"`
+	DepartmentName string    `json:"department_name"`
+	Title          string    `json:"title"`
+	Level          int       `json:"level"`
+	CreatedAt      time.Time `json:"created_at"`
+	UpdatedAt      time.Time `json:"updated_at"`
+}
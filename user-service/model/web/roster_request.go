@@ -0,0 +1,30 @@
+package web
+
+// The roster is assigned to the employees or to every active employee of the department (and its
+// sub-departments). Either the shift or the pattern is assigned: the shift is planned on the working days
+// only, while the pattern is planned on every day including its days off.
+type AssignRosterRequest struct {
+	EmployeeIDs  []string `json:"employee_ids" validate:"omitempty,dive,uuid"`
+	DepartmentID string   `json:"department_id" validate:"omitempty,uuid"`
+	ShiftID      string   `json:"shift_id" validate:"omitempty,uuid"`
+	PatternID    string   `json:"pattern_id" validate:"omitempty,uuid"`
+	// StartDayIndex is the day of the pattern on the start date, it is used for staggering the rotation.
+	StartDayIndex int    `json:"start_day_index" validate:"min=0"`
+	StartDate     string `json:"start_date" validate:"required,datetime=2006-01-02"`
+	EndDate       string `json:"end_date" validate:"required,datetime=2006-01-02"`
+}
+
+// The planned roster of the employees or the department is removed, the assigned or default shift is used again.
+type ClearRosterRequest struct {
+	EmployeeIDs  []string `json:"employee_ids" validate:"omitempty,dive,uuid"`
+	DepartmentID string   `json:"department_id" validate:"omitempty,uuid"`
+	StartDate    string   `json:"start_date" validate:"required,datetime=2006-01-02"`
+	EndDate      string   `json:"end_date" validate:"required,datetime=2006-01-02"`
+}
+
+type RosterQueryFilter struct {
+	EmployeeID   string `query:"employee_id" validate:"omitempty,uuid"`
+	DepartmentID string `query:"department_id" validate:"omitempty,uuid"`
+	From         string `query:"from" validate:"required,datetime=2006-01-02"`
+	To           string `query:"to" validate:"required,datetime=2006-01-02"`
+}
@@ -0,0 +1,24 @@
+package web
+
+import "time"
+
+// The schedule of the employee on the date. The source is "roster" when it is planned, or "default" when
+// the assigned or default shift of the employee is used.
+type RosterResponse struct {
+	EmployeeID     string     `json:"employee_id"`
+	EmployeeNumber string     `json:"employee_number"`
+	EmployeeName   string     `json:"employee_name"`
+	Date           string     `json:"date"`
+	IsDayOff       bool       `json:"is_day_off"`
+	ShiftID        *string    `json:"shift_id"`
+	ShiftCode      string     `json:"shift_code"`
+	ShiftName      string     `json:"shift_name"`
+	StartAt        *time.Time `json:"start_at"`
+	EndAt          *time.Time `json:"end_at"`
+	BreakMinutes   int        `json:"break_minutes"`
+	Source         string     `json:"source"`
+}
+
+type RosterCalendarResponse struct {
+	URL string `json:"url"`
+}
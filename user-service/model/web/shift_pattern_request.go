@@ -0,0 +1,15 @@
+package web
+
+// The days of the pattern are the shift ids from the first day of the rotation, an empty id is a day off.
+// The pattern repeats after its last day, e.g. ["<morning>", "<morning>", "<night>", "<night>", "", ""].
+type CreateShiftPatternRequest struct {
+	Code string   `json:"code" validate:"required,max=32"`
+	Name string   `json:"name" validate:"required"`
+	Days []string `json:"days" validate:"required,min=1,max=366,dive,omitempty,uuid"`
+}
+
+// All fields are optional, only the filled fields will be updated. The days replace the current days.
+type UpdateShiftPatternRequest struct {
+	Name string   `json:"name"`
+	Days []string `json:"days" validate:"omitempty,max=366,dive,omitempty,uuid"`
+}
@@ -0,0 +1,20 @@
+package web
+
+import "time"
+
+type ShiftPatternResponse struct {
+	ID        string                    `json:"id"`
+	Code      string                    `json:"code"`
+	Name      string                    `json:"name"`
+	Days      []ShiftPatternDayResponse `json:"days"`
+	CreatedAt time.Time                 `json:"created_at"`
+	UpdatedAt time.Time                 `json:"updated_at"`
+}
+
+type ShiftPatternDayResponse struct {
+	DayIndex  int     `json:"day_index"`
+	ShiftID   *string `json:"shift_id"`
+	ShiftCode string  `json:"shift_code"`
+	ShiftName string  `json:"shift_name"`
+	IsDayOff  bool    `json:"is_day_off"`
+}
@@ -0,0 +1,31 @@
+package web
+
+// The start and end time use the "15:04" format, a shift crosses midnight when the end time is before the start time.
+type CreateShiftRequest struct {
+	Code                       string `json:"code" validate:"required,max=32"`
+	Name                       string `json:"name" validate:"required"`
+	StartTime                  string `json:"start_time" validate:"required,datetime=15:04"`
+	EndTime                    string `json:"end_time" validate:"required,datetime=15:04"`
+	BreakMinutes               int    `json:"break_minutes" validate:"min=0"`
+	LateToleranceMinutes       int    `json:"late_tolerance_minutes" validate:"min=0"`
+	EarlyLeaveToleranceMinutes int    `json:"early_leave_tolerance_minutes" validate:"min=0"`
+	MinOvertimeMinutes         int    `json:"min_overtime_minutes" validate:"min=0"`
+	IsDefault                  bool   `json:"is_default"`
+}
+
+// All fields are optional, only the filled fields will be updated.
+type UpdateShiftRequest struct {
+	Name                       string `json:"name"`
+	StartTime                  string `json:"start_time" validate:"omitempty,datetime=15:04"`
+	EndTime                    string `json:"end_time" validate:"omitempty,datetime=15:04"`
+	BreakMinutes               *int   `json:"break_minutes" validate:"omitempty,min=0"`
+	LateToleranceMinutes       *int   `json:"late_tolerance_minutes" validate:"omitempty,min=0"`
+	EarlyLeaveToleranceMinutes *int   `json:"early_leave_tolerance_minutes" validate:"omitempty,min=0"`
+	MinOvertimeMinutes         *int   `json:"min_overtime_minutes" validate:"omitempty,min=0"`
+	IsDefault                  *bool  `json:"is_default"`
+}
+
+// The request body of assigning the shift to the employees.
+type AssignShiftRequest struct {
+	EmployeeIDs []string `json:"employee_ids" validate:"required,min=1,dive,uuid"`
+}
@@ -0,0 +1,18 @@
+package web
+
+import "time"
+
+type ShiftResponse struct {
+	ID                         string    `json:"id"`
+	Code                       string    `json:"code"`
+	Name                       string    `json:"name"`
+	StartTime                  string    `json:"start_time"`
+	EndTime                    string    `json:"end_time"`
+	BreakMinutes               int       `json:"break_minutes"`
+	LateToleranceMinutes       int       `json:"late_tolerance_minutes"`
+	EarlyLeaveToleranceMinutes int       `json:"early_leave_tolerance_minutes"`
+	MinOvertimeMinutes         int       `json:"min_overtime_minutes"`
+	IsDefault                  bool      `json:"is_default"`
+	CreatedAt                  time.Time `json:"created_at"`
+	UpdatedAt                  time.Time `json:"updated_at"`
+}
@@ -0,0 +1,23 @@
+package web
+
+// The requester and the colleague exchange their shifts on the date.
+type CreateShiftSwapRequest struct {
+	ColleagueID string `json:"colleague_id" validate:"required,uuid"`
+	Date        string `json:"date" validate:"required,datetime=2006-01-02"`
+	Reason      string `json:"reason" validate:"max=255"`
+}
+
+// The request body of approving or rejecting a shift swap request.
+type DecideShiftSwapRequest struct {
+	Note string `json:"note" validate:"max=255"`
+}
+
+type ShiftSwapQueryFilter struct {
+	Status string `query:"status"`
+	From   string `query:"from" validate:"omitempty,datetime=2006-01-02"`
+	To     string `query:"to" validate:"omitempty,datetime=2006-01-02"`
+
+	// Pagination is used for fetching the data by page. The default value is 1.
+	Page  string `query:"page"`
+	Limit string `query:"limit"`
+}
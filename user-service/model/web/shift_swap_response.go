@@ -0,0 +1,25 @@
+package web
+
+import "time"
+
+type ShiftSwapResponse struct {
+	ID                 string     `json:"id"`
+	RequesterID        string     `json:"requester_id"`
+	RequesterName      string     `json:"requester_name"`
+	RequesterShiftID   *string    `json:"requester_shift_id"`
+	RequesterShiftName string     `json:"requester_shift_name"`
+	ColleagueID        string     `json:"colleague_id"`
+	ColleagueName      string     `json:"colleague_name"`
+	ColleagueShiftID   *string    `json:"colleague_shift_id"`
+	ColleagueShiftName string     `json:"colleague_shift_name"`
+	Date               string     `json:"date"`
+	Reason             string     `json:"reason"`
+	Status             string     `json:"status"`
+	ApproverID         *string    `json:"approver_id"`
+	ApproverName       string     `json:"approver_name"`
+	DecisionNote       string     `json:"decision_note"`
+	DecidedAt          *time.Time `json:"decided_at"`
+	CancelledAt        *time.Time `json:"cancelled_at"`
+	CreatedAt          time.Time  `json:"created_at"`
+	UpdatedAt          time.Time  `json:"updated_at"`
+}
@@ -0,0 +1,71 @@
+package web
+
+// The requirements replace the positions which must take the training, the employees of the positions are enrolled
+// by the scheduler. The certificate validity is 0 when the certificate doesn't expire.
+type TrainingRequest struct {
+	Code                      string                       `json:"code" validate:"required,max=50"`
+	Name                      string                       `json:"name" validate:"required,max=255"`
+	Description               string                       `json:"description"`
+	Provider                  string                       `json:"provider" validate:"max=255"`
+	DurationHours             int                          `json:"duration_hours" validate:"gte=0,lte=1000"`
+	CertificateRequired       bool                         `json:"certificate_required"`
+	CertificateValidityMonths int                          `json:"certificate_validity_months" validate:"gte=0,lte=120"`
+	Requirements              []TrainingRequirementRequest `json:"requirements" validate:"dive"`
+}
+
+// The employees of the position must complete the training within the due days after they are enrolled.
+type TrainingRequirementRequest struct {
+	PositionID string `json:"position_id" validate:"required,uuid"`
+	DueDays    int    `json:"due_days" validate:"required,gte=1,lte=365"`
+}
+
+type TrainingQueryFilter struct {
+	Search     string `query:"search"`
+	PositionID string `query:"position_id" validate:"omitempty,uuid"`
+
+	// Pagination is used for fetching the data by page. The default value is 1.
+	Page  string `query:"page"`
+	Limit string `query:"limit"`
+}
+
+// Enroll the employees in the training, the employees who are already enrolled are skipped.
+type TrainingEnrollmentRequest struct {
+	TrainingID  string   `json:"training_id" validate:"required,uuid"`
+	EmployeeIDs []string `json:"employee_ids" validate:"required,min=1,max=500,dive,uuid"`
+	DueDate     string   `json:"due_date" validate:"omitempty,datetime=2006-01-02"`
+}
+
+// The enrollment is completed with a multipart form, the certificate is uploaded in the 'file' field. The
+// completion date is today when it is not filled, and the expiry date of the certificate is computed from the
+// validity of the training when it is not filled.
+type CompleteTrainingEnrollmentRequest struct {
+	CompletedOn       string `form:"completed_on" validate:"omitempty,datetime=2006-01-02"`
+	CertificateNumber string `form:"certificate_number" validate:"max=100"`
+	ExpiryDate        string `form:"expiry_date" validate:"omitempty,datetime=2006-01-02"`
+	Note              string `form:"note" validate:"max=255"`
+}
+
+type CancelTrainingEnrollmentRequest struct {
+	Note string `json:"note" validate:"max=255"`
+}
+
+type TrainingEnrollmentQueryFilter struct {
+	TrainingID   string `query:"training_id" validate:"omitempty,uuid"`
+	EmployeeID   string `query:"employee_id" validate:"omitempty,uuid"`
+	DepartmentID string `query:"department_id" validate:"omitempty,uuid"`
+	Status       string `query:"status" validate:"omitempty,oneof=enrolled completed cancelled"`
+
+	// Pagination is used for fetching the data by page. The default value is 1.
+	Page  string `query:"page"`
+	Limit string `query:"limit"`
+}
+
+// The compliance report lists the mandatory enrollments which are not completed by the due date.
+type TrainingComplianceQueryFilter struct {
+	TrainingID   string `query:"training_id" validate:"omitempty,uuid"`
+	DepartmentID string `query:"department_id" validate:"omitempty,uuid"`
+
+	// Pagination is used for fetching the data by page. The default value is 1.
+	Page  string `query:"page"`
+	Limit string `query:"limit"`
+}
@@ -0,0 +1,63 @@
+package web
+
+import "time"
+
+type TrainingResponse struct {
+	ID                        string    `json:"id"`
+	Code                      string    `json:"code"`
+	Name                      string    `json:"name"`
+	Description               string    `json:"description"`
+	Provider                  string    `json:"provider"`
+	DurationHours             int       `json:"duration_hours"`
+	CertificateRequired       bool      `json:"certificate_required"`
+	CertificateValidityMonths int       `json:"certificate_validity_months"`
+	CreatedAt                 time.Time `json:"created_at"`
+	UpdatedAt                 time.Time `json:"updated_at"`
+	// the requirements are only filled when a single training is fetched
+	Requirements []TrainingRequirementResponse `json:"requirements,omitempty"`
+}
+
+type TrainingRequirementResponse struct {
+	PositionID string `json:"position_id"`
+	Position   string `json:"position"`
+	DueDays    int    `json:"due_days"`
+}
+
+type TrainingEnrollmentResponse struct {
+	ID                    string    `json:"id"`
+	TrainingID            string    `json:"training_id"`
+	TrainingCode          string    `json:"training_code"`
+	TrainingName          string    `json:"training_name"`
+	EmployeeID            string    `json:"employee_id"`
+	EmployeeNumber        string    `json:"employee_number"`
+	EmployeeName          string    `json:"employee_name"`
+	Department            string    `json:"department"`
+	Position              string    `json:"position"`
+	Mandatory             bool      `json:"mandatory"`
+	Status                string    `json:"status"`
+	DueDate               *string   `json:"due_date"`
+	Overdue               bool      `json:"overdue"`
+	CompletedOn           *string   `json:"completed_on"`
+	Note                  string    `json:"note"`
+	CertificateDocumentID *string   `json:"certificate_document_id"`
+	CertificateExpiryDate *string   `json:"certificate_expiry_date"`
+	EnrolledBy            *string   `json:"enrolled_by"`
+	CompletedBy           *string   `json:"completed_by"`
+	CreatedAt             time.Time `json:"created_at"`
+	UpdatedAt             time.Time `json:"updated_at"`
+}
+
+// The mandatory enrollment which is not completed by the due date.
+type TrainingComplianceResponse struct {
+	EnrollmentID   string `json:"enrollment_id"`
+	TrainingID     string `json:"training_id"`
+	TrainingCode   string `json:"training_code"`
+	TrainingName   string `json:"training_name"`
+	EmployeeID     string `json:"employee_id"`
+	EmployeeNumber string `json:"employee_number"`
+	EmployeeName   string `json:"employee_name"`
+	Department     string `json:"department"`
+	Position       string `json:"position"`
+	DueDate        string `json:"due_date"`
+	DaysOverdue    int    `json:"days_overdue"`
+}
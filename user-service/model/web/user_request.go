@@ -3,7 +3,7 @@ package web
 type CreateUserRequest struct {
 	Name     string `json:"name" validate:"required"`
 	Email    string `json:"email" validate:"required,email"`
-	Password string `json:"password" validate:"required,min:6"`
+	Password string `json:"password" validate:"required,min=6"`
 	Phone    string `json:"phone" validate:"required"`
 }
 
@@ -14,7 +14,7 @@ type UpdateUserRequest struct {
 }
 
 type UpdateUserPasswordRequest struct {
-	Password string `json:"password" validate:"min:6"`
+	Password string `json:"password" validate:"min=6"`
 }
 
 type ForgetPassword struct {
@@ -45,4 +45,16 @@ func (q *UserQueryFilter) IsNotEmpty() bool {
 	}
 
 	return true
-}
\ No newline at end of file
+}
+
+// The request form of importing the users, the file is uploaded as the 'file' of the multipart form. The file is a CSV
+// or an XLSX file whose first row is the header of the 'name', 'email', 'phone' and 'password' columns, every row is
+// validated as a CreateUserRequest. The rows are only validated and nothing is created when it is a dry run.
+type ImportUserRequest struct {
+	DryRun bool `form:"dry_run"`
+}
+
+// The users are exported with the filters of the UserQueryFilter, the default format is CSV.
+type ExportUserRequest struct {
+	Format string `query:"format" validate:"omitempty,oneof=csv xlsx"`
+}
@@ -5,4 +5,23 @@ type UserResponse struct {
 	Name  string `json:"name"`
 	Email string `json:"email"`
 	Phone string `json:"phone"`
+	// the URL of the profile photo, it is null when the user has no photo
+	AvatarURL *string `json:"avatar_url"`
+}
+
+// The result of importing the users, the users are created only when every row is valid. The users of a dry run
+// are not created and have no id.
+type ImportUserResponse struct {
+	DryRun   bool                 `json:"dry_run"`
+	Total    int                  `json:"total"`
+	Imported int                  `json:"imported"`
+	Errors   []ImportUserRowError `json:"errors"`
+	Users    []UserResponse       `json:"users"`
+}
+
+// The errors of a row of the imported file, the rows are numbered as in the spreadsheet where the header is row 1.
+type ImportUserRowError struct {
+	Row    int      `json:"row"`
+	Email  string   `json:"email"`
+	Errors []string `json:"errors"`
 }
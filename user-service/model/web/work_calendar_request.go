@@ -0,0 +1,27 @@
+package web
+
+// The weekend days use the weekday numbers (0 is Sunday, 6 is Saturday), Saturday and Sunday are used when it is empty.
+type CreateWorkCalendarRequest struct {
+	Code        string `json:"code" validate:"required,max=32"`
+	Name        string `json:"name" validate:"required"`
+	WeekendDays []int  `json:"weekend_days" validate:"omitempty,max=6,dive,min=0,max=6"`
+	IsDefault   bool   `json:"is_default"`
+}
+
+// All fields are optional, only the filled fields will be updated. An empty weekend days array makes every day
+// of the week a working day.
+type UpdateWorkCalendarRequest struct {
+	Name        string `json:"name"`
+	WeekendDays []int  `json:"weekend_days" validate:"omitempty,max=6,dive,min=0,max=6"`
+	IsDefault   *bool  `json:"is_default"`
+}
+
+// The working days are counted with the calendar, the calendar of the office location or the calendar of the
+// employee, in that order. The default calendar is used when none of them is filled.
+type WorkingDaysQueryFilter struct {
+	From             string `query:"from" validate:"required,datetime=2006-01-02"`
+	To               string `query:"to" validate:"required,datetime=2006-01-02"`
+	WorkCalendarID   string `query:"work_calendar_id" validate:"omitempty,uuid"`
+	OfficeLocationID string `query:"office_location_id" validate:"omitempty,uuid"`
+	EmployeeID       string `query:"employee_id" validate:"omitempty,uuid"`
+}
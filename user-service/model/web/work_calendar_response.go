@@ -0,0 +1,25 @@
+package web
+
+import "time"
+
+type WorkCalendarResponse struct {
+	ID          string    `json:"id"`
+	Code        string    `json:"code"`
+	Name        string    `json:"name"`
+	WeekendDays []int     `json:"weekend_days"`
+	IsDefault   bool      `json:"is_default"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+type WorkingDaysResponse struct {
+	WorkCalendarID   string `json:"work_calendar_id"`
+	WorkCalendarName string `json:"work_calendar_name"`
+	From             string `json:"from"`
+	To               string `json:"to"`
+	CalendarDays     int    `json:"calendar_days"`
+	WorkingDays      int    `json:"working_days"`
+	DaysOff          int    `json:"days_off"`
+	// the holidays and the working day overrides of the calendar between the dates
+	Holidays []HolidayResponse `json:"holidays"`
+}
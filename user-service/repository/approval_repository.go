@@ -0,0 +1,369 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/iqbaludinm/hr-microservice/user-service/model/domain"
+	"github.com/iqbaludinm/hr-microservice/user-service/repository/query"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type ApprovalRepository interface {
+	CreateWorkflow(c context.Context, workflow domain.ApprovalWorkflow) error
+	UpdateWorkflow(c context.Context, workflow domain.ApprovalWorkflow) error
+	DeleteWorkflow(c context.Context, id string) error
+	CreateRequest(c context.Context, request domain.ApprovalRequest) error
+	UpdateRequest(c context.Context, id string, update func(request *domain.ApprovalRequest) ([]domain.ApprovalAction, error)) error
+	FindAllWorkflow(c context.Context, filter domain.ApprovalWorkflowQueryFilter) ([]domain.ApprovalWorkflow, error)
+	CountAllWorkflow(c context.Context, filter domain.ApprovalWorkflowQueryFilter) (int, error)
+	FindWorkflowById(c context.Context, id string) (domain.ApprovalWorkflow, error)
+	FindActiveWorkflow(c context.Context, entityType string) (domain.ApprovalWorkflow, error)
+	FindAllRequest(c context.Context, filter domain.ApprovalQueryFilter) ([]domain.ApprovalRequest, error)
+	CountAllRequest(c context.Context, filter domain.ApprovalQueryFilter) (int, error)
+	FindRequestById(c context.Context, id string) (domain.ApprovalRequest, error)
+	FindOverdueRequestIds(c context.Context, now time.Time) ([]string, error)
+	CreateDelegation(c context.Context, delegation domain.ApprovalDelegation) error
+	CancelDelegation(c context.Context, delegation domain.ApprovalDelegation) error
+	FindAllDelegation(c context.Context, filter domain.ApprovalDelegationQueryFilter) ([]domain.ApprovalDelegation, error)
+	CountAllDelegation(c context.Context, filter domain.ApprovalDelegationQueryFilter) (int, error)
+	FindDelegationById(c context.Context, id string) (domain.ApprovalDelegation, error)
+}
+
+type approvalRepository struct {
+	db            Store
+	ApprovalQuery query.ApprovalQuery
+}
+
+func NewApproval(db Store, q query.ApprovalQuery) ApprovalRepository {
+	return &approvalRepository{
+		db:            db,
+		ApprovalQuery: q,
+	}
+}
+
+// create the approval workflow with its steps
+func (r *approvalRepository) CreateWorkflow(c context.Context, workflow domain.ApprovalWorkflow) error {
+	var err error
+
+	// create transaction to create approval workflow
+	err = r.db.WithTransaction(c, func(tx pgx.Tx) error {
+		// create approval workflow, if error will rollback
+		if err = r.ApprovalQuery.CreateWorkflow(c, tx, workflow); err != nil {
+			return err
+		}
+		return r.createSteps(c, tx, workflow)
+	})
+
+	return err
+}
+
+// update the approval workflow, the steps are replaced
+func (r *approvalRepository) UpdateWorkflow(c context.Context, workflow domain.ApprovalWorkflow) error {
+	var err error
+
+	// create transaction to update approval workflow
+	err = r.db.WithTransaction(c, func(tx pgx.Tx) error {
+		// update approval workflow by id, if error will rollback
+		if err = r.ApprovalQuery.UpdateWorkflow(c, tx, workflow); err != nil {
+			return err
+		}
+		if err = r.ApprovalQuery.DeleteSteps(c, tx, workflow.ID); err != nil {
+			return err
+		}
+		return r.createSteps(c, tx, workflow)
+	})
+
+	return err
+}
+
+func (r *approvalRepository) createSteps(c context.Context, tx pgx.Tx, workflow domain.ApprovalWorkflow) error {
+	for _, step := range workflow.Steps {
+		step.WorkflowID = workflow.ID
+		if err := r.ApprovalQuery.CreateStep(c, tx, step); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (r *approvalRepository) DeleteWorkflow(c context.Context, id string) error {
+	var err error
+
+	// create transaction to delete approval workflow
+	err = r.db.WithTransaction(c, func(tx pgx.Tx) error {
+		// delete approval workflow by id, if error will rollback
+		if err = r.ApprovalQuery.DeleteWorkflow(c, tx, id); err != nil {
+			return err
+		}
+		return nil
+	})
+
+	return err
+}
+
+// create the approval request with its tasks and its actions
+func (r *approvalRepository) CreateRequest(c context.Context, request domain.ApprovalRequest) error {
+	var err error
+
+	// create transaction to create approval request
+	err = r.db.WithTransaction(c, func(tx pgx.Tx) error {
+		// create approval request, if error will rollback
+		if err = r.ApprovalQuery.CreateRequest(c, tx, request); err != nil {
+			return err
+		}
+		// create the tasks of the request, if error will rollback
+		for _, task := range request.Tasks {
+			if err = r.ApprovalQuery.CreateTask(c, tx, task); err != nil {
+				return err
+			}
+		}
+		for _, action := range request.Actions {
+			if err = r.ApprovalQuery.CreateAction(c, tx, action); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+
+	return err
+}
+
+// update the approval request while it is locked, so the decisions and the escalations of the request are made one
+// at a time on its latest tasks. The update returns the new actions, the status of the request, the tasks it changed
+// and the actions are saved. Nothing is saved when there is no new action.
+func (r *approvalRepository) UpdateRequest(c context.Context, id string, update func(request *domain.ApprovalRequest) ([]domain.ApprovalAction, error)) error {
+	var err error
+
+	// create transaction to save the progress of approval request
+	err = r.db.WithTransaction(c, func(tx pgx.Tx) error {
+		// lock approval request with its tasks, if error will rollback
+		request, err := r.ApprovalQuery.FindRequestByIdForUpdate(c, tx, id)
+		if err != nil {
+			return err
+		}
+		if request.Tasks, err = r.ApprovalQuery.FindTasks(c, tx, id); err != nil {
+			return err
+		}
+		loaded := map[string]domain.ApprovalTask{}
+		for _, task := range request.Tasks {
+			loaded[task.ID] = task
+		}
+
+		actions, err := update(&request)
+		if err != nil || len(actions) == 0 {
+			return err
+		}
+
+		// update the status of approval request, if error will rollback
+		if err = r.ApprovalQuery.UpdateRequestStatus(c, tx, request); err != nil {
+			return err
+		}
+		// update the changed tasks of the request, if error will rollback
+		for _, task := range request.Tasks {
+			if task.UpdatedAt.Equal(loaded[task.ID].UpdatedAt) {
+				continue
+			}
+			if err = r.ApprovalQuery.UpdateTask(c, tx, task, loaded[task.ID].Status); err != nil {
+				return err
+			}
+		}
+		for _, action := range actions {
+			if err = r.ApprovalQuery.CreateAction(c, tx, action); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+
+	return err
+}
+
+func (r *approvalRepository) FindAllWorkflow(c context.Context, filter domain.ApprovalWorkflowQueryFilter) ([]domain.ApprovalWorkflow, error) {
+	var workflows []domain.ApprovalWorkflow
+	var err error
+
+	// get all approval workflows without transaction.
+	err = r.db.WithoutTransaction(c, func(db *pgxpool.Pool) error {
+		workflows, err = r.ApprovalQuery.FindAllWorkflow(c, db, filter)
+		return err
+	})
+
+	return workflows, err
+}
+
+func (r *approvalRepository) CountAllWorkflow(c context.Context, filter domain.ApprovalWorkflowQueryFilter) (int, error) {
+	var count int
+	var err error
+
+	// count all approval workflows without transaction.
+	err = r.db.WithoutTransaction(c, func(db *pgxpool.Pool) error {
+		count, err = r.ApprovalQuery.CountAllWorkflow(c, db, filter)
+		return err
+	})
+
+	return count, err
+}
+
+// find the approval workflow by id with its steps
+func (r *approvalRepository) FindWorkflowById(c context.Context, id string) (domain.ApprovalWorkflow, error) {
+	var workflow domain.ApprovalWorkflow
+	var err error
+
+	// get approval workflow by id without transaction.
+	err = r.db.WithoutTransaction(c, func(db *pgxpool.Pool) error {
+		workflow, err = r.ApprovalQuery.FindWorkflowById(c, db, id)
+		if err != nil {
+			return err
+		}
+		workflow.Steps, err = r.ApprovalQuery.FindSteps(c, db, id)
+		return err
+	})
+
+	return workflow, err
+}
+
+// find the active approval workflow of the entity type with its steps
+func (r *approvalRepository) FindActiveWorkflow(c context.Context, entityType string) (domain.ApprovalWorkflow, error) {
+	var workflow domain.ApprovalWorkflow
+	var err error
+
+	// get the active approval workflow without transaction.
+	err = r.db.WithoutTransaction(c, func(db *pgxpool.Pool) error {
+		workflow, err = r.ApprovalQuery.FindActiveWorkflow(c, db, entityType)
+		if err != nil {
+			return err
+		}
+		workflow.Steps, err = r.ApprovalQuery.FindSteps(c, db, workflow.ID)
+		return err
+	})
+
+	return workflow, err
+}
+
+func (r *approvalRepository) FindAllRequest(c context.Context, filter domain.ApprovalQueryFilter) ([]domain.ApprovalRequest, error) {
+	var requests []domain.ApprovalRequest
+	var err error
+
+	// get all approval requests without transaction.
+	err = r.db.WithoutTransaction(c, func(db *pgxpool.Pool) error {
+		requests, err = r.ApprovalQuery.FindAllRequest(c, db, filter)
+		return err
+	})
+
+	return requests, err
+}
+
+func (r *approvalRepository) CountAllRequest(c context.Context, filter domain.ApprovalQueryFilter) (int, error) {
+	var count int
+	var err error
+
+	// count all approval requests without transaction.
+	err = r.db.WithoutTransaction(c, func(db *pgxpool.Pool) error {
+		count, err = r.ApprovalQuery.CountAllRequest(c, db, filter)
+		return err
+	})
+
+	return count, err
+}
+
+// find the approval request by id with its tasks and its actions
+func (r *approvalRepository) FindRequestById(c context.Context, id string) (domain.ApprovalRequest, error) {
+	var request domain.ApprovalRequest
+	var err error
+
+	// get approval request by id without transaction.
+	err = r.db.WithoutTransaction(c, func(db *pgxpool.Pool) error {
+		request, err = r.ApprovalQuery.FindRequestById(c, db, id)
+		if err != nil {
+			return err
+		}
+		request.Tasks, err = r.ApprovalQuery.FindTasks(c, db, id)
+		if err != nil {
+			return err
+		}
+		request.Actions, err = r.ApprovalQuery.FindActions(c, db, id)
+		return err
+	})
+
+	return request, err
+}
+
+func (r *approvalRepository) FindOverdueRequestIds(c context.Context, now time.Time) ([]string, error) {
+	var ids []string
+	var err error
+
+	// get the id of the requests with an overdue task without transaction.
+	err = r.db.WithoutTransaction(c, func(db *pgxpool.Pool) error {
+		ids, err = r.ApprovalQuery.FindOverdueRequestIds(c, db, now)
+		return err
+	})
+
+	return ids, err
+}
+
+func (r *approvalRepository) CreateDelegation(c context.Context, delegation domain.ApprovalDelegation) error {
+	var err error
+
+	// create transaction to create approval delegation
+	err = r.db.WithTransaction(c, func(tx pgx.Tx) error {
+		// create approval delegation, if error will rollback
+		err = r.ApprovalQuery.CreateDelegation(c, tx, delegation)
+		return err
+	})
+
+	return err
+}
+
+func (r *approvalRepository) CancelDelegation(c context.Context, delegation domain.ApprovalDelegation) error {
+	var err error
+
+	// create transaction to cancel approval delegation
+	err = r.db.WithTransaction(c, func(tx pgx.Tx) error {
+		// cancel approval delegation, if error will rollback
+		err = r.ApprovalQuery.CancelDelegation(c, tx, delegation)
+		return err
+	})
+
+	return err
+}
+
+func (r *approvalRepository) FindAllDelegation(c context.Context, filter domain.ApprovalDelegationQueryFilter) ([]domain.ApprovalDelegation, error) {
+	var delegations []domain.ApprovalDelegation
+	var err error
+
+	// get all approval delegations without transaction.
+	err = r.db.WithoutTransaction(c, func(db *pgxpool.Pool) error {
+		delegations, err = r.ApprovalQuery.FindAllDelegation(c, db, filter)
+		return err
+	})
+
+	return delegations, err
+}
+
+func (r *approvalRepository) CountAllDelegation(c context.Context, filter domain.ApprovalDelegationQueryFilter) (int, error) {
+	var count int
+	var err error
+
+	// count all approval delegations without transaction.
+	err = r.db.WithoutTransaction(c, func(db *pgxpool.Pool) error {
+		count, err = r.ApprovalQuery.CountAllDelegation(c, db, filter)
+		return err
+	})
+
+	return count, err
+}
+
+func (r *approvalRepository) FindDelegationById(c context.Context, id string) (domain.ApprovalDelegation, error) {
+	var delegation domain.ApprovalDelegation
+	var err error
+
+	// get approval delegation by id without transaction.
+	err = r.db.WithoutTransaction(c, func(db *pgxpool.Pool) error {
+		delegation, err = r.ApprovalQuery.FindDelegationById(c, db, id)
+		return err
+	})
+
+	return delegation, err
+}
@@ -0,0 +1,235 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/iqbaludinm/hr-microservice/user-service/model/domain"
+	"github.com/iqbaludinm/hr-microservice/user-service/repository/query"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type AssetRepository interface {
+	CreateAsset(c context.Context, asset domain.Asset) error
+	UpdateAsset(c context.Context, id string, asset domain.Asset) error
+	Delete(c context.Context, id string) error
+	Assign(c context.Context, asset domain.Asset, assignment domain.AssetAssignment) error
+	Return(c context.Context, asset domain.Asset, assignment domain.AssetAssignment) error
+	CreateHandover(c context.Context, handover *domain.Handover, assignment domain.AssetAssignment) error
+	FindAllAsset(c context.Context, filter domain.AssetQueryFilter) ([]domain.Asset, error)
+	CountAllAsset(c context.Context, filter domain.AssetQueryFilter) (int, error)
+	FindById(c context.Context, id string) (domain.Asset, error)
+	CountAssignedAsset(c context.Context, employeeID string) (int, error)
+	FindAssignments(c context.Context, assetID string) ([]domain.AssetAssignment, error)
+	FindAssignmentById(c context.Context, assetID, id string) (domain.AssetAssignment, error)
+}
+
+type assetRepository struct {
+	db            Store
+	AssetQuery    query.AssetQuery
+	HandoverQuery query.HandoverQuery
+}
+
+func NewAsset(db Store, q query.AssetQuery, handoverQuery query.HandoverQuery) AssetRepository {
+	return &assetRepository{
+		db:            db,
+		AssetQuery:    q,
+		HandoverQuery: handoverQuery,
+	}
+}
+
+func (r *assetRepository) CreateAsset(c context.Context, asset domain.Asset) error {
+	var err error
+
+	// create transaction to create asset
+	err = r.db.WithTransaction(c, func(tx pgx.Tx) error {
+		// create asset, if error will rollback
+		if err = r.AssetQuery.CreateAsset(c, tx, asset); err != nil {
+			return err
+		}
+		return nil
+	})
+
+	return err
+}
+
+func (r *assetRepository) UpdateAsset(c context.Context, id string, asset domain.Asset) error {
+	var err error
+
+	// create transaction to update asset
+	err = r.db.WithTransaction(c, func(tx pgx.Tx) error {
+		// update asset by id, if error will rollback
+		if err = r.AssetQuery.UpdateAsset(c, tx, id, asset); err != nil {
+			return err
+		}
+		return nil
+	})
+
+	return err
+}
+
+func (r *assetRepository) Delete(c context.Context, id string) error {
+	var err error
+
+	// create transaction to delete asset
+	err = r.db.WithTransaction(c, func(tx pgx.Tx) error {
+		// delete asset by id, if error will rollback
+		if err = r.AssetQuery.Delete(c, tx, id); err != nil {
+			return err
+		}
+		return nil
+	})
+
+	return err
+}
+
+// assign the asset to the employee, the assignment is opened together with updating the holder of the asset
+func (r *assetRepository) Assign(c context.Context, asset domain.Asset, assignment domain.AssetAssignment) error {
+	var err error
+
+	// create transaction to assign asset
+	err = r.db.WithTransaction(c, func(tx pgx.Tx) error {
+		// update the holder of the asset, if error will rollback
+		if err = r.AssetQuery.UpdateHolder(c, tx, asset.ID, asset); err != nil {
+			return err
+		}
+		// open the assignment, if error will rollback
+		if err = r.AssetQuery.CreateAssignment(c, tx, assignment); err != nil {
+			return err
+		}
+		return nil
+	})
+
+	return err
+}
+
+// return the asset from the employee, the assignment is closed together with clearing the holder of the asset
+func (r *assetRepository) Return(c context.Context, asset domain.Asset, assignment domain.AssetAssignment) error {
+	var err error
+
+	// create transaction to return asset
+	err = r.db.WithTransaction(c, func(tx pgx.Tx) error {
+		// clear the holder of the asset, if error will rollback
+		if err = r.AssetQuery.UpdateHolder(c, tx, asset.ID, asset); err != nil {
+			return err
+		}
+		// close the assignment, if error will rollback
+		if err = r.AssetQuery.ReturnAssignment(c, tx, assignment.ID, assignment); err != nil {
+			return err
+		}
+		return nil
+	})
+
+	return err
+}
+
+// create the handover document of the assignment, the handover is linked to the assignment in the same
+// transaction
+func (r *assetRepository) CreateHandover(c context.Context, handover *domain.Handover, assignment domain.AssetAssignment) error {
+	var err error
+
+	// create transaction to create asset handover
+	err = r.db.WithTransaction(c, func(tx pgx.Tx) error {
+		// create handover, if error will rollback
+		if err = createHandover(c, tx, r.HandoverQuery, handover); err != nil {
+			return err
+		}
+		// link the handover to the assignment, if error will rollback
+		if err = r.AssetQuery.UpdateAssignmentHandover(c, tx, assignment.ID, assignment); err != nil {
+			return err
+		}
+		return nil
+	})
+
+	return err
+}
+
+func (r *assetRepository) FindAllAsset(c context.Context, filter domain.AssetQueryFilter) ([]domain.Asset, error) {
+	var assets []domain.Asset
+	var err error
+
+	// get assets without transaction.
+	err = r.db.WithoutTransaction(c, func(db *pgxpool.Pool) error {
+		if assets, err = r.AssetQuery.FindAllAsset(c, db, filter); err != nil {
+			return err
+		}
+		return nil
+	})
+
+	return assets, err
+}
+
+func (r *assetRepository) CountAllAsset(c context.Context, filter domain.AssetQueryFilter) (int, error) {
+	var count int
+	var err error
+
+	// count assets without transaction.
+	err = r.db.WithoutTransaction(c, func(db *pgxpool.Pool) error {
+		if count, err = r.AssetQuery.CountAllAsset(c, db, filter); err != nil {
+			return err
+		}
+		return nil
+	})
+
+	return count, err
+}
+
+func (r *assetRepository) FindById(c context.Context, id string) (domain.Asset, error) {
+	var asset domain.Asset
+	var err error
+
+	// get asset by id without transaction.
+	err = r.db.WithoutTransaction(c, func(db *pgxpool.Pool) error {
+		if asset, err = r.AssetQuery.FindById(c, db, id); err != nil {
+			return err
+		}
+		return nil
+	})
+
+	return asset, err
+}
+
+func (r *assetRepository) CountAssignedAsset(c context.Context, employeeID string) (int, error) {
+	var count int
+	var err error
+
+	// count the assets held by the employee without transaction.
+	err = r.db.WithoutTransaction(c, func(db *pgxpool.Pool) error {
+		if count, err = r.AssetQuery.CountAssignedAsset(c, db, employeeID); err != nil {
+			return err
+		}
+		return nil
+	})
+
+	return count, err
+}
+
+func (r *assetRepository) FindAssignments(c context.Context, assetID string) ([]domain.AssetAssignment, error) {
+	var assignments []domain.AssetAssignment
+	var err error
+
+	// get the assignment history of the asset without transaction.
+	err = r.db.WithoutTransaction(c, func(db *pgxpool.Pool) error {
+		if assignments, err = r.AssetQuery.FindAssignments(c, db, assetID); err != nil {
+			return err
+		}
+		return nil
+	})
+
+	return assignments, err
+}
+
+func (r *assetRepository) FindAssignmentById(c context.Context, assetID, id string) (domain.AssetAssignment, error) {
+	var assignment domain.AssetAssignment
+	var err error
+
+	// get the assignment of the asset by id without transaction.
+	err = r.db.WithoutTransaction(c, func(db *pgxpool.Pool) error {
+		if assignment, err = r.AssetQuery.FindAssignmentById(c, db, assetID, id); err != nil {
+			return err
+		}
+		return nil
+	})
+
+	return assignment, err
+}
@@ -0,0 +1,154 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/iqbaludinm/hr-microservice/user-service/model/domain"
+	"github.com/iqbaludinm/hr-microservice/user-service/repository/query"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type AttendanceRepository interface {
+	CreateAttendance(c context.Context, attendance domain.Attendance) error
+	UpdateCheckOut(c context.Context, id string, attendance domain.Attendance) error
+	FindAllAttendance(c context.Context, filter domain.AttendanceQueryFilter) ([]domain.Attendance, error)
+	CountAllAttendance(c context.Context, filter domain.AttendanceQueryFilter) (int, error)
+	FindById(c context.Context, id string) (domain.Attendance, error)
+	FindByEmployeeDate(c context.Context, employeeID string, date time.Time) (domain.Attendance, error)
+	FindOpenByEmployee(c context.Context, employeeID string) (domain.Attendance, error)
+	Summarize(c context.Context, filter domain.AttendanceQueryFilter, groupByDepartment bool) ([]domain.AttendanceSummary, error)
+}
+
+type attendanceRepository struct {
+	db              Store
+	AttendanceQuery query.AttendanceQuery
+}
+
+func NewAttendance(db Store, q query.AttendanceQuery) AttendanceRepository {
+	return &attendanceRepository{
+		db:              db,
+		AttendanceQuery: q,
+	}
+}
+
+func (r *attendanceRepository) CreateAttendance(c context.Context, attendance domain.Attendance) error {
+	var err error
+
+	// create transaction to check in
+	err = r.db.WithTransaction(c, func(tx pgx.Tx) error {
+		// create attendance, if error will rollback
+		if err = r.AttendanceQuery.CreateAttendance(c, tx, attendance); err != nil {
+			return err
+		}
+		return nil
+	})
+
+	return err
+}
+
+func (r *attendanceRepository) UpdateCheckOut(c context.Context, id string, attendance domain.Attendance) error {
+	var err error
+
+	// create transaction to check out
+	err = r.db.WithTransaction(c, func(tx pgx.Tx) error {
+		// update the check-out of the attendance by id, if error will rollback
+		if err = r.AttendanceQuery.UpdateCheckOut(c, tx, id, attendance); err != nil {
+			return err
+		}
+		return nil
+	})
+
+	return err
+}
+
+func (r *attendanceRepository) FindAllAttendance(c context.Context, filter domain.AttendanceQueryFilter) ([]domain.Attendance, error) {
+	var attendances []domain.Attendance
+	var err error
+
+	// get attendances without transaction.
+	err = r.db.WithoutTransaction(c, func(db *pgxpool.Pool) error {
+		if attendances, err = r.AttendanceQuery.FindAllAttendance(c, db, filter); err != nil {
+			return err
+		}
+		return nil
+	})
+
+	return attendances, err
+}
+
+func (r *attendanceRepository) CountAllAttendance(c context.Context, filter domain.AttendanceQueryFilter) (int, error) {
+	var count int
+	var err error
+
+	// count attendances without transaction.
+	err = r.db.WithoutTransaction(c, func(db *pgxpool.Pool) error {
+		if count, err = r.AttendanceQuery.CountAllAttendance(c, db, filter); err != nil {
+			return err
+		}
+		return nil
+	})
+
+	return count, err
+}
+
+func (r *attendanceRepository) FindById(c context.Context, id string) (domain.Attendance, error) {
+	var attendance domain.Attendance
+	var err error
+
+	// get attendance by id without transaction.
+	err = r.db.WithoutTransaction(c, func(db *pgxpool.Pool) error {
+		if attendance, err = r.AttendanceQuery.FindById(c, db, id); err != nil {
+			return err
+		}
+		return nil
+	})
+
+	return attendance, err
+}
+
+func (r *attendanceRepository) FindByEmployeeDate(c context.Context, employeeID string, date time.Time) (domain.Attendance, error) {
+	var attendance domain.Attendance
+	var err error
+
+	// get attendance of the employee on the date without transaction.
+	err = r.db.WithoutTransaction(c, func(db *pgxpool.Pool) error {
+		if attendance, err = r.AttendanceQuery.FindByEmployeeDate(c, db, employeeID, date); err != nil {
+			return err
+		}
+		return nil
+	})
+
+	return attendance, err
+}
+
+func (r *attendanceRepository) FindOpenByEmployee(c context.Context, employeeID string) (domain.Attendance, error) {
+	var attendance domain.Attendance
+	var err error
+
+	// get the attendance that is not checked out yet without transaction.
+	err = r.db.WithoutTransaction(c, func(db *pgxpool.Pool) error {
+		if attendance, err = r.AttendanceQuery.FindOpenByEmployee(c, db, employeeID); err != nil {
+			return err
+		}
+		return nil
+	})
+
+	return attendance, err
+}
+
+func (r *attendanceRepository) Summarize(c context.Context, filter domain.AttendanceQueryFilter, groupByDepartment bool) ([]domain.AttendanceSummary, error) {
+	var summaries []domain.AttendanceSummary
+	var err error
+
+	// summarize attendances without transaction.
+	err = r.db.WithoutTransaction(c, func(db *pgxpool.Pool) error {
+		if summaries, err = r.AttendanceQuery.Summarize(c, db, filter, groupByDepartment); err != nil {
+			return err
+		}
+		return nil
+	})
+
+	return summaries, err
+}
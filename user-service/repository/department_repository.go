@@ -0,0 +1,137 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/iqbaludinm/hr-microservice/user-service/model/domain"
+	"github.com/iqbaludinm/hr-microservice/user-service/repository/query"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type DepartmentRepository interface {
+	CreateDepartment(c context.Context, department domain.Department) error
+	UpdateDepartment(c context.Context, id string, department domain.Department) error
+	Delete(c context.Context, id string) error
+	FindAllDepartment(c context.Context, filter domain.DepartmentQueryFilter) ([]domain.Department, error)
+	FindById(c context.Context, id string) (domain.Department, error)
+	CountAllDepartment(c context.Context, filter domain.DepartmentQueryFilter) (int, error)
+	FindSubDepartmentIds(c context.Context, id string) ([]string, error)
+}
+
+type departmentRepository struct {
+	db              Store
+	DepartmentQuery query.DepartmentQuery
+}
+
+func NewDepartment(db Store, q query.DepartmentQuery) DepartmentRepository {
+	return &departmentRepository{
+		db:              db,
+		DepartmentQuery: q,
+	}
+}
+
+func (r *departmentRepository) CreateDepartment(c context.Context, department domain.Department) error {
+	var err error
+
+	// create transaction to create department
+	err = r.db.WithTransaction(c, func(tx pgx.Tx) error {
+		// create department, if error will rollback
+		if err = r.DepartmentQuery.CreateDepartment(c, tx, department); err != nil {
+			return err
+		}
+		return nil
+	})
+
+	return err
+}
+
+func (r *departmentRepository) UpdateDepartment(c context.Context, id string, department domain.Department) error {
+	var err error
+
+	// create transaction to update department
+	err = r.db.WithTransaction(c, func(tx pgx.Tx) error {
+		// update department by id, if error will rollback
+		if err = r.DepartmentQuery.UpdateDepartment(c, tx, id, department); err != nil {
+			return err
+		}
+		return nil
+	})
+
+	return err
+}
+
+func (r *departmentRepository) Delete(c context.Context, id string) error {
+	var err error
+
+	// create transaction to delete department
+	err = r.db.WithTransaction(c, func(tx pgx.Tx) error {
+		// delete department by id, if error will rollback
+		if err = r.DepartmentQuery.Delete(c, tx, id); err != nil {
+			return err
+		}
+		return nil
+	})
+
+	return err
+}
+
+func (r *departmentRepository) FindAllDepartment(c context.Context, filter domain.DepartmentQueryFilter) ([]domain.Department, error) {
+	var departments []domain.Department
+	var err error
+
+	// get departments without transaction.
+	err = r.db.WithoutTransaction(c, func(db *pgxpool.Pool) error {
+		if departments, err = r.DepartmentQuery.FindAllDepartment(c, db, filter); err != nil {
+			return err
+		}
+		return nil
+	})
+
+	return departments, err
+}
+
+func (r *departmentRepository) FindById(c context.Context, id string) (domain.Department, error) {
+	var department domain.Department
+	var err error
+
+	// get department by id without transaction.
+	err = r.db.WithoutTransaction(c, func(db *pgxpool.Pool) error {
+		if department, err = r.DepartmentQuery.FindById(c, db, id); err != nil {
+			return err
+		}
+		return nil
+	})
+
+	return department, err
+}
+
+func (r *departmentRepository) CountAllDepartment(c context.Context, filter domain.DepartmentQueryFilter) (int, error) {
+	var count int
+	var err error
+
+	// count departments without transaction.
+	err = r.db.WithoutTransaction(c, func(db *pgxpool.Pool) error {
+		if count, err = r.DepartmentQuery.CountAllDepartment(c, db, filter); err != nil {
+			return err
+		}
+		return nil
+	})
+
+	return count, err
+}
+
+func (r *departmentRepository) FindSubDepartmentIds(c context.Context, id string) ([]string, error) {
+	var ids []string
+	var err error
+
+	// get the department and sub-department ids without transaction.
+	err = r.db.WithoutTransaction(c, func(db *pgxpool.Pool) error {
+		if ids, err = r.DepartmentQuery.FindSubDepartmentIds(c, db, id); err != nil {
+			return err
+		}
+		return nil
+	})
+
+	return ids, err
+}
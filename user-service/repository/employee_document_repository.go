@@ -0,0 +1,183 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/iqbaludinm/hr-microservice/user-service/model/domain"
+	"github.com/iqbaludinm/hr-microservice/user-service/repository/query"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type EmployeeDocumentRepository interface {
+	CreateDocument(c context.Context, document domain.EmployeeDocument, version domain.EmployeeDocumentVersion) error
+	AddVersion(c context.Context, document domain.EmployeeDocument, version domain.EmployeeDocumentVersion) error
+	UpdateDocument(c context.Context, document domain.EmployeeDocument) error
+	UpdateReminded(c context.Context, id string, expiryDate time.Time) error
+	Delete(c context.Context, id string) error
+	FindAll(c context.Context, filter domain.EmployeeDocumentQueryFilter) ([]domain.EmployeeDocument, error)
+	CountAll(c context.Context, filter domain.EmployeeDocumentQueryFilter) (int, error)
+	FindById(c context.Context, id string) (domain.EmployeeDocument, error)
+	FindExpiring(c context.Context, expiryBefore time.Time) ([]domain.EmployeeDocument, error)
+}
+
+type employeeDocumentRepository struct {
+	db                    Store
+	EmployeeDocumentQuery query.EmployeeDocumentQuery
+}
+
+func NewEmployeeDocument(db Store, q query.EmployeeDocumentQuery) EmployeeDocumentRepository {
+	return &employeeDocumentRepository{
+		db:                    db,
+		EmployeeDocumentQuery: q,
+	}
+}
+
+// create the document with its first version
+func (r *employeeDocumentRepository) CreateDocument(c context.Context, document domain.EmployeeDocument, version domain.EmployeeDocumentVersion) error {
+	var err error
+
+	// create transaction to create employee document
+	err = r.db.WithTransaction(c, func(tx pgx.Tx) error {
+		// create document, if error will rollback
+		if err = r.EmployeeDocumentQuery.CreateDocument(c, tx, document); err != nil {
+			return err
+		}
+		// create the first version of the document, if error will rollback
+		if err = r.EmployeeDocumentQuery.CreateVersion(c, tx, version); err != nil {
+			return err
+		}
+		return nil
+	})
+
+	return err
+}
+
+// add the version to the document and make it the latest version
+func (r *employeeDocumentRepository) AddVersion(c context.Context, document domain.EmployeeDocument, version domain.EmployeeDocumentVersion) error {
+	var err error
+
+	// create transaction to add employee document version
+	err = r.db.WithTransaction(c, func(tx pgx.Tx) error {
+		// create version, if error will rollback
+		if err = r.EmployeeDocumentQuery.CreateVersion(c, tx, version); err != nil {
+			return err
+		}
+		// update the latest version of the document, if error will rollback
+		if err = r.EmployeeDocumentQuery.UpdateLatestVersion(c, tx, document.ID, document); err != nil {
+			return err
+		}
+		return nil
+	})
+
+	return err
+}
+
+func (r *employeeDocumentRepository) UpdateDocument(c context.Context, document domain.EmployeeDocument) error {
+	var err error
+
+	// create transaction to update employee document
+	err = r.db.WithTransaction(c, func(tx pgx.Tx) error {
+		// update document by id, if error will rollback
+		if err = r.EmployeeDocumentQuery.UpdateDocument(c, tx, document.ID, document); err != nil {
+			return err
+		}
+		return nil
+	})
+
+	return err
+}
+
+func (r *employeeDocumentRepository) UpdateReminded(c context.Context, id string, expiryDate time.Time) error {
+	var err error
+
+	// create transaction to mark employee document as reminded
+	err = r.db.WithTransaction(c, func(tx pgx.Tx) error {
+		// update reminded expiry date, if error will rollback
+		if err = r.EmployeeDocumentQuery.UpdateReminded(c, tx, id, expiryDate); err != nil {
+			return err
+		}
+		return nil
+	})
+
+	return err
+}
+
+func (r *employeeDocumentRepository) Delete(c context.Context, id string) error {
+	var err error
+
+	// create transaction to delete employee document
+	err = r.db.WithTransaction(c, func(tx pgx.Tx) error {
+		// delete document by id, if error will rollback
+		if err = r.EmployeeDocumentQuery.DeleteDocument(c, tx, id); err != nil {
+			return err
+		}
+		return nil
+	})
+
+	return err
+}
+
+func (r *employeeDocumentRepository) FindAll(c context.Context, filter domain.EmployeeDocumentQueryFilter) ([]domain.EmployeeDocument, error) {
+	var documents []domain.EmployeeDocument
+	var err error
+
+	// get employee documents without transaction.
+	err = r.db.WithoutTransaction(c, func(db *pgxpool.Pool) error {
+		if documents, err = r.EmployeeDocumentQuery.FindAll(c, db, filter); err != nil {
+			return err
+		}
+		return nil
+	})
+
+	return documents, err
+}
+
+func (r *employeeDocumentRepository) CountAll(c context.Context, filter domain.EmployeeDocumentQueryFilter) (int, error) {
+	var count int
+	var err error
+
+	// count employee documents without transaction.
+	err = r.db.WithoutTransaction(c, func(db *pgxpool.Pool) error {
+		if count, err = r.EmployeeDocumentQuery.CountAll(c, db, filter); err != nil {
+			return err
+		}
+		return nil
+	})
+
+	return count, err
+}
+
+func (r *employeeDocumentRepository) FindById(c context.Context, id string) (domain.EmployeeDocument, error) {
+	var document domain.EmployeeDocument
+	var err error
+
+	// get employee document by id with its versions without transaction.
+	err = r.db.WithoutTransaction(c, func(db *pgxpool.Pool) error {
+		if document, err = r.EmployeeDocumentQuery.FindById(c, db, id); err != nil {
+			return err
+		}
+		if document.Versions, err = r.EmployeeDocumentQuery.FindVersions(c, db, document.ID); err != nil {
+			return err
+		}
+		return nil
+	})
+
+	return document, err
+}
+
+func (r *employeeDocumentRepository) FindExpiring(c context.Context, expiryBefore time.Time) ([]domain.EmployeeDocument, error) {
+	var documents []domain.EmployeeDocument
+	var err error
+
+	// get the expiring employee documents without transaction.
+	err = r.db.WithoutTransaction(c, func(db *pgxpool.Pool) error {
+		if documents, err = r.EmployeeDocumentQuery.FindExpiring(c, db, expiryBefore); err != nil {
+			return err
+		}
+		return nil
+	})
+
+	return documents, err
+}
@@ -0,0 +1,175 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/iqbaludinm/hr-microservice/user-service/model/domain"
+	"github.com/iqbaludinm/hr-microservice/user-service/repository/query"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type EmployeeRepository interface {
+	CreateEmployee(c context.Context, employee domain.Employee, history domain.EmploymentHistory) error
+	UpdateEmployee(c context.Context, id string, employee domain.Employee) error
+	UpdatePersonal(c context.Context, id string, employee domain.Employee) error
+	Delete(c context.Context, id string) error
+	FindAllEmployee(c context.Context, filter domain.EmployeeQueryFilter) ([]domain.Employee, error)
+	FindById(c context.Context, id string) (domain.Employee, error)
+	FindByUserId(c context.Context, userID string) (domain.Employee, error)
+	CountAllEmployee(c context.Context, filter domain.EmployeeQueryFilter) (int, error)
+	FindReportingChain(c context.Context, id string) ([]domain.Employee, error)
+}
+
+type employeeRepository struct {
+	db                     Store
+	EmployeeQuery          query.EmployeeQuery
+	EmploymentHistoryQuery query.EmploymentHistoryQuery
+}
+
+func NewEmployee(db Store, q query.EmployeeQuery, historyQuery query.EmploymentHistoryQuery) EmployeeRepository {
+	return &employeeRepository{
+		db:                     db,
+		EmployeeQuery:          q,
+		EmploymentHistoryQuery: historyQuery,
+	}
+}
+
+func (r *employeeRepository) CreateEmployee(c context.Context, employee domain.Employee, history domain.EmploymentHistory) error {
+	var err error
+
+	// create transaction to create employee
+	err = r.db.WithTransaction(c, func(tx pgx.Tx) error {
+		// create employee, if error will rollback
+		if err = r.EmployeeQuery.CreateEmployee(c, tx, employee); err != nil {
+			return err
+		}
+		// create the first employment history of the employee, if error will rollback
+		if err = r.EmploymentHistoryQuery.SaveHistory(c, tx, history); err != nil {
+			return err
+		}
+		return nil
+	})
+
+	return err
+}
+
+func (r *employeeRepository) UpdateEmployee(c context.Context, id string, employee domain.Employee) error {
+	var err error
+
+	// create transaction to update employee
+	err = r.db.WithTransaction(c, func(tx pgx.Tx) error {
+		// update employee by id, if error will rollback
+		if err = r.EmployeeQuery.UpdateEmployee(c, tx, id, employee); err != nil {
+			return err
+		}
+		return nil
+	})
+
+	return err
+}
+
+func (r *employeeRepository) UpdatePersonal(c context.Context, id string, employee domain.Employee) error {
+	var err error
+
+	// create transaction to update the personal data of the employee
+	err = r.db.WithTransaction(c, func(tx pgx.Tx) error {
+		// update personal data by id, if error will rollback
+		if err = r.EmployeeQuery.UpdatePersonal(c, tx, id, employee); err != nil {
+			return err
+		}
+		return nil
+	})
+
+	return err
+}
+
+func (r *employeeRepository) Delete(c context.Context, id string) error {
+	var err error
+
+	// create transaction to delete employee
+	err = r.db.WithTransaction(c, func(tx pgx.Tx) error {
+		// delete employee by id, if error will rollback
+		if err = r.EmployeeQuery.Delete(c, tx, id); err != nil {
+			return err
+		}
+		return nil
+	})
+
+	return err
+}
+
+func (r *employeeRepository) FindAllEmployee(c context.Context, filter domain.EmployeeQueryFilter) ([]domain.Employee, error) {
+	var employees []domain.Employee
+	var err error
+
+	// get employees without transaction.
+	err = r.db.WithoutTransaction(c, func(db *pgxpool.Pool) error {
+		if employees, err = r.EmployeeQuery.FindAllEmployee(c, db, filter); err != nil {
+			return err
+		}
+		return nil
+	})
+
+	return employees, err
+}
+
+func (r *employeeRepository) FindById(c context.Context, id string) (domain.Employee, error) {
+	var employee domain.Employee
+	var err error
+
+	// get employee by id without transaction.
+	err = r.db.WithoutTransaction(c, func(db *pgxpool.Pool) error {
+		if employee, err = r.EmployeeQuery.FindById(c, db, id); err != nil {
+			return err
+		}
+		return nil
+	})
+
+	return employee, err
+}
+
+func (r *employeeRepository) FindByUserId(c context.Context, userID string) (domain.Employee, error) {
+	var employee domain.Employee
+	var err error
+
+	// get employee by user id without transaction.
+	err = r.db.WithoutTransaction(c, func(db *pgxpool.Pool) error {
+		if employee, err = r.EmployeeQuery.FindByUserId(c, db, userID); err != nil {
+			return err
+		}
+		return nil
+	})
+
+	return employee, err
+}
+
+func (r *employeeRepository) CountAllEmployee(c context.Context, filter domain.EmployeeQueryFilter) (int, error) {
+	var count int
+	var err error
+
+	// count employees without transaction.
+	err = r.db.WithoutTransaction(c, func(db *pgxpool.Pool) error {
+		if count, err = r.EmployeeQuery.CountAllEmployee(c, db, filter); err != nil {
+			return err
+		}
+		return nil
+	})
+
+	return count, err
+}
+
+func (r *employeeRepository) FindReportingChain(c context.Context, id string) ([]domain.Employee, error) {
+	var employees []domain.Employee
+	var err error
+
+	// get the managers of the employee without transaction.
+	err = r.db.WithoutTransaction(c, func(db *pgxpool.Pool) error {
+		if employees, err = r.EmployeeQuery.FindReportingChain(c, db, id); err != nil {
+			return err
+		}
+		return nil
+	})
+
+	return employees, err
+}
@@ -0,0 +1,74 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/iqbaludinm/hr-microservice/user-service/model/domain"
+	"github.com/iqbaludinm/hr-microservice/user-service/repository/query"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type EmployeeSalaryRepository interface {
+	CreateSalary(c context.Context, salary domain.EmployeeSalary) error
+	FindAllSalary(c context.Context, employeeID string) ([]domain.EmployeeSalary, error)
+	FindEffectiveSalaries(c context.Context, date time.Time) ([]domain.EmployeeSalary, error)
+}
+
+type employeeSalaryRepository struct {
+	db                  Store
+	EmployeeSalaryQuery query.EmployeeSalaryQuery
+}
+
+func NewEmployeeSalary(db Store, q query.EmployeeSalaryQuery) EmployeeSalaryRepository {
+	return &employeeSalaryRepository{
+		db:                  db,
+		EmployeeSalaryQuery: q,
+	}
+}
+
+func (r *employeeSalaryRepository) CreateSalary(c context.Context, salary domain.EmployeeSalary) error {
+	var err error
+
+	// create transaction to create employee salary
+	err = r.db.WithTransaction(c, func(tx pgx.Tx) error {
+		// create employee salary, if error will rollback
+		if err = r.EmployeeSalaryQuery.CreateSalary(c, tx, salary); err != nil {
+			return err
+		}
+		return nil
+	})
+
+	return err
+}
+
+func (r *employeeSalaryRepository) FindAllSalary(c context.Context, employeeID string) ([]domain.EmployeeSalary, error) {
+	var salaries []domain.EmployeeSalary
+	var err error
+
+	// get salaries of the employee without transaction.
+	err = r.db.WithoutTransaction(c, func(db *pgxpool.Pool) error {
+		if salaries, err = r.EmployeeSalaryQuery.FindAllSalary(c, db, employeeID); err != nil {
+			return err
+		}
+		return nil
+	})
+
+	return salaries, err
+}
+
+func (r *employeeSalaryRepository) FindEffectiveSalaries(c context.Context, date time.Time) ([]domain.EmployeeSalary, error) {
+	var salaries []domain.EmployeeSalary
+	var err error
+
+	// get salaries effective on the date without transaction.
+	err = r.db.WithoutTransaction(c, func(db *pgxpool.Pool) error {
+		if salaries, err = r.EmployeeSalaryQuery.FindEffectiveSalaries(c, db, date); err != nil {
+			return err
+		}
+		return nil
+	})
+
+	return salaries, err
+}
@@ -0,0 +1,230 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/iqbaludinm/hr-microservice/user-service/model/domain"
+	"github.com/iqbaludinm/hr-microservice/user-service/repository/query"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type EmploymentContractRepository interface {
+	CreateContract(c context.Context, contract *domain.EmploymentContract) error
+	UpdateContract(c context.Context, contract domain.EmploymentContract) error
+	UpdateStatus(c context.Context, contract domain.EmploymentContract) error
+	Sign(c context.Context, contract domain.EmploymentContract, previous *domain.EmploymentContract) error
+	UpdateAlerted(c context.Context, id string, days int) error
+	EndExpired(c context.Context, today time.Time) (int64, error)
+	FindAll(c context.Context, filter domain.EmploymentContractQueryFilter) ([]domain.EmploymentContract, error)
+	CountAll(c context.Context, filter domain.EmploymentContractQueryFilter) (int, error)
+	FindById(c context.Context, id string) (domain.EmploymentContract, error)
+	FindActiveByEmployee(c context.Context, employeeID string) (domain.EmploymentContract, error)
+	FindSuccessor(c context.Context, previousID string) (domain.EmploymentContract, error)
+	FindExpiring(c context.Context, today, until time.Time) ([]domain.EmploymentContract, error)
+}
+
+type employmentContractRepository struct {
+	db                      Store
+	EmploymentContractQuery query.EmploymentContractQuery
+}
+
+func NewEmploymentContract(db Store, q query.EmploymentContractQuery) EmploymentContractRepository {
+	return &employmentContractRepository{
+		db:                      db,
+		EmploymentContractQuery: q,
+	}
+}
+
+// create the draft contract, the contract number is taken from the running number
+func (r *employmentContractRepository) CreateContract(c context.Context, contract *domain.EmploymentContract) error {
+	var err error
+
+	// create transaction to create employment contract
+	err = r.db.WithTransaction(c, func(tx pgx.Tx) error {
+		number, err := r.EmploymentContractQuery.NextContractNumber(c, tx)
+		if err != nil {
+			return err
+		}
+		contract.ContractNumber = domain.ContractNumber(contract.Type, contract.StartDate, number)
+
+		// create employment contract, if error will rollback
+		return r.EmploymentContractQuery.CreateContract(c, tx, *contract)
+	})
+
+	return err
+}
+
+func (r *employmentContractRepository) UpdateContract(c context.Context, contract domain.EmploymentContract) error {
+	var err error
+
+	// create transaction to update employment contract
+	err = r.db.WithTransaction(c, func(tx pgx.Tx) error {
+		// update employment contract by id, if error will rollback
+		if err = r.EmploymentContractQuery.UpdateContract(c, tx, contract.ID, contract); err != nil {
+			return err
+		}
+		return nil
+	})
+
+	return err
+}
+
+func (r *employmentContractRepository) UpdateStatus(c context.Context, contract domain.EmploymentContract) error {
+	var err error
+
+	// create transaction to update employment contract status
+	err = r.db.WithTransaction(c, func(tx pgx.Tx) error {
+		// update employment contract status by id, if error will rollback
+		if err = r.EmploymentContractQuery.UpdateStatus(c, tx, contract.ID, contract); err != nil {
+			return err
+		}
+		return nil
+	})
+
+	return err
+}
+
+// activate the signed contract, the previous contract is only filled when the contract renews or converts it and
+// its status is updated in the same transaction
+func (r *employmentContractRepository) Sign(c context.Context, contract domain.EmploymentContract, previous *domain.EmploymentContract) error {
+	var err error
+
+	// create transaction to sign employment contract
+	err = r.db.WithTransaction(c, func(tx pgx.Tx) error {
+		// the previous contract is no longer active before the signed contract is, if error will rollback
+		if previous != nil {
+			if err = r.EmploymentContractQuery.UpdateStatus(c, tx, previous.ID, *previous); err != nil {
+				return err
+			}
+		}
+		if err = r.EmploymentContractQuery.UpdateStatus(c, tx, contract.ID, contract); err != nil {
+			return err
+		}
+		return nil
+	})
+
+	return err
+}
+
+func (r *employmentContractRepository) UpdateAlerted(c context.Context, id string, days int) error {
+	var err error
+
+	// create transaction to mark employment contract as alerted
+	err = r.db.WithTransaction(c, func(tx pgx.Tx) error {
+		// update alerted days, if error will rollback
+		if err = r.EmploymentContractQuery.UpdateAlerted(c, tx, id, days); err != nil {
+			return err
+		}
+		return nil
+	})
+
+	return err
+}
+
+func (r *employmentContractRepository) EndExpired(c context.Context, today time.Time) (int64, error) {
+	var ended int64
+	var err error
+
+	// create transaction to end the expired employment contracts
+	err = r.db.WithTransaction(c, func(tx pgx.Tx) error {
+		// end the expired contracts, if error will rollback
+		if ended, err = r.EmploymentContractQuery.EndExpired(c, tx, today); err != nil {
+			return err
+		}
+		return nil
+	})
+
+	return ended, err
+}
+
+func (r *employmentContractRepository) FindAll(c context.Context, filter domain.EmploymentContractQueryFilter) ([]domain.EmploymentContract, error) {
+	var contracts []domain.EmploymentContract
+	var err error
+
+	// get employment contracts without transaction.
+	err = r.db.WithoutTransaction(c, func(db *pgxpool.Pool) error {
+		if contracts, err = r.EmploymentContractQuery.FindAll(c, db, filter); err != nil {
+			return err
+		}
+		return nil
+	})
+
+	return contracts, err
+}
+
+func (r *employmentContractRepository) CountAll(c context.Context, filter domain.EmploymentContractQueryFilter) (int, error) {
+	var count int
+	var err error
+
+	// count employment contracts without transaction.
+	err = r.db.WithoutTransaction(c, func(db *pgxpool.Pool) error {
+		if count, err = r.EmploymentContractQuery.CountAll(c, db, filter); err != nil {
+			return err
+		}
+		return nil
+	})
+
+	return count, err
+}
+
+func (r *employmentContractRepository) FindById(c context.Context, id string) (domain.EmploymentContract, error) {
+	var contract domain.EmploymentContract
+	var err error
+
+	// get employment contract by id without transaction.
+	err = r.db.WithoutTransaction(c, func(db *pgxpool.Pool) error {
+		if contract, err = r.EmploymentContractQuery.FindById(c, db, id); err != nil {
+			return err
+		}
+		return nil
+	})
+
+	return contract, err
+}
+
+func (r *employmentContractRepository) FindActiveByEmployee(c context.Context, employeeID string) (domain.EmploymentContract, error) {
+	var contract domain.EmploymentContract
+	var err error
+
+	// get the active employment contract of the employee without transaction.
+	err = r.db.WithoutTransaction(c, func(db *pgxpool.Pool) error {
+		if contract, err = r.EmploymentContractQuery.FindActiveByEmployee(c, db, employeeID); err != nil {
+			return err
+		}
+		return nil
+	})
+
+	return contract, err
+}
+
+func (r *employmentContractRepository) FindSuccessor(c context.Context, previousID string) (domain.EmploymentContract, error) {
+	var contract domain.EmploymentContract
+	var err error
+
+	// get the contract renewing or converting the previous contract without transaction.
+	err = r.db.WithoutTransaction(c, func(db *pgxpool.Pool) error {
+		if contract, err = r.EmploymentContractQuery.FindSuccessor(c, db, previousID); err != nil {
+			return err
+		}
+		return nil
+	})
+
+	return contract, err
+}
+
+func (r *employmentContractRepository) FindExpiring(c context.Context, today, until time.Time) ([]domain.EmploymentContract, error) {
+	var contracts []domain.EmploymentContract
+	var err error
+
+	// get the expiring employment contracts without transaction.
+	err = r.db.WithoutTransaction(c, func(db *pgxpool.Pool) error {
+		if contracts, err = r.EmploymentContractQuery.FindExpiring(c, db, today, until); err != nil {
+			return err
+		}
+		return nil
+	})
+
+	return contracts, err
+}
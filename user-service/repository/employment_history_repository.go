@@ -0,0 +1,151 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/iqbaludinm/hr-microservice/user-service/model/domain"
+	"github.com/iqbaludinm/hr-microservice/user-service/repository/query"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type EmploymentHistoryRepository interface {
+	CreateHistory(c context.Context, history domain.EmploymentHistory) error
+	ApplyHistory(c context.Context, history domain.EmploymentHistory, employee domain.Employee) error
+	Delete(c context.Context, id string) error
+	FindByEmployeeId(c context.Context, employeeID string) ([]domain.EmploymentHistory, error)
+	FindById(c context.Context, id string) (domain.EmploymentHistory, error)
+	FindDue(c context.Context, date time.Time) ([]domain.EmploymentHistory, error)
+	FindAsOf(c context.Context, employeeID string, date time.Time) (domain.EmploymentHistory, error)
+}
+
+type employmentHistoryRepository struct {
+	db                     Store
+	EmploymentHistoryQuery query.EmploymentHistoryQuery
+	EmployeeQuery          query.EmployeeQuery
+}
+
+func NewEmploymentHistory(db Store, q query.EmploymentHistoryQuery, employeeQuery query.EmployeeQuery) EmploymentHistoryRepository {
+	return &employmentHistoryRepository{
+		db:                     db,
+		EmploymentHistoryQuery: q,
+		EmployeeQuery:          employeeQuery,
+	}
+}
+
+// store a pending (future-dated) change of the employee
+func (r *employmentHistoryRepository) CreateHistory(c context.Context, history domain.EmploymentHistory) error {
+	var err error
+
+	// create transaction to create employment history
+	err = r.db.WithTransaction(c, func(tx pgx.Tx) error {
+		// create employment history, if error will rollback
+		if err = r.EmploymentHistoryQuery.SaveHistory(c, tx, history); err != nil {
+			return err
+		}
+		return nil
+	})
+
+	return err
+}
+
+// apply the change to the employee: close the current period the day before the change is effective,
+// save the change as applied and update the current assignment of the employee.
+func (r *employmentHistoryRepository) ApplyHistory(c context.Context, history domain.EmploymentHistory, employee domain.Employee) error {
+	var err error
+
+	// create transaction to apply employment history
+	err = r.db.WithTransaction(c, func(tx pgx.Tx) error {
+		// close the current period, if error will rollback
+		if err = r.EmploymentHistoryQuery.CloseCurrent(c, tx, history.EmployeeID, history.EffectiveFrom.AddDate(0, 0, -1)); err != nil {
+			return err
+		}
+		// save the applied change, if error will rollback
+		if err = r.EmploymentHistoryQuery.SaveHistory(c, tx, history); err != nil {
+			return err
+		}
+		// update the employee, if error will rollback
+		if err = r.EmployeeQuery.UpdateAssignment(c, tx, employee.ID, employee); err != nil {
+			return err
+		}
+		return nil
+	})
+
+	return err
+}
+
+func (r *employmentHistoryRepository) Delete(c context.Context, id string) error {
+	var err error
+
+	// create transaction to delete employment history
+	err = r.db.WithTransaction(c, func(tx pgx.Tx) error {
+		// delete employment history by id, if error will rollback
+		if err = r.EmploymentHistoryQuery.Delete(c, tx, id); err != nil {
+			return err
+		}
+		return nil
+	})
+
+	return err
+}
+
+func (r *employmentHistoryRepository) FindByEmployeeId(c context.Context, employeeID string) ([]domain.EmploymentHistory, error) {
+	var histories []domain.EmploymentHistory
+	var err error
+
+	// get employment histories by employee id without transaction.
+	err = r.db.WithoutTransaction(c, func(db *pgxpool.Pool) error {
+		if histories, err = r.EmploymentHistoryQuery.FindByEmployeeId(c, db, employeeID); err != nil {
+			return err
+		}
+		return nil
+	})
+
+	return histories, err
+}
+
+func (r *employmentHistoryRepository) FindById(c context.Context, id string) (domain.EmploymentHistory, error) {
+	var history domain.EmploymentHistory
+	var err error
+
+	// get employment history by id without transaction.
+	err = r.db.WithoutTransaction(c, func(db *pgxpool.Pool) error {
+		if history, err = r.EmploymentHistoryQuery.FindById(c, db, id); err != nil {
+			return err
+		}
+		return nil
+	})
+
+	return history, err
+}
+
+func (r *employmentHistoryRepository) FindDue(c context.Context, date time.Time) ([]domain.EmploymentHistory, error) {
+	var histories []domain.EmploymentHistory
+	var err error
+
+	// get the due pending changes without transaction.
+	err = r.db.WithoutTransaction(c, func(db *pgxpool.Pool) error {
+		if histories, err = r.EmploymentHistoryQuery.FindDue(c, db, date); err != nil {
+			return err
+		}
+		return nil
+	})
+
+	return histories, err
+}
+
+func (r *employmentHistoryRepository) FindAsOf(c context.Context, employeeID string, date time.Time) (domain.EmploymentHistory, error) {
+	var history domain.EmploymentHistory
+	var err error
+
+	// get the employment history that is effective on the date without transaction.
+	err = r.db.WithoutTransaction(c, func(db *pgxpool.Pool) error {
+		if history, err = r.EmploymentHistoryQuery.FindAsOf(c, db, employeeID, date); err != nil {
+			return err
+		}
+		return nil
+	})
+
+	return history, err
+}
@@ -0,0 +1,322 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/iqbaludinm/hr-microservice/user-service/model/domain"
+	"github.com/iqbaludinm/hr-microservice/user-service/repository/query"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type ExpenseClaimRepository interface {
+	CreateCategory(c context.Context, category domain.ExpenseCategory) error
+	UpdateCategory(c context.Context, category domain.ExpenseCategory) error
+	DeleteCategory(c context.Context, id string) error
+	CreateClaim(c context.Context, claim domain.ExpenseClaim, history domain.ExpenseClaimHistory) error
+	UpdateClaim(c context.Context, claim domain.ExpenseClaim) error
+	AddItem(c context.Context, claim domain.ExpenseClaim, item domain.ExpenseClaimItem) error
+	DeleteItem(c context.Context, claim domain.ExpenseClaim, seq int) error
+	UpdateItemReceipt(c context.Context, item domain.ExpenseClaimItem) error
+	UpdateClaimStatus(c context.Context, claim domain.ExpenseClaim, history domain.ExpenseClaimHistory, status string) error
+	FindAllCategory(c context.Context, filter domain.ExpenseCategoryQueryFilter) ([]domain.ExpenseCategory, error)
+	CountAllCategory(c context.Context, filter domain.ExpenseCategoryQueryFilter) (int, error)
+	FindCategoryById(c context.Context, id string) (domain.ExpenseCategory, error)
+	FindLimitsByGrade(c context.Context, salaryGrade string) ([]domain.ExpenseCategoryLimit, error)
+	FindAllClaim(c context.Context, filter domain.ExpenseClaimQueryFilter) ([]domain.ExpenseClaim, error)
+	CountAllClaim(c context.Context, filter domain.ExpenseClaimQueryFilter) (int, error)
+	FindClaimById(c context.Context, id string) (domain.ExpenseClaim, error)
+	FindPayableClaims(c context.Context, payrollRunID string, approvedBefore time.Time) ([]domain.ExpenseClaim, error)
+}
+
+type expenseClaimRepository struct {
+	db                Store
+	ExpenseClaimQuery query.ExpenseClaimQuery
+}
+
+func NewExpenseClaim(db Store, q query.ExpenseClaimQuery) ExpenseClaimRepository {
+	return &expenseClaimRepository{
+		db:                db,
+		ExpenseClaimQuery: q,
+	}
+}
+
+// create the expense category with its limits
+func (r *expenseClaimRepository) CreateCategory(c context.Context, category domain.ExpenseCategory) error {
+	var err error
+
+	// create transaction to create expense category
+	err = r.db.WithTransaction(c, func(tx pgx.Tx) error {
+		// create expense category, if error will rollback
+		if err = r.ExpenseClaimQuery.CreateCategory(c, tx, category); err != nil {
+			return err
+		}
+		return r.createLimits(c, tx, category)
+	})
+
+	return err
+}
+
+// update the expense category, the limits are replaced
+func (r *expenseClaimRepository) UpdateCategory(c context.Context, category domain.ExpenseCategory) error {
+	var err error
+
+	// create transaction to update expense category
+	err = r.db.WithTransaction(c, func(tx pgx.Tx) error {
+		// update expense category by id, if error will rollback
+		if err = r.ExpenseClaimQuery.UpdateCategory(c, tx, category); err != nil {
+			return err
+		}
+		if err = r.ExpenseClaimQuery.DeleteLimits(c, tx, category.ID); err != nil {
+			return err
+		}
+		return r.createLimits(c, tx, category)
+	})
+
+	return err
+}
+
+func (r *expenseClaimRepository) createLimits(c context.Context, tx pgx.Tx, category domain.ExpenseCategory) error {
+	for _, limit := range category.Limits {
+		limit.CategoryID = category.ID
+		if err := r.ExpenseClaimQuery.CreateLimit(c, tx, limit); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (r *expenseClaimRepository) DeleteCategory(c context.Context, id string) error {
+	var err error
+
+	// create transaction to delete expense category
+	err = r.db.WithTransaction(c, func(tx pgx.Tx) error {
+		// delete expense category by id, if error will rollback
+		if err = r.ExpenseClaimQuery.DeleteCategory(c, tx, id); err != nil {
+			return err
+		}
+		return nil
+	})
+
+	return err
+}
+
+// create the expense claim with its items and its first history
+func (r *expenseClaimRepository) CreateClaim(c context.Context, claim domain.ExpenseClaim, history domain.ExpenseClaimHistory) error {
+	var err error
+
+	// create transaction to create expense claim
+	err = r.db.WithTransaction(c, func(tx pgx.Tx) error {
+		// create expense claim, if error will rollback
+		if err = r.ExpenseClaimQuery.CreateClaim(c, tx, claim); err != nil {
+			return err
+		}
+		// create the items of the claim, if error will rollback
+		for _, item := range claim.Items {
+			item.ClaimID = claim.ID
+			if err = r.ExpenseClaimQuery.CreateItem(c, tx, item); err != nil {
+				return err
+			}
+		}
+		return r.ExpenseClaimQuery.CreateHistory(c, tx, history)
+	})
+
+	return err
+}
+
+func (r *expenseClaimRepository) UpdateClaim(c context.Context, claim domain.ExpenseClaim) error {
+	var err error
+
+	// create transaction to update expense claim
+	err = r.db.WithTransaction(c, func(tx pgx.Tx) error {
+		// update expense claim by id, if error will rollback
+		if err = r.ExpenseClaimQuery.UpdateClaim(c, tx, claim); err != nil {
+			return err
+		}
+		return nil
+	})
+
+	return err
+}
+
+// add the item to the claim, the total of the claim is updated in the same transaction
+func (r *expenseClaimRepository) AddItem(c context.Context, claim domain.ExpenseClaim, item domain.ExpenseClaimItem) error {
+	var err error
+
+	// create transaction to add expense claim item
+	err = r.db.WithTransaction(c, func(tx pgx.Tx) error {
+		// create expense claim item, if error will rollback
+		if err = r.ExpenseClaimQuery.CreateItem(c, tx, item); err != nil {
+			return err
+		}
+		return r.ExpenseClaimQuery.UpdateClaim(c, tx, claim)
+	})
+
+	return err
+}
+
+// delete the item of the claim, the total of the claim is updated in the same transaction
+func (r *expenseClaimRepository) DeleteItem(c context.Context, claim domain.ExpenseClaim, seq int) error {
+	var err error
+
+	// create transaction to delete expense claim item
+	err = r.db.WithTransaction(c, func(tx pgx.Tx) error {
+		// delete expense claim item, if error will rollback
+		if err = r.ExpenseClaimQuery.DeleteItem(c, tx, claim.ID, seq); err != nil {
+			return err
+		}
+		return r.ExpenseClaimQuery.UpdateClaim(c, tx, claim)
+	})
+
+	return err
+}
+
+func (r *expenseClaimRepository) UpdateItemReceipt(c context.Context, item domain.ExpenseClaimItem) error {
+	var err error
+
+	// create transaction to update the receipt of expense claim item
+	err = r.db.WithTransaction(c, func(tx pgx.Tx) error {
+		// update the receipt of expense claim item, if error will rollback
+		if err = r.ExpenseClaimQuery.UpdateItemReceipt(c, tx, item); err != nil {
+			return err
+		}
+		return nil
+	})
+
+	return err
+}
+
+// update the status of the claim which still has the given status and keep the change in its history
+func (r *expenseClaimRepository) UpdateClaimStatus(c context.Context, claim domain.ExpenseClaim, history domain.ExpenseClaimHistory, status string) error {
+	var err error
+
+	// create transaction to update the status of expense claim
+	err = r.db.WithTransaction(c, func(tx pgx.Tx) error {
+		// update the status of expense claim, if error will rollback
+		if err = r.ExpenseClaimQuery.UpdateClaimStatus(c, tx, claim, status); err != nil {
+			return err
+		}
+		return r.ExpenseClaimQuery.CreateHistory(c, tx, history)
+	})
+
+	return err
+}
+
+func (r *expenseClaimRepository) FindAllCategory(c context.Context, filter domain.ExpenseCategoryQueryFilter) ([]domain.ExpenseCategory, error) {
+	var categories []domain.ExpenseCategory
+	var err error
+
+	// get all expense categories without transaction.
+	err = r.db.WithoutTransaction(c, func(db *pgxpool.Pool) error {
+		categories, err = r.ExpenseClaimQuery.FindAllCategory(c, db, filter)
+		return err
+	})
+
+	return categories, err
+}
+
+func (r *expenseClaimRepository) CountAllCategory(c context.Context, filter domain.ExpenseCategoryQueryFilter) (int, error) {
+	var count int
+	var err error
+
+	// count all expense categories without transaction.
+	err = r.db.WithoutTransaction(c, func(db *pgxpool.Pool) error {
+		count, err = r.ExpenseClaimQuery.CountAllCategory(c, db, filter)
+		return err
+	})
+
+	return count, err
+}
+
+// find the expense category by id with its limits
+func (r *expenseClaimRepository) FindCategoryById(c context.Context, id string) (domain.ExpenseCategory, error) {
+	var category domain.ExpenseCategory
+	var err error
+
+	// get expense category by id without transaction.
+	err = r.db.WithoutTransaction(c, func(db *pgxpool.Pool) error {
+		category, err = r.ExpenseClaimQuery.FindCategoryById(c, db, id)
+		if err != nil {
+			return err
+		}
+		category.Limits, err = r.ExpenseClaimQuery.FindLimits(c, db, id)
+		return err
+	})
+
+	return category, err
+}
+
+func (r *expenseClaimRepository) FindLimitsByGrade(c context.Context, salaryGrade string) ([]domain.ExpenseCategoryLimit, error) {
+	var limits []domain.ExpenseCategoryLimit
+	var err error
+
+	// get the expense category limits of the salary grade without transaction.
+	err = r.db.WithoutTransaction(c, func(db *pgxpool.Pool) error {
+		limits, err = r.ExpenseClaimQuery.FindLimitsByGrade(c, db, salaryGrade)
+		return err
+	})
+
+	return limits, err
+}
+
+func (r *expenseClaimRepository) FindAllClaim(c context.Context, filter domain.ExpenseClaimQueryFilter) ([]domain.ExpenseClaim, error) {
+	var claims []domain.ExpenseClaim
+	var err error
+
+	// get all expense claims without transaction.
+	err = r.db.WithoutTransaction(c, func(db *pgxpool.Pool) error {
+		claims, err = r.ExpenseClaimQuery.FindAllClaim(c, db, filter)
+		return err
+	})
+
+	return claims, err
+}
+
+func (r *expenseClaimRepository) CountAllClaim(c context.Context, filter domain.ExpenseClaimQueryFilter) (int, error) {
+	var count int
+	var err error
+
+	// count all expense claims without transaction.
+	err = r.db.WithoutTransaction(c, func(db *pgxpool.Pool) error {
+		count, err = r.ExpenseClaimQuery.CountAllClaim(c, db, filter)
+		return err
+	})
+
+	return count, err
+}
+
+// find the expense claim by id with its items and its histories
+func (r *expenseClaimRepository) FindClaimById(c context.Context, id string) (domain.ExpenseClaim, error) {
+	var claim domain.ExpenseClaim
+	var err error
+
+	// get expense claim by id without transaction.
+	err = r.db.WithoutTransaction(c, func(db *pgxpool.Pool) error {
+		claim, err = r.ExpenseClaimQuery.FindClaimById(c, db, id)
+		if err != nil {
+			return err
+		}
+		claim.Items, err = r.ExpenseClaimQuery.FindItems(c, db, id)
+		if err != nil {
+			return err
+		}
+		claim.Histories, err = r.ExpenseClaimQuery.FindHistories(c, db, id)
+		return err
+	})
+
+	return claim, err
+}
+
+func (r *expenseClaimRepository) FindPayableClaims(c context.Context, payrollRunID string, approvedBefore time.Time) ([]domain.ExpenseClaim, error) {
+	var claims []domain.ExpenseClaim
+	var err error
+
+	// get the expense claims reimbursed in the payroll run without transaction.
+	err = r.db.WithoutTransaction(c, func(db *pgxpool.Pool) error {
+		claims, err = r.ExpenseClaimQuery.FindPayableClaims(c, db, payrollRunID, approvedBefore)
+		return err
+	})
+
+	return claims, err
+}
@@ -0,0 +1,187 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/iqbaludinm/hr-microservice/user-service/model/domain"
+	"github.com/iqbaludinm/hr-microservice/user-service/repository/query"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type HandoverRepository interface {
+	CreateHandover(c context.Context, handover *domain.Handover) error
+	UpdateHandover(c context.Context, handover domain.Handover) error
+	UpdateStatus(c context.Context, handover domain.Handover, signer *domain.HandoverSigner, status string) error
+	UpdateDocument(c context.Context, handover domain.Handover) error
+	FindAllHandover(c context.Context, filter domain.HandoverQueryFilter) ([]domain.Handover, error)
+	CountAllHandover(c context.Context, filter domain.HandoverQueryFilter) (int, error)
+	FindById(c context.Context, id string) (domain.Handover, error)
+}
+
+type handoverRepository struct {
+	db            Store
+	HandoverQuery query.HandoverQuery
+}
+
+func NewHandover(db Store, q query.HandoverQuery) HandoverRepository {
+	return &handoverRepository{
+		db:            db,
+		HandoverQuery: q,
+	}
+}
+
+// create the handover with its items and signers, the document number of the handover is taken from the
+// running number
+func (r *handoverRepository) CreateHandover(c context.Context, handover *domain.Handover) error {
+	var err error
+
+	// create transaction to create handover
+	err = r.db.WithTransaction(c, func(tx pgx.Tx) error {
+		// create handover, if error will rollback
+		return createHandover(c, tx, r.HandoverQuery, handover)
+	})
+
+	return err
+}
+
+// create the handover with its items and signers in the transaction, it is shared with the repositories that
+// produce a handover document
+func createHandover(c context.Context, tx pgx.Tx, q query.HandoverQuery, handover *domain.Handover) error {
+	number, err := q.NextDocumentNumber(c, tx)
+	if err != nil {
+		return err
+	}
+	handover.DocumentNumber = domain.HandoverDocumentNumber(handover.HandoverDate, number)
+
+	if err := q.CreateHandover(c, tx, *handover); err != nil {
+		return err
+	}
+	return createHandoverLines(c, tx, q, *handover)
+}
+
+// update the draft handover, the items and the signers are replaced
+func (r *handoverRepository) UpdateHandover(c context.Context, handover domain.Handover) error {
+	var err error
+
+	// create transaction to update handover
+	err = r.db.WithTransaction(c, func(tx pgx.Tx) error {
+		// update handover by id, if error will rollback
+		if err = r.HandoverQuery.UpdateHandover(c, tx, handover.ID, handover); err != nil {
+			return err
+		}
+		if err = r.HandoverQuery.DeleteItems(c, tx, handover.ID); err != nil {
+			return err
+		}
+		if err = r.HandoverQuery.DeleteSigners(c, tx, handover.ID); err != nil {
+			return err
+		}
+		return createHandoverLines(c, tx, r.HandoverQuery, handover)
+	})
+
+	return err
+}
+
+func createHandoverLines(c context.Context, tx pgx.Tx, q query.HandoverQuery, handover domain.Handover) error {
+	for _, item := range handover.Items {
+		item.HandoverID = handover.ID
+		if err := q.CreateItem(c, tx, item); err != nil {
+			return err
+		}
+	}
+	for _, signer := range handover.Signers {
+		signer.HandoverID = handover.ID
+		if err := q.CreateSigner(c, tx, signer); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// update the status of the handover which still has the given status and the signature of the signer, the signer is
+// only filled when the handover is signed or rejected
+func (r *handoverRepository) UpdateStatus(c context.Context, handover domain.Handover, signer *domain.HandoverSigner, status string) error {
+	var err error
+
+	// create transaction to update handover status
+	err = r.db.WithTransaction(c, func(tx pgx.Tx) error {
+		// update handover status by id, if error will rollback
+		if err = r.HandoverQuery.UpdateStatus(c, tx, handover.ID, handover, status); err != nil {
+			return err
+		}
+		if signer != nil {
+			if err = r.HandoverQuery.UpdateSigner(c, tx, *signer); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+
+	return err
+}
+
+func (r *handoverRepository) UpdateDocument(c context.Context, handover domain.Handover) error {
+	var err error
+
+	// create transaction to update handover document
+	err = r.db.WithTransaction(c, func(tx pgx.Tx) error {
+		// update handover document by id, if error will rollback
+		if err = r.HandoverQuery.UpdateDocument(c, tx, handover.ID, handover); err != nil {
+			return err
+		}
+		return nil
+	})
+
+	return err
+}
+
+func (r *handoverRepository) FindAllHandover(c context.Context, filter domain.HandoverQueryFilter) ([]domain.Handover, error) {
+	var handovers []domain.Handover
+	var err error
+
+	// get handovers without transaction.
+	err = r.db.WithoutTransaction(c, func(db *pgxpool.Pool) error {
+		if handovers, err = r.HandoverQuery.FindAllHandover(c, db, filter); err != nil {
+			return err
+		}
+		return nil
+	})
+
+	return handovers, err
+}
+
+func (r *handoverRepository) CountAllHandover(c context.Context, filter domain.HandoverQueryFilter) (int, error) {
+	var count int
+	var err error
+
+	// count handovers without transaction.
+	err = r.db.WithoutTransaction(c, func(db *pgxpool.Pool) error {
+		if count, err = r.HandoverQuery.CountAllHandover(c, db, filter); err != nil {
+			return err
+		}
+		return nil
+	})
+
+	return count, err
+}
+
+func (r *handoverRepository) FindById(c context.Context, id string) (domain.Handover, error) {
+	var handover domain.Handover
+	var err error
+
+	// get handover with its items and signers by id without transaction.
+	err = r.db.WithoutTransaction(c, func(db *pgxpool.Pool) error {
+		if handover, err = r.HandoverQuery.FindById(c, db, id); err != nil {
+			return err
+		}
+		if handover.Items, err = r.HandoverQuery.FindItems(c, db, id); err != nil {
+			return err
+		}
+		if handover.Signers, err = r.HandoverQuery.FindSigners(c, db, id); err != nil {
+			return err
+		}
+		return nil
+	})
+
+	return handover, err
+}
@@ -0,0 +1,107 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/iqbaludinm/hr-microservice/user-service/model/domain"
+	"github.com/iqbaludinm/hr-microservice/user-service/repository/query"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type HolidayRepository interface {
+	CreateHoliday(c context.Context, holiday domain.Holiday) error
+	ImportHolidays(c context.Context, holidays []domain.Holiday) error
+	Delete(c context.Context, id string) error
+	FindAllHoliday(c context.Context, filter domain.HolidayQueryFilter) ([]domain.Holiday, error)
+	FindById(c context.Context, id string) (domain.Holiday, error)
+}
+
+type holidayRepository struct {
+	db           Store
+	HolidayQuery query.HolidayQuery
+}
+
+func NewHoliday(db Store, q query.HolidayQuery) HolidayRepository {
+	return &holidayRepository{
+		db:           db,
+		HolidayQuery: q,
+	}
+}
+
+func (r *holidayRepository) CreateHoliday(c context.Context, holiday domain.Holiday) error {
+	var err error
+
+	// create transaction to create holiday
+	err = r.db.WithTransaction(c, func(tx pgx.Tx) error {
+		// create holiday, if error will rollback
+		if err = r.HolidayQuery.CreateHoliday(c, tx, holiday); err != nil {
+			return err
+		}
+		return nil
+	})
+
+	return err
+}
+
+func (r *holidayRepository) ImportHolidays(c context.Context, holidays []domain.Holiday) error {
+	var err error
+
+	// create transaction to import holidays, the file is imported entirely or not at all
+	err = r.db.WithTransaction(c, func(tx pgx.Tx) error {
+		for _, holiday := range holidays {
+			// upsert holiday, if error will rollback
+			if err = r.HolidayQuery.UpsertHoliday(c, tx, holiday); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+
+	return err
+}
+
+func (r *holidayRepository) Delete(c context.Context, id string) error {
+	var err error
+
+	// create transaction to delete holiday
+	err = r.db.WithTransaction(c, func(tx pgx.Tx) error {
+		// delete holiday by id, if error will rollback
+		if err = r.HolidayQuery.Delete(c, tx, id); err != nil {
+			return err
+		}
+		return nil
+	})
+
+	return err
+}
+
+func (r *holidayRepository) FindAllHoliday(c context.Context, filter domain.HolidayQueryFilter) ([]domain.Holiday, error) {
+	var holidays []domain.Holiday
+	var err error
+
+	// get holidays without transaction.
+	err = r.db.WithoutTransaction(c, func(db *pgxpool.Pool) error {
+		if holidays, err = r.HolidayQuery.FindAllHoliday(c, db, filter); err != nil {
+			return err
+		}
+		return nil
+	})
+
+	return holidays, err
+}
+
+func (r *holidayRepository) FindById(c context.Context, id string) (domain.Holiday, error) {
+	var holiday domain.Holiday
+	var err error
+
+	// get holiday by id without transaction.
+	err = r.db.WithoutTransaction(c, func(db *pgxpool.Pool) error {
+		if holiday, err = r.HolidayQuery.FindById(c, db, id); err != nil {
+			return err
+		}
+		return nil
+	})
+
+	return holiday, err
+}
@@ -0,0 +1,459 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/iqbaludinm/hr-microservice/user-service/model/domain"
+	"github.com/iqbaludinm/hr-microservice/user-service/repository/query"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type JobApplicationRepository interface {
+	CreateStage(c context.Context, stage domain.RecruitmentStage) error
+	UpdateStage(c context.Context, stage domain.RecruitmentStage) error
+	DeleteStage(c context.Context, id string) error
+	Apply(c context.Context, candidate domain.Candidate, newCandidate bool, application domain.JobApplication, move domain.JobApplicationMove) error
+	MoveStage(c context.Context, application domain.JobApplication, move domain.JobApplicationMove) error
+	UpdateApplicationStatus(c context.Context, application domain.JobApplication) error
+	Hire(c context.Context, application domain.JobApplication, user domain.User, employee domain.Employee, history domain.EmploymentHistory, posting *domain.JobPosting) error
+	CreateInterview(c context.Context, interview domain.Interview) error
+	UpdateInterviewStatus(c context.Context, interview domain.Interview) error
+	UpdateScorecard(c context.Context, interview domain.Interview) error
+	CreateOffer(c context.Context, offer domain.JobOffer) error
+	UpdateOfferStatus(c context.Context, offer domain.JobOffer) error
+	FindAllStage(c context.Context) ([]domain.RecruitmentStage, error)
+	FindStageById(c context.Context, id string) (domain.RecruitmentStage, error)
+	FindFirstStage(c context.Context) (domain.RecruitmentStage, error)
+	CountStageUsage(c context.Context, id string) (int, error)
+	FindCandidateByEmail(c context.Context, email string) (domain.Candidate, error)
+	FindAllApplication(c context.Context, filter domain.JobApplicationQueryFilter) ([]domain.JobApplication, error)
+	CountAllApplication(c context.Context, filter domain.JobApplicationQueryFilter) (int, error)
+	FindApplicationById(c context.Context, id string) (domain.JobApplication, error)
+	FindInterviewsByInterviewer(c context.Context, interviewerID string) ([]domain.Interview, error)
+	FindInterviewById(c context.Context, id string) (domain.Interview, error)
+	FindOfferById(c context.Context, id string) (domain.JobOffer, error)
+}
+
+type jobApplicationRepository struct {
+	db                     Store
+	JobApplicationQuery    query.JobApplicationQuery
+	JobRequisitionQuery    query.JobRequisitionQuery
+	UserQuery              query.UserQuery
+	EmployeeQuery          query.EmployeeQuery
+	EmploymentHistoryQuery query.EmploymentHistoryQuery
+}
+
+func NewJobApplication(db Store, q query.JobApplicationQuery, requisitionQuery query.JobRequisitionQuery, userQuery query.UserQuery, employeeQuery query.EmployeeQuery, historyQuery query.EmploymentHistoryQuery) JobApplicationRepository {
+	return &jobApplicationRepository{
+		db:                     db,
+		JobApplicationQuery:    q,
+		JobRequisitionQuery:    requisitionQuery,
+		UserQuery:              userQuery,
+		EmployeeQuery:          employeeQuery,
+		EmploymentHistoryQuery: historyQuery,
+	}
+}
+
+func (r *jobApplicationRepository) CreateStage(c context.Context, stage domain.RecruitmentStage) error {
+	var err error
+
+	// create transaction to create recruitment stage
+	err = r.db.WithTransaction(c, func(tx pgx.Tx) error {
+		// create recruitment stage, if error will rollback
+		if err = r.JobApplicationQuery.CreateStage(c, tx, stage); err != nil {
+			return err
+		}
+		return nil
+	})
+
+	return err
+}
+
+func (r *jobApplicationRepository) UpdateStage(c context.Context, stage domain.RecruitmentStage) error {
+	var err error
+
+	// create transaction to update recruitment stage
+	err = r.db.WithTransaction(c, func(tx pgx.Tx) error {
+		// update recruitment stage by id, if error will rollback
+		if err = r.JobApplicationQuery.UpdateStage(c, tx, stage.ID, stage); err != nil {
+			return err
+		}
+		return nil
+	})
+
+	return err
+}
+
+func (r *jobApplicationRepository) DeleteStage(c context.Context, id string) error {
+	var err error
+
+	// create transaction to delete recruitment stage
+	err = r.db.WithTransaction(c, func(tx pgx.Tx) error {
+		// delete recruitment stage by id, if error will rollback
+		if err = r.JobApplicationQuery.DeleteStage(c, tx, id); err != nil {
+			return err
+		}
+		return nil
+	})
+
+	return err
+}
+
+// create the application of the candidate at its first stage, the candidate is created when it applies for the first
+// time or updated otherwise
+func (r *jobApplicationRepository) Apply(c context.Context, candidate domain.Candidate, newCandidate bool, application domain.JobApplication, move domain.JobApplicationMove) error {
+	var err error
+
+	// create transaction to create job application
+	err = r.db.WithTransaction(c, func(tx pgx.Tx) error {
+		// create or update the candidate, if error will rollback
+		if newCandidate {
+			err = r.JobApplicationQuery.CreateCandidate(c, tx, candidate)
+		} else {
+			err = r.JobApplicationQuery.UpdateCandidate(c, tx, candidate.ID, candidate)
+		}
+		if err != nil {
+			return err
+		}
+		if err = r.JobApplicationQuery.CreateApplication(c, tx, application); err != nil {
+			return err
+		}
+		return r.JobApplicationQuery.CreateMove(c, tx, move)
+	})
+
+	return err
+}
+
+// move the application to another stage and record the move
+func (r *jobApplicationRepository) MoveStage(c context.Context, application domain.JobApplication, move domain.JobApplicationMove) error {
+	var err error
+
+	// create transaction to move job application
+	err = r.db.WithTransaction(c, func(tx pgx.Tx) error {
+		// update job application stage by id, if error will rollback
+		if err = r.JobApplicationQuery.UpdateApplicationStage(c, tx, application.ID, application); err != nil {
+			return err
+		}
+		return r.JobApplicationQuery.CreateMove(c, tx, move)
+	})
+
+	return err
+}
+
+func (r *jobApplicationRepository) UpdateApplicationStatus(c context.Context, application domain.JobApplication) error {
+	var err error
+
+	// create transaction to update job application status
+	err = r.db.WithTransaction(c, func(tx pgx.Tx) error {
+		// update job application status by id, if error will rollback
+		if err = r.JobApplicationQuery.UpdateApplicationStatus(c, tx, application.ID, application); err != nil {
+			return err
+		}
+		return nil
+	})
+
+	return err
+}
+
+// mark the application as hired and create the user and the employee of the candidate. The hire is counted on the
+// requisition in the same transaction, the given posting is closed when the hire fills the requisition.
+func (r *jobApplicationRepository) Hire(c context.Context, application domain.JobApplication, user domain.User, employee domain.Employee, history domain.EmploymentHistory, posting *domain.JobPosting) error {
+	var err error
+
+	// create transaction to hire the candidate
+	err = r.db.WithTransaction(c, func(tx pgx.Tx) error {
+		// create the user and the employee of the candidate, if error will rollback
+		if err = r.UserQuery.CreateUser(c, tx, user); err != nil {
+			return err
+		}
+		if err = r.EmployeeQuery.CreateEmployee(c, tx, employee); err != nil {
+			return err
+		}
+		if err = r.EmploymentHistoryQuery.SaveHistory(c, tx, history); err != nil {
+			return err
+		}
+		// update job application status by id, if error will rollback
+		if err = r.JobApplicationQuery.UpdateApplicationStatus(c, tx, application.ID, application); err != nil {
+			return err
+		}
+		// count the hire on the requisition, if error will rollback
+		var status string
+		if status, err = r.JobRequisitionQuery.IncrementHired(c, tx, application.RequisitionID, application.UpdatedAt); err != nil {
+			return err
+		}
+		if status == domain.JobRequisitionFilled && posting != nil {
+			return r.JobRequisitionQuery.UpdatePostingStatus(c, tx, posting.ID, *posting)
+		}
+		return nil
+	})
+
+	return err
+}
+
+func (r *jobApplicationRepository) CreateInterview(c context.Context, interview domain.Interview) error {
+	var err error
+
+	// create transaction to schedule interview
+	err = r.db.WithTransaction(c, func(tx pgx.Tx) error {
+		// create interview, if error will rollback
+		if err = r.JobApplicationQuery.CreateInterview(c, tx, interview); err != nil {
+			return err
+		}
+		return nil
+	})
+
+	return err
+}
+
+func (r *jobApplicationRepository) UpdateInterviewStatus(c context.Context, interview domain.Interview) error {
+	var err error
+
+	// create transaction to update interview status
+	err = r.db.WithTransaction(c, func(tx pgx.Tx) error {
+		// update interview status by id, if error will rollback
+		if err = r.JobApplicationQuery.UpdateInterviewStatus(c, tx, interview.ID, interview); err != nil {
+			return err
+		}
+		return nil
+	})
+
+	return err
+}
+
+// submit the scorecard of the interview, the items of the scorecard are replaced
+func (r *jobApplicationRepository) UpdateScorecard(c context.Context, interview domain.Interview) error {
+	var err error
+
+	// create transaction to submit scorecard
+	err = r.db.WithTransaction(c, func(tx pgx.Tx) error {
+		// update scorecard by id, if error will rollback
+		if err = r.JobApplicationQuery.UpdateScorecard(c, tx, interview.ID, interview); err != nil {
+			return err
+		}
+		if err = r.JobApplicationQuery.DeleteScorecardItems(c, tx, interview.ID); err != nil {
+			return err
+		}
+		for _, item := range interview.Items {
+			if err = r.JobApplicationQuery.CreateScorecardItem(c, tx, item); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+
+	return err
+}
+
+func (r *jobApplicationRepository) CreateOffer(c context.Context, offer domain.JobOffer) error {
+	var err error
+
+	// create transaction to create job offer
+	err = r.db.WithTransaction(c, func(tx pgx.Tx) error {
+		// create job offer, if error will rollback
+		if err = r.JobApplicationQuery.CreateOffer(c, tx, offer); err != nil {
+			return err
+		}
+		return nil
+	})
+
+	return err
+}
+
+func (r *jobApplicationRepository) UpdateOfferStatus(c context.Context, offer domain.JobOffer) error {
+	var err error
+
+	// create transaction to update job offer status
+	err = r.db.WithTransaction(c, func(tx pgx.Tx) error {
+		// update job offer status by id, if error will rollback
+		if err = r.JobApplicationQuery.UpdateOfferStatus(c, tx, offer.ID, offer); err != nil {
+			return err
+		}
+		return nil
+	})
+
+	return err
+}
+
+func (r *jobApplicationRepository) FindAllStage(c context.Context) ([]domain.RecruitmentStage, error) {
+	var stages []domain.RecruitmentStage
+	var err error
+
+	// get recruitment stages without transaction.
+	err = r.db.WithoutTransaction(c, func(db *pgxpool.Pool) error {
+		if stages, err = r.JobApplicationQuery.FindAllStage(c, db); err != nil {
+			return err
+		}
+		return nil
+	})
+
+	return stages, err
+}
+
+func (r *jobApplicationRepository) FindStageById(c context.Context, id string) (domain.RecruitmentStage, error) {
+	var stage domain.RecruitmentStage
+	var err error
+
+	// get recruitment stage by id without transaction.
+	err = r.db.WithoutTransaction(c, func(db *pgxpool.Pool) error {
+		if stage, err = r.JobApplicationQuery.FindStageById(c, db, id); err != nil {
+			return err
+		}
+		return nil
+	})
+
+	return stage, err
+}
+
+func (r *jobApplicationRepository) FindFirstStage(c context.Context) (domain.RecruitmentStage, error) {
+	var stage domain.RecruitmentStage
+	var err error
+
+	// get the first recruitment stage without transaction.
+	err = r.db.WithoutTransaction(c, func(db *pgxpool.Pool) error {
+		if stage, err = r.JobApplicationQuery.FindFirstStage(c, db); err != nil {
+			return err
+		}
+		return nil
+	})
+
+	return stage, err
+}
+
+func (r *jobApplicationRepository) CountStageUsage(c context.Context, id string) (int, error) {
+	var count int
+	var err error
+
+	// count the usage of the recruitment stage without transaction.
+	err = r.db.WithoutTransaction(c, func(db *pgxpool.Pool) error {
+		if count, err = r.JobApplicationQuery.CountStageUsage(c, db, id); err != nil {
+			return err
+		}
+		return nil
+	})
+
+	return count, err
+}
+
+func (r *jobApplicationRepository) FindCandidateByEmail(c context.Context, email string) (domain.Candidate, error) {
+	var candidate domain.Candidate
+	var err error
+
+	// get candidate by email without transaction.
+	err = r.db.WithoutTransaction(c, func(db *pgxpool.Pool) error {
+		if candidate, err = r.JobApplicationQuery.FindCandidateByEmail(c, db, email); err != nil {
+			return err
+		}
+		return nil
+	})
+
+	return candidate, err
+}
+
+func (r *jobApplicationRepository) FindAllApplication(c context.Context, filter domain.JobApplicationQueryFilter) ([]domain.JobApplication, error) {
+	var applications []domain.JobApplication
+	var err error
+
+	// get job applications without transaction.
+	err = r.db.WithoutTransaction(c, func(db *pgxpool.Pool) error {
+		if applications, err = r.JobApplicationQuery.FindAllApplication(c, db, filter); err != nil {
+			return err
+		}
+		return nil
+	})
+
+	return applications, err
+}
+
+func (r *jobApplicationRepository) CountAllApplication(c context.Context, filter domain.JobApplicationQueryFilter) (int, error) {
+	var count int
+	var err error
+
+	// count job applications without transaction.
+	err = r.db.WithoutTransaction(c, func(db *pgxpool.Pool) error {
+		if count, err = r.JobApplicationQuery.CountAllApplication(c, db, filter); err != nil {
+			return err
+		}
+		return nil
+	})
+
+	return count, err
+}
+
+func (r *jobApplicationRepository) FindApplicationById(c context.Context, id string) (domain.JobApplication, error) {
+	var application domain.JobApplication
+	var err error
+
+	// get job application with its moves, interviews and offers by id without transaction.
+	err = r.db.WithoutTransaction(c, func(db *pgxpool.Pool) error {
+		if application, err = r.JobApplicationQuery.FindApplicationById(c, db, id); err != nil {
+			return err
+		}
+		if application.Moves, err = r.JobApplicationQuery.FindMoves(c, db, id); err != nil {
+			return err
+		}
+		if application.Interviews, err = r.JobApplicationQuery.FindInterviews(c, db, id); err != nil {
+			return err
+		}
+		for i := range application.Interviews {
+			interview := &application.Interviews[i]
+			if interview.Items, err = r.JobApplicationQuery.FindScorecardItems(c, db, interview.ID); err != nil {
+				return err
+			}
+		}
+		if application.Offers, err = r.JobApplicationQuery.FindOffers(c, db, id); err != nil {
+			return err
+		}
+		return nil
+	})
+
+	return application, err
+}
+
+func (r *jobApplicationRepository) FindInterviewsByInterviewer(c context.Context, interviewerID string) ([]domain.Interview, error) {
+	var interviews []domain.Interview
+	var err error
+
+	// get the scheduled interviews of the interviewer without transaction.
+	err = r.db.WithoutTransaction(c, func(db *pgxpool.Pool) error {
+		if interviews, err = r.JobApplicationQuery.FindInterviewsByInterviewer(c, db, interviewerID); err != nil {
+			return err
+		}
+		return nil
+	})
+
+	return interviews, err
+}
+
+func (r *jobApplicationRepository) FindInterviewById(c context.Context, id string) (domain.Interview, error) {
+	var interview domain.Interview
+	var err error
+
+	// get interview with its scorecard by id without transaction.
+	err = r.db.WithoutTransaction(c, func(db *pgxpool.Pool) error {
+		if interview, err = r.JobApplicationQuery.FindInterviewById(c, db, id); err != nil {
+			return err
+		}
+		if interview.Items, err = r.JobApplicationQuery.FindScorecardItems(c, db, id); err != nil {
+			return err
+		}
+		return nil
+	})
+
+	return interview, err
+}
+
+func (r *jobApplicationRepository) FindOfferById(c context.Context, id string) (domain.JobOffer, error) {
+	var offer domain.JobOffer
+	var err error
+
+	// get job offer by id without transaction.
+	err = r.db.WithoutTransaction(c, func(db *pgxpool.Pool) error {
+		if offer, err = r.JobApplicationQuery.FindOfferById(c, db, id); err != nil {
+			return err
+		}
+		return nil
+	})
+
+	return offer, err
+}
@@ -0,0 +1,233 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/iqbaludinm/hr-microservice/user-service/model/domain"
+	"github.com/iqbaludinm/hr-microservice/user-service/repository/query"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type JobRequisitionRepository interface {
+	CreateRequisition(c context.Context, requisition domain.JobRequisition) error
+	UpdateRequisition(c context.Context, requisition domain.JobRequisition) error
+	UpdateRequisitionStatus(c context.Context, requisition domain.JobRequisition) error
+	CreatePosting(c context.Context, posting domain.JobPosting) error
+	UpdatePostingStatus(c context.Context, posting domain.JobPosting) error
+	CloseExpiredPostings(c context.Context, today time.Time) (int64, error)
+	FindAllRequisition(c context.Context, filter domain.JobRequisitionQueryFilter) ([]domain.JobRequisition, error)
+	CountAllRequisition(c context.Context, filter domain.JobRequisitionQueryFilter) (int, error)
+	FindRequisitionById(c context.Context, id string) (domain.JobRequisition, error)
+	FindAllPosting(c context.Context, filter domain.JobPostingQueryFilter) ([]domain.JobPosting, error)
+	CountAllPosting(c context.Context, filter domain.JobPostingQueryFilter) (int, error)
+	FindPostingById(c context.Context, id string) (domain.JobPosting, error)
+}
+
+type jobRequisitionRepository struct {
+	db                  Store
+	JobRequisitionQuery query.JobRequisitionQuery
+}
+
+func NewJobRequisition(db Store, q query.JobRequisitionQuery) JobRequisitionRepository {
+	return &jobRequisitionRepository{
+		db:                  db,
+		JobRequisitionQuery: q,
+	}
+}
+
+func (r *jobRequisitionRepository) CreateRequisition(c context.Context, requisition domain.JobRequisition) error {
+	var err error
+
+	// create transaction to create job requisition
+	err = r.db.WithTransaction(c, func(tx pgx.Tx) error {
+		// create job requisition, if error will rollback
+		if err = r.JobRequisitionQuery.CreateRequisition(c, tx, requisition); err != nil {
+			return err
+		}
+		return nil
+	})
+
+	return err
+}
+
+func (r *jobRequisitionRepository) UpdateRequisition(c context.Context, requisition domain.JobRequisition) error {
+	var err error
+
+	// create transaction to update job requisition
+	err = r.db.WithTransaction(c, func(tx pgx.Tx) error {
+		// update job requisition by id, if error will rollback
+		if err = r.JobRequisitionQuery.UpdateRequisition(c, tx, requisition.ID, requisition); err != nil {
+			return err
+		}
+		return nil
+	})
+
+	return err
+}
+
+// update the status of the requisition, the status of its posting is updated too when the requisition has one
+func (r *jobRequisitionRepository) UpdateRequisitionStatus(c context.Context, requisition domain.JobRequisition) error {
+	var err error
+
+	// create transaction to update job requisition status
+	err = r.db.WithTransaction(c, func(tx pgx.Tx) error {
+		// update job requisition status by id, if error will rollback
+		if err = r.JobRequisitionQuery.UpdateRequisitionStatus(c, tx, requisition.ID, requisition); err != nil {
+			return err
+		}
+		if requisition.Posting != nil {
+			return r.JobRequisitionQuery.UpdatePostingStatus(c, tx, requisition.Posting.ID, *requisition.Posting)
+		}
+		return nil
+	})
+
+	return err
+}
+
+func (r *jobRequisitionRepository) CreatePosting(c context.Context, posting domain.JobPosting) error {
+	var err error
+
+	// create transaction to publish job posting
+	err = r.db.WithTransaction(c, func(tx pgx.Tx) error {
+		// create job posting, if error will rollback
+		if err = r.JobRequisitionQuery.CreatePosting(c, tx, posting); err != nil {
+			return err
+		}
+		return nil
+	})
+
+	return err
+}
+
+func (r *jobRequisitionRepository) UpdatePostingStatus(c context.Context, posting domain.JobPosting) error {
+	var err error
+
+	// create transaction to update job posting status
+	err = r.db.WithTransaction(c, func(tx pgx.Tx) error {
+		// update job posting status by id, if error will rollback
+		if err = r.JobRequisitionQuery.UpdatePostingStatus(c, tx, posting.ID, posting); err != nil {
+			return err
+		}
+		return nil
+	})
+
+	return err
+}
+
+func (r *jobRequisitionRepository) CloseExpiredPostings(c context.Context, today time.Time) (int64, error) {
+	var closed int64
+	var err error
+
+	// create transaction to close the expired job postings
+	err = r.db.WithTransaction(c, func(tx pgx.Tx) error {
+		// close the job postings which closing date has passed, if error will rollback
+		if closed, err = r.JobRequisitionQuery.CloseExpiredPostings(c, tx, today); err != nil {
+			return err
+		}
+		return nil
+	})
+
+	return closed, err
+}
+
+func (r *jobRequisitionRepository) FindAllRequisition(c context.Context, filter domain.JobRequisitionQueryFilter) ([]domain.JobRequisition, error) {
+	var requisitions []domain.JobRequisition
+	var err error
+
+	// get job requisitions without transaction.
+	err = r.db.WithoutTransaction(c, func(db *pgxpool.Pool) error {
+		if requisitions, err = r.JobRequisitionQuery.FindAllRequisition(c, db, filter); err != nil {
+			return err
+		}
+		return nil
+	})
+
+	return requisitions, err
+}
+
+func (r *jobRequisitionRepository) CountAllRequisition(c context.Context, filter domain.JobRequisitionQueryFilter) (int, error) {
+	var count int
+	var err error
+
+	// count job requisitions without transaction.
+	err = r.db.WithoutTransaction(c, func(db *pgxpool.Pool) error {
+		if count, err = r.JobRequisitionQuery.CountAllRequisition(c, db, filter); err != nil {
+			return err
+		}
+		return nil
+	})
+
+	return count, err
+}
+
+func (r *jobRequisitionRepository) FindRequisitionById(c context.Context, id string) (domain.JobRequisition, error) {
+	var requisition domain.JobRequisition
+	var err error
+
+	// get job requisition with its posting by id without transaction.
+	err = r.db.WithoutTransaction(c, func(db *pgxpool.Pool) error {
+		if requisition, err = r.JobRequisitionQuery.FindRequisitionById(c, db, id); err != nil {
+			return err
+		}
+
+		// the requisition has no posting until it is published
+		posting, err := r.JobRequisitionQuery.FindPostingByRequisition(c, db, id)
+		if err == pgx.ErrNoRows {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		requisition.Posting = &posting
+		return nil
+	})
+
+	return requisition, err
+}
+
+func (r *jobRequisitionRepository) FindAllPosting(c context.Context, filter domain.JobPostingQueryFilter) ([]domain.JobPosting, error) {
+	var postings []domain.JobPosting
+	var err error
+
+	// get job postings without transaction.
+	err = r.db.WithoutTransaction(c, func(db *pgxpool.Pool) error {
+		if postings, err = r.JobRequisitionQuery.FindAllPosting(c, db, filter); err != nil {
+			return err
+		}
+		return nil
+	})
+
+	return postings, err
+}
+
+func (r *jobRequisitionRepository) CountAllPosting(c context.Context, filter domain.JobPostingQueryFilter) (int, error) {
+	var count int
+	var err error
+
+	// count job postings without transaction.
+	err = r.db.WithoutTransaction(c, func(db *pgxpool.Pool) error {
+		if count, err = r.JobRequisitionQuery.CountAllPosting(c, db, filter); err != nil {
+			return err
+		}
+		return nil
+	})
+
+	return count, err
+}
+
+func (r *jobRequisitionRepository) FindPostingById(c context.Context, id string) (domain.JobPosting, error) {
+	var posting domain.JobPosting
+	var err error
+
+	// get job posting by id without transaction.
+	err = r.db.WithoutTransaction(c, func(db *pgxpool.Pool) error {
+		if posting, err = r.JobRequisitionQuery.FindPostingById(c, db, id); err != nil {
+			return err
+		}
+		return nil
+	})
+
+	return posting, err
+}
@@ -0,0 +1,185 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/iqbaludinm/hr-microservice/user-service/model/domain"
+	"github.com/iqbaludinm/hr-microservice/user-service/repository/query"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type LeaveRepository interface {
+	CreateLeave(c context.Context, leave domain.Leave, balance domain.LeaveBalance, check func(balance domain.LeaveBalance, overlaps int) error) error
+	UpdateStatus(c context.Context, leave domain.Leave, status string, used, pending float64) error
+	FindAllLeave(c context.Context, filter domain.LeaveQueryFilter) ([]domain.Leave, error)
+	CountAllLeave(c context.Context, filter domain.LeaveQueryFilter) (int, error)
+	FindById(c context.Context, id string) (domain.Leave, error)
+	CountOverlap(c context.Context, employeeID string, startDate, endDate time.Time) (int, error)
+	FindBalance(c context.Context, employeeID, leaveTypeID string, year int) (domain.LeaveBalance, error)
+	FindAllBalance(c context.Context, employeeID string, year int) ([]domain.LeaveBalance, error)
+}
+
+type leaveRepository struct {
+	db                Store
+	LeaveQuery        query.LeaveQuery
+	LeaveBalanceQuery query.LeaveBalanceQuery
+}
+
+func NewLeave(db Store, q query.LeaveQuery, balanceQuery query.LeaveBalanceQuery) LeaveRepository {
+	return &leaveRepository{
+		db:                db,
+		LeaveQuery:        q,
+		LeaveBalanceQuery: balanceQuery,
+	}
+}
+
+// create the leave request and reserve its days as pending in the balance of the year.
+// The balance is locked before the check is called with the stored balance and the number of overlapping
+// leave requests, so concurrent leave requests can't both pass it.
+func (r *leaveRepository) CreateLeave(c context.Context, leave domain.Leave, balance domain.LeaveBalance, check func(balance domain.LeaveBalance, overlaps int) error) error {
+	var err error
+
+	// create transaction to create leave request
+	err = r.db.WithTransaction(c, func(tx pgx.Tx) error {
+		// create the balance of the year if it does not exist yet, if error will rollback
+		if err = r.LeaveBalanceQuery.CreateBalance(c, tx, balance); err != nil {
+			return err
+		}
+		// lock the balance and check it with the overlapping leave requests, if error will rollback
+		locked, err := r.LeaveBalanceQuery.FindBalanceForUpdate(c, tx, leave.EmployeeID, leave.LeaveTypeID, balance.Year)
+		if err != nil {
+			return err
+		}
+		overlaps, err := r.LeaveQuery.CountOverlap(c, tx, leave.EmployeeID, leave.StartDate, leave.EndDate)
+		if err != nil {
+			return err
+		}
+		if err = check(locked, overlaps); err != nil {
+			return err
+		}
+		// reserve the days of the leave request, if error will rollback
+		if err = r.LeaveBalanceQuery.AdjustBalance(c, tx, leave.EmployeeID, leave.LeaveTypeID, balance.Year, 0, leave.Days); err != nil {
+			return err
+		}
+		// create leave request, if error will rollback
+		if err = r.LeaveQuery.CreateLeave(c, tx, leave); err != nil {
+			return err
+		}
+		return nil
+	})
+
+	return err
+}
+
+// update the status of the leave request that still has the given status and move its days in the balance of the year,
+// e.g. an approval moves the days from 'pending' to 'used'. The balance is left as it is when the status has changed.
+func (r *leaveRepository) UpdateStatus(c context.Context, leave domain.Leave, status string, used, pending float64) error {
+	var err error
+
+	// create transaction to update leave request status
+	err = r.db.WithTransaction(c, func(tx pgx.Tx) error {
+		// update leave request status by id, if error will rollback
+		if err = r.LeaveQuery.UpdateStatus(c, tx, leave.ID, leave, status); err != nil {
+			return err
+		}
+		// adjust the balance, if error will rollback
+		if err = r.LeaveBalanceQuery.AdjustBalance(c, tx, leave.EmployeeID, leave.LeaveTypeID, leave.StartDate.Year(), used, pending); err != nil {
+			return err
+		}
+		return nil
+	})
+
+	return err
+}
+
+func (r *leaveRepository) FindAllLeave(c context.Context, filter domain.LeaveQueryFilter) ([]domain.Leave, error) {
+	var leaves []domain.Leave
+	var err error
+
+	// get leave requests without transaction.
+	err = r.db.WithoutTransaction(c, func(db *pgxpool.Pool) error {
+		if leaves, err = r.LeaveQuery.FindAllLeave(c, db, filter); err != nil {
+			return err
+		}
+		return nil
+	})
+
+	return leaves, err
+}
+
+func (r *leaveRepository) CountAllLeave(c context.Context, filter domain.LeaveQueryFilter) (int, error) {
+	var count int
+	var err error
+
+	// count leave requests without transaction.
+	err = r.db.WithoutTransaction(c, func(db *pgxpool.Pool) error {
+		if count, err = r.LeaveQuery.CountAllLeave(c, db, filter); err != nil {
+			return err
+		}
+		return nil
+	})
+
+	return count, err
+}
+
+func (r *leaveRepository) FindById(c context.Context, id string) (domain.Leave, error) {
+	var leave domain.Leave
+	var err error
+
+	// get leave request by id without transaction.
+	err = r.db.WithoutTransaction(c, func(db *pgxpool.Pool) error {
+		if leave, err = r.LeaveQuery.FindById(c, db, id); err != nil {
+			return err
+		}
+		return nil
+	})
+
+	return leave, err
+}
+
+func (r *leaveRepository) CountOverlap(c context.Context, employeeID string, startDate, endDate time.Time) (int, error) {
+	var count int
+	var err error
+
+	// count overlapping leave requests without transaction.
+	err = r.db.WithoutTransaction(c, func(db *pgxpool.Pool) error {
+		if count, err = r.LeaveQuery.CountOverlap(c, db, employeeID, startDate, endDate); err != nil {
+			return err
+		}
+		return nil
+	})
+
+	return count, err
+}
+
+func (r *leaveRepository) FindBalance(c context.Context, employeeID, leaveTypeID string, year int) (domain.LeaveBalance, error) {
+	var balance domain.LeaveBalance
+	var err error
+
+	// get leave balance without transaction.
+	err = r.db.WithoutTransaction(c, func(db *pgxpool.Pool) error {
+		if balance, err = r.LeaveBalanceQuery.FindBalance(c, db, employeeID, leaveTypeID, year); err != nil {
+			return err
+		}
+		return nil
+	})
+
+	return balance, err
+}
+
+func (r *leaveRepository) FindAllBalance(c context.Context, employeeID string, year int) ([]domain.LeaveBalance, error) {
+	var balances []domain.LeaveBalance
+	var err error
+
+	// get leave balances without transaction.
+	err = r.db.WithoutTransaction(c, func(db *pgxpool.Pool) error {
+		if balances, err = r.LeaveBalanceQuery.FindAllBalance(c, db, employeeID, year); err != nil {
+			return err
+		}
+		return nil
+	})
+
+	return balances, err
+}
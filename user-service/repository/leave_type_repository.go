@@ -0,0 +1,105 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/iqbaludinm/hr-microservice/user-service/model/domain"
+	"github.com/iqbaludinm/hr-microservice/user-service/repository/query"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type LeaveTypeRepository interface {
+	CreateLeaveType(c context.Context, leaveType domain.LeaveType) error
+	UpdateLeaveType(c context.Context, id string, leaveType domain.LeaveType) error
+	Delete(c context.Context, id string) error
+	FindAllLeaveType(c context.Context) ([]domain.LeaveType, error)
+	FindById(c context.Context, id string) (domain.LeaveType, error)
+}
+
+type leaveTypeRepository struct {
+	db             Store
+	LeaveTypeQuery query.LeaveTypeQuery
+}
+
+func NewLeaveType(db Store, q query.LeaveTypeQuery) LeaveTypeRepository {
+	return &leaveTypeRepository{
+		db:             db,
+		LeaveTypeQuery: q,
+	}
+}
+
+func (r *leaveTypeRepository) CreateLeaveType(c context.Context, leaveType domain.LeaveType) error {
+	var err error
+
+	// create transaction to create leave type
+	err = r.db.WithTransaction(c, func(tx pgx.Tx) error {
+		// create leave type, if error will rollback
+		if err = r.LeaveTypeQuery.CreateLeaveType(c, tx, leaveType); err != nil {
+			return err
+		}
+		return nil
+	})
+
+	return err
+}
+
+func (r *leaveTypeRepository) UpdateLeaveType(c context.Context, id string, leaveType domain.LeaveType) error {
+	var err error
+
+	// create transaction to update leave type
+	err = r.db.WithTransaction(c, func(tx pgx.Tx) error {
+		// update leave type by id, if error will rollback
+		if err = r.LeaveTypeQuery.UpdateLeaveType(c, tx, id, leaveType); err != nil {
+			return err
+		}
+		return nil
+	})
+
+	return err
+}
+
+func (r *leaveTypeRepository) Delete(c context.Context, id string) error {
+	var err error
+
+	// create transaction to delete leave type
+	err = r.db.WithTransaction(c, func(tx pgx.Tx) error {
+		// delete leave type by id, if error will rollback
+		if err = r.LeaveTypeQuery.Delete(c, tx, id); err != nil {
+			return err
+		}
+		return nil
+	})
+
+	return err
+}
+
+func (r *leaveTypeRepository) FindAllLeaveType(c context.Context) ([]domain.LeaveType, error) {
+	var leaveTypes []domain.LeaveType
+	var err error
+
+	// get leave types without transaction.
+	err = r.db.WithoutTransaction(c, func(db *pgxpool.Pool) error {
+		if leaveTypes, err = r.LeaveTypeQuery.FindAllLeaveType(c, db); err != nil {
+			return err
+		}
+		return nil
+	})
+
+	return leaveTypes, err
+}
+
+func (r *leaveTypeRepository) FindById(c context.Context, id string) (domain.LeaveType, error) {
+	var leaveType domain.LeaveType
+	var err error
+
+	// get leave type by id without transaction.
+	err = r.db.WithoutTransaction(c, func(db *pgxpool.Pool) error {
+		if leaveType, err = r.LeaveTypeQuery.FindById(c, db, id); err != nil {
+			return err
+		}
+		return nil
+	})
+
+	return leaveType, err
+}
@@ -0,0 +1,238 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/iqbaludinm/hr-microservice/user-service/model/domain"
+	"github.com/iqbaludinm/hr-microservice/user-service/repository/query"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type OffboardingRepository interface {
+	CreateOffboarding(c context.Context, offboarding domain.Offboarding) error
+	UpdateOffboarding(c context.Context, offboarding domain.Offboarding, shiftDays int) error
+	UpdateStatus(c context.Context, offboarding domain.Offboarding) error
+	UpdateTask(c context.Context, task domain.OffboardingTask, offboarding domain.Offboarding) error
+	UpdateDeactivated(c context.Context, id string, deactivatedAt time.Time) error
+	UpdateClearanceDocument(c context.Context, id string, key string) error
+	FindAll(c context.Context, filter domain.OffboardingQueryFilter) ([]domain.Offboarding, error)
+	CountAll(c context.Context, filter domain.OffboardingQueryFilter) (int, error)
+	FindById(c context.Context, id string) (domain.Offboarding, error)
+	FindByEmployee(c context.Context, employeeID string) (domain.Offboarding, error)
+	FindTasksByAssignee(c context.Context, assigneeID string) ([]domain.OffboardingTask, error)
+	FindDue(c context.Context, today time.Time) ([]domain.Offboarding, error)
+}
+
+type offboardingRepository struct {
+	db               Store
+	OffboardingQuery query.OffboardingQuery
+}
+
+func NewOffboarding(db Store, q query.OffboardingQuery) OffboardingRepository {
+	return &offboardingRepository{
+		db:               db,
+		OffboardingQuery: q,
+	}
+}
+
+// create the offboarding with its exit tasks
+func (r *offboardingRepository) CreateOffboarding(c context.Context, offboarding domain.Offboarding) error {
+	var err error
+
+	// create transaction to create offboarding
+	err = r.db.WithTransaction(c, func(tx pgx.Tx) error {
+		// create offboarding, if error will rollback
+		if err = r.OffboardingQuery.CreateOffboarding(c, tx, offboarding); err != nil {
+			return err
+		}
+		// create the exit tasks of the offboarding, if error will rollback
+		for _, task := range offboarding.Tasks {
+			task.OffboardingID = offboarding.ID
+			if err = r.OffboardingQuery.CreateTask(c, tx, task); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+
+	return err
+}
+
+// update the offboarding, the due date of the pending tasks is shifted by the change of the last working day
+func (r *offboardingRepository) UpdateOffboarding(c context.Context, offboarding domain.Offboarding, shiftDays int) error {
+	var err error
+
+	// create transaction to update offboarding
+	err = r.db.WithTransaction(c, func(tx pgx.Tx) error {
+		// update offboarding by id, if error will rollback
+		if err = r.OffboardingQuery.UpdateOffboarding(c, tx, offboarding.ID, offboarding); err != nil {
+			return err
+		}
+		if shiftDays == 0 {
+			return nil
+		}
+		return r.OffboardingQuery.ShiftTaskDueDates(c, tx, offboarding.ID, shiftDays)
+	})
+
+	return err
+}
+
+func (r *offboardingRepository) UpdateStatus(c context.Context, offboarding domain.Offboarding) error {
+	var err error
+
+	// create transaction to update offboarding status
+	err = r.db.WithTransaction(c, func(tx pgx.Tx) error {
+		// update offboarding status by id, if error will rollback
+		if err = r.OffboardingQuery.UpdateStatus(c, tx, offboarding.ID, offboarding); err != nil {
+			return err
+		}
+		return nil
+	})
+
+	return err
+}
+
+// update the task together with the status of the offboarding, the offboarding is completed by its last task
+func (r *offboardingRepository) UpdateTask(c context.Context, task domain.OffboardingTask, offboarding domain.Offboarding) error {
+	var err error
+
+	// create transaction to update offboarding task
+	err = r.db.WithTransaction(c, func(tx pgx.Tx) error {
+		// update task, if error will rollback
+		if err = r.OffboardingQuery.UpdateTask(c, tx, task); err != nil {
+			return err
+		}
+		// update offboarding status by id, if error will rollback
+		if err = r.OffboardingQuery.UpdateStatus(c, tx, offboarding.ID, offboarding); err != nil {
+			return err
+		}
+		return nil
+	})
+
+	return err
+}
+
+func (r *offboardingRepository) UpdateDeactivated(c context.Context, id string, deactivatedAt time.Time) error {
+	var err error
+
+	// create transaction to mark offboarding as deactivated
+	err = r.db.WithTransaction(c, func(tx pgx.Tx) error {
+		// update offboarding deactivated time, if error will rollback
+		if err = r.OffboardingQuery.UpdateDeactivated(c, tx, id, deactivatedAt); err != nil {
+			return err
+		}
+		return nil
+	})
+
+	return err
+}
+
+func (r *offboardingRepository) UpdateClearanceDocument(c context.Context, id string, key string) error {
+	var err error
+
+	// create transaction to update clearance document of offboarding
+	err = r.db.WithTransaction(c, func(tx pgx.Tx) error {
+		// update clearance document key, if error will rollback
+		if err = r.OffboardingQuery.UpdateClearanceDocument(c, tx, id, key); err != nil {
+			return err
+		}
+		return nil
+	})
+
+	return err
+}
+
+func (r *offboardingRepository) FindAll(c context.Context, filter domain.OffboardingQueryFilter) ([]domain.Offboarding, error) {
+	var offboardings []domain.Offboarding
+	var err error
+
+	// get offboardings without transaction.
+	err = r.db.WithoutTransaction(c, func(db *pgxpool.Pool) error {
+		if offboardings, err = r.OffboardingQuery.FindAll(c, db, filter); err != nil {
+			return err
+		}
+		return nil
+	})
+
+	return offboardings, err
+}
+
+func (r *offboardingRepository) CountAll(c context.Context, filter domain.OffboardingQueryFilter) (int, error) {
+	var count int
+	var err error
+
+	// count offboardings without transaction.
+	err = r.db.WithoutTransaction(c, func(db *pgxpool.Pool) error {
+		if count, err = r.OffboardingQuery.CountAll(c, db, filter); err != nil {
+			return err
+		}
+		return nil
+	})
+
+	return count, err
+}
+
+func (r *offboardingRepository) FindById(c context.Context, id string) (domain.Offboarding, error) {
+	var offboarding domain.Offboarding
+	var err error
+
+	// get offboarding by id with its tasks without transaction.
+	err = r.db.WithoutTransaction(c, func(db *pgxpool.Pool) error {
+		if offboarding, err = r.OffboardingQuery.FindById(c, db, id); err != nil {
+			return err
+		}
+		if offboarding.Tasks, err = r.OffboardingQuery.FindTasks(c, db, offboarding.ID); err != nil {
+			return err
+		}
+		return nil
+	})
+
+	return offboarding, err
+}
+
+func (r *offboardingRepository) FindByEmployee(c context.Context, employeeID string) (domain.Offboarding, error) {
+	var offboarding domain.Offboarding
+	var err error
+
+	// get the offboarding of the employee without transaction.
+	err = r.db.WithoutTransaction(c, func(db *pgxpool.Pool) error {
+		if offboarding, err = r.OffboardingQuery.FindByEmployee(c, db, employeeID); err != nil {
+			return err
+		}
+		return nil
+	})
+
+	return offboarding, err
+}
+
+func (r *offboardingRepository) FindTasksByAssignee(c context.Context, assigneeID string) ([]domain.OffboardingTask, error) {
+	var tasks []domain.OffboardingTask
+	var err error
+
+	// get the exit tasks of the assignee without transaction.
+	err = r.db.WithoutTransaction(c, func(db *pgxpool.Pool) error {
+		if tasks, err = r.OffboardingQuery.FindTasksByAssignee(c, db, assigneeID); err != nil {
+			return err
+		}
+		return nil
+	})
+
+	return tasks, err
+}
+
+func (r *offboardingRepository) FindDue(c context.Context, today time.Time) ([]domain.Offboarding, error) {
+	var offboardings []domain.Offboarding
+	var err error
+
+	// get the offboardings to deactivate without transaction.
+	err = r.db.WithoutTransaction(c, func(db *pgxpool.Pool) error {
+		if offboardings, err = r.OffboardingQuery.FindDue(c, db, today); err != nil {
+			return err
+		}
+		return nil
+	})
+
+	return offboardings, err
+}
@@ -0,0 +1,121 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/iqbaludinm/hr-microservice/user-service/model/domain"
+	"github.com/iqbaludinm/hr-microservice/user-service/repository/query"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type OfficeLocationRepository interface {
+	CreateOfficeLocation(c context.Context, location domain.OfficeLocation) error
+	UpdateOfficeLocation(c context.Context, id string, location domain.OfficeLocation) error
+	Delete(c context.Context, id string) error
+	FindAllOfficeLocation(c context.Context) ([]domain.OfficeLocation, error)
+	FindById(c context.Context, id string) (domain.OfficeLocation, error)
+	AssignEmployees(c context.Context, id string, employeeIDs []string) error
+}
+
+type officeLocationRepository struct {
+	db                  Store
+	OfficeLocationQuery query.OfficeLocationQuery
+}
+
+func NewOfficeLocation(db Store, q query.OfficeLocationQuery) OfficeLocationRepository {
+	return &officeLocationRepository{
+		db:                  db,
+		OfficeLocationQuery: q,
+	}
+}
+
+func (r *officeLocationRepository) CreateOfficeLocation(c context.Context, location domain.OfficeLocation) error {
+	var err error
+
+	// create transaction to create office location
+	err = r.db.WithTransaction(c, func(tx pgx.Tx) error {
+		// create office location, if error will rollback
+		if err = r.OfficeLocationQuery.CreateOfficeLocation(c, tx, location); err != nil {
+			return err
+		}
+		return nil
+	})
+
+	return err
+}
+
+func (r *officeLocationRepository) UpdateOfficeLocation(c context.Context, id string, location domain.OfficeLocation) error {
+	var err error
+
+	// create transaction to update office location
+	err = r.db.WithTransaction(c, func(tx pgx.Tx) error {
+		// update office location by id, if error will rollback
+		if err = r.OfficeLocationQuery.UpdateOfficeLocation(c, tx, id, location); err != nil {
+			return err
+		}
+		return nil
+	})
+
+	return err
+}
+
+func (r *officeLocationRepository) Delete(c context.Context, id string) error {
+	var err error
+
+	// create transaction to delete office location
+	err = r.db.WithTransaction(c, func(tx pgx.Tx) error {
+		// delete office location by id, if error will rollback
+		if err = r.OfficeLocationQuery.Delete(c, tx, id); err != nil {
+			return err
+		}
+		return nil
+	})
+
+	return err
+}
+
+func (r *officeLocationRepository) AssignEmployees(c context.Context, id string, employeeIDs []string) error {
+	var err error
+
+	// create transaction to assign office location to the employees
+	err = r.db.WithTransaction(c, func(tx pgx.Tx) error {
+		// assign office location, if error will rollback
+		if err = r.OfficeLocationQuery.AssignEmployees(c, tx, id, employeeIDs); err != nil {
+			return err
+		}
+		return nil
+	})
+
+	return err
+}
+
+func (r *officeLocationRepository) FindAllOfficeLocation(c context.Context) ([]domain.OfficeLocation, error) {
+	var locations []domain.OfficeLocation
+	var err error
+
+	// get office locations without transaction.
+	err = r.db.WithoutTransaction(c, func(db *pgxpool.Pool) error {
+		if locations, err = r.OfficeLocationQuery.FindAllOfficeLocation(c, db); err != nil {
+			return err
+		}
+		return nil
+	})
+
+	return locations, err
+}
+
+func (r *officeLocationRepository) FindById(c context.Context, id string) (domain.OfficeLocation, error) {
+	var location domain.OfficeLocation
+	var err error
+
+	// get office location by id without transaction.
+	err = r.db.WithoutTransaction(c, func(db *pgxpool.Pool) error {
+		if location, err = r.OfficeLocationQuery.FindById(c, db, id); err != nil {
+			return err
+		}
+		return nil
+	})
+
+	return location, err
+}
@@ -0,0 +1,322 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/iqbaludinm/hr-microservice/user-service/model/domain"
+	"github.com/iqbaludinm/hr-microservice/user-service/repository/query"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type OnboardingRepository interface {
+	CreateTemplate(c context.Context, template domain.OnboardingTemplate) error
+	UpdateTemplate(c context.Context, template domain.OnboardingTemplate) error
+	DeleteTemplate(c context.Context, id string) error
+	CreateOnboarding(c context.Context, onboarding domain.Onboarding) error
+	UpdateStatus(c context.Context, onboarding domain.Onboarding) error
+	UpdateTask(c context.Context, task domain.OnboardingTask, onboarding domain.Onboarding) error
+	UpdateTaskReminded(c context.Context, task domain.OnboardingTask) error
+	FindAllTemplate(c context.Context) ([]domain.OnboardingTemplate, error)
+	FindTemplateById(c context.Context, id string) (domain.OnboardingTemplate, error)
+	FindTemplateForEmployee(c context.Context, departmentID, positionID *string) (domain.OnboardingTemplate, error)
+	FindAllOnboarding(c context.Context, filter domain.OnboardingQueryFilter) ([]domain.Onboarding, error)
+	CountAllOnboarding(c context.Context, filter domain.OnboardingQueryFilter) (int, error)
+	FindById(c context.Context, id string) (domain.Onboarding, error)
+	FindByEmployee(c context.Context, employeeID string) (domain.Onboarding, error)
+	FindTasksByAssignee(c context.Context, assigneeID string) ([]domain.OnboardingTask, error)
+	FindDueTasks(c context.Context, dueDate, remindedBefore time.Time) ([]domain.OnboardingTask, error)
+}
+
+type onboardingRepository struct {
+	db              Store
+	OnboardingQuery query.OnboardingQuery
+}
+
+func NewOnboarding(db Store, q query.OnboardingQuery) OnboardingRepository {
+	return &onboardingRepository{
+		db:              db,
+		OnboardingQuery: q,
+	}
+}
+
+// create the template with its tasks
+func (r *onboardingRepository) CreateTemplate(c context.Context, template domain.OnboardingTemplate) error {
+	var err error
+
+	// create transaction to create onboarding template
+	err = r.db.WithTransaction(c, func(tx pgx.Tx) error {
+		// create template, if error will rollback
+		if err = r.OnboardingQuery.CreateTemplate(c, tx, template); err != nil {
+			return err
+		}
+		return r.createTemplateTasks(c, tx, template)
+	})
+
+	return err
+}
+
+// update the template, the tasks are replaced
+func (r *onboardingRepository) UpdateTemplate(c context.Context, template domain.OnboardingTemplate) error {
+	var err error
+
+	// create transaction to update onboarding template
+	err = r.db.WithTransaction(c, func(tx pgx.Tx) error {
+		// update template by id, if error will rollback
+		if err = r.OnboardingQuery.UpdateTemplate(c, tx, template.ID, template); err != nil {
+			return err
+		}
+		if err = r.OnboardingQuery.DeleteTemplateTasks(c, tx, template.ID); err != nil {
+			return err
+		}
+		return r.createTemplateTasks(c, tx, template)
+	})
+
+	return err
+}
+
+func (r *onboardingRepository) createTemplateTasks(c context.Context, tx pgx.Tx, template domain.OnboardingTemplate) error {
+	for _, task := range template.Tasks {
+		task.TemplateID = template.ID
+		if err := r.OnboardingQuery.CreateTemplateTask(c, tx, task); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (r *onboardingRepository) DeleteTemplate(c context.Context, id string) error {
+	var err error
+
+	// create transaction to delete onboarding template
+	err = r.db.WithTransaction(c, func(tx pgx.Tx) error {
+		// delete template by id, if error will rollback
+		if err = r.OnboardingQuery.DeleteTemplate(c, tx, id); err != nil {
+			return err
+		}
+		return nil
+	})
+
+	return err
+}
+
+// create the onboarding with its tasks
+func (r *onboardingRepository) CreateOnboarding(c context.Context, onboarding domain.Onboarding) error {
+	var err error
+
+	// create transaction to create onboarding
+	err = r.db.WithTransaction(c, func(tx pgx.Tx) error {
+		// create onboarding, if error will rollback
+		if err = r.OnboardingQuery.CreateOnboarding(c, tx, onboarding); err != nil {
+			return err
+		}
+		// create the tasks of the onboarding, if error will rollback
+		for _, task := range onboarding.Tasks {
+			task.OnboardingID = onboarding.ID
+			if err = r.OnboardingQuery.CreateTask(c, tx, task); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+
+	return err
+}
+
+func (r *onboardingRepository) UpdateStatus(c context.Context, onboarding domain.Onboarding) error {
+	var err error
+
+	// create transaction to update onboarding status
+	err = r.db.WithTransaction(c, func(tx pgx.Tx) error {
+		// update onboarding status by id, if error will rollback
+		if err = r.OnboardingQuery.UpdateStatus(c, tx, onboarding.ID, onboarding); err != nil {
+			return err
+		}
+		return nil
+	})
+
+	return err
+}
+
+// update the task together with the status of the onboarding, the onboarding is completed by its last task
+func (r *onboardingRepository) UpdateTask(c context.Context, task domain.OnboardingTask, onboarding domain.Onboarding) error {
+	var err error
+
+	// create transaction to update onboarding task
+	err = r.db.WithTransaction(c, func(tx pgx.Tx) error {
+		// update task, if error will rollback
+		if err = r.OnboardingQuery.UpdateTask(c, tx, task); err != nil {
+			return err
+		}
+		// update onboarding status by id, if error will rollback
+		if err = r.OnboardingQuery.UpdateStatus(c, tx, onboarding.ID, onboarding); err != nil {
+			return err
+		}
+		return nil
+	})
+
+	return err
+}
+
+func (r *onboardingRepository) UpdateTaskReminded(c context.Context, task domain.OnboardingTask) error {
+	var err error
+
+	// create transaction to mark onboarding task as reminded
+	err = r.db.WithTransaction(c, func(tx pgx.Tx) error {
+		// update task reminded time, if error will rollback
+		if err = r.OnboardingQuery.UpdateTaskReminded(c, tx, task); err != nil {
+			return err
+		}
+		return nil
+	})
+
+	return err
+}
+
+func (r *onboardingRepository) FindAllTemplate(c context.Context) ([]domain.OnboardingTemplate, error) {
+	var templates []domain.OnboardingTemplate
+	var err error
+
+	// get onboarding templates without transaction.
+	err = r.db.WithoutTransaction(c, func(db *pgxpool.Pool) error {
+		if templates, err = r.OnboardingQuery.FindAllTemplate(c, db); err != nil {
+			return err
+		}
+		return nil
+	})
+
+	return templates, err
+}
+
+func (r *onboardingRepository) FindTemplateById(c context.Context, id string) (domain.OnboardingTemplate, error) {
+	var template domain.OnboardingTemplate
+	var err error
+
+	// get onboarding template by id with its tasks without transaction.
+	err = r.db.WithoutTransaction(c, func(db *pgxpool.Pool) error {
+		if template, err = r.OnboardingQuery.FindTemplateById(c, db, id); err != nil {
+			return err
+		}
+		if template.Tasks, err = r.OnboardingQuery.FindTemplateTasks(c, db, template.ID); err != nil {
+			return err
+		}
+		return nil
+	})
+
+	return template, err
+}
+
+func (r *onboardingRepository) FindTemplateForEmployee(c context.Context, departmentID, positionID *string) (domain.OnboardingTemplate, error) {
+	var template domain.OnboardingTemplate
+	var err error
+
+	// get the onboarding template of the employee with its tasks without transaction.
+	err = r.db.WithoutTransaction(c, func(db *pgxpool.Pool) error {
+		if template, err = r.OnboardingQuery.FindTemplateForEmployee(c, db, departmentID, positionID); err != nil {
+			return err
+		}
+		if template.Tasks, err = r.OnboardingQuery.FindTemplateTasks(c, db, template.ID); err != nil {
+			return err
+		}
+		return nil
+	})
+
+	return template, err
+}
+
+func (r *onboardingRepository) FindAllOnboarding(c context.Context, filter domain.OnboardingQueryFilter) ([]domain.Onboarding, error) {
+	var onboardings []domain.Onboarding
+	var err error
+
+	// get onboardings without transaction.
+	err = r.db.WithoutTransaction(c, func(db *pgxpool.Pool) error {
+		if onboardings, err = r.OnboardingQuery.FindAllOnboarding(c, db, filter); err != nil {
+			return err
+		}
+		return nil
+	})
+
+	return onboardings, err
+}
+
+func (r *onboardingRepository) CountAllOnboarding(c context.Context, filter domain.OnboardingQueryFilter) (int, error) {
+	var count int
+	var err error
+
+	// count onboardings without transaction.
+	err = r.db.WithoutTransaction(c, func(db *pgxpool.Pool) error {
+		if count, err = r.OnboardingQuery.CountAllOnboarding(c, db, filter); err != nil {
+			return err
+		}
+		return nil
+	})
+
+	return count, err
+}
+
+func (r *onboardingRepository) FindById(c context.Context, id string) (domain.Onboarding, error) {
+	var onboarding domain.Onboarding
+	var err error
+
+	// get onboarding by id with its tasks without transaction.
+	err = r.db.WithoutTransaction(c, func(db *pgxpool.Pool) error {
+		if onboarding, err = r.OnboardingQuery.FindById(c, db, id); err != nil {
+			return err
+		}
+		if onboarding.Tasks, err = r.OnboardingQuery.FindTasks(c, db, onboarding.ID); err != nil {
+			return err
+		}
+		return nil
+	})
+
+	return onboarding, err
+}
+
+func (r *onboardingRepository) FindByEmployee(c context.Context, employeeID string) (domain.Onboarding, error) {
+	var onboarding domain.Onboarding
+	var err error
+
+	// get the onboarding of the employee with its tasks without transaction.
+	err = r.db.WithoutTransaction(c, func(db *pgxpool.Pool) error {
+		if onboarding, err = r.OnboardingQuery.FindByEmployee(c, db, employeeID); err != nil {
+			return err
+		}
+		if onboarding.Tasks, err = r.OnboardingQuery.FindTasks(c, db, onboarding.ID); err != nil {
+			return err
+		}
+		return nil
+	})
+
+	return onboarding, err
+}
+
+func (r *onboardingRepository) FindTasksByAssignee(c context.Context, assigneeID string) ([]domain.OnboardingTask, error) {
+	var tasks []domain.OnboardingTask
+	var err error
+
+	// get the onboarding tasks of the assignee without transaction.
+	err = r.db.WithoutTransaction(c, func(db *pgxpool.Pool) error {
+		if tasks, err = r.OnboardingQuery.FindTasksByAssignee(c, db, assigneeID); err != nil {
+			return err
+		}
+		return nil
+	})
+
+	return tasks, err
+}
+
+func (r *onboardingRepository) FindDueTasks(c context.Context, dueDate, remindedBefore time.Time) ([]domain.OnboardingTask, error) {
+	var tasks []domain.OnboardingTask
+	var err error
+
+	// get the due onboarding tasks without transaction.
+	err = r.db.WithoutTransaction(c, func(db *pgxpool.Pool) error {
+		if tasks, err = r.OnboardingQuery.FindDueTasks(c, db, dueDate, remindedBefore); err != nil {
+			return err
+		}
+		return nil
+	})
+
+	return tasks, err
+}
@@ -0,0 +1,123 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/iqbaludinm/hr-microservice/user-service/model/domain"
+	"github.com/iqbaludinm/hr-microservice/user-service/repository/query"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type OvertimeRepository interface {
+	CreateOvertime(c context.Context, overtime domain.Overtime) error
+	UpdateStatus(c context.Context, overtime domain.Overtime, status string) error
+	FindAllOvertime(c context.Context, filter domain.OvertimeQueryFilter) ([]domain.Overtime, error)
+	CountAllOvertime(c context.Context, filter domain.OvertimeQueryFilter) (int, error)
+	FindById(c context.Context, id string) (domain.Overtime, error)
+	SumHours(c context.Context, employeeID string, from, to time.Time) (float64, error)
+}
+
+type overtimeRepository struct {
+	db            Store
+	OvertimeQuery query.OvertimeQuery
+}
+
+func NewOvertime(db Store, q query.OvertimeQuery) OvertimeRepository {
+	return &overtimeRepository{
+		db:            db,
+		OvertimeQuery: q,
+	}
+}
+
+func (r *overtimeRepository) CreateOvertime(c context.Context, overtime domain.Overtime) error {
+	var err error
+
+	// create transaction to create overtime request
+	err = r.db.WithTransaction(c, func(tx pgx.Tx) error {
+		// create overtime request, if error will rollback
+		if err = r.OvertimeQuery.CreateOvertime(c, tx, overtime); err != nil {
+			return err
+		}
+		return nil
+	})
+
+	return err
+}
+
+// update the status of the overtime request that still has the given status
+func (r *overtimeRepository) UpdateStatus(c context.Context, overtime domain.Overtime, status string) error {
+	var err error
+
+	// create transaction to update overtime request status
+	err = r.db.WithTransaction(c, func(tx pgx.Tx) error {
+		// update overtime request status by id, if error will rollback
+		if err = r.OvertimeQuery.UpdateStatus(c, tx, overtime.ID, overtime, status); err != nil {
+			return err
+		}
+		return nil
+	})
+
+	return err
+}
+
+func (r *overtimeRepository) FindAllOvertime(c context.Context, filter domain.OvertimeQueryFilter) ([]domain.Overtime, error) {
+	var overtimes []domain.Overtime
+	var err error
+
+	// get overtime requests without transaction.
+	err = r.db.WithoutTransaction(c, func(db *pgxpool.Pool) error {
+		if overtimes, err = r.OvertimeQuery.FindAllOvertime(c, db, filter); err != nil {
+			return err
+		}
+		return nil
+	})
+
+	return overtimes, err
+}
+
+func (r *overtimeRepository) CountAllOvertime(c context.Context, filter domain.OvertimeQueryFilter) (int, error) {
+	var count int
+	var err error
+
+	// count overtime requests without transaction.
+	err = r.db.WithoutTransaction(c, func(db *pgxpool.Pool) error {
+		if count, err = r.OvertimeQuery.CountAllOvertime(c, db, filter); err != nil {
+			return err
+		}
+		return nil
+	})
+
+	return count, err
+}
+
+func (r *overtimeRepository) FindById(c context.Context, id string) (domain.Overtime, error) {
+	var overtime domain.Overtime
+	var err error
+
+	// get overtime request by id without transaction.
+	err = r.db.WithoutTransaction(c, func(db *pgxpool.Pool) error {
+		if overtime, err = r.OvertimeQuery.FindById(c, db, id); err != nil {
+			return err
+		}
+		return nil
+	})
+
+	return overtime, err
+}
+
+func (r *overtimeRepository) SumHours(c context.Context, employeeID string, from, to time.Time) (float64, error) {
+	var hours float64
+	var err error
+
+	// sum the requested overtime hours without transaction.
+	err = r.db.WithoutTransaction(c, func(db *pgxpool.Pool) error {
+		if hours, err = r.OvertimeQuery.SumHours(c, db, employeeID, from, to); err != nil {
+			return err
+		}
+		return nil
+	})
+
+	return hours, err
+}
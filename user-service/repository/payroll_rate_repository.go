@@ -0,0 +1,71 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/iqbaludinm/hr-microservice/user-service/model/domain"
+	"github.com/iqbaludinm/hr-microservice/user-service/repository/query"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type PayrollRateRepository interface {
+	CreateBpjsRate(c context.Context, rate domain.BpjsRate) error
+	FindRates(c context.Context, date time.Time) (domain.PayrollRates, error)
+}
+
+type payrollRateRepository struct {
+	db               Store
+	PayrollRateQuery query.PayrollRateQuery
+}
+
+func NewPayrollRate(db Store, q query.PayrollRateQuery) PayrollRateRepository {
+	return &payrollRateRepository{
+		db:               db,
+		PayrollRateQuery: q,
+	}
+}
+
+func (r *payrollRateRepository) CreateBpjsRate(c context.Context, rate domain.BpjsRate) error {
+	var err error
+
+	// create transaction to create BPJS rate
+	err = r.db.WithTransaction(c, func(tx pgx.Tx) error {
+		// create BPJS rate, if error will rollback
+		if err = r.PayrollRateQuery.CreateBpjsRate(c, tx, rate); err != nil {
+			return err
+		}
+		return nil
+	})
+
+	return err
+}
+
+func (r *payrollRateRepository) FindRates(c context.Context, date time.Time) (domain.PayrollRates, error) {
+	rates := domain.PayrollRates{Ptkp: map[string]domain.PtkpRate{}}
+	var err error
+
+	// get every rate effective on the date without transaction.
+	err = r.db.WithoutTransaction(c, func(db *pgxpool.Pool) error {
+		if rates.Bpjs, err = r.PayrollRateQuery.FindBpjsRates(c, db, date); err != nil {
+			return err
+		}
+		ptkpRates, err := r.PayrollRateQuery.FindPtkpRates(c, db, date)
+		if err != nil {
+			return err
+		}
+		for _, rate := range ptkpRates {
+			rates.Ptkp[rate.TaxStatus] = rate
+		}
+		if rates.Ter, err = r.PayrollRateQuery.FindTerRates(c, db, date); err != nil {
+			return err
+		}
+		if rates.Tax, err = r.PayrollRateQuery.FindTaxRates(c, db, date); err != nil {
+			return err
+		}
+		return nil
+	})
+
+	return rates, err
+}
@@ -0,0 +1,318 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/iqbaludinm/hr-microservice/user-service/model/domain"
+	"github.com/iqbaludinm/hr-microservice/user-service/repository/query"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type PayrollRepository interface {
+	CreateRun(c context.Context, run domain.PayrollRun, payslips []domain.Payslip) error
+	RecalculateRun(c context.Context, run domain.PayrollRun, payslips []domain.Payslip) error
+	UpdateRun(c context.Context, run domain.PayrollRun, status string) error
+	LockRun(c context.Context, run domain.PayrollRun, histories []domain.ExpenseClaimHistory) error
+	DeleteRun(c context.Context, id string, status string) error
+	UpdatePayslipDocument(c context.Context, payslip domain.Payslip) error
+	FindAllRun(c context.Context, filter domain.PayrollRunQueryFilter) ([]domain.PayrollRun, error)
+	CountAllRun(c context.Context, filter domain.PayrollRunQueryFilter) (int, error)
+	FindRunById(c context.Context, id string) (domain.PayrollRun, error)
+	CountUnlockedRuns(c context.Context, year, month int) (int, error)
+	FindAllPayslip(c context.Context, runID string) ([]domain.Payslip, error)
+	FindPayslipById(c context.Context, runID, id string) (domain.Payslip, error)
+	FindAllPayslipByEmployee(c context.Context, employeeID string, year int) ([]domain.Payslip, error)
+	FindPayslipByEmployee(c context.Context, employeeID, id string) (domain.Payslip, error)
+	FindYearToDate(c context.Context, year, month int) ([]domain.PayslipYearToDate, error)
+}
+
+type payrollRepository struct {
+	db                Store
+	PayrollQuery      query.PayrollQuery
+	ExpenseClaimQuery query.ExpenseClaimQuery
+}
+
+func NewPayroll(db Store, q query.PayrollQuery, expenseClaimQuery query.ExpenseClaimQuery) PayrollRepository {
+	return &payrollRepository{
+		db:                db,
+		PayrollQuery:      q,
+		ExpenseClaimQuery: expenseClaimQuery,
+	}
+}
+
+func (r *payrollRepository) CreateRun(c context.Context, run domain.PayrollRun, payslips []domain.Payslip) error {
+	var err error
+
+	// create transaction to create payroll run with its payslips
+	err = r.db.WithTransaction(c, func(tx pgx.Tx) error {
+		// create payroll run, if error will rollback
+		if err = r.PayrollQuery.CreateRun(c, tx, run); err != nil {
+			return err
+		}
+		// create payslips and their items, if error will rollback
+		return r.createPayslips(c, tx, payslips)
+	})
+
+	return err
+}
+
+// replace the payslips of the draft payroll run, the run is updated first so it can't be reviewed, locked or deleted
+// while its payslips are replaced
+func (r *payrollRepository) RecalculateRun(c context.Context, run domain.PayrollRun, payslips []domain.Payslip) error {
+	var err error
+
+	// create transaction to replace the payslips of the payroll run
+	err = r.db.WithTransaction(c, func(tx pgx.Tx) error {
+		// update the totals of the draft payroll run, if error will rollback
+		if err = r.PayrollQuery.UpdateRun(c, tx, run.ID, run, domain.PayrollStatusDraft); err != nil {
+			return err
+		}
+		// delete the previous payslips, if error will rollback
+		if err = r.PayrollQuery.DeletePayslips(c, tx, run.ID); err != nil {
+			return err
+		}
+		// release the expense claims of the previous payslips, if error will rollback
+		if err = r.ExpenseClaimQuery.ReleaseClaims(c, tx, run.ID); err != nil {
+			return err
+		}
+		// create payslips and their items, if error will rollback
+		return r.createPayslips(c, tx, payslips)
+	})
+
+	return err
+}
+
+// create the payslips with their items, the expense claims reimbursed with the payslips are assigned to the run
+func (r *payrollRepository) createPayslips(c context.Context, tx pgx.Tx, payslips []domain.Payslip) error {
+	for _, payslip := range payslips {
+		if err := r.PayrollQuery.CreatePayslip(c, tx, payslip); err != nil {
+			return err
+		}
+		for _, item := range payslip.Items {
+			if err := r.PayrollQuery.CreatePayslipItem(c, tx, item); err != nil {
+				return err
+			}
+		}
+		if len(payslip.ExpenseClaimIDs) > 0 {
+			if err := r.ExpenseClaimQuery.AssignClaims(c, tx, payslip.PayrollRunID, payslip.ExpenseClaimIDs); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// update the payroll run which still has the given status
+func (r *payrollRepository) UpdateRun(c context.Context, run domain.PayrollRun, status string) error {
+	var err error
+
+	// create transaction to update payroll run
+	err = r.db.WithTransaction(c, func(tx pgx.Tx) error {
+		// update payroll run by id, if error will rollback
+		if err = r.PayrollQuery.UpdateRun(c, tx, run.ID, run, status); err != nil {
+			return err
+		}
+		return nil
+	})
+
+	return err
+}
+
+// lock the reviewed payroll run, the expense claims reimbursed in the run are paid and the payments are kept in their
+// histories
+func (r *payrollRepository) LockRun(c context.Context, run domain.PayrollRun, histories []domain.ExpenseClaimHistory) error {
+	var err error
+
+	// create transaction to lock payroll run
+	err = r.db.WithTransaction(c, func(tx pgx.Tx) error {
+		// update the reviewed payroll run by id, if error will rollback
+		if err = r.PayrollQuery.UpdateRun(c, tx, run.ID, run, domain.PayrollStatusReviewed); err != nil {
+			return err
+		}
+		// mark the expense claims of the run as paid, if error will rollback
+		if err = r.ExpenseClaimQuery.PayClaims(c, tx, run.ID, *run.LockedAt); err != nil {
+			return err
+		}
+		for _, history := range histories {
+			if err = r.ExpenseClaimQuery.CreateHistory(c, tx, history); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+
+	return err
+}
+
+// delete the payroll run which still has the given status
+func (r *payrollRepository) DeleteRun(c context.Context, id string, status string) error {
+	var err error
+
+	// create transaction to delete payroll run
+	err = r.db.WithTransaction(c, func(tx pgx.Tx) error {
+		// delete payroll run by id, if error will rollback
+		if err = r.PayrollQuery.DeleteRun(c, tx, id, status); err != nil {
+			return err
+		}
+		return nil
+	})
+
+	return err
+}
+
+func (r *payrollRepository) UpdatePayslipDocument(c context.Context, payslip domain.Payslip) error {
+	var err error
+
+	// create transaction to update payslip document
+	err = r.db.WithTransaction(c, func(tx pgx.Tx) error {
+		// update payslip document by id, if error will rollback
+		if err = r.PayrollQuery.UpdatePayslipDocument(c, tx, payslip.ID, payslip); err != nil {
+			return err
+		}
+		return nil
+	})
+
+	return err
+}
+
+func (r *payrollRepository) FindAllRun(c context.Context, filter domain.PayrollRunQueryFilter) ([]domain.PayrollRun, error) {
+	var runs []domain.PayrollRun
+	var err error
+
+	// get payroll runs without transaction.
+	err = r.db.WithoutTransaction(c, func(db *pgxpool.Pool) error {
+		if runs, err = r.PayrollQuery.FindAllRun(c, db, filter); err != nil {
+			return err
+		}
+		return nil
+	})
+
+	return runs, err
+}
+
+func (r *payrollRepository) CountAllRun(c context.Context, filter domain.PayrollRunQueryFilter) (int, error) {
+	var count int
+	var err error
+
+	// count payroll runs without transaction.
+	err = r.db.WithoutTransaction(c, func(db *pgxpool.Pool) error {
+		if count, err = r.PayrollQuery.CountAllRun(c, db, filter); err != nil {
+			return err
+		}
+		return nil
+	})
+
+	return count, err
+}
+
+func (r *payrollRepository) FindRunById(c context.Context, id string) (domain.PayrollRun, error) {
+	var run domain.PayrollRun
+	var err error
+
+	// get payroll run by id without transaction.
+	err = r.db.WithoutTransaction(c, func(db *pgxpool.Pool) error {
+		if run, err = r.PayrollQuery.FindRunById(c, db, id); err != nil {
+			return err
+		}
+		return nil
+	})
+
+	return run, err
+}
+
+func (r *payrollRepository) CountUnlockedRuns(c context.Context, year, month int) (int, error) {
+	var count int
+	var err error
+
+	// count the unlocked payroll runs before the month without transaction.
+	err = r.db.WithoutTransaction(c, func(db *pgxpool.Pool) error {
+		if count, err = r.PayrollQuery.CountUnlockedRuns(c, db, year, month); err != nil {
+			return err
+		}
+		return nil
+	})
+
+	return count, err
+}
+
+func (r *payrollRepository) FindAllPayslip(c context.Context, runID string) ([]domain.Payslip, error) {
+	var payslips []domain.Payslip
+	var err error
+
+	// get payslips of the payroll run without transaction.
+	err = r.db.WithoutTransaction(c, func(db *pgxpool.Pool) error {
+		if payslips, err = r.PayrollQuery.FindAllPayslip(c, db, runID); err != nil {
+			return err
+		}
+		return nil
+	})
+
+	return payslips, err
+}
+
+func (r *payrollRepository) FindPayslipById(c context.Context, runID, id string) (domain.Payslip, error) {
+	var payslip domain.Payslip
+	var err error
+
+	// get payslip with its items by id without transaction.
+	err = r.db.WithoutTransaction(c, func(db *pgxpool.Pool) error {
+		if payslip, err = r.PayrollQuery.FindPayslipById(c, db, runID, id); err != nil {
+			return err
+		}
+		if payslip.Items, err = r.PayrollQuery.FindPayslipItems(c, db, id); err != nil {
+			return err
+		}
+		return nil
+	})
+
+	return payslip, err
+}
+
+func (r *payrollRepository) FindAllPayslipByEmployee(c context.Context, employeeID string, year int) ([]domain.Payslip, error) {
+	var payslips []domain.Payslip
+	var err error
+
+	// get payslips of the employee without transaction.
+	err = r.db.WithoutTransaction(c, func(db *pgxpool.Pool) error {
+		if payslips, err = r.PayrollQuery.FindAllPayslipByEmployee(c, db, employeeID, year); err != nil {
+			return err
+		}
+		return nil
+	})
+
+	return payslips, err
+}
+
+func (r *payrollRepository) FindPayslipByEmployee(c context.Context, employeeID, id string) (domain.Payslip, error) {
+	var payslip domain.Payslip
+	var err error
+
+	// get payslip of the employee with its items by id without transaction.
+	err = r.db.WithoutTransaction(c, func(db *pgxpool.Pool) error {
+		if payslip, err = r.PayrollQuery.FindPayslipByEmployee(c, db, employeeID, id); err != nil {
+			return err
+		}
+		if payslip.Items, err = r.PayrollQuery.FindPayslipItems(c, db, id); err != nil {
+			return err
+		}
+		return nil
+	})
+
+	return payslip, err
+}
+
+func (r *payrollRepository) FindYearToDate(c context.Context, year, month int) ([]domain.PayslipYearToDate, error) {
+	var yearToDate []domain.PayslipYearToDate
+	var err error
+
+	// get the year-to-date of the payslips without transaction.
+	err = r.db.WithoutTransaction(c, func(db *pgxpool.Pool) error {
+		if yearToDate, err = r.PayrollQuery.FindYearToDate(c, db, year, month); err != nil {
+			return err
+		}
+		return nil
+	})
+
+	return yearToDate, err
+}
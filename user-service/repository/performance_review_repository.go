@@ -0,0 +1,376 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/iqbaludinm/hr-microservice/user-service/model/domain"
+	"github.com/iqbaludinm/hr-microservice/user-service/repository/query"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type PerformanceReviewRepository interface {
+	CreateCycle(c context.Context, cycle domain.ReviewCycle) error
+	UpdateCycle(c context.Context, cycle domain.ReviewCycle) error
+	UpdateCycleStatus(c context.Context, cycle domain.ReviewCycle) error
+	CloseCycle(c context.Context, cycle domain.ReviewCycle) error
+	UpdateGoals(c context.Context, reviewID string, goals []domain.ReviewGoal) error
+	UpdateSelfAssessment(c context.Context, review domain.PerformanceReview) error
+	UpdateManagerAssessment(c context.Context, review domain.PerformanceReview) error
+	UpdateReviewer(c context.Context, review domain.PerformanceReview) error
+	UpdateCalibration(c context.Context, review domain.PerformanceReview) error
+	CreatePeers(c context.Context, peers []domain.ReviewPeer) error
+	UpdatePeer(c context.Context, peer domain.ReviewPeer) error
+	FindAllCycle(c context.Context, filter domain.ReviewCycleQueryFilter) ([]domain.ReviewCycle, error)
+	CountAllCycle(c context.Context, filter domain.ReviewCycleQueryFilter) (int, error)
+	FindCycleById(c context.Context, id string) (domain.ReviewCycle, error)
+	FindAllReview(c context.Context, filter domain.PerformanceReviewQueryFilter) ([]domain.PerformanceReview, error)
+	CountAllReview(c context.Context, filter domain.PerformanceReviewQueryFilter) (int, error)
+	FindReviewById(c context.Context, id string) (domain.PerformanceReview, error)
+	CountReviewsWithoutGoals(c context.Context, cycleID string) (int, error)
+	FindPeersByReviewer(c context.Context, reviewerID string) ([]domain.ReviewPeer, error)
+}
+
+type performanceReviewRepository struct {
+	db                     Store
+	PerformanceReviewQuery query.PerformanceReviewQuery
+}
+
+func NewPerformanceReview(db Store, q query.PerformanceReviewQuery) PerformanceReviewRepository {
+	return &performanceReviewRepository{
+		db:                     db,
+		PerformanceReviewQuery: q,
+	}
+}
+
+// create the review cycle with the reviews of its participants
+func (r *performanceReviewRepository) CreateCycle(c context.Context, cycle domain.ReviewCycle) error {
+	var err error
+
+	// create transaction to create review cycle
+	err = r.db.WithTransaction(c, func(tx pgx.Tx) error {
+		// create review cycle, if error will rollback
+		if err = r.PerformanceReviewQuery.CreateCycle(c, tx, cycle); err != nil {
+			return err
+		}
+		return createReviews(c, tx, r.PerformanceReviewQuery, cycle)
+	})
+
+	return err
+}
+
+// update the draft review cycle, the reviews are replaced by the reviews of the updated participants
+func (r *performanceReviewRepository) UpdateCycle(c context.Context, cycle domain.ReviewCycle) error {
+	var err error
+
+	// create transaction to update review cycle
+	err = r.db.WithTransaction(c, func(tx pgx.Tx) error {
+		// update review cycle by id, if error will rollback
+		if err = r.PerformanceReviewQuery.UpdateCycle(c, tx, cycle.ID, cycle); err != nil {
+			return err
+		}
+		if err = r.PerformanceReviewQuery.DeleteReviews(c, tx, cycle.ID); err != nil {
+			return err
+		}
+		return createReviews(c, tx, r.PerformanceReviewQuery, cycle)
+	})
+
+	return err
+}
+
+func createReviews(c context.Context, tx pgx.Tx, q query.PerformanceReviewQuery, cycle domain.ReviewCycle) error {
+	for _, review := range cycle.Reviews {
+		review.CycleID = cycle.ID
+		if err := q.CreateReview(c, tx, review); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (r *performanceReviewRepository) UpdateCycleStatus(c context.Context, cycle domain.ReviewCycle) error {
+	var err error
+
+	// create transaction to update review cycle status
+	err = r.db.WithTransaction(c, func(tx pgx.Tx) error {
+		// update review cycle status by id, if error will rollback
+		if err = r.PerformanceReviewQuery.UpdateCycleStatus(c, tx, cycle.ID, cycle); err != nil {
+			return err
+		}
+		return nil
+	})
+
+	return err
+}
+
+// close the review cycle and lock the final score and rating of its reviews
+func (r *performanceReviewRepository) CloseCycle(c context.Context, cycle domain.ReviewCycle) error {
+	var err error
+
+	// create transaction to close review cycle
+	err = r.db.WithTransaction(c, func(tx pgx.Tx) error {
+		// update review cycle status by id, if error will rollback
+		if err = r.PerformanceReviewQuery.UpdateCycleStatus(c, tx, cycle.ID, cycle); err != nil {
+			return err
+		}
+		for _, review := range cycle.Reviews {
+			if err = r.PerformanceReviewQuery.LockReview(c, tx, review.ID, review); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+
+	return err
+}
+
+// replace the goals of the review
+func (r *performanceReviewRepository) UpdateGoals(c context.Context, reviewID string, goals []domain.ReviewGoal) error {
+	var err error
+
+	// create transaction to update review goals
+	err = r.db.WithTransaction(c, func(tx pgx.Tx) error {
+		// delete the goals and create the updated goals, if error will rollback
+		if err = r.PerformanceReviewQuery.DeleteGoals(c, tx, reviewID); err != nil {
+			return err
+		}
+		for _, goal := range goals {
+			goal.ReviewID = reviewID
+			if err = r.PerformanceReviewQuery.CreateGoal(c, tx, goal); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+
+	return err
+}
+
+// update the self assessment of the review with the scores of its goals
+func (r *performanceReviewRepository) UpdateSelfAssessment(c context.Context, review domain.PerformanceReview) error {
+	var err error
+
+	// create transaction to update self assessment
+	err = r.db.WithTransaction(c, func(tx pgx.Tx) error {
+		// update self assessment by id, if error will rollback
+		if err = r.PerformanceReviewQuery.UpdateSelfAssessment(c, tx, review.ID, review); err != nil {
+			return err
+		}
+		return updateGoalScores(c, tx, r.PerformanceReviewQuery, review)
+	})
+
+	return err
+}
+
+// update the manager assessment of the review with the scores of its goals
+func (r *performanceReviewRepository) UpdateManagerAssessment(c context.Context, review domain.PerformanceReview) error {
+	var err error
+
+	// create transaction to update manager assessment
+	err = r.db.WithTransaction(c, func(tx pgx.Tx) error {
+		// update manager assessment by id, if error will rollback
+		if err = r.PerformanceReviewQuery.UpdateManagerAssessment(c, tx, review.ID, review); err != nil {
+			return err
+		}
+		return updateGoalScores(c, tx, r.PerformanceReviewQuery, review)
+	})
+
+	return err
+}
+
+func updateGoalScores(c context.Context, tx pgx.Tx, q query.PerformanceReviewQuery, review domain.PerformanceReview) error {
+	for _, goal := range review.Goals {
+		if err := q.UpdateGoalScore(c, tx, goal); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (r *performanceReviewRepository) UpdateReviewer(c context.Context, review domain.PerformanceReview) error {
+	var err error
+
+	// create transaction to update reviewer
+	err = r.db.WithTransaction(c, func(tx pgx.Tx) error {
+		// update reviewer by id, if error will rollback
+		if err = r.PerformanceReviewQuery.UpdateReviewer(c, tx, review.ID, review); err != nil {
+			return err
+		}
+		return nil
+	})
+
+	return err
+}
+
+func (r *performanceReviewRepository) UpdateCalibration(c context.Context, review domain.PerformanceReview) error {
+	var err error
+
+	// create transaction to update calibration
+	err = r.db.WithTransaction(c, func(tx pgx.Tx) error {
+		// update calibrated score by id, if error will rollback
+		if err = r.PerformanceReviewQuery.UpdateCalibration(c, tx, review.ID, review); err != nil {
+			return err
+		}
+		return nil
+	})
+
+	return err
+}
+
+func (r *performanceReviewRepository) CreatePeers(c context.Context, peers []domain.ReviewPeer) error {
+	var err error
+
+	// create transaction to request peer feedback
+	err = r.db.WithTransaction(c, func(tx pgx.Tx) error {
+		// create peer feedback requests, if error will rollback
+		for _, peer := range peers {
+			if err = r.PerformanceReviewQuery.CreatePeer(c, tx, peer); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+
+	return err
+}
+
+func (r *performanceReviewRepository) UpdatePeer(c context.Context, peer domain.ReviewPeer) error {
+	var err error
+
+	// create transaction to submit peer feedback
+	err = r.db.WithTransaction(c, func(tx pgx.Tx) error {
+		// update peer feedback, if error will rollback
+		if err = r.PerformanceReviewQuery.UpdatePeer(c, tx, peer); err != nil {
+			return err
+		}
+		return nil
+	})
+
+	return err
+}
+
+func (r *performanceReviewRepository) FindAllCycle(c context.Context, filter domain.ReviewCycleQueryFilter) ([]domain.ReviewCycle, error) {
+	var cycles []domain.ReviewCycle
+	var err error
+
+	// get review cycles without transaction.
+	err = r.db.WithoutTransaction(c, func(db *pgxpool.Pool) error {
+		if cycles, err = r.PerformanceReviewQuery.FindAllCycle(c, db, filter); err != nil {
+			return err
+		}
+		return nil
+	})
+
+	return cycles, err
+}
+
+func (r *performanceReviewRepository) CountAllCycle(c context.Context, filter domain.ReviewCycleQueryFilter) (int, error) {
+	var count int
+	var err error
+
+	// count review cycles without transaction.
+	err = r.db.WithoutTransaction(c, func(db *pgxpool.Pool) error {
+		if count, err = r.PerformanceReviewQuery.CountAllCycle(c, db, filter); err != nil {
+			return err
+		}
+		return nil
+	})
+
+	return count, err
+}
+
+func (r *performanceReviewRepository) FindCycleById(c context.Context, id string) (domain.ReviewCycle, error) {
+	var cycle domain.ReviewCycle
+	var err error
+
+	// get review cycle by id without transaction.
+	err = r.db.WithoutTransaction(c, func(db *pgxpool.Pool) error {
+		if cycle, err = r.PerformanceReviewQuery.FindCycleById(c, db, id); err != nil {
+			return err
+		}
+		return nil
+	})
+
+	return cycle, err
+}
+
+func (r *performanceReviewRepository) FindAllReview(c context.Context, filter domain.PerformanceReviewQueryFilter) ([]domain.PerformanceReview, error) {
+	var reviews []domain.PerformanceReview
+	var err error
+
+	// get performance reviews without transaction.
+	err = r.db.WithoutTransaction(c, func(db *pgxpool.Pool) error {
+		if reviews, err = r.PerformanceReviewQuery.FindAllReview(c, db, filter); err != nil {
+			return err
+		}
+		return nil
+	})
+
+	return reviews, err
+}
+
+func (r *performanceReviewRepository) CountAllReview(c context.Context, filter domain.PerformanceReviewQueryFilter) (int, error) {
+	var count int
+	var err error
+
+	// count performance reviews without transaction.
+	err = r.db.WithoutTransaction(c, func(db *pgxpool.Pool) error {
+		if count, err = r.PerformanceReviewQuery.CountAllReview(c, db, filter); err != nil {
+			return err
+		}
+		return nil
+	})
+
+	return count, err
+}
+
+func (r *performanceReviewRepository) FindReviewById(c context.Context, id string) (domain.PerformanceReview, error) {
+	var review domain.PerformanceReview
+	var err error
+
+	// get performance review with its goals and peers by id without transaction.
+	err = r.db.WithoutTransaction(c, func(db *pgxpool.Pool) error {
+		if review, err = r.PerformanceReviewQuery.FindReviewById(c, db, id); err != nil {
+			return err
+		}
+		if review.Goals, err = r.PerformanceReviewQuery.FindGoals(c, db, id); err != nil {
+			return err
+		}
+		if review.Peers, err = r.PerformanceReviewQuery.FindPeers(c, db, id); err != nil {
+			return err
+		}
+		return nil
+	})
+
+	return review, err
+}
+
+func (r *performanceReviewRepository) CountReviewsWithoutGoals(c context.Context, cycleID string) (int, error) {
+	var count int
+	var err error
+
+	// count the reviews without complete goals without transaction.
+	err = r.db.WithoutTransaction(c, func(db *pgxpool.Pool) error {
+		if count, err = r.PerformanceReviewQuery.CountReviewsWithoutGoals(c, db, cycleID); err != nil {
+			return err
+		}
+		return nil
+	})
+
+	return count, err
+}
+
+func (r *performanceReviewRepository) FindPeersByReviewer(c context.Context, reviewerID string) ([]domain.ReviewPeer, error) {
+	var peers []domain.ReviewPeer
+	var err error
+
+	// get the peer feedback requested from the employee without transaction.
+	err = r.db.WithoutTransaction(c, func(db *pgxpool.Pool) error {
+		if peers, err = r.PerformanceReviewQuery.FindPeersByReviewer(c, db, reviewerID); err != nil {
+			return err
+		}
+		return nil
+	})
+
+	return peers, err
+}
@@ -0,0 +1,105 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/iqbaludinm/hr-microservice/user-service/model/domain"
+	"github.com/iqbaludinm/hr-microservice/user-service/repository/query"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type PositionRepository interface {
+	CreatePosition(c context.Context, position domain.Position) error
+	UpdatePosition(c context.Context, id string, position domain.Position) error
+	Delete(c context.Context, id string) error
+	FindAllPosition(c context.Context, departmentID string) ([]domain.Position, error)
+	FindById(c context.Context, id string) (domain.Position, error)
+}
+
+type positionRepository struct {
+	db            Store
+	PositionQuery query.PositionQuery
+}
+
+func NewPosition(db Store, q query.PositionQuery) PositionRepository {
+	return &positionRepository{
+		db:            db,
+		PositionQuery: q,
+	}
+}
+
+func (r *positionRepository) CreatePosition(c context.Context, position domain.Position) error {
+	var err error
+
+	// create transaction to create position
+	err = r.db.WithTransaction(c, func(tx pgx.Tx) error {
+		// create position, if error will rollback
+		if err = r.PositionQuery.CreatePosition(c, tx, position); err != nil {
+			return err
+		}
+		return nil
+	})
+
+	return err
+}
+
+func (r *positionRepository) UpdatePosition(c context.Context, id string, position domain.Position) error {
+	var err error
+
+	// create transaction to update position
+	err = r.db.WithTransaction(c, func(tx pgx.Tx) error {
+		// update position by id, if error will rollback
+		if err = r.PositionQuery.UpdatePosition(c, tx, id, position); err != nil {
+			return err
+		}
+		return nil
+	})
+
+	return err
+}
+
+func (r *positionRepository) Delete(c context.Context, id string) error {
+	var err error
+
+	// create transaction to delete position
+	err = r.db.WithTransaction(c, func(tx pgx.Tx) error {
+		// delete position by id, if error will rollback
+		if err = r.PositionQuery.Delete(c, tx, id); err != nil {
+			return err
+		}
+		return nil
+	})
+
+	return err
+}
+
+func (r *positionRepository) FindAllPosition(c context.Context, departmentID string) ([]domain.Position, error) {
+	var positions []domain.Position
+	var err error
+
+	// get positions without transaction.
+	err = r.db.WithoutTransaction(c, func(db *pgxpool.Pool) error {
+		if positions, err = r.PositionQuery.FindAllPosition(c, db, departmentID); err != nil {
+			return err
+		}
+		return nil
+	})
+
+	return positions, err
+}
+
+func (r *positionRepository) FindById(c context.Context, id string) (domain.Position, error) {
+	var position domain.Position
+	var err error
+
+	// get position by id without transaction.
+	err = r.db.WithoutTransaction(c, func(db *pgxpool.Pool) error {
+		if position, err = r.PositionQuery.FindById(c, db, id); err != nil {
+			return err
+		}
+		return nil
+	})
+
+	return position, err
+}
@@ -0,0 +1,852 @@
+package query
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/iqbaludinm/hr-microservice/user-service/model/domain"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type ApprovalQuery interface {
+	CreateWorkflow(c context.Context, tx pgx.Tx, workflow domain.ApprovalWorkflow) error
+	UpdateWorkflow(c context.Context, tx pgx.Tx, workflow domain.ApprovalWorkflow) error
+	DeleteWorkflow(c context.Context, tx pgx.Tx, id string) error
+	CreateStep(c context.Context, tx pgx.Tx, step domain.ApprovalWorkflowStep) error
+	DeleteSteps(c context.Context, tx pgx.Tx, workflowID string) error
+	CreateRequest(c context.Context, tx pgx.Tx, request domain.ApprovalRequest) error
+	UpdateRequestStatus(c context.Context, tx pgx.Tx, request domain.ApprovalRequest) error
+	CreateTask(c context.Context, tx pgx.Tx, task domain.ApprovalTask) error
+	UpdateTask(c context.Context, tx pgx.Tx, task domain.ApprovalTask, status string) error
+	CreateAction(c context.Context, tx pgx.Tx, action domain.ApprovalAction) error
+	FindAllWorkflow(c context.Context, db *pgxpool.Pool, filter domain.ApprovalWorkflowQueryFilter) ([]domain.ApprovalWorkflow, error)
+	CountAllWorkflow(c context.Context, db *pgxpool.Pool, filter domain.ApprovalWorkflowQueryFilter) (int, error)
+	FindWorkflowById(c context.Context, db *pgxpool.Pool, id string) (domain.ApprovalWorkflow, error)
+	FindActiveWorkflow(c context.Context, db *pgxpool.Pool, entityType string) (domain.ApprovalWorkflow, error)
+	FindSteps(c context.Context, db *pgxpool.Pool, workflowID string) ([]domain.ApprovalWorkflowStep, error)
+	FindAllRequest(c context.Context, db *pgxpool.Pool, filter domain.ApprovalQueryFilter) ([]domain.ApprovalRequest, error)
+	CountAllRequest(c context.Context, db *pgxpool.Pool, filter domain.ApprovalQueryFilter) (int, error)
+	FindRequestById(c context.Context, db *pgxpool.Pool, id string) (domain.ApprovalRequest, error)
+	FindRequestByIdForUpdate(c context.Context, tx pgx.Tx, id string) (domain.ApprovalRequest, error)
+	FindTasks(c context.Context, db Querier, requestID string) ([]domain.ApprovalTask, error)
+	FindActions(c context.Context, db *pgxpool.Pool, requestID string) ([]domain.ApprovalAction, error)
+	FindOverdueRequestIds(c context.Context, db *pgxpool.Pool, now time.Time) ([]string, error)
+	CreateDelegation(c context.Context, tx pgx.Tx, delegation domain.ApprovalDelegation) error
+	CancelDelegation(c context.Context, tx pgx.Tx, delegation domain.ApprovalDelegation) error
+	FindAllDelegation(c context.Context, db *pgxpool.Pool, filter domain.ApprovalDelegationQueryFilter) ([]domain.ApprovalDelegation, error)
+	CountAllDelegation(c context.Context, db *pgxpool.Pool, filter domain.ApprovalDelegationQueryFilter) (int, error)
+	FindDelegationById(c context.Context, db *pgxpool.Pool, id string) (domain.ApprovalDelegation, error)
+}
+
+type ApprovalQueryImpl struct {
+}
+
+func NewApproval() ApprovalQuery {
+	return &ApprovalQueryImpl{}
+}
+
+// the selected columns of the approval workflow. The order must match the 'scanApprovalWorkflow' function.
+const approvalWorkflowColumns = `
+	w.id,
+	w.code,
+	w.name,
+	w.entity_type,
+	w.description,
+	w.is_active,
+	w.created_at,
+	w.updated_at,
+	w.deleted_at`
+
+func scanApprovalWorkflow(row pgx.Row) (domain.ApprovalWorkflow, error) {
+	var data domain.ApprovalWorkflow
+	err := row.Scan(
+		&data.ID,
+		&data.Code,
+		&data.Name,
+		&data.EntityType,
+		&data.Description,
+		&data.IsActive,
+		&data.CreatedAt,
+		&data.UpdatedAt,
+		&data.DeletedAt,
+	)
+
+	return data, err
+}
+
+// the selected columns of the approval request, joined with the workflow and the requester. The order must match
+// the 'scanApprovalRequest' function.
+const approvalRequestColumns = `
+	r.id,
+	r.workflow_id,
+	r.entity_type,
+	r.entity_id,
+	r.title,
+	r.amount,
+	r.requester_id,
+	r.status,
+	r.created_at,
+	r.updated_at,
+	r.completed_at,
+	w.code,
+	w.name,
+	u.name,
+	u.id`
+
+const approvalRequestJoins = `
+	JOIN approval_workflows AS w ON w.id = r.workflow_id
+	JOIN employees AS e ON e.id = r.requester_id
+	JOIN users AS u ON u.id = e.user_id`
+
+func scanApprovalRequest(row pgx.Row) (domain.ApprovalRequest, error) {
+	var data domain.ApprovalRequest
+	err := row.Scan(
+		&data.ID,
+		&data.WorkflowID,
+		&data.EntityType,
+		&data.EntityID,
+		&data.Title,
+		&data.Amount,
+		&data.RequesterID,
+		&data.Status,
+		&data.CreatedAt,
+		&data.UpdatedAt,
+		&data.CompletedAt,
+		&data.WorkflowCode,
+		&data.WorkflowName,
+		&data.RequesterName,
+		&data.RequesterUserID,
+	)
+
+	return data, err
+}
+
+// the selected columns of the approval delegation, joined with the delegator and the delegate. The order must match
+// the 'scanApprovalDelegation' function.
+const approvalDelegationColumns = `
+	d.id,
+	d.delegator_id,
+	d.delegate_id,
+	d.start_date,
+	d.end_date,
+	d.entity_types,
+	d.reason,
+	d.cancelled_at,
+	d.created_at,
+	d.updated_at,
+	fu.name,
+	fu.id,
+	du.name,
+	du.id`
+
+const approvalDelegationJoins = `
+	JOIN employees AS fe ON fe.id = d.delegator_id
+	JOIN users AS fu ON fu.id = fe.user_id
+	JOIN employees AS de ON de.id = d.delegate_id
+	JOIN users AS du ON du.id = de.user_id`
+
+func scanApprovalDelegation(row pgx.Row) (domain.ApprovalDelegation, error) {
+	var data domain.ApprovalDelegation
+	err := row.Scan(
+		&data.ID,
+		&data.DelegatorID,
+		&data.DelegateID,
+		&data.StartDate,
+		&data.EndDate,
+		&data.EntityTypes,
+		&data.Reason,
+		&data.CancelledAt,
+		&data.CreatedAt,
+		&data.UpdatedAt,
+		&data.DelegatorName,
+		&data.DelegatorUserID,
+		&data.DelegateName,
+		&data.DelegateUserID,
+	)
+
+	return data, err
+}
+
+func (repository *ApprovalQueryImpl) CreateWorkflow(c context.Context, tx pgx.Tx, workflow domain.ApprovalWorkflow) error {
+	// build INSERT query
+	query := `INSERT INTO approval_workflows (
+		"id",
+		"code",
+		"name",
+		"entity_type",
+		"description",
+		"is_active",
+		"created_at",
+		"updated_at"
+		) VALUES ($1,$2,$3,$4,$5,$6,$7,$8)`
+
+	_, err := tx.Exec(c, query,
+		workflow.ID,
+		workflow.Code,
+		workflow.Name,
+		workflow.EntityType,
+		workflow.Description,
+		workflow.IsActive,
+		workflow.CreatedAt,
+		workflow.UpdatedAt,
+	)
+
+	return err
+}
+
+func (repository *ApprovalQueryImpl) UpdateWorkflow(c context.Context, tx pgx.Tx, workflow domain.ApprovalWorkflow) error {
+	// build UPDATE query
+	query := `UPDATE approval_workflows SET
+		code=$1,
+		name=$2,
+		entity_type=$3,
+		description=$4,
+		is_active=$5,
+		updated_at=$6
+		WHERE id=$7`
+
+	_, err := tx.Exec(c, query,
+		workflow.Code,
+		workflow.Name,
+		workflow.EntityType,
+		workflow.Description,
+		workflow.IsActive,
+		workflow.UpdatedAt,
+		workflow.ID,
+	)
+
+	return err
+}
+
+func (repository *ApprovalQueryImpl) DeleteWorkflow(c context.Context, tx pgx.Tx, id string) error {
+	// build UPDATE query
+	query := `UPDATE approval_workflows SET deleted_at=$1 WHERE id=$2`
+
+	_, err := tx.Exec(c, query, time.Now(), id)
+
+	return err
+}
+
+func (repository *ApprovalQueryImpl) CreateStep(c context.Context, tx pgx.Tx, step domain.ApprovalWorkflowStep) error {
+	// build INSERT query
+	query := `INSERT INTO approval_workflow_steps (
+		"workflow_id",
+		"seq",
+		"step_order",
+		"name",
+		"approver_type",
+		"approver_position_id",
+		"approver_employee_id",
+		"min_amount",
+		"timeout_hours"
+		) VALUES ($1,$2,$3,$4,$5,$6,$7,$8,$9)`
+
+	_, err := tx.Exec(c, query,
+		step.WorkflowID,
+		step.Seq,
+		step.StepOrder,
+		step.Name,
+		step.ApproverType,
+		step.ApproverPositionID,
+		step.ApproverEmployeeID,
+		step.MinAmount,
+		step.TimeoutHours,
+	)
+
+	return err
+}
+
+// delete the steps of the workflow, the steps are replaced when the workflow is updated
+func (repository *ApprovalQueryImpl) DeleteSteps(c context.Context, tx pgx.Tx, workflowID string) error {
+	query := `DELETE FROM approval_workflow_steps WHERE workflow_id=$1`
+
+	_, err := tx.Exec(c, query, workflowID)
+
+	return err
+}
+
+func (repository *ApprovalQueryImpl) CreateRequest(c context.Context, tx pgx.Tx, request domain.ApprovalRequest) error {
+	// build INSERT query
+	query := `INSERT INTO approval_requests (
+		"id",
+		"workflow_id",
+		"entity_type",
+		"entity_id",
+		"title",
+		"amount",
+		"requester_id",
+		"status",
+		"created_at",
+		"updated_at",
+		"completed_at"
+		) VALUES ($1,$2,$3,$4,$5,$6,$7,$8,$9,$10,$11)`
+
+	_, err := tx.Exec(c, query,
+		request.ID,
+		request.WorkflowID,
+		request.EntityType,
+		request.EntityID,
+		request.Title,
+		request.Amount,
+		request.RequesterID,
+		request.Status,
+		request.CreatedAt,
+		request.UpdatedAt,
+		request.CompletedAt,
+	)
+
+	return err
+}
+
+func (repository *ApprovalQueryImpl) UpdateRequestStatus(c context.Context, tx pgx.Tx, request domain.ApprovalRequest) error {
+	// build UPDATE query
+	query := `UPDATE approval_requests SET
+		status=$1,
+		updated_at=$2,
+		completed_at=$3
+		WHERE id=$4`
+
+	_, err := tx.Exec(c, query,
+		request.Status,
+		request.UpdatedAt,
+		request.CompletedAt,
+		request.ID,
+	)
+
+	return err
+}
+
+func (repository *ApprovalQueryImpl) CreateTask(c context.Context, tx pgx.Tx, task domain.ApprovalTask) error {
+	// build INSERT query
+	query := `INSERT INTO approval_tasks (
+		"id",
+		"request_id",
+		"seq",
+		"step_order",
+		"name",
+		"approver_type",
+		"approver_position_id",
+		"approver_id",
+		"assigned_from_id",
+		"on_behalf_of_id",
+		"timeout_hours",
+		"status",
+		"due_at",
+		"created_at",
+		"updated_at"
+		) VALUES ($1,$2,$3,$4,$5,$6,$7,$8,$9,$10,$11,$12,$13,$14,$15)`
+
+	_, err := tx.Exec(c, query,
+		task.ID,
+		task.RequestID,
+		task.Seq,
+		task.StepOrder,
+		task.Name,
+		task.ApproverType,
+		task.ApproverPositionID,
+		task.ApproverID,
+		task.AssignedFromID,
+		task.OnBehalfOfID,
+		task.TimeoutHours,
+		task.Status,
+		task.DueAt,
+		task.CreatedAt,
+		task.UpdatedAt,
+	)
+
+	return err
+}
+
+// update the assignment and the decision of the task
+// update the task which still has the status, it fails when the task has been changed since it was loaded
+func (repository *ApprovalQueryImpl) UpdateTask(c context.Context, tx pgx.Tx, task domain.ApprovalTask, status string) error {
+	// build UPDATE query
+	query := `UPDATE approval_tasks SET
+		approver_id=$1,
+		assigned_from_id=$2,
+		status=$3,
+		due_at=$4,
+		escalated_at=$5,
+		note=$6,
+		decided_by=$7,
+		decided_at=$8,
+		on_behalf_of_id=$9,
+		updated_at=$10
+		WHERE id=$11 AND status=$12`
+
+	tag, err := tx.Exec(c, query,
+		task.ApproverID,
+		task.AssignedFromID,
+		task.Status,
+		task.DueAt,
+		task.EscalatedAt,
+		task.Note,
+		task.DecidedBy,
+		task.DecidedAt,
+		task.OnBehalfOfID,
+		task.UpdatedAt,
+		task.ID,
+		status,
+	)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return fmt.Errorf("approval task %s is no longer %s", task.ID, status)
+	}
+
+	return nil
+}
+
+func (repository *ApprovalQueryImpl) CreateAction(c context.Context, tx pgx.Tx, action domain.ApprovalAction) error {
+	// build INSERT query
+	query := `INSERT INTO approval_actions (
+		"id",
+		"request_id",
+		"task_id",
+		"action",
+		"actor_id",
+		"on_behalf_of_id",
+		"note",
+		"created_at"
+		) VALUES ($1,$2,$3,$4,$5,$6,$7,$8)`
+
+	_, err := tx.Exec(c, query,
+		action.ID,
+		action.RequestID,
+		action.TaskID,
+		action.Action,
+		action.ActorID,
+		action.OnBehalfOfID,
+		action.Note,
+		action.CreatedAt,
+	)
+
+	return err
+}
+
+func (repository *ApprovalQueryImpl) FindAllWorkflow(c context.Context, db *pgxpool.Pool, filter domain.ApprovalWorkflowQueryFilter) ([]domain.ApprovalWorkflow, error) {
+	// approval workflow query filter builders
+	filterString, args, pagination := filter.BuildApprovalWorkflowQueries()
+
+	query := fmt.Sprintf(
+		`SELECT %s
+		FROM approval_workflows AS w
+		%s
+		ORDER BY w.entity_type, w.name
+		%s`,
+		approvalWorkflowColumns, filterString, pagination,
+	)
+	rows, err := db.Query(c, query, args...)
+	if err != nil {
+		return []domain.ApprovalWorkflow{}, err
+	}
+	defer rows.Close()
+
+	var datas []domain.ApprovalWorkflow
+	for rows.Next() {
+		data, err := scanApprovalWorkflow(rows)
+		if err != nil {
+			return []domain.ApprovalWorkflow{}, err
+		}
+		datas = append(datas, data)
+	}
+
+	return datas, rows.Err()
+}
+
+func (repository *ApprovalQueryImpl) CountAllWorkflow(c context.Context, db *pgxpool.Pool, filter domain.ApprovalWorkflowQueryFilter) (int, error) {
+	// approval workflow query filter builders
+	filterString, args, _ := filter.BuildApprovalWorkflowQueries()
+
+	query := fmt.Sprintf(`SELECT COUNT(*) FROM approval_workflows AS w %s`, filterString)
+
+	var count int
+	err := db.QueryRow(c, query, args...).Scan(&count)
+	if err != nil {
+		return 0, err
+	}
+
+	return count, err
+}
+
+func (repository *ApprovalQueryImpl) FindWorkflowById(c context.Context, db *pgxpool.Pool, id string) (domain.ApprovalWorkflow, error) {
+	query := `SELECT ` + approvalWorkflowColumns + ` FROM approval_workflows AS w WHERE w.id=$1 AND w.deleted_at IS NULL`
+
+	return scanApprovalWorkflow(db.QueryRow(c, query, id))
+}
+
+// find the active workflow of the entity type
+func (repository *ApprovalQueryImpl) FindActiveWorkflow(c context.Context, db *pgxpool.Pool, entityType string) (domain.ApprovalWorkflow, error) {
+	query := `SELECT ` + approvalWorkflowColumns + ` FROM approval_workflows AS w WHERE w.entity_type=$1 AND w.is_active AND w.deleted_at IS NULL`
+
+	return scanApprovalWorkflow(db.QueryRow(c, query, entityType))
+}
+
+func (repository *ApprovalQueryImpl) FindSteps(c context.Context, db *pgxpool.Pool, workflowID string) ([]domain.ApprovalWorkflowStep, error) {
+	query := `SELECT
+		s.workflow_id,
+		s.seq,
+		s.step_order,
+		s.name,
+		s.approver_type,
+		s.approver_position_id,
+		s.approver_employee_id,
+		s.min_amount,
+		s.timeout_hours,
+		COALESCE(p.title, ''),
+		COALESCE(u.name, '')
+		FROM approval_workflow_steps AS s
+		LEFT JOIN positions AS p ON p.id = s.approver_position_id
+		LEFT JOIN employees AS e ON e.id = s.approver_employee_id
+		LEFT JOIN users AS u ON u.id = e.user_id
+		WHERE s.workflow_id=$1
+		ORDER BY s.step_order, s.seq`
+
+	rows, err := db.Query(c, query, workflowID)
+	if err != nil {
+		return []domain.ApprovalWorkflowStep{}, err
+	}
+	defer rows.Close()
+
+	var datas []domain.ApprovalWorkflowStep
+	for rows.Next() {
+		var data domain.ApprovalWorkflowStep
+		err := rows.Scan(
+			&data.WorkflowID,
+			&data.Seq,
+			&data.StepOrder,
+			&data.Name,
+			&data.ApproverType,
+			&data.ApproverPositionID,
+			&data.ApproverEmployeeID,
+			&data.MinAmount,
+			&data.TimeoutHours,
+			&data.ApproverPositionName,
+			&data.ApproverEmployeeName,
+		)
+		if err != nil {
+			return []domain.ApprovalWorkflowStep{}, err
+		}
+		datas = append(datas, data)
+	}
+
+	return datas, rows.Err()
+}
+
+func (repository *ApprovalQueryImpl) FindAllRequest(c context.Context, db *pgxpool.Pool, filter domain.ApprovalQueryFilter) ([]domain.ApprovalRequest, error) {
+	// approval request query filter builders
+	filterString, args, pagination := filter.BuildApprovalQueries()
+
+	query := fmt.Sprintf(
+		`SELECT %s
+		FROM approval_requests AS r
+		%s
+		%s
+		ORDER BY r.created_at DESC
+		%s`,
+		approvalRequestColumns, approvalRequestJoins, filterString, pagination,
+	)
+	rows, err := db.Query(c, query, args...)
+	if err != nil {
+		return []domain.ApprovalRequest{}, err
+	}
+	defer rows.Close()
+
+	var datas []domain.ApprovalRequest
+	for rows.Next() {
+		data, err := scanApprovalRequest(rows)
+		if err != nil {
+			return []domain.ApprovalRequest{}, err
+		}
+		datas = append(datas, data)
+	}
+
+	return datas, rows.Err()
+}
+
+func (repository *ApprovalQueryImpl) CountAllRequest(c context.Context, db *pgxpool.Pool, filter domain.ApprovalQueryFilter) (int, error) {
+	// approval request query filter builders
+	filterString, args, _ := filter.BuildApprovalQueries()
+
+	query := fmt.Sprintf(`SELECT COUNT(*) FROM approval_requests AS r %s`, filterString)
+
+	var count int
+	err := db.QueryRow(c, query, args...).Scan(&count)
+	if err != nil {
+		return 0, err
+	}
+
+	return count, err
+}
+
+func (repository *ApprovalQueryImpl) FindRequestById(c context.Context, db *pgxpool.Pool, id string) (domain.ApprovalRequest, error) {
+	query := `SELECT ` + approvalRequestColumns + ` FROM approval_requests AS r ` + approvalRequestJoins + ` WHERE r.id=$1`
+
+	return scanApprovalRequest(db.QueryRow(c, query, id))
+}
+
+// find the approval request by id and lock it until the transaction ends, the requests are updated one at a time
+func (repository *ApprovalQueryImpl) FindRequestByIdForUpdate(c context.Context, tx pgx.Tx, id string) (domain.ApprovalRequest, error) {
+	query := `SELECT ` + approvalRequestColumns + ` FROM approval_requests AS r ` + approvalRequestJoins + ` WHERE r.id=$1 FOR UPDATE OF r`
+
+	return scanApprovalRequest(tx.QueryRow(c, query, id))
+}
+
+// find the tasks of the request, joined with the position, the approver, the approver it was assigned from, the
+// employee who decided it and the approver it was decided on behalf of
+func (repository *ApprovalQueryImpl) FindTasks(c context.Context, db Querier, requestID string) ([]domain.ApprovalTask, error) {
+	query := `SELECT
+		t.id,
+		t.request_id,
+		t.seq,
+		t.step_order,
+		t.name,
+		t.approver_type,
+		t.approver_position_id,
+		t.approver_id,
+		t.assigned_from_id,
+		t.timeout_hours,
+		t.status,
+		t.due_at,
+		t.escalated_at,
+		t.note,
+		t.decided_by,
+		t.decided_at,
+		t.on_behalf_of_id,
+		t.created_at,
+		t.updated_at,
+		COALESCE(p.title, ''),
+		COALESCE(au.name, ''),
+		COALESCE(au.id::text, ''),
+		COALESCE(fu.name, ''),
+		COALESCE(du.name, ''),
+		COALESCE(bu.name, '')
+		FROM approval_tasks AS t
+		LEFT JOIN positions AS p ON p.id = t.approver_position_id
+		LEFT JOIN employees AS ae ON ae.id = t.approver_id
+		LEFT JOIN users AS au ON au.id = ae.user_id
+		LEFT JOIN employees AS fe ON fe.id = t.assigned_from_id
+		LEFT JOIN users AS fu ON fu.id = fe.user_id
+		LEFT JOIN employees AS de ON de.id = t.decided_by
+		LEFT JOIN users AS du ON du.id = de.user_id
+		LEFT JOIN employees AS be ON be.id = t.on_behalf_of_id
+		LEFT JOIN users AS bu ON bu.id = be.user_id
+		WHERE t.request_id=$1
+		ORDER BY t.step_order, t.seq`
+
+	rows, err := db.Query(c, query, requestID)
+	if err != nil {
+		return []domain.ApprovalTask{}, err
+	}
+	defer rows.Close()
+
+	var datas []domain.ApprovalTask
+	for rows.Next() {
+		var data domain.ApprovalTask
+		err := rows.Scan(
+			&data.ID,
+			&data.RequestID,
+			&data.Seq,
+			&data.StepOrder,
+			&data.Name,
+			&data.ApproverType,
+			&data.ApproverPositionID,
+			&data.ApproverID,
+			&data.AssignedFromID,
+			&data.TimeoutHours,
+			&data.Status,
+			&data.DueAt,
+			&data.EscalatedAt,
+			&data.Note,
+			&data.DecidedBy,
+			&data.DecidedAt,
+			&data.OnBehalfOfID,
+			&data.CreatedAt,
+			&data.UpdatedAt,
+			&data.ApproverPositionName,
+			&data.ApproverName,
+			&data.ApproverUserID,
+			&data.AssignedFromName,
+			&data.DecidedByName,
+			&data.OnBehalfOfName,
+		)
+		if err != nil {
+			return []domain.ApprovalTask{}, err
+		}
+		datas = append(datas, data)
+	}
+
+	return datas, rows.Err()
+}
+
+func (repository *ApprovalQueryImpl) FindActions(c context.Context, db *pgxpool.Pool, requestID string) ([]domain.ApprovalAction, error) {
+	query := `SELECT
+		a.id,
+		a.request_id,
+		a.task_id,
+		a.action,
+		a.actor_id,
+		a.on_behalf_of_id,
+		a.note,
+		a.created_at,
+		COALESCE(u.name, ''),
+		COALESCE(bu.name, '')
+		FROM approval_actions AS a
+		LEFT JOIN employees AS e ON e.id = a.actor_id
+		LEFT JOIN users AS u ON u.id = e.user_id
+		LEFT JOIN employees AS be ON be.id = a.on_behalf_of_id
+		LEFT JOIN users AS bu ON bu.id = be.user_id
+		WHERE a.request_id=$1
+		ORDER BY a.created_at, a.id`
+
+	rows, err := db.Query(c, query, requestID)
+	if err != nil {
+		return []domain.ApprovalAction{}, err
+	}
+	defer rows.Close()
+
+	var datas []domain.ApprovalAction
+	for rows.Next() {
+		var data domain.ApprovalAction
+		err := rows.Scan(
+			&data.ID,
+			&data.RequestID,
+			&data.TaskID,
+			&data.Action,
+			&data.ActorID,
+			&data.OnBehalfOfID,
+			&data.Note,
+			&data.CreatedAt,
+			&data.ActorName,
+			&data.OnBehalfOfName,
+		)
+		if err != nil {
+			return []domain.ApprovalAction{}, err
+		}
+		datas = append(datas, data)
+	}
+
+	return datas, rows.Err()
+}
+
+// find the id of the pending requests with a task which is not decided before its due time and is not escalated yet
+func (repository *ApprovalQueryImpl) FindOverdueRequestIds(c context.Context, db *pgxpool.Pool, now time.Time) ([]string, error) {
+	query := `SELECT DISTINCT t.request_id
+		FROM approval_tasks AS t
+		JOIN approval_requests AS r ON r.id = t.request_id
+		WHERE
+			r.status = 'pending' AND
+			t.status = 'pending' AND
+			t.escalated_at IS NULL AND
+			t.due_at < $1`
+
+	rows, err := db.Query(c, query, now)
+	if err != nil {
+		return []string{}, err
+	}
+	defer rows.Close()
+
+	var datas []string
+	for rows.Next() {
+		var data string
+		if err := rows.Scan(&data); err != nil {
+			return []string{}, err
+		}
+		datas = append(datas, data)
+	}
+
+	return datas, rows.Err()
+}
+
+func (repository *ApprovalQueryImpl) CreateDelegation(c context.Context, tx pgx.Tx, delegation domain.ApprovalDelegation) error {
+	// build INSERT query
+	query := `INSERT INTO approval_delegations (
+		"id",
+		"delegator_id",
+		"delegate_id",
+		"start_date",
+		"end_date",
+		"entity_types",
+		"reason",
+		"created_at",
+		"updated_at"
+		) VALUES ($1,$2,$3,$4,$5,$6,$7,$8,$9)`
+
+	_, err := tx.Exec(c, query,
+		delegation.ID,
+		delegation.DelegatorID,
+		delegation.DelegateID,
+		delegation.StartDate,
+		delegation.EndDate,
+		delegation.EntityTypes,
+		delegation.Reason,
+		delegation.CreatedAt,
+		delegation.UpdatedAt,
+	)
+
+	return err
+}
+
+func (repository *ApprovalQueryImpl) CancelDelegation(c context.Context, tx pgx.Tx, delegation domain.ApprovalDelegation) error {
+	// build UPDATE query
+	query := `UPDATE approval_delegations SET cancelled_at=$1, updated_at=$2 WHERE id=$3`
+
+	_, err := tx.Exec(c, query, delegation.CancelledAt, delegation.UpdatedAt, delegation.ID)
+
+	return err
+}
+
+func (repository *ApprovalQueryImpl) FindAllDelegation(c context.Context, db *pgxpool.Pool, filter domain.ApprovalDelegationQueryFilter) ([]domain.ApprovalDelegation, error) {
+	// approval delegation query filter builders
+	filterString, args, pagination := filter.BuildApprovalDelegationQueries()
+
+	query := fmt.Sprintf(
+		`SELECT %s
+		FROM approval_delegations AS d
+		%s
+		%s
+		ORDER BY d.start_date DESC, fu.name
+		%s`,
+		approvalDelegationColumns, approvalDelegationJoins, filterString, pagination,
+	)
+	rows, err := db.Query(c, query, args...)
+	if err != nil {
+		return []domain.ApprovalDelegation{}, err
+	}
+	defer rows.Close()
+
+	var datas []domain.ApprovalDelegation
+	for rows.Next() {
+		data, err := scanApprovalDelegation(rows)
+		if err != nil {
+			return []domain.ApprovalDelegation{}, err
+		}
+		datas = append(datas, data)
+	}
+
+	return datas, rows.Err()
+}
+
+func (repository *ApprovalQueryImpl) CountAllDelegation(c context.Context, db *pgxpool.Pool, filter domain.ApprovalDelegationQueryFilter) (int, error) {
+	// approval delegation query filter builders
+	filterString, args, _ := filter.BuildApprovalDelegationQueries()
+
+	query := fmt.Sprintf(`SELECT COUNT(*) FROM approval_delegations AS d %s`, filterString)
+
+	var count int
+	err := db.QueryRow(c, query, args...).Scan(&count)
+	if err != nil {
+		return 0, err
+	}
+
+	return count, err
+}
+
+func (repository *ApprovalQueryImpl) FindDelegationById(c context.Context, db *pgxpool.Pool, id string) (domain.ApprovalDelegation, error) {
+	query := `SELECT ` + approvalDelegationColumns + ` FROM approval_delegations AS d ` + approvalDelegationJoins + ` WHERE d.id=$1`
+
+	return scanApprovalDelegation(db.QueryRow(c, query, id))
+}
@@ -0,0 +1,415 @@
+package query
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/iqbaludinm/hr-microservice/user-service/model/domain"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type AssetQuery interface {
+	CreateAsset(c context.Context, tx pgx.Tx, asset domain.Asset) error
+	UpdateAsset(c context.Context, tx pgx.Tx, id string, asset domain.Asset) error
+	UpdateHolder(c context.Context, tx pgx.Tx, id string, asset domain.Asset) error
+	Delete(c context.Context, tx pgx.Tx, id string) error
+	CreateAssignment(c context.Context, tx pgx.Tx, assignment domain.AssetAssignment) error
+	ReturnAssignment(c context.Context, tx pgx.Tx, id string, assignment domain.AssetAssignment) error
+	UpdateAssignmentHandover(c context.Context, tx pgx.Tx, id string, assignment domain.AssetAssignment) error
+	FindAllAsset(c context.Context, db *pgxpool.Pool, filter domain.AssetQueryFilter) ([]domain.Asset, error)
+	CountAllAsset(c context.Context, db *pgxpool.Pool, filter domain.AssetQueryFilter) (int, error)
+	FindById(c context.Context, db *pgxpool.Pool, id string) (domain.Asset, error)
+	CountAssignedAsset(c context.Context, db *pgxpool.Pool, employeeID string) (int, error)
+	FindAssignments(c context.Context, db *pgxpool.Pool, assetID string) ([]domain.AssetAssignment, error)
+	FindAssignmentById(c context.Context, db *pgxpool.Pool, assetID, id string) (domain.AssetAssignment, error)
+}
+
+type AssetQueryImpl struct {
+}
+
+func NewAsset() AssetQuery {
+	return &AssetQueryImpl{}
+}
+
+// the selected columns of the asset, joined with the employee who holds the asset and the open assignment.
+// The order must match the 'scanAsset' function.
+const assetColumns = `
+	a.id,
+	a.asset_code,
+	a.name,
+	a.category,
+	a.brand,
+	a.serial_number,
+	a.purchase_date,
+	a.purchase_price,
+	a.condition,
+	a.location,
+	a.status,
+	a.employee_id,
+	a.note,
+	a.created_at,
+	a.updated_at,
+	a.deleted_at,
+	COALESCE(e.employee_number, ''),
+	COALESCE(u.name, ''),
+	aa.id,
+	aa.assigned_at`
+
+const assetJoins = `
+	LEFT JOIN employees AS e ON e.id = a.employee_id
+	LEFT JOIN users AS u ON u.id = e.user_id
+	LEFT JOIN asset_assignments AS aa ON aa.asset_id = a.id AND aa.returned_at IS NULL`
+
+func scanAsset(row pgx.Row) (domain.Asset, error) {
+	var data domain.Asset
+	err := row.Scan(
+		&data.ID,
+		&data.AssetCode,
+		&data.Name,
+		&data.Category,
+		&data.Brand,
+		&data.SerialNumber,
+		&data.PurchaseDate,
+		&data.PurchasePrice,
+		&data.Condition,
+		&data.Location,
+		&data.Status,
+		&data.EmployeeID,
+		&data.Note,
+		&data.CreatedAt,
+		&data.UpdatedAt,
+		&data.DeletedAt,
+		&data.EmployeeNumber,
+		&data.EmployeeName,
+		&data.AssignmentID,
+		&data.AssignedAt,
+	)
+
+	return data, err
+}
+
+// the selected columns of the asset assignment, joined with the asset and the employee.
+// The order must match the 'scanAssetAssignment' function.
+const assetAssignmentColumns = `
+	aa.id,
+	aa.asset_id,
+	aa.employee_id,
+	aa.assigned_at,
+	aa.assigned_by,
+	aa.assign_condition,
+	aa.assign_note,
+	aa.assign_handover_id,
+	aa.returned_at,
+	aa.returned_by,
+	aa.return_condition,
+	aa.return_note,
+	aa.return_handover_id,
+	aa.created_at,
+	aa.updated_at,
+	a.asset_code,
+	a.name,
+	e.employee_number,
+	u.name`
+
+const assetAssignmentJoins = `
+	JOIN assets AS a ON a.id = aa.asset_id
+	JOIN employees AS e ON e.id = aa.employee_id
+	JOIN users AS u ON u.id = e.user_id`
+
+func scanAssetAssignment(row pgx.Row) (domain.AssetAssignment, error) {
+	var data domain.AssetAssignment
+	err := row.Scan(
+		&data.ID,
+		&data.AssetID,
+		&data.EmployeeID,
+		&data.AssignedAt,
+		&data.AssignedBy,
+		&data.AssignCondition,
+		&data.AssignNote,
+		&data.AssignHandoverID,
+		&data.ReturnedAt,
+		&data.ReturnedBy,
+		&data.ReturnCondition,
+		&data.ReturnNote,
+		&data.ReturnHandoverID,
+		&data.CreatedAt,
+		&data.UpdatedAt,
+		&data.AssetCode,
+		&data.AssetName,
+		&data.EmployeeNumber,
+		&data.EmployeeName,
+	)
+
+	return data, err
+}
+
+func (repository *AssetQueryImpl) CreateAsset(c context.Context, tx pgx.Tx, asset domain.Asset) error {
+	// build INSERT query
+	query := `INSERT INTO assets (
+		"id",
+		"asset_code",
+		"name",
+		"category",
+		"brand",
+		"serial_number",
+		"purchase_date",
+		"purchase_price",
+		"condition",
+		"location",
+		"status",
+		"note",
+		"created_at",
+		"updated_at"
+		) VALUES ($1,$2,$3,$4,$5,$6,$7,$8,$9,$10,$11,$12,$13,$14)`
+
+	_, err := tx.Exec(c, query,
+		asset.ID,
+		asset.AssetCode,
+		asset.Name,
+		asset.Category,
+		asset.Brand,
+		asset.SerialNumber,
+		asset.PurchaseDate,
+		asset.PurchasePrice,
+		asset.Condition,
+		asset.Location,
+		asset.Status,
+		asset.Note,
+		asset.CreatedAt,
+		asset.UpdatedAt,
+	)
+
+	return err
+}
+
+func (repository *AssetQueryImpl) UpdateAsset(c context.Context, tx pgx.Tx, id string, asset domain.Asset) error {
+	// build UPDATE query
+	query := `UPDATE assets SET
+		asset_code=$1,
+		name=$2,
+		category=$3,
+		brand=$4,
+		serial_number=$5,
+		purchase_date=$6,
+		purchase_price=$7,
+		condition=$8,
+		location=$9,
+		status=$10,
+		note=$11,
+		updated_at=$12
+		WHERE id=$13`
+
+	_, err := tx.Exec(c, query,
+		asset.AssetCode,
+		asset.Name,
+		asset.Category,
+		asset.Brand,
+		asset.SerialNumber,
+		asset.PurchaseDate,
+		asset.PurchasePrice,
+		asset.Condition,
+		asset.Location,
+		asset.Status,
+		asset.Note,
+		asset.UpdatedAt,
+		id,
+	)
+
+	return err
+}
+
+// update the employee who holds the asset, its status and its condition when it is assigned or returned
+func (repository *AssetQueryImpl) UpdateHolder(c context.Context, tx pgx.Tx, id string, asset domain.Asset) error {
+	// build UPDATE query
+	query := `UPDATE assets SET
+		employee_id=$1,
+		status=$2,
+		condition=$3,
+		updated_at=$4
+		WHERE id=$5`
+
+	_, err := tx.Exec(c, query,
+		asset.EmployeeID,
+		asset.Status,
+		asset.Condition,
+		asset.UpdatedAt,
+		id,
+	)
+
+	return err
+}
+
+func (repository *AssetQueryImpl) Delete(c context.Context, tx pgx.Tx, id string) error {
+	// build UPDATE query
+	query := `UPDATE assets SET deleted_at=$1 WHERE id=$2`
+
+	_, err := tx.Exec(c, query, time.Now(), id)
+
+	return err
+}
+
+func (repository *AssetQueryImpl) CreateAssignment(c context.Context, tx pgx.Tx, assignment domain.AssetAssignment) error {
+	// build INSERT query
+	query := `INSERT INTO asset_assignments (
+		"id",
+		"asset_id",
+		"employee_id",
+		"assigned_at",
+		"assigned_by",
+		"assign_condition",
+		"assign_note",
+		"created_at",
+		"updated_at"
+		) VALUES ($1,$2,$3,$4,$5,$6,$7,$8,$9)`
+
+	_, err := tx.Exec(c, query,
+		assignment.ID,
+		assignment.AssetID,
+		assignment.EmployeeID,
+		assignment.AssignedAt,
+		assignment.AssignedBy,
+		assignment.AssignCondition,
+		assignment.AssignNote,
+		assignment.CreatedAt,
+		assignment.UpdatedAt,
+	)
+
+	return err
+}
+
+// close the assignment when the asset is returned
+func (repository *AssetQueryImpl) ReturnAssignment(c context.Context, tx pgx.Tx, id string, assignment domain.AssetAssignment) error {
+	// build UPDATE query
+	query := `UPDATE asset_assignments SET
+		returned_at=$1,
+		returned_by=$2,
+		return_condition=$3,
+		return_note=$4,
+		updated_at=$5
+		WHERE id=$6`
+
+	_, err := tx.Exec(c, query,
+		assignment.ReturnedAt,
+		assignment.ReturnedBy,
+		assignment.ReturnCondition,
+		assignment.ReturnNote,
+		assignment.UpdatedAt,
+		id,
+	)
+
+	return err
+}
+
+// update the handover documents of the assignment
+func (repository *AssetQueryImpl) UpdateAssignmentHandover(c context.Context, tx pgx.Tx, id string, assignment domain.AssetAssignment) error {
+	// build UPDATE query
+	query := `UPDATE asset_assignments SET
+		assign_handover_id=$1,
+		return_handover_id=$2,
+		updated_at=$3
+		WHERE id=$4`
+
+	_, err := tx.Exec(c, query,
+		assignment.AssignHandoverID,
+		assignment.ReturnHandoverID,
+		assignment.UpdatedAt,
+		id,
+	)
+
+	return err
+}
+
+func (repository *AssetQueryImpl) FindAllAsset(c context.Context, db *pgxpool.Pool, filter domain.AssetQueryFilter) ([]domain.Asset, error) {
+	// asset query filter builders
+	filterString, args, pagination := filter.BuildAssetQueries()
+
+	query := fmt.Sprintf(
+		`SELECT %s
+		FROM assets AS a
+		%s
+		%s
+		ORDER BY a.asset_code
+		%s`,
+		assetColumns, assetJoins, filterString, pagination,
+	)
+	rows, err := db.Query(c, query, args...)
+	if err != nil {
+		return []domain.Asset{}, err
+	}
+	defer rows.Close()
+
+	var datas []domain.Asset
+	for rows.Next() {
+		data, err := scanAsset(rows)
+		if err != nil {
+			return []domain.Asset{}, err
+		}
+		datas = append(datas, data)
+	}
+
+	return datas, rows.Err()
+}
+
+func (repository *AssetQueryImpl) CountAllAsset(c context.Context, db *pgxpool.Pool, filter domain.AssetQueryFilter) (int, error) {
+	// asset query filter builders
+	filterString, args, _ := filter.BuildAssetQueries()
+
+	query := fmt.Sprintf(`SELECT COUNT(*) FROM assets AS a %s`, filterString)
+
+	var count int
+	err := db.QueryRow(c, query, args...).Scan(&count)
+	if err != nil {
+		return 0, err
+	}
+
+	return count, err
+}
+
+func (repository *AssetQueryImpl) FindById(c context.Context, db *pgxpool.Pool, id string) (domain.Asset, error) {
+	query := `SELECT ` + assetColumns + ` FROM assets AS a ` + assetJoins + ` WHERE a.deleted_at IS NULL AND a.id=$1`
+
+	return scanAsset(db.QueryRow(c, query, id))
+}
+
+// count the assets held by the employee, the assets must be returned before the employee leaves
+func (repository *AssetQueryImpl) CountAssignedAsset(c context.Context, db *pgxpool.Pool, employeeID string) (int, error) {
+	query := `SELECT COUNT(*) FROM asset_assignments WHERE employee_id=$1 AND returned_at IS NULL`
+
+	var count int
+	err := db.QueryRow(c, query, employeeID).Scan(&count)
+
+	return count, err
+}
+
+// find the assignment history of the asset, the latest assignment first
+func (repository *AssetQueryImpl) FindAssignments(c context.Context, db *pgxpool.Pool, assetID string) ([]domain.AssetAssignment, error) {
+	query := `SELECT ` + assetAssignmentColumns + ` FROM asset_assignments AS aa ` + assetAssignmentJoins + `
+		WHERE aa.asset_id=$1
+		ORDER BY aa.assigned_at DESC, aa.created_at DESC`
+
+	rows, err := db.Query(c, query, assetID)
+	if err != nil {
+		return []domain.AssetAssignment{}, err
+	}
+	defer rows.Close()
+
+	var datas []domain.AssetAssignment
+	for rows.Next() {
+		data, err := scanAssetAssignment(rows)
+		if err != nil {
+			return []domain.AssetAssignment{}, err
+		}
+		datas = append(datas, data)
+	}
+
+	return datas, rows.Err()
+}
+
+func (repository *AssetQueryImpl) FindAssignmentById(c context.Context, db *pgxpool.Pool, assetID, id string) (domain.AssetAssignment, error) {
+	query := `SELECT ` + assetAssignmentColumns + ` FROM asset_assignments AS aa ` + assetAssignmentJoins + `
+		WHERE aa.asset_id=$1 AND aa.id=$2`
+
+	return scanAssetAssignment(db.QueryRow(c, query, assetID, id))
+}
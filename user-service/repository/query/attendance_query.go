@@ -0,0 +1,305 @@
+package query
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/iqbaludinm/hr-microservice/user-service/model/domain"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type AttendanceQuery interface {
+	CreateAttendance(c context.Context, tx pgx.Tx, attendance domain.Attendance) error
+	UpdateCheckOut(c context.Context, tx pgx.Tx, id string, attendance domain.Attendance) error
+	FindAllAttendance(c context.Context, db *pgxpool.Pool, filter domain.AttendanceQueryFilter) ([]domain.Attendance, error)
+	CountAllAttendance(c context.Context, db *pgxpool.Pool, filter domain.AttendanceQueryFilter) (int, error)
+	FindById(c context.Context, db *pgxpool.Pool, id string) (domain.Attendance, error)
+	FindByEmployeeDate(c context.Context, db *pgxpool.Pool, employeeID string, date time.Time) (domain.Attendance, error)
+	FindOpenByEmployee(c context.Context, db *pgxpool.Pool, employeeID string) (domain.Attendance, error)
+	Summarize(c context.Context, db *pgxpool.Pool, filter domain.AttendanceQueryFilter, groupByDepartment bool) ([]domain.AttendanceSummary, error)
+}
+
+type AttendanceQueryImpl struct {
+}
+
+func NewAttendance() AttendanceQuery {
+	return &AttendanceQueryImpl{}
+}
+
+// the selected columns of the attendance, joined with the employee, the department and the shift.
+// The order must match the 'scanAttendance' function.
+const attendanceColumns = `
+	a.id,
+	a.employee_id,
+	a.date,
+	a.shift_id,
+	a.scheduled_start,
+	a.scheduled_end,
+	a.check_in_at,
+	a.check_in_latitude,
+	a.check_in_longitude,
+	a.check_in_location_id,
+	a.check_in_photo,
+	a.check_out_at,
+	a.check_out_latitude,
+	a.check_out_longitude,
+	a.check_out_location_id,
+	a.check_out_photo,
+	a.late_minutes,
+	a.early_leave_minutes,
+	a.overtime_minutes,
+	a.work_minutes,
+	a.created_at,
+	a.updated_at,
+	e.employee_number,
+	u.name,
+	COALESCE(d.name, ''),
+	COALESCE(s.name, '')`
+
+const attendanceJoins = `
+	JOIN employees AS e ON e.id = a.employee_id
+	JOIN users AS u ON u.id = e.user_id
+	LEFT JOIN departments AS d ON d.id = e.department_id
+	LEFT JOIN shifts AS s ON s.id = a.shift_id`
+
+func scanAttendance(row pgx.Row) (domain.Attendance, error) {
+	var data domain.Attendance
+	err := row.Scan(
+		&data.ID,
+		&data.EmployeeID,
+		&data.Date,
+		&data.ShiftID,
+		&data.ScheduledStart,
+		&data.ScheduledEnd,
+		&data.CheckInAt,
+		&data.CheckInLatitude,
+		&data.CheckInLongitude,
+		&data.CheckInLocationID,
+		&data.CheckInPhoto,
+		&data.CheckOutAt,
+		&data.CheckOutLatitude,
+		&data.CheckOutLongitude,
+		&data.CheckOutLocationID,
+		&data.CheckOutPhoto,
+		&data.LateMinutes,
+		&data.EarlyLeaveMinutes,
+		&data.OvertimeMinutes,
+		&data.WorkMinutes,
+		&data.CreatedAt,
+		&data.UpdatedAt,
+		&data.EmployeeNumber,
+		&data.EmployeeName,
+		&data.DepartmentName,
+		&data.ShiftName,
+	)
+
+	return data, err
+}
+
+func (repository *AttendanceQueryImpl) CreateAttendance(c context.Context, tx pgx.Tx, attendance domain.Attendance) error {
+	// build INSERT query
+	query := `INSERT INTO attendances (
+		"id",
+		"employee_id",
+		"date",
+		"shift_id",
+		"scheduled_start",
+		"scheduled_end",
+		"check_in_at",
+		"check_in_latitude",
+		"check_in_longitude",
+		"check_in_location_id",
+		"check_in_photo",
+		"late_minutes",
+		"created_at",
+		"updated_at"
+		) VALUES ($1,$2,$3,$4,$5,$6,$7,$8,$9,$10,$11,$12,$13,$14)`
+
+	_, err := tx.Exec(c, query,
+		attendance.ID,
+		attendance.EmployeeID,
+		attendance.Date,
+		attendance.ShiftID,
+		attendance.ScheduledStart,
+		attendance.ScheduledEnd,
+		attendance.CheckInAt,
+		attendance.CheckInLatitude,
+		attendance.CheckInLongitude,
+		attendance.CheckInLocationID,
+		attendance.CheckInPhoto,
+		attendance.LateMinutes,
+		attendance.CreatedAt,
+		attendance.UpdatedAt,
+	)
+
+	return err
+}
+
+func (repository *AttendanceQueryImpl) UpdateCheckOut(c context.Context, tx pgx.Tx, id string, attendance domain.Attendance) error {
+	// build UPDATE query
+	query := `UPDATE attendances SET
+		check_out_at=$1,
+		check_out_latitude=$2,
+		check_out_longitude=$3,
+		check_out_location_id=$4,
+		check_out_photo=$5,
+		early_leave_minutes=$6,
+		overtime_minutes=$7,
+		work_minutes=$8,
+		updated_at=$9
+		WHERE id=$10`
+
+	_, err := tx.Exec(c, query,
+		attendance.CheckOutAt,
+		attendance.CheckOutLatitude,
+		attendance.CheckOutLongitude,
+		attendance.CheckOutLocationID,
+		attendance.CheckOutPhoto,
+		attendance.EarlyLeaveMinutes,
+		attendance.OvertimeMinutes,
+		attendance.WorkMinutes,
+		attendance.UpdatedAt,
+		id,
+	)
+
+	return err
+}
+
+func (repository *AttendanceQueryImpl) FindAllAttendance(c context.Context, db *pgxpool.Pool, filter domain.AttendanceQueryFilter) ([]domain.Attendance, error) {
+	// attendance query filter builders
+	filterString, args, pagination := filter.BuildAttendanceQueries()
+
+	query := fmt.Sprintf(
+		`SELECT %s
+		FROM attendances AS a
+		%s
+		%s
+		ORDER BY a.date DESC, u.name
+		%s`,
+		attendanceColumns, attendanceJoins, filterString, pagination,
+	)
+	rows, err := db.Query(c, query, args...)
+	if err != nil {
+		return []domain.Attendance{}, err
+	}
+	defer rows.Close()
+
+	var datas []domain.Attendance
+	for rows.Next() {
+		data, err := scanAttendance(rows)
+		if err != nil {
+			return []domain.Attendance{}, err
+		}
+		datas = append(datas, data)
+	}
+
+	return datas, rows.Err()
+}
+
+func (repository *AttendanceQueryImpl) CountAllAttendance(c context.Context, db *pgxpool.Pool, filter domain.AttendanceQueryFilter) (int, error) {
+	// attendance query filter builders
+	filterString, args, _ := filter.BuildAttendanceQueries()
+
+	query := fmt.Sprintf(`SELECT COUNT(*) FROM attendances AS a JOIN employees AS e ON e.id = a.employee_id %s`, filterString)
+
+	var count int
+	err := db.QueryRow(c, query, args...).Scan(&count)
+	if err != nil {
+		return 0, err
+	}
+
+	return count, err
+}
+
+func (repository *AttendanceQueryImpl) FindById(c context.Context, db *pgxpool.Pool, id string) (domain.Attendance, error) {
+	query := fmt.Sprintf(`SELECT %s FROM attendances AS a %s WHERE a.id=$1`, attendanceColumns, attendanceJoins)
+
+	return scanAttendance(db.QueryRow(c, query, id))
+}
+
+func (repository *AttendanceQueryImpl) FindByEmployeeDate(c context.Context, db *pgxpool.Pool, employeeID string, date time.Time) (domain.Attendance, error) {
+	query := fmt.Sprintf(`SELECT %s FROM attendances AS a %s WHERE a.employee_id=$1 AND a.date=$2`, attendanceColumns, attendanceJoins)
+
+	return scanAttendance(db.QueryRow(c, query, employeeID, date))
+}
+
+// find the latest attendance of the employee that is not checked out yet
+func (repository *AttendanceQueryImpl) FindOpenByEmployee(c context.Context, db *pgxpool.Pool, employeeID string) (domain.Attendance, error) {
+	query := fmt.Sprintf(
+		`SELECT %s
+		FROM attendances AS a
+		%s
+		WHERE a.employee_id=$1 AND a.check_out_at is null
+		ORDER BY a.check_in_at DESC
+		LIMIT 1`,
+		attendanceColumns, attendanceJoins,
+	)
+
+	return scanAttendance(db.QueryRow(c, query, employeeID))
+}
+
+// summarize the attendances per employee, or per department when 'groupByDepartment' is true
+func (repository *AttendanceQueryImpl) Summarize(c context.Context, db *pgxpool.Pool, filter domain.AttendanceQueryFilter, groupByDepartment bool) ([]domain.AttendanceSummary, error) {
+	// attendance query filter builders, the summary is not paginated
+	filterString, args, _ := filter.BuildAttendanceQueries()
+
+	groupColumns := `e.id::text, e.employee_number, u.name, COALESCE(d.id::text, ''), COALESCE(d.name, '')`
+	groupBy := `GROUP BY e.id, e.employee_number, u.name, d.id, d.name ORDER BY d.name, u.name`
+	if groupByDepartment {
+		groupColumns = `'', '', '', COALESCE(d.id::text, ''), COALESCE(d.name, '')`
+		groupBy = `GROUP BY d.id, d.name ORDER BY d.name`
+	}
+
+	query := fmt.Sprintf(
+		`SELECT
+			%s,
+			COUNT(*),
+			COUNT(*) FILTER (WHERE a.late_minutes > 0),
+			COUNT(*) FILTER (WHERE a.early_leave_minutes > 0),
+			COUNT(*) FILTER (WHERE a.check_out_at is null),
+			COALESCE(SUM(a.late_minutes), 0),
+			COALESCE(SUM(a.early_leave_minutes), 0),
+			COALESCE(SUM(a.overtime_minutes), 0),
+			COALESCE(SUM(a.work_minutes), 0)
+		FROM attendances AS a
+		JOIN employees AS e ON e.id = a.employee_id
+		JOIN users AS u ON u.id = e.user_id
+		LEFT JOIN departments AS d ON d.id = e.department_id
+		%s
+		%s`,
+		groupColumns, filterString, groupBy,
+	)
+	rows, err := db.Query(c, query, args...)
+	if err != nil {
+		return []domain.AttendanceSummary{}, err
+	}
+	defer rows.Close()
+
+	var datas []domain.AttendanceSummary
+	for rows.Next() {
+		var data domain.AttendanceSummary
+		err := rows.Scan(
+			&data.EmployeeID,
+			&data.EmployeeNumber,
+			&data.EmployeeName,
+			&data.DepartmentID,
+			&data.DepartmentName,
+			&data.PresentDays,
+			&data.LateDays,
+			&data.EarlyLeaveDays,
+			&data.MissingCheckOut,
+			&data.LateMinutes,
+			&data.EarlyLeaveMinutes,
+			&data.OvertimeMinutes,
+			&data.WorkMinutes,
+		)
+		if err != nil {
+			return []domain.AttendanceSummary{}, err
+		}
+		datas = append(datas, data)
+	}
+
+	return datas, rows.Err()
+}
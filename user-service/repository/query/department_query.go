@@ -0,0 +1,151 @@
+package query
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/iqbaludinm/hr-microservice/user-service/model/domain"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type DepartmentQuery interface {
+	CreateDepartment(c context.Context, tx pgx.Tx, department domain.Department) error
+	UpdateDepartment(c context.Context, tx pgx.Tx, id string, department domain.Department) error
+	Delete(c context.Context, tx pgx.Tx, id string) error
+	FindAllDepartment(c context.Context, db *pgxpool.Pool, filter domain.DepartmentQueryFilter) ([]domain.Department, error)
+	FindById(c context.Context, db *pgxpool.Pool, id string) (domain.Department, error)
+	CountAllDepartment(c context.Context, db *pgxpool.Pool, filter domain.DepartmentQueryFilter) (int, error)
+	FindSubDepartmentIds(c context.Context, db *pgxpool.Pool, id string) ([]string, error)
+}
+
+type DepartmentQueryImpl struct {
+}
+
+func NewDepartment() DepartmentQuery {
+	return &DepartmentQueryImpl{}
+}
+
+func (repository *DepartmentQueryImpl) CreateDepartment(c context.Context, tx pgx.Tx, department domain.Department) error {
+	// build INSERT query
+	query := `INSERT INTO departments ("id", "code", "name", "parent_id", "created_at", "updated_at") VALUES ($1,$2,$3,$4,$5,$6)`
+
+	_, err := tx.Exec(c, query,
+		department.ID,
+		department.Code,
+		department.Name,
+		department.ParentID,
+		department.CreatedAt,
+		department.UpdatedAt,
+	)
+
+	return err
+}
+
+func (repository *DepartmentQueryImpl) UpdateDepartment(c context.Context, tx pgx.Tx, id string, department domain.Department) error {
+	// build UPDATE query
+	query := `UPDATE departments SET code=$1, name=$2, parent_id=$3, updated_at=$4 WHERE id=$5`
+
+	_, err := tx.Exec(c, query, department.Code, department.Name, department.ParentID, department.UpdatedAt, id)
+
+	return err
+}
+
+func (repository *DepartmentQueryImpl) Delete(c context.Context, tx pgx.Tx, id string) error {
+	// build UPDATE query
+	query := `UPDATE departments SET deleted_at=$1 WHERE id=$2`
+
+	_, err := tx.Exec(c, query, time.Now(), id)
+
+	return err
+}
+
+func (repository *DepartmentQueryImpl) FindAllDepartment(c context.Context, db *pgxpool.Pool, filter domain.DepartmentQueryFilter) ([]domain.Department, error) {
+	// department query filter builders
+	filterString, args, pagination := filter.BuildDepartmentQueries()
+
+	query := fmt.Sprintf(
+		`SELECT d.id, d.code, d.name, d.parent_id, d.created_at, d.updated_at, d.deleted_at
+		FROM departments AS d
+		%s
+		ORDER BY d.name
+		%s`,
+		filterString, pagination,
+	)
+	rows, err := db.Query(c, query, args...)
+	if err != nil {
+		return []domain.Department{}, err
+	}
+	defer rows.Close()
+
+	var datas []domain.Department
+	for rows.Next() {
+		var data domain.Department
+		err := rows.Scan(&data.ID, &data.Code, &data.Name, &data.ParentID, &data.CreatedAt, &data.UpdatedAt, &data.DeletedAt)
+		if err != nil {
+			return []domain.Department{}, err
+		}
+		datas = append(datas, data)
+	}
+
+	return datas, rows.Err()
+}
+
+func (repository *DepartmentQueryImpl) FindById(c context.Context, db *pgxpool.Pool, id string) (domain.Department, error) {
+	// build SELECT query
+	query := `SELECT id, code, name, parent_id, created_at, updated_at, deleted_at
+		FROM departments
+		WHERE
+			deleted_at is null AND
+			id=$1`
+
+	var data domain.Department
+	err := db.QueryRow(c, query, id).Scan(&data.ID, &data.Code, &data.Name, &data.ParentID, &data.CreatedAt, &data.UpdatedAt, &data.DeletedAt)
+
+	return data, err
+}
+
+func (repository *DepartmentQueryImpl) CountAllDepartment(c context.Context, db *pgxpool.Pool, filter domain.DepartmentQueryFilter) (int, error) {
+	// department query filter builders
+	filterString, args, _ := filter.BuildDepartmentQueries()
+
+	query := fmt.Sprintf(`SELECT COUNT(*) FROM departments AS d %s`, filterString)
+
+	var count int
+	err := db.QueryRow(c, query, args...).Scan(&count)
+	if err != nil {
+		return 0, err
+	}
+
+	return count, err
+}
+
+// find the id of the department and the ids of all of its sub-departments (recursively)
+func (repository *DepartmentQueryImpl) FindSubDepartmentIds(c context.Context, db *pgxpool.Pool, id string) ([]string, error) {
+	query := `WITH RECURSIVE tree AS (
+			SELECT id FROM departments WHERE id=$1 AND deleted_at is null
+			UNION
+			SELECT d.id FROM departments AS d
+			JOIN tree ON d.parent_id = tree.id
+			WHERE d.deleted_at is null
+		)
+		SELECT id FROM tree`
+
+	rows, err := db.Query(c, query, id)
+	if err != nil {
+		return []string{}, err
+	}
+	defer rows.Close()
+
+	var ids []string
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			return []string{}, err
+		}
+		ids = append(ids, id)
+	}
+
+	return ids, rows.Err()
+}
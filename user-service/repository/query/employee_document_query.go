@@ -0,0 +1,330 @@
+package query
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/iqbaludinm/hr-microservice/user-service/model/domain"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type EmployeeDocumentQuery interface {
+	CreateDocument(c context.Context, tx pgx.Tx, document domain.EmployeeDocument) error
+	UpdateDocument(c context.Context, tx pgx.Tx, id string, document domain.EmployeeDocument) error
+	UpdateLatestVersion(c context.Context, tx pgx.Tx, id string, document domain.EmployeeDocument) error
+	UpdateReminded(c context.Context, tx pgx.Tx, id string, expiryDate time.Time) error
+	DeleteDocument(c context.Context, tx pgx.Tx, id string) error
+	CreateVersion(c context.Context, tx pgx.Tx, version domain.EmployeeDocumentVersion) error
+	FindAll(c context.Context, db *pgxpool.Pool, filter domain.EmployeeDocumentQueryFilter) ([]domain.EmployeeDocument, error)
+	CountAll(c context.Context, db *pgxpool.Pool, filter domain.EmployeeDocumentQueryFilter) (int, error)
+	FindById(c context.Context, db *pgxpool.Pool, id string) (domain.EmployeeDocument, error)
+	FindVersions(c context.Context, db *pgxpool.Pool, documentID string) ([]domain.EmployeeDocumentVersion, error)
+	FindExpiring(c context.Context, db *pgxpool.Pool, expiryBefore time.Time) ([]domain.EmployeeDocument, error)
+}
+
+type EmployeeDocumentQueryImpl struct {
+}
+
+func NewEmployeeDocument() EmployeeDocumentQuery {
+	return &EmployeeDocumentQueryImpl{}
+}
+
+// the selected columns of the employee document, joined with the employee and the latest version.
+// The order must match the 'scanEmployeeDocument' function.
+const employeeDocumentColumns = `
+	d.id,
+	d.employee_id,
+	d.type,
+	d.title,
+	d.number,
+	d.expiry_date,
+	d.access,
+	d.latest_version,
+	d.reminded_expiry_date,
+	d.created_by,
+	d.created_at,
+	d.updated_at,
+	d.deleted_at,
+	e.employee_number,
+	u.name,
+	e.user_id,
+	v.file_name,
+	v.mime_type,
+	v.size,
+	v.checksum`
+
+const employeeDocumentJoins = `
+	JOIN employees AS e ON e.id = d.employee_id
+	JOIN users AS u ON u.id = e.user_id
+	JOIN employee_document_versions AS v ON v.document_id = d.id AND v.version = d.latest_version`
+
+func scanEmployeeDocument(row pgx.Row) (domain.EmployeeDocument, error) {
+	var data domain.EmployeeDocument
+	err := row.Scan(
+		&data.ID,
+		&data.EmployeeID,
+		&data.Type,
+		&data.Title,
+		&data.Number,
+		&data.ExpiryDate,
+		&data.Access,
+		&data.LatestVersion,
+		&data.RemindedExpiryDate,
+		&data.CreatedBy,
+		&data.CreatedAt,
+		&data.UpdatedAt,
+		&data.DeletedAt,
+		&data.EmployeeNumber,
+		&data.EmployeeName,
+		&data.EmployeeUserID,
+		&data.FileName,
+		&data.MimeType,
+		&data.Size,
+		&data.Checksum,
+	)
+
+	return data, err
+}
+
+func (repository *EmployeeDocumentQueryImpl) CreateDocument(c context.Context, tx pgx.Tx, document domain.EmployeeDocument) error {
+	// build INSERT query
+	query := `INSERT INTO employee_documents (
+		"id",
+		"employee_id",
+		"type",
+		"title",
+		"number",
+		"expiry_date",
+		"access",
+		"latest_version",
+		"created_by",
+		"created_at",
+		"updated_at"
+		) VALUES ($1,$2,$3,$4,$5,$6,$7,$8,$9,$10,$11)`
+
+	_, err := tx.Exec(c, query,
+		document.ID,
+		document.EmployeeID,
+		document.Type,
+		document.Title,
+		document.Number,
+		document.ExpiryDate,
+		document.Access,
+		document.LatestVersion,
+		document.CreatedBy,
+		document.CreatedAt,
+		document.UpdatedAt,
+	)
+
+	return err
+}
+
+func (repository *EmployeeDocumentQueryImpl) UpdateDocument(c context.Context, tx pgx.Tx, id string, document domain.EmployeeDocument) error {
+	// build UPDATE query
+	query := `UPDATE employee_documents SET
+		title=$1,
+		number=$2,
+		expiry_date=$3,
+		access=$4,
+		updated_at=$5
+		WHERE id=$6`
+
+	_, err := tx.Exec(c, query,
+		document.Title,
+		document.Number,
+		document.ExpiryDate,
+		document.Access,
+		document.UpdatedAt,
+		id,
+	)
+
+	return err
+}
+
+// update the latest version of the document when a new version is uploaded, the expiry date may be renewed
+func (repository *EmployeeDocumentQueryImpl) UpdateLatestVersion(c context.Context, tx pgx.Tx, id string, document domain.EmployeeDocument) error {
+	// build UPDATE query
+	query := `UPDATE employee_documents SET
+		latest_version=$1,
+		expiry_date=$2,
+		updated_at=$3
+		WHERE id=$4`
+
+	_, err := tx.Exec(c, query,
+		document.LatestVersion,
+		document.ExpiryDate,
+		document.UpdatedAt,
+		id,
+	)
+
+	return err
+}
+
+// mark the expiry date of the document as reminded, so the owner is not reminded again of the same expiry date
+func (repository *EmployeeDocumentQueryImpl) UpdateReminded(c context.Context, tx pgx.Tx, id string, expiryDate time.Time) error {
+	// build UPDATE query
+	query := `UPDATE employee_documents SET reminded_expiry_date=$1 WHERE id=$2`
+
+	_, err := tx.Exec(c, query, expiryDate, id)
+
+	return err
+}
+
+func (repository *EmployeeDocumentQueryImpl) DeleteDocument(c context.Context, tx pgx.Tx, id string) error {
+	// build UPDATE query
+	query := `UPDATE employee_documents SET deleted_at=$1 WHERE id=$2`
+
+	_, err := tx.Exec(c, query, time.Now(), id)
+
+	return err
+}
+
+func (repository *EmployeeDocumentQueryImpl) CreateVersion(c context.Context, tx pgx.Tx, version domain.EmployeeDocumentVersion) error {
+	// build INSERT query
+	query := `INSERT INTO employee_document_versions (
+		"document_id",
+		"version",
+		"storage_key",
+		"file_name",
+		"mime_type",
+		"size",
+		"checksum",
+		"note",
+		"uploaded_by",
+		"uploaded_at"
+		) VALUES ($1,$2,$3,$4,$5,$6,$7,$8,$9,$10)`
+
+	_, err := tx.Exec(c, query,
+		version.DocumentID,
+		version.Version,
+		version.StorageKey,
+		version.FileName,
+		version.MimeType,
+		version.Size,
+		version.Checksum,
+		version.Note,
+		version.UploadedBy,
+		version.UploadedAt,
+	)
+
+	return err
+}
+
+func (repository *EmployeeDocumentQueryImpl) FindAll(c context.Context, db *pgxpool.Pool, filter domain.EmployeeDocumentQueryFilter) ([]domain.EmployeeDocument, error) {
+	// employee document query filter builders
+	filterString, args, pagination := filter.BuildEmployeeDocumentQueries()
+
+	query := fmt.Sprintf(
+		`SELECT %s
+		FROM employee_documents AS d
+		%s
+		%s
+		ORDER BY d.created_at DESC
+		%s`,
+		employeeDocumentColumns, employeeDocumentJoins, filterString, pagination,
+	)
+
+	return findEmployeeDocuments(c, db, query, args...)
+}
+
+func (repository *EmployeeDocumentQueryImpl) CountAll(c context.Context, db *pgxpool.Pool, filter domain.EmployeeDocumentQueryFilter) (int, error) {
+	// employee document query filter builders
+	filterString, args, _ := filter.BuildEmployeeDocumentQueries()
+
+	query := fmt.Sprintf(`SELECT COUNT(*) FROM employee_documents AS d %s`, filterString)
+
+	var count int
+	err := db.QueryRow(c, query, args...).Scan(&count)
+	if err != nil {
+		return 0, err
+	}
+
+	return count, err
+}
+
+func (repository *EmployeeDocumentQueryImpl) FindById(c context.Context, db *pgxpool.Pool, id string) (domain.EmployeeDocument, error) {
+	query := `SELECT ` + employeeDocumentColumns + ` FROM employee_documents AS d ` + employeeDocumentJoins + `
+		WHERE d.id=$1 AND d.deleted_at IS NULL`
+
+	return scanEmployeeDocument(db.QueryRow(c, query, id))
+}
+
+// find the versions of the document, the latest version first
+func (repository *EmployeeDocumentQueryImpl) FindVersions(c context.Context, db *pgxpool.Pool, documentID string) ([]domain.EmployeeDocumentVersion, error) {
+	query := `SELECT
+		document_id,
+		version,
+		storage_key,
+		file_name,
+		mime_type,
+		size,
+		checksum,
+		note,
+		uploaded_by,
+		uploaded_at
+		FROM employee_document_versions
+		WHERE document_id=$1
+		ORDER BY version DESC`
+
+	rows, err := db.Query(c, query, documentID)
+	if err != nil {
+		return []domain.EmployeeDocumentVersion{}, err
+	}
+	defer rows.Close()
+
+	var datas []domain.EmployeeDocumentVersion
+	for rows.Next() {
+		var data domain.EmployeeDocumentVersion
+		err := rows.Scan(
+			&data.DocumentID,
+			&data.Version,
+			&data.StorageKey,
+			&data.FileName,
+			&data.MimeType,
+			&data.Size,
+			&data.Checksum,
+			&data.Note,
+			&data.UploadedBy,
+			&data.UploadedAt,
+		)
+		if err != nil {
+			return []domain.EmployeeDocumentVersion{}, err
+		}
+		datas = append(datas, data)
+	}
+
+	return datas, rows.Err()
+}
+
+// find the documents which expire on or before the date and whose owner has not been reminded of the expiry date,
+// the documents of the employees who have left are skipped
+func (repository *EmployeeDocumentQueryImpl) FindExpiring(c context.Context, db *pgxpool.Pool, expiryBefore time.Time) ([]domain.EmployeeDocument, error) {
+	query := `SELECT ` + employeeDocumentColumns + ` FROM employee_documents AS d ` + employeeDocumentJoins + `
+		WHERE d.deleted_at IS NULL AND d.expiry_date <= $1
+		AND (d.reminded_expiry_date IS NULL OR d.reminded_expiry_date <> d.expiry_date)
+		AND e.status NOT IN ('resigned', 'terminated')
+		ORDER BY d.expiry_date`
+
+	return findEmployeeDocuments(c, db, query, expiryBefore)
+}
+
+func findEmployeeDocuments(c context.Context, db *pgxpool.Pool, query string, args ...interface{}) ([]domain.EmployeeDocument, error) {
+	rows, err := db.Query(c, query, args...)
+	if err != nil {
+		return []domain.EmployeeDocument{}, err
+	}
+	defer rows.Close()
+
+	var datas []domain.EmployeeDocument
+	for rows.Next() {
+		data, err := scanEmployeeDocument(rows)
+		if err != nil {
+			return []domain.EmployeeDocument{}, err
+		}
+		datas = append(datas, data)
+	}
+
+	return datas, rows.Err()
+}
@@ -0,0 +1,368 @@
+package query
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/iqbaludinm/hr-microservice/user-service/model/domain"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type EmployeeQuery interface {
+	CreateEmployee(c context.Context, tx pgx.Tx, employee domain.Employee) error
+	UpdateEmployee(c context.Context, tx pgx.Tx, id string, employee domain.Employee) error
+	UpdatePersonal(c context.Context, tx pgx.Tx, id string, employee domain.Employee) error
+	Delete(c context.Context, tx pgx.Tx, id string) error
+	FindAllEmployee(c context.Context, db *pgxpool.Pool, filter domain.EmployeeQueryFilter) ([]domain.Employee, error)
+	FindById(c context.Context, db *pgxpool.Pool, id string) (domain.Employee, error)
+	FindByUserId(c context.Context, db *pgxpool.Pool, userID string) (domain.Employee, error)
+	CountAllEmployee(c context.Context, db *pgxpool.Pool, filter domain.EmployeeQueryFilter) (int, error)
+	FindReportingChain(c context.Context, db *pgxpool.Pool, id string) ([]domain.Employee, error)
+	UpdateAssignment(c context.Context, tx pgx.Tx, id string, employee domain.Employee) error
+}
+
+type EmployeeQueryImpl struct {
+}
+
+func NewEmployee() EmployeeQuery {
+	return &EmployeeQueryImpl{}
+}
+
+// the selected columns of the employee, joined with the 'users' table for the name, email and phone.
+// The order must match the 'scanEmployee' function.
+const employeeColumns = `
+	e.id,
+	e.user_id,
+	e.employee_number,
+	e.date_of_birth,
+	e.gender,
+	e.nik,
+	e.address,
+	e.hire_date,
+	e.employment_type,
+	e.status,
+	e.job_title,
+	e.salary_grade,
+	e.department_id,
+	e.position_id,
+	e.manager_id,
+	e.shift_id,
+	e.office_location_id,
+	e.emergency_contacts,
+	e.created_at,
+	e.updated_at,
+	e.deleted_at,
+	u.name,
+	u.email,
+	u.phone,
+	d.name,
+	COALESCE(p.title, ''),
+	ol.work_calendar_id`
+
+// the joined tables of the employee, used together with the 'employeeColumns'
+const employeeJoins = `
+	JOIN users AS u ON u.id = e.user_id
+	JOIN departments AS d ON d.id = e.department_id
+	LEFT JOIN positions AS p ON p.id = e.position_id
+	LEFT JOIN office_locations AS ol ON ol.id = e.office_location_id`
+
+func scanEmployee(row pgx.Row) (domain.Employee, error) {
+	var data domain.Employee
+	err := row.Scan(
+		&data.ID,
+		&data.UserID,
+		&data.EmployeeNumber,
+		&data.DateOfBirth,
+		&data.Gender,
+		&data.NIK,
+		&data.Address,
+		&data.HireDate,
+		&data.EmploymentType,
+		&data.Status,
+		&data.JobTitle,
+		&data.SalaryGrade,
+		&data.DepartmentID,
+		&data.PositionID,
+		&data.ManagerID,
+		&data.ShiftID,
+		&data.OfficeLocationID,
+		&data.EmergencyContacts,
+		&data.CreatedAt,
+		&data.UpdatedAt,
+		&data.DeletedAt,
+		&data.Name,
+		&data.Email,
+		&data.Phone,
+		&data.Department,
+		&data.Position,
+		&data.WorkCalendarID,
+	)
+
+	return data, err
+}
+
+func (repository *EmployeeQueryImpl) CreateEmployee(c context.Context, tx pgx.Tx, employee domain.Employee) error {
+	emergencyContacts, err := json.Marshal(employee.EmergencyContacts)
+	if err != nil {
+		return err
+	}
+
+	// build INSERT query
+	query := `INSERT INTO employees (
+		"id",
+		"user_id",
+		"employee_number",
+		"date_of_birth",
+		"gender",
+		"nik",
+		"address",
+		"hire_date",
+		"employment_type",
+		"status",
+		"job_title",
+		"salary_grade",
+		"department_id",
+		"position_id",
+		"manager_id",
+		"emergency_contacts",
+		"created_at",
+		"updated_at"
+		) VALUES ($1,$2,$3,$4,$5,$6,$7,$8,$9,$10,$11,$12,$13,$14,$15,$16::jsonb,$17,$18)`
+
+	_, err = tx.Exec(c, query,
+		employee.ID,
+		employee.UserID,
+		employee.EmployeeNumber,
+		employee.DateOfBirth,
+		employee.Gender,
+		employee.NIK,
+		employee.Address,
+		employee.HireDate,
+		employee.EmploymentType,
+		employee.Status,
+		employee.JobTitle,
+		employee.SalaryGrade,
+		employee.DepartmentID,
+		employee.PositionID,
+		employee.ManagerID,
+		string(emergencyContacts),
+		employee.CreatedAt,
+		employee.UpdatedAt,
+	)
+
+	return err
+}
+
+func (repository *EmployeeQueryImpl) UpdateEmployee(c context.Context, tx pgx.Tx, id string, employee domain.Employee) error {
+	emergencyContacts, err := json.Marshal(employee.EmergencyContacts)
+	if err != nil {
+		return err
+	}
+
+	// build UPDATE query
+	query := `UPDATE employees SET
+		employee_number=$1,
+		date_of_birth=$2,
+		gender=$3,
+		nik=$4,
+		address=$5,
+		hire_date=$6,
+		employment_type=$7,
+		status=$8,
+		emergency_contacts=$9::jsonb,
+		updated_at=$10
+		WHERE id=$11`
+
+	_, err = tx.Exec(c, query,
+		employee.EmployeeNumber,
+		employee.DateOfBirth,
+		employee.Gender,
+		employee.NIK,
+		employee.Address,
+		employee.HireDate,
+		employee.EmploymentType,
+		employee.Status,
+		string(emergencyContacts),
+		employee.UpdatedAt,
+		id,
+	)
+
+	return err
+}
+
+// KAFKA INTEGRATION: updating the self-editable personal data that is edited from profile-service
+func (repository *EmployeeQueryImpl) UpdatePersonal(c context.Context, tx pgx.Tx, id string, employee domain.Employee) error {
+	emergencyContacts, err := json.Marshal(employee.EmergencyContacts)
+	if err != nil {
+		return err
+	}
+
+	// build UPDATE query
+	query := `UPDATE employees SET address=$1, emergency_contacts=$2::jsonb, updated_at=$3 WHERE id=$4`
+
+	_, err = tx.Exec(c, query, employee.Address, string(emergencyContacts), employee.UpdatedAt, id)
+
+	return err
+}
+
+func (repository *EmployeeQueryImpl) Delete(c context.Context, tx pgx.Tx, id string) error {
+	// build UPDATE query
+	query := `UPDATE employees SET deleted_at=$1 WHERE id=$2`
+
+	_, err := tx.Exec(c, query, time.Now(), id)
+
+	return err
+}
+
+func (repository *EmployeeQueryImpl) FindAllEmployee(c context.Context, db *pgxpool.Pool, filter domain.EmployeeQueryFilter) ([]domain.Employee, error) {
+	// employee query filter builders
+	filterString, args, pagination := filter.BuildEmployeeQueries(0)
+
+	query := fmt.Sprintf(
+		`SELECT %s
+		FROM employees AS e
+		%s
+		%s
+		ORDER BY e.employee_number
+		%s`,
+		employeeColumns, employeeJoins, filterString, pagination,
+	)
+	rows, err := db.Query(c, query, args...)
+	if err != nil {
+		return []domain.Employee{}, err
+	}
+	defer rows.Close()
+
+	var datas []domain.Employee
+	for rows.Next() {
+		data, err := scanEmployee(rows)
+		if err != nil {
+			return []domain.Employee{}, err
+		}
+		datas = append(datas, data)
+	}
+
+	return datas, rows.Err()
+}
+
+func (repository *EmployeeQueryImpl) FindById(c context.Context, db *pgxpool.Pool, id string) (domain.Employee, error) {
+	// build SELECT query
+	query := fmt.Sprintf(
+		`SELECT %s
+		FROM employees AS e
+		%s
+		WHERE
+			e.deleted_at is null AND
+			e.id=$1
+		`,
+		employeeColumns, employeeJoins,
+	)
+
+	return scanEmployee(db.QueryRow(c, query, id))
+}
+
+func (repository *EmployeeQueryImpl) FindByUserId(c context.Context, db *pgxpool.Pool, userID string) (domain.Employee, error) {
+	// build SELECT query
+	query := fmt.Sprintf(
+		`SELECT %s
+		FROM employees AS e
+		%s
+		WHERE
+			e.deleted_at is null AND
+			e.user_id=$1
+		`,
+		employeeColumns, employeeJoins,
+	)
+
+	return scanEmployee(db.QueryRow(c, query, userID))
+}
+
+func (repository *EmployeeQueryImpl) CountAllEmployee(c context.Context, db *pgxpool.Pool, filter domain.EmployeeQueryFilter) (int, error) {
+	// employee query filter builders
+	filterString, args, _ := filter.BuildEmployeeQueries(0)
+
+	query := fmt.Sprintf(
+		`SELECT
+			COUNT(*)
+		FROM employees AS e
+		%s
+		%s
+		`,
+		employeeJoins, filterString,
+	)
+
+	row := db.QueryRow(c, query, args...)
+
+	var count int
+	err := row.Scan(&count)
+	if err != nil {
+		return 0, err
+	}
+
+	return count, err
+}
+
+// find the managers of the employee, ordered from the direct manager to the top of the organization
+func (repository *EmployeeQueryImpl) FindReportingChain(c context.Context, db *pgxpool.Pool, id string) ([]domain.Employee, error) {
+	// the depth limit is used for guarding a broken data with a cycle in the manager relationship
+	query := fmt.Sprintf(
+		`WITH RECURSIVE chain AS (
+			SELECT manager_id, 1 AS depth FROM employees WHERE id=$1
+			UNION ALL
+			SELECT m.manager_id, chain.depth + 1 FROM employees AS m
+			JOIN chain ON m.id = chain.manager_id
+			WHERE chain.depth < 100
+		)
+		SELECT %s
+		FROM chain
+		JOIN employees AS e ON e.id = chain.manager_id
+		%s
+		ORDER BY chain.depth`,
+		employeeColumns, employeeJoins,
+	)
+	rows, err := db.Query(c, query, id)
+	if err != nil {
+		return []domain.Employee{}, err
+	}
+	defer rows.Close()
+
+	var datas []domain.Employee
+	for rows.Next() {
+		data, err := scanEmployee(rows)
+		if err != nil {
+			return []domain.Employee{}, err
+		}
+		datas = append(datas, data)
+	}
+
+	return datas, rows.Err()
+}
+
+// update the current employment (department, position, job title, salary grade and manager) of the employee,
+// it is called when an employment history becomes effective.
+func (repository *EmployeeQueryImpl) UpdateAssignment(c context.Context, tx pgx.Tx, id string, employee domain.Employee) error {
+	// build UPDATE query
+	query := `UPDATE employees SET
+		department_id=$1,
+		position_id=$2,
+		job_title=$3,
+		salary_grade=$4,
+		manager_id=$5,
+		updated_at=$6
+		WHERE id=$7`
+
+	_, err := tx.Exec(c, query,
+		employee.DepartmentID,
+		employee.PositionID,
+		employee.JobTitle,
+		employee.SalaryGrade,
+		employee.ManagerID,
+		employee.UpdatedAt,
+		id,
+	)
+
+	return err
+}
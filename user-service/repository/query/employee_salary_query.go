@@ -0,0 +1,134 @@
+package query
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/iqbaludinm/hr-microservice/user-service/model/domain"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type EmployeeSalaryQuery interface {
+	CreateSalary(c context.Context, tx pgx.Tx, salary domain.EmployeeSalary) error
+	FindAllSalary(c context.Context, db *pgxpool.Pool, employeeID string) ([]domain.EmployeeSalary, error)
+	FindEffectiveSalaries(c context.Context, db *pgxpool.Pool, date time.Time) ([]domain.EmployeeSalary, error)
+}
+
+type EmployeeSalaryQueryImpl struct {
+}
+
+func NewEmployeeSalary() EmployeeSalaryQuery {
+	return &EmployeeSalaryQueryImpl{}
+}
+
+// the selected columns of the employee salary, joined with the 'employees' and 'users' table.
+// The order must match the 'scanEmployeeSalary' function.
+const employeeSalaryColumns = `
+	s.id,
+	s.employee_id,
+	s.base_salary,
+	s.allowances,
+	s.tax_status,
+	s.effective_from,
+	s.note,
+	s.created_at,
+	s.updated_at,
+	e.employee_number,
+	u.name`
+
+// the joined tables of the employee salary, used together with the 'employeeSalaryColumns'
+const employeeSalaryJoins = `
+	JOIN employees AS e ON e.id = s.employee_id
+	JOIN users AS u ON u.id = e.user_id`
+
+func scanEmployeeSalary(row pgx.Row) (domain.EmployeeSalary, error) {
+	var data domain.EmployeeSalary
+	err := row.Scan(
+		&data.ID,
+		&data.EmployeeID,
+		&data.BaseSalary,
+		&data.Allowances,
+		&data.TaxStatus,
+		&data.EffectiveFrom,
+		&data.Note,
+		&data.CreatedAt,
+		&data.UpdatedAt,
+		&data.EmployeeNumber,
+		&data.EmployeeName,
+	)
+
+	return data, err
+}
+
+func (repository *EmployeeSalaryQueryImpl) CreateSalary(c context.Context, tx pgx.Tx, salary domain.EmployeeSalary) error {
+	allowances, err := json.Marshal(salary.Allowances)
+	if err != nil {
+		return err
+	}
+
+	// build INSERT query
+	query := `INSERT INTO employee_salaries (
+		"id",
+		"employee_id",
+		"base_salary",
+		"allowances",
+		"tax_status",
+		"effective_from",
+		"note",
+		"created_at",
+		"updated_at"
+		) VALUES ($1,$2,$3,$4::jsonb,$5,$6,$7,$8,$9)`
+
+	_, err = tx.Exec(c, query,
+		salary.ID,
+		salary.EmployeeID,
+		salary.BaseSalary,
+		string(allowances),
+		salary.TaxStatus,
+		salary.EffectiveFrom,
+		salary.Note,
+		salary.CreatedAt,
+		salary.UpdatedAt,
+	)
+
+	return err
+}
+
+// find the salaries of the employee, the latest first
+func (repository *EmployeeSalaryQueryImpl) FindAllSalary(c context.Context, db *pgxpool.Pool, employeeID string) ([]domain.EmployeeSalary, error) {
+	query := `SELECT ` + employeeSalaryColumns + ` FROM employee_salaries AS s ` + employeeSalaryJoins + `
+		WHERE s.employee_id=$1
+		ORDER BY s.effective_from DESC`
+
+	return findEmployeeSalaries(c, db, query, employeeID)
+}
+
+// find the salary of every employee effective on the date
+func (repository *EmployeeSalaryQueryImpl) FindEffectiveSalaries(c context.Context, db *pgxpool.Pool, date time.Time) ([]domain.EmployeeSalary, error) {
+	query := `SELECT DISTINCT ON (s.employee_id) ` + employeeSalaryColumns + ` FROM employee_salaries AS s ` + employeeSalaryJoins + `
+		WHERE s.effective_from <= $1
+		ORDER BY s.employee_id, s.effective_from DESC`
+
+	return findEmployeeSalaries(c, db, query, date)
+}
+
+func findEmployeeSalaries(c context.Context, db *pgxpool.Pool, query string, args ...interface{}) ([]domain.EmployeeSalary, error) {
+	rows, err := db.Query(c, query, args...)
+	if err != nil {
+		return []domain.EmployeeSalary{}, err
+	}
+	defer rows.Close()
+
+	var datas []domain.EmployeeSalary
+	for rows.Next() {
+		data, err := scanEmployeeSalary(rows)
+		if err != nil {
+			return []domain.EmployeeSalary{}, err
+		}
+		datas = append(datas, data)
+	}
+
+	return datas, rows.Err()
+}
@@ -0,0 +1,301 @@
+package query
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/iqbaludinm/hr-microservice/user-service/model/domain"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type EmploymentContractQuery interface {
+	NextContractNumber(c context.Context, tx pgx.Tx) (int64, error)
+	CreateContract(c context.Context, tx pgx.Tx, contract domain.EmploymentContract) error
+	UpdateContract(c context.Context, tx pgx.Tx, id string, contract domain.EmploymentContract) error
+	UpdateStatus(c context.Context, tx pgx.Tx, id string, contract domain.EmploymentContract) error
+	UpdateAlerted(c context.Context, tx pgx.Tx, id string, days int) error
+	EndExpired(c context.Context, tx pgx.Tx, today time.Time) (int64, error)
+	FindAll(c context.Context, db *pgxpool.Pool, filter domain.EmploymentContractQueryFilter) ([]domain.EmploymentContract, error)
+	CountAll(c context.Context, db *pgxpool.Pool, filter domain.EmploymentContractQueryFilter) (int, error)
+	FindById(c context.Context, db *pgxpool.Pool, id string) (domain.EmploymentContract, error)
+	FindActiveByEmployee(c context.Context, db *pgxpool.Pool, employeeID string) (domain.EmploymentContract, error)
+	FindSuccessor(c context.Context, db *pgxpool.Pool, previousID string) (domain.EmploymentContract, error)
+	FindExpiring(c context.Context, db *pgxpool.Pool, today, until time.Time) ([]domain.EmploymentContract, error)
+}
+
+type EmploymentContractQueryImpl struct {
+}
+
+func NewEmploymentContract() EmploymentContractQuery {
+	return &EmploymentContractQueryImpl{}
+}
+
+// the selected columns of the employment contract, joined with the employee, the manager of the employee and
+// the salary. The order must match the 'scanEmploymentContract' function.
+const employmentContractColumns = `
+	ec.id,
+	ec.contract_number,
+	ec.employee_id,
+	ec.type,
+	ec.job_title,
+	ec.start_date,
+	ec.end_date,
+	ec.probation_end_date,
+	ec.salary_id,
+	ec.previous_contract_id,
+	ec.status,
+	ec.signed_document_id,
+	ec.signed_at,
+	ec.alerted_days,
+	ec.note,
+	ec.created_by,
+	ec.created_at,
+	ec.updated_at,
+	e.employee_number,
+	u.name,
+	e.user_id,
+	me.user_id,
+	s.base_salary`
+
+const employmentContractJoins = `
+	JOIN employees AS e ON e.id = ec.employee_id
+	JOIN users AS u ON u.id = e.user_id
+	LEFT JOIN employees AS me ON me.id = e.manager_id
+	LEFT JOIN employee_salaries AS s ON s.id = ec.salary_id`
+
+func scanEmploymentContract(row pgx.Row) (domain.EmploymentContract, error) {
+	var data domain.EmploymentContract
+	err := row.Scan(
+		&data.ID,
+		&data.ContractNumber,
+		&data.EmployeeID,
+		&data.Type,
+		&data.JobTitle,
+		&data.StartDate,
+		&data.EndDate,
+		&data.ProbationEndDate,
+		&data.SalaryID,
+		&data.PreviousContractID,
+		&data.Status,
+		&data.SignedDocumentID,
+		&data.SignedAt,
+		&data.AlertedDays,
+		&data.Note,
+		&data.CreatedBy,
+		&data.CreatedAt,
+		&data.UpdatedAt,
+		&data.EmployeeNumber,
+		&data.EmployeeName,
+		&data.EmployeeUserID,
+		&data.ManagerUserID,
+		&data.BaseSalary,
+	)
+
+	return data, err
+}
+
+// get the next running number of the employment contract
+func (repository *EmploymentContractQueryImpl) NextContractNumber(c context.Context, tx pgx.Tx) (int64, error) {
+	var number int64
+	err := tx.QueryRow(c, `SELECT nextval('employment_contract_number_seq')`).Scan(&number)
+
+	return number, err
+}
+
+func (repository *EmploymentContractQueryImpl) CreateContract(c context.Context, tx pgx.Tx, contract domain.EmploymentContract) error {
+	// build INSERT query
+	query := `INSERT INTO employment_contracts (
+		"id",
+		"contract_number",
+		"employee_id",
+		"type",
+		"job_title",
+		"start_date",
+		"end_date",
+		"probation_end_date",
+		"salary_id",
+		"previous_contract_id",
+		"status",
+		"note",
+		"created_by",
+		"created_at",
+		"updated_at"
+		) VALUES ($1,$2,$3,$4,$5,$6,$7,$8,$9,$10,$11,$12,$13,$14,$15)`
+
+	_, err := tx.Exec(c, query,
+		contract.ID,
+		contract.ContractNumber,
+		contract.EmployeeID,
+		contract.Type,
+		contract.JobTitle,
+		contract.StartDate,
+		contract.EndDate,
+		contract.ProbationEndDate,
+		contract.SalaryID,
+		contract.PreviousContractID,
+		contract.Status,
+		contract.Note,
+		contract.CreatedBy,
+		contract.CreatedAt,
+		contract.UpdatedAt,
+	)
+
+	return err
+}
+
+// update the terms of the draft contract
+func (repository *EmploymentContractQueryImpl) UpdateContract(c context.Context, tx pgx.Tx, id string, contract domain.EmploymentContract) error {
+	// build UPDATE query
+	query := `UPDATE employment_contracts SET
+		job_title=$1,
+		start_date=$2,
+		end_date=$3,
+		probation_end_date=$4,
+		salary_id=$5,
+		note=$6,
+		updated_at=$7
+		WHERE id=$8`
+
+	_, err := tx.Exec(c, query,
+		contract.JobTitle,
+		contract.StartDate,
+		contract.EndDate,
+		contract.ProbationEndDate,
+		contract.SalaryID,
+		contract.Note,
+		contract.UpdatedAt,
+		id,
+	)
+
+	return err
+}
+
+func (repository *EmploymentContractQueryImpl) UpdateStatus(c context.Context, tx pgx.Tx, id string, contract domain.EmploymentContract) error {
+	// build UPDATE query
+	query := `UPDATE employment_contracts SET
+		status=$1,
+		signed_document_id=$2,
+		signed_at=$3,
+		updated_at=$4
+		WHERE id=$5`
+
+	_, err := tx.Exec(c, query,
+		contract.Status,
+		contract.SignedDocumentID,
+		contract.SignedAt,
+		contract.UpdatedAt,
+		id,
+	)
+
+	return err
+}
+
+// update the smallest number of days before the end date the expiry alert has been sent for
+func (repository *EmploymentContractQueryImpl) UpdateAlerted(c context.Context, tx pgx.Tx, id string, days int) error {
+	query := `UPDATE employment_contracts SET alerted_days=$1 WHERE id=$2`
+
+	_, err := tx.Exec(c, query, days, id)
+
+	return err
+}
+
+// end the active contracts which end date has passed, it returns the number of the ended contracts
+func (repository *EmploymentContractQueryImpl) EndExpired(c context.Context, tx pgx.Tx, today time.Time) (int64, error) {
+	query := `UPDATE employment_contracts SET status=$1, updated_at=$2
+		WHERE status=$3 AND end_date < $4::date`
+
+	tag, err := tx.Exec(c, query, domain.ContractStatusEnded, time.Now(), domain.ContractStatusActive, today)
+	if err != nil {
+		return 0, err
+	}
+
+	return tag.RowsAffected(), nil
+}
+
+func (repository *EmploymentContractQueryImpl) FindAll(c context.Context, db *pgxpool.Pool, filter domain.EmploymentContractQueryFilter) ([]domain.EmploymentContract, error) {
+	// employment contract query filter builders
+	filterString, args, pagination := filter.BuildEmploymentContractQueries()
+
+	query := fmt.Sprintf(
+		`SELECT %s
+		FROM employment_contracts AS ec
+		%s
+		%s
+		ORDER BY ec.start_date DESC, ec.contract_number DESC
+		%s`,
+		employmentContractColumns, employmentContractJoins, filterString, pagination,
+	)
+
+	return findEmploymentContracts(c, db, query, args...)
+}
+
+func (repository *EmploymentContractQueryImpl) CountAll(c context.Context, db *pgxpool.Pool, filter domain.EmploymentContractQueryFilter) (int, error) {
+	// employment contract query filter builders
+	filterString, args, _ := filter.BuildEmploymentContractQueries()
+
+	query := fmt.Sprintf(`SELECT COUNT(*) FROM employment_contracts AS ec %s`, filterString)
+
+	var count int
+	err := db.QueryRow(c, query, args...).Scan(&count)
+	if err != nil {
+		return 0, err
+	}
+
+	return count, err
+}
+
+func (repository *EmploymentContractQueryImpl) FindById(c context.Context, db *pgxpool.Pool, id string) (domain.EmploymentContract, error) {
+	query := `SELECT ` + employmentContractColumns + ` FROM employment_contracts AS ec ` + employmentContractJoins + ` WHERE ec.id=$1`
+
+	return scanEmploymentContract(db.QueryRow(c, query, id))
+}
+
+func (repository *EmploymentContractQueryImpl) FindActiveByEmployee(c context.Context, db *pgxpool.Pool, employeeID string) (domain.EmploymentContract, error) {
+	query := `SELECT ` + employmentContractColumns + ` FROM employment_contracts AS ec ` + employmentContractJoins + `
+		WHERE ec.employee_id=$1 AND ec.status=$2`
+
+	return scanEmploymentContract(db.QueryRow(c, query, employeeID, domain.ContractStatusActive))
+}
+
+// get the contract which renews or converts the previous contract, the cancelled contract is excluded
+func (repository *EmploymentContractQueryImpl) FindSuccessor(c context.Context, db *pgxpool.Pool, previousID string) (domain.EmploymentContract, error) {
+	query := `SELECT ` + employmentContractColumns + ` FROM employment_contracts AS ec ` + employmentContractJoins + `
+		WHERE ec.previous_contract_id=$1 AND ec.status<>$2`
+
+	return scanEmploymentContract(db.QueryRow(c, query, previousID, domain.ContractStatusCancelled))
+}
+
+// get the active PKWT which ends between today and the 'until' date and is not renewed or converted yet
+func (repository *EmploymentContractQueryImpl) FindExpiring(c context.Context, db *pgxpool.Pool, today, until time.Time) ([]domain.EmploymentContract, error) {
+	query := `SELECT ` + employmentContractColumns + ` FROM employment_contracts AS ec ` + employmentContractJoins + `
+		WHERE ec.status=$1 AND ec.type=$2
+		AND ec.end_date BETWEEN $3::date AND $4::date
+		AND NOT EXISTS (
+			SELECT 1 FROM employment_contracts AS n
+			WHERE n.previous_contract_id = ec.id AND n.status<>$5)
+		ORDER BY ec.end_date`
+
+	return findEmploymentContracts(c, db, query,
+		domain.ContractStatusActive, domain.ContractTypePKWT, today, until, domain.ContractStatusCancelled)
+}
+
+func findEmploymentContracts(c context.Context, db *pgxpool.Pool, query string, args ...interface{}) ([]domain.EmploymentContract, error) {
+	rows, err := db.Query(c, query, args...)
+	if err != nil {
+		return []domain.EmploymentContract{}, err
+	}
+	defer rows.Close()
+
+	var datas []domain.EmploymentContract
+	for rows.Next() {
+		data, err := scanEmploymentContract(rows)
+		if err != nil {
+			return []domain.EmploymentContract{}, err
+		}
+		datas = append(datas, data)
+	}
+
+	return datas, rows.Err()
+}
@@ -0,0 +1,207 @@
+package query
+
+import (
+	"context"
+	"time"
+
+	"github.com/iqbaludinm/hr-microservice/user-service/model/domain"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type EmploymentHistoryQuery interface {
+	SaveHistory(c context.Context, tx pgx.Tx, history domain.EmploymentHistory) error
+	CloseCurrent(c context.Context, tx pgx.Tx, employeeID string, effectiveTo time.Time) error
+	Delete(c context.Context, tx pgx.Tx, id string) error
+	FindByEmployeeId(c context.Context, db *pgxpool.Pool, employeeID string) ([]domain.EmploymentHistory, error)
+	FindById(c context.Context, db *pgxpool.Pool, id string) (domain.EmploymentHistory, error)
+	FindDue(c context.Context, db *pgxpool.Pool, date time.Time) ([]domain.EmploymentHistory, error)
+	FindAsOf(c context.Context, db *pgxpool.Pool, employeeID string, date time.Time) (domain.EmploymentHistory, error)
+}
+
+type EmploymentHistoryQueryImpl struct {
+}
+
+func NewEmploymentHistory() EmploymentHistoryQuery {
+	return &EmploymentHistoryQueryImpl{}
+}
+
+// the selected columns of the employment history, joined with the 'departments', 'positions' and
+// 'users' (for the name of the manager) table.
+// The order must match the 'scanEmploymentHistory' function.
+const employmentHistoryColumns = `
+	h.id,
+	h.employee_id,
+	h.change_type,
+	h.department_id,
+	h.position_id,
+	h.job_title,
+	h.salary_grade,
+	h.manager_id,
+	h.effective_from,
+	h.effective_to,
+	h.reason,
+	h.applied_at,
+	h.created_at,
+	d.name,
+	COALESCE(p.title, ''),
+	COALESCE(mu.name, '')`
+
+const employmentHistoryJoins = `
+	JOIN departments AS d ON d.id = h.department_id
+	LEFT JOIN positions AS p ON p.id = h.position_id
+	LEFT JOIN employees AS m ON m.id = h.manager_id
+	LEFT JOIN users AS mu ON mu.id = m.user_id`
+
+func scanEmploymentHistory(row pgx.Row) (domain.EmploymentHistory, error) {
+	var data domain.EmploymentHistory
+	err := row.Scan(
+		&data.ID,
+		&data.EmployeeID,
+		&data.ChangeType,
+		&data.DepartmentID,
+		&data.PositionID,
+		&data.JobTitle,
+		&data.SalaryGrade,
+		&data.ManagerID,
+		&data.EffectiveFrom,
+		&data.EffectiveTo,
+		&data.Reason,
+		&data.AppliedAt,
+		&data.CreatedAt,
+		&data.DepartmentName,
+		&data.PositionTitle,
+		&data.ManagerName,
+	)
+
+	return data, err
+}
+
+// insert the employment history, or mark it as applied when it already exist (a pending change).
+func (repository *EmploymentHistoryQueryImpl) SaveHistory(c context.Context, tx pgx.Tx, history domain.EmploymentHistory) error {
+	// build INSERT query
+	query := `INSERT INTO employment_histories (
+		"id",
+		"employee_id",
+		"change_type",
+		"department_id",
+		"position_id",
+		"job_title",
+		"salary_grade",
+		"manager_id",
+		"effective_from",
+		"effective_to",
+		"reason",
+		"applied_at",
+		"created_at"
+		) VALUES ($1,$2,$3,$4,$5,$6,$7,$8,$9,$10,$11,$12,$13)
+		ON CONFLICT ("id") DO UPDATE SET applied_at=EXCLUDED.applied_at`
+
+	_, err := tx.Exec(c, query,
+		history.ID,
+		history.EmployeeID,
+		history.ChangeType,
+		history.DepartmentID,
+		history.PositionID,
+		history.JobTitle,
+		history.SalaryGrade,
+		history.ManagerID,
+		history.EffectiveFrom,
+		history.EffectiveTo,
+		history.Reason,
+		history.AppliedAt,
+		history.CreatedAt,
+	)
+
+	return err
+}
+
+// close the period of the current (applied and still open) employment history of the employee
+func (repository *EmploymentHistoryQueryImpl) CloseCurrent(c context.Context, tx pgx.Tx, employeeID string, effectiveTo time.Time) error {
+	// build UPDATE query
+	query := `UPDATE employment_histories SET effective_to=$1
+		WHERE
+			employee_id=$2 AND
+			applied_at is not null AND
+			effective_to is null`
+
+	_, err := tx.Exec(c, query, effectiveTo, employeeID)
+
+	return err
+}
+
+// delete the employment history, only a pending change can be deleted
+func (repository *EmploymentHistoryQueryImpl) Delete(c context.Context, tx pgx.Tx, id string) error {
+	// build DELETE query
+	query := `DELETE FROM employment_histories WHERE id=$1 AND applied_at is null`
+
+	_, err := tx.Exec(c, query, id)
+
+	return err
+}
+
+func (repository *EmploymentHistoryQueryImpl) FindByEmployeeId(c context.Context, db *pgxpool.Pool, employeeID string) ([]domain.EmploymentHistory, error) {
+	query := `SELECT ` + employmentHistoryColumns + `
+		FROM employment_histories AS h
+		` + employmentHistoryJoins + `
+		WHERE h.employee_id=$1
+		ORDER BY h.effective_from DESC, h.created_at DESC`
+
+	return findEmploymentHistories(c, db, query, employeeID)
+}
+
+func (repository *EmploymentHistoryQueryImpl) FindById(c context.Context, db *pgxpool.Pool, id string) (domain.EmploymentHistory, error) {
+	query := `SELECT ` + employmentHistoryColumns + `
+		FROM employment_histories AS h
+		` + employmentHistoryJoins + `
+		WHERE h.id=$1`
+
+	return scanEmploymentHistory(db.QueryRow(c, query, id))
+}
+
+// find the pending changes that are effective on or before the date
+func (repository *EmploymentHistoryQueryImpl) FindDue(c context.Context, db *pgxpool.Pool, date time.Time) ([]domain.EmploymentHistory, error) {
+	query := `SELECT ` + employmentHistoryColumns + `
+		FROM employment_histories AS h
+		` + employmentHistoryJoins + `
+		WHERE
+			h.applied_at is null AND
+			h.effective_from <= $1
+		ORDER BY h.effective_from, h.created_at`
+
+	return findEmploymentHistories(c, db, query, date)
+}
+
+// find the employment history that is effective on the date. A pending change is included,
+// so a future date returns the planned employment of the employee.
+func (repository *EmploymentHistoryQueryImpl) FindAsOf(c context.Context, db *pgxpool.Pool, employeeID string, date time.Time) (domain.EmploymentHistory, error) {
+	query := `SELECT ` + employmentHistoryColumns + `
+		FROM employment_histories AS h
+		` + employmentHistoryJoins + `
+		WHERE
+			h.employee_id=$1 AND
+			h.effective_from <= $2
+		ORDER BY h.effective_from DESC, h.created_at DESC
+		LIMIT 1`
+
+	return scanEmploymentHistory(db.QueryRow(c, query, employeeID, date))
+}
+
+func findEmploymentHistories(c context.Context, db *pgxpool.Pool, query string, args ...interface{}) ([]domain.EmploymentHistory, error) {
+	rows, err := db.Query(c, query, args...)
+	if err != nil {
+		return []domain.EmploymentHistory{}, err
+	}
+	defer rows.Close()
+
+	var datas []domain.EmploymentHistory
+	for rows.Next() {
+		data, err := scanEmploymentHistory(rows)
+		if err != nil {
+			return []domain.EmploymentHistory{}, err
+		}
+		datas = append(datas, data)
+	}
+
+	return datas, rows.Err()
+}
@@ -0,0 +1,716 @@
+package query
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/iqbaludinm/hr-microservice/user-service/model/domain"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type ExpenseClaimQuery interface {
+	CreateCategory(c context.Context, tx pgx.Tx, category domain.ExpenseCategory) error
+	UpdateCategory(c context.Context, tx pgx.Tx, category domain.ExpenseCategory) error
+	DeleteCategory(c context.Context, tx pgx.Tx, id string) error
+	CreateLimit(c context.Context, tx pgx.Tx, limit domain.ExpenseCategoryLimit) error
+	DeleteLimits(c context.Context, tx pgx.Tx, categoryID string) error
+	CreateClaim(c context.Context, tx pgx.Tx, claim domain.ExpenseClaim) error
+	UpdateClaim(c context.Context, tx pgx.Tx, claim domain.ExpenseClaim) error
+	UpdateClaimStatus(c context.Context, tx pgx.Tx, claim domain.ExpenseClaim, status string) error
+	CreateItem(c context.Context, tx pgx.Tx, item domain.ExpenseClaimItem) error
+	DeleteItem(c context.Context, tx pgx.Tx, claimID string, seq int) error
+	UpdateItemReceipt(c context.Context, tx pgx.Tx, item domain.ExpenseClaimItem) error
+	CreateHistory(c context.Context, tx pgx.Tx, history domain.ExpenseClaimHistory) error
+	AssignClaims(c context.Context, tx pgx.Tx, payrollRunID string, ids []string) error
+	ReleaseClaims(c context.Context, tx pgx.Tx, payrollRunID string) error
+	PayClaims(c context.Context, tx pgx.Tx, payrollRunID string, paidAt time.Time) error
+	FindAllCategory(c context.Context, db *pgxpool.Pool, filter domain.ExpenseCategoryQueryFilter) ([]domain.ExpenseCategory, error)
+	CountAllCategory(c context.Context, db *pgxpool.Pool, filter domain.ExpenseCategoryQueryFilter) (int, error)
+	FindCategoryById(c context.Context, db *pgxpool.Pool, id string) (domain.ExpenseCategory, error)
+	FindLimits(c context.Context, db *pgxpool.Pool, categoryID string) ([]domain.ExpenseCategoryLimit, error)
+	FindLimitsByGrade(c context.Context, db *pgxpool.Pool, salaryGrade string) ([]domain.ExpenseCategoryLimit, error)
+	FindAllClaim(c context.Context, db *pgxpool.Pool, filter domain.ExpenseClaimQueryFilter) ([]domain.ExpenseClaim, error)
+	CountAllClaim(c context.Context, db *pgxpool.Pool, filter domain.ExpenseClaimQueryFilter) (int, error)
+	FindClaimById(c context.Context, db *pgxpool.Pool, id string) (domain.ExpenseClaim, error)
+	FindItems(c context.Context, db *pgxpool.Pool, claimID string) ([]domain.ExpenseClaimItem, error)
+	FindHistories(c context.Context, db *pgxpool.Pool, claimID string) ([]domain.ExpenseClaimHistory, error)
+	FindPayableClaims(c context.Context, db *pgxpool.Pool, payrollRunID string, approvedBefore time.Time) ([]domain.ExpenseClaim, error)
+}
+
+type ExpenseClaimQueryImpl struct {
+}
+
+func NewExpenseClaim() ExpenseClaimQuery {
+	return &ExpenseClaimQueryImpl{}
+}
+
+// the selected columns of the expense category. The order must match the 'scanExpenseCategory' function.
+const expenseCategoryColumns = `
+	ec.id,
+	ec.code,
+	ec.name,
+	ec.description,
+	ec.receipt_required,
+	ec.created_at,
+	ec.updated_at,
+	ec.deleted_at`
+
+func scanExpenseCategory(row pgx.Row) (domain.ExpenseCategory, error) {
+	var data domain.ExpenseCategory
+	err := row.Scan(
+		&data.ID,
+		&data.Code,
+		&data.Name,
+		&data.Description,
+		&data.ReceiptRequired,
+		&data.CreatedAt,
+		&data.UpdatedAt,
+		&data.DeletedAt,
+	)
+
+	return data, err
+}
+
+// the selected columns of the expense claim, joined with the employee. The order must match the 'scanExpenseClaim'
+// function.
+const expenseClaimColumns = `
+	c.id,
+	c.employee_id,
+	c.title,
+	c.description,
+	c.status,
+	c.total_amount,
+	c.manager_id,
+	c.submitted_at,
+	c.manager_approved_at,
+	c.finance_approved_by,
+	c.finance_approved_at,
+	c.payroll_run_id,
+	c.paid_at,
+	c.created_at,
+	c.updated_at,
+	e.employee_number,
+	u.name,
+	e.user_id,
+	COALESCE(d.name, '')`
+
+const expenseClaimJoins = `
+	JOIN employees AS e ON e.id = c.employee_id
+	JOIN users AS u ON u.id = e.user_id
+	LEFT JOIN departments AS d ON d.id = e.department_id`
+
+func scanExpenseClaim(row pgx.Row) (domain.ExpenseClaim, error) {
+	var data domain.ExpenseClaim
+	err := row.Scan(
+		&data.ID,
+		&data.EmployeeID,
+		&data.Title,
+		&data.Description,
+		&data.Status,
+		&data.TotalAmount,
+		&data.ManagerID,
+		&data.SubmittedAt,
+		&data.ManagerApprovedAt,
+		&data.FinanceApprovedBy,
+		&data.FinanceApprovedAt,
+		&data.PayrollRunID,
+		&data.PaidAt,
+		&data.CreatedAt,
+		&data.UpdatedAt,
+		&data.EmployeeNumber,
+		&data.EmployeeName,
+		&data.EmployeeUserID,
+		&data.Department,
+	)
+
+	return data, err
+}
+
+func (repository *ExpenseClaimQueryImpl) CreateCategory(c context.Context, tx pgx.Tx, category domain.ExpenseCategory) error {
+	// build INSERT query
+	query := `INSERT INTO expense_categories (
+		"id",
+		"code",
+		"name",
+		"description",
+		"receipt_required",
+		"created_at",
+		"updated_at"
+		) VALUES ($1,$2,$3,$4,$5,$6,$7)`
+
+	_, err := tx.Exec(c, query,
+		category.ID,
+		category.Code,
+		category.Name,
+		category.Description,
+		category.ReceiptRequired,
+		category.CreatedAt,
+		category.UpdatedAt,
+	)
+
+	return err
+}
+
+func (repository *ExpenseClaimQueryImpl) UpdateCategory(c context.Context, tx pgx.Tx, category domain.ExpenseCategory) error {
+	// build UPDATE query
+	query := `UPDATE expense_categories SET
+		code=$1,
+		name=$2,
+		description=$3,
+		receipt_required=$4,
+		updated_at=$5
+		WHERE id=$6`
+
+	_, err := tx.Exec(c, query,
+		category.Code,
+		category.Name,
+		category.Description,
+		category.ReceiptRequired,
+		category.UpdatedAt,
+		category.ID,
+	)
+
+	return err
+}
+
+func (repository *ExpenseClaimQueryImpl) DeleteCategory(c context.Context, tx pgx.Tx, id string) error {
+	// build UPDATE query
+	query := `UPDATE expense_categories SET deleted_at=$1 WHERE id=$2`
+
+	_, err := tx.Exec(c, query, time.Now(), id)
+
+	return err
+}
+
+func (repository *ExpenseClaimQueryImpl) CreateLimit(c context.Context, tx pgx.Tx, limit domain.ExpenseCategoryLimit) error {
+	// build INSERT query
+	query := `INSERT INTO expense_category_limits (
+		"category_id",
+		"salary_grade",
+		"max_amount",
+		"created_at"
+		) VALUES ($1,$2,$3,$4)`
+
+	_, err := tx.Exec(c, query,
+		limit.CategoryID,
+		limit.SalaryGrade,
+		limit.MaxAmount,
+		limit.CreatedAt,
+	)
+
+	return err
+}
+
+// delete the limits of the category, the limits are replaced when the category is updated
+func (repository *ExpenseClaimQueryImpl) DeleteLimits(c context.Context, tx pgx.Tx, categoryID string) error {
+	query := `DELETE FROM expense_category_limits WHERE category_id=$1`
+
+	_, err := tx.Exec(c, query, categoryID)
+
+	return err
+}
+
+func (repository *ExpenseClaimQueryImpl) CreateClaim(c context.Context, tx pgx.Tx, claim domain.ExpenseClaim) error {
+	// build INSERT query
+	query := `INSERT INTO expense_claims (
+		"id",
+		"employee_id",
+		"title",
+		"description",
+		"status",
+		"total_amount",
+		"created_at",
+		"updated_at"
+		) VALUES ($1,$2,$3,$4,$5,$6,$7,$8)`
+
+	_, err := tx.Exec(c, query,
+		claim.ID,
+		claim.EmployeeID,
+		claim.Title,
+		claim.Description,
+		claim.Status,
+		claim.TotalAmount,
+		claim.CreatedAt,
+		claim.UpdatedAt,
+	)
+
+	return err
+}
+
+// update the title and the total of the claim which is edited
+// update the claim which still has its status, otherwise 'pgx.ErrNoRows' is returned. The items can't be changed
+// once the claim is submitted by another request.
+func (repository *ExpenseClaimQueryImpl) UpdateClaim(c context.Context, tx pgx.Tx, claim domain.ExpenseClaim) error {
+	// build UPDATE query
+	query := `UPDATE expense_claims SET
+		title=$1,
+		description=$2,
+		total_amount=$3,
+		updated_at=$4
+		WHERE id=$5 AND status=$6`
+
+	tag, err := tx.Exec(c, query,
+		claim.Title,
+		claim.Description,
+		claim.TotalAmount,
+		claim.UpdatedAt,
+		claim.ID,
+		claim.Status,
+	)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return pgx.ErrNoRows
+	}
+
+	return nil
+}
+
+// update the status of the claim with its approvals. The claim is only updated while it still has the given status
+// and its total, so the status isn't changed twice and the items aren't changed since they were checked. Otherwise
+// 'pgx.ErrNoRows' is returned.
+func (repository *ExpenseClaimQueryImpl) UpdateClaimStatus(c context.Context, tx pgx.Tx, claim domain.ExpenseClaim, status string) error {
+	// build UPDATE query
+	query := `UPDATE expense_claims SET
+		status=$1,
+		manager_id=$2,
+		submitted_at=$3,
+		manager_approved_at=$4,
+		finance_approved_by=$5,
+		finance_approved_at=$6,
+		updated_at=$7
+		WHERE id=$8 AND status=$9 AND total_amount=$10`
+
+	tag, err := tx.Exec(c, query,
+		claim.Status,
+		claim.ManagerID,
+		claim.SubmittedAt,
+		claim.ManagerApprovedAt,
+		claim.FinanceApprovedBy,
+		claim.FinanceApprovedAt,
+		claim.UpdatedAt,
+		claim.ID,
+		status,
+		claim.TotalAmount,
+	)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return pgx.ErrNoRows
+	}
+
+	return nil
+}
+
+func (repository *ExpenseClaimQueryImpl) CreateItem(c context.Context, tx pgx.Tx, item domain.ExpenseClaimItem) error {
+	// build INSERT query
+	query := `INSERT INTO expense_claim_items (
+		"claim_id",
+		"seq",
+		"category_id",
+		"expense_date",
+		"description",
+		"currency",
+		"amount",
+		"exchange_rate",
+		"amount_idr"
+		) VALUES ($1,$2,$3,$4,$5,$6,$7,$8,$9)`
+
+	_, err := tx.Exec(c, query,
+		item.ClaimID,
+		item.Seq,
+		item.CategoryID,
+		item.ExpenseDate,
+		item.Description,
+		item.Currency,
+		item.Amount,
+		item.ExchangeRate,
+		item.AmountIDR,
+	)
+
+	return err
+}
+
+func (repository *ExpenseClaimQueryImpl) DeleteItem(c context.Context, tx pgx.Tx, claimID string, seq int) error {
+	query := `DELETE FROM expense_claim_items WHERE claim_id=$1 AND seq=$2`
+
+	_, err := tx.Exec(c, query, claimID, seq)
+
+	return err
+}
+
+func (repository *ExpenseClaimQueryImpl) UpdateItemReceipt(c context.Context, tx pgx.Tx, item domain.ExpenseClaimItem) error {
+	// build UPDATE query
+	query := `UPDATE expense_claim_items SET
+		receipt_storage_key=$1,
+		receipt_file_name=$2,
+		receipt_mime_type=$3
+		WHERE claim_id=$4 AND seq=$5`
+
+	_, err := tx.Exec(c, query,
+		item.ReceiptStorageKey,
+		item.ReceiptFileName,
+		item.ReceiptMimeType,
+		item.ClaimID,
+		item.Seq,
+	)
+
+	return err
+}
+
+func (repository *ExpenseClaimQueryImpl) CreateHistory(c context.Context, tx pgx.Tx, history domain.ExpenseClaimHistory) error {
+	// build INSERT query
+	query := `INSERT INTO expense_claim_histories (
+		"id",
+		"claim_id",
+		"from_status",
+		"to_status",
+		"note",
+		"changed_by",
+		"changed_at"
+		) VALUES ($1,$2,$3,$4,$5,$6,$7)`
+
+	_, err := tx.Exec(c, query,
+		history.ID,
+		history.ClaimID,
+		history.FromStatus,
+		history.ToStatus,
+		history.Note,
+		history.ChangedBy,
+		history.ChangedAt,
+	)
+
+	return err
+}
+
+// assign the approved claims to the payroll run they are reimbursed in
+func (repository *ExpenseClaimQueryImpl) AssignClaims(c context.Context, tx pgx.Tx, payrollRunID string, ids []string) error {
+	query := `UPDATE expense_claims SET payroll_run_id=$1 WHERE id = ANY($2) AND status='approved'`
+
+	_, err := tx.Exec(c, query, payrollRunID, ids)
+
+	return err
+}
+
+// release the claims of the payroll run, the claims are assigned again when the run is recalculated
+func (repository *ExpenseClaimQueryImpl) ReleaseClaims(c context.Context, tx pgx.Tx, payrollRunID string) error {
+	query := `UPDATE expense_claims SET payroll_run_id=NULL WHERE payroll_run_id=$1 AND status='approved'`
+
+	_, err := tx.Exec(c, query, payrollRunID)
+
+	return err
+}
+
+// mark the approved claims of the payroll run as paid when the run is locked
+func (repository *ExpenseClaimQueryImpl) PayClaims(c context.Context, tx pgx.Tx, payrollRunID string, paidAt time.Time) error {
+	query := `UPDATE expense_claims SET status='paid', paid_at=$1, updated_at=$1 WHERE payroll_run_id=$2 AND status='approved'`
+
+	_, err := tx.Exec(c, query, paidAt, payrollRunID)
+
+	return err
+}
+
+func (repository *ExpenseClaimQueryImpl) FindAllCategory(c context.Context, db *pgxpool.Pool, filter domain.ExpenseCategoryQueryFilter) ([]domain.ExpenseCategory, error) {
+	// expense category query filter builders
+	filterString, args, pagination := filter.BuildExpenseCategoryQueries()
+
+	query := fmt.Sprintf(
+		`SELECT %s
+		FROM expense_categories AS ec
+		%s
+		ORDER BY ec.name
+		%s`,
+		expenseCategoryColumns, filterString, pagination,
+	)
+	rows, err := db.Query(c, query, args...)
+	if err != nil {
+		return []domain.ExpenseCategory{}, err
+	}
+	defer rows.Close()
+
+	var datas []domain.ExpenseCategory
+	for rows.Next() {
+		data, err := scanExpenseCategory(rows)
+		if err != nil {
+			return []domain.ExpenseCategory{}, err
+		}
+		datas = append(datas, data)
+	}
+
+	return datas, rows.Err()
+}
+
+func (repository *ExpenseClaimQueryImpl) CountAllCategory(c context.Context, db *pgxpool.Pool, filter domain.ExpenseCategoryQueryFilter) (int, error) {
+	// expense category query filter builders
+	filterString, args, _ := filter.BuildExpenseCategoryQueries()
+
+	query := fmt.Sprintf(`SELECT COUNT(*) FROM expense_categories AS ec %s`, filterString)
+
+	var count int
+	err := db.QueryRow(c, query, args...).Scan(&count)
+	if err != nil {
+		return 0, err
+	}
+
+	return count, err
+}
+
+func (repository *ExpenseClaimQueryImpl) FindCategoryById(c context.Context, db *pgxpool.Pool, id string) (domain.ExpenseCategory, error) {
+	query := `SELECT ` + expenseCategoryColumns + ` FROM expense_categories AS ec WHERE ec.deleted_at IS NULL AND ec.id=$1`
+
+	return scanExpenseCategory(db.QueryRow(c, query, id))
+}
+
+func (repository *ExpenseClaimQueryImpl) FindLimits(c context.Context, db *pgxpool.Pool, categoryID string) ([]domain.ExpenseCategoryLimit, error) {
+	query := `SELECT
+		l.category_id,
+		l.salary_grade,
+		l.max_amount,
+		l.created_at,
+		ec.name
+		FROM expense_category_limits AS l
+		JOIN expense_categories AS ec ON ec.id = l.category_id
+		WHERE l.category_id=$1
+		ORDER BY l.salary_grade`
+
+	rows, err := db.Query(c, query, categoryID)
+	if err != nil {
+		return []domain.ExpenseCategoryLimit{}, err
+	}
+	defer rows.Close()
+
+	var datas []domain.ExpenseCategoryLimit
+	for rows.Next() {
+		var data domain.ExpenseCategoryLimit
+		err := rows.Scan(
+			&data.CategoryID,
+			&data.SalaryGrade,
+			&data.MaxAmount,
+			&data.CreatedAt,
+			&data.CategoryName,
+		)
+		if err != nil {
+			return []domain.ExpenseCategoryLimit{}, err
+		}
+		datas = append(datas, data)
+	}
+
+	return datas, rows.Err()
+}
+
+// find the limits of the categories for the employees with the salary grade
+func (repository *ExpenseClaimQueryImpl) FindLimitsByGrade(c context.Context, db *pgxpool.Pool, salaryGrade string) ([]domain.ExpenseCategoryLimit, error) {
+	query := `SELECT
+		l.category_id,
+		l.salary_grade,
+		l.max_amount,
+		l.created_at,
+		ec.name
+		FROM expense_category_limits AS l
+		JOIN expense_categories AS ec ON ec.id = l.category_id AND ec.deleted_at IS NULL
+		WHERE l.salary_grade=$1
+		ORDER BY ec.name`
+
+	rows, err := db.Query(c, query, salaryGrade)
+	if err != nil {
+		return []domain.ExpenseCategoryLimit{}, err
+	}
+	defer rows.Close()
+
+	var datas []domain.ExpenseCategoryLimit
+	for rows.Next() {
+		var data domain.ExpenseCategoryLimit
+		err := rows.Scan(
+			&data.CategoryID,
+			&data.SalaryGrade,
+			&data.MaxAmount,
+			&data.CreatedAt,
+			&data.CategoryName,
+		)
+		if err != nil {
+			return []domain.ExpenseCategoryLimit{}, err
+		}
+		datas = append(datas, data)
+	}
+
+	return datas, rows.Err()
+}
+
+func (repository *ExpenseClaimQueryImpl) FindAllClaim(c context.Context, db *pgxpool.Pool, filter domain.ExpenseClaimQueryFilter) ([]domain.ExpenseClaim, error) {
+	// expense claim query filter builders
+	filterString, args, pagination := filter.BuildExpenseClaimQueries()
+
+	query := fmt.Sprintf(
+		`SELECT %s
+		FROM expense_claims AS c
+		%s
+		%s
+		ORDER BY c.created_at DESC
+		%s`,
+		expenseClaimColumns, expenseClaimJoins, filterString, pagination,
+	)
+
+	rows, err := db.Query(c, query, args...)
+	if err != nil {
+		return []domain.ExpenseClaim{}, err
+	}
+	defer rows.Close()
+
+	var datas []domain.ExpenseClaim
+	for rows.Next() {
+		data, err := scanExpenseClaim(rows)
+		if err != nil {
+			return []domain.ExpenseClaim{}, err
+		}
+		datas = append(datas, data)
+	}
+
+	return datas, rows.Err()
+}
+
+func (repository *ExpenseClaimQueryImpl) CountAllClaim(c context.Context, db *pgxpool.Pool, filter domain.ExpenseClaimQueryFilter) (int, error) {
+	// expense claim query filter builders
+	filterString, args, _ := filter.BuildExpenseClaimQueries()
+
+	query := fmt.Sprintf(`SELECT COUNT(*) FROM expense_claims AS c %s %s`, expenseClaimJoins, filterString)
+
+	var count int
+	err := db.QueryRow(c, query, args...).Scan(&count)
+	if err != nil {
+		return 0, err
+	}
+
+	return count, err
+}
+
+func (repository *ExpenseClaimQueryImpl) FindClaimById(c context.Context, db *pgxpool.Pool, id string) (domain.ExpenseClaim, error) {
+	query := `SELECT ` + expenseClaimColumns + ` FROM expense_claims AS c ` + expenseClaimJoins + ` WHERE c.id=$1`
+
+	return scanExpenseClaim(db.QueryRow(c, query, id))
+}
+
+func (repository *ExpenseClaimQueryImpl) FindItems(c context.Context, db *pgxpool.Pool, claimID string) ([]domain.ExpenseClaimItem, error) {
+	query := `SELECT
+		i.claim_id,
+		i.seq,
+		i.category_id,
+		i.expense_date,
+		i.description,
+		i.currency,
+		i.amount,
+		i.exchange_rate,
+		i.amount_idr,
+		i.receipt_storage_key,
+		i.receipt_file_name,
+		i.receipt_mime_type,
+		ec.code,
+		ec.name,
+		ec.receipt_required
+		FROM expense_claim_items AS i
+		JOIN expense_categories AS ec ON ec.id = i.category_id
+		WHERE i.claim_id=$1
+		ORDER BY i.seq`
+
+	rows, err := db.Query(c, query, claimID)
+	if err != nil {
+		return []domain.ExpenseClaimItem{}, err
+	}
+	defer rows.Close()
+
+	var datas []domain.ExpenseClaimItem
+	for rows.Next() {
+		var data domain.ExpenseClaimItem
+		err := rows.Scan(
+			&data.ClaimID,
+			&data.Seq,
+			&data.CategoryID,
+			&data.ExpenseDate,
+			&data.Description,
+			&data.Currency,
+			&data.Amount,
+			&data.ExchangeRate,
+			&data.AmountIDR,
+			&data.ReceiptStorageKey,
+			&data.ReceiptFileName,
+			&data.ReceiptMimeType,
+			&data.CategoryCode,
+			&data.CategoryName,
+			&data.ReceiptRequired,
+		)
+		if err != nil {
+			return []domain.ExpenseClaimItem{}, err
+		}
+		datas = append(datas, data)
+	}
+
+	return datas, rows.Err()
+}
+
+func (repository *ExpenseClaimQueryImpl) FindHistories(c context.Context, db *pgxpool.Pool, claimID string) ([]domain.ExpenseClaimHistory, error) {
+	query := `SELECT
+		h.id,
+		h.claim_id,
+		h.from_status,
+		h.to_status,
+		h.note,
+		h.changed_by,
+		h.changed_at
+		FROM expense_claim_histories AS h
+		WHERE h.claim_id=$1
+		ORDER BY h.changed_at`
+
+	rows, err := db.Query(c, query, claimID)
+	if err != nil {
+		return []domain.ExpenseClaimHistory{}, err
+	}
+	defer rows.Close()
+
+	var datas []domain.ExpenseClaimHistory
+	for rows.Next() {
+		var data domain.ExpenseClaimHistory
+		err := rows.Scan(
+			&data.ID,
+			&data.ClaimID,
+			&data.FromStatus,
+			&data.ToStatus,
+			&data.Note,
+			&data.ChangedBy,
+			&data.ChangedAt,
+		)
+		if err != nil {
+			return []domain.ExpenseClaimHistory{}, err
+		}
+		datas = append(datas, data)
+	}
+
+	return datas, rows.Err()
+}
+
+// find the approved claims which are reimbursed in the payroll run, the claims approved by finance before the date
+// which are not assigned to another run
+func (repository *ExpenseClaimQueryImpl) FindPayableClaims(c context.Context, db *pgxpool.Pool, payrollRunID string, approvedBefore time.Time) ([]domain.ExpenseClaim, error) {
+	query := `SELECT ` + expenseClaimColumns + ` FROM expense_claims AS c ` + expenseClaimJoins + `
+		WHERE c.status='approved' AND (c.payroll_run_id IS NULL OR c.payroll_run_id=$1) AND c.finance_approved_at < $2
+		ORDER BY c.finance_approved_at`
+
+	rows, err := db.Query(c, query, payrollRunID, approvedBefore)
+	if err != nil {
+		return []domain.ExpenseClaim{}, err
+	}
+	defer rows.Close()
+
+	var datas []domain.ExpenseClaim
+	for rows.Next() {
+		data, err := scanExpenseClaim(rows)
+		if err != nil {
+			return []domain.ExpenseClaim{}, err
+		}
+		datas = append(datas, data)
+	}
+
+	return datas, rows.Err()
+}
@@ -0,0 +1,459 @@
+package query
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/iqbaludinm/hr-microservice/user-service/model/domain"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type HandoverQuery interface {
+	NextDocumentNumber(c context.Context, tx pgx.Tx) (int64, error)
+	CreateHandover(c context.Context, tx pgx.Tx, handover domain.Handover) error
+	UpdateHandover(c context.Context, tx pgx.Tx, id string, handover domain.Handover) error
+	UpdateStatus(c context.Context, tx pgx.Tx, id string, handover domain.Handover, status string) error
+	UpdateDocument(c context.Context, tx pgx.Tx, id string, handover domain.Handover) error
+	CreateItem(c context.Context, tx pgx.Tx, item domain.HandoverItem) error
+	DeleteItems(c context.Context, tx pgx.Tx, handoverID string) error
+	CreateSigner(c context.Context, tx pgx.Tx, signer domain.HandoverSigner) error
+	UpdateSigner(c context.Context, tx pgx.Tx, signer domain.HandoverSigner) error
+	DeleteSigners(c context.Context, tx pgx.Tx, handoverID string) error
+	FindAllHandover(c context.Context, db *pgxpool.Pool, filter domain.HandoverQueryFilter) ([]domain.Handover, error)
+	CountAllHandover(c context.Context, db *pgxpool.Pool, filter domain.HandoverQueryFilter) (int, error)
+	FindById(c context.Context, db *pgxpool.Pool, id string) (domain.Handover, error)
+	FindItems(c context.Context, db *pgxpool.Pool, handoverID string) ([]domain.HandoverItem, error)
+	FindSigners(c context.Context, db *pgxpool.Pool, handoverID string) ([]domain.HandoverSigner, error)
+}
+
+type HandoverQueryImpl struct {
+}
+
+func NewHandover() HandoverQuery {
+	return &HandoverQueryImpl{}
+}
+
+// the selected columns of the handover, joined with the employee who hands over and the employee who receives.
+// The order must match the 'scanHandover' function.
+const handoverColumns = `
+	h.id,
+	h.document_number,
+	h.type,
+	h.title,
+	h.description,
+	h.location,
+	h.handover_date,
+	h.from_employee_id,
+	h.to_employee_id,
+	h.status,
+	h.document_key,
+	h.emailed_at,
+	h.created_by,
+	h.submitted_at,
+	h.completed_at,
+	h.cancelled_at,
+	h.created_at,
+	h.updated_at,
+	fe.employee_number,
+	fu.name,
+	te.employee_number,
+	tu.name`
+
+const handoverJoins = `
+	JOIN employees AS fe ON fe.id = h.from_employee_id
+	JOIN users AS fu ON fu.id = fe.user_id
+	JOIN employees AS te ON te.id = h.to_employee_id
+	JOIN users AS tu ON tu.id = te.user_id`
+
+func scanHandover(row pgx.Row) (domain.Handover, error) {
+	var data domain.Handover
+	err := row.Scan(
+		&data.ID,
+		&data.DocumentNumber,
+		&data.Type,
+		&data.Title,
+		&data.Description,
+		&data.Location,
+		&data.HandoverDate,
+		&data.FromEmployeeID,
+		&data.ToEmployeeID,
+		&data.Status,
+		&data.DocumentKey,
+		&data.EmailedAt,
+		&data.CreatedBy,
+		&data.SubmittedAt,
+		&data.CompletedAt,
+		&data.CancelledAt,
+		&data.CreatedAt,
+		&data.UpdatedAt,
+		&data.FromEmployeeNumber,
+		&data.FromEmployeeName,
+		&data.ToEmployeeNumber,
+		&data.ToEmployeeName,
+	)
+
+	return data, err
+}
+
+// get the next running number of the handover document
+func (repository *HandoverQueryImpl) NextDocumentNumber(c context.Context, tx pgx.Tx) (int64, error) {
+	var number int64
+	err := tx.QueryRow(c, `SELECT nextval('handover_number_seq')`).Scan(&number)
+
+	return number, err
+}
+
+func (repository *HandoverQueryImpl) CreateHandover(c context.Context, tx pgx.Tx, handover domain.Handover) error {
+	// build INSERT query
+	query := `INSERT INTO handovers (
+		"id",
+		"document_number",
+		"type",
+		"title",
+		"description",
+		"location",
+		"handover_date",
+		"from_employee_id",
+		"to_employee_id",
+		"status",
+		"created_by",
+		"created_at",
+		"updated_at"
+		) VALUES ($1,$2,$3,$4,$5,$6,$7,$8,$9,$10,$11,$12,$13)`
+
+	_, err := tx.Exec(c, query,
+		handover.ID,
+		handover.DocumentNumber,
+		handover.Type,
+		handover.Title,
+		handover.Description,
+		handover.Location,
+		handover.HandoverDate,
+		handover.FromEmployeeID,
+		handover.ToEmployeeID,
+		handover.Status,
+		handover.CreatedBy,
+		handover.CreatedAt,
+		handover.UpdatedAt,
+	)
+
+	return err
+}
+
+func (repository *HandoverQueryImpl) UpdateHandover(c context.Context, tx pgx.Tx, id string, handover domain.Handover) error {
+	// build UPDATE query
+	query := `UPDATE handovers SET
+		type=$1,
+		title=$2,
+		description=$3,
+		location=$4,
+		handover_date=$5,
+		from_employee_id=$6,
+		to_employee_id=$7,
+		updated_at=$8
+		WHERE id=$9`
+
+	_, err := tx.Exec(c, query,
+		handover.Type,
+		handover.Title,
+		handover.Description,
+		handover.Location,
+		handover.HandoverDate,
+		handover.FromEmployeeID,
+		handover.ToEmployeeID,
+		handover.UpdatedAt,
+		id,
+	)
+
+	return err
+}
+
+// update the status of the handover which still has the given status, otherwise 'pgx.ErrNoRows' is returned
+func (repository *HandoverQueryImpl) UpdateStatus(c context.Context, tx pgx.Tx, id string, handover domain.Handover, status string) error {
+	// build UPDATE query
+	query := `UPDATE handovers SET
+		status=$1,
+		submitted_at=$2,
+		completed_at=$3,
+		cancelled_at=$4,
+		updated_at=$5
+		WHERE id=$6 AND status=$7`
+
+	tag, err := tx.Exec(c, query,
+		handover.Status,
+		handover.SubmittedAt,
+		handover.CompletedAt,
+		handover.CancelledAt,
+		handover.UpdatedAt,
+		id,
+		status,
+	)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return pgx.ErrNoRows
+	}
+
+	return nil
+}
+
+// update the signed document of the handover and the time it is emailed
+func (repository *HandoverQueryImpl) UpdateDocument(c context.Context, tx pgx.Tx, id string, handover domain.Handover) error {
+	// build UPDATE query
+	query := `UPDATE handovers SET
+		document_key=$1,
+		emailed_at=$2
+		WHERE id=$3`
+
+	_, err := tx.Exec(c, query,
+		handover.DocumentKey,
+		handover.EmailedAt,
+		id,
+	)
+
+	return err
+}
+
+func (repository *HandoverQueryImpl) CreateItem(c context.Context, tx pgx.Tx, item domain.HandoverItem) error {
+	// build INSERT query
+	query := `INSERT INTO handover_items (
+		"handover_id",
+		"seq",
+		"name",
+		"description",
+		"quantity",
+		"unit",
+		"serial_number",
+		"condition",
+		"note"
+		) VALUES ($1,$2,$3,$4,$5,$6,$7,$8,$9)`
+
+	_, err := tx.Exec(c, query,
+		item.HandoverID,
+		item.Seq,
+		item.Name,
+		item.Description,
+		item.Quantity,
+		item.Unit,
+		item.SerialNumber,
+		item.Condition,
+		item.Note,
+	)
+
+	return err
+}
+
+// delete the items of the handover before the items of the updated draft are created
+func (repository *HandoverQueryImpl) DeleteItems(c context.Context, tx pgx.Tx, handoverID string) error {
+	query := `DELETE FROM handover_items WHERE handover_id=$1`
+
+	_, err := tx.Exec(c, query, handoverID)
+
+	return err
+}
+
+func (repository *HandoverQueryImpl) CreateSigner(c context.Context, tx pgx.Tx, signer domain.HandoverSigner) error {
+	// build INSERT query
+	query := `INSERT INTO handover_signers (
+		"handover_id",
+		"seq",
+		"employee_id",
+		"role",
+		"status"
+		) VALUES ($1,$2,$3,$4,$5)`
+
+	_, err := tx.Exec(c, query,
+		signer.HandoverID,
+		signer.Seq,
+		signer.EmployeeID,
+		signer.Role,
+		signer.Status,
+	)
+
+	return err
+}
+
+// update the signature of the signer
+// update the signer which has not signed or rejected the handover yet, otherwise 'pgx.ErrNoRows' is returned
+func (repository *HandoverQueryImpl) UpdateSigner(c context.Context, tx pgx.Tx, signer domain.HandoverSigner) error {
+	// build UPDATE query
+	query := `UPDATE handover_signers SET
+		status=$1,
+		note=$2,
+		signed_at=$3
+		WHERE handover_id=$4 AND seq=$5 AND status=$6`
+
+	tag, err := tx.Exec(c, query,
+		signer.Status,
+		signer.Note,
+		signer.SignedAt,
+		signer.HandoverID,
+		signer.Seq,
+		domain.HandoverSignerPending,
+	)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return pgx.ErrNoRows
+	}
+
+	return nil
+}
+
+// delete the signers of the handover before the signers of the updated draft are created
+func (repository *HandoverQueryImpl) DeleteSigners(c context.Context, tx pgx.Tx, handoverID string) error {
+	query := `DELETE FROM handover_signers WHERE handover_id=$1`
+
+	_, err := tx.Exec(c, query, handoverID)
+
+	return err
+}
+
+func (repository *HandoverQueryImpl) FindAllHandover(c context.Context, db *pgxpool.Pool, filter domain.HandoverQueryFilter) ([]domain.Handover, error) {
+	// handover query filter builders
+	filterString, args, pagination := filter.BuildHandoverQueries()
+
+	query := fmt.Sprintf(
+		`SELECT %s
+		FROM handovers AS h
+		%s
+		%s
+		ORDER BY h.handover_date DESC, h.document_number DESC
+		%s`,
+		handoverColumns, handoverJoins, filterString, pagination,
+	)
+	rows, err := db.Query(c, query, args...)
+	if err != nil {
+		return []domain.Handover{}, err
+	}
+	defer rows.Close()
+
+	var datas []domain.Handover
+	for rows.Next() {
+		data, err := scanHandover(rows)
+		if err != nil {
+			return []domain.Handover{}, err
+		}
+		datas = append(datas, data)
+	}
+
+	return datas, rows.Err()
+}
+
+func (repository *HandoverQueryImpl) CountAllHandover(c context.Context, db *pgxpool.Pool, filter domain.HandoverQueryFilter) (int, error) {
+	// handover query filter builders
+	filterString, args, _ := filter.BuildHandoverQueries()
+
+	query := fmt.Sprintf(`SELECT COUNT(*) FROM handovers AS h %s`, filterString)
+
+	var count int
+	err := db.QueryRow(c, query, args...).Scan(&count)
+	if err != nil {
+		return 0, err
+	}
+
+	return count, err
+}
+
+func (repository *HandoverQueryImpl) FindById(c context.Context, db *pgxpool.Pool, id string) (domain.Handover, error) {
+	query := `SELECT ` + handoverColumns + ` FROM handovers AS h ` + handoverJoins + ` WHERE h.id=$1`
+
+	return scanHandover(db.QueryRow(c, query, id))
+}
+
+func (repository *HandoverQueryImpl) FindItems(c context.Context, db *pgxpool.Pool, handoverID string) ([]domain.HandoverItem, error) {
+	query := `SELECT
+		handover_id,
+		seq,
+		name,
+		description,
+		quantity,
+		unit,
+		serial_number,
+		condition,
+		note
+		FROM handover_items
+		WHERE handover_id=$1
+		ORDER BY seq`
+
+	rows, err := db.Query(c, query, handoverID)
+	if err != nil {
+		return []domain.HandoverItem{}, err
+	}
+	defer rows.Close()
+
+	var datas []domain.HandoverItem
+	for rows.Next() {
+		var data domain.HandoverItem
+		err := rows.Scan(
+			&data.HandoverID,
+			&data.Seq,
+			&data.Name,
+			&data.Description,
+			&data.Quantity,
+			&data.Unit,
+			&data.SerialNumber,
+			&data.Condition,
+			&data.Note,
+		)
+		if err != nil {
+			return []domain.HandoverItem{}, err
+		}
+		datas = append(datas, data)
+	}
+
+	return datas, rows.Err()
+}
+
+func (repository *HandoverQueryImpl) FindSigners(c context.Context, db *pgxpool.Pool, handoverID string) ([]domain.HandoverSigner, error) {
+	query := `SELECT
+		s.handover_id,
+		s.seq,
+		s.employee_id,
+		s.role,
+		s.status,
+		s.note,
+		s.signed_at,
+		e.employee_number,
+		u.name,
+		e.job_title,
+		u.id
+		FROM handover_signers AS s
+		JOIN employees AS e ON e.id = s.employee_id
+		JOIN users AS u ON u.id = e.user_id
+		WHERE s.handover_id=$1
+		ORDER BY s.seq`
+
+	rows, err := db.Query(c, query, handoverID)
+	if err != nil {
+		return []domain.HandoverSigner{}, err
+	}
+	defer rows.Close()
+
+	var datas []domain.HandoverSigner
+	for rows.Next() {
+		var data domain.HandoverSigner
+		err := rows.Scan(
+			&data.HandoverID,
+			&data.Seq,
+			&data.EmployeeID,
+			&data.Role,
+			&data.Status,
+			&data.Note,
+			&data.SignedAt,
+			&data.EmployeeNumber,
+			&data.EmployeeName,
+			&data.JobTitle,
+			&data.UserID,
+		)
+		if err != nil {
+			return []domain.HandoverSigner{}, err
+		}
+		datas = append(datas, data)
+	}
+
+	return datas, rows.Err()
+}
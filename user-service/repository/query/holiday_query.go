@@ -0,0 +1,102 @@
+package query
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/iqbaludinm/hr-microservice/user-service/model/domain"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type HolidayQuery interface {
+	CreateHoliday(c context.Context, tx pgx.Tx, holiday domain.Holiday) error
+	UpsertHoliday(c context.Context, tx pgx.Tx, holiday domain.Holiday) error
+	Delete(c context.Context, tx pgx.Tx, id string) error
+	FindAllHoliday(c context.Context, db *pgxpool.Pool, filter domain.HolidayQueryFilter) ([]domain.Holiday, error)
+	FindById(c context.Context, db *pgxpool.Pool, id string) (domain.Holiday, error)
+}
+
+type HolidayQueryImpl struct {
+}
+
+func NewHoliday() HolidayQuery {
+	return &HolidayQueryImpl{}
+}
+
+// the selected columns of the holiday. The order must match the 'scanHoliday' function.
+const holidayColumns = `id, work_calendar_id, date, name, type, created_at, updated_at`
+
+func scanHoliday(row pgx.Row) (domain.Holiday, error) {
+	var data domain.Holiday
+	err := row.Scan(
+		&data.ID,
+		&data.WorkCalendarID,
+		&data.Date,
+		&data.Name,
+		&data.Type,
+		&data.CreatedAt,
+		&data.UpdatedAt,
+	)
+
+	return data, err
+}
+
+func (repository *HolidayQueryImpl) CreateHoliday(c context.Context, tx pgx.Tx, holiday domain.Holiday) error {
+	// build INSERT query
+	query := `INSERT INTO public_holidays ("id", "work_calendar_id", "date", "name", "type", "created_at", "updated_at") VALUES ($1,$2,$3,$4,$5,$6,$7)`
+
+	_, err := tx.Exec(c, query, holiday.ID, holiday.WorkCalendarID, holiday.Date, holiday.Name, holiday.Type, holiday.CreatedAt, holiday.UpdatedAt)
+
+	return err
+}
+
+// insert the holiday or replace the name and type of the holiday of the calendar on the same date
+func (repository *HolidayQueryImpl) UpsertHoliday(c context.Context, tx pgx.Tx, holiday domain.Holiday) error {
+	// build INSERT query
+	query := `INSERT INTO public_holidays ("id", "work_calendar_id", "date", "name", "type", "created_at", "updated_at") VALUES ($1,$2,$3,$4,$5,$6,$7)
+		ON CONFLICT ((COALESCE("work_calendar_id", '00000000-0000-0000-0000-000000000000'::uuid)), "date")
+		DO UPDATE SET name=EXCLUDED.name, type=EXCLUDED.type, updated_at=EXCLUDED.updated_at`
+
+	_, err := tx.Exec(c, query, holiday.ID, holiday.WorkCalendarID, holiday.Date, holiday.Name, holiday.Type, holiday.CreatedAt, holiday.UpdatedAt)
+
+	return err
+}
+
+func (repository *HolidayQueryImpl) Delete(c context.Context, tx pgx.Tx, id string) error {
+	// build DELETE query
+	query := `DELETE FROM public_holidays WHERE id=$1`
+
+	_, err := tx.Exec(c, query, id)
+
+	return err
+}
+
+func (repository *HolidayQueryImpl) FindAllHoliday(c context.Context, db *pgxpool.Pool, filter domain.HolidayQueryFilter) ([]domain.Holiday, error) {
+	filterString, args := filter.BuildHolidayQueries()
+	// the national day is listed before the day of the calendar on the same date
+	query := fmt.Sprintf(`SELECT %s FROM public_holidays %s ORDER BY date, work_calendar_id NULLS FIRST`, holidayColumns, filterString)
+
+	rows, err := db.Query(c, query, args...)
+	if err != nil {
+		return []domain.Holiday{}, err
+	}
+	defer rows.Close()
+
+	var datas []domain.Holiday
+	for rows.Next() {
+		data, err := scanHoliday(rows)
+		if err != nil {
+			return []domain.Holiday{}, err
+		}
+		datas = append(datas, data)
+	}
+
+	return datas, rows.Err()
+}
+
+func (repository *HolidayQueryImpl) FindById(c context.Context, db *pgxpool.Pool, id string) (domain.Holiday, error) {
+	query := `SELECT ` + holidayColumns + ` FROM public_holidays WHERE id=$1`
+
+	return scanHoliday(db.QueryRow(c, query, id))
+}
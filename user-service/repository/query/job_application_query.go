@@ -0,0 +1,813 @@
+package query
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/iqbaludinm/hr-microservice/user-service/model/domain"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type JobApplicationQuery interface {
+	CreateStage(c context.Context, tx pgx.Tx, stage domain.RecruitmentStage) error
+	UpdateStage(c context.Context, tx pgx.Tx, id string, stage domain.RecruitmentStage) error
+	DeleteStage(c context.Context, tx pgx.Tx, id string) error
+	CreateCandidate(c context.Context, tx pgx.Tx, candidate domain.Candidate) error
+	UpdateCandidate(c context.Context, tx pgx.Tx, id string, candidate domain.Candidate) error
+	CreateApplication(c context.Context, tx pgx.Tx, application domain.JobApplication) error
+	UpdateApplicationStage(c context.Context, tx pgx.Tx, id string, application domain.JobApplication) error
+	UpdateApplicationStatus(c context.Context, tx pgx.Tx, id string, application domain.JobApplication) error
+	CreateMove(c context.Context, tx pgx.Tx, move domain.JobApplicationMove) error
+	CreateInterview(c context.Context, tx pgx.Tx, interview domain.Interview) error
+	UpdateInterviewStatus(c context.Context, tx pgx.Tx, id string, interview domain.Interview) error
+	UpdateScorecard(c context.Context, tx pgx.Tx, id string, interview domain.Interview) error
+	CreateScorecardItem(c context.Context, tx pgx.Tx, item domain.InterviewScorecardItem) error
+	DeleteScorecardItems(c context.Context, tx pgx.Tx, interviewID string) error
+	CreateOffer(c context.Context, tx pgx.Tx, offer domain.JobOffer) error
+	UpdateOfferStatus(c context.Context, tx pgx.Tx, id string, offer domain.JobOffer) error
+	FindAllStage(c context.Context, db *pgxpool.Pool) ([]domain.RecruitmentStage, error)
+	FindStageById(c context.Context, db *pgxpool.Pool, id string) (domain.RecruitmentStage, error)
+	FindFirstStage(c context.Context, db *pgxpool.Pool) (domain.RecruitmentStage, error)
+	CountStageUsage(c context.Context, db *pgxpool.Pool, id string) (int, error)
+	FindCandidateByEmail(c context.Context, db *pgxpool.Pool, email string) (domain.Candidate, error)
+	FindAllApplication(c context.Context, db *pgxpool.Pool, filter domain.JobApplicationQueryFilter) ([]domain.JobApplication, error)
+	CountAllApplication(c context.Context, db *pgxpool.Pool, filter domain.JobApplicationQueryFilter) (int, error)
+	FindApplicationById(c context.Context, db *pgxpool.Pool, id string) (domain.JobApplication, error)
+	FindMoves(c context.Context, db *pgxpool.Pool, applicationID string) ([]domain.JobApplicationMove, error)
+	FindInterviews(c context.Context, db *pgxpool.Pool, applicationID string) ([]domain.Interview, error)
+	FindInterviewsByInterviewer(c context.Context, db *pgxpool.Pool, interviewerID string) ([]domain.Interview, error)
+	FindInterviewById(c context.Context, db *pgxpool.Pool, id string) (domain.Interview, error)
+	FindScorecardItems(c context.Context, db *pgxpool.Pool, interviewID string) ([]domain.InterviewScorecardItem, error)
+	FindOffers(c context.Context, db *pgxpool.Pool, applicationID string) ([]domain.JobOffer, error)
+	FindOfferById(c context.Context, db *pgxpool.Pool, id string) (domain.JobOffer, error)
+}
+
+type JobApplicationQueryImpl struct {
+}
+
+func NewJobApplication() JobApplicationQuery {
+	return &JobApplicationQueryImpl{}
+}
+
+// the selected columns of the job application, joined with the posting, the requisition, the candidate and the
+// current stage. The order must match the 'scanJobApplication' function.
+const jobApplicationColumns = `
+	ja.id,
+	ja.posting_id,
+	ja.candidate_id,
+	ja.stage_id,
+	ja.status,
+	ja.cover_letter,
+	ja.cv_file_name,
+	ja.cv_mime_type,
+	ja.cv_size,
+	ja.cv_storage_key,
+	ja.rejection_reason,
+	ja.user_id,
+	ja.hired_at,
+	ja.created_at,
+	ja.updated_at,
+	jp.title,
+	jp.requisition_id,
+	jr.requested_by,
+	ca.name,
+	ca.email,
+	ca.phone,
+	rs.name`
+
+const jobApplicationJoins = `
+	JOIN job_postings AS jp ON jp.id = ja.posting_id
+	JOIN job_requisitions AS jr ON jr.id = jp.requisition_id
+	JOIN candidates AS ca ON ca.id = ja.candidate_id
+	JOIN recruitment_stages AS rs ON rs.id = ja.stage_id`
+
+func scanJobApplication(row pgx.Row) (domain.JobApplication, error) {
+	var data domain.JobApplication
+	err := row.Scan(
+		&data.ID,
+		&data.PostingID,
+		&data.CandidateID,
+		&data.StageID,
+		&data.Status,
+		&data.CoverLetter,
+		&data.CVFileName,
+		&data.CVMimeType,
+		&data.CVSize,
+		&data.CVStorageKey,
+		&data.RejectionReason,
+		&data.UserID,
+		&data.HiredAt,
+		&data.CreatedAt,
+		&data.UpdatedAt,
+		&data.PostingTitle,
+		&data.RequisitionID,
+		&data.RequestedBy,
+		&data.CandidateName,
+		&data.CandidateEmail,
+		&data.CandidatePhone,
+		&data.StageName,
+	)
+
+	return data, err
+}
+
+// the selected columns of the interview, joined with the application, the candidate, the posting, the stage and
+// the interviewer. The order must match the 'scanInterview' function.
+const interviewColumns = `
+	i.id,
+	i.application_id,
+	i.stage_id,
+	i.interviewer_id,
+	i.scheduled_at,
+	i.duration_minutes,
+	i.location,
+	i.status,
+	i.recommendation,
+	i.score,
+	i.feedback,
+	i.feedback_submitted_at,
+	i.created_by,
+	i.created_at,
+	i.updated_at,
+	ca.name,
+	ca.email,
+	jp.title,
+	rs.name,
+	u.name,
+	e.user_id`
+
+const interviewJoins = `
+	JOIN job_applications AS ja ON ja.id = i.application_id
+	JOIN candidates AS ca ON ca.id = ja.candidate_id
+	JOIN job_postings AS jp ON jp.id = ja.posting_id
+	JOIN recruitment_stages AS rs ON rs.id = i.stage_id
+	JOIN employees AS e ON e.id = i.interviewer_id
+	JOIN users AS u ON u.id = e.user_id`
+
+func scanInterview(row pgx.Row) (domain.Interview, error) {
+	var data domain.Interview
+	err := row.Scan(
+		&data.ID,
+		&data.ApplicationID,
+		&data.StageID,
+		&data.InterviewerID,
+		&data.ScheduledAt,
+		&data.DurationMinutes,
+		&data.Location,
+		&data.Status,
+		&data.Recommendation,
+		&data.Score,
+		&data.Feedback,
+		&data.FeedbackSubmittedAt,
+		&data.CreatedBy,
+		&data.CreatedAt,
+		&data.UpdatedAt,
+		&data.CandidateName,
+		&data.CandidateEmail,
+		&data.PostingTitle,
+		&data.StageName,
+		&data.InterviewerName,
+		&data.InterviewerUserID,
+	)
+
+	return data, err
+}
+
+// the selected columns of the job offer. The order must match the 'scanJobOffer' function.
+const jobOfferColumns = `
+	o.id,
+	o.application_id,
+	o.job_title,
+	o.base_salary,
+	o.start_date,
+	o.expires_on,
+	o.status,
+	o.note,
+	o.response_note,
+	o.created_by,
+	o.sent_at,
+	o.responded_at,
+	o.created_at,
+	o.updated_at`
+
+func scanJobOffer(row pgx.Row) (domain.JobOffer, error) {
+	var data domain.JobOffer
+	err := row.Scan(
+		&data.ID,
+		&data.ApplicationID,
+		&data.JobTitle,
+		&data.BaseSalary,
+		&data.StartDate,
+		&data.ExpiresOn,
+		&data.Status,
+		&data.Note,
+		&data.ResponseNote,
+		&data.CreatedBy,
+		&data.SentAt,
+		&data.RespondedAt,
+		&data.CreatedAt,
+		&data.UpdatedAt,
+	)
+
+	return data, err
+}
+
+func (repository *JobApplicationQueryImpl) CreateStage(c context.Context, tx pgx.Tx, stage domain.RecruitmentStage) error {
+	// build INSERT query
+	query := `INSERT INTO recruitment_stages (
+		"id",
+		"name",
+		"seq",
+		"created_at",
+		"updated_at"
+		) VALUES ($1,$2,$3,$4,$5)`
+
+	_, err := tx.Exec(c, query,
+		stage.ID,
+		stage.Name,
+		stage.Seq,
+		stage.CreatedAt,
+		stage.UpdatedAt,
+	)
+
+	return err
+}
+
+func (repository *JobApplicationQueryImpl) UpdateStage(c context.Context, tx pgx.Tx, id string, stage domain.RecruitmentStage) error {
+	// build UPDATE query
+	query := `UPDATE recruitment_stages SET
+		name=$1,
+		seq=$2,
+		updated_at=$3
+		WHERE id=$4`
+
+	_, err := tx.Exec(c, query,
+		stage.Name,
+		stage.Seq,
+		stage.UpdatedAt,
+		id,
+	)
+
+	return err
+}
+
+func (repository *JobApplicationQueryImpl) DeleteStage(c context.Context, tx pgx.Tx, id string) error {
+	query := `DELETE FROM recruitment_stages WHERE id=$1`
+
+	_, err := tx.Exec(c, query, id)
+
+	return err
+}
+
+func (repository *JobApplicationQueryImpl) CreateCandidate(c context.Context, tx pgx.Tx, candidate domain.Candidate) error {
+	// build INSERT query
+	query := `INSERT INTO candidates (
+		"id",
+		"name",
+		"email",
+		"phone",
+		"created_at",
+		"updated_at"
+		) VALUES ($1,$2,$3,$4,$5,$6)`
+
+	_, err := tx.Exec(c, query,
+		candidate.ID,
+		candidate.Name,
+		candidate.Email,
+		candidate.Phone,
+		candidate.CreatedAt,
+		candidate.UpdatedAt,
+	)
+
+	return err
+}
+
+func (repository *JobApplicationQueryImpl) UpdateCandidate(c context.Context, tx pgx.Tx, id string, candidate domain.Candidate) error {
+	// build UPDATE query
+	query := `UPDATE candidates SET
+		name=$1,
+		phone=$2,
+		updated_at=$3
+		WHERE id=$4`
+
+	_, err := tx.Exec(c, query,
+		candidate.Name,
+		candidate.Phone,
+		candidate.UpdatedAt,
+		id,
+	)
+
+	return err
+}
+
+func (repository *JobApplicationQueryImpl) CreateApplication(c context.Context, tx pgx.Tx, application domain.JobApplication) error {
+	// build INSERT query
+	query := `INSERT INTO job_applications (
+		"id",
+		"posting_id",
+		"candidate_id",
+		"stage_id",
+		"status",
+		"cover_letter",
+		"cv_file_name",
+		"cv_mime_type",
+		"cv_size",
+		"cv_storage_key",
+		"created_at",
+		"updated_at"
+		) VALUES ($1,$2,$3,$4,$5,$6,$7,$8,$9,$10,$11,$12)`
+
+	_, err := tx.Exec(c, query,
+		application.ID,
+		application.PostingID,
+		application.CandidateID,
+		application.StageID,
+		application.Status,
+		application.CoverLetter,
+		application.CVFileName,
+		application.CVMimeType,
+		application.CVSize,
+		application.CVStorageKey,
+		application.CreatedAt,
+		application.UpdatedAt,
+	)
+
+	return err
+}
+
+func (repository *JobApplicationQueryImpl) UpdateApplicationStage(c context.Context, tx pgx.Tx, id string, application domain.JobApplication) error {
+	// build UPDATE query
+	query := `UPDATE job_applications SET
+		stage_id=$1,
+		updated_at=$2
+		WHERE id=$3`
+
+	_, err := tx.Exec(c, query,
+		application.StageID,
+		application.UpdatedAt,
+		id,
+	)
+
+	return err
+}
+
+func (repository *JobApplicationQueryImpl) UpdateApplicationStatus(c context.Context, tx pgx.Tx, id string, application domain.JobApplication) error {
+	// build UPDATE query
+	query := `UPDATE job_applications SET
+		status=$1,
+		rejection_reason=$2,
+		user_id=$3,
+		hired_at=$4,
+		updated_at=$5
+		WHERE id=$6`
+
+	_, err := tx.Exec(c, query,
+		application.Status,
+		application.RejectionReason,
+		application.UserID,
+		application.HiredAt,
+		application.UpdatedAt,
+		id,
+	)
+
+	return err
+}
+
+func (repository *JobApplicationQueryImpl) CreateMove(c context.Context, tx pgx.Tx, move domain.JobApplicationMove) error {
+	// build INSERT query
+	query := `INSERT INTO job_application_stage_moves (
+		"id",
+		"application_id",
+		"from_stage_id",
+		"to_stage_id",
+		"note",
+		"moved_by",
+		"moved_at"
+		) VALUES ($1,$2,$3,$4,$5,$6,$7)`
+
+	_, err := tx.Exec(c, query,
+		move.ID,
+		move.ApplicationID,
+		move.FromStageID,
+		move.ToStageID,
+		move.Note,
+		move.MovedBy,
+		move.MovedAt,
+	)
+
+	return err
+}
+
+func (repository *JobApplicationQueryImpl) CreateInterview(c context.Context, tx pgx.Tx, interview domain.Interview) error {
+	// build INSERT query
+	query := `INSERT INTO interviews (
+		"id",
+		"application_id",
+		"stage_id",
+		"interviewer_id",
+		"scheduled_at",
+		"duration_minutes",
+		"location",
+		"status",
+		"created_by",
+		"created_at",
+		"updated_at"
+		) VALUES ($1,$2,$3,$4,$5,$6,$7,$8,$9,$10,$11)`
+
+	_, err := tx.Exec(c, query,
+		interview.ID,
+		interview.ApplicationID,
+		interview.StageID,
+		interview.InterviewerID,
+		interview.ScheduledAt,
+		interview.DurationMinutes,
+		interview.Location,
+		interview.Status,
+		interview.CreatedBy,
+		interview.CreatedAt,
+		interview.UpdatedAt,
+	)
+
+	return err
+}
+
+func (repository *JobApplicationQueryImpl) UpdateInterviewStatus(c context.Context, tx pgx.Tx, id string, interview domain.Interview) error {
+	// build UPDATE query
+	query := `UPDATE interviews SET
+		status=$1,
+		updated_at=$2
+		WHERE id=$3`
+
+	_, err := tx.Exec(c, query,
+		interview.Status,
+		interview.UpdatedAt,
+		id,
+	)
+
+	return err
+}
+
+func (repository *JobApplicationQueryImpl) UpdateScorecard(c context.Context, tx pgx.Tx, id string, interview domain.Interview) error {
+	// build UPDATE query
+	query := `UPDATE interviews SET
+		status=$1,
+		recommendation=$2,
+		score=$3,
+		feedback=$4,
+		feedback_submitted_at=$5,
+		updated_at=$6
+		WHERE id=$7`
+
+	_, err := tx.Exec(c, query,
+		interview.Status,
+		interview.Recommendation,
+		interview.Score,
+		interview.Feedback,
+		interview.FeedbackSubmittedAt,
+		interview.UpdatedAt,
+		id,
+	)
+
+	return err
+}
+
+func (repository *JobApplicationQueryImpl) CreateScorecardItem(c context.Context, tx pgx.Tx, item domain.InterviewScorecardItem) error {
+	// build INSERT query
+	query := `INSERT INTO interview_scorecard_items (
+		"interview_id",
+		"seq",
+		"criterion",
+		"score",
+		"comment"
+		) VALUES ($1,$2,$3,$4,$5)`
+
+	_, err := tx.Exec(c, query,
+		item.InterviewID,
+		item.Seq,
+		item.Criterion,
+		item.Score,
+		item.Comment,
+	)
+
+	return err
+}
+
+func (repository *JobApplicationQueryImpl) DeleteScorecardItems(c context.Context, tx pgx.Tx, interviewID string) error {
+	query := `DELETE FROM interview_scorecard_items WHERE interview_id=$1`
+
+	_, err := tx.Exec(c, query, interviewID)
+
+	return err
+}
+
+func (repository *JobApplicationQueryImpl) CreateOffer(c context.Context, tx pgx.Tx, offer domain.JobOffer) error {
+	// build INSERT query
+	query := `INSERT INTO job_offers (
+		"id",
+		"application_id",
+		"job_title",
+		"base_salary",
+		"start_date",
+		"expires_on",
+		"status",
+		"note",
+		"created_by",
+		"created_at",
+		"updated_at"
+		) VALUES ($1,$2,$3,$4,$5,$6,$7,$8,$9,$10,$11)`
+
+	_, err := tx.Exec(c, query,
+		offer.ID,
+		offer.ApplicationID,
+		offer.JobTitle,
+		offer.BaseSalary,
+		offer.StartDate,
+		offer.ExpiresOn,
+		offer.Status,
+		offer.Note,
+		offer.CreatedBy,
+		offer.CreatedAt,
+		offer.UpdatedAt,
+	)
+
+	return err
+}
+
+func (repository *JobApplicationQueryImpl) UpdateOfferStatus(c context.Context, tx pgx.Tx, id string, offer domain.JobOffer) error {
+	// build UPDATE query
+	query := `UPDATE job_offers SET
+		status=$1,
+		response_note=$2,
+		sent_at=$3,
+		responded_at=$4,
+		updated_at=$5
+		WHERE id=$6`
+
+	_, err := tx.Exec(c, query,
+		offer.Status,
+		offer.ResponseNote,
+		offer.SentAt,
+		offer.RespondedAt,
+		offer.UpdatedAt,
+		id,
+	)
+
+	return err
+}
+
+func (repository *JobApplicationQueryImpl) FindAllStage(c context.Context, db *pgxpool.Pool) ([]domain.RecruitmentStage, error) {
+	query := `SELECT id, name, seq, created_at, updated_at FROM recruitment_stages ORDER BY seq`
+
+	rows, err := db.Query(c, query)
+	if err != nil {
+		return []domain.RecruitmentStage{}, err
+	}
+	defer rows.Close()
+
+	var datas []domain.RecruitmentStage
+	for rows.Next() {
+		var data domain.RecruitmentStage
+		if err := rows.Scan(&data.ID, &data.Name, &data.Seq, &data.CreatedAt, &data.UpdatedAt); err != nil {
+			return []domain.RecruitmentStage{}, err
+		}
+		datas = append(datas, data)
+	}
+
+	return datas, rows.Err()
+}
+
+func (repository *JobApplicationQueryImpl) FindStageById(c context.Context, db *pgxpool.Pool, id string) (domain.RecruitmentStage, error) {
+	query := `SELECT id, name, seq, created_at, updated_at FROM recruitment_stages WHERE id=$1`
+
+	var data domain.RecruitmentStage
+	err := db.QueryRow(c, query, id).Scan(&data.ID, &data.Name, &data.Seq, &data.CreatedAt, &data.UpdatedAt)
+
+	return data, err
+}
+
+// get the stage the new applications start from, the stage with the lowest seq
+func (repository *JobApplicationQueryImpl) FindFirstStage(c context.Context, db *pgxpool.Pool) (domain.RecruitmentStage, error) {
+	query := `SELECT id, name, seq, created_at, updated_at FROM recruitment_stages ORDER BY seq LIMIT 1`
+
+	var data domain.RecruitmentStage
+	err := db.QueryRow(c, query).Scan(&data.ID, &data.Name, &data.Seq, &data.CreatedAt, &data.UpdatedAt)
+
+	return data, err
+}
+
+// count the applications, the moves and the interviews which refer to the stage
+func (repository *JobApplicationQueryImpl) CountStageUsage(c context.Context, db *pgxpool.Pool, id string) (int, error) {
+	query := `SELECT
+		(SELECT COUNT(*) FROM job_applications WHERE stage_id=$1) +
+		(SELECT COUNT(*) FROM job_application_stage_moves WHERE from_stage_id=$1 OR to_stage_id=$1) +
+		(SELECT COUNT(*) FROM interviews WHERE stage_id=$1)`
+
+	var count int
+	err := db.QueryRow(c, query, id).Scan(&count)
+	if err != nil {
+		return 0, err
+	}
+
+	return count, err
+}
+
+func (repository *JobApplicationQueryImpl) FindCandidateByEmail(c context.Context, db *pgxpool.Pool, email string) (domain.Candidate, error) {
+	query := `SELECT id, name, email, phone, created_at, updated_at FROM candidates WHERE lower(email)=lower($1)`
+
+	var data domain.Candidate
+	err := db.QueryRow(c, query, email).Scan(&data.ID, &data.Name, &data.Email, &data.Phone, &data.CreatedAt, &data.UpdatedAt)
+
+	return data, err
+}
+
+func (repository *JobApplicationQueryImpl) FindAllApplication(c context.Context, db *pgxpool.Pool, filter domain.JobApplicationQueryFilter) ([]domain.JobApplication, error) {
+	// job application query filter builders
+	filterString, args, pagination := filter.BuildJobApplicationQueries()
+
+	query := fmt.Sprintf(
+		`SELECT %s
+		FROM job_applications AS ja
+		%s
+		%s
+		ORDER BY rs.seq, ja.created_at
+		%s`,
+		jobApplicationColumns, jobApplicationJoins, filterString, pagination,
+	)
+
+	rows, err := db.Query(c, query, args...)
+	if err != nil {
+		return []domain.JobApplication{}, err
+	}
+	defer rows.Close()
+
+	var datas []domain.JobApplication
+	for rows.Next() {
+		data, err := scanJobApplication(rows)
+		if err != nil {
+			return []domain.JobApplication{}, err
+		}
+		datas = append(datas, data)
+	}
+
+	return datas, rows.Err()
+}
+
+func (repository *JobApplicationQueryImpl) CountAllApplication(c context.Context, db *pgxpool.Pool, filter domain.JobApplicationQueryFilter) (int, error) {
+	// job application query filter builders
+	filterString, args, _ := filter.BuildJobApplicationQueries()
+
+	query := fmt.Sprintf(`SELECT COUNT(*) FROM job_applications AS ja
+		JOIN candidates AS ca ON ca.id = ja.candidate_id %s`, filterString)
+
+	var count int
+	err := db.QueryRow(c, query, args...).Scan(&count)
+	if err != nil {
+		return 0, err
+	}
+
+	return count, err
+}
+
+func (repository *JobApplicationQueryImpl) FindApplicationById(c context.Context, db *pgxpool.Pool, id string) (domain.JobApplication, error) {
+	query := `SELECT ` + jobApplicationColumns + ` FROM job_applications AS ja ` + jobApplicationJoins + ` WHERE ja.id=$1`
+
+	return scanJobApplication(db.QueryRow(c, query, id))
+}
+
+func (repository *JobApplicationQueryImpl) FindMoves(c context.Context, db *pgxpool.Pool, applicationID string) ([]domain.JobApplicationMove, error) {
+	query := `SELECT
+		m.id,
+		m.application_id,
+		m.from_stage_id,
+		m.to_stage_id,
+		m.note,
+		m.moved_by,
+		m.moved_at,
+		fs.name,
+		ts.name
+		FROM job_application_stage_moves AS m
+		LEFT JOIN recruitment_stages AS fs ON fs.id = m.from_stage_id
+		JOIN recruitment_stages AS ts ON ts.id = m.to_stage_id
+		WHERE m.application_id=$1
+		ORDER BY m.moved_at`
+
+	rows, err := db.Query(c, query, applicationID)
+	if err != nil {
+		return []domain.JobApplicationMove{}, err
+	}
+	defer rows.Close()
+
+	var datas []domain.JobApplicationMove
+	for rows.Next() {
+		var data domain.JobApplicationMove
+		err := rows.Scan(
+			&data.ID,
+			&data.ApplicationID,
+			&data.FromStageID,
+			&data.ToStageID,
+			&data.Note,
+			&data.MovedBy,
+			&data.MovedAt,
+			&data.FromStageName,
+			&data.ToStageName,
+		)
+		if err != nil {
+			return []domain.JobApplicationMove{}, err
+		}
+		datas = append(datas, data)
+	}
+
+	return datas, rows.Err()
+}
+
+func (repository *JobApplicationQueryImpl) FindInterviews(c context.Context, db *pgxpool.Pool, applicationID string) ([]domain.Interview, error) {
+	query := `SELECT ` + interviewColumns + ` FROM interviews AS i ` + interviewJoins + `
+		WHERE i.application_id=$1
+		ORDER BY i.scheduled_at`
+
+	return findInterviews(c, db, query, applicationID)
+}
+
+// get the interviews of the interviewer which are scheduled or still waiting for the scorecard
+func (repository *JobApplicationQueryImpl) FindInterviewsByInterviewer(c context.Context, db *pgxpool.Pool, interviewerID string) ([]domain.Interview, error) {
+	query := `SELECT ` + interviewColumns + ` FROM interviews AS i ` + interviewJoins + `
+		WHERE i.interviewer_id=$1 AND i.status=$2
+		ORDER BY i.scheduled_at`
+
+	return findInterviews(c, db, query, interviewerID, domain.InterviewScheduled)
+}
+
+func (repository *JobApplicationQueryImpl) FindInterviewById(c context.Context, db *pgxpool.Pool, id string) (domain.Interview, error) {
+	query := `SELECT ` + interviewColumns + ` FROM interviews AS i ` + interviewJoins + ` WHERE i.id=$1`
+
+	return scanInterview(db.QueryRow(c, query, id))
+}
+
+func (repository *JobApplicationQueryImpl) FindScorecardItems(c context.Context, db *pgxpool.Pool, interviewID string) ([]domain.InterviewScorecardItem, error) {
+	query := `SELECT interview_id, seq, criterion, score, comment FROM interview_scorecard_items
+		WHERE interview_id=$1 ORDER BY seq`
+
+	rows, err := db.Query(c, query, interviewID)
+	if err != nil {
+		return []domain.InterviewScorecardItem{}, err
+	}
+	defer rows.Close()
+
+	var datas []domain.InterviewScorecardItem
+	for rows.Next() {
+		var data domain.InterviewScorecardItem
+		if err := rows.Scan(&data.InterviewID, &data.Seq, &data.Criterion, &data.Score, &data.Comment); err != nil {
+			return []domain.InterviewScorecardItem{}, err
+		}
+		datas = append(datas, data)
+	}
+
+	return datas, rows.Err()
+}
+
+func (repository *JobApplicationQueryImpl) FindOffers(c context.Context, db *pgxpool.Pool, applicationID string) ([]domain.JobOffer, error) {
+	query := `SELECT ` + jobOfferColumns + ` FROM job_offers AS o WHERE o.application_id=$1 ORDER BY o.created_at`
+
+	rows, err := db.Query(c, query, applicationID)
+	if err != nil {
+		return []domain.JobOffer{}, err
+	}
+	defer rows.Close()
+
+	var datas []domain.JobOffer
+	for rows.Next() {
+		data, err := scanJobOffer(rows)
+		if err != nil {
+			return []domain.JobOffer{}, err
+		}
+		datas = append(datas, data)
+	}
+
+	return datas, rows.Err()
+}
+
+func (repository *JobApplicationQueryImpl) FindOfferById(c context.Context, db *pgxpool.Pool, id string) (domain.JobOffer, error) {
+	query := `SELECT ` + jobOfferColumns + ` FROM job_offers AS o WHERE o.id=$1`
+
+	return scanJobOffer(db.QueryRow(c, query, id))
+}
+
+func findInterviews(c context.Context, db *pgxpool.Pool, query string, args ...interface{}) ([]domain.Interview, error) {
+	rows, err := db.Query(c, query, args...)
+	if err != nil {
+		return []domain.Interview{}, err
+	}
+	defer rows.Close()
+
+	var datas []domain.Interview
+	for rows.Next() {
+		data, err := scanInterview(rows)
+		if err != nil {
+			return []domain.Interview{}, err
+		}
+		datas = append(datas, data)
+	}
+
+	return datas, rows.Err()
+}
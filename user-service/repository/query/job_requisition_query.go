@@ -0,0 +1,434 @@
+package query
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/iqbaludinm/hr-microservice/user-service/model/domain"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type JobRequisitionQuery interface {
+	CreateRequisition(c context.Context, tx pgx.Tx, requisition domain.JobRequisition) error
+	UpdateRequisition(c context.Context, tx pgx.Tx, id string, requisition domain.JobRequisition) error
+	UpdateRequisitionStatus(c context.Context, tx pgx.Tx, id string, requisition domain.JobRequisition) error
+	IncrementHired(c context.Context, tx pgx.Tx, id string, updatedAt time.Time) (string, error)
+	CreatePosting(c context.Context, tx pgx.Tx, posting domain.JobPosting) error
+	UpdatePostingStatus(c context.Context, tx pgx.Tx, id string, posting domain.JobPosting) error
+	CloseExpiredPostings(c context.Context, tx pgx.Tx, today time.Time) (int64, error)
+	FindAllRequisition(c context.Context, db *pgxpool.Pool, filter domain.JobRequisitionQueryFilter) ([]domain.JobRequisition, error)
+	CountAllRequisition(c context.Context, db *pgxpool.Pool, filter domain.JobRequisitionQueryFilter) (int, error)
+	FindRequisitionById(c context.Context, db *pgxpool.Pool, id string) (domain.JobRequisition, error)
+	FindAllPosting(c context.Context, db *pgxpool.Pool, filter domain.JobPostingQueryFilter) ([]domain.JobPosting, error)
+	CountAllPosting(c context.Context, db *pgxpool.Pool, filter domain.JobPostingQueryFilter) (int, error)
+	FindPostingById(c context.Context, db *pgxpool.Pool, id string) (domain.JobPosting, error)
+	FindPostingByRequisition(c context.Context, db *pgxpool.Pool, requisitionID string) (domain.JobPosting, error)
+}
+
+type JobRequisitionQueryImpl struct {
+}
+
+func NewJobRequisition() JobRequisitionQuery {
+	return &JobRequisitionQueryImpl{}
+}
+
+// the selected columns of the job requisition, joined with the department, the position, the requester and the
+// approver. The order must match the 'scanJobRequisition' function.
+const jobRequisitionColumns = `
+	jr.id,
+	jr.title,
+	jr.department_id,
+	jr.position_id,
+	jr.headcount,
+	jr.employment_type,
+	jr.description,
+	jr.reason,
+	jr.salary_min,
+	jr.salary_max,
+	jr.status,
+	jr.requested_by,
+	jr.approver_id,
+	jr.decision_note,
+	jr.decided_at,
+	jr.created_at,
+	jr.updated_at,
+	d.name,
+	p.title,
+	ru.name,
+	au.name,
+	ae.user_id,
+	jr.hired`
+
+const jobRequisitionJoins = `
+	JOIN departments AS d ON d.id = jr.department_id
+	LEFT JOIN positions AS p ON p.id = jr.position_id
+	JOIN users AS ru ON ru.id = jr.requested_by
+	LEFT JOIN employees AS ae ON ae.id = jr.approver_id
+	LEFT JOIN users AS au ON au.id = ae.user_id`
+
+func scanJobRequisition(row pgx.Row) (domain.JobRequisition, error) {
+	var data domain.JobRequisition
+	err := row.Scan(
+		&data.ID,
+		&data.Title,
+		&data.DepartmentID,
+		&data.PositionID,
+		&data.Headcount,
+		&data.EmploymentType,
+		&data.Description,
+		&data.Reason,
+		&data.SalaryMin,
+		&data.SalaryMax,
+		&data.Status,
+		&data.RequestedBy,
+		&data.ApproverID,
+		&data.DecisionNote,
+		&data.DecidedAt,
+		&data.CreatedAt,
+		&data.UpdatedAt,
+		&data.Department,
+		&data.Position,
+		&data.RequesterName,
+		&data.ApproverName,
+		&data.ApproverUserID,
+		&data.Hired,
+	)
+
+	return data, err
+}
+
+// the selected columns of the job posting, joined with the requisition and its department.
+// The order must match the 'scanJobPosting' function.
+const jobPostingColumns = `
+	jp.id,
+	jp.requisition_id,
+	jp.title,
+	jp.description,
+	jp.requirements,
+	jp.location,
+	jp.status,
+	jp.published_at,
+	jp.closes_on,
+	jp.closed_at,
+	jp.created_at,
+	jp.updated_at,
+	jr.department_id,
+	d.name,
+	jr.employment_type,
+	jr.requested_by`
+
+const jobPostingJoins = `
+	JOIN job_requisitions AS jr ON jr.id = jp.requisition_id
+	JOIN departments AS d ON d.id = jr.department_id`
+
+func scanJobPosting(row pgx.Row) (domain.JobPosting, error) {
+	var data domain.JobPosting
+	err := row.Scan(
+		&data.ID,
+		&data.RequisitionID,
+		&data.Title,
+		&data.Description,
+		&data.Requirements,
+		&data.Location,
+		&data.Status,
+		&data.PublishedAt,
+		&data.ClosesOn,
+		&data.ClosedAt,
+		&data.CreatedAt,
+		&data.UpdatedAt,
+		&data.DepartmentID,
+		&data.Department,
+		&data.EmploymentType,
+		&data.RequestedBy,
+	)
+
+	return data, err
+}
+
+func (repository *JobRequisitionQueryImpl) CreateRequisition(c context.Context, tx pgx.Tx, requisition domain.JobRequisition) error {
+	// build INSERT query
+	query := `INSERT INTO job_requisitions (
+		"id",
+		"title",
+		"department_id",
+		"position_id",
+		"headcount",
+		"employment_type",
+		"description",
+		"reason",
+		"salary_min",
+		"salary_max",
+		"status",
+		"requested_by",
+		"created_at",
+		"updated_at"
+		) VALUES ($1,$2,$3,$4,$5,$6,$7,$8,$9,$10,$11,$12,$13,$14)`
+
+	_, err := tx.Exec(c, query,
+		requisition.ID,
+		requisition.Title,
+		requisition.DepartmentID,
+		requisition.PositionID,
+		requisition.Headcount,
+		requisition.EmploymentType,
+		requisition.Description,
+		requisition.Reason,
+		requisition.SalaryMin,
+		requisition.SalaryMax,
+		requisition.Status,
+		requisition.RequestedBy,
+		requisition.CreatedAt,
+		requisition.UpdatedAt,
+	)
+
+	return err
+}
+
+func (repository *JobRequisitionQueryImpl) UpdateRequisition(c context.Context, tx pgx.Tx, id string, requisition domain.JobRequisition) error {
+	// build UPDATE query
+	query := `UPDATE job_requisitions SET
+		title=$1,
+		department_id=$2,
+		position_id=$3,
+		headcount=$4,
+		employment_type=$5,
+		description=$6,
+		reason=$7,
+		salary_min=$8,
+		salary_max=$9,
+		updated_at=$10
+		WHERE id=$11`
+
+	_, err := tx.Exec(c, query,
+		requisition.Title,
+		requisition.DepartmentID,
+		requisition.PositionID,
+		requisition.Headcount,
+		requisition.EmploymentType,
+		requisition.Description,
+		requisition.Reason,
+		requisition.SalaryMin,
+		requisition.SalaryMax,
+		requisition.UpdatedAt,
+		id,
+	)
+
+	return err
+}
+
+func (repository *JobRequisitionQueryImpl) UpdateRequisitionStatus(c context.Context, tx pgx.Tx, id string, requisition domain.JobRequisition) error {
+	// build UPDATE query
+	query := `UPDATE job_requisitions SET
+		status=$1,
+		approver_id=$2,
+		decision_note=$3,
+		decided_at=$4,
+		updated_at=$5
+		WHERE id=$6`
+
+	_, err := tx.Exec(c, query,
+		requisition.Status,
+		requisition.ApproverID,
+		requisition.DecisionNote,
+		requisition.DecidedAt,
+		requisition.UpdatedAt,
+		id,
+	)
+
+	return err
+}
+
+// count a hired candidate of the approved requisition, the requisition is filled by the last hire of the headcount
+// and its status is returned. The headcount is checked in the same statement so the concurrent hires can't exceed it,
+// 'no rows' is returned when the requisition is no longer approved or its headcount is already hired.
+func (repository *JobRequisitionQueryImpl) IncrementHired(c context.Context, tx pgx.Tx, id string, updatedAt time.Time) (string, error) {
+	query := `UPDATE job_requisitions SET
+		hired=hired + 1,
+		status=CASE WHEN hired + 1 >= headcount THEN $1 ELSE status END,
+		updated_at=$2
+		WHERE id=$3 AND status=$4 AND hired < headcount
+		RETURNING status`
+
+	var status string
+	err := tx.QueryRow(c, query, domain.JobRequisitionFilled, updatedAt, id, domain.JobRequisitionApproved).Scan(&status)
+
+	return status, err
+}
+
+func (repository *JobRequisitionQueryImpl) CreatePosting(c context.Context, tx pgx.Tx, posting domain.JobPosting) error {
+	// build INSERT query
+	query := `INSERT INTO job_postings (
+		"id",
+		"requisition_id",
+		"title",
+		"description",
+		"requirements",
+		"location",
+		"status",
+		"published_at",
+		"closes_on",
+		"created_at",
+		"updated_at"
+		) VALUES ($1,$2,$3,$4,$5,$6,$7,$8,$9,$10,$11)`
+
+	_, err := tx.Exec(c, query,
+		posting.ID,
+		posting.RequisitionID,
+		posting.Title,
+		posting.Description,
+		posting.Requirements,
+		posting.Location,
+		posting.Status,
+		posting.PublishedAt,
+		posting.ClosesOn,
+		posting.CreatedAt,
+		posting.UpdatedAt,
+	)
+
+	return err
+}
+
+func (repository *JobRequisitionQueryImpl) UpdatePostingStatus(c context.Context, tx pgx.Tx, id string, posting domain.JobPosting) error {
+	// build UPDATE query
+	query := `UPDATE job_postings SET
+		status=$1,
+		closed_at=$2,
+		updated_at=$3
+		WHERE id=$4`
+
+	_, err := tx.Exec(c, query,
+		posting.Status,
+		posting.ClosedAt,
+		posting.UpdatedAt,
+		id,
+	)
+
+	return err
+}
+
+// close the open postings which closing date has passed, it returns the number of the closed postings
+func (repository *JobRequisitionQueryImpl) CloseExpiredPostings(c context.Context, tx pgx.Tx, today time.Time) (int64, error) {
+	query := `UPDATE job_postings SET status=$1, closed_at=$2, updated_at=$2
+		WHERE status=$3 AND closes_on < $4::date`
+
+	tag, err := tx.Exec(c, query, domain.JobPostingClosed, time.Now(), domain.JobPostingOpen, today)
+	if err != nil {
+		return 0, err
+	}
+
+	return tag.RowsAffected(), nil
+}
+
+func (repository *JobRequisitionQueryImpl) FindAllRequisition(c context.Context, db *pgxpool.Pool, filter domain.JobRequisitionQueryFilter) ([]domain.JobRequisition, error) {
+	// job requisition query filter builders
+	filterString, args, pagination := filter.BuildJobRequisitionQueries()
+
+	query := fmt.Sprintf(
+		`SELECT %s
+		FROM job_requisitions AS jr
+		%s
+		%s
+		ORDER BY jr.created_at DESC
+		%s`,
+		jobRequisitionColumns, jobRequisitionJoins, filterString, pagination,
+	)
+
+	rows, err := db.Query(c, query, args...)
+	if err != nil {
+		return []domain.JobRequisition{}, err
+	}
+	defer rows.Close()
+
+	var datas []domain.JobRequisition
+	for rows.Next() {
+		data, err := scanJobRequisition(rows)
+		if err != nil {
+			return []domain.JobRequisition{}, err
+		}
+		datas = append(datas, data)
+	}
+
+	return datas, rows.Err()
+}
+
+func (repository *JobRequisitionQueryImpl) CountAllRequisition(c context.Context, db *pgxpool.Pool, filter domain.JobRequisitionQueryFilter) (int, error) {
+	// job requisition query filter builders
+	filterString, args, _ := filter.BuildJobRequisitionQueries()
+
+	query := fmt.Sprintf(`SELECT COUNT(*) FROM job_requisitions AS jr %s`, filterString)
+
+	var count int
+	err := db.QueryRow(c, query, args...).Scan(&count)
+	if err != nil {
+		return 0, err
+	}
+
+	return count, err
+}
+
+func (repository *JobRequisitionQueryImpl) FindRequisitionById(c context.Context, db *pgxpool.Pool, id string) (domain.JobRequisition, error) {
+	query := `SELECT ` + jobRequisitionColumns + ` FROM job_requisitions AS jr ` + jobRequisitionJoins + ` WHERE jr.id=$1`
+
+	return scanJobRequisition(db.QueryRow(c, query, id))
+}
+
+func (repository *JobRequisitionQueryImpl) FindAllPosting(c context.Context, db *pgxpool.Pool, filter domain.JobPostingQueryFilter) ([]domain.JobPosting, error) {
+	// job posting query filter builders
+	filterString, args, pagination := filter.BuildJobPostingQueries()
+
+	query := fmt.Sprintf(
+		`SELECT %s
+		FROM job_postings AS jp
+		%s
+		%s
+		ORDER BY jp.published_at DESC
+		%s`,
+		jobPostingColumns, jobPostingJoins, filterString, pagination,
+	)
+
+	rows, err := db.Query(c, query, args...)
+	if err != nil {
+		return []domain.JobPosting{}, err
+	}
+	defer rows.Close()
+
+	var datas []domain.JobPosting
+	for rows.Next() {
+		data, err := scanJobPosting(rows)
+		if err != nil {
+			return []domain.JobPosting{}, err
+		}
+		datas = append(datas, data)
+	}
+
+	return datas, rows.Err()
+}
+
+func (repository *JobRequisitionQueryImpl) CountAllPosting(c context.Context, db *pgxpool.Pool, filter domain.JobPostingQueryFilter) (int, error) {
+	// job posting query filter builders
+	filterString, args, _ := filter.BuildJobPostingQueries()
+
+	query := fmt.Sprintf(`SELECT COUNT(*) FROM job_postings AS jp %s %s`, jobPostingJoins, filterString)
+
+	var count int
+	err := db.QueryRow(c, query, args...).Scan(&count)
+	if err != nil {
+		return 0, err
+	}
+
+	return count, err
+}
+
+func (repository *JobRequisitionQueryImpl) FindPostingById(c context.Context, db *pgxpool.Pool, id string) (domain.JobPosting, error) {
+	query := `SELECT ` + jobPostingColumns + ` FROM job_postings AS jp ` + jobPostingJoins + ` WHERE jp.id=$1`
+
+	return scanJobPosting(db.QueryRow(c, query, id))
+}
+
+func (repository *JobRequisitionQueryImpl) FindPostingByRequisition(c context.Context, db *pgxpool.Pool, requisitionID string) (domain.JobPosting, error) {
+	query := `SELECT ` + jobPostingColumns + ` FROM job_postings AS jp ` + jobPostingJoins + ` WHERE jp.requisition_id=$1`
+
+	return scanJobPosting(db.QueryRow(c, query, requisitionID))
+}
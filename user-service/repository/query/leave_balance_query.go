@@ -0,0 +1,150 @@
+package query
+
+import (
+	"context"
+	"time"
+
+	"github.com/iqbaludinm/hr-microservice/user-service/model/domain"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type LeaveBalanceQuery interface {
+	CreateBalance(c context.Context, tx pgx.Tx, balance domain.LeaveBalance) error
+	AdjustBalance(c context.Context, tx pgx.Tx, employeeID, leaveTypeID string, year int, used, pending float64) error
+	FindBalance(c context.Context, db *pgxpool.Pool, employeeID, leaveTypeID string, year int) (domain.LeaveBalance, error)
+	FindBalanceForUpdate(c context.Context, tx pgx.Tx, employeeID, leaveTypeID string, year int) (domain.LeaveBalance, error)
+	FindAllBalance(c context.Context, db *pgxpool.Pool, employeeID string, year int) ([]domain.LeaveBalance, error)
+}
+
+type LeaveBalanceQueryImpl struct {
+}
+
+func NewLeaveBalance() LeaveBalanceQuery {
+	return &LeaveBalanceQueryImpl{}
+}
+
+// the selected columns of the leave balance, joined with the 'leave_types' table.
+// The order must match the 'scanLeaveBalance' function.
+const leaveBalanceColumns = `b.id, b.employee_id, b.leave_type_id, b.year, b.carried_over, b.used, b.pending, b.created_at, b.updated_at, lt.code, lt.name`
+
+func scanLeaveBalance(row pgx.Row) (domain.LeaveBalance, error) {
+	var data domain.LeaveBalance
+	err := row.Scan(
+		&data.ID,
+		&data.EmployeeID,
+		&data.LeaveTypeID,
+		&data.Year,
+		&data.CarriedOver,
+		&data.Used,
+		&data.Pending,
+		&data.CreatedAt,
+		&data.UpdatedAt,
+		&data.LeaveTypeCode,
+		&data.LeaveTypeName,
+	)
+
+	return data, err
+}
+
+// create the balance of the year, an existing balance is kept as it is
+func (repository *LeaveBalanceQueryImpl) CreateBalance(c context.Context, tx pgx.Tx, balance domain.LeaveBalance) error {
+	// build INSERT query
+	query := `INSERT INTO leave_balances (
+		"id",
+		"employee_id",
+		"leave_type_id",
+		"year",
+		"carried_over",
+		"used",
+		"pending",
+		"created_at",
+		"updated_at"
+		) VALUES ($1,$2,$3,$4,$5,$6,$7,$8,$9)
+		ON CONFLICT ("employee_id", "leave_type_id", "year") DO NOTHING`
+
+	_, err := tx.Exec(c, query,
+		balance.ID,
+		balance.EmployeeID,
+		balance.LeaveTypeID,
+		balance.Year,
+		balance.CarriedOver,
+		balance.Used,
+		balance.Pending,
+		balance.CreatedAt,
+		balance.UpdatedAt,
+	)
+
+	return err
+}
+
+// add the 'used' and 'pending' days to the balance, a negative value is used for refunding the days
+func (repository *LeaveBalanceQueryImpl) AdjustBalance(c context.Context, tx pgx.Tx, employeeID, leaveTypeID string, year int, used, pending float64) error {
+	// build UPDATE query
+	query := `UPDATE leave_balances SET
+		used=used + $1,
+		pending=pending + $2,
+		updated_at=$3
+		WHERE
+			employee_id=$4 AND
+			leave_type_id=$5 AND
+			year=$6`
+
+	_, err := tx.Exec(c, query, used, pending, time.Now(), employeeID, leaveTypeID, year)
+
+	return err
+}
+
+func (repository *LeaveBalanceQueryImpl) FindBalance(c context.Context, db *pgxpool.Pool, employeeID, leaveTypeID string, year int) (domain.LeaveBalance, error) {
+	query := `SELECT ` + leaveBalanceColumns + `
+		FROM leave_balances AS b
+		JOIN leave_types AS lt ON lt.id = b.leave_type_id
+		WHERE
+			b.employee_id=$1 AND
+			b.leave_type_id=$2 AND
+			b.year=$3`
+
+	return scanLeaveBalance(db.QueryRow(c, query, employeeID, leaveTypeID, year))
+}
+
+// find the balance and lock it until the transaction ends, the leave requests of the employee
+// in the same leave type and year are created one at a time
+func (repository *LeaveBalanceQueryImpl) FindBalanceForUpdate(c context.Context, tx pgx.Tx, employeeID, leaveTypeID string, year int) (domain.LeaveBalance, error) {
+	query := `SELECT ` + leaveBalanceColumns + `
+		FROM leave_balances AS b
+		JOIN leave_types AS lt ON lt.id = b.leave_type_id
+		WHERE
+			b.employee_id=$1 AND
+			b.leave_type_id=$2 AND
+			b.year=$3
+		FOR UPDATE OF b`
+
+	return scanLeaveBalance(tx.QueryRow(c, query, employeeID, leaveTypeID, year))
+}
+
+func (repository *LeaveBalanceQueryImpl) FindAllBalance(c context.Context, db *pgxpool.Pool, employeeID string, year int) ([]domain.LeaveBalance, error) {
+	query := `SELECT ` + leaveBalanceColumns + `
+		FROM leave_balances AS b
+		JOIN leave_types AS lt ON lt.id = b.leave_type_id
+		WHERE
+			b.employee_id=$1 AND
+			b.year=$2
+		ORDER BY lt.name`
+
+	rows, err := db.Query(c, query, employeeID, year)
+	if err != nil {
+		return []domain.LeaveBalance{}, err
+	}
+	defer rows.Close()
+
+	var datas []domain.LeaveBalance
+	for rows.Next() {
+		data, err := scanLeaveBalance(rows)
+		if err != nil {
+			return []domain.LeaveBalance{}, err
+		}
+		datas = append(datas, data)
+	}
+
+	return datas, rows.Err()
+}
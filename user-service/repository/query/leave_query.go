@@ -0,0 +1,231 @@
+package query
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/iqbaludinm/hr-microservice/user-service/model/domain"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type LeaveQuery interface {
+	CreateLeave(c context.Context, tx pgx.Tx, leave domain.Leave) error
+	UpdateStatus(c context.Context, tx pgx.Tx, id string, leave domain.Leave, status string) error
+	FindAllLeave(c context.Context, db *pgxpool.Pool, filter domain.LeaveQueryFilter) ([]domain.Leave, error)
+	CountAllLeave(c context.Context, db *pgxpool.Pool, filter domain.LeaveQueryFilter) (int, error)
+	FindById(c context.Context, db *pgxpool.Pool, id string) (domain.Leave, error)
+	CountOverlap(c context.Context, db Querier, employeeID string, startDate, endDate time.Time) (int, error)
+}
+
+type LeaveQueryImpl struct {
+}
+
+func NewLeave() LeaveQuery {
+	return &LeaveQueryImpl{}
+}
+
+// the selected columns of the leave request, joined with the employee, the leave type and the approver.
+// The order must match the 'scanLeave' function.
+const leaveColumns = `
+	l.id,
+	l.employee_id,
+	l.leave_type_id,
+	l.start_date,
+	l.end_date,
+	l.half_day,
+	l.days,
+	l.reason,
+	l.status,
+	l.approver_id,
+	l.decision_note,
+	l.decided_at,
+	l.cancelled_at,
+	l.created_at,
+	l.updated_at,
+	e.employee_number,
+	u.name,
+	u.id,
+	lt.code,
+	lt.name,
+	COALESCE(au.name, '')`
+
+const leaveJoins = `
+	JOIN employees AS e ON e.id = l.employee_id
+	JOIN users AS u ON u.id = e.user_id
+	JOIN leave_types AS lt ON lt.id = l.leave_type_id
+	LEFT JOIN employees AS a ON a.id = l.approver_id
+	LEFT JOIN users AS au ON au.id = a.user_id`
+
+func scanLeave(row pgx.Row) (domain.Leave, error) {
+	var data domain.Leave
+	err := row.Scan(
+		&data.ID,
+		&data.EmployeeID,
+		&data.LeaveTypeID,
+		&data.StartDate,
+		&data.EndDate,
+		&data.HalfDay,
+		&data.Days,
+		&data.Reason,
+		&data.Status,
+		&data.ApproverID,
+		&data.DecisionNote,
+		&data.DecidedAt,
+		&data.CancelledAt,
+		&data.CreatedAt,
+		&data.UpdatedAt,
+		&data.EmployeeNumber,
+		&data.EmployeeName,
+		&data.EmployeeUserID,
+		&data.LeaveTypeCode,
+		&data.LeaveTypeName,
+		&data.ApproverName,
+	)
+
+	return data, err
+}
+
+func (repository *LeaveQueryImpl) CreateLeave(c context.Context, tx pgx.Tx, leave domain.Leave) error {
+	// build INSERT query
+	query := `INSERT INTO leave_requests (
+		"id",
+		"employee_id",
+		"leave_type_id",
+		"start_date",
+		"end_date",
+		"half_day",
+		"days",
+		"reason",
+		"status",
+		"approver_id",
+		"created_at",
+		"updated_at"
+		) VALUES ($1,$2,$3,$4,$5,$6,$7,$8,$9,$10,$11,$12)`
+
+	_, err := tx.Exec(c, query,
+		leave.ID,
+		leave.EmployeeID,
+		leave.LeaveTypeID,
+		leave.StartDate,
+		leave.EndDate,
+		leave.HalfDay,
+		leave.Days,
+		leave.Reason,
+		leave.Status,
+		leave.ApproverID,
+		leave.CreatedAt,
+		leave.UpdatedAt,
+	)
+
+	return err
+}
+
+// update the status of the leave request with the decision (approve, reject) or the cancellation.
+// The leave request is only updated while it still has the given status, otherwise 'pgx.ErrNoRows' is returned.
+func (repository *LeaveQueryImpl) UpdateStatus(c context.Context, tx pgx.Tx, id string, leave domain.Leave, status string) error {
+	// build UPDATE query
+	query := `UPDATE leave_requests SET
+		status=$1,
+		decision_note=$2,
+		decided_at=$3,
+		cancelled_at=$4,
+		updated_at=$5
+		WHERE id=$6 AND status=$7`
+
+	tag, err := tx.Exec(c, query,
+		leave.Status,
+		leave.DecisionNote,
+		leave.DecidedAt,
+		leave.CancelledAt,
+		leave.UpdatedAt,
+		id,
+		status,
+	)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return pgx.ErrNoRows
+	}
+
+	return nil
+}
+
+func (repository *LeaveQueryImpl) FindAllLeave(c context.Context, db *pgxpool.Pool, filter domain.LeaveQueryFilter) ([]domain.Leave, error) {
+	// leave query filter builders
+	filterString, args, pagination := filter.BuildLeaveQueries()
+
+	query := fmt.Sprintf(
+		`SELECT %s
+		FROM leave_requests AS l
+		%s
+		%s
+		ORDER BY l.start_date DESC, l.created_at DESC
+		%s`,
+		leaveColumns, leaveJoins, filterString, pagination,
+	)
+	rows, err := db.Query(c, query, args...)
+	if err != nil {
+		return []domain.Leave{}, err
+	}
+	defer rows.Close()
+
+	var datas []domain.Leave
+	for rows.Next() {
+		data, err := scanLeave(rows)
+		if err != nil {
+			return []domain.Leave{}, err
+		}
+		datas = append(datas, data)
+	}
+
+	return datas, rows.Err()
+}
+
+func (repository *LeaveQueryImpl) CountAllLeave(c context.Context, db *pgxpool.Pool, filter domain.LeaveQueryFilter) (int, error) {
+	// leave query filter builders
+	filterString, args, _ := filter.BuildLeaveQueries()
+
+	query := fmt.Sprintf(`SELECT COUNT(*) FROM leave_requests AS l %s`, filterString)
+
+	var count int
+	err := db.QueryRow(c, query, args...).Scan(&count)
+	if err != nil {
+		return 0, err
+	}
+
+	return count, err
+}
+
+func (repository *LeaveQueryImpl) FindById(c context.Context, db *pgxpool.Pool, id string) (domain.Leave, error) {
+	query := fmt.Sprintf(
+		`SELECT %s
+		FROM leave_requests AS l
+		%s
+		WHERE l.id=$1`,
+		leaveColumns, leaveJoins,
+	)
+
+	return scanLeave(db.QueryRow(c, query, id))
+}
+
+// count the pending and approved leave requests of the employee that overlap the dates
+func (repository *LeaveQueryImpl) CountOverlap(c context.Context, db Querier, employeeID string, startDate, endDate time.Time) (int, error) {
+	query := `SELECT COUNT(*)
+		FROM leave_requests
+		WHERE
+			employee_id=$1 AND
+			status IN ('pending', 'approved') AND
+			start_date <= $3 AND
+			end_date >= $2`
+
+	var count int
+	err := db.QueryRow(c, query, employeeID, startDate, endDate).Scan(&count)
+	if err != nil {
+		return 0, err
+	}
+
+	return count, err
+}
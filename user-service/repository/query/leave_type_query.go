@@ -0,0 +1,145 @@
+package query
+
+import (
+	"context"
+	"time"
+
+	"github.com/iqbaludinm/hr-microservice/user-service/model/domain"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type LeaveTypeQuery interface {
+	CreateLeaveType(c context.Context, tx pgx.Tx, leaveType domain.LeaveType) error
+	UpdateLeaveType(c context.Context, tx pgx.Tx, id string, leaveType domain.LeaveType) error
+	Delete(c context.Context, tx pgx.Tx, id string) error
+	FindAllLeaveType(c context.Context, db *pgxpool.Pool) ([]domain.LeaveType, error)
+	FindById(c context.Context, db *pgxpool.Pool, id string) (domain.LeaveType, error)
+}
+
+type LeaveTypeQueryImpl struct {
+}
+
+func NewLeaveType() LeaveTypeQuery {
+	return &LeaveTypeQueryImpl{}
+}
+
+// the selected columns of the leave type. The order must match the 'scanLeaveType' function.
+const leaveTypeColumns = `id, code, name, accrual_method, annual_quota, max_carry_over, requires_balance, allow_half_day, is_paid, created_at, updated_at, deleted_at`
+
+func scanLeaveType(row pgx.Row) (domain.LeaveType, error) {
+	var data domain.LeaveType
+	err := row.Scan(
+		&data.ID,
+		&data.Code,
+		&data.Name,
+		&data.AccrualMethod,
+		&data.AnnualQuota,
+		&data.MaxCarryOver,
+		&data.RequiresBalance,
+		&data.AllowHalfDay,
+		&data.IsPaid,
+		&data.CreatedAt,
+		&data.UpdatedAt,
+		&data.DeletedAt,
+	)
+
+	return data, err
+}
+
+func (repository *LeaveTypeQueryImpl) CreateLeaveType(c context.Context, tx pgx.Tx, leaveType domain.LeaveType) error {
+	// build INSERT query
+	query := `INSERT INTO leave_types (
+		"id",
+		"code",
+		"name",
+		"accrual_method",
+		"annual_quota",
+		"max_carry_over",
+		"requires_balance",
+		"allow_half_day",
+		"is_paid",
+		"created_at",
+		"updated_at"
+		) VALUES ($1,$2,$3,$4,$5,$6,$7,$8,$9,$10,$11)`
+
+	_, err := tx.Exec(c, query,
+		leaveType.ID,
+		leaveType.Code,
+		leaveType.Name,
+		leaveType.AccrualMethod,
+		leaveType.AnnualQuota,
+		leaveType.MaxCarryOver,
+		leaveType.RequiresBalance,
+		leaveType.AllowHalfDay,
+		leaveType.IsPaid,
+		leaveType.CreatedAt,
+		leaveType.UpdatedAt,
+	)
+
+	return err
+}
+
+func (repository *LeaveTypeQueryImpl) UpdateLeaveType(c context.Context, tx pgx.Tx, id string, leaveType domain.LeaveType) error {
+	// build UPDATE query
+	query := `UPDATE leave_types SET
+		name=$1,
+		accrual_method=$2,
+		annual_quota=$3,
+		max_carry_over=$4,
+		requires_balance=$5,
+		allow_half_day=$6,
+		is_paid=$7,
+		updated_at=$8
+		WHERE id=$9`
+
+	_, err := tx.Exec(c, query,
+		leaveType.Name,
+		leaveType.AccrualMethod,
+		leaveType.AnnualQuota,
+		leaveType.MaxCarryOver,
+		leaveType.RequiresBalance,
+		leaveType.AllowHalfDay,
+		leaveType.IsPaid,
+		leaveType.UpdatedAt,
+		id,
+	)
+
+	return err
+}
+
+func (repository *LeaveTypeQueryImpl) Delete(c context.Context, tx pgx.Tx, id string) error {
+	// build UPDATE query
+	query := `UPDATE leave_types SET deleted_at=$1 WHERE id=$2`
+
+	_, err := tx.Exec(c, query, time.Now(), id)
+
+	return err
+}
+
+func (repository *LeaveTypeQueryImpl) FindAllLeaveType(c context.Context, db *pgxpool.Pool) ([]domain.LeaveType, error) {
+	query := `SELECT ` + leaveTypeColumns + ` FROM leave_types WHERE deleted_at is null ORDER BY name`
+
+	rows, err := db.Query(c, query)
+	if err != nil {
+		return []domain.LeaveType{}, err
+	}
+	defer rows.Close()
+
+	var datas []domain.LeaveType
+	for rows.Next() {
+		data, err := scanLeaveType(rows)
+		if err != nil {
+			return []domain.LeaveType{}, err
+		}
+		datas = append(datas, data)
+	}
+
+	return datas, rows.Err()
+}
+
+func (repository *LeaveTypeQueryImpl) FindById(c context.Context, db *pgxpool.Pool, id string) (domain.LeaveType, error) {
+	query := `SELECT ` + leaveTypeColumns + ` FROM leave_types WHERE deleted_at is null AND id=$1`
+
+	return scanLeaveType(db.QueryRow(c, query, id))
+}
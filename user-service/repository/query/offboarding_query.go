@@ -0,0 +1,410 @@
+package query
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/iqbaludinm/hr-microservice/user-service/model/domain"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type OffboardingQuery interface {
+	CreateOffboarding(c context.Context, tx pgx.Tx, offboarding domain.Offboarding) error
+	UpdateOffboarding(c context.Context, tx pgx.Tx, id string, offboarding domain.Offboarding) error
+	UpdateStatus(c context.Context, tx pgx.Tx, id string, offboarding domain.Offboarding) error
+	UpdateDeactivated(c context.Context, tx pgx.Tx, id string, deactivatedAt time.Time) error
+	UpdateClearanceDocument(c context.Context, tx pgx.Tx, id string, key string) error
+	CreateTask(c context.Context, tx pgx.Tx, task domain.OffboardingTask) error
+	UpdateTask(c context.Context, tx pgx.Tx, task domain.OffboardingTask) error
+	ShiftTaskDueDates(c context.Context, tx pgx.Tx, offboardingID string, days int) error
+	FindAll(c context.Context, db *pgxpool.Pool, filter domain.OffboardingQueryFilter) ([]domain.Offboarding, error)
+	CountAll(c context.Context, db *pgxpool.Pool, filter domain.OffboardingQueryFilter) (int, error)
+	FindById(c context.Context, db *pgxpool.Pool, id string) (domain.Offboarding, error)
+	FindByEmployee(c context.Context, db *pgxpool.Pool, employeeID string) (domain.Offboarding, error)
+	FindTasks(c context.Context, db *pgxpool.Pool, offboardingID string) ([]domain.OffboardingTask, error)
+	FindTasksByAssignee(c context.Context, db *pgxpool.Pool, assigneeID string) ([]domain.OffboardingTask, error)
+	FindDue(c context.Context, db *pgxpool.Pool, today time.Time) ([]domain.Offboarding, error)
+}
+
+type OffboardingQueryImpl struct {
+}
+
+func NewOffboarding() OffboardingQuery {
+	return &OffboardingQueryImpl{}
+}
+
+// the selected columns of the offboarding, joined with the employee and counted from the tasks.
+// The order must match the 'scanOffboarding' function.
+const offboardingColumns = `
+	o.id,
+	o.employee_id,
+	o.type,
+	o.notice_date,
+	o.last_working_day,
+	o.reason,
+	o.rehire_eligible,
+	o.status,
+	o.completed_at,
+	o.cancelled_at,
+	o.deactivated_at,
+	o.clearance_document_key,
+	o.created_by,
+	o.created_at,
+	o.updated_at,
+	e.employee_number,
+	u.name,
+	e.user_id,
+	(SELECT COUNT(*) FROM offboarding_tasks AS t WHERE t.offboarding_id = o.id),
+	(SELECT COUNT(*) FROM offboarding_tasks AS t WHERE t.offboarding_id = o.id AND t.status <> 'pending')`
+
+const offboardingJoins = `
+	JOIN employees AS e ON e.id = o.employee_id
+	JOIN users AS u ON u.id = e.user_id`
+
+func scanOffboarding(row pgx.Row) (domain.Offboarding, error) {
+	var data domain.Offboarding
+	err := row.Scan(
+		&data.ID,
+		&data.EmployeeID,
+		&data.Type,
+		&data.NoticeDate,
+		&data.LastWorkingDay,
+		&data.Reason,
+		&data.RehireEligible,
+		&data.Status,
+		&data.CompletedAt,
+		&data.CancelledAt,
+		&data.DeactivatedAt,
+		&data.ClearanceDocumentKey,
+		&data.CreatedBy,
+		&data.CreatedAt,
+		&data.UpdatedAt,
+		&data.EmployeeNumber,
+		&data.EmployeeName,
+		&data.EmployeeUserID,
+		&data.TotalTasks,
+		&data.CompletedTasks,
+	)
+
+	return data, err
+}
+
+// the selected columns of the exit task, joined with the offboarding, the leaving employee and the assignee.
+// The order must match the 'scanOffboardingTask' function.
+const offboardingTaskColumns = `
+	t.offboarding_id,
+	t.seq,
+	t.title,
+	t.description,
+	t.category,
+	t.assignee_role,
+	t.assignee_id,
+	t.due_date,
+	t.status,
+	t.note,
+	t.completed_by,
+	t.completed_at,
+	COALESCE(au.name, ''),
+	ae.user_id,
+	o.employee_id,
+	e.employee_number,
+	u.name`
+
+const offboardingTaskJoins = `
+	JOIN offboardings AS o ON o.id = t.offboarding_id
+	JOIN employees AS e ON e.id = o.employee_id
+	JOIN users AS u ON u.id = e.user_id
+	LEFT JOIN employees AS ae ON ae.id = t.assignee_id
+	LEFT JOIN users AS au ON au.id = ae.user_id`
+
+func scanOffboardingTask(row pgx.Row) (domain.OffboardingTask, error) {
+	var data domain.OffboardingTask
+	err := row.Scan(
+		&data.OffboardingID,
+		&data.Seq,
+		&data.Title,
+		&data.Description,
+		&data.Category,
+		&data.AssigneeRole,
+		&data.AssigneeID,
+		&data.DueDate,
+		&data.Status,
+		&data.Note,
+		&data.CompletedBy,
+		&data.CompletedAt,
+		&data.AssigneeName,
+		&data.AssigneeUserID,
+		&data.EmployeeID,
+		&data.EmployeeNumber,
+		&data.EmployeeName,
+	)
+
+	return data, err
+}
+
+func (repository *OffboardingQueryImpl) CreateOffboarding(c context.Context, tx pgx.Tx, offboarding domain.Offboarding) error {
+	// build INSERT query
+	query := `INSERT INTO offboardings (
+		"id",
+		"employee_id",
+		"type",
+		"notice_date",
+		"last_working_day",
+		"reason",
+		"rehire_eligible",
+		"status",
+		"created_by",
+		"created_at",
+		"updated_at"
+		) VALUES ($1,$2,$3,$4,$5,$6,$7,$8,$9,$10,$11)`
+
+	_, err := tx.Exec(c, query,
+		offboarding.ID,
+		offboarding.EmployeeID,
+		offboarding.Type,
+		offboarding.NoticeDate,
+		offboarding.LastWorkingDay,
+		offboarding.Reason,
+		offboarding.RehireEligible,
+		offboarding.Status,
+		offboarding.CreatedBy,
+		offboarding.CreatedAt,
+		offboarding.UpdatedAt,
+	)
+
+	return err
+}
+
+func (repository *OffboardingQueryImpl) UpdateOffboarding(c context.Context, tx pgx.Tx, id string, offboarding domain.Offboarding) error {
+	// build UPDATE query
+	query := `UPDATE offboardings SET
+		last_working_day=$1,
+		reason=$2,
+		rehire_eligible=$3,
+		updated_at=$4
+		WHERE id=$5`
+
+	_, err := tx.Exec(c, query,
+		offboarding.LastWorkingDay,
+		offboarding.Reason,
+		offboarding.RehireEligible,
+		offboarding.UpdatedAt,
+		id,
+	)
+
+	return err
+}
+
+// update the status of the offboarding when it is completed or cancelled
+func (repository *OffboardingQueryImpl) UpdateStatus(c context.Context, tx pgx.Tx, id string, offboarding domain.Offboarding) error {
+	// build UPDATE query
+	query := `UPDATE offboardings SET
+		status=$1,
+		completed_at=$2,
+		cancelled_at=$3,
+		updated_at=$4
+		WHERE id=$5`
+
+	_, err := tx.Exec(c, query,
+		offboarding.Status,
+		offboarding.CompletedAt,
+		offboarding.CancelledAt,
+		offboarding.UpdatedAt,
+		id,
+	)
+
+	return err
+}
+
+// mark the offboarding as deactivated, so the account of the employee is not deactivated again
+func (repository *OffboardingQueryImpl) UpdateDeactivated(c context.Context, tx pgx.Tx, id string, deactivatedAt time.Time) error {
+	// build UPDATE query
+	query := `UPDATE offboardings SET deactivated_at=$1, updated_at=$1 WHERE id=$2`
+
+	_, err := tx.Exec(c, query, deactivatedAt, id)
+
+	return err
+}
+
+func (repository *OffboardingQueryImpl) UpdateClearanceDocument(c context.Context, tx pgx.Tx, id string, key string) error {
+	// build UPDATE query
+	query := `UPDATE offboardings SET clearance_document_key=$1 WHERE id=$2`
+
+	_, err := tx.Exec(c, query, key, id)
+
+	return err
+}
+
+func (repository *OffboardingQueryImpl) CreateTask(c context.Context, tx pgx.Tx, task domain.OffboardingTask) error {
+	// build INSERT query
+	query := `INSERT INTO offboarding_tasks (
+		"offboarding_id",
+		"seq",
+		"title",
+		"description",
+		"category",
+		"assignee_role",
+		"assignee_id",
+		"due_date",
+		"status"
+		) VALUES ($1,$2,$3,$4,$5,$6,$7,$8,$9)`
+
+	_, err := tx.Exec(c, query,
+		task.OffboardingID,
+		task.Seq,
+		task.Title,
+		task.Description,
+		task.Category,
+		task.AssigneeRole,
+		task.AssigneeID,
+		task.DueDate,
+		task.Status,
+	)
+
+	return err
+}
+
+// update the progress of the task
+func (repository *OffboardingQueryImpl) UpdateTask(c context.Context, tx pgx.Tx, task domain.OffboardingTask) error {
+	// build UPDATE query
+	query := `UPDATE offboarding_tasks SET
+		status=$1,
+		note=$2,
+		completed_by=$3,
+		completed_at=$4
+		WHERE offboarding_id=$5 AND seq=$6`
+
+	_, err := tx.Exec(c, query,
+		task.Status,
+		task.Note,
+		task.CompletedBy,
+		task.CompletedAt,
+		task.OffboardingID,
+		task.Seq,
+	)
+
+	return err
+}
+
+// shift the due date of the pending tasks when the last working day is changed
+func (repository *OffboardingQueryImpl) ShiftTaskDueDates(c context.Context, tx pgx.Tx, offboardingID string, days int) error {
+	// build UPDATE query
+	query := `UPDATE offboarding_tasks SET due_date = due_date + $1::int WHERE offboarding_id=$2 AND status='pending'`
+
+	_, err := tx.Exec(c, query, days, offboardingID)
+
+	return err
+}
+
+func (repository *OffboardingQueryImpl) FindAll(c context.Context, db *pgxpool.Pool, filter domain.OffboardingQueryFilter) ([]domain.Offboarding, error) {
+	// offboarding query filter builders
+	filterString, args, pagination := filter.BuildOffboardingQueries()
+
+	query := fmt.Sprintf(
+		`SELECT %s
+		FROM offboardings AS o
+		%s
+		%s
+		ORDER BY o.last_working_day DESC, o.created_at DESC
+		%s`,
+		offboardingColumns, offboardingJoins, filterString, pagination,
+	)
+
+	return findOffboardings(c, db, query, args...)
+}
+
+func (repository *OffboardingQueryImpl) CountAll(c context.Context, db *pgxpool.Pool, filter domain.OffboardingQueryFilter) (int, error) {
+	// offboarding query filter builders
+	filterString, args, _ := filter.BuildOffboardingQueries()
+
+	query := fmt.Sprintf(`SELECT COUNT(*) FROM offboardings AS o JOIN employees AS e ON e.id = o.employee_id %s`, filterString)
+
+	var count int
+	err := db.QueryRow(c, query, args...).Scan(&count)
+	if err != nil {
+		return 0, err
+	}
+
+	return count, err
+}
+
+func (repository *OffboardingQueryImpl) FindById(c context.Context, db *pgxpool.Pool, id string) (domain.Offboarding, error) {
+	query := `SELECT ` + offboardingColumns + ` FROM offboardings AS o ` + offboardingJoins + ` WHERE o.id=$1`
+
+	return scanOffboarding(db.QueryRow(c, query, id))
+}
+
+// find the offboarding of the employee which is not cancelled
+func (repository *OffboardingQueryImpl) FindByEmployee(c context.Context, db *pgxpool.Pool, employeeID string) (domain.Offboarding, error) {
+	query := `SELECT ` + offboardingColumns + ` FROM offboardings AS o ` + offboardingJoins + `
+		WHERE o.employee_id=$1 AND o.status <> 'cancelled'`
+
+	return scanOffboarding(db.QueryRow(c, query, employeeID))
+}
+
+func (repository *OffboardingQueryImpl) FindTasks(c context.Context, db *pgxpool.Pool, offboardingID string) ([]domain.OffboardingTask, error) {
+	query := `SELECT ` + offboardingTaskColumns + ` FROM offboarding_tasks AS t ` + offboardingTaskJoins + `
+		WHERE t.offboarding_id=$1
+		ORDER BY t.seq`
+
+	return findOffboardingTasks(c, db, query, offboardingID)
+}
+
+// find the tasks assigned to the employee on the offboardings which are not cancelled, the nearest due date first
+func (repository *OffboardingQueryImpl) FindTasksByAssignee(c context.Context, db *pgxpool.Pool, assigneeID string) ([]domain.OffboardingTask, error) {
+	query := `SELECT ` + offboardingTaskColumns + ` FROM offboarding_tasks AS t ` + offboardingTaskJoins + `
+		WHERE t.assignee_id=$1 AND o.status <> 'cancelled'
+		ORDER BY t.status <> 'pending', t.due_date, t.seq`
+
+	return findOffboardingTasks(c, db, query, assigneeID)
+}
+
+// find the offboardings which are not cancelled nor deactivated and whose last working day has passed, the account
+// of the employee is deactivated on the day after the last working day
+func (repository *OffboardingQueryImpl) FindDue(c context.Context, db *pgxpool.Pool, today time.Time) ([]domain.Offboarding, error) {
+	query := `SELECT ` + offboardingColumns + ` FROM offboardings AS o ` + offboardingJoins + `
+		WHERE o.status <> 'cancelled' AND o.deactivated_at IS NULL AND o.last_working_day < $1
+		ORDER BY o.last_working_day`
+
+	return findOffboardings(c, db, query, today)
+}
+
+func findOffboardings(c context.Context, db *pgxpool.Pool, query string, args ...interface{}) ([]domain.Offboarding, error) {
+	rows, err := db.Query(c, query, args...)
+	if err != nil {
+		return []domain.Offboarding{}, err
+	}
+	defer rows.Close()
+
+	var datas []domain.Offboarding
+	for rows.Next() {
+		data, err := scanOffboarding(rows)
+		if err != nil {
+			return []domain.Offboarding{}, err
+		}
+		datas = append(datas, data)
+	}
+
+	return datas, rows.Err()
+}
+
+func findOffboardingTasks(c context.Context, db *pgxpool.Pool, query string, args ...interface{}) ([]domain.OffboardingTask, error) {
+	rows, err := db.Query(c, query, args...)
+	if err != nil {
+		return []domain.OffboardingTask{}, err
+	}
+	defer rows.Close()
+
+	var datas []domain.OffboardingTask
+	for rows.Next() {
+		data, err := scanOffboardingTask(rows)
+		if err != nil {
+			return []domain.OffboardingTask{}, err
+		}
+		datas = append(datas, data)
+	}
+
+	return datas, rows.Err()
+}
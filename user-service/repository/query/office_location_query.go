@@ -0,0 +1,142 @@
+package query
+
+import (
+	"context"
+	"time"
+
+	"github.com/iqbaludinm/hr-microservice/user-service/model/domain"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type OfficeLocationQuery interface {
+	CreateOfficeLocation(c context.Context, tx pgx.Tx, location domain.OfficeLocation) error
+	UpdateOfficeLocation(c context.Context, tx pgx.Tx, id string, location domain.OfficeLocation) error
+	Delete(c context.Context, tx pgx.Tx, id string) error
+	FindAllOfficeLocation(c context.Context, db *pgxpool.Pool) ([]domain.OfficeLocation, error)
+	FindById(c context.Context, db *pgxpool.Pool, id string) (domain.OfficeLocation, error)
+	AssignEmployees(c context.Context, tx pgx.Tx, id string, employeeIDs []string) error
+}
+
+type OfficeLocationQueryImpl struct {
+}
+
+func NewOfficeLocation() OfficeLocationQuery {
+	return &OfficeLocationQueryImpl{}
+}
+
+// the selected columns of the office location. The order must match the 'scanOfficeLocation' function.
+const officeLocationColumns = `id, name, latitude, longitude, radius_meters, work_calendar_id, created_at, updated_at, deleted_at`
+
+func scanOfficeLocation(row pgx.Row) (domain.OfficeLocation, error) {
+	var data domain.OfficeLocation
+	err := row.Scan(
+		&data.ID,
+		&data.Name,
+		&data.Latitude,
+		&data.Longitude,
+		&data.RadiusMeters,
+		&data.WorkCalendarID,
+		&data.CreatedAt,
+		&data.UpdatedAt,
+		&data.DeletedAt,
+	)
+
+	return data, err
+}
+
+func (repository *OfficeLocationQueryImpl) CreateOfficeLocation(c context.Context, tx pgx.Tx, location domain.OfficeLocation) error {
+	// build INSERT query
+	query := `INSERT INTO office_locations (
+		"id",
+		"name",
+		"latitude",
+		"longitude",
+		"radius_meters",
+		"work_calendar_id",
+		"created_at",
+		"updated_at"
+		) VALUES ($1,$2,$3,$4,$5,$6,$7,$8)`
+
+	_, err := tx.Exec(c, query,
+		location.ID,
+		location.Name,
+		location.Latitude,
+		location.Longitude,
+		location.RadiusMeters,
+		location.WorkCalendarID,
+		location.CreatedAt,
+		location.UpdatedAt,
+	)
+
+	return err
+}
+
+func (repository *OfficeLocationQueryImpl) UpdateOfficeLocation(c context.Context, tx pgx.Tx, id string, location domain.OfficeLocation) error {
+	// build UPDATE query
+	query := `UPDATE office_locations SET
+		name=$1,
+		latitude=$2,
+		longitude=$3,
+		radius_meters=$4,
+		work_calendar_id=$5,
+		updated_at=$6
+		WHERE id=$7`
+
+	_, err := tx.Exec(c, query,
+		location.Name,
+		location.Latitude,
+		location.Longitude,
+		location.RadiusMeters,
+		location.WorkCalendarID,
+		location.UpdatedAt,
+		id,
+	)
+
+	return err
+}
+
+func (repository *OfficeLocationQueryImpl) Delete(c context.Context, tx pgx.Tx, id string) error {
+	// build UPDATE query
+	query := `UPDATE office_locations SET deleted_at=$1 WHERE id=$2`
+
+	_, err := tx.Exec(c, query, time.Now(), id)
+
+	return err
+}
+
+func (repository *OfficeLocationQueryImpl) FindAllOfficeLocation(c context.Context, db *pgxpool.Pool) ([]domain.OfficeLocation, error) {
+	query := `SELECT ` + officeLocationColumns + ` FROM office_locations WHERE deleted_at is null ORDER BY name`
+
+	rows, err := db.Query(c, query)
+	if err != nil {
+		return []domain.OfficeLocation{}, err
+	}
+	defer rows.Close()
+
+	var datas []domain.OfficeLocation
+	for rows.Next() {
+		data, err := scanOfficeLocation(rows)
+		if err != nil {
+			return []domain.OfficeLocation{}, err
+		}
+		datas = append(datas, data)
+	}
+
+	return datas, rows.Err()
+}
+
+func (repository *OfficeLocationQueryImpl) FindById(c context.Context, db *pgxpool.Pool, id string) (domain.OfficeLocation, error) {
+	query := `SELECT ` + officeLocationColumns + ` FROM office_locations WHERE deleted_at is null AND id=$1`
+
+	return scanOfficeLocation(db.QueryRow(c, query, id))
+}
+
+func (repository *OfficeLocationQueryImpl) AssignEmployees(c context.Context, tx pgx.Tx, id string, employeeIDs []string) error {
+	// build UPDATE query
+	query := `UPDATE employees SET office_location_id=$1, updated_at=$2 WHERE id = ANY($3::uuid[])`
+
+	_, err := tx.Exec(c, query, id, time.Now(), employeeIDs)
+
+	return err
+}
@@ -0,0 +1,559 @@
+package query
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/iqbaludinm/hr-microservice/user-service/model/domain"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type OnboardingQuery interface {
+	CreateTemplate(c context.Context, tx pgx.Tx, template domain.OnboardingTemplate) error
+	UpdateTemplate(c context.Context, tx pgx.Tx, id string, template domain.OnboardingTemplate) error
+	DeleteTemplate(c context.Context, tx pgx.Tx, id string) error
+	CreateTemplateTask(c context.Context, tx pgx.Tx, task domain.OnboardingTemplateTask) error
+	DeleteTemplateTasks(c context.Context, tx pgx.Tx, templateID string) error
+	FindAllTemplate(c context.Context, db *pgxpool.Pool) ([]domain.OnboardingTemplate, error)
+	FindTemplateById(c context.Context, db *pgxpool.Pool, id string) (domain.OnboardingTemplate, error)
+	FindTemplateTasks(c context.Context, db *pgxpool.Pool, templateID string) ([]domain.OnboardingTemplateTask, error)
+	FindTemplateForEmployee(c context.Context, db *pgxpool.Pool, departmentID, positionID *string) (domain.OnboardingTemplate, error)
+	CreateOnboarding(c context.Context, tx pgx.Tx, onboarding domain.Onboarding) error
+	UpdateStatus(c context.Context, tx pgx.Tx, id string, onboarding domain.Onboarding) error
+	CreateTask(c context.Context, tx pgx.Tx, task domain.OnboardingTask) error
+	UpdateTask(c context.Context, tx pgx.Tx, task domain.OnboardingTask) error
+	UpdateTaskReminded(c context.Context, tx pgx.Tx, task domain.OnboardingTask) error
+	FindAllOnboarding(c context.Context, db *pgxpool.Pool, filter domain.OnboardingQueryFilter) ([]domain.Onboarding, error)
+	CountAllOnboarding(c context.Context, db *pgxpool.Pool, filter domain.OnboardingQueryFilter) (int, error)
+	FindById(c context.Context, db *pgxpool.Pool, id string) (domain.Onboarding, error)
+	FindByEmployee(c context.Context, db *pgxpool.Pool, employeeID string) (domain.Onboarding, error)
+	FindTasks(c context.Context, db *pgxpool.Pool, onboardingID string) ([]domain.OnboardingTask, error)
+	FindTasksByAssignee(c context.Context, db *pgxpool.Pool, assigneeID string) ([]domain.OnboardingTask, error)
+	FindDueTasks(c context.Context, db *pgxpool.Pool, dueDate, remindedBefore time.Time) ([]domain.OnboardingTask, error)
+}
+
+type OnboardingQueryImpl struct {
+}
+
+func NewOnboarding() OnboardingQuery {
+	return &OnboardingQueryImpl{}
+}
+
+// the selected columns of the onboarding template. The order must match the 'scanOnboardingTemplate' function.
+const onboardingTemplateColumns = `
+	ot.id,
+	ot.name,
+	ot.description,
+	ot.department_id,
+	ot.position_id,
+	ot.created_at,
+	ot.updated_at,
+	ot.deleted_at`
+
+func scanOnboardingTemplate(row pgx.Row) (domain.OnboardingTemplate, error) {
+	var data domain.OnboardingTemplate
+	err := row.Scan(
+		&data.ID,
+		&data.Name,
+		&data.Description,
+		&data.DepartmentID,
+		&data.PositionID,
+		&data.CreatedAt,
+		&data.UpdatedAt,
+		&data.DeletedAt,
+	)
+
+	return data, err
+}
+
+// the selected columns of the onboarding, joined with the employee and counted from the tasks.
+// The order must match the 'scanOnboarding' function.
+const onboardingColumns = `
+	o.id,
+	o.employee_id,
+	o.template_id,
+	o.status,
+	o.started_at,
+	o.completed_at,
+	o.cancelled_at,
+	o.created_at,
+	o.updated_at,
+	e.employee_number,
+	u.name,
+	e.hire_date,
+	(SELECT COUNT(*) FROM onboarding_tasks AS t WHERE t.onboarding_id = o.id),
+	(SELECT COUNT(*) FROM onboarding_tasks AS t WHERE t.onboarding_id = o.id AND t.status <> 'pending'),
+	(SELECT COUNT(*) FROM onboarding_tasks AS t WHERE t.onboarding_id = o.id AND t.status = 'pending'
+		AND t.due_date < (now() AT TIME ZONE 'Asia/Jakarta')::date)`
+
+const onboardingJoins = `
+	JOIN employees AS e ON e.id = o.employee_id
+	JOIN users AS u ON u.id = e.user_id`
+
+func scanOnboarding(row pgx.Row) (domain.Onboarding, error) {
+	var data domain.Onboarding
+	err := row.Scan(
+		&data.ID,
+		&data.EmployeeID,
+		&data.TemplateID,
+		&data.Status,
+		&data.StartedAt,
+		&data.CompletedAt,
+		&data.CancelledAt,
+		&data.CreatedAt,
+		&data.UpdatedAt,
+		&data.EmployeeNumber,
+		&data.EmployeeName,
+		&data.HireDate,
+		&data.TotalTasks,
+		&data.CompletedTasks,
+		&data.OverdueTasks,
+	)
+
+	return data, err
+}
+
+// the selected columns of the onboarding task, joined with the onboarding, the new employee and the assignee.
+// The order must match the 'scanOnboardingTask' function.
+const onboardingTaskColumns = `
+	t.onboarding_id,
+	t.seq,
+	t.title,
+	t.description,
+	t.category,
+	t.assignee_role,
+	t.assignee_id,
+	t.due_date,
+	t.status,
+	t.note,
+	t.completed_by,
+	t.completed_at,
+	t.reminded_at,
+	COALESCE(au.name, ''),
+	ae.user_id,
+	o.employee_id,
+	e.employee_number,
+	u.name`
+
+const onboardingTaskJoins = `
+	JOIN onboardings AS o ON o.id = t.onboarding_id
+	JOIN employees AS e ON e.id = o.employee_id
+	JOIN users AS u ON u.id = e.user_id
+	LEFT JOIN employees AS ae ON ae.id = t.assignee_id
+	LEFT JOIN users AS au ON au.id = ae.user_id`
+
+func scanOnboardingTask(row pgx.Row) (domain.OnboardingTask, error) {
+	var data domain.OnboardingTask
+	err := row.Scan(
+		&data.OnboardingID,
+		&data.Seq,
+		&data.Title,
+		&data.Description,
+		&data.Category,
+		&data.AssigneeRole,
+		&data.AssigneeID,
+		&data.DueDate,
+		&data.Status,
+		&data.Note,
+		&data.CompletedBy,
+		&data.CompletedAt,
+		&data.RemindedAt,
+		&data.AssigneeName,
+		&data.AssigneeUserID,
+		&data.EmployeeID,
+		&data.EmployeeNumber,
+		&data.EmployeeName,
+	)
+
+	return data, err
+}
+
+func (repository *OnboardingQueryImpl) CreateTemplate(c context.Context, tx pgx.Tx, template domain.OnboardingTemplate) error {
+	// build INSERT query
+	query := `INSERT INTO onboarding_templates (
+		"id",
+		"name",
+		"description",
+		"department_id",
+		"position_id",
+		"created_at",
+		"updated_at"
+		) VALUES ($1,$2,$3,$4,$5,$6,$7)`
+
+	_, err := tx.Exec(c, query,
+		template.ID,
+		template.Name,
+		template.Description,
+		template.DepartmentID,
+		template.PositionID,
+		template.CreatedAt,
+		template.UpdatedAt,
+	)
+
+	return err
+}
+
+func (repository *OnboardingQueryImpl) UpdateTemplate(c context.Context, tx pgx.Tx, id string, template domain.OnboardingTemplate) error {
+	// build UPDATE query
+	query := `UPDATE onboarding_templates SET
+		name=$1,
+		description=$2,
+		department_id=$3,
+		position_id=$4,
+		updated_at=$5
+		WHERE id=$6`
+
+	_, err := tx.Exec(c, query,
+		template.Name,
+		template.Description,
+		template.DepartmentID,
+		template.PositionID,
+		template.UpdatedAt,
+		id,
+	)
+
+	return err
+}
+
+func (repository *OnboardingQueryImpl) DeleteTemplate(c context.Context, tx pgx.Tx, id string) error {
+	// build UPDATE query
+	query := `UPDATE onboarding_templates SET deleted_at=$1 WHERE id=$2`
+
+	_, err := tx.Exec(c, query, time.Now(), id)
+
+	return err
+}
+
+func (repository *OnboardingQueryImpl) CreateTemplateTask(c context.Context, tx pgx.Tx, task domain.OnboardingTemplateTask) error {
+	// build INSERT query
+	query := `INSERT INTO onboarding_template_tasks (
+		"template_id",
+		"seq",
+		"title",
+		"description",
+		"category",
+		"assignee_role",
+		"assignee_id",
+		"due_days"
+		) VALUES ($1,$2,$3,$4,$5,$6,$7,$8)`
+
+	_, err := tx.Exec(c, query,
+		task.TemplateID,
+		task.Seq,
+		task.Title,
+		task.Description,
+		task.Category,
+		task.AssigneeRole,
+		task.AssigneeID,
+		task.DueDays,
+	)
+
+	return err
+}
+
+// delete the tasks of the template, the tasks are replaced when the template is updated
+func (repository *OnboardingQueryImpl) DeleteTemplateTasks(c context.Context, tx pgx.Tx, templateID string) error {
+	query := `DELETE FROM onboarding_template_tasks WHERE template_id=$1`
+
+	_, err := tx.Exec(c, query, templateID)
+
+	return err
+}
+
+func (repository *OnboardingQueryImpl) FindAllTemplate(c context.Context, db *pgxpool.Pool) ([]domain.OnboardingTemplate, error) {
+	query := `SELECT ` + onboardingTemplateColumns + ` FROM onboarding_templates AS ot
+		WHERE ot.deleted_at IS NULL
+		ORDER BY ot.name`
+
+	rows, err := db.Query(c, query)
+	if err != nil {
+		return []domain.OnboardingTemplate{}, err
+	}
+	defer rows.Close()
+
+	var datas []domain.OnboardingTemplate
+	for rows.Next() {
+		data, err := scanOnboardingTemplate(rows)
+		if err != nil {
+			return []domain.OnboardingTemplate{}, err
+		}
+		datas = append(datas, data)
+	}
+
+	return datas, rows.Err()
+}
+
+func (repository *OnboardingQueryImpl) FindTemplateById(c context.Context, db *pgxpool.Pool, id string) (domain.OnboardingTemplate, error) {
+	query := `SELECT ` + onboardingTemplateColumns + ` FROM onboarding_templates AS ot WHERE ot.deleted_at IS NULL AND ot.id=$1`
+
+	return scanOnboardingTemplate(db.QueryRow(c, query, id))
+}
+
+func (repository *OnboardingQueryImpl) FindTemplateTasks(c context.Context, db *pgxpool.Pool, templateID string) ([]domain.OnboardingTemplateTask, error) {
+	query := `SELECT
+		template_id,
+		seq,
+		title,
+		description,
+		category,
+		assignee_role,
+		assignee_id,
+		due_days
+		FROM onboarding_template_tasks
+		WHERE template_id=$1
+		ORDER BY seq`
+
+	rows, err := db.Query(c, query, templateID)
+	if err != nil {
+		return []domain.OnboardingTemplateTask{}, err
+	}
+	defer rows.Close()
+
+	var datas []domain.OnboardingTemplateTask
+	for rows.Next() {
+		var data domain.OnboardingTemplateTask
+		err := rows.Scan(
+			&data.TemplateID,
+			&data.Seq,
+			&data.Title,
+			&data.Description,
+			&data.Category,
+			&data.AssigneeRole,
+			&data.AssigneeID,
+			&data.DueDays,
+		)
+		if err != nil {
+			return []domain.OnboardingTemplateTask{}, err
+		}
+		datas = append(datas, data)
+	}
+
+	return datas, rows.Err()
+}
+
+// find the template of the new employee, the template of the position comes first, then the template of the
+// department and then the default template which has no position and department. The latest template is used when
+// there are several templates of the same level.
+func (repository *OnboardingQueryImpl) FindTemplateForEmployee(c context.Context, db *pgxpool.Pool, departmentID, positionID *string) (domain.OnboardingTemplate, error) {
+	query := `SELECT ` + onboardingTemplateColumns + ` FROM onboarding_templates AS ot
+		WHERE ot.deleted_at IS NULL
+		AND (ot.position_id=$1 OR (ot.position_id IS NULL AND (ot.department_id=$2 OR ot.department_id IS NULL)))
+		ORDER BY ot.position_id IS NOT NULL DESC, ot.department_id IS NOT NULL DESC, ot.created_at DESC
+		LIMIT 1`
+
+	return scanOnboardingTemplate(db.QueryRow(c, query, positionID, departmentID))
+}
+
+func (repository *OnboardingQueryImpl) CreateOnboarding(c context.Context, tx pgx.Tx, onboarding domain.Onboarding) error {
+	// build INSERT query
+	query := `INSERT INTO onboardings (
+		"id",
+		"employee_id",
+		"template_id",
+		"status",
+		"started_at",
+		"created_at",
+		"updated_at"
+		) VALUES ($1,$2,$3,$4,$5,$6,$7)`
+
+	_, err := tx.Exec(c, query,
+		onboarding.ID,
+		onboarding.EmployeeID,
+		onboarding.TemplateID,
+		onboarding.Status,
+		onboarding.StartedAt,
+		onboarding.CreatedAt,
+		onboarding.UpdatedAt,
+	)
+
+	return err
+}
+
+// update the status of the onboarding when it is completed or cancelled
+func (repository *OnboardingQueryImpl) UpdateStatus(c context.Context, tx pgx.Tx, id string, onboarding domain.Onboarding) error {
+	// build UPDATE query
+	query := `UPDATE onboardings SET
+		status=$1,
+		completed_at=$2,
+		cancelled_at=$3,
+		updated_at=$4
+		WHERE id=$5`
+
+	_, err := tx.Exec(c, query,
+		onboarding.Status,
+		onboarding.CompletedAt,
+		onboarding.CancelledAt,
+		onboarding.UpdatedAt,
+		id,
+	)
+
+	return err
+}
+
+func (repository *OnboardingQueryImpl) CreateTask(c context.Context, tx pgx.Tx, task domain.OnboardingTask) error {
+	// build INSERT query
+	query := `INSERT INTO onboarding_tasks (
+		"onboarding_id",
+		"seq",
+		"title",
+		"description",
+		"category",
+		"assignee_role",
+		"assignee_id",
+		"due_date",
+		"status"
+		) VALUES ($1,$2,$3,$4,$5,$6,$7,$8,$9)`
+
+	_, err := tx.Exec(c, query,
+		task.OnboardingID,
+		task.Seq,
+		task.Title,
+		task.Description,
+		task.Category,
+		task.AssigneeRole,
+		task.AssigneeID,
+		task.DueDate,
+		task.Status,
+	)
+
+	return err
+}
+
+// update the progress of the task
+func (repository *OnboardingQueryImpl) UpdateTask(c context.Context, tx pgx.Tx, task domain.OnboardingTask) error {
+	// build UPDATE query
+	query := `UPDATE onboarding_tasks SET
+		status=$1,
+		note=$2,
+		completed_by=$3,
+		completed_at=$4
+		WHERE onboarding_id=$5 AND seq=$6`
+
+	_, err := tx.Exec(c, query,
+		task.Status,
+		task.Note,
+		task.CompletedBy,
+		task.CompletedAt,
+		task.OnboardingID,
+		task.Seq,
+	)
+
+	return err
+}
+
+// mark the task as reminded, so the assignee is not reminded again on the same day
+func (repository *OnboardingQueryImpl) UpdateTaskReminded(c context.Context, tx pgx.Tx, task domain.OnboardingTask) error {
+	// build UPDATE query
+	query := `UPDATE onboarding_tasks SET reminded_at=$1 WHERE onboarding_id=$2 AND seq=$3`
+
+	_, err := tx.Exec(c, query, task.RemindedAt, task.OnboardingID, task.Seq)
+
+	return err
+}
+
+func (repository *OnboardingQueryImpl) FindAllOnboarding(c context.Context, db *pgxpool.Pool, filter domain.OnboardingQueryFilter) ([]domain.Onboarding, error) {
+	// onboarding query filter builders
+	filterString, args, pagination := filter.BuildOnboardingQueries()
+
+	query := fmt.Sprintf(
+		`SELECT %s
+		FROM onboardings AS o
+		%s
+		%s
+		ORDER BY o.started_at DESC
+		%s`,
+		onboardingColumns, onboardingJoins, filterString, pagination,
+	)
+	rows, err := db.Query(c, query, args...)
+	if err != nil {
+		return []domain.Onboarding{}, err
+	}
+	defer rows.Close()
+
+	var datas []domain.Onboarding
+	for rows.Next() {
+		data, err := scanOnboarding(rows)
+		if err != nil {
+			return []domain.Onboarding{}, err
+		}
+		datas = append(datas, data)
+	}
+
+	return datas, rows.Err()
+}
+
+func (repository *OnboardingQueryImpl) CountAllOnboarding(c context.Context, db *pgxpool.Pool, filter domain.OnboardingQueryFilter) (int, error) {
+	// onboarding query filter builders
+	filterString, args, _ := filter.BuildOnboardingQueries()
+
+	query := fmt.Sprintf(`SELECT COUNT(*) FROM onboardings AS o JOIN employees AS e ON e.id = o.employee_id %s`, filterString)
+
+	var count int
+	err := db.QueryRow(c, query, args...).Scan(&count)
+	if err != nil {
+		return 0, err
+	}
+
+	return count, err
+}
+
+func (repository *OnboardingQueryImpl) FindById(c context.Context, db *pgxpool.Pool, id string) (domain.Onboarding, error) {
+	query := `SELECT ` + onboardingColumns + ` FROM onboardings AS o ` + onboardingJoins + ` WHERE o.id=$1`
+
+	return scanOnboarding(db.QueryRow(c, query, id))
+}
+
+func (repository *OnboardingQueryImpl) FindByEmployee(c context.Context, db *pgxpool.Pool, employeeID string) (domain.Onboarding, error) {
+	query := `SELECT ` + onboardingColumns + ` FROM onboardings AS o ` + onboardingJoins + ` WHERE o.employee_id=$1`
+
+	return scanOnboarding(db.QueryRow(c, query, employeeID))
+}
+
+func (repository *OnboardingQueryImpl) FindTasks(c context.Context, db *pgxpool.Pool, onboardingID string) ([]domain.OnboardingTask, error) {
+	query := `SELECT ` + onboardingTaskColumns + ` FROM onboarding_tasks AS t ` + onboardingTaskJoins + `
+		WHERE t.onboarding_id=$1
+		ORDER BY t.seq`
+
+	return findOnboardingTasks(c, db, query, onboardingID)
+}
+
+// find the tasks assigned to the employee on the onboardings in progress, the nearest due date first
+func (repository *OnboardingQueryImpl) FindTasksByAssignee(c context.Context, db *pgxpool.Pool, assigneeID string) ([]domain.OnboardingTask, error) {
+	query := `SELECT ` + onboardingTaskColumns + ` FROM onboarding_tasks AS t ` + onboardingTaskJoins + `
+		WHERE t.assignee_id=$1 AND o.status='in_progress'
+		ORDER BY t.status <> 'pending', t.due_date, t.seq`
+
+	return findOnboardingTasks(c, db, query, assigneeID)
+}
+
+// find the pending tasks of the onboardings in progress that are due on or before the due date, the tasks which
+// the assignee has been reminded since the start of the day (remindedBefore) are skipped
+func (repository *OnboardingQueryImpl) FindDueTasks(c context.Context, db *pgxpool.Pool, dueDate, remindedBefore time.Time) ([]domain.OnboardingTask, error) {
+	query := `SELECT ` + onboardingTaskColumns + ` FROM onboarding_tasks AS t ` + onboardingTaskJoins + `
+		WHERE o.status='in_progress' AND t.status='pending' AND t.assignee_id IS NOT NULL AND t.due_date <= $1
+		AND (t.reminded_at IS NULL OR t.reminded_at < $2)
+		ORDER BY t.due_date, o.id, t.seq`
+
+	return findOnboardingTasks(c, db, query, dueDate, remindedBefore)
+}
+
+func findOnboardingTasks(c context.Context, db *pgxpool.Pool, query string, args ...interface{}) ([]domain.OnboardingTask, error) {
+	rows, err := db.Query(c, query, args...)
+	if err != nil {
+		return []domain.OnboardingTask{}, err
+	}
+	defer rows.Close()
+
+	var datas []domain.OnboardingTask
+	for rows.Next() {
+		data, err := scanOnboardingTask(rows)
+		if err != nil {
+			return []domain.OnboardingTask{}, err
+		}
+		datas = append(datas, data)
+	}
+
+	return datas, rows.Err()
+}
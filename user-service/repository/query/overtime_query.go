@@ -0,0 +1,222 @@
+package query
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/iqbaludinm/hr-microservice/user-service/model/domain"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type OvertimeQuery interface {
+	CreateOvertime(c context.Context, tx pgx.Tx, overtime domain.Overtime) error
+	UpdateStatus(c context.Context, tx pgx.Tx, id string, overtime domain.Overtime, status string) error
+	FindAllOvertime(c context.Context, db *pgxpool.Pool, filter domain.OvertimeQueryFilter) ([]domain.Overtime, error)
+	CountAllOvertime(c context.Context, db *pgxpool.Pool, filter domain.OvertimeQueryFilter) (int, error)
+	FindById(c context.Context, db *pgxpool.Pool, id string) (domain.Overtime, error)
+	SumHours(c context.Context, db *pgxpool.Pool, employeeID string, from, to time.Time) (float64, error)
+}
+
+type OvertimeQueryImpl struct {
+}
+
+func NewOvertime() OvertimeQuery {
+	return &OvertimeQueryImpl{}
+}
+
+// the selected columns of the overtime request, joined with the checked out attendance of the date, the employee
+// and the approver. The order must match the 'scanOvertime' function.
+const overtimeColumns = `
+	o.id,
+	o.employee_id,
+	o.date,
+	o.hours,
+	o.reason,
+	o.working_day,
+	o.status,
+	o.approver_id,
+	o.decision_note,
+	o.decided_at,
+	o.cancelled_at,
+	o.created_at,
+	o.updated_at,
+	a.overtime_minutes,
+	a.work_minutes,
+	e.employee_number,
+	u.name,
+	u.id,
+	COALESCE(d.id::text, ''),
+	COALESCE(d.name, ''),
+	COALESCE(au.name, '')`
+
+const overtimeJoins = `
+	LEFT JOIN attendances AS a ON a.employee_id = o.employee_id AND a.date = o.date AND a.check_out_at IS NOT NULL
+	JOIN employees AS e ON e.id = o.employee_id
+	JOIN users AS u ON u.id = e.user_id
+	LEFT JOIN departments AS d ON d.id = e.department_id
+	LEFT JOIN employees AS ap ON ap.id = o.approver_id
+	LEFT JOIN users AS au ON au.id = ap.user_id`
+
+func scanOvertime(row pgx.Row) (domain.Overtime, error) {
+	var data domain.Overtime
+	err := row.Scan(
+		&data.ID,
+		&data.EmployeeID,
+		&data.Date,
+		&data.Hours,
+		&data.Reason,
+		&data.WorkingDay,
+		&data.Status,
+		&data.ApproverID,
+		&data.DecisionNote,
+		&data.DecidedAt,
+		&data.CancelledAt,
+		&data.CreatedAt,
+		&data.UpdatedAt,
+		&data.AttendanceOvertimeMinutes,
+		&data.AttendanceWorkMinutes,
+		&data.EmployeeNumber,
+		&data.EmployeeName,
+		&data.EmployeeUserID,
+		&data.DepartmentID,
+		&data.DepartmentName,
+		&data.ApproverName,
+	)
+
+	return data, err
+}
+
+func (repository *OvertimeQueryImpl) CreateOvertime(c context.Context, tx pgx.Tx, overtime domain.Overtime) error {
+	// build INSERT query
+	query := `INSERT INTO overtime_requests (
+		"id",
+		"employee_id",
+		"date",
+		"hours",
+		"reason",
+		"working_day",
+		"status",
+		"approver_id",
+		"created_at",
+		"updated_at"
+		) VALUES ($1,$2,$3,$4,$5,$6,$7,$8,$9,$10)`
+
+	_, err := tx.Exec(c, query,
+		overtime.ID,
+		overtime.EmployeeID,
+		overtime.Date,
+		overtime.Hours,
+		overtime.Reason,
+		overtime.WorkingDay,
+		overtime.Status,
+		overtime.ApproverID,
+		overtime.CreatedAt,
+		overtime.UpdatedAt,
+	)
+
+	return err
+}
+
+// update the status of the overtime request with the decision (approve, reject) or the cancellation.
+// The overtime request is only updated while it still has the given status, otherwise 'pgx.ErrNoRows' is returned.
+func (repository *OvertimeQueryImpl) UpdateStatus(c context.Context, tx pgx.Tx, id string, overtime domain.Overtime, status string) error {
+	// build UPDATE query
+	query := `UPDATE overtime_requests SET
+		status=$1,
+		decision_note=$2,
+		decided_at=$3,
+		cancelled_at=$4,
+		updated_at=$5
+		WHERE id=$6 AND status=$7`
+
+	tag, err := tx.Exec(c, query,
+		overtime.Status,
+		overtime.DecisionNote,
+		overtime.DecidedAt,
+		overtime.CancelledAt,
+		overtime.UpdatedAt,
+		id,
+		status,
+	)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return pgx.ErrNoRows
+	}
+
+	return nil
+}
+
+func (repository *OvertimeQueryImpl) FindAllOvertime(c context.Context, db *pgxpool.Pool, filter domain.OvertimeQueryFilter) ([]domain.Overtime, error) {
+	// overtime query filter builders
+	filterString, args, pagination := filter.BuildOvertimeQueries()
+
+	query := fmt.Sprintf(
+		`SELECT %s
+		FROM overtime_requests AS o
+		%s
+		%s
+		ORDER BY o.date DESC, u.name
+		%s`,
+		overtimeColumns, overtimeJoins, filterString, pagination,
+	)
+	rows, err := db.Query(c, query, args...)
+	if err != nil {
+		return []domain.Overtime{}, err
+	}
+	defer rows.Close()
+
+	var datas []domain.Overtime
+	for rows.Next() {
+		data, err := scanOvertime(rows)
+		if err != nil {
+			return []domain.Overtime{}, err
+		}
+		datas = append(datas, data)
+	}
+
+	return datas, rows.Err()
+}
+
+func (repository *OvertimeQueryImpl) CountAllOvertime(c context.Context, db *pgxpool.Pool, filter domain.OvertimeQueryFilter) (int, error) {
+	// overtime query filter builders
+	filterString, args, _ := filter.BuildOvertimeQueries()
+
+	query := fmt.Sprintf(`SELECT COUNT(*) FROM overtime_requests AS o JOIN employees AS e ON e.id = o.employee_id %s`, filterString)
+
+	var count int
+	err := db.QueryRow(c, query, args...).Scan(&count)
+	if err != nil {
+		return 0, err
+	}
+
+	return count, err
+}
+
+func (repository *OvertimeQueryImpl) FindById(c context.Context, db *pgxpool.Pool, id string) (domain.Overtime, error) {
+	query := fmt.Sprintf(`SELECT %s FROM overtime_requests AS o %s WHERE o.id=$1`, overtimeColumns, overtimeJoins)
+
+	return scanOvertime(db.QueryRow(c, query, id))
+}
+
+// sum the hours of the pending and approved overtime requests of the employee between the dates (inclusive)
+func (repository *OvertimeQueryImpl) SumHours(c context.Context, db *pgxpool.Pool, employeeID string, from, to time.Time) (float64, error) {
+	query := `SELECT COALESCE(SUM(hours), 0)
+		FROM overtime_requests
+		WHERE
+			employee_id=$1 AND
+			status IN ('pending', 'approved') AND
+			date >= $2 AND
+			date <= $3`
+
+	var hours float64
+	err := db.QueryRow(c, query, employeeID, from, to).Scan(&hours)
+	if err != nil {
+		return 0, err
+	}
+
+	return hours, err
+}
@@ -0,0 +1,554 @@
+package query
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/iqbaludinm/hr-microservice/user-service/model/domain"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type PayrollQuery interface {
+	CreateRun(c context.Context, tx pgx.Tx, run domain.PayrollRun) error
+	UpdateRun(c context.Context, tx pgx.Tx, id string, run domain.PayrollRun, status string) error
+	DeleteRun(c context.Context, tx pgx.Tx, id string, status string) error
+	CreatePayslip(c context.Context, tx pgx.Tx, payslip domain.Payslip) error
+	CreatePayslipItem(c context.Context, tx pgx.Tx, item domain.PayslipItem) error
+	DeletePayslips(c context.Context, tx pgx.Tx, runID string) error
+	UpdatePayslipDocument(c context.Context, tx pgx.Tx, id string, payslip domain.Payslip) error
+	FindAllRun(c context.Context, db *pgxpool.Pool, filter domain.PayrollRunQueryFilter) ([]domain.PayrollRun, error)
+	CountAllRun(c context.Context, db *pgxpool.Pool, filter domain.PayrollRunQueryFilter) (int, error)
+	FindRunById(c context.Context, db *pgxpool.Pool, id string) (domain.PayrollRun, error)
+	CountUnlockedRuns(c context.Context, db *pgxpool.Pool, year, month int) (int, error)
+	FindAllPayslip(c context.Context, db *pgxpool.Pool, runID string) ([]domain.Payslip, error)
+	FindPayslipById(c context.Context, db *pgxpool.Pool, runID, id string) (domain.Payslip, error)
+	FindAllPayslipByEmployee(c context.Context, db *pgxpool.Pool, employeeID string, year int) ([]domain.Payslip, error)
+	FindPayslipByEmployee(c context.Context, db *pgxpool.Pool, employeeID, id string) (domain.Payslip, error)
+	FindPayslipItems(c context.Context, db *pgxpool.Pool, payslipID string) ([]domain.PayslipItem, error)
+	FindYearToDate(c context.Context, db *pgxpool.Pool, year, month int) ([]domain.PayslipYearToDate, error)
+}
+
+type PayrollQueryImpl struct {
+}
+
+func NewPayroll() PayrollQuery {
+	return &PayrollQueryImpl{}
+}
+
+// the selected columns of the payroll run. The order must match the 'scanPayrollRun' function.
+const payrollRunColumns = `
+	id,
+	year,
+	month,
+	period_start,
+	period_end,
+	status,
+	employee_count,
+	total_gross,
+	total_deductions,
+	total_net,
+	total_employer_contributions,
+	calculated_at,
+	created_by,
+	reviewed_by,
+	reviewed_at,
+	locked_by,
+	locked_at,
+	created_at,
+	updated_at`
+
+func scanPayrollRun(row pgx.Row) (domain.PayrollRun, error) {
+	var data domain.PayrollRun
+	err := row.Scan(
+		&data.ID,
+		&data.Year,
+		&data.Month,
+		&data.PeriodStart,
+		&data.PeriodEnd,
+		&data.Status,
+		&data.EmployeeCount,
+		&data.TotalGross,
+		&data.TotalDeductions,
+		&data.TotalNet,
+		&data.TotalEmployerContributions,
+		&data.CalculatedAt,
+		&data.CreatedBy,
+		&data.ReviewedBy,
+		&data.ReviewedAt,
+		&data.LockedBy,
+		&data.LockedAt,
+		&data.CreatedAt,
+		&data.UpdatedAt,
+	)
+
+	return data, err
+}
+
+// the selected columns of the payslip, joined with the 'payroll_runs', 'employees', 'users' and 'departments' table.
+// The order must match the 'scanPayslip' function.
+const payslipColumns = `
+	ps.id,
+	ps.payroll_run_id,
+	ps.employee_id,
+	ps.base_salary,
+	ps.allowances,
+	ps.tax_status,
+	ps.ter_category,
+	ps.working_days,
+	ps.overtime_hours,
+	ps.unpaid_leave_days,
+	ps.reimbursement,
+	ps.gross_pay,
+	ps.total_deductions,
+	ps.net_pay,
+	ps.employer_contributions,
+	ps.taxable_income,
+	ps.deductible_contributions,
+	ps.income_tax,
+	ps.created_at,
+	ps.document_key,
+	ps.document_generated_at,
+	ps.emailed_at,
+	r.year,
+	r.month,
+	r.status,
+	e.employee_number,
+	u.name,
+	d.name`
+
+// the joined tables of the payslip, used together with the 'payslipColumns'
+const payslipJoins = `
+	JOIN payroll_runs AS r ON r.id = ps.payroll_run_id
+	JOIN employees AS e ON e.id = ps.employee_id
+	JOIN users AS u ON u.id = e.user_id
+	JOIN departments AS d ON d.id = e.department_id`
+
+func scanPayslip(row pgx.Row) (domain.Payslip, error) {
+	var data domain.Payslip
+	err := row.Scan(
+		&data.ID,
+		&data.PayrollRunID,
+		&data.EmployeeID,
+		&data.BaseSalary,
+		&data.Allowances,
+		&data.TaxStatus,
+		&data.TerCategory,
+		&data.WorkingDays,
+		&data.OvertimeHours,
+		&data.UnpaidLeaveDays,
+		&data.Reimbursement,
+		&data.GrossPay,
+		&data.TotalDeductions,
+		&data.NetPay,
+		&data.EmployerContributions,
+		&data.TaxableIncome,
+		&data.DeductibleContributions,
+		&data.IncomeTax,
+		&data.CreatedAt,
+		&data.DocumentKey,
+		&data.DocumentGeneratedAt,
+		&data.EmailedAt,
+		&data.Year,
+		&data.Month,
+		&data.RunStatus,
+		&data.EmployeeNumber,
+		&data.EmployeeName,
+		&data.DepartmentName,
+	)
+
+	return data, err
+}
+
+func (repository *PayrollQueryImpl) CreateRun(c context.Context, tx pgx.Tx, run domain.PayrollRun) error {
+	// build INSERT query
+	query := `INSERT INTO payroll_runs (
+		"id",
+		"year",
+		"month",
+		"period_start",
+		"period_end",
+		"status",
+		"employee_count",
+		"total_gross",
+		"total_deductions",
+		"total_net",
+		"total_employer_contributions",
+		"calculated_at",
+		"created_by",
+		"created_at",
+		"updated_at"
+		) VALUES ($1,$2,$3,$4,$5,$6,$7,$8,$9,$10,$11,$12,$13,$14,$15)`
+
+	_, err := tx.Exec(c, query,
+		run.ID,
+		run.Year,
+		run.Month,
+		run.PeriodStart,
+		run.PeriodEnd,
+		run.Status,
+		run.EmployeeCount,
+		run.TotalGross,
+		run.TotalDeductions,
+		run.TotalNet,
+		run.TotalEmployerContributions,
+		run.CalculatedAt,
+		run.CreatedBy,
+		run.CreatedAt,
+		run.UpdatedAt,
+	)
+
+	return err
+}
+
+// update the payroll run which still has the given status, otherwise 'pgx.ErrNoRows' is returned. The row stays locked
+// until the transaction ends, so the run can't be changed by another request in the meantime.
+func (repository *PayrollQueryImpl) UpdateRun(c context.Context, tx pgx.Tx, id string, run domain.PayrollRun, status string) error {
+	// build UPDATE query
+	query := `UPDATE payroll_runs SET
+		status=$1,
+		employee_count=$2,
+		total_gross=$3,
+		total_deductions=$4,
+		total_net=$5,
+		total_employer_contributions=$6,
+		calculated_at=$7,
+		reviewed_by=$8,
+		reviewed_at=$9,
+		locked_by=$10,
+		locked_at=$11,
+		updated_at=$12
+		WHERE id=$13 AND status=$14`
+
+	tag, err := tx.Exec(c, query,
+		run.Status,
+		run.EmployeeCount,
+		run.TotalGross,
+		run.TotalDeductions,
+		run.TotalNet,
+		run.TotalEmployerContributions,
+		run.CalculatedAt,
+		run.ReviewedBy,
+		run.ReviewedAt,
+		run.LockedBy,
+		run.LockedAt,
+		run.UpdatedAt,
+		id,
+		status,
+	)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return pgx.ErrNoRows
+	}
+
+	return nil
+}
+
+// delete the payroll run which still has the given status, the payslips and their items are deleted by the cascade.
+// 'pgx.ErrNoRows' is returned when the run has another status.
+func (repository *PayrollQueryImpl) DeleteRun(c context.Context, tx pgx.Tx, id string, status string) error {
+	query := `DELETE FROM payroll_runs WHERE id=$1 AND status=$2`
+
+	tag, err := tx.Exec(c, query, id, status)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return pgx.ErrNoRows
+	}
+
+	return nil
+}
+
+func (repository *PayrollQueryImpl) CreatePayslip(c context.Context, tx pgx.Tx, payslip domain.Payslip) error {
+	allowances, err := json.Marshal(payslip.Allowances)
+	if err != nil {
+		return err
+	}
+
+	// build INSERT query
+	query := `INSERT INTO payslips (
+		"id",
+		"payroll_run_id",
+		"employee_id",
+		"base_salary",
+		"allowances",
+		"tax_status",
+		"ter_category",
+		"working_days",
+		"overtime_hours",
+		"unpaid_leave_days",
+		"reimbursement",
+		"gross_pay",
+		"total_deductions",
+		"net_pay",
+		"employer_contributions",
+		"taxable_income",
+		"deductible_contributions",
+		"income_tax",
+		"created_at"
+		) VALUES ($1,$2,$3,$4,$5::jsonb,$6,$7,$8,$9,$10,$11,$12,$13,$14,$15,$16,$17,$18,$19)`
+
+	_, err = tx.Exec(c, query,
+		payslip.ID,
+		payslip.PayrollRunID,
+		payslip.EmployeeID,
+		payslip.BaseSalary,
+		string(allowances),
+		payslip.TaxStatus,
+		payslip.TerCategory,
+		payslip.WorkingDays,
+		payslip.OvertimeHours,
+		payslip.UnpaidLeaveDays,
+		payslip.Reimbursement,
+		payslip.GrossPay,
+		payslip.TotalDeductions,
+		payslip.NetPay,
+		payslip.EmployerContributions,
+		payslip.TaxableIncome,
+		payslip.DeductibleContributions,
+		payslip.IncomeTax,
+		payslip.CreatedAt,
+	)
+
+	return err
+}
+
+func (repository *PayrollQueryImpl) CreatePayslipItem(c context.Context, tx pgx.Tx, item domain.PayslipItem) error {
+	// build INSERT query
+	query := `INSERT INTO payslip_items (
+		"payslip_id",
+		"seq",
+		"code",
+		"name",
+		"category",
+		"quantity",
+		"amount"
+		) VALUES ($1,$2,$3,$4,$5,$6,$7)`
+
+	_, err := tx.Exec(c, query,
+		item.PayslipID,
+		item.Seq,
+		item.Code,
+		item.Name,
+		item.Category,
+		item.Quantity,
+		item.Amount,
+	)
+
+	return err
+}
+
+// delete the payslips of the run before it is recalculated, the items are deleted by the cascade
+func (repository *PayrollQueryImpl) DeletePayslips(c context.Context, tx pgx.Tx, runID string) error {
+	query := `DELETE FROM payslips WHERE payroll_run_id=$1`
+
+	_, err := tx.Exec(c, query, runID)
+
+	return err
+}
+
+// update the generated pdf of the payslip and the time it is emailed
+func (repository *PayrollQueryImpl) UpdatePayslipDocument(c context.Context, tx pgx.Tx, id string, payslip domain.Payslip) error {
+	// build UPDATE query
+	query := `UPDATE payslips SET
+		document_key=$1,
+		document_generated_at=$2,
+		emailed_at=$3
+		WHERE id=$4`
+
+	_, err := tx.Exec(c, query,
+		payslip.DocumentKey,
+		payslip.DocumentGeneratedAt,
+		payslip.EmailedAt,
+		id,
+	)
+
+	return err
+}
+
+func (repository *PayrollQueryImpl) FindAllRun(c context.Context, db *pgxpool.Pool, filter domain.PayrollRunQueryFilter) ([]domain.PayrollRun, error) {
+	// payroll run query filter builders
+	filterString, args, pagination := filter.BuildPayrollRunQueries()
+
+	query := fmt.Sprintf(
+		`SELECT %s
+		FROM payroll_runs
+		%s
+		ORDER BY year DESC, month DESC
+		%s`,
+		payrollRunColumns, filterString, pagination,
+	)
+	rows, err := db.Query(c, query, args...)
+	if err != nil {
+		return []domain.PayrollRun{}, err
+	}
+	defer rows.Close()
+
+	var datas []domain.PayrollRun
+	for rows.Next() {
+		data, err := scanPayrollRun(rows)
+		if err != nil {
+			return []domain.PayrollRun{}, err
+		}
+		datas = append(datas, data)
+	}
+
+	return datas, rows.Err()
+}
+
+func (repository *PayrollQueryImpl) CountAllRun(c context.Context, db *pgxpool.Pool, filter domain.PayrollRunQueryFilter) (int, error) {
+	// payroll run query filter builders
+	filterString, args, _ := filter.BuildPayrollRunQueries()
+
+	query := fmt.Sprintf(`SELECT COUNT(*) FROM payroll_runs %s`, filterString)
+
+	var count int
+	err := db.QueryRow(c, query, args...).Scan(&count)
+	if err != nil {
+		return 0, err
+	}
+
+	return count, err
+}
+
+func (repository *PayrollQueryImpl) FindRunById(c context.Context, db *pgxpool.Pool, id string) (domain.PayrollRun, error) {
+	query := `SELECT ` + payrollRunColumns + ` FROM payroll_runs WHERE id=$1`
+
+	return scanPayrollRun(db.QueryRow(c, query, id))
+}
+
+// count the runs of the year before the month that are not locked yet
+func (repository *PayrollQueryImpl) CountUnlockedRuns(c context.Context, db *pgxpool.Pool, year, month int) (int, error) {
+	query := `SELECT COUNT(*) FROM payroll_runs WHERE year=$1 AND month<$2 AND status<>'locked'`
+
+	var count int
+	err := db.QueryRow(c, query, year, month).Scan(&count)
+	if err != nil {
+		return 0, err
+	}
+
+	return count, err
+}
+
+func (repository *PayrollQueryImpl) FindAllPayslip(c context.Context, db *pgxpool.Pool, runID string) ([]domain.Payslip, error) {
+	query := `SELECT ` + payslipColumns + ` FROM payslips AS ps ` + payslipJoins + `
+		WHERE ps.payroll_run_id=$1
+		ORDER BY e.employee_number`
+
+	return findPayslips(c, db, query, runID)
+}
+
+// find the payslips of the employee in the locked runs, the payslips of the runs that are not locked yet are
+// never shown to the employee
+func (repository *PayrollQueryImpl) FindAllPayslipByEmployee(c context.Context, db *pgxpool.Pool, employeeID string, year int) ([]domain.Payslip, error) {
+	query := `SELECT ` + payslipColumns + ` FROM payslips AS ps ` + payslipJoins + `
+		WHERE ps.employee_id=$1 AND r.status='locked' AND ($2=0 OR r.year=$2)
+		ORDER BY r.year DESC, r.month DESC`
+
+	return findPayslips(c, db, query, employeeID, year)
+}
+
+func (repository *PayrollQueryImpl) FindPayslipByEmployee(c context.Context, db *pgxpool.Pool, employeeID, id string) (domain.Payslip, error) {
+	query := `SELECT ` + payslipColumns + ` FROM payslips AS ps ` + payslipJoins + `
+		WHERE ps.employee_id=$1 AND ps.id=$2 AND r.status='locked'`
+
+	return scanPayslip(db.QueryRow(c, query, employeeID, id))
+}
+
+func findPayslips(c context.Context, db *pgxpool.Pool, query string, args ...interface{}) ([]domain.Payslip, error) {
+	rows, err := db.Query(c, query, args...)
+	if err != nil {
+		return []domain.Payslip{}, err
+	}
+	defer rows.Close()
+
+	var datas []domain.Payslip
+	for rows.Next() {
+		data, err := scanPayslip(rows)
+		if err != nil {
+			return []domain.Payslip{}, err
+		}
+		datas = append(datas, data)
+	}
+
+	return datas, rows.Err()
+}
+
+func (repository *PayrollQueryImpl) FindPayslipById(c context.Context, db *pgxpool.Pool, runID, id string) (domain.Payslip, error) {
+	query := `SELECT ` + payslipColumns + ` FROM payslips AS ps ` + payslipJoins + `
+		WHERE ps.payroll_run_id=$1 AND ps.id=$2`
+
+	return scanPayslip(db.QueryRow(c, query, runID, id))
+}
+
+func (repository *PayrollQueryImpl) FindPayslipItems(c context.Context, db *pgxpool.Pool, payslipID string) ([]domain.PayslipItem, error) {
+	query := `SELECT payslip_id, seq, code, name, category, quantity, amount FROM payslip_items WHERE payslip_id=$1 ORDER BY seq`
+
+	rows, err := db.Query(c, query, payslipID)
+	if err != nil {
+		return []domain.PayslipItem{}, err
+	}
+	defer rows.Close()
+
+	var datas []domain.PayslipItem
+	for rows.Next() {
+		var data domain.PayslipItem
+		err := rows.Scan(
+			&data.PayslipID,
+			&data.Seq,
+			&data.Code,
+			&data.Name,
+			&data.Category,
+			&data.Quantity,
+			&data.Amount,
+		)
+		if err != nil {
+			return []domain.PayslipItem{}, err
+		}
+		datas = append(datas, data)
+	}
+
+	return datas, rows.Err()
+}
+
+// sum the payslips of every employee in the locked runs of the year before the month
+func (repository *PayrollQueryImpl) FindYearToDate(c context.Context, db *pgxpool.Pool, year, month int) ([]domain.PayslipYearToDate, error) {
+	query := `SELECT
+		ps.employee_id,
+		COUNT(*),
+		SUM(ps.taxable_income),
+		SUM(ps.deductible_contributions),
+		SUM(ps.income_tax)
+		FROM payslips AS ps
+		JOIN payroll_runs AS r ON r.id = ps.payroll_run_id
+		WHERE r.year=$1 AND r.month<$2 AND r.status='locked'
+		GROUP BY ps.employee_id`
+
+	rows, err := db.Query(c, query, year, month)
+	if err != nil {
+		return []domain.PayslipYearToDate{}, err
+	}
+	defer rows.Close()
+
+	var datas []domain.PayslipYearToDate
+	for rows.Next() {
+		var data domain.PayslipYearToDate
+		err := rows.Scan(
+			&data.EmployeeID,
+			&data.Months,
+			&data.TaxableIncome,
+			&data.DeductibleContributions,
+			&data.IncomeTax,
+		)
+		if err != nil {
+			return []domain.PayslipYearToDate{}, err
+		}
+		datas = append(datas, data)
+	}
+
+	return datas, rows.Err()
+}
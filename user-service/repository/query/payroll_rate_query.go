@@ -0,0 +1,168 @@
+package query
+
+import (
+	"context"
+	"time"
+
+	"github.com/iqbaludinm/hr-microservice/user-service/model/domain"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type PayrollRateQuery interface {
+	CreateBpjsRate(c context.Context, tx pgx.Tx, rate domain.BpjsRate) error
+	FindBpjsRates(c context.Context, db *pgxpool.Pool, date time.Time) ([]domain.BpjsRate, error)
+	FindPtkpRates(c context.Context, db *pgxpool.Pool, date time.Time) ([]domain.PtkpRate, error)
+	FindTerRates(c context.Context, db *pgxpool.Pool, date time.Time) ([]domain.TerRate, error)
+	FindTaxRates(c context.Context, db *pgxpool.Pool, date time.Time) ([]domain.TaxRate, error)
+}
+
+type PayrollRateQueryImpl struct {
+}
+
+func NewPayrollRate() PayrollRateQuery {
+	return &PayrollRateQueryImpl{}
+}
+
+func (repository *PayrollRateQueryImpl) CreateBpjsRate(c context.Context, tx pgx.Tx, rate domain.BpjsRate) error {
+	// build INSERT query
+	query := `INSERT INTO bpjs_rates (
+		"id",
+		"program",
+		"employee_rate",
+		"employer_rate",
+		"wage_cap",
+		"employer_taxable",
+		"employee_deductible",
+		"effective_from",
+		"created_at"
+		) VALUES ($1,$2,$3,$4,$5,$6,$7,$8,$9)`
+
+	_, err := tx.Exec(c, query,
+		rate.ID,
+		rate.Program,
+		rate.EmployeeRate,
+		rate.EmployerRate,
+		rate.WageCap,
+		rate.EmployerTaxable,
+		rate.EmployeeDeductible,
+		rate.EffectiveFrom,
+		rate.CreatedAt,
+	)
+
+	return err
+}
+
+// find the latest rate of every BPJS program effective on the date
+func (repository *PayrollRateQueryImpl) FindBpjsRates(c context.Context, db *pgxpool.Pool, date time.Time) ([]domain.BpjsRate, error) {
+	query := `SELECT DISTINCT ON (program)
+		id, program, employee_rate, employer_rate, wage_cap, employer_taxable, employee_deductible, effective_from, created_at
+		FROM bpjs_rates
+		WHERE effective_from <= $1
+		ORDER BY program, effective_from DESC`
+
+	rows, err := db.Query(c, query, date)
+	if err != nil {
+		return []domain.BpjsRate{}, err
+	}
+	defer rows.Close()
+
+	var datas []domain.BpjsRate
+	for rows.Next() {
+		var data domain.BpjsRate
+		err := rows.Scan(
+			&data.ID,
+			&data.Program,
+			&data.EmployeeRate,
+			&data.EmployerRate,
+			&data.WageCap,
+			&data.EmployerTaxable,
+			&data.EmployeeDeductible,
+			&data.EffectiveFrom,
+			&data.CreatedAt,
+		)
+		if err != nil {
+			return []domain.BpjsRate{}, err
+		}
+		datas = append(datas, data)
+	}
+
+	return datas, rows.Err()
+}
+
+// find the latest PTKP of every tax status effective on the date
+func (repository *PayrollRateQueryImpl) FindPtkpRates(c context.Context, db *pgxpool.Pool, date time.Time) ([]domain.PtkpRate, error) {
+	query := `SELECT DISTINCT ON (tax_status) tax_status, amount, ter_category, effective_from
+		FROM ptkp_rates
+		WHERE effective_from <= $1
+		ORDER BY tax_status, effective_from DESC`
+
+	rows, err := db.Query(c, query, date)
+	if err != nil {
+		return []domain.PtkpRate{}, err
+	}
+	defer rows.Close()
+
+	var datas []domain.PtkpRate
+	for rows.Next() {
+		var data domain.PtkpRate
+		if err := rows.Scan(&data.TaxStatus, &data.Amount, &data.TerCategory, &data.EffectiveFrom); err != nil {
+			return []domain.PtkpRate{}, err
+		}
+		datas = append(datas, data)
+	}
+
+	return datas, rows.Err()
+}
+
+// find the TER rates effective on the date, the rates of a category are versioned together
+func (repository *PayrollRateQueryImpl) FindTerRates(c context.Context, db *pgxpool.Pool, date time.Time) ([]domain.TerRate, error) {
+	query := `SELECT t.category, t.min_income, t.max_income, t.rate, t.effective_from
+		FROM pph21_ter_rates AS t
+		WHERE t.effective_from = (
+			SELECT MAX(effective_from) FROM pph21_ter_rates WHERE category = t.category AND effective_from <= $1
+		)
+		ORDER BY t.category, t.min_income`
+
+	rows, err := db.Query(c, query, date)
+	if err != nil {
+		return []domain.TerRate{}, err
+	}
+	defer rows.Close()
+
+	var datas []domain.TerRate
+	for rows.Next() {
+		var data domain.TerRate
+		if err := rows.Scan(&data.Category, &data.MinIncome, &data.MaxIncome, &data.Rate, &data.EffectiveFrom); err != nil {
+			return []domain.TerRate{}, err
+		}
+		datas = append(datas, data)
+	}
+
+	return datas, rows.Err()
+}
+
+// find the progressive rates effective on the date ordered by layer, the rates are versioned together
+func (repository *PayrollRateQueryImpl) FindTaxRates(c context.Context, db *pgxpool.Pool, date time.Time) ([]domain.TaxRate, error) {
+	query := `SELECT up_to, rate, effective_from
+		FROM pph21_rates
+		WHERE effective_from = (SELECT MAX(effective_from) FROM pph21_rates WHERE effective_from <= $1)
+		ORDER BY up_to NULLS LAST`
+
+	rows, err := db.Query(c, query, date)
+	if err != nil {
+		return []domain.TaxRate{}, err
+	}
+	defer rows.Close()
+
+	var datas []domain.TaxRate
+	for rows.Next() {
+		var data domain.TaxRate
+		if err := rows.Scan(&data.UpTo, &data.Rate, &data.EffectiveFrom); err != nil {
+			return []domain.TaxRate{}, err
+		}
+		datas = append(datas, data)
+	}
+
+	return datas, rows.Err()
+}
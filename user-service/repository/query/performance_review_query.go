@@ -0,0 +1,714 @@
+package query
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/iqbaludinm/hr-microservice/user-service/model/domain"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type PerformanceReviewQuery interface {
+	CreateCycle(c context.Context, tx pgx.Tx, cycle domain.ReviewCycle) error
+	UpdateCycle(c context.Context, tx pgx.Tx, id string, cycle domain.ReviewCycle) error
+	UpdateCycleStatus(c context.Context, tx pgx.Tx, id string, cycle domain.ReviewCycle) error
+	CreateReview(c context.Context, tx pgx.Tx, review domain.PerformanceReview) error
+	DeleteReviews(c context.Context, tx pgx.Tx, cycleID string) error
+	UpdateReviewer(c context.Context, tx pgx.Tx, id string, review domain.PerformanceReview) error
+	UpdateSelfAssessment(c context.Context, tx pgx.Tx, id string, review domain.PerformanceReview) error
+	UpdateManagerAssessment(c context.Context, tx pgx.Tx, id string, review domain.PerformanceReview) error
+	UpdateCalibration(c context.Context, tx pgx.Tx, id string, review domain.PerformanceReview) error
+	LockReview(c context.Context, tx pgx.Tx, id string, review domain.PerformanceReview) error
+	CreateGoal(c context.Context, tx pgx.Tx, goal domain.ReviewGoal) error
+	DeleteGoals(c context.Context, tx pgx.Tx, reviewID string) error
+	UpdateGoalScore(c context.Context, tx pgx.Tx, goal domain.ReviewGoal) error
+	CreatePeer(c context.Context, tx pgx.Tx, peer domain.ReviewPeer) error
+	UpdatePeer(c context.Context, tx pgx.Tx, peer domain.ReviewPeer) error
+	FindAllCycle(c context.Context, db *pgxpool.Pool, filter domain.ReviewCycleQueryFilter) ([]domain.ReviewCycle, error)
+	CountAllCycle(c context.Context, db *pgxpool.Pool, filter domain.ReviewCycleQueryFilter) (int, error)
+	FindCycleById(c context.Context, db *pgxpool.Pool, id string) (domain.ReviewCycle, error)
+	FindAllReview(c context.Context, db *pgxpool.Pool, filter domain.PerformanceReviewQueryFilter) ([]domain.PerformanceReview, error)
+	CountAllReview(c context.Context, db *pgxpool.Pool, filter domain.PerformanceReviewQueryFilter) (int, error)
+	FindReviewById(c context.Context, db *pgxpool.Pool, id string) (domain.PerformanceReview, error)
+	CountReviewsWithoutGoals(c context.Context, db *pgxpool.Pool, cycleID string) (int, error)
+	FindGoals(c context.Context, db *pgxpool.Pool, reviewID string) ([]domain.ReviewGoal, error)
+	FindPeers(c context.Context, db *pgxpool.Pool, reviewID string) ([]domain.ReviewPeer, error)
+	FindPeersByReviewer(c context.Context, db *pgxpool.Pool, reviewerID string) ([]domain.ReviewPeer, error)
+}
+
+type PerformanceReviewQueryImpl struct {
+}
+
+func NewPerformanceReview() PerformanceReviewQuery {
+	return &PerformanceReviewQueryImpl{}
+}
+
+// the selected columns of the review cycle with the progress of its reviews.
+// The order must match the 'scanReviewCycle' function.
+const reviewCycleColumns = `
+	rc.id,
+	rc.name,
+	rc.description,
+	rc.period_start,
+	rc.period_end,
+	rc.status,
+	rc.peer_feedback,
+	rc.peer_anonymous,
+	rc.created_by,
+	rc.closed_at,
+	rc.created_at,
+	rc.updated_at,
+	(SELECT COUNT(*) FROM performance_reviews AS r WHERE r.cycle_id = rc.id),
+	(SELECT COUNT(*) FROM performance_reviews AS r WHERE r.cycle_id = rc.id AND r.self_submitted_at IS NOT NULL),
+	(SELECT COUNT(*) FROM performance_reviews AS r WHERE r.cycle_id = rc.id AND r.manager_submitted_at IS NOT NULL)`
+
+func scanReviewCycle(row pgx.Row) (domain.ReviewCycle, error) {
+	var data domain.ReviewCycle
+	err := row.Scan(
+		&data.ID,
+		&data.Name,
+		&data.Description,
+		&data.PeriodStart,
+		&data.PeriodEnd,
+		&data.Status,
+		&data.PeerFeedback,
+		&data.PeerAnonymous,
+		&data.CreatedBy,
+		&data.ClosedAt,
+		&data.CreatedAt,
+		&data.UpdatedAt,
+		&data.TotalReviews,
+		&data.SelfSubmitted,
+		&data.ManagerSubmitted,
+	)
+
+	return data, err
+}
+
+// the selected columns of the performance review, joined with the cycle, the employee and the reviewer, and the
+// average score of the submitted peer feedback. The order must match the 'scanPerformanceReview' function.
+const performanceReviewColumns = `
+	r.id,
+	r.cycle_id,
+	r.employee_id,
+	r.reviewer_id,
+	r.self_score,
+	r.self_comment,
+	r.self_submitted_at,
+	r.manager_score,
+	r.manager_comment,
+	r.manager_submitted_at,
+	r.calibrated_score,
+	r.calibration_note,
+	r.calibrated_by,
+	r.final_score,
+	r.final_rating,
+	r.locked_at,
+	r.created_at,
+	r.updated_at,
+	rc.name,
+	rc.status,
+	rc.peer_anonymous,
+	e.employee_number,
+	u.name,
+	e.user_id,
+	ru.name,
+	re.user_id,
+	(SELECT ROUND(AVG(p.score), 2) FROM performance_review_peers AS p WHERE p.review_id = r.id AND p.status = 'submitted')`
+
+const performanceReviewJoins = `
+	JOIN review_cycles AS rc ON rc.id = r.cycle_id
+	JOIN employees AS e ON e.id = r.employee_id
+	JOIN users AS u ON u.id = e.user_id
+	LEFT JOIN employees AS re ON re.id = r.reviewer_id
+	LEFT JOIN users AS ru ON ru.id = re.user_id`
+
+func scanPerformanceReview(row pgx.Row) (domain.PerformanceReview, error) {
+	var data domain.PerformanceReview
+	err := row.Scan(
+		&data.ID,
+		&data.CycleID,
+		&data.EmployeeID,
+		&data.ReviewerID,
+		&data.SelfScore,
+		&data.SelfComment,
+		&data.SelfSubmittedAt,
+		&data.ManagerScore,
+		&data.ManagerComment,
+		&data.ManagerSubmittedAt,
+		&data.CalibratedScore,
+		&data.CalibrationNote,
+		&data.CalibratedBy,
+		&data.FinalScore,
+		&data.FinalRating,
+		&data.LockedAt,
+		&data.CreatedAt,
+		&data.UpdatedAt,
+		&data.CycleName,
+		&data.CycleStatus,
+		&data.PeerAnonymous,
+		&data.EmployeeNumber,
+		&data.EmployeeName,
+		&data.EmployeeUserID,
+		&data.ReviewerName,
+		&data.ReviewerUserID,
+		&data.PeerScore,
+	)
+
+	return data, err
+}
+
+// the selected columns of the peer feedback, joined with the peer, the review, the cycle and the reviewed employee.
+// The order must match the 'scanReviewPeer' function.
+const reviewPeerColumns = `
+	p.review_id,
+	p.reviewer_id,
+	p.status,
+	p.score,
+	p.comment,
+	p.requested_by,
+	p.requested_at,
+	p.submitted_at,
+	pu.name,
+	pe.user_id,
+	rc.name,
+	rc.status,
+	r.employee_id,
+	e.employee_number,
+	u.name`
+
+const reviewPeerJoins = `
+	JOIN employees AS pe ON pe.id = p.reviewer_id
+	JOIN users AS pu ON pu.id = pe.user_id
+	JOIN performance_reviews AS r ON r.id = p.review_id
+	JOIN review_cycles AS rc ON rc.id = r.cycle_id
+	JOIN employees AS e ON e.id = r.employee_id
+	JOIN users AS u ON u.id = e.user_id`
+
+func scanReviewPeer(row pgx.Row) (domain.ReviewPeer, error) {
+	var data domain.ReviewPeer
+	err := row.Scan(
+		&data.ReviewID,
+		&data.ReviewerID,
+		&data.Status,
+		&data.Score,
+		&data.Comment,
+		&data.RequestedBy,
+		&data.RequestedAt,
+		&data.SubmittedAt,
+		&data.ReviewerName,
+		&data.ReviewerUserID,
+		&data.CycleName,
+		&data.CycleStatus,
+		&data.EmployeeID,
+		&data.EmployeeNumber,
+		&data.EmployeeName,
+	)
+
+	return data, err
+}
+
+func (repository *PerformanceReviewQueryImpl) CreateCycle(c context.Context, tx pgx.Tx, cycle domain.ReviewCycle) error {
+	// build INSERT query
+	query := `INSERT INTO review_cycles (
+		"id",
+		"name",
+		"description",
+		"period_start",
+		"period_end",
+		"status",
+		"peer_feedback",
+		"peer_anonymous",
+		"created_by",
+		"created_at",
+		"updated_at"
+		) VALUES ($1,$2,$3,$4,$5,$6,$7,$8,$9,$10,$11)`
+
+	_, err := tx.Exec(c, query,
+		cycle.ID,
+		cycle.Name,
+		cycle.Description,
+		cycle.PeriodStart,
+		cycle.PeriodEnd,
+		cycle.Status,
+		cycle.PeerFeedback,
+		cycle.PeerAnonymous,
+		cycle.CreatedBy,
+		cycle.CreatedAt,
+		cycle.UpdatedAt,
+	)
+
+	return err
+}
+
+func (repository *PerformanceReviewQueryImpl) UpdateCycle(c context.Context, tx pgx.Tx, id string, cycle domain.ReviewCycle) error {
+	// build UPDATE query
+	query := `UPDATE review_cycles SET
+		name=$1,
+		description=$2,
+		period_start=$3,
+		period_end=$4,
+		peer_feedback=$5,
+		peer_anonymous=$6,
+		updated_at=$7
+		WHERE id=$8`
+
+	_, err := tx.Exec(c, query,
+		cycle.Name,
+		cycle.Description,
+		cycle.PeriodStart,
+		cycle.PeriodEnd,
+		cycle.PeerFeedback,
+		cycle.PeerAnonymous,
+		cycle.UpdatedAt,
+		id,
+	)
+
+	return err
+}
+
+func (repository *PerformanceReviewQueryImpl) UpdateCycleStatus(c context.Context, tx pgx.Tx, id string, cycle domain.ReviewCycle) error {
+	// build UPDATE query
+	query := `UPDATE review_cycles SET
+		status=$1,
+		closed_at=$2,
+		updated_at=$3
+		WHERE id=$4`
+
+	_, err := tx.Exec(c, query,
+		cycle.Status,
+		cycle.ClosedAt,
+		cycle.UpdatedAt,
+		id,
+	)
+
+	return err
+}
+
+func (repository *PerformanceReviewQueryImpl) CreateReview(c context.Context, tx pgx.Tx, review domain.PerformanceReview) error {
+	// build INSERT query
+	query := `INSERT INTO performance_reviews (
+		"id",
+		"cycle_id",
+		"employee_id",
+		"reviewer_id",
+		"created_at",
+		"updated_at"
+		) VALUES ($1,$2,$3,$4,$5,$6)`
+
+	_, err := tx.Exec(c, query,
+		review.ID,
+		review.CycleID,
+		review.EmployeeID,
+		review.ReviewerID,
+		review.CreatedAt,
+		review.UpdatedAt,
+	)
+
+	return err
+}
+
+// delete the reviews of the draft cycle before the reviews of the updated participants are created
+func (repository *PerformanceReviewQueryImpl) DeleteReviews(c context.Context, tx pgx.Tx, cycleID string) error {
+	query := `DELETE FROM performance_reviews WHERE cycle_id=$1`
+
+	_, err := tx.Exec(c, query, cycleID)
+
+	return err
+}
+
+func (repository *PerformanceReviewQueryImpl) UpdateReviewer(c context.Context, tx pgx.Tx, id string, review domain.PerformanceReview) error {
+	// build UPDATE query
+	query := `UPDATE performance_reviews SET
+		reviewer_id=$1,
+		updated_at=$2
+		WHERE id=$3`
+
+	_, err := tx.Exec(c, query,
+		review.ReviewerID,
+		review.UpdatedAt,
+		id,
+	)
+
+	return err
+}
+
+func (repository *PerformanceReviewQueryImpl) UpdateSelfAssessment(c context.Context, tx pgx.Tx, id string, review domain.PerformanceReview) error {
+	// build UPDATE query
+	query := `UPDATE performance_reviews SET
+		self_score=$1,
+		self_comment=$2,
+		self_submitted_at=$3,
+		updated_at=$4
+		WHERE id=$5`
+
+	_, err := tx.Exec(c, query,
+		review.SelfScore,
+		review.SelfComment,
+		review.SelfSubmittedAt,
+		review.UpdatedAt,
+		id,
+	)
+
+	return err
+}
+
+func (repository *PerformanceReviewQueryImpl) UpdateManagerAssessment(c context.Context, tx pgx.Tx, id string, review domain.PerformanceReview) error {
+	// build UPDATE query
+	query := `UPDATE performance_reviews SET
+		manager_score=$1,
+		manager_comment=$2,
+		manager_submitted_at=$3,
+		updated_at=$4
+		WHERE id=$5`
+
+	_, err := tx.Exec(c, query,
+		review.ManagerScore,
+		review.ManagerComment,
+		review.ManagerSubmittedAt,
+		review.UpdatedAt,
+		id,
+	)
+
+	return err
+}
+
+func (repository *PerformanceReviewQueryImpl) UpdateCalibration(c context.Context, tx pgx.Tx, id string, review domain.PerformanceReview) error {
+	// build UPDATE query
+	query := `UPDATE performance_reviews SET
+		calibrated_score=$1,
+		calibration_note=$2,
+		calibrated_by=$3,
+		updated_at=$4
+		WHERE id=$5`
+
+	_, err := tx.Exec(c, query,
+		review.CalibratedScore,
+		review.CalibrationNote,
+		review.CalibratedBy,
+		review.UpdatedAt,
+		id,
+	)
+
+	return err
+}
+
+// set the final score and rating of the review, the locked review can't be changed
+func (repository *PerformanceReviewQueryImpl) LockReview(c context.Context, tx pgx.Tx, id string, review domain.PerformanceReview) error {
+	// build UPDATE query
+	query := `UPDATE performance_reviews SET
+		final_score=$1,
+		final_rating=$2,
+		locked_at=$3,
+		updated_at=$4
+		WHERE id=$5 AND locked_at IS NULL`
+
+	_, err := tx.Exec(c, query,
+		review.FinalScore,
+		review.FinalRating,
+		review.LockedAt,
+		review.UpdatedAt,
+		id,
+	)
+
+	return err
+}
+
+func (repository *PerformanceReviewQueryImpl) CreateGoal(c context.Context, tx pgx.Tx, goal domain.ReviewGoal) error {
+	// build INSERT query
+	query := `INSERT INTO performance_review_goals (
+		"review_id",
+		"seq",
+		"title",
+		"description",
+		"target",
+		"weight"
+		) VALUES ($1,$2,$3,$4,$5,$6)`
+
+	_, err := tx.Exec(c, query,
+		goal.ReviewID,
+		goal.Seq,
+		goal.Title,
+		goal.Description,
+		goal.Target,
+		goal.Weight,
+	)
+
+	return err
+}
+
+// delete the goals of the review before the updated goals are created
+func (repository *PerformanceReviewQueryImpl) DeleteGoals(c context.Context, tx pgx.Tx, reviewID string) error {
+	query := `DELETE FROM performance_review_goals WHERE review_id=$1`
+
+	_, err := tx.Exec(c, query, reviewID)
+
+	return err
+}
+
+// update the scores of the goal given by the employee and the reviewer
+func (repository *PerformanceReviewQueryImpl) UpdateGoalScore(c context.Context, tx pgx.Tx, goal domain.ReviewGoal) error {
+	// build UPDATE query
+	query := `UPDATE performance_review_goals SET
+		self_score=$1,
+		self_comment=$2,
+		manager_score=$3,
+		manager_comment=$4
+		WHERE review_id=$5 AND seq=$6`
+
+	_, err := tx.Exec(c, query,
+		goal.SelfScore,
+		goal.SelfComment,
+		goal.ManagerScore,
+		goal.ManagerComment,
+		goal.ReviewID,
+		goal.Seq,
+	)
+
+	return err
+}
+
+func (repository *PerformanceReviewQueryImpl) CreatePeer(c context.Context, tx pgx.Tx, peer domain.ReviewPeer) error {
+	// build INSERT query
+	query := `INSERT INTO performance_review_peers (
+		"review_id",
+		"reviewer_id",
+		"status",
+		"requested_by",
+		"requested_at"
+		) VALUES ($1,$2,$3,$4,$5)`
+
+	_, err := tx.Exec(c, query,
+		peer.ReviewID,
+		peer.ReviewerID,
+		peer.Status,
+		peer.RequestedBy,
+		peer.RequestedAt,
+	)
+
+	return err
+}
+
+// update the feedback submitted by the peer
+func (repository *PerformanceReviewQueryImpl) UpdatePeer(c context.Context, tx pgx.Tx, peer domain.ReviewPeer) error {
+	// build UPDATE query
+	query := `UPDATE performance_review_peers SET
+		status=$1,
+		score=$2,
+		comment=$3,
+		submitted_at=$4
+		WHERE review_id=$5 AND reviewer_id=$6`
+
+	_, err := tx.Exec(c, query,
+		peer.Status,
+		peer.Score,
+		peer.Comment,
+		peer.SubmittedAt,
+		peer.ReviewID,
+		peer.ReviewerID,
+	)
+
+	return err
+}
+
+func (repository *PerformanceReviewQueryImpl) FindAllCycle(c context.Context, db *pgxpool.Pool, filter domain.ReviewCycleQueryFilter) ([]domain.ReviewCycle, error) {
+	// review cycle query filter builders
+	filterString, args, pagination := filter.BuildReviewCycleQueries()
+
+	query := fmt.Sprintf(
+		`SELECT %s
+		FROM review_cycles AS rc
+		%s
+		ORDER BY rc.period_start DESC, rc.created_at DESC
+		%s`,
+		reviewCycleColumns, filterString, pagination,
+	)
+	rows, err := db.Query(c, query, args...)
+	if err != nil {
+		return []domain.ReviewCycle{}, err
+	}
+	defer rows.Close()
+
+	var datas []domain.ReviewCycle
+	for rows.Next() {
+		data, err := scanReviewCycle(rows)
+		if err != nil {
+			return []domain.ReviewCycle{}, err
+		}
+		datas = append(datas, data)
+	}
+
+	return datas, rows.Err()
+}
+
+func (repository *PerformanceReviewQueryImpl) CountAllCycle(c context.Context, db *pgxpool.Pool, filter domain.ReviewCycleQueryFilter) (int, error) {
+	// review cycle query filter builders
+	filterString, args, _ := filter.BuildReviewCycleQueries()
+
+	query := fmt.Sprintf(`SELECT COUNT(*) FROM review_cycles AS rc %s`, filterString)
+
+	var count int
+	err := db.QueryRow(c, query, args...).Scan(&count)
+	if err != nil {
+		return 0, err
+	}
+
+	return count, err
+}
+
+func (repository *PerformanceReviewQueryImpl) FindCycleById(c context.Context, db *pgxpool.Pool, id string) (domain.ReviewCycle, error) {
+	query := `SELECT ` + reviewCycleColumns + ` FROM review_cycles AS rc WHERE rc.id=$1`
+
+	return scanReviewCycle(db.QueryRow(c, query, id))
+}
+
+func (repository *PerformanceReviewQueryImpl) FindAllReview(c context.Context, db *pgxpool.Pool, filter domain.PerformanceReviewQueryFilter) ([]domain.PerformanceReview, error) {
+	// performance review query filter builders
+	filterString, args, pagination := filter.BuildPerformanceReviewQueries()
+
+	query := fmt.Sprintf(
+		`SELECT %s
+		FROM performance_reviews AS r
+		%s
+		%s
+		ORDER BY rc.period_start DESC, e.employee_number
+		%s`,
+		performanceReviewColumns, performanceReviewJoins, filterString, pagination,
+	)
+	rows, err := db.Query(c, query, args...)
+	if err != nil {
+		return []domain.PerformanceReview{}, err
+	}
+	defer rows.Close()
+
+	var datas []domain.PerformanceReview
+	for rows.Next() {
+		data, err := scanPerformanceReview(rows)
+		if err != nil {
+			return []domain.PerformanceReview{}, err
+		}
+		datas = append(datas, data)
+	}
+
+	return datas, rows.Err()
+}
+
+func (repository *PerformanceReviewQueryImpl) CountAllReview(c context.Context, db *pgxpool.Pool, filter domain.PerformanceReviewQueryFilter) (int, error) {
+	// performance review query filter builders
+	filterString, args, _ := filter.BuildPerformanceReviewQueries()
+
+	query := fmt.Sprintf(`SELECT COUNT(*) FROM performance_reviews AS r
+		JOIN employees AS e ON e.id = r.employee_id %s`, filterString)
+
+	var count int
+	err := db.QueryRow(c, query, args...).Scan(&count)
+	if err != nil {
+		return 0, err
+	}
+
+	return count, err
+}
+
+func (repository *PerformanceReviewQueryImpl) FindReviewById(c context.Context, db *pgxpool.Pool, id string) (domain.PerformanceReview, error) {
+	query := `SELECT ` + performanceReviewColumns + ` FROM performance_reviews AS r ` + performanceReviewJoins + ` WHERE r.id=$1`
+
+	return scanPerformanceReview(db.QueryRow(c, query, id))
+}
+
+// count the reviews of the cycle which goals don't add up to the total weight
+func (repository *PerformanceReviewQueryImpl) CountReviewsWithoutGoals(c context.Context, db *pgxpool.Pool, cycleID string) (int, error) {
+	query := `SELECT COUNT(*) FROM performance_reviews AS r
+		WHERE r.cycle_id=$1
+		AND COALESCE((SELECT SUM(g.weight) FROM performance_review_goals AS g WHERE g.review_id = r.id), 0) <> $2`
+
+	var count int
+	err := db.QueryRow(c, query, cycleID, domain.ReviewGoalTotalWeight).Scan(&count)
+	if err != nil {
+		return 0, err
+	}
+
+	return count, err
+}
+
+func (repository *PerformanceReviewQueryImpl) FindGoals(c context.Context, db *pgxpool.Pool, reviewID string) ([]domain.ReviewGoal, error) {
+	query := `SELECT
+		review_id,
+		seq,
+		title,
+		description,
+		target,
+		weight,
+		self_score,
+		self_comment,
+		manager_score,
+		manager_comment
+		FROM performance_review_goals
+		WHERE review_id=$1
+		ORDER BY seq`
+
+	rows, err := db.Query(c, query, reviewID)
+	if err != nil {
+		return []domain.ReviewGoal{}, err
+	}
+	defer rows.Close()
+
+	var datas []domain.ReviewGoal
+	for rows.Next() {
+		var data domain.ReviewGoal
+		err := rows.Scan(
+			&data.ReviewID,
+			&data.Seq,
+			&data.Title,
+			&data.Description,
+			&data.Target,
+			&data.Weight,
+			&data.SelfScore,
+			&data.SelfComment,
+			&data.ManagerScore,
+			&data.ManagerComment,
+		)
+		if err != nil {
+			return []domain.ReviewGoal{}, err
+		}
+		datas = append(datas, data)
+	}
+
+	return datas, rows.Err()
+}
+
+func (repository *PerformanceReviewQueryImpl) FindPeers(c context.Context, db *pgxpool.Pool, reviewID string) ([]domain.ReviewPeer, error) {
+	query := `SELECT ` + reviewPeerColumns + ` FROM performance_review_peers AS p ` + reviewPeerJoins + `
+		WHERE p.review_id=$1
+		ORDER BY p.requested_at`
+
+	return findReviewPeers(c, db, query, reviewID)
+}
+
+// get the peer feedback requested from the employee, the feedback of the closed cycles is excluded
+func (repository *PerformanceReviewQueryImpl) FindPeersByReviewer(c context.Context, db *pgxpool.Pool, reviewerID string) ([]domain.ReviewPeer, error) {
+	query := `SELECT ` + reviewPeerColumns + ` FROM performance_review_peers AS p ` + reviewPeerJoins + `
+		WHERE p.reviewer_id=$1 AND rc.status<>$2
+		ORDER BY p.status, p.requested_at`
+
+	return findReviewPeers(c, db, query, reviewerID, domain.ReviewCycleClosed)
+}
+
+func findReviewPeers(c context.Context, db *pgxpool.Pool, query string, args ...interface{}) ([]domain.ReviewPeer, error) {
+	rows, err := db.Query(c, query, args...)
+	if err != nil {
+		return []domain.ReviewPeer{}, err
+	}
+	defer rows.Close()
+
+	var datas []domain.ReviewPeer
+	for rows.Next() {
+		data, err := scanReviewPeer(rows)
+		if err != nil {
+			return []domain.ReviewPeer{}, err
+		}
+		datas = append(datas, data)
+	}
+
+	return datas, rows.Err()
+}
@@ -0,0 +1,118 @@
+package query
+
+import (
+	"context"
+	"time"
+
+	"github.com/iqbaludinm/hr-microservice/user-service/model/domain"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type PositionQuery interface {
+	CreatePosition(c context.Context, tx pgx.Tx, position domain.Position) error
+	UpdatePosition(c context.Context, tx pgx.Tx, id string, position domain.Position) error
+	Delete(c context.Context, tx pgx.Tx, id string) error
+	FindAllPosition(c context.Context, db *pgxpool.Pool, departmentID string) ([]domain.Position, error)
+	FindById(c context.Context, db *pgxpool.Pool, id string) (domain.Position, error)
+}
+
+type PositionQueryImpl struct {
+}
+
+func NewPosition() PositionQuery {
+	return &PositionQueryImpl{}
+}
+
+// the selected columns of the position, joined with the 'departments' table for the department name.
+const positionColumns = `p.id, p.department_id, p.title, p.level, p.created_at, p.updated_at, p.deleted_at, d.name`
+
+func scanPosition(row pgx.Row) (domain.Position, error) {
+	var data domain.Position
+	err := row.Scan(
+		&data.ID,
+		&data.DepartmentID,
+		&data.Title,
+		&data.Level,
+		&data.CreatedAt,
+		&data.UpdatedAt,
+		&data.DeletedAt,
+		&data.DepartmentName,
+	)
+
+	return data, err
+}
+
+func (repository *PositionQueryImpl) CreatePosition(c context.Context, tx pgx.Tx, position domain.Position) error {
+	// build INSERT query
+	query := `INSERT INTO positions ("id", "department_id", "title", "level", "created_at", "updated_at") VALUES ($1,$2,$3,$4,$5,$6)`
+
+	_, err := tx.Exec(c, query,
+		position.ID,
+		position.DepartmentID,
+		position.Title,
+		position.Level,
+		position.CreatedAt,
+		position.UpdatedAt,
+	)
+
+	return err
+}
+
+func (repository *PositionQueryImpl) UpdatePosition(c context.Context, tx pgx.Tx, id string, position domain.Position) error {
+	// build UPDATE query
+	query := `UPDATE positions SET department_id=$1, title=$2, level=$3, updated_at=$4 WHERE id=$5`
+
+	_, err := tx.Exec(c, query, position.DepartmentID, position.Title, position.Level, position.UpdatedAt, id)
+
+	return err
+}
+
+func (repository *PositionQueryImpl) Delete(c context.Context, tx pgx.Tx, id string) error {
+	// build UPDATE query
+	query := `UPDATE positions SET deleted_at=$1 WHERE id=$2`
+
+	_, err := tx.Exec(c, query, time.Now(), id)
+
+	return err
+}
+
+// find all positions, an empty 'departmentID' returns the positions of all departments
+func (repository *PositionQueryImpl) FindAllPosition(c context.Context, db *pgxpool.Pool, departmentID string) ([]domain.Position, error) {
+	query := `SELECT ` + positionColumns + `
+		FROM positions AS p
+		JOIN departments AS d ON d.id = p.department_id
+		WHERE
+			p.deleted_at is null AND
+			($1 = '' OR p.department_id::text = $1)
+		ORDER BY d.name, p.level DESC, p.title`
+
+	rows, err := db.Query(c, query, departmentID)
+	if err != nil {
+		return []domain.Position{}, err
+	}
+	defer rows.Close()
+
+	var datas []domain.Position
+	for rows.Next() {
+		data, err := scanPosition(rows)
+		if err != nil {
+			return []domain.Position{}, err
+		}
+		datas = append(datas, data)
+	}
+
+	return datas, rows.Err()
+}
+
+func (repository *PositionQueryImpl) FindById(c context.Context, db *pgxpool.Pool, id string) (domain.Position, error) {
+	// build SELECT query
+	query := `SELECT ` + positionColumns + `
+		FROM positions AS p
+		JOIN departments AS d ON d.id = p.department_id
+		WHERE
+			p.deleted_at is null AND
+			p.id=$1`
+
+	return scanPosition(db.QueryRow(c, query, id))
+}
@@ -0,0 +1,155 @@
+package query
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/iqbaludinm/hr-microservice/user-service/model/domain"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type RosterQuery interface {
+	UpsertRoster(c context.Context, tx pgx.Tx, roster domain.Roster) error
+	DeleteRoster(c context.Context, tx pgx.Tx, employeeIDs []string, from, to time.Time) error
+	FindAllRoster(c context.Context, db *pgxpool.Pool, filter domain.RosterQueryFilter) ([]domain.Roster, error)
+	FindByEmployeeDate(c context.Context, db *pgxpool.Pool, employeeID string, date time.Time) (domain.Roster, error)
+	UpdateCalendarToken(c context.Context, tx pgx.Tx, employeeID, token string) error
+	FindCalendarToken(c context.Context, db *pgxpool.Pool, employeeID string) (*string, error)
+	FindEmployeeIdByCalendarToken(c context.Context, db *pgxpool.Pool, token string) (string, error)
+}
+
+type RosterQueryImpl struct {
+}
+
+func NewRoster() RosterQuery {
+	return &RosterQueryImpl{}
+}
+
+// the selected columns of the roster. The order must match the 'scanRoster' function.
+const rosterColumns = `
+	r.id,
+	r.employee_id,
+	r.date,
+	r.shift_id,
+	r.pattern_id,
+	r.created_at,
+	r.updated_at`
+
+func scanRoster(row pgx.Row) (domain.Roster, error) {
+	var data domain.Roster
+	err := row.Scan(
+		&data.ID,
+		&data.EmployeeID,
+		&data.Date,
+		&data.ShiftID,
+		&data.PatternID,
+		&data.CreatedAt,
+		&data.UpdatedAt,
+	)
+
+	return data, err
+}
+
+// insert the roster, or replace the shift when the employee is already rostered on the date
+func (repository *RosterQueryImpl) UpsertRoster(c context.Context, tx pgx.Tx, roster domain.Roster) error {
+	// build INSERT query
+	query := `INSERT INTO rosters (
+		"id",
+		"employee_id",
+		"date",
+		"shift_id",
+		"pattern_id",
+		"created_at",
+		"updated_at"
+		) VALUES ($1,$2,$3,$4,$5,$6,$7)
+		ON CONFLICT ("employee_id", "date") DO UPDATE SET
+		shift_id=EXCLUDED.shift_id,
+		pattern_id=EXCLUDED.pattern_id,
+		updated_at=EXCLUDED.updated_at`
+
+	_, err := tx.Exec(c, query,
+		roster.ID,
+		roster.EmployeeID,
+		roster.Date,
+		roster.ShiftID,
+		roster.PatternID,
+		roster.CreatedAt,
+		roster.UpdatedAt,
+	)
+
+	return err
+}
+
+func (repository *RosterQueryImpl) DeleteRoster(c context.Context, tx pgx.Tx, employeeIDs []string, from, to time.Time) error {
+	// build DELETE query
+	query := `DELETE FROM rosters WHERE employee_id = ANY($1::uuid[]) AND date >= $2 AND date <= $3`
+
+	_, err := tx.Exec(c, query, employeeIDs, from, to)
+
+	return err
+}
+
+func (repository *RosterQueryImpl) FindAllRoster(c context.Context, db *pgxpool.Pool, filter domain.RosterQueryFilter) ([]domain.Roster, error) {
+	// roster query filter builders
+	filterString, args := filter.BuildRosterQueries()
+
+	query := fmt.Sprintf(
+		`SELECT %s
+		FROM rosters AS r
+		%s
+		ORDER BY r.date, r.employee_id`,
+		rosterColumns, filterString,
+	)
+	rows, err := db.Query(c, query, args...)
+	if err != nil {
+		return []domain.Roster{}, err
+	}
+	defer rows.Close()
+
+	var datas []domain.Roster
+	for rows.Next() {
+		data, err := scanRoster(rows)
+		if err != nil {
+			return []domain.Roster{}, err
+		}
+		datas = append(datas, data)
+	}
+
+	return datas, rows.Err()
+}
+
+func (repository *RosterQueryImpl) FindByEmployeeDate(c context.Context, db *pgxpool.Pool, employeeID string, date time.Time) (domain.Roster, error) {
+	query := `SELECT ` + rosterColumns + ` FROM rosters AS r WHERE r.employee_id=$1 AND r.date=$2`
+
+	return scanRoster(db.QueryRow(c, query, employeeID, date))
+}
+
+func (repository *RosterQueryImpl) UpdateCalendarToken(c context.Context, tx pgx.Tx, employeeID, token string) error {
+	// build UPDATE query
+	query := `UPDATE employees SET calendar_token=$1, updated_at=$2 WHERE id=$3`
+
+	_, err := tx.Exec(c, query, token, time.Now(), employeeID)
+
+	return err
+}
+
+// find the token of the calendar feed of the employee, nil is returned when it is not generated yet
+func (repository *RosterQueryImpl) FindCalendarToken(c context.Context, db *pgxpool.Pool, employeeID string) (*string, error) {
+	query := `SELECT calendar_token::text FROM employees WHERE id=$1 AND deleted_at is null`
+
+	var token *string
+	err := db.QueryRow(c, query, employeeID).Scan(&token)
+
+	return token, err
+}
+
+func (repository *RosterQueryImpl) FindEmployeeIdByCalendarToken(c context.Context, db *pgxpool.Pool, token string) (string, error) {
+	query := `SELECT id FROM employees WHERE calendar_token=$1 AND deleted_at is null`
+
+	var employeeID string
+	err := db.QueryRow(c, query, token).Scan(&employeeID)
+
+	return employeeID, err
+}
@@ -0,0 +1,164 @@
+package query
+
+import (
+	"context"
+	"time"
+
+	"github.com/iqbaludinm/hr-microservice/user-service/model/domain"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type ShiftPatternQuery interface {
+	CreatePattern(c context.Context, tx pgx.Tx, pattern domain.ShiftPattern) error
+	UpdatePattern(c context.Context, tx pgx.Tx, id string, pattern domain.ShiftPattern) error
+	Delete(c context.Context, tx pgx.Tx, id string) error
+	ReplaceDays(c context.Context, tx pgx.Tx, id string, days []domain.ShiftPatternDay) error
+	FindAllPattern(c context.Context, db *pgxpool.Pool) ([]domain.ShiftPattern, error)
+	FindById(c context.Context, db *pgxpool.Pool, id string) (domain.ShiftPattern, error)
+	FindDays(c context.Context, db *pgxpool.Pool, id string) ([]domain.ShiftPatternDay, error)
+}
+
+type ShiftPatternQueryImpl struct {
+}
+
+func NewShiftPattern() ShiftPatternQuery {
+	return &ShiftPatternQueryImpl{}
+}
+
+// the selected columns of the shift pattern. The order must match the 'scanShiftPattern' function.
+const shiftPatternColumns = `
+	sp.id,
+	sp.code,
+	sp.name,
+	sp.created_at,
+	sp.updated_at,
+	sp.deleted_at`
+
+func scanShiftPattern(row pgx.Row) (domain.ShiftPattern, error) {
+	var data domain.ShiftPattern
+	err := row.Scan(
+		&data.ID,
+		&data.Code,
+		&data.Name,
+		&data.CreatedAt,
+		&data.UpdatedAt,
+		&data.DeletedAt,
+	)
+
+	return data, err
+}
+
+func (repository *ShiftPatternQueryImpl) CreatePattern(c context.Context, tx pgx.Tx, pattern domain.ShiftPattern) error {
+	// build INSERT query
+	query := `INSERT INTO shift_patterns (
+		"id",
+		"code",
+		"name",
+		"created_at",
+		"updated_at"
+		) VALUES ($1,$2,$3,$4,$5)`
+
+	_, err := tx.Exec(c, query,
+		pattern.ID,
+		pattern.Code,
+		pattern.Name,
+		pattern.CreatedAt,
+		pattern.UpdatedAt,
+	)
+
+	return err
+}
+
+func (repository *ShiftPatternQueryImpl) UpdatePattern(c context.Context, tx pgx.Tx, id string, pattern domain.ShiftPattern) error {
+	// build UPDATE query
+	query := `UPDATE shift_patterns SET name=$1, updated_at=$2 WHERE id=$3`
+
+	_, err := tx.Exec(c, query, pattern.Name, pattern.UpdatedAt, id)
+
+	return err
+}
+
+func (repository *ShiftPatternQueryImpl) Delete(c context.Context, tx pgx.Tx, id string) error {
+	// build UPDATE query
+	query := `UPDATE shift_patterns SET deleted_at=$1 WHERE id=$2`
+
+	_, err := tx.Exec(c, query, time.Now(), id)
+
+	return err
+}
+
+// replace the days of the pattern with the new days
+func (repository *ShiftPatternQueryImpl) ReplaceDays(c context.Context, tx pgx.Tx, id string, days []domain.ShiftPatternDay) error {
+	if _, err := tx.Exec(c, `DELETE FROM shift_pattern_days WHERE pattern_id=$1`, id); err != nil {
+		return err
+	}
+
+	// build INSERT query
+	query := `INSERT INTO shift_pattern_days ("pattern_id", "day_index", "shift_id") VALUES ($1,$2,$3)`
+
+	for _, day := range days {
+		if _, err := tx.Exec(c, query, id, day.DayIndex, day.ShiftID); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (repository *ShiftPatternQueryImpl) FindAllPattern(c context.Context, db *pgxpool.Pool) ([]domain.ShiftPattern, error) {
+	query := `SELECT ` + shiftPatternColumns + ` FROM shift_patterns AS sp WHERE sp.deleted_at is null ORDER BY sp.name`
+
+	rows, err := db.Query(c, query)
+	if err != nil {
+		return []domain.ShiftPattern{}, err
+	}
+	defer rows.Close()
+
+	var datas []domain.ShiftPattern
+	for rows.Next() {
+		data, err := scanShiftPattern(rows)
+		if err != nil {
+			return []domain.ShiftPattern{}, err
+		}
+		datas = append(datas, data)
+	}
+
+	return datas, rows.Err()
+}
+
+func (repository *ShiftPatternQueryImpl) FindById(c context.Context, db *pgxpool.Pool, id string) (domain.ShiftPattern, error) {
+	query := `SELECT ` + shiftPatternColumns + ` FROM shift_patterns AS sp WHERE sp.deleted_at is null AND sp.id=$1`
+
+	return scanShiftPattern(db.QueryRow(c, query, id))
+}
+
+// find the days of the pattern, ordered by the day of the rotation
+func (repository *ShiftPatternQueryImpl) FindDays(c context.Context, db *pgxpool.Pool, id string) ([]domain.ShiftPatternDay, error) {
+	query := `SELECT
+			spd.day_index,
+			spd.shift_id,
+			COALESCE(s.code, ''),
+			COALESCE(s.name, '')
+		FROM shift_pattern_days AS spd
+		LEFT JOIN shifts AS s ON s.id = spd.shift_id
+		WHERE spd.pattern_id=$1
+		ORDER BY spd.day_index`
+
+	rows, err := db.Query(c, query, id)
+	if err != nil {
+		return []domain.ShiftPatternDay{}, err
+	}
+	defer rows.Close()
+
+	var datas []domain.ShiftPatternDay
+	for rows.Next() {
+		var data domain.ShiftPatternDay
+		if err := rows.Scan(&data.DayIndex, &data.ShiftID, &data.ShiftCode, &data.ShiftName); err != nil {
+			return []domain.ShiftPatternDay{}, err
+		}
+		datas = append(datas, data)
+	}
+
+	return datas, rows.Err()
+}
@@ -0,0 +1,232 @@
+package query
+
+import (
+	"context"
+	"time"
+
+	"github.com/iqbaludinm/hr-microservice/user-service/model/domain"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type ShiftQuery interface {
+	CreateShift(c context.Context, tx pgx.Tx, shift domain.Shift) error
+	UpdateShift(c context.Context, tx pgx.Tx, id string, shift domain.Shift) error
+	Delete(c context.Context, tx pgx.Tx, id string) error
+	ClearDefault(c context.Context, tx pgx.Tx, exceptID string) error
+	AssignEmployees(c context.Context, tx pgx.Tx, id string, employeeIDs []string) error
+	FindAllShift(c context.Context, db *pgxpool.Pool) ([]domain.Shift, error)
+	FindById(c context.Context, db *pgxpool.Pool, id string) (domain.Shift, error)
+	FindByEmployeeId(c context.Context, db *pgxpool.Pool, employeeID string) (domain.Shift, error)
+	CountEmployees(c context.Context, db *pgxpool.Pool, id string) (int, error)
+	CountPatterns(c context.Context, db *pgxpool.Pool, id string) (int, error)
+}
+
+type ShiftQueryImpl struct {
+}
+
+func NewShift() ShiftQuery {
+	return &ShiftQueryImpl{}
+}
+
+// the selected columns of the shift. The order must match the 'scanShift' function.
+const shiftColumns = `
+	s.id,
+	s.code,
+	s.name,
+	to_char(s.start_time, 'HH24:MI'),
+	to_char(s.end_time, 'HH24:MI'),
+	s.break_minutes,
+	s.late_tolerance_minutes,
+	s.early_leave_tolerance_minutes,
+	s.min_overtime_minutes,
+	s.is_default,
+	s.created_at,
+	s.updated_at,
+	s.deleted_at`
+
+func scanShift(row pgx.Row) (domain.Shift, error) {
+	var data domain.Shift
+	err := row.Scan(
+		&data.ID,
+		&data.Code,
+		&data.Name,
+		&data.StartTime,
+		&data.EndTime,
+		&data.BreakMinutes,
+		&data.LateToleranceMinutes,
+		&data.EarlyLeaveToleranceMinutes,
+		&data.MinOvertimeMinutes,
+		&data.IsDefault,
+		&data.CreatedAt,
+		&data.UpdatedAt,
+		&data.DeletedAt,
+	)
+
+	return data, err
+}
+
+func (repository *ShiftQueryImpl) CreateShift(c context.Context, tx pgx.Tx, shift domain.Shift) error {
+	// build INSERT query
+	query := `INSERT INTO shifts (
+		"id",
+		"code",
+		"name",
+		"start_time",
+		"end_time",
+		"break_minutes",
+		"late_tolerance_minutes",
+		"early_leave_tolerance_minutes",
+		"min_overtime_minutes",
+		"is_default",
+		"created_at",
+		"updated_at"
+		) VALUES ($1,$2,$3,$4::time,$5::time,$6,$7,$8,$9,$10,$11,$12)`
+
+	_, err := tx.Exec(c, query,
+		shift.ID,
+		shift.Code,
+		shift.Name,
+		shift.StartTime,
+		shift.EndTime,
+		shift.BreakMinutes,
+		shift.LateToleranceMinutes,
+		shift.EarlyLeaveToleranceMinutes,
+		shift.MinOvertimeMinutes,
+		shift.IsDefault,
+		shift.CreatedAt,
+		shift.UpdatedAt,
+	)
+
+	return err
+}
+
+func (repository *ShiftQueryImpl) UpdateShift(c context.Context, tx pgx.Tx, id string, shift domain.Shift) error {
+	// build UPDATE query
+	query := `UPDATE shifts SET
+		name=$1,
+		start_time=$2::time,
+		end_time=$3::time,
+		break_minutes=$4,
+		late_tolerance_minutes=$5,
+		early_leave_tolerance_minutes=$6,
+		min_overtime_minutes=$7,
+		is_default=$8,
+		updated_at=$9
+		WHERE id=$10`
+
+	_, err := tx.Exec(c, query,
+		shift.Name,
+		shift.StartTime,
+		shift.EndTime,
+		shift.BreakMinutes,
+		shift.LateToleranceMinutes,
+		shift.EarlyLeaveToleranceMinutes,
+		shift.MinOvertimeMinutes,
+		shift.IsDefault,
+		shift.UpdatedAt,
+		id,
+	)
+
+	return err
+}
+
+func (repository *ShiftQueryImpl) Delete(c context.Context, tx pgx.Tx, id string) error {
+	// build UPDATE query
+	query := `UPDATE shifts SET deleted_at=$1 WHERE id=$2`
+
+	_, err := tx.Exec(c, query, time.Now(), id)
+
+	return err
+}
+
+// unset the default flag of the other shifts, only one shift can be the default
+func (repository *ShiftQueryImpl) ClearDefault(c context.Context, tx pgx.Tx, exceptID string) error {
+	query := `UPDATE shifts SET is_default=false, updated_at=$1 WHERE is_default AND id<>$2`
+
+	_, err := tx.Exec(c, query, time.Now(), exceptID)
+
+	return err
+}
+
+func (repository *ShiftQueryImpl) AssignEmployees(c context.Context, tx pgx.Tx, id string, employeeIDs []string) error {
+	// build UPDATE query
+	query := `UPDATE employees SET shift_id=$1, updated_at=$2 WHERE id = ANY($3::uuid[])`
+
+	_, err := tx.Exec(c, query, id, time.Now(), employeeIDs)
+
+	return err
+}
+
+func (repository *ShiftQueryImpl) FindAllShift(c context.Context, db *pgxpool.Pool) ([]domain.Shift, error) {
+	query := `SELECT ` + shiftColumns + ` FROM shifts AS s WHERE s.deleted_at is null ORDER BY s.start_time, s.name`
+
+	rows, err := db.Query(c, query)
+	if err != nil {
+		return []domain.Shift{}, err
+	}
+	defer rows.Close()
+
+	var datas []domain.Shift
+	for rows.Next() {
+		data, err := scanShift(rows)
+		if err != nil {
+			return []domain.Shift{}, err
+		}
+		datas = append(datas, data)
+	}
+
+	return datas, rows.Err()
+}
+
+func (repository *ShiftQueryImpl) FindById(c context.Context, db *pgxpool.Pool, id string) (domain.Shift, error) {
+	query := `SELECT ` + shiftColumns + ` FROM shifts AS s WHERE s.deleted_at is null AND s.id=$1`
+
+	return scanShift(db.QueryRow(c, query, id))
+}
+
+// find the shift assigned to the employee, the default shift is used when the employee has no assigned shift
+func (repository *ShiftQueryImpl) FindByEmployeeId(c context.Context, db *pgxpool.Pool, employeeID string) (domain.Shift, error) {
+	query := `SELECT ` + shiftColumns + `
+		FROM shifts AS s
+		WHERE
+			s.deleted_at is null AND
+			(s.id = (SELECT shift_id FROM employees WHERE id=$1) OR s.is_default)
+		ORDER BY s.is_default
+		LIMIT 1`
+
+	return scanShift(db.QueryRow(c, query, employeeID))
+}
+
+// count the active employees assigned to the shift or rostered on the shift from today
+func (repository *ShiftQueryImpl) CountEmployees(c context.Context, db *pgxpool.Pool, id string) (int, error) {
+	query := `SELECT COUNT(*)
+		FROM employees AS e
+		WHERE
+			e.deleted_at is null AND
+			(e.shift_id=$1 OR EXISTS (SELECT 1 FROM rosters AS r WHERE r.employee_id=e.id AND r.shift_id=$1 AND r.date >= CURRENT_DATE))`
+
+	var count int
+	err := db.QueryRow(c, query, id).Scan(&count)
+	if err != nil {
+		return 0, err
+	}
+
+	return count, err
+}
+
+// count the shift patterns that use the shift
+func (repository *ShiftQueryImpl) CountPatterns(c context.Context, db *pgxpool.Pool, id string) (int, error) {
+	query := `SELECT COUNT(DISTINCT sp.id)
+		FROM shift_patterns AS sp
+		JOIN shift_pattern_days AS spd ON spd.pattern_id = sp.id
+		WHERE spd.shift_id=$1 AND sp.deleted_at is null`
+
+	var count int
+	err := db.QueryRow(c, query, id).Scan(&count)
+	if err != nil {
+		return 0, err
+	}
+
+	return count, err
+}
@@ -0,0 +1,223 @@
+package query
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/iqbaludinm/hr-microservice/user-service/model/domain"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type ShiftSwapQuery interface {
+	CreateShiftSwap(c context.Context, tx pgx.Tx, swap domain.ShiftSwap) error
+	UpdateStatus(c context.Context, tx pgx.Tx, id string, swap domain.ShiftSwap) error
+	FindAllShiftSwap(c context.Context, db *pgxpool.Pool, filter domain.ShiftSwapQueryFilter) ([]domain.ShiftSwap, error)
+	CountAllShiftSwap(c context.Context, db *pgxpool.Pool, filter domain.ShiftSwapQueryFilter) (int, error)
+	FindById(c context.Context, db *pgxpool.Pool, id string) (domain.ShiftSwap, error)
+	CountPending(c context.Context, db *pgxpool.Pool, employeeIDs []string, date time.Time) (int, error)
+}
+
+type ShiftSwapQueryImpl struct {
+}
+
+func NewShiftSwap() ShiftSwapQuery {
+	return &ShiftSwapQueryImpl{}
+}
+
+// the selected columns of the shift swap request, joined with the requester, the colleague, their shifts
+// and the approver. The order must match the 'scanShiftSwap' function.
+const shiftSwapColumns = `
+	w.id,
+	w.requester_id,
+	w.colleague_id,
+	w.date,
+	w.requester_shift_id,
+	w.colleague_shift_id,
+	w.reason,
+	w.status,
+	w.approver_id,
+	w.decision_note,
+	w.decided_at,
+	w.cancelled_at,
+	w.created_at,
+	w.updated_at,
+	ru.name,
+	ru.id,
+	COALESCE(rs.name, ''),
+	cu.name,
+	cu.id,
+	COALESCE(cs.name, ''),
+	COALESCE(au.name, '')`
+
+const shiftSwapJoins = `
+	JOIN employees AS re ON re.id = w.requester_id
+	JOIN users AS ru ON ru.id = re.user_id
+	LEFT JOIN shifts AS rs ON rs.id = w.requester_shift_id
+	JOIN employees AS ce ON ce.id = w.colleague_id
+	JOIN users AS cu ON cu.id = ce.user_id
+	LEFT JOIN shifts AS cs ON cs.id = w.colleague_shift_id
+	LEFT JOIN employees AS a ON a.id = w.approver_id
+	LEFT JOIN users AS au ON au.id = a.user_id`
+
+func scanShiftSwap(row pgx.Row) (domain.ShiftSwap, error) {
+	var data domain.ShiftSwap
+	err := row.Scan(
+		&data.ID,
+		&data.RequesterID,
+		&data.ColleagueID,
+		&data.Date,
+		&data.RequesterShiftID,
+		&data.ColleagueShiftID,
+		&data.Reason,
+		&data.Status,
+		&data.ApproverID,
+		&data.DecisionNote,
+		&data.DecidedAt,
+		&data.CancelledAt,
+		&data.CreatedAt,
+		&data.UpdatedAt,
+		&data.RequesterName,
+		&data.RequesterUserID,
+		&data.RequesterShiftName,
+		&data.ColleagueName,
+		&data.ColleagueUserID,
+		&data.ColleagueShiftName,
+		&data.ApproverName,
+	)
+
+	return data, err
+}
+
+func (repository *ShiftSwapQueryImpl) CreateShiftSwap(c context.Context, tx pgx.Tx, swap domain.ShiftSwap) error {
+	// build INSERT query
+	query := `INSERT INTO shift_swaps (
+		"id",
+		"requester_id",
+		"colleague_id",
+		"date",
+		"requester_shift_id",
+		"colleague_shift_id",
+		"reason",
+		"status",
+		"approver_id",
+		"created_at",
+		"updated_at"
+		) VALUES ($1,$2,$3,$4,$5,$6,$7,$8,$9,$10,$11)`
+
+	_, err := tx.Exec(c, query,
+		swap.ID,
+		swap.RequesterID,
+		swap.ColleagueID,
+		swap.Date,
+		swap.RequesterShiftID,
+		swap.ColleagueShiftID,
+		swap.Reason,
+		swap.Status,
+		swap.ApproverID,
+		swap.CreatedAt,
+		swap.UpdatedAt,
+	)
+
+	return err
+}
+
+// update the status of the shift swap request with the decision (approve, reject) or the cancellation
+func (repository *ShiftSwapQueryImpl) UpdateStatus(c context.Context, tx pgx.Tx, id string, swap domain.ShiftSwap) error {
+	// build UPDATE query
+	query := `UPDATE shift_swaps SET
+		status=$1,
+		decision_note=$2,
+		decided_at=$3,
+		cancelled_at=$4,
+		updated_at=$5
+		WHERE id=$6`
+
+	_, err := tx.Exec(c, query,
+		swap.Status,
+		swap.DecisionNote,
+		swap.DecidedAt,
+		swap.CancelledAt,
+		swap.UpdatedAt,
+		id,
+	)
+
+	return err
+}
+
+func (repository *ShiftSwapQueryImpl) FindAllShiftSwap(c context.Context, db *pgxpool.Pool, filter domain.ShiftSwapQueryFilter) ([]domain.ShiftSwap, error) {
+	// shift swap query filter builders
+	filterString, args, pagination := filter.BuildShiftSwapQueries()
+
+	query := fmt.Sprintf(
+		`SELECT %s
+		FROM shift_swaps AS w
+		%s
+		%s
+		ORDER BY w.date DESC, w.created_at DESC
+		%s`,
+		shiftSwapColumns, shiftSwapJoins, filterString, pagination,
+	)
+	rows, err := db.Query(c, query, args...)
+	if err != nil {
+		return []domain.ShiftSwap{}, err
+	}
+	defer rows.Close()
+
+	var datas []domain.ShiftSwap
+	for rows.Next() {
+		data, err := scanShiftSwap(rows)
+		if err != nil {
+			return []domain.ShiftSwap{}, err
+		}
+		datas = append(datas, data)
+	}
+
+	return datas, rows.Err()
+}
+
+func (repository *ShiftSwapQueryImpl) CountAllShiftSwap(c context.Context, db *pgxpool.Pool, filter domain.ShiftSwapQueryFilter) (int, error) {
+	// shift swap query filter builders
+	filterString, args, _ := filter.BuildShiftSwapQueries()
+
+	query := fmt.Sprintf(`SELECT COUNT(*) FROM shift_swaps AS w %s`, filterString)
+
+	var count int
+	err := db.QueryRow(c, query, args...).Scan(&count)
+	if err != nil {
+		return 0, err
+	}
+
+	return count, err
+}
+
+func (repository *ShiftSwapQueryImpl) FindById(c context.Context, db *pgxpool.Pool, id string) (domain.ShiftSwap, error) {
+	query := fmt.Sprintf(
+		`SELECT %s
+		FROM shift_swaps AS w
+		%s
+		WHERE w.id=$1`,
+		shiftSwapColumns, shiftSwapJoins,
+	)
+
+	return scanShiftSwap(db.QueryRow(c, query, id))
+}
+
+// count the pending shift swap requests of the employees (as the requester or the colleague) on the date
+func (repository *ShiftSwapQueryImpl) CountPending(c context.Context, db *pgxpool.Pool, employeeIDs []string, date time.Time) (int, error) {
+	query := `SELECT COUNT(*)
+		FROM shift_swaps
+		WHERE
+			status='pending' AND
+			date=$2 AND
+			(requester_id = ANY($1::uuid[]) OR colleague_id = ANY($1::uuid[]))`
+
+	var count int
+	err := db.QueryRow(c, query, employeeIDs, date).Scan(&count)
+	if err != nil {
+		return 0, err
+	}
+
+	return count, err
+}
@@ -0,0 +1,512 @@
+package query
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/iqbaludinm/hr-microservice/user-service/model/domain"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type TrainingQuery interface {
+	CreateTraining(c context.Context, tx pgx.Tx, training domain.Training) error
+	UpdateTraining(c context.Context, tx pgx.Tx, training domain.Training) error
+	DeleteTraining(c context.Context, tx pgx.Tx, id string) error
+	CreateRequirement(c context.Context, tx pgx.Tx, requirement domain.TrainingRequirement) error
+	DeleteRequirements(c context.Context, tx pgx.Tx, trainingID string) error
+	CreateEnrollment(c context.Context, tx pgx.Tx, enrollment domain.TrainingEnrollment) error
+	UpdateEnrollment(c context.Context, tx pgx.Tx, enrollment domain.TrainingEnrollment) error
+	FindAllTraining(c context.Context, db *pgxpool.Pool, filter domain.TrainingQueryFilter) ([]domain.Training, error)
+	CountAllTraining(c context.Context, db *pgxpool.Pool, filter domain.TrainingQueryFilter) (int, error)
+	FindTrainingById(c context.Context, db *pgxpool.Pool, id string) (domain.Training, error)
+	FindRequirements(c context.Context, db *pgxpool.Pool, trainingID string) ([]domain.TrainingRequirement, error)
+	CountOpenEnrollment(c context.Context, db *pgxpool.Pool, trainingID string) (int, error)
+	FindAllEnrollment(c context.Context, db *pgxpool.Pool, filter domain.TrainingEnrollmentQueryFilter) ([]domain.TrainingEnrollment, error)
+	CountAllEnrollment(c context.Context, db *pgxpool.Pool, filter domain.TrainingEnrollmentQueryFilter) (int, error)
+	FindEnrollmentById(c context.Context, db *pgxpool.Pool, id string) (domain.TrainingEnrollment, error)
+	FindEnrolledEmployees(c context.Context, db *pgxpool.Pool, trainingID string, employeeIDs []string) ([]string, error)
+	FindMandatoryTrainings(c context.Context, db *pgxpool.Pool, expiryBefore time.Time) ([]domain.MandatoryTraining, error)
+}
+
+type TrainingQueryImpl struct {
+}
+
+func NewTraining() TrainingQuery {
+	return &TrainingQueryImpl{}
+}
+
+// the selected columns of the training. The order must match the 'scanTraining' function.
+const trainingColumns = `
+	t.id,
+	t.code,
+	t.name,
+	t.description,
+	t.provider,
+	t.duration_hours,
+	t.certificate_required,
+	t.certificate_validity_months,
+	t.created_at,
+	t.updated_at,
+	t.deleted_at`
+
+func scanTraining(row pgx.Row) (domain.Training, error) {
+	var data domain.Training
+	err := row.Scan(
+		&data.ID,
+		&data.Code,
+		&data.Name,
+		&data.Description,
+		&data.Provider,
+		&data.DurationHours,
+		&data.CertificateRequired,
+		&data.CertificateValidityMonths,
+		&data.CreatedAt,
+		&data.UpdatedAt,
+		&data.DeletedAt,
+	)
+
+	return data, err
+}
+
+// the selected columns of the training enrollment, joined with the training, the employee and the certificate.
+// The order must match the 'scanTrainingEnrollment' function.
+const trainingEnrollmentColumns = `
+	te.id,
+	te.training_id,
+	te.employee_id,
+	te.mandatory,
+	te.status,
+	te.due_date,
+	te.completed_on,
+	te.note,
+	te.certificate_document_id,
+	te.enrolled_by,
+	te.completed_by,
+	te.created_at,
+	te.updated_at,
+	t.code,
+	t.name,
+	e.employee_number,
+	u.name,
+	e.user_id,
+	COALESCE(dp.name, ''),
+	COALESCE(p.title, ''),
+	cd.expiry_date`
+
+const trainingEnrollmentJoins = `
+	JOIN trainings AS t ON t.id = te.training_id
+	JOIN employees AS e ON e.id = te.employee_id
+	JOIN users AS u ON u.id = e.user_id
+	LEFT JOIN departments AS dp ON dp.id = e.department_id
+	LEFT JOIN positions AS p ON p.id = e.position_id
+	LEFT JOIN employee_documents AS cd ON cd.id = te.certificate_document_id AND cd.deleted_at IS NULL`
+
+func scanTrainingEnrollment(row pgx.Row) (domain.TrainingEnrollment, error) {
+	var data domain.TrainingEnrollment
+	err := row.Scan(
+		&data.ID,
+		&data.TrainingID,
+		&data.EmployeeID,
+		&data.Mandatory,
+		&data.Status,
+		&data.DueDate,
+		&data.CompletedOn,
+		&data.Note,
+		&data.CertificateDocumentID,
+		&data.EnrolledBy,
+		&data.CompletedBy,
+		&data.CreatedAt,
+		&data.UpdatedAt,
+		&data.TrainingCode,
+		&data.TrainingName,
+		&data.EmployeeNumber,
+		&data.EmployeeName,
+		&data.EmployeeUserID,
+		&data.Department,
+		&data.Position,
+		&data.CertificateExpiryDate,
+	)
+
+	return data, err
+}
+
+func (repository *TrainingQueryImpl) CreateTraining(c context.Context, tx pgx.Tx, training domain.Training) error {
+	// build INSERT query
+	query := `INSERT INTO trainings (
+		"id",
+		"code",
+		"name",
+		"description",
+		"provider",
+		"duration_hours",
+		"certificate_required",
+		"certificate_validity_months",
+		"created_at",
+		"updated_at"
+		) VALUES ($1,$2,$3,$4,$5,$6,$7,$8,$9,$10)`
+
+	_, err := tx.Exec(c, query,
+		training.ID,
+		training.Code,
+		training.Name,
+		training.Description,
+		training.Provider,
+		training.DurationHours,
+		training.CertificateRequired,
+		training.CertificateValidityMonths,
+		training.CreatedAt,
+		training.UpdatedAt,
+	)
+
+	return err
+}
+
+func (repository *TrainingQueryImpl) UpdateTraining(c context.Context, tx pgx.Tx, training domain.Training) error {
+	// build UPDATE query
+	query := `UPDATE trainings SET
+		code=$1,
+		name=$2,
+		description=$3,
+		provider=$4,
+		duration_hours=$5,
+		certificate_required=$6,
+		certificate_validity_months=$7,
+		updated_at=$8
+		WHERE id=$9`
+
+	_, err := tx.Exec(c, query,
+		training.Code,
+		training.Name,
+		training.Description,
+		training.Provider,
+		training.DurationHours,
+		training.CertificateRequired,
+		training.CertificateValidityMonths,
+		training.UpdatedAt,
+		training.ID,
+	)
+
+	return err
+}
+
+func (repository *TrainingQueryImpl) DeleteTraining(c context.Context, tx pgx.Tx, id string) error {
+	// build UPDATE query
+	query := `UPDATE trainings SET deleted_at=$1 WHERE id=$2`
+
+	_, err := tx.Exec(c, query, time.Now(), id)
+
+	return err
+}
+
+func (repository *TrainingQueryImpl) CreateRequirement(c context.Context, tx pgx.Tx, requirement domain.TrainingRequirement) error {
+	// build INSERT query
+	query := `INSERT INTO training_requirements (
+		"training_id",
+		"position_id",
+		"due_days",
+		"created_at"
+		) VALUES ($1,$2,$3,$4)`
+
+	_, err := tx.Exec(c, query,
+		requirement.TrainingID,
+		requirement.PositionID,
+		requirement.DueDays,
+		requirement.CreatedAt,
+	)
+
+	return err
+}
+
+// delete the requirements of the training, the requirements are replaced when the training is updated
+func (repository *TrainingQueryImpl) DeleteRequirements(c context.Context, tx pgx.Tx, trainingID string) error {
+	query := `DELETE FROM training_requirements WHERE training_id=$1`
+
+	_, err := tx.Exec(c, query, trainingID)
+
+	return err
+}
+
+func (repository *TrainingQueryImpl) CreateEnrollment(c context.Context, tx pgx.Tx, enrollment domain.TrainingEnrollment) error {
+	// build INSERT query
+	query := `INSERT INTO training_enrollments (
+		"id",
+		"training_id",
+		"employee_id",
+		"mandatory",
+		"status",
+		"due_date",
+		"enrolled_by",
+		"created_at",
+		"updated_at"
+		) VALUES ($1,$2,$3,$4,$5,$6,$7,$8,$9)`
+
+	_, err := tx.Exec(c, query,
+		enrollment.ID,
+		enrollment.TrainingID,
+		enrollment.EmployeeID,
+		enrollment.Mandatory,
+		enrollment.Status,
+		enrollment.DueDate,
+		enrollment.EnrolledBy,
+		enrollment.CreatedAt,
+		enrollment.UpdatedAt,
+	)
+
+	return err
+}
+
+// update the enrollment when it is completed or cancelled
+func (repository *TrainingQueryImpl) UpdateEnrollment(c context.Context, tx pgx.Tx, enrollment domain.TrainingEnrollment) error {
+	// build UPDATE query
+	query := `UPDATE training_enrollments SET
+		status=$1,
+		completed_on=$2,
+		note=$3,
+		certificate_document_id=$4,
+		completed_by=$5,
+		updated_at=$6
+		WHERE id=$7`
+
+	_, err := tx.Exec(c, query,
+		enrollment.Status,
+		enrollment.CompletedOn,
+		enrollment.Note,
+		enrollment.CertificateDocumentID,
+		enrollment.CompletedBy,
+		enrollment.UpdatedAt,
+		enrollment.ID,
+	)
+
+	return err
+}
+
+func (repository *TrainingQueryImpl) FindAllTraining(c context.Context, db *pgxpool.Pool, filter domain.TrainingQueryFilter) ([]domain.Training, error) {
+	// training query filter builders
+	filterString, args, pagination := filter.BuildTrainingQueries()
+
+	query := fmt.Sprintf(
+		`SELECT %s
+		FROM trainings AS t
+		%s
+		ORDER BY t.name
+		%s`,
+		trainingColumns, filterString, pagination,
+	)
+	rows, err := db.Query(c, query, args...)
+	if err != nil {
+		return []domain.Training{}, err
+	}
+	defer rows.Close()
+
+	var datas []domain.Training
+	for rows.Next() {
+		data, err := scanTraining(rows)
+		if err != nil {
+			return []domain.Training{}, err
+		}
+		datas = append(datas, data)
+	}
+
+	return datas, rows.Err()
+}
+
+func (repository *TrainingQueryImpl) CountAllTraining(c context.Context, db *pgxpool.Pool, filter domain.TrainingQueryFilter) (int, error) {
+	// training query filter builders
+	filterString, args, _ := filter.BuildTrainingQueries()
+
+	query := fmt.Sprintf(`SELECT COUNT(*) FROM trainings AS t %s`, filterString)
+
+	var count int
+	err := db.QueryRow(c, query, args...).Scan(&count)
+	if err != nil {
+		return 0, err
+	}
+
+	return count, err
+}
+
+func (repository *TrainingQueryImpl) FindTrainingById(c context.Context, db *pgxpool.Pool, id string) (domain.Training, error) {
+	query := `SELECT ` + trainingColumns + ` FROM trainings AS t WHERE t.deleted_at IS NULL AND t.id=$1`
+
+	return scanTraining(db.QueryRow(c, query, id))
+}
+
+func (repository *TrainingQueryImpl) FindRequirements(c context.Context, db *pgxpool.Pool, trainingID string) ([]domain.TrainingRequirement, error) {
+	query := `SELECT
+		tr.training_id,
+		tr.position_id,
+		tr.due_days,
+		tr.created_at,
+		p.title
+		FROM training_requirements AS tr
+		JOIN positions AS p ON p.id = tr.position_id
+		WHERE tr.training_id=$1
+		ORDER BY p.title`
+
+	rows, err := db.Query(c, query, trainingID)
+	if err != nil {
+		return []domain.TrainingRequirement{}, err
+	}
+	defer rows.Close()
+
+	var datas []domain.TrainingRequirement
+	for rows.Next() {
+		var data domain.TrainingRequirement
+		err := rows.Scan(
+			&data.TrainingID,
+			&data.PositionID,
+			&data.DueDays,
+			&data.CreatedAt,
+			&data.Position,
+		)
+		if err != nil {
+			return []domain.TrainingRequirement{}, err
+		}
+		datas = append(datas, data)
+	}
+
+	return datas, rows.Err()
+}
+
+// count the enrollments of the training which are not completed or cancelled yet
+func (repository *TrainingQueryImpl) CountOpenEnrollment(c context.Context, db *pgxpool.Pool, trainingID string) (int, error) {
+	query := `SELECT COUNT(*) FROM training_enrollments WHERE training_id=$1 AND status='enrolled'`
+
+	var count int
+	err := db.QueryRow(c, query, trainingID).Scan(&count)
+	if err != nil {
+		return 0, err
+	}
+
+	return count, err
+}
+
+func (repository *TrainingQueryImpl) FindAllEnrollment(c context.Context, db *pgxpool.Pool, filter domain.TrainingEnrollmentQueryFilter) ([]domain.TrainingEnrollment, error) {
+	// training enrollment query filter builders
+	filterString, args, pagination := filter.BuildTrainingEnrollmentQueries()
+
+	query := fmt.Sprintf(
+		`SELECT %s
+		FROM training_enrollments AS te
+		%s
+		%s
+		ORDER BY te.status <> 'enrolled', te.due_date NULLS LAST, te.created_at DESC
+		%s`,
+		trainingEnrollmentColumns, trainingEnrollmentJoins, filterString, pagination,
+	)
+	rows, err := db.Query(c, query, args...)
+	if err != nil {
+		return []domain.TrainingEnrollment{}, err
+	}
+	defer rows.Close()
+
+	var datas []domain.TrainingEnrollment
+	for rows.Next() {
+		data, err := scanTrainingEnrollment(rows)
+		if err != nil {
+			return []domain.TrainingEnrollment{}, err
+		}
+		datas = append(datas, data)
+	}
+
+	return datas, rows.Err()
+}
+
+func (repository *TrainingQueryImpl) CountAllEnrollment(c context.Context, db *pgxpool.Pool, filter domain.TrainingEnrollmentQueryFilter) (int, error) {
+	// training enrollment query filter builders
+	filterString, args, _ := filter.BuildTrainingEnrollmentQueries()
+
+	query := fmt.Sprintf(`SELECT COUNT(*) FROM training_enrollments AS te JOIN employees AS e ON e.id = te.employee_id %s`, filterString)
+
+	var count int
+	err := db.QueryRow(c, query, args...).Scan(&count)
+	if err != nil {
+		return 0, err
+	}
+
+	return count, err
+}
+
+func (repository *TrainingQueryImpl) FindEnrollmentById(c context.Context, db *pgxpool.Pool, id string) (domain.TrainingEnrollment, error) {
+	query := `SELECT ` + trainingEnrollmentColumns + ` FROM training_enrollments AS te ` + trainingEnrollmentJoins + ` WHERE te.id=$1`
+
+	return scanTrainingEnrollment(db.QueryRow(c, query, id))
+}
+
+// find which of the employees are enrolled in the training and have not completed it yet
+func (repository *TrainingQueryImpl) FindEnrolledEmployees(c context.Context, db *pgxpool.Pool, trainingID string, employeeIDs []string) ([]string, error) {
+	query := `SELECT employee_id FROM training_enrollments
+		WHERE training_id=$1 AND employee_id = ANY($2) AND status='enrolled'`
+
+	rows, err := db.Query(c, query, trainingID, employeeIDs)
+	if err != nil {
+		return []string{}, err
+	}
+	defer rows.Close()
+
+	var datas []string
+	for rows.Next() {
+		var data string
+		if err := rows.Scan(&data); err != nil {
+			return []string{}, err
+		}
+		datas = append(datas, data)
+	}
+
+	return datas, rows.Err()
+}
+
+// find the active employees who must be enrolled in the mandatory trainings of their positions. The employee is
+// not enrolled when the training is in progress, or when it is completed and the certificate is valid after the
+// expiryBefore date. The completion without a certificate or with a certificate which doesn't expire stays valid.
+func (repository *TrainingQueryImpl) FindMandatoryTrainings(c context.Context, db *pgxpool.Pool, expiryBefore time.Time) ([]domain.MandatoryTraining, error) {
+	query := `SELECT
+		tr.training_id,
+		t.name,
+		tr.due_days,
+		e.id,
+		e.user_id,
+		(SELECT MAX(d.expiry_date) FROM training_enrollments AS ce
+			JOIN employee_documents AS d ON d.id = ce.certificate_document_id AND d.deleted_at IS NULL
+			WHERE ce.training_id = tr.training_id AND ce.employee_id = e.id AND ce.status = 'completed')
+		FROM training_requirements AS tr
+		JOIN trainings AS t ON t.id = tr.training_id AND t.deleted_at IS NULL
+		JOIN employees AS e ON e.position_id = tr.position_id AND e.deleted_at IS NULL AND e.status = 'active'
+		WHERE NOT EXISTS (
+			SELECT 1 FROM training_enrollments AS te
+			WHERE te.training_id = tr.training_id AND te.employee_id = e.id AND te.status = 'enrolled'
+		) AND NOT EXISTS (
+			SELECT 1 FROM training_enrollments AS te
+			LEFT JOIN employee_documents AS d ON d.id = te.certificate_document_id AND d.deleted_at IS NULL
+			WHERE te.training_id = tr.training_id AND te.employee_id = e.id AND te.status = 'completed'
+			AND (d.expiry_date IS NULL OR d.expiry_date > $1)
+		)
+		ORDER BY t.name, e.employee_number`
+
+	rows, err := db.Query(c, query, expiryBefore)
+	if err != nil {
+		return []domain.MandatoryTraining{}, err
+	}
+	defer rows.Close()
+
+	var datas []domain.MandatoryTraining
+	for rows.Next() {
+		var data domain.MandatoryTraining
+		err := rows.Scan(
+			&data.TrainingID,
+			&data.TrainingName,
+			&data.DueDays,
+			&data.EmployeeID,
+			&data.EmployeeUserID,
+			&data.CertificateExpiryDate,
+		)
+		if err != nil {
+			return []domain.MandatoryTraining{}, err
+		}
+		datas = append(datas, data)
+	}
+
+	return datas, rows.Err()
+}
@@ -14,11 +14,14 @@ type UserQuery interface {
 	CreateUser(c context.Context, tx pgx.Tx, user domain.User) error
 	UpdateUser(c context.Context, tx pgx.Tx, id string, user domain.User) error
 	UpdatePassword(c context.Context, tx pgx.Tx, user domain.User) error
+	UpdateAvatar(c context.Context, tx pgx.Tx, user domain.User) error
 	Delete(c context.Context, tx pgx.Tx, id string) error
+	IsDeleted(c context.Context, db *pgxpool.Pool, id string) (bool, error)
 	FindAllUser(c context.Context, db *pgxpool.Pool, filter domain.UserQueryFilter) ([]domain.User, error)
 	FindById(c context.Context, db *pgxpool.Pool, id string, filter domain.UserQueryFilter) (domain.User, error)
 	FindByEmail(c context.Context, db *pgxpool.Pool, email string) (domain.User, error)
 	FindByPhoneNumber(c context.Context, db *pgxpool.Pool, phone string) (domain.User, error)
+	FindByEmailsOrPhones(c context.Context, db *pgxpool.Pool, emails, phones []string) ([]domain.User, error)
 	FindUserNotDeleteByQuery(c context.Context, db *pgxpool.Pool, query, value string) (domain.User, error) // forgot-pass
 	CheckTokenWithQuery(ctx context.Context, db pgx.Tx, query, value string) (domain.ResetPasswordToken, error)
 	CountAllUser(c context.Context, db *pgxpool.Pool, filter domain.UserQueryFilter) (int, error)
@@ -58,13 +61,22 @@ func (repository *UserQueryImpl) UpdatePassword(c context.Context, tx pgx.Tx, us
 	return nil
 }
 
+func (repository *UserQueryImpl) UpdateAvatar(c context.Context, tx pgx.Tx, user domain.User) error {
+	// build UPDATE query
+	query := `UPDATE users SET avatar_url=$1, updated_at=$2 WHERE id=$3`
+
+	_, err := tx.Exec(c, query, user.AvatarURL, user.UpdatedAt, user.ID)
+
+	return err
+}
+
 func (repository *UserQueryImpl) FindUserNotDeleteByQuery(c context.Context, db *pgxpool.Pool, query, value string) (domain.User, error) {
 	var data domain.User
-	queryStr := fmt.Sprintf("SELECT * FROM %s WHERE %s = $1 AND deleted_at is NULL", "users", query)
+	queryStr := fmt.Sprintf("SELECT id, name, email, password, phone, created_at, updated_at, deleted_at, avatar_url FROM %s WHERE %s = $1 AND deleted_at is NULL", "users", query)
 
 	row := db.QueryRow(c, queryStr, value)
 
-	err := row.Scan(&data.ID, &data.Name, &data.Email, &data.Password, &data.Phone, &data.CreatedAt, &data.UpdatedAt, &data.DeletedAt)
+	err := row.Scan(&data.ID, &data.Name, &data.Email, &data.Password, &data.Phone, &data.CreatedAt, &data.UpdatedAt, &data.DeletedAt, &data.AvatarURL)
 
 	if err != nil {
 		return domain.User{}, err
@@ -75,7 +87,15 @@ func (repository *UserQueryImpl) FindUserNotDeleteByQuery(c context.Context, db
 
 func (repository *UserQueryImpl) FindById(c context.Context, db *pgxpool.Pool, id string, filter domain.UserQueryFilter) (domain.User, error) {
 	// user query filter builders
-	filterString, _ := filter.BuildUserQueries()
+	filterString, args, _ := filter.BuildUserQueries()
+
+	// the id is the last arg of the filter
+	args = append(args, id)
+	if filterString == "" {
+		filterString = fmt.Sprintf("WHERE u.id=$%d", len(args))
+	} else {
+		filterString += fmt.Sprintf(" AND u.id=$%d", len(args))
+	}
 
 	// build SELECT query
 	query := fmt.Sprintf(
@@ -87,18 +107,17 @@ func (repository *UserQueryImpl) FindById(c context.Context, db *pgxpool.Pool, i
 			u.phone, 
 			u.created_at, 
 			u.updated_at, 
-			u.deleted_at
+			u.deleted_at,
+			u.avatar_url
 		FROM users AS u
-		WHERE
-			%s
-			AND id=$1
+		%s
 		`,
 		filterString,
 	)
-	row := db.QueryRow(c, query, id)
+	row := db.QueryRow(c, query, args...)
 
 	var data domain.User
-	err := row.Scan(&data.ID, &data.Name, &data.Email, &data.Password, &data.Phone, &data.CreatedAt, &data.UpdatedAt, &data.DeletedAt)
+	err := row.Scan(&data.ID, &data.Name, &data.Email, &data.Password, &data.Phone, &data.CreatedAt, &data.UpdatedAt, &data.DeletedAt, &data.AvatarURL)
 
 	return data, err
 }
@@ -106,7 +125,7 @@ func (repository *UserQueryImpl) FindById(c context.Context, db *pgxpool.Pool, i
 func (repository *UserQueryImpl) FindByEmail(c context.Context, db *pgxpool.Pool, email string) (domain.User, error) {
 	// build SELECT query
 	query := `
-		SELECT u.id, u.name, u.email, u.password, u.phone, u.created_at, u.updated_at, u.deleted_at
+		SELECT u.id, u.name, u.email, u.password, u.phone, u.created_at, u.updated_at, u.deleted_at, u.avatar_url
 			FROM users AS u
 			WHERE
 				u.deleted_at is null AND
@@ -115,7 +134,7 @@ func (repository *UserQueryImpl) FindByEmail(c context.Context, db *pgxpool.Pool
 	row := db.QueryRow(c, query, email)
 
 	var data domain.User
-	err := row.Scan(&data.ID, &data.Name, &data.Email, &data.Password, &data.Phone, &data.CreatedAt, &data.UpdatedAt, &data.DeletedAt)
+	err := row.Scan(&data.ID, &data.Name, &data.Email, &data.Password, &data.Phone, &data.CreatedAt, &data.UpdatedAt, &data.DeletedAt, &data.AvatarURL)
 
 	return data, err
 }
@@ -123,7 +142,7 @@ func (repository *UserQueryImpl) FindByEmail(c context.Context, db *pgxpool.Pool
 func (repository *UserQueryImpl) FindByPhoneNumber(c context.Context, db *pgxpool.Pool, phone string) (domain.User, error) {
 	// build SELECT query
 	query := `
-		SELECT u.id, u.name, u.email, u.password, u.phone, u.created_at, u.updated_at, u.deleted_at
+		SELECT u.id, u.name, u.email, u.password, u.phone, u.created_at, u.updated_at, u.deleted_at, u.avatar_url
 			FROM users AS u 
 			WHERE
 				u.deleted_at is null AND
@@ -132,19 +151,49 @@ func (repository *UserQueryImpl) FindByPhoneNumber(c context.Context, db *pgxpoo
 	row := db.QueryRow(c, query, phone)
 
 	var data domain.User
-	err := row.Scan(&data.ID, &data.Name, &data.Email, &data.Password, &data.Phone, &data.CreatedAt, &data.UpdatedAt, &data.DeletedAt)
+	err := row.Scan(&data.ID, &data.Name, &data.Email, &data.Password, &data.Phone, &data.CreatedAt, &data.UpdatedAt, &data.DeletedAt, &data.AvatarURL)
 
 	return data, err
 }
 
+// find the users with one of the emails or one of the phones, the deleted users are included since they still hold
+// the email and the phone
+func (repository *UserQueryImpl) FindByEmailsOrPhones(c context.Context, db *pgxpool.Pool, emails, phones []string) ([]domain.User, error) {
+	// build SELECT query
+	query := `
+		SELECT u.id, u.name, u.email, u.password, u.phone, u.created_at, u.updated_at, u.deleted_at, u.avatar_url
+			FROM users AS u
+			WHERE
+				u.email = ANY($1) OR
+				u.phone = ANY($2)
+		`
+	rows, err := db.Query(c, query, emails, phones)
+	if err != nil {
+		return []domain.User{}, err
+	}
+	defer rows.Close()
+
+	var datas []domain.User
+	for rows.Next() {
+		var data domain.User
+		err := rows.Scan(&data.ID, &data.Name, &data.Email, &data.Password, &data.Phone, &data.CreatedAt, &data.UpdatedAt, &data.DeletedAt, &data.AvatarURL)
+		if err != nil {
+			return []domain.User{}, err
+		}
+		datas = append(datas, data)
+	}
+
+	return datas, rows.Err()
+}
+
 func (repository *UserQueryImpl) FindAllUser(c context.Context, db *pgxpool.Pool, filter domain.UserQueryFilter) ([]domain.User, error) {
 	// user query filter builders
-	filterString, _ := filter.BuildUserQueries()
+	filterString, args, _ := filter.BuildUserQueries()
 
 	query := fmt.Sprintf(
-		`SELECT * FROM users as u %s`, filterString,
+		`SELECT u.id, u.name, u.email, u.password, u.phone, u.created_at, u.updated_at, u.deleted_at, u.avatar_url FROM users as u %s`, filterString,
 	)
-	rows, err := db.Query(c, query)
+	rows, err := db.Query(c, query, args...)
 	if err != nil {
 		return []domain.User{}, err
 	}
@@ -153,7 +202,7 @@ func (repository *UserQueryImpl) FindAllUser(c context.Context, db *pgxpool.Pool
 	var datas []domain.User
 	for rows.Next() {
 		var data domain.User
-		err := rows.Scan(&data.ID, &data.Name, &data.Email, &data.Password, &data.Phone, &data.CreatedAt, &data.UpdatedAt, &data.DeletedAt)
+		err := rows.Scan(&data.ID, &data.Name, &data.Email, &data.Password, &data.Phone, &data.CreatedAt, &data.UpdatedAt, &data.DeletedAt, &data.AvatarURL)
 		if err != nil {
 			return []domain.User{}, err
 		}
@@ -195,16 +244,29 @@ func (repository *UserQueryImpl) CreateUser(c context.Context, tx pgx.Tx, user d
 
 func (repository *UserQueryImpl) Delete(c context.Context, tx pgx.Tx, id string) error {
 	// build UPDATE query
-	query := `UPDATE users SET deleted_at=$1 WHERE id=$1`
+	query := `UPDATE users SET deleted_at=$1 WHERE id=$2`
 
 	_, err := tx.Exec(c, query, time.Now(), id)
 
 	return err
 }
 
+// check whether the user is deleted, the user which is not found is not deleted
+func (repository *UserQueryImpl) IsDeleted(c context.Context, db *pgxpool.Pool, id string) (bool, error) {
+	query := `SELECT deleted_at IS NOT NULL FROM users WHERE id=$1`
+
+	var deleted bool
+	err := db.QueryRow(c, query, id).Scan(&deleted)
+	if err == pgx.ErrNoRows {
+		return false, nil
+	}
+
+	return deleted, err
+}
+
 func (r *UserQueryImpl) CountAllUser(c context.Context, db *pgxpool.Pool, filter domain.UserQueryFilter) (int, error) {
 	// user query filter builders
-	filterString, _ := filter.BuildUserQueries()
+	filterString, args, _ := filter.BuildUserQueries()
 
 	query := fmt.Sprintf(
 		`SELECT
@@ -215,7 +277,7 @@ func (r *UserQueryImpl) CountAllUser(c context.Context, db *pgxpool.Pool, filter
 		filterString,
 	)
 
-	row := db.QueryRow(c, query)
+	row := db.QueryRow(c, query, args...)
 
 	var count int
 	err := row.Scan(&count)
@@ -0,0 +1,148 @@
+package query
+
+import (
+	"context"
+	"time"
+
+	"github.com/iqbaludinm/hr-microservice/user-service/model/domain"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type WorkCalendarQuery interface {
+	CreateWorkCalendar(c context.Context, tx pgx.Tx, calendar domain.WorkCalendar) error
+	UpdateWorkCalendar(c context.Context, tx pgx.Tx, id string, calendar domain.WorkCalendar) error
+	Delete(c context.Context, tx pgx.Tx, id string) error
+	ClearDefault(c context.Context, tx pgx.Tx, exceptID string) error
+	FindAllWorkCalendar(c context.Context, db *pgxpool.Pool) ([]domain.WorkCalendar, error)
+	FindById(c context.Context, db *pgxpool.Pool, id string) (domain.WorkCalendar, error)
+	CountLocations(c context.Context, db *pgxpool.Pool, id string) (int, error)
+}
+
+type WorkCalendarQueryImpl struct {
+}
+
+func NewWorkCalendar() WorkCalendarQuery {
+	return &WorkCalendarQueryImpl{}
+}
+
+// the selected columns of the work calendar. The order must match the 'scanWorkCalendar' function.
+const workCalendarColumns = `id, code, name, weekend_days, is_default, created_at, updated_at, deleted_at`
+
+func scanWorkCalendar(row pgx.Row) (domain.WorkCalendar, error) {
+	var data domain.WorkCalendar
+	err := row.Scan(
+		&data.ID,
+		&data.Code,
+		&data.Name,
+		&data.WeekendDays,
+		&data.IsDefault,
+		&data.CreatedAt,
+		&data.UpdatedAt,
+		&data.DeletedAt,
+	)
+
+	return data, err
+}
+
+func (repository *WorkCalendarQueryImpl) CreateWorkCalendar(c context.Context, tx pgx.Tx, calendar domain.WorkCalendar) error {
+	// build INSERT query
+	query := `INSERT INTO work_calendars (
+		"id",
+		"code",
+		"name",
+		"weekend_days",
+		"is_default",
+		"created_at",
+		"updated_at"
+		) VALUES ($1,$2,$3,$4,$5,$6,$7)`
+
+	_, err := tx.Exec(c, query,
+		calendar.ID,
+		calendar.Code,
+		calendar.Name,
+		calendar.WeekendDays,
+		calendar.IsDefault,
+		calendar.CreatedAt,
+		calendar.UpdatedAt,
+	)
+
+	return err
+}
+
+func (repository *WorkCalendarQueryImpl) UpdateWorkCalendar(c context.Context, tx pgx.Tx, id string, calendar domain.WorkCalendar) error {
+	// build UPDATE query
+	query := `UPDATE work_calendars SET
+		name=$1,
+		weekend_days=$2,
+		is_default=$3,
+		updated_at=$4
+		WHERE id=$5`
+
+	_, err := tx.Exec(c, query,
+		calendar.Name,
+		calendar.WeekendDays,
+		calendar.IsDefault,
+		calendar.UpdatedAt,
+		id,
+	)
+
+	return err
+}
+
+func (repository *WorkCalendarQueryImpl) Delete(c context.Context, tx pgx.Tx, id string) error {
+	// build UPDATE query
+	query := `UPDATE work_calendars SET deleted_at=$1 WHERE id=$2`
+
+	_, err := tx.Exec(c, query, time.Now(), id)
+
+	return err
+}
+
+func (repository *WorkCalendarQueryImpl) ClearDefault(c context.Context, tx pgx.Tx, exceptID string) error {
+	query := `UPDATE work_calendars SET is_default=false, updated_at=$1 WHERE is_default AND id<>$2`
+
+	_, err := tx.Exec(c, query, time.Now(), exceptID)
+
+	return err
+}
+
+func (repository *WorkCalendarQueryImpl) FindAllWorkCalendar(c context.Context, db *pgxpool.Pool) ([]domain.WorkCalendar, error) {
+	query := `SELECT ` + workCalendarColumns + ` FROM work_calendars WHERE deleted_at is null ORDER BY is_default DESC, name`
+
+	rows, err := db.Query(c, query)
+	if err != nil {
+		return []domain.WorkCalendar{}, err
+	}
+	defer rows.Close()
+
+	var datas []domain.WorkCalendar
+	for rows.Next() {
+		data, err := scanWorkCalendar(rows)
+		if err != nil {
+			return []domain.WorkCalendar{}, err
+		}
+		datas = append(datas, data)
+	}
+
+	return datas, rows.Err()
+}
+
+func (repository *WorkCalendarQueryImpl) FindById(c context.Context, db *pgxpool.Pool, id string) (domain.WorkCalendar, error) {
+	query := `SELECT ` + workCalendarColumns + ` FROM work_calendars WHERE deleted_at is null AND id=$1`
+
+	return scanWorkCalendar(db.QueryRow(c, query, id))
+}
+
+// count the office locations that use the calendar
+func (repository *WorkCalendarQueryImpl) CountLocations(c context.Context, db *pgxpool.Pool, id string) (int, error) {
+	query := `SELECT COUNT(*) FROM office_locations WHERE work_calendar_id=$1 AND deleted_at is null`
+
+	var count int
+	err := db.QueryRow(c, query, id).Scan(&count)
+	if err != nil {
+		return 0, err
+	}
+
+	return count, err
+}
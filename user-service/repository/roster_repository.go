@@ -0,0 +1,141 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/iqbaludinm/hr-microservice/user-service/model/domain"
+	"github.com/iqbaludinm/hr-microservice/user-service/repository/query"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type RosterRepository interface {
+	UpsertRosters(c context.Context, rosters []domain.Roster) error
+	DeleteRoster(c context.Context, employeeIDs []string, from, to time.Time) error
+	FindAllRoster(c context.Context, filter domain.RosterQueryFilter) ([]domain.Roster, error)
+	FindByEmployeeDate(c context.Context, employeeID string, date time.Time) (domain.Roster, error)
+	UpdateCalendarToken(c context.Context, employeeID, token string) error
+	FindCalendarToken(c context.Context, employeeID string) (*string, error)
+	FindEmployeeIdByCalendarToken(c context.Context, token string) (string, error)
+}
+
+type rosterRepository struct {
+	db          Store
+	RosterQuery query.RosterQuery
+}
+
+func NewRoster(db Store, q query.RosterQuery) RosterRepository {
+	return &rosterRepository{
+		db:          db,
+		RosterQuery: q,
+	}
+}
+
+// insert or replace the rosters, the rosters are saved together or not at all
+func (r *rosterRepository) UpsertRosters(c context.Context, rosters []domain.Roster) error {
+	var err error
+
+	// create transaction to save rosters
+	err = r.db.WithTransaction(c, func(tx pgx.Tx) error {
+		for _, roster := range rosters {
+			// save roster, if error will rollback
+			if err = r.RosterQuery.UpsertRoster(c, tx, roster); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+
+	return err
+}
+
+func (r *rosterRepository) DeleteRoster(c context.Context, employeeIDs []string, from, to time.Time) error {
+	var err error
+
+	// create transaction to delete rosters
+	err = r.db.WithTransaction(c, func(tx pgx.Tx) error {
+		// delete the rosters of the employees in the range, if error will rollback
+		if err = r.RosterQuery.DeleteRoster(c, tx, employeeIDs, from, to); err != nil {
+			return err
+		}
+		return nil
+	})
+
+	return err
+}
+
+func (r *rosterRepository) FindAllRoster(c context.Context, filter domain.RosterQueryFilter) ([]domain.Roster, error) {
+	var rosters []domain.Roster
+	var err error
+
+	// get rosters without transaction.
+	err = r.db.WithoutTransaction(c, func(db *pgxpool.Pool) error {
+		if rosters, err = r.RosterQuery.FindAllRoster(c, db, filter); err != nil {
+			return err
+		}
+		return nil
+	})
+
+	return rosters, err
+}
+
+func (r *rosterRepository) FindByEmployeeDate(c context.Context, employeeID string, date time.Time) (domain.Roster, error) {
+	var roster domain.Roster
+	var err error
+
+	// get roster of the employee on the date without transaction.
+	err = r.db.WithoutTransaction(c, func(db *pgxpool.Pool) error {
+		if roster, err = r.RosterQuery.FindByEmployeeDate(c, db, employeeID, date); err != nil {
+			return err
+		}
+		return nil
+	})
+
+	return roster, err
+}
+
+func (r *rosterRepository) UpdateCalendarToken(c context.Context, employeeID, token string) error {
+	var err error
+
+	// create transaction to update calendar token
+	err = r.db.WithTransaction(c, func(tx pgx.Tx) error {
+		// update the calendar token of the employee, if error will rollback
+		if err = r.RosterQuery.UpdateCalendarToken(c, tx, employeeID, token); err != nil {
+			return err
+		}
+		return nil
+	})
+
+	return err
+}
+
+func (r *rosterRepository) FindCalendarToken(c context.Context, employeeID string) (*string, error) {
+	var token *string
+	var err error
+
+	// get calendar token of the employee without transaction.
+	err = r.db.WithoutTransaction(c, func(db *pgxpool.Pool) error {
+		if token, err = r.RosterQuery.FindCalendarToken(c, db, employeeID); err != nil {
+			return err
+		}
+		return nil
+	})
+
+	return token, err
+}
+
+func (r *rosterRepository) FindEmployeeIdByCalendarToken(c context.Context, token string) (string, error) {
+	var employeeID string
+	var err error
+
+	// get employee id of the calendar token without transaction.
+	err = r.db.WithoutTransaction(c, func(db *pgxpool.Pool) error {
+		if employeeID, err = r.RosterQuery.FindEmployeeIdByCalendarToken(c, db, token); err != nil {
+			return err
+		}
+		return nil
+	})
+
+	return employeeID, err
+}
@@ -0,0 +1,121 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/iqbaludinm/hr-microservice/user-service/model/domain"
+	"github.com/iqbaludinm/hr-microservice/user-service/repository/query"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type ShiftPatternRepository interface {
+	CreatePattern(c context.Context, pattern domain.ShiftPattern) error
+	UpdatePattern(c context.Context, id string, pattern domain.ShiftPattern) error
+	Delete(c context.Context, id string) error
+	FindAllPattern(c context.Context) ([]domain.ShiftPattern, error)
+	FindById(c context.Context, id string) (domain.ShiftPattern, error)
+}
+
+type shiftPatternRepository struct {
+	db                Store
+	ShiftPatternQuery query.ShiftPatternQuery
+}
+
+func NewShiftPattern(db Store, q query.ShiftPatternQuery) ShiftPatternRepository {
+	return &shiftPatternRepository{
+		db:                db,
+		ShiftPatternQuery: q,
+	}
+}
+
+func (r *shiftPatternRepository) CreatePattern(c context.Context, pattern domain.ShiftPattern) error {
+	var err error
+
+	// create transaction to create shift pattern
+	err = r.db.WithTransaction(c, func(tx pgx.Tx) error {
+		// create shift pattern, if error will rollback
+		if err = r.ShiftPatternQuery.CreatePattern(c, tx, pattern); err != nil {
+			return err
+		}
+		// create the days of the pattern, if error will rollback
+		if err = r.ShiftPatternQuery.ReplaceDays(c, tx, pattern.ID, pattern.Days); err != nil {
+			return err
+		}
+		return nil
+	})
+
+	return err
+}
+
+func (r *shiftPatternRepository) UpdatePattern(c context.Context, id string, pattern domain.ShiftPattern) error {
+	var err error
+
+	// create transaction to update shift pattern
+	err = r.db.WithTransaction(c, func(tx pgx.Tx) error {
+		// update shift pattern by id, if error will rollback
+		if err = r.ShiftPatternQuery.UpdatePattern(c, tx, id, pattern); err != nil {
+			return err
+		}
+		// replace the days of the pattern, if error will rollback
+		if err = r.ShiftPatternQuery.ReplaceDays(c, tx, id, pattern.Days); err != nil {
+			return err
+		}
+		return nil
+	})
+
+	return err
+}
+
+func (r *shiftPatternRepository) Delete(c context.Context, id string) error {
+	var err error
+
+	// create transaction to delete shift pattern
+	err = r.db.WithTransaction(c, func(tx pgx.Tx) error {
+		// delete shift pattern by id, if error will rollback
+		if err = r.ShiftPatternQuery.Delete(c, tx, id); err != nil {
+			return err
+		}
+		return nil
+	})
+
+	return err
+}
+
+func (r *shiftPatternRepository) FindAllPattern(c context.Context) ([]domain.ShiftPattern, error) {
+	var patterns []domain.ShiftPattern
+	var err error
+
+	// get shift patterns with their days without transaction.
+	err = r.db.WithoutTransaction(c, func(db *pgxpool.Pool) error {
+		if patterns, err = r.ShiftPatternQuery.FindAllPattern(c, db); err != nil {
+			return err
+		}
+		for i := range patterns {
+			if patterns[i].Days, err = r.ShiftPatternQuery.FindDays(c, db, patterns[i].ID); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+
+	return patterns, err
+}
+
+func (r *shiftPatternRepository) FindById(c context.Context, id string) (domain.ShiftPattern, error) {
+	var pattern domain.ShiftPattern
+	var err error
+
+	// get shift pattern by id with its days without transaction.
+	err = r.db.WithoutTransaction(c, func(db *pgxpool.Pool) error {
+		if pattern, err = r.ShiftPatternQuery.FindById(c, db, id); err != nil {
+			return err
+		}
+		if pattern.Days, err = r.ShiftPatternQuery.FindDays(c, db, id); err != nil {
+			return err
+		}
+		return nil
+	})
+
+	return pattern, err
+}
@@ -0,0 +1,181 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/iqbaludinm/hr-microservice/user-service/model/domain"
+	"github.com/iqbaludinm/hr-microservice/user-service/repository/query"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type ShiftRepository interface {
+	CreateShift(c context.Context, shift domain.Shift) error
+	UpdateShift(c context.Context, id string, shift domain.Shift) error
+	Delete(c context.Context, id string) error
+	AssignEmployees(c context.Context, id string, employeeIDs []string) error
+	FindAllShift(c context.Context) ([]domain.Shift, error)
+	FindById(c context.Context, id string) (domain.Shift, error)
+	FindByEmployeeId(c context.Context, employeeID string) (domain.Shift, error)
+	CountEmployees(c context.Context, id string) (int, error)
+	CountPatterns(c context.Context, id string) (int, error)
+}
+
+type shiftRepository struct {
+	db         Store
+	ShiftQuery query.ShiftQuery
+}
+
+func NewShift(db Store, q query.ShiftQuery) ShiftRepository {
+	return &shiftRepository{
+		db:         db,
+		ShiftQuery: q,
+	}
+}
+
+func (r *shiftRepository) CreateShift(c context.Context, shift domain.Shift) error {
+	var err error
+
+	// create transaction to create shift
+	err = r.db.WithTransaction(c, func(tx pgx.Tx) error {
+		// the new default shift replaces the current default, if error will rollback
+		if shift.IsDefault {
+			if err = r.ShiftQuery.ClearDefault(c, tx, shift.ID); err != nil {
+				return err
+			}
+		}
+		// create shift, if error will rollback
+		if err = r.ShiftQuery.CreateShift(c, tx, shift); err != nil {
+			return err
+		}
+		return nil
+	})
+
+	return err
+}
+
+func (r *shiftRepository) UpdateShift(c context.Context, id string, shift domain.Shift) error {
+	var err error
+
+	// create transaction to update shift
+	err = r.db.WithTransaction(c, func(tx pgx.Tx) error {
+		// the new default shift replaces the current default, if error will rollback
+		if shift.IsDefault {
+			if err = r.ShiftQuery.ClearDefault(c, tx, id); err != nil {
+				return err
+			}
+		}
+		// update shift by id, if error will rollback
+		if err = r.ShiftQuery.UpdateShift(c, tx, id, shift); err != nil {
+			return err
+		}
+		return nil
+	})
+
+	return err
+}
+
+func (r *shiftRepository) Delete(c context.Context, id string) error {
+	var err error
+
+	// create transaction to delete shift
+	err = r.db.WithTransaction(c, func(tx pgx.Tx) error {
+		// delete shift by id, if error will rollback
+		if err = r.ShiftQuery.Delete(c, tx, id); err != nil {
+			return err
+		}
+		return nil
+	})
+
+	return err
+}
+
+func (r *shiftRepository) AssignEmployees(c context.Context, id string, employeeIDs []string) error {
+	var err error
+
+	// create transaction to assign shift
+	err = r.db.WithTransaction(c, func(tx pgx.Tx) error {
+		// assign the shift to the employees, if error will rollback
+		if err = r.ShiftQuery.AssignEmployees(c, tx, id, employeeIDs); err != nil {
+			return err
+		}
+		return nil
+	})
+
+	return err
+}
+
+func (r *shiftRepository) FindAllShift(c context.Context) ([]domain.Shift, error) {
+	var shifts []domain.Shift
+	var err error
+
+	// get shifts without transaction.
+	err = r.db.WithoutTransaction(c, func(db *pgxpool.Pool) error {
+		if shifts, err = r.ShiftQuery.FindAllShift(c, db); err != nil {
+			return err
+		}
+		return nil
+	})
+
+	return shifts, err
+}
+
+func (r *shiftRepository) FindById(c context.Context, id string) (domain.Shift, error) {
+	var shift domain.Shift
+	var err error
+
+	// get shift by id without transaction.
+	err = r.db.WithoutTransaction(c, func(db *pgxpool.Pool) error {
+		if shift, err = r.ShiftQuery.FindById(c, db, id); err != nil {
+			return err
+		}
+		return nil
+	})
+
+	return shift, err
+}
+
+func (r *shiftRepository) FindByEmployeeId(c context.Context, employeeID string) (domain.Shift, error) {
+	var shift domain.Shift
+	var err error
+
+	// get shift of the employee without transaction.
+	err = r.db.WithoutTransaction(c, func(db *pgxpool.Pool) error {
+		if shift, err = r.ShiftQuery.FindByEmployeeId(c, db, employeeID); err != nil {
+			return err
+		}
+		return nil
+	})
+
+	return shift, err
+}
+
+func (r *shiftRepository) CountEmployees(c context.Context, id string) (int, error) {
+	var count int
+	var err error
+
+	// count employees of the shift without transaction.
+	err = r.db.WithoutTransaction(c, func(db *pgxpool.Pool) error {
+		if count, err = r.ShiftQuery.CountEmployees(c, db, id); err != nil {
+			return err
+		}
+		return nil
+	})
+
+	return count, err
+}
+
+func (r *shiftRepository) CountPatterns(c context.Context, id string) (int, error) {
+	var count int
+	var err error
+
+	// count shift patterns of the shift without transaction.
+	err = r.db.WithoutTransaction(c, func(db *pgxpool.Pool) error {
+		if count, err = r.ShiftQuery.CountPatterns(c, db, id); err != nil {
+			return err
+		}
+		return nil
+	})
+
+	return count, err
+}
@@ -0,0 +1,132 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/iqbaludinm/hr-microservice/user-service/model/domain"
+	"github.com/iqbaludinm/hr-microservice/user-service/repository/query"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type ShiftSwapRepository interface {
+	CreateShiftSwap(c context.Context, swap domain.ShiftSwap) error
+	UpdateStatus(c context.Context, swap domain.ShiftSwap, rosters []domain.Roster) error
+	FindAllShiftSwap(c context.Context, filter domain.ShiftSwapQueryFilter) ([]domain.ShiftSwap, error)
+	CountAllShiftSwap(c context.Context, filter domain.ShiftSwapQueryFilter) (int, error)
+	FindById(c context.Context, id string) (domain.ShiftSwap, error)
+	CountPending(c context.Context, employeeIDs []string, date time.Time) (int, error)
+}
+
+type shiftSwapRepository struct {
+	db             Store
+	ShiftSwapQuery query.ShiftSwapQuery
+	RosterQuery    query.RosterQuery
+}
+
+func NewShiftSwap(db Store, q query.ShiftSwapQuery, rosterQuery query.RosterQuery) ShiftSwapRepository {
+	return &shiftSwapRepository{
+		db:             db,
+		ShiftSwapQuery: q,
+		RosterQuery:    rosterQuery,
+	}
+}
+
+func (r *shiftSwapRepository) CreateShiftSwap(c context.Context, swap domain.ShiftSwap) error {
+	var err error
+
+	// create transaction to create shift swap request
+	err = r.db.WithTransaction(c, func(tx pgx.Tx) error {
+		// create shift swap request, if error will rollback
+		if err = r.ShiftSwapQuery.CreateShiftSwap(c, tx, swap); err != nil {
+			return err
+		}
+		return nil
+	})
+
+	return err
+}
+
+// update the status of the shift swap request and save the swapped rosters, the rosters are only filled
+// when the shift swap request is approved
+func (r *shiftSwapRepository) UpdateStatus(c context.Context, swap domain.ShiftSwap, rosters []domain.Roster) error {
+	var err error
+
+	// create transaction to update shift swap request status
+	err = r.db.WithTransaction(c, func(tx pgx.Tx) error {
+		// update shift swap request status by id, if error will rollback
+		if err = r.ShiftSwapQuery.UpdateStatus(c, tx, swap.ID, swap); err != nil {
+			return err
+		}
+		for _, roster := range rosters {
+			// save the swapped roster, if error will rollback
+			if err = r.RosterQuery.UpsertRoster(c, tx, roster); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+
+	return err
+}
+
+func (r *shiftSwapRepository) FindAllShiftSwap(c context.Context, filter domain.ShiftSwapQueryFilter) ([]domain.ShiftSwap, error) {
+	var swaps []domain.ShiftSwap
+	var err error
+
+	// get shift swap requests without transaction.
+	err = r.db.WithoutTransaction(c, func(db *pgxpool.Pool) error {
+		if swaps, err = r.ShiftSwapQuery.FindAllShiftSwap(c, db, filter); err != nil {
+			return err
+		}
+		return nil
+	})
+
+	return swaps, err
+}
+
+func (r *shiftSwapRepository) CountAllShiftSwap(c context.Context, filter domain.ShiftSwapQueryFilter) (int, error) {
+	var count int
+	var err error
+
+	// count shift swap requests without transaction.
+	err = r.db.WithoutTransaction(c, func(db *pgxpool.Pool) error {
+		if count, err = r.ShiftSwapQuery.CountAllShiftSwap(c, db, filter); err != nil {
+			return err
+		}
+		return nil
+	})
+
+	return count, err
+}
+
+func (r *shiftSwapRepository) FindById(c context.Context, id string) (domain.ShiftSwap, error) {
+	var swap domain.ShiftSwap
+	var err error
+
+	// get shift swap request by id without transaction.
+	err = r.db.WithoutTransaction(c, func(db *pgxpool.Pool) error {
+		if swap, err = r.ShiftSwapQuery.FindById(c, db, id); err != nil {
+			return err
+		}
+		return nil
+	})
+
+	return swap, err
+}
+
+func (r *shiftSwapRepository) CountPending(c context.Context, employeeIDs []string, date time.Time) (int, error) {
+	var count int
+	var err error
+
+	// count pending shift swap requests without transaction.
+	err = r.db.WithoutTransaction(c, func(db *pgxpool.Pool) error {
+		if count, err = r.ShiftSwapQuery.CountPending(c, db, employeeIDs, date); err != nil {
+			return err
+		}
+		return nil
+	})
+
+	return count, err
+}
@@ -0,0 +1,304 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/iqbaludinm/hr-microservice/user-service/model/domain"
+	"github.com/iqbaludinm/hr-microservice/user-service/repository/query"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type TrainingRepository interface {
+	CreateTraining(c context.Context, training domain.Training) error
+	UpdateTraining(c context.Context, training domain.Training) error
+	DeleteTraining(c context.Context, id string) error
+	Enroll(c context.Context, enrollments []domain.TrainingEnrollment) error
+	Complete(c context.Context, enrollment domain.TrainingEnrollment, certificate *domain.EmployeeDocument, version *domain.EmployeeDocumentVersion) error
+	UpdateEnrollment(c context.Context, enrollment domain.TrainingEnrollment) error
+	FindAllTraining(c context.Context, filter domain.TrainingQueryFilter) ([]domain.Training, error)
+	CountAllTraining(c context.Context, filter domain.TrainingQueryFilter) (int, error)
+	FindTrainingById(c context.Context, id string) (domain.Training, error)
+	CountOpenEnrollment(c context.Context, trainingID string) (int, error)
+	FindAllEnrollment(c context.Context, filter domain.TrainingEnrollmentQueryFilter) ([]domain.TrainingEnrollment, error)
+	CountAllEnrollment(c context.Context, filter domain.TrainingEnrollmentQueryFilter) (int, error)
+	FindEnrollmentById(c context.Context, id string) (domain.TrainingEnrollment, error)
+	FindEnrolledEmployees(c context.Context, trainingID string, employeeIDs []string) ([]string, error)
+	FindMandatoryTrainings(c context.Context, expiryBefore time.Time) ([]domain.MandatoryTraining, error)
+}
+
+type trainingRepository struct {
+	db                    Store
+	TrainingQuery         query.TrainingQuery
+	EmployeeDocumentQuery query.EmployeeDocumentQuery
+}
+
+func NewTraining(db Store, q query.TrainingQuery, employeeDocumentQuery query.EmployeeDocumentQuery) TrainingRepository {
+	return &trainingRepository{
+		db:                    db,
+		TrainingQuery:         q,
+		EmployeeDocumentQuery: employeeDocumentQuery,
+	}
+}
+
+// create the training with its requirements
+func (r *trainingRepository) CreateTraining(c context.Context, training domain.Training) error {
+	var err error
+
+	// create transaction to create training
+	err = r.db.WithTransaction(c, func(tx pgx.Tx) error {
+		// create training, if error will rollback
+		if err = r.TrainingQuery.CreateTraining(c, tx, training); err != nil {
+			return err
+		}
+		return r.createRequirements(c, tx, training)
+	})
+
+	return err
+}
+
+// update the training, the requirements are replaced
+func (r *trainingRepository) UpdateTraining(c context.Context, training domain.Training) error {
+	var err error
+
+	// create transaction to update training
+	err = r.db.WithTransaction(c, func(tx pgx.Tx) error {
+		// update training by id, if error will rollback
+		if err = r.TrainingQuery.UpdateTraining(c, tx, training); err != nil {
+			return err
+		}
+		if err = r.TrainingQuery.DeleteRequirements(c, tx, training.ID); err != nil {
+			return err
+		}
+		return r.createRequirements(c, tx, training)
+	})
+
+	return err
+}
+
+func (r *trainingRepository) createRequirements(c context.Context, tx pgx.Tx, training domain.Training) error {
+	for _, requirement := range training.Requirements {
+		requirement.TrainingID = training.ID
+		if err := r.TrainingQuery.CreateRequirement(c, tx, requirement); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (r *trainingRepository) DeleteTraining(c context.Context, id string) error {
+	var err error
+
+	// create transaction to delete training
+	err = r.db.WithTransaction(c, func(tx pgx.Tx) error {
+		// delete training by id, if error will rollback
+		if err = r.TrainingQuery.DeleteTraining(c, tx, id); err != nil {
+			return err
+		}
+		// the training is no longer mandatory for the positions, if error will rollback
+		if err = r.TrainingQuery.DeleteRequirements(c, tx, id); err != nil {
+			return err
+		}
+		return nil
+	})
+
+	return err
+}
+
+// create the enrollments of the employees at once
+func (r *trainingRepository) Enroll(c context.Context, enrollments []domain.TrainingEnrollment) error {
+	var err error
+
+	// create transaction to create training enrollments
+	err = r.db.WithTransaction(c, func(tx pgx.Tx) error {
+		for _, enrollment := range enrollments {
+			// create enrollment, if error will rollback
+			if err = r.TrainingQuery.CreateEnrollment(c, tx, enrollment); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+
+	return err
+}
+
+// complete the enrollment, the certificate is saved to the employee documents in the same transaction when it is
+// uploaded
+func (r *trainingRepository) Complete(c context.Context, enrollment domain.TrainingEnrollment, certificate *domain.EmployeeDocument, version *domain.EmployeeDocumentVersion) error {
+	var err error
+
+	// create transaction to complete training enrollment
+	err = r.db.WithTransaction(c, func(tx pgx.Tx) error {
+		if certificate != nil {
+			// create the certificate document with its first version, if error will rollback
+			if err = r.EmployeeDocumentQuery.CreateDocument(c, tx, *certificate); err != nil {
+				return err
+			}
+			if err = r.EmployeeDocumentQuery.CreateVersion(c, tx, *version); err != nil {
+				return err
+			}
+		}
+		// update enrollment by id, if error will rollback
+		if err = r.TrainingQuery.UpdateEnrollment(c, tx, enrollment); err != nil {
+			return err
+		}
+		return nil
+	})
+
+	return err
+}
+
+func (r *trainingRepository) UpdateEnrollment(c context.Context, enrollment domain.TrainingEnrollment) error {
+	var err error
+
+	// create transaction to update training enrollment
+	err = r.db.WithTransaction(c, func(tx pgx.Tx) error {
+		// update enrollment by id, if error will rollback
+		if err = r.TrainingQuery.UpdateEnrollment(c, tx, enrollment); err != nil {
+			return err
+		}
+		return nil
+	})
+
+	return err
+}
+
+func (r *trainingRepository) FindAllTraining(c context.Context, filter domain.TrainingQueryFilter) ([]domain.Training, error) {
+	var trainings []domain.Training
+	var err error
+
+	// get trainings without transaction.
+	err = r.db.WithoutTransaction(c, func(db *pgxpool.Pool) error {
+		if trainings, err = r.TrainingQuery.FindAllTraining(c, db, filter); err != nil {
+			return err
+		}
+		return nil
+	})
+
+	return trainings, err
+}
+
+func (r *trainingRepository) CountAllTraining(c context.Context, filter domain.TrainingQueryFilter) (int, error) {
+	var count int
+	var err error
+
+	// count trainings without transaction.
+	err = r.db.WithoutTransaction(c, func(db *pgxpool.Pool) error {
+		if count, err = r.TrainingQuery.CountAllTraining(c, db, filter); err != nil {
+			return err
+		}
+		return nil
+	})
+
+	return count, err
+}
+
+func (r *trainingRepository) FindTrainingById(c context.Context, id string) (domain.Training, error) {
+	var training domain.Training
+	var err error
+
+	// get training by id with its requirements without transaction.
+	err = r.db.WithoutTransaction(c, func(db *pgxpool.Pool) error {
+		if training, err = r.TrainingQuery.FindTrainingById(c, db, id); err != nil {
+			return err
+		}
+		if training.Requirements, err = r.TrainingQuery.FindRequirements(c, db, training.ID); err != nil {
+			return err
+		}
+		return nil
+	})
+
+	return training, err
+}
+
+func (r *trainingRepository) CountOpenEnrollment(c context.Context, trainingID string) (int, error) {
+	var count int
+	var err error
+
+	// count the open enrollments of the training without transaction.
+	err = r.db.WithoutTransaction(c, func(db *pgxpool.Pool) error {
+		if count, err = r.TrainingQuery.CountOpenEnrollment(c, db, trainingID); err != nil {
+			return err
+		}
+		return nil
+	})
+
+	return count, err
+}
+
+func (r *trainingRepository) FindAllEnrollment(c context.Context, filter domain.TrainingEnrollmentQueryFilter) ([]domain.TrainingEnrollment, error) {
+	var enrollments []domain.TrainingEnrollment
+	var err error
+
+	// get training enrollments without transaction.
+	err = r.db.WithoutTransaction(c, func(db *pgxpool.Pool) error {
+		if enrollments, err = r.TrainingQuery.FindAllEnrollment(c, db, filter); err != nil {
+			return err
+		}
+		return nil
+	})
+
+	return enrollments, err
+}
+
+func (r *trainingRepository) CountAllEnrollment(c context.Context, filter domain.TrainingEnrollmentQueryFilter) (int, error) {
+	var count int
+	var err error
+
+	// count training enrollments without transaction.
+	err = r.db.WithoutTransaction(c, func(db *pgxpool.Pool) error {
+		if count, err = r.TrainingQuery.CountAllEnrollment(c, db, filter); err != nil {
+			return err
+		}
+		return nil
+	})
+
+	return count, err
+}
+
+func (r *trainingRepository) FindEnrollmentById(c context.Context, id string) (domain.TrainingEnrollment, error) {
+	var enrollment domain.TrainingEnrollment
+	var err error
+
+	// get training enrollment by id without transaction.
+	err = r.db.WithoutTransaction(c, func(db *pgxpool.Pool) error {
+		if enrollment, err = r.TrainingQuery.FindEnrollmentById(c, db, id); err != nil {
+			return err
+		}
+		return nil
+	})
+
+	return enrollment, err
+}
+
+func (r *trainingRepository) FindEnrolledEmployees(c context.Context, trainingID string, employeeIDs []string) ([]string, error) {
+	var enrolled []string
+	var err error
+
+	// get the enrolled employees of the training without transaction.
+	err = r.db.WithoutTransaction(c, func(db *pgxpool.Pool) error {
+		if enrolled, err = r.TrainingQuery.FindEnrolledEmployees(c, db, trainingID, employeeIDs); err != nil {
+			return err
+		}
+		return nil
+	})
+
+	return enrolled, err
+}
+
+func (r *trainingRepository) FindMandatoryTrainings(c context.Context, expiryBefore time.Time) ([]domain.MandatoryTraining, error) {
+	var trainings []domain.MandatoryTraining
+	var err error
+
+	// get the mandatory trainings to be enrolled without transaction.
+	err = r.db.WithoutTransaction(c, func(db *pgxpool.Pool) error {
+		if trainings, err = r.TrainingQuery.FindMandatoryTrainings(c, db, expiryBefore); err != nil {
+			return err
+		}
+		return nil
+	})
+
+	return trainings, err
+}
@@ -11,13 +11,17 @@ import (
 
 type UserRepository interface {
 	CreateUser(c context.Context, user domain.User) error
+	ImportUsers(c context.Context, users []domain.User) error
 	UpdateUser(c context.Context, id string, user domain.User) error
 	UpdatePassword(c context.Context, user domain.User) error
+	UpdateAvatar(c context.Context, user domain.User) error
 	Delete(c context.Context, id string) error
+	IsDeleted(c context.Context, id string) (bool, error)
 	FindAllUser(c context.Context, filter domain.UserQueryFilter) ([]domain.User, error)
 	FindById(c context.Context, id string, filter domain.UserQueryFilter) (domain.User, error)
 	FindByPhoneNumber(c context.Context, phone string) (domain.User, error)
 	FindByEmail(c context.Context, email string) (domain.User, error)
+	FindByEmailsOrPhones(c context.Context, emails, phones []string) ([]domain.User, error)
 	FindUserNotDeleteByQueryTx(ctx context.Context, query, value string) (domain.User, error)
 	CheckTokenWithQueryTx(ctx context.Context, query, value string) (domain.ResetPasswordToken, error)
 	CountAllUser(c context.Context, filter domain.UserQueryFilter) (int, error)
@@ -68,6 +72,21 @@ func (r *userRepository) UpdatePassword(c context.Context, user domain.User) err
 	return err
 }
 
+func (r *userRepository) UpdateAvatar(c context.Context, user domain.User) error {
+	var err error
+
+	// create transaction to update avatar user
+	err = r.db.WithTransaction(c, func(tx pgx.Tx) error {
+		// update avatar user id, if error will rollback
+		if err = r.UserQuery.UpdateAvatar(c, tx, user); err != nil {
+			return err
+		}
+		return nil
+	})
+
+	return err
+}
+
 func (r *userRepository) FindById(c context.Context, id string, filter domain.UserQueryFilter) (domain.User, error) {
 	var user domain.User
 	var err error
@@ -145,6 +164,40 @@ func (r *userRepository) CreateUser(c context.Context, user domain.User) error {
 	return err
 }
 
+// create the users in a transaction, none of the users is created when one of them fails
+func (r *userRepository) ImportUsers(c context.Context, users []domain.User) error {
+	var err error
+
+	// create transaction to import users
+	err = r.db.WithTransaction(c, func(tx pgx.Tx) error {
+		for _, user := range users {
+			// create user, if error will rollback
+			if err = r.UserQuery.CreateUser(c, tx, user); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+
+	return err
+}
+
+// Find the users by the emails or the phones
+func (r *userRepository) FindByEmailsOrPhones(c context.Context, emails, phones []string) ([]domain.User, error) {
+	var users []domain.User
+	var err error
+
+	// get users by the emails or the phones without transaction.
+	err = r.db.WithoutTransaction(c, func(db *pgxpool.Pool) error {
+		if users, err = r.UserQuery.FindByEmailsOrPhones(c, db, emails, phones); err != nil {
+			return err
+		}
+		return nil
+	})
+
+	return users, err
+}
+
 func (r *userRepository) Delete(c context.Context, id string) error {
 	var err error
 
@@ -160,6 +213,21 @@ func (r *userRepository) Delete(c context.Context, id string) error {
 	return err
 }
 
+func (r *userRepository) IsDeleted(c context.Context, id string) (bool, error) {
+	var deleted bool
+	var err error
+
+	// check the user without transaction.
+	err = r.db.WithoutTransaction(c, func(db *pgxpool.Pool) error {
+		if deleted, err = r.UserQuery.IsDeleted(c, db, id); err != nil {
+			return err
+		}
+		return nil
+	})
+
+	return deleted, err
+}
+
 func (r *userRepository) CountAllUser(c context.Context, filter domain.UserQueryFilter) (int, error) {
 	var count int
 	var err error
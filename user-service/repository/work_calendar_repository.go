@@ -0,0 +1,133 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/iqbaludinm/hr-microservice/user-service/model/domain"
+	"github.com/iqbaludinm/hr-microservice/user-service/repository/query"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type WorkCalendarRepository interface {
+	CreateWorkCalendar(c context.Context, calendar domain.WorkCalendar) error
+	UpdateWorkCalendar(c context.Context, id string, calendar domain.WorkCalendar) error
+	Delete(c context.Context, id string) error
+	FindAllWorkCalendar(c context.Context) ([]domain.WorkCalendar, error)
+	FindById(c context.Context, id string) (domain.WorkCalendar, error)
+	CountLocations(c context.Context, id string) (int, error)
+}
+
+type workCalendarRepository struct {
+	db                Store
+	WorkCalendarQuery query.WorkCalendarQuery
+}
+
+func NewWorkCalendar(db Store, q query.WorkCalendarQuery) WorkCalendarRepository {
+	return &workCalendarRepository{
+		db:                db,
+		WorkCalendarQuery: q,
+	}
+}
+
+func (r *workCalendarRepository) CreateWorkCalendar(c context.Context, calendar domain.WorkCalendar) error {
+	var err error
+
+	// create transaction to create work calendar
+	err = r.db.WithTransaction(c, func(tx pgx.Tx) error {
+		// the new default calendar replaces the current default, if error will rollback
+		if calendar.IsDefault {
+			if err = r.WorkCalendarQuery.ClearDefault(c, tx, calendar.ID); err != nil {
+				return err
+			}
+		}
+		// create work calendar, if error will rollback
+		if err = r.WorkCalendarQuery.CreateWorkCalendar(c, tx, calendar); err != nil {
+			return err
+		}
+		return nil
+	})
+
+	return err
+}
+
+func (r *workCalendarRepository) UpdateWorkCalendar(c context.Context, id string, calendar domain.WorkCalendar) error {
+	var err error
+
+	// create transaction to update work calendar
+	err = r.db.WithTransaction(c, func(tx pgx.Tx) error {
+		// the new default calendar replaces the current default, if error will rollback
+		if calendar.IsDefault {
+			if err = r.WorkCalendarQuery.ClearDefault(c, tx, id); err != nil {
+				return err
+			}
+		}
+		// update work calendar by id, if error will rollback
+		if err = r.WorkCalendarQuery.UpdateWorkCalendar(c, tx, id, calendar); err != nil {
+			return err
+		}
+		return nil
+	})
+
+	return err
+}
+
+func (r *workCalendarRepository) Delete(c context.Context, id string) error {
+	var err error
+
+	// create transaction to delete work calendar
+	err = r.db.WithTransaction(c, func(tx pgx.Tx) error {
+		// delete work calendar by id, if error will rollback
+		if err = r.WorkCalendarQuery.Delete(c, tx, id); err != nil {
+			return err
+		}
+		return nil
+	})
+
+	return err
+}
+
+func (r *workCalendarRepository) FindAllWorkCalendar(c context.Context) ([]domain.WorkCalendar, error) {
+	var calendars []domain.WorkCalendar
+	var err error
+
+	// get work calendars without transaction.
+	err = r.db.WithoutTransaction(c, func(db *pgxpool.Pool) error {
+		if calendars, err = r.WorkCalendarQuery.FindAllWorkCalendar(c, db); err != nil {
+			return err
+		}
+		return nil
+	})
+
+	return calendars, err
+}
+
+func (r *workCalendarRepository) FindById(c context.Context, id string) (domain.WorkCalendar, error) {
+	var calendar domain.WorkCalendar
+	var err error
+
+	// get work calendar by id without transaction.
+	err = r.db.WithoutTransaction(c, func(db *pgxpool.Pool) error {
+		if calendar, err = r.WorkCalendarQuery.FindById(c, db, id); err != nil {
+			return err
+		}
+		return nil
+	})
+
+	return calendar, err
+}
+
+func (r *workCalendarRepository) CountLocations(c context.Context, id string) (int, error) {
+	var count int
+	var err error
+
+	// count office locations of the work calendar without transaction.
+	err = r.db.WithoutTransaction(c, func(db *pgxpool.Pool) error {
+		if count, err = r.WorkCalendarQuery.CountLocations(c, db, id); err != nil {
+			return err
+		}
+		return nil
+	})
+
+	return count, err
+}
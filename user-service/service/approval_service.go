@@ -0,0 +1,1073 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/iqbaludinm/hr-microservice/user-service/config"
+	"github.com/iqbaludinm/hr-microservice/user-service/exception"
+	"github.com/iqbaludinm/hr-microservice/user-service/helper"
+	"github.com/iqbaludinm/hr-microservice/user-service/model/domain"
+	"github.com/iqbaludinm/hr-microservice/user-service/model/kafkamodel"
+	"github.com/iqbaludinm/hr-microservice/user-service/model/web"
+	"github.com/iqbaludinm/hr-microservice/user-service/repository"
+	"github.com/iqbaludinm/hr-microservice/user-service/service/producers"
+	"go.uber.org/zap"
+)
+
+// Type of the notifications produced by the approval service.
+const (
+	NotificationApprovalAssigned = "APPROVAL_ASSIGNED"
+	NotificationApprovalApproved = "APPROVAL_APPROVED"
+	NotificationApprovalRejected = "APPROVAL_REJECTED"
+)
+
+// ApprovalEntity is implemented by the service owning an entity type which is approved through the approval workflow.
+// The owning service requests the approval of its entity and the approval service applies the result back through it.
+type ApprovalEntity interface {
+	// FindApprovalSubject finds the requester, the title and the amount of the entity waiting for the approval.
+	FindApprovalSubject(ctx context.Context, entityID string) (domain.ApprovalSubject, error)
+	// ApplyApproval applies the approved, rejected or cancelled approval to the entity.
+	ApplyApproval(ctx context.Context, approval domain.ApprovalRequest) error
+}
+
+type ApprovalService interface {
+	// With Transaction
+	CreateWorkflow(ctx context.Context, request web.ApprovalWorkflowRequest) (web.ApprovalWorkflowResponse, error)
+	UpdateWorkflow(ctx context.Context, id string, request web.ApprovalWorkflowRequest) (web.ApprovalWorkflowResponse, error)
+	DeleteWorkflow(ctx context.Context, id string) error
+	Create(ctx context.Context, userID string, request web.CreateApprovalRequest) (web.ApprovalResponse, error)
+	Approve(ctx context.Context, userID, id string, request web.DecideApprovalRequest) (web.ApprovalResponse, error)
+	Reject(ctx context.Context, userID, id string, request web.DecideApprovalRequest) (web.ApprovalResponse, error)
+	Cancel(ctx context.Context, userID, id string) (web.ApprovalResponse, error)
+	EscalateOverdue(ctx context.Context) error
+	CreateDelegation(ctx context.Context, userID string, request web.CreateApprovalDelegationRequest) (web.ApprovalDelegationResponse, error)
+	CancelDelegation(ctx context.Context, userID, id string) (web.ApprovalDelegationResponse, error)
+
+	// Without Transaction
+	FindAllWorkflow(ctx context.Context, filter web.ApprovalWorkflowQueryFilter) ([]web.ApprovalWorkflowResponse, int, error)
+	FindWorkflowById(ctx context.Context, id string) (web.ApprovalWorkflowResponse, error)
+	FindAll(ctx context.Context, filter web.ApprovalQueryFilter) ([]web.ApprovalResponse, int, error)
+	FindMy(ctx context.Context, userID string, filter web.ApprovalQueryFilter) ([]web.ApprovalResponse, int, error)
+	FindPending(ctx context.Context, userID string, filter web.ApprovalQueryFilter) ([]web.ApprovalResponse, int, error)
+	FindById(ctx context.Context, id string) (web.ApprovalResponse, error)
+	FindAllDelegation(ctx context.Context, filter web.ApprovalDelegationQueryFilter) ([]web.ApprovalDelegationResponse, int, error)
+	FindMyDelegations(ctx context.Context, userID string, filter web.ApprovalDelegationQueryFilter) ([]web.ApprovalDelegationResponse, int, error)
+	FindDelegationById(ctx context.Context, id string) (web.ApprovalDelegationResponse, error)
+
+	// Approval Entity
+	RegisterEntity(entityType string, entity ApprovalEntity)
+	Request(ctx context.Context, entityType, entityID string) (bool, error)
+	CancelEntity(ctx context.Context, entityType, entityID string) error
+	IsPending(ctx context.Context, entityType, entityID string) (bool, error)
+}
+
+type approvalService struct {
+	approvalRepository   repository.ApprovalRepository
+	leaveRepository      repository.LeaveRepository
+	employeeRepository   repository.EmployeeRepository
+	positionRepository   repository.PositionRepository
+	kafkaProducerService producers.KafkaProducerService
+	logger               *zap.SugaredLogger
+
+	// the services owning the entity types, registered when the services are created
+	entities map[string]ApprovalEntity
+}
+
+func NewApprovalService(approvalRepository repository.ApprovalRepository, leaveRepository repository.LeaveRepository, employeeRepository repository.EmployeeRepository, positionRepository repository.PositionRepository, kafkaProducerService producers.KafkaProducerService, logger *zap.SugaredLogger) ApprovalService {
+	return &approvalService{
+		approvalRepository:   approvalRepository,
+		leaveRepository:      leaveRepository,
+		employeeRepository:   employeeRepository,
+		positionRepository:   positionRepository,
+		kafkaProducerService: kafkaProducerService,
+		logger:               logger,
+		entities:             map[string]ApprovalEntity{},
+	}
+}
+
+func (s *approvalService) CreateWorkflow(c context.Context, request web.ApprovalWorkflowRequest) (web.ApprovalWorkflowResponse, error) {
+	// convert to domain or model approval workflow
+	workflow := domain.ToDomainApprovalWorkflow(uuid.New().String(), request)
+	if err := s.validateSteps(c, workflow); err != nil {
+		return web.ApprovalWorkflowResponse{}, err
+	}
+
+	// call the repo for inserting to db
+	if err := s.approvalRepository.CreateWorkflow(c, workflow); err != nil {
+		s.logger.Infow(err.Error(), "Create Approval Workflow Error")
+		return web.ApprovalWorkflowResponse{}, toApprovalWorkflowUniqueError(err)
+	}
+
+	// get or returning the approval workflow have created to db
+	newWorkflow, err := s.approvalRepository.FindWorkflowById(c, workflow.ID)
+	if err != nil {
+		return web.ApprovalWorkflowResponse{}, exception.ErrInternalServer(fmt.Sprintf("Successfully created approval workflow, but failed to get the approval workflow have created. Error: %s", err.Error()))
+	}
+
+	return newWorkflow.ToApprovalWorkflowResponse(), nil
+}
+
+// update the approval workflow, the steps are replaced. The pending approvals keep the steps they were created with.
+func (s *approvalService) UpdateWorkflow(c context.Context, id string, request web.ApprovalWorkflowRequest) (web.ApprovalWorkflowResponse, error) {
+	existing, err := findApprovalWorkflow(c, s.approvalRepository, id)
+	if err != nil {
+		return web.ApprovalWorkflowResponse{}, err
+	}
+
+	workflow := domain.ToDomainApprovalWorkflow(id, request)
+	if err := s.validateSteps(c, workflow); err != nil {
+		return web.ApprovalWorkflowResponse{}, err
+	}
+	workflow.CreatedAt = existing.CreatedAt
+
+	if err := s.approvalRepository.UpdateWorkflow(c, workflow); err != nil {
+		s.logger.Infow(err.Error(), "Update Approval Workflow Error")
+		return web.ApprovalWorkflowResponse{}, toApprovalWorkflowUniqueError(err)
+	}
+
+	return s.FindWorkflowById(c, id)
+}
+
+// delete the approval workflow, the pending approvals of the workflow are continued
+func (s *approvalService) DeleteWorkflow(c context.Context, id string) error {
+	if _, err := findApprovalWorkflow(c, s.approvalRepository, id); err != nil {
+		return err
+	}
+
+	return s.approvalRepository.DeleteWorkflow(c, id)
+}
+
+// request the approval of the entity by its requester through the active workflow of the entity type, e.g. the
+// entity which was submitted before the workflow was activated
+func (s *approvalService) Create(c context.Context, userID string, request web.CreateApprovalRequest) (web.ApprovalResponse, error) {
+	requester, err := findEmployeeByUser(c, s.employeeRepository, userID)
+	if err != nil {
+		return web.ApprovalResponse{}, err
+	}
+
+	subject, err := s.findSubject(c, request.EntityType, request.EntityID)
+	if err != nil {
+		return web.ApprovalResponse{}, err
+	}
+	if subject.RequesterID != requester.ID {
+		return web.ApprovalResponse{}, exception.ErrUnauthorized("Only the requester of the entity can request its approval.")
+	}
+
+	workflow, err := s.approvalRepository.FindActiveWorkflow(c, request.EntityType)
+	if err != nil {
+		if strings.Contains(err.Error(), "no rows") {
+			return web.ApprovalResponse{}, exception.ErrBadRequest(fmt.Sprintf("There is no active approval workflow of %s.", request.EntityType))
+		}
+		return web.ApprovalResponse{}, err
+	}
+
+	approval, err := s.create(c, workflow, request.EntityType, request.EntityID, subject)
+	if err != nil {
+		return web.ApprovalResponse{}, err
+	}
+
+	return approval.ToApprovalResponse(), nil
+}
+
+func (s *approvalService) Approve(c context.Context, userID, id string, request web.DecideApprovalRequest) (web.ApprovalResponse, error) {
+	return s.decide(c, userID, id, true, request.Note)
+}
+
+func (s *approvalService) Reject(c context.Context, userID, id string, request web.DecideApprovalRequest) (web.ApprovalResponse, error) {
+	if request.Note == "" {
+		return web.ApprovalResponse{}, exception.ErrBadRequest("The note is required when the approval is rejected.")
+	}
+	return s.decide(c, userID, id, false, request.Note)
+}
+
+// cancel the pending approval, only the requester can cancel it
+func (s *approvalService) Cancel(c context.Context, userID, id string) (web.ApprovalResponse, error) {
+	actions, err := s.updateApproval(c, id, func(approval *domain.ApprovalRequest) ([]domain.ApprovalAction, error) {
+		if approval.RequesterUserID != userID {
+			return nil, exception.ErrUnauthorized("Only the requester can cancel the approval.")
+		}
+		if approval.Status != domain.ApprovalStatusPending {
+			return nil, exception.ErrBadRequest(fmt.Sprintf("Approval is already %s.", approval.Status))
+		}
+
+		now := time.Now()
+		actions := []domain.ApprovalAction{approval.NewAction(uuid.New().String(), nil, domain.ApprovalActionCancelled, &approval.RequesterID, "", now)}
+		return append(actions, closeApproval(approval, domain.ApprovalStatusCancelled, now)...), nil
+	})
+	if err != nil {
+		s.logger.Infow(err.Error(), "Cancel Approval Error")
+		return web.ApprovalResponse{}, err
+	}
+
+	return s.findAndPublish(c, id, actions)
+}
+
+// EscalateOverdue escalates the pending tasks which are not decided within their timeout to the manager of the
+// approver, the task of a role is escalated to the manager of the requester. A task is escalated once.
+func (s *approvalService) EscalateOverdue(c context.Context) error {
+	now := time.Now()
+	ids, err := s.approvalRepository.FindOverdueRequestIds(c, now)
+	if err != nil {
+		return err
+	}
+
+	escalated := 0
+	for _, id := range ids {
+		if err := s.escalate(c, id, now); err != nil {
+			s.logger.Errorw("Escalate Approval Error", "approval_id", id, "error", err.Error())
+			continue
+		}
+		escalated++
+	}
+	if escalated > 0 {
+		s.logger.Infow("Approvals escalated", "count", escalated)
+	}
+
+	return nil
+}
+
+// delegate the approvals of the logged in user to the delegate between the dates. The delegation can't overlap
+// another delegation of the user and can't delegate the approvals back to the user through other delegations.
+func (s *approvalService) CreateDelegation(c context.Context, userID string, request web.CreateApprovalDelegationRequest) (web.ApprovalDelegationResponse, error) {
+	delegator, err := findEmployeeByUser(c, s.employeeRepository, userID)
+	if err != nil {
+		return web.ApprovalDelegationResponse{}, err
+	}
+
+	// convert to domain or model approval delegation
+	delegation := domain.ToDomainApprovalDelegation(delegator.ID, request)
+	if delegation.DelegateID == delegator.ID {
+		return web.ApprovalDelegationResponse{}, exception.ErrBadRequest("You can't delegate the approvals to yourself.")
+	}
+	if delegation.EndDate.Before(delegation.StartDate) {
+		return web.ApprovalDelegationResponse{}, exception.ErrBadRequest("End date must be on or after the start date.")
+	}
+	if delegation.EndDate.Before(helper.Today()) {
+		return web.ApprovalDelegationResponse{}, exception.ErrBadRequest("The delegation can't end in the past.")
+	}
+	if _, err := findEmployee(c, s.employeeRepository, delegation.DelegateID); err != nil {
+		return web.ApprovalDelegationResponse{}, err
+	}
+
+	// the delegations which are active between the dates
+	delegations, err := s.approvalRepository.FindAllDelegation(c, domain.ApprovalDelegationQueryFilter{
+		From:   request.StartDate,
+		To:     request.EndDate,
+		Active: true,
+	})
+	if err != nil {
+		return web.ApprovalDelegationResponse{}, err
+	}
+	for _, other := range delegations {
+		if delegation.Overlaps(other) {
+			return web.ApprovalDelegationResponse{}, exception.ErrBadRequest(fmt.Sprintf("The delegation overlaps the delegation to %s from %s to %s.",
+				other.DelegateName, other.StartDate.Format(helper.DateLayout), other.EndDate.Format(helper.DateLayout)))
+		}
+	}
+	if cycle := delegation.FindCycle(delegations); len(cycle) > 0 {
+		names := []string{}
+		for _, other := range cycle {
+			names = append(names, other.DelegatorName)
+		}
+		return web.ApprovalDelegationResponse{}, exception.ErrBadRequest(fmt.Sprintf("The delegation forms a cycle, the approvals are delegated back to you by %s.", strings.Join(names, " -> ")))
+	}
+
+	now := time.Now()
+	delegation.ID = uuid.New().String()
+	delegation.CreatedAt = now
+	delegation.UpdatedAt = now
+
+	// call the repo for inserting to db
+	if err := s.approvalRepository.CreateDelegation(c, delegation); err != nil {
+		s.logger.Infow(err.Error(), "Create Approval Delegation Error")
+		return web.ApprovalDelegationResponse{}, err
+	}
+
+	// get or returning the approval delegation have created to db
+	newDelegation, err := s.approvalRepository.FindDelegationById(c, delegation.ID)
+	if err != nil {
+		return web.ApprovalDelegationResponse{}, exception.ErrInternalServer(fmt.Sprintf("Successfully created approval delegation, but failed to get the approval delegation have created. Error: %s", err.Error()))
+	}
+
+	return newDelegation.ToApprovalDelegationResponse(), nil
+}
+
+// cancel the delegation which is not ended yet, only the delegator can cancel it. The tasks already assigned to the
+// delegate are kept.
+func (s *approvalService) CancelDelegation(c context.Context, userID, id string) (web.ApprovalDelegationResponse, error) {
+	delegation, err := findApprovalDelegation(c, s.approvalRepository, id)
+	if err != nil {
+		return web.ApprovalDelegationResponse{}, err
+	}
+	if delegation.DelegatorUserID != userID {
+		return web.ApprovalDelegationResponse{}, exception.ErrUnauthorized("Only the delegator can cancel the delegation.")
+	}
+	if delegation.CancelledAt != nil {
+		return web.ApprovalDelegationResponse{}, exception.ErrBadRequest("Delegation is already cancelled.")
+	}
+	if delegation.EndDate.Before(helper.Today()) {
+		return web.ApprovalDelegationResponse{}, exception.ErrBadRequest("Delegation has already ended.")
+	}
+
+	now := time.Now()
+	delegation.CancelledAt = &now
+	delegation.UpdatedAt = now
+
+	if err := s.approvalRepository.CancelDelegation(c, delegation); err != nil {
+		s.logger.Infow(err.Error(), "Cancel Approval Delegation Error")
+		return web.ApprovalDelegationResponse{}, err
+	}
+
+	return s.FindDelegationById(c, id)
+}
+
+func (s *approvalService) FindAllWorkflow(c context.Context, filter web.ApprovalWorkflowQueryFilter) (result []web.ApprovalWorkflowResponse, totalData int, err error) {
+	domainFilter := domain.ToDomainApprovalWorkflowQueryFilter(filter)
+
+	workflows, err := s.approvalRepository.FindAllWorkflow(c, domainFilter)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	// get total-data
+	totalData, err = s.approvalRepository.CountAllWorkflow(c, domainFilter)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	// convert to web.ApprovalWorkflowResponse
+	result = []web.ApprovalWorkflowResponse{}
+	for _, workflow := range workflows {
+		result = append(result, workflow.ToApprovalWorkflowResponse())
+	}
+
+	return result, totalData, nil
+}
+
+func (s *approvalService) FindWorkflowById(c context.Context, id string) (web.ApprovalWorkflowResponse, error) {
+	workflow, err := findApprovalWorkflow(c, s.approvalRepository, id)
+	if err != nil {
+		return web.ApprovalWorkflowResponse{}, err
+	}
+
+	return workflow.ToApprovalWorkflowResponse(), nil
+}
+
+func (s *approvalService) FindAll(c context.Context, filter web.ApprovalQueryFilter) ([]web.ApprovalResponse, int, error) {
+	return s.findAll(c, domain.ToDomainApprovalQueryFilter(filter))
+}
+
+func (s *approvalService) FindMy(c context.Context, userID string, filter web.ApprovalQueryFilter) ([]web.ApprovalResponse, int, error) {
+	requester, err := findEmployeeByUser(c, s.employeeRepository, userID)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	domainFilter := domain.ToDomainApprovalQueryFilter(filter)
+	domainFilter.RequesterID = requester.ID
+	return s.findAll(c, domainFilter)
+}
+
+// find the approvals with a pending task of the logged in user, of a role of the position of the user or of the
+// delegators who delegate the approvals to the user today
+func (s *approvalService) FindPending(c context.Context, userID string, filter web.ApprovalQueryFilter) ([]web.ApprovalResponse, int, error) {
+	approver, err := findEmployeeByUser(c, s.employeeRepository, userID)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	domainFilter := domain.ToDomainApprovalQueryFilter(filter)
+	domainFilter.Status = domain.ApprovalStatusPending
+	domainFilter.ApproverID = approver.ID
+	if approver.PositionID != nil {
+		domainFilter.ApproverPositionID = *approver.PositionID
+	}
+	return s.findAll(c, domainFilter)
+}
+
+func (s *approvalService) FindById(c context.Context, id string) (web.ApprovalResponse, error) {
+	approval, err := findApproval(c, s.approvalRepository, id)
+	if err != nil {
+		return web.ApprovalResponse{}, err
+	}
+
+	return approval.ToApprovalResponse(), nil
+}
+
+func (s *approvalService) FindAllDelegation(c context.Context, filter web.ApprovalDelegationQueryFilter) ([]web.ApprovalDelegationResponse, int, error) {
+	return s.findAllDelegation(c, domain.ToDomainApprovalDelegationQueryFilter(filter))
+}
+
+// find the delegations of the logged in user as the delegator or as the delegate
+func (s *approvalService) FindMyDelegations(c context.Context, userID string, filter web.ApprovalDelegationQueryFilter) ([]web.ApprovalDelegationResponse, int, error) {
+	employee, err := findEmployeeByUser(c, s.employeeRepository, userID)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	domainFilter := domain.ToDomainApprovalDelegationQueryFilter(filter)
+	domainFilter.EmployeeID = employee.ID
+	return s.findAllDelegation(c, domainFilter)
+}
+
+func (s *approvalService) FindDelegationById(c context.Context, id string) (web.ApprovalDelegationResponse, error) {
+	delegation, err := findApprovalDelegation(c, s.approvalRepository, id)
+	if err != nil {
+		return web.ApprovalDelegationResponse{}, err
+	}
+
+	return delegation.ToApprovalDelegationResponse(), nil
+}
+
+// RegisterEntity registers the service owning the entity type, the approvals of the entity type are applied through it
+func (s *approvalService) RegisterEntity(entityType string, entity ApprovalEntity) {
+	s.entities[entityType] = entity
+}
+
+// Request requests the approval of the entity through the active workflow of the entity type, it is called by the
+// service owning the entity. It returns false when the entity type has no active workflow, the owning service
+// approves the entity by itself then.
+func (s *approvalService) Request(c context.Context, entityType, entityID string) (bool, error) {
+	workflow, err := s.approvalRepository.FindActiveWorkflow(c, entityType)
+	if err != nil {
+		if strings.Contains(err.Error(), "no rows") {
+			return false, nil
+		}
+		return false, err
+	}
+
+	subject, err := s.findSubject(c, entityType, entityID)
+	if err != nil {
+		return false, err
+	}
+	if _, err := s.create(c, workflow, entityType, entityID, subject); err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+// CancelEntity cancels the pending approval of the entity which is cancelled by the service owning it, the
+// cancellation isn't applied back to the entity
+func (s *approvalService) CancelEntity(c context.Context, entityType, entityID string) error {
+	approvals, err := s.approvalRepository.FindAllRequest(c, domain.ApprovalQueryFilter{
+		EntityType: entityType,
+		EntityID:   entityID,
+		Status:     domain.ApprovalStatusPending,
+	})
+	if err != nil {
+		return err
+	}
+
+	for _, approval := range approvals {
+		actions, err := s.updateApproval(c, approval.ID, func(approval *domain.ApprovalRequest) ([]domain.ApprovalAction, error) {
+			// the approval completed in the meantime is left as it is
+			if approval.Status != domain.ApprovalStatusPending {
+				return nil, nil
+			}
+
+			now := time.Now()
+			actions := []domain.ApprovalAction{approval.NewAction(uuid.New().String(), nil, domain.ApprovalActionCancelled, nil, fmt.Sprintf("The %s is cancelled.", strings.ReplaceAll(entityType, "_", " ")), now)}
+			return append(actions, closeApproval(approval, domain.ApprovalStatusCancelled, now)...), nil
+		})
+		if err != nil {
+			return err
+		}
+
+		if len(actions) > 0 {
+			cancelled, err := findApproval(c, s.approvalRepository, approval.ID)
+			if err != nil {
+				return err
+			}
+			s.publish(cancelled, actions)
+		}
+	}
+
+	return nil
+}
+
+// IsPending checks whether the entity has a pending approval, the entity is decided through the approval then
+func (s *approvalService) IsPending(c context.Context, entityType, entityID string) (bool, error) {
+	count, err := s.approvalRepository.CountAllRequest(c, domain.ApprovalQueryFilter{
+		EntityType: entityType,
+		EntityID:   entityID,
+		Status:     domain.ApprovalStatusPending,
+	})
+	if err != nil {
+		return false, err
+	}
+
+	return count > 0, nil
+}
+
+// create the approval of the subject through the workflow. The steps which don't apply to the amount are left out,
+// the approval without any step is approved and applied right away.
+func (s *approvalService) create(c context.Context, workflow domain.ApprovalWorkflow, entityType, entityID string, subject domain.ApprovalSubject) (domain.ApprovalRequest, error) {
+	requester, err := findEmployee(c, s.employeeRepository, subject.RequesterID)
+	if err != nil {
+		return domain.ApprovalRequest{}, err
+	}
+
+	now := time.Now()
+	approval := domain.ApprovalRequest{
+		ID:          uuid.New().String(),
+		WorkflowID:  workflow.ID,
+		EntityType:  entityType,
+		EntityID:    entityID,
+		Title:       subject.Title,
+		Amount:      subject.Amount,
+		RequesterID: requester.ID,
+		Status:      domain.ApprovalStatusPending,
+		CreatedAt:   now,
+		UpdatedAt:   now,
+	}
+	approval.Actions = append(approval.Actions, approval.NewAction(uuid.New().String(), nil, domain.ApprovalActionSubmitted, &requester.ID, "", now))
+
+	// copy the steps to the tasks, the manager is resolved now and the role is resolved by whoever decides it
+	for _, step := range workflow.Steps {
+		if !step.Applies(subject.Amount) {
+			continue
+		}
+		task := domain.ToDomainApprovalTask(uuid.New().String(), approval.ID, step, now)
+		if step.ApproverType == domain.ApproverManager {
+			if requester.ManagerID == nil {
+				return domain.ApprovalRequest{}, exception.ErrBadRequest("Employee has no manager to approve the request.")
+			}
+			task.ApproverID = requester.ManagerID
+		}
+		approval.Tasks = append(approval.Tasks, task)
+	}
+
+	actions, err := s.advance(c, &approval, now)
+	if err != nil {
+		return domain.ApprovalRequest{}, err
+	}
+	approval.Actions = append(approval.Actions, actions...)
+
+	// call the repo for inserting to db
+	if err := s.approvalRepository.CreateRequest(c, approval); err != nil {
+		s.logger.Infow(err.Error(), "Create Approval Error")
+		return domain.ApprovalRequest{}, toApprovalUniqueError(err)
+	}
+
+	// get or returning the approval have created to db
+	newApproval, err := s.approvalRepository.FindRequestById(c, approval.ID)
+	if err != nil {
+		return domain.ApprovalRequest{}, exception.ErrInternalServer(fmt.Sprintf("Successfully created approval, but failed to get the approval have created. Error: %s", err.Error()))
+	}
+	if err := s.complete(c, newApproval, actions); err != nil {
+		return domain.ApprovalRequest{}, err
+	}
+
+	return newApproval, nil
+}
+
+// find the subject of the entity through the service owning the entity type
+func (s *approvalService) findSubject(c context.Context, entityType, entityID string) (domain.ApprovalSubject, error) {
+	entity, ok := s.entities[entityType]
+	if !ok {
+		return domain.ApprovalSubject{}, exception.ErrBadRequest(fmt.Sprintf("The %s can't be approved through the approval workflow.", entityType))
+	}
+
+	return entity.FindApprovalSubject(c, entityID)
+}
+
+// decide the pending task of the logged in user, or of the delegator who delegates the approval to the user today.
+// The next steps start when every task of the step is approved, a rejected task rejects the approval and skips the
+// rest of the tasks.
+func (s *approvalService) decide(c context.Context, userID, id string, approve bool, note string) (web.ApprovalResponse, error) {
+	approver, err := findEmployeeByUser(c, s.employeeRepository, userID)
+	if err != nil {
+		return web.ApprovalResponse{}, err
+	}
+
+	// the task is decided on the locked approval, so the approvers of the same step don't overwrite each other
+	actions, err := s.updateApproval(c, id, func(approval *domain.ApprovalRequest) ([]domain.ApprovalAction, error) {
+		if approval.Status != domain.ApprovalStatusPending {
+			return nil, exception.ErrBadRequest(fmt.Sprintf("Approval is already %s.", approval.Status))
+		}
+		if approval.RequesterID == approver.ID {
+			return nil, exception.ErrUnauthorized("The requester can't decide their own approval.")
+		}
+		i := approval.AssignedTask(approver.ID, approver.PositionID)
+		var onBehalfOfID *string
+		if i < 0 {
+			delegations, err := s.approvalRepository.FindAllDelegation(c, domain.ApprovalDelegationQueryFilter{
+				DelegateID: approver.ID,
+				EntityType: approval.EntityType,
+				Date:       helper.Today().Format(helper.DateLayout),
+				Active:     true,
+			})
+			if err != nil {
+				return nil, err
+			}
+			for _, delegation := range delegations {
+				if i = approval.AssignedTask(delegation.DelegatorID, nil); i >= 0 {
+					delegatorID := delegation.DelegatorID
+					onBehalfOfID = &delegatorID
+					break
+				}
+			}
+		}
+		if i < 0 {
+			return nil, exception.ErrUnauthorized("The approval has no pending step assigned to you.")
+		}
+
+		now := time.Now()
+		task := &approval.Tasks[i]
+		taskID := task.ID
+		// the task delegated when it was assigned is decided on behalf of the approver it was delegated from
+		if onBehalfOfID == nil {
+			onBehalfOfID = task.OnBehalfOfID
+		}
+		task.Note = note
+		task.DecidedBy = &approver.ID
+		task.DecidedAt = &now
+		task.OnBehalfOfID = onBehalfOfID
+		task.UpdatedAt = now
+
+		var actions []domain.ApprovalAction
+		if approve {
+			task.Status = domain.ApprovalTaskStatusApproved
+			action := approval.NewAction(uuid.New().String(), &taskID, domain.ApprovalActionApproved, &approver.ID, note, now)
+			action.OnBehalfOfID = onBehalfOfID
+			actions = append(actions, action)
+			if approval.StageApproved(task.StepOrder) {
+				next, err := s.advance(c, approval, now)
+				if err != nil {
+					return nil, err
+				}
+				actions = append(actions, next...)
+			}
+		} else {
+			task.Status = domain.ApprovalTaskStatusRejected
+			action := approval.NewAction(uuid.New().String(), &taskID, domain.ApprovalActionRejected, &approver.ID, note, now)
+			action.OnBehalfOfID = onBehalfOfID
+			actions = append(actions, action)
+			actions = append(actions, closeApproval(approval, domain.ApprovalStatusRejected, now)...)
+		}
+		approval.UpdatedAt = now
+
+		return actions, nil
+	})
+	if err != nil {
+		s.logger.Infow(err.Error(), "Decide Approval Error")
+		return web.ApprovalResponse{}, err
+	}
+
+	return s.findAndPublish(c, id, actions)
+}
+
+// start the next step of the approval, its tasks are pending until they are decided. The approval is approved when
+// there is no step left.
+func (s *approvalService) advance(c context.Context, approval *domain.ApprovalRequest, now time.Time) ([]domain.ApprovalAction, error) {
+	stage := approval.NextStage()
+	if len(stage) == 0 {
+		approval.Status = domain.ApprovalStatusApproved
+		approval.CompletedAt = &now
+		approval.UpdatedAt = now
+		return []domain.ApprovalAction{approval.NewAction(uuid.New().String(), nil, domain.ApprovalActionCompleted, nil, "", now)}, nil
+	}
+
+	var actions []domain.ApprovalAction
+	for _, i := range stage {
+		task := &approval.Tasks[i]
+		taskID := task.ID
+		task.Status = domain.ApprovalTaskStatusPending
+		task.UpdatedAt = now
+		if task.TimeoutHours != nil {
+			dueAt := now.Add(time.Duration(*task.TimeoutHours) * time.Hour)
+			task.DueAt = &dueAt
+		}
+		actions = append(actions, approval.NewAction(uuid.New().String(), &taskID, domain.ApprovalActionAssigned, nil, "", now))
+
+		delegated, err := s.delegate(c, approval, task, now)
+		if err != nil {
+			return nil, err
+		}
+		if delegated != nil {
+			actions = append(actions, *delegated)
+		}
+	}
+
+	return actions, nil
+}
+
+// delegate the task to the delegate of the active delegation of the approver, or to the manager of the approver on
+// leave, until the approver neither delegates nor is on leave. The task is never delegated to the requester and is
+// decided on behalf of the approver it was assigned to.
+func (s *approvalService) delegate(c context.Context, approval *domain.ApprovalRequest, task *domain.ApprovalTask, now time.Time) (*domain.ApprovalAction, error) {
+	if task.ApproverID == nil {
+		return nil, nil
+	}
+
+	approverID := *task.ApproverID
+	visited := map[string]bool{approval.RequesterID: true, approverID: true}
+	var note string
+	for {
+		var nextID, reason string
+		delegation, err := s.activeDelegation(c, approverID, approval.EntityType)
+		if err != nil {
+			return nil, err
+		}
+		if delegation != nil {
+			nextID = delegation.DelegateID
+			reason = fmt.Sprintf("The approver delegated the approvals to %s.", delegation.DelegateName)
+		} else {
+			onLeave, err := s.onLeave(c, approverID)
+			if err != nil {
+				return nil, err
+			}
+			if !onLeave {
+				break
+			}
+			approver, err := findEmployee(c, s.employeeRepository, approverID)
+			if err != nil {
+				return nil, err
+			}
+			if approver.ManagerID == nil {
+				break
+			}
+			nextID = *approver.ManagerID
+			reason = "The approver is on leave."
+		}
+		if visited[nextID] {
+			break
+		}
+		approverID = nextID
+		visited[approverID] = true
+		if note == "" {
+			note = reason
+		}
+	}
+	if approverID == *task.ApproverID {
+		return nil, nil
+	}
+
+	taskID := task.ID
+	task.AssignedFromID = task.ApproverID
+	task.OnBehalfOfID = task.ApproverID
+	task.ApproverID = &approverID
+	action := approval.NewAction(uuid.New().String(), &taskID, domain.ApprovalActionDelegated, nil, note, now)
+	action.OnBehalfOfID = task.OnBehalfOfID
+	return &action, nil
+}
+
+// escalate the overdue tasks of the locked approval, the tasks decided in the meantime are no longer overdue
+func (s *approvalService) escalate(c context.Context, id string, now time.Time) error {
+	actions, err := s.updateApproval(c, id, func(approval *domain.ApprovalRequest) ([]domain.ApprovalAction, error) {
+		var actions []domain.ApprovalAction
+		for i := range approval.Tasks {
+			task := &approval.Tasks[i]
+			if !task.Overdue(now) {
+				continue
+			}
+			taskID := task.ID
+			task.EscalatedAt = &now
+			task.UpdatedAt = now
+
+			// the task of a role has no approver yet, it is escalated to the manager of the requester
+			escalateFrom := approval.RequesterID
+			if task.ApproverID != nil {
+				escalateFrom = *task.ApproverID
+			}
+			employee, err := findEmployee(c, s.employeeRepository, escalateFrom)
+			if err != nil {
+				return nil, err
+			}
+			if employee.ManagerID == nil || *employee.ManagerID == approval.RequesterID {
+				actions = append(actions, approval.NewAction(uuid.New().String(), &taskID, domain.ApprovalActionEscalated, nil, "There is no manager to escalate the step to.", now))
+				continue
+			}
+
+			task.AssignedFromID = task.ApproverID
+			task.ApproverID = employee.ManagerID
+			task.OnBehalfOfID = nil
+			actions = append(actions, approval.NewAction(uuid.New().String(), &taskID, domain.ApprovalActionEscalated, nil,
+				fmt.Sprintf("The step is not decided within %d hours.", *task.TimeoutHours), now))
+		}
+		if len(actions) > 0 {
+			approval.UpdatedAt = now
+		}
+
+		return actions, nil
+	})
+	if err != nil || len(actions) == 0 {
+		return err
+	}
+
+	_, err = s.findAndPublish(c, id, actions)
+	return err
+}
+
+// the employee is on leave when an approved leave covers today
+func (s *approvalService) onLeave(c context.Context, employeeID string) (bool, error) {
+	day := helper.Today().Format(helper.DateLayout)
+	leaves, err := s.leaveRepository.FindAllLeave(c, domain.LeaveQueryFilter{
+		EmployeeID: employeeID,
+		Status:     domain.LeaveStatusApproved,
+		From:       day,
+		To:         day,
+	})
+	if err != nil {
+		return false, err
+	}
+
+	return len(leaves) > 0, nil
+}
+
+// the delegation of the employee which is active today and covers the entity type, nil when there is none. The
+// delegations of an employee don't overlap.
+func (s *approvalService) activeDelegation(c context.Context, employeeID, entityType string) (*domain.ApprovalDelegation, error) {
+	delegations, err := s.approvalRepository.FindAllDelegation(c, domain.ApprovalDelegationQueryFilter{
+		DelegatorID: employeeID,
+		EntityType:  entityType,
+		Date:        helper.Today().Format(helper.DateLayout),
+		Active:      true,
+	})
+	if err != nil || len(delegations) == 0 {
+		return nil, err
+	}
+
+	return &delegations[0], nil
+}
+
+// validate the approver of the steps exist, the position of a role and the employee of a user
+func (s *approvalService) validateSteps(c context.Context, workflow domain.ApprovalWorkflow) error {
+	for _, step := range workflow.Steps {
+		if step.ApproverPositionID != nil {
+			if _, err := s.positionRepository.FindById(c, *step.ApproverPositionID); err != nil {
+				if strings.Contains(err.Error(), "no rows") {
+					return exception.ErrNotFound(fmt.Sprintf("Position %s not found", *step.ApproverPositionID))
+				}
+				return err
+			}
+		}
+		if step.ApproverEmployeeID != nil {
+			if _, err := findEmployee(c, s.employeeRepository, *step.ApproverEmployeeID); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+func (s *approvalService) findAll(c context.Context, filter domain.ApprovalQueryFilter) (result []web.ApprovalResponse, totalData int, err error) {
+	approvals, err := s.approvalRepository.FindAllRequest(c, filter)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	// get total-data
+	totalData, err = s.approvalRepository.CountAllRequest(c, filter)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	// convert to web.ApprovalResponse
+	result = []web.ApprovalResponse{}
+	for _, approval := range approvals {
+		result = append(result, approval.ToApprovalResponse())
+	}
+
+	return result, totalData, nil
+}
+
+func (s *approvalService) findAllDelegation(c context.Context, filter domain.ApprovalDelegationQueryFilter) (result []web.ApprovalDelegationResponse, totalData int, err error) {
+	delegations, err := s.approvalRepository.FindAllDelegation(c, filter)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	// get total-data
+	totalData, err = s.approvalRepository.CountAllDelegation(c, filter)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	// convert to web.ApprovalDelegationResponse
+	result = []web.ApprovalDelegationResponse{}
+	for _, delegation := range delegations {
+		result = append(result, delegation.ToApprovalDelegationResponse())
+	}
+
+	return result, totalData, nil
+}
+
+// update the approval while it is locked, it returns the new actions of the update. The 'no rows' error is converted
+// to not found error.
+func (s *approvalService) updateApproval(c context.Context, id string, update func(approval *domain.ApprovalRequest) ([]domain.ApprovalAction, error)) ([]domain.ApprovalAction, error) {
+	var actions []domain.ApprovalAction
+	err := s.approvalRepository.UpdateRequest(c, id, func(approval *domain.ApprovalRequest) ([]domain.ApprovalAction, error) {
+		var err error
+		actions, err = update(approval)
+		return actions, err
+	})
+	if err != nil {
+		if strings.Contains(err.Error(), "no rows") {
+			return nil, exception.ErrNotFound(fmt.Sprintf("Approval %s not found", id))
+		}
+		return nil, err
+	}
+
+	return actions, nil
+}
+
+// find the saved approval, apply it to the entity when it is completed and publish its new actions
+func (s *approvalService) findAndPublish(c context.Context, id string, actions []domain.ApprovalAction) (web.ApprovalResponse, error) {
+	approval, err := findApproval(c, s.approvalRepository, id)
+	if err != nil {
+		return web.ApprovalResponse{}, err
+	}
+	if err := s.complete(c, approval, actions); err != nil {
+		return web.ApprovalResponse{}, err
+	}
+
+	return approval.ToApprovalResponse(), nil
+}
+
+// apply the completed approval to the entity through the service owning it and publish the new actions. The
+// approval stays completed when it can't be applied, the error is returned so it doesn't go unnoticed.
+func (s *approvalService) complete(c context.Context, approval domain.ApprovalRequest, actions []domain.ApprovalAction) error {
+	var err error
+	if entity, ok := s.entities[approval.EntityType]; ok && approval.Status != domain.ApprovalStatusPending {
+		if err = entity.ApplyApproval(c, approval); err != nil {
+			s.logger.Errorw("Apply Approval Error", "approval_id", approval.ID, "entity_type", approval.EntityType, "entity_id", approval.EntityID, "error", err.Error())
+		}
+	}
+	s.publish(approval, actions)
+
+	return err
+}
+
+// notify the approvers of the tasks assigned by the actions, the requester is notified and the completion is produced
+// when the approval is no longer pending
+func (s *approvalService) publish(approval domain.ApprovalRequest, actions []domain.ApprovalAction) {
+	notified := map[string]bool{}
+	for _, action := range actions {
+		if action.TaskID == nil || notified[*action.TaskID] {
+			continue
+		}
+		if action.Action != domain.ApprovalActionAssigned && action.Action != domain.ApprovalActionDelegated && action.Action != domain.ApprovalActionEscalated {
+			continue
+		}
+		for _, task := range approval.Tasks {
+			if task.ID == *action.TaskID && task.Status == domain.ApprovalTaskStatusPending && task.ApproverUserID != "" {
+				s.notify(task.ApproverUserID, NotificationApprovalAssigned, "Approval Requested",
+					fmt.Sprintf("%s requested your approval of %s.", approval.RequesterName, approval.Title), approval)
+				notified[task.ID] = true
+			}
+		}
+	}
+
+	switch approval.Status {
+	case domain.ApprovalStatusPending:
+		return
+	case domain.ApprovalStatusApproved:
+		s.notify(approval.RequesterUserID, NotificationApprovalApproved, "Approval Approved",
+			fmt.Sprintf("Your request %s has been approved.", approval.Title), approval)
+	case domain.ApprovalStatusRejected:
+		s.notify(approval.RequesterUserID, NotificationApprovalRejected, "Approval Rejected",
+			fmt.Sprintf("Your request %s has been rejected.", approval.Title), approval)
+	}
+
+	// publish the result for the other services, the service owning the entity has applied it
+	kafkaApprovalMessage := kafkamodel.NewKafkaApprovalMessage(approval)
+	go s.kafkaProducerService.Produce(kafkaApprovalMessage, "POST.APPROVAL_COMPLETED", config.KafkaTopic)
+}
+
+// produce the notification of the approval to the user
+func (s *approvalService) notify(userID, notificationType, title, message string, approval domain.ApprovalRequest) {
+	kafkaNotificationMessage := kafkamodel.NewKafkaNotificationMessage(userID, notificationType, title, message, map[string]interface{}{
+		"approval_id": approval.ID,
+		"entity_type": approval.EntityType,
+		"entity_id":   approval.EntityID,
+		"status":      approval.Status,
+	})
+	go s.kafkaProducerService.Produce(kafkaNotificationMessage, "POST.NOTIFICATION", config.KafkaTopicNotification)
+}
+
+// close the approval with the status, the tasks which are not decided yet are skipped
+func closeApproval(approval *domain.ApprovalRequest, status string, now time.Time) []domain.ApprovalAction {
+	var actions []domain.ApprovalAction
+	for i := range approval.Tasks {
+		task := &approval.Tasks[i]
+		if task.Status != domain.ApprovalTaskStatusWaiting && task.Status != domain.ApprovalTaskStatusPending {
+			continue
+		}
+		taskID := task.ID
+		task.Status = domain.ApprovalTaskStatusSkipped
+		task.UpdatedAt = now
+		actions = append(actions, approval.NewAction(uuid.New().String(), &taskID, domain.ApprovalActionSkipped, nil, "", now))
+	}
+	approval.Status = status
+	approval.CompletedAt = &now
+	approval.UpdatedAt = now
+
+	return actions
+}
+
+// find the approval workflow by id with its steps and convert the 'no rows' error to not found error
+func findApprovalWorkflow(c context.Context, approvalRepository repository.ApprovalRepository, id string) (domain.ApprovalWorkflow, error) {
+	workflow, err := approvalRepository.FindWorkflowById(c, id)
+	if err != nil {
+		if strings.Contains(err.Error(), "no rows") {
+			return domain.ApprovalWorkflow{}, exception.ErrNotFound(fmt.Sprintf("Approval workflow %s not found", id))
+		}
+		return domain.ApprovalWorkflow{}, err
+	}
+
+	return workflow, nil
+}
+
+// find the approval by id with its tasks and actions and convert the 'no rows' error to not found error
+func findApproval(c context.Context, approvalRepository repository.ApprovalRepository, id string) (domain.ApprovalRequest, error) {
+	approval, err := approvalRepository.FindRequestById(c, id)
+	if err != nil {
+		if strings.Contains(err.Error(), "no rows") {
+			return domain.ApprovalRequest{}, exception.ErrNotFound(fmt.Sprintf("Approval %s not found", id))
+		}
+		return domain.ApprovalRequest{}, err
+	}
+
+	return approval, nil
+}
+
+// find the approval delegation by id and convert the 'no rows' error to not found error
+func findApprovalDelegation(c context.Context, approvalRepository repository.ApprovalRepository, id string) (domain.ApprovalDelegation, error) {
+	delegation, err := approvalRepository.FindDelegationById(c, id)
+	if err != nil {
+		if strings.Contains(err.Error(), "no rows") {
+			return domain.ApprovalDelegation{}, exception.ErrNotFound(fmt.Sprintf("Approval delegation %s not found", id))
+		}
+		return domain.ApprovalDelegation{}, err
+	}
+
+	return delegation, nil
+}
+
+// convert the unique constraint error of the 'approval_workflows' table to bad request error
+func toApprovalWorkflowUniqueError(err error) error {
+	if strings.Contains(err.Error(), "unique") {
+		switch {
+		case strings.Contains(err.Error(), "approval_workflows_code_key"):
+			return exception.ErrBadRequest("Approval workflow code already exist.")
+		case strings.Contains(err.Error(), "approval_workflows_entity_type_key"):
+			return exception.ErrBadRequest("The entity type already has an active approval workflow.")
+		}
+	}
+	return err
+}
+
+// convert the unique constraint error of the 'approval_requests' table to bad request error
+func toApprovalUniqueError(err error) error {
+	if strings.Contains(err.Error(), "unique") && strings.Contains(err.Error(), "approval_requests_entity_key") {
+		return exception.ErrBadRequest("The entity already has a pending approval.")
+	}
+	return err
+}
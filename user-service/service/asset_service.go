@@ -0,0 +1,411 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/iqbaludinm/hr-microservice/user-service/exception"
+	"github.com/iqbaludinm/hr-microservice/user-service/helper"
+	"github.com/iqbaludinm/hr-microservice/user-service/model/domain"
+	"github.com/iqbaludinm/hr-microservice/user-service/model/web"
+	"github.com/iqbaludinm/hr-microservice/user-service/repository"
+	"go.uber.org/zap"
+)
+
+type AssetService interface {
+	// With Transaction
+	CreateAsset(ctx context.Context, request web.AssetRequest) (web.AssetResponse, error)
+	UpdateAsset(ctx context.Context, id string, request web.AssetRequest) (web.AssetResponse, error)
+	Delete(ctx context.Context, id string) error
+	Assign(ctx context.Context, userID, id string, request web.AssignAssetRequest) (web.AssetAssignmentResponse, error)
+	Return(ctx context.Context, userID, id string, request web.ReturnAssetRequest) (web.AssetAssignmentResponse, error)
+	CreateHandover(ctx context.Context, userID, id, assignmentID string, request web.AssetHandoverRequest) (web.HandoverResponse, error)
+
+	// Without Transaction
+	FindAllAsset(ctx context.Context, filter web.AssetQueryFilter) ([]web.AssetResponse, int, error)
+	FindMyAsset(ctx context.Context, userID string) ([]web.AssetResponse, error)
+	FindById(ctx context.Context, id string) (web.AssetResponse, error)
+	FindAssignments(ctx context.Context, id string) ([]web.AssetAssignmentResponse, error)
+}
+
+type assetService struct {
+	assetRepository    repository.AssetRepository
+	handoverRepository repository.HandoverRepository
+	employeeRepository repository.EmployeeRepository
+	logger             *zap.SugaredLogger
+}
+
+func NewAssetService(assetRepository repository.AssetRepository, handoverRepository repository.HandoverRepository, employeeRepository repository.EmployeeRepository, logger *zap.SugaredLogger) AssetService {
+	return &assetService{
+		assetRepository:    assetRepository,
+		handoverRepository: handoverRepository,
+		employeeRepository: employeeRepository,
+		logger:             logger,
+	}
+}
+
+func (s *assetService) CreateAsset(c context.Context, request web.AssetRequest) (web.AssetResponse, error) {
+	// convert to domain or model asset
+	asset := domain.ToDomainAsset(request)
+	asset.ID = uuid.New().String()
+	asset.CreatedAt = time.Now()
+	asset.UpdatedAt = time.Now()
+
+	// call the repo for inserting to db
+	if err := s.assetRepository.CreateAsset(c, asset); err != nil {
+		s.logger.Infow(err.Error(), "Create Asset Error")
+		return web.AssetResponse{}, toAssetUniqueError(err)
+	}
+
+	// get or returning the asset have created to db
+	newAsset, err := s.assetRepository.FindById(c, asset.ID)
+	if err != nil {
+		return web.AssetResponse{}, exception.ErrInternalServer(fmt.Sprintf("Successfully created asset, but failed to get the asset have created. Error: %s", err.Error()))
+	}
+
+	return newAsset.ToAssetResponse(), nil
+}
+
+// update the asset, the status of an assigned asset can only be changed by returning the asset
+func (s *assetService) UpdateAsset(c context.Context, id string, request web.AssetRequest) (web.AssetResponse, error) {
+	asset, err := findAsset(c, s.assetRepository, id)
+	if err != nil {
+		return web.AssetResponse{}, err
+	}
+
+	updated := domain.ToDomainAsset(request)
+	if asset.Status == domain.AssetStatusAssigned {
+		if request.Status != "" {
+			return web.AssetResponse{}, exception.ErrBadRequest("The status of an assigned asset can only be changed by returning the asset.")
+		}
+		updated.Status = domain.AssetStatusAssigned
+	}
+	updated.UpdatedAt = time.Now()
+
+	if err := s.assetRepository.UpdateAsset(c, id, updated); err != nil {
+		s.logger.Infow(err.Error(), "Update Asset Error")
+		return web.AssetResponse{}, toAssetUniqueError(err)
+	}
+
+	updatedAsset, err := s.assetRepository.FindById(c, id)
+	if err != nil {
+		return web.AssetResponse{}, exception.ErrInternalServer(fmt.Sprintf("Successfully updated asset, but failed to get the asset have updated. Error: %s", err.Error()))
+	}
+
+	return updatedAsset.ToAssetResponse(), nil
+}
+
+func (s *assetService) Delete(c context.Context, id string) error {
+	asset, err := findAsset(c, s.assetRepository, id)
+	if err != nil {
+		return err
+	}
+	if asset.Status == domain.AssetStatusAssigned {
+		return exception.ErrBadRequest("Asset is still assigned, the asset must be returned first.")
+	}
+
+	return s.assetRepository.Delete(c, id)
+}
+
+// assign the available asset to the employee, the assignment is recorded in the history of the asset
+func (s *assetService) Assign(c context.Context, userID, id string, request web.AssignAssetRequest) (web.AssetAssignmentResponse, error) {
+	asset, err := findAsset(c, s.assetRepository, id)
+	if err != nil {
+		return web.AssetAssignmentResponse{}, err
+	}
+	if asset.Status != domain.AssetStatusAvailable {
+		return web.AssetAssignmentResponse{}, exception.ErrBadRequest(fmt.Sprintf("Asset is %s, only an available asset can be assigned.", asset.Status))
+	}
+
+	employee, err := findEmployee(c, s.employeeRepository, request.EmployeeID)
+	if err != nil {
+		return web.AssetAssignmentResponse{}, err
+	}
+	if leavingStatus(employee.Status) {
+		return web.AssetAssignmentResponse{}, exception.ErrBadRequest(fmt.Sprintf("Employee is %s.", employee.Status))
+	}
+
+	assignedAt, _ := helper.ParseDate(request.AssignedAt)
+	if asset.PurchaseDate != nil && assignedAt.Before(*asset.PurchaseDate) {
+		return web.AssetAssignmentResponse{}, exception.ErrBadRequest("Asset can't be assigned before its purchase date.")
+	}
+
+	condition := request.Condition
+	if condition == "" {
+		condition = asset.Condition
+	}
+
+	assignment := domain.AssetAssignment{
+		ID:              uuid.New().String(),
+		AssetID:         asset.ID,
+		EmployeeID:      employee.ID,
+		AssignedAt:      assignedAt,
+		AssignedBy:      &userID,
+		AssignCondition: condition,
+		AssignNote:      request.Note,
+		CreatedAt:       time.Now(),
+		UpdatedAt:       time.Now(),
+	}
+
+	asset.EmployeeID = &employee.ID
+	asset.Status = domain.AssetStatusAssigned
+	asset.Condition = condition
+	asset.UpdatedAt = time.Now()
+
+	if err := s.assetRepository.Assign(c, asset, assignment); err != nil {
+		s.logger.Infow(err.Error(), "Assign Asset Error")
+		// the asset is assigned by another request at the same time
+		if strings.Contains(err.Error(), "asset_assignments_open_idx") {
+			return web.AssetAssignmentResponse{}, exception.ErrBadRequest("Asset is already assigned.")
+		}
+		return web.AssetAssignmentResponse{}, err
+	}
+
+	return s.findAssignment(c, asset.ID, assignment.ID)
+}
+
+// return the assigned asset from the employee. A damaged asset goes to maintenance and a lost asset is retired,
+// the other assets are available again.
+func (s *assetService) Return(c context.Context, userID, id string, request web.ReturnAssetRequest) (web.AssetAssignmentResponse, error) {
+	asset, err := findAsset(c, s.assetRepository, id)
+	if err != nil {
+		return web.AssetAssignmentResponse{}, err
+	}
+	if asset.Status != domain.AssetStatusAssigned || asset.AssignmentID == nil {
+		return web.AssetAssignmentResponse{}, exception.ErrBadRequest("Asset is not assigned.")
+	}
+
+	assignment, err := s.assetRepository.FindAssignmentById(c, asset.ID, *asset.AssignmentID)
+	if err != nil {
+		return web.AssetAssignmentResponse{}, err
+	}
+
+	returnedAt, _ := helper.ParseDate(request.ReturnedAt)
+	if returnedAt.Before(assignment.AssignedAt) {
+		return web.AssetAssignmentResponse{}, exception.ErrBadRequest("Asset can't be returned before it is assigned.")
+	}
+
+	assignment.ReturnedAt = &returnedAt
+	assignment.ReturnedBy = &userID
+	assignment.ReturnCondition = &request.Condition
+	assignment.ReturnNote = request.Note
+	assignment.UpdatedAt = time.Now()
+
+	asset.EmployeeID = nil
+	asset.Condition = request.Condition
+	asset.UpdatedAt = time.Now()
+	switch request.Condition {
+	case domain.AssetConditionDamaged:
+		asset.Status = domain.AssetStatusMaintenance
+	case domain.AssetConditionLost:
+		asset.Status = domain.AssetStatusRetired
+	default:
+		asset.Status = domain.AssetStatusAvailable
+	}
+
+	if err := s.assetRepository.Return(c, asset, assignment); err != nil {
+		s.logger.Infow(err.Error(), "Return Asset Error")
+		return web.AssetAssignmentResponse{}, err
+	}
+
+	return s.findAssignment(c, asset.ID, assignment.ID)
+}
+
+// create the draft handover document (BAST) of the assignment or the return of the asset. The handover is
+// submitted and signed through the handover endpoints, a new handover can only be created when the previous
+// one is rejected or cancelled.
+func (s *assetService) CreateHandover(c context.Context, userID, id, assignmentID string, request web.AssetHandoverRequest) (web.HandoverResponse, error) {
+	asset, err := findAsset(c, s.assetRepository, id)
+	if err != nil {
+		return web.HandoverResponse{}, err
+	}
+
+	assignment, err := s.assetRepository.FindAssignmentById(c, asset.ID, assignmentID)
+	if err != nil {
+		if strings.Contains(err.Error(), "no rows") {
+			return web.HandoverResponse{}, exception.ErrNotFound(fmt.Sprintf("Asset assignment %s not found", assignmentID))
+		}
+		return web.HandoverResponse{}, err
+	}
+	if request.Event == domain.AssetEventReturn && assignment.ReturnedAt == nil {
+		return web.HandoverResponse{}, exception.ErrBadRequest("Asset has not been returned.")
+	}
+
+	if handoverID := assignment.HandoverID(request.Event); handoverID != nil {
+		handover, err := findHandover(c, s.handoverRepository, *handoverID)
+		if err != nil {
+			return web.HandoverResponse{}, err
+		}
+		if handover.Status != domain.HandoverStatusRejected && handover.Status != domain.HandoverStatusCancelled {
+			return web.HandoverResponse{}, exception.ErrBadRequest(fmt.Sprintf("The %s of the asset already has handover %s.", request.Event, handover.DocumentNumber))
+		}
+	}
+
+	// the employee on the company side, who hands over the asset on assignment or receives it on return
+	officerID := request.EmployeeID
+	if officerID == "" {
+		officer, err := findEmployeeByUser(c, s.employeeRepository, userID)
+		if err != nil {
+			return web.HandoverResponse{}, err
+		}
+		officerID = officer.ID
+	}
+
+	fromEmployeeID, toEmployeeID := officerID, assignment.EmployeeID
+	if request.Event == domain.AssetEventReturn {
+		fromEmployeeID, toEmployeeID = assignment.EmployeeID, officerID
+	}
+
+	handover := domain.NewAssetHandover(asset, assignment, request.Event, fromEmployeeID, toEmployeeID, request.Location, request.Signers)
+	if err := validateHandover(c, s.employeeRepository, handover); err != nil {
+		return web.HandoverResponse{}, err
+	}
+
+	handover.ID = uuid.New().String()
+	handover.Status = domain.HandoverStatusDraft
+	handover.CreatedBy = &userID
+	handover.CreatedAt = time.Now()
+	handover.UpdatedAt = time.Now()
+
+	if request.Event == domain.AssetEventReturn {
+		assignment.ReturnHandoverID = &handover.ID
+	} else {
+		assignment.AssignHandoverID = &handover.ID
+	}
+	assignment.UpdatedAt = time.Now()
+
+	// call the repo for inserting the handover and linking it to the assignment
+	if err := s.assetRepository.CreateHandover(c, &handover, assignment); err != nil {
+		s.logger.Infow(err.Error(), "Create Asset Handover Error")
+		return web.HandoverResponse{}, err
+	}
+
+	newHandover, err := s.handoverRepository.FindById(c, handover.ID)
+	if err != nil {
+		return web.HandoverResponse{}, exception.ErrInternalServer(fmt.Sprintf("Successfully created handover, but failed to get the handover have created. Error: %s", err.Error()))
+	}
+
+	return newHandover.ToHandoverResponse(), nil
+}
+
+func (s *assetService) FindAllAsset(c context.Context, filter web.AssetQueryFilter) (result []web.AssetResponse, totalData int, err error) {
+	assets, err := s.assetRepository.FindAllAsset(c, domain.ToDomainAssetQueryFilter(filter))
+	if err != nil {
+		return nil, 0, err
+	}
+
+	// get total-data
+	totalData, err = s.assetRepository.CountAllAsset(c, domain.ToDomainAssetQueryFilter(filter))
+	if err != nil {
+		return nil, 0, err
+	}
+
+	// convert to web.AssetResponse
+	result = []web.AssetResponse{}
+	for _, asset := range assets {
+		result = append(result, asset.ToAssetResponse())
+	}
+
+	return result, totalData, nil
+}
+
+// find the assets held by the logged in user
+func (s *assetService) FindMyAsset(c context.Context, userID string) ([]web.AssetResponse, error) {
+	employee, err := findEmployeeByUser(c, s.employeeRepository, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	return findEmployeeAssets(c, s.assetRepository, employee.ID)
+}
+
+func (s *assetService) FindById(c context.Context, id string) (web.AssetResponse, error) {
+	asset, err := findAsset(c, s.assetRepository, id)
+	if err != nil {
+		return web.AssetResponse{}, err
+	}
+
+	return asset.ToAssetResponse(), nil
+}
+
+// find the assignment history of the asset, the latest assignment first
+func (s *assetService) FindAssignments(c context.Context, id string) ([]web.AssetAssignmentResponse, error) {
+	if _, err := findAsset(c, s.assetRepository, id); err != nil {
+		return nil, err
+	}
+
+	assignments, err := s.assetRepository.FindAssignments(c, id)
+	if err != nil {
+		return nil, err
+	}
+
+	// convert to web.AssetAssignmentResponse
+	result := []web.AssetAssignmentResponse{}
+	for _, assignment := range assignments {
+		result = append(result, assignment.ToAssetAssignmentResponse())
+	}
+
+	return result, nil
+}
+
+func (s *assetService) findAssignment(c context.Context, assetID, id string) (web.AssetAssignmentResponse, error) {
+	assignment, err := s.assetRepository.FindAssignmentById(c, assetID, id)
+	if err != nil {
+		return web.AssetAssignmentResponse{}, exception.ErrInternalServer(fmt.Sprintf("Successfully saved asset assignment, but failed to get the assignment have saved. Error: %s", err.Error()))
+	}
+
+	return assignment.ToAssetAssignmentResponse(), nil
+}
+
+// find the asset by id and convert the 'no rows' error to not found error
+func findAsset(c context.Context, assetRepository repository.AssetRepository, id string) (domain.Asset, error) {
+	asset, err := assetRepository.FindById(c, id)
+	if err != nil {
+		if strings.Contains(err.Error(), "no rows") {
+			return domain.Asset{}, exception.ErrNotFound(fmt.Sprintf("Asset %s not found", id))
+		}
+		return domain.Asset{}, err
+	}
+
+	return asset, nil
+}
+
+// find the assets held by the employee
+func findEmployeeAssets(c context.Context, assetRepository repository.AssetRepository, employeeID string) ([]web.AssetResponse, error) {
+	assets, err := assetRepository.FindAllAsset(c, domain.AssetQueryFilter{EmployeeID: employeeID})
+	if err != nil {
+		return nil, err
+	}
+
+	// convert to web.AssetResponse
+	result := []web.AssetResponse{}
+	for _, asset := range assets {
+		result = append(result, asset.ToAssetResponse())
+	}
+
+	return result, nil
+}
+
+// validate the employee has returned every asset, the employee can't leave the company while holding an asset
+func validateAssetsReturned(c context.Context, assetRepository repository.AssetRepository, employeeID string) error {
+	count, err := assetRepository.CountAssignedAsset(c, employeeID)
+	if err != nil {
+		return err
+	}
+	if count > 0 {
+		return exception.ErrBadRequest(fmt.Sprintf("Employee still holds %d asset(s), the assets must be returned first.", count))
+	}
+
+	return nil
+}
+
+// convert the unique constraint error of the 'assets' table to bad request error
+func toAssetUniqueError(err error) error {
+	if strings.Contains(err.Error(), "unique") && strings.Contains(err.Error(), "assets_asset_code_key") {
+		return exception.ErrBadRequest("Asset code already exist.")
+	}
+	return err
+}
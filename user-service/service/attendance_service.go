@@ -0,0 +1,431 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/iqbaludinm/hr-microservice/user-service/config"
+	"github.com/iqbaludinm/hr-microservice/user-service/exception"
+	"github.com/iqbaludinm/hr-microservice/user-service/helper"
+	"github.com/iqbaludinm/hr-microservice/user-service/model/domain"
+	"github.com/iqbaludinm/hr-microservice/user-service/model/kafkamodel"
+	"github.com/iqbaludinm/hr-microservice/user-service/model/web"
+	"github.com/iqbaludinm/hr-microservice/user-service/repository"
+	"github.com/iqbaludinm/hr-microservice/user-service/service/producers"
+	"go.uber.org/zap"
+)
+
+type AttendanceService interface {
+	// With Transaction
+	CheckIn(ctx context.Context, userID string, request web.CheckAttendanceRequest, selfie *multipart.FileHeader) (web.AttendanceResponse, error)
+	CheckOut(ctx context.Context, userID string, request web.CheckAttendanceRequest, selfie *multipart.FileHeader) (web.AttendanceResponse, error)
+
+	// Without Transaction
+	FindAllAttendance(ctx context.Context, filter web.AttendanceQueryFilter) ([]web.AttendanceResponse, int, error)
+	FindMyAttendance(ctx context.Context, userID string, filter web.AttendanceQueryFilter) ([]web.AttendanceResponse, int, error)
+	FindById(ctx context.Context, id string) (web.AttendanceResponse, error)
+	DailySummary(ctx context.Context, filter web.AttendanceSummaryQueryFilter) (web.AttendanceSummaryResponse, error)
+	MonthlySummary(ctx context.Context, filter web.AttendanceSummaryQueryFilter) (web.AttendanceSummaryResponse, error)
+}
+
+type attendanceService struct {
+	attendanceRepository     repository.AttendanceRepository
+	shiftRepository          repository.ShiftRepository
+	rosterRepository         repository.RosterRepository
+	officeLocationRepository repository.OfficeLocationRepository
+	employeeRepository       repository.EmployeeRepository
+	departmentRepository     repository.DepartmentRepository
+	kafkaProducerService     producers.KafkaProducerService
+	logger                   *zap.SugaredLogger
+}
+
+func NewAttendanceService(attendanceRepository repository.AttendanceRepository, shiftRepository repository.ShiftRepository, rosterRepository repository.RosterRepository, officeLocationRepository repository.OfficeLocationRepository, employeeRepository repository.EmployeeRepository, departmentRepository repository.DepartmentRepository, kafkaProducerService producers.KafkaProducerService, logger *zap.SugaredLogger) AttendanceService {
+	return &attendanceService{
+		attendanceRepository:     attendanceRepository,
+		shiftRepository:          shiftRepository,
+		rosterRepository:         rosterRepository,
+		officeLocationRepository: officeLocationRepository,
+		employeeRepository:       employeeRepository,
+		departmentRepository:     departmentRepository,
+		kafkaProducerService:     kafkaProducerService,
+		logger:                   logger,
+	}
+}
+
+func (s *attendanceService) CheckIn(c context.Context, userID string, request web.CheckAttendanceRequest, selfie *multipart.FileHeader) (web.AttendanceResponse, error) {
+	now := time.Now()
+
+	employee, err := s.findActiveEmployee(c, userID)
+	if err != nil {
+		return web.AttendanceResponse{}, err
+	}
+
+	// the previous attendance must be checked out first, unless its check-out is already missed
+	open, err := s.attendanceRepository.FindOpenByEmployee(c, employee.ID)
+	if err != nil && !strings.Contains(err.Error(), "no rows") {
+		return web.AttendanceResponse{}, err
+	}
+	if err == nil && now.Before(open.CheckOutDeadline()) {
+		return web.AttendanceResponse{}, exception.ErrBadRequest(fmt.Sprintf("Already checked in at %s, check out first.", open.CheckInAt.In(helper.WIB).Format("2006-01-02 15:04")))
+	}
+
+	shift, err := s.findScheduledShift(c, employee.ID, now)
+	if err != nil {
+		return web.AttendanceResponse{}, err
+	}
+
+	attendance := domain.NewAttendance(employee.ID, shift, now)
+	if _, err := s.attendanceRepository.FindByEmployeeDate(c, employee.ID, attendance.Date); err == nil {
+		return web.AttendanceResponse{}, exception.ErrBadRequest(fmt.Sprintf("Already checked in on %s.", attendance.Date.Format(helper.DateLayout)))
+	} else if !strings.Contains(err.Error(), "no rows") {
+		return web.AttendanceResponse{}, err
+	}
+
+	locationID, err := s.validateLocation(c, request)
+	if err != nil {
+		return web.AttendanceResponse{}, err
+	}
+
+	attendance.ID = uuid.New().String()
+	attendance.CheckInLatitude = request.Latitude
+	attendance.CheckInLongitude = request.Longitude
+	attendance.CheckInLocationID = locationID
+	attendance.CreatedAt = now
+	attendance.UpdatedAt = now
+
+	if selfie != nil {
+		if attendance.CheckInPhoto, err = s.saveSelfie(selfie, attendance, "check_in"); err != nil {
+			return web.AttendanceResponse{}, err
+		}
+	}
+
+	// call the repo for inserting to db
+	if err := s.attendanceRepository.CreateAttendance(c, attendance); err != nil {
+		s.logger.Infow(err.Error(), "Check In Error")
+		s.removeSelfie(attendance.CheckInPhoto)
+		return web.AttendanceResponse{}, toAttendanceUniqueError(err)
+	}
+
+	newAttendance, err := s.attendanceRepository.FindById(c, attendance.ID)
+	if err != nil {
+		return web.AttendanceResponse{}, exception.ErrInternalServer(fmt.Sprintf("Successfully checked in, but failed to get the attendance have created. Error: %s", err.Error()))
+	}
+
+	kafkaAttendanceMessage := kafkamodel.NewKafkaAttendanceMessage(newAttendance)
+	go s.kafkaProducerService.Produce(kafkaAttendanceMessage, "POST.ATTENDANCE", config.KafkaTopic)
+
+	return newAttendance.ToAttendanceResponse(), nil
+}
+
+func (s *attendanceService) CheckOut(c context.Context, userID string, request web.CheckAttendanceRequest, selfie *multipart.FileHeader) (web.AttendanceResponse, error) {
+	now := time.Now()
+
+	employee, err := s.findActiveEmployee(c, userID)
+	if err != nil {
+		return web.AttendanceResponse{}, err
+	}
+
+	attendance, err := s.attendanceRepository.FindOpenByEmployee(c, employee.ID)
+	if err != nil && !strings.Contains(err.Error(), "no rows") {
+		return web.AttendanceResponse{}, err
+	}
+	if err != nil || !now.Before(attendance.CheckOutDeadline()) {
+		return web.AttendanceResponse{}, exception.ErrBadRequest("There is no check-in to check out.")
+	}
+
+	locationID, err := s.validateLocation(c, request)
+	if err != nil {
+		return web.AttendanceResponse{}, err
+	}
+
+	// the check-out is computed against the shift of the check-in
+	var shift *domain.Shift
+	if attendance.ShiftID != nil {
+		if data, err := s.shiftRepository.FindById(c, *attendance.ShiftID); err == nil {
+			shift = &data
+		}
+	}
+
+	attendance.CheckOut(shift, now)
+	attendance.CheckOutLatitude = request.Latitude
+	attendance.CheckOutLongitude = request.Longitude
+	attendance.CheckOutLocationID = locationID
+	attendance.UpdatedAt = now
+
+	if selfie != nil {
+		if attendance.CheckOutPhoto, err = s.saveSelfie(selfie, attendance, "check_out"); err != nil {
+			return web.AttendanceResponse{}, err
+		}
+	}
+
+	if err := s.attendanceRepository.UpdateCheckOut(c, attendance.ID, attendance); err != nil {
+		s.logger.Infow(err.Error(), "Check Out Error")
+		s.removeSelfie(attendance.CheckOutPhoto)
+		return web.AttendanceResponse{}, err
+	}
+
+	kafkaAttendanceMessage := kafkamodel.NewKafkaAttendanceMessage(attendance)
+	go s.kafkaProducerService.Produce(kafkaAttendanceMessage, "PUT.ATTENDANCE", config.KafkaTopic)
+
+	return attendance.ToAttendanceResponse(), nil
+}
+
+func (s *attendanceService) FindAllAttendance(c context.Context, filter web.AttendanceQueryFilter) ([]web.AttendanceResponse, int, error) {
+	domainFilter := domain.ToDomainAttendanceQueryFilter(filter)
+	if filter.DepartmentID != "" {
+		departmentIds, err := findDepartmentIds(c, s.departmentRepository, filter.DepartmentID)
+		if err != nil {
+			return nil, 0, err
+		}
+		domainFilter.DepartmentIDs = departmentIds
+	}
+
+	return s.findAllAttendance(c, domainFilter)
+}
+
+func (s *attendanceService) FindMyAttendance(c context.Context, userID string, filter web.AttendanceQueryFilter) ([]web.AttendanceResponse, int, error) {
+	employee, err := findEmployeeByUser(c, s.employeeRepository, userID)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	domainFilter := domain.ToDomainAttendanceQueryFilter(filter)
+	domainFilter.EmployeeID = employee.ID
+
+	return s.findAllAttendance(c, domainFilter)
+}
+
+func (s *attendanceService) FindById(c context.Context, id string) (web.AttendanceResponse, error) {
+	attendance, err := s.attendanceRepository.FindById(c, id)
+	if err != nil {
+		if strings.Contains(err.Error(), "no rows") {
+			return web.AttendanceResponse{}, exception.ErrNotFound(fmt.Sprintf("Attendance %s not found", id))
+		}
+		return web.AttendanceResponse{}, err
+	}
+
+	return attendance.ToAttendanceResponse(), nil
+}
+
+func (s *attendanceService) DailySummary(c context.Context, filter web.AttendanceSummaryQueryFilter) (web.AttendanceSummaryResponse, error) {
+	date := helper.Today()
+	if filter.Date != "" {
+		date, _ = helper.ParseDate(filter.Date)
+	}
+
+	return s.summarize(c, filter, date, date)
+}
+
+func (s *attendanceService) MonthlySummary(c context.Context, filter web.AttendanceSummaryQueryFilter) (web.AttendanceSummaryResponse, error) {
+	today := helper.Today()
+	from := time.Date(today.Year(), today.Month(), 1, 0, 0, 0, 0, time.UTC)
+	if filter.Month != "" {
+		from, _ = time.Parse("2006-01", filter.Month)
+	}
+
+	return s.summarize(c, filter, from, from.AddDate(0, 1, -1))
+}
+
+// summarize the attendances between the dates (inclusive), per employee or per department
+func (s *attendanceService) summarize(c context.Context, filter web.AttendanceSummaryQueryFilter, from, to time.Time) (web.AttendanceSummaryResponse, error) {
+	domainFilter := domain.AttendanceQueryFilter{
+		EmployeeID: filter.EmployeeID,
+		From:       from.Format(helper.DateLayout),
+		To:         to.Format(helper.DateLayout),
+	}
+	if filter.DepartmentID != "" {
+		departmentIds, err := findDepartmentIds(c, s.departmentRepository, filter.DepartmentID)
+		if err != nil {
+			return web.AttendanceSummaryResponse{}, err
+		}
+		domainFilter.DepartmentIDs = departmentIds
+	}
+
+	summaries, err := s.attendanceRepository.Summarize(c, domainFilter, filter.GroupBy == "department")
+	if err != nil {
+		return web.AttendanceSummaryResponse{}, err
+	}
+
+	// convert to web.AttendanceSummaryResponse
+	result := web.AttendanceSummaryResponse{
+		From:  domainFilter.From,
+		To:    domainFilter.To,
+		Items: []web.AttendanceSummaryItem{},
+	}
+	for _, summary := range summaries {
+		result.Items = append(result.Items, summary.ToAttendanceSummaryItem())
+	}
+
+	return result, nil
+}
+
+func (s *attendanceService) findAllAttendance(c context.Context, filter domain.AttendanceQueryFilter) (result []web.AttendanceResponse, totalData int, err error) {
+	attendances, err := s.attendanceRepository.FindAllAttendance(c, filter)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	// get total-data
+	totalData, err = s.attendanceRepository.CountAllAttendance(c, filter)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	// convert to web.AttendanceResponse
+	result = []web.AttendanceResponse{}
+	for _, attendance := range attendances {
+		result = append(result, attendance.ToAttendanceResponse())
+	}
+
+	return result, totalData, nil
+}
+
+// find the employee of the user, only the active employee can check in and check out
+func (s *attendanceService) findActiveEmployee(c context.Context, userID string) (domain.Employee, error) {
+	employee, err := findEmployeeByUser(c, s.employeeRepository, userID)
+	if err != nil {
+		return domain.Employee{}, err
+	}
+	if employee.Status != domain.EmployeeStatusActive {
+		return domain.Employee{}, exception.ErrBadRequest(fmt.Sprintf("Employee is %s and can't record the attendance.", employee.Status))
+	}
+
+	return employee, nil
+}
+
+// find the shift of the employee at the check-in time. The planned roster is used when it exists: the roster
+// of the previous date when its shift crosses midnight and hasn't ended yet, otherwise the roster of the date.
+// The assigned or default shift is used when the employee is not rostered. nil is returned on a rostered day
+// off, or when the employee has no shift and there is no default shift.
+func (s *attendanceService) findScheduledShift(c context.Context, employeeID string, at time.Time) (*domain.Shift, error) {
+	local := at.In(helper.WIB)
+	today := time.Date(local.Year(), local.Month(), local.Day(), 0, 0, 0, 0, time.UTC)
+
+	for _, date := range []time.Time{today.AddDate(0, 0, -1), today} {
+		roster, err := s.rosterRepository.FindByEmployeeDate(c, employeeID, date)
+		if err != nil {
+			if strings.Contains(err.Error(), "no rows") {
+				continue
+			}
+			return nil, err
+		}
+		if roster.ShiftID == nil {
+			if date.Equal(today) {
+				return nil, nil
+			}
+			continue
+		}
+
+		shift, err := findShift(c, s.shiftRepository, *roster.ShiftID)
+		if err != nil {
+			return nil, err
+		}
+		if date.Equal(today) || shift.WorkDate(at).Equal(date) {
+			return &shift, nil
+		}
+	}
+
+	shift, err := s.shiftRepository.FindByEmployeeId(c, employeeID)
+	if err != nil {
+		if strings.Contains(err.Error(), "no rows") {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return &shift, nil
+}
+
+// validate the coordinates are inside one of the office geofences and return the matched office location.
+// The coordinates are optional unless ATTENDANCE_REQUIRE_LOCATION is true, and every location is
+// accepted when there is no office location configured.
+func (s *attendanceService) validateLocation(c context.Context, request web.CheckAttendanceRequest) (*string, error) {
+	if (request.Latitude == nil) != (request.Longitude == nil) {
+		return nil, exception.ErrBadRequest("Latitude and longitude must be filled together.")
+	}
+	if request.Latitude == nil {
+		if config.AttendanceRequireLocation {
+			return nil, exception.ErrBadRequest("GPS coordinates are required.")
+		}
+		return nil, nil
+	}
+
+	locations, err := s.officeLocationRepository.FindAllOfficeLocation(c)
+	if err != nil {
+		return nil, err
+	}
+	if len(locations) == 0 {
+		return nil, nil
+	}
+
+	for _, location := range locations {
+		if location.Contains(*request.Latitude, *request.Longitude) {
+			return &location.ID, nil
+		}
+	}
+
+	return nil, exception.ErrBadRequest("Location is outside of the office area.")
+}
+
+// validate and save the selfie of the attendance, the path of the saved file is returned
+func (s *attendanceService) saveSelfie(selfie *multipart.FileHeader, attendance domain.Attendance, kind string) (string, error) {
+	if config.AttendancePhotoMaxSizeKB > 0 && selfie.Size > int64(config.AttendancePhotoMaxSizeKB)*1024 {
+		return "", exception.ErrBadRequest(fmt.Sprintf("Selfie can't be larger than %d KB.", config.AttendancePhotoMaxSizeKB))
+	}
+
+	// the type is detected from the content, the content type of the request can't be trusted
+	file, err := selfie.Open()
+	if err != nil {
+		s.logger.Infow(err.Error(), "Save Selfie Error")
+		return "", exception.ErrInternalServer("Failed to read the selfie.")
+	}
+	head := make([]byte, 512)
+	n, _ := io.ReadFull(file, head)
+	file.Close()
+
+	var extension string
+	switch http.DetectContentType(head[:n]) {
+	case "image/jpeg":
+		extension = ".jpg"
+	case "image/png":
+		extension = ".png"
+	default:
+		return "", exception.ErrBadRequest("Selfie must be a JPEG or PNG image.")
+	}
+
+	// the id of the attendance keeps a rejected check in from overwriting the selfie of the existing one
+	fileName := fmt.Sprintf("%s_%s_%s_%s%s", attendance.EmployeeID, attendance.Date.Format(helper.DateLayout), kind, attendance.ID, extension)
+	path, err := helper.SaveMultipartFile(selfie, config.AttendancePhotoDir, fileName)
+	if err != nil {
+		s.logger.Infow(err.Error(), "Save Selfie Error")
+		return "", exception.ErrInternalServer("Failed to save the selfie.")
+	}
+
+	return path, nil
+}
+
+// remove the saved selfie of an attendance that failed to be saved
+func (s *attendanceService) removeSelfie(path string) {
+	if path == "" {
+		return
+	}
+	if err := os.Remove(path); err != nil && !errors.Is(err, os.ErrNotExist) {
+		s.logger.Errorw("Remove Selfie Error", "path", path, "error", err.Error())
+	}
+}
+
+// convert the unique constraint error of the 'attendances' table to bad request error
+func toAttendanceUniqueError(err error) error {
+	if strings.Contains(err.Error(), "unique") && strings.Contains(err.Error(), "attendances_employee_id_date_key") {
+		return exception.ErrBadRequest("Already checked in on the date.")
+	}
+	return err
+}
@@ -0,0 +1,53 @@
+package consumers
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/iqbaludinm/hr-microservice/user-service/model/domain"
+	"github.com/iqbaludinm/hr-microservice/user-service/model/kafkamodel"
+	"github.com/iqbaludinm/hr-microservice/user-service/repository"
+	"go.uber.org/zap"
+)
+
+type KafkaEmployeeConsumerService interface {
+	UpdatePersonal(message []byte) error
+}
+
+type kafkaEmployeeConsumerService struct {
+	employeeRepository repository.EmployeeRepository
+	logger             *zap.SugaredLogger
+}
+
+func NewKafkaEmployeeConsumerService(employeeRepository repository.EmployeeRepository, logger *zap.SugaredLogger) KafkaEmployeeConsumerService {
+	return &kafkaEmployeeConsumerService{
+		employeeRepository: employeeRepository,
+		logger:             logger,
+	}
+}
+
+// Consume the personal data (address and emergency contacts) that is edited by the employee
+// itself through the profile-service.
+func (s *kafkaEmployeeConsumerService) UpdatePersonal(message []byte) error {
+	employeeMsg := new(kafkamodel.KafkaEmployeeMessage)
+
+	if err := json.Unmarshal(message, employeeMsg); err != nil {
+		s.logger.Errorw("error kafka update employee personal consumer:", "error", err.Error())
+		return nil
+	}
+
+	employee := domain.Employee{
+		ID:                employeeMsg.ID,
+		Address:           employeeMsg.Address,
+		EmergencyContacts: employeeMsg.EmergencyContacts,
+		UpdatedAt:         time.Now(),
+	}
+
+	// update employee
+	if err := s.employeeRepository.UpdatePersonal(context.TODO(), employee.ID, employee); err != nil {
+		s.logger.Errorw("error kafka update employee personal consumer:", "error", err.Error())
+	}
+
+	return nil
+}
@@ -15,6 +15,7 @@ type KafkaUserConsumerService interface {
 	Insert(message []byte) error
 	Update(message []byte) error
 	UpdatePass(message []byte) error
+	UpdateAvatar(message []byte) error
 	Delete(message []byte) error
 }
 
@@ -100,6 +101,27 @@ func (s *kafkaUserConsumerService) UpdatePass(message []byte) error {
 	return nil
 }
 
+func (s *kafkaUserConsumerService) UpdateAvatar(message []byte) error {
+	userMsg := new(kafkamodel.KafkaUserMessage)
+
+	if err := json.Unmarshal(message, userMsg); err != nil {
+		s.logger.Errorw("error kafka update avatar user consumer:", "error", err.Error())
+	}
+
+	user := domain.User{
+		ID:        userMsg.ID,
+		AvatarURL: userMsg.AvatarURL,
+		UpdatedAt: userMsg.UpdatedAt,
+	}
+
+	// update avatar of the user
+	if err := s.userRepository.UpdateAvatar(context.TODO(), user); err != nil {
+		s.logger.Errorw("error kafka update avatar user consumer:", "error", err.Error())
+	}
+
+	return nil
+}
+
 func (s *kafkaUserConsumerService) Delete(message []byte) error {
 	userMsg := new(kafkamodel.KafkaUserMessage)
 
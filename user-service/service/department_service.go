@@ -0,0 +1,300 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"embed"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/iqbaludinm/hr-microservice/user-service/exception"
+	"github.com/iqbaludinm/hr-microservice/user-service/helper"
+	"github.com/iqbaludinm/hr-microservice/user-service/model/domain"
+	"github.com/iqbaludinm/hr-microservice/user-service/model/web"
+	"github.com/iqbaludinm/hr-microservice/user-service/repository"
+	"go.uber.org/zap"
+)
+
+type DepartmentService interface {
+	// With Transaction
+	CreateDepartment(ctx context.Context, request web.CreateDepartmentRequest) (web.DepartmentResponse, error)
+	UpdateDepartment(ctx context.Context, id string, request web.UpdateDepartmentRequest) (web.DepartmentResponse, error)
+	Delete(ctx context.Context, id string) error
+
+	// Without Transaction
+	FindAllDepartment(ctx context.Context, filter web.DepartmentQueryFilter) (result []web.DepartmentResponse, totalData int, err error)
+	FindById(ctx context.Context, id string) (web.DepartmentResponse, error)
+	FindTree(ctx context.Context) ([]web.DepartmentTreeResponse, error)
+	FindMembers(ctx context.Context, id string, filter web.DepartmentMemberQueryFilter) ([]web.EmployeeResponse, error)
+	OrgChart(ctx context.Context) ([]web.OrgChartDepartment, error)
+	OrgChartPDF(ctx context.Context) (bytes.Buffer, error)
+}
+
+type departmentService struct {
+	departmentRepository repository.DepartmentRepository
+	positionRepository   repository.PositionRepository
+	employeeRepository   repository.EmployeeRepository
+	templateFS           embed.FS
+	pdfRenderer          helper.PDFRenderer
+	logger               *zap.SugaredLogger
+}
+
+func NewDepartmentService(departmentRepository repository.DepartmentRepository, positionRepository repository.PositionRepository, employeeRepository repository.EmployeeRepository, templateFS embed.FS, pdfRenderer helper.PDFRenderer, logger *zap.SugaredLogger) DepartmentService {
+	return &departmentService{
+		departmentRepository: departmentRepository,
+		positionRepository:   positionRepository,
+		employeeRepository:   employeeRepository,
+		templateFS:           templateFS,
+		pdfRenderer:          pdfRenderer,
+		logger:               logger,
+	}
+}
+
+func (s *departmentService) CreateDepartment(c context.Context, request web.CreateDepartmentRequest) (web.DepartmentResponse, error) {
+	parentID := request.ParentID
+	if parentID != nil && *parentID == "" {
+		parentID = nil
+	}
+	if parentID != nil {
+		if _, err := s.findDepartment(c, *parentID); err != nil {
+			return web.DepartmentResponse{}, err
+		}
+	}
+
+	// convert to domain or model department
+	department := domain.Department{
+		ID:        uuid.New().String(),
+		Code:      strings.ToUpper(request.Code),
+		Name:      request.Name,
+		ParentID:  parentID,
+		CreatedAt: time.Now(),
+		UpdatedAt: time.Now(),
+	}
+
+	// call the repo for inserting to db
+	if err := s.departmentRepository.CreateDepartment(c, department); err != nil {
+		s.logger.Infow(err.Error(), "Create Department Error")
+		return web.DepartmentResponse{}, toDepartmentUniqueError(err)
+	}
+
+	return department.ToDepartmentResponse(), nil
+}
+
+func (s *departmentService) UpdateDepartment(c context.Context, id string, request web.UpdateDepartmentRequest) (web.DepartmentResponse, error) {
+	department, err := s.findDepartment(c, id)
+	if err != nil {
+		return web.DepartmentResponse{}, err
+	}
+
+	// only the filled fields are updated
+	if request.Code != "" {
+		department.Code = strings.ToUpper(request.Code)
+	}
+	if request.Name != "" {
+		department.Name = request.Name
+	}
+	if request.ParentID != nil {
+		// an empty parent id is used to move the department to the root of the tree
+		department.ParentID = request.ParentID
+		if *request.ParentID == "" {
+			department.ParentID = nil
+		}
+	}
+	department.UpdatedAt = time.Now()
+
+	if department.ParentID != nil {
+		if _, err := s.findDepartment(c, *department.ParentID); err != nil {
+			return web.DepartmentResponse{}, err
+		}
+
+		// the parent can't be the department itself or one of its sub-departments
+		subDepartmentIds, err := s.departmentRepository.FindSubDepartmentIds(c, id)
+		if err != nil {
+			return web.DepartmentResponse{}, err
+		}
+		for _, subDepartmentID := range subDepartmentIds {
+			if subDepartmentID == *department.ParentID {
+				return web.DepartmentResponse{}, exception.ErrBadRequest("Parent can't be the department itself or one of its sub-departments.")
+			}
+		}
+	}
+
+	if err := s.departmentRepository.UpdateDepartment(c, id, department); err != nil {
+		s.logger.Infow(err.Error(), "Update Department Error")
+		return web.DepartmentResponse{}, toDepartmentUniqueError(err)
+	}
+
+	return department.ToDepartmentResponse(), nil
+}
+
+func (s *departmentService) Delete(c context.Context, id string) error {
+	if _, err := s.findDepartment(c, id); err != nil {
+		return err
+	}
+
+	// the department can only be deleted when nothing refers to it anymore
+	children, err := s.departmentRepository.CountAllDepartment(c, domain.DepartmentQueryFilter{ParentID: id})
+	if err != nil {
+		return err
+	}
+	if children > 0 {
+		return exception.ErrBadRequest("Department still has sub-departments.")
+	}
+
+	members, err := s.employeeRepository.CountAllEmployee(c, domain.EmployeeQueryFilter{DepartmentID: id})
+	if err != nil {
+		return err
+	}
+	if members > 0 {
+		return exception.ErrBadRequest("Department still has members.")
+	}
+
+	positions, err := s.positionRepository.FindAllPosition(c, id)
+	if err != nil {
+		return err
+	}
+	if len(positions) > 0 {
+		return exception.ErrBadRequest("Department still has positions.")
+	}
+
+	return s.departmentRepository.Delete(c, id)
+}
+
+func (s *departmentService) FindAllDepartment(c context.Context, filter web.DepartmentQueryFilter) (result []web.DepartmentResponse, totalData int, err error) {
+	repositoryResponse, err := s.departmentRepository.FindAllDepartment(c, domain.ToDomainDepartmentQueryFilter(filter))
+	if err != nil {
+		return nil, 0, err
+	}
+
+	// get total-data
+	totalData, err = s.departmentRepository.CountAllDepartment(c, domain.ToDomainDepartmentQueryFilter(filter))
+	if err != nil {
+		return nil, 0, err
+	}
+
+	// convert to web.DepartmentResponse
+	result = []web.DepartmentResponse{}
+	for _, department := range repositoryResponse {
+		result = append(result, department.ToDepartmentResponse())
+	}
+
+	return result, totalData, nil
+}
+
+func (s *departmentService) FindById(c context.Context, id string) (web.DepartmentResponse, error) {
+	department, err := s.findDepartment(c, id)
+	if err != nil {
+		return web.DepartmentResponse{}, err
+	}
+
+	return department.ToDepartmentResponse(), nil
+}
+
+func (s *departmentService) FindTree(c context.Context) ([]web.DepartmentTreeResponse, error) {
+	departments, err := s.departmentRepository.FindAllDepartment(c, domain.DepartmentQueryFilter{})
+	if err != nil {
+		return nil, err
+	}
+
+	return domain.BuildDepartmentTree(departments), nil
+}
+
+func (s *departmentService) FindMembers(c context.Context, id string, filter web.DepartmentMemberQueryFilter) ([]web.EmployeeResponse, error) {
+	if _, err := s.findDepartment(c, id); err != nil {
+		return nil, err
+	}
+
+	employeeFilter := domain.EmployeeQueryFilter{DepartmentID: id}
+	if filter.Recursive {
+		departmentIds, err := s.departmentRepository.FindSubDepartmentIds(c, id)
+		if err != nil {
+			return nil, err
+		}
+		employeeFilter = domain.EmployeeQueryFilter{DepartmentIDs: departmentIds}
+	}
+
+	employees, err := s.employeeRepository.FindAllEmployee(c, employeeFilter)
+	if err != nil {
+		return nil, err
+	}
+
+	// convert to web.EmployeeResponse
+	result := []web.EmployeeResponse{}
+	for _, employee := range employees {
+		result = append(result, employee.ToEmployeeResponse())
+	}
+
+	return result, nil
+}
+
+func (s *departmentService) OrgChart(c context.Context) ([]web.OrgChartDepartment, error) {
+	departments, err := s.departmentRepository.FindAllDepartment(c, domain.DepartmentQueryFilter{})
+	if err != nil {
+		return nil, err
+	}
+
+	employees, err := s.employeeRepository.FindAllEmployee(c, domain.EmployeeQueryFilter{Status: domain.EmployeeStatusActive})
+	if err != nil {
+		return nil, err
+	}
+
+	return domain.BuildOrgChart(departments, employees), nil
+}
+
+// render the org chart to pdf with the 'org_chart.html' template
+func (s *departmentService) OrgChartPDF(c context.Context) (bytes.Buffer, error) {
+	orgChart, err := s.OrgChart(c)
+	if err != nil {
+		return bytes.Buffer{}, err
+	}
+
+	data := map[string]interface{}{
+		"Departments": orgChart,
+		"PrintedAt":   helper.ParseTimeToFullIndonesian(helper.Today()),
+	}
+
+	pdf, err := helper.RenderPDF(c, s.pdfRenderer, s.templateFS, "org_chart.html", helper.PDFOptions{Landscape: true}, data)
+	if err != nil {
+		s.logger.Errorw(err.Error(), "Render Org Chart Error")
+		return bytes.Buffer{}, exception.ErrInternalServer(fmt.Sprintf("Failed to render the org chart. Error: %s", err.Error()))
+	}
+
+	return pdf, nil
+}
+
+// find the department by id and convert the 'no rows' error to not found error
+func (s *departmentService) findDepartment(c context.Context, id string) (domain.Department, error) {
+	department, err := s.departmentRepository.FindById(c, id)
+	if err != nil {
+		if strings.Contains(err.Error(), "no rows") {
+			return domain.Department{}, exception.ErrNotFound(fmt.Sprintf("Department %s not found", id))
+		}
+		return domain.Department{}, err
+	}
+
+	return department, nil
+}
+
+// find the ids of the department and its sub-departments, the not found error is returned when the
+// department doesn't exist
+func findDepartmentIds(c context.Context, departmentRepository repository.DepartmentRepository, departmentID string) ([]string, error) {
+	departmentIds, err := departmentRepository.FindSubDepartmentIds(c, departmentID)
+	if err != nil {
+		return nil, err
+	}
+	if len(departmentIds) == 0 {
+		return nil, exception.ErrNotFound(fmt.Sprintf("Department %s not found", departmentID))
+	}
+
+	return departmentIds, nil
+}
+
+// convert the unique constraint error of the 'departments' table to bad request error
+func toDepartmentUniqueError(err error) error {
+	if strings.Contains(err.Error(), "unique") && strings.Contains(err.Error(), "departments_code_key") {
+		return exception.ErrBadRequest("Department code already exist.")
+	}
+	return err
+}
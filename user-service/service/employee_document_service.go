@@ -0,0 +1,443 @@
+package service
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/http"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/iqbaludinm/hr-microservice/user-service/config"
+	"github.com/iqbaludinm/hr-microservice/user-service/exception"
+	"github.com/iqbaludinm/hr-microservice/user-service/helper"
+	"github.com/iqbaludinm/hr-microservice/user-service/model/domain"
+	"github.com/iqbaludinm/hr-microservice/user-service/model/kafkamodel"
+	"github.com/iqbaludinm/hr-microservice/user-service/model/web"
+	"github.com/iqbaludinm/hr-microservice/user-service/repository"
+	"github.com/iqbaludinm/hr-microservice/user-service/service/producers"
+	"go.uber.org/zap"
+)
+
+// Type of the notifications produced by the employee document service.
+const (
+	NotificationEmployeeDocumentExpiring = "EMPLOYEE_DOCUMENT_EXPIRING"
+)
+
+type EmployeeDocumentService interface {
+	// With Transaction
+	Upload(ctx context.Context, userID string, request web.EmployeeDocumentRequest, file web.DocumentFile) (web.EmployeeDocumentResponse, error)
+	UploadMine(ctx context.Context, userID string, request web.MyEmployeeDocumentRequest, file web.DocumentFile) (web.EmployeeDocumentResponse, error)
+	AddVersion(ctx context.Context, userID, id string, request web.EmployeeDocumentVersionRequest, file web.DocumentFile) (web.EmployeeDocumentResponse, error)
+	Update(ctx context.Context, id string, request web.UpdateEmployeeDocumentRequest) (web.EmployeeDocumentResponse, error)
+	Delete(ctx context.Context, id string) error
+	SendExpiryReminders(ctx context.Context) error
+
+	// Without Transaction
+	FindAll(ctx context.Context, filter web.EmployeeDocumentQueryFilter) ([]web.EmployeeDocumentResponse, int, error)
+	FindMine(ctx context.Context, userID string, filter web.EmployeeDocumentQueryFilter) ([]web.EmployeeDocumentResponse, int, error)
+	FindById(ctx context.Context, id string) (web.EmployeeDocumentResponse, error)
+	FindShared(ctx context.Context, userID, id string) (web.EmployeeDocumentResponse, error)
+	Download(ctx context.Context, id string, version int) ([]byte, string, string, error)
+	DownloadShared(ctx context.Context, userID, id string, version int) ([]byte, string, string, error)
+}
+
+type employeeDocumentService struct {
+	employeeDocumentRepository repository.EmployeeDocumentRepository
+	employeeRepository         repository.EmployeeRepository
+	storage                    helper.Storage
+	kafkaProducerService       producers.KafkaProducerService
+	logger                     *zap.SugaredLogger
+}
+
+func NewEmployeeDocumentService(employeeDocumentRepository repository.EmployeeDocumentRepository, employeeRepository repository.EmployeeRepository, storage helper.Storage, kafkaProducerService producers.KafkaProducerService, logger *zap.SugaredLogger) EmployeeDocumentService {
+	return &employeeDocumentService{
+		employeeDocumentRepository: employeeDocumentRepository,
+		employeeRepository:         employeeRepository,
+		storage:                    storage,
+		kafkaProducerService:       kafkaProducerService,
+		logger:                     logger,
+	}
+}
+
+// upload the document of the employee by HR, the access decides who can view the document besides HR
+func (s *employeeDocumentService) Upload(c context.Context, userID string, request web.EmployeeDocumentRequest, file web.DocumentFile) (web.EmployeeDocumentResponse, error) {
+	if _, err := findEmployee(c, s.employeeRepository, request.EmployeeID); err != nil {
+		return web.EmployeeDocumentResponse{}, err
+	}
+
+	// convert to domain or model employee document
+	document := domain.ToDomainEmployeeDocument(request)
+	return s.create(c, userID, document, request.Note, file)
+}
+
+// upload the document of the logged in user, e.g. the KTP or the NPWP. The document can always be viewed by the
+// employee.
+func (s *employeeDocumentService) UploadMine(c context.Context, userID string, request web.MyEmployeeDocumentRequest, file web.DocumentFile) (web.EmployeeDocumentResponse, error) {
+	employee, err := findEmployeeByUser(c, s.employeeRepository, userID)
+	if err != nil {
+		return web.EmployeeDocumentResponse{}, err
+	}
+
+	// convert to domain or model employee document
+	document := domain.ToDomainEmployeeDocument(web.EmployeeDocumentRequest{
+		EmployeeID: employee.ID,
+		Type:       request.Type,
+		Title:      request.Title,
+		Number:     request.Number,
+		ExpiryDate: request.ExpiryDate,
+		Access:     domain.EmployeeDocumentAccessEmployee,
+	})
+	return s.create(c, userID, document, request.Note, file)
+}
+
+// upload a new version of the document, e.g. the renewed contract or certificate. The expiry date of the document
+// is replaced when it is filled, so the reminder is sent again before the new expiry date.
+func (s *employeeDocumentService) AddVersion(c context.Context, userID, id string, request web.EmployeeDocumentVersionRequest, file web.DocumentFile) (web.EmployeeDocumentResponse, error) {
+	document, err := findEmployeeDocument(c, s.employeeDocumentRepository, id)
+	if err != nil {
+		return web.EmployeeDocumentResponse{}, err
+	}
+
+	version, err := newEmployeeDocumentVersion(file)
+	if err != nil {
+		return web.EmployeeDocumentResponse{}, err
+	}
+	if version.Checksum == document.Checksum {
+		return web.EmployeeDocumentResponse{}, exception.ErrBadRequest("The file is the same as the latest version.")
+	}
+
+	if expiryDate := helper.ParseOptionalDate(request.ExpiryDate); expiryDate != nil {
+		document.ExpiryDate = expiryDate
+	}
+	document.LatestVersion++
+	document.UpdatedAt = time.Now()
+
+	version.DocumentID = document.ID
+	version.Version = document.LatestVersion
+	version.StorageKey = document.NewStorageKey(version.Version, version.FileName)
+	version.Note = request.Note
+	version.UploadedBy = &userID
+	version.UploadedAt = time.Now()
+
+	// store the file first, the stored file is removed when the version fails to be saved
+	if err := s.storage.Put(c, version.StorageKey, file.Data); err != nil {
+		s.logger.Infow(err.Error(), "Store Employee Document Error")
+		return web.EmployeeDocumentResponse{}, err
+	}
+
+	if err := s.employeeDocumentRepository.AddVersion(c, document, version); err != nil {
+		s.logger.Infow(err.Error(), "Add Employee Document Version Error")
+		_ = s.storage.Delete(c, version.StorageKey)
+		if strings.Contains(err.Error(), "duplicate key") {
+			return web.EmployeeDocumentResponse{}, exception.ErrBadRequest("The document was updated by another upload, please try again.")
+		}
+		return web.EmployeeDocumentResponse{}, err
+	}
+
+	return s.FindById(c, id)
+}
+
+func (s *employeeDocumentService) Update(c context.Context, id string, request web.UpdateEmployeeDocumentRequest) (web.EmployeeDocumentResponse, error) {
+	document, err := findEmployeeDocument(c, s.employeeDocumentRepository, id)
+	if err != nil {
+		return web.EmployeeDocumentResponse{}, err
+	}
+
+	document.Title = request.Title
+	document.Number = request.Number
+	document.ExpiryDate = helper.ParseOptionalDate(request.ExpiryDate)
+	document.Access = request.Access
+	document.UpdatedAt = time.Now()
+
+	if err := s.employeeDocumentRepository.UpdateDocument(c, document); err != nil {
+		s.logger.Infow(err.Error(), "Update Employee Document Error")
+		return web.EmployeeDocumentResponse{}, err
+	}
+
+	return s.FindById(c, id)
+}
+
+// delete the document, the files of the versions are kept in the storage as the document is soft deleted
+func (s *employeeDocumentService) Delete(c context.Context, id string) error {
+	if _, err := findEmployeeDocument(c, s.employeeDocumentRepository, id); err != nil {
+		return err
+	}
+
+	if err := s.employeeDocumentRepository.Delete(c, id); err != nil {
+		s.logger.Infow(err.Error(), "Delete Employee Document Error")
+		return err
+	}
+
+	return nil
+}
+
+// remind the owner and the uploader of the documents which expire within the reminder days, each expiry date of a
+// document is reminded once. The owner is not reminded of the documents which can only be viewed by HR. It is run
+// by the scheduler.
+func (s *employeeDocumentService) SendExpiryReminders(c context.Context) error {
+	today := helper.Today()
+	documents, err := s.employeeDocumentRepository.FindExpiring(c, today.AddDate(0, 0, config.DocumentExpiryReminderDays))
+	if err != nil {
+		return err
+	}
+
+	for _, document := range documents {
+		expiryDate := document.ExpiryDate.Format(helper.DateLayout)
+		message := fmt.Sprintf("The %s '%s' of %s (%s) expires on %s, please upload the renewed document.", document.Type, document.Title, document.EmployeeName, document.EmployeeNumber, expiryDate)
+		if document.Expired() {
+			message = fmt.Sprintf("The %s '%s' of %s (%s) has expired on %s, please upload the renewed document.", document.Type, document.Title, document.EmployeeName, document.EmployeeNumber, expiryDate)
+		}
+
+		var recipients []string
+		if document.Access != domain.EmployeeDocumentAccessHR {
+			recipients = append(recipients, document.EmployeeUserID)
+		}
+		if document.CreatedBy != nil && *document.CreatedBy != document.EmployeeUserID {
+			recipients = append(recipients, *document.CreatedBy)
+		}
+
+		for _, recipient := range recipients {
+			kafkaNotificationMessage := kafkamodel.NewKafkaNotificationMessage(recipient, NotificationEmployeeDocumentExpiring, "Document Expiring", message, map[string]interface{}{
+				"document_id": document.ID,
+				"employee_id": document.EmployeeID,
+				"expiry_date": expiryDate,
+			})
+			go s.kafkaProducerService.Produce(kafkaNotificationMessage, "POST.NOTIFICATION", config.KafkaTopicNotification)
+		}
+
+		if err := s.employeeDocumentRepository.UpdateReminded(c, document.ID, *document.ExpiryDate); err != nil {
+			s.logger.Errorw("Send Employee Document Expiry Reminder Error", "document_id", document.ID, "error", err.Error())
+			continue
+		}
+	}
+
+	return nil
+}
+
+func (s *employeeDocumentService) FindAll(c context.Context, filter web.EmployeeDocumentQueryFilter) ([]web.EmployeeDocumentResponse, int, error) {
+	return s.findAll(c, domain.ToDomainEmployeeDocumentQueryFilter(filter))
+}
+
+// find the documents of the logged in user, the documents which can only be viewed by HR are hidden
+func (s *employeeDocumentService) FindMine(c context.Context, userID string, filter web.EmployeeDocumentQueryFilter) ([]web.EmployeeDocumentResponse, int, error) {
+	employee, err := findEmployeeByUser(c, s.employeeRepository, userID)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	domainFilter := domain.ToDomainEmployeeDocumentQueryFilter(filter)
+	domainFilter.EmployeeID = employee.ID
+	domainFilter.VisibleToEmployee = true
+	return s.findAll(c, domainFilter)
+}
+
+func (s *employeeDocumentService) FindById(c context.Context, id string) (web.EmployeeDocumentResponse, error) {
+	document, err := findEmployeeDocument(c, s.employeeDocumentRepository, id)
+	if err != nil {
+		return web.EmployeeDocumentResponse{}, err
+	}
+
+	return document.ToEmployeeDocumentResponse(), nil
+}
+
+// find the document which the logged in user can view, i.e. the own document or the document of a subordinate
+// which is shared with the managers
+func (s *employeeDocumentService) FindShared(c context.Context, userID, id string) (web.EmployeeDocumentResponse, error) {
+	document, err := s.findShared(c, userID, id)
+	if err != nil {
+		return web.EmployeeDocumentResponse{}, err
+	}
+
+	return document.ToEmployeeDocumentResponse(), nil
+}
+
+// Download returns the file of the version of the document with its file name and MIME type, the latest version is
+// returned when the version is 0. The checksum of the stored file is verified before it is returned.
+func (s *employeeDocumentService) Download(c context.Context, id string, version int) ([]byte, string, string, error) {
+	document, err := findEmployeeDocument(c, s.employeeDocumentRepository, id)
+	if err != nil {
+		return nil, "", "", err
+	}
+
+	return s.download(c, document, version)
+}
+
+func (s *employeeDocumentService) DownloadShared(c context.Context, userID, id string, version int) ([]byte, string, string, error) {
+	document, err := s.findShared(c, userID, id)
+	if err != nil {
+		return nil, "", "", err
+	}
+
+	return s.download(c, document, version)
+}
+
+// create the document with its first version, the file is stored before the document is saved and removed when the
+// document fails to be saved
+func (s *employeeDocumentService) create(c context.Context, userID string, document domain.EmployeeDocument, note string, file web.DocumentFile) (web.EmployeeDocumentResponse, error) {
+	version, err := newEmployeeDocumentVersion(file)
+	if err != nil {
+		return web.EmployeeDocumentResponse{}, err
+	}
+
+	document.ID = uuid.New().String()
+	document.LatestVersion = 1
+	document.CreatedBy = &userID
+	document.CreatedAt = time.Now()
+	document.UpdatedAt = time.Now()
+
+	version.DocumentID = document.ID
+	version.Version = document.LatestVersion
+	version.StorageKey = document.NewStorageKey(version.Version, version.FileName)
+	version.Note = note
+	version.UploadedBy = &userID
+	version.UploadedAt = time.Now()
+
+	if err := s.storage.Put(c, version.StorageKey, file.Data); err != nil {
+		s.logger.Infow(err.Error(), "Store Employee Document Error")
+		return web.EmployeeDocumentResponse{}, err
+	}
+
+	// call the repo for inserting to db
+	if err := s.employeeDocumentRepository.CreateDocument(c, document, version); err != nil {
+		s.logger.Infow(err.Error(), "Create Employee Document Error")
+		_ = s.storage.Delete(c, version.StorageKey)
+		return web.EmployeeDocumentResponse{}, err
+	}
+
+	// get or returning the document have created to db
+	newDocument, err := s.employeeDocumentRepository.FindById(c, document.ID)
+	if err != nil {
+		return web.EmployeeDocumentResponse{}, exception.ErrInternalServer(fmt.Sprintf("Successfully created employee document, but failed to get the employee document have created. Error: %s", err.Error()))
+	}
+
+	return newDocument.ToEmployeeDocumentResponse(), nil
+}
+
+func (s *employeeDocumentService) findAll(c context.Context, filter domain.EmployeeDocumentQueryFilter) (result []web.EmployeeDocumentResponse, totalData int, err error) {
+	documents, err := s.employeeDocumentRepository.FindAll(c, filter)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	// get total-data
+	totalData, err = s.employeeDocumentRepository.CountAll(c, filter)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	// convert to web.EmployeeDocumentResponse
+	result = []web.EmployeeDocumentResponse{}
+	for _, document := range documents {
+		result = append(result, document.ToEmployeeDocumentResponse())
+	}
+
+	return result, totalData, nil
+}
+
+// the owner can view the document unless it can only be viewed by HR, and the managers in the reporting chain of
+// the owner can view the document shared with the managers. The document is reported as not found otherwise, so its
+// existence is not revealed.
+func (s *employeeDocumentService) findShared(c context.Context, userID, id string) (domain.EmployeeDocument, error) {
+	document, err := findEmployeeDocument(c, s.employeeDocumentRepository, id)
+	if err != nil {
+		return domain.EmployeeDocument{}, err
+	}
+	notFound := exception.ErrNotFound(fmt.Sprintf("Employee document %s not found", id))
+
+	if document.EmployeeUserID == userID {
+		if document.Access == domain.EmployeeDocumentAccessHR {
+			return domain.EmployeeDocument{}, notFound
+		}
+		return document, nil
+	}
+	if document.Access != domain.EmployeeDocumentAccessManager {
+		return domain.EmployeeDocument{}, notFound
+	}
+
+	employee, err := findEmployeeByUser(c, s.employeeRepository, userID)
+	if err != nil {
+		return domain.EmployeeDocument{}, err
+	}
+	chain, err := s.employeeRepository.FindReportingChain(c, document.EmployeeID)
+	if err != nil {
+		return domain.EmployeeDocument{}, err
+	}
+	for _, manager := range chain {
+		if manager.ID == employee.ID {
+			return document, nil
+		}
+	}
+
+	return domain.EmployeeDocument{}, notFound
+}
+
+func (s *employeeDocumentService) download(c context.Context, document domain.EmployeeDocument, version int) ([]byte, string, string, error) {
+	documentVersion, ok := document.FindVersion(version)
+	if !ok {
+		return nil, "", "", exception.ErrNotFound(fmt.Sprintf("Version %d of employee document %s not found", version, document.ID))
+	}
+
+	data, err := s.storage.Get(c, documentVersion.StorageKey)
+	if err != nil {
+		if errors.Is(err, helper.ErrObjectNotFound) {
+			return nil, "", "", exception.ErrNotFound(fmt.Sprintf("The file of version %d of employee document %s not found", documentVersion.Version, document.ID))
+		}
+		return nil, "", "", err
+	}
+
+	if checksum := sha256.Sum256(data); hex.EncodeToString(checksum[:]) != documentVersion.Checksum {
+		s.logger.Errorw("Employee Document Checksum Error", "document_id", document.ID, "version", documentVersion.Version)
+		return nil, "", "", exception.ErrInternalServer("The stored file does not match its checksum.")
+	}
+
+	return data, documentVersion.FileName, documentVersion.MimeType, nil
+}
+
+// validate the size and the MIME type of the uploaded file and compute its checksum. The MIME type is detected from
+// the content of the file, not from its name or the header sent by the client.
+func newEmployeeDocumentVersion(file web.DocumentFile) (domain.EmployeeDocumentVersion, error) {
+	if len(file.Data) == 0 {
+		return domain.EmployeeDocumentVersion{}, exception.ErrBadRequest("The file is empty.")
+	}
+	if len(file.Data) > config.DocumentMaxSizeMB*1024*1024 {
+		return domain.EmployeeDocumentVersion{}, exception.ErrBadRequest(fmt.Sprintf("The file can't be larger than %d MB.", config.DocumentMaxSizeMB))
+	}
+
+	mimeType, _, _ := strings.Cut(http.DetectContentType(file.Data), ";")
+	allowed := false
+	for _, allowedMimeType := range config.DocumentAllowedMimeTypes {
+		if strings.TrimSpace(allowedMimeType) == mimeType {
+			allowed = true
+			break
+		}
+	}
+	if !allowed {
+		return domain.EmployeeDocumentVersion{}, exception.ErrBadRequest(fmt.Sprintf("The file type %s is not allowed.", mimeType))
+	}
+
+	checksum := sha256.Sum256(file.Data)
+	return domain.EmployeeDocumentVersion{
+		FileName: filepath.Base(file.Name),
+		MimeType: mimeType,
+		Size:     int64(len(file.Data)),
+		Checksum: hex.EncodeToString(checksum[:]),
+	}, nil
+}
+
+// find the employee document by id with its versions and convert the 'no rows' error to not found error
+func findEmployeeDocument(c context.Context, employeeDocumentRepository repository.EmployeeDocumentRepository, id string) (domain.EmployeeDocument, error) {
+	document, err := employeeDocumentRepository.FindById(c, id)
+	if err != nil {
+		if strings.Contains(err.Error(), "no rows") {
+			return domain.EmployeeDocument{}, exception.ErrNotFound(fmt.Sprintf("Employee document %s not found", id))
+		}
+		return domain.EmployeeDocument{}, err
+	}
+
+	return document, nil
+}
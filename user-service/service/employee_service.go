@@ -0,0 +1,330 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/iqbaludinm/hr-microservice/user-service/config"
+	"github.com/iqbaludinm/hr-microservice/user-service/exception"
+	"github.com/iqbaludinm/hr-microservice/user-service/helper"
+	"github.com/iqbaludinm/hr-microservice/user-service/model/domain"
+	"github.com/iqbaludinm/hr-microservice/user-service/model/kafkamodel"
+	"github.com/iqbaludinm/hr-microservice/user-service/model/web"
+	"github.com/iqbaludinm/hr-microservice/user-service/repository"
+	"github.com/iqbaludinm/hr-microservice/user-service/service/producers"
+	"go.uber.org/zap"
+)
+
+type EmployeeService interface {
+	// With Transaction
+	CreateEmployee(ctx context.Context, request web.CreateEmployeeRequest) (web.EmployeeResponse, error)
+	UpdateEmployee(ctx context.Context, id string, request web.UpdateEmployeeRequest) (web.EmployeeResponse, error)
+	Delete(ctx context.Context, id string) error
+
+	// Without Transaction
+	FindAllEmployee(ctx context.Context, filter web.EmployeeQueryFilter) (result []web.EmployeeResponse, totalData int, err error)
+	FindById(ctx context.Context, id string) (web.EmployeeResponse, error)
+	ReportingChain(ctx context.Context, id string) ([]web.EmployeeResponse, error)
+}
+
+type employeeService struct {
+	employeeRepository   repository.EmployeeRepository
+	userRepository       repository.UserRepository
+	departmentRepository repository.DepartmentRepository
+	positionRepository   repository.PositionRepository
+	assetRepository      repository.AssetRepository
+	onboardingRepository repository.OnboardingRepository
+	kafkaProducerService producers.KafkaProducerService
+	logger               *zap.SugaredLogger
+}
+
+func NewEmployeeService(employeeRepository repository.EmployeeRepository, userRepository repository.UserRepository, departmentRepository repository.DepartmentRepository, positionRepository repository.PositionRepository, assetRepository repository.AssetRepository, onboardingRepository repository.OnboardingRepository, kafkaProducerService producers.KafkaProducerService, logger *zap.SugaredLogger) EmployeeService {
+	return &employeeService{
+		employeeRepository:   employeeRepository,
+		userRepository:       userRepository,
+		departmentRepository: departmentRepository,
+		positionRepository:   positionRepository,
+		assetRepository:      assetRepository,
+		onboardingRepository: onboardingRepository,
+		kafkaProducerService: kafkaProducerService,
+		logger:               logger,
+	}
+}
+
+func (s *employeeService) CreateEmployee(c context.Context, request web.CreateEmployeeRequest) (web.EmployeeResponse, error) {
+	// validate the user exist, the user is created through the auth-service
+	if _, err := s.userRepository.FindUserNotDeleteByQueryTx(c, "id", request.UserID); err != nil {
+		if strings.Contains(err.Error(), "no rows") {
+			return web.EmployeeResponse{}, exception.ErrNotFound(fmt.Sprintf("User %s not found", request.UserID))
+		}
+		return web.EmployeeResponse{}, err
+	}
+
+	position, err := validateAssignment(c, s.departmentRepository, s.positionRepository, request.DepartmentID, request.PositionID)
+	if err != nil {
+		return web.EmployeeResponse{}, err
+	}
+
+	// the job title follows the position title when it is not filled
+	jobTitle := request.JobTitle
+	if jobTitle == "" && position != nil {
+		jobTitle = position.Title
+	}
+
+	dateOfBirth, _ := helper.ParseDate(request.DateOfBirth)
+	hireDate, _ := helper.ParseDate(request.HireDate)
+
+	status := request.Status
+	if status == "" {
+		status = domain.EmployeeStatusActive
+	}
+
+	managerID := request.ManagerID
+	if managerID != nil && *managerID == "" {
+		managerID = nil
+	}
+
+	// convert to domain or model employee
+	employee := domain.Employee{
+		ID:                uuid.New().String(),
+		UserID:            request.UserID,
+		EmployeeNumber:    request.EmployeeNumber,
+		DateOfBirth:       dateOfBirth,
+		Gender:            request.Gender,
+		NIK:               request.NIK,
+		Address:           request.Address,
+		HireDate:          hireDate,
+		EmploymentType:    request.EmploymentType,
+		Status:            status,
+		JobTitle:          jobTitle,
+		SalaryGrade:       request.SalaryGrade,
+		DepartmentID:      request.DepartmentID,
+		PositionID:        request.PositionID,
+		ManagerID:         managerID,
+		EmergencyContacts: domain.ToDomainEmergencyContacts(request.EmergencyContacts),
+		CreatedAt:         time.Now(),
+		UpdatedAt:         time.Now(),
+	}
+
+	if err := s.validateEmployee(c, employee); err != nil {
+		return web.EmployeeResponse{}, err
+	}
+
+	// call the repo for inserting to db
+	if err := s.employeeRepository.CreateEmployee(c, employee, newHireHistory(employee)); err != nil {
+		s.logger.Infow(err.Error(), "Create Employee Error")
+		return web.EmployeeResponse{}, toEmployeeUniqueError(err)
+	}
+
+	// get or returning the employee have created to db
+	newEmployee, err := s.employeeRepository.FindById(c, employee.ID)
+	if err != nil {
+		return web.EmployeeResponse{}, exception.ErrInternalServer(fmt.Sprintf("Successfully created employee, but failed to get the employee have created. Error: %s", err.Error()))
+	}
+
+	// produce kafka create-employee message
+	kafkaEmployeeMessage := kafkamodel.NewKafkaEmployeeMessage(newEmployee)
+	go s.kafkaProducerService.Produce(kafkaEmployeeMessage, "POST.EMPLOYEE", config.KafkaTopic)
+
+	s.startOnboarding(c, newEmployee)
+
+	return newEmployee.ToEmployeeResponse(), nil
+}
+
+func (s *employeeService) UpdateEmployee(c context.Context, id string, request web.UpdateEmployeeRequest) (web.EmployeeResponse, error) {
+	employee, err := findEmployee(c, s.employeeRepository, id)
+	if err != nil {
+		return web.EmployeeResponse{}, err
+	}
+
+	// only the filled fields are updated
+	if request.EmployeeNumber != "" {
+		employee.EmployeeNumber = request.EmployeeNumber
+	}
+	if request.DateOfBirth != "" {
+		employee.DateOfBirth, _ = helper.ParseDate(request.DateOfBirth)
+	}
+	if request.Gender != "" {
+		employee.Gender = request.Gender
+	}
+	if request.NIK != "" {
+		employee.NIK = request.NIK
+	}
+	if request.Address != "" {
+		employee.Address = request.Address
+	}
+	if request.HireDate != "" {
+		employee.HireDate, _ = helper.ParseDate(request.HireDate)
+	}
+	if request.EmploymentType != "" {
+		employee.EmploymentType = request.EmploymentType
+	}
+	if request.Status != "" {
+		// the employee can only leave the company after returning every asset
+		if leavingStatus(request.Status) && !leavingStatus(employee.Status) {
+			if err := validateAssetsReturned(c, s.assetRepository, employee.ID); err != nil {
+				return web.EmployeeResponse{}, err
+			}
+		}
+		employee.Status = request.Status
+	}
+	if request.EmergencyContacts != nil {
+		employee.EmergencyContacts = domain.ToDomainEmergencyContacts(request.EmergencyContacts)
+	}
+	employee.UpdatedAt = time.Now()
+
+	if err := s.validateEmployee(c, employee); err != nil {
+		return web.EmployeeResponse{}, err
+	}
+
+	if err := s.employeeRepository.UpdateEmployee(c, id, employee); err != nil {
+		s.logger.Infow(err.Error(), "Update Employee Error")
+		return web.EmployeeResponse{}, toEmployeeUniqueError(err)
+	}
+
+	updatedEmployee, err := s.employeeRepository.FindById(c, id)
+	if err != nil {
+		return web.EmployeeResponse{}, exception.ErrInternalServer(fmt.Sprintf("Successfully updated employee, but failed to get the employee have updated. Error: %s", err.Error()))
+	}
+
+	// produce kafka update-employee message
+	kafkaEmployeeMessage := kafkamodel.NewKafkaEmployeeMessage(updatedEmployee)
+	go s.kafkaProducerService.Produce(kafkaEmployeeMessage, "PUT.EMPLOYEE", config.KafkaTopic)
+
+	return updatedEmployee.ToEmployeeResponse(), nil
+}
+
+func (s *employeeService) Delete(c context.Context, id string) error {
+	employee, err := findEmployee(c, s.employeeRepository, id)
+	if err != nil {
+		return err
+	}
+	if err := validateAssetsReturned(c, s.assetRepository, id); err != nil {
+		return err
+	}
+
+	if err := s.employeeRepository.Delete(c, id); err != nil {
+		return err
+	}
+
+	// produce kafka delete-employee message
+	deletedAt := time.Now()
+	employee.DeletedAt = &deletedAt
+	kafkaEmployeeMessage := kafkamodel.NewKafkaEmployeeMessage(employee)
+	go s.kafkaProducerService.Produce(kafkaEmployeeMessage, "DELETE.EMPLOYEE", config.KafkaTopic)
+
+	return nil
+}
+
+func (s *employeeService) FindAllEmployee(c context.Context, filter web.EmployeeQueryFilter) (result []web.EmployeeResponse, totalData int, err error) {
+	repositoryResponse, err := s.employeeRepository.FindAllEmployee(c, domain.ToDomainEmployeeQueryFilter(filter))
+	if err != nil {
+		return nil, 0, err
+	}
+
+	// get total-data
+	totalData, err = s.employeeRepository.CountAllEmployee(c, domain.ToDomainEmployeeQueryFilter(filter))
+	if err != nil {
+		return nil, 0, err
+	}
+
+	// convert to web.EmployeeResponse
+	result = []web.EmployeeResponse{}
+	for _, employee := range repositoryResponse {
+		result = append(result, employee.ToEmployeeResponse())
+	}
+
+	return result, totalData, nil
+}
+
+func (s *employeeService) FindById(c context.Context, id string) (web.EmployeeResponse, error) {
+	employee, err := findEmployee(c, s.employeeRepository, id)
+	if err != nil {
+		return web.EmployeeResponse{}, err
+	}
+
+	// the profile of the employee shows the assets held by the employee
+	response := employee.ToEmployeeResponse()
+	if response.Assets, err = findEmployeeAssets(c, s.assetRepository, id); err != nil {
+		return web.EmployeeResponse{}, err
+	}
+
+	return response, nil
+}
+
+func (s *employeeService) ReportingChain(c context.Context, id string) ([]web.EmployeeResponse, error) {
+	if _, err := findEmployee(c, s.employeeRepository, id); err != nil {
+		return nil, err
+	}
+
+	chain, err := s.employeeRepository.FindReportingChain(c, id)
+	if err != nil {
+		return nil, err
+	}
+
+	// convert to web.EmployeeResponse, the first element is the direct manager
+	result := []web.EmployeeResponse{}
+	for _, manager := range chain {
+		result = append(result, manager.ToEmployeeResponse())
+	}
+
+	return result, nil
+}
+
+// start the onboarding of the new employee from the template of the position or the department. The employee is
+// created even when the onboarding can't be started, the onboarding can be started later through the onboarding
+// endpoints.
+func (s *employeeService) startOnboarding(c context.Context, employee domain.Employee) {
+	startNewEmployeeOnboarding(c, s.onboardingRepository, s.kafkaProducerService, s.logger, employee)
+}
+
+// validate the rules of the employee data that can't be covered by the validator tags
+func (s *employeeService) validateEmployee(c context.Context, employee domain.Employee) error {
+	if !employee.DateOfBirth.Before(employee.HireDate) {
+		return exception.ErrBadRequest("Date of birth must be before the hire date.")
+	}
+
+	if employee.ManagerID != nil {
+		if _, err := validateManager(c, s.employeeRepository, employee.ID, *employee.ManagerID); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// whether the employee with the status has left the company
+func leavingStatus(status string) bool {
+	return status == domain.EmployeeStatusResigned || status == domain.EmployeeStatusTerminated
+}
+
+// the first employment of the new employee, it is effective from the hire date
+func newHireHistory(employee domain.Employee) domain.EmploymentHistory {
+	appliedAt := time.Now()
+	history := domain.NewEmploymentHistory(employee)
+	history.ID = uuid.New().String()
+	history.ChangeType = domain.ChangeTypeHire
+	history.EffectiveFrom = employee.HireDate
+	history.Reason = "hire"
+	history.AppliedAt = &appliedAt
+	history.CreatedAt = time.Now()
+
+	return history
+}
+
+// convert the unique constraint error of the 'employees' table to bad request error
+func toEmployeeUniqueError(err error) error {
+	if strings.Contains(err.Error(), "unique") {
+		if strings.Contains(err.Error(), "employees_user_id_key") {
+			return exception.ErrBadRequest("User already registered as employee.")
+		} else if strings.Contains(err.Error(), "employees_employee_number_key") {
+			return exception.ErrBadRequest("Employee number already exist.")
+		} else if strings.Contains(err.Error(), "employees_nik_key") {
+			return exception.ErrBadRequest("NIK already exist.")
+		}
+	}
+	return err
+}
@@ -0,0 +1,685 @@
+package service
+
+import (
+	"context"
+	"embed"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/iqbaludinm/hr-microservice/user-service/config"
+	"github.com/iqbaludinm/hr-microservice/user-service/exception"
+	"github.com/iqbaludinm/hr-microservice/user-service/helper"
+	"github.com/iqbaludinm/hr-microservice/user-service/model/domain"
+	"github.com/iqbaludinm/hr-microservice/user-service/model/kafkamodel"
+	"github.com/iqbaludinm/hr-microservice/user-service/model/web"
+	"github.com/iqbaludinm/hr-microservice/user-service/repository"
+	"github.com/iqbaludinm/hr-microservice/user-service/service/producers"
+	"go.uber.org/zap"
+)
+
+// Type of the notifications produced by the employment contract service.
+const (
+	NotificationEmploymentContractExpiring = "EMPLOYMENT_CONTRACT_EXPIRING"
+)
+
+type EmploymentContractService interface {
+	// With Transaction
+	Create(ctx context.Context, userID string, request web.EmploymentContractRequest) (web.EmploymentContractResponse, error)
+	Update(ctx context.Context, id string, request web.UpdateEmploymentContractRequest) (web.EmploymentContractResponse, error)
+	Renew(ctx context.Context, userID, id string, request web.RenewEmploymentContractRequest) (web.EmploymentContractResponse, error)
+	Convert(ctx context.Context, userID, id string, request web.ConvertEmploymentContractRequest) (web.EmploymentContractResponse, error)
+	Sign(ctx context.Context, id string, request web.SignEmploymentContractRequest) (web.EmploymentContractResponse, error)
+	Cancel(ctx context.Context, id string) (web.EmploymentContractResponse, error)
+	SendExpiryAlerts(ctx context.Context) error
+	EndExpired(ctx context.Context) error
+
+	// Without Transaction
+	FindAll(ctx context.Context, filter web.EmploymentContractQueryFilter) ([]web.EmploymentContractResponse, int, error)
+	FindById(ctx context.Context, id string) (web.EmploymentContractResponse, error)
+	Document(ctx context.Context, id string) ([]byte, string, error)
+
+	// Approval Entity
+	ApprovalEntity
+}
+
+type employmentContractService struct {
+	employmentContractRepository repository.EmploymentContractRepository
+	employeeRepository           repository.EmployeeRepository
+	employeeSalaryRepository     repository.EmployeeSalaryRepository
+	employeeDocumentRepository   repository.EmployeeDocumentRepository
+	templateFS                   embed.FS
+	pdfRenderer                  helper.PDFRenderer
+	approvalService              ApprovalService
+	kafkaProducerService         producers.KafkaProducerService
+	logger                       *zap.SugaredLogger
+}
+
+func NewEmploymentContractService(employmentContractRepository repository.EmploymentContractRepository, employeeRepository repository.EmployeeRepository, employeeSalaryRepository repository.EmployeeSalaryRepository, employeeDocumentRepository repository.EmployeeDocumentRepository, templateFS embed.FS, pdfRenderer helper.PDFRenderer, approvalService ApprovalService, kafkaProducerService producers.KafkaProducerService, logger *zap.SugaredLogger) EmploymentContractService {
+	return &employmentContractService{
+		employmentContractRepository: employmentContractRepository,
+		employeeRepository:           employeeRepository,
+		employeeSalaryRepository:     employeeSalaryRepository,
+		employeeDocumentRepository:   employeeDocumentRepository,
+		templateFS:                   templateFS,
+		pdfRenderer:                  pdfRenderer,
+		approvalService:              approvalService,
+		kafkaProducerService:         kafkaProducerService,
+		logger:                       logger,
+	}
+}
+
+// create the first contract of the employee, the next contracts are created by renewing or converting it
+func (s *employmentContractService) Create(c context.Context, userID string, request web.EmploymentContractRequest) (web.EmploymentContractResponse, error) {
+	// convert to domain or model employment contract
+	contract := domain.ToDomainEmploymentContract(request)
+	return s.create(c, userID, contract, nil)
+}
+
+// update the terms of the draft contract, the type and the employee can't be changed. The updated terms are
+// approved again.
+func (s *employmentContractService) Update(c context.Context, id string, request web.UpdateEmploymentContractRequest) (web.EmploymentContractResponse, error) {
+	contract, err := findEmploymentContract(c, s.employmentContractRepository, id)
+	if err != nil {
+		return web.EmploymentContractResponse{}, err
+	}
+	if contract.Status != domain.ContractStatusDraft {
+		return web.EmploymentContractResponse{}, exception.ErrBadRequest("Only a draft contract can be updated.")
+	}
+	if err := s.checkApproval(c, contract.ID); err != nil {
+		return web.EmploymentContractResponse{}, err
+	}
+
+	var previous *domain.EmploymentContract
+	if contract.PreviousContractID != nil {
+		previousContract, err := findEmploymentContract(c, s.employmentContractRepository, *contract.PreviousContractID)
+		if err != nil {
+			return web.EmploymentContractResponse{}, err
+		}
+		previous = &previousContract
+	}
+
+	contract.JobTitle = request.JobTitle
+	contract.StartDate, _ = helper.ParseDate(request.StartDate)
+	contract.EndDate = helper.ParseOptionalDate(request.EndDate)
+	contract.ProbationEndDate = helper.ParseOptionalDate(request.ProbationEndDate)
+	contract.SalaryID = request.SalaryID
+	if contract.SalaryID != nil && *contract.SalaryID == "" {
+		contract.SalaryID = nil
+	}
+	contract.Note = request.Note
+	contract.UpdatedAt = time.Now()
+
+	if _, err := s.validate(c, &contract, previous); err != nil {
+		return web.EmploymentContractResponse{}, err
+	}
+
+	if err := s.employmentContractRepository.UpdateContract(c, contract); err != nil {
+		s.logger.Infow(err.Error(), "Update Employment Contract Error")
+		return web.EmploymentContractResponse{}, err
+	}
+	if err := s.requestApproval(c, contract); err != nil {
+		return web.EmploymentContractResponse{}, err
+	}
+
+	return s.FindById(c, id)
+}
+
+// draft the renewal of the active PKWT, it starts the day after the end date of the renewed contract
+func (s *employmentContractService) Renew(c context.Context, userID, id string, request web.RenewEmploymentContractRequest) (web.EmploymentContractResponse, error) {
+	previous, err := s.findRenewable(c, id)
+	if err != nil {
+		return web.EmploymentContractResponse{}, err
+	}
+
+	contract := domain.EmploymentContract{
+		EmployeeID: previous.EmployeeID,
+		Type:       domain.ContractTypePKWT,
+		JobTitle:   previous.JobTitle,
+		StartDate:  previous.EndDate.AddDate(0, 0, 1),
+		EndDate:    helper.ParseOptionalDate(request.EndDate),
+		SalaryID:   previous.SalaryID,
+		Status:     domain.ContractStatusDraft,
+		Note:       request.Note,
+	}
+	if request.JobTitle != "" {
+		contract.JobTitle = request.JobTitle
+	}
+	if request.SalaryID != nil && *request.SalaryID != "" {
+		contract.SalaryID = request.SalaryID
+	}
+
+	return s.create(c, userID, contract, &previous)
+}
+
+// draft the conversion of the active PKWT to PKWTT, the converted employee has no probation
+func (s *employmentContractService) Convert(c context.Context, userID, id string, request web.ConvertEmploymentContractRequest) (web.EmploymentContractResponse, error) {
+	previous, err := s.findRenewable(c, id)
+	if err != nil {
+		return web.EmploymentContractResponse{}, err
+	}
+
+	contract := domain.EmploymentContract{
+		EmployeeID: previous.EmployeeID,
+		Type:       domain.ContractTypePKWTT,
+		JobTitle:   previous.JobTitle,
+		StartDate:  previous.EndDate.AddDate(0, 0, 1),
+		SalaryID:   previous.SalaryID,
+		Status:     domain.ContractStatusDraft,
+		Note:       request.Note,
+	}
+	if startDate := helper.ParseOptionalDate(request.StartDate); startDate != nil {
+		contract.StartDate = *startDate
+	}
+	if request.JobTitle != "" {
+		contract.JobTitle = request.JobTitle
+	}
+	if request.SalaryID != nil && *request.SalaryID != "" {
+		contract.SalaryID = request.SalaryID
+	}
+
+	return s.create(c, userID, contract, &previous)
+}
+
+// activate the draft contract with the signed contract uploaded to the employee documents. The renewed or
+// converted contract is no longer active, and the employment type of the employee follows the contract.
+func (s *employmentContractService) Sign(c context.Context, id string, request web.SignEmploymentContractRequest) (web.EmploymentContractResponse, error) {
+	contract, err := findEmploymentContract(c, s.employmentContractRepository, id)
+	if err != nil {
+		return web.EmploymentContractResponse{}, err
+	}
+	if contract.Status != domain.ContractStatusDraft {
+		return web.EmploymentContractResponse{}, exception.ErrBadRequest("Only a draft contract can be signed.")
+	}
+	if err := s.checkApproval(c, contract.ID); err != nil {
+		return web.EmploymentContractResponse{}, err
+	}
+
+	document, err := s.employeeDocumentRepository.FindById(c, request.DocumentID)
+	if err != nil {
+		if strings.Contains(err.Error(), "no rows") {
+			return web.EmploymentContractResponse{}, exception.ErrNotFound(fmt.Sprintf("Employee document %s not found", request.DocumentID))
+		}
+		return web.EmploymentContractResponse{}, err
+	}
+	if document.EmployeeID != contract.EmployeeID || document.Type != domain.EmployeeDocumentTypeContract {
+		return web.EmploymentContractResponse{}, exception.ErrBadRequest("The signed contract must be a contract document of the employee.")
+	}
+
+	var previous *domain.EmploymentContract
+	if contract.PreviousContractID != nil {
+		previousContract, err := findEmploymentContract(c, s.employmentContractRepository, *contract.PreviousContractID)
+		if err != nil {
+			return web.EmploymentContractResponse{}, err
+		}
+		if previousContract.Status != domain.ContractStatusActive {
+			return web.EmploymentContractResponse{}, exception.ErrBadRequest(fmt.Sprintf("The contract %s is no longer active.", previousContract.ContractNumber))
+		}
+		previousContract.Status = contract.PreviousStatus()
+		previousContract.UpdatedAt = time.Now()
+		previous = &previousContract
+	} else {
+		active, err := s.employmentContractRepository.FindActiveByEmployee(c, contract.EmployeeID)
+		if err != nil && !strings.Contains(err.Error(), "no rows") {
+			return web.EmploymentContractResponse{}, err
+		}
+		if err == nil {
+			return web.EmploymentContractResponse{}, exception.ErrBadRequest(fmt.Sprintf("The employee already has the active contract %s, renew or convert it instead.", active.ContractNumber))
+		}
+	}
+
+	signedAt := time.Now()
+	contract.Status = domain.ContractStatusActive
+	contract.SignedDocumentID = &document.ID
+	contract.SignedAt = &signedAt
+	contract.UpdatedAt = time.Now()
+
+	if err := s.employmentContractRepository.Sign(c, contract, previous); err != nil {
+		s.logger.Infow(err.Error(), "Sign Employment Contract Error")
+		return web.EmploymentContractResponse{}, err
+	}
+
+	if err := s.updateEmploymentType(c, contract); err != nil {
+		s.logger.Errorw("Update Employment Type Error", "contract_id", contract.ID, "error", err.Error())
+	}
+
+	return s.FindById(c, id)
+}
+
+func (s *employmentContractService) Cancel(c context.Context, id string) (web.EmploymentContractResponse, error) {
+	contract, err := findEmploymentContract(c, s.employmentContractRepository, id)
+	if err != nil {
+		return web.EmploymentContractResponse{}, err
+	}
+	if contract.Status != domain.ContractStatusDraft {
+		return web.EmploymentContractResponse{}, exception.ErrBadRequest("Only a draft contract can be cancelled.")
+	}
+
+	contract.Status = domain.ContractStatusCancelled
+	contract.UpdatedAt = time.Now()
+	if err := s.employmentContractRepository.UpdateStatus(c, contract); err != nil {
+		s.logger.Infow(err.Error(), "Cancel Employment Contract Error")
+		return web.EmploymentContractResponse{}, err
+	}
+	if err := s.approvalService.CancelEntity(c, domain.ApprovalEntityEmploymentContract, contract.ID); err != nil {
+		s.logger.Errorw("Cancel Employment Contract Approval Error", "contract_id", contract.ID, "error", err.Error())
+	}
+
+	return s.FindById(c, id)
+}
+
+// FindApprovalSubject finds the employee who drafted the contract and the base salary of the contract
+func (s *employmentContractService) FindApprovalSubject(c context.Context, id string) (domain.ApprovalSubject, error) {
+	contract, err := findEmploymentContract(c, s.employmentContractRepository, id)
+	if err != nil {
+		return domain.ApprovalSubject{}, err
+	}
+	if contract.Status != domain.ContractStatusDraft {
+		return domain.ApprovalSubject{}, exception.ErrBadRequest(fmt.Sprintf("The contract is already %s.", contract.Status))
+	}
+	if contract.CreatedBy == nil {
+		return domain.ApprovalSubject{}, exception.ErrBadRequest("The contract has no creator to request its approval.")
+	}
+	creator, err := findEmployeeByUser(c, s.employeeRepository, *contract.CreatedBy)
+	if err != nil {
+		return domain.ApprovalSubject{}, err
+	}
+
+	return domain.ApprovalSubject{
+		RequesterID: creator.ID,
+		Title:       fmt.Sprintf("%s %s of %s", contract.Type, contract.ContractNumber, contract.EmployeeName),
+		Amount:      contract.BaseSalary,
+	}, nil
+}
+
+// ApplyApproval lets the approved draft contract be signed, the draft is cancelled when the approval is rejected or
+// cancelled
+func (s *employmentContractService) ApplyApproval(c context.Context, approval domain.ApprovalRequest) error {
+	if approval.Status == domain.ApprovalStatusApproved {
+		return nil
+	}
+
+	contract, err := findEmploymentContract(c, s.employmentContractRepository, approval.EntityID)
+	if err != nil {
+		return err
+	}
+	if contract.Status != domain.ContractStatusDraft {
+		return nil
+	}
+
+	contract.Status = domain.ContractStatusCancelled
+	contract.UpdatedAt = time.Now()
+	return s.employmentContractRepository.UpdateStatus(c, contract)
+}
+
+// alert the creator of the contract and the manager of the employee when the active PKWT which is not renewed or
+// converted yet is about to end. Each of the alert days is sent once, e.g. 30, 14 and 7 days before the end date.
+// It is run by the scheduler.
+func (s *employmentContractService) SendExpiryAlerts(c context.Context) error {
+	maxDays := 0
+	for _, days := range config.ContractExpiryAlertDays {
+		if days > maxDays {
+			maxDays = days
+		}
+	}
+	if maxDays == 0 {
+		return nil
+	}
+
+	today := helper.Today()
+	contracts, err := s.employmentContractRepository.FindExpiring(c, today, today.AddDate(0, 0, maxDays))
+	if err != nil {
+		return err
+	}
+
+	for _, contract := range contracts {
+		alertDays, ok := contract.AlertDays(today, config.ContractExpiryAlertDays)
+		if !ok {
+			continue
+		}
+
+		daysLeft, _ := contract.DaysLeft(today)
+		endDate := contract.EndDate.Format(helper.DateLayout)
+		message := fmt.Sprintf("The contract %s of %s (%s) ends on %s in %d days, please renew or convert it.", contract.ContractNumber, contract.EmployeeName, contract.EmployeeNumber, endDate, daysLeft)
+
+		var recipients []string
+		if contract.CreatedBy != nil {
+			recipients = append(recipients, *contract.CreatedBy)
+		}
+		if contract.ManagerUserID != nil && (contract.CreatedBy == nil || *contract.ManagerUserID != *contract.CreatedBy) {
+			recipients = append(recipients, *contract.ManagerUserID)
+		}
+
+		for _, recipient := range recipients {
+			kafkaNotificationMessage := kafkamodel.NewKafkaNotificationMessage(recipient, NotificationEmploymentContractExpiring, "Contract Expiring", message, map[string]interface{}{
+				"contract_id": contract.ID,
+				"employee_id": contract.EmployeeID,
+				"end_date":    endDate,
+				"days_left":   daysLeft,
+			})
+			go s.kafkaProducerService.Produce(kafkaNotificationMessage, "POST.NOTIFICATION", config.KafkaTopicNotification)
+		}
+
+		if err := s.employmentContractRepository.UpdateAlerted(c, contract.ID, alertDays); err != nil {
+			s.logger.Errorw("Send Employment Contract Expiry Alert Error", "contract_id", contract.ID, "error", err.Error())
+			continue
+		}
+	}
+
+	return nil
+}
+
+// end the active contracts which end date has passed. It is run by the scheduler.
+func (s *employmentContractService) EndExpired(c context.Context) error {
+	ended, err := s.employmentContractRepository.EndExpired(c, helper.Today())
+	if err != nil {
+		return err
+	}
+	if ended > 0 {
+		s.logger.Infow("Employment contracts ended", "count", ended)
+	}
+
+	return nil
+}
+
+func (s *employmentContractService) FindAll(c context.Context, filter web.EmploymentContractQueryFilter) (result []web.EmploymentContractResponse, totalData int, err error) {
+	contracts, err := s.employmentContractRepository.FindAll(c, domain.ToDomainEmploymentContractQueryFilter(filter))
+	if err != nil {
+		return nil, 0, err
+	}
+
+	// get total-data
+	totalData, err = s.employmentContractRepository.CountAll(c, domain.ToDomainEmploymentContractQueryFilter(filter))
+	if err != nil {
+		return nil, 0, err
+	}
+
+	// convert to web.EmploymentContractResponse
+	result = []web.EmploymentContractResponse{}
+	for _, contract := range contracts {
+		result = append(result, contract.ToEmploymentContractResponse())
+	}
+
+	return result, totalData, nil
+}
+
+func (s *employmentContractService) FindById(c context.Context, id string) (web.EmploymentContractResponse, error) {
+	contract, err := findEmploymentContract(c, s.employmentContractRepository, id)
+	if err != nil {
+		return web.EmploymentContractResponse{}, err
+	}
+
+	return contract.ToEmploymentContractResponse(), nil
+}
+
+// Document returns the contract rendered from the 'contract.html' template with its file name, it is printed to be
+// signed by the employee.
+func (s *employmentContractService) Document(c context.Context, id string) ([]byte, string, error) {
+	contract, err := findEmploymentContract(c, s.employmentContractRepository, id)
+	if err != nil {
+		return nil, "", err
+	}
+	if contract.Status == domain.ContractStatusCancelled {
+		return nil, "", exception.ErrBadRequest("The document of a cancelled contract is not available.")
+	}
+
+	employee, err := findEmployee(c, s.employeeRepository, contract.EmployeeID)
+	if err != nil {
+		return nil, "", err
+	}
+
+	contractType := "PERJANJIAN KERJA WAKTU TERTENTU (PKWT)"
+	reason := "PERPANJANGAN"
+	if contract.Type == domain.ContractTypePKWTT {
+		contractType = "PERJANJIAN KERJA WAKTU TIDAK TERTENTU (PKWTT)"
+		reason = "PENGANGKATAN KARYAWAN TETAP"
+	}
+	data := map[string]interface{}{
+		"Contract":  contract,
+		"Employee":  employee,
+		"Type":      contractType,
+		"Reason":    reason,
+		"StartDate": helper.ParseTimeToFullIndonesian(contract.StartDate),
+		"PrintedAt": helper.ParseTimeToFullIndonesian(helper.Today()),
+	}
+	if contract.EndDate != nil {
+		data["EndDate"] = helper.ParseTimeToFullIndonesian(*contract.EndDate)
+	}
+	if contract.ProbationEndDate != nil {
+		data["ProbationEndDate"] = helper.ParseTimeToFullIndonesian(*contract.ProbationEndDate)
+	}
+
+	pdf, err := helper.RenderPDF(c, s.pdfRenderer, s.templateFS, "contract.html", helper.PDFOptions{PaperSize: "A4"}, data)
+	if err != nil {
+		s.logger.Infow(err.Error(), "Generate Employment Contract Document Error")
+		return nil, "", err
+	}
+
+	return pdf.Bytes(), strings.ReplaceAll(contract.ContractNumber, "/", "-") + ".pdf", nil
+}
+
+// validate and create the draft contract, the previous contract is only filled when the contract renews or
+// converts it
+func (s *employmentContractService) create(c context.Context, userID string, contract domain.EmploymentContract, previous *domain.EmploymentContract) (web.EmploymentContractResponse, error) {
+	employee, err := s.validate(c, &contract, previous)
+	if err != nil {
+		return web.EmploymentContractResponse{}, err
+	}
+	if leavingStatus(employee.Status) {
+		return web.EmploymentContractResponse{}, exception.ErrBadRequest("The employee has already left.")
+	}
+
+	contract.ID = uuid.New().String()
+	if previous != nil {
+		contract.PreviousContractID = &previous.ID
+	}
+	contract.CreatedBy = &userID
+	contract.CreatedAt = time.Now()
+	contract.UpdatedAt = time.Now()
+
+	// call the repo for inserting to db, the contract number is set by the repo
+	if err := s.employmentContractRepository.CreateContract(c, &contract); err != nil {
+		s.logger.Infow(err.Error(), "Create Employment Contract Error")
+		if strings.Contains(err.Error(), "employment_contracts_previous_contract_id_idx") {
+			return web.EmploymentContractResponse{}, exception.ErrBadRequest("The contract is already renewed or converted.")
+		}
+		return web.EmploymentContractResponse{}, err
+	}
+	if err := s.requestApproval(c, contract); err != nil {
+		return web.EmploymentContractResponse{}, err
+	}
+
+	newContract, err := s.employmentContractRepository.FindById(c, contract.ID)
+	if err != nil {
+		return web.EmploymentContractResponse{}, exception.ErrInternalServer(fmt.Sprintf("Successfully created employment contract, but failed to get the employment contract have created. Error: %s", err.Error()))
+	}
+
+	return newContract.ToEmploymentContractResponse(), nil
+}
+
+// request the approval of the draft contract through the active approval workflow of employment contract, the draft
+// is cancelled when its approval can't be requested so it can't be signed without the approval
+func (s *employmentContractService) requestApproval(c context.Context, contract domain.EmploymentContract) error {
+	if _, err := s.approvalService.Request(c, domain.ApprovalEntityEmploymentContract, contract.ID); err != nil {
+		s.logger.Infow(err.Error(), "Request Employment Contract Approval Error")
+		contract.Status = domain.ContractStatusCancelled
+		contract.UpdatedAt = time.Now()
+		if err := s.employmentContractRepository.UpdateStatus(c, contract); err != nil {
+			s.logger.Errorw("Withdraw Employment Contract Error", "contract_id", contract.ID, "error", err.Error())
+		}
+		return err
+	}
+
+	return nil
+}
+
+// the draft contract with a pending approval can't be changed or signed until it is approved
+func (s *employmentContractService) checkApproval(c context.Context, id string) error {
+	pending, err := s.approvalService.IsPending(c, domain.ApprovalEntityEmploymentContract, id)
+	if err != nil {
+		return err
+	}
+	if pending {
+		return exception.ErrBadRequest("The contract is waiting for its approval.")
+	}
+
+	return nil
+}
+
+// validate the terms of the contract against PP 35/2021: the PKWT must end and, with the PKWTs it renews, can't be
+// longer than 5 years, and only the PKWTT can have a probation of at most 3 months. The job title of the contract is
+// the job title of the employee when it is not filled.
+func (s *employmentContractService) validate(c context.Context, contract *domain.EmploymentContract, previous *domain.EmploymentContract) (domain.Employee, error) {
+	employee, err := findEmployee(c, s.employeeRepository, contract.EmployeeID)
+	if err != nil {
+		return domain.Employee{}, err
+	}
+	if contract.JobTitle == "" {
+		contract.JobTitle = employee.JobTitle
+	}
+
+	switch contract.Type {
+	case domain.ContractTypePKWT:
+		if contract.EndDate == nil {
+			return domain.Employee{}, exception.ErrBadRequest("The end date of a PKWT is required.")
+		}
+		if !contract.EndDate.After(contract.StartDate) {
+			return domain.Employee{}, exception.ErrBadRequest("The end date must be after the start date.")
+		}
+		if contract.ProbationEndDate != nil {
+			return domain.Employee{}, exception.ErrBadRequest("A PKWT can't have a probation.")
+		}
+
+		// the PKWT and the PKWTs it renews can't be longer than 5 years
+		chainStart, err := s.chainStart(c, contract.StartDate, previous)
+		if err != nil {
+			return domain.Employee{}, err
+		}
+		if contract.EndDate.After(chainStart.AddDate(domain.MaxPKWTYears, 0, -1)) {
+			return domain.Employee{}, exception.ErrBadRequest(fmt.Sprintf("A PKWT with its renewals can't be longer than %d years since %s.", domain.MaxPKWTYears, chainStart.Format(helper.DateLayout)))
+		}
+	case domain.ContractTypePKWTT:
+		if contract.EndDate != nil {
+			return domain.Employee{}, exception.ErrBadRequest("A PKWTT can't have an end date.")
+		}
+		if contract.ProbationEndDate != nil {
+			if previous != nil {
+				return domain.Employee{}, exception.ErrBadRequest("A PKWTT converted from a PKWT can't have a probation.")
+			}
+			if !contract.ProbationEndDate.After(contract.StartDate) || contract.ProbationEndDate.After(contract.StartDate.AddDate(0, domain.MaxProbationMonths, 0)) {
+				return domain.Employee{}, exception.ErrBadRequest(fmt.Sprintf("The probation must end within %d months after the start date.", domain.MaxProbationMonths))
+			}
+		}
+	}
+
+	// the renewal or conversion must continue the previous contract
+	if previous != nil {
+		if !contract.StartDate.After(previous.StartDate) || (previous.EndDate != nil && contract.StartDate.After(previous.EndDate.AddDate(0, 0, 1))) {
+			return domain.Employee{}, exception.ErrBadRequest(fmt.Sprintf("The start date must be within the contract %s or the day after it ends.", previous.ContractNumber))
+		}
+	}
+
+	if contract.SalaryID != nil {
+		if err := s.validateSalary(c, contract.EmployeeID, *contract.SalaryID); err != nil {
+			return domain.Employee{}, err
+		}
+	}
+
+	return employee, nil
+}
+
+// find the start date of the PKWTs renewed up to the contract
+func (s *employmentContractService) chainStart(c context.Context, startDate time.Time, previous *domain.EmploymentContract) (time.Time, error) {
+	for previous != nil && previous.Type == domain.ContractTypePKWT {
+		startDate = previous.StartDate
+		if previous.PreviousContractID == nil {
+			break
+		}
+
+		contract, err := findEmploymentContract(c, s.employmentContractRepository, *previous.PreviousContractID)
+		if err != nil {
+			return time.Time{}, err
+		}
+		previous = &contract
+	}
+
+	return startDate, nil
+}
+
+// validate the salary is one of the salaries of the employee
+func (s *employmentContractService) validateSalary(c context.Context, employeeID, salaryID string) error {
+	salaries, err := s.employeeSalaryRepository.FindAllSalary(c, employeeID)
+	if err != nil {
+		return err
+	}
+	for _, salary := range salaries {
+		if salary.ID == salaryID {
+			return nil
+		}
+	}
+
+	return exception.ErrNotFound(fmt.Sprintf("Salary %s of the employee not found", salaryID))
+}
+
+// find the active PKWT which is not renewed or converted yet
+func (s *employmentContractService) findRenewable(c context.Context, id string) (domain.EmploymentContract, error) {
+	contract, err := findEmploymentContract(c, s.employmentContractRepository, id)
+	if err != nil {
+		return domain.EmploymentContract{}, err
+	}
+	if contract.Type != domain.ContractTypePKWT || contract.Status != domain.ContractStatusActive {
+		return domain.EmploymentContract{}, exception.ErrBadRequest("Only an active PKWT can be renewed or converted.")
+	}
+
+	successor, err := s.employmentContractRepository.FindSuccessor(c, contract.ID)
+	if err != nil && !strings.Contains(err.Error(), "no rows") {
+		return domain.EmploymentContract{}, err
+	}
+	if err == nil {
+		return domain.EmploymentContract{}, exception.ErrBadRequest(fmt.Sprintf("The contract is already renewed or converted by %s.", successor.ContractNumber))
+	}
+
+	return contract, nil
+}
+
+// set the employment type of the employee from the signed contract
+func (s *employmentContractService) updateEmploymentType(c context.Context, contract domain.EmploymentContract) error {
+	employee, err := findEmployee(c, s.employeeRepository, contract.EmployeeID)
+	if err != nil {
+		return err
+	}
+	if employee.EmploymentType == contract.EmploymentType() {
+		return nil
+	}
+
+	employee.EmploymentType = contract.EmploymentType()
+	employee.UpdatedAt = time.Now()
+	if err := s.employeeRepository.UpdateEmployee(c, employee.ID, employee); err != nil {
+		return err
+	}
+
+	updatedEmployee, err := findEmployee(c, s.employeeRepository, employee.ID)
+	if err != nil {
+		return err
+	}
+
+	// produce kafka update-employee message
+	kafkaEmployeeMessage := kafkamodel.NewKafkaEmployeeMessage(updatedEmployee)
+	go s.kafkaProducerService.Produce(kafkaEmployeeMessage, "PUT.EMPLOYEE", config.KafkaTopic)
+
+	return nil
+}
+
+// find the employment contract and convert the 'no rows' error to not found error
+func findEmploymentContract(c context.Context, employmentContractRepository repository.EmploymentContractRepository, id string) (domain.EmploymentContract, error) {
+	contract, err := employmentContractRepository.FindById(c, id)
+	if err != nil {
+		if strings.Contains(err.Error(), "no rows") {
+			return domain.EmploymentContract{}, exception.ErrNotFound(fmt.Sprintf("Employment contract %s not found", id))
+		}
+		return domain.EmploymentContract{}, err
+	}
+
+	return contract, nil
+}
@@ -0,0 +1,356 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/iqbaludinm/hr-microservice/user-service/config"
+	"github.com/iqbaludinm/hr-microservice/user-service/exception"
+	"github.com/iqbaludinm/hr-microservice/user-service/helper"
+	"github.com/iqbaludinm/hr-microservice/user-service/model/domain"
+	"github.com/iqbaludinm/hr-microservice/user-service/model/kafkamodel"
+	"github.com/iqbaludinm/hr-microservice/user-service/model/web"
+	"github.com/iqbaludinm/hr-microservice/user-service/repository"
+	"github.com/iqbaludinm/hr-microservice/user-service/service/producers"
+	"go.uber.org/zap"
+)
+
+type EmploymentHistoryService interface {
+	// With Transaction
+	CreateChange(ctx context.Context, employeeID string, request web.CreateEmploymentChangeRequest) (web.EmploymentHistoryResponse, error)
+	CancelChange(ctx context.Context, employeeID, historyID string) error
+
+	// Without Transaction
+	FindAllHistory(ctx context.Context, employeeID string) ([]web.EmploymentHistoryResponse, error)
+	FindAsOf(ctx context.Context, employeeID string, filter web.EmployeeAsOfQueryFilter) (web.EmployeeAsOfResponse, error)
+
+	// Scheduler
+	ApplyDueChanges(ctx context.Context) error
+}
+
+type employmentHistoryService struct {
+	employmentHistoryRepository repository.EmploymentHistoryRepository
+	employeeRepository          repository.EmployeeRepository
+	departmentRepository        repository.DepartmentRepository
+	positionRepository          repository.PositionRepository
+	kafkaProducerService        producers.KafkaProducerService
+	logger                      *zap.SugaredLogger
+}
+
+func NewEmploymentHistoryService(employmentHistoryRepository repository.EmploymentHistoryRepository, employeeRepository repository.EmployeeRepository, departmentRepository repository.DepartmentRepository, positionRepository repository.PositionRepository, kafkaProducerService producers.KafkaProducerService, logger *zap.SugaredLogger) EmploymentHistoryService {
+	return &employmentHistoryService{
+		employmentHistoryRepository: employmentHistoryRepository,
+		employeeRepository:          employeeRepository,
+		departmentRepository:        departmentRepository,
+		positionRepository:          positionRepository,
+		kafkaProducerService:        kafkaProducerService,
+		logger:                      logger,
+	}
+}
+
+func (s *employmentHistoryService) CreateChange(c context.Context, employeeID string, request web.CreateEmploymentChangeRequest) (web.EmploymentHistoryResponse, error) {
+	employee, err := findEmployee(c, s.employeeRepository, employeeID)
+	if err != nil {
+		return web.EmploymentHistoryResponse{}, err
+	}
+
+	histories, err := s.employmentHistoryRepository.FindByEmployeeId(c, employeeID)
+	if err != nil {
+		return web.EmploymentHistoryResponse{}, err
+	}
+
+	// the change is started from the current employment, only the filled fields are changed
+	current := domain.NewEmploymentHistory(employee)
+	current.EffectiveFrom = employee.HireDate
+	for _, history := range histories {
+		if history.IsPending() {
+			return web.EmploymentHistoryResponse{}, exception.ErrBadRequest("Employee already has a pending employment change, cancel it before creating a new one.")
+		}
+		if history.EffectiveTo == nil {
+			current = history
+		}
+	}
+
+	effectiveDate, _ := helper.ParseDate(request.EffectiveDate)
+	if !effectiveDate.After(current.EffectiveFrom) {
+		return web.EmploymentHistoryResponse{}, exception.ErrBadRequest(fmt.Sprintf("Effective date must be after %s.", current.EffectiveFrom.Format(helper.DateLayout)))
+	}
+
+	change := current
+	change.ID = uuid.New().String()
+	change.ChangeType = request.ChangeType
+	change.EffectiveFrom = effectiveDate
+	change.EffectiveTo = nil
+	change.Reason = request.Reason
+	change.AppliedAt = nil
+	change.CreatedAt = time.Now()
+
+	if request.DepartmentID != "" && request.DepartmentID != change.DepartmentID {
+		// the position belongs to the old department, it must be filled again
+		change.DepartmentID = request.DepartmentID
+		change.PositionID = nil
+	}
+	if request.PositionID != nil {
+		// an empty position id is used to remove the position of the employee
+		change.PositionID = request.PositionID
+		if *request.PositionID == "" {
+			change.PositionID = nil
+		}
+	}
+	position, err := validateAssignment(c, s.departmentRepository, s.positionRepository, change.DepartmentID, change.PositionID)
+	if err != nil {
+		return web.EmploymentHistoryResponse{}, err
+	}
+	department, _ := s.departmentRepository.FindById(c, change.DepartmentID)
+	change.DepartmentName = department.Name
+	change.PositionTitle = ""
+	if position != nil {
+		change.PositionTitle = position.Title
+	}
+
+	// the job title follows the new position title when it is not filled
+	if request.JobTitle != "" {
+		change.JobTitle = request.JobTitle
+	} else if position != nil && !equalStringPtr(change.PositionID, current.PositionID) {
+		change.JobTitle = position.Title
+	}
+	if request.SalaryGrade != "" {
+		change.SalaryGrade = request.SalaryGrade
+	}
+	if request.ManagerID != nil {
+		// an empty manager id is used to remove the manager of the employee
+		change.ManagerID = request.ManagerID
+		change.ManagerName = ""
+		if *request.ManagerID == "" {
+			change.ManagerID = nil
+		} else {
+			manager, err := validateManager(c, s.employeeRepository, employeeID, *request.ManagerID)
+			if err != nil {
+				return web.EmploymentHistoryResponse{}, err
+			}
+			change.ManagerName = manager.Name
+		}
+	}
+
+	if change.DepartmentID == current.DepartmentID &&
+		equalStringPtr(change.PositionID, current.PositionID) &&
+		change.JobTitle == current.JobTitle &&
+		change.SalaryGrade == current.SalaryGrade &&
+		equalStringPtr(change.ManagerID, current.ManagerID) {
+		return web.EmploymentHistoryResponse{}, exception.ErrBadRequest("Employment change doesn't change anything.")
+	}
+
+	// a future-dated change is applied by the scheduler on the effective date
+	if effectiveDate.After(helper.Today()) {
+		if err := s.employmentHistoryRepository.CreateHistory(c, change); err != nil {
+			return web.EmploymentHistoryResponse{}, err
+		}
+		return change.ToEmploymentHistoryResponse(), nil
+	}
+
+	if change, err = s.applyChange(c, change, employee); err != nil {
+		return web.EmploymentHistoryResponse{}, err
+	}
+
+	return change.ToEmploymentHistoryResponse(), nil
+}
+
+func (s *employmentHistoryService) CancelChange(c context.Context, employeeID, historyID string) error {
+	history, err := s.employmentHistoryRepository.FindById(c, historyID)
+	if err != nil || history.EmployeeID != employeeID {
+		if err == nil || strings.Contains(err.Error(), "no rows") {
+			return exception.ErrNotFound(fmt.Sprintf("Employment history %s not found", historyID))
+		}
+		return err
+	}
+
+	if !history.IsPending() {
+		return exception.ErrBadRequest("Employment change has been applied and can't be canceled.")
+	}
+
+	return s.employmentHistoryRepository.Delete(c, historyID)
+}
+
+func (s *employmentHistoryService) FindAllHistory(c context.Context, employeeID string) ([]web.EmploymentHistoryResponse, error) {
+	if _, err := findEmployee(c, s.employeeRepository, employeeID); err != nil {
+		return nil, err
+	}
+
+	histories, err := s.employmentHistoryRepository.FindByEmployeeId(c, employeeID)
+	if err != nil {
+		return nil, err
+	}
+
+	// convert to web.EmploymentHistoryResponse
+	result := []web.EmploymentHistoryResponse{}
+	for _, history := range histories {
+		result = append(result, history.ToEmploymentHistoryResponse())
+	}
+
+	return result, nil
+}
+
+func (s *employmentHistoryService) FindAsOf(c context.Context, employeeID string, filter web.EmployeeAsOfQueryFilter) (web.EmployeeAsOfResponse, error) {
+	employee, err := findEmployee(c, s.employeeRepository, employeeID)
+	if err != nil {
+		return web.EmployeeAsOfResponse{}, err
+	}
+
+	date := helper.Today()
+	if filter.Date != "" {
+		date, _ = helper.ParseDate(filter.Date)
+	}
+
+	history, err := s.employmentHistoryRepository.FindAsOf(c, employeeID, date)
+	if err != nil {
+		if strings.Contains(err.Error(), "no rows") {
+			return web.EmployeeAsOfResponse{}, exception.ErrNotFound(fmt.Sprintf("Employee %s has no employment on %s", employeeID, date.Format(helper.DateLayout)))
+		}
+		return web.EmployeeAsOfResponse{}, err
+	}
+
+	return web.EmployeeAsOfResponse{
+		EmployeeID:     employee.ID,
+		EmployeeNumber: employee.EmployeeNumber,
+		Name:           employee.Name,
+		AsOf:           date.Format(helper.DateLayout),
+		Employment:     history.ToEmploymentHistoryResponse(),
+	}, nil
+}
+
+// apply the pending changes that are effective today or before,
+// a failed change is logged and retried on the next run.
+func (s *employmentHistoryService) ApplyDueChanges(c context.Context) error {
+	histories, err := s.employmentHistoryRepository.FindDue(c, helper.Today())
+	if err != nil {
+		return err
+	}
+
+	for _, history := range histories {
+		employee, err := s.employeeRepository.FindById(c, history.EmployeeID)
+		if err != nil {
+			s.logger.Errorw("Apply Employment Change Error", "employment_history_id", history.ID, "error", err.Error())
+			continue
+		}
+		if _, err := s.applyChange(c, history, employee); err != nil {
+			s.logger.Errorw("Apply Employment Change Error", "employment_history_id", history.ID, "error", err.Error())
+			continue
+		}
+		s.logger.Infow("Employment change applied", "employment_history_id", history.ID, "employee_id", history.EmployeeID)
+	}
+
+	return nil
+}
+
+// apply the change to the employee and produce the updated employee and the applied change to kafka
+func (s *employmentHistoryService) applyChange(c context.Context, history domain.EmploymentHistory, employee domain.Employee) (domain.EmploymentHistory, error) {
+	appliedAt := time.Now()
+	history.AppliedAt = &appliedAt
+
+	history.ApplyTo(&employee)
+	employee.UpdatedAt = time.Now()
+
+	if err := s.employmentHistoryRepository.ApplyHistory(c, history, employee); err != nil {
+		return domain.EmploymentHistory{}, err
+	}
+
+	updatedEmployee, err := s.employeeRepository.FindById(c, employee.ID)
+	if err != nil {
+		return domain.EmploymentHistory{}, exception.ErrInternalServer(fmt.Sprintf("Successfully applied employment change, but failed to get the employee have updated. Error: %s", err.Error()))
+	}
+
+	// produce kafka update-employee and employment-history message
+	kafkaEmployeeMessage := kafkamodel.NewKafkaEmployeeMessage(updatedEmployee)
+	go s.kafkaProducerService.Produce(kafkaEmployeeMessage, "PUT.EMPLOYEE", config.KafkaTopic)
+	kafkaEmploymentHistoryMessage := kafkamodel.NewKafkaEmploymentHistoryMessage(history, updatedEmployee)
+	go s.kafkaProducerService.Produce(kafkaEmploymentHistoryMessage, "POST.EMPLOYMENT_HISTORY", config.KafkaTopic)
+
+	return history, nil
+}
+
+// find the employee by id and convert the 'no rows' error to not found error
+func findEmployee(c context.Context, employeeRepository repository.EmployeeRepository, id string) (domain.Employee, error) {
+	employee, err := employeeRepository.FindById(c, id)
+	if err != nil {
+		if strings.Contains(err.Error(), "no rows") {
+			return domain.Employee{}, exception.ErrNotFound(fmt.Sprintf("Employee %s not found", id))
+		}
+		return domain.Employee{}, err
+	}
+
+	return employee, nil
+}
+
+// find the employee of the user (e.g. the logged in user) and convert the 'no rows' error to not found error
+func findEmployeeByUser(c context.Context, employeeRepository repository.EmployeeRepository, userID string) (domain.Employee, error) {
+	employee, err := employeeRepository.FindByUserId(c, userID)
+	if err != nil {
+		if strings.Contains(err.Error(), "no rows") {
+			return domain.Employee{}, exception.ErrNotFound(fmt.Sprintf("Employee of user %s not found", userID))
+		}
+		return domain.Employee{}, err
+	}
+
+	return employee, nil
+}
+
+// validate the department exist and the position (if any) belongs to the department
+func validateAssignment(c context.Context, departmentRepository repository.DepartmentRepository, positionRepository repository.PositionRepository, departmentID string, positionID *string) (*domain.Position, error) {
+	if _, err := departmentRepository.FindById(c, departmentID); err != nil {
+		if strings.Contains(err.Error(), "no rows") {
+			return nil, exception.ErrNotFound(fmt.Sprintf("Department %s not found", departmentID))
+		}
+		return nil, err
+	}
+	if positionID == nil {
+		return nil, nil
+	}
+
+	position, err := positionRepository.FindById(c, *positionID)
+	if err != nil {
+		if strings.Contains(err.Error(), "no rows") {
+			return nil, exception.ErrNotFound(fmt.Sprintf("Position %s not found", *positionID))
+		}
+		return nil, err
+	}
+	if position.DepartmentID != departmentID {
+		return nil, exception.ErrBadRequest("Position doesn't belong to the department.")
+	}
+
+	return &position, nil
+}
+
+// validate the manager exist, isn't the employee itself and isn't one of the employee's subordinates
+func validateManager(c context.Context, employeeRepository repository.EmployeeRepository, employeeID, managerID string) (domain.Employee, error) {
+	if managerID == employeeID {
+		return domain.Employee{}, exception.ErrBadRequest("Employee can't be the manager of itself.")
+	}
+
+	manager, err := findEmployee(c, employeeRepository, managerID)
+	if err != nil {
+		return domain.Employee{}, err
+	}
+
+	chain, err := employeeRepository.FindReportingChain(c, managerID)
+	if err != nil {
+		return domain.Employee{}, err
+	}
+	for _, superior := range chain {
+		if superior.ID == employeeID {
+			return domain.Employee{}, exception.ErrBadRequest("Manager can't be a subordinate of the employee.")
+		}
+	}
+
+	return manager, nil
+}
+
+// compare the value of two nullable strings
+func equalStringPtr(a, b *string) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
@@ -0,0 +1,94 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+	"github.com/iqbaludinm/hr-microservice/user-service/helper"
+	"github.com/iqbaludinm/hr-microservice/user-service/model/domain"
+	"github.com/iqbaludinm/hr-microservice/user-service/model/web"
+	"github.com/iqbaludinm/hr-microservice/user-service/repository"
+	"go.uber.org/zap"
+)
+
+// the fakes embed the repository interfaces, only the methods used by the test are implemented
+type fakeEmployeeRepository struct {
+	repository.EmployeeRepository
+	employee domain.Employee
+}
+
+func (r *fakeEmployeeRepository) FindById(c context.Context, id string) (domain.Employee, error) {
+	return r.employee, nil
+}
+
+type fakeDepartmentRepository struct {
+	repository.DepartmentRepository
+}
+
+func (r *fakeDepartmentRepository) FindById(c context.Context, id string) (domain.Department, error) {
+	return domain.Department{ID: id, Name: "Engineering"}, nil
+}
+
+type fakeEmploymentHistoryRepository struct {
+	repository.EmploymentHistoryRepository
+	created []domain.EmploymentHistory
+}
+
+func (r *fakeEmploymentHistoryRepository) FindByEmployeeId(c context.Context, employeeID string) ([]domain.EmploymentHistory, error) {
+	return nil, nil
+}
+
+func (r *fakeEmploymentHistoryRepository) CreateHistory(c context.Context, history domain.EmploymentHistory) error {
+	r.created = append(r.created, history)
+	return nil
+}
+
+func TestCreateChangeRemovesManager(t *testing.T) {
+	managerID := uuid.New().String()
+	employee := domain.Employee{
+		ID:           uuid.New().String(),
+		HireDate:     helper.Today().AddDate(-1, 0, 0),
+		DepartmentID: uuid.New().String(),
+		ManagerID:    &managerID,
+	}
+
+	// an empty manager id passes the validation of the request
+	emptyManagerID := ""
+	request := web.CreateEmploymentChangeRequest{
+		ChangeType:    "manager_change",
+		ManagerID:     &emptyManagerID,
+		EffectiveDate: helper.Today().Add(24 * time.Hour).Format(helper.DateLayout),
+	}
+	if err := validator.New().Struct(request); err != nil {
+		t.Fatalf("validate request: %v", err)
+	}
+
+	// the future-dated change is stored without the manager
+	historyRepository := &fakeEmploymentHistoryRepository{}
+	s := NewEmploymentHistoryService(historyRepository, &fakeEmployeeRepository{employee: employee}, &fakeDepartmentRepository{}, nil, nil, zap.NewNop().Sugar())
+	response, err := s.CreateChange(context.Background(), employee.ID, request)
+	if err != nil {
+		t.Fatalf("create change: %v", err)
+	}
+	if response.ManagerID != nil {
+		t.Errorf("response manager id = %s, want nil", *response.ManagerID)
+	}
+	if len(historyRepository.created) != 1 || historyRepository.created[0].ManagerID != nil {
+		t.Errorf("created histories = %+v, want one history without manager", historyRepository.created)
+	}
+}
+
+func TestCreateEmploymentChangeRequestRejectsInvalidManager(t *testing.T) {
+	invalidManagerID := "not-a-uuid"
+	request := web.CreateEmploymentChangeRequest{
+		ChangeType:    "manager_change",
+		ManagerID:     &invalidManagerID,
+		EffectiveDate: helper.Today().Format(helper.DateLayout),
+	}
+	if err := validator.New().Struct(request); err == nil {
+		t.Error("validate request: want error for an invalid manager id")
+	}
+}
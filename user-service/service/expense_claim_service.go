@@ -0,0 +1,826 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/iqbaludinm/hr-microservice/user-service/config"
+	"github.com/iqbaludinm/hr-microservice/user-service/exception"
+	"github.com/iqbaludinm/hr-microservice/user-service/helper"
+	"github.com/iqbaludinm/hr-microservice/user-service/model/domain"
+	"github.com/iqbaludinm/hr-microservice/user-service/model/kafkamodel"
+	"github.com/iqbaludinm/hr-microservice/user-service/model/web"
+	"github.com/iqbaludinm/hr-microservice/user-service/repository"
+	"github.com/iqbaludinm/hr-microservice/user-service/service/producers"
+	"go.uber.org/zap"
+)
+
+// Type of the notifications produced by the expense claim service.
+const (
+	NotificationExpenseClaimSubmitted       = "EXPENSE_CLAIM_SUBMITTED"
+	NotificationExpenseClaimManagerApproved = "EXPENSE_CLAIM_MANAGER_APPROVED"
+	NotificationExpenseClaimApproved        = "EXPENSE_CLAIM_APPROVED"
+	NotificationExpenseClaimRejected        = "EXPENSE_CLAIM_REJECTED"
+	NotificationExpenseClaimPaid            = "EXPENSE_CLAIM_PAID"
+)
+
+type ExpenseClaimService interface {
+	// With Transaction
+	CreateCategory(ctx context.Context, request web.ExpenseCategoryRequest) (web.ExpenseCategoryResponse, error)
+	UpdateCategory(ctx context.Context, id string, request web.ExpenseCategoryRequest) (web.ExpenseCategoryResponse, error)
+	DeleteCategory(ctx context.Context, id string) error
+	CreateClaim(ctx context.Context, userID string, request web.ExpenseClaimRequest) (web.ExpenseClaimResponse, error)
+	UpdateClaim(ctx context.Context, userID, id string, request web.UpdateExpenseClaimRequest) (web.ExpenseClaimResponse, error)
+	AddItem(ctx context.Context, userID, id string, request web.ExpenseClaimItemRequest) (web.ExpenseClaimResponse, error)
+	DeleteItem(ctx context.Context, userID, id string, seq int) (web.ExpenseClaimResponse, error)
+	UploadReceipt(ctx context.Context, userID, id string, seq int, file web.DocumentFile) (web.ExpenseClaimResponse, error)
+	SubmitClaim(ctx context.Context, userID, id string) (web.ExpenseClaimResponse, error)
+	ApproveClaim(ctx context.Context, userID, id string, request web.DecideExpenseClaimRequest) (web.ExpenseClaimResponse, error)
+	RejectClaim(ctx context.Context, userID, id string, request web.DecideExpenseClaimRequest) (web.ExpenseClaimResponse, error)
+	CancelClaim(ctx context.Context, userID, id string) (web.ExpenseClaimResponse, error)
+
+	// Without Transaction
+	FindAllCategory(ctx context.Context, filter web.ExpenseCategoryQueryFilter) ([]web.ExpenseCategoryResponse, int, error)
+	FindCategoryById(ctx context.Context, id string) (web.ExpenseCategoryResponse, error)
+	FindAllClaim(ctx context.Context, filter web.ExpenseClaimQueryFilter) ([]web.ExpenseClaimResponse, int, error)
+	FindMyClaims(ctx context.Context, userID string, filter web.ExpenseClaimQueryFilter) ([]web.ExpenseClaimResponse, int, error)
+	FindPendingApprovals(ctx context.Context, userID string, filter web.ExpenseClaimQueryFilter) ([]web.ExpenseClaimResponse, int, error)
+	FindClaimById(ctx context.Context, id string) (web.ExpenseClaimResponse, error)
+	Receipt(ctx context.Context, id string, seq int) ([]byte, string, string, error)
+
+	// Approval Entity
+	ApprovalEntity
+}
+
+type expenseClaimService struct {
+	expenseClaimRepository repository.ExpenseClaimRepository
+	employeeRepository     repository.EmployeeRepository
+	departmentRepository   repository.DepartmentRepository
+	storage                helper.Storage
+	approvalService        ApprovalService
+	kafkaProducerService   producers.KafkaProducerService
+	logger                 *zap.SugaredLogger
+}
+
+func NewExpenseClaimService(expenseClaimRepository repository.ExpenseClaimRepository, employeeRepository repository.EmployeeRepository, departmentRepository repository.DepartmentRepository, storage helper.Storage, approvalService ApprovalService, kafkaProducerService producers.KafkaProducerService, logger *zap.SugaredLogger) ExpenseClaimService {
+	return &expenseClaimService{
+		expenseClaimRepository: expenseClaimRepository,
+		employeeRepository:     employeeRepository,
+		departmentRepository:   departmentRepository,
+		storage:                storage,
+		approvalService:        approvalService,
+		kafkaProducerService:   kafkaProducerService,
+		logger:                 logger,
+	}
+}
+
+func (s *expenseClaimService) CreateCategory(c context.Context, request web.ExpenseCategoryRequest) (web.ExpenseCategoryResponse, error) {
+	// convert to domain or model expense category
+	category := domain.ToDomainExpenseCategory(request)
+	if err := validateExpenseLimits(category); err != nil {
+		return web.ExpenseCategoryResponse{}, err
+	}
+
+	category.ID = uuid.New().String()
+	category.CreatedAt = time.Now()
+	category.UpdatedAt = time.Now()
+	for i := range category.Limits {
+		category.Limits[i].CreatedAt = category.CreatedAt
+	}
+
+	// call the repo for inserting to db
+	if err := s.expenseClaimRepository.CreateCategory(c, category); err != nil {
+		s.logger.Infow(err.Error(), "Create Expense Category Error")
+		return web.ExpenseCategoryResponse{}, toExpenseCategoryUniqueError(err)
+	}
+
+	// get or returning the expense category have created to db
+	newCategory, err := s.expenseClaimRepository.FindCategoryById(c, category.ID)
+	if err != nil {
+		return web.ExpenseCategoryResponse{}, exception.ErrInternalServer(fmt.Sprintf("Successfully created expense category, but failed to get the expense category have created. Error: %s", err.Error()))
+	}
+
+	return newCategory.ToExpenseCategoryResponse(), nil
+}
+
+// update the expense category, the limits are replaced. The limits apply to the claims submitted afterwards.
+func (s *expenseClaimService) UpdateCategory(c context.Context, id string, request web.ExpenseCategoryRequest) (web.ExpenseCategoryResponse, error) {
+	if _, err := findExpenseCategory(c, s.expenseClaimRepository, id); err != nil {
+		return web.ExpenseCategoryResponse{}, err
+	}
+
+	category := domain.ToDomainExpenseCategory(request)
+	if err := validateExpenseLimits(category); err != nil {
+		return web.ExpenseCategoryResponse{}, err
+	}
+
+	category.ID = id
+	category.UpdatedAt = time.Now()
+	for i := range category.Limits {
+		category.Limits[i].CreatedAt = category.UpdatedAt
+	}
+
+	if err := s.expenseClaimRepository.UpdateCategory(c, category); err != nil {
+		s.logger.Infow(err.Error(), "Update Expense Category Error")
+		return web.ExpenseCategoryResponse{}, toExpenseCategoryUniqueError(err)
+	}
+
+	return s.FindCategoryById(c, id)
+}
+
+// delete the expense category, the items of the existing claims keep their category
+func (s *expenseClaimService) DeleteCategory(c context.Context, id string) error {
+	if _, err := findExpenseCategory(c, s.expenseClaimRepository, id); err != nil {
+		return err
+	}
+
+	return s.expenseClaimRepository.DeleteCategory(c, id)
+}
+
+// create the draft claim of the logged in user
+func (s *expenseClaimService) CreateClaim(c context.Context, userID string, request web.ExpenseClaimRequest) (web.ExpenseClaimResponse, error) {
+	employee, err := findEmployeeByUser(c, s.employeeRepository, userID)
+	if err != nil {
+		return web.ExpenseClaimResponse{}, err
+	}
+
+	// convert to domain or model expense claim
+	claim := domain.ToDomainExpenseClaim(request)
+	for _, item := range claim.Items {
+		if err := s.validateItem(c, item); err != nil {
+			return web.ExpenseClaimResponse{}, err
+		}
+	}
+
+	now := time.Now()
+	claim.ID = uuid.New().String()
+	claim.EmployeeID = employee.ID
+	claim.Status = domain.ExpenseClaimDraft
+	claim.CreatedAt = now
+	claim.UpdatedAt = now
+	claim.Total()
+
+	// call the repo for inserting to db
+	history := claim.NewHistory(uuid.New().String(), nil, "", &userID, now)
+	if err := s.expenseClaimRepository.CreateClaim(c, claim, history); err != nil {
+		s.logger.Infow(err.Error(), "Create Expense Claim Error")
+		return web.ExpenseClaimResponse{}, err
+	}
+
+	// get or returning the expense claim have created to db
+	newClaim, err := s.expenseClaimRepository.FindClaimById(c, claim.ID)
+	if err != nil {
+		return web.ExpenseClaimResponse{}, exception.ErrInternalServer(fmt.Sprintf("Successfully created expense claim, but failed to get the expense claim have created. Error: %s", err.Error()))
+	}
+
+	return newClaim.ToExpenseClaimResponse(), nil
+}
+
+func (s *expenseClaimService) UpdateClaim(c context.Context, userID, id string, request web.UpdateExpenseClaimRequest) (web.ExpenseClaimResponse, error) {
+	claim, err := s.findEditableClaim(c, userID, id)
+	if err != nil {
+		return web.ExpenseClaimResponse{}, err
+	}
+
+	claim.Title = request.Title
+	claim.Description = request.Description
+	claim.UpdatedAt = time.Now()
+
+	if err := s.expenseClaimRepository.UpdateClaim(c, claim); err != nil {
+		s.logger.Infow(err.Error(), "Update Expense Claim Error")
+		return web.ExpenseClaimResponse{}, toExpenseClaimChangedError(err)
+	}
+
+	return s.FindClaimById(c, id)
+}
+
+func (s *expenseClaimService) AddItem(c context.Context, userID, id string, request web.ExpenseClaimItemRequest) (web.ExpenseClaimResponse, error) {
+	claim, err := s.findEditableClaim(c, userID, id)
+	if err != nil {
+		return web.ExpenseClaimResponse{}, err
+	}
+
+	item := domain.ToDomainExpenseClaimItem(request)
+	if err := s.validateItem(c, item); err != nil {
+		return web.ExpenseClaimResponse{}, err
+	}
+	item.ClaimID = claim.ID
+	item.Seq = claim.NextSeq()
+
+	claim.Items = append(claim.Items, item)
+	claim.Total()
+	claim.UpdatedAt = time.Now()
+
+	if err := s.expenseClaimRepository.AddItem(c, claim, item); err != nil {
+		s.logger.Infow(err.Error(), "Add Expense Claim Item Error")
+		return web.ExpenseClaimResponse{}, toExpenseClaimChangedError(err)
+	}
+
+	return s.FindClaimById(c, id)
+}
+
+// delete the item of the claim, its receipt is removed from the storage
+func (s *expenseClaimService) DeleteItem(c context.Context, userID, id string, seq int) (web.ExpenseClaimResponse, error) {
+	claim, err := s.findEditableClaim(c, userID, id)
+	if err != nil {
+		return web.ExpenseClaimResponse{}, err
+	}
+	item, ok := claim.FindItem(seq)
+	if !ok {
+		return web.ExpenseClaimResponse{}, exception.ErrNotFound(fmt.Sprintf("Item %d of expense claim %s not found", seq, id))
+	}
+
+	items := []domain.ExpenseClaimItem{}
+	for _, claimItem := range claim.Items {
+		if claimItem.Seq != seq {
+			items = append(items, claimItem)
+		}
+	}
+	claim.Items = items
+	claim.Total()
+	claim.UpdatedAt = time.Now()
+
+	if err := s.expenseClaimRepository.DeleteItem(c, claim, seq); err != nil {
+		s.logger.Infow(err.Error(), "Delete Expense Claim Item Error")
+		return web.ExpenseClaimResponse{}, toExpenseClaimChangedError(err)
+	}
+	if item.ReceiptStorageKey != nil {
+		_ = s.storage.Delete(c, *item.ReceiptStorageKey)
+	}
+
+	return s.FindClaimById(c, id)
+}
+
+// upload the receipt of the item, the previous receipt of the item is replaced
+func (s *expenseClaimService) UploadReceipt(c context.Context, userID, id string, seq int, file web.DocumentFile) (web.ExpenseClaimResponse, error) {
+	claim, err := s.findEditableClaim(c, userID, id)
+	if err != nil {
+		return web.ExpenseClaimResponse{}, err
+	}
+	item, ok := claim.FindItem(seq)
+	if !ok {
+		return web.ExpenseClaimResponse{}, exception.ErrNotFound(fmt.Sprintf("Item %d of expense claim %s not found", seq, id))
+	}
+
+	mimeType, err := validateReceipt(file)
+	if err != nil {
+		return web.ExpenseClaimResponse{}, err
+	}
+
+	previousKey := item.ReceiptStorageKey
+	storageKey := item.NewReceiptStorageKey(file.Name)
+	fileName := filepath.Base(file.Name)
+	item.ReceiptStorageKey = &storageKey
+	item.ReceiptFileName = &fileName
+	item.ReceiptMimeType = &mimeType
+
+	// store the receipt first, the stored file is removed when the item fails to be updated
+	if err := s.storage.Put(c, storageKey, file.Data); err != nil {
+		s.logger.Errorw(err.Error(), "Store Expense Receipt Error")
+		return web.ExpenseClaimResponse{}, err
+	}
+	if err := s.expenseClaimRepository.UpdateItemReceipt(c, item); err != nil {
+		s.logger.Infow(err.Error(), "Upload Expense Receipt Error")
+		if previousKey == nil || *previousKey != storageKey {
+			_ = s.storage.Delete(c, storageKey)
+		}
+		return web.ExpenseClaimResponse{}, err
+	}
+	if previousKey != nil && *previousKey != storageKey {
+		_ = s.storage.Delete(c, *previousKey)
+	}
+
+	return s.FindClaimById(c, id)
+}
+
+// submit the draft or rejected claim to the manager of the employee. The receipts required by the categories must be
+// uploaded and the sum of the items of a category can't exceed its limit for the salary grade of the employee, the
+// claim of the employee without a salary grade can't be submitted.
+func (s *expenseClaimService) SubmitClaim(c context.Context, userID, id string) (web.ExpenseClaimResponse, error) {
+	claim, err := s.findEditableClaim(c, userID, id)
+	if err != nil {
+		return web.ExpenseClaimResponse{}, err
+	}
+	if len(claim.Items) == 0 {
+		return web.ExpenseClaimResponse{}, exception.ErrBadRequest("Expense claim has no items.")
+	}
+	for _, item := range claim.Items {
+		if item.ReceiptRequired && item.ReceiptStorageKey == nil {
+			return web.ExpenseClaimResponse{}, exception.ErrBadRequest(fmt.Sprintf("The receipt of item %d (%s) is required.", item.Seq, item.CategoryName))
+		}
+	}
+
+	employee, err := findEmployee(c, s.employeeRepository, claim.EmployeeID)
+	if err != nil {
+		return web.ExpenseClaimResponse{}, err
+	}
+	// the limits are set for the salary grades, so the claim can't be checked without the salary grade
+	if employee.SalaryGrade == "" {
+		return web.ExpenseClaimResponse{}, exception.ErrBadRequest("Employee has no salary grade to check the expense limits, please contact HR.")
+	}
+	limits, err := s.expenseClaimRepository.FindLimitsByGrade(c, employee.SalaryGrade)
+	if err != nil {
+		return web.ExpenseClaimResponse{}, err
+	}
+	if exceeded := claim.ExceededLimits(limits); len(exceeded) > 0 {
+		return web.ExpenseClaimResponse{}, exception.ErrBadRequest(fmt.Sprintf("The %s expenses exceed the limit of %s for the salary grade %s.",
+			exceeded[0].CategoryName, helper.FormatRupiah(exceeded[0].MaxAmount), employee.SalaryGrade))
+	}
+
+	// the expense claim is approved by the employee's manager first
+	if employee.ManagerID == nil {
+		return web.ExpenseClaimResponse{}, exception.ErrBadRequest("Employee has no manager to approve the expense claim.")
+	}
+	manager, err := findEmployee(c, s.employeeRepository, *employee.ManagerID)
+	if err != nil {
+		return web.ExpenseClaimResponse{}, err
+	}
+
+	now := time.Now()
+	fromStatus := claim.Status
+	claim.Status = domain.ExpenseClaimSubmitted
+	claim.ManagerID = &manager.ID
+	claim.SubmittedAt = &now
+	claim.ManagerApprovedAt = nil
+	claim.FinanceApprovedBy = nil
+	claim.FinanceApprovedAt = nil
+	claim.UpdatedAt = now
+
+	history := claim.NewHistory(uuid.New().String(), &fromStatus, "", &userID, now)
+	if err := s.updateClaimStatus(c, claim, history, fromStatus); err != nil {
+		s.logger.Infow(err.Error(), "Submit Expense Claim Error")
+		return web.ExpenseClaimResponse{}, err
+	}
+
+	// the claim is approved through the active approval workflow of expense claim, otherwise by the manager and
+	// finance. The claim is moved back to its previous status when its approval can't be requested.
+	governed, err := s.approvalService.Request(c, domain.ApprovalEntityExpenseClaim, claim.ID)
+	if err != nil {
+		s.logger.Infow(err.Error(), "Request Expense Claim Approval Error")
+		claim.Status = fromStatus
+		claim.UpdatedAt = time.Now()
+		if err := s.updateClaimStatus(c, claim, claim.NewHistory(uuid.New().String(), &history.ToStatus, "", &userID, claim.UpdatedAt), history.ToStatus); err != nil {
+			s.logger.Errorw("Withdraw Expense Claim Error", "claim_id", claim.ID, "error", err.Error())
+		}
+		return web.ExpenseClaimResponse{}, err
+	}
+
+	// the approvers of the workflow are notified by it
+	if !governed {
+		s.notify(manager.UserID, NotificationExpenseClaimSubmitted, "Expense Claim Submitted",
+			fmt.Sprintf("%s submitted the expense claim %s of %s.", claim.EmployeeName, claim.Title, helper.FormatRupiah(claim.TotalAmount)), claim)
+	}
+
+	return s.FindClaimById(c, id)
+}
+
+// approve the claim, the submitted claim is approved by the manager and then by finance
+func (s *expenseClaimService) ApproveClaim(c context.Context, userID, id string, request web.DecideExpenseClaimRequest) (web.ExpenseClaimResponse, error) {
+	claim, err := findExpenseClaim(c, s.expenseClaimRepository, id)
+	if err != nil {
+		return web.ExpenseClaimResponse{}, err
+	}
+	if err := s.authorizeDecision(c, userID, claim); err != nil {
+		return web.ExpenseClaimResponse{}, err
+	}
+
+	now := time.Now()
+	fromStatus := claim.Status
+	if claim.Status == domain.ExpenseClaimSubmitted {
+		claim.Status = domain.ExpenseClaimManagerApproved
+		claim.ManagerApprovedAt = &now
+	} else {
+		claim.Status = domain.ExpenseClaimApproved
+		claim.FinanceApprovedBy = &userID
+		claim.FinanceApprovedAt = &now
+	}
+	claim.UpdatedAt = now
+
+	history := claim.NewHistory(uuid.New().String(), &fromStatus, request.Note, &userID, now)
+	if err := s.updateClaimStatus(c, claim, history, fromStatus); err != nil {
+		s.logger.Infow(err.Error(), "Approve Expense Claim Error")
+		return web.ExpenseClaimResponse{}, err
+	}
+
+	if claim.Status == domain.ExpenseClaimManagerApproved {
+		s.notify(claim.EmployeeUserID, NotificationExpenseClaimManagerApproved, "Expense Claim Approved by Manager",
+			fmt.Sprintf("Your expense claim %s has been approved by your manager and is waiting for the approval of finance.", claim.Title), claim)
+	} else {
+		s.notify(claim.EmployeeUserID, NotificationExpenseClaimApproved, "Expense Claim Approved",
+			fmt.Sprintf("Your expense claim %s has been approved and will be reimbursed with the next payroll.", claim.Title), claim)
+	}
+
+	return s.FindClaimById(c, id)
+}
+
+func (s *expenseClaimService) RejectClaim(c context.Context, userID, id string, request web.DecideExpenseClaimRequest) (web.ExpenseClaimResponse, error) {
+	if request.Note == "" {
+		return web.ExpenseClaimResponse{}, exception.ErrBadRequest("The note is required when the expense claim is rejected.")
+	}
+
+	claim, err := findExpenseClaim(c, s.expenseClaimRepository, id)
+	if err != nil {
+		return web.ExpenseClaimResponse{}, err
+	}
+	if err := s.authorizeDecision(c, userID, claim); err != nil {
+		return web.ExpenseClaimResponse{}, err
+	}
+
+	now := time.Now()
+	fromStatus := claim.Status
+	claim.Status = domain.ExpenseClaimRejected
+	claim.UpdatedAt = now
+
+	history := claim.NewHistory(uuid.New().String(), &fromStatus, request.Note, &userID, now)
+	if err := s.updateClaimStatus(c, claim, history, fromStatus); err != nil {
+		s.logger.Infow(err.Error(), "Reject Expense Claim Error")
+		return web.ExpenseClaimResponse{}, err
+	}
+
+	s.notify(claim.EmployeeUserID, NotificationExpenseClaimRejected, "Expense Claim Rejected",
+		fmt.Sprintf("Your expense claim %s has been rejected. %s", claim.Title, request.Note), claim)
+
+	return s.FindClaimById(c, id)
+}
+
+// cancel the claim which is not approved by finance yet, only the employee can cancel it
+func (s *expenseClaimService) CancelClaim(c context.Context, userID, id string) (web.ExpenseClaimResponse, error) {
+	claim, err := findExpenseClaim(c, s.expenseClaimRepository, id)
+	if err != nil {
+		return web.ExpenseClaimResponse{}, err
+	}
+	if claim.EmployeeUserID != userID {
+		return web.ExpenseClaimResponse{}, exception.ErrUnauthorized("Only the employee can cancel the expense claim.")
+	}
+	if claim.Status == domain.ExpenseClaimApproved || claim.Status == domain.ExpenseClaimPaid || claim.Status == domain.ExpenseClaimCancelled {
+		return web.ExpenseClaimResponse{}, exception.ErrBadRequest(fmt.Sprintf("Expense claim is already %s.", claim.Status))
+	}
+
+	now := time.Now()
+	fromStatus := claim.Status
+	claim.Status = domain.ExpenseClaimCancelled
+	claim.UpdatedAt = now
+
+	history := claim.NewHistory(uuid.New().String(), &fromStatus, "", &userID, now)
+	if err := s.updateClaimStatus(c, claim, history, fromStatus); err != nil {
+		s.logger.Infow(err.Error(), "Cancel Expense Claim Error")
+		return web.ExpenseClaimResponse{}, err
+	}
+	if fromStatus == domain.ExpenseClaimSubmitted {
+		if err := s.approvalService.CancelEntity(c, domain.ApprovalEntityExpenseClaim, claim.ID); err != nil {
+			s.logger.Errorw("Cancel Expense Claim Approval Error", "claim_id", claim.ID, "error", err.Error())
+		}
+	}
+
+	return s.FindClaimById(c, id)
+}
+
+// FindApprovalSubject finds the employee and the total amount of the submitted claim
+func (s *expenseClaimService) FindApprovalSubject(c context.Context, id string) (domain.ApprovalSubject, error) {
+	claim, err := findExpenseClaim(c, s.expenseClaimRepository, id)
+	if err != nil {
+		return domain.ApprovalSubject{}, err
+	}
+	if claim.Status != domain.ExpenseClaimSubmitted {
+		return domain.ApprovalSubject{}, exception.ErrBadRequest(fmt.Sprintf("Expense claim is already %s.", claim.Status))
+	}
+
+	return domain.ApprovalSubject{
+		RequesterID: claim.EmployeeID,
+		Title:       claim.Title,
+		Amount:      &claim.TotalAmount,
+	}, nil
+}
+
+// ApplyApproval decides the submitted claim with the completed approval, the employee is notified by the approval
+func (s *expenseClaimService) ApplyApproval(c context.Context, approval domain.ApprovalRequest) error {
+	claim, err := findExpenseClaim(c, s.expenseClaimRepository, approval.EntityID)
+	if err != nil {
+		return err
+	}
+	if claim.Status != domain.ExpenseClaimSubmitted {
+		return exception.ErrBadRequest(fmt.Sprintf("Expense claim is already %s.", claim.Status))
+	}
+
+	now := time.Now()
+	fromStatus := claim.Status
+	switch approval.Status {
+	case domain.ApprovalStatusApproved:
+		claim.Status = domain.ExpenseClaimApproved
+		claim.FinanceApprovedAt = &now
+	case domain.ApprovalStatusRejected:
+		claim.Status = domain.ExpenseClaimRejected
+	default:
+		claim.Status = domain.ExpenseClaimCancelled
+	}
+	claim.UpdatedAt = now
+
+	// the decision is made by the approvers of the workflow, so the history has no user
+	history := claim.NewHistory(uuid.New().String(), &fromStatus, approval.DecisionNote(), nil, now)
+	return s.updateClaimStatus(c, claim, history, fromStatus)
+}
+
+func (s *expenseClaimService) FindAllCategory(c context.Context, filter web.ExpenseCategoryQueryFilter) (result []web.ExpenseCategoryResponse, totalData int, err error) {
+	domainFilter := domain.ToDomainExpenseCategoryQueryFilter(filter)
+
+	categories, err := s.expenseClaimRepository.FindAllCategory(c, domainFilter)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	// get total-data
+	totalData, err = s.expenseClaimRepository.CountAllCategory(c, domainFilter)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	// convert to web.ExpenseCategoryResponse
+	result = []web.ExpenseCategoryResponse{}
+	for _, category := range categories {
+		result = append(result, category.ToExpenseCategoryResponse())
+	}
+
+	return result, totalData, nil
+}
+
+func (s *expenseClaimService) FindCategoryById(c context.Context, id string) (web.ExpenseCategoryResponse, error) {
+	category, err := findExpenseCategory(c, s.expenseClaimRepository, id)
+	if err != nil {
+		return web.ExpenseCategoryResponse{}, err
+	}
+
+	return category.ToExpenseCategoryResponse(), nil
+}
+
+func (s *expenseClaimService) FindAllClaim(c context.Context, filter web.ExpenseClaimQueryFilter) ([]web.ExpenseClaimResponse, int, error) {
+	return s.findAllClaim(c, domain.ToDomainExpenseClaimQueryFilter(filter))
+}
+
+func (s *expenseClaimService) FindMyClaims(c context.Context, userID string, filter web.ExpenseClaimQueryFilter) ([]web.ExpenseClaimResponse, int, error) {
+	employee, err := findEmployeeByUser(c, s.employeeRepository, userID)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	domainFilter := domain.ToDomainExpenseClaimQueryFilter(filter)
+	domainFilter.EmployeeID = employee.ID
+	return s.findAllClaim(c, domainFilter)
+}
+
+// find the claims waiting for the approval of the logged in user, as the manager of the employees or as finance
+func (s *expenseClaimService) FindPendingApprovals(c context.Context, userID string, filter web.ExpenseClaimQueryFilter) ([]web.ExpenseClaimResponse, int, error) {
+	approver, err := findEmployeeByUser(c, s.employeeRepository, userID)
+	if err != nil {
+		return nil, 0, err
+	}
+	finance, err := s.isFinance(c, approver)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	domainFilter := domain.ToDomainExpenseClaimQueryFilter(filter)
+	domainFilter.ApproverID = approver.ID
+	domainFilter.Finance = finance
+
+	return s.findAllClaim(c, domainFilter)
+}
+
+func (s *expenseClaimService) FindClaimById(c context.Context, id string) (web.ExpenseClaimResponse, error) {
+	claim, err := findExpenseClaim(c, s.expenseClaimRepository, id)
+	if err != nil {
+		return web.ExpenseClaimResponse{}, err
+	}
+
+	return claim.ToExpenseClaimResponse(), nil
+}
+
+// Receipt returns the receipt of the item of the claim with its file name and MIME type.
+func (s *expenseClaimService) Receipt(c context.Context, id string, seq int) ([]byte, string, string, error) {
+	claim, err := findExpenseClaim(c, s.expenseClaimRepository, id)
+	if err != nil {
+		return nil, "", "", err
+	}
+	item, ok := claim.FindItem(seq)
+	if !ok || item.ReceiptStorageKey == nil {
+		return nil, "", "", exception.ErrNotFound(fmt.Sprintf("The receipt of item %d of expense claim %s not found", seq, id))
+	}
+
+	data, err := s.storage.Get(c, *item.ReceiptStorageKey)
+	if err != nil {
+		if errors.Is(err, helper.ErrObjectNotFound) {
+			return nil, "", "", exception.ErrNotFound(fmt.Sprintf("The receipt of item %d of expense claim %s not found", seq, id))
+		}
+		return nil, "", "", err
+	}
+
+	return data, *item.ReceiptFileName, *item.ReceiptMimeType, nil
+}
+
+// find the claim of the logged in user which can be edited, the draft or the rejected claim
+func (s *expenseClaimService) findEditableClaim(c context.Context, userID, id string) (domain.ExpenseClaim, error) {
+	claim, err := findExpenseClaim(c, s.expenseClaimRepository, id)
+	if err != nil {
+		return domain.ExpenseClaim{}, err
+	}
+	if claim.EmployeeUserID != userID {
+		return domain.ExpenseClaim{}, exception.ErrUnauthorized("Only the employee can change the expense claim.")
+	}
+	if !claim.Editable() {
+		return domain.ExpenseClaim{}, exception.ErrBadRequest(fmt.Sprintf("Expense claim is already %s.", claim.Status))
+	}
+
+	return claim, nil
+}
+
+// the submitted claim is decided by the manager of the employee, the claim approved by the manager is decided by
+// finance. Nobody decides their own claim.
+func (s *expenseClaimService) authorizeDecision(c context.Context, userID string, claim domain.ExpenseClaim) error {
+	approver, err := findEmployeeByUser(c, s.employeeRepository, userID)
+	if err != nil {
+		return err
+	}
+	if approver.ID == claim.EmployeeID {
+		return exception.ErrUnauthorized("The employee can't decide their own expense claim.")
+	}
+
+	// the claim with a pending approval is decided through the approval workflow
+	governed, err := s.approvalService.IsPending(c, domain.ApprovalEntityExpenseClaim, claim.ID)
+	if err != nil {
+		return err
+	}
+	if governed {
+		return exception.ErrBadRequest("Expense claim is decided through its approval.")
+	}
+
+	switch claim.Status {
+	case domain.ExpenseClaimSubmitted:
+		if claim.ManagerID == nil || *claim.ManagerID != approver.ID {
+			return exception.ErrUnauthorized("Only the manager of the employee can decide the expense claim.")
+		}
+	case domain.ExpenseClaimManagerApproved:
+		finance, err := s.isFinance(c, approver)
+		if err != nil {
+			return err
+		}
+		if !finance {
+			return exception.ErrUnauthorized("Only finance can decide the expense claim approved by the manager.")
+		}
+	default:
+		return exception.ErrBadRequest(fmt.Sprintf("Expense claim is already %s.", claim.Status))
+	}
+
+	return nil
+}
+
+// update the status of the claim which still has the given status
+func (s *expenseClaimService) updateClaimStatus(c context.Context, claim domain.ExpenseClaim, history domain.ExpenseClaimHistory, status string) error {
+	if err := s.expenseClaimRepository.UpdateClaimStatus(c, claim, history, status); err != nil {
+		return toExpenseClaimChangedError(err)
+	}
+
+	return nil
+}
+
+// the employee is finance when it belongs to the finance department of the config
+func (s *expenseClaimService) isFinance(c context.Context, employee domain.Employee) (bool, error) {
+	if config.ExpenseFinanceDepartmentCode == "" {
+		return false, nil
+	}
+
+	department, err := s.departmentRepository.FindById(c, employee.DepartmentID)
+	if err != nil {
+		if strings.Contains(err.Error(), "no rows") {
+			return false, nil
+		}
+		return false, err
+	}
+
+	return department.Code == config.ExpenseFinanceDepartmentCode, nil
+}
+
+// validate the category of the item exist and the expense date is not in the future
+func (s *expenseClaimService) validateItem(c context.Context, item domain.ExpenseClaimItem) error {
+	if _, err := findExpenseCategory(c, s.expenseClaimRepository, item.CategoryID); err != nil {
+		return err
+	}
+	if item.ExpenseDate.After(helper.Today()) {
+		return exception.ErrBadRequest("The expense date can't be in the future.")
+	}
+	if item.ExchangeRate <= 0 {
+		return exception.ErrBadRequest(fmt.Sprintf("The exchange rate of %s is required.", item.Currency))
+	}
+
+	return nil
+}
+
+func (s *expenseClaimService) findAllClaim(c context.Context, filter domain.ExpenseClaimQueryFilter) (result []web.ExpenseClaimResponse, totalData int, err error) {
+	claims, err := s.expenseClaimRepository.FindAllClaim(c, filter)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	// get total-data
+	totalData, err = s.expenseClaimRepository.CountAllClaim(c, filter)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	// convert to web.ExpenseClaimResponse
+	result = []web.ExpenseClaimResponse{}
+	for _, claim := range claims {
+		result = append(result, claim.ToExpenseClaimResponse())
+	}
+
+	return result, totalData, nil
+}
+
+// produce the notification of the expense claim to the user
+func (s *expenseClaimService) notify(userID, notificationType, title, message string, claim domain.ExpenseClaim) {
+	kafkaNotificationMessage := kafkamodel.NewKafkaNotificationMessage(userID, notificationType, title, message, map[string]interface{}{
+		"claim_id":     claim.ID,
+		"status":       claim.Status,
+		"total_amount": claim.TotalAmount,
+	})
+	go s.kafkaProducerService.Produce(kafkaNotificationMessage, "POST.NOTIFICATION", config.KafkaTopicNotification)
+}
+
+// find the expense category by id with its limits and convert the 'no rows' error to not found error
+func findExpenseCategory(c context.Context, expenseClaimRepository repository.ExpenseClaimRepository, id string) (domain.ExpenseCategory, error) {
+	category, err := expenseClaimRepository.FindCategoryById(c, id)
+	if err != nil {
+		if strings.Contains(err.Error(), "no rows") {
+			return domain.ExpenseCategory{}, exception.ErrNotFound(fmt.Sprintf("Expense category %s not found", id))
+		}
+		return domain.ExpenseCategory{}, err
+	}
+
+	return category, nil
+}
+
+// find the expense claim by id with its items and histories and convert the 'no rows' error to not found error
+func findExpenseClaim(c context.Context, expenseClaimRepository repository.ExpenseClaimRepository, id string) (domain.ExpenseClaim, error) {
+	claim, err := expenseClaimRepository.FindClaimById(c, id)
+	if err != nil {
+		if strings.Contains(err.Error(), "no rows") {
+			return domain.ExpenseClaim{}, exception.ErrNotFound(fmt.Sprintf("Expense claim %s not found", id))
+		}
+		return domain.ExpenseClaim{}, err
+	}
+
+	return claim, nil
+}
+
+// convert the 'no rows' error of the guarded update of the claim, the claim has been changed by another request in
+// the meantime, e.g. decided by another approver or submitted while an item is added
+func toExpenseClaimChangedError(err error) error {
+	if strings.Contains(err.Error(), "no rows") {
+		return exception.ErrConflict("Expense claim has been changed by another request, please reload it.")
+	}
+	return err
+}
+
+// a salary grade has at most one limit in the category
+func validateExpenseLimits(category domain.ExpenseCategory) error {
+	grades := map[string]bool{}
+	for _, limit := range category.Limits {
+		if grades[limit.SalaryGrade] {
+			return exception.ErrBadRequest(fmt.Sprintf("The limit of the salary grade %s is duplicated.", limit.SalaryGrade))
+		}
+		grades[limit.SalaryGrade] = true
+	}
+
+	return nil
+}
+
+// validate the size and the MIME type of the receipt, the MIME type is detected from the content of the file
+func validateReceipt(file web.DocumentFile) (string, error) {
+	if len(file.Data) == 0 {
+		return "", exception.ErrBadRequest("The receipt is empty.")
+	}
+	if len(file.Data) > config.ExpenseReceiptMaxSizeMB*1024*1024 {
+		return "", exception.ErrBadRequest(fmt.Sprintf("The receipt can't be larger than %d MB.", config.ExpenseReceiptMaxSizeMB))
+	}
+
+	mimeType, _, _ := strings.Cut(http.DetectContentType(file.Data), ";")
+	for _, allowedMimeType := range config.ExpenseReceiptAllowedMimeTypes {
+		if strings.TrimSpace(allowedMimeType) == mimeType {
+			return mimeType, nil
+		}
+	}
+
+	return "", exception.ErrBadRequest(fmt.Sprintf("The receipt type %s is not allowed.", mimeType))
+}
+
+// convert the unique constraint error of the 'expense_categories' table to bad request error
+func toExpenseCategoryUniqueError(err error) error {
+	if strings.Contains(err.Error(), "unique") && strings.Contains(err.Error(), "expense_categories_code_key") {
+		return exception.ErrBadRequest("Expense category code already exist.")
+	}
+	return err
+}
@@ -0,0 +1,558 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"embed"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/iqbaludinm/hr-microservice/user-service/config"
+	"github.com/iqbaludinm/hr-microservice/user-service/exception"
+	"github.com/iqbaludinm/hr-microservice/user-service/helper"
+	"github.com/iqbaludinm/hr-microservice/user-service/model/domain"
+	"github.com/iqbaludinm/hr-microservice/user-service/model/kafkamodel"
+	"github.com/iqbaludinm/hr-microservice/user-service/model/web"
+	"github.com/iqbaludinm/hr-microservice/user-service/repository"
+	"github.com/iqbaludinm/hr-microservice/user-service/service/producers"
+	"go.uber.org/zap"
+)
+
+// Type of the notifications produced by the handover service.
+const (
+	NotificationHandoverSignatureRequested = "HANDOVER_SIGNATURE_REQUESTED"
+	NotificationHandoverApproved           = "HANDOVER_APPROVED"
+	NotificationHandoverRejected           = "HANDOVER_REJECTED"
+)
+
+type HandoverService interface {
+	// With Transaction
+	CreateHandover(ctx context.Context, userID string, request web.HandoverRequest) (web.HandoverResponse, error)
+	UpdateHandover(ctx context.Context, userID, id string, request web.HandoverRequest) (web.HandoverResponse, error)
+	Submit(ctx context.Context, userID, id string) (web.HandoverResponse, error)
+	Sign(ctx context.Context, userID, id string, request web.SignHandoverRequest) (web.HandoverResponse, error)
+	Reject(ctx context.Context, userID, id string, request web.SignHandoverRequest) (web.HandoverResponse, error)
+	Cancel(ctx context.Context, userID, id string) (web.HandoverResponse, error)
+	Document(ctx context.Context, id string) ([]byte, string, error)
+
+	// Without Transaction
+	FindAllHandover(ctx context.Context, filter web.HandoverQueryFilter) ([]web.HandoverResponse, int, error)
+	FindPendingSignatures(ctx context.Context, userID string, filter web.HandoverQueryFilter) ([]web.HandoverResponse, int, error)
+	FindById(ctx context.Context, id string) (web.HandoverResponse, error)
+
+	// Approval Entity
+	ApprovalEntity
+}
+
+type handoverService struct {
+	handoverRepository   repository.HandoverRepository
+	employeeRepository   repository.EmployeeRepository
+	storage              helper.Storage
+	templateFS           embed.FS
+	pdfRenderer          helper.PDFRenderer
+	approvalService      ApprovalService
+	kafkaProducerService producers.KafkaProducerService
+	logger               *zap.SugaredLogger
+}
+
+func NewHandoverService(handoverRepository repository.HandoverRepository, employeeRepository repository.EmployeeRepository, storage helper.Storage, templateFS embed.FS, pdfRenderer helper.PDFRenderer, approvalService ApprovalService, kafkaProducerService producers.KafkaProducerService, logger *zap.SugaredLogger) HandoverService {
+	return &handoverService{
+		handoverRepository:   handoverRepository,
+		employeeRepository:   employeeRepository,
+		storage:              storage,
+		templateFS:           templateFS,
+		pdfRenderer:          pdfRenderer,
+		approvalService:      approvalService,
+		kafkaProducerService: kafkaProducerService,
+		logger:               logger,
+	}
+}
+
+func (s *handoverService) CreateHandover(c context.Context, userID string, request web.HandoverRequest) (web.HandoverResponse, error) {
+	// convert to domain or model handover
+	handover := domain.ToDomainHandover(request)
+	if err := validateHandover(c, s.employeeRepository, handover); err != nil {
+		return web.HandoverResponse{}, err
+	}
+
+	handover.ID = uuid.New().String()
+	handover.Status = domain.HandoverStatusDraft
+	handover.CreatedBy = &userID
+	handover.CreatedAt = time.Now()
+	handover.UpdatedAt = time.Now()
+
+	// call the repo for inserting to db, the document number is set by the repo
+	if err := s.handoverRepository.CreateHandover(c, &handover); err != nil {
+		s.logger.Infow(err.Error(), "Create Handover Error")
+		return web.HandoverResponse{}, err
+	}
+
+	newHandover, err := s.handoverRepository.FindById(c, handover.ID)
+	if err != nil {
+		return web.HandoverResponse{}, exception.ErrInternalServer(fmt.Sprintf("Successfully created handover, but failed to get the handover have created. Error: %s", err.Error()))
+	}
+
+	return newHandover.ToHandoverResponse(), nil
+}
+
+func (s *handoverService) UpdateHandover(c context.Context, userID, id string, request web.HandoverRequest) (web.HandoverResponse, error) {
+	handover, err := s.findCreatedHandover(c, userID, id)
+	if err != nil {
+		return web.HandoverResponse{}, err
+	}
+	if handover.Status != domain.HandoverStatusDraft {
+		return web.HandoverResponse{}, exception.ErrBadRequest("Only a draft handover can be updated.")
+	}
+
+	updated := domain.ToDomainHandover(request)
+	if err := validateHandover(c, s.employeeRepository, updated); err != nil {
+		return web.HandoverResponse{}, err
+	}
+	updated.ID = handover.ID
+	updated.UpdatedAt = time.Now()
+
+	if err := s.handoverRepository.UpdateHandover(c, updated); err != nil {
+		s.logger.Infow(err.Error(), "Update Handover Error")
+		return web.HandoverResponse{}, err
+	}
+
+	return s.FindById(c, id)
+}
+
+// submit the draft handover to the signers, the first signer is notified. The handover governed by the active
+// approval workflow of handover is signed after it is approved.
+func (s *handoverService) Submit(c context.Context, userID, id string) (web.HandoverResponse, error) {
+	handover, err := s.findCreatedHandover(c, userID, id)
+	if err != nil {
+		return web.HandoverResponse{}, err
+	}
+	if handover.Status != domain.HandoverStatusDraft {
+		return web.HandoverResponse{}, exception.ErrBadRequest(fmt.Sprintf("Handover is already %s.", handover.Status))
+	}
+
+	submittedAt := time.Now()
+	handover.Status = domain.HandoverStatusPending
+	handover.SubmittedAt = &submittedAt
+	handover.UpdatedAt = submittedAt
+
+	if err := s.updateStatus(c, handover, nil, domain.HandoverStatusDraft); err != nil {
+		s.logger.Infow(err.Error(), "Submit Handover Error")
+		return web.HandoverResponse{}, err
+	}
+
+	// the handover is moved back to draft when its approval can't be requested
+	governed, err := s.approvalService.Request(c, domain.ApprovalEntityHandover, handover.ID)
+	if err != nil {
+		s.logger.Infow(err.Error(), "Request Handover Approval Error")
+		handover.Status = domain.HandoverStatusDraft
+		handover.SubmittedAt = nil
+		handover.UpdatedAt = time.Now()
+		if err := s.updateStatus(c, handover, nil, domain.HandoverStatusPending); err != nil {
+			s.logger.Errorw("Withdraw Handover Error", "handover_id", handover.ID, "error", err.Error())
+		}
+		return web.HandoverResponse{}, err
+	}
+	if !governed {
+		s.notifyNextSigner(handover)
+	}
+
+	return handover.ToHandoverResponse(), nil
+}
+
+// sign the pending handover by its next signer. The handover is approved when the last signer signs, then the
+// signed document is generated and emailed to the BAST receivers.
+func (s *handoverService) Sign(c context.Context, userID, id string, request web.SignHandoverRequest) (web.HandoverResponse, error) {
+	handover, signer, err := s.findNextSigner(c, userID, id)
+	if err != nil {
+		return web.HandoverResponse{}, err
+	}
+
+	signedAt := time.Now()
+	signer.Status = domain.HandoverSignerSigned
+	signer.Note = request.Note
+	signer.SignedAt = &signedAt
+	handover.UpdatedAt = signedAt
+
+	if handover.NextSigner() == nil {
+		handover.Status = domain.HandoverStatusApproved
+		handover.CompletedAt = &signedAt
+	}
+
+	if err := s.updateStatus(c, handover, signer, domain.HandoverStatusPending); err != nil {
+		s.logger.Infow(err.Error(), "Sign Handover Error")
+		return web.HandoverResponse{}, err
+	}
+
+	if handover.Status != domain.HandoverStatusApproved {
+		s.notifyNextSigner(handover)
+		return handover.ToHandoverResponse(), nil
+	}
+
+	// the handover stays approved when the document fails, the document is generated again when it is downloaded
+	if err := s.email(c, &handover); err != nil {
+		s.logger.Infow(err.Error(), "Email Handover Document Error")
+	}
+
+	message := fmt.Sprintf("Handover %s has been signed by every signer.", handover.DocumentNumber)
+	s.notifyCreator(handover, NotificationHandoverApproved, "Handover Approved", message)
+
+	return handover.ToHandoverResponse(), nil
+}
+
+// reject the pending handover by its next signer, the handover can't be signed anymore
+func (s *handoverService) Reject(c context.Context, userID, id string, request web.SignHandoverRequest) (web.HandoverResponse, error) {
+	handover, signer, err := s.findNextSigner(c, userID, id)
+	if err != nil {
+		return web.HandoverResponse{}, err
+	}
+
+	rejectedAt := time.Now()
+	signer.Status = domain.HandoverSignerRejected
+	signer.Note = request.Note
+	signer.SignedAt = &rejectedAt
+	handover.Status = domain.HandoverStatusRejected
+	handover.CompletedAt = &rejectedAt
+	handover.UpdatedAt = rejectedAt
+
+	if err := s.updateStatus(c, handover, signer, domain.HandoverStatusPending); err != nil {
+		s.logger.Infow(err.Error(), "Reject Handover Error")
+		return web.HandoverResponse{}, err
+	}
+
+	message := fmt.Sprintf("Handover %s has been rejected by %s.", handover.DocumentNumber, signer.EmployeeName)
+	s.notifyCreator(handover, NotificationHandoverRejected, "Handover Rejected", message)
+
+	return handover.ToHandoverResponse(), nil
+}
+
+func (s *handoverService) Cancel(c context.Context, userID, id string) (web.HandoverResponse, error) {
+	handover, err := s.findCreatedHandover(c, userID, id)
+	if err != nil {
+		return web.HandoverResponse{}, err
+	}
+	if handover.Status != domain.HandoverStatusDraft && handover.Status != domain.HandoverStatusPending {
+		return web.HandoverResponse{}, exception.ErrBadRequest(fmt.Sprintf("Handover is already %s.", handover.Status))
+	}
+
+	cancelledAt := time.Now()
+	previousStatus := handover.Status
+	handover.Status = domain.HandoverStatusCancelled
+	handover.CancelledAt = &cancelledAt
+	handover.UpdatedAt = cancelledAt
+
+	if err := s.updateStatus(c, handover, nil, previousStatus); err != nil {
+		s.logger.Infow(err.Error(), "Cancel Handover Error")
+		return web.HandoverResponse{}, err
+	}
+	if previousStatus == domain.HandoverStatusPending {
+		if err := s.approvalService.CancelEntity(c, domain.ApprovalEntityHandover, handover.ID); err != nil {
+			s.logger.Errorw("Cancel Handover Approval Error", "handover_id", handover.ID, "error", err.Error())
+		}
+	}
+
+	return handover.ToHandoverResponse(), nil
+}
+
+// FindApprovalSubject finds the employee who created the pending handover, the handover has no amount
+func (s *handoverService) FindApprovalSubject(c context.Context, id string) (domain.ApprovalSubject, error) {
+	handover, err := findHandover(c, s.handoverRepository, id)
+	if err != nil {
+		return domain.ApprovalSubject{}, err
+	}
+	if handover.Status != domain.HandoverStatusPending {
+		return domain.ApprovalSubject{}, exception.ErrBadRequest(fmt.Sprintf("Handover is already %s.", handover.Status))
+	}
+	if handover.CreatedBy == nil {
+		return domain.ApprovalSubject{}, exception.ErrBadRequest("Handover has no creator to request its approval.")
+	}
+	creator, err := findEmployeeByUser(c, s.employeeRepository, *handover.CreatedBy)
+	if err != nil {
+		return domain.ApprovalSubject{}, err
+	}
+
+	return domain.ApprovalSubject{
+		RequesterID: creator.ID,
+		Title:       fmt.Sprintf("Handover %s", handover.DocumentNumber),
+	}, nil
+}
+
+// ApplyApproval sends the approved handover to its signers, the rejected or cancelled approval closes the handover
+func (s *handoverService) ApplyApproval(c context.Context, approval domain.ApprovalRequest) error {
+	handover, err := findHandover(c, s.handoverRepository, approval.EntityID)
+	if err != nil {
+		return err
+	}
+	if handover.Status != domain.HandoverStatusPending {
+		return exception.ErrBadRequest(fmt.Sprintf("Handover is already %s.", handover.Status))
+	}
+
+	now := time.Now()
+	switch approval.Status {
+	case domain.ApprovalStatusApproved:
+		s.notifyNextSigner(handover)
+		return nil
+	case domain.ApprovalStatusRejected:
+		handover.Status = domain.HandoverStatusRejected
+		handover.CompletedAt = &now
+	default:
+		handover.Status = domain.HandoverStatusCancelled
+		handover.CancelledAt = &now
+	}
+	handover.UpdatedAt = now
+
+	return s.updateStatus(c, handover, nil, domain.HandoverStatusPending)
+}
+
+// update the handover which still has the given status, the 'no rows' error means the handover or the signer has
+// been changed by another request in the meantime
+func (s *handoverService) updateStatus(c context.Context, handover domain.Handover, signer *domain.HandoverSigner, status string) error {
+	if err := s.handoverRepository.UpdateStatus(c, handover, signer, status); err != nil {
+		if strings.Contains(err.Error(), "no rows") {
+			return exception.ErrConflict("Handover has been changed by another request, please reload it.")
+		}
+		return err
+	}
+
+	return nil
+}
+
+// get the signed document of the approved handover, it is generated when it is not in the storage yet
+func (s *handoverService) Document(c context.Context, id string) ([]byte, string, error) {
+	handover, err := findHandover(c, s.handoverRepository, id)
+	if err != nil {
+		return nil, "", err
+	}
+	if handover.Status != domain.HandoverStatusApproved {
+		return nil, "", exception.ErrBadRequest("The document is only available for an approved handover.")
+	}
+
+	pdf, err := s.document(c, &handover)
+	if err != nil {
+		s.logger.Infow(err.Error(), "Generate Handover Document Error")
+		return nil, "", err
+	}
+
+	return pdf.Bytes(), strings.ReplaceAll(handover.DocumentNumber, "/", "-") + ".pdf", nil
+}
+
+func (s *handoverService) FindAllHandover(c context.Context, filter web.HandoverQueryFilter) ([]web.HandoverResponse, int, error) {
+	return s.findAllHandover(c, domain.ToDomainHandoverQueryFilter(filter))
+}
+
+// find the pending handovers where the logged in user is the next signer
+func (s *handoverService) FindPendingSignatures(c context.Context, userID string, filter web.HandoverQueryFilter) ([]web.HandoverResponse, int, error) {
+	signer, err := findEmployeeByUser(c, s.employeeRepository, userID)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	domainFilter := domain.ToDomainHandoverQueryFilter(filter)
+	domainFilter.SignerID = signer.ID
+
+	return s.findAllHandover(c, domainFilter)
+}
+
+func (s *handoverService) FindById(c context.Context, id string) (web.HandoverResponse, error) {
+	handover, err := findHandover(c, s.handoverRepository, id)
+	if err != nil {
+		return web.HandoverResponse{}, err
+	}
+
+	return handover.ToHandoverResponse(), nil
+}
+
+// validate the employees of the handover and its signers exist, an employee can only sign the handover once. It
+// is shared with the services that produce a handover document.
+func validateHandover(c context.Context, employeeRepository repository.EmployeeRepository, handover domain.Handover) error {
+	if handover.FromEmployeeID == handover.ToEmployeeID {
+		return exception.ErrBadRequest("Handover can't be made to the same employee.")
+	}
+	if _, err := findEmployee(c, employeeRepository, handover.FromEmployeeID); err != nil {
+		return err
+	}
+	if _, err := findEmployee(c, employeeRepository, handover.ToEmployeeID); err != nil {
+		return err
+	}
+
+	signers := map[string]bool{}
+	for _, signer := range handover.Signers {
+		if signers[signer.EmployeeID] {
+			return exception.ErrBadRequest(fmt.Sprintf("Signer %s is repeated.", signer.EmployeeID))
+		}
+		signers[signer.EmployeeID] = true
+
+		if _, err := findEmployee(c, employeeRepository, signer.EmployeeID); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// find the handover and its next signer, only the next signer can sign or reject the handover
+func (s *handoverService) findNextSigner(c context.Context, userID, id string) (domain.Handover, *domain.HandoverSigner, error) {
+	handover, err := findHandover(c, s.handoverRepository, id)
+	if err != nil {
+		return domain.Handover{}, nil, err
+	}
+	if handover.Status != domain.HandoverStatusPending {
+		return domain.Handover{}, nil, exception.ErrBadRequest(fmt.Sprintf("Handover is %s.", handover.Status))
+	}
+
+	// the handover is signed after its pending approval is approved
+	governed, err := s.approvalService.IsPending(c, domain.ApprovalEntityHandover, handover.ID)
+	if err != nil {
+		return domain.Handover{}, nil, err
+	}
+	if governed {
+		return domain.Handover{}, nil, exception.ErrBadRequest("Handover is waiting for its approval.")
+	}
+
+	employee, err := findEmployeeByUser(c, s.employeeRepository, userID)
+	if err != nil {
+		return domain.Handover{}, nil, err
+	}
+
+	signer := handover.NextSigner()
+	if signer == nil || signer.EmployeeID != employee.ID {
+		return domain.Handover{}, nil, exception.ErrUnauthorized("Only the next signer can sign the handover.")
+	}
+
+	return handover, signer, nil
+}
+
+// find the handover created by the logged in user, only the creator can update, submit or cancel the handover
+func (s *handoverService) findCreatedHandover(c context.Context, userID, id string) (domain.Handover, error) {
+	handover, err := findHandover(c, s.handoverRepository, id)
+	if err != nil {
+		return domain.Handover{}, err
+	}
+	if handover.CreatedBy == nil || *handover.CreatedBy != userID {
+		return domain.Handover{}, exception.ErrUnauthorized("Only the creator can change the handover.")
+	}
+
+	return handover, nil
+}
+
+// find the handover by id and convert the 'no rows' error to not found error
+func findHandover(c context.Context, handoverRepository repository.HandoverRepository, id string) (domain.Handover, error) {
+	handover, err := handoverRepository.FindById(c, id)
+	if err != nil {
+		if strings.Contains(err.Error(), "no rows") {
+			return domain.Handover{}, exception.ErrNotFound(fmt.Sprintf("Handover %s not found", id))
+		}
+		return domain.Handover{}, err
+	}
+
+	return handover, nil
+}
+
+func (s *handoverService) findAllHandover(c context.Context, filter domain.HandoverQueryFilter) (result []web.HandoverResponse, totalData int, err error) {
+	handovers, err := s.handoverRepository.FindAllHandover(c, filter)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	// get total-data
+	totalData, err = s.handoverRepository.CountAllHandover(c, filter)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	// convert to web.HandoverResponse
+	result = []web.HandoverResponse{}
+	for _, handover := range handovers {
+		result = append(result, handover.ToHandoverResponse())
+	}
+
+	return result, totalData, nil
+}
+
+// return the signed document of the handover from the storage, the document is rendered with the 'bast.html'
+// template and saved to the storage when it is not generated yet
+func (s *handoverService) document(c context.Context, handover *domain.Handover) (bytes.Buffer, error) {
+	if handover.DocumentKey != nil {
+		pdf, err := s.storage.Get(c, *handover.DocumentKey)
+		if err == nil {
+			return *bytes.NewBuffer(pdf), nil
+		}
+		if !errors.Is(err, helper.ErrObjectNotFound) {
+			return bytes.Buffer{}, err
+		}
+	}
+
+	handoverType := "Aset"
+	if handover.Type == domain.HandoverTypeEmployee {
+		handoverType = "Pekerjaan"
+	}
+	data := map[string]interface{}{
+		"Handover":  handover,
+		"Type":      handoverType,
+		"Date":      helper.ParseTimeToFullIndonesian(handover.HandoverDate),
+		"PrintedAt": helper.ParseTimeToFullIndonesian(helper.Today()),
+	}
+
+	pdf, err := helper.RenderPDF(c, s.pdfRenderer, s.templateFS, "bast.html", helper.PDFOptions{PaperSize: "A4"}, data)
+	if err != nil {
+		return bytes.Buffer{}, err
+	}
+
+	key := handover.NewDocumentKey()
+	if err := s.storage.Put(c, key, pdf.Bytes()); err != nil {
+		return bytes.Buffer{}, err
+	}
+
+	handover.DocumentKey = &key
+	if err := s.handoverRepository.UpdateDocument(c, *handover); err != nil {
+		return bytes.Buffer{}, err
+	}
+
+	return pdf, nil
+}
+
+// generate the signed document and email it to the BAST receivers
+func (s *handoverService) email(c context.Context, handover *domain.Handover) error {
+	pdf, err := s.document(c, handover)
+	if err != nil {
+		return err
+	}
+	if err := helper.EmailSender(pdf); err != nil {
+		return err
+	}
+
+	emailedAt := time.Now()
+	handover.EmailedAt = &emailedAt
+
+	return s.handoverRepository.UpdateDocument(c, *handover)
+}
+
+// notify the next signer there is a handover to be signed
+func (s *handoverService) notifyNextSigner(handover domain.Handover) {
+	signer := handover.NextSigner()
+	if signer == nil {
+		return
+	}
+
+	message := fmt.Sprintf("Handover %s (%s) is waiting for your signature as '%s'.", handover.DocumentNumber, handover.Title, signer.Role)
+	s.notify(signer.UserID, NotificationHandoverSignatureRequested, "Handover Signature Requested", message, handover)
+}
+
+func (s *handoverService) notifyCreator(handover domain.Handover, notificationType, title, message string) {
+	if handover.CreatedBy == nil {
+		return
+	}
+	s.notify(*handover.CreatedBy, notificationType, title, message, handover)
+}
+
+// produce the notification of the handover to the user
+func (s *handoverService) notify(userID, notificationType, title, message string, handover domain.Handover) {
+	kafkaNotificationMessage := kafkamodel.NewKafkaNotificationMessage(userID, notificationType, title, message, map[string]interface{}{
+		"handover_id":     handover.ID,
+		"document_number": handover.DocumentNumber,
+		"status":          handover.Status,
+	})
+	go s.kafkaProducerService.Produce(kafkaNotificationMessage, "POST.NOTIFICATION", config.KafkaTopicNotification)
+}
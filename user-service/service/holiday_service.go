@@ -0,0 +1,281 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/iqbaludinm/hr-microservice/user-service/exception"
+	"github.com/iqbaludinm/hr-microservice/user-service/helper"
+	"github.com/iqbaludinm/hr-microservice/user-service/model/domain"
+	"github.com/iqbaludinm/hr-microservice/user-service/model/web"
+	"github.com/iqbaludinm/hr-microservice/user-service/repository"
+	"go.uber.org/zap"
+)
+
+type HolidayService interface {
+	// With Transaction
+	CreateHoliday(ctx context.Context, request web.CreateHolidayRequest) (web.HolidayResponse, error)
+	ImportHolidays(ctx context.Context, request web.ImportHolidayRequest, file *multipart.FileHeader) (web.ImportHolidayResponse, error)
+	Delete(ctx context.Context, id string) error
+
+	// Without Transaction
+	FindAllHoliday(ctx context.Context, filter web.HolidayQueryFilter) ([]web.HolidayResponse, error)
+}
+
+type holidayService struct {
+	holidayRepository      repository.HolidayRepository
+	workCalendarRepository repository.WorkCalendarRepository
+	logger                 *zap.SugaredLogger
+}
+
+func NewHolidayService(holidayRepository repository.HolidayRepository, workCalendarRepository repository.WorkCalendarRepository, logger *zap.SugaredLogger) HolidayService {
+	return &holidayService{
+		holidayRepository:      holidayRepository,
+		workCalendarRepository: workCalendarRepository,
+		logger:                 logger,
+	}
+}
+
+func (s *holidayService) CreateHoliday(c context.Context, request web.CreateHolidayRequest) (web.HolidayResponse, error) {
+	workCalendarID, err := s.findWorkCalendarID(c, request.WorkCalendarID)
+	if err != nil {
+		return web.HolidayResponse{}, err
+	}
+	date, _ := helper.ParseDate(request.Date)
+
+	// convert to domain or model holiday
+	holiday := domain.Holiday{
+		ID:             uuid.New().String(),
+		WorkCalendarID: workCalendarID,
+		Date:           date,
+		Name:           request.Name,
+		Type:           toHolidayType(request.Type, request.Name),
+		CreatedAt:      time.Now(),
+		UpdatedAt:      time.Now(),
+	}
+
+	// call the repo for inserting to db
+	if err := s.holidayRepository.CreateHoliday(c, holiday); err != nil {
+		s.logger.Infow(err.Error(), "Create Holiday Error")
+		return web.HolidayResponse{}, toHolidayUniqueError(err)
+	}
+
+	return holiday.ToHolidayResponse(), nil
+}
+
+func (s *holidayService) ImportHolidays(c context.Context, request web.ImportHolidayRequest, file *multipart.FileHeader) (web.ImportHolidayResponse, error) {
+	if file == nil {
+		return web.ImportHolidayResponse{}, exception.ErrBadRequest("Upload the .ics or .csv file as the 'file' of the form.")
+	}
+	workCalendarID, err := s.findWorkCalendarID(c, request.WorkCalendarID)
+	if err != nil {
+		return web.ImportHolidayResponse{}, err
+	}
+
+	src, err := file.Open()
+	if err != nil {
+		return web.ImportHolidayResponse{}, err
+	}
+	defer src.Close()
+	data, err := io.ReadAll(src)
+	if err != nil {
+		return web.ImportHolidayResponse{}, err
+	}
+
+	var holidays []domain.Holiday
+	switch strings.ToLower(filepath.Ext(file.Filename)) {
+	case ".ics":
+		holidays, err = parseHolidayICalendar(data, request.Type)
+	case ".csv":
+		holidays, err = parseHolidayCSV(data, request.Type)
+	default:
+		return web.ImportHolidayResponse{}, exception.ErrBadRequest("Only .ics and .csv files can be imported.")
+	}
+	if err != nil {
+		return web.ImportHolidayResponse{}, err
+	}
+	if len(holidays) == 0 {
+		return web.ImportHolidayResponse{}, exception.ErrBadRequest("The file has no holidays.")
+	}
+
+	// the last day of the file wins when the date is repeated
+	now := time.Now()
+	byDate := map[string]int{}
+	result := []domain.Holiday{}
+	for _, holiday := range holidays {
+		holiday.ID = uuid.New().String()
+		holiday.WorkCalendarID = workCalendarID
+		holiday.CreatedAt = now
+		holiday.UpdatedAt = now
+
+		key := holiday.Date.Format(helper.DateLayout)
+		if i, ok := byDate[key]; ok {
+			result[i] = holiday
+			continue
+		}
+		byDate[key] = len(result)
+		result = append(result, holiday)
+	}
+
+	if err := s.holidayRepository.ImportHolidays(c, result); err != nil {
+		s.logger.Infow(err.Error(), "Import Holidays Error")
+		return web.ImportHolidayResponse{}, err
+	}
+
+	// convert to web.ImportHolidayResponse
+	response := web.ImportHolidayResponse{Imported: len(result), Holidays: []web.HolidayResponse{}}
+	for _, holiday := range result {
+		response.Holidays = append(response.Holidays, holiday.ToHolidayResponse())
+	}
+
+	return response, nil
+}
+
+func (s *holidayService) Delete(c context.Context, id string) error {
+	if _, err := s.holidayRepository.FindById(c, id); err != nil {
+		if strings.Contains(err.Error(), "no rows") {
+			return exception.ErrNotFound(fmt.Sprintf("Holiday %s not found", id))
+		}
+		return err
+	}
+
+	return s.holidayRepository.Delete(c, id)
+}
+
+func (s *holidayService) FindAllHoliday(c context.Context, filter web.HolidayQueryFilter) ([]web.HolidayResponse, error) {
+	year := filter.Year
+	if year == 0 {
+		year = helper.Today().Year()
+	}
+
+	holidays, err := s.holidayRepository.FindAllHoliday(c, domain.HolidayQueryFilter{
+		From:           time.Date(year, 1, 1, 0, 0, 0, 0, time.UTC),
+		To:             time.Date(year, 12, 31, 0, 0, 0, 0, time.UTC),
+		WorkCalendarID: filter.WorkCalendarID,
+		Type:           filter.Type,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// convert to web.HolidayResponse
+	result := []web.HolidayResponse{}
+	for _, holiday := range holidays {
+		result = append(result, holiday.ToHolidayResponse())
+	}
+
+	return result, nil
+}
+
+// find the work calendar of the holiday, the empty id is the national day
+func (s *holidayService) findWorkCalendarID(c context.Context, id string) (*string, error) {
+	if id == "" {
+		return nil, nil
+	}
+	if _, err := findWorkCalendar(c, s.workCalendarRepository, id); err != nil {
+		return nil, err
+	}
+	return &id, nil
+}
+
+// parse the all-day events of the iCalendar file, an event spanning several days is imported as a holiday per day
+func parseHolidayICalendar(data []byte, holidayType string) ([]domain.Holiday, error) {
+	events, err := helper.ParseICalendar(data)
+	if err != nil {
+		return nil, exception.ErrBadRequest(fmt.Sprintf("Invalid iCalendar file, %s.", err.Error()))
+	}
+
+	holidays := []domain.Holiday{}
+	for _, event := range events {
+		// the timed event is a holiday on the date it starts in WIB
+		start, end := event.Start, event.End
+		if !event.AllDay {
+			y, m, d := event.Start.In(helper.WIB).Date()
+			start = time.Date(y, m, d, 0, 0, 0, 0, time.UTC)
+			end = start.AddDate(0, 0, 1)
+		}
+
+		for date := start; date.Before(end); date = date.AddDate(0, 0, 1) {
+			holidays = append(holidays, domain.Holiday{
+				Date: date,
+				Name: event.Summary,
+				Type: toHolidayType(holidayType, event.Summary),
+			})
+		}
+	}
+
+	return holidays, nil
+}
+
+// parse the 'date,name,type' rows of the CSV file, the header row and the type column are optional
+func parseHolidayCSV(data []byte, holidayType string) ([]domain.Holiday, error) {
+	reader := csv.NewReader(bytes.NewReader(bytes.TrimPrefix(data, []byte("\xef\xbb\xbf"))))
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+	rows, err := reader.ReadAll()
+	if err != nil {
+		return nil, exception.ErrBadRequest(fmt.Sprintf("Invalid CSV file, %s.", err.Error()))
+	}
+
+	holidays := []domain.Holiday{}
+	for i, row := range rows {
+		if i == 0 && len(row) > 0 && strings.EqualFold(strings.TrimSpace(row[0]), "date") {
+			continue
+		}
+		if len(row) < 2 {
+			return nil, exception.ErrBadRequest(fmt.Sprintf("Row %d must have the date and the name.", i+1))
+		}
+
+		date, err := helper.ParseDate(strings.TrimSpace(row[0]))
+		if err != nil || date.IsZero() {
+			return nil, exception.ErrBadRequest(fmt.Sprintf("Row %d has an invalid date, use the %s format.", i+1, helper.DateLayout))
+		}
+		name := strings.TrimSpace(row[1])
+		if name == "" {
+			return nil, exception.ErrBadRequest(fmt.Sprintf("Row %d has no name.", i+1))
+		}
+
+		rowType := holidayType
+		if len(row) > 2 && strings.TrimSpace(row[2]) != "" {
+			rowType = strings.ToLower(strings.TrimSpace(row[2]))
+			if rowType != domain.HolidayTypePublicHoliday && rowType != domain.HolidayTypeCollectiveLeave && rowType != domain.HolidayTypeWorkingDay {
+				return nil, exception.ErrBadRequest(fmt.Sprintf("Row %d has an invalid type %q.", i+1, rowType))
+			}
+		}
+
+		holidays = append(holidays, domain.Holiday{
+			Date: date,
+			Name: name,
+			Type: toHolidayType(rowType, name),
+		})
+	}
+
+	return holidays, nil
+}
+
+// the type of the holiday is detected from the name when it is empty, the collective leave is named "cuti bersama"
+func toHolidayType(holidayType, name string) string {
+	if holidayType != "" {
+		return holidayType
+	}
+	if strings.Contains(strings.ToLower(name), "cuti bersama") {
+		return domain.HolidayTypeCollectiveLeave
+	}
+	return domain.HolidayTypePublicHoliday
+}
+
+// convert the unique constraint error of the 'public_holidays' table to bad request error
+func toHolidayUniqueError(err error) error {
+	if strings.Contains(err.Error(), "unique") && strings.Contains(err.Error(), "public_holidays_calendar_date_key") {
+		return exception.ErrBadRequest("Holiday already exist on the date.")
+	}
+	return err
+}
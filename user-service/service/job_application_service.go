@@ -0,0 +1,957 @@
+package service
+
+import (
+	"context"
+	"embed"
+	"errors"
+	"fmt"
+	"net/http"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/iqbaludinm/hr-microservice/user-service/config"
+	"github.com/iqbaludinm/hr-microservice/user-service/exception"
+	"github.com/iqbaludinm/hr-microservice/user-service/helper"
+	"github.com/iqbaludinm/hr-microservice/user-service/model/domain"
+	"github.com/iqbaludinm/hr-microservice/user-service/model/kafkamodel"
+	"github.com/iqbaludinm/hr-microservice/user-service/model/web"
+	"github.com/iqbaludinm/hr-microservice/user-service/repository"
+	"github.com/iqbaludinm/hr-microservice/user-service/service/producers"
+	"github.com/thanhpk/randstr"
+	"go.uber.org/zap"
+)
+
+// Type of the notifications produced by the job application service.
+const (
+	NotificationJobApplicationReceived = "JOB_APPLICATION_RECEIVED"
+	NotificationInterviewScheduled     = "INTERVIEW_SCHEDULED"
+)
+
+// the employment type of the requisition printed in the offer letter
+var employmentTypeLabels = map[string]string{
+	domain.EmploymentTypePermanent:  "Karyawan Tetap (PKWTT)",
+	domain.EmploymentTypeContract:   "Karyawan Kontrak (PKWT)",
+	domain.EmploymentTypeProbation:  "Masa Percobaan",
+	domain.EmploymentTypeInternship: "Magang",
+	domain.EmploymentTypeOutsource:  "Alih Daya",
+}
+
+type JobApplicationService interface {
+	// With Transaction
+	Apply(ctx context.Context, postingID string, request web.JobApplicationRequest, file web.DocumentFile) error
+	CreateStage(ctx context.Context, request web.RecruitmentStageRequest) (web.RecruitmentStageResponse, error)
+	UpdateStage(ctx context.Context, id string, request web.RecruitmentStageRequest) (web.RecruitmentStageResponse, error)
+	DeleteStage(ctx context.Context, id string) error
+	MoveStage(ctx context.Context, userID, id string, request web.MoveJobApplicationRequest) (web.JobApplicationResponse, error)
+	Close(ctx context.Context, id string, request web.CloseJobApplicationRequest) (web.JobApplicationResponse, error)
+	ScheduleInterview(ctx context.Context, userID, id string, request web.InterviewRequest) (web.InterviewResponse, error)
+	SubmitScorecard(ctx context.Context, userID, interviewID string, request web.InterviewScorecardRequest) (web.InterviewResponse, error)
+	CancelInterview(ctx context.Context, interviewID string) (web.InterviewResponse, error)
+	CreateOffer(ctx context.Context, userID, id string, request web.JobOfferRequest) (web.JobOfferResponse, error)
+	SendOffer(ctx context.Context, offerID string) (web.JobOfferResponse, error)
+	RespondOffer(ctx context.Context, offerID string, request web.JobOfferResponseRequest) (web.JobOfferResponse, error)
+	CancelOffer(ctx context.Context, offerID string) (web.JobOfferResponse, error)
+	Hire(ctx context.Context, id string, request web.HireRequest) (web.JobApplicationResponse, error)
+
+	// Without Transaction
+	FindAllStage(ctx context.Context) ([]web.RecruitmentStageResponse, error)
+	FindAll(ctx context.Context, filter web.JobApplicationQueryFilter) ([]web.JobApplicationResponse, int, error)
+	FindById(ctx context.Context, id string) (web.JobApplicationResponse, error)
+	CV(ctx context.Context, id string) ([]byte, string, string, error)
+	FindMyInterviews(ctx context.Context, userID string) ([]web.InterviewResponse, error)
+	OfferDocument(ctx context.Context, offerID string) ([]byte, string, error)
+}
+
+type jobApplicationService struct {
+	jobApplicationRepository repository.JobApplicationRepository
+	jobRequisitionRepository repository.JobRequisitionRepository
+	employeeRepository       repository.EmployeeRepository
+	userRepository           repository.UserRepository
+	onboardingRepository     repository.OnboardingRepository
+	storage                  helper.Storage
+	templateFS               embed.FS
+	pdfRenderer              helper.PDFRenderer
+	kafkaProducerService     producers.KafkaProducerService
+	logger                   *zap.SugaredLogger
+}
+
+func NewJobApplicationService(jobApplicationRepository repository.JobApplicationRepository, jobRequisitionRepository repository.JobRequisitionRepository, employeeRepository repository.EmployeeRepository, userRepository repository.UserRepository, onboardingRepository repository.OnboardingRepository, storage helper.Storage, templateFS embed.FS, pdfRenderer helper.PDFRenderer, kafkaProducerService producers.KafkaProducerService, logger *zap.SugaredLogger) JobApplicationService {
+	return &jobApplicationService{
+		jobApplicationRepository: jobApplicationRepository,
+		jobRequisitionRepository: jobRequisitionRepository,
+		employeeRepository:       employeeRepository,
+		userRepository:           userRepository,
+		onboardingRepository:     onboardingRepository,
+		storage:                  storage,
+		templateFS:               templateFS,
+		pdfRenderer:              pdfRenderer,
+		kafkaProducerService:     kafkaProducerService,
+		logger:                   logger,
+	}
+}
+
+// apply for the open posting from the public careers page. The candidate is identified by the email, the application
+// starts at the first stage and the requester of the requisition is notified.
+func (s *jobApplicationService) Apply(c context.Context, postingID string, request web.JobApplicationRequest, file web.DocumentFile) error {
+	posting, err := findOpenJobPosting(c, s.jobRequisitionRepository, postingID)
+	if err != nil {
+		return err
+	}
+
+	mimeType, err := validateCV(file)
+	if err != nil {
+		return err
+	}
+
+	stage, err := s.jobApplicationRepository.FindFirstStage(c)
+	if err != nil {
+		if strings.Contains(err.Error(), "no rows") {
+			return exception.ErrBadRequest("The recruitment stages are not configured yet.")
+		}
+		return err
+	}
+
+	email := strings.ToLower(strings.TrimSpace(request.Email))
+	candidate, err := s.jobApplicationRepository.FindCandidateByEmail(c, email)
+	newCandidate := false
+	if err != nil {
+		if !strings.Contains(err.Error(), "no rows") {
+			return err
+		}
+		newCandidate = true
+		candidate = domain.Candidate{
+			ID:        uuid.New().String(),
+			Email:     email,
+			CreatedAt: time.Now(),
+		}
+	}
+	// the name and the phone of the candidate are taken from the latest application
+	candidate.Name = request.Name
+	candidate.Phone = request.Phone
+	candidate.UpdatedAt = time.Now()
+
+	application := domain.JobApplication{
+		ID:          uuid.New().String(),
+		PostingID:   posting.ID,
+		CandidateID: candidate.ID,
+		StageID:     stage.ID,
+		Status:      domain.JobApplicationActive,
+		CoverLetter: request.CoverLetter,
+		CVFileName:  filepath.Base(file.Name),
+		CVMimeType:  mimeType,
+		CVSize:      int64(len(file.Data)),
+		CreatedAt:   time.Now(),
+		UpdatedAt:   time.Now(),
+	}
+	application.CVStorageKey = application.NewCVStorageKey(application.CVFileName)
+
+	move := domain.JobApplicationMove{
+		ID:            uuid.New().String(),
+		ApplicationID: application.ID,
+		ToStageID:     stage.ID,
+		MovedAt:       time.Now(),
+	}
+
+	if err := s.storage.Put(c, application.CVStorageKey, file.Data); err != nil {
+		s.logger.Infow(err.Error(), "Store CV Error")
+		return err
+	}
+
+	// call the repo for inserting to db
+	if err := s.jobApplicationRepository.Apply(c, candidate, newCandidate, application, move); err != nil {
+		s.logger.Infow(err.Error(), "Create Job Application Error")
+		_ = s.storage.Delete(c, application.CVStorageKey)
+		if strings.Contains(err.Error(), "unique") && strings.Contains(err.Error(), "job_applications_posting_id_candidate_id_key") {
+			return exception.ErrBadRequest("You have already applied for this position.")
+		}
+		return err
+	}
+
+	kafkaNotificationMessage := kafkamodel.NewKafkaNotificationMessage(posting.RequestedBy, NotificationJobApplicationReceived, "Job Application Received",
+		fmt.Sprintf("%s applied for %s.", candidate.Name, posting.Title), map[string]interface{}{
+			"application_id": application.ID,
+			"posting_id":     posting.ID,
+		})
+	go s.kafkaProducerService.Produce(kafkaNotificationMessage, "POST.NOTIFICATION", config.KafkaTopicNotification)
+
+	return nil
+}
+
+func (s *jobApplicationService) CreateStage(c context.Context, request web.RecruitmentStageRequest) (web.RecruitmentStageResponse, error) {
+	stage := domain.RecruitmentStage{
+		ID:        uuid.New().String(),
+		Name:      request.Name,
+		Seq:       request.Seq,
+		CreatedAt: time.Now(),
+		UpdatedAt: time.Now(),
+	}
+
+	// call the repo for inserting to db
+	if err := s.jobApplicationRepository.CreateStage(c, stage); err != nil {
+		s.logger.Infow(err.Error(), "Create Recruitment Stage Error")
+		return web.RecruitmentStageResponse{}, toRecruitmentStageUniqueError(err)
+	}
+
+	return stage.ToRecruitmentStageResponse(), nil
+}
+
+func (s *jobApplicationService) UpdateStage(c context.Context, id string, request web.RecruitmentStageRequest) (web.RecruitmentStageResponse, error) {
+	stage, err := findRecruitmentStage(c, s.jobApplicationRepository, id)
+	if err != nil {
+		return web.RecruitmentStageResponse{}, err
+	}
+
+	stage.Name = request.Name
+	stage.Seq = request.Seq
+	stage.UpdatedAt = time.Now()
+
+	if err := s.jobApplicationRepository.UpdateStage(c, stage); err != nil {
+		s.logger.Infow(err.Error(), "Update Recruitment Stage Error")
+		return web.RecruitmentStageResponse{}, toRecruitmentStageUniqueError(err)
+	}
+
+	return stage.ToRecruitmentStageResponse(), nil
+}
+
+// delete the stage which has never been used by an application
+func (s *jobApplicationService) DeleteStage(c context.Context, id string) error {
+	if _, err := findRecruitmentStage(c, s.jobApplicationRepository, id); err != nil {
+		return err
+	}
+
+	count, err := s.jobApplicationRepository.CountStageUsage(c, id)
+	if err != nil {
+		return err
+	}
+	if count > 0 {
+		return exception.ErrBadRequest("Recruitment stage is already used by the job applications.")
+	}
+
+	return s.jobApplicationRepository.DeleteStage(c, id)
+}
+
+// move the active application to another stage, the move is recorded in the history of the application
+func (s *jobApplicationService) MoveStage(c context.Context, userID, id string, request web.MoveJobApplicationRequest) (web.JobApplicationResponse, error) {
+	application, err := findJobApplication(c, s.jobApplicationRepository, id)
+	if err != nil {
+		return web.JobApplicationResponse{}, err
+	}
+	if application.Status != domain.JobApplicationActive {
+		return web.JobApplicationResponse{}, exception.ErrBadRequest(fmt.Sprintf("Job application is already %s.", application.Status))
+	}
+	if application.StageID == request.StageID {
+		return web.JobApplicationResponse{}, exception.ErrBadRequest("Job application is already in the stage.")
+	}
+
+	stage, err := findRecruitmentStage(c, s.jobApplicationRepository, request.StageID)
+	if err != nil {
+		return web.JobApplicationResponse{}, err
+	}
+
+	fromStageID := application.StageID
+	move := domain.JobApplicationMove{
+		ID:            uuid.New().String(),
+		ApplicationID: application.ID,
+		FromStageID:   &fromStageID,
+		ToStageID:     stage.ID,
+		Note:          request.Note,
+		MovedBy:       &userID,
+		MovedAt:       time.Now(),
+	}
+	application.StageID = stage.ID
+	application.UpdatedAt = time.Now()
+
+	if err := s.jobApplicationRepository.MoveStage(c, application, move); err != nil {
+		s.logger.Infow(err.Error(), "Move Job Application Error")
+		return web.JobApplicationResponse{}, err
+	}
+
+	return s.FindById(c, id)
+}
+
+// reject the active application or withdraw it on behalf of the candidate
+func (s *jobApplicationService) Close(c context.Context, id string, request web.CloseJobApplicationRequest) (web.JobApplicationResponse, error) {
+	application, err := findJobApplication(c, s.jobApplicationRepository, id)
+	if err != nil {
+		return web.JobApplicationResponse{}, err
+	}
+	if application.Status != domain.JobApplicationActive {
+		return web.JobApplicationResponse{}, exception.ErrBadRequest(fmt.Sprintf("Job application is already %s.", application.Status))
+	}
+
+	application.Status = request.Status
+	application.RejectionReason = request.Reason
+	application.UpdatedAt = time.Now()
+
+	if err := s.jobApplicationRepository.UpdateApplicationStatus(c, application); err != nil {
+		s.logger.Infow(err.Error(), "Close Job Application Error")
+		return web.JobApplicationResponse{}, err
+	}
+
+	return s.FindById(c, id)
+}
+
+// schedule the interview of the active application in its current stage. The interviewer is notified and the
+// candidate is invited by email.
+func (s *jobApplicationService) ScheduleInterview(c context.Context, userID, id string, request web.InterviewRequest) (web.InterviewResponse, error) {
+	application, err := findJobApplication(c, s.jobApplicationRepository, id)
+	if err != nil {
+		return web.InterviewResponse{}, err
+	}
+	if application.Status != domain.JobApplicationActive {
+		return web.InterviewResponse{}, exception.ErrBadRequest(fmt.Sprintf("Job application is already %s.", application.Status))
+	}
+
+	interviewer, err := findEmployee(c, s.employeeRepository, request.InterviewerID)
+	if err != nil {
+		return web.InterviewResponse{}, err
+	}
+	if interviewer.Status != domain.EmployeeStatusActive {
+		return web.InterviewResponse{}, exception.ErrBadRequest("The interviewer must be an active employee.")
+	}
+
+	scheduledAt, _ := time.ParseInLocation("2006-01-02 15:04", request.ScheduledAt, helper.WIB)
+	if !scheduledAt.After(time.Now()) {
+		return web.InterviewResponse{}, exception.ErrBadRequest("The interview must be scheduled in the future.")
+	}
+
+	interview := domain.Interview{
+		ID:              uuid.New().String(),
+		ApplicationID:   application.ID,
+		StageID:         application.StageID,
+		InterviewerID:   interviewer.ID,
+		ScheduledAt:     scheduledAt,
+		DurationMinutes: request.DurationMinutes,
+		Location:        request.Location,
+		Status:          domain.InterviewScheduled,
+		CreatedBy:       &userID,
+		CreatedAt:       time.Now(),
+		UpdatedAt:       time.Now(),
+	}
+
+	// call the repo for inserting to db
+	if err := s.jobApplicationRepository.CreateInterview(c, interview); err != nil {
+		s.logger.Infow(err.Error(), "Schedule Interview Error")
+		return web.InterviewResponse{}, err
+	}
+
+	newInterview, err := s.jobApplicationRepository.FindInterviewById(c, interview.ID)
+	if err != nil {
+		return web.InterviewResponse{}, exception.ErrInternalServer(fmt.Sprintf("Successfully created interview, but failed to get the interview have created. Error: %s", err.Error()))
+	}
+
+	kafkaNotificationMessage := kafkamodel.NewKafkaNotificationMessage(interviewer.UserID, NotificationInterviewScheduled, "Interview Scheduled",
+		fmt.Sprintf("You are scheduled to interview %s for %s on %s.", newInterview.CandidateName, newInterview.PostingTitle, scheduledAt.In(helper.WIB).Format("2006-01-02 15:04")), map[string]interface{}{
+			"interview_id":   newInterview.ID,
+			"application_id": application.ID,
+		})
+	go s.kafkaProducerService.Produce(kafkaNotificationMessage, "POST.NOTIFICATION", config.KafkaTopicNotification)
+
+	// the interview is already scheduled, so the failed invitation is only logged
+	if err := s.emailInterview(newInterview); err != nil {
+		s.logger.Infow(err.Error(), "Email Interview Invitation Error")
+	}
+
+	return newInterview.ToInterviewResponse(), nil
+}
+
+// submit the scorecard of the scheduled interview, only the interviewer can submit it. The score of the interview is
+// the average score of the scorecard.
+func (s *jobApplicationService) SubmitScorecard(c context.Context, userID, interviewID string, request web.InterviewScorecardRequest) (web.InterviewResponse, error) {
+	interview, err := findInterview(c, s.jobApplicationRepository, interviewID)
+	if err != nil {
+		return web.InterviewResponse{}, err
+	}
+	if interview.InterviewerUserID != userID {
+		return web.InterviewResponse{}, exception.ErrUnauthorized("Only the interviewer can submit the scorecard.")
+	}
+	if interview.Status != domain.InterviewScheduled {
+		return web.InterviewResponse{}, exception.ErrBadRequest(fmt.Sprintf("Interview is already %s.", interview.Status))
+	}
+
+	submittedAt := time.Now()
+	interview.Items = domain.ToDomainInterviewScorecardItems(interview.ID, request)
+	interview.Score = interview.AverageScore()
+	interview.Recommendation = &request.Recommendation
+	interview.Feedback = request.Feedback
+	interview.FeedbackSubmittedAt = &submittedAt
+	interview.Status = domain.InterviewCompleted
+	interview.UpdatedAt = submittedAt
+
+	if err := s.jobApplicationRepository.UpdateScorecard(c, interview); err != nil {
+		s.logger.Infow(err.Error(), "Submit Interview Scorecard Error")
+		return web.InterviewResponse{}, err
+	}
+
+	return interview.ToInterviewResponse(), nil
+}
+
+func (s *jobApplicationService) CancelInterview(c context.Context, interviewID string) (web.InterviewResponse, error) {
+	interview, err := findInterview(c, s.jobApplicationRepository, interviewID)
+	if err != nil {
+		return web.InterviewResponse{}, err
+	}
+	if interview.Status != domain.InterviewScheduled {
+		return web.InterviewResponse{}, exception.ErrBadRequest(fmt.Sprintf("Interview is already %s.", interview.Status))
+	}
+
+	interview.Status = domain.InterviewCancelled
+	interview.UpdatedAt = time.Now()
+
+	if err := s.jobApplicationRepository.UpdateInterviewStatus(c, interview); err != nil {
+		s.logger.Infow(err.Error(), "Cancel Interview Error")
+		return web.InterviewResponse{}, err
+	}
+
+	return interview.ToInterviewResponse(), nil
+}
+
+// create the draft offer of the active application, an application has only one offer in progress
+func (s *jobApplicationService) CreateOffer(c context.Context, userID, id string, request web.JobOfferRequest) (web.JobOfferResponse, error) {
+	application, err := findJobApplication(c, s.jobApplicationRepository, id)
+	if err != nil {
+		return web.JobOfferResponse{}, err
+	}
+	if application.Status != domain.JobApplicationActive {
+		return web.JobOfferResponse{}, exception.ErrBadRequest(fmt.Sprintf("Job application is already %s.", application.Status))
+	}
+	if offer, ok := application.FindOffer(); ok {
+		return web.JobOfferResponse{}, exception.ErrBadRequest(fmt.Sprintf("Job application already has a %s offer.", offer.Status))
+	}
+
+	offer := domain.ToDomainJobOffer(request)
+	if offer.ExpiresOn.Before(helper.Today()) || offer.StartDate.Before(helper.Today()) {
+		return web.JobOfferResponse{}, exception.ErrBadRequest("The start date and the expiry date can't be in the past.")
+	}
+	if offer.JobTitle == "" {
+		requisition, err := findJobRequisition(c, s.jobRequisitionRepository, application.RequisitionID)
+		if err != nil {
+			return web.JobOfferResponse{}, err
+		}
+		offer.JobTitle = requisition.Title
+	}
+
+	offer.ID = uuid.New().String()
+	offer.ApplicationID = application.ID
+	offer.CreatedBy = &userID
+	offer.CreatedAt = time.Now()
+	offer.UpdatedAt = time.Now()
+
+	// call the repo for inserting to db
+	if err := s.jobApplicationRepository.CreateOffer(c, offer); err != nil {
+		s.logger.Infow(err.Error(), "Create Job Offer Error")
+		if strings.Contains(err.Error(), "unique") && strings.Contains(err.Error(), "job_offers_application_id_idx") {
+			return web.JobOfferResponse{}, exception.ErrBadRequest("Job application already has an offer in progress.")
+		}
+		return web.JobOfferResponse{}, err
+	}
+
+	return offer.ToJobOfferResponse(), nil
+}
+
+// email the offer letter of the draft offer to the candidate
+func (s *jobApplicationService) SendOffer(c context.Context, offerID string) (web.JobOfferResponse, error) {
+	offer, err := findJobOffer(c, s.jobApplicationRepository, offerID)
+	if err != nil {
+		return web.JobOfferResponse{}, err
+	}
+	if offer.Status != domain.JobOfferDraft {
+		return web.JobOfferResponse{}, exception.ErrBadRequest(fmt.Sprintf("Job offer is already %s.", offer.Status))
+	}
+	if offer.IsExpired(helper.Today()) {
+		return web.JobOfferResponse{}, exception.ErrBadRequest("Job offer has expired.")
+	}
+
+	pdf, fileName, application, err := s.offerDocument(c, offer)
+	if err != nil {
+		return web.JobOfferResponse{}, err
+	}
+
+	data := map[string]interface{}{
+		"Name":      application.CandidateName,
+		"Position":  offer.JobTitle,
+		"ExpiresOn": helper.ParseTimeToFullIndonesian(offer.ExpiresOn),
+	}
+	body, err := helper.RenderHTML(s.templateFS, "offer_email.html", data)
+	if err != nil {
+		return web.JobOfferResponse{}, err
+	}
+	if err := helper.SendEmail([]string{application.CandidateEmail}, fmt.Sprintf("Penawaran Kerja %s", offer.JobTitle), body.String(), fileName, pdf); err != nil {
+		s.logger.Infow(err.Error(), "Email Job Offer Error")
+		return web.JobOfferResponse{}, err
+	}
+
+	sentAt := time.Now()
+	offer.Status = domain.JobOfferSent
+	offer.SentAt = &sentAt
+	offer.UpdatedAt = sentAt
+
+	if err := s.jobApplicationRepository.UpdateOfferStatus(c, offer); err != nil {
+		s.logger.Infow(err.Error(), "Send Job Offer Error")
+		return web.JobOfferResponse{}, err
+	}
+
+	return offer.ToJobOfferResponse(), nil
+}
+
+// record the response of the candidate to the sent offer, the expired offer can only be declined
+func (s *jobApplicationService) RespondOffer(c context.Context, offerID string, request web.JobOfferResponseRequest) (web.JobOfferResponse, error) {
+	offer, err := findJobOffer(c, s.jobApplicationRepository, offerID)
+	if err != nil {
+		return web.JobOfferResponse{}, err
+	}
+	if offer.Status != domain.JobOfferSent {
+		return web.JobOfferResponse{}, exception.ErrBadRequest("Only a sent job offer can be responded.")
+	}
+
+	offer.Status = domain.JobOfferDeclined
+	if request.Accepted {
+		if offer.IsExpired(helper.Today()) {
+			return web.JobOfferResponse{}, exception.ErrBadRequest("Job offer has expired.")
+		}
+		offer.Status = domain.JobOfferAccepted
+	}
+
+	respondedAt := time.Now()
+	offer.ResponseNote = request.Note
+	offer.RespondedAt = &respondedAt
+	offer.UpdatedAt = respondedAt
+
+	if err := s.jobApplicationRepository.UpdateOfferStatus(c, offer); err != nil {
+		s.logger.Infow(err.Error(), "Respond Job Offer Error")
+		return web.JobOfferResponse{}, err
+	}
+
+	return offer.ToJobOfferResponse(), nil
+}
+
+func (s *jobApplicationService) CancelOffer(c context.Context, offerID string) (web.JobOfferResponse, error) {
+	offer, err := findJobOffer(c, s.jobApplicationRepository, offerID)
+	if err != nil {
+		return web.JobOfferResponse{}, err
+	}
+	if offer.Status != domain.JobOfferDraft && offer.Status != domain.JobOfferSent {
+		return web.JobOfferResponse{}, exception.ErrBadRequest(fmt.Sprintf("Job offer is already %s.", offer.Status))
+	}
+
+	offer.Status = domain.JobOfferCancelled
+	offer.UpdatedAt = time.Now()
+
+	if err := s.jobApplicationRepository.UpdateOfferStatus(c, offer); err != nil {
+		s.logger.Infow(err.Error(), "Cancel Job Offer Error")
+		return web.JobOfferResponse{}, err
+	}
+
+	return offer.ToJobOfferResponse(), nil
+}
+
+// hire the candidate of the accepted offer as an employee of the department and the position of the requisition,
+// starting from the start date of the offer. The user and the employee are created with the hire in one transaction,
+// the onboarding of the employee is started and the candidate is emailed to set the password.
+func (s *jobApplicationService) Hire(c context.Context, id string, request web.HireRequest) (web.JobApplicationResponse, error) {
+	application, err := findJobApplication(c, s.jobApplicationRepository, id)
+	if err != nil {
+		return web.JobApplicationResponse{}, err
+	}
+	if application.Status != domain.JobApplicationActive {
+		return web.JobApplicationResponse{}, exception.ErrBadRequest(fmt.Sprintf("Job application is already %s.", application.Status))
+	}
+	offer, ok := application.FindOffer()
+	if !ok || offer.Status != domain.JobOfferAccepted {
+		return web.JobApplicationResponse{}, exception.ErrBadRequest("The candidate can only be hired after the offer is accepted.")
+	}
+
+	requisition, err := findJobRequisition(c, s.jobRequisitionRepository, application.RequisitionID)
+	if err != nil {
+		return web.JobApplicationResponse{}, err
+	}
+	if requisition.Status != domain.JobRequisitionApproved {
+		return web.JobApplicationResponse{}, exception.ErrBadRequest(fmt.Sprintf("Job requisition is already %s.", requisition.Status))
+	}
+
+	// the candidate is never linked to an existing user, the user may be another person with the same email
+	if existing, err := s.userRepository.FindUserNotDeleteByQueryTx(c, "email", application.CandidateEmail); err == nil && existing.ID != "" {
+		return web.JobApplicationResponse{}, exception.ErrConflict("The email of the candidate is already used by another user.")
+	} else if err != nil && !strings.Contains(err.Error(), "no rows") {
+		return web.JobApplicationResponse{}, err
+	}
+
+	hiredAt := time.Now()
+	user := domain.User{
+		ID:        uuid.New().String(),
+		Name:      application.CandidateName,
+		Email:     application.CandidateEmail,
+		Phone:     application.CandidatePhone,
+		CreatedAt: hiredAt,
+		UpdatedAt: hiredAt,
+	}
+	user.SetPassword(randstr.String(30))
+
+	employee, err := s.newHiredEmployee(c, user, offer, requisition, request)
+	if err != nil {
+		return web.JobApplicationResponse{}, err
+	}
+
+	application.Status = domain.JobApplicationHired
+	application.UserID = &user.ID
+	application.HiredAt = &hiredAt
+	application.UpdatedAt = hiredAt
+
+	// the posting is closed when the hire fills the requisition
+	var posting *domain.JobPosting
+	if requisition.Posting != nil && requisition.Posting.Status == domain.JobPostingOpen {
+		closed := *requisition.Posting
+		closePosting(&closed)
+		posting = &closed
+	}
+
+	if err := s.jobApplicationRepository.Hire(c, application, user, employee, newHireHistory(employee), posting); err != nil {
+		s.logger.Infow(err.Error(), "Hire Candidate Error")
+		return web.JobApplicationResponse{}, toHireError(err)
+	}
+
+	// produce to kafka
+	kafkaUserMessage := kafkamodel.NewKafkaUserMessage(user)
+	go s.kafkaProducerService.Produce(kafkaUserMessage, "POST.USER", config.KafkaTopic)
+
+	// the candidate is already hired, so the failures after the hire are only logged. The employee can be onboarded
+	// through the onboarding endpoints and the password can be set through the forgot password.
+	if newEmployee, err := s.employeeRepository.FindById(c, employee.ID); err != nil {
+		s.logger.Infow(err.Error(), "Find Hired Employee Error")
+	} else {
+		kafkaEmployeeMessage := kafkamodel.NewKafkaEmployeeMessage(newEmployee)
+		go s.kafkaProducerService.Produce(kafkaEmployeeMessage, "POST.EMPLOYEE", config.KafkaTopic)
+
+		startNewEmployeeOnboarding(c, s.onboardingRepository, s.kafkaProducerService, s.logger, newEmployee)
+	}
+	if err := s.emailActivation(c, user); err != nil {
+		s.logger.Infow(err.Error(), "Email User Activation Error")
+	}
+
+	return s.FindById(c, id)
+}
+
+func (s *jobApplicationService) FindAllStage(c context.Context) ([]web.RecruitmentStageResponse, error) {
+	stages, err := s.jobApplicationRepository.FindAllStage(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// convert to web.RecruitmentStageResponse
+	result := []web.RecruitmentStageResponse{}
+	for _, stage := range stages {
+		result = append(result, stage.ToRecruitmentStageResponse())
+	}
+
+	return result, nil
+}
+
+func (s *jobApplicationService) FindAll(c context.Context, filter web.JobApplicationQueryFilter) (result []web.JobApplicationResponse, totalData int, err error) {
+	applications, err := s.jobApplicationRepository.FindAllApplication(c, domain.ToDomainJobApplicationQueryFilter(filter))
+	if err != nil {
+		return nil, 0, err
+	}
+
+	// get total-data
+	totalData, err = s.jobApplicationRepository.CountAllApplication(c, domain.ToDomainJobApplicationQueryFilter(filter))
+	if err != nil {
+		return nil, 0, err
+	}
+
+	// convert to web.JobApplicationResponse
+	result = []web.JobApplicationResponse{}
+	for _, application := range applications {
+		result = append(result, application.ToJobApplicationResponse())
+	}
+
+	return result, totalData, nil
+}
+
+func (s *jobApplicationService) FindById(c context.Context, id string) (web.JobApplicationResponse, error) {
+	application, err := findJobApplication(c, s.jobApplicationRepository, id)
+	if err != nil {
+		return web.JobApplicationResponse{}, err
+	}
+
+	return application.ToJobApplicationResponse(), nil
+}
+
+// CV returns the CV of the application with its file name and MIME type.
+func (s *jobApplicationService) CV(c context.Context, id string) ([]byte, string, string, error) {
+	application, err := findJobApplication(c, s.jobApplicationRepository, id)
+	if err != nil {
+		return nil, "", "", err
+	}
+
+	data, err := s.storage.Get(c, application.CVStorageKey)
+	if err != nil {
+		if errors.Is(err, helper.ErrObjectNotFound) {
+			return nil, "", "", exception.ErrNotFound(fmt.Sprintf("The CV of job application %s not found", id))
+		}
+		return nil, "", "", err
+	}
+
+	return data, application.CVFileName, application.CVMimeType, nil
+}
+
+// find the scheduled interviews of the logged in user as the interviewer
+func (s *jobApplicationService) FindMyInterviews(c context.Context, userID string) ([]web.InterviewResponse, error) {
+	employee, err := findEmployeeByUser(c, s.employeeRepository, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	interviews, err := s.jobApplicationRepository.FindInterviewsByInterviewer(c, employee.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	// convert to web.InterviewResponse
+	result := []web.InterviewResponse{}
+	for _, interview := range interviews {
+		result = append(result, interview.ToInterviewResponse())
+	}
+
+	return result, nil
+}
+
+// OfferDocument returns the offer letter rendered from the 'offer.html' template with its file name.
+func (s *jobApplicationService) OfferDocument(c context.Context, offerID string) ([]byte, string, error) {
+	offer, err := findJobOffer(c, s.jobApplicationRepository, offerID)
+	if err != nil {
+		return nil, "", err
+	}
+
+	pdf, fileName, _, err := s.offerDocument(c, offer)
+	if err != nil {
+		return nil, "", err
+	}
+
+	return pdf, fileName, nil
+}
+
+// render the offer letter of the offer, the application is returned for the email of the offer
+func (s *jobApplicationService) offerDocument(c context.Context, offer domain.JobOffer) ([]byte, string, domain.JobApplication, error) {
+	application, err := findJobApplication(c, s.jobApplicationRepository, offer.ApplicationID)
+	if err != nil {
+		return nil, "", domain.JobApplication{}, err
+	}
+	requisition, err := findJobRequisition(c, s.jobRequisitionRepository, application.RequisitionID)
+	if err != nil {
+		return nil, "", domain.JobApplication{}, err
+	}
+
+	data := map[string]interface{}{
+		"Offer":          offer,
+		"Application":    application,
+		"Requisition":    requisition,
+		"EmploymentType": employmentTypeLabels[requisition.EmploymentType],
+		"StartDate":      helper.ParseTimeToFullIndonesian(offer.StartDate),
+		"ExpiresOn":      helper.ParseTimeToFullIndonesian(offer.ExpiresOn),
+		"PrintedAt":      helper.ParseTimeToFullIndonesian(helper.Today()),
+	}
+
+	pdf, err := helper.RenderPDF(c, s.pdfRenderer, s.templateFS, "offer.html", helper.PDFOptions{PaperSize: "A4"}, data)
+	if err != nil {
+		s.logger.Infow(err.Error(), "Generate Offer Letter Error")
+		return nil, "", domain.JobApplication{}, err
+	}
+
+	return pdf.Bytes(), fmt.Sprintf("offer-letter-%s.pdf", offer.ID), application, nil
+}
+
+// email the invitation of the interview to the candidate
+func (s *jobApplicationService) emailInterview(interview domain.Interview) error {
+	scheduledAt := interview.ScheduledAt.In(helper.WIB)
+	data := map[string]interface{}{
+		"Name":     interview.CandidateName,
+		"Position": interview.PostingTitle,
+		"Date":     helper.ParseTimeToFullIndonesian(scheduledAt),
+		"Time":     scheduledAt.Format("15:04"),
+		"Duration": interview.DurationMinutes,
+		"Location": interview.Location,
+	}
+	body, err := helper.RenderHTML(s.templateFS, "interview_email.html", data)
+	if err != nil {
+		return err
+	}
+
+	return helper.SendEmail([]string{interview.CandidateEmail}, fmt.Sprintf("Undangan Wawancara %s", interview.PostingTitle), body.String(), "", nil)
+}
+
+// the employee of the hired candidate, the employment follows the requisition and the accepted offer while the
+// personal data is given by the HR
+func (s *jobApplicationService) newHiredEmployee(c context.Context, user domain.User, offer domain.JobOffer, requisition domain.JobRequisition, request web.HireRequest) (domain.Employee, error) {
+	dateOfBirth, _ := helper.ParseDate(request.DateOfBirth)
+
+	managerID := request.ManagerID
+	if managerID != nil && *managerID == "" {
+		managerID = nil
+	}
+
+	employee := domain.Employee{
+		ID:                uuid.New().String(),
+		UserID:            user.ID,
+		EmployeeNumber:    request.EmployeeNumber,
+		DateOfBirth:       dateOfBirth,
+		Gender:            request.Gender,
+		NIK:               request.NIK,
+		Address:           request.Address,
+		HireDate:          offer.StartDate,
+		EmploymentType:    requisition.EmploymentType,
+		Status:            domain.EmployeeStatusActive,
+		JobTitle:          offer.JobTitle,
+		SalaryGrade:       request.SalaryGrade,
+		DepartmentID:      requisition.DepartmentID,
+		PositionID:        requisition.PositionID,
+		ManagerID:         managerID,
+		EmergencyContacts: domain.ToDomainEmergencyContacts(request.EmergencyContacts),
+		CreatedAt:         user.CreatedAt,
+		UpdatedAt:         user.CreatedAt,
+	}
+
+	if !employee.DateOfBirth.Before(employee.HireDate) {
+		return domain.Employee{}, exception.ErrBadRequest("Date of birth must be before the start date of the offer.")
+	}
+	if employee.ManagerID != nil {
+		if _, err := validateManager(c, s.employeeRepository, employee.ID, *employee.ManagerID); err != nil {
+			return domain.Employee{}, err
+		}
+	}
+
+	return employee, nil
+}
+
+// email the link to set the password of the new user, the link is the reset password link
+func (s *jobApplicationService) emailActivation(c context.Context, user domain.User) error {
+	attempt := 1
+	token := strings.ToLower(randstr.String(30))
+	resetToken := domain.ResetPasswordToken{
+		Id:          uuid.New().String(),
+		Tokens:      token,
+		Email:       user.Email,
+		Attempt:     &attempt,
+		LastAttempt: time.Now(),
+		URL:         helper.UrlReset + "?email=" + user.Email + "&token=" + token,
+	}
+
+	var err error
+	if existing, _ := s.userRepository.CheckTokenWithQueryTx(c, "email", user.Email); existing.Id != "" {
+		resetToken.Id = existing.Id
+		err = s.userRepository.UpdateTokenTx(c, resetToken)
+	} else {
+		err = s.userRepository.AddTokenTx(c, resetToken)
+	}
+	if err != nil {
+		return err
+	}
+
+	data := map[string]interface{}{
+		"Name":  user.Name,
+		"Email": user.Email,
+		"URL":   resetToken.URL,
+	}
+	body, err := helper.RenderHTML(s.templateFS, "welcome_email.html", data)
+	if err != nil {
+		return err
+	}
+
+	return helper.SendEmail([]string{user.Email}, "Selamat Bergabung", body.String(), "", nil)
+}
+
+// validate the size and the MIME type of the CV, the MIME type is detected from the content of the file
+func validateCV(file web.DocumentFile) (string, error) {
+	if len(file.Data) == 0 {
+		return "", exception.ErrBadRequest("The CV is empty.")
+	}
+	if len(file.Data) > config.RecruitmentCVMaxSizeMB*1024*1024 {
+		return "", exception.ErrBadRequest(fmt.Sprintf("The CV can't be larger than %d MB.", config.RecruitmentCVMaxSizeMB))
+	}
+
+	mimeType, _, _ := strings.Cut(http.DetectContentType(file.Data), ";")
+	for _, allowedMimeType := range config.RecruitmentCVAllowedMimeTypes {
+		if strings.TrimSpace(allowedMimeType) == mimeType {
+			return mimeType, nil
+		}
+	}
+
+	return "", exception.ErrBadRequest(fmt.Sprintf("The CV type %s is not allowed.", mimeType))
+}
+
+// convert the error of the hire, the requisition which is no longer approved or already filled by another hire is
+// returned as 'no rows'
+func toHireError(err error) error {
+	if strings.Contains(err.Error(), "no rows") {
+		return exception.ErrConflict("Job requisition has been filled or changed by another request, please reload it.")
+	}
+	if strings.Contains(err.Error(), "unique") && (strings.Contains(err.Error(), "users_email_key") || strings.Contains(err.Error(), "users_phone_key")) {
+		return exception.ErrConflict("The email or the phone of the candidate is already used by another user.")
+	}
+	return toEmployeeUniqueError(err)
+}
+
+// convert the unique constraint error of the 'recruitment_stages' table to bad request error
+func toRecruitmentStageUniqueError(err error) error {
+	if strings.Contains(err.Error(), "unique") && strings.Contains(err.Error(), "recruitment_stages_name_key") {
+		return exception.ErrBadRequest("Recruitment stage name already exist.")
+	}
+	if strings.Contains(err.Error(), "unique") && strings.Contains(err.Error(), "recruitment_stages_seq_key") {
+		return exception.ErrBadRequest("Recruitment stage seq already exist.")
+	}
+	return err
+}
+
+// find the recruitment stage by id and convert the 'no rows' error to not found error
+func findRecruitmentStage(c context.Context, jobApplicationRepository repository.JobApplicationRepository, id string) (domain.RecruitmentStage, error) {
+	stage, err := jobApplicationRepository.FindStageById(c, id)
+	if err != nil {
+		if strings.Contains(err.Error(), "no rows") {
+			return domain.RecruitmentStage{}, exception.ErrNotFound(fmt.Sprintf("Recruitment stage %s not found", id))
+		}
+		return domain.RecruitmentStage{}, err
+	}
+
+	return stage, nil
+}
+
+// find the job application by id with its moves, interviews and offers and convert the 'no rows' error to not found
+// error
+func findJobApplication(c context.Context, jobApplicationRepository repository.JobApplicationRepository, id string) (domain.JobApplication, error) {
+	application, err := jobApplicationRepository.FindApplicationById(c, id)
+	if err != nil {
+		if strings.Contains(err.Error(), "no rows") {
+			return domain.JobApplication{}, exception.ErrNotFound(fmt.Sprintf("Job application %s not found", id))
+		}
+		return domain.JobApplication{}, err
+	}
+
+	return application, nil
+}
+
+// find the interview by id with its scorecard and convert the 'no rows' error to not found error
+func findInterview(c context.Context, jobApplicationRepository repository.JobApplicationRepository, id string) (domain.Interview, error) {
+	interview, err := jobApplicationRepository.FindInterviewById(c, id)
+	if err != nil {
+		if strings.Contains(err.Error(), "no rows") {
+			return domain.Interview{}, exception.ErrNotFound(fmt.Sprintf("Interview %s not found", id))
+		}
+		return domain.Interview{}, err
+	}
+
+	return interview, nil
+}
+
+// find the job offer by id and convert the 'no rows' error to not found error
+func findJobOffer(c context.Context, jobApplicationRepository repository.JobApplicationRepository, id string) (domain.JobOffer, error) {
+	offer, err := jobApplicationRepository.FindOfferById(c, id)
+	if err != nil {
+		if strings.Contains(err.Error(), "no rows") {
+			return domain.JobOffer{}, exception.ErrNotFound(fmt.Sprintf("Job offer %s not found", id))
+		}
+		return domain.JobOffer{}, err
+	}
+
+	return offer, nil
+}
@@ -0,0 +1,458 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/iqbaludinm/hr-microservice/user-service/config"
+	"github.com/iqbaludinm/hr-microservice/user-service/exception"
+	"github.com/iqbaludinm/hr-microservice/user-service/helper"
+	"github.com/iqbaludinm/hr-microservice/user-service/model/domain"
+	"github.com/iqbaludinm/hr-microservice/user-service/model/kafkamodel"
+	"github.com/iqbaludinm/hr-microservice/user-service/model/web"
+	"github.com/iqbaludinm/hr-microservice/user-service/repository"
+	"github.com/iqbaludinm/hr-microservice/user-service/service/producers"
+	"go.uber.org/zap"
+)
+
+// Type of the notifications produced by the job requisition service.
+const (
+	NotificationJobRequisitionSubmitted = "JOB_REQUISITION_SUBMITTED"
+	NotificationJobRequisitionApproved  = "JOB_REQUISITION_APPROVED"
+	NotificationJobRequisitionRejected  = "JOB_REQUISITION_REJECTED"
+)
+
+type JobRequisitionService interface {
+	// With Transaction
+	CreateRequisition(ctx context.Context, userID string, request web.JobRequisitionRequest) (web.JobRequisitionResponse, error)
+	UpdateRequisition(ctx context.Context, userID, id string, request web.JobRequisitionRequest) (web.JobRequisitionResponse, error)
+	SubmitRequisition(ctx context.Context, userID, id string) (web.JobRequisitionResponse, error)
+	ApproveRequisition(ctx context.Context, userID, id string, request web.DecideJobRequisitionRequest) (web.JobRequisitionResponse, error)
+	RejectRequisition(ctx context.Context, userID, id string, request web.DecideJobRequisitionRequest) (web.JobRequisitionResponse, error)
+	CancelRequisition(ctx context.Context, userID, id string) (web.JobRequisitionResponse, error)
+	PublishPosting(ctx context.Context, id string, request web.JobPostingRequest) (web.JobRequisitionResponse, error)
+	ClosePosting(ctx context.Context, id string) (web.JobRequisitionResponse, error)
+	CloseExpiredPostings(ctx context.Context) error
+
+	// Without Transaction
+	FindAllRequisition(ctx context.Context, filter web.JobRequisitionQueryFilter) ([]web.JobRequisitionResponse, int, error)
+	FindPendingApprovals(ctx context.Context, userID string, filter web.JobRequisitionQueryFilter) ([]web.JobRequisitionResponse, int, error)
+	FindRequisitionById(ctx context.Context, id string) (web.JobRequisitionResponse, error)
+	FindAllOpenPosting(ctx context.Context, filter web.JobPostingQueryFilter) ([]web.JobPostingResponse, int, error)
+	FindOpenPostingById(ctx context.Context, id string) (web.JobPostingResponse, error)
+}
+
+type jobRequisitionService struct {
+	jobRequisitionRepository repository.JobRequisitionRepository
+	employeeRepository       repository.EmployeeRepository
+	departmentRepository     repository.DepartmentRepository
+	positionRepository       repository.PositionRepository
+	kafkaProducerService     producers.KafkaProducerService
+	logger                   *zap.SugaredLogger
+}
+
+func NewJobRequisitionService(jobRequisitionRepository repository.JobRequisitionRepository, employeeRepository repository.EmployeeRepository, departmentRepository repository.DepartmentRepository, positionRepository repository.PositionRepository, kafkaProducerService producers.KafkaProducerService, logger *zap.SugaredLogger) JobRequisitionService {
+	return &jobRequisitionService{
+		jobRequisitionRepository: jobRequisitionRepository,
+		employeeRepository:       employeeRepository,
+		departmentRepository:     departmentRepository,
+		positionRepository:       positionRepository,
+		kafkaProducerService:     kafkaProducerService,
+		logger:                   logger,
+	}
+}
+
+// create the draft requisition of the logged in user
+func (s *jobRequisitionService) CreateRequisition(c context.Context, userID string, request web.JobRequisitionRequest) (web.JobRequisitionResponse, error) {
+	// convert to domain or model job requisition
+	requisition := domain.ToDomainJobRequisition(request)
+	if err := s.validateRequisition(c, requisition); err != nil {
+		return web.JobRequisitionResponse{}, err
+	}
+
+	requisition.ID = uuid.New().String()
+	requisition.RequestedBy = userID
+	requisition.CreatedAt = time.Now()
+	requisition.UpdatedAt = time.Now()
+
+	// call the repo for inserting to db
+	if err := s.jobRequisitionRepository.CreateRequisition(c, requisition); err != nil {
+		s.logger.Infow(err.Error(), "Create Job Requisition Error")
+		return web.JobRequisitionResponse{}, err
+	}
+
+	newRequisition, err := s.jobRequisitionRepository.FindRequisitionById(c, requisition.ID)
+	if err != nil {
+		return web.JobRequisitionResponse{}, exception.ErrInternalServer(fmt.Sprintf("Successfully created job requisition, but failed to get the job requisition have created. Error: %s", err.Error()))
+	}
+
+	return newRequisition.ToJobRequisitionResponse(), nil
+}
+
+// update the draft or rejected requisition, only the requester can update it
+func (s *jobRequisitionService) UpdateRequisition(c context.Context, userID, id string, request web.JobRequisitionRequest) (web.JobRequisitionResponse, error) {
+	requisition, err := findJobRequisition(c, s.jobRequisitionRepository, id)
+	if err != nil {
+		return web.JobRequisitionResponse{}, err
+	}
+	if requisition.RequestedBy != userID {
+		return web.JobRequisitionResponse{}, exception.ErrUnauthorized("Only the requester can update the job requisition.")
+	}
+	if requisition.Status != domain.JobRequisitionDraft && requisition.Status != domain.JobRequisitionRejected {
+		return web.JobRequisitionResponse{}, exception.ErrBadRequest(fmt.Sprintf("Job requisition is already %s.", requisition.Status))
+	}
+
+	updated := domain.ToDomainJobRequisition(request)
+	if err := s.validateRequisition(c, updated); err != nil {
+		return web.JobRequisitionResponse{}, err
+	}
+
+	updated.ID = requisition.ID
+	updated.UpdatedAt = time.Now()
+	if err := s.jobRequisitionRepository.UpdateRequisition(c, updated); err != nil {
+		s.logger.Infow(err.Error(), "Update Job Requisition Error")
+		return web.JobRequisitionResponse{}, err
+	}
+
+	return s.FindRequisitionById(c, id)
+}
+
+// submit the draft or rejected requisition to the manager of the requester for approval
+func (s *jobRequisitionService) SubmitRequisition(c context.Context, userID, id string) (web.JobRequisitionResponse, error) {
+	requisition, err := findJobRequisition(c, s.jobRequisitionRepository, id)
+	if err != nil {
+		return web.JobRequisitionResponse{}, err
+	}
+	if requisition.RequestedBy != userID {
+		return web.JobRequisitionResponse{}, exception.ErrUnauthorized("Only the requester can submit the job requisition.")
+	}
+	if requisition.Status != domain.JobRequisitionDraft && requisition.Status != domain.JobRequisitionRejected {
+		return web.JobRequisitionResponse{}, exception.ErrBadRequest(fmt.Sprintf("Job requisition is already %s.", requisition.Status))
+	}
+
+	requester, err := findEmployeeByUser(c, s.employeeRepository, userID)
+	if err != nil {
+		return web.JobRequisitionResponse{}, err
+	}
+	// the job requisition is approved by the requester's manager
+	if requester.ManagerID == nil {
+		return web.JobRequisitionResponse{}, exception.ErrBadRequest("Requester has no manager to approve the job requisition.")
+	}
+	approver, err := findEmployee(c, s.employeeRepository, *requester.ManagerID)
+	if err != nil {
+		return web.JobRequisitionResponse{}, err
+	}
+
+	requisition.Status = domain.JobRequisitionPendingApproval
+	requisition.ApproverID = &approver.ID
+	requisition.DecisionNote = ""
+	requisition.DecidedAt = nil
+	requisition.UpdatedAt = time.Now()
+
+	if err := s.jobRequisitionRepository.UpdateRequisitionStatus(c, requisition); err != nil {
+		s.logger.Infow(err.Error(), "Submit Job Requisition Error")
+		return web.JobRequisitionResponse{}, err
+	}
+
+	s.notify(approver.UserID, NotificationJobRequisitionSubmitted, "Job Requisition Submitted",
+		fmt.Sprintf("%s requested %d %s for %s.", requester.Name, requisition.Headcount, requisition.Title, requisition.Department), requisition)
+
+	return s.FindRequisitionById(c, id)
+}
+
+func (s *jobRequisitionService) ApproveRequisition(c context.Context, userID, id string, request web.DecideJobRequisitionRequest) (web.JobRequisitionResponse, error) {
+	requisition, err := s.decide(c, userID, id, domain.JobRequisitionApproved, request.Note)
+	if err != nil {
+		return web.JobRequisitionResponse{}, err
+	}
+
+	s.notify(requisition.RequestedBy, NotificationJobRequisitionApproved, "Job Requisition Approved",
+		fmt.Sprintf("Your job requisition %s has been approved.", requisition.Title), requisition)
+
+	return s.FindRequisitionById(c, id)
+}
+
+func (s *jobRequisitionService) RejectRequisition(c context.Context, userID, id string, request web.DecideJobRequisitionRequest) (web.JobRequisitionResponse, error) {
+	requisition, err := s.decide(c, userID, id, domain.JobRequisitionRejected, request.Note)
+	if err != nil {
+		return web.JobRequisitionResponse{}, err
+	}
+
+	s.notify(requisition.RequestedBy, NotificationJobRequisitionRejected, "Job Requisition Rejected",
+		fmt.Sprintf("Your job requisition %s has been rejected.", requisition.Title), requisition)
+
+	return s.FindRequisitionById(c, id)
+}
+
+// cancel the requisition which is not filled yet, its open posting is closed
+func (s *jobRequisitionService) CancelRequisition(c context.Context, userID, id string) (web.JobRequisitionResponse, error) {
+	requisition, err := findJobRequisition(c, s.jobRequisitionRepository, id)
+	if err != nil {
+		return web.JobRequisitionResponse{}, err
+	}
+	if requisition.RequestedBy != userID {
+		return web.JobRequisitionResponse{}, exception.ErrUnauthorized("Only the requester can cancel the job requisition.")
+	}
+	if requisition.Status == domain.JobRequisitionFilled || requisition.Status == domain.JobRequisitionCancelled {
+		return web.JobRequisitionResponse{}, exception.ErrBadRequest(fmt.Sprintf("Job requisition is already %s.", requisition.Status))
+	}
+
+	requisition.Status = domain.JobRequisitionCancelled
+	requisition.UpdatedAt = time.Now()
+	closePosting(requisition.Posting)
+
+	if err := s.jobRequisitionRepository.UpdateRequisitionStatus(c, requisition); err != nil {
+		s.logger.Infow(err.Error(), "Cancel Job Requisition Error")
+		return web.JobRequisitionResponse{}, err
+	}
+
+	return s.FindRequisitionById(c, id)
+}
+
+// publish the posting of the approved requisition, a requisition has only one posting
+func (s *jobRequisitionService) PublishPosting(c context.Context, id string, request web.JobPostingRequest) (web.JobRequisitionResponse, error) {
+	requisition, err := findJobRequisition(c, s.jobRequisitionRepository, id)
+	if err != nil {
+		return web.JobRequisitionResponse{}, err
+	}
+	if requisition.Status != domain.JobRequisitionApproved {
+		return web.JobRequisitionResponse{}, exception.ErrBadRequest("Only an approved job requisition can be posted.")
+	}
+	if requisition.Posting != nil {
+		return web.JobRequisitionResponse{}, exception.ErrBadRequest("Job requisition is already posted.")
+	}
+
+	posting := domain.ToDomainJobPosting(request)
+	if posting.ClosesOn != nil && posting.ClosesOn.Before(helper.Today()) {
+		return web.JobRequisitionResponse{}, exception.ErrBadRequest("The closing date can't be in the past.")
+	}
+	if posting.Title == "" {
+		posting.Title = requisition.Title
+	}
+
+	posting.ID = uuid.New().String()
+	posting.RequisitionID = requisition.ID
+	posting.PublishedAt = time.Now()
+	posting.CreatedAt = time.Now()
+	posting.UpdatedAt = time.Now()
+
+	if err := s.jobRequisitionRepository.CreatePosting(c, posting); err != nil {
+		s.logger.Infow(err.Error(), "Publish Job Posting Error")
+		return web.JobRequisitionResponse{}, err
+	}
+
+	return s.FindRequisitionById(c, id)
+}
+
+// close the open posting of the requisition before its closing date
+func (s *jobRequisitionService) ClosePosting(c context.Context, id string) (web.JobRequisitionResponse, error) {
+	requisition, err := findJobRequisition(c, s.jobRequisitionRepository, id)
+	if err != nil {
+		return web.JobRequisitionResponse{}, err
+	}
+	if requisition.Posting == nil || requisition.Posting.Status != domain.JobPostingOpen {
+		return web.JobRequisitionResponse{}, exception.ErrBadRequest("Job requisition has no open posting.")
+	}
+
+	closePosting(requisition.Posting)
+	if err := s.jobRequisitionRepository.UpdatePostingStatus(c, *requisition.Posting); err != nil {
+		s.logger.Infow(err.Error(), "Close Job Posting Error")
+		return web.JobRequisitionResponse{}, err
+	}
+
+	return s.FindRequisitionById(c, id)
+}
+
+// close the open postings which closing date has passed. It is run by the scheduler.
+func (s *jobRequisitionService) CloseExpiredPostings(c context.Context) error {
+	closed, err := s.jobRequisitionRepository.CloseExpiredPostings(c, helper.Today())
+	if err != nil {
+		return err
+	}
+	if closed > 0 {
+		s.logger.Infow("Job postings closed", "count", closed)
+	}
+
+	return nil
+}
+
+func (s *jobRequisitionService) FindAllRequisition(c context.Context, filter web.JobRequisitionQueryFilter) ([]web.JobRequisitionResponse, int, error) {
+	return s.findAllRequisition(c, domain.ToDomainJobRequisitionQueryFilter(filter))
+}
+
+// find the requisitions waiting for the approval of the logged in user
+func (s *jobRequisitionService) FindPendingApprovals(c context.Context, userID string, filter web.JobRequisitionQueryFilter) ([]web.JobRequisitionResponse, int, error) {
+	employee, err := findEmployeeByUser(c, s.employeeRepository, userID)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	domainFilter := domain.ToDomainJobRequisitionQueryFilter(filter)
+	domainFilter.Status = domain.JobRequisitionPendingApproval
+	domainFilter.ApproverID = employee.ID
+
+	return s.findAllRequisition(c, domainFilter)
+}
+
+func (s *jobRequisitionService) FindRequisitionById(c context.Context, id string) (web.JobRequisitionResponse, error) {
+	requisition, err := findJobRequisition(c, s.jobRequisitionRepository, id)
+	if err != nil {
+		return web.JobRequisitionResponse{}, err
+	}
+
+	return requisition.ToJobRequisitionResponse(), nil
+}
+
+// find the postings which accept applications today, it is used by the public careers page
+func (s *jobRequisitionService) FindAllOpenPosting(c context.Context, filter web.JobPostingQueryFilter) (result []web.JobPostingResponse, totalData int, err error) {
+	domainFilter := domain.ToDomainJobPostingQueryFilter(filter)
+	domainFilter.OpenOn = helper.Today().Format(helper.DateLayout)
+
+	postings, err := s.jobRequisitionRepository.FindAllPosting(c, domainFilter)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	// get total-data
+	totalData, err = s.jobRequisitionRepository.CountAllPosting(c, domainFilter)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	// convert to web.JobPostingResponse
+	result = []web.JobPostingResponse{}
+	for _, posting := range postings {
+		result = append(result, posting.ToJobPostingResponse())
+	}
+
+	return result, totalData, nil
+}
+
+// find the posting which accepts applications today, the closed posting is reported as not found
+func (s *jobRequisitionService) FindOpenPostingById(c context.Context, id string) (web.JobPostingResponse, error) {
+	posting, err := findOpenJobPosting(c, s.jobRequisitionRepository, id)
+	if err != nil {
+		return web.JobPostingResponse{}, err
+	}
+
+	return posting.ToJobPostingResponse(), nil
+}
+
+// approve or reject the pending requisition, only the approver can decide it
+func (s *jobRequisitionService) decide(c context.Context, userID, id, status, note string) (domain.JobRequisition, error) {
+	requisition, err := findJobRequisition(c, s.jobRequisitionRepository, id)
+	if err != nil {
+		return domain.JobRequisition{}, err
+	}
+
+	approver, err := findEmployeeByUser(c, s.employeeRepository, userID)
+	if err != nil {
+		return domain.JobRequisition{}, err
+	}
+	if requisition.ApproverID == nil || *requisition.ApproverID != approver.ID {
+		return domain.JobRequisition{}, exception.ErrUnauthorized("Only the approver can decide the job requisition.")
+	}
+	if requisition.Status != domain.JobRequisitionPendingApproval {
+		return domain.JobRequisition{}, exception.ErrBadRequest(fmt.Sprintf("Job requisition is already %s.", requisition.Status))
+	}
+
+	decidedAt := time.Now()
+	requisition.Status = status
+	requisition.DecisionNote = note
+	requisition.DecidedAt = &decidedAt
+	requisition.UpdatedAt = decidedAt
+
+	if err := s.jobRequisitionRepository.UpdateRequisitionStatus(c, requisition); err != nil {
+		s.logger.Infow(err.Error(), "Decide Job Requisition Error")
+		return domain.JobRequisition{}, err
+	}
+
+	return requisition, nil
+}
+
+// validate the department and the position of the requisition and its salary range
+func (s *jobRequisitionService) validateRequisition(c context.Context, requisition domain.JobRequisition) error {
+	if _, err := validateAssignment(c, s.departmentRepository, s.positionRepository, requisition.DepartmentID, requisition.PositionID); err != nil {
+		return err
+	}
+	if requisition.SalaryMin != nil && requisition.SalaryMax != nil && *requisition.SalaryMax < *requisition.SalaryMin {
+		return exception.ErrBadRequest("The maximum salary must be greater than or equal to the minimum salary.")
+	}
+
+	return nil
+}
+
+func (s *jobRequisitionService) findAllRequisition(c context.Context, filter domain.JobRequisitionQueryFilter) (result []web.JobRequisitionResponse, totalData int, err error) {
+	requisitions, err := s.jobRequisitionRepository.FindAllRequisition(c, filter)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	// get total-data
+	totalData, err = s.jobRequisitionRepository.CountAllRequisition(c, filter)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	// convert to web.JobRequisitionResponse
+	result = []web.JobRequisitionResponse{}
+	for _, requisition := range requisitions {
+		result = append(result, requisition.ToJobRequisitionResponse())
+	}
+
+	return result, totalData, nil
+}
+
+// produce the notification of the job requisition to the user
+func (s *jobRequisitionService) notify(userID, notificationType, title, message string, requisition domain.JobRequisition) {
+	kafkaNotificationMessage := kafkamodel.NewKafkaNotificationMessage(userID, notificationType, title, message, map[string]interface{}{
+		"requisition_id": requisition.ID,
+		"status":         requisition.Status,
+		"headcount":      requisition.Headcount,
+	})
+	go s.kafkaProducerService.Produce(kafkaNotificationMessage, "POST.NOTIFICATION", config.KafkaTopicNotification)
+}
+
+// close the open posting, the posting which is nil or already closed is left as is
+func closePosting(posting *domain.JobPosting) {
+	if posting == nil || posting.Status != domain.JobPostingOpen {
+		return
+	}
+
+	closedAt := time.Now()
+	posting.Status = domain.JobPostingClosed
+	posting.ClosedAt = &closedAt
+	posting.UpdatedAt = closedAt
+}
+
+// find the job requisition by id with its posting and convert the 'no rows' error to not found error
+func findJobRequisition(c context.Context, jobRequisitionRepository repository.JobRequisitionRepository, id string) (domain.JobRequisition, error) {
+	requisition, err := jobRequisitionRepository.FindRequisitionById(c, id)
+	if err != nil {
+		if strings.Contains(err.Error(), "no rows") {
+			return domain.JobRequisition{}, exception.ErrNotFound(fmt.Sprintf("Job requisition %s not found", id))
+		}
+		return domain.JobRequisition{}, err
+	}
+
+	return requisition, nil
+}
+
+// find the job posting which accepts applications today, the posting which is closed is reported as not found
+func findOpenJobPosting(c context.Context, jobRequisitionRepository repository.JobRequisitionRepository, id string) (domain.JobPosting, error) {
+	posting, err := jobRequisitionRepository.FindPostingById(c, id)
+	if err != nil {
+		if strings.Contains(err.Error(), "no rows") {
+			return domain.JobPosting{}, exception.ErrNotFound(fmt.Sprintf("Job posting %s not found", id))
+		}
+		return domain.JobPosting{}, err
+	}
+	if !posting.IsOpen(helper.Today()) {
+		return domain.JobPosting{}, exception.ErrNotFound(fmt.Sprintf("Job posting %s not found", id))
+	}
+
+	return posting, nil
+}
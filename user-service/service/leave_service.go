@@ -0,0 +1,533 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/iqbaludinm/hr-microservice/user-service/config"
+	"github.com/iqbaludinm/hr-microservice/user-service/exception"
+	"github.com/iqbaludinm/hr-microservice/user-service/helper"
+	"github.com/iqbaludinm/hr-microservice/user-service/model/domain"
+	"github.com/iqbaludinm/hr-microservice/user-service/model/kafkamodel"
+	"github.com/iqbaludinm/hr-microservice/user-service/model/web"
+	"github.com/iqbaludinm/hr-microservice/user-service/repository"
+	"github.com/iqbaludinm/hr-microservice/user-service/service/producers"
+	"go.uber.org/zap"
+)
+
+// Type of the notifications produced by the leave service.
+const (
+	NotificationLeaveSubmitted = "LEAVE_SUBMITTED"
+	NotificationLeaveApproved  = "LEAVE_APPROVED"
+	NotificationLeaveRejected  = "LEAVE_REJECTED"
+	NotificationLeaveCancelled = "LEAVE_CANCELLED"
+)
+
+type LeaveService interface {
+	// With Transaction
+	CreateLeave(ctx context.Context, userID string, request web.CreateLeaveRequest) (web.LeaveResponse, error)
+	Approve(ctx context.Context, userID, id string, request web.DecideLeaveRequest) (web.LeaveResponse, error)
+	Reject(ctx context.Context, userID, id string, request web.DecideLeaveRequest) (web.LeaveResponse, error)
+	Cancel(ctx context.Context, userID, id string) (web.LeaveResponse, error)
+
+	// Without Transaction
+	FindAllLeave(ctx context.Context, filter web.LeaveQueryFilter) ([]web.LeaveResponse, int, error)
+	FindMyLeave(ctx context.Context, userID string, filter web.LeaveQueryFilter) ([]web.LeaveResponse, int, error)
+	FindPendingApprovals(ctx context.Context, userID string, filter web.LeaveQueryFilter) ([]web.LeaveResponse, int, error)
+	FindById(ctx context.Context, id string) (web.LeaveResponse, error)
+	FindMyBalances(ctx context.Context, userID string, filter web.LeaveBalanceQueryFilter) ([]web.LeaveBalanceResponse, error)
+	FindBalances(ctx context.Context, employeeID string, filter web.LeaveBalanceQueryFilter) ([]web.LeaveBalanceResponse, error)
+
+	// Approval Entity
+	ApprovalEntity
+}
+
+type leaveService struct {
+	leaveRepository        repository.LeaveRepository
+	leaveTypeRepository    repository.LeaveTypeRepository
+	holidayRepository      repository.HolidayRepository
+	workCalendarRepository repository.WorkCalendarRepository
+	employeeRepository     repository.EmployeeRepository
+	approvalService        ApprovalService
+	kafkaProducerService   producers.KafkaProducerService
+	logger                 *zap.SugaredLogger
+}
+
+func NewLeaveService(leaveRepository repository.LeaveRepository, leaveTypeRepository repository.LeaveTypeRepository, holidayRepository repository.HolidayRepository, workCalendarRepository repository.WorkCalendarRepository, employeeRepository repository.EmployeeRepository, approvalService ApprovalService, kafkaProducerService producers.KafkaProducerService, logger *zap.SugaredLogger) LeaveService {
+	return &leaveService{
+		leaveRepository:        leaveRepository,
+		leaveTypeRepository:    leaveTypeRepository,
+		holidayRepository:      holidayRepository,
+		workCalendarRepository: workCalendarRepository,
+		employeeRepository:     employeeRepository,
+		approvalService:        approvalService,
+		kafkaProducerService:   kafkaProducerService,
+		logger:                 logger,
+	}
+}
+
+func (s *leaveService) CreateLeave(c context.Context, userID string, request web.CreateLeaveRequest) (web.LeaveResponse, error) {
+	employee, err := findEmployeeByUser(c, s.employeeRepository, userID)
+	if err != nil {
+		return web.LeaveResponse{}, err
+	}
+	// the leave request is approved by the employee's manager
+	if employee.ManagerID == nil {
+		return web.LeaveResponse{}, exception.ErrBadRequest("Employee has no manager to approve the leave request.")
+	}
+	manager, err := findEmployee(c, s.employeeRepository, *employee.ManagerID)
+	if err != nil {
+		return web.LeaveResponse{}, err
+	}
+
+	leaveType, err := findLeaveType(c, s.leaveTypeRepository, request.LeaveTypeID)
+	if err != nil {
+		return web.LeaveResponse{}, err
+	}
+
+	startDate, _ := helper.ParseDate(request.StartDate)
+	endDate, _ := helper.ParseDate(request.EndDate)
+	if endDate.Before(startDate) {
+		return web.LeaveResponse{}, exception.ErrBadRequest("End date must be on or after the start date.")
+	}
+	// the days are taken from the balance of a single year
+	if startDate.Year() != endDate.Year() {
+		return web.LeaveResponse{}, exception.ErrBadRequest("Leave request can't span two years, split it into two requests.")
+	}
+	if request.HalfDay != "" {
+		if !leaveType.AllowHalfDay {
+			return web.LeaveResponse{}, exception.ErrBadRequest(fmt.Sprintf("Leave type %s doesn't allow half day.", leaveType.Code))
+		}
+		if !startDate.Equal(endDate) {
+			return web.LeaveResponse{}, exception.ErrBadRequest("Half day leave must start and end on the same date.")
+		}
+	}
+
+	// count the working days of the employee's calendar, the weekends and holidays are excluded
+	calendar, err := findCalendar(c, s.workCalendarRepository, s.holidayRepository, employee.WorkCalendarID, startDate, endDate)
+	if err != nil {
+		return web.LeaveResponse{}, err
+	}
+	days := domain.CountLeaveDays(startDate, endDate, request.HalfDay, calendar)
+	if days == 0 {
+		return web.LeaveResponse{}, exception.ErrBadRequest("Leave request has no working days.")
+	}
+
+	balance, err := s.findBalance(c, employee, leaveType, startDate.Year())
+	if err != nil {
+		return web.LeaveResponse{}, err
+	}
+
+	// convert to domain or model leave
+	leave := domain.Leave{
+		ID:          uuid.New().String(),
+		EmployeeID:  employee.ID,
+		LeaveTypeID: leaveType.ID,
+		StartDate:   startDate,
+		EndDate:     endDate,
+		HalfDay:     request.HalfDay,
+		Days:        days,
+		Reason:      request.Reason,
+		Status:      domain.LeaveStatusPending,
+		ApproverID:  &manager.ID,
+		CreatedAt:   time.Now(),
+		UpdatedAt:   time.Now(),
+	}
+
+	// the overlap and the balance are checked against the locked balance, while inserting to db
+	check := func(locked domain.LeaveBalance, overlaps int) error {
+		if overlaps > 0 {
+			return exception.ErrBadRequest("Leave request overlaps another pending or approved leave request.")
+		}
+		locked.Entitled = balance.Entitled
+		if leaveType.RequiresBalance && locked.Available() < days {
+			return exception.ErrBadRequest(fmt.Sprintf("Insufficient leave balance, %.1f days available.", locked.Available()))
+		}
+		return nil
+	}
+
+	// call the repo for inserting to db
+	if err := s.leaveRepository.CreateLeave(c, leave, balance, check); err != nil {
+		s.logger.Infow(err.Error(), "Create Leave Error")
+		return web.LeaveResponse{}, err
+	}
+
+	// the leave request is approved through the active approval workflow of leave, otherwise by the manager. The leave
+	// request is withdrawn when its approval can't be requested.
+	governed, err := s.approvalService.Request(c, domain.ApprovalEntityLeave, leave.ID)
+	if err != nil {
+		s.logger.Infow(err.Error(), "Request Leave Approval Error")
+		leave.Status = domain.LeaveStatusCancelled
+		leave.CancelledAt = &leave.UpdatedAt
+		if err := s.updateStatus(c, leave, domain.LeaveStatusPending, 0, -leave.Days); err != nil {
+			s.logger.Errorw("Withdraw Leave Error", "leave_id", leave.ID, "error", err.Error())
+		}
+		return web.LeaveResponse{}, err
+	}
+
+	newLeave, err := s.leaveRepository.FindById(c, leave.ID)
+	if err != nil {
+		return web.LeaveResponse{}, exception.ErrInternalServer(fmt.Sprintf("Successfully created leave request, but failed to get the leave request have created. Error: %s", err.Error()))
+	}
+
+	// notify the manager there is a leave request to be approved, the approvers of the workflow are notified by it
+	if !governed {
+		s.notify(manager.UserID, NotificationLeaveSubmitted, "Leave Request Submitted",
+			fmt.Sprintf("%s requested %s for %.1f days (%s - %s).", newLeave.EmployeeName, newLeave.LeaveTypeName, newLeave.Days, request.StartDate, request.EndDate), newLeave)
+	}
+
+	return newLeave.ToLeaveResponse(), nil
+}
+
+func (s *leaveService) Approve(c context.Context, userID, id string, request web.DecideLeaveRequest) (web.LeaveResponse, error) {
+	leave, err := s.decide(c, userID, id, domain.LeaveStatusApproved, request.Note)
+	if err != nil {
+		return web.LeaveResponse{}, err
+	}
+
+	s.notify(leave.EmployeeUserID, NotificationLeaveApproved, "Leave Request Approved",
+		fmt.Sprintf("Your %s request (%s - %s) has been approved by %s.", leave.LeaveTypeName, leave.StartDate.Format(helper.DateLayout), leave.EndDate.Format(helper.DateLayout), leave.ApproverName), leave)
+
+	return leave.ToLeaveResponse(), nil
+}
+
+func (s *leaveService) Reject(c context.Context, userID, id string, request web.DecideLeaveRequest) (web.LeaveResponse, error) {
+	leave, err := s.decide(c, userID, id, domain.LeaveStatusRejected, request.Note)
+	if err != nil {
+		return web.LeaveResponse{}, err
+	}
+
+	s.notify(leave.EmployeeUserID, NotificationLeaveRejected, "Leave Request Rejected",
+		fmt.Sprintf("Your %s request (%s - %s) has been rejected by %s.", leave.LeaveTypeName, leave.StartDate.Format(helper.DateLayout), leave.EndDate.Format(helper.DateLayout), leave.ApproverName), leave)
+
+	return leave.ToLeaveResponse(), nil
+}
+
+func (s *leaveService) Cancel(c context.Context, userID, id string) (web.LeaveResponse, error) {
+	leave, err := s.findLeave(c, id)
+	if err != nil {
+		return web.LeaveResponse{}, err
+	}
+
+	employee, err := findEmployeeByUser(c, s.employeeRepository, userID)
+	if err != nil {
+		return web.LeaveResponse{}, err
+	}
+	if leave.EmployeeID != employee.ID {
+		return web.LeaveResponse{}, exception.ErrUnauthorized("Only the requester can cancel the leave request.")
+	}
+
+	// refund the reserved or used days to the balance
+	var used, pending float64
+	switch {
+	case leave.Status == domain.LeaveStatusPending:
+		pending = -leave.Days
+	case leave.Status == domain.LeaveStatusApproved && helper.Today().Before(leave.StartDate):
+		used = -leave.Days
+	case leave.Status == domain.LeaveStatusApproved:
+		return web.LeaveResponse{}, exception.ErrBadRequest("Approved leave request can only be cancelled before the start date.")
+	default:
+		return web.LeaveResponse{}, exception.ErrBadRequest(fmt.Sprintf("Leave request is already %s.", leave.Status))
+	}
+
+	previousStatus := leave.Status
+	cancelledAt := time.Now()
+	leave.Status = domain.LeaveStatusCancelled
+	leave.CancelledAt = &cancelledAt
+	leave.UpdatedAt = cancelledAt
+
+	if err := s.updateStatus(c, leave, previousStatus, used, pending); err != nil {
+		s.logger.Infow(err.Error(), "Cancel Leave Error")
+		return web.LeaveResponse{}, err
+	}
+	if previousStatus == domain.LeaveStatusPending {
+		if err := s.approvalService.CancelEntity(c, domain.ApprovalEntityLeave, leave.ID); err != nil {
+			s.logger.Errorw("Cancel Leave Approval Error", "leave_id", leave.ID, "error", err.Error())
+		}
+	}
+
+	// the approver is notified only when the leave request was already approved
+	if previousStatus == domain.LeaveStatusApproved && leave.ApproverID != nil {
+		if approver, err := s.employeeRepository.FindById(c, *leave.ApproverID); err == nil {
+			s.notify(approver.UserID, NotificationLeaveCancelled, "Leave Request Cancelled",
+				fmt.Sprintf("%s cancelled the approved %s (%s - %s).", leave.EmployeeName, leave.LeaveTypeName, leave.StartDate.Format(helper.DateLayout), leave.EndDate.Format(helper.DateLayout)), leave)
+		}
+	}
+
+	return leave.ToLeaveResponse(), nil
+}
+
+func (s *leaveService) FindAllLeave(c context.Context, filter web.LeaveQueryFilter) ([]web.LeaveResponse, int, error) {
+	return s.findAllLeave(c, domain.ToDomainLeaveQueryFilter(filter))
+}
+
+func (s *leaveService) FindMyLeave(c context.Context, userID string, filter web.LeaveQueryFilter) ([]web.LeaveResponse, int, error) {
+	employee, err := findEmployeeByUser(c, s.employeeRepository, userID)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	domainFilter := domain.ToDomainLeaveQueryFilter(filter)
+	domainFilter.EmployeeID = employee.ID
+
+	return s.findAllLeave(c, domainFilter)
+}
+
+func (s *leaveService) FindPendingApprovals(c context.Context, userID string, filter web.LeaveQueryFilter) ([]web.LeaveResponse, int, error) {
+	approver, err := findEmployeeByUser(c, s.employeeRepository, userID)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	domainFilter := domain.ToDomainLeaveQueryFilter(filter)
+	domainFilter.ApproverID = approver.ID
+	domainFilter.Status = domain.LeaveStatusPending
+
+	return s.findAllLeave(c, domainFilter)
+}
+
+func (s *leaveService) FindById(c context.Context, id string) (web.LeaveResponse, error) {
+	leave, err := s.findLeave(c, id)
+	if err != nil {
+		return web.LeaveResponse{}, err
+	}
+
+	return leave.ToLeaveResponse(), nil
+}
+
+func (s *leaveService) FindMyBalances(c context.Context, userID string, filter web.LeaveBalanceQueryFilter) ([]web.LeaveBalanceResponse, error) {
+	employee, err := findEmployeeByUser(c, s.employeeRepository, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.findAllBalance(c, employee, filter.Year)
+}
+
+func (s *leaveService) FindBalances(c context.Context, employeeID string, filter web.LeaveBalanceQueryFilter) ([]web.LeaveBalanceResponse, error) {
+	employee, err := findEmployee(c, s.employeeRepository, employeeID)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.findAllBalance(c, employee, filter.Year)
+}
+
+// approve or reject the pending leave request, only the approver can decide it
+func (s *leaveService) decide(c context.Context, userID, id, status, note string) (domain.Leave, error) {
+	leave, err := s.findLeave(c, id)
+	if err != nil {
+		return domain.Leave{}, err
+	}
+
+	approver, err := findEmployeeByUser(c, s.employeeRepository, userID)
+	if err != nil {
+		return domain.Leave{}, err
+	}
+	if leave.ApproverID == nil || *leave.ApproverID != approver.ID {
+		return domain.Leave{}, exception.ErrUnauthorized("Only the approver can decide the leave request.")
+	}
+	if leave.Status != domain.LeaveStatusPending {
+		return domain.Leave{}, exception.ErrBadRequest(fmt.Sprintf("Leave request is already %s.", leave.Status))
+	}
+
+	// the leave request with a pending approval is decided through the approval workflow
+	governed, err := s.approvalService.IsPending(c, domain.ApprovalEntityLeave, id)
+	if err != nil {
+		return domain.Leave{}, err
+	}
+	if governed {
+		return domain.Leave{}, exception.ErrBadRequest("Leave request is decided through its approval.")
+	}
+
+	// the reserved days are moved to 'used' when approved and released when rejected
+	used, pending := 0.0, -leave.Days
+	if status == domain.LeaveStatusApproved {
+		used = leave.Days
+	}
+
+	decidedAt := time.Now()
+	leave.Status = status
+	leave.DecisionNote = note
+	leave.DecidedAt = &decidedAt
+	leave.UpdatedAt = decidedAt
+
+	if err := s.updateStatus(c, leave, domain.LeaveStatusPending, used, pending); err != nil {
+		s.logger.Infow(err.Error(), "Decide Leave Error")
+		return domain.Leave{}, err
+	}
+
+	return leave, nil
+}
+
+// FindApprovalSubject finds the requester of the pending leave request, the leave request has no amount
+func (s *leaveService) FindApprovalSubject(c context.Context, id string) (domain.ApprovalSubject, error) {
+	leave, err := s.findLeave(c, id)
+	if err != nil {
+		return domain.ApprovalSubject{}, err
+	}
+	if leave.Status != domain.LeaveStatusPending {
+		return domain.ApprovalSubject{}, exception.ErrBadRequest(fmt.Sprintf("Leave request is already %s.", leave.Status))
+	}
+
+	return domain.ApprovalSubject{
+		RequesterID: leave.EmployeeID,
+		Title:       fmt.Sprintf("%s %s - %s", leave.LeaveTypeName, leave.StartDate.Format(helper.DateLayout), leave.EndDate.Format(helper.DateLayout)),
+	}, nil
+}
+
+// ApplyApproval decides the pending leave request with the completed approval, the reserved days are moved to 'used'
+// when it is approved and released otherwise
+func (s *leaveService) ApplyApproval(c context.Context, approval domain.ApprovalRequest) error {
+	leave, err := s.findLeave(c, approval.EntityID)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	used, pending := 0.0, -leave.Days
+	switch approval.Status {
+	case domain.ApprovalStatusApproved:
+		used = leave.Days
+		leave.Status = domain.LeaveStatusApproved
+		leave.DecidedAt = &now
+	case domain.ApprovalStatusRejected:
+		leave.Status = domain.LeaveStatusRejected
+		leave.DecidedAt = &now
+	default:
+		leave.Status = domain.LeaveStatusCancelled
+		leave.CancelledAt = &now
+	}
+	leave.DecisionNote = approval.DecisionNote()
+	leave.UpdatedAt = now
+
+	return s.updateStatus(c, leave, domain.LeaveStatusPending, used, pending)
+}
+
+func (s *leaveService) findAllLeave(c context.Context, filter domain.LeaveQueryFilter) (result []web.LeaveResponse, totalData int, err error) {
+	leaves, err := s.leaveRepository.FindAllLeave(c, filter)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	// get total-data
+	totalData, err = s.leaveRepository.CountAllLeave(c, filter)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	// convert to web.LeaveResponse
+	result = []web.LeaveResponse{}
+	for _, leave := range leaves {
+		result = append(result, leave.ToLeaveResponse())
+	}
+
+	return result, totalData, nil
+}
+
+// find the balances of every leave type of the employee in the year, the current year is used when it's empty
+func (s *leaveService) findAllBalance(c context.Context, employee domain.Employee, year int) ([]web.LeaveBalanceResponse, error) {
+	if year == 0 {
+		year = helper.Today().Year()
+	}
+
+	leaveTypes, err := s.leaveTypeRepository.FindAllLeaveType(c)
+	if err != nil {
+		return nil, err
+	}
+
+	result := []web.LeaveBalanceResponse{}
+	for _, leaveType := range leaveTypes {
+		balance, err := s.findBalance(c, employee, leaveType, year)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, balance.ToLeaveBalanceResponse())
+	}
+
+	return result, nil
+}
+
+// find the balance of the leave type in the year with the calculated entitlement.
+// The balance that doesn't exist yet is initialized with the days carried over from the previous year,
+// it is stored when the first leave request of the year is created.
+func (s *leaveService) findBalance(c context.Context, employee domain.Employee, leaveType domain.LeaveType, year int) (domain.LeaveBalance, error) {
+	// the entitlement of a future year is counted from its first day
+	asOf := helper.Today()
+	if asOf.Year() < year {
+		asOf = time.Date(year, 1, 1, 0, 0, 0, 0, time.UTC)
+	}
+
+	balance, err := s.leaveRepository.FindBalance(c, employee.ID, leaveType.ID, year)
+	if err != nil {
+		if !strings.Contains(err.Error(), "no rows") {
+			return domain.LeaveBalance{}, err
+		}
+
+		balance = domain.LeaveBalance{
+			ID:            uuid.New().String(),
+			EmployeeID:    employee.ID,
+			LeaveTypeID:   leaveType.ID,
+			Year:          year,
+			CreatedAt:     time.Now(),
+			UpdatedAt:     time.Now(),
+			LeaveTypeCode: leaveType.Code,
+			LeaveTypeName: leaveType.Name,
+		}
+
+		// carry over the remaining days of the previous year, limited by the leave type
+		previous, err := s.leaveRepository.FindBalance(c, employee.ID, leaveType.ID, year-1)
+		if err != nil && !strings.Contains(err.Error(), "no rows") {
+			return domain.LeaveBalance{}, err
+		}
+		if err == nil {
+			previous.Entitled = leaveType.Entitlement(employee.HireDate, year-1, asOf)
+			balance.CarriedOver = math.Max(0, math.Min(previous.Available(), leaveType.MaxCarryOver))
+		}
+	}
+	balance.Entitled = leaveType.Entitlement(employee.HireDate, year, asOf)
+
+	return balance, nil
+}
+
+// update the leave request that still has the given status, the 'no rows' error means
+// another request has changed the status in the meantime, e.g. a double approval
+func (s *leaveService) updateStatus(c context.Context, leave domain.Leave, status string, used, pending float64) error {
+	if err := s.leaveRepository.UpdateStatus(c, leave, status, used, pending); err != nil {
+		if strings.Contains(err.Error(), "no rows") {
+			return exception.ErrBadRequest(fmt.Sprintf("Leave request is no longer %s.", status))
+		}
+		return err
+	}
+
+	return nil
+}
+
+// find the leave request by id and convert the 'no rows' error to not found error
+func (s *leaveService) findLeave(c context.Context, id string) (domain.Leave, error) {
+	leave, err := s.leaveRepository.FindById(c, id)
+	if err != nil {
+		if strings.Contains(err.Error(), "no rows") {
+			return domain.Leave{}, exception.ErrNotFound(fmt.Sprintf("Leave request %s not found", id))
+		}
+		return domain.Leave{}, err
+	}
+
+	return leave, nil
+}
+
+// produce the notification of the leave request to the user
+func (s *leaveService) notify(userID, notificationType, title, message string, leave domain.Leave) {
+	kafkaNotificationMessage := kafkamodel.NewKafkaNotificationMessage(userID, notificationType, title, message, map[string]interface{}{
+		"leave_id":   leave.ID,
+		"status":     leave.Status,
+		"start_date": leave.StartDate.Format(helper.DateLayout),
+		"end_date":   leave.EndDate.Format(helper.DateLayout),
+		"days":       leave.Days,
+	})
+	go s.kafkaProducerService.Produce(kafkaNotificationMessage, "POST.NOTIFICATION", config.KafkaTopicNotification)
+}
@@ -0,0 +1,146 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/iqbaludinm/hr-microservice/user-service/exception"
+	"github.com/iqbaludinm/hr-microservice/user-service/model/domain"
+	"github.com/iqbaludinm/hr-microservice/user-service/model/web"
+	"github.com/iqbaludinm/hr-microservice/user-service/repository"
+	"go.uber.org/zap"
+)
+
+type LeaveTypeService interface {
+	// With Transaction
+	CreateLeaveType(ctx context.Context, request web.CreateLeaveTypeRequest) (web.LeaveTypeResponse, error)
+	UpdateLeaveType(ctx context.Context, id string, request web.UpdateLeaveTypeRequest) (web.LeaveTypeResponse, error)
+	Delete(ctx context.Context, id string) error
+
+	// Without Transaction
+	FindAllLeaveType(ctx context.Context) ([]web.LeaveTypeResponse, error)
+	FindById(ctx context.Context, id string) (web.LeaveTypeResponse, error)
+}
+
+type leaveTypeService struct {
+	leaveTypeRepository repository.LeaveTypeRepository
+	logger              *zap.SugaredLogger
+}
+
+func NewLeaveTypeService(leaveTypeRepository repository.LeaveTypeRepository, logger *zap.SugaredLogger) LeaveTypeService {
+	return &leaveTypeService{
+		leaveTypeRepository: leaveTypeRepository,
+		logger:              logger,
+	}
+}
+
+func (s *leaveTypeService) CreateLeaveType(c context.Context, request web.CreateLeaveTypeRequest) (web.LeaveTypeResponse, error) {
+	// convert to domain or model leave type
+	leaveType := domain.ToDomainLeaveType(request)
+	leaveType.ID = uuid.New().String()
+	leaveType.Code = strings.ToUpper(leaveType.Code)
+	leaveType.CreatedAt = time.Now()
+	leaveType.UpdatedAt = time.Now()
+
+	// call the repo for inserting to db
+	if err := s.leaveTypeRepository.CreateLeaveType(c, leaveType); err != nil {
+		s.logger.Infow(err.Error(), "Create Leave Type Error")
+		return web.LeaveTypeResponse{}, toLeaveTypeUniqueError(err)
+	}
+
+	return leaveType.ToLeaveTypeResponse(), nil
+}
+
+func (s *leaveTypeService) UpdateLeaveType(c context.Context, id string, request web.UpdateLeaveTypeRequest) (web.LeaveTypeResponse, error) {
+	leaveType, err := findLeaveType(c, s.leaveTypeRepository, id)
+	if err != nil {
+		return web.LeaveTypeResponse{}, err
+	}
+
+	// only the filled fields are updated
+	if request.Name != "" {
+		leaveType.Name = request.Name
+	}
+	if request.AccrualMethod != "" {
+		leaveType.AccrualMethod = request.AccrualMethod
+	}
+	if request.AnnualQuota != nil {
+		leaveType.AnnualQuota = *request.AnnualQuota
+	}
+	if request.MaxCarryOver != nil {
+		leaveType.MaxCarryOver = *request.MaxCarryOver
+	}
+	if request.RequiresBalance != nil {
+		leaveType.RequiresBalance = *request.RequiresBalance
+	}
+	if request.AllowHalfDay != nil {
+		leaveType.AllowHalfDay = *request.AllowHalfDay
+	}
+	if request.IsPaid != nil {
+		leaveType.IsPaid = *request.IsPaid
+	}
+	leaveType.UpdatedAt = time.Now()
+
+	if err := s.leaveTypeRepository.UpdateLeaveType(c, id, leaveType); err != nil {
+		s.logger.Infow(err.Error(), "Update Leave Type Error")
+		return web.LeaveTypeResponse{}, err
+	}
+
+	return leaveType.ToLeaveTypeResponse(), nil
+}
+
+func (s *leaveTypeService) Delete(c context.Context, id string) error {
+	if _, err := findLeaveType(c, s.leaveTypeRepository, id); err != nil {
+		return err
+	}
+
+	return s.leaveTypeRepository.Delete(c, id)
+}
+
+func (s *leaveTypeService) FindAllLeaveType(c context.Context) ([]web.LeaveTypeResponse, error) {
+	leaveTypes, err := s.leaveTypeRepository.FindAllLeaveType(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// convert to web.LeaveTypeResponse
+	result := []web.LeaveTypeResponse{}
+	for _, leaveType := range leaveTypes {
+		result = append(result, leaveType.ToLeaveTypeResponse())
+	}
+
+	return result, nil
+}
+
+func (s *leaveTypeService) FindById(c context.Context, id string) (web.LeaveTypeResponse, error) {
+	leaveType, err := findLeaveType(c, s.leaveTypeRepository, id)
+	if err != nil {
+		return web.LeaveTypeResponse{}, err
+	}
+
+	return leaveType.ToLeaveTypeResponse(), nil
+}
+
+// find the leave type by id and convert the 'no rows' error to not found error
+func findLeaveType(c context.Context, leaveTypeRepository repository.LeaveTypeRepository, id string) (domain.LeaveType, error) {
+	leaveType, err := leaveTypeRepository.FindById(c, id)
+	if err != nil {
+		if strings.Contains(err.Error(), "no rows") {
+			return domain.LeaveType{}, exception.ErrNotFound(fmt.Sprintf("Leave type %s not found", id))
+		}
+		return domain.LeaveType{}, err
+	}
+
+	return leaveType, nil
+}
+
+// convert the unique constraint error of the 'leave_types' table to bad request error
+func toLeaveTypeUniqueError(err error) error {
+	if strings.Contains(err.Error(), "unique") && strings.Contains(err.Error(), "leave_types_code_key") {
+		return exception.ErrBadRequest("Leave type code already exist.")
+	}
+	return err
+}
@@ -0,0 +1,505 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"embed"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/iqbaludinm/hr-microservice/user-service/config"
+	"github.com/iqbaludinm/hr-microservice/user-service/exception"
+	"github.com/iqbaludinm/hr-microservice/user-service/helper"
+	"github.com/iqbaludinm/hr-microservice/user-service/model/domain"
+	"github.com/iqbaludinm/hr-microservice/user-service/model/kafkamodel"
+	"github.com/iqbaludinm/hr-microservice/user-service/model/web"
+	"github.com/iqbaludinm/hr-microservice/user-service/repository"
+	"github.com/iqbaludinm/hr-microservice/user-service/service/producers"
+	"go.uber.org/zap"
+)
+
+// Type of the notifications produced by the offboarding service.
+const (
+	NotificationOffboardingTaskAssigned = "OFFBOARDING_TASK_ASSIGNED"
+)
+
+type OffboardingService interface {
+	// With Transaction
+	Create(ctx context.Context, userID string, request web.OffboardingRequest) (web.OffboardingResponse, error)
+	Update(ctx context.Context, id string, request web.UpdateOffboardingRequest) (web.OffboardingResponse, error)
+	Cancel(ctx context.Context, id string) (web.OffboardingResponse, error)
+	UpdateTask(ctx context.Context, userID, id string, seq int, request web.UpdateOffboardingTaskRequest) (web.OffboardingResponse, error)
+	Clearance(ctx context.Context, id string) ([]byte, string, error)
+	DeactivateDue(ctx context.Context) error
+
+	// Without Transaction
+	FindAll(ctx context.Context, filter web.OffboardingQueryFilter) ([]web.OffboardingResponse, int, error)
+	FindMyTasks(ctx context.Context, userID string) ([]web.OffboardingTaskResponse, error)
+	FindById(ctx context.Context, id string) (web.OffboardingResponse, error)
+}
+
+type offboardingService struct {
+	offboardingRepository repository.OffboardingRepository
+	employeeRepository    repository.EmployeeRepository
+	userRepository        repository.UserRepository
+	assetRepository       repository.AssetRepository
+	storage               helper.Storage
+	templateFS            embed.FS
+	pdfRenderer           helper.PDFRenderer
+	kafkaProducerService  producers.KafkaProducerService
+	logger                *zap.SugaredLogger
+}
+
+func NewOffboardingService(offboardingRepository repository.OffboardingRepository, employeeRepository repository.EmployeeRepository, userRepository repository.UserRepository, assetRepository repository.AssetRepository, storage helper.Storage, templateFS embed.FS, pdfRenderer helper.PDFRenderer, kafkaProducerService producers.KafkaProducerService, logger *zap.SugaredLogger) OffboardingService {
+	return &offboardingService{
+		offboardingRepository: offboardingRepository,
+		employeeRepository:    employeeRepository,
+		userRepository:        userRepository,
+		assetRepository:       assetRepository,
+		storage:               storage,
+		templateFS:            templateFS,
+		pdfRenderer:           pdfRenderer,
+		kafkaProducerService:  kafkaProducerService,
+		logger:                logger,
+	}
+}
+
+// create the offboarding of the employee with the exit checklist and notify the assignees of their tasks. The
+// 'hr' tasks are assigned to the logged in user when the HR assignee is not filled.
+func (s *offboardingService) Create(c context.Context, userID string, request web.OffboardingRequest) (web.OffboardingResponse, error) {
+	employee, err := findEmployee(c, s.employeeRepository, request.EmployeeID)
+	if err != nil {
+		return web.OffboardingResponse{}, err
+	}
+	if leavingStatus(employee.Status) {
+		return web.OffboardingResponse{}, exception.ErrBadRequest(fmt.Sprintf("Employee is %s.", employee.Status))
+	}
+
+	if _, err := s.offboardingRepository.FindByEmployee(c, employee.ID); err == nil {
+		return web.OffboardingResponse{}, exception.ErrBadRequest("Employee already has an offboarding.")
+	} else if !strings.Contains(err.Error(), "no rows") {
+		return web.OffboardingResponse{}, err
+	}
+
+	// convert to domain or model offboarding
+	offboarding := domain.ToDomainOffboarding(request)
+	if offboarding.LastWorkingDay.Before(offboarding.NoticeDate) {
+		return web.OffboardingResponse{}, exception.ErrBadRequest("Last working day can't be before the notice date.")
+	}
+
+	hrAssigneeID := request.HRAssigneeID
+	if hrAssigneeID == nil || *hrAssigneeID == "" {
+		hrAssigneeID = nil
+		if creator, err := s.employeeRepository.FindByUserId(c, userID); err == nil {
+			hrAssigneeID = &creator.ID
+		}
+	}
+	itAssigneeID := request.ITAssigneeID
+	if itAssigneeID != nil && *itAssigneeID == "" {
+		itAssigneeID = nil
+	}
+	offboarding.AddExitTasks(employee, hrAssigneeID, itAssigneeID, request.Tasks)
+	if err := s.validateAssignees(c, employee, offboarding); err != nil {
+		return web.OffboardingResponse{}, err
+	}
+
+	offboarding.ID = uuid.New().String()
+	offboarding.CreatedBy = &userID
+	offboarding.CreatedAt = time.Now()
+	offboarding.UpdatedAt = time.Now()
+
+	// call the repo for inserting to db
+	if err := s.offboardingRepository.CreateOffboarding(c, offboarding); err != nil {
+		s.logger.Infow(err.Error(), "Create Offboarding Error")
+		if strings.Contains(err.Error(), "unique") && strings.Contains(err.Error(), "offboardings_employee_id_idx") {
+			return web.OffboardingResponse{}, exception.ErrBadRequest("Employee already has an offboarding.")
+		}
+		return web.OffboardingResponse{}, err
+	}
+
+	// get or returning the offboarding have created to db
+	newOffboarding, err := s.offboardingRepository.FindById(c, offboarding.ID)
+	if err != nil {
+		return web.OffboardingResponse{}, exception.ErrInternalServer(fmt.Sprintf("Successfully created offboarding, but failed to get the offboarding have created. Error: %s", err.Error()))
+	}
+
+	// notify each assignee once with the number of their tasks and the nearest due date
+	assigned := map[string][]domain.OffboardingTask{}
+	var assignees []string
+	for _, task := range newOffboarding.Tasks {
+		if task.AssigneeUserID == nil {
+			continue
+		}
+		if _, ok := assigned[*task.AssigneeUserID]; !ok {
+			assignees = append(assignees, *task.AssigneeUserID)
+		}
+		assigned[*task.AssigneeUserID] = append(assigned[*task.AssigneeUserID], task)
+	}
+	for _, assigneeUserID := range assignees {
+		tasks := assigned[assigneeUserID]
+		first := tasks[0]
+		for _, task := range tasks[1:] {
+			if task.DueDate.Before(first.DueDate) {
+				first = task
+			}
+		}
+
+		message := fmt.Sprintf("You have %d exit task(s) for %s (%s), the first one '%s' is due on %s.", len(tasks), newOffboarding.EmployeeName, newOffboarding.EmployeeNumber, first.Title, first.DueDate.Format(helper.DateLayout))
+		kafkaNotificationMessage := kafkamodel.NewKafkaNotificationMessage(assigneeUserID, NotificationOffboardingTaskAssigned, "Exit Task Assigned", message, map[string]interface{}{
+			"offboarding_id": first.OffboardingID,
+			"employee_id":    first.EmployeeID,
+			"seq":            first.Seq,
+			"due_date":       first.DueDate.Format(helper.DateLayout),
+		})
+		go s.kafkaProducerService.Produce(kafkaNotificationMessage, "POST.NOTIFICATION", config.KafkaTopicNotification)
+	}
+
+	return newOffboarding.ToOffboardingResponse(), nil
+}
+
+// update the offboarding before the account of the employee is deactivated, the due date of the pending tasks
+// follows the last working day
+func (s *offboardingService) Update(c context.Context, id string, request web.UpdateOffboardingRequest) (web.OffboardingResponse, error) {
+	offboarding, err := findOffboarding(c, s.offboardingRepository, id)
+	if err != nil {
+		return web.OffboardingResponse{}, err
+	}
+	if err := editableOffboarding(offboarding); err != nil {
+		return web.OffboardingResponse{}, err
+	}
+
+	lastWorkingDay, _ := helper.ParseDate(request.LastWorkingDay)
+	if lastWorkingDay.Before(offboarding.NoticeDate) {
+		return web.OffboardingResponse{}, exception.ErrBadRequest("Last working day can't be before the notice date.")
+	}
+
+	shiftDays := int(lastWorkingDay.Sub(offboarding.LastWorkingDay).Hours() / 24)
+	offboarding.LastWorkingDay = lastWorkingDay
+	offboarding.Reason = request.Reason
+	if request.RehireEligible != nil {
+		offboarding.RehireEligible = *request.RehireEligible
+	}
+	offboarding.UpdatedAt = time.Now()
+
+	if err := s.offboardingRepository.UpdateOffboarding(c, offboarding, shiftDays); err != nil {
+		s.logger.Infow(err.Error(), "Update Offboarding Error")
+		return web.OffboardingResponse{}, err
+	}
+
+	return s.FindById(c, id)
+}
+
+// cancel the offboarding before the account of the employee is deactivated, e.g. the resignation is withdrawn
+func (s *offboardingService) Cancel(c context.Context, id string) (web.OffboardingResponse, error) {
+	offboarding, err := findOffboarding(c, s.offboardingRepository, id)
+	if err != nil {
+		return web.OffboardingResponse{}, err
+	}
+	if err := editableOffboarding(offboarding); err != nil {
+		return web.OffboardingResponse{}, err
+	}
+
+	cancelledAt := time.Now()
+	offboarding.Status = domain.OffboardingStatusCancelled
+	offboarding.CancelledAt = &cancelledAt
+	offboarding.UpdatedAt = time.Now()
+
+	if err := s.offboardingRepository.UpdateStatus(c, offboarding); err != nil {
+		s.logger.Infow(err.Error(), "Cancel Offboarding Error")
+		return web.OffboardingResponse{}, err
+	}
+
+	return s.FindById(c, id)
+}
+
+// update the progress of the exit task, the offboarding is completed when its last pending task is done or skipped.
+// The asset return can't be skipped and is only done when the employee holds no asset.
+func (s *offboardingService) UpdateTask(c context.Context, userID, id string, seq int, request web.UpdateOffboardingTaskRequest) (web.OffboardingResponse, error) {
+	offboarding, err := findOffboarding(c, s.offboardingRepository, id)
+	if err != nil {
+		return web.OffboardingResponse{}, err
+	}
+	if offboarding.Status != domain.OffboardingStatusInProgress {
+		return web.OffboardingResponse{}, exception.ErrBadRequest(fmt.Sprintf("Offboarding is %s.", offboarding.Status))
+	}
+
+	index := -1
+	for i, task := range offboarding.Tasks {
+		if task.Seq == seq {
+			index = i
+			break
+		}
+	}
+	if index < 0 {
+		return web.OffboardingResponse{}, exception.ErrNotFound(fmt.Sprintf("Offboarding task %d not found", seq))
+	}
+
+	task := offboarding.Tasks[index]
+	if task.Category == domain.OffboardingCategoryAssetReturn {
+		if request.Status == domain.OffboardingTaskSkipped {
+			return web.OffboardingResponse{}, exception.ErrBadRequest("The asset return task can't be skipped.")
+		}
+		if request.Status == domain.OffboardingTaskDone {
+			if err := validateAssetsReturned(c, s.assetRepository, offboarding.EmployeeID); err != nil {
+				return web.OffboardingResponse{}, err
+			}
+		}
+	}
+
+	task.Status = request.Status
+	task.Note = request.Note
+	task.CompletedBy = nil
+	task.CompletedAt = nil
+	if task.Status != domain.OffboardingTaskPending {
+		completedAt := time.Now()
+		task.CompletedBy = &userID
+		task.CompletedAt = &completedAt
+	}
+	offboarding.Tasks[index] = task
+
+	if offboarding.Finished() {
+		completedAt := time.Now()
+		offboarding.Status = domain.OffboardingStatusCompleted
+		offboarding.CompletedAt = &completedAt
+	}
+	offboarding.UpdatedAt = time.Now()
+
+	if err := s.offboardingRepository.UpdateTask(c, task, offboarding); err != nil {
+		s.logger.Infow(err.Error(), "Update Offboarding Task Error")
+		return web.OffboardingResponse{}, err
+	}
+
+	return s.FindById(c, id)
+}
+
+// Clearance returns the clearance document of the completed offboarding with its file name. The document is
+// rendered once and stored, the stored document is returned afterwards.
+func (s *offboardingService) Clearance(c context.Context, id string) ([]byte, string, error) {
+	offboarding, err := findOffboarding(c, s.offboardingRepository, id)
+	if err != nil {
+		return nil, "", err
+	}
+	if offboarding.Status != domain.OffboardingStatusCompleted {
+		return nil, "", exception.ErrBadRequest("The clearance document is only available for a completed offboarding.")
+	}
+
+	pdf, err := s.clearance(c, &offboarding)
+	if err != nil {
+		s.logger.Infow(err.Error(), "Generate Clearance Document Error")
+		return nil, "", err
+	}
+
+	return pdf.Bytes(), fmt.Sprintf("clearance-%s.pdf", offboarding.EmployeeNumber), nil
+}
+
+// deactivate the account of the employees whose last working day has passed. The status of the employee is set to
+// resigned or terminated, and the user is deleted so the sessions are revoked and the auth and profile services
+// deactivate the account from the 'DELETE.USER' message. It is run by the scheduler.
+func (s *offboardingService) DeactivateDue(c context.Context) error {
+	offboardings, err := s.offboardingRepository.FindDue(c, helper.Today())
+	if err != nil {
+		return err
+	}
+
+	for _, offboarding := range offboardings {
+		if err := s.deactivate(c, offboarding); err != nil {
+			s.logger.Errorw("Deactivate Offboarded Employee Error", "offboarding_id", offboarding.ID, "error", err.Error())
+			continue
+		}
+	}
+
+	return nil
+}
+
+func (s *offboardingService) FindAll(c context.Context, filter web.OffboardingQueryFilter) (result []web.OffboardingResponse, totalData int, err error) {
+	offboardings, err := s.offboardingRepository.FindAll(c, domain.ToDomainOffboardingQueryFilter(filter))
+	if err != nil {
+		return nil, 0, err
+	}
+
+	// get total-data
+	totalData, err = s.offboardingRepository.CountAll(c, domain.ToDomainOffboardingQueryFilter(filter))
+	if err != nil {
+		return nil, 0, err
+	}
+
+	// convert to web.OffboardingResponse
+	result = []web.OffboardingResponse{}
+	for _, offboarding := range offboardings {
+		result = append(result, offboarding.ToOffboardingResponse())
+	}
+
+	return result, totalData, nil
+}
+
+// find the exit tasks assigned to the logged in user, e.g. the tasks of the HR officer or the manager of the
+// leaving employees
+func (s *offboardingService) FindMyTasks(c context.Context, userID string) ([]web.OffboardingTaskResponse, error) {
+	employee, err := findEmployeeByUser(c, s.employeeRepository, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	tasks, err := s.offboardingRepository.FindTasksByAssignee(c, employee.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	// convert to web.OffboardingTaskResponse
+	result := []web.OffboardingTaskResponse{}
+	for _, task := range tasks {
+		result = append(result, task.ToOffboardingTaskResponse())
+	}
+
+	return result, nil
+}
+
+func (s *offboardingService) FindById(c context.Context, id string) (web.OffboardingResponse, error) {
+	offboarding, err := findOffboarding(c, s.offboardingRepository, id)
+	if err != nil {
+		return web.OffboardingResponse{}, err
+	}
+
+	return offboarding.ToOffboardingResponse(), nil
+}
+
+// validate the assignees of the tasks are employees other than the leaving employee. The assignee of the 'manager'
+// and 'employee' tasks is resolved from the employee.
+func (s *offboardingService) validateAssignees(c context.Context, employee domain.Employee, offboarding domain.Offboarding) error {
+	validated := map[string]bool{}
+	for _, task := range offboarding.Tasks {
+		if task.AssigneeID == nil || task.AssigneeRole == domain.OffboardingAssigneeEmployee || task.AssigneeRole == domain.OffboardingAssigneeManager {
+			continue
+		}
+		if *task.AssigneeID == employee.ID {
+			return exception.ErrBadRequest(fmt.Sprintf("The '%s' task can't be assigned to the leaving employee.", task.Title))
+		}
+		if validated[*task.AssigneeID] {
+			continue
+		}
+		if _, err := findEmployee(c, s.employeeRepository, *task.AssigneeID); err != nil {
+			return err
+		}
+		validated[*task.AssigneeID] = true
+	}
+
+	return nil
+}
+
+// set the status of the employee, delete the user and mark the offboarding as deactivated
+func (s *offboardingService) deactivate(c context.Context, offboarding domain.Offboarding) error {
+	employee, err := findEmployee(c, s.employeeRepository, offboarding.EmployeeID)
+	if err != nil {
+		return err
+	}
+
+	if !leavingStatus(employee.Status) {
+		employee.Status = offboarding.EmployeeStatus()
+		employee.UpdatedAt = time.Now()
+		if err := s.employeeRepository.UpdateEmployee(c, employee.ID, employee); err != nil {
+			return err
+		}
+
+		updatedEmployee, err := findEmployee(c, s.employeeRepository, employee.ID)
+		if err != nil {
+			return err
+		}
+
+		// produce kafka update-employee message
+		kafkaEmployeeMessage := kafkamodel.NewKafkaEmployeeMessage(updatedEmployee)
+		go s.kafkaProducerService.Produce(kafkaEmployeeMessage, "PUT.EMPLOYEE", config.KafkaTopic)
+	}
+
+	user, err := s.userRepository.FindUserNotDeleteByQueryTx(c, "id", offboarding.EmployeeUserID)
+	if err != nil && !strings.Contains(err.Error(), "no rows") {
+		return err
+	}
+	if err == nil {
+		if err := s.userRepository.Delete(c, user.ID); err != nil {
+			return err
+		}
+
+		// produce kafka delete-user message, the auth and profile services deactivate the account
+		deletedAt := time.Now()
+		user.DeletedAt = &deletedAt
+		kafkaUserMessage := kafkamodel.NewKafkaUserMessage(user)
+		go s.kafkaProducerService.Produce(kafkaUserMessage, "DELETE.USER", config.KafkaTopic)
+	}
+
+	return s.offboardingRepository.UpdateDeactivated(c, offboarding.ID, time.Now())
+}
+
+func (s *offboardingService) clearance(c context.Context, offboarding *domain.Offboarding) (bytes.Buffer, error) {
+	if offboarding.ClearanceDocumentKey != nil {
+		pdf, err := s.storage.Get(c, *offboarding.ClearanceDocumentKey)
+		if err == nil {
+			return *bytes.NewBuffer(pdf), nil
+		}
+		if !errors.Is(err, helper.ErrObjectNotFound) {
+			return bytes.Buffer{}, err
+		}
+	}
+
+	employee, err := findEmployee(c, s.employeeRepository, offboarding.EmployeeID)
+	if err != nil {
+		return bytes.Buffer{}, err
+	}
+
+	offboardingType := "Pengunduran Diri"
+	if offboarding.Type == domain.OffboardingTypeTermination {
+		offboardingType = "Pemutusan Hubungan Kerja"
+	}
+	data := map[string]interface{}{
+		"Offboarding":    offboarding,
+		"Employee":       employee,
+		"Type":           offboardingType,
+		"HireDate":       helper.ParseTimeToFullIndonesian(employee.HireDate),
+		"NoticeDate":     helper.ParseTimeToFullIndonesian(offboarding.NoticeDate),
+		"LastWorkingDay": helper.ParseTimeToFullIndonesian(offboarding.LastWorkingDay),
+		"PrintedAt":      helper.ParseTimeToFullIndonesian(helper.Today()),
+	}
+
+	pdf, err := helper.RenderPDF(c, s.pdfRenderer, s.templateFS, "clearance.html", helper.PDFOptions{PaperSize: "A4"}, data)
+	if err != nil {
+		return bytes.Buffer{}, err
+	}
+
+	key := offboarding.NewClearanceDocumentKey()
+	if err := s.storage.Put(c, key, pdf.Bytes()); err != nil {
+		return bytes.Buffer{}, err
+	}
+
+	offboarding.ClearanceDocumentKey = &key
+	if err := s.offboardingRepository.UpdateClearanceDocument(c, offboarding.ID, key); err != nil {
+		return bytes.Buffer{}, err
+	}
+
+	return pdf, nil
+}
+
+// the offboarding can only be changed before the account of the employee is deactivated
+func editableOffboarding(offboarding domain.Offboarding) error {
+	if offboarding.Status == domain.OffboardingStatusCancelled {
+		return exception.ErrBadRequest("Offboarding is cancelled.")
+	}
+	if offboarding.DeactivatedAt != nil {
+		return exception.ErrBadRequest("The account of the employee is already deactivated.")
+	}
+	return nil
+}
+
+// find the offboarding by id with its tasks and convert the 'no rows' error to not found error
+func findOffboarding(c context.Context, offboardingRepository repository.OffboardingRepository, id string) (domain.Offboarding, error) {
+	offboarding, err := offboardingRepository.FindById(c, id)
+	if err != nil {
+		if strings.Contains(err.Error(), "no rows") {
+			return domain.Offboarding{}, exception.ErrNotFound(fmt.Sprintf("Offboarding %s not found", id))
+		}
+		return domain.Offboarding{}, err
+	}
+
+	return offboarding, nil
+}
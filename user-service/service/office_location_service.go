@@ -0,0 +1,170 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/iqbaludinm/hr-microservice/user-service/exception"
+	"github.com/iqbaludinm/hr-microservice/user-service/model/domain"
+	"github.com/iqbaludinm/hr-microservice/user-service/model/web"
+	"github.com/iqbaludinm/hr-microservice/user-service/repository"
+	"go.uber.org/zap"
+)
+
+type OfficeLocationService interface {
+	// With Transaction
+	CreateOfficeLocation(ctx context.Context, request web.CreateOfficeLocationRequest) (web.OfficeLocationResponse, error)
+	UpdateOfficeLocation(ctx context.Context, id string, request web.UpdateOfficeLocationRequest) (web.OfficeLocationResponse, error)
+	Delete(ctx context.Context, id string) error
+	AssignEmployees(ctx context.Context, id string, request web.AssignOfficeLocationRequest) error
+
+	// Without Transaction
+	FindAllOfficeLocation(ctx context.Context) ([]web.OfficeLocationResponse, error)
+	FindById(ctx context.Context, id string) (web.OfficeLocationResponse, error)
+}
+
+type officeLocationService struct {
+	officeLocationRepository repository.OfficeLocationRepository
+	workCalendarRepository   repository.WorkCalendarRepository
+	employeeRepository       repository.EmployeeRepository
+	logger                   *zap.SugaredLogger
+}
+
+func NewOfficeLocationService(officeLocationRepository repository.OfficeLocationRepository, workCalendarRepository repository.WorkCalendarRepository, employeeRepository repository.EmployeeRepository, logger *zap.SugaredLogger) OfficeLocationService {
+	return &officeLocationService{
+		officeLocationRepository: officeLocationRepository,
+		workCalendarRepository:   workCalendarRepository,
+		employeeRepository:       employeeRepository,
+		logger:                   logger,
+	}
+}
+
+func (s *officeLocationService) CreateOfficeLocation(c context.Context, request web.CreateOfficeLocationRequest) (web.OfficeLocationResponse, error) {
+	var workCalendarID *string
+	if request.WorkCalendarID != "" {
+		if _, err := findWorkCalendar(c, s.workCalendarRepository, request.WorkCalendarID); err != nil {
+			return web.OfficeLocationResponse{}, err
+		}
+		workCalendarID = &request.WorkCalendarID
+	}
+
+	// convert to domain or model office location
+	location := domain.OfficeLocation{
+		ID:             uuid.New().String(),
+		Name:           request.Name,
+		Latitude:       request.Latitude,
+		Longitude:      request.Longitude,
+		RadiusMeters:   request.RadiusMeters,
+		WorkCalendarID: workCalendarID,
+		CreatedAt:      time.Now(),
+		UpdatedAt:      time.Now(),
+	}
+
+	// call the repo for inserting to db
+	if err := s.officeLocationRepository.CreateOfficeLocation(c, location); err != nil {
+		s.logger.Infow(err.Error(), "Create Office Location Error")
+		return web.OfficeLocationResponse{}, err
+	}
+
+	return location.ToOfficeLocationResponse(), nil
+}
+
+func (s *officeLocationService) UpdateOfficeLocation(c context.Context, id string, request web.UpdateOfficeLocationRequest) (web.OfficeLocationResponse, error) {
+	location, err := findOfficeLocation(c, s.officeLocationRepository, id)
+	if err != nil {
+		return web.OfficeLocationResponse{}, err
+	}
+
+	// only the filled fields are updated
+	if request.Name != "" {
+		location.Name = request.Name
+	}
+	if request.Latitude != nil {
+		location.Latitude = *request.Latitude
+	}
+	if request.Longitude != nil {
+		location.Longitude = *request.Longitude
+	}
+	if request.RadiusMeters != nil {
+		location.RadiusMeters = *request.RadiusMeters
+	}
+	if request.WorkCalendarID != nil {
+		// the empty id removes the calendar of the location
+		location.WorkCalendarID = nil
+		if *request.WorkCalendarID != "" {
+			if _, err := findWorkCalendar(c, s.workCalendarRepository, *request.WorkCalendarID); err != nil {
+				return web.OfficeLocationResponse{}, err
+			}
+			location.WorkCalendarID = request.WorkCalendarID
+		}
+	}
+	location.UpdatedAt = time.Now()
+
+	if err := s.officeLocationRepository.UpdateOfficeLocation(c, id, location); err != nil {
+		s.logger.Infow(err.Error(), "Update Office Location Error")
+		return web.OfficeLocationResponse{}, err
+	}
+
+	return location.ToOfficeLocationResponse(), nil
+}
+
+func (s *officeLocationService) Delete(c context.Context, id string) error {
+	if _, err := findOfficeLocation(c, s.officeLocationRepository, id); err != nil {
+		return err
+	}
+
+	return s.officeLocationRepository.Delete(c, id)
+}
+
+func (s *officeLocationService) AssignEmployees(c context.Context, id string, request web.AssignOfficeLocationRequest) error {
+	if _, err := findOfficeLocation(c, s.officeLocationRepository, id); err != nil {
+		return err
+	}
+	for _, employeeID := range request.EmployeeIDs {
+		if _, err := findEmployee(c, s.employeeRepository, employeeID); err != nil {
+			return err
+		}
+	}
+
+	return s.officeLocationRepository.AssignEmployees(c, id, request.EmployeeIDs)
+}
+
+func (s *officeLocationService) FindAllOfficeLocation(c context.Context) ([]web.OfficeLocationResponse, error) {
+	locations, err := s.officeLocationRepository.FindAllOfficeLocation(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// convert to web.OfficeLocationResponse
+	result := []web.OfficeLocationResponse{}
+	for _, location := range locations {
+		result = append(result, location.ToOfficeLocationResponse())
+	}
+
+	return result, nil
+}
+
+func (s *officeLocationService) FindById(c context.Context, id string) (web.OfficeLocationResponse, error) {
+	location, err := findOfficeLocation(c, s.officeLocationRepository, id)
+	if err != nil {
+		return web.OfficeLocationResponse{}, err
+	}
+
+	return location.ToOfficeLocationResponse(), nil
+}
+
+// find the office location by id and convert the 'no rows' error to not found error
+func findOfficeLocation(c context.Context, officeLocationRepository repository.OfficeLocationRepository, id string) (domain.OfficeLocation, error) {
+	location, err := officeLocationRepository.FindById(c, id)
+	if err != nil {
+		if strings.Contains(err.Error(), "no rows") {
+			return domain.OfficeLocation{}, exception.ErrNotFound(fmt.Sprintf("Office location %s not found", id))
+		}
+		return domain.OfficeLocation{}, err
+	}
+
+	return location, nil
+}
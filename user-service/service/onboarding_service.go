@@ -0,0 +1,500 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/iqbaludinm/hr-microservice/user-service/config"
+	"github.com/iqbaludinm/hr-microservice/user-service/exception"
+	"github.com/iqbaludinm/hr-microservice/user-service/helper"
+	"github.com/iqbaludinm/hr-microservice/user-service/model/domain"
+	"github.com/iqbaludinm/hr-microservice/user-service/model/kafkamodel"
+	"github.com/iqbaludinm/hr-microservice/user-service/model/web"
+	"github.com/iqbaludinm/hr-microservice/user-service/repository"
+	"github.com/iqbaludinm/hr-microservice/user-service/service/producers"
+	"go.uber.org/zap"
+)
+
+// Type of the notifications produced by the onboarding service.
+const (
+	NotificationOnboardingTaskAssigned = "ONBOARDING_TASK_ASSIGNED"
+	NotificationOnboardingTaskReminder = "ONBOARDING_TASK_REMINDER"
+)
+
+type OnboardingService interface {
+	// With Transaction
+	CreateTemplate(ctx context.Context, request web.OnboardingTemplateRequest) (web.OnboardingTemplateResponse, error)
+	UpdateTemplate(ctx context.Context, id string, request web.OnboardingTemplateRequest) (web.OnboardingTemplateResponse, error)
+	DeleteTemplate(ctx context.Context, id string) error
+	Start(ctx context.Context, request web.StartOnboardingRequest) (web.OnboardingResponse, error)
+	Cancel(ctx context.Context, id string) (web.OnboardingResponse, error)
+	UpdateTask(ctx context.Context, userID, id string, seq int, request web.UpdateOnboardingTaskRequest) (web.OnboardingResponse, error)
+	SendReminders(ctx context.Context) error
+
+	// Without Transaction
+	FindAllTemplate(ctx context.Context) ([]web.OnboardingTemplateResponse, error)
+	FindTemplateById(ctx context.Context, id string) (web.OnboardingTemplateResponse, error)
+	FindAllOnboarding(ctx context.Context, filter web.OnboardingQueryFilter) ([]web.OnboardingResponse, int, error)
+	FindMyOnboarding(ctx context.Context, userID string) (web.OnboardingResponse, error)
+	FindMyTasks(ctx context.Context, userID string) ([]web.OnboardingTaskResponse, error)
+	FindById(ctx context.Context, id string) (web.OnboardingResponse, error)
+}
+
+type onboardingService struct {
+	onboardingRepository repository.OnboardingRepository
+	employeeRepository   repository.EmployeeRepository
+	departmentRepository repository.DepartmentRepository
+	positionRepository   repository.PositionRepository
+	kafkaProducerService producers.KafkaProducerService
+	logger               *zap.SugaredLogger
+}
+
+func NewOnboardingService(onboardingRepository repository.OnboardingRepository, employeeRepository repository.EmployeeRepository, departmentRepository repository.DepartmentRepository, positionRepository repository.PositionRepository, kafkaProducerService producers.KafkaProducerService, logger *zap.SugaredLogger) OnboardingService {
+	return &onboardingService{
+		onboardingRepository: onboardingRepository,
+		employeeRepository:   employeeRepository,
+		departmentRepository: departmentRepository,
+		positionRepository:   positionRepository,
+		kafkaProducerService: kafkaProducerService,
+		logger:               logger,
+	}
+}
+
+func (s *onboardingService) CreateTemplate(c context.Context, request web.OnboardingTemplateRequest) (web.OnboardingTemplateResponse, error) {
+	// convert to domain or model onboarding template
+	template := domain.ToDomainOnboardingTemplate(request)
+	if err := s.validateTemplate(c, template); err != nil {
+		return web.OnboardingTemplateResponse{}, err
+	}
+
+	template.ID = uuid.New().String()
+	template.CreatedAt = time.Now()
+	template.UpdatedAt = time.Now()
+
+	// call the repo for inserting to db
+	if err := s.onboardingRepository.CreateTemplate(c, template); err != nil {
+		s.logger.Infow(err.Error(), "Create Onboarding Template Error")
+		return web.OnboardingTemplateResponse{}, err
+	}
+
+	// get or returning the template have created to db
+	newTemplate, err := s.onboardingRepository.FindTemplateById(c, template.ID)
+	if err != nil {
+		return web.OnboardingTemplateResponse{}, exception.ErrInternalServer(fmt.Sprintf("Successfully created onboarding template, but failed to get the template have created. Error: %s", err.Error()))
+	}
+
+	return newTemplate.ToOnboardingTemplateResponse(), nil
+}
+
+// update the template, the onboardings which are already started keep their tasks
+func (s *onboardingService) UpdateTemplate(c context.Context, id string, request web.OnboardingTemplateRequest) (web.OnboardingTemplateResponse, error) {
+	if _, err := findOnboardingTemplate(c, s.onboardingRepository, id); err != nil {
+		return web.OnboardingTemplateResponse{}, err
+	}
+
+	template := domain.ToDomainOnboardingTemplate(request)
+	if err := s.validateTemplate(c, template); err != nil {
+		return web.OnboardingTemplateResponse{}, err
+	}
+
+	template.ID = id
+	template.UpdatedAt = time.Now()
+
+	if err := s.onboardingRepository.UpdateTemplate(c, template); err != nil {
+		s.logger.Infow(err.Error(), "Update Onboarding Template Error")
+		return web.OnboardingTemplateResponse{}, err
+	}
+
+	updatedTemplate, err := s.onboardingRepository.FindTemplateById(c, id)
+	if err != nil {
+		return web.OnboardingTemplateResponse{}, exception.ErrInternalServer(fmt.Sprintf("Successfully updated onboarding template, but failed to get the template have updated. Error: %s", err.Error()))
+	}
+
+	return updatedTemplate.ToOnboardingTemplateResponse(), nil
+}
+
+func (s *onboardingService) DeleteTemplate(c context.Context, id string) error {
+	if _, err := findOnboardingTemplate(c, s.onboardingRepository, id); err != nil {
+		return err
+	}
+
+	return s.onboardingRepository.DeleteTemplate(c, id)
+}
+
+// start the onboarding of the employee. The template is selected from the position or the department of the
+// employee when it is not filled.
+func (s *onboardingService) Start(c context.Context, request web.StartOnboardingRequest) (web.OnboardingResponse, error) {
+	employee, err := findEmployee(c, s.employeeRepository, request.EmployeeID)
+	if err != nil {
+		return web.OnboardingResponse{}, err
+	}
+	if leavingStatus(employee.Status) {
+		return web.OnboardingResponse{}, exception.ErrBadRequest(fmt.Sprintf("Employee is %s.", employee.Status))
+	}
+
+	if _, err := s.onboardingRepository.FindByEmployee(c, employee.ID); err == nil {
+		return web.OnboardingResponse{}, exception.ErrBadRequest("Employee already has an onboarding.")
+	} else if !strings.Contains(err.Error(), "no rows") {
+		return web.OnboardingResponse{}, err
+	}
+
+	var template domain.OnboardingTemplate
+	if request.TemplateID != nil && *request.TemplateID != "" {
+		template, err = findOnboardingTemplate(c, s.onboardingRepository, *request.TemplateID)
+	} else {
+		template, err = s.onboardingRepository.FindTemplateForEmployee(c, &employee.DepartmentID, employee.PositionID)
+		if err != nil && strings.Contains(err.Error(), "no rows") {
+			return web.OnboardingResponse{}, exception.ErrNotFound("No onboarding template found for the position or the department of the employee")
+		}
+	}
+	if err != nil {
+		return web.OnboardingResponse{}, err
+	}
+
+	onboarding, err := startOnboarding(c, s.onboardingRepository, s.kafkaProducerService, employee, template)
+	if err != nil {
+		s.logger.Infow(err.Error(), "Start Onboarding Error")
+		return web.OnboardingResponse{}, err
+	}
+
+	return onboarding.ToOnboardingResponse(), nil
+}
+
+func (s *onboardingService) Cancel(c context.Context, id string) (web.OnboardingResponse, error) {
+	onboarding, err := findOnboarding(c, s.onboardingRepository, id)
+	if err != nil {
+		return web.OnboardingResponse{}, err
+	}
+	if onboarding.Status != domain.OnboardingStatusInProgress {
+		return web.OnboardingResponse{}, exception.ErrBadRequest(fmt.Sprintf("Onboarding is %s.", onboarding.Status))
+	}
+
+	cancelledAt := time.Now()
+	onboarding.Status = domain.OnboardingStatusCancelled
+	onboarding.CancelledAt = &cancelledAt
+	onboarding.UpdatedAt = time.Now()
+
+	if err := s.onboardingRepository.UpdateStatus(c, onboarding); err != nil {
+		s.logger.Infow(err.Error(), "Cancel Onboarding Error")
+		return web.OnboardingResponse{}, err
+	}
+
+	return s.FindById(c, id)
+}
+
+// update the progress of the task, the onboarding is completed when its last pending task is done or skipped
+func (s *onboardingService) UpdateTask(c context.Context, userID, id string, seq int, request web.UpdateOnboardingTaskRequest) (web.OnboardingResponse, error) {
+	onboarding, err := findOnboarding(c, s.onboardingRepository, id)
+	if err != nil {
+		return web.OnboardingResponse{}, err
+	}
+	if onboarding.Status != domain.OnboardingStatusInProgress {
+		return web.OnboardingResponse{}, exception.ErrBadRequest(fmt.Sprintf("Onboarding is %s.", onboarding.Status))
+	}
+
+	index := -1
+	for i, task := range onboarding.Tasks {
+		if task.Seq == seq {
+			index = i
+			break
+		}
+	}
+	if index < 0 {
+		return web.OnboardingResponse{}, exception.ErrNotFound(fmt.Sprintf("Onboarding task %d not found", seq))
+	}
+
+	task := onboarding.Tasks[index]
+	task.Status = request.Status
+	task.Note = request.Note
+	task.CompletedBy = nil
+	task.CompletedAt = nil
+	if task.Status != domain.OnboardingTaskPending {
+		completedAt := time.Now()
+		task.CompletedBy = &userID
+		task.CompletedAt = &completedAt
+	}
+	onboarding.Tasks[index] = task
+
+	if onboarding.Finished() {
+		completedAt := time.Now()
+		onboarding.Status = domain.OnboardingStatusCompleted
+		onboarding.CompletedAt = &completedAt
+	}
+	onboarding.UpdatedAt = time.Now()
+
+	if err := s.onboardingRepository.UpdateTask(c, task, onboarding); err != nil {
+		s.logger.Infow(err.Error(), "Update Onboarding Task Error")
+		return web.OnboardingResponse{}, err
+	}
+
+	return s.FindById(c, id)
+}
+
+// remind the assignees of the pending tasks which are due within the reminder days, the overdue tasks are
+// reminded every day until they are done or skipped. It is run by the scheduler.
+func (s *onboardingService) SendReminders(c context.Context) error {
+	y, m, d := time.Now().In(helper.WIB).Date()
+	startOfDay := time.Date(y, m, d, 0, 0, 0, 0, helper.WIB).UTC()
+
+	tasks, err := s.onboardingRepository.FindDueTasks(c, helper.Today().AddDate(0, 0, config.OnboardingReminderDays), startOfDay)
+	if err != nil {
+		return err
+	}
+
+	for _, task := range tasks {
+		if task.AssigneeUserID == nil {
+			continue
+		}
+
+		message := fmt.Sprintf("Onboarding task '%s' for %s (%s) is due on %s.", task.Title, task.EmployeeName, task.EmployeeNumber, task.DueDate.Format(helper.DateLayout))
+		if task.Overdue() {
+			message = fmt.Sprintf("Onboarding task '%s' for %s (%s) was due on %s and is overdue.", task.Title, task.EmployeeName, task.EmployeeNumber, task.DueDate.Format(helper.DateLayout))
+		}
+		notifyOnboardingTask(s.kafkaProducerService, *task.AssigneeUserID, NotificationOnboardingTaskReminder, "Onboarding Task Reminder", message, task)
+
+		remindedAt := time.Now()
+		task.RemindedAt = &remindedAt
+		if err := s.onboardingRepository.UpdateTaskReminded(c, task); err != nil {
+			s.logger.Errorw("Send Onboarding Reminder Error", "onboarding_id", task.OnboardingID, "seq", task.Seq, "error", err.Error())
+			continue
+		}
+	}
+
+	return nil
+}
+
+func (s *onboardingService) FindAllTemplate(c context.Context) ([]web.OnboardingTemplateResponse, error) {
+	templates, err := s.onboardingRepository.FindAllTemplate(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// convert to web.OnboardingTemplateResponse
+	result := []web.OnboardingTemplateResponse{}
+	for _, template := range templates {
+		result = append(result, template.ToOnboardingTemplateResponse())
+	}
+
+	return result, nil
+}
+
+func (s *onboardingService) FindTemplateById(c context.Context, id string) (web.OnboardingTemplateResponse, error) {
+	template, err := findOnboardingTemplate(c, s.onboardingRepository, id)
+	if err != nil {
+		return web.OnboardingTemplateResponse{}, err
+	}
+
+	return template.ToOnboardingTemplateResponse(), nil
+}
+
+func (s *onboardingService) FindAllOnboarding(c context.Context, filter web.OnboardingQueryFilter) (result []web.OnboardingResponse, totalData int, err error) {
+	onboardings, err := s.onboardingRepository.FindAllOnboarding(c, domain.ToDomainOnboardingQueryFilter(filter))
+	if err != nil {
+		return nil, 0, err
+	}
+
+	// get total-data
+	totalData, err = s.onboardingRepository.CountAllOnboarding(c, domain.ToDomainOnboardingQueryFilter(filter))
+	if err != nil {
+		return nil, 0, err
+	}
+
+	// convert to web.OnboardingResponse
+	result = []web.OnboardingResponse{}
+	for _, onboarding := range onboardings {
+		result = append(result, onboarding.ToOnboardingResponse())
+	}
+
+	return result, totalData, nil
+}
+
+// find the onboarding of the logged in user
+func (s *onboardingService) FindMyOnboarding(c context.Context, userID string) (web.OnboardingResponse, error) {
+	employee, err := findEmployeeByUser(c, s.employeeRepository, userID)
+	if err != nil {
+		return web.OnboardingResponse{}, err
+	}
+
+	onboarding, err := s.onboardingRepository.FindByEmployee(c, employee.ID)
+	if err != nil {
+		if strings.Contains(err.Error(), "no rows") {
+			return web.OnboardingResponse{}, exception.ErrNotFound(fmt.Sprintf("Onboarding of employee %s not found", employee.ID))
+		}
+		return web.OnboardingResponse{}, err
+	}
+
+	return onboarding.ToOnboardingResponse(), nil
+}
+
+// find the tasks assigned to the logged in user on the onboardings in progress, e.g. the tasks of the HR officer
+// or the manager of the new employees
+func (s *onboardingService) FindMyTasks(c context.Context, userID string) ([]web.OnboardingTaskResponse, error) {
+	employee, err := findEmployeeByUser(c, s.employeeRepository, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	tasks, err := s.onboardingRepository.FindTasksByAssignee(c, employee.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	// convert to web.OnboardingTaskResponse
+	result := []web.OnboardingTaskResponse{}
+	for _, task := range tasks {
+		result = append(result, task.ToOnboardingTaskResponse())
+	}
+
+	return result, nil
+}
+
+func (s *onboardingService) FindById(c context.Context, id string) (web.OnboardingResponse, error) {
+	onboarding, err := findOnboarding(c, s.onboardingRepository, id)
+	if err != nil {
+		return web.OnboardingResponse{}, err
+	}
+
+	return onboarding.ToOnboardingResponse(), nil
+}
+
+// validate the department and the position of the template exist and the assignees of the tasks are employees.
+// The assignee of the 'manager' and 'employee' tasks is resolved when the onboarding is started.
+func (s *onboardingService) validateTemplate(c context.Context, template domain.OnboardingTemplate) error {
+	if template.DepartmentID != nil {
+		if _, err := validateAssignment(c, s.departmentRepository, s.positionRepository, *template.DepartmentID, template.PositionID); err != nil {
+			return err
+		}
+	} else if template.PositionID != nil {
+		if _, err := s.positionRepository.FindById(c, *template.PositionID); err != nil {
+			if strings.Contains(err.Error(), "no rows") {
+				return exception.ErrNotFound(fmt.Sprintf("Position %s not found", *template.PositionID))
+			}
+			return err
+		}
+	}
+
+	for _, task := range template.Tasks {
+		if task.AssigneeID == nil {
+			continue
+		}
+		if task.AssigneeRole == domain.OnboardingAssigneeManager || task.AssigneeRole == domain.OnboardingAssigneeEmployee {
+			return exception.ErrBadRequest(fmt.Sprintf("The assignee of the '%s' task is resolved when the onboarding is started.", task.AssigneeRole))
+		}
+		if _, err := findEmployee(c, s.employeeRepository, *task.AssigneeID); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// start the onboarding of the new employee from the template of the position or the department, the failure is only
+// logged so the employee is kept
+func startNewEmployeeOnboarding(c context.Context, onboardingRepository repository.OnboardingRepository, kafkaProducerService producers.KafkaProducerService, logger *zap.SugaredLogger, employee domain.Employee) {
+	if leavingStatus(employee.Status) {
+		return
+	}
+
+	template, err := onboardingRepository.FindTemplateForEmployee(c, &employee.DepartmentID, employee.PositionID)
+	if err != nil {
+		if !strings.Contains(err.Error(), "no rows") {
+			logger.Infow(err.Error(), "Start Onboarding Error")
+		}
+		return
+	}
+
+	if _, err := startOnboarding(c, onboardingRepository, kafkaProducerService, employee, template); err != nil {
+		logger.Infow(err.Error(), "Start Onboarding Error")
+	}
+}
+
+// start the onboarding of the employee from the template and notify the assignees of their tasks, it is shared
+// with the employee service which starts the onboarding of the new employees
+func startOnboarding(c context.Context, onboardingRepository repository.OnboardingRepository, kafkaProducerService producers.KafkaProducerService, employee domain.Employee, template domain.OnboardingTemplate) (domain.Onboarding, error) {
+	onboarding := domain.NewOnboarding(employee, template)
+	onboarding.ID = uuid.New().String()
+	onboarding.StartedAt = time.Now()
+	onboarding.CreatedAt = time.Now()
+	onboarding.UpdatedAt = time.Now()
+
+	// call the repo for inserting to db
+	if err := onboardingRepository.CreateOnboarding(c, onboarding); err != nil {
+		if strings.Contains(err.Error(), "unique") && strings.Contains(err.Error(), "onboardings_employee_id_key") {
+			return domain.Onboarding{}, exception.ErrBadRequest("Employee already has an onboarding.")
+		}
+		return domain.Onboarding{}, err
+	}
+
+	// get or returning the onboarding have created to db
+	newOnboarding, err := onboardingRepository.FindById(c, onboarding.ID)
+	if err != nil {
+		return domain.Onboarding{}, exception.ErrInternalServer(fmt.Sprintf("Successfully started onboarding, but failed to get the onboarding have started. Error: %s", err.Error()))
+	}
+
+	// notify each assignee once with the number of their tasks and the nearest due date
+	assigned := map[string][]domain.OnboardingTask{}
+	var assignees []string
+	for _, task := range newOnboarding.Tasks {
+		if task.AssigneeUserID == nil {
+			continue
+		}
+		if _, ok := assigned[*task.AssigneeUserID]; !ok {
+			assignees = append(assignees, *task.AssigneeUserID)
+		}
+		assigned[*task.AssigneeUserID] = append(assigned[*task.AssigneeUserID], task)
+	}
+	for _, userID := range assignees {
+		tasks := assigned[userID]
+		first := tasks[0]
+		for _, task := range tasks[1:] {
+			if task.DueDate.Before(first.DueDate) {
+				first = task
+			}
+		}
+
+		message := fmt.Sprintf("You have %d onboarding task(s) for %s (%s), the first one '%s' is due on %s.", len(tasks), newOnboarding.EmployeeName, newOnboarding.EmployeeNumber, first.Title, first.DueDate.Format(helper.DateLayout))
+		notifyOnboardingTask(kafkaProducerService, userID, NotificationOnboardingTaskAssigned, "Onboarding Task Assigned", message, first)
+	}
+
+	return newOnboarding, nil
+}
+
+// produce the notification of the onboarding task to the user
+func notifyOnboardingTask(kafkaProducerService producers.KafkaProducerService, userID, notificationType, title, message string, task domain.OnboardingTask) {
+	kafkaNotificationMessage := kafkamodel.NewKafkaNotificationMessage(userID, notificationType, title, message, map[string]interface{}{
+		"onboarding_id": task.OnboardingID,
+		"employee_id":   task.EmployeeID,
+		"seq":           task.Seq,
+		"due_date":      task.DueDate.Format(helper.DateLayout),
+	})
+	go kafkaProducerService.Produce(kafkaNotificationMessage, "POST.NOTIFICATION", config.KafkaTopicNotification)
+}
+
+// find the onboarding by id with its tasks and convert the 'no rows' error to not found error
+func findOnboarding(c context.Context, onboardingRepository repository.OnboardingRepository, id string) (domain.Onboarding, error) {
+	onboarding, err := onboardingRepository.FindById(c, id)
+	if err != nil {
+		if strings.Contains(err.Error(), "no rows") {
+			return domain.Onboarding{}, exception.ErrNotFound(fmt.Sprintf("Onboarding %s not found", id))
+		}
+		return domain.Onboarding{}, err
+	}
+
+	return onboarding, nil
+}
+
+// find the onboarding template by id with its tasks and convert the 'no rows' error to not found error
+func findOnboardingTemplate(c context.Context, onboardingRepository repository.OnboardingRepository, id string) (domain.OnboardingTemplate, error) {
+	template, err := onboardingRepository.FindTemplateById(c, id)
+	if err != nil {
+		if strings.Contains(err.Error(), "no rows") {
+			return domain.OnboardingTemplate{}, exception.ErrNotFound(fmt.Sprintf("Onboarding template %s not found", id))
+		}
+		return domain.OnboardingTemplate{}, err
+	}
+
+	return template, nil
+}
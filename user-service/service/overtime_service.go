@@ -0,0 +1,506 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/iqbaludinm/hr-microservice/user-service/config"
+	"github.com/iqbaludinm/hr-microservice/user-service/exception"
+	"github.com/iqbaludinm/hr-microservice/user-service/helper"
+	"github.com/iqbaludinm/hr-microservice/user-service/model/domain"
+	"github.com/iqbaludinm/hr-microservice/user-service/model/kafkamodel"
+	"github.com/iqbaludinm/hr-microservice/user-service/model/web"
+	"github.com/iqbaludinm/hr-microservice/user-service/repository"
+	"github.com/iqbaludinm/hr-microservice/user-service/service/producers"
+	"go.uber.org/zap"
+)
+
+// Type of the notifications produced by the overtime service.
+const (
+	NotificationOvertimeSubmitted = "OVERTIME_SUBMITTED"
+	NotificationOvertimeApproved  = "OVERTIME_APPROVED"
+	NotificationOvertimeRejected  = "OVERTIME_REJECTED"
+	NotificationOvertimeCancelled = "OVERTIME_CANCELLED"
+)
+
+type OvertimeService interface {
+	// With Transaction
+	CreateOvertime(ctx context.Context, userID string, request web.CreateOvertimeRequest) (web.OvertimeResponse, error)
+	Approve(ctx context.Context, userID, id string, request web.DecideOvertimeRequest) (web.OvertimeResponse, error)
+	Reject(ctx context.Context, userID, id string, request web.DecideOvertimeRequest) (web.OvertimeResponse, error)
+	Cancel(ctx context.Context, userID, id string) (web.OvertimeResponse, error)
+
+	// Without Transaction
+	FindAllOvertime(ctx context.Context, filter web.OvertimeQueryFilter) ([]web.OvertimeResponse, int, error)
+	FindMyOvertime(ctx context.Context, userID string, filter web.OvertimeQueryFilter) ([]web.OvertimeResponse, int, error)
+	FindPendingApprovals(ctx context.Context, userID string, filter web.OvertimeQueryFilter) ([]web.OvertimeResponse, int, error)
+	FindById(ctx context.Context, id string) (web.OvertimeResponse, error)
+	Dashboard(ctx context.Context, userID string, filter web.OvertimeDashboardQueryFilter) (web.OvertimeDashboardResponse, error)
+
+	// Approval Entity
+	ApprovalEntity
+}
+
+type overtimeService struct {
+	overtimeRepository     repository.OvertimeRepository
+	holidayRepository      repository.HolidayRepository
+	workCalendarRepository repository.WorkCalendarRepository
+	employeeRepository     repository.EmployeeRepository
+	departmentRepository   repository.DepartmentRepository
+	approvalService        ApprovalService
+	kafkaProducerService   producers.KafkaProducerService
+	logger                 *zap.SugaredLogger
+}
+
+func NewOvertimeService(overtimeRepository repository.OvertimeRepository, holidayRepository repository.HolidayRepository, workCalendarRepository repository.WorkCalendarRepository, employeeRepository repository.EmployeeRepository, departmentRepository repository.DepartmentRepository, approvalService ApprovalService, kafkaProducerService producers.KafkaProducerService, logger *zap.SugaredLogger) OvertimeService {
+	return &overtimeService{
+		overtimeRepository:     overtimeRepository,
+		holidayRepository:      holidayRepository,
+		workCalendarRepository: workCalendarRepository,
+		employeeRepository:     employeeRepository,
+		departmentRepository:   departmentRepository,
+		approvalService:        approvalService,
+		kafkaProducerService:   kafkaProducerService,
+		logger:                 logger,
+	}
+}
+
+func (s *overtimeService) CreateOvertime(c context.Context, userID string, request web.CreateOvertimeRequest) (web.OvertimeResponse, error) {
+	employee, err := findEmployeeByUser(c, s.employeeRepository, userID)
+	if err != nil {
+		return web.OvertimeResponse{}, err
+	}
+	// the overtime request is approved by the employee's manager
+	if employee.ManagerID == nil {
+		return web.OvertimeResponse{}, exception.ErrBadRequest("Employee has no manager to approve the overtime request.")
+	}
+	manager, err := findEmployee(c, s.employeeRepository, *employee.ManagerID)
+	if err != nil {
+		return web.OvertimeResponse{}, err
+	}
+
+	// the overtime is approved before it is worked
+	date, _ := helper.ParseDate(request.Date)
+	if date.Before(helper.Today()) {
+		return web.OvertimeResponse{}, exception.ErrBadRequest("Overtime must be requested before the date.")
+	}
+
+	// the multipliers and the daily limit depend on the date being a working day of the employee's calendar
+	calendar, err := findCalendar(c, s.workCalendarRepository, s.holidayRepository, employee.WorkCalendarID, date, date)
+	if err != nil {
+		return web.OvertimeResponse{}, err
+	}
+	workingDay := calendar.IsWorkingDay(date)
+	if workingDay && request.Hours > config.OvertimeMaxHoursPerDay {
+		return web.OvertimeResponse{}, exception.ErrBadRequest(fmt.Sprintf("Overtime on a working day can't be more than %.1f hours.", config.OvertimeMaxHoursPerDay))
+	}
+
+	// the week starts on Monday
+	weekStart := date.AddDate(0, 0, -(int(date.Weekday())+6)%7)
+	hours, err := s.overtimeRepository.SumHours(c, employee.ID, weekStart, weekStart.AddDate(0, 0, 6))
+	if err != nil {
+		return web.OvertimeResponse{}, err
+	}
+	if hours+request.Hours > config.OvertimeMaxHoursPerWeek {
+		return web.OvertimeResponse{}, exception.ErrBadRequest(fmt.Sprintf("Overtime can't be more than %.1f hours in a week, %.1f hours already requested.", config.OvertimeMaxHoursPerWeek, hours))
+	}
+
+	// convert to domain or model overtime
+	overtime := domain.Overtime{
+		ID:         uuid.New().String(),
+		EmployeeID: employee.ID,
+		Date:       date,
+		Hours:      request.Hours,
+		Reason:     request.Reason,
+		WorkingDay: workingDay,
+		Status:     domain.OvertimeStatusPending,
+		ApproverID: &manager.ID,
+		CreatedAt:  time.Now(),
+		UpdatedAt:  time.Now(),
+	}
+
+	// call the repo for inserting to db
+	if err := s.overtimeRepository.CreateOvertime(c, overtime); err != nil {
+		s.logger.Infow(err.Error(), "Create Overtime Error")
+		return web.OvertimeResponse{}, toOvertimeUniqueError(err)
+	}
+
+	// the overtime request is approved through the active approval workflow of overtime, otherwise by the manager.
+	// The overtime request is withdrawn when its approval can't be requested.
+	governed, err := s.approvalService.Request(c, domain.ApprovalEntityOvertime, overtime.ID)
+	if err != nil {
+		s.logger.Infow(err.Error(), "Request Overtime Approval Error")
+		overtime.Status = domain.OvertimeStatusCancelled
+		overtime.CancelledAt = &overtime.UpdatedAt
+		if err := s.overtimeRepository.UpdateStatus(c, overtime, domain.OvertimeStatusPending); err != nil {
+			s.logger.Errorw("Withdraw Overtime Error", "overtime_id", overtime.ID, "error", err.Error())
+		}
+		return web.OvertimeResponse{}, err
+	}
+
+	newOvertime, err := s.overtimeRepository.FindById(c, overtime.ID)
+	if err != nil {
+		return web.OvertimeResponse{}, exception.ErrInternalServer(fmt.Sprintf("Successfully created overtime request, but failed to get the overtime request have created. Error: %s", err.Error()))
+	}
+
+	// notify the manager there is an overtime request to be approved, the approvers of the workflow are notified by it
+	if !governed {
+		s.notify(manager.UserID, NotificationOvertimeSubmitted, "Overtime Request Submitted",
+			fmt.Sprintf("%s requested %.1f hours of overtime on %s.", newOvertime.EmployeeName, newOvertime.Hours, request.Date), newOvertime)
+	}
+
+	return newOvertime.ToOvertimeResponse(), nil
+}
+
+func (s *overtimeService) Approve(c context.Context, userID, id string, request web.DecideOvertimeRequest) (web.OvertimeResponse, error) {
+	overtime, err := s.decide(c, userID, id, domain.OvertimeStatusApproved, request.Note)
+	if err != nil {
+		return web.OvertimeResponse{}, err
+	}
+
+	s.notify(overtime.EmployeeUserID, NotificationOvertimeApproved, "Overtime Request Approved",
+		fmt.Sprintf("Your overtime request on %s has been approved by %s.", overtime.Date.Format(helper.DateLayout), overtime.ApproverName), overtime)
+
+	return overtime.ToOvertimeResponse(), nil
+}
+
+func (s *overtimeService) Reject(c context.Context, userID, id string, request web.DecideOvertimeRequest) (web.OvertimeResponse, error) {
+	overtime, err := s.decide(c, userID, id, domain.OvertimeStatusRejected, request.Note)
+	if err != nil {
+		return web.OvertimeResponse{}, err
+	}
+
+	s.notify(overtime.EmployeeUserID, NotificationOvertimeRejected, "Overtime Request Rejected",
+		fmt.Sprintf("Your overtime request on %s has been rejected by %s.", overtime.Date.Format(helper.DateLayout), overtime.ApproverName), overtime)
+
+	return overtime.ToOvertimeResponse(), nil
+}
+
+func (s *overtimeService) Cancel(c context.Context, userID, id string) (web.OvertimeResponse, error) {
+	overtime, err := s.findOvertime(c, id)
+	if err != nil {
+		return web.OvertimeResponse{}, err
+	}
+
+	employee, err := findEmployeeByUser(c, s.employeeRepository, userID)
+	if err != nil {
+		return web.OvertimeResponse{}, err
+	}
+	if overtime.EmployeeID != employee.ID {
+		return web.OvertimeResponse{}, exception.ErrUnauthorized("Only the requester can cancel the overtime request.")
+	}
+
+	switch {
+	case overtime.Status == domain.OvertimeStatusPending:
+	case overtime.Status == domain.OvertimeStatusApproved && helper.Today().Before(overtime.Date):
+	case overtime.Status == domain.OvertimeStatusApproved:
+		return web.OvertimeResponse{}, exception.ErrBadRequest("Approved overtime request can only be cancelled before the date.")
+	default:
+		return web.OvertimeResponse{}, exception.ErrBadRequest(fmt.Sprintf("Overtime request is already %s.", overtime.Status))
+	}
+
+	previousStatus := overtime.Status
+	cancelledAt := time.Now()
+	overtime.Status = domain.OvertimeStatusCancelled
+	overtime.CancelledAt = &cancelledAt
+	overtime.UpdatedAt = cancelledAt
+
+	if err := s.updateStatus(c, overtime, previousStatus); err != nil {
+		s.logger.Infow(err.Error(), "Cancel Overtime Error")
+		return web.OvertimeResponse{}, err
+	}
+	if previousStatus == domain.OvertimeStatusPending {
+		if err := s.approvalService.CancelEntity(c, domain.ApprovalEntityOvertime, overtime.ID); err != nil {
+			s.logger.Errorw("Cancel Overtime Approval Error", "overtime_id", overtime.ID, "error", err.Error())
+		}
+	}
+
+	// the approver is notified only when the overtime request was already approved
+	if previousStatus == domain.OvertimeStatusApproved && overtime.ApproverID != nil {
+		if approver, err := s.employeeRepository.FindById(c, *overtime.ApproverID); err == nil {
+			s.notify(approver.UserID, NotificationOvertimeCancelled, "Overtime Request Cancelled",
+				fmt.Sprintf("%s cancelled the approved overtime on %s.", overtime.EmployeeName, overtime.Date.Format(helper.DateLayout)), overtime)
+		}
+	}
+
+	return overtime.ToOvertimeResponse(), nil
+}
+
+func (s *overtimeService) FindAllOvertime(c context.Context, filter web.OvertimeQueryFilter) ([]web.OvertimeResponse, int, error) {
+	domainFilter := domain.ToDomainOvertimeQueryFilter(filter)
+	if filter.DepartmentID != "" {
+		departmentIds, err := findDepartmentIds(c, s.departmentRepository, filter.DepartmentID)
+		if err != nil {
+			return nil, 0, err
+		}
+		domainFilter.DepartmentIDs = departmentIds
+	}
+
+	return s.findAllOvertime(c, domainFilter)
+}
+
+func (s *overtimeService) FindMyOvertime(c context.Context, userID string, filter web.OvertimeQueryFilter) ([]web.OvertimeResponse, int, error) {
+	employee, err := findEmployeeByUser(c, s.employeeRepository, userID)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	domainFilter := domain.ToDomainOvertimeQueryFilter(filter)
+	domainFilter.EmployeeID = employee.ID
+
+	return s.findAllOvertime(c, domainFilter)
+}
+
+func (s *overtimeService) FindPendingApprovals(c context.Context, userID string, filter web.OvertimeQueryFilter) ([]web.OvertimeResponse, int, error) {
+	approver, err := findEmployeeByUser(c, s.employeeRepository, userID)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	domainFilter := domain.ToDomainOvertimeQueryFilter(filter)
+	domainFilter.ApproverID = approver.ID
+	domainFilter.Status = domain.OvertimeStatusPending
+
+	return s.findAllOvertime(c, domainFilter)
+}
+
+func (s *overtimeService) FindById(c context.Context, id string) (web.OvertimeResponse, error) {
+	overtime, err := s.findOvertime(c, id)
+	if err != nil {
+		return web.OvertimeResponse{}, err
+	}
+
+	return overtime.ToOvertimeResponse(), nil
+}
+
+// summarize the overtime requests of the department and its sub-departments in the month per employee, the
+// department of the logged in user and the current month are used when they're empty
+func (s *overtimeService) Dashboard(c context.Context, userID string, filter web.OvertimeDashboardQueryFilter) (web.OvertimeDashboardResponse, error) {
+	departmentID := filter.DepartmentID
+	if departmentID == "" {
+		manager, err := findEmployeeByUser(c, s.employeeRepository, userID)
+		if err != nil {
+			return web.OvertimeDashboardResponse{}, err
+		}
+		departmentID = manager.DepartmentID
+	}
+	if departmentID == "" {
+		return web.OvertimeDashboardResponse{}, exception.ErrBadRequest("The department of the dashboard is required.")
+	}
+	department, err := s.departmentRepository.FindById(c, departmentID)
+	if err != nil {
+		if strings.Contains(err.Error(), "no rows") {
+			return web.OvertimeDashboardResponse{}, exception.ErrNotFound(fmt.Sprintf("Department %s not found", departmentID))
+		}
+		return web.OvertimeDashboardResponse{}, err
+	}
+	departmentIds, err := findDepartmentIds(c, s.departmentRepository, department.ID)
+	if err != nil {
+		return web.OvertimeDashboardResponse{}, err
+	}
+
+	today := helper.Today()
+	from := time.Date(today.Year(), today.Month(), 1, 0, 0, 0, 0, time.UTC)
+	if filter.Month != "" {
+		from, _ = time.Parse("2006-01", filter.Month)
+	}
+	to := from.AddDate(0, 1, -1)
+
+	overtimes, err := s.overtimeRepository.FindAllOvertime(c, domain.OvertimeQueryFilter{
+		DepartmentIDs: departmentIds,
+		From:          from.Format(helper.DateLayout),
+		To:            to.Format(helper.DateLayout),
+	})
+	if err != nil {
+		return web.OvertimeDashboardResponse{}, err
+	}
+
+	// the days off are paid by the multipliers of the week of the employee's calendar
+	employees, err := s.employeeRepository.FindAllEmployee(c, domain.EmployeeQueryFilter{DepartmentIDs: departmentIds})
+	if err != nil {
+		return web.OvertimeDashboardResponse{}, err
+	}
+	calendars, err := findCalendars(c, s.workCalendarRepository, s.holidayRepository, from, to)
+	if err != nil {
+		return web.OvertimeDashboardResponse{}, err
+	}
+	sixDayWeek := map[string]bool{}
+	for _, employee := range employees {
+		calendar := calendars.For(employee.WorkCalendarID)
+		sixDayWeek[employee.ID] = calendar.SixDayWeek()
+	}
+
+	summaries, total := domain.SummarizeOvertimes(overtimes, sixDayWeek)
+
+	// convert to web.OvertimeDashboardResponse
+	result := web.OvertimeDashboardResponse{
+		DepartmentID:           department.ID,
+		DepartmentName:         department.Name,
+		From:                   from.Format(helper.DateLayout),
+		To:                     to.Format(helper.DateLayout),
+		OvertimeDashboardTotal: total.ToOvertimeDashboardTotal(),
+		Items:                  []web.OvertimeDashboardItem{},
+	}
+	for _, summary := range summaries {
+		result.Items = append(result.Items, web.OvertimeDashboardItem{
+			EmployeeID:             summary.EmployeeID,
+			EmployeeNumber:         summary.EmployeeNumber,
+			EmployeeName:           summary.EmployeeName,
+			DepartmentName:         summary.DepartmentName,
+			OvertimeDashboardTotal: summary.ToOvertimeDashboardTotal(),
+		})
+	}
+
+	return result, nil
+}
+
+// approve or reject the pending overtime request, only the approver can decide it
+func (s *overtimeService) decide(c context.Context, userID, id, status, note string) (domain.Overtime, error) {
+	overtime, err := s.findOvertime(c, id)
+	if err != nil {
+		return domain.Overtime{}, err
+	}
+
+	approver, err := findEmployeeByUser(c, s.employeeRepository, userID)
+	if err != nil {
+		return domain.Overtime{}, err
+	}
+	if overtime.ApproverID == nil || *overtime.ApproverID != approver.ID {
+		return domain.Overtime{}, exception.ErrUnauthorized("Only the approver can decide the overtime request.")
+	}
+	if overtime.Status != domain.OvertimeStatusPending {
+		return domain.Overtime{}, exception.ErrBadRequest(fmt.Sprintf("Overtime request is already %s.", overtime.Status))
+	}
+
+	// the overtime request with a pending approval is decided through the approval workflow
+	governed, err := s.approvalService.IsPending(c, domain.ApprovalEntityOvertime, id)
+	if err != nil {
+		return domain.Overtime{}, err
+	}
+	if governed {
+		return domain.Overtime{}, exception.ErrBadRequest("Overtime request is decided through its approval.")
+	}
+
+	decidedAt := time.Now()
+	overtime.Status = status
+	overtime.DecisionNote = note
+	overtime.DecidedAt = &decidedAt
+	overtime.UpdatedAt = decidedAt
+
+	if err := s.updateStatus(c, overtime, domain.OvertimeStatusPending); err != nil {
+		s.logger.Infow(err.Error(), "Decide Overtime Error")
+		return domain.Overtime{}, err
+	}
+
+	return overtime, nil
+}
+
+func (s *overtimeService) findAllOvertime(c context.Context, filter domain.OvertimeQueryFilter) (result []web.OvertimeResponse, totalData int, err error) {
+	overtimes, err := s.overtimeRepository.FindAllOvertime(c, filter)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	// get total-data
+	totalData, err = s.overtimeRepository.CountAllOvertime(c, filter)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	// convert to web.OvertimeResponse
+	result = []web.OvertimeResponse{}
+	for _, overtime := range overtimes {
+		result = append(result, overtime.ToOvertimeResponse())
+	}
+
+	return result, totalData, nil
+}
+
+// find the overtime request by id and convert the 'no rows' error to not found error
+// FindApprovalSubject finds the requester of the pending overtime request, the overtime request has no amount
+func (s *overtimeService) FindApprovalSubject(c context.Context, id string) (domain.ApprovalSubject, error) {
+	overtime, err := s.findOvertime(c, id)
+	if err != nil {
+		return domain.ApprovalSubject{}, err
+	}
+	if overtime.Status != domain.OvertimeStatusPending {
+		return domain.ApprovalSubject{}, exception.ErrBadRequest(fmt.Sprintf("Overtime request is already %s.", overtime.Status))
+	}
+
+	return domain.ApprovalSubject{
+		RequesterID: overtime.EmployeeID,
+		Title:       fmt.Sprintf("Overtime %.1f hours on %s", overtime.Hours, overtime.Date.Format(helper.DateLayout)),
+	}, nil
+}
+
+// ApplyApproval decides the pending overtime request with the completed approval
+func (s *overtimeService) ApplyApproval(c context.Context, approval domain.ApprovalRequest) error {
+	overtime, err := s.findOvertime(c, approval.EntityID)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	switch approval.Status {
+	case domain.ApprovalStatusApproved:
+		overtime.Status = domain.OvertimeStatusApproved
+		overtime.DecidedAt = &now
+	case domain.ApprovalStatusRejected:
+		overtime.Status = domain.OvertimeStatusRejected
+		overtime.DecidedAt = &now
+	default:
+		overtime.Status = domain.OvertimeStatusCancelled
+		overtime.CancelledAt = &now
+	}
+	overtime.DecisionNote = approval.DecisionNote()
+	overtime.UpdatedAt = now
+
+	return s.updateStatus(c, overtime, domain.OvertimeStatusPending)
+}
+
+// update the overtime request that still has the given status, the 'no rows' error means another request has
+// changed the status in the meantime
+func (s *overtimeService) updateStatus(c context.Context, overtime domain.Overtime, status string) error {
+	if err := s.overtimeRepository.UpdateStatus(c, overtime, status); err != nil {
+		if strings.Contains(err.Error(), "no rows") {
+			return exception.ErrBadRequest(fmt.Sprintf("Overtime request is no longer %s.", status))
+		}
+		return err
+	}
+
+	return nil
+}
+
+func (s *overtimeService) findOvertime(c context.Context, id string) (domain.Overtime, error) {
+	overtime, err := s.overtimeRepository.FindById(c, id)
+	if err != nil {
+		if strings.Contains(err.Error(), "no rows") {
+			return domain.Overtime{}, exception.ErrNotFound(fmt.Sprintf("Overtime request %s not found", id))
+		}
+		return domain.Overtime{}, err
+	}
+
+	return overtime, nil
+}
+
+// produce the notification of the overtime request to the user
+func (s *overtimeService) notify(userID, notificationType, title, message string, overtime domain.Overtime) {
+	kafkaNotificationMessage := kafkamodel.NewKafkaNotificationMessage(userID, notificationType, title, message, map[string]interface{}{
+		"overtime_id": overtime.ID,
+		"status":      overtime.Status,
+		"date":        overtime.Date.Format(helper.DateLayout),
+		"hours":       overtime.Hours,
+	})
+	go s.kafkaProducerService.Produce(kafkaNotificationMessage, "POST.NOTIFICATION", config.KafkaTopicNotification)
+}
+
+// convert the unique constraint error of the 'overtime_requests' table to bad request error
+func toOvertimeUniqueError(err error) error {
+	if strings.Contains(err.Error(), "unique") && strings.Contains(err.Error(), "overtime_requests_employee_id_date_key") {
+		return exception.ErrBadRequest("Overtime request of the date already exist.")
+	}
+	return err
+}
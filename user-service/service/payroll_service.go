@@ -0,0 +1,592 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/iqbaludinm/hr-microservice/user-service/config"
+	"github.com/iqbaludinm/hr-microservice/user-service/exception"
+	"github.com/iqbaludinm/hr-microservice/user-service/helper"
+	"github.com/iqbaludinm/hr-microservice/user-service/model/domain"
+	"github.com/iqbaludinm/hr-microservice/user-service/model/kafkamodel"
+	"github.com/iqbaludinm/hr-microservice/user-service/model/web"
+	"github.com/iqbaludinm/hr-microservice/user-service/repository"
+	"github.com/iqbaludinm/hr-microservice/user-service/service/producers"
+	"go.uber.org/zap"
+)
+
+type PayrollService interface {
+	// With Transaction
+	CreateSalary(ctx context.Context, request web.CreateEmployeeSalaryRequest) (web.EmployeeSalaryResponse, error)
+	CreateBpjsRate(ctx context.Context, request web.CreateBpjsRateRequest) (web.BpjsRateResponse, error)
+	CreateRun(ctx context.Context, userID string, request web.CreatePayrollRunRequest) (web.PayrollRunResponse, error)
+	RecalculateRun(ctx context.Context, id string) (web.PayrollRunResponse, error)
+	ReviewRun(ctx context.Context, userID, id string) (web.PayrollRunResponse, error)
+	ReopenRun(ctx context.Context, id string) (web.PayrollRunResponse, error)
+	LockRun(ctx context.Context, userID, id string) (web.PayrollRunResponse, error)
+	DeleteRun(ctx context.Context, id string) error
+
+	// Without Transaction
+	FindAllSalary(ctx context.Context, filter web.EmployeeSalaryQueryFilter) ([]web.EmployeeSalaryResponse, error)
+	FindRates(ctx context.Context, filter web.PayrollRateQueryFilter) (web.PayrollRatesResponse, error)
+	FindAllRun(ctx context.Context, filter web.PayrollRunQueryFilter) ([]web.PayrollRunResponse, int, error)
+	FindRunById(ctx context.Context, id string) (web.PayrollRunResponse, error)
+	FindAllPayslip(ctx context.Context, runID string) ([]web.PayslipResponse, error)
+	FindPayslipById(ctx context.Context, runID, id string) (web.PayslipResponse, error)
+}
+
+type payrollService struct {
+	payrollRepository        repository.PayrollRepository
+	payrollRateRepository    repository.PayrollRateRepository
+	employeeSalaryRepository repository.EmployeeSalaryRepository
+	overtimeRepository       repository.OvertimeRepository
+	leaveRepository          repository.LeaveRepository
+	leaveTypeRepository      repository.LeaveTypeRepository
+	holidayRepository        repository.HolidayRepository
+	workCalendarRepository   repository.WorkCalendarRepository
+	expenseClaimRepository   repository.ExpenseClaimRepository
+	employeeRepository       repository.EmployeeRepository
+	kafkaProducerService     producers.KafkaProducerService
+	logger                   *zap.SugaredLogger
+}
+
+func NewPayrollService(payrollRepository repository.PayrollRepository, payrollRateRepository repository.PayrollRateRepository, employeeSalaryRepository repository.EmployeeSalaryRepository, overtimeRepository repository.OvertimeRepository, leaveRepository repository.LeaveRepository, leaveTypeRepository repository.LeaveTypeRepository, holidayRepository repository.HolidayRepository, workCalendarRepository repository.WorkCalendarRepository, expenseClaimRepository repository.ExpenseClaimRepository, employeeRepository repository.EmployeeRepository, kafkaProducerService producers.KafkaProducerService, logger *zap.SugaredLogger) PayrollService {
+	return &payrollService{
+		payrollRepository:        payrollRepository,
+		payrollRateRepository:    payrollRateRepository,
+		employeeSalaryRepository: employeeSalaryRepository,
+		overtimeRepository:       overtimeRepository,
+		leaveRepository:          leaveRepository,
+		leaveTypeRepository:      leaveTypeRepository,
+		holidayRepository:        holidayRepository,
+		workCalendarRepository:   workCalendarRepository,
+		expenseClaimRepository:   expenseClaimRepository,
+		employeeRepository:       employeeRepository,
+		kafkaProducerService:     kafkaProducerService,
+		logger:                   logger,
+	}
+}
+
+func (s *payrollService) CreateSalary(c context.Context, request web.CreateEmployeeSalaryRequest) (web.EmployeeSalaryResponse, error) {
+	employee, err := findEmployee(c, s.employeeRepository, request.EmployeeID)
+	if err != nil {
+		return web.EmployeeSalaryResponse{}, err
+	}
+
+	// convert to domain or model employee salary
+	salary := domain.ToDomainEmployeeSalary(request)
+	salary.ID = uuid.New().String()
+	salary.CreatedAt = time.Now()
+	salary.UpdatedAt = time.Now()
+	salary.EmployeeNumber = employee.EmployeeNumber
+	salary.EmployeeName = employee.Name
+
+	// the allowance codes are the codes of the payslip items
+	codes := map[string]bool{}
+	for _, allowance := range salary.Allowances {
+		if codes[allowance.Code] {
+			return web.EmployeeSalaryResponse{}, exception.ErrBadRequest(fmt.Sprintf("Allowance code %s is repeated.", allowance.Code))
+		}
+		codes[allowance.Code] = true
+	}
+
+	// call the repo for inserting to db
+	if err := s.employeeSalaryRepository.CreateSalary(c, salary); err != nil {
+		s.logger.Infow(err.Error(), "Create Employee Salary Error")
+		return web.EmployeeSalaryResponse{}, toEmployeeSalaryUniqueError(err)
+	}
+
+	return salary.ToEmployeeSalaryResponse(), nil
+}
+
+func (s *payrollService) CreateBpjsRate(c context.Context, request web.CreateBpjsRateRequest) (web.BpjsRateResponse, error) {
+	// convert to domain or model BPJS rate
+	rate := domain.ToDomainBpjsRate(request)
+	rate.ID = uuid.New().String()
+	rate.CreatedAt = time.Now()
+
+	// call the repo for inserting to db
+	if err := s.payrollRateRepository.CreateBpjsRate(c, rate); err != nil {
+		s.logger.Infow(err.Error(), "Create BPJS Rate Error")
+		return web.BpjsRateResponse{}, toBpjsRateUniqueError(err)
+	}
+
+	return rate.ToBpjsRateResponse(), nil
+}
+
+func (s *payrollService) CreateRun(c context.Context, userID string, request web.CreatePayrollRunRequest) (web.PayrollRunResponse, error) {
+	now := time.Now()
+	periodStart := time.Date(request.Year, time.Month(request.Month), 1, 0, 0, 0, 0, time.UTC)
+	run := domain.PayrollRun{
+		ID:          uuid.New().String(),
+		Year:        request.Year,
+		Month:       request.Month,
+		PeriodStart: periodStart,
+		PeriodEnd:   periodStart.AddDate(0, 1, -1),
+		Status:      domain.PayrollStatusDraft,
+		CreatedBy:   &userID,
+		CreatedAt:   now,
+		UpdatedAt:   now,
+	}
+
+	payslips, err := s.calculate(c, &run)
+	if err != nil {
+		return web.PayrollRunResponse{}, err
+	}
+
+	// call the repo for inserting to db
+	if err := s.payrollRepository.CreateRun(c, run, payslips); err != nil {
+		s.logger.Infow(err.Error(), "Create Payroll Run Error")
+		return web.PayrollRunResponse{}, toPayrollRunUniqueError(err)
+	}
+
+	return run.ToPayrollRunResponse(), nil
+}
+
+func (s *payrollService) RecalculateRun(c context.Context, id string) (web.PayrollRunResponse, error) {
+	run, err := findPayrollRun(c, s.payrollRepository, id)
+	if err != nil {
+		return web.PayrollRunResponse{}, err
+	}
+	if run.Status != domain.PayrollStatusDraft {
+		return web.PayrollRunResponse{}, exception.ErrBadRequest("Only a draft payroll run can be recalculated.")
+	}
+
+	payslips, err := s.calculate(c, &run)
+	if err != nil {
+		return web.PayrollRunResponse{}, err
+	}
+	run.UpdatedAt = time.Now()
+
+	if err := s.payrollRepository.RecalculateRun(c, run, payslips); err != nil {
+		s.logger.Infow(err.Error(), "Recalculate Payroll Run Error")
+		return web.PayrollRunResponse{}, toPayrollRunStatusError(err, domain.PayrollStatusDraft)
+	}
+
+	return run.ToPayrollRunResponse(), nil
+}
+
+func (s *payrollService) ReviewRun(c context.Context, userID, id string) (web.PayrollRunResponse, error) {
+	run, err := findPayrollRun(c, s.payrollRepository, id)
+	if err != nil {
+		return web.PayrollRunResponse{}, err
+	}
+	if run.Status != domain.PayrollStatusDraft {
+		return web.PayrollRunResponse{}, exception.ErrBadRequest("Only a draft payroll run can be reviewed.")
+	}
+
+	now := time.Now()
+	run.Status = domain.PayrollStatusReviewed
+	run.ReviewedBy = &userID
+	run.ReviewedAt = &now
+	run.UpdatedAt = now
+
+	if err := s.payrollRepository.UpdateRun(c, run, domain.PayrollStatusDraft); err != nil {
+		s.logger.Infow(err.Error(), "Review Payroll Run Error")
+		return web.PayrollRunResponse{}, toPayrollRunStatusError(err, domain.PayrollStatusDraft)
+	}
+
+	return run.ToPayrollRunResponse(), nil
+}
+
+func (s *payrollService) ReopenRun(c context.Context, id string) (web.PayrollRunResponse, error) {
+	run, err := findPayrollRun(c, s.payrollRepository, id)
+	if err != nil {
+		return web.PayrollRunResponse{}, err
+	}
+	if run.Status != domain.PayrollStatusReviewed {
+		return web.PayrollRunResponse{}, exception.ErrBadRequest("Only a reviewed payroll run can be reopened.")
+	}
+
+	run.Status = domain.PayrollStatusDraft
+	run.ReviewedBy = nil
+	run.ReviewedAt = nil
+	run.UpdatedAt = time.Now()
+
+	if err := s.payrollRepository.UpdateRun(c, run, domain.PayrollStatusReviewed); err != nil {
+		s.logger.Infow(err.Error(), "Reopen Payroll Run Error")
+		return web.PayrollRunResponse{}, toPayrollRunStatusError(err, domain.PayrollStatusReviewed)
+	}
+
+	return run.ToPayrollRunResponse(), nil
+}
+
+func (s *payrollService) LockRun(c context.Context, userID, id string) (web.PayrollRunResponse, error) {
+	run, err := findPayrollRun(c, s.payrollRepository, id)
+	if err != nil {
+		return web.PayrollRunResponse{}, err
+	}
+	if run.Status != domain.PayrollStatusReviewed {
+		return web.PayrollRunResponse{}, exception.ErrBadRequest("Only a reviewed payroll run can be locked.")
+	}
+
+	// the runs are locked in order, the year-to-date of the December run is summed from the locked runs
+	unlocked, err := s.payrollRepository.CountUnlockedRuns(c, run.Year, run.Month)
+	if err != nil {
+		return web.PayrollRunResponse{}, err
+	}
+	if unlocked > 0 {
+		return web.PayrollRunResponse{}, exception.ErrBadRequest("Lock the earlier payroll runs of the year first.")
+	}
+
+	// the expense claims reimbursed in the run are paid when the run is locked
+	claims, err := s.expenseClaimRepository.FindAllClaim(c, domain.ExpenseClaimQueryFilter{PayrollRunID: run.ID, Status: domain.ExpenseClaimApproved})
+	if err != nil {
+		return web.PayrollRunResponse{}, err
+	}
+
+	now := time.Now()
+	run.Status = domain.PayrollStatusLocked
+	run.LockedBy = &userID
+	run.LockedAt = &now
+	run.UpdatedAt = now
+
+	histories := []domain.ExpenseClaimHistory{}
+	fromStatus := domain.ExpenseClaimApproved
+	for i := range claims {
+		claims[i].Status = domain.ExpenseClaimPaid
+		histories = append(histories, claims[i].NewHistory(uuid.New().String(), &fromStatus,
+			fmt.Sprintf("Reimbursed in the payroll of %02d/%d.", run.Month, run.Year), &userID, now))
+	}
+
+	if err := s.payrollRepository.LockRun(c, run, histories); err != nil {
+		s.logger.Infow(err.Error(), "Lock Payroll Run Error")
+		return web.PayrollRunResponse{}, toPayrollRunStatusError(err, domain.PayrollStatusReviewed)
+	}
+
+	kafkaPayrollRunMessage := kafkamodel.NewKafkaPayrollRunMessage(run)
+	go s.kafkaProducerService.Produce(kafkaPayrollRunMessage, "PUT.PAYROLL_RUN", config.KafkaTopic)
+
+	for _, claim := range claims {
+		kafkaNotificationMessage := kafkamodel.NewKafkaNotificationMessage(claim.EmployeeUserID, NotificationExpenseClaimPaid, "Expense Claim Paid",
+			fmt.Sprintf("Your expense claim %s is reimbursed in the payroll of %02d/%d.", claim.Title, run.Month, run.Year), map[string]interface{}{
+				"claim_id":       claim.ID,
+				"payroll_run_id": run.ID,
+				"total_amount":   claim.TotalAmount,
+			})
+		go s.kafkaProducerService.Produce(kafkaNotificationMessage, "POST.NOTIFICATION", config.KafkaTopicNotification)
+	}
+
+	return run.ToPayrollRunResponse(), nil
+}
+
+func (s *payrollService) DeleteRun(c context.Context, id string) error {
+	run, err := findPayrollRun(c, s.payrollRepository, id)
+	if err != nil {
+		return err
+	}
+	if run.Status != domain.PayrollStatusDraft {
+		return exception.ErrBadRequest("Only a draft payroll run can be deleted.")
+	}
+
+	if err := s.payrollRepository.DeleteRun(c, id, domain.PayrollStatusDraft); err != nil {
+		return toPayrollRunStatusError(err, domain.PayrollStatusDraft)
+	}
+
+	return nil
+}
+
+func (s *payrollService) FindAllSalary(c context.Context, filter web.EmployeeSalaryQueryFilter) ([]web.EmployeeSalaryResponse, error) {
+	if _, err := findEmployee(c, s.employeeRepository, filter.EmployeeID); err != nil {
+		return nil, err
+	}
+
+	salaries, err := s.employeeSalaryRepository.FindAllSalary(c, filter.EmployeeID)
+	if err != nil {
+		return nil, err
+	}
+
+	// convert to web.EmployeeSalaryResponse
+	result := []web.EmployeeSalaryResponse{}
+	for _, salary := range salaries {
+		result = append(result, salary.ToEmployeeSalaryResponse())
+	}
+
+	return result, nil
+}
+
+func (s *payrollService) FindRates(c context.Context, filter web.PayrollRateQueryFilter) (web.PayrollRatesResponse, error) {
+	date := helper.Today()
+	if filter.Date != "" {
+		date, _ = helper.ParseDate(filter.Date)
+	}
+
+	rates, err := s.payrollRateRepository.FindRates(c, date)
+	if err != nil {
+		return web.PayrollRatesResponse{}, err
+	}
+
+	return rates.ToPayrollRatesResponse(date), nil
+}
+
+func (s *payrollService) FindAllRun(c context.Context, filter web.PayrollRunQueryFilter) ([]web.PayrollRunResponse, int, error) {
+	domainFilter := domain.ToDomainPayrollRunQueryFilter(filter)
+
+	runs, err := s.payrollRepository.FindAllRun(c, domainFilter)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	totalData, err := s.payrollRepository.CountAllRun(c, domainFilter)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	// convert to web.PayrollRunResponse
+	result := []web.PayrollRunResponse{}
+	for _, run := range runs {
+		result = append(result, run.ToPayrollRunResponse())
+	}
+
+	return result, totalData, nil
+}
+
+func (s *payrollService) FindRunById(c context.Context, id string) (web.PayrollRunResponse, error) {
+	run, err := findPayrollRun(c, s.payrollRepository, id)
+	if err != nil {
+		return web.PayrollRunResponse{}, err
+	}
+
+	return run.ToPayrollRunResponse(), nil
+}
+
+func (s *payrollService) FindAllPayslip(c context.Context, runID string) ([]web.PayslipResponse, error) {
+	if _, err := findPayrollRun(c, s.payrollRepository, runID); err != nil {
+		return nil, err
+	}
+
+	payslips, err := s.payrollRepository.FindAllPayslip(c, runID)
+	if err != nil {
+		return nil, err
+	}
+
+	// convert to web.PayslipResponse
+	result := []web.PayslipResponse{}
+	for _, payslip := range payslips {
+		result = append(result, payslip.ToPayslipResponse())
+	}
+
+	return result, nil
+}
+
+func (s *payrollService) FindPayslipById(c context.Context, runID, id string) (web.PayslipResponse, error) {
+	payslip, err := s.payrollRepository.FindPayslipById(c, runID, id)
+	if err != nil {
+		if strings.Contains(err.Error(), "no rows") {
+			return web.PayslipResponse{}, exception.ErrNotFound(fmt.Sprintf("Payslip %s not found", id))
+		}
+		return web.PayslipResponse{}, err
+	}
+
+	return payslip.ToPayslipResponse(), nil
+}
+
+// find the payroll run by id and convert the 'no rows' error to not found error
+func findPayrollRun(c context.Context, payrollRepository repository.PayrollRepository, id string) (domain.PayrollRun, error) {
+	run, err := payrollRepository.FindRunById(c, id)
+	if err != nil {
+		if strings.Contains(err.Error(), "no rows") {
+			return domain.PayrollRun{}, exception.ErrNotFound(fmt.Sprintf("Payroll run %s not found", id))
+		}
+		return domain.PayrollRun{}, err
+	}
+
+	return run, nil
+}
+
+// convert the 'no rows' error of the guarded change of the payroll run, the run is changed by another request in the
+// meantime
+func toPayrollRunStatusError(err error, status string) error {
+	if strings.Contains(err.Error(), "no rows") {
+		return exception.ErrBadRequest(fmt.Sprintf("Payroll run is no longer %s.", status))
+	}
+	return err
+}
+
+// calculate the payslips of the active employees with a salary, from the salaries and the rates effective on
+// the last day of the period, the approved overtime worked in the attendances, the approved unpaid leaves of the
+// period and the expense claims approved until the end of the period.
+// The totals of the run are updated.
+func (s *payrollService) calculate(c context.Context, run *domain.PayrollRun) ([]domain.Payslip, error) {
+	from, to := run.PeriodStart, run.PeriodEnd
+
+	rates, err := s.payrollRateRepository.FindRates(c, to)
+	if err != nil {
+		return nil, err
+	}
+	if len(rates.Ptkp) == 0 || len(rates.Ter) == 0 || len(rates.Tax) == 0 {
+		return nil, exception.ErrBadRequest(fmt.Sprintf("The PPh 21 rates effective on %s are not configured.", to.Format(helper.DateLayout)))
+	}
+
+	// the annual tax of the last period is reconciled with the locked runs of the year
+	lastPeriod := run.Month == 12
+	yearToDate := map[string]domain.PayslipYearToDate{}
+	if lastPeriod {
+		unlocked, err := s.payrollRepository.CountUnlockedRuns(c, run.Year, run.Month)
+		if err != nil {
+			return nil, err
+		}
+		if unlocked > 0 {
+			return nil, exception.ErrBadRequest("Lock the earlier payroll runs of the year before calculating the last period.")
+		}
+
+		sums, err := s.payrollRepository.FindYearToDate(c, run.Year, run.Month)
+		if err != nil {
+			return nil, err
+		}
+		for _, sum := range sums {
+			yearToDate[sum.EmployeeID] = sum
+		}
+	}
+
+	employees, err := s.employeeRepository.FindAllEmployee(c, domain.EmployeeQueryFilter{Status: domain.EmployeeStatusActive})
+	if err != nil {
+		return nil, err
+	}
+
+	salaries, err := s.employeeSalaryRepository.FindEffectiveSalaries(c, to)
+	if err != nil {
+		return nil, err
+	}
+	salaryByEmployee := map[string]domain.EmployeeSalary{}
+	for _, salary := range salaries {
+		salaryByEmployee[salary.EmployeeID] = salary
+	}
+
+	calendars, err := findCalendars(c, s.workCalendarRepository, s.holidayRepository, from, to)
+	if err != nil {
+		return nil, err
+	}
+	calendarByEmployee := map[string]domain.Calendar{}
+	for _, employee := range employees {
+		calendarByEmployee[employee.ID] = calendars.For(employee.WorkCalendarID)
+	}
+
+	// the approved overtime is paid up to the overtime of the attendance, by the multipliers of a working day or
+	// a day off
+	overtimes, err := s.overtimeRepository.FindAllOvertime(c, domain.OvertimeQueryFilter{
+		Status: domain.OvertimeStatusApproved,
+		From:   from.Format(helper.DateLayout),
+		To:     to.Format(helper.DateLayout),
+	})
+	if err != nil {
+		return nil, err
+	}
+	overtimeHours := map[string]float64{}
+	for _, overtime := range overtimes {
+		calendar, ok := calendarByEmployee[overtime.EmployeeID]
+		if !ok {
+			continue
+		}
+		overtimeHours[overtime.EmployeeID] += overtime.MultipliedHours(calendar.SixDayWeek())
+	}
+
+	// the days of the approved unpaid leaves within the period
+	leaveTypes, err := s.leaveTypeRepository.FindAllLeaveType(c)
+	if err != nil {
+		return nil, err
+	}
+	unpaidTypes := map[string]bool{}
+	for _, leaveType := range leaveTypes {
+		if !leaveType.IsPaid {
+			unpaidTypes[leaveType.ID] = true
+		}
+	}
+	leaves, err := s.leaveRepository.FindAllLeave(c, domain.LeaveQueryFilter{
+		Status: domain.LeaveStatusApproved,
+		From:   from.Format(helper.DateLayout),
+		To:     to.Format(helper.DateLayout),
+	})
+	if err != nil {
+		return nil, err
+	}
+	unpaidLeaveDays := map[string]float64{}
+	for _, leave := range leaves {
+		calendar, ok := calendarByEmployee[leave.EmployeeID]
+		if !ok || !unpaidTypes[leave.LeaveTypeID] {
+			continue
+		}
+		startDate, endDate := leave.StartDate, leave.EndDate
+		if startDate.Before(from) {
+			startDate = from
+		}
+		if endDate.After(to) {
+			endDate = to
+		}
+		unpaidLeaveDays[leave.EmployeeID] += domain.CountLeaveDays(startDate, endDate, leave.HalfDay, calendar)
+	}
+
+	// the expense claims approved by finance until the end of the period which are not reimbursed in another run
+	claims, err := s.expenseClaimRepository.FindPayableClaims(c, run.ID, to.AddDate(0, 0, 1))
+	if err != nil {
+		return nil, err
+	}
+	reimbursements := map[string]int64{}
+	claimIDs := map[string][]string{}
+	for _, claim := range claims {
+		reimbursements[claim.EmployeeID] += claim.TotalAmount
+		claimIDs[claim.EmployeeID] = append(claimIDs[claim.EmployeeID], claim.ID)
+	}
+
+	now := time.Now()
+	payslips := []domain.Payslip{}
+	for _, employee := range employees {
+		salary, ok := salaryByEmployee[employee.ID]
+		if !ok {
+			continue
+		}
+		calendar := calendarByEmployee[employee.ID]
+
+		payslip := domain.Payslip{
+			ID:              uuid.New().String(),
+			PayrollRunID:    run.ID,
+			EmployeeID:      employee.ID,
+			BaseSalary:      salary.BaseSalary,
+			Allowances:      salary.Allowances,
+			TaxStatus:       salary.TaxStatus,
+			WorkingDays:     calendar.CountWorkingDays(from, to),
+			OvertimeHours:   math.Round(overtimeHours[employee.ID]*100) / 100,
+			UnpaidLeaveDays: unpaidLeaveDays[employee.ID],
+			Reimbursement:   reimbursements[employee.ID],
+			ExpenseClaimIDs: claimIDs[employee.ID],
+			CreatedAt:       now,
+		}
+		if err := payslip.Calculate(rates, yearToDate[employee.ID], lastPeriod); err != nil {
+			return nil, exception.ErrBadRequest(fmt.Sprintf("Failed to calculate the payslip of employee %s, %s.", employee.EmployeeNumber, err.Error()))
+		}
+		payslips = append(payslips, payslip)
+	}
+
+	run.Total(payslips)
+	run.CalculatedAt = now
+
+	return payslips, nil
+}
+
+// convert the unique constraint error of the 'employee_salaries' table to bad request error
+func toEmployeeSalaryUniqueError(err error) error {
+	if strings.Contains(err.Error(), "unique") && strings.Contains(err.Error(), "employee_salaries_employee_id_effective_from_key") {
+		return exception.ErrBadRequest("Salary of the employee already exist on the effective date.")
+	}
+	return err
+}
+
+// convert the unique constraint error of the 'bpjs_rates' table to bad request error
+func toBpjsRateUniqueError(err error) error {
+	if strings.Contains(err.Error(), "unique") && strings.Contains(err.Error(), "bpjs_rates_program_effective_from_key") {
+		return exception.ErrBadRequest("Rate of the BPJS program already exist on the effective date.")
+	}
+	return err
+}
+
+// convert the unique constraint error of the 'payroll_runs' table to bad request error
+func toPayrollRunUniqueError(err error) error {
+	if strings.Contains(err.Error(), "unique") && strings.Contains(err.Error(), "payroll_runs_year_month_key") {
+		return exception.ErrBadRequest("Payroll run of the month already exist.")
+	}
+	return err
+}
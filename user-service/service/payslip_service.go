@@ -0,0 +1,274 @@
+package service
+
+import (
+	"context"
+	"embed"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/iqbaludinm/hr-microservice/user-service/exception"
+	"github.com/iqbaludinm/hr-microservice/user-service/helper"
+	"github.com/iqbaludinm/hr-microservice/user-service/model/domain"
+	"github.com/iqbaludinm/hr-microservice/user-service/model/web"
+	"github.com/iqbaludinm/hr-microservice/user-service/repository"
+	"go.uber.org/zap"
+)
+
+type PayslipService interface {
+	// With Transaction
+	GenerateDocuments(ctx context.Context, runID string, request web.GeneratePayslipDocumentRequest) (web.GeneratePayslipDocumentResponse, error)
+	DownloadMyPayslip(ctx context.Context, userID, id string) ([]byte, string, error)
+	EmailMyPayslip(ctx context.Context, userID, id string) (web.PayslipResponse, error)
+
+	// Without Transaction
+	FindMyPayslip(ctx context.Context, userID string, filter web.PayslipQueryFilter) ([]web.PayslipResponse, error)
+}
+
+type payslipService struct {
+	payrollRepository  repository.PayrollRepository
+	employeeRepository repository.EmployeeRepository
+	storage            helper.Storage
+	templateFS         embed.FS
+	pdfRenderer        helper.PDFRenderer
+	logger             *zap.SugaredLogger
+}
+
+func NewPayslipService(payrollRepository repository.PayrollRepository, employeeRepository repository.EmployeeRepository, storage helper.Storage, templateFS embed.FS, pdfRenderer helper.PDFRenderer, logger *zap.SugaredLogger) PayslipService {
+	return &payslipService{
+		payrollRepository:  payrollRepository,
+		employeeRepository: employeeRepository,
+		storage:            storage,
+		templateFS:         templateFS,
+		pdfRenderer:        pdfRenderer,
+		logger:             logger,
+	}
+}
+
+// generate the payslip pdfs of the locked run again and email them if requested, a failed payslip does not stop
+// the others and is returned in the result
+func (s *payslipService) GenerateDocuments(c context.Context, runID string, request web.GeneratePayslipDocumentRequest) (web.GeneratePayslipDocumentResponse, error) {
+	run, err := findPayrollRun(c, s.payrollRepository, runID)
+	if err != nil {
+		return web.GeneratePayslipDocumentResponse{}, err
+	}
+	if run.Status != domain.PayrollStatusLocked {
+		return web.GeneratePayslipDocumentResponse{}, exception.ErrBadRequest("The payslips can only be generated for a locked payroll run.")
+	}
+
+	payslips, err := s.payrollRepository.FindAllPayslip(c, runID)
+	if err != nil {
+		return web.GeneratePayslipDocumentResponse{}, err
+	}
+
+	result := web.GeneratePayslipDocumentResponse{Failed: []web.PayslipDocumentErrorResponse{}}
+	for _, payslip := range payslips {
+		emailed, err := s.generate(c, payslip.ID, runID, request.SendEmail)
+		if err != nil {
+			s.logger.Infow(err.Error(), "Generate Payslip Document Error")
+			result.Failed = append(result.Failed, web.PayslipDocumentErrorResponse{
+				PayslipID:      payslip.ID,
+				EmployeeNumber: payslip.EmployeeNumber,
+				Error:          err.Error(),
+			})
+			continue
+		}
+
+		result.Generated++
+		if emailed {
+			result.Emailed++
+		}
+	}
+
+	return result, nil
+}
+
+// download the payslip pdf of the logged in user, the pdf is generated when it is not in the storage yet
+func (s *payslipService) DownloadMyPayslip(c context.Context, userID, id string) ([]byte, string, error) {
+	employee, payslip, err := s.findMyPayslip(c, userID, id)
+	if err != nil {
+		return nil, "", err
+	}
+
+	pdf, err := s.document(c, &payslip, employee, false)
+	if err != nil {
+		s.logger.Infow(err.Error(), "Download Payslip Error")
+		return nil, "", err
+	}
+
+	return pdf, payslip.DocumentName(), nil
+}
+
+// email the payslip pdf to the logged in user
+func (s *payslipService) EmailMyPayslip(c context.Context, userID, id string) (web.PayslipResponse, error) {
+	employee, payslip, err := s.findMyPayslip(c, userID, id)
+	if err != nil {
+		return web.PayslipResponse{}, err
+	}
+	if employee.Email == "" {
+		return web.PayslipResponse{}, exception.ErrBadRequest("The employee has no email.")
+	}
+
+	pdf, err := s.document(c, &payslip, employee, false)
+	if err != nil {
+		s.logger.Infow(err.Error(), "Email Payslip Error")
+		return web.PayslipResponse{}, err
+	}
+
+	if err := s.email(c, &payslip, employee, pdf); err != nil {
+		s.logger.Infow(err.Error(), "Email Payslip Error")
+		return web.PayslipResponse{}, err
+	}
+
+	return payslip.ToPayslipResponse(), nil
+}
+
+// find the payslips of the logged in user, only the payslips of the locked runs are returned
+func (s *payslipService) FindMyPayslip(c context.Context, userID string, filter web.PayslipQueryFilter) ([]web.PayslipResponse, error) {
+	employee, err := findEmployeeByUser(c, s.employeeRepository, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	payslips, err := s.payrollRepository.FindAllPayslipByEmployee(c, employee.ID, filter.Year)
+	if err != nil {
+		return nil, err
+	}
+
+	// convert to web.PayslipResponse
+	result := []web.PayslipResponse{}
+	for _, payslip := range payslips {
+		result = append(result, payslip.ToPayslipResponse())
+	}
+
+	return result, nil
+}
+
+func (s *payslipService) findMyPayslip(c context.Context, userID, id string) (domain.Employee, domain.Payslip, error) {
+	employee, err := findEmployeeByUser(c, s.employeeRepository, userID)
+	if err != nil {
+		return domain.Employee{}, domain.Payslip{}, err
+	}
+
+	payslip, err := s.payrollRepository.FindPayslipByEmployee(c, employee.ID, id)
+	if err != nil {
+		if strings.Contains(err.Error(), "no rows") {
+			return domain.Employee{}, domain.Payslip{}, exception.ErrNotFound(fmt.Sprintf("Payslip %s not found", id))
+		}
+		return domain.Employee{}, domain.Payslip{}, err
+	}
+
+	return employee, payslip, nil
+}
+
+// generate the pdf of the payslip in the run and email it to the employee (if requested and the employee has an
+// email), it returns whether the payslip is emailed
+func (s *payslipService) generate(c context.Context, id, runID string, sendEmail bool) (bool, error) {
+	payslip, err := s.payrollRepository.FindPayslipById(c, runID, id)
+	if err != nil {
+		return false, err
+	}
+
+	employee, err := findEmployee(c, s.employeeRepository, payslip.EmployeeID)
+	if err != nil {
+		return false, err
+	}
+
+	pdf, err := s.document(c, &payslip, employee, true)
+	if err != nil {
+		return false, err
+	}
+
+	if !sendEmail || employee.Email == "" {
+		return false, nil
+	}
+	if err := s.email(c, &payslip, employee, pdf); err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+// return the pdf of the payslip from the storage, the pdf is rendered and saved to the storage when it is not
+// generated yet or 'regenerate' is true. The pdf is protected with the date of birth of the employee.
+func (s *payslipService) document(c context.Context, payslip *domain.Payslip, employee domain.Employee, regenerate bool) ([]byte, error) {
+	if payslip.DocumentKey != nil && !regenerate {
+		pdf, err := s.storage.Get(c, *payslip.DocumentKey)
+		if err == nil {
+			return pdf, nil
+		}
+		if !errors.Is(err, helper.ErrObjectNotFound) {
+			return nil, err
+		}
+	}
+
+	data := map[string]interface{}{
+		"Payslip":               payslip,
+		"Period":                helper.ParsePeriodToIndonesian(payslip.Year, payslip.Month),
+		"JobTitle":              employee.JobTitle,
+		"Earnings":              payslipItems(payslip.Items, domain.PayslipItemEarning),
+		"Deductions":            payslipItems(payslip.Items, domain.PayslipItemDeduction),
+		"EmployerContributions": payslipItems(payslip.Items, domain.PayslipItemEmployerContribution),
+		"PrintedAt":             helper.ParseTimeToFullIndonesian(helper.Today()),
+	}
+
+	options := helper.PDFOptions{
+		PaperSize: "A4",
+		Margin:    &helper.PDFMargin{Top: 0.5, Right: 0.5, Bottom: 0.5, Left: 0.5},
+		Password:  domain.PayslipPassword(employee.DateOfBirth),
+	}
+	pdf, err := helper.RenderPDF(c, s.pdfRenderer, s.templateFS, "payslip.html", options, data)
+	if err != nil {
+		return nil, err
+	}
+
+	key := payslip.NewDocumentKey()
+	if err := s.storage.Put(c, key, pdf.Bytes()); err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	payslip.DocumentKey = &key
+	payslip.DocumentGeneratedAt = &now
+	if err := s.payrollRepository.UpdatePayslipDocument(c, *payslip); err != nil {
+		return nil, err
+	}
+
+	return pdf.Bytes(), nil
+}
+
+// email the payslip pdf to the employee with the 'payslip_email.html' template as the body
+func (s *payslipService) email(c context.Context, payslip *domain.Payslip, employee domain.Employee, pdf []byte) error {
+	period := helper.ParsePeriodToIndonesian(payslip.Year, payslip.Month)
+
+	data := map[string]interface{}{
+		"Name":   employee.Name,
+		"Period": period,
+	}
+	body, err := helper.RenderHTML(s.templateFS, "payslip_email.html", data)
+	if err != nil {
+		return err
+	}
+
+	subject := fmt.Sprintf("Slip Gaji %s", period)
+	if err := helper.SendEmail([]string{employee.Email}, subject, body.String(), payslip.DocumentName(), pdf); err != nil {
+		return err
+	}
+
+	now := time.Now()
+	payslip.EmailedAt = &now
+
+	return s.payrollRepository.UpdatePayslipDocument(c, *payslip)
+}
+
+// the items of the payslip in the category, in the order of the payslip
+func payslipItems(items []domain.PayslipItem, category string) []domain.PayslipItem {
+	result := []domain.PayslipItem{}
+	for _, item := range items {
+		if item.Category == category {
+			result = append(result, item)
+		}
+	}
+	return result
+}
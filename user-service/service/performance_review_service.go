@@ -0,0 +1,831 @@
+package service
+
+import (
+	"context"
+	"embed"
+	"fmt"
+	"math"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/iqbaludinm/hr-microservice/user-service/config"
+	"github.com/iqbaludinm/hr-microservice/user-service/exception"
+	"github.com/iqbaludinm/hr-microservice/user-service/helper"
+	"github.com/iqbaludinm/hr-microservice/user-service/model/domain"
+	"github.com/iqbaludinm/hr-microservice/user-service/model/kafkamodel"
+	"github.com/iqbaludinm/hr-microservice/user-service/model/web"
+	"github.com/iqbaludinm/hr-microservice/user-service/repository"
+	"github.com/iqbaludinm/hr-microservice/user-service/service/producers"
+	"go.uber.org/zap"
+)
+
+// Type of the notifications produced by the performance review service.
+const (
+	NotificationReviewPhaseStarted  = "REVIEW_PHASE_STARTED"
+	NotificationReviewPeerRequested = "REVIEW_PEER_FEEDBACK_REQUESTED"
+	NotificationReviewCompleted     = "REVIEW_COMPLETED"
+)
+
+// the rating of the review printed in the review summary
+var reviewRatingLabels = map[string]string{
+	domain.ReviewRatingOutstanding:      "Istimewa",
+	domain.ReviewRatingExceeds:          "Melebihi Harapan",
+	domain.ReviewRatingMeets:            "Memenuhi Harapan",
+	domain.ReviewRatingNeedsImprovement: "Perlu Perbaikan",
+	domain.ReviewRatingUnsatisfactory:   "Tidak Memuaskan",
+}
+
+type PerformanceReviewService interface {
+	// With Transaction
+	CreateCycle(ctx context.Context, userID string, request web.ReviewCycleRequest) (web.ReviewCycleResponse, error)
+	UpdateCycle(ctx context.Context, id string, request web.ReviewCycleRequest) (web.ReviewCycleResponse, error)
+	AdvanceCycle(ctx context.Context, id string) (web.ReviewCycleResponse, error)
+	UpdateGoals(ctx context.Context, userID, id string, request web.ReviewGoalsRequest) (web.PerformanceReviewResponse, error)
+	SelfAssessment(ctx context.Context, userID, id string, request web.ReviewAssessmentRequest) (web.PerformanceReviewResponse, error)
+	ManagerAssessment(ctx context.Context, userID, id string, request web.ReviewAssessmentRequest) (web.PerformanceReviewResponse, error)
+	UpdateReviewer(ctx context.Context, id string, request web.ReviewReviewerRequest) (web.PerformanceReviewResponse, error)
+	RequestPeers(ctx context.Context, userID, id string, request web.ReviewPeersRequest) (web.PerformanceReviewResponse, error)
+	SubmitPeerFeedback(ctx context.Context, userID, id string, request web.ReviewPeerFeedbackRequest) error
+	Calibrate(ctx context.Context, userID, id string, request web.ReviewCalibrationRequest) (web.PerformanceReviewResponse, error)
+
+	// Without Transaction
+	FindAllCycle(ctx context.Context, filter web.ReviewCycleQueryFilter) ([]web.ReviewCycleResponse, int, error)
+	FindCycleById(ctx context.Context, id string) (web.ReviewCycleResponse, error)
+	FindCycleReviews(ctx context.Context, id string, filter web.PerformanceReviewQueryFilter) ([]web.PerformanceReviewResponse, int, error)
+	RatingDistribution(ctx context.Context, id string) ([]web.ReviewRatingDistributionResponse, error)
+	FindMyReviews(ctx context.Context, userID string) ([]web.PerformanceReviewResponse, error)
+	FindTeamReviews(ctx context.Context, userID string) ([]web.PerformanceReviewResponse, error)
+	FindMyPeerRequests(ctx context.Context, userID string) ([]web.ReviewPeerRequestResponse, error)
+	FindReviewById(ctx context.Context, id string) (web.PerformanceReviewResponse, error)
+	Summary(ctx context.Context, id string) ([]byte, string, error)
+}
+
+type performanceReviewService struct {
+	performanceReviewRepository repository.PerformanceReviewRepository
+	employeeRepository          repository.EmployeeRepository
+	templateFS                  embed.FS
+	pdfRenderer                 helper.PDFRenderer
+	kafkaProducerService        producers.KafkaProducerService
+	logger                      *zap.SugaredLogger
+}
+
+func NewPerformanceReviewService(performanceReviewRepository repository.PerformanceReviewRepository, employeeRepository repository.EmployeeRepository, templateFS embed.FS, pdfRenderer helper.PDFRenderer, kafkaProducerService producers.KafkaProducerService, logger *zap.SugaredLogger) PerformanceReviewService {
+	return &performanceReviewService{
+		performanceReviewRepository: performanceReviewRepository,
+		employeeRepository:          employeeRepository,
+		templateFS:                  templateFS,
+		pdfRenderer:                 pdfRenderer,
+		kafkaProducerService:        kafkaProducerService,
+		logger:                      logger,
+	}
+}
+
+// create the draft review cycle with the reviews of its participants, the reviewer of a participant is the manager
+// of the employee
+func (s *performanceReviewService) CreateCycle(c context.Context, userID string, request web.ReviewCycleRequest) (web.ReviewCycleResponse, error) {
+	// convert to domain or model review cycle
+	cycle := domain.ToDomainReviewCycle(request)
+	if !cycle.PeriodEnd.After(cycle.PeriodStart) {
+		return web.ReviewCycleResponse{}, exception.ErrBadRequest("The period end must be after the period start.")
+	}
+
+	reviews, err := s.participants(c, request)
+	if err != nil {
+		return web.ReviewCycleResponse{}, err
+	}
+
+	cycle.ID = uuid.New().String()
+	cycle.Reviews = reviews
+	cycle.CreatedBy = &userID
+	cycle.CreatedAt = time.Now()
+	cycle.UpdatedAt = time.Now()
+
+	// call the repo for inserting to db
+	if err := s.performanceReviewRepository.CreateCycle(c, cycle); err != nil {
+		s.logger.Infow(err.Error(), "Create Review Cycle Error")
+		return web.ReviewCycleResponse{}, err
+	}
+
+	newCycle, err := s.performanceReviewRepository.FindCycleById(c, cycle.ID)
+	if err != nil {
+		return web.ReviewCycleResponse{}, exception.ErrInternalServer(fmt.Sprintf("Successfully created review cycle, but failed to get the review cycle have created. Error: %s", err.Error()))
+	}
+
+	return newCycle.ToReviewCycleResponse(), nil
+}
+
+// update the draft review cycle, the reviews are replaced by the reviews of the updated participants
+func (s *performanceReviewService) UpdateCycle(c context.Context, id string, request web.ReviewCycleRequest) (web.ReviewCycleResponse, error) {
+	cycle, err := findReviewCycle(c, s.performanceReviewRepository, id)
+	if err != nil {
+		return web.ReviewCycleResponse{}, err
+	}
+	if cycle.Status != domain.ReviewCycleDraft {
+		return web.ReviewCycleResponse{}, exception.ErrBadRequest("Only a draft review cycle can be updated.")
+	}
+
+	updated := domain.ToDomainReviewCycle(request)
+	if !updated.PeriodEnd.After(updated.PeriodStart) {
+		return web.ReviewCycleResponse{}, exception.ErrBadRequest("The period end must be after the period start.")
+	}
+
+	reviews, err := s.participants(c, request)
+	if err != nil {
+		return web.ReviewCycleResponse{}, err
+	}
+
+	updated.ID = cycle.ID
+	updated.Reviews = reviews
+	updated.UpdatedAt = time.Now()
+
+	if err := s.performanceReviewRepository.UpdateCycle(c, updated); err != nil {
+		s.logger.Infow(err.Error(), "Update Review Cycle Error")
+		return web.ReviewCycleResponse{}, err
+	}
+
+	return s.FindCycleById(c, id)
+}
+
+// move the review cycle to the next phase. The goals of every review must add up to the total weight before the
+// self assessment, every review must have a reviewer before the manager assessment and every manager assessment
+// must be submitted before the calibration. The reviews are locked when the cycle is closed.
+func (s *performanceReviewService) AdvanceCycle(c context.Context, id string) (web.ReviewCycleResponse, error) {
+	cycle, err := findReviewCycle(c, s.performanceReviewRepository, id)
+	if err != nil {
+		return web.ReviewCycleResponse{}, err
+	}
+
+	nextStatus, ok := cycle.NextStatus()
+	if !ok {
+		return web.ReviewCycleResponse{}, exception.ErrBadRequest("The review cycle is already closed.")
+	}
+	if cycle.TotalReviews == 0 {
+		return web.ReviewCycleResponse{}, exception.ErrBadRequest("The review cycle has no participants.")
+	}
+
+	reviews, err := s.performanceReviewRepository.FindAllReview(c, domain.PerformanceReviewQueryFilter{CycleID: cycle.ID})
+	if err != nil {
+		return web.ReviewCycleResponse{}, err
+	}
+
+	switch nextStatus {
+	case domain.ReviewCycleSelfAssessment:
+		count, err := s.performanceReviewRepository.CountReviewsWithoutGoals(c, cycle.ID)
+		if err != nil {
+			return web.ReviewCycleResponse{}, err
+		}
+		if count > 0 {
+			return web.ReviewCycleResponse{}, exception.ErrBadRequest(fmt.Sprintf("%d reviews don't have goals with the total weight of %d.", count, domain.ReviewGoalTotalWeight))
+		}
+	case domain.ReviewCycleManagerAssessment:
+		count := 0
+		for _, review := range reviews {
+			if review.ReviewerID == nil {
+				count++
+			}
+		}
+		if count > 0 {
+			return web.ReviewCycleResponse{}, exception.ErrBadRequest(fmt.Sprintf("%d reviews don't have a reviewer.", count))
+		}
+	case domain.ReviewCycleCalibration:
+		if cycle.ManagerSubmitted < cycle.TotalReviews {
+			return web.ReviewCycleResponse{}, exception.ErrBadRequest(fmt.Sprintf("%d manager assessments are not submitted yet.", cycle.TotalReviews-cycle.ManagerSubmitted))
+		}
+	}
+
+	cycle.Status = nextStatus
+	cycle.UpdatedAt = time.Now()
+	if nextStatus == domain.ReviewCycleClosed {
+		closedAt := time.Now()
+		cycle.ClosedAt = &closedAt
+		for i := range reviews {
+			reviews[i].Lock(closedAt)
+		}
+		cycle.Reviews = reviews
+		err = s.performanceReviewRepository.CloseCycle(c, cycle)
+	} else {
+		err = s.performanceReviewRepository.UpdateCycleStatus(c, cycle)
+	}
+	if err != nil {
+		s.logger.Infow(err.Error(), "Advance Review Cycle Error")
+		return web.ReviewCycleResponse{}, err
+	}
+
+	s.notifyPhase(cycle, reviews)
+
+	return s.FindCycleById(c, id)
+}
+
+// set the goals of the review, by the employee or the reviewer during the goal setting
+func (s *performanceReviewService) UpdateGoals(c context.Context, userID, id string, request web.ReviewGoalsRequest) (web.PerformanceReviewResponse, error) {
+	review, err := findPerformanceReview(c, s.performanceReviewRepository, id)
+	if err != nil {
+		return web.PerformanceReviewResponse{}, err
+	}
+	if review.CycleStatus != domain.ReviewCycleGoalSetting {
+		return web.PerformanceReviewResponse{}, exception.ErrBadRequest("The goals can only be set during the goal setting.")
+	}
+	if userID != review.EmployeeUserID && (review.ReviewerUserID == nil || userID != *review.ReviewerUserID) {
+		return web.PerformanceReviewResponse{}, exception.ErrBadRequest("The goals can only be set by the employee or the reviewer.")
+	}
+
+	goals := domain.ToDomainReviewGoals(review.ID, request)
+	review.Goals = goals
+	if review.TotalWeight() != domain.ReviewGoalTotalWeight {
+		return web.PerformanceReviewResponse{}, exception.ErrBadRequest(fmt.Sprintf("The weights of the goals must add up to %d.", domain.ReviewGoalTotalWeight))
+	}
+
+	if err := s.performanceReviewRepository.UpdateGoals(c, review.ID, goals); err != nil {
+		s.logger.Infow(err.Error(), "Update Review Goals Error")
+		return web.PerformanceReviewResponse{}, err
+	}
+
+	return s.FindReviewById(c, id)
+}
+
+// score the goals by the employee during the self assessment
+func (s *performanceReviewService) SelfAssessment(c context.Context, userID, id string, request web.ReviewAssessmentRequest) (web.PerformanceReviewResponse, error) {
+	review, err := findPerformanceReview(c, s.performanceReviewRepository, id)
+	if err != nil {
+		return web.PerformanceReviewResponse{}, err
+	}
+	if review.CycleStatus != domain.ReviewCycleSelfAssessment {
+		return web.PerformanceReviewResponse{}, exception.ErrBadRequest("The self assessment can only be filled during the self assessment.")
+	}
+	if userID != review.EmployeeUserID {
+		return web.PerformanceReviewResponse{}, exception.ErrBadRequest("The self assessment can only be filled by the employee.")
+	}
+	if review.SelfSubmittedAt != nil {
+		return web.PerformanceReviewResponse{}, exception.ErrBadRequest("The self assessment is already submitted.")
+	}
+
+	if err := applyGoalScores(&review, request.Goals, true); err != nil {
+		return web.PerformanceReviewResponse{}, err
+	}
+	review.SelfScore = review.SelfGoalScore()
+	review.SelfComment = request.Comment
+	review.UpdatedAt = time.Now()
+	if request.Submit {
+		if review.SelfScore == nil {
+			return web.PerformanceReviewResponse{}, exception.ErrBadRequest("Every goal must be scored before the assessment is submitted.")
+		}
+		submittedAt := time.Now()
+		review.SelfSubmittedAt = &submittedAt
+	}
+
+	if err := s.performanceReviewRepository.UpdateSelfAssessment(c, review); err != nil {
+		s.logger.Infow(err.Error(), "Update Self Assessment Error")
+		return web.PerformanceReviewResponse{}, err
+	}
+
+	return s.FindReviewById(c, id)
+}
+
+// score the goals by the reviewer during the manager assessment
+func (s *performanceReviewService) ManagerAssessment(c context.Context, userID, id string, request web.ReviewAssessmentRequest) (web.PerformanceReviewResponse, error) {
+	review, err := findPerformanceReview(c, s.performanceReviewRepository, id)
+	if err != nil {
+		return web.PerformanceReviewResponse{}, err
+	}
+	if review.CycleStatus != domain.ReviewCycleManagerAssessment {
+		return web.PerformanceReviewResponse{}, exception.ErrBadRequest("The manager assessment can only be filled during the manager assessment.")
+	}
+	if review.ReviewerUserID == nil || userID != *review.ReviewerUserID {
+		return web.PerformanceReviewResponse{}, exception.ErrBadRequest("The manager assessment can only be filled by the reviewer.")
+	}
+	if review.ManagerSubmittedAt != nil {
+		return web.PerformanceReviewResponse{}, exception.ErrBadRequest("The manager assessment is already submitted.")
+	}
+
+	if err := applyGoalScores(&review, request.Goals, false); err != nil {
+		return web.PerformanceReviewResponse{}, err
+	}
+	review.ManagerScore = review.ManagerGoalScore()
+	review.ManagerComment = request.Comment
+	review.UpdatedAt = time.Now()
+	if request.Submit {
+		if review.ManagerScore == nil {
+			return web.PerformanceReviewResponse{}, exception.ErrBadRequest("Every goal must be scored before the assessment is submitted.")
+		}
+		submittedAt := time.Now()
+		review.ManagerSubmittedAt = &submittedAt
+	}
+
+	if err := s.performanceReviewRepository.UpdateManagerAssessment(c, review); err != nil {
+		s.logger.Infow(err.Error(), "Update Manager Assessment Error")
+		return web.PerformanceReviewResponse{}, err
+	}
+
+	return s.FindReviewById(c, id)
+}
+
+// replace the reviewer of the review, e.g. when the employee has no manager or the manager has changed
+func (s *performanceReviewService) UpdateReviewer(c context.Context, id string, request web.ReviewReviewerRequest) (web.PerformanceReviewResponse, error) {
+	review, err := findPerformanceReview(c, s.performanceReviewRepository, id)
+	if err != nil {
+		return web.PerformanceReviewResponse{}, err
+	}
+	if review.CycleStatus == domain.ReviewCycleCalibration || review.CycleStatus == domain.ReviewCycleClosed || review.ManagerSubmittedAt != nil {
+		return web.PerformanceReviewResponse{}, exception.ErrBadRequest("The reviewer can't be changed after the manager assessment is submitted.")
+	}
+	if request.ReviewerID == review.EmployeeID {
+		return web.PerformanceReviewResponse{}, exception.ErrBadRequest("The employee can't review itself.")
+	}
+
+	reviewer, err := findEmployee(c, s.employeeRepository, request.ReviewerID)
+	if err != nil {
+		return web.PerformanceReviewResponse{}, err
+	}
+	if reviewer.Status != domain.EmployeeStatusActive {
+		return web.PerformanceReviewResponse{}, exception.ErrBadRequest("The reviewer must be an active employee.")
+	}
+
+	review.ReviewerID = &reviewer.ID
+	review.UpdatedAt = time.Now()
+	if err := s.performanceReviewRepository.UpdateReviewer(c, review); err != nil {
+		s.logger.Infow(err.Error(), "Update Reviewer Error")
+		return web.PerformanceReviewResponse{}, err
+	}
+
+	return s.FindReviewById(c, id)
+}
+
+// request the feedback of the peers of the employee, by the employee or the reviewer during the assessments
+func (s *performanceReviewService) RequestPeers(c context.Context, userID, id string, request web.ReviewPeersRequest) (web.PerformanceReviewResponse, error) {
+	review, err := findPerformanceReview(c, s.performanceReviewRepository, id)
+	if err != nil {
+		return web.PerformanceReviewResponse{}, err
+	}
+	cycle, err := findReviewCycle(c, s.performanceReviewRepository, review.CycleID)
+	if err != nil {
+		return web.PerformanceReviewResponse{}, err
+	}
+	if !cycle.AcceptsPeerFeedback() {
+		return web.PerformanceReviewResponse{}, exception.ErrBadRequest("The peer feedback can't be requested in the review cycle.")
+	}
+	if userID != review.EmployeeUserID && (review.ReviewerUserID == nil || userID != *review.ReviewerUserID) {
+		return web.PerformanceReviewResponse{}, exception.ErrBadRequest("The peer feedback can only be requested by the employee or the reviewer.")
+	}
+
+	requested := map[string]bool{}
+	for _, peer := range review.Peers {
+		requested[peer.ReviewerID] = true
+	}
+
+	var peers []domain.ReviewPeer
+	var peerUserIDs []string
+	for _, employeeID := range request.EmployeeIDs {
+		if requested[employeeID] {
+			continue
+		}
+		if employeeID == review.EmployeeID || (review.ReviewerID != nil && employeeID == *review.ReviewerID) {
+			return web.PerformanceReviewResponse{}, exception.ErrBadRequest("The employee and the reviewer can't give peer feedback.")
+		}
+
+		peer, err := findEmployee(c, s.employeeRepository, employeeID)
+		if err != nil {
+			return web.PerformanceReviewResponse{}, err
+		}
+		if peer.Status != domain.EmployeeStatusActive {
+			return web.PerformanceReviewResponse{}, exception.ErrBadRequest(fmt.Sprintf("The peer %s is not an active employee.", peer.Name))
+		}
+
+		requested[employeeID] = true
+		peers = append(peers, domain.ReviewPeer{
+			ReviewID:    review.ID,
+			ReviewerID:  peer.ID,
+			Status:      domain.ReviewPeerRequested,
+			RequestedBy: &userID,
+			RequestedAt: time.Now(),
+		})
+		peerUserIDs = append(peerUserIDs, peer.UserID)
+	}
+	if len(peers) == 0 {
+		return s.FindReviewById(c, id)
+	}
+
+	if err := s.performanceReviewRepository.CreatePeers(c, peers); err != nil {
+		s.logger.Infow(err.Error(), "Request Peer Feedback Error")
+		return web.PerformanceReviewResponse{}, err
+	}
+
+	message := fmt.Sprintf("Your feedback for %s (%s) is requested in the %s.", review.EmployeeName, review.EmployeeNumber, review.CycleName)
+	for _, peerUserID := range peerUserIDs {
+		kafkaNotificationMessage := kafkamodel.NewKafkaNotificationMessage(peerUserID, NotificationReviewPeerRequested, "Peer Feedback Requested", message, map[string]interface{}{
+			"review_id": review.ID,
+			"cycle_id":  review.CycleID,
+		})
+		go s.kafkaProducerService.Produce(kafkaNotificationMessage, "POST.NOTIFICATION", config.KafkaTopicNotification)
+	}
+
+	return s.FindReviewById(c, id)
+}
+
+// submit the feedback of the logged in user as a peer of the employee, the feedback can't be changed afterwards
+func (s *performanceReviewService) SubmitPeerFeedback(c context.Context, userID, id string, request web.ReviewPeerFeedbackRequest) error {
+	review, err := findPerformanceReview(c, s.performanceReviewRepository, id)
+	if err != nil {
+		return err
+	}
+	cycle, err := findReviewCycle(c, s.performanceReviewRepository, review.CycleID)
+	if err != nil {
+		return err
+	}
+	if !cycle.AcceptsPeerFeedback() {
+		return exception.ErrBadRequest("The peer feedback can't be submitted in the review cycle.")
+	}
+
+	var peer *domain.ReviewPeer
+	for i := range review.Peers {
+		if review.Peers[i].ReviewerUserID == userID {
+			peer = &review.Peers[i]
+			break
+		}
+	}
+	if peer == nil {
+		return exception.ErrNotFound(fmt.Sprintf("Peer feedback of user %s not found", userID))
+	}
+	if peer.Status == domain.ReviewPeerSubmitted {
+		return exception.ErrBadRequest("The peer feedback is already submitted.")
+	}
+
+	submittedAt := time.Now()
+	peer.Status = domain.ReviewPeerSubmitted
+	peer.Score = &request.Score
+	peer.Comment = request.Comment
+	peer.SubmittedAt = &submittedAt
+
+	if err := s.performanceReviewRepository.UpdatePeer(c, *peer); err != nil {
+		s.logger.Infow(err.Error(), "Submit Peer Feedback Error")
+		return err
+	}
+
+	return nil
+}
+
+// adjust the score of the review during the calibration, the calibrated score replaces the manager score in the
+// final rating. The score is calibrated by HR, nobody calibrates their own review.
+func (s *performanceReviewService) Calibrate(c context.Context, userID, id string, request web.ReviewCalibrationRequest) (web.PerformanceReviewResponse, error) {
+	review, err := findPerformanceReview(c, s.performanceReviewRepository, id)
+	if err != nil {
+		return web.PerformanceReviewResponse{}, err
+	}
+	if review.CycleStatus != domain.ReviewCycleCalibration {
+		return web.PerformanceReviewResponse{}, exception.ErrBadRequest("The score can only be calibrated during the calibration.")
+	}
+	calibrator, err := findEmployeeByUser(c, s.employeeRepository, userID)
+	if err != nil {
+		return web.PerformanceReviewResponse{}, err
+	}
+	if calibrator.ID == review.EmployeeID {
+		return web.PerformanceReviewResponse{}, exception.ErrUnauthorized("The employee can't calibrate their own review.")
+	}
+
+	score := math.Round(request.Score*100) / 100
+	review.CalibratedScore = &score
+	review.CalibrationNote = request.Note
+	review.CalibratedBy = &userID
+	review.UpdatedAt = time.Now()
+
+	if err := s.performanceReviewRepository.UpdateCalibration(c, review); err != nil {
+		s.logger.Infow(err.Error(), "Calibrate Review Error")
+		return web.PerformanceReviewResponse{}, err
+	}
+
+	return s.FindReviewById(c, id)
+}
+
+func (s *performanceReviewService) FindAllCycle(c context.Context, filter web.ReviewCycleQueryFilter) (result []web.ReviewCycleResponse, totalData int, err error) {
+	cycles, err := s.performanceReviewRepository.FindAllCycle(c, domain.ToDomainReviewCycleQueryFilter(filter))
+	if err != nil {
+		return nil, 0, err
+	}
+
+	// get total-data
+	totalData, err = s.performanceReviewRepository.CountAllCycle(c, domain.ToDomainReviewCycleQueryFilter(filter))
+	if err != nil {
+		return nil, 0, err
+	}
+
+	// convert to web.ReviewCycleResponse
+	result = []web.ReviewCycleResponse{}
+	for _, cycle := range cycles {
+		result = append(result, cycle.ToReviewCycleResponse())
+	}
+
+	return result, totalData, nil
+}
+
+func (s *performanceReviewService) FindCycleById(c context.Context, id string) (web.ReviewCycleResponse, error) {
+	cycle, err := findReviewCycle(c, s.performanceReviewRepository, id)
+	if err != nil {
+		return web.ReviewCycleResponse{}, err
+	}
+
+	return cycle.ToReviewCycleResponse(), nil
+}
+
+func (s *performanceReviewService) FindCycleReviews(c context.Context, id string, filter web.PerformanceReviewQueryFilter) (result []web.PerformanceReviewResponse, totalData int, err error) {
+	if _, err := findReviewCycle(c, s.performanceReviewRepository, id); err != nil {
+		return nil, 0, err
+	}
+
+	domainFilter := domain.ToDomainPerformanceReviewQueryFilter(filter)
+	domainFilter.CycleID = id
+
+	reviews, err := s.performanceReviewRepository.FindAllReview(c, domainFilter)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	// get total-data
+	totalData, err = s.performanceReviewRepository.CountAllReview(c, domainFilter)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return toPerformanceReviewResponses(reviews), totalData, nil
+}
+
+// count the reviews of the cycle by the rating, it is used to compare the ratings of the managers during the
+// calibration
+func (s *performanceReviewService) RatingDistribution(c context.Context, id string) ([]web.ReviewRatingDistributionResponse, error) {
+	if _, err := findReviewCycle(c, s.performanceReviewRepository, id); err != nil {
+		return nil, err
+	}
+
+	reviews, err := s.performanceReviewRepository.FindAllReview(c, domain.PerformanceReviewQueryFilter{CycleID: id})
+	if err != nil {
+		return nil, err
+	}
+
+	counts := map[string]int{}
+	rated := 0
+	for _, review := range reviews {
+		if score := review.CurrentScore(); score != nil {
+			counts[domain.ReviewRating(*score)]++
+			rated++
+		}
+	}
+
+	result := []web.ReviewRatingDistributionResponse{}
+	for _, rating := range domain.ReviewRatings {
+		var percentage float64
+		if rated > 0 {
+			percentage = math.Round(float64(counts[rating])/float64(rated)*1000) / 10
+		}
+		result = append(result, web.ReviewRatingDistributionResponse{
+			Rating:     rating,
+			Count:      counts[rating],
+			Percentage: percentage,
+		})
+	}
+
+	return result, nil
+}
+
+// find the reviews of the logged in user
+func (s *performanceReviewService) FindMyReviews(c context.Context, userID string) ([]web.PerformanceReviewResponse, error) {
+	employee, err := findEmployeeByUser(c, s.employeeRepository, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	reviews, err := s.performanceReviewRepository.FindAllReview(c, domain.PerformanceReviewQueryFilter{EmployeeID: employee.ID})
+	if err != nil {
+		return nil, err
+	}
+
+	return toPerformanceReviewResponses(reviews), nil
+}
+
+// find the reviews where the logged in user is the reviewer, e.g. the reviews of the direct reports of a manager
+func (s *performanceReviewService) FindTeamReviews(c context.Context, userID string) ([]web.PerformanceReviewResponse, error) {
+	employee, err := findEmployeeByUser(c, s.employeeRepository, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	reviews, err := s.performanceReviewRepository.FindAllReview(c, domain.PerformanceReviewQueryFilter{ReviewerID: employee.ID})
+	if err != nil {
+		return nil, err
+	}
+
+	return toPerformanceReviewResponses(reviews), nil
+}
+
+// find the peer feedback requested from the logged in user in the open review cycles
+func (s *performanceReviewService) FindMyPeerRequests(c context.Context, userID string) ([]web.ReviewPeerRequestResponse, error) {
+	employee, err := findEmployeeByUser(c, s.employeeRepository, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	peers, err := s.performanceReviewRepository.FindPeersByReviewer(c, employee.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	// convert to web.ReviewPeerRequestResponse
+	result := []web.ReviewPeerRequestResponse{}
+	for _, peer := range peers {
+		result = append(result, peer.ToReviewPeerRequestResponse())
+	}
+
+	return result, nil
+}
+
+func (s *performanceReviewService) FindReviewById(c context.Context, id string) (web.PerformanceReviewResponse, error) {
+	review, err := findPerformanceReview(c, s.performanceReviewRepository, id)
+	if err != nil {
+		return web.PerformanceReviewResponse{}, err
+	}
+
+	return review.ToPerformanceReviewResponse(), nil
+}
+
+// Summary returns the summary of the locked review rendered from the 'review_summary.html' template with its file
+// name.
+func (s *performanceReviewService) Summary(c context.Context, id string) ([]byte, string, error) {
+	review, err := findPerformanceReview(c, s.performanceReviewRepository, id)
+	if err != nil {
+		return nil, "", err
+	}
+	if review.LockedAt == nil {
+		return nil, "", exception.ErrBadRequest("The review summary is only available after the review cycle is closed.")
+	}
+
+	cycle, err := findReviewCycle(c, s.performanceReviewRepository, review.CycleID)
+	if err != nil {
+		return nil, "", err
+	}
+	employee, err := findEmployee(c, s.employeeRepository, review.EmployeeID)
+	if err != nil {
+		return nil, "", err
+	}
+
+	var rating string
+	if review.FinalRating != nil {
+		rating = reviewRatingLabels[*review.FinalRating]
+	}
+	data := map[string]interface{}{
+		"Cycle":       cycle,
+		"Review":      review.ToPerformanceReviewResponse(),
+		"Employee":    employee,
+		"Rating":      rating,
+		"PeriodStart": helper.ParseTimeToFullIndonesian(cycle.PeriodStart),
+		"PeriodEnd":   helper.ParseTimeToFullIndonesian(cycle.PeriodEnd),
+		"PrintedAt":   helper.ParseTimeToFullIndonesian(helper.Today()),
+	}
+
+	pdf, err := helper.RenderPDF(c, s.pdfRenderer, s.templateFS, "review_summary.html", helper.PDFOptions{PaperSize: "A4"}, data)
+	if err != nil {
+		s.logger.Infow(err.Error(), "Generate Review Summary Error")
+		return nil, "", err
+	}
+
+	return pdf.Bytes(), fmt.Sprintf("performance-review-%s-%d.pdf", review.EmployeeNumber, cycle.PeriodEnd.Year()), nil
+}
+
+// resolve the reviews of the participants, the active employees of the departments and the listed employees
+func (s *performanceReviewService) participants(c context.Context, request web.ReviewCycleRequest) ([]domain.PerformanceReview, error) {
+	var employees []domain.Employee
+	if len(request.DepartmentIDs) > 0 || len(request.EmployeeIDs) == 0 {
+		departmentEmployees, err := s.employeeRepository.FindAllEmployee(c, domain.EmployeeQueryFilter{
+			DepartmentIDs: request.DepartmentIDs,
+			Status:        domain.EmployeeStatusActive,
+		})
+		if err != nil {
+			return nil, err
+		}
+		employees = append(employees, departmentEmployees...)
+	}
+	for _, employeeID := range request.EmployeeIDs {
+		employee, err := findEmployee(c, s.employeeRepository, employeeID)
+		if err != nil {
+			return nil, err
+		}
+		if employee.Status != domain.EmployeeStatusActive {
+			return nil, exception.ErrBadRequest(fmt.Sprintf("The participant %s is not an active employee.", employee.Name))
+		}
+		employees = append(employees, employee)
+	}
+
+	added := map[string]bool{}
+	var reviews []domain.PerformanceReview
+	for _, employee := range employees {
+		if added[employee.ID] {
+			continue
+		}
+		added[employee.ID] = true
+
+		reviews = append(reviews, domain.PerformanceReview{
+			ID:         uuid.New().String(),
+			EmployeeID: employee.ID,
+			ReviewerID: employee.ManagerID,
+			CreatedAt:  time.Now(),
+			UpdatedAt:  time.Now(),
+		})
+	}
+	if len(reviews) == 0 {
+		return nil, exception.ErrBadRequest("The review cycle has no participants.")
+	}
+
+	return reviews, nil
+}
+
+// notify the participants who act in the phase the review cycle has moved to
+func (s *performanceReviewService) notifyPhase(cycle domain.ReviewCycle, reviews []domain.PerformanceReview) {
+	for _, review := range reviews {
+		recipient, notificationType, title, message := review.EmployeeUserID, NotificationReviewPhaseStarted, "", ""
+		switch cycle.Status {
+		case domain.ReviewCycleGoalSetting:
+			title = "Set Your Goals"
+			message = fmt.Sprintf("The goal setting of the %s has started, please set your goals.", cycle.Name)
+		case domain.ReviewCycleSelfAssessment:
+			title = "Self Assessment"
+			message = fmt.Sprintf("The self assessment of the %s has started, please assess your goals.", cycle.Name)
+		case domain.ReviewCycleManagerAssessment:
+			if review.ReviewerUserID == nil {
+				continue
+			}
+			recipient = *review.ReviewerUserID
+			title = "Manager Assessment"
+			message = fmt.Sprintf("The manager assessment of the %s has started, please assess %s (%s).", cycle.Name, review.EmployeeName, review.EmployeeNumber)
+		case domain.ReviewCycleClosed:
+			notificationType = NotificationReviewCompleted
+			title = "Performance Review Completed"
+			message = fmt.Sprintf("Your performance review of the %s is completed.", cycle.Name)
+		default:
+			continue
+		}
+
+		kafkaNotificationMessage := kafkamodel.NewKafkaNotificationMessage(recipient, notificationType, title, message, map[string]interface{}{
+			"cycle_id":  cycle.ID,
+			"review_id": review.ID,
+			"status":    cycle.Status,
+		})
+		go s.kafkaProducerService.Produce(kafkaNotificationMessage, "POST.NOTIFICATION", config.KafkaTopicNotification)
+	}
+}
+
+// set the scores of the goals from the request, the self scores or the manager scores
+func applyGoalScores(review *domain.PerformanceReview, scores []web.ReviewGoalScoreRequest, self bool) error {
+	for _, score := range scores {
+		found := false
+		for i := range review.Goals {
+			goal := &review.Goals[i]
+			if goal.Seq != score.Seq {
+				continue
+			}
+			if self {
+				goal.SelfScore, goal.SelfComment = score.Score, score.Comment
+			} else {
+				goal.ManagerScore, goal.ManagerComment = score.Score, score.Comment
+			}
+			found = true
+			break
+		}
+		if !found {
+			return exception.ErrNotFound(fmt.Sprintf("Goal %d of the review not found", score.Seq))
+		}
+	}
+
+	return nil
+}
+
+func toPerformanceReviewResponses(reviews []domain.PerformanceReview) []web.PerformanceReviewResponse {
+	// convert to web.PerformanceReviewResponse
+	result := []web.PerformanceReviewResponse{}
+	for _, review := range reviews {
+		result = append(result, review.ToPerformanceReviewResponse())
+	}
+	return result
+}
+
+// find the review cycle and convert the 'no rows' error to not found error
+func findReviewCycle(c context.Context, performanceReviewRepository repository.PerformanceReviewRepository, id string) (domain.ReviewCycle, error) {
+	cycle, err := performanceReviewRepository.FindCycleById(c, id)
+	if err != nil {
+		if strings.Contains(err.Error(), "no rows") {
+			return domain.ReviewCycle{}, exception.ErrNotFound(fmt.Sprintf("Review cycle %s not found", id))
+		}
+		return domain.ReviewCycle{}, err
+	}
+
+	return cycle, nil
+}
+
+// find the performance review with its goals and peers and convert the 'no rows' error to not found error
+func findPerformanceReview(c context.Context, performanceReviewRepository repository.PerformanceReviewRepository, id string) (domain.PerformanceReview, error) {
+	review, err := performanceReviewRepository.FindReviewById(c, id)
+	if err != nil {
+		if strings.Contains(err.Error(), "no rows") {
+			return domain.PerformanceReview{}, exception.ErrNotFound(fmt.Sprintf("Performance review %s not found", id))
+		}
+		return domain.PerformanceReview{}, err
+	}
+
+	return review, nil
+}
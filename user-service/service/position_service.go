@@ -0,0 +1,186 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/iqbaludinm/hr-microservice/user-service/exception"
+	"github.com/iqbaludinm/hr-microservice/user-service/model/domain"
+	"github.com/iqbaludinm/hr-microservice/user-service/model/web"
+	"github.com/iqbaludinm/hr-microservice/user-service/repository"
+	"go.uber.org/zap"
+)
+
+type PositionService interface {
+	// With Transaction
+	CreatePosition(ctx context.Context, request web.CreatePositionRequest) (web.PositionResponse, error)
+	UpdatePosition(ctx context.Context, id string, request web.UpdatePositionRequest) (web.PositionResponse, error)
+	Delete(ctx context.Context, id string) error
+
+	// Without Transaction
+	FindAllPosition(ctx context.Context, filter web.PositionQueryFilter) ([]web.PositionResponse, error)
+	FindById(ctx context.Context, id string) (web.PositionResponse, error)
+}
+
+type positionService struct {
+	positionRepository   repository.PositionRepository
+	departmentRepository repository.DepartmentRepository
+	employeeRepository   repository.EmployeeRepository
+	logger               *zap.SugaredLogger
+}
+
+func NewPositionService(positionRepository repository.PositionRepository, departmentRepository repository.DepartmentRepository, employeeRepository repository.EmployeeRepository, logger *zap.SugaredLogger) PositionService {
+	return &positionService{
+		positionRepository:   positionRepository,
+		departmentRepository: departmentRepository,
+		employeeRepository:   employeeRepository,
+		logger:               logger,
+	}
+}
+
+func (s *positionService) CreatePosition(c context.Context, request web.CreatePositionRequest) (web.PositionResponse, error) {
+	if err := s.validateDepartment(c, request.DepartmentID); err != nil {
+		return web.PositionResponse{}, err
+	}
+
+	// convert to domain or model position
+	position := domain.Position{
+		ID:           uuid.New().String(),
+		DepartmentID: request.DepartmentID,
+		Title:        request.Title,
+		Level:        request.Level,
+		CreatedAt:    time.Now(),
+		UpdatedAt:    time.Now(),
+	}
+
+	// call the repo for inserting to db
+	if err := s.positionRepository.CreatePosition(c, position); err != nil {
+		s.logger.Infow(err.Error(), "Create Position Error")
+		return web.PositionResponse{}, toPositionUniqueError(err)
+	}
+
+	newPosition, err := s.positionRepository.FindById(c, position.ID)
+	if err != nil {
+		return web.PositionResponse{}, exception.ErrInternalServer(fmt.Sprintf("Successfully created position, but failed to get the position have created. Error: %s", err.Error()))
+	}
+
+	return newPosition.ToPositionResponse(), nil
+}
+
+func (s *positionService) UpdatePosition(c context.Context, id string, request web.UpdatePositionRequest) (web.PositionResponse, error) {
+	position, err := s.findPosition(c, id)
+	if err != nil {
+		return web.PositionResponse{}, err
+	}
+
+	// only the filled fields are updated
+	if request.DepartmentID != "" && request.DepartmentID != position.DepartmentID {
+		if err := s.validateDepartment(c, request.DepartmentID); err != nil {
+			return web.PositionResponse{}, err
+		}
+
+		// moving a filled position would break the employees' assignment, they must be transferred first
+		members, err := s.employeeRepository.CountAllEmployee(c, domain.EmployeeQueryFilter{PositionID: id})
+		if err != nil {
+			return web.PositionResponse{}, err
+		}
+		if members > 0 {
+			return web.PositionResponse{}, exception.ErrBadRequest("Position is still assigned to employees and can't be moved to another department.")
+		}
+		position.DepartmentID = request.DepartmentID
+	}
+	if request.Title != "" {
+		position.Title = request.Title
+	}
+	if request.Level != nil {
+		position.Level = *request.Level
+	}
+	position.UpdatedAt = time.Now()
+
+	if err := s.positionRepository.UpdatePosition(c, id, position); err != nil {
+		s.logger.Infow(err.Error(), "Update Position Error")
+		return web.PositionResponse{}, toPositionUniqueError(err)
+	}
+
+	updatedPosition, err := s.positionRepository.FindById(c, id)
+	if err != nil {
+		return web.PositionResponse{}, exception.ErrInternalServer(fmt.Sprintf("Successfully updated position, but failed to get the position have updated. Error: %s", err.Error()))
+	}
+
+	return updatedPosition.ToPositionResponse(), nil
+}
+
+func (s *positionService) Delete(c context.Context, id string) error {
+	if _, err := s.findPosition(c, id); err != nil {
+		return err
+	}
+
+	members, err := s.employeeRepository.CountAllEmployee(c, domain.EmployeeQueryFilter{PositionID: id})
+	if err != nil {
+		return err
+	}
+	if members > 0 {
+		return exception.ErrBadRequest("Position is still assigned to employees.")
+	}
+
+	return s.positionRepository.Delete(c, id)
+}
+
+func (s *positionService) FindAllPosition(c context.Context, filter web.PositionQueryFilter) ([]web.PositionResponse, error) {
+	positions, err := s.positionRepository.FindAllPosition(c, filter.DepartmentID)
+	if err != nil {
+		return nil, err
+	}
+
+	// convert to web.PositionResponse
+	result := []web.PositionResponse{}
+	for _, position := range positions {
+		result = append(result, position.ToPositionResponse())
+	}
+
+	return result, nil
+}
+
+func (s *positionService) FindById(c context.Context, id string) (web.PositionResponse, error) {
+	position, err := s.findPosition(c, id)
+	if err != nil {
+		return web.PositionResponse{}, err
+	}
+
+	return position.ToPositionResponse(), nil
+}
+
+// find the position by id and convert the 'no rows' error to not found error
+func (s *positionService) findPosition(c context.Context, id string) (domain.Position, error) {
+	position, err := s.positionRepository.FindById(c, id)
+	if err != nil {
+		if strings.Contains(err.Error(), "no rows") {
+			return domain.Position{}, exception.ErrNotFound(fmt.Sprintf("Position %s not found", id))
+		}
+		return domain.Position{}, err
+	}
+
+	return position, nil
+}
+
+func (s *positionService) validateDepartment(c context.Context, departmentID string) error {
+	if _, err := s.departmentRepository.FindById(c, departmentID); err != nil {
+		if strings.Contains(err.Error(), "no rows") {
+			return exception.ErrNotFound(fmt.Sprintf("Department %s not found", departmentID))
+		}
+		return err
+	}
+
+	return nil
+}
+
+// convert the unique constraint error of the 'positions' table to bad request error
+func toPositionUniqueError(err error) error {
+	if strings.Contains(err.Error(), "unique") && strings.Contains(err.Error(), "positions_department_id_title_key") {
+		return exception.ErrBadRequest("Position title already exist in the department.")
+	}
+	return err
+}
@@ -0,0 +1,429 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/iqbaludinm/hr-microservice/user-service/config"
+	"github.com/iqbaludinm/hr-microservice/user-service/exception"
+	"github.com/iqbaludinm/hr-microservice/user-service/helper"
+	"github.com/iqbaludinm/hr-microservice/user-service/model/domain"
+	"github.com/iqbaludinm/hr-microservice/user-service/model/web"
+	"github.com/iqbaludinm/hr-microservice/user-service/repository"
+	"go.uber.org/zap"
+)
+
+// The maximum days of the roster that can be viewed or assigned at once.
+const (
+	maxRosterViewDays   = 93
+	maxRosterAssignDays = 366
+)
+
+type RosterService interface {
+	// With Transaction
+	AssignRoster(ctx context.Context, request web.AssignRosterRequest) error
+	ClearRoster(ctx context.Context, request web.ClearRosterRequest) error
+	FindMyCalendar(ctx context.Context, userID string) (web.RosterCalendarResponse, error)
+	ResetMyCalendar(ctx context.Context, userID string) (web.RosterCalendarResponse, error)
+
+	// Without Transaction
+	FindAllRoster(ctx context.Context, filter web.RosterQueryFilter) ([]web.RosterResponse, error)
+	FindMyRoster(ctx context.Context, userID string, filter web.RosterQueryFilter) ([]web.RosterResponse, error)
+	ExportCalendar(ctx context.Context, token string) ([]byte, error)
+}
+
+type rosterService struct {
+	rosterRepository       repository.RosterRepository
+	shiftRepository        repository.ShiftRepository
+	shiftPatternRepository repository.ShiftPatternRepository
+	holidayRepository      repository.HolidayRepository
+	workCalendarRepository repository.WorkCalendarRepository
+	employeeRepository     repository.EmployeeRepository
+	departmentRepository   repository.DepartmentRepository
+	logger                 *zap.SugaredLogger
+}
+
+func NewRosterService(rosterRepository repository.RosterRepository, shiftRepository repository.ShiftRepository, shiftPatternRepository repository.ShiftPatternRepository, holidayRepository repository.HolidayRepository, workCalendarRepository repository.WorkCalendarRepository, employeeRepository repository.EmployeeRepository, departmentRepository repository.DepartmentRepository, logger *zap.SugaredLogger) RosterService {
+	return &rosterService{
+		rosterRepository:       rosterRepository,
+		shiftRepository:        shiftRepository,
+		shiftPatternRepository: shiftPatternRepository,
+		holidayRepository:      holidayRepository,
+		workCalendarRepository: workCalendarRepository,
+		employeeRepository:     employeeRepository,
+		departmentRepository:   departmentRepository,
+		logger:                 logger,
+	}
+}
+
+func (s *rosterService) AssignRoster(c context.Context, request web.AssignRosterRequest) error {
+	startDate, endDate, err := parseRosterRange(request.StartDate, request.EndDate, maxRosterAssignDays)
+	if err != nil {
+		return err
+	}
+	if (request.ShiftID == "") == (request.PatternID == "") {
+		return exception.ErrBadRequest("Fill either the shift or the pattern.")
+	}
+
+	employees, err := s.findTargetEmployees(c, request.EmployeeIDs, request.DepartmentID)
+	if err != nil {
+		return err
+	}
+
+	// the shift of each employee and date, the day off has no shift
+	var shiftOn func(employee domain.Employee, day int, date time.Time) (*string, bool)
+	var patternID *string
+	if request.ShiftID != "" {
+		shift, err := findShift(c, s.shiftRepository, request.ShiftID)
+		if err != nil {
+			return err
+		}
+		calendars, err := findCalendars(c, s.workCalendarRepository, s.holidayRepository, startDate, endDate)
+		if err != nil {
+			return err
+		}
+
+		// the single shift is planned on the working days of the employee's calendar only
+		shiftOn = func(employee domain.Employee, day int, date time.Time) (*string, bool) {
+			calendar := calendars.For(employee.WorkCalendarID)
+			return &shift.ID, calendar.IsWorkingDay(date)
+		}
+	} else {
+		pattern, err := findShiftPattern(c, s.shiftPatternRepository, request.PatternID)
+		if err != nil {
+			return err
+		}
+		patternID = &pattern.ID
+
+		shiftOn = func(employee domain.Employee, day int, date time.Time) (*string, bool) {
+			return pattern.ShiftOn(request.StartDayIndex + day), true
+		}
+	}
+
+	now := time.Now()
+	rosters := []domain.Roster{}
+	for _, employee := range employees {
+		for day, date := 0, startDate; !date.After(endDate); day, date = day+1, date.AddDate(0, 0, 1) {
+			shiftID, planned := shiftOn(employee, day, date)
+			if !planned {
+				continue
+			}
+			rosters = append(rosters, domain.Roster{
+				ID:         uuid.New().String(),
+				EmployeeID: employee.ID,
+				Date:       date,
+				ShiftID:    shiftID,
+				PatternID:  patternID,
+				CreatedAt:  now,
+				UpdatedAt:  now,
+			})
+		}
+	}
+
+	if err := s.rosterRepository.UpsertRosters(c, rosters); err != nil {
+		s.logger.Infow(err.Error(), "Assign Roster Error")
+		return err
+	}
+
+	return nil
+}
+
+func (s *rosterService) ClearRoster(c context.Context, request web.ClearRosterRequest) error {
+	startDate, endDate, err := parseRosterRange(request.StartDate, request.EndDate, maxRosterAssignDays)
+	if err != nil {
+		return err
+	}
+
+	employees, err := s.findTargetEmployees(c, request.EmployeeIDs, request.DepartmentID)
+	if err != nil {
+		return err
+	}
+
+	employeeIDs := []string{}
+	for _, employee := range employees {
+		employeeIDs = append(employeeIDs, employee.ID)
+	}
+
+	if err := s.rosterRepository.DeleteRoster(c, employeeIDs, startDate, endDate); err != nil {
+		s.logger.Infow(err.Error(), "Clear Roster Error")
+		return err
+	}
+
+	return nil
+}
+
+func (s *rosterService) FindMyCalendar(c context.Context, userID string) (web.RosterCalendarResponse, error) {
+	employee, err := findEmployeeByUser(c, s.employeeRepository, userID)
+	if err != nil {
+		return web.RosterCalendarResponse{}, err
+	}
+
+	token, err := s.rosterRepository.FindCalendarToken(c, employee.ID)
+	if err != nil {
+		return web.RosterCalendarResponse{}, err
+	}
+	// the token is generated when the calendar is requested for the first time
+	if token == nil {
+		return s.ResetMyCalendar(c, userID)
+	}
+
+	return toRosterCalendarResponse(*token), nil
+}
+
+func (s *rosterService) ResetMyCalendar(c context.Context, userID string) (web.RosterCalendarResponse, error) {
+	employee, err := findEmployeeByUser(c, s.employeeRepository, userID)
+	if err != nil {
+		return web.RosterCalendarResponse{}, err
+	}
+
+	// the new token replaces the current one, the subscriptions with the current token stop working
+	token := uuid.New().String()
+	if err := s.rosterRepository.UpdateCalendarToken(c, employee.ID, token); err != nil {
+		s.logger.Infow(err.Error(), "Reset Calendar Error")
+		return web.RosterCalendarResponse{}, err
+	}
+
+	return toRosterCalendarResponse(token), nil
+}
+
+func (s *rosterService) FindAllRoster(c context.Context, filter web.RosterQueryFilter) ([]web.RosterResponse, error) {
+	from, to, err := parseRosterRange(filter.From, filter.To, maxRosterViewDays)
+	if err != nil {
+		return nil, err
+	}
+
+	var employees []domain.Employee
+	switch {
+	case filter.EmployeeID != "":
+		employee, err := findEmployee(c, s.employeeRepository, filter.EmployeeID)
+		if err != nil {
+			return nil, err
+		}
+		employees = []domain.Employee{employee}
+	case filter.DepartmentID != "":
+		departmentIds, err := findDepartmentIds(c, s.departmentRepository, filter.DepartmentID)
+		if err != nil {
+			return nil, err
+		}
+		if employees, err = s.employeeRepository.FindAllEmployee(c, domain.EmployeeQueryFilter{DepartmentIDs: departmentIds, Status: domain.EmployeeStatusActive}); err != nil {
+			return nil, err
+		}
+	default:
+		if employees, err = s.employeeRepository.FindAllEmployee(c, domain.EmployeeQueryFilter{Status: domain.EmployeeStatusActive}); err != nil {
+			return nil, err
+		}
+	}
+
+	return s.findRoster(c, employees, from, to)
+}
+
+func (s *rosterService) FindMyRoster(c context.Context, userID string, filter web.RosterQueryFilter) ([]web.RosterResponse, error) {
+	from, to, err := parseRosterRange(filter.From, filter.To, maxRosterViewDays)
+	if err != nil {
+		return nil, err
+	}
+
+	employee, err := findEmployeeByUser(c, s.employeeRepository, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.findRoster(c, []domain.Employee{employee}, from, to)
+}
+
+func (s *rosterService) ExportCalendar(c context.Context, token string) ([]byte, error) {
+	// the token is a uuid, any other value can't match a calendar
+	if _, err := uuid.Parse(token); err != nil {
+		return nil, exception.ErrNotFound("Calendar not found")
+	}
+
+	employeeID, err := s.rosterRepository.FindEmployeeIdByCalendarToken(c, token)
+	if err != nil {
+		if strings.Contains(err.Error(), "no rows") {
+			return nil, exception.ErrNotFound("Calendar not found")
+		}
+		return nil, err
+	}
+	employee, err := findEmployee(c, s.employeeRepository, employeeID)
+	if err != nil {
+		return nil, err
+	}
+
+	today := helper.Today()
+	from := today.AddDate(0, 0, -config.RosterCalendarPastDays)
+	to := today.AddDate(0, 0, config.RosterCalendarFutureDays)
+
+	entries, err := findRosterEntries(c, s.rosterRepository, s.shiftRepository, s.holidayRepository, s.workCalendarRepository, []domain.Employee{employee}, from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	events := []helper.ICalendarEvent{}
+	for _, entry := range entries {
+		if event, ok := entry.ToICalendarEvent(); ok {
+			events = append(events, event)
+		}
+	}
+
+	return helper.RenderICalendar(fmt.Sprintf("%s Shifts", employee.Name), events), nil
+}
+
+// find the schedules of the employees between the dates and convert them to web.RosterResponse
+func (s *rosterService) findRoster(c context.Context, employees []domain.Employee, from, to time.Time) ([]web.RosterResponse, error) {
+	entries, err := findRosterEntries(c, s.rosterRepository, s.shiftRepository, s.holidayRepository, s.workCalendarRepository, employees, from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	// convert to web.RosterResponse
+	result := []web.RosterResponse{}
+	for _, entry := range entries {
+		result = append(result, entry.ToRosterResponse())
+	}
+
+	return result, nil
+}
+
+// find the active employees the roster is assigned to, either the employees or the employees of the department
+// (and its sub-departments)
+func (s *rosterService) findTargetEmployees(c context.Context, employeeIDs []string, departmentID string) ([]domain.Employee, error) {
+	if (len(employeeIDs) == 0) == (departmentID == "") {
+		return nil, exception.ErrBadRequest("Fill either the employees or the department.")
+	}
+
+	if departmentID != "" {
+		departmentIds, err := findDepartmentIds(c, s.departmentRepository, departmentID)
+		if err != nil {
+			return nil, err
+		}
+		employees, err := s.employeeRepository.FindAllEmployee(c, domain.EmployeeQueryFilter{DepartmentIDs: departmentIds, Status: domain.EmployeeStatusActive})
+		if err != nil {
+			return nil, err
+		}
+		if len(employees) == 0 {
+			return nil, exception.ErrBadRequest("Department has no active employee.")
+		}
+		return employees, nil
+	}
+
+	employees := []domain.Employee{}
+	for _, employeeID := range employeeIDs {
+		employee, err := findEmployee(c, s.employeeRepository, employeeID)
+		if err != nil {
+			return nil, err
+		}
+		if employee.Status != domain.EmployeeStatusActive {
+			return nil, exception.ErrBadRequest(fmt.Sprintf("Employee %s is %s.", employee.EmployeeNumber, employee.Status))
+		}
+		employees = append(employees, employee)
+	}
+
+	return employees, nil
+}
+
+// parse the date range of the roster, the range can't be longer than the maximum days
+func parseRosterRange(fromValue, toValue string, maxDays int) (from, to time.Time, err error) {
+	if from, err = helper.ParseDate(fromValue); err != nil {
+		return from, to, exception.ErrBadRequest("Invalid start date.")
+	}
+	if to, err = helper.ParseDate(toValue); err != nil {
+		return from, to, exception.ErrBadRequest("Invalid end date.")
+	}
+	if to.Before(from) {
+		return from, to, exception.ErrBadRequest("End date can't be before the start date.")
+	}
+	if to.Sub(from) >= time.Duration(maxDays)*24*time.Hour {
+		return from, to, exception.ErrBadRequest(fmt.Sprintf("Date range can't be longer than %d days.", maxDays))
+	}
+
+	return from, to, nil
+}
+
+// find the schedules of the employees for every date between 'from' and 'to' (inclusive). The planned roster
+// is used when it exists, otherwise the assigned or default shift of the employee is used on the working days
+// of the employee's calendar.
+func findRosterEntries(c context.Context, rosterRepository repository.RosterRepository, shiftRepository repository.ShiftRepository, holidayRepository repository.HolidayRepository, workCalendarRepository repository.WorkCalendarRepository, employees []domain.Employee, from, to time.Time) ([]domain.RosterEntry, error) {
+	if len(employees) == 0 {
+		return []domain.RosterEntry{}, nil
+	}
+
+	shifts, err := shiftRepository.FindAllShift(c)
+	if err != nil {
+		return nil, err
+	}
+	shiftsById := map[string]domain.Shift{}
+	var defaultShift *domain.Shift
+	for i, shift := range shifts {
+		shiftsById[shift.ID] = shift
+		if shift.IsDefault {
+			defaultShift = &shifts[i]
+		}
+	}
+
+	calendars, err := findCalendars(c, workCalendarRepository, holidayRepository, from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	employeeIDs := []string{}
+	for _, employee := range employees {
+		employeeIDs = append(employeeIDs, employee.ID)
+	}
+	rosters, err := rosterRepository.FindAllRoster(c, domain.RosterQueryFilter{EmployeeIDs: employeeIDs, From: from, To: to})
+	if err != nil {
+		return nil, err
+	}
+	planned := map[string]domain.Roster{}
+	for _, roster := range rosters {
+		planned[roster.EmployeeID+roster.Date.Format(helper.DateLayout)] = roster
+	}
+
+	// find the shift by id, the deleted shift is treated as a day off
+	shiftOf := func(id *string) *domain.Shift {
+		if id == nil {
+			return nil
+		}
+		if shift, ok := shiftsById[*id]; ok {
+			return &shift
+		}
+		return nil
+	}
+
+	entries := []domain.RosterEntry{}
+	for _, employee := range employees {
+		fallback := defaultShift
+		if assigned := shiftOf(employee.ShiftID); assigned != nil {
+			fallback = assigned
+		}
+		calendar := calendars.For(employee.WorkCalendarID)
+
+		for date := from; !date.After(to); date = date.AddDate(0, 0, 1) {
+			entry := domain.RosterEntry{
+				EmployeeID:     employee.ID,
+				EmployeeNumber: employee.EmployeeNumber,
+				EmployeeName:   employee.Name,
+				Date:           date,
+				Source:         domain.RosterSourceDefault,
+			}
+			if roster, ok := planned[employee.ID+date.Format(helper.DateLayout)]; ok {
+				entry.Shift = shiftOf(roster.ShiftID)
+				entry.Source = domain.RosterSourceRoster
+			} else if calendar.IsWorkingDay(date) {
+				entry.Shift = fallback
+			}
+			entries = append(entries, entry)
+		}
+	}
+
+	return entries, nil
+}
+
+// build the subscription URL of the calendar feed
+func toRosterCalendarResponse(token string) web.RosterCalendarResponse {
+	return web.RosterCalendarResponse{
+		URL: fmt.Sprintf("%s/%s.ics", strings.TrimRight(config.RosterCalendarURL, "/"), token),
+	}
+}
@@ -0,0 +1,77 @@
+package schedulers
+
+import (
+	"context"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// the default interval when 'SCHEDULER_INTERVAL_MINUTES' is not set
+const defaultInterval = 60 * time.Minute
+
+// Job is a background task that is run periodically by the scheduler.
+// A job must be safe to run more than once, e.g. only processing the data that hasn't been processed.
+type Job struct {
+	Name string
+	Run  func(ctx context.Context) error
+}
+
+type Scheduler interface {
+	// Register adds a job to the scheduler, it must be called before 'Start'.
+	Register(name string, run func(ctx context.Context) error)
+	// Start runs the registered jobs immediately and then on every interval until the context is done.
+	Start(ctx context.Context)
+}
+
+type scheduler struct {
+	interval time.Duration
+	jobs     []Job
+	logger   *zap.SugaredLogger
+}
+
+func NewScheduler(intervalMinutes int, logger *zap.SugaredLogger) Scheduler {
+	interval := time.Duration(intervalMinutes) * time.Minute
+	if interval <= 0 {
+		interval = defaultInterval
+	}
+
+	return &scheduler{
+		interval: interval,
+		logger:   logger,
+	}
+}
+
+func (s *scheduler) Register(name string, run func(ctx context.Context) error) {
+	s.jobs = append(s.jobs, Job{Name: name, Run: run})
+}
+
+func (s *scheduler) Start(ctx context.Context) {
+	s.logger.Infow("Scheduler started", "interval", s.interval.String(), "jobs", len(s.jobs))
+
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+
+	for {
+		s.runJobs(ctx)
+
+		select {
+		case <-ctx.Done():
+			s.logger.Info("Scheduler stopped")
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// run the jobs one by one, a failed job is logged and doesn't stop the other jobs
+func (s *scheduler) runJobs(ctx context.Context) {
+	for _, job := range s.jobs {
+		start := time.Now()
+		if err := job.Run(ctx); err != nil {
+			s.logger.Errorw("Scheduler Job Error", "job", job.Name, "error", err.Error())
+			continue
+		}
+		s.logger.Infow("Scheduler Job Done", "job", job.Name, "latency", time.Since(start).String())
+	}
+}
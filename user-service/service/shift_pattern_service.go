@@ -0,0 +1,161 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/iqbaludinm/hr-microservice/user-service/exception"
+	"github.com/iqbaludinm/hr-microservice/user-service/model/domain"
+	"github.com/iqbaludinm/hr-microservice/user-service/model/web"
+	"github.com/iqbaludinm/hr-microservice/user-service/repository"
+	"go.uber.org/zap"
+)
+
+type ShiftPatternService interface {
+	// With Transaction
+	CreatePattern(ctx context.Context, request web.CreateShiftPatternRequest) (web.ShiftPatternResponse, error)
+	UpdatePattern(ctx context.Context, id string, request web.UpdateShiftPatternRequest) (web.ShiftPatternResponse, error)
+	Delete(ctx context.Context, id string) error
+
+	// Without Transaction
+	FindAllPattern(ctx context.Context) ([]web.ShiftPatternResponse, error)
+	FindById(ctx context.Context, id string) (web.ShiftPatternResponse, error)
+}
+
+type shiftPatternService struct {
+	shiftPatternRepository repository.ShiftPatternRepository
+	shiftRepository        repository.ShiftRepository
+	logger                 *zap.SugaredLogger
+}
+
+func NewShiftPatternService(shiftPatternRepository repository.ShiftPatternRepository, shiftRepository repository.ShiftRepository, logger *zap.SugaredLogger) ShiftPatternService {
+	return &shiftPatternService{
+		shiftPatternRepository: shiftPatternRepository,
+		shiftRepository:        shiftRepository,
+		logger:                 logger,
+	}
+}
+
+func (s *shiftPatternService) CreatePattern(c context.Context, request web.CreateShiftPatternRequest) (web.ShiftPatternResponse, error) {
+	// convert to domain or model shift pattern
+	pattern := domain.ShiftPattern{
+		ID:        uuid.New().String(),
+		Code:      strings.ToUpper(request.Code),
+		Name:      request.Name,
+		CreatedAt: time.Now(),
+		UpdatedAt: time.Now(),
+	}
+	if err := s.validateDays(c, request.Days); err != nil {
+		return web.ShiftPatternResponse{}, err
+	}
+	pattern.Days = domain.ToDomainShiftPatternDays(request.Days)
+
+	// call the repo for inserting to db
+	if err := s.shiftPatternRepository.CreatePattern(c, pattern); err != nil {
+		s.logger.Infow(err.Error(), "Create Shift Pattern Error")
+		return web.ShiftPatternResponse{}, toShiftPatternUniqueError(err)
+	}
+
+	return s.FindById(c, pattern.ID)
+}
+
+func (s *shiftPatternService) UpdatePattern(c context.Context, id string, request web.UpdateShiftPatternRequest) (web.ShiftPatternResponse, error) {
+	pattern, err := findShiftPattern(c, s.shiftPatternRepository, id)
+	if err != nil {
+		return web.ShiftPatternResponse{}, err
+	}
+
+	// only the filled fields are updated
+	if request.Name != "" {
+		pattern.Name = request.Name
+	}
+	if len(request.Days) > 0 {
+		if err := s.validateDays(c, request.Days); err != nil {
+			return web.ShiftPatternResponse{}, err
+		}
+		pattern.Days = domain.ToDomainShiftPatternDays(request.Days)
+	}
+	pattern.UpdatedAt = time.Now()
+
+	if err := s.shiftPatternRepository.UpdatePattern(c, id, pattern); err != nil {
+		s.logger.Infow(err.Error(), "Update Shift Pattern Error")
+		return web.ShiftPatternResponse{}, err
+	}
+
+	return s.FindById(c, id)
+}
+
+func (s *shiftPatternService) Delete(c context.Context, id string) error {
+	if _, err := findShiftPattern(c, s.shiftPatternRepository, id); err != nil {
+		return err
+	}
+
+	return s.shiftPatternRepository.Delete(c, id)
+}
+
+func (s *shiftPatternService) FindAllPattern(c context.Context) ([]web.ShiftPatternResponse, error) {
+	patterns, err := s.shiftPatternRepository.FindAllPattern(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// convert to web.ShiftPatternResponse
+	result := []web.ShiftPatternResponse{}
+	for _, pattern := range patterns {
+		result = append(result, pattern.ToShiftPatternResponse())
+	}
+
+	return result, nil
+}
+
+func (s *shiftPatternService) FindById(c context.Context, id string) (web.ShiftPatternResponse, error) {
+	pattern, err := findShiftPattern(c, s.shiftPatternRepository, id)
+	if err != nil {
+		return web.ShiftPatternResponse{}, err
+	}
+
+	return pattern.ToShiftPatternResponse(), nil
+}
+
+// validate the shifts of the days exist, the pattern must have at least one working day
+func (s *shiftPatternService) validateDays(c context.Context, shiftIDs []string) error {
+	working := false
+	for _, shiftID := range shiftIDs {
+		if shiftID == "" {
+			continue
+		}
+		if _, err := findShift(c, s.shiftRepository, shiftID); err != nil {
+			return err
+		}
+		working = true
+	}
+	if !working {
+		return exception.ErrBadRequest("Shift pattern must have at least one working day.")
+	}
+
+	return nil
+}
+
+// find the shift pattern by id and convert the 'no rows' error to not found error
+func findShiftPattern(c context.Context, shiftPatternRepository repository.ShiftPatternRepository, id string) (domain.ShiftPattern, error) {
+	pattern, err := shiftPatternRepository.FindById(c, id)
+	if err != nil {
+		if strings.Contains(err.Error(), "no rows") {
+			return domain.ShiftPattern{}, exception.ErrNotFound(fmt.Sprintf("Shift pattern %s not found", id))
+		}
+		return domain.ShiftPattern{}, err
+	}
+
+	return pattern, nil
+}
+
+// convert the unique constraint error of the 'shift_patterns' table to bad request error
+func toShiftPatternUniqueError(err error) error {
+	if strings.Contains(err.Error(), "unique") && strings.Contains(err.Error(), "shift_patterns_code_key") {
+		return exception.ErrBadRequest("Shift pattern code already exist.")
+	}
+	return err
+}
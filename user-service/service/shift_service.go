@@ -0,0 +1,207 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/iqbaludinm/hr-microservice/user-service/exception"
+	"github.com/iqbaludinm/hr-microservice/user-service/model/domain"
+	"github.com/iqbaludinm/hr-microservice/user-service/model/web"
+	"github.com/iqbaludinm/hr-microservice/user-service/repository"
+	"go.uber.org/zap"
+)
+
+type ShiftService interface {
+	// With Transaction
+	CreateShift(ctx context.Context, request web.CreateShiftRequest) (web.ShiftResponse, error)
+	UpdateShift(ctx context.Context, id string, request web.UpdateShiftRequest) (web.ShiftResponse, error)
+	Delete(ctx context.Context, id string) error
+	AssignEmployees(ctx context.Context, id string, request web.AssignShiftRequest) error
+
+	// Without Transaction
+	FindAllShift(ctx context.Context) ([]web.ShiftResponse, error)
+	FindById(ctx context.Context, id string) (web.ShiftResponse, error)
+}
+
+type shiftService struct {
+	shiftRepository    repository.ShiftRepository
+	employeeRepository repository.EmployeeRepository
+	logger             *zap.SugaredLogger
+}
+
+func NewShiftService(shiftRepository repository.ShiftRepository, employeeRepository repository.EmployeeRepository, logger *zap.SugaredLogger) ShiftService {
+	return &shiftService{
+		shiftRepository:    shiftRepository,
+		employeeRepository: employeeRepository,
+		logger:             logger,
+	}
+}
+
+func (s *shiftService) CreateShift(c context.Context, request web.CreateShiftRequest) (web.ShiftResponse, error) {
+	// convert to domain or model shift
+	shift := domain.ToDomainShift(request)
+	shift.ID = uuid.New().String()
+	shift.Code = strings.ToUpper(shift.Code)
+	shift.CreatedAt = time.Now()
+	shift.UpdatedAt = time.Now()
+
+	if err := validateShift(shift); err != nil {
+		return web.ShiftResponse{}, err
+	}
+
+	// call the repo for inserting to db
+	if err := s.shiftRepository.CreateShift(c, shift); err != nil {
+		s.logger.Infow(err.Error(), "Create Shift Error")
+		return web.ShiftResponse{}, toShiftUniqueError(err)
+	}
+
+	return shift.ToShiftResponse(), nil
+}
+
+func (s *shiftService) UpdateShift(c context.Context, id string, request web.UpdateShiftRequest) (web.ShiftResponse, error) {
+	shift, err := findShift(c, s.shiftRepository, id)
+	if err != nil {
+		return web.ShiftResponse{}, err
+	}
+
+	// only the filled fields are updated
+	if request.Name != "" {
+		shift.Name = request.Name
+	}
+	if request.StartTime != "" {
+		shift.StartTime = request.StartTime
+	}
+	if request.EndTime != "" {
+		shift.EndTime = request.EndTime
+	}
+	if request.BreakMinutes != nil {
+		shift.BreakMinutes = *request.BreakMinutes
+	}
+	if request.LateToleranceMinutes != nil {
+		shift.LateToleranceMinutes = *request.LateToleranceMinutes
+	}
+	if request.EarlyLeaveToleranceMinutes != nil {
+		shift.EarlyLeaveToleranceMinutes = *request.EarlyLeaveToleranceMinutes
+	}
+	if request.MinOvertimeMinutes != nil {
+		shift.MinOvertimeMinutes = *request.MinOvertimeMinutes
+	}
+	if request.IsDefault != nil {
+		// there must always be a default shift, it is replaced by setting another shift as the default
+		if shift.IsDefault && !*request.IsDefault {
+			return web.ShiftResponse{}, exception.ErrBadRequest("Set another shift as the default instead of unsetting the default shift.")
+		}
+		shift.IsDefault = *request.IsDefault
+	}
+	if err := validateShift(shift); err != nil {
+		return web.ShiftResponse{}, err
+	}
+	shift.UpdatedAt = time.Now()
+
+	if err := s.shiftRepository.UpdateShift(c, id, shift); err != nil {
+		s.logger.Infow(err.Error(), "Update Shift Error")
+		return web.ShiftResponse{}, toShiftUniqueError(err)
+	}
+
+	return shift.ToShiftResponse(), nil
+}
+
+func (s *shiftService) Delete(c context.Context, id string) error {
+	shift, err := findShift(c, s.shiftRepository, id)
+	if err != nil {
+		return err
+	}
+	if shift.IsDefault {
+		return exception.ErrBadRequest("Default shift can't be deleted.")
+	}
+
+	employees, err := s.shiftRepository.CountEmployees(c, id)
+	if err != nil {
+		return err
+	}
+	if employees > 0 {
+		return exception.ErrBadRequest("Shift is still assigned to employees.")
+	}
+
+	patterns, err := s.shiftRepository.CountPatterns(c, id)
+	if err != nil {
+		return err
+	}
+	if patterns > 0 {
+		return exception.ErrBadRequest("Shift is still used by shift patterns.")
+	}
+
+	return s.shiftRepository.Delete(c, id)
+}
+
+func (s *shiftService) AssignEmployees(c context.Context, id string, request web.AssignShiftRequest) error {
+	if _, err := findShift(c, s.shiftRepository, id); err != nil {
+		return err
+	}
+	for _, employeeID := range request.EmployeeIDs {
+		if _, err := findEmployee(c, s.employeeRepository, employeeID); err != nil {
+			return err
+		}
+	}
+
+	return s.shiftRepository.AssignEmployees(c, id, request.EmployeeIDs)
+}
+
+func (s *shiftService) FindAllShift(c context.Context) ([]web.ShiftResponse, error) {
+	shifts, err := s.shiftRepository.FindAllShift(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// convert to web.ShiftResponse
+	result := []web.ShiftResponse{}
+	for _, shift := range shifts {
+		result = append(result, shift.ToShiftResponse())
+	}
+
+	return result, nil
+}
+
+func (s *shiftService) FindById(c context.Context, id string) (web.ShiftResponse, error) {
+	shift, err := findShift(c, s.shiftRepository, id)
+	if err != nil {
+		return web.ShiftResponse{}, err
+	}
+
+	return shift.ToShiftResponse(), nil
+}
+
+// find the shift by id and convert the 'no rows' error to not found error
+func findShift(c context.Context, shiftRepository repository.ShiftRepository, id string) (domain.Shift, error) {
+	shift, err := shiftRepository.FindById(c, id)
+	if err != nil {
+		if strings.Contains(err.Error(), "no rows") {
+			return domain.Shift{}, exception.ErrNotFound(fmt.Sprintf("Shift %s not found", id))
+		}
+		return domain.Shift{}, err
+	}
+
+	return shift, nil
+}
+
+// validate the schedule of the shift
+func validateShift(shift domain.Shift) error {
+	if shift.StartTime == shift.EndTime {
+		return exception.ErrBadRequest("Start time and end time of the shift can't be the same.")
+	}
+	if shift.BreakMinutes >= shift.Minutes() {
+		return exception.ErrBadRequest("Break of the shift must be shorter than the shift.")
+	}
+	return nil
+}
+
+// convert the unique constraint error of the 'shifts' table to bad request error
+func toShiftUniqueError(err error) error {
+	if strings.Contains(err.Error(), "unique") && strings.Contains(err.Error(), "shifts_code_key") {
+		return exception.ErrBadRequest("Shift code already exist.")
+	}
+	return err
+}
@@ -0,0 +1,366 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/iqbaludinm/hr-microservice/user-service/config"
+	"github.com/iqbaludinm/hr-microservice/user-service/exception"
+	"github.com/iqbaludinm/hr-microservice/user-service/helper"
+	"github.com/iqbaludinm/hr-microservice/user-service/model/domain"
+	"github.com/iqbaludinm/hr-microservice/user-service/model/kafkamodel"
+	"github.com/iqbaludinm/hr-microservice/user-service/model/web"
+	"github.com/iqbaludinm/hr-microservice/user-service/repository"
+	"github.com/iqbaludinm/hr-microservice/user-service/service/producers"
+	"go.uber.org/zap"
+)
+
+// Type of the notifications produced by the shift swap service.
+const (
+	NotificationShiftSwapSubmitted = "SHIFT_SWAP_SUBMITTED"
+	NotificationShiftSwapApproved  = "SHIFT_SWAP_APPROVED"
+	NotificationShiftSwapRejected  = "SHIFT_SWAP_REJECTED"
+)
+
+type ShiftSwapService interface {
+	// With Transaction
+	CreateShiftSwap(ctx context.Context, userID string, request web.CreateShiftSwapRequest) (web.ShiftSwapResponse, error)
+	Approve(ctx context.Context, userID, id string, request web.DecideShiftSwapRequest) (web.ShiftSwapResponse, error)
+	Reject(ctx context.Context, userID, id string, request web.DecideShiftSwapRequest) (web.ShiftSwapResponse, error)
+	Cancel(ctx context.Context, userID, id string) (web.ShiftSwapResponse, error)
+
+	// Without Transaction
+	FindAllShiftSwap(ctx context.Context, filter web.ShiftSwapQueryFilter) ([]web.ShiftSwapResponse, int, error)
+	FindMyShiftSwap(ctx context.Context, userID string, filter web.ShiftSwapQueryFilter) ([]web.ShiftSwapResponse, int, error)
+	FindPendingApprovals(ctx context.Context, userID string, filter web.ShiftSwapQueryFilter) ([]web.ShiftSwapResponse, int, error)
+	FindById(ctx context.Context, id string) (web.ShiftSwapResponse, error)
+}
+
+type shiftSwapService struct {
+	shiftSwapRepository    repository.ShiftSwapRepository
+	rosterRepository       repository.RosterRepository
+	shiftRepository        repository.ShiftRepository
+	holidayRepository      repository.HolidayRepository
+	workCalendarRepository repository.WorkCalendarRepository
+	employeeRepository     repository.EmployeeRepository
+	kafkaProducerService   producers.KafkaProducerService
+	logger                 *zap.SugaredLogger
+}
+
+func NewShiftSwapService(shiftSwapRepository repository.ShiftSwapRepository, rosterRepository repository.RosterRepository, shiftRepository repository.ShiftRepository, holidayRepository repository.HolidayRepository, workCalendarRepository repository.WorkCalendarRepository, employeeRepository repository.EmployeeRepository, kafkaProducerService producers.KafkaProducerService, logger *zap.SugaredLogger) ShiftSwapService {
+	return &shiftSwapService{
+		shiftSwapRepository:    shiftSwapRepository,
+		rosterRepository:       rosterRepository,
+		shiftRepository:        shiftRepository,
+		holidayRepository:      holidayRepository,
+		workCalendarRepository: workCalendarRepository,
+		employeeRepository:     employeeRepository,
+		kafkaProducerService:   kafkaProducerService,
+		logger:                 logger,
+	}
+}
+
+func (s *shiftSwapService) CreateShiftSwap(c context.Context, userID string, request web.CreateShiftSwapRequest) (web.ShiftSwapResponse, error) {
+	requester, err := findEmployeeByUser(c, s.employeeRepository, userID)
+	if err != nil {
+		return web.ShiftSwapResponse{}, err
+	}
+
+	// the shift swap request is approved by the requester's manager
+	if requester.ManagerID == nil {
+		return web.ShiftSwapResponse{}, exception.ErrBadRequest("Employee has no manager to approve the shift swap request.")
+	}
+	manager, err := findEmployee(c, s.employeeRepository, *requester.ManagerID)
+	if err != nil {
+		return web.ShiftSwapResponse{}, err
+	}
+
+	colleague, err := findEmployee(c, s.employeeRepository, request.ColleagueID)
+	if err != nil {
+		return web.ShiftSwapResponse{}, err
+	}
+	if colleague.ID == requester.ID {
+		return web.ShiftSwapResponse{}, exception.ErrBadRequest("Shift can't be swapped with yourself.")
+	}
+	if colleague.Status != domain.EmployeeStatusActive {
+		return web.ShiftSwapResponse{}, exception.ErrBadRequest(fmt.Sprintf("Colleague is %s.", colleague.Status))
+	}
+
+	date, _ := helper.ParseDate(request.Date)
+	if date.Before(helper.Today()) {
+		return web.ShiftSwapResponse{}, exception.ErrBadRequest("Shift swap date can't be in the past.")
+	}
+
+	requesterShift, colleagueShift, err := s.findShifts(c, requester, colleague, date)
+	if err != nil {
+		return web.ShiftSwapResponse{}, err
+	}
+	if equalStringPtr(requesterShift, colleagueShift) {
+		return web.ShiftSwapResponse{}, exception.ErrBadRequest("You and the colleague have the same schedule on the date.")
+	}
+
+	// an employee can only be in one pending shift swap request on the date
+	pending, err := s.shiftSwapRepository.CountPending(c, []string{requester.ID, colleague.ID}, date)
+	if err != nil {
+		return web.ShiftSwapResponse{}, err
+	}
+	if pending > 0 {
+		return web.ShiftSwapResponse{}, exception.ErrBadRequest("There is already a pending shift swap request on the date.")
+	}
+
+	swap := domain.ShiftSwap{
+		ID:               uuid.New().String(),
+		RequesterID:      requester.ID,
+		ColleagueID:      colleague.ID,
+		Date:             date,
+		RequesterShiftID: requesterShift,
+		ColleagueShiftID: colleagueShift,
+		Reason:           request.Reason,
+		Status:           domain.ShiftSwapStatusPending,
+		ApproverID:       &manager.ID,
+		CreatedAt:        time.Now(),
+		UpdatedAt:        time.Now(),
+	}
+
+	// call the repo for inserting to db
+	if err := s.shiftSwapRepository.CreateShiftSwap(c, swap); err != nil {
+		s.logger.Infow(err.Error(), "Create Shift Swap Error")
+		return web.ShiftSwapResponse{}, err
+	}
+
+	newSwap, err := s.shiftSwapRepository.FindById(c, swap.ID)
+	if err != nil {
+		return web.ShiftSwapResponse{}, exception.ErrInternalServer(fmt.Sprintf("Successfully created shift swap request, but failed to get the shift swap request have created. Error: %s", err.Error()))
+	}
+
+	// notify the manager there is a shift swap request to be approved, and the colleague whose shift is swapped
+	message := fmt.Sprintf("%s requested to swap shifts with %s on %s.", newSwap.RequesterName, newSwap.ColleagueName, newSwap.Date.Format(helper.DateLayout))
+	s.notify(manager.UserID, NotificationShiftSwapSubmitted, "Shift Swap Request Submitted", message, newSwap)
+	s.notify(newSwap.ColleagueUserID, NotificationShiftSwapSubmitted, "Shift Swap Request Submitted", message, newSwap)
+
+	return newSwap.ToShiftSwapResponse(), nil
+}
+
+func (s *shiftSwapService) Approve(c context.Context, userID, id string, request web.DecideShiftSwapRequest) (web.ShiftSwapResponse, error) {
+	swap, err := s.decide(c, userID, id, domain.ShiftSwapStatusApproved, request.Note)
+	if err != nil {
+		return web.ShiftSwapResponse{}, err
+	}
+
+	message := fmt.Sprintf("The shift swap of %s and %s on %s has been approved by %s.", swap.RequesterName, swap.ColleagueName, swap.Date.Format(helper.DateLayout), swap.ApproverName)
+	s.notify(swap.RequesterUserID, NotificationShiftSwapApproved, "Shift Swap Request Approved", message, swap)
+	s.notify(swap.ColleagueUserID, NotificationShiftSwapApproved, "Shift Swap Request Approved", message, swap)
+
+	return swap.ToShiftSwapResponse(), nil
+}
+
+func (s *shiftSwapService) Reject(c context.Context, userID, id string, request web.DecideShiftSwapRequest) (web.ShiftSwapResponse, error) {
+	swap, err := s.decide(c, userID, id, domain.ShiftSwapStatusRejected, request.Note)
+	if err != nil {
+		return web.ShiftSwapResponse{}, err
+	}
+
+	message := fmt.Sprintf("The shift swap of %s and %s on %s has been rejected by %s.", swap.RequesterName, swap.ColleagueName, swap.Date.Format(helper.DateLayout), swap.ApproverName)
+	s.notify(swap.RequesterUserID, NotificationShiftSwapRejected, "Shift Swap Request Rejected", message, swap)
+	s.notify(swap.ColleagueUserID, NotificationShiftSwapRejected, "Shift Swap Request Rejected", message, swap)
+
+	return swap.ToShiftSwapResponse(), nil
+}
+
+func (s *shiftSwapService) Cancel(c context.Context, userID, id string) (web.ShiftSwapResponse, error) {
+	swap, err := s.findShiftSwap(c, id)
+	if err != nil {
+		return web.ShiftSwapResponse{}, err
+	}
+
+	employee, err := findEmployeeByUser(c, s.employeeRepository, userID)
+	if err != nil {
+		return web.ShiftSwapResponse{}, err
+	}
+	if swap.RequesterID != employee.ID {
+		return web.ShiftSwapResponse{}, exception.ErrUnauthorized("Only the requester can cancel the shift swap request.")
+	}
+	if swap.Status != domain.ShiftSwapStatusPending {
+		return web.ShiftSwapResponse{}, exception.ErrBadRequest(fmt.Sprintf("Shift swap request is already %s.", swap.Status))
+	}
+
+	cancelledAt := time.Now()
+	swap.Status = domain.ShiftSwapStatusCancelled
+	swap.CancelledAt = &cancelledAt
+	swap.UpdatedAt = cancelledAt
+
+	if err := s.shiftSwapRepository.UpdateStatus(c, swap, nil); err != nil {
+		s.logger.Infow(err.Error(), "Cancel Shift Swap Error")
+		return web.ShiftSwapResponse{}, err
+	}
+
+	return swap.ToShiftSwapResponse(), nil
+}
+
+func (s *shiftSwapService) FindAllShiftSwap(c context.Context, filter web.ShiftSwapQueryFilter) ([]web.ShiftSwapResponse, int, error) {
+	return s.findAllShiftSwap(c, domain.ToDomainShiftSwapQueryFilter(filter))
+}
+
+func (s *shiftSwapService) FindMyShiftSwap(c context.Context, userID string, filter web.ShiftSwapQueryFilter) ([]web.ShiftSwapResponse, int, error) {
+	employee, err := findEmployeeByUser(c, s.employeeRepository, userID)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	domainFilter := domain.ToDomainShiftSwapQueryFilter(filter)
+	domainFilter.EmployeeID = employee.ID
+
+	return s.findAllShiftSwap(c, domainFilter)
+}
+
+func (s *shiftSwapService) FindPendingApprovals(c context.Context, userID string, filter web.ShiftSwapQueryFilter) ([]web.ShiftSwapResponse, int, error) {
+	approver, err := findEmployeeByUser(c, s.employeeRepository, userID)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	domainFilter := domain.ToDomainShiftSwapQueryFilter(filter)
+	domainFilter.ApproverID = approver.ID
+	domainFilter.Status = domain.ShiftSwapStatusPending
+
+	return s.findAllShiftSwap(c, domainFilter)
+}
+
+func (s *shiftSwapService) FindById(c context.Context, id string) (web.ShiftSwapResponse, error) {
+	swap, err := s.findShiftSwap(c, id)
+	if err != nil {
+		return web.ShiftSwapResponse{}, err
+	}
+
+	return swap.ToShiftSwapResponse(), nil
+}
+
+// approve or reject the pending shift swap request, only the approver can decide it.
+// The approval exchanges the shifts of the requester and the colleague in the roster of the date.
+func (s *shiftSwapService) decide(c context.Context, userID, id, status, note string) (domain.ShiftSwap, error) {
+	swap, err := s.findShiftSwap(c, id)
+	if err != nil {
+		return domain.ShiftSwap{}, err
+	}
+
+	approver, err := findEmployeeByUser(c, s.employeeRepository, userID)
+	if err != nil {
+		return domain.ShiftSwap{}, err
+	}
+	if swap.ApproverID == nil || *swap.ApproverID != approver.ID {
+		return domain.ShiftSwap{}, exception.ErrUnauthorized("Only the approver can decide the shift swap request.")
+	}
+	if swap.Status != domain.ShiftSwapStatusPending {
+		return domain.ShiftSwap{}, exception.ErrBadRequest(fmt.Sprintf("Shift swap request is already %s.", swap.Status))
+	}
+
+	decidedAt := time.Now()
+	var rosters []domain.Roster
+	if status == domain.ShiftSwapStatusApproved {
+		if swap.Date.Before(helper.Today()) {
+			return domain.ShiftSwap{}, exception.ErrBadRequest("Shift swap date has passed.")
+		}
+
+		requester, err := findEmployee(c, s.employeeRepository, swap.RequesterID)
+		if err != nil {
+			return domain.ShiftSwap{}, err
+		}
+		colleague, err := findEmployee(c, s.employeeRepository, swap.ColleagueID)
+		if err != nil {
+			return domain.ShiftSwap{}, err
+		}
+
+		// the shifts are only swapped when the roster is still the same as when it was requested
+		requesterShift, colleagueShift, err := s.findShifts(c, requester, colleague, swap.Date)
+		if err != nil {
+			return domain.ShiftSwap{}, err
+		}
+		if !equalStringPtr(requesterShift, swap.RequesterShiftID) || !equalStringPtr(colleagueShift, swap.ColleagueShiftID) {
+			return domain.ShiftSwap{}, exception.ErrBadRequest("Roster has changed since the shift swap was requested, please request it again.")
+		}
+
+		rosters = []domain.Roster{
+			{ID: uuid.New().String(), EmployeeID: requester.ID, Date: swap.Date, ShiftID: colleagueShift, CreatedAt: decidedAt, UpdatedAt: decidedAt},
+			{ID: uuid.New().String(), EmployeeID: colleague.ID, Date: swap.Date, ShiftID: requesterShift, CreatedAt: decidedAt, UpdatedAt: decidedAt},
+		}
+	}
+
+	swap.Status = status
+	swap.DecisionNote = note
+	swap.DecidedAt = &decidedAt
+	swap.UpdatedAt = decidedAt
+
+	if err := s.shiftSwapRepository.UpdateStatus(c, swap, rosters); err != nil {
+		s.logger.Infow(err.Error(), "Decide Shift Swap Error")
+		return domain.ShiftSwap{}, err
+	}
+
+	return swap, nil
+}
+
+// find the current shifts of the requester and the colleague on the date, nil is a day off
+func (s *shiftSwapService) findShifts(c context.Context, requester, colleague domain.Employee, date time.Time) (requesterShift, colleagueShift *string, err error) {
+	entries, err := findRosterEntries(c, s.rosterRepository, s.shiftRepository, s.holidayRepository, s.workCalendarRepository, []domain.Employee{requester, colleague}, date, date)
+	if err != nil {
+		return nil, nil, err
+	}
+	for _, entry := range entries {
+		if entry.Shift == nil {
+			continue
+		}
+		if entry.EmployeeID == requester.ID {
+			requesterShift = &entry.Shift.ID
+		} else {
+			colleagueShift = &entry.Shift.ID
+		}
+	}
+
+	return requesterShift, colleagueShift, nil
+}
+
+func (s *shiftSwapService) findAllShiftSwap(c context.Context, filter domain.ShiftSwapQueryFilter) (result []web.ShiftSwapResponse, totalData int, err error) {
+	swaps, err := s.shiftSwapRepository.FindAllShiftSwap(c, filter)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	// get total-data
+	totalData, err = s.shiftSwapRepository.CountAllShiftSwap(c, filter)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	// convert to web.ShiftSwapResponse
+	result = []web.ShiftSwapResponse{}
+	for _, swap := range swaps {
+		result = append(result, swap.ToShiftSwapResponse())
+	}
+
+	return result, totalData, nil
+}
+
+// find the shift swap request by id and convert the 'no rows' error to not found error
+func (s *shiftSwapService) findShiftSwap(c context.Context, id string) (domain.ShiftSwap, error) {
+	swap, err := s.shiftSwapRepository.FindById(c, id)
+	if err != nil {
+		if strings.Contains(err.Error(), "no rows") {
+			return domain.ShiftSwap{}, exception.ErrNotFound(fmt.Sprintf("Shift swap request %s not found", id))
+		}
+		return domain.ShiftSwap{}, err
+	}
+
+	return swap, nil
+}
+
+// produce the notification of the shift swap request to the user
+func (s *shiftSwapService) notify(userID, notificationType, title, message string, swap domain.ShiftSwap) {
+	kafkaNotificationMessage := kafkamodel.NewKafkaNotificationMessage(userID, notificationType, title, message, map[string]interface{}{
+		"shift_swap_id": swap.ID,
+		"status":        swap.Status,
+		"date":          swap.Date.Format(helper.DateLayout),
+	})
+	go s.kafkaProducerService.Produce(kafkaNotificationMessage, "POST.NOTIFICATION", config.KafkaTopicNotification)
+}
@@ -0,0 +1,551 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/iqbaludinm/hr-microservice/user-service/config"
+	"github.com/iqbaludinm/hr-microservice/user-service/exception"
+	"github.com/iqbaludinm/hr-microservice/user-service/helper"
+	"github.com/iqbaludinm/hr-microservice/user-service/model/domain"
+	"github.com/iqbaludinm/hr-microservice/user-service/model/kafkamodel"
+	"github.com/iqbaludinm/hr-microservice/user-service/model/web"
+	"github.com/iqbaludinm/hr-microservice/user-service/repository"
+	"github.com/iqbaludinm/hr-microservice/user-service/service/producers"
+	"go.uber.org/zap"
+)
+
+// Type of the notifications produced by the training service.
+const (
+	NotificationTrainingEnrolled = "TRAINING_ENROLLED"
+)
+
+type TrainingService interface {
+	// With Transaction
+	CreateTraining(ctx context.Context, request web.TrainingRequest) (web.TrainingResponse, error)
+	UpdateTraining(ctx context.Context, id string, request web.TrainingRequest) (web.TrainingResponse, error)
+	DeleteTraining(ctx context.Context, id string) error
+	Enroll(ctx context.Context, userID string, request web.TrainingEnrollmentRequest) ([]web.TrainingEnrollmentResponse, error)
+	Complete(ctx context.Context, userID, id string, request web.CompleteTrainingEnrollmentRequest, file *web.DocumentFile) (web.TrainingEnrollmentResponse, error)
+	Cancel(ctx context.Context, id string, request web.CancelTrainingEnrollmentRequest) (web.TrainingEnrollmentResponse, error)
+	EnrollMandatory(ctx context.Context) error
+
+	// Without Transaction
+	FindAllTraining(ctx context.Context, filter web.TrainingQueryFilter) ([]web.TrainingResponse, int, error)
+	FindTrainingById(ctx context.Context, id string) (web.TrainingResponse, error)
+	FindAllEnrollment(ctx context.Context, filter web.TrainingEnrollmentQueryFilter) ([]web.TrainingEnrollmentResponse, int, error)
+	FindMyEnrollments(ctx context.Context, userID string, filter web.TrainingEnrollmentQueryFilter) ([]web.TrainingEnrollmentResponse, int, error)
+	FindEnrollmentById(ctx context.Context, id string) (web.TrainingEnrollmentResponse, error)
+	Compliance(ctx context.Context, filter web.TrainingComplianceQueryFilter) ([]web.TrainingComplianceResponse, int, error)
+}
+
+type trainingService struct {
+	trainingRepository   repository.TrainingRepository
+	employeeRepository   repository.EmployeeRepository
+	positionRepository   repository.PositionRepository
+	storage              helper.Storage
+	kafkaProducerService producers.KafkaProducerService
+	logger               *zap.SugaredLogger
+}
+
+func NewTrainingService(trainingRepository repository.TrainingRepository, employeeRepository repository.EmployeeRepository, positionRepository repository.PositionRepository, storage helper.Storage, kafkaProducerService producers.KafkaProducerService, logger *zap.SugaredLogger) TrainingService {
+	return &trainingService{
+		trainingRepository:   trainingRepository,
+		employeeRepository:   employeeRepository,
+		positionRepository:   positionRepository,
+		storage:              storage,
+		kafkaProducerService: kafkaProducerService,
+		logger:               logger,
+	}
+}
+
+func (s *trainingService) CreateTraining(c context.Context, request web.TrainingRequest) (web.TrainingResponse, error) {
+	// convert to domain or model training
+	training := domain.ToDomainTraining(request)
+	if err := s.validateRequirements(c, training); err != nil {
+		return web.TrainingResponse{}, err
+	}
+
+	training.ID = uuid.New().String()
+	training.CreatedAt = time.Now()
+	training.UpdatedAt = time.Now()
+	for i := range training.Requirements {
+		training.Requirements[i].CreatedAt = training.CreatedAt
+	}
+
+	// call the repo for inserting to db
+	if err := s.trainingRepository.CreateTraining(c, training); err != nil {
+		s.logger.Infow(err.Error(), "Create Training Error")
+		return web.TrainingResponse{}, toTrainingUniqueError(err)
+	}
+
+	// get or returning the training have created to db
+	newTraining, err := s.trainingRepository.FindTrainingById(c, training.ID)
+	if err != nil {
+		return web.TrainingResponse{}, exception.ErrInternalServer(fmt.Sprintf("Successfully created training, but failed to get the training have created. Error: %s", err.Error()))
+	}
+
+	return newTraining.ToTrainingResponse(), nil
+}
+
+// update the training, the requirements are replaced. The requirement which is kept keeps its creation time, the
+// employees who are already enrolled keep their due dates.
+func (s *trainingService) UpdateTraining(c context.Context, id string, request web.TrainingRequest) (web.TrainingResponse, error) {
+	existing, err := findTraining(c, s.trainingRepository, id)
+	if err != nil {
+		return web.TrainingResponse{}, err
+	}
+
+	training := domain.ToDomainTraining(request)
+	if err := s.validateRequirements(c, training); err != nil {
+		return web.TrainingResponse{}, err
+	}
+
+	training.ID = id
+	training.UpdatedAt = time.Now()
+	createdAt := map[string]time.Time{}
+	for _, requirement := range existing.Requirements {
+		createdAt[requirement.PositionID] = requirement.CreatedAt
+	}
+	for i, requirement := range training.Requirements {
+		training.Requirements[i].CreatedAt = training.UpdatedAt
+		if t, ok := createdAt[requirement.PositionID]; ok {
+			training.Requirements[i].CreatedAt = t
+		}
+	}
+
+	if err := s.trainingRepository.UpdateTraining(c, training); err != nil {
+		s.logger.Infow(err.Error(), "Update Training Error")
+		return web.TrainingResponse{}, toTrainingUniqueError(err)
+	}
+
+	updatedTraining, err := s.trainingRepository.FindTrainingById(c, id)
+	if err != nil {
+		return web.TrainingResponse{}, exception.ErrInternalServer(fmt.Sprintf("Successfully updated training, but failed to get the training have updated. Error: %s", err.Error()))
+	}
+
+	return updatedTraining.ToTrainingResponse(), nil
+}
+
+// delete the training, the training which still has open enrollments can't be deleted. The completed enrollments
+// and their certificates are kept.
+func (s *trainingService) DeleteTraining(c context.Context, id string) error {
+	if _, err := findTraining(c, s.trainingRepository, id); err != nil {
+		return err
+	}
+
+	count, err := s.trainingRepository.CountOpenEnrollment(c, id)
+	if err != nil {
+		return err
+	}
+	if count > 0 {
+		return exception.ErrBadRequest(fmt.Sprintf("The training still has %d open enrollment(s), complete or cancel them first.", count))
+	}
+
+	if err := s.trainingRepository.DeleteTraining(c, id); err != nil {
+		s.logger.Infow(err.Error(), "Delete Training Error")
+		return err
+	}
+
+	return nil
+}
+
+// enroll the employees in the training and notify them, the employees who are already enrolled are skipped
+func (s *trainingService) Enroll(c context.Context, userID string, request web.TrainingEnrollmentRequest) ([]web.TrainingEnrollmentResponse, error) {
+	training, err := findTraining(c, s.trainingRepository, request.TrainingID)
+	if err != nil {
+		return nil, err
+	}
+
+	dueDate := helper.ParseOptionalDate(request.DueDate)
+	if dueDate != nil && dueDate.Before(helper.Today()) {
+		return nil, exception.ErrBadRequest("The due date can't be in the past.")
+	}
+
+	enrolled, err := s.trainingRepository.FindEnrolledEmployees(c, training.ID, request.EmployeeIDs)
+	if err != nil {
+		return nil, err
+	}
+	skipped := map[string]bool{}
+	for _, employeeID := range enrolled {
+		skipped[employeeID] = true
+	}
+
+	var enrollments []domain.TrainingEnrollment
+	for _, employeeID := range request.EmployeeIDs {
+		if skipped[employeeID] {
+			continue
+		}
+		if _, err := findEmployee(c, s.employeeRepository, employeeID); err != nil {
+			return nil, err
+		}
+		// the same employee may be listed twice in the request
+		skipped[employeeID] = true
+
+		enrollments = append(enrollments, domain.TrainingEnrollment{
+			ID:         uuid.New().String(),
+			TrainingID: training.ID,
+			EmployeeID: employeeID,
+			Status:     domain.TrainingEnrollmentEnrolled,
+			DueDate:    dueDate,
+			EnrolledBy: &userID,
+			CreatedAt:  time.Now(),
+			UpdatedAt:  time.Now(),
+		})
+	}
+	if len(enrollments) == 0 {
+		return nil, exception.ErrBadRequest("The employees are already enrolled in the training.")
+	}
+
+	// call the repo for inserting to db
+	if err := s.trainingRepository.Enroll(c, enrollments); err != nil {
+		s.logger.Infow(err.Error(), "Enroll Training Error")
+		if strings.Contains(err.Error(), "unique") && strings.Contains(err.Error(), "training_enrollments_training_id_employee_id_idx") {
+			return nil, exception.ErrBadRequest("The employee is already enrolled in the training.")
+		}
+		return nil, err
+	}
+
+	result := []web.TrainingEnrollmentResponse{}
+	for _, enrollment := range enrollments {
+		newEnrollment, err := s.trainingRepository.FindEnrollmentById(c, enrollment.ID)
+		if err != nil {
+			return nil, exception.ErrInternalServer(fmt.Sprintf("Successfully enrolled the employees, but failed to get the enrollments have created. Error: %s", err.Error()))
+		}
+		s.notifyEnrolled(newEnrollment)
+		result = append(result, newEnrollment.ToTrainingEnrollmentResponse())
+	}
+
+	return result, nil
+}
+
+// complete the enrollment and publish the completion. The uploaded certificate is saved to the employee documents,
+// so the owner and HR are reminded before it expires together with the other documents.
+func (s *trainingService) Complete(c context.Context, userID, id string, request web.CompleteTrainingEnrollmentRequest, file *web.DocumentFile) (web.TrainingEnrollmentResponse, error) {
+	enrollment, err := findTrainingEnrollment(c, s.trainingRepository, id)
+	if err != nil {
+		return web.TrainingEnrollmentResponse{}, err
+	}
+	if enrollment.Status != domain.TrainingEnrollmentEnrolled {
+		return web.TrainingEnrollmentResponse{}, exception.ErrBadRequest("Only an enrolled training can be completed.")
+	}
+
+	training, err := findTraining(c, s.trainingRepository, enrollment.TrainingID)
+	if err != nil {
+		return web.TrainingEnrollmentResponse{}, err
+	}
+	if training.CertificateRequired && file == nil {
+		return web.TrainingEnrollmentResponse{}, exception.ErrBadRequest("The certificate of the training is required.")
+	}
+
+	completedOn := helper.Today()
+	if request.CompletedOn != "" {
+		completedOn = *helper.ParseOptionalDate(request.CompletedOn)
+	}
+	if completedOn.After(helper.Today()) {
+		return web.TrainingEnrollmentResponse{}, exception.ErrBadRequest("The completion date can't be in the future.")
+	}
+
+	var certificate *domain.EmployeeDocument
+	var version *domain.EmployeeDocumentVersion
+	if file != nil {
+		expiryDate := training.CertificateExpiryDate(completedOn)
+		if request.ExpiryDate != "" {
+			expiryDate = helper.ParseOptionalDate(request.ExpiryDate)
+		}
+		if expiryDate != nil && !expiryDate.After(completedOn) {
+			return web.TrainingEnrollmentResponse{}, exception.ErrBadRequest("The expiry date of the certificate must be after the completion date.")
+		}
+
+		newVersion, err := newEmployeeDocumentVersion(*file)
+		if err != nil {
+			return web.TrainingEnrollmentResponse{}, err
+		}
+		certificate = &domain.EmployeeDocument{
+			ID:            uuid.New().String(),
+			EmployeeID:    enrollment.EmployeeID,
+			Type:          domain.EmployeeDocumentTypeCertificate,
+			Title:         training.Name,
+			Number:        request.CertificateNumber,
+			ExpiryDate:    expiryDate,
+			Access:        domain.EmployeeDocumentAccessManager,
+			LatestVersion: 1,
+			CreatedBy:     &userID,
+			CreatedAt:     time.Now(),
+			UpdatedAt:     time.Now(),
+		}
+		newVersion.DocumentID = certificate.ID
+		newVersion.Version = certificate.LatestVersion
+		newVersion.StorageKey = certificate.NewStorageKey(newVersion.Version, newVersion.FileName)
+		newVersion.Note = request.Note
+		newVersion.UploadedBy = &userID
+		newVersion.UploadedAt = time.Now()
+		version = &newVersion
+
+		enrollment.CertificateDocumentID = &certificate.ID
+	} else if request.ExpiryDate != "" || request.CertificateNumber != "" {
+		return web.TrainingEnrollmentResponse{}, exception.ErrBadRequest("The certificate number and the expiry date are only kept with the uploaded certificate.")
+	}
+
+	enrollment.Status = domain.TrainingEnrollmentCompleted
+	enrollment.CompletedOn = &completedOn
+	enrollment.Note = request.Note
+	enrollment.CompletedBy = &userID
+	enrollment.UpdatedAt = time.Now()
+
+	// store the certificate first, the stored file is removed when the enrollment fails to be completed
+	if version != nil {
+		if err := s.storage.Put(c, version.StorageKey, file.Data); err != nil {
+			s.logger.Infow(err.Error(), "Store Training Certificate Error")
+			return web.TrainingEnrollmentResponse{}, err
+		}
+	}
+
+	if err := s.trainingRepository.Complete(c, enrollment, certificate, version); err != nil {
+		s.logger.Infow(err.Error(), "Complete Training Enrollment Error")
+		if version != nil {
+			_ = s.storage.Delete(c, version.StorageKey)
+		}
+		return web.TrainingEnrollmentResponse{}, err
+	}
+
+	completed, err := s.trainingRepository.FindEnrollmentById(c, id)
+	if err != nil {
+		return web.TrainingEnrollmentResponse{}, exception.ErrInternalServer(fmt.Sprintf("Successfully completed training enrollment, but failed to get the enrollment have completed. Error: %s", err.Error()))
+	}
+
+	// publish the completion, e.g. for the learning history of the employee
+	kafkaTrainingCompletionMessage := kafkamodel.NewKafkaTrainingCompletionMessage(completed)
+	go s.kafkaProducerService.Produce(kafkaTrainingCompletionMessage, "POST.TRAINING_COMPLETION", config.KafkaTopic)
+
+	return completed.ToTrainingEnrollmentResponse(), nil
+}
+
+func (s *trainingService) Cancel(c context.Context, id string, request web.CancelTrainingEnrollmentRequest) (web.TrainingEnrollmentResponse, error) {
+	enrollment, err := findTrainingEnrollment(c, s.trainingRepository, id)
+	if err != nil {
+		return web.TrainingEnrollmentResponse{}, err
+	}
+	if enrollment.Status != domain.TrainingEnrollmentEnrolled {
+		return web.TrainingEnrollmentResponse{}, exception.ErrBadRequest("Only an enrolled training can be cancelled.")
+	}
+
+	enrollment.Status = domain.TrainingEnrollmentCancelled
+	enrollment.Note = request.Note
+	enrollment.UpdatedAt = time.Now()
+
+	if err := s.trainingRepository.UpdateEnrollment(c, enrollment); err != nil {
+		s.logger.Infow(err.Error(), "Cancel Training Enrollment Error")
+		return web.TrainingEnrollmentResponse{}, err
+	}
+
+	return s.FindEnrollmentById(c, id)
+}
+
+// enroll the active employees in the mandatory trainings of their positions. The employee whose certificate
+// expires within the document reminder days is enrolled again and the renewal is due when the certificate expires.
+// The cancelled mandatory enrollment is enrolled again on the next run. It is run by the scheduler.
+func (s *trainingService) EnrollMandatory(c context.Context) error {
+	today := helper.Today()
+	mandatoryTrainings, err := s.trainingRepository.FindMandatoryTrainings(c, today.AddDate(0, 0, config.DocumentExpiryReminderDays))
+	if err != nil {
+		return err
+	}
+
+	for _, mandatory := range mandatoryTrainings {
+		dueDate := mandatory.DueDate(today)
+		enrollment := domain.TrainingEnrollment{
+			ID:         uuid.New().String(),
+			TrainingID: mandatory.TrainingID,
+			EmployeeID: mandatory.EmployeeID,
+			Mandatory:  true,
+			Status:     domain.TrainingEnrollmentEnrolled,
+			DueDate:    &dueDate,
+			CreatedAt:  time.Now(),
+			UpdatedAt:  time.Now(),
+		}
+
+		if err := s.trainingRepository.Enroll(c, []domain.TrainingEnrollment{enrollment}); err != nil {
+			s.logger.Errorw("Enroll Mandatory Training Error", "training_id", mandatory.TrainingID, "employee_id", mandatory.EmployeeID, "error", err.Error())
+			continue
+		}
+
+		enrollment.TrainingName = mandatory.TrainingName
+		enrollment.EmployeeUserID = mandatory.EmployeeUserID
+		s.notifyEnrolled(enrollment)
+	}
+
+	return nil
+}
+
+func (s *trainingService) FindAllTraining(c context.Context, filter web.TrainingQueryFilter) (result []web.TrainingResponse, totalData int, err error) {
+	trainings, err := s.trainingRepository.FindAllTraining(c, domain.ToDomainTrainingQueryFilter(filter))
+	if err != nil {
+		return nil, 0, err
+	}
+
+	// get total-data
+	totalData, err = s.trainingRepository.CountAllTraining(c, domain.ToDomainTrainingQueryFilter(filter))
+	if err != nil {
+		return nil, 0, err
+	}
+
+	// convert to web.TrainingResponse, the requirements are only filled when a single training is fetched
+	result = []web.TrainingResponse{}
+	for _, training := range trainings {
+		result = append(result, training.ToTrainingResponse())
+	}
+
+	return result, totalData, nil
+}
+
+func (s *trainingService) FindTrainingById(c context.Context, id string) (web.TrainingResponse, error) {
+	training, err := findTraining(c, s.trainingRepository, id)
+	if err != nil {
+		return web.TrainingResponse{}, err
+	}
+
+	return training.ToTrainingResponse(), nil
+}
+
+func (s *trainingService) FindAllEnrollment(c context.Context, filter web.TrainingEnrollmentQueryFilter) ([]web.TrainingEnrollmentResponse, int, error) {
+	return s.findAllEnrollment(c, domain.ToDomainTrainingEnrollmentQueryFilter(filter))
+}
+
+func (s *trainingService) FindMyEnrollments(c context.Context, userID string, filter web.TrainingEnrollmentQueryFilter) ([]web.TrainingEnrollmentResponse, int, error) {
+	employee, err := findEmployeeByUser(c, s.employeeRepository, userID)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	domainFilter := domain.ToDomainTrainingEnrollmentQueryFilter(filter)
+	domainFilter.EmployeeID = employee.ID
+	return s.findAllEnrollment(c, domainFilter)
+}
+
+func (s *trainingService) FindEnrollmentById(c context.Context, id string) (web.TrainingEnrollmentResponse, error) {
+	enrollment, err := findTrainingEnrollment(c, s.trainingRepository, id)
+	if err != nil {
+		return web.TrainingEnrollmentResponse{}, err
+	}
+
+	return enrollment.ToTrainingEnrollmentResponse(), nil
+}
+
+// the compliance report, the mandatory enrollments which are not completed by the due date
+func (s *trainingService) Compliance(c context.Context, filter web.TrainingComplianceQueryFilter) (result []web.TrainingComplianceResponse, totalData int, err error) {
+	domainFilter := domain.ToDomainTrainingComplianceQueryFilter(filter)
+	enrollments, err := s.trainingRepository.FindAllEnrollment(c, domainFilter)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	// get total-data
+	totalData, err = s.trainingRepository.CountAllEnrollment(c, domainFilter)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	// convert to web.TrainingComplianceResponse
+	result = []web.TrainingComplianceResponse{}
+	for _, enrollment := range enrollments {
+		result = append(result, enrollment.ToTrainingComplianceResponse())
+	}
+
+	return result, totalData, nil
+}
+
+func (s *trainingService) findAllEnrollment(c context.Context, filter domain.TrainingEnrollmentQueryFilter) (result []web.TrainingEnrollmentResponse, totalData int, err error) {
+	enrollments, err := s.trainingRepository.FindAllEnrollment(c, filter)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	// get total-data
+	totalData, err = s.trainingRepository.CountAllEnrollment(c, filter)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	// convert to web.TrainingEnrollmentResponse
+	result = []web.TrainingEnrollmentResponse{}
+	for _, enrollment := range enrollments {
+		result = append(result, enrollment.ToTrainingEnrollmentResponse())
+	}
+
+	return result, totalData, nil
+}
+
+// validate the positions of the requirements exist and each position is listed once
+func (s *trainingService) validateRequirements(c context.Context, training domain.Training) error {
+	positions := map[string]bool{}
+	for _, requirement := range training.Requirements {
+		if positions[requirement.PositionID] {
+			return exception.ErrBadRequest(fmt.Sprintf("Position %s is listed more than once.", requirement.PositionID))
+		}
+		positions[requirement.PositionID] = true
+
+		if _, err := s.positionRepository.FindById(c, requirement.PositionID); err != nil {
+			if strings.Contains(err.Error(), "no rows") {
+				return exception.ErrNotFound(fmt.Sprintf("Position %s not found", requirement.PositionID))
+			}
+			return err
+		}
+	}
+
+	return nil
+}
+
+// notify the employee of the enrollment with the due date
+func (s *trainingService) notifyEnrolled(enrollment domain.TrainingEnrollment) {
+	message := fmt.Sprintf("You are enrolled in the training '%s'.", enrollment.TrainingName)
+	var dueDate *string
+	if enrollment.DueDate != nil {
+		date := enrollment.DueDate.Format(helper.DateLayout)
+		dueDate = &date
+		message = fmt.Sprintf("You are enrolled in the training '%s', please complete it by %s.", enrollment.TrainingName, date)
+	}
+
+	kafkaNotificationMessage := kafkamodel.NewKafkaNotificationMessage(enrollment.EmployeeUserID, NotificationTrainingEnrolled, "Training Enrollment", message, map[string]interface{}{
+		"enrollment_id": enrollment.ID,
+		"training_id":   enrollment.TrainingID,
+		"due_date":      dueDate,
+	})
+	go s.kafkaProducerService.Produce(kafkaNotificationMessage, "POST.NOTIFICATION", config.KafkaTopicNotification)
+}
+
+func toTrainingUniqueError(err error) error {
+	if strings.Contains(err.Error(), "unique") && strings.Contains(err.Error(), "trainings_code_key") {
+		return exception.ErrBadRequest("Training code already exist.")
+	}
+	return err
+}
+
+// find the training by id with its requirements and convert the 'no rows' error to not found error
+func findTraining(c context.Context, trainingRepository repository.TrainingRepository, id string) (domain.Training, error) {
+	training, err := trainingRepository.FindTrainingById(c, id)
+	if err != nil {
+		if strings.Contains(err.Error(), "no rows") {
+			return domain.Training{}, exception.ErrNotFound(fmt.Sprintf("Training %s not found", id))
+		}
+		return domain.Training{}, err
+	}
+
+	return training, nil
+}
+
+// find the training enrollment by id and convert the 'no rows' error to not found error
+func findTrainingEnrollment(c context.Context, trainingRepository repository.TrainingRepository, id string) (domain.TrainingEnrollment, error) {
+	enrollment, err := trainingRepository.FindEnrollmentById(c, id)
+	if err != nil {
+		if strings.Contains(err.Error(), "no rows") {
+			return domain.TrainingEnrollment{}, exception.ErrNotFound(fmt.Sprintf("Training enrollment %s not found", id))
+		}
+		return domain.TrainingEnrollment{}, err
+	}
+
+	return enrollment, nil
+}
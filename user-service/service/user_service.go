@@ -1,11 +1,21 @@
 package service
 
 import (
+	"bytes"
 	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
 	"log"
+	"mime/multipart"
+	"path/filepath"
+	"runtime"
 	"strings"
+	"sync"
 	"time"
 
+	"github.com/go-playground/validator/v10"
 	"github.com/gofiber/fiber/v2"
 	"github.com/google/uuid"
 	"github.com/iqbaludinm/hr-microservice/user-service/config"
@@ -18,6 +28,7 @@ import (
 	"github.com/iqbaludinm/hr-microservice/user-service/model/web"
 	"github.com/iqbaludinm/hr-microservice/user-service/repository"
 	"github.com/iqbaludinm/hr-microservice/user-service/service/producers"
+	"github.com/xuri/excelize/v2"
 	"go.uber.org/zap"
 )
 
@@ -27,11 +38,13 @@ type UserService interface {
 	// UpdateUser(ctx context.Context, id string, request web.UpdateUserRequest) (web.UserResponse, error)
 	// UpdatePassword(ctx context.Context, request web.UpdateUserPasswordRequest) (web.UserResponse, error)
 	// Delete(ctx context.Context, id string) (web.UserResponse, error)
+	ImportUsers(ctx context.Context, request web.ImportUserRequest, file *multipart.FileHeader) (web.ImportUserResponse, error)
 
 	// Without Transaction
 	FindAllUser(ctx context.Context, filter web.UserQueryFilter) (result []web.UserResponse, totalData int, err error)
 	ForgetPasswordEmail(ctx *fiber.Ctx, email string) (domain.ResetPasswordToken, error)
 	ResetPassword(ctx *fiber.Ctx, email, token string, request web.ResetPassword) error
+	ExportUsers(ctx context.Context, filter web.UserQueryFilter, format string) ([]byte, string, error)
 	// FindById(ctx context.Context, id string, filter web.UserQueryFilter) (web.UserResponse, error)
 	// FindByEmail(ctx context.Context, email string) (web.UserResponse, error)
 	// FindByPhoneNumber(ctx context.Context, phone string) (web.UserResponse, error)
@@ -39,14 +52,17 @@ type UserService interface {
 
 type userService struct {
 	userRepository repository.UserRepository
+	validate *validator.Validate
 	kafkaProducerService producers.KafkaProducerService
 	logger *zap.SugaredLogger
 }
 
-func NewUserService(userRepository repository.UserRepository, kafkaProducerService producers.KafkaProducerService, logger *zap.SugaredLogger) UserService {
+func NewUserService(userRepository repository.UserRepository, validate *validator.Validate, kafkaProducerService producers.KafkaProducerService, logger *zap.SugaredLogger) UserService {
 	return &userService{
 		userRepository: userRepository,
+		validate: validate,
 		kafkaProducerService: kafkaProducerService,
+		logger: logger,
 	}
 }
 
@@ -268,4 +284,334 @@ func (u *userService) FindAllUser(c context.Context, filter web.UserQueryFilter)
 // }
 // func (u *userService) FindByPhoneNumber(c context.Context, request web.CreateUserRequest) (web.UserResponse, error) {
 // 	return 
-// }
\ No newline at end of file
+// }
+
+// the columns of the imported users file, the header row names them in any order
+var userImportColumns = []string{"name", "email", "phone", "password"}
+
+// the columns of the exported users file
+var userExportColumns = []string{"id", "name", "email", "phone", "avatar_url", "created_at", "deleted_at"}
+
+// a row of the imported users file with its errors
+type userImportRow struct {
+	row     int
+	request web.CreateUserRequest
+	errors  []string
+}
+
+// import the users of the CSV or XLSX file. Every row is validated with the rules of the CreateUserRequest, and its
+// email and phone must not be used by another row or another user. The users are created in a transaction when every
+// row is valid and it is not a dry run, the 'POST.USER' message of each user is produced after the transaction.
+func (service *userService) ImportUsers(c context.Context, request web.ImportUserRequest, file *multipart.FileHeader) (web.ImportUserResponse, error) {
+	if file == nil {
+		return web.ImportUserResponse{}, exception.ErrBadRequest("Upload the .csv or .xlsx file as the 'file' of the form.")
+	}
+
+	src, err := file.Open()
+	if err != nil {
+		return web.ImportUserResponse{}, err
+	}
+	defer src.Close()
+	data, err := io.ReadAll(src)
+	if err != nil {
+		return web.ImportUserResponse{}, err
+	}
+
+	rows, err := readUserImportRows(file.Filename, data)
+	if err != nil {
+		return web.ImportUserResponse{}, err
+	}
+	if len(rows) < 2 {
+		return web.ImportUserResponse{}, exception.ErrBadRequest("The file has no users.")
+	}
+
+	// find the column of each field from the header row
+	columns := map[string]int{}
+	for i, column := range rows[0] {
+		columns[strings.ToLower(strings.TrimSpace(column))] = i
+	}
+	for _, column := range userImportColumns {
+		if _, ok := columns[column]; !ok {
+			return web.ImportUserResponse{}, exception.ErrBadRequest(fmt.Sprintf("The header row must have the %s columns, the '%s' column is missing.", strings.Join(userImportColumns, ", "), column))
+		}
+	}
+	cell := func(row []string, column string) string {
+		if i := columns[column]; i < len(row) {
+			return strings.TrimSpace(row[i])
+		}
+		return ""
+	}
+
+	// validate the rows, the email and the phone of a row must not be used by the rows before it. The rows which use
+	// them first are indexed by the email and the phone.
+	var importRows []userImportRow
+	var emails, phones []string
+	emailRows := map[string]int{}
+	phoneRows := map[string]int{}
+	for i, row := range rows[1:] {
+		if strings.TrimSpace(strings.Join(row, "")) == "" {
+			continue
+		}
+
+		importRow := userImportRow{
+			row: i + 2,
+			request: web.CreateUserRequest{
+				Name:     cell(row, "name"),
+				Email:    cell(row, "email"),
+				Phone:    cell(row, "phone"),
+				Password: cell(row, "password"),
+			},
+		}
+		if err := service.validate.Struct(importRow.request); err != nil {
+			var validationErrors validator.ValidationErrors
+			if !errors.As(err, &validationErrors) {
+				return web.ImportUserResponse{}, err
+			}
+			for _, fieldError := range validationErrors {
+				importRow.errors = append(importRow.errors, exception.ErrValidateBadRequest(fieldError.Error(), importRow.request).Message)
+			}
+		}
+
+		if email := importRow.request.Email; email != "" {
+			if first, ok := emailRows[email]; ok {
+				importRow.errors = append(importRow.errors, fmt.Sprintf("Email is already used in row %d.", importRows[first].row))
+			} else {
+				emailRows[email] = len(importRows)
+				emails = append(emails, email)
+			}
+		}
+		if phone := importRow.request.Phone; phone != "" {
+			if first, ok := phoneRows[phone]; ok {
+				importRow.errors = append(importRow.errors, fmt.Sprintf("Phone is already used in row %d.", importRows[first].row))
+			} else {
+				phoneRows[phone] = len(importRows)
+				phones = append(phones, phone)
+			}
+		}
+		importRows = append(importRows, importRow)
+	}
+	if len(importRows) == 0 {
+		return web.ImportUserResponse{}, exception.ErrBadRequest("The file has no users.")
+	}
+
+	// the email and the phone must not be used by another user
+	existingUsers, err := service.userRepository.FindByEmailsOrPhones(c, emails, phones)
+	if err != nil {
+		return web.ImportUserResponse{}, err
+	}
+	for _, user := range existingUsers {
+		if i, ok := emailRows[user.Email]; ok {
+			importRows[i].errors = append(importRows[i].errors, "Email already exist.")
+		}
+		if i, ok := phoneRows[user.Phone]; ok {
+			importRows[i].errors = append(importRows[i].errors, "Phone already exist.")
+		}
+	}
+
+	response := web.ImportUserResponse{
+		DryRun: request.DryRun,
+		Total:  len(importRows),
+		Errors: []web.ImportUserRowError{},
+		Users:  []web.UserResponse{},
+	}
+	for _, importRow := range importRows {
+		if len(importRow.errors) > 0 {
+			response.Errors = append(response.Errors, web.ImportUserRowError{
+				Row:    importRow.row,
+				Email:  importRow.request.Email,
+				Errors: importRow.errors,
+			})
+		}
+	}
+	if len(response.Errors) > 0 {
+		return response, nil
+	}
+
+	now := time.Now()
+	users := []domain.User{}
+	for _, importRow := range importRows {
+		user := domain.User{
+			Name:      importRow.request.Name,
+			Email:     importRow.request.Email,
+			Phone:     importRow.request.Phone,
+			CreatedAt: now,
+			UpdatedAt: now,
+		}
+		if request.DryRun {
+			response.Users = append(response.Users, user.ToUserResponse())
+			continue
+		}
+		user.ID = uuid.New().String()
+		users = append(users, user)
+	}
+	if request.DryRun {
+		return response, nil
+	}
+	setUserPasswords(users, importRows)
+
+	// call the repo for inserting to db
+	if err := service.userRepository.ImportUsers(c, users); err != nil {
+		service.logger.Infow(err.Error(), "Import Users Error")
+		return web.ImportUserResponse{}, toUserUniqueError(err)
+	}
+
+	// produce kafka create-user message of each user
+	for _, user := range users {
+		kafkaUserMessage := kafkamodel.NewKafkaUserMessage(user)
+		go service.kafkaProducerService.Produce(kafkaUserMessage, "POST.USER", config.KafkaTopic)
+		response.Users = append(response.Users, user.ToUserResponse())
+	}
+	response.Imported = len(users)
+
+	return response, nil
+}
+
+// export the users of the filter to a CSV or XLSX file, it returns the file and the name of the file
+func (service *userService) ExportUsers(c context.Context, filter web.UserQueryFilter, format string) ([]byte, string, error) {
+	users, err := service.userRepository.FindAllUser(c, domain.ToDomainUserQueryFilter(filter))
+	if err != nil {
+		return nil, "", err
+	}
+
+	rows := [][]string{userExportColumns}
+	for _, user := range users {
+		var avatarURL, deletedAt string
+		if user.AvatarURL != nil {
+			avatarURL = *user.AvatarURL
+		}
+		if user.DeletedAt != nil {
+			deletedAt = user.DeletedAt.In(helper.WIB).Format(time.RFC3339)
+		}
+		rows = append(rows, []string{user.ID, user.Name, user.Email, user.Phone, avatarURL, user.CreatedAt.In(helper.WIB).Format(time.RFC3339), deletedAt})
+	}
+
+	var data []byte
+	switch format {
+	case "xlsx":
+		data, err = writeUserXLSX(rows)
+	default:
+		format = "csv"
+		data, err = writeUserCSV(rows)
+	}
+	if err != nil {
+		return nil, "", err
+	}
+
+	return data, fmt.Sprintf("users-%s.%s", helper.Today().Format("20060102"), format), nil
+}
+
+// read the rows of the CSV file or of the first sheet of the XLSX file
+func readUserImportRows(fileName string, data []byte) ([][]string, error) {
+	switch strings.ToLower(filepath.Ext(fileName)) {
+	case ".csv":
+		reader := csv.NewReader(bytes.NewReader(bytes.TrimPrefix(data, []byte("\xef\xbb\xbf"))))
+		reader.FieldsPerRecord = -1
+		reader.TrimLeadingSpace = true
+		rows, err := reader.ReadAll()
+		if err != nil {
+			return nil, exception.ErrBadRequest(fmt.Sprintf("Invalid CSV file, %s.", err.Error()))
+		}
+		return rows, nil
+	case ".xlsx":
+		workbook, err := excelize.OpenReader(bytes.NewReader(data))
+		if err != nil {
+			return nil, exception.ErrBadRequest(fmt.Sprintf("Invalid XLSX file, %s.", err.Error()))
+		}
+		defer workbook.Close()
+		rows, err := workbook.GetRows(workbook.GetSheetName(0))
+		if err != nil {
+			return nil, exception.ErrBadRequest(fmt.Sprintf("Invalid XLSX file, %s.", err.Error()))
+		}
+		return rows, nil
+	default:
+		return nil, exception.ErrBadRequest("Only .csv and .xlsx files can be imported.")
+	}
+}
+
+func writeUserCSV(rows [][]string) ([]byte, error) {
+	var buffer bytes.Buffer
+	writer := csv.NewWriter(&buffer)
+	for _, row := range rows {
+		escaped := make([]string, len(row))
+		for i, value := range row {
+			escaped[i] = escapeCSVFormula(value)
+		}
+		if err := writer.Write(escaped); err != nil {
+			return nil, err
+		}
+	}
+	writer.Flush()
+	if err := writer.Error(); err != nil {
+		return nil, err
+	}
+
+	return buffer.Bytes(), nil
+}
+
+// prefix the cell that starts with a formula character with a quote, so the spreadsheet apps show it as text
+// instead of running it (CSV injection)
+func escapeCSVFormula(value string) string {
+	if value != "" && strings.ContainsRune("=+-@\t\r", rune(value[0])) {
+		return "'" + value
+	}
+	return value
+}
+
+// write the rows to the first sheet of the XLSX file, the cells are written as text so the phones keep their
+// leading zero
+func writeUserXLSX(rows [][]string) ([]byte, error) {
+	workbook := excelize.NewFile()
+	defer workbook.Close()
+
+	sheet := workbook.GetSheetName(0)
+	for i, row := range rows {
+		values := make([]interface{}, len(row))
+		for j, value := range row {
+			values[j] = value
+		}
+		cell, err := excelize.CoordinatesToCellName(1, i+1)
+		if err != nil {
+			return nil, err
+		}
+		if err := workbook.SetSheetRow(sheet, cell, &values); err != nil {
+			return nil, err
+		}
+	}
+
+	buffer, err := workbook.WriteToBuffer()
+	if err != nil {
+		return nil, err
+	}
+
+	return buffer.Bytes(), nil
+}
+
+// hash the passwords of the rows to the users concurrently, hashing is the slowest part of the import because of the
+// bcrypt cost
+func setUserPasswords(users []domain.User, importRows []userImportRow) {
+	var wg sync.WaitGroup
+	limit := make(chan struct{}, runtime.NumCPU())
+	for i := range users {
+		wg.Add(1)
+		limit <- struct{}{}
+		go func(i int) {
+			defer wg.Done()
+			users[i].SetPassword(importRows[i].request.Password)
+			<-limit
+		}(i)
+	}
+	wg.Wait()
+}
+
+// convert the unique constraint error of the 'users' table to bad request error
+func toUserUniqueError(err error) error {
+	if strings.Contains(err.Error(), "unique") {
+		switch {
+		case strings.Contains(err.Error(), "users_email_key"):
+			return exception.ErrBadRequest("Email already exist.")
+		case strings.Contains(err.Error(), "users_phone_key"):
+			return exception.ErrBadRequest("Phone already exist.")
+		}
+	}
+	return err
+}
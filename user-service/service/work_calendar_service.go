@@ -0,0 +1,263 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/iqbaludinm/hr-microservice/user-service/exception"
+	"github.com/iqbaludinm/hr-microservice/user-service/helper"
+	"github.com/iqbaludinm/hr-microservice/user-service/model/domain"
+	"github.com/iqbaludinm/hr-microservice/user-service/model/web"
+	"github.com/iqbaludinm/hr-microservice/user-service/repository"
+	"go.uber.org/zap"
+)
+
+type WorkCalendarService interface {
+	// With Transaction
+	CreateWorkCalendar(ctx context.Context, request web.CreateWorkCalendarRequest) (web.WorkCalendarResponse, error)
+	UpdateWorkCalendar(ctx context.Context, id string, request web.UpdateWorkCalendarRequest) (web.WorkCalendarResponse, error)
+	Delete(ctx context.Context, id string) error
+
+	// Without Transaction
+	FindAllWorkCalendar(ctx context.Context) ([]web.WorkCalendarResponse, error)
+	FindById(ctx context.Context, id string) (web.WorkCalendarResponse, error)
+	CountWorkingDays(ctx context.Context, filter web.WorkingDaysQueryFilter) (web.WorkingDaysResponse, error)
+}
+
+type workCalendarService struct {
+	workCalendarRepository   repository.WorkCalendarRepository
+	holidayRepository        repository.HolidayRepository
+	officeLocationRepository repository.OfficeLocationRepository
+	employeeRepository       repository.EmployeeRepository
+	logger                   *zap.SugaredLogger
+}
+
+func NewWorkCalendarService(workCalendarRepository repository.WorkCalendarRepository, holidayRepository repository.HolidayRepository, officeLocationRepository repository.OfficeLocationRepository, employeeRepository repository.EmployeeRepository, logger *zap.SugaredLogger) WorkCalendarService {
+	return &workCalendarService{
+		workCalendarRepository:   workCalendarRepository,
+		holidayRepository:        holidayRepository,
+		officeLocationRepository: officeLocationRepository,
+		employeeRepository:       employeeRepository,
+		logger:                   logger,
+	}
+}
+
+func (s *workCalendarService) CreateWorkCalendar(c context.Context, request web.CreateWorkCalendarRequest) (web.WorkCalendarResponse, error) {
+	weekendDays := request.WeekendDays
+	if weekendDays == nil {
+		weekendDays = []int{int(time.Sunday), int(time.Saturday)}
+	}
+
+	// convert to domain or model work calendar
+	calendar := domain.WorkCalendar{
+		ID:          uuid.New().String(),
+		Code:        strings.ToUpper(request.Code),
+		Name:        request.Name,
+		WeekendDays: toWeekendDays(weekendDays),
+		IsDefault:   request.IsDefault,
+		CreatedAt:   time.Now(),
+		UpdatedAt:   time.Now(),
+	}
+
+	// call the repo for inserting to db
+	if err := s.workCalendarRepository.CreateWorkCalendar(c, calendar); err != nil {
+		s.logger.Infow(err.Error(), "Create Work Calendar Error")
+		return web.WorkCalendarResponse{}, toWorkCalendarUniqueError(err)
+	}
+
+	return calendar.ToWorkCalendarResponse(), nil
+}
+
+func (s *workCalendarService) UpdateWorkCalendar(c context.Context, id string, request web.UpdateWorkCalendarRequest) (web.WorkCalendarResponse, error) {
+	calendar, err := findWorkCalendar(c, s.workCalendarRepository, id)
+	if err != nil {
+		return web.WorkCalendarResponse{}, err
+	}
+
+	// only the filled fields are updated
+	if request.Name != "" {
+		calendar.Name = request.Name
+	}
+	if request.WeekendDays != nil {
+		calendar.WeekendDays = toWeekendDays(request.WeekendDays)
+	}
+	if request.IsDefault != nil {
+		// there must always be a default calendar, it is replaced by setting another calendar as the default
+		if calendar.IsDefault && !*request.IsDefault {
+			return web.WorkCalendarResponse{}, exception.ErrBadRequest("Set another calendar as the default instead of unsetting the default calendar.")
+		}
+		calendar.IsDefault = *request.IsDefault
+	}
+	calendar.UpdatedAt = time.Now()
+
+	if err := s.workCalendarRepository.UpdateWorkCalendar(c, id, calendar); err != nil {
+		s.logger.Infow(err.Error(), "Update Work Calendar Error")
+		return web.WorkCalendarResponse{}, err
+	}
+
+	return calendar.ToWorkCalendarResponse(), nil
+}
+
+func (s *workCalendarService) Delete(c context.Context, id string) error {
+	calendar, err := findWorkCalendar(c, s.workCalendarRepository, id)
+	if err != nil {
+		return err
+	}
+	if calendar.IsDefault {
+		return exception.ErrBadRequest("Default calendar can't be deleted.")
+	}
+
+	locations, err := s.workCalendarRepository.CountLocations(c, id)
+	if err != nil {
+		return err
+	}
+	if locations > 0 {
+		return exception.ErrBadRequest("Calendar is still used by office locations.")
+	}
+
+	return s.workCalendarRepository.Delete(c, id)
+}
+
+func (s *workCalendarService) FindAllWorkCalendar(c context.Context) ([]web.WorkCalendarResponse, error) {
+	calendars, err := s.workCalendarRepository.FindAllWorkCalendar(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// convert to web.WorkCalendarResponse
+	result := []web.WorkCalendarResponse{}
+	for _, calendar := range calendars {
+		result = append(result, calendar.ToWorkCalendarResponse())
+	}
+
+	return result, nil
+}
+
+func (s *workCalendarService) FindById(c context.Context, id string) (web.WorkCalendarResponse, error) {
+	calendar, err := findWorkCalendar(c, s.workCalendarRepository, id)
+	if err != nil {
+		return web.WorkCalendarResponse{}, err
+	}
+
+	return calendar.ToWorkCalendarResponse(), nil
+}
+
+func (s *workCalendarService) CountWorkingDays(c context.Context, filter web.WorkingDaysQueryFilter) (web.WorkingDaysResponse, error) {
+	from, _ := helper.ParseDate(filter.From)
+	to, _ := helper.ParseDate(filter.To)
+	if to.Before(from) {
+		return web.WorkingDaysResponse{}, exception.ErrBadRequest("'to' must be on or after 'from'.")
+	}
+
+	// the calendar is chosen explicitly, by the office location or by the employee
+	var workCalendarID *string
+	switch {
+	case filter.WorkCalendarID != "":
+		if _, err := findWorkCalendar(c, s.workCalendarRepository, filter.WorkCalendarID); err != nil {
+			return web.WorkingDaysResponse{}, err
+		}
+		workCalendarID = &filter.WorkCalendarID
+	case filter.OfficeLocationID != "":
+		location, err := findOfficeLocation(c, s.officeLocationRepository, filter.OfficeLocationID)
+		if err != nil {
+			return web.WorkingDaysResponse{}, err
+		}
+		workCalendarID = location.WorkCalendarID
+	case filter.EmployeeID != "":
+		employee, err := findEmployee(c, s.employeeRepository, filter.EmployeeID)
+		if err != nil {
+			return web.WorkingDaysResponse{}, err
+		}
+		workCalendarID = employee.WorkCalendarID
+	}
+
+	calendar, err := findCalendar(c, s.workCalendarRepository, s.holidayRepository, workCalendarID, from, to)
+	if err != nil {
+		return web.WorkingDaysResponse{}, err
+	}
+
+	calendarDays := int(to.Sub(from).Hours()/24) + 1
+	workingDays := calendar.CountWorkingDays(from, to)
+	response := web.WorkingDaysResponse{
+		WorkCalendarID:   calendar.WorkCalendar.ID,
+		WorkCalendarName: calendar.WorkCalendar.Name,
+		From:             from.Format(helper.DateLayout),
+		To:               to.Format(helper.DateLayout),
+		CalendarDays:     calendarDays,
+		WorkingDays:      workingDays,
+		DaysOff:          calendarDays - workingDays,
+		Holidays:         []web.HolidayResponse{},
+	}
+	for _, holiday := range calendar.Holidays(from, to) {
+		response.Holidays = append(response.Holidays, holiday.ToHolidayResponse())
+	}
+
+	return response, nil
+}
+
+// find the work calendar by id and convert the 'no rows' error to not found error
+func findWorkCalendar(c context.Context, workCalendarRepository repository.WorkCalendarRepository, id string) (domain.WorkCalendar, error) {
+	calendar, err := workCalendarRepository.FindById(c, id)
+	if err != nil {
+		if strings.Contains(err.Error(), "no rows") {
+			return domain.WorkCalendar{}, exception.ErrNotFound(fmt.Sprintf("Work calendar %s not found", id))
+		}
+		return domain.WorkCalendar{}, err
+	}
+
+	return calendar, nil
+}
+
+// find every work calendar with its days between the dates (inclusive), for resolving the calendar of
+// many employees at once
+func findCalendars(c context.Context, workCalendarRepository repository.WorkCalendarRepository, holidayRepository repository.HolidayRepository, from, to time.Time) (domain.Calendars, error) {
+	workCalendars, err := workCalendarRepository.FindAllWorkCalendar(c)
+	if err != nil {
+		return domain.Calendars{}, err
+	}
+
+	holidays, err := holidayRepository.FindAllHoliday(c, domain.HolidayQueryFilter{From: from, To: to})
+	if err != nil {
+		return domain.Calendars{}, err
+	}
+
+	return domain.NewCalendars(workCalendars, holidays), nil
+}
+
+// find the work calendar with its days between the dates (inclusive), the default calendar is used when
+// the id is empty
+func findCalendar(c context.Context, workCalendarRepository repository.WorkCalendarRepository, holidayRepository repository.HolidayRepository, workCalendarID *string, from, to time.Time) (domain.Calendar, error) {
+	calendars, err := findCalendars(c, workCalendarRepository, holidayRepository, from, to)
+	if err != nil {
+		return domain.Calendar{}, err
+	}
+
+	return calendars.For(workCalendarID), nil
+}
+
+// remove the duplicated weekend days and sort them
+func toWeekendDays(days []int) []int {
+	seen := map[int]bool{}
+	result := []int{}
+	for _, day := range days {
+		if !seen[day] {
+			seen[day] = true
+			result = append(result, day)
+		}
+	}
+	sort.Ints(result)
+
+	return result
+}
+
+// convert the unique constraint error of the 'work_calendars' table to bad request error
+func toWorkCalendarUniqueError(err error) error {
+	if strings.Contains(err.Error(), "unique") && strings.Contains(err.Error(), "work_calendars_code_key") {
+		return exception.ErrBadRequest("Work calendar code already exist.")
+	}
+	return err
+}